@@ -0,0 +1,35 @@
+#!/bin/bash
+# Capture `jj log -T GraphTemplate` output from each supported jj release into
+# internal/integrations/jj/parse/testdata/graph, for parse's TestGraphRowsCaptured.
+# Each release binary is downloaded to a cache and integration_tests' TestGraphTemplateCapture
+# runs with it first on PATH. Override the releases with JJ_VERSIONS="0.24.0 0.25.0 ...".
+
+set -e
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+ROOT="$(dirname "$SCRIPT_DIR")"
+CACHE="${JJ_CACHE:-${XDG_CACHE_HOME:-$HOME/.cache}/jj-tui/jj-releases}"
+JJ_VERSIONS="${JJ_VERSIONS:-0.24.0 0.25.0 0.26.0 0.27.0 0.28.0 0.29.0 0.30.0 0.31.0 0.32.0}"
+
+case "$(uname -s)-$(uname -m)" in
+	Linux-x86_64) TARGET=x86_64-unknown-linux-musl ;;
+	Linux-aarch64) TARGET=aarch64-unknown-linux-musl ;;
+	Darwin-x86_64) TARGET=x86_64-apple-darwin ;;
+	Darwin-arm64) TARGET=aarch64-apple-darwin ;;
+	*) echo "No jj release build for $(uname -s)-$(uname -m)" >&2; exit 1 ;;
+esac
+
+for version in $JJ_VERSIONS; do
+	bin="$CACHE/$version"
+	if [ ! -x "$bin/jj" ]; then
+		echo "Downloading jj $version..."
+		mkdir -p "$bin"
+		curl -LsSf "https://github.com/martinvonz/jj/releases/download/v${version}/jj-v${version}-${TARGET}.tar.gz" \
+			| tar xz -C "$bin" ./jj
+	fi
+	echo "Capturing with $("$bin/jj" --version)..."
+	(cd "$ROOT" && PATH="$bin:$PATH" JJ_TUI_CAPTURE_GRAPH=1 \
+		go test ./integration_tests -run '^TestGraphTemplateCapture$' -count=1 -v)
+done
+
+echo "Fixtures in internal/integrations/jj/parse/testdata/graph; check them with: go test ./internal/integrations/jj/parse"
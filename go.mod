@@ -6,6 +6,7 @@ require (
 	github.com/charmbracelet/bubbles v1.0.0
 	github.com/charmbracelet/bubbletea v1.3.10
 	github.com/charmbracelet/lipgloss v1.1.0
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/google/go-github/v66 v66.0.0
 	github.com/lrstanley/bubblezone v1.0.0
 	github.com/madicen/bubble-color-picker v0.1.0
@@ -0,0 +1,96 @@
+package integration_tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/jj/parse"
+)
+
+// graphFixtureDir is where TestGraphTemplateCapture writes captures when JJ_TUI_CAPTURE_GRAPH is
+// set; parse's TestGraphRowsCaptured reads them back.
+const graphFixtureDir = "../internal/integrations/jj/parse/testdata/graph"
+
+// TestGraphTemplateCapture runs `jj log -T GraphTemplate` on a small fixed repository (a merge of
+// two conflicting commits on an immutable trunk, a bookmark, and an undescribed working copy) with
+// the installed jj, in the curved and ASCII graph styles, and checks GraphRows reads it. With
+// JJ_TUI_CAPTURE_GRAPH=1 the output is also saved as testdata for the parse package, named after
+// `jj --version` (see `make graph-fixtures`).
+func TestGraphTemplateCapture(t *testing.T) {
+	if _, err := exec.LookPath("jj"); err != nil {
+		t.Skip("jj not in PATH")
+	}
+	dir := t.TempDir()
+	// Fixed user, clock and seed so a version's capture only changes when jj's output does.
+	t.Setenv("JJ_USER", "jj-tui fixtures")
+	t.Setenv("JJ_EMAIL", "fixtures@example.com")
+	t.Setenv("JJ_TIMESTAMP", "2025-01-02T03:04:05+00:00")
+	t.Setenv("JJ_OP_TIMESTAMP", "2025-01-02T03:04:05+00:00")
+	t.Setenv("JJ_RANDOMNESS_SEED", "1")
+	t.Setenv("JJ_CONFIG", filepath.Join(dir, "no-user-config.toml"))
+
+	repo := filepath.Join(dir, "repo")
+	runJJ := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("jj", append([]string{"--color", "never"}, args...)...)
+		cmd.Dir = repo
+		out, err := cmd.Output()
+		if err != nil {
+			stderr := ""
+			if ee, ok := err.(*exec.ExitError); ok {
+				stderr = string(ee.Stderr)
+			}
+			t.Fatalf("jj %v: %v\n%s", args, err, stderr)
+		}
+		return string(out)
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.Mkdir(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	runJJ("git", "init")
+	runJJ("config", "set", "--repo", `revset-aliases."immutable_heads()"`, "main")
+	write("README.md", "trunk\n")
+	runJJ("describe", "-m", "Initial commit")
+	runJJ("bookmark", "create", "main", "-r", "@")
+	runJJ("new", "main", "-m", "Left side")
+	write("side.txt", "left\n")
+	runJJ("bookmark", "create", "feat", "-r", "@")
+	runJJ("new", "main", "-m", "Right side")
+	write("side.txt", "right\n")
+	runJJ("new", "feat", "@", "-m", `Merge "left" and right`)
+	runJJ("new")
+
+	version := strings.TrimPrefix(strings.Fields(runJJ("--version"))[1], "v")
+	for _, style := range []string{"curved", "ascii"} {
+		runJJ("config", "set", "--repo", "ui.graph.style", style)
+		out := runJJ("log", "-r", "all()", "-T", parse.GraphTemplate)
+		rows, err := parse.GraphRows(out)
+		if err != nil {
+			t.Fatalf("jj %s, %s graph: %v\n%s", version, style, err, out)
+		}
+		if len(rows) != 6 || !rows[0].Commit.WorkingCopy || len(rows[1].Commit.Parents) != 2 || !rows[1].Commit.Conflict {
+			t.Errorf("jj %s, %s graph: want @ on a conflicted merge of 2 parents, 6 rows; got:\n%s", version, style, out)
+		}
+		if os.Getenv("JJ_TUI_CAPTURE_GRAPH") == "" {
+			continue
+		}
+		if err := os.MkdirAll(graphFixtureDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		name := filepath.Join(graphFixtureDir, "jj-"+version+"-"+style+".txt")
+		if err := os.WriteFile(name, []byte(out), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("wrote %s", name)
+	}
+}
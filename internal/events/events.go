@@ -0,0 +1,155 @@
+// Package events writes a machine-readable JSON Lines stream of what a jj-tui session does
+// (jj operations, pushes, PRs created) so status bars, loggers, and window managers can follow
+// along. The stream is off unless main calls SetOutput (--events-fd / --events-file).
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types written to the stream.
+const (
+	TypeSessionStart = "session_start"
+	TypeSessionEnd   = "session_end"
+	TypeOpExecuted   = "op_executed"   // a mutating jj command finished (ok or not)
+	TypePushFinished = "push_finished" // jj git push finished (ok or not)
+	TypePRCreated    = "pr_created"
+)
+
+// Event is one line of the stream. Only the fields relevant to Type are set.
+type Event struct {
+	Type       string    `json:"type"`
+	Time       time.Time `json:"time"`
+	Repo       string    `json:"repo,omitempty"`
+	Command    string    `json:"command,omitempty"`
+	DurationMS int64     `json:"duration_ms,omitempty"`
+	OK         *bool     `json:"ok,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	PRNumber   int       `json:"pr_number,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	Branch     string    `json:"branch,omitempty"`
+	Base       string    `json:"base,omitempty"`
+}
+
+var (
+	mu  sync.Mutex
+	out io.Writer
+	enc *json.Encoder
+	now = time.Now
+)
+
+// SetOutput directs the event stream to w; nil turns it off.
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	out = w
+	if w == nil {
+		enc = nil
+		return
+	}
+	enc = json.NewEncoder(w)
+}
+
+// Enabled reports whether an event stream is configured.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return out != nil
+}
+
+// Emit writes e as one JSON line, stamping Time when unset. Write errors are dropped: a reader
+// that went away must never break the TUI.
+func Emit(e Event) {
+	mu.Lock()
+	defer mu.Unlock()
+	if enc == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = now().UTC()
+	}
+	_ = enc.Encode(e)
+}
+
+// Bool returns a pointer to b for Event.OK.
+func Bool(b bool) *bool { return &b }
+
+// readOnlyCommands are jj subcommands that never change the repo; they are not reported.
+var readOnlyCommands = map[string]bool{
+	"log": true, "diff": true, "show": true, "status": true, "st": true, "evolog": true,
+	"obslog": true, "root": true, "config": true, "help": true, "interdiff": true, "version": true,
+}
+
+// readOnlySubcommands are "<group> <sub>" pairs that only read state.
+var readOnlySubcommands = map[string]bool{
+	"bookmark list": true, "op log": true, "op show": true, "op diff": true, "workspace list": true,
+	"workspace root": true, "git remote": true, "tag list": true, "branch list": true, "sparse list": true,
+	"file list": true, "file show": true, "file annotate": true,
+}
+
+// globalValueFlags are jj global options whose value follows as a separate argument.
+var globalValueFlags = map[string]bool{
+	"--at-op": true, "--at-operation": true, "-R": true, "--repository": true, "--color": true,
+	"--config": true, "--config-toml": true, "--config-file": true,
+}
+
+// ReadOnly reports whether the jj arguments (without the leading "jj") only read state.
+// Commands run at an older operation (--at-op) and `resolve --list` count as reads.
+func ReadOnly(args []string) bool {
+	words := commandWords(args)
+	for _, a := range args {
+		if a == "--at-op" || a == "--at-operation" || strings.HasPrefix(a, "--at-op=") || strings.HasPrefix(a, "--at-operation=") {
+			return true
+		}
+		if (a == "--list" || a == "-l") && len(words) > 0 && words[0] == "resolve" {
+			return true
+		}
+	}
+	if len(words) == 0 || readOnlyCommands[words[0]] {
+		return true
+	}
+	if len(words) > 1 && readOnlySubcommands[words[0]+" "+words[1]] {
+		// "git remote list" is read-only but "git remote add" is not.
+		return !(words[0] == "git" && words[1] == "remote" && len(words) > 2 && words[2] != "list")
+	}
+	return false
+}
+
+// commandWords returns args without options and the values of globalValueFlags.
+func commandWords(args []string) []string {
+	var words []string
+	for i := 0; i < len(args); i++ {
+		switch a := args[i]; {
+		case globalValueFlags[a]:
+			i++
+		case !strings.HasPrefix(a, "-"):
+			words = append(words, a)
+		}
+	}
+	return words
+}
+
+// CommandEvent classifies a finished jj command line ("jj git push --bookmark x") and returns
+// the event to emit for it, or ok=false when the command only reads state.
+func CommandEvent(command string, duration time.Duration, err string) (Event, bool) {
+	fields := strings.Fields(command)
+	args := fields[min(1, len(fields)):]
+	if ReadOnly(args) {
+		return Event{}, false
+	}
+	e := Event{
+		Type:       TypeOpExecuted,
+		Command:    command,
+		DurationMS: duration.Milliseconds(),
+		OK:         Bool(err == ""),
+		Error:      err,
+	}
+	if words := commandWords(args); len(words) > 1 && words[0] == "git" && words[1] == "push" {
+		e.Type = TypePushFinished
+	}
+	return e, true
+}
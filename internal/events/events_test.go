@@ -0,0 +1,73 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmit_WritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	Emit(Event{Type: TypePRCreated, PRNumber: 7, URL: "https://example.com/pull/7"})
+	Emit(Event{Type: TypeSessionEnd})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	var e Event
+	if err := json.Unmarshal([]byte(lines[0]), &e); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if e.Type != TypePRCreated || e.PRNumber != 7 || e.Time.IsZero() {
+		t.Errorf("event = %+v, want pr_created #7 with time", e)
+	}
+}
+
+func TestEmit_DisabledIsNoop(t *testing.T) {
+	SetOutput(nil)
+	if Enabled() {
+		t.Fatal("Enabled() = true with no output")
+	}
+	Emit(Event{Type: TypeSessionStart}) // must not panic
+}
+
+func TestCommandEvent(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string // "" = not reported
+	}{
+		{"jj log -r @ --no-graph", ""},
+		{"jj bookmark list --all-remotes", ""},
+		{"jj git remote list", ""},
+		{"jj git remote add origin url", TypeOpExecuted},
+		{"jj new @", TypeOpExecuted},
+		{"jj bookmark set feat -r @", TypeOpExecuted},
+		{"jj git push --bookmark exact:feat", TypePushFinished},
+		{"jj --version", ""},
+		{"jj --at-op 1a2b --ignore-working-copy log -r @", ""},
+		{"jj resolve --list", ""},
+		{"jj file show -r @ README.md", ""},
+		{"jj file annotate README.md", ""},
+		{"jj file list", ""},
+		{"jj file track new.txt", TypeOpExecuted},
+		{"jj file untrack secret.env", TypeOpExecuted},
+		{"jj file chmod x run.sh", TypeOpExecuted},
+		{"jj --ignore-working-copy git export", TypeOpExecuted},
+	}
+	for _, tt := range tests {
+		e, ok := CommandEvent(tt.command, time.Second, "")
+		got := ""
+		if ok {
+			got = e.Type
+		}
+		if got != tt.want {
+			t.Errorf("CommandEvent(%q) type = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
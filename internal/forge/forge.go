@@ -0,0 +1,113 @@
+// Package forge provides a common interface for the code hosts pull requests live on (GitHub,
+// GitLab), so the PR tab and Create PR form work against either.
+package forge
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+// Merge methods accepted by MergePullRequest and EnableAutoMerge.
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// Review events accepted by SubmitReview.
+const (
+	ReviewComment        = "COMMENT"
+	ReviewApprove        = "APPROVE"
+	ReviewRequestChanges = "REQUEST_CHANGES"
+)
+
+// PRFilterOptions contains options for filtering PRs
+type PRFilterOptions struct {
+	OnlyMine   bool // Only show PRs created by the authenticated user
+	Limit      int  // Maximum number of PRs to fetch (0 = no limit)
+	ShowMerged bool // Include merged PRs
+	ShowClosed bool // Include closed PRs
+}
+
+// MergeOptions says how to merge a pull request. An empty CommitTitle or CommitMessage keeps
+// the forge's default; both are ignored for rebase merges.
+type MergeOptions struct {
+	Method        string
+	CommitTitle   string
+	CommitMessage string
+}
+
+// Service is what the PR tab needs from a forge. GitLab merge requests are reported as
+// internal.GitHubPR values numbered by their IID, so the rest of the UI needn't tell them apart.
+type Service interface {
+	// Name is the forge's display name ("GitHub", "GitLab").
+	Name() string
+	GetPullRequestsWithOptions(ctx context.Context, filterOpts PRFilterOptions) ([]internal.GitHubPR, error)
+	// GetOpenPRForBranch returns the open PR whose head is branch, or nil when there is none.
+	GetOpenPRForBranch(ctx context.Context, branch string) (*internal.GitHubPR, error)
+	CreatePullRequest(ctx context.Context, req *internal.CreatePRRequest) (*internal.GitHubPR, error)
+	MergePullRequest(ctx context.Context, prNumber int, opts MergeOptions) error
+	ClosePullRequest(ctx context.Context, prNumber int) error
+	// SubmitReview reviews a PR: event is one of ReviewComment, ReviewApprove or ReviewRequestChanges.
+	SubmitReview(ctx context.Context, prNumber int, event, body string) error
+	GetAuthenticatedUsername(ctx context.Context) (string, error)
+}
+
+// AutoMerger is implemented by forges that can merge a PR once its checks pass.
+type AutoMerger interface {
+	EnableAutoMerge(ctx context.Context, prNumber int, opts MergeOptions) error
+}
+
+// Kind identifies which forge a remote is hosted on.
+type Kind string
+
+const (
+	KindNone   Kind = ""
+	KindGitHub Kind = "github"
+	KindGitLab Kind = "gitlab"
+)
+
+// Detect reports which forge hosts remoteURL. github.com remotes are GitHub; gitlab.com, hosts
+// whose name contains "gitlab", and the host of gitlabURL (a self-managed instance, may be
+// empty) are GitLab.
+func Detect(remoteURL, gitlabURL string) Kind {
+	host := strings.ToLower(RemoteHost(remoteURL))
+	if host == "" {
+		return KindNone
+	}
+	if host == "github.com" {
+		return KindGitHub
+	}
+	if strings.Contains(host, "gitlab") {
+		return KindGitLab
+	}
+	if u, err := url.Parse(strings.TrimSpace(gitlabURL)); err == nil && u.Host != "" && strings.EqualFold(u.Hostname(), host) {
+		return KindGitLab
+	}
+	return KindNone
+}
+
+// RemoteHost returns the host name of a git remote URL in any of the usual forms
+// (https://host/…, ssh://git@host:22/…, git@host:path), or "" when it has none.
+func RemoteHost(remoteURL string) string {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return ""
+		}
+		return u.Hostname()
+	}
+	// scp-like syntax: [user@]host:path
+	hostPart, _, ok := strings.Cut(remoteURL, ":")
+	if !ok {
+		return ""
+	}
+	if _, after, found := strings.Cut(hostPart, "@"); found {
+		hostPart = after
+	}
+	return hostPart
+}
@@ -0,0 +1,24 @@
+package forge
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		remote, gitlabURL string
+		want              Kind
+	}{
+		{"git@github.com:o/r.git", "", KindGitHub},
+		{"https://github.com/o/r", "", KindGitHub},
+		{"git@gitlab.com:g/r.git", "", KindGitLab},
+		{"https://gitlab.example.org/g/r.git", "", KindGitLab},
+		{"ssh://git@code.corp.io:2222/g/r.git", "https://code.corp.io", KindGitLab},
+		{"git@code.corp.io:g/r.git", "", KindNone},
+		{"https://bitbucket.org/o/r.git", "", KindNone},
+		{"", "", KindNone},
+	}
+	for _, tt := range tests {
+		if got := Detect(tt.remote, tt.gitlabURL); got != tt.want {
+			t.Errorf("Detect(%q, %q) = %q, want %q", tt.remote, tt.gitlabURL, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package i18n holds the message catalog for user-facing strings (status messages, action labels,
+// modal text). Each string has a stable ID; locales/en.json is the source catalog and the other
+// built-in locales translate every ID the code uses. Missing translations fall back to English,
+// so a partial user catalog is always usable.
+//
+// Teams can add or override a locale without rebuilding by dropping <lang>.json into
+// ~/.config/jj-tui/locales/; entries there win over the built-in catalog.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var builtin embed.FS
+
+var (
+	mu      sync.RWMutex
+	english = mustLoadBuiltin("en")
+	active  map[string]string // nil = English
+	current = "en"
+)
+
+// T returns the message for id in the active locale, falling back to English and then to id
+// itself. With args, the message is a fmt format string.
+func T(id string, args ...any) string {
+	mu.RLock()
+	msg, ok := active[id]
+	if !ok {
+		msg, ok = english[id]
+	}
+	mu.RUnlock()
+	if !ok {
+		msg = id
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// SetLocale switches the catalog. "" and "en" select English; "auto" reads LC_ALL, LC_MESSAGES,
+// and LANG; other values are language tags such as "de" or "de_DE.UTF-8" (only the language is
+// used). It returns an error, and keeps English, when no catalog exists for the language.
+func SetLocale(locale string) error {
+	lang := Normalize(locale)
+	if lang == "auto" {
+		lang = detect()
+	}
+	if lang == "" || lang == "en" {
+		mu.Lock()
+		active, current = userCatalog("en"), "en"
+		mu.Unlock()
+		return nil
+	}
+	catalog, err := loadBuiltin(lang)
+	user := userCatalog(lang)
+	if err != nil && user == nil {
+		mu.Lock()
+		active, current = nil, "en"
+		mu.Unlock()
+		return fmt.Errorf("no message catalog for locale %q (available: %s)", locale, strings.Join(Available(), ", "))
+	}
+	if catalog == nil {
+		catalog = map[string]string{}
+	}
+	for id, msg := range user {
+		catalog[id] = msg
+	}
+	mu.Lock()
+	active, current = catalog, lang
+	mu.Unlock()
+	return nil
+}
+
+// Locale returns the active language ("en" when none was set or loading failed).
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Available lists the built-in languages.
+func Available() []string {
+	entries, _ := builtin.ReadDir("locales")
+	var out []string
+	for _, e := range entries {
+		out = append(out, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Normalize reduces a locale value to its lowercase language ("de_DE.UTF-8" -> "de"). "auto"
+// is kept; "C" and "POSIX" mean English.
+func Normalize(locale string) string {
+	l := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(l, "_-.@"); i >= 0 {
+		l = l[:i]
+	}
+	if l == "c" || l == "posix" {
+		return "en"
+	}
+	return l
+}
+
+// detect returns the language from the POSIX locale variables, in their precedence order.
+func detect() string {
+	for _, k := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(k); v != "" {
+			return Normalize(v)
+		}
+	}
+	return "en"
+}
+
+func loadBuiltin(lang string) (map[string]string, error) {
+	data, err := builtin.ReadFile(path.Join("locales", lang+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var catalog map[string]string
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("locale %s: %w", lang, err)
+	}
+	return catalog, nil
+}
+
+func mustLoadBuiltin(lang string) map[string]string {
+	catalog, err := loadBuiltin(lang)
+	if err != nil {
+		panic(err)
+	}
+	return catalog
+}
+
+// userCatalog reads ~/.config/jj-tui/locales/<lang>.json, or returns nil when it is missing or
+// unreadable (a broken override shouldn't take the UI down).
+func userCatalog(lang string) map[string]string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".config", "jj-tui", "locales", lang+".json"))
+	if err != nil {
+		return nil
+	}
+	var catalog map[string]string
+	if json.Unmarshal(data, &catalog) != nil {
+		return nil
+	}
+	return catalog
+}
@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var verbRE = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// Every built-in locale must only translate IDs that exist in en.json and keep the same format
+// verbs in the same order, or T would print %!v(MISSING) noise.
+func TestCatalogsMatchEnglish(t *testing.T) {
+	for _, lang := range Available() {
+		if lang == "en" {
+			continue
+		}
+		catalog, err := loadBuiltin(lang)
+		if err != nil {
+			t.Fatalf("load %s: %v", lang, err)
+		}
+		for id, msg := range catalog {
+			eng, ok := english[id]
+			if !ok {
+				t.Errorf("%s: unknown id %q", lang, id)
+				continue
+			}
+			if got, want := verbRE.FindAllString(msg, -1), verbRE.FindAllString(eng, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, id, got, want)
+			}
+		}
+	}
+}
+
+// Every T("id") call in the tree must have a message in every built-in catalog, so a new string
+// can't ship untranslated.
+func TestSourceIDsInCatalogs(t *testing.T) {
+	catalogs := map[string]map[string]string{}
+	for _, lang := range Available() {
+		catalog, err := loadBuiltin(lang)
+		if err != nil {
+			t.Fatalf("load %s: %v", lang, err)
+		}
+		catalogs[lang] = catalog
+	}
+	callRE := regexp.MustCompile(`i18n\.T\("([^"]+)"`)
+	root := filepath.Join("..", "..")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range callRE.FindAllStringSubmatch(string(data), -1) {
+			for lang, catalog := range catalogs {
+				if _, ok := catalog[m[1]]; !ok {
+					t.Errorf("%s: i18n.T(%q) has no entry in locales/%s.json", path, m[1], lang)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer SetLocale("en")
+
+	if err := SetLocale("de_DE.UTF-8"); err != nil {
+		t.Fatalf("SetLocale(de_DE.UTF-8): %v", err)
+	}
+	if Locale() != "de" {
+		t.Errorf("Locale() = %q, want de", Locale())
+	}
+	if got := T("status.loaded_commits", 3); got != "3 Commits geladen" {
+		t.Errorf("T(status.loaded_commits) = %q", got)
+	}
+	if got := T("no.such.id"); got != "no.such.id" {
+		t.Errorf("T(unknown) = %q, want the id", got)
+	}
+
+	if err := SetLocale("xx"); err == nil {
+		t.Error("SetLocale(xx) should fail")
+	}
+	if got := T("status.ready"); got != "Ready" {
+		t.Errorf("after failed SetLocale, T(status.ready) = %q, want English", got)
+	}
+
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_AT.UTF-8")
+	if err := SetLocale("auto"); err != nil || Locale() != "de" {
+		t.Errorf("SetLocale(auto) with LANG=de_AT = %q, %v", Locale(), err)
+	}
+}
+
+func TestUserCatalogOverrides(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	defer SetLocale("en")
+	dir := filepath.Join(home, ".config", "jj-tui", "locales")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "nl.json"), []byte(`{"status.ready": "Klaar"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetLocale("nl"); err != nil {
+		t.Fatalf("SetLocale(nl): %v", err)
+	}
+	if got := T("status.ready"); got != "Klaar" {
+		t.Errorf("T(status.ready) = %q, want Klaar", got)
+	}
+	if got := T("status.refreshing"); got != "Refreshing..." {
+		t.Errorf("untranslated id = %q, want English fallback", got)
+	}
+}
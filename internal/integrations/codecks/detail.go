@@ -0,0 +1,86 @@
+package codecks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/madicen/jj-tui/internal/tickets"
+)
+
+// GetTicketDetail fetches a card's content, assignee, tags and conversation entries (comments).
+func (s *Service) GetTicketDetail(ctx context.Context, key string) (*tickets.TicketDetail, error) {
+	query := map[string]any{
+		"query": map[string]any{
+			fmt.Sprintf("card(%s)", key): []any{
+				"title", "content", "masterTags",
+				map[string]any{"assignee": []string{"name"}},
+				map[string]any{"resolvables": []any{
+					map[string]any{"entries": []any{"content", "createdAt", map[string]any{"author": []string{"name"}}}},
+				}},
+			},
+		},
+	}
+
+	respBody, err := s.doRequest(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return cardDetail(result, key)
+}
+
+// cardDetail reads a card's detail out of a normalized query response, where relations are IDs
+// into top-level maps ("user", "resolvable", "resolvableEntry").
+func cardDetail(result map[string]any, key string) (*tickets.TicketDetail, error) {
+	lookup := func(kind, id string) map[string]any {
+		m, _ := result[kind].(map[string]any)
+		entry, _ := m[id].(map[string]any)
+		return entry
+	}
+	card := lookup("card", key)
+	if card == nil {
+		return nil, fmt.Errorf("card %s not found", key)
+	}
+
+	d := &tickets.TicketDetail{
+		Key:                 key,
+		DescriptionMarkdown: contentToMarkdown(getString(card, "content"), getString(card, "title")),
+	}
+	if user := lookup("user", getString(card, "assignee")); user != nil {
+		d.Assignee = getString(user, "name")
+	}
+	tags, _ := card["masterTags"].([]any)
+	for _, t := range tags {
+		if tag, ok := t.(string); ok && tag != "" {
+			d.Labels = append(d.Labels, tag)
+		}
+	}
+
+	resolvables, _ := card["resolvables"].([]any)
+	for _, rid := range resolvables {
+		id, _ := rid.(string)
+		entries, _ := lookup("resolvable", id)["entries"].([]any)
+		for _, eid := range entries {
+			id, _ := eid.(string)
+			entry := lookup("resolvableEntry", id)
+			if entry == nil {
+				continue
+			}
+			comment := tickets.TicketComment{Body: getString(entry, "content")}
+			if author := lookup("user", getString(entry, "author")); author != nil {
+				comment.Author = getString(author, "name")
+			}
+			comment.Created, _ = time.Parse(time.RFC3339, getString(entry, "createdAt"))
+			d.Comments = append(d.Comments, comment)
+		}
+	}
+	sort.SliceStable(d.Comments, func(i, j int) bool { return d.Comments[i].Created.Before(d.Comments[j].Created) })
+	return d, nil
+}
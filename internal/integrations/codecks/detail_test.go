@@ -0,0 +1,35 @@
+package codecks
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCardDetail(t *testing.T) {
+	raw := `{
+		"card": {"c1": {"title": "Fix login", "content": "Fix login\n[] repro", "masterTags": ["auth"], "assignee": "u1", "resolvables": ["r1"]}},
+		"user": {"u1": {"name": "Ada"}, "u2": {"name": "Grace"}},
+		"resolvable": {"r1": {"entries": ["e2", "e1"]}},
+		"resolvableEntry": {
+			"e1": {"content": "first", "createdAt": "2024-05-01T09:30:00.000Z", "author": "u2"},
+			"e2": {"content": "second", "createdAt": "2024-05-02T09:30:00Z", "author": "u1"}
+		}
+	}`
+	var result map[string]any
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		t.Fatal(err)
+	}
+	d, err := cardDetail(result, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.DescriptionMarkdown != "- [ ] repro" || d.Assignee != "Ada" || len(d.Labels) != 1 || d.Labels[0] != "auth" {
+		t.Fatalf("detail = %+v", d)
+	}
+	if len(d.Comments) != 2 || d.Comments[0].Body != "first" || d.Comments[0].Author != "Grace" || d.Comments[1].Author != "Ada" {
+		t.Fatalf("comments = %+v", d.Comments)
+	}
+	if _, err := cardDetail(result, "missing"); err == nil {
+		t.Fatal("expected an error for a missing card")
+	}
+}
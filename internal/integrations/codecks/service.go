@@ -572,6 +572,22 @@ func mapCodecksPriority(priority string) string {
 	}
 }
 
+// codecksPriorityCode is the inverse of mapCodecksPriority; unknown names get the default "c".
+func codecksPriorityCode(priority string) string {
+	switch priority {
+	case "Highest":
+		return "a"
+	case "High":
+		return "b"
+	case "Low":
+		return "d"
+	case "Lowest":
+		return "e"
+	default:
+		return "c"
+	}
+}
+
 // GetTicket fetches a single card by ID (can be short or full ID)
 func (s *Service) GetTicket(ctx context.Context, key string) (*tickets.Ticket, error) {
 	// If it's a short ID, we need to find the full ID first
@@ -615,18 +631,45 @@ func (s *Service) GetTicket(ctx context.Context, key string) (*tickets.Ticket, e
 	accountSeq := getInt(cardData, "accountSeq")
 	displayKey := "$" + encodeShortID(accountSeq)
 
+	title := getString(cardData, "title")
+	content := getString(cardData, "content")
 	return &tickets.Ticket{
-		Key:         key, // Full GUID used for URLs
-		DisplayKey:  displayKey,
-		Summary:     getString(cardData, "title"),
-		Status:      mapCodecksStatus(getString(cardData, "status")),
-		Priority:    mapCodecksPriority(getString(cardData, "priority")),
-		Type:        "Card",
-		Description: getString(cardData, "content"),
-		DeckID:      getString(cardData, "deck"),
+		Key:                 key, // Full GUID used for URLs
+		DisplayKey:          displayKey,
+		Summary:             title,
+		Status:              mapCodecksStatus(getString(cardData, "status")),
+		Priority:            mapCodecksPriority(getString(cardData, "priority")),
+		Type:                "Card",
+		Description:         content,
+		DeckID:              getString(cardData, "deck"),
+		DescriptionMarkdown: contentToMarkdown(content, title),
 	}, nil
 }
 
+// contentToMarkdown converts card content to GitHub markdown: the leading title line Codecks
+// stores in content is dropped (the PR already carries it) and Codecks "[] " / "[x] "
+// checkboxes become GitHub task list items.
+func contentToMarkdown(content, title string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if len(lines) > 0 {
+		first := strings.TrimSpace(strings.TrimLeft(lines[0], "# "))
+		if first == strings.TrimSpace(title) {
+			lines = lines[1:]
+		}
+	}
+	for i, l := range lines {
+		trimmed := strings.TrimLeft(l, " \t")
+		indent := l[:len(l)-len(trimmed)]
+		switch {
+		case strings.HasPrefix(trimmed, "[] "):
+			lines[i] = indent + "- [ ] " + strings.TrimPrefix(trimmed, "[] ")
+		case strings.HasPrefix(trimmed, "[x] "):
+			lines[i] = indent + "- [x] " + strings.TrimPrefix(trimmed, "[x] ")
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
 // GetTicketURL returns the browser URL for a card
 // URL format: https://{subdomain}.codecks.io/decks/{deckSeq}-{deckSlug}/card/{shortId}-{cardSlug}
 func (s *Service) GetTicketURL(ticket tickets.Ticket) string {
@@ -703,6 +746,11 @@ func (s *Service) CanCreateTicket() bool {
 	return true
 }
 
+// CreateOptions offers the card priorities; Codecks cards have no type.
+func (s *Service) CreateOptions() tickets.CreateOptions {
+	return tickets.CreateOptions{Priorities: []string{"Highest", "High", "Medium", "Low", "Lowest"}}
+}
+
 // CreateTicket creates a new card via the Codecks dispatch API (cards/create).
 // See https://manual.codecks.io/api/ — creates card on hand by default; optional deckId from CODECKS_PROJECT.
 // We omit userId when we cannot resolve it (owner/currentUser queries 500); the API may infer user from the auth token.
@@ -725,7 +773,7 @@ func (s *Service) CreateTicket(ctx context.Context, input *tickets.CreateTicketI
 		"masterTags":   []any{},
 		"attachments":  []any{},
 		"effort":       0,
-		"priority":     "c",
+		"priority":     codecksPriorityCode(input.Priority),
 		"childCards":   []any{},
 	}
 	if s.currentUserID != "" {
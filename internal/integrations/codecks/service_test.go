@@ -107,3 +107,26 @@ func TestIsConfigured(t *testing.T) {
 	})
 }
 
+
+// TestContentToMarkdown verifies the title line is dropped and checkboxes become task list items
+func TestContentToMarkdown(t *testing.T) {
+	content := "Fix login\n\nSteps:\n[] write test\n  [x] repro"
+	want := "Steps:\n- [ ] write test\n  - [x] repro"
+	if got := contentToMarkdown(content, "Fix login"); got != want {
+		t.Errorf("contentToMarkdown() = %q, want %q", got, want)
+	}
+	if got := contentToMarkdown("Body only", "Other title"); got != "Body only" {
+		t.Errorf("contentToMarkdown() kept = %q", got)
+	}
+}
+
+func TestCodecksPriorityCodeRoundTrip(t *testing.T) {
+	for _, name := range (&Service{}).CreateOptions().Priorities {
+		if got := mapCodecksPriority(codecksPriorityCode(name)); got != name {
+			t.Errorf("%s -> %q -> %s", name, codecksPriorityCode(name), got)
+		}
+	}
+	if codecksPriorityCode("") != "c" {
+		t.Errorf("default priority = %q, want c", codecksPriorityCode(""))
+	}
+}
@@ -0,0 +1,186 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CodeownersLocations are where GitHub looks for a CODEOWNERS file, in the order it checks them.
+var CodeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeownersRule is one pattern line of a CODEOWNERS file.
+type CodeownersRule struct {
+	Pattern string
+	Owners  []string // "@user", "@org/team" or an email address; empty marks paths as unowned
+	Line    int
+	re      *regexp.Regexp
+}
+
+// Codeowners is a parsed CODEOWNERS file. Path is relative to the repository root.
+type Codeowners struct {
+	Path  string
+	Rules []CodeownersRule
+}
+
+// LoadCodeowners reads the first CODEOWNERS file GitHub would use from the repository at root.
+// It returns nil (and no error) when the repository has none.
+func LoadCodeowners(root string) (*Codeowners, error) {
+	for _, loc := range CodeownersLocations {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(loc)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", loc, err)
+		}
+		return &Codeowners{Path: loc, Rules: ParseCodeowners(string(data))}, nil
+	}
+	return nil, nil
+}
+
+// ParseCodeowners parses CODEOWNERS content. Comments, blank lines and patterns GitHub would reject
+// are skipped.
+func ParseCodeowners(content string) []CodeownersRule {
+	var rules []CodeownersRule
+	for i, line := range strings.Split(content, "\n") {
+		if j := strings.Index(line, "#"); j >= 0 {
+			line = line[:j]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		re, err := codeownersPatternRE(fields[0])
+		if err != nil {
+			continue
+		}
+		rules = append(rules, CodeownersRule{Pattern: fields[0], Owners: fields[1:], Line: i + 1, re: re})
+	}
+	return rules
+}
+
+// codeownersPatternRE translates a gitignore-style CODEOWNERS pattern into a regexp over
+// slash-separated paths. Patterns without a slash (other than a trailing one) match at any depth;
+// a match on a directory covers everything below it, except for a trailing "/*".
+func codeownersPatternRE(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") || strings.Contains(pattern, "[") {
+		return nil, fmt.Errorf("unsupported pattern %q", pattern)
+	}
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	switch {
+	case dirOnly:
+		b.WriteString("/.*")
+	case !strings.HasSuffix(p, "/*"):
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// Match returns the rule that owns path (the last matching one, as on GitHub), or nil.
+func (c *Codeowners) Match(path string) *CodeownersRule {
+	if c == nil {
+		return nil
+	}
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if c.Rules[i].re != nil && c.Rules[i].re.MatchString(path) {
+			return &c.Rules[i]
+		}
+	}
+	return nil
+}
+
+// ReviewerSuggestion is a CODEOWNERS owner of some of a change's paths.
+type ReviewerSuggestion struct {
+	Owner string         // as written in CODEOWNERS ("@user" or "@org/team")
+	Rule  CodeownersRule // the rule that matched the owner's first path
+	Paths int            // how many of the paths the owner owns
+}
+
+// IsTeam reports whether the owner is a team ("@org/team").
+func (r ReviewerSuggestion) IsTeam() bool {
+	return strings.Contains(r.Owner, "/")
+}
+
+// SuggestReviewers returns the owners of paths, those owning the most paths first. Email owners
+// (which can't be requested as reviewers) and exclude (the PR author's login) are left out.
+func (c *Codeowners) SuggestReviewers(paths []string, exclude string) []ReviewerSuggestion {
+	var out []ReviewerSuggestion
+	index := map[string]int{}
+	for _, p := range paths {
+		rule := c.Match(p)
+		if rule == nil {
+			continue
+		}
+		for _, owner := range rule.Owners {
+			if !strings.HasPrefix(owner, "@") || (exclude != "" && strings.EqualFold(owner, "@"+exclude)) {
+				continue
+			}
+			key := strings.ToLower(owner)
+			if i, ok := index[key]; ok {
+				out[i].Paths++
+				continue
+			}
+			index[key] = len(out)
+			out = append(out, ReviewerSuggestion{Owner: owner, Rule: *rule, Paths: 1})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Paths > out[j].Paths })
+	return out
+}
+
+// SplitReviewers splits a reviewer list ("@alice, @org/web bob") into user logins and team slugs
+// as the review request API takes them.
+func SplitReviewers(list string) (users, teams []string) {
+	seen := map[string]bool{}
+	for _, f := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		f = strings.TrimPrefix(f, "@")
+		if f == "" || seen[strings.ToLower(f)] {
+			continue
+		}
+		seen[strings.ToLower(f)] = true
+		if _, team, ok := strings.Cut(f, "/"); ok {
+			if team != "" {
+				teams = append(teams, team)
+			}
+			continue
+		}
+		users = append(users, f)
+	}
+	return users, teams
+}
@@ -0,0 +1,74 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCodeownersMatch(t *testing.T) {
+	c := &Codeowners{Rules: ParseCodeowners(`# Default owners
+*           @org/core
+*.go        @gopher   # Go files anywhere
+/docs/      @writer
+apps/       @apps-team
+/build/*    @builder
+internal/**/testdata @qa
+/vendor/                 
+`)}
+	for path, want := range map[string]string{
+		"README.md":                     "*",
+		"cmd/main.go":                   "*.go",
+		"docs/guide/intro.md":           "/docs/",
+		"web/apps/index.ts":             "apps/",
+		"build/Makefile":                "/build/*",
+		"build/scripts/release.sh":      "*",
+		"internal/tui/testdata/a.json":  "internal/**/testdata",
+		"internal/testdata/b.json":      "internal/**/testdata",
+		"vendor/github.com/x/y/file.go": "/vendor/",
+	} {
+		rule := c.Match(path)
+		if rule == nil || rule.Pattern != want {
+			t.Errorf("Match(%q) = %+v, want pattern %q", path, rule, want)
+		}
+	}
+	if rule := c.Match("vendor/a.go"); len(rule.Owners) != 0 {
+		t.Errorf("vendor should be unowned, got %v", rule.Owners)
+	}
+}
+
+func TestCodeownersSuggestReviewers(t *testing.T) {
+	c := &Codeowners{Rules: ParseCodeowners("* @org/core\n*.go @gopher @me dev@example.com\n")}
+	got := c.SuggestReviewers([]string{"a.go", "b.go", "README.md"}, "me")
+	if len(got) != 2 || got[0].Owner != "@gopher" || got[0].Paths != 2 || got[0].Rule.Line != 2 || got[1].Owner != "@org/core" || !got[1].IsTeam() {
+		t.Fatalf("suggestions = %+v", got)
+	}
+}
+
+func TestSplitReviewers(t *testing.T) {
+	users, teams := SplitReviewers("@alice, @org/web bob @Alice")
+	if !reflect.DeepEqual(users, []string{"alice", "bob"}) || !reflect.DeepEqual(teams, []string{"web"}) {
+		t.Fatalf("users = %v, teams = %v", users, teams)
+	}
+}
+
+func TestLoadCodeowners(t *testing.T) {
+	root := t.TempDir()
+	if c, err := LoadCodeowners(root); c != nil || err != nil {
+		t.Fatalf("no file: %v, %v", c, err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte("* @root\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte("* @dotgithub\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := LoadCodeowners(root)
+	if err != nil || c.Path != ".github/CODEOWNERS" || c.Match("x").Owners[0] != "@dotgithub" {
+		t.Fatalf("got %+v, %v", c, err)
+	}
+}
@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// Upstream is the repository origin was forked from.
+type Upstream struct {
+	Owner         string
+	Repo          string
+	DefaultBranch string
+	// PushDenied is true when GitHub reported the user's role in the upstream and it lacks push,
+	// so PRs there can be opened but not merged.
+	PushDenied bool
+}
+
+// FullName returns "owner/repo".
+func (u Upstream) FullName() string {
+	return u.Owner + "/" + u.Repo
+}
+
+// DetectFork reports whether origin is a fork and, if so, remembers its parent as the upstream.
+// Returns nil (and no error) for non-forks. Does not change the PR target; see SetPRTarget.
+func (s *Service) DetectFork(ctx context.Context) (*Upstream, error) {
+	if s == nil {
+		return nil, fmt.Errorf("github service unavailable")
+	}
+	repo, resp, err := s.client.Repositories.Get(ctx, s.owner, s.repo)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			return nil, NewAuthError(fmt.Errorf("failed to read repository: %w", err), resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to read repository %s/%s: %w", s.owner, s.repo, err)
+	}
+	if branch := strings.TrimSpace(repo.GetDefaultBranch()); branch != "" {
+		s.defaultBranch = branch
+	}
+	parent := repo.GetParent()
+	if !repo.GetFork() || parent == nil || parent.GetOwner().GetLogin() == "" {
+		s.upstream = nil
+		s.prsOnUpstream = false
+		return nil, nil
+	}
+	s.upstream = &Upstream{
+		Owner:         parent.GetOwner().GetLogin(),
+		Repo:          parent.GetName(),
+		DefaultBranch: parent.GetDefaultBranch(),
+	}
+	if perms := parent.GetPermissions(); perms != nil && !perms["push"] {
+		s.upstream.PushDenied = true
+	}
+	return s.upstream, nil
+}
+
+// Upstream returns the fork's parent, or nil when origin isn't a fork (or DetectFork hasn't run).
+func (s *Service) Upstream() *Upstream {
+	if s == nil {
+		return nil
+	}
+	return s.upstream
+}
+
+// SetPRTarget chooses where PRs are listed, updated, merged, and closed: the upstream repository
+// (when origin is a fork) or origin itself. Ignored when there is no upstream.
+func (s *Service) SetPRTarget(upstream bool) {
+	s.prsOnUpstream = upstream && s.upstream != nil
+}
+
+// TargetsUpstream reports whether PR operations go to the upstream repository.
+func (s *Service) TargetsUpstream() bool {
+	return s != nil && s.prsOnUpstream
+}
+
+// PRTargetFullName returns "owner/repo" of the repository PRs are listed from.
+func (s *Service) PRTargetFullName() string {
+	owner, repo := s.prRepo()
+	return owner + "/" + repo
+}
+
+// BaseBranchExists checks that branch exists in the repository a new PR is opened against.
+func (s *Service) BaseBranchExists(ctx context.Context, branch string, toUpstream bool) (bool, error) {
+	if toUpstream && s.upstream != nil {
+		return s.branchExists(ctx, s.upstream.Owner, s.upstream.Repo, branch)
+	}
+	return s.branchExists(ctx, s.owner, s.repo, branch)
+}
+
+// SyncFork asks GitHub to bring branch on the fork up to date with the upstream, like the web UI's
+// "Sync fork" button. Returns GitHub's merge type ("fast-forward", "merge", or "none" when already
+// current). A conflict (409) means the branches diverged and must be synced locally instead.
+func (s *Service) SyncFork(ctx context.Context, branch string) (string, error) {
+	if s == nil || s.upstream == nil {
+		return "", fmt.Errorf("origin is not a GitHub fork")
+	}
+	res, resp, err := s.client.Repositories.MergeUpstream(ctx, s.owner, s.repo, &github.RepoMergeUpstreamRequest{
+		Branch: github.String(branch),
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return "", fmt.Errorf("%s on %s/%s has diverged from %s: %w", branch, s.owner, s.repo, s.upstream.FullName(), err)
+		}
+		return "", fmt.Errorf("failed to sync %s/%s with %s: %w", s.owner, s.repo, s.upstream.FullName(), err)
+	}
+	return res.GetMergeType(), nil
+}
+
+// prRepo returns the owner and name of the repository PR calls go to.
+func (s *Service) prRepo() (owner, repo string) {
+	if s.prsOnUpstream && s.upstream != nil {
+		return s.upstream.Owner, s.upstream.Repo
+	}
+	return s.owner, s.repo
+}
+
+// createRepo returns where a new PR is opened and its head ref. PRs against the upstream must name
+// the fork's owner in the head ("owner:branch"); same-repo PRs use the bare branch.
+func (s *Service) createRepo(toUpstream bool, branch string) (owner, repo, head string) {
+	if toUpstream && s.upstream != nil {
+		return s.upstream.Owner, s.upstream.Repo, s.owner + ":" + branch
+	}
+	return s.owner, s.repo, branch
+}
+
+// headName returns the head branch as jj-tui matches it against local bookmarks. Upstream PRs
+// opened from other people's forks are shown as "owner:branch" so a same-named local bookmark
+// isn't mistaken for their head.
+func (s *Service) headName(headOwner, ref string) string {
+	if s.prsOnUpstream && headOwner != "" && !strings.EqualFold(headOwner, s.owner) {
+		return headOwner + ":" + ref
+	}
+	return ref
+}
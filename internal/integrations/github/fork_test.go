@@ -0,0 +1,126 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+// TestForkTargetsUpstream: a fork's parent is detected, PRs are opened there with an
+// "owner:branch" head, and upstream PRs from other forks don't claim local branch names.
+func TestForkTargetsUpstream(t *testing.T) {
+	t.Parallel()
+	var createdOn, createdHead string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/me/proj":
+			fmt.Fprint(w, `{"fork":true,"default_branch":"main","parent":{"name":"proj","owner":{"login":"up"},"default_branch":"trunk","permissions":{"pull":true}}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/up/proj/pulls":
+			var body struct{ Head string }
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			createdOn, createdHead = r.URL.Path, body.Head
+			fmt.Fprint(w, `{"number":7,"state":"open","head":{"ref":"feature"},"base":{"ref":"trunk"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/up/proj/pulls":
+			fmt.Fprint(w, `[
+				{"number":7,"state":"open","head":{"ref":"feature","repo":{"owner":{"login":"me"}}},"base":{"ref":"trunk"}},
+				{"number":8,"state":"open","head":{"ref":"feature","repo":{"owner":{"login":"someone"}}},"base":{"ref":"trunk"}}
+			]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	svc := newTestServiceWithBaseURL(t, "me", "proj", server.URL)
+	up, err := svc.DetectFork(context.Background())
+	if err != nil || up == nil {
+		t.Fatalf("DetectFork: %v, %v", up, err)
+	}
+	if up.FullName() != "up/proj" || up.DefaultBranch != "trunk" || !up.PushDenied {
+		t.Fatalf("upstream = %+v", up)
+	}
+	svc.SetPRTarget(true)
+	if !svc.TargetsUpstream() || svc.PRTargetFullName() != "up/proj" {
+		t.Fatalf("target = %s", svc.PRTargetFullName())
+	}
+
+	if _, err := svc.CreatePullRequest(context.Background(), &internal.CreatePRRequest{
+		Title: "t", HeadBranch: "feature", BaseBranch: "trunk", ToUpstream: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if createdOn != "/repos/up/proj/pulls" || createdHead != "me:feature" {
+		t.Fatalf("created on %s with head %q", createdOn, createdHead)
+	}
+
+	prs, err := svc.getPullRequestsREST(context.Background(), PRFilterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 2 || prs[0].HeadBranch != "feature" || prs[1].HeadBranch != "someone:feature" {
+		t.Fatalf("heads = %+v", prs)
+	}
+}
+
+func TestDetectFork_NotAFork(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"fork":false,"default_branch":"main"}`)
+	}))
+	defer server.Close()
+
+	svc := newTestServiceWithBaseURL(t, "me", "proj", server.URL)
+	up, err := svc.DetectFork(context.Background())
+	if err != nil || up != nil {
+		t.Fatalf("DetectFork = %v, %v", up, err)
+	}
+	svc.SetPRTarget(true)
+	if svc.TargetsUpstream() || svc.PRTargetFullName() != "me/proj" {
+		t.Fatal("without an upstream PRs must stay on origin")
+	}
+}
+
+func TestSyncFork(t *testing.T) {
+	t.Parallel()
+	var branch string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/me/proj":
+			fmt.Fprint(w, `{"fork":true,"default_branch":"main","parent":{"name":"proj","owner":{"login":"up"},"default_branch":"main"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/me/proj/merge-upstream":
+			var body struct{ Branch string }
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			branch = body.Branch
+			if branch == "diverged" {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{"message":"merge conflict"}`)
+				return
+			}
+			fmt.Fprint(w, `{"message":"ok","merge_type":"fast-forward","base_branch":"up:main"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	svc := newTestServiceWithBaseURL(t, "me", "proj", server.URL)
+	if _, err := svc.SyncFork(context.Background(), "main"); err == nil {
+		t.Fatal("SyncFork before DetectFork should fail")
+	}
+	if _, err := svc.DetectFork(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	mergeType, err := svc.SyncFork(context.Background(), "main")
+	if err != nil || mergeType != "fast-forward" || branch != "main" {
+		t.Fatalf("SyncFork = %q, %v (branch %q)", mergeType, err, branch)
+	}
+	if _, err := svc.SyncFork(context.Background(), "diverged"); err == nil || !strings.Contains(err.Error(), "has diverged from up/proj") {
+		t.Fatalf("conflict error = %v", err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-github/v66/github"
 	"github.com/madicen/jj-tui/internal/tickets"
@@ -15,6 +17,12 @@ type IssuesService struct {
 	owner    string
 	repo     string
 	username string // cached authenticated username
+
+	// delta is the assigned list PollAssignedTickets keeps up to date; listETag is the ETag of
+	// the last delta page it saw.
+	delta    tickets.DeltaList
+	mu       sync.Mutex
+	listETag string
 }
 
 // NewIssuesService creates a new GitHub Issues service from an existing GitHub service
@@ -42,26 +50,25 @@ func NewIssuesServiceWithToken(owner, repo, token string) (*IssuesService, error
 
 // GetAssignedTickets returns GitHub issues assigned to the current user
 func (s *IssuesService) GetAssignedTickets(ctx context.Context) ([]tickets.Ticket, error) {
-	// Get authenticated username if not cached
-	if s.username == "" {
-		user, _, err := s.client.Users.Get(ctx, "")
-		if err != nil {
-			return nil, fmt.Errorf("failed to get authenticated user: %w", err)
-		}
-		s.username = user.GetLogin()
+	start := time.Now()
+	list, err := s.fetchAssignedTickets(ctx)
+	if err != nil {
+		return nil, err
 	}
+	s.delta.Reset(list, start)
+	return list, nil
+}
 
-	// List issues assigned to the current user
-	opts := &github.IssueListByRepoOptions{
-		Assignee:  s.username,
-		State:     "open", // Default to open issues
-		Sort:      "updated",
-		Direction: "desc",
-		ListOptions: github.ListOptions{
-			PerPage: 100,
-		},
+// fetchAssignedTickets lists every open issue assigned to the current user.
+func (s *IssuesService) fetchAssignedTickets(ctx context.Context) ([]tickets.Ticket, error) {
+	username, err := s.login(ctx)
+	if err != nil {
+		return nil, err
 	}
 
+	// List issues assigned to the current user
+	opts := assignedIssuesOptions(username)
+
 	var allTickets []tickets.Ticket
 	for {
 		issues, resp, err := s.client.Issues.ListByRepo(ctx, s.owner, s.repo, opts)
@@ -88,6 +95,30 @@ func (s *IssuesService) GetAssignedTickets(ctx context.Context) ([]tickets.Ticke
 	return allTickets, nil
 }
 
+// login returns the authenticated user's login, fetching it on first use.
+func (s *IssuesService) login(ctx context.Context) (string, error) {
+	if s.username == "" {
+		user, _, err := s.client.Users.Get(ctx, "")
+		if err != nil {
+			return "", fmt.Errorf("failed to get authenticated user: %w", err)
+		}
+		s.username = user.GetLogin()
+	}
+	return s.username, nil
+}
+
+func assignedIssuesOptions(username string) *github.IssueListByRepoOptions {
+	return &github.IssueListByRepoOptions{
+		Assignee:  username,
+		State:     "open", // Default to open issues
+		Sort:      "updated",
+		Direction: "desc",
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+}
+
 // GetTicket returns a single issue by number
 func (s *IssuesService) GetTicket(ctx context.Context, key string) (*tickets.Ticket, error) {
 	// Parse the issue number from the key (e.g., "#123" or "123")
@@ -106,6 +137,43 @@ func (s *IssuesService) GetTicket(ctx context.Context, key string) (*tickets.Tic
 	return &ticket, nil
 }
 
+// GetTicketDetail returns an issue's body, assignees, labels and comments
+func (s *IssuesService) GetTicketDetail(ctx context.Context, key string) (*tickets.TicketDetail, error) {
+	var issueNumber int
+	if _, err := fmt.Sscanf(strings.TrimPrefix(key, "#"), "%d", &issueNumber); err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", key)
+	}
+
+	issue, _, err := s.client.Issues.Get(ctx, s.owner, s.repo, issueNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", issueNumber, err)
+	}
+	comments, _, err := s.client.Issues.ListComments(ctx, s.owner, s.repo, issueNumber, &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments on issue #%d: %w", issueNumber, err)
+	}
+
+	detail := &tickets.TicketDetail{Key: key, DescriptionMarkdown: strings.TrimSpace(issue.GetBody())}
+	var assignees []string
+	for _, a := range issue.Assignees {
+		assignees = append(assignees, "@"+a.GetLogin())
+	}
+	detail.Assignee = strings.Join(assignees, ", ")
+	for _, l := range issue.Labels {
+		detail.Labels = append(detail.Labels, l.GetName())
+	}
+	for _, c := range comments {
+		detail.Comments = append(detail.Comments, tickets.TicketComment{
+			Author:  "@" + c.GetUser().GetLogin(),
+			Body:    c.GetBody(),
+			Created: c.GetCreatedAt().Time,
+		})
+	}
+	return detail, nil
+}
+
 // GetTicketURL returns the browser URL for an issue
 func (s *IssuesService) GetTicketURL(ticket tickets.Ticket) string {
 	return fmt.Sprintf("https://github.com/%s/%s/issues/%s", s.owner, s.repo, strings.TrimPrefix(ticket.Key, "#"))
@@ -181,7 +249,22 @@ func (s *IssuesService) CanCreateTicket() bool {
 	return true
 }
 
-// CreateTicket creates a new GitHub issue.
+// issueTypeLabels and issuePriorityLabels map the create form's choices to the labels
+// issueToTicket reads the type and priority back from (GitHub's default labels for the types).
+var (
+	issueTypeLabels     = map[string]string{"Bug": "bug", "Feature": "enhancement", "Documentation": "documentation"}
+	issuePriorityLabels = map[string]string{"High": "priority: high", "Medium": "priority: medium", "Low": "priority: low"}
+)
+
+// CreateOptions offers types and priorities, which are applied as labels.
+func (s *IssuesService) CreateOptions() tickets.CreateOptions {
+	return tickets.CreateOptions{
+		Types:      []string{"Bug", "Feature", "Documentation"},
+		Priorities: []string{"High", "Medium", "Low"},
+	}
+}
+
+// CreateTicket creates a new GitHub issue. Type and priority become labels.
 func (s *IssuesService) CreateTicket(ctx context.Context, input *tickets.CreateTicketInput) (*tickets.Ticket, error) {
 	if input == nil || strings.TrimSpace(input.Summary) == "" {
 		return nil, fmt.Errorf("title is required")
@@ -194,6 +277,16 @@ func (s *IssuesService) CreateTicket(ctx context.Context, input *tickets.CreateT
 	if body != "" {
 		issueReq.Body = github.String(body)
 	}
+	var labels []string
+	if l := issueTypeLabels[input.Type]; l != "" {
+		labels = append(labels, l)
+	}
+	if l := issuePriorityLabels[input.Priority]; l != "" {
+		labels = append(labels, l)
+	}
+	if len(labels) > 0 {
+		issueReq.Labels = &labels
+	}
 	issue, _, err := s.client.Issues.Create(ctx, s.owner, s.repo, issueReq)
 	if err != nil {
 		return nil, fmt.Errorf("create issue: %w", err)
@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/madicen/jj-tui/internal/tickets"
+)
+
+// PollAssignedTickets asks GitHub only for the assigned issues updated since the last change it
+// saw (the REST `since` parameter, state=all so closed issues come back to be dropped) and merges
+// them into the list GetAssignedTickets fetched. The previous ETag is sent too: while nothing
+// changes the request stays the same and comes back as 304 Not Modified, which GitHub doesn't
+// count against the rate limit. changed is false (and list nil) when nothing changed. Without a
+// list yet, or every tickets.DeltaFullSyncInterval, the whole list is fetched instead.
+func (s *IssuesService) PollAssignedTickets(ctx context.Context) ([]tickets.Ticket, bool, error) {
+	start := time.Now()
+	since, full := s.delta.Since(start)
+	if full {
+		return s.pollFullList(ctx, start)
+	}
+	username, err := s.login(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	opts := assignedIssuesOptions(username)
+	q := url.Values{}
+	q.Set("assignee", opts.Assignee)
+	q.Set("state", "all")
+	q.Set("since", since.UTC().Format(time.RFC3339))
+	q.Set("sort", opts.Sort)
+	q.Set("direction", opts.Direction)
+	q.Set("per_page", fmt.Sprint(opts.PerPage))
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/issues?%s", s.owner, s.repo, q.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	s.mu.Lock()
+	etag := s.listETag
+	s.mu.Unlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	var issues []*github.Issue
+	resp, err := s.client.Do(ctx, req, &issues)
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to poll issues: %w", err)
+	}
+	s.mu.Lock()
+	s.listETag = resp.Header.Get("ETag")
+	s.mu.Unlock()
+	if resp.NextPage != 0 {
+		// More changes than fit a page: fetch the whole list the usual way.
+		return s.pollFullList(ctx, start)
+	}
+	var updated []tickets.Ticket
+	var removed []string
+	for _, issue := range issues {
+		if issue.IsPullRequest() {
+			continue
+		}
+		if t := s.issueToTicket(issue); issue.GetState() == "open" {
+			updated = append(updated, t)
+		} else {
+			removed = append(removed, t.Key)
+		}
+	}
+	list, changed := s.delta.Apply(updated, removed, start)
+	if !changed {
+		return nil, false, nil
+	}
+	return list, true, nil
+}
+
+// pollFullList is PollAssignedTickets' full fetch.
+func (s *IssuesService) pollFullList(ctx context.Context, start time.Time) ([]tickets.Ticket, bool, error) {
+	list, err := s.fetchAssignedTickets(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if !s.delta.Reset(list, start) {
+		return nil, false, nil
+	}
+	return list, true, nil
+}
+
+// CloseForMergedPR closes the issue with a comment pointing at the merged pull request. closed
+// is false when the issue was already closed (e.g. by a "Fixes #N" in the PR description), in
+// which case nothing is posted.
+func (s *IssuesService) CloseForMergedPR(ctx context.Context, ticketKey string, prNumber int) (bool, error) {
+	var issueNumber int
+	if _, err := fmt.Sscanf(strings.TrimPrefix(ticketKey, "#"), "%d", &issueNumber); err != nil {
+		return false, fmt.Errorf("invalid issue number: %s", ticketKey)
+	}
+	issue, _, err := s.client.Issues.Get(ctx, s.owner, s.repo, issueNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to get issue #%d: %w", issueNumber, err)
+	}
+	if issue.GetState() == "closed" {
+		return false, nil
+	}
+	comment := &github.IssueComment{Body: github.String(fmt.Sprintf("Closed by #%d, which was merged.", prNumber))}
+	if _, _, err := s.client.Issues.CreateComment(ctx, s.owner, s.repo, issueNumber, comment); err != nil {
+		return false, fmt.Errorf("failed to comment on issue #%d: %w", issueNumber, err)
+	}
+	req := &github.IssueRequest{State: github.String("closed"), StateReason: github.String("completed")}
+	if _, _, err := s.client.Issues.Edit(ctx, s.owner, s.repo, issueNumber, req); err != nil {
+		return false, fmt.Errorf("failed to close issue #%d: %w", issueNumber, err)
+	}
+	return true, nil
+}
+
+var (
+	_ tickets.ChangePoller = (*IssuesService)(nil)
+	_ tickets.MergeCloser  = (*IssuesService)(nil)
+)
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// The first poll fetches the whole list; later ones only ask for issues updated since, sending
+// the last ETag so an unchanged delta is a 304. A closed issue in a delta leaves the list.
+func TestIssuesPollAssignedTickets(t *testing.T) {
+	t.Parallel()
+	etag, delta := `"v1"`, `[]`
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/repos/o/r/issues" || q.Get("assignee") != "me" {
+			http.NotFound(w, r)
+			return
+		}
+		if q.Get("since") == "" {
+			if q.Get("state") != "open" {
+				t.Errorf("full fetch state = %q", q.Get("state"))
+			}
+			fmt.Fprint(w, `[{"number":4,"title":"Fix it","state":"open"},{"number":5,"title":"PR","state":"open","pull_request":{}}]`)
+			return
+		}
+		if q.Get("state") != "all" {
+			t.Errorf("delta state = %q", q.Get("state"))
+		}
+		sent = append(sent, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, delta)
+	}))
+	defer server.Close()
+	gh := newTestServiceWithBaseURL(t, "o", "r", server.URL)
+	gh.username = "me"
+	svc, _ := NewIssuesService(gh)
+
+	list, changed, err := svc.PollAssignedTickets(context.Background())
+	if err != nil || !changed || len(list) != 1 || list[0].Key != "#4" {
+		t.Fatalf("first poll = %+v, %v, %v", list, changed, err)
+	}
+	for range 2 {
+		if list, changed, err = svc.PollAssignedTickets(context.Background()); err != nil || changed || list != nil {
+			t.Fatalf("unchanged poll = %+v, %v, %v", list, changed, err)
+		}
+	}
+	etag, delta = `"v2"`, `[{"number":6,"title":"New","state":"open"},{"number":4,"title":"Fix it","state":"closed"}]`
+	list, changed, err = svc.PollAssignedTickets(context.Background())
+	if err != nil || !changed || len(list) != 1 || list[0].Key != "#6" {
+		t.Fatalf("changed poll = %+v, %v, %v", list, changed, err)
+	}
+	if strings.Join(sent, ",") != `,"v1","v1"` {
+		t.Fatalf("If-None-Match sent = %q", sent)
+	}
+}
+
+// An open issue gets a comment naming the PR and is closed as completed; a closed one is left alone.
+func TestIssuesCloseForMergedPR(t *testing.T) {
+	t.Parallel()
+	var comment, edit string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/o/r/issues/4":
+			fmt.Fprint(w, `{"number":4,"state":"open"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/o/r/issues/6":
+			fmt.Fprint(w, `{"number":6,"state":"closed"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/o/r/issues/4/comments":
+			comment = string(body)
+			fmt.Fprint(w, `{}`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/repos/o/r/issues/4":
+			edit = string(body)
+			fmt.Fprint(w, `{"number":4,"state":"closed"}`)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	svc, _ := NewIssuesService(newTestServiceWithBaseURL(t, "o", "r", server.URL))
+
+	if closed, err := svc.CloseForMergedPR(context.Background(), "#4", 12); err != nil || !closed {
+		t.Fatalf("close #4 = %v, %v", closed, err)
+	}
+	if !strings.Contains(comment, "Closed by #12") || !strings.Contains(edit, `"state":"closed"`) || !strings.Contains(edit, `"state_reason":"completed"`) {
+		t.Fatalf("comment = %s, edit = %s", comment, edit)
+	}
+	if closed, err := svc.CloseForMergedPR(context.Background(), "#6", 12); err != nil || closed {
+		t.Fatalf("close already-closed #6 = %v, %v", closed, err)
+	}
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/madicen/jj-tui/internal/forge"
+	"github.com/shurcooL/githubv4"
+)
+
+// Merge methods accepted by MergePullRequest and EnableAutoMerge.
+const (
+	MergeMethodMerge  = forge.MergeMethodMerge
+	MergeMethodSquash = forge.MergeMethodSquash
+	MergeMethodRebase = forge.MergeMethodRebase
+)
+
+// MergeMethods lists the merge methods in the order the merge form offers them.
+var MergeMethods = []string{MergeMethodSquash, MergeMethodRebase, MergeMethodMerge}
+
+// MergeOptions says how to merge a pull request. An empty CommitTitle or CommitMessage keeps
+// GitHub's default; both are ignored for rebase merges.
+type MergeOptions = forge.MergeOptions
+
+// EnableAutoMerge turns on auto-merge for a pull request: GitHub merges it with opts once the
+// required checks and reviews pass. The repository must allow auto-merge.
+func (s *Service) EnableAutoMerge(ctx context.Context, prNumber int, opts MergeOptions) error {
+	if s == nil || s.graphqlClient == nil {
+		return fmt.Errorf("github service unavailable")
+	}
+	owner, repo := s.prRepo()
+	pr, resp, err := s.client.PullRequests.Get(ctx, owner, repo, prNumber)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == 401 || resp.StatusCode == 403) {
+			return NewAuthError(fmt.Errorf("failed to enable auto-merge: %w", err), resp.StatusCode)
+		}
+		return fmt.Errorf("failed to get PR #%d: %w", prNumber, err)
+	}
+	method := githubv4.PullRequestMergeMethod(strings.ToUpper(opts.Method))
+	input := githubv4.EnablePullRequestAutoMergeInput{
+		PullRequestID: githubv4.ID(pr.GetNodeID()),
+		MergeMethod:   &method,
+	}
+	if opts.Method != MergeMethodRebase {
+		if opts.CommitTitle != "" {
+			input.CommitHeadline = githubv4.NewString(githubv4.String(opts.CommitTitle))
+		}
+		if opts.CommitMessage != "" {
+			input.CommitBody = githubv4.NewString(githubv4.String(opts.CommitMessage))
+		}
+	}
+	var mutation struct {
+		EnablePullRequestAutoMerge struct {
+			ClientMutationID githubv4.String
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}
+	if err := s.graphqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return fmt.Errorf("failed to enable auto-merge on PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// mergeRequestOptions converts opts to go-github's merge options and commit message argument.
+func mergeRequestOptions(opts MergeOptions) (*github.PullRequestOptions, string) {
+	method := opts.Method
+	if method == "" {
+		method = MergeMethodMerge
+	}
+	options := &github.PullRequestOptions{MergeMethod: method}
+	if method == MergeMethodRebase {
+		return options, ""
+	}
+	options.CommitTitle = opts.CommitTitle
+	return options, opts.CommitMessage
+}
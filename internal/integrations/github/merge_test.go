@@ -0,0 +1,17 @@
+package github
+
+import "testing"
+
+func TestMergeRequestOptions(t *testing.T) {
+	opts, msg := mergeRequestOptions(MergeOptions{Method: MergeMethodSquash, CommitTitle: "Add flag (#3)", CommitMessage: "body"})
+	if opts.MergeMethod != "squash" || opts.CommitTitle != "Add flag (#3)" || msg != "body" {
+		t.Fatalf("squash = %+v, %q", opts, msg)
+	}
+	opts, msg = mergeRequestOptions(MergeOptions{Method: MergeMethodRebase, CommitTitle: "ignored", CommitMessage: "ignored"})
+	if opts.MergeMethod != "rebase" || opts.CommitTitle != "" || msg != "" {
+		t.Fatalf("rebase = %+v, %q", opts, msg)
+	}
+	if opts, _ = mergeRequestOptions(MergeOptions{}); opts.MergeMethod != "merge" {
+		t.Fatalf("default = %q", opts.MergeMethod)
+	}
+}
@@ -0,0 +1,183 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Capability is a GitHub action jj-tui performs that the token may not be allowed to do.
+type Capability int
+
+const (
+	CapReadPRs Capability = iota
+	CapCreatePR
+	CapMergePR
+	CapClosePR
+	CapReadChecks
+)
+
+// Capabilities lists every Capability in display order.
+var Capabilities = []Capability{CapReadPRs, CapCreatePR, CapMergePR, CapClosePR, CapReadChecks}
+
+func (c Capability) String() string {
+	switch c {
+	case CapReadPRs:
+		return "Read PRs"
+	case CapCreatePR:
+		return "Create PRs"
+	case CapMergePR:
+		return "Merge PRs"
+	case CapClosePR:
+		return "Close PRs"
+	case CapReadChecks:
+		return "Read checks"
+	}
+	return "unknown"
+}
+
+// Token kinds reported by Permissions.Kind.
+const (
+	TokenKindClassic     = "classic"      // OAuth app or classic PAT; scopes come from X-OAuth-Scopes
+	TokenKindFineGrained = "fine-grained" // fine-grained PAT or GitHub App token; no scope header
+)
+
+// Permissions is what ProbePermissions learned about the token for the current repository. A nil
+// *Permissions means "not probed" and allows everything, so callers never block on a failed probe.
+type Permissions struct {
+	Kind   string
+	Scopes []string // classic tokens only
+	denied map[Capability]string
+}
+
+// NewPermissions returns Permissions of the given kind that deny each capability in denied with
+// its reason (demo mode and tests; real tokens go through ProbePermissions).
+func NewPermissions(kind string, denied map[Capability]string) *Permissions {
+	p := &Permissions{Kind: kind, denied: map[Capability]string{}}
+	for c, reason := range denied {
+		p.deny(c, reason)
+	}
+	return p
+}
+
+// Can reports whether the token should be able to perform c.
+func (p *Permissions) Can(c Capability) bool {
+	return p == nil || p.denied[c] == ""
+}
+
+// Reason explains why c is not allowed, or "" when it is.
+func (p *Permissions) Reason(c Capability) string {
+	if p == nil {
+		return ""
+	}
+	return p.denied[c]
+}
+
+func (p *Permissions) deny(c Capability, reason string) {
+	if p.denied[c] == "" {
+		p.denied[c] = reason
+	}
+}
+
+// ProbePermissions works out which Capabilities the token has in owner/repo. For classic tokens
+// the X-OAuth-Scopes header and the repo's permissions block are enough. Fine-grained and App
+// tokens don't report scopes, so PR and check-run reads are probed with one cheap GET each. Write
+// access can't be probed without writing, so for those tokens only the user's push role gates it.
+// The repo lookup also fills the default-branch cache (see GetDefaultBranch). When PRs target a
+// fork's upstream (see SetPRTarget), merging also needs push access there.
+func (s *Service) ProbePermissions(ctx context.Context) (*Permissions, error) {
+	if s == nil {
+		return nil, fmt.Errorf("github service unavailable")
+	}
+	repo, resp, err := s.client.Repositories.Get(ctx, s.owner, s.repo)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return nil, NewAuthError(fmt.Errorf("GitHub authentication failed: %w", err), resp.StatusCode)
+		}
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			p := &Permissions{Kind: tokenKind(resp.Header), denied: map[Capability]string{}}
+			for _, c := range Capabilities {
+				p.deny(c, fmt.Sprintf("token can't access %s/%s", s.owner, s.repo))
+			}
+			return p, nil
+		}
+		return nil, fmt.Errorf("failed to read repository %s/%s: %w", s.owner, s.repo, err)
+	}
+	if branch := strings.TrimSpace(repo.GetDefaultBranch()); branch != "" {
+		s.defaultBranch = branch
+	}
+
+	p := permissionsFor(resp.Header, repo.GetPrivate(), repo.GetPermissions())
+	if s.prsOnUpstream && s.upstream.PushDenied {
+		p.deny(CapMergePR, fmt.Sprintf("your GitHub account can't merge into %s", s.upstream.FullName()))
+	}
+	if p.Kind == TokenKindFineGrained {
+		prOwner, prRepo := s.prRepo()
+		if s.probeDenied(ctx, fmt.Sprintf("repos/%s/%s/pulls?per_page=1", prOwner, prRepo)) {
+			p.deny(CapReadPRs, "token lacks the \"Pull requests: read\" permission")
+		}
+		if s.defaultBranch != "" {
+			path := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs?per_page=1", s.owner, s.repo, url.PathEscape(s.defaultBranch))
+			if s.probeDenied(ctx, path) {
+				p.deny(CapReadChecks, "token lacks the \"Checks: read\" permission")
+			}
+		}
+	}
+	return p, nil
+}
+
+// probeDenied GETs path and reports whether GitHub refused it (403/404). Other failures count as
+// allowed: a flaky network must not disable features.
+func (s *Service) probeDenied(ctx context.Context, path string) bool {
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return false
+	}
+	resp, _ := s.client.Do(ctx, req, nil)
+	return resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound)
+}
+
+func tokenKind(h http.Header) string {
+	if _, ok := h[http.CanonicalHeaderKey("X-OAuth-Scopes")]; ok {
+		return TokenKindClassic
+	}
+	return TokenKindFineGrained
+}
+
+// permissionsFor derives Permissions from the repo response: the token's scopes (classic tokens)
+// and the user's role in the repo (repoPerms keys: admin, maintain, push, triage, pull).
+func permissionsFor(h http.Header, private bool, repoPerms map[string]bool) *Permissions {
+	p := &Permissions{Kind: tokenKind(h), denied: map[Capability]string{}}
+	if p.Kind == TokenKindClassic {
+		for _, sc := range strings.Split(h.Get("X-OAuth-Scopes"), ",") {
+			if sc = strings.TrimSpace(sc); sc != "" {
+				p.Scopes = append(p.Scopes, sc)
+			}
+		}
+		repoScope := slices.Contains(p.Scopes, "repo")
+		if !repoScope && (private || !slices.Contains(p.Scopes, "public_repo")) {
+			reason := "token needs the \"repo\" scope"
+			if !private {
+				reason = "token needs the \"repo\" or \"public_repo\" scope"
+			}
+			if private {
+				p.deny(CapReadPRs, reason)
+				p.deny(CapReadChecks, reason)
+			}
+			p.deny(CapCreatePR, reason)
+			p.deny(CapMergePR, reason)
+			p.deny(CapClosePR, reason)
+		}
+	}
+	// jj-tui opens PRs from branches it pushes to this repo, and merging needs write access, so
+	// both depend on the user's role as well as the token.
+	if repoPerms != nil && !repoPerms["push"] {
+		reason := "your GitHub account doesn't have push access to this repository"
+		p.deny(CapCreatePR, reason)
+		p.deny(CapMergePR, reason)
+	}
+	return p
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPermissionsFor_ClassicScopes(t *testing.T) {
+	t.Parallel()
+	h := http.Header{}
+	h.Set("X-OAuth-Scopes", "repo, read:org")
+	p := permissionsFor(h, true, map[string]bool{"push": true, "pull": true})
+	if p.Kind != TokenKindClassic || len(p.Scopes) != 2 {
+		t.Fatalf("kind=%q scopes=%v", p.Kind, p.Scopes)
+	}
+	for _, c := range Capabilities {
+		if !p.Can(c) {
+			t.Errorf("%s denied: %s", c, p.Reason(c))
+		}
+	}
+
+	h.Set("X-OAuth-Scopes", "read:org")
+	p = permissionsFor(h, false, map[string]bool{"push": true, "pull": true})
+	if !p.Can(CapReadPRs) || !p.Can(CapReadChecks) {
+		t.Error("public repo should stay readable without scopes")
+	}
+	if p.Can(CapCreatePR) || p.Can(CapMergePR) || p.Can(CapClosePR) {
+		t.Error("writes need repo or public_repo")
+	}
+	if !strings.Contains(p.Reason(CapMergePR), "public_repo") {
+		t.Errorf("reason = %q", p.Reason(CapMergePR))
+	}
+}
+
+func TestPermissionsFor_NoPushRole(t *testing.T) {
+	t.Parallel()
+	h := http.Header{}
+	h.Set("X-OAuth-Scopes", "repo")
+	p := permissionsFor(h, false, map[string]bool{"pull": true})
+	if p.Can(CapCreatePR) || p.Can(CapMergePR) {
+		t.Error("create and merge need push access")
+	}
+	if !p.Can(CapClosePR) {
+		t.Error("close should not depend on push role (authors can close their own PRs)")
+	}
+}
+
+func TestPermissionsNilAllowsEverything(t *testing.T) {
+	t.Parallel()
+	var p *Permissions
+	if !p.Can(CapMergePR) || p.Reason(CapMergePR) != "" {
+		t.Fatal("unprobed permissions must not block anything")
+	}
+}
+
+// TestProbePermissions_FineGrained: no X-OAuth-Scopes header, so PR and check-run reads are probed.
+func TestProbePermissions_FineGrained(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/owner/repo":
+			fmt.Fprint(w, `{"default_branch":"main","private":true,"permissions":{"push":true,"pull":true}}`)
+		case "/repos/owner/repo/pulls":
+			fmt.Fprint(w, `[]`)
+		case "/repos/owner/repo/commits/main/check-runs":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"Resource not accessible by personal access token"}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc := newTestServiceWithBaseURL(t, "owner", "repo", server.URL)
+	p, err := svc.ProbePermissions(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Kind != TokenKindFineGrained {
+		t.Errorf("kind = %q", p.Kind)
+	}
+	if !p.Can(CapReadPRs) || !p.Can(CapCreatePR) || !p.Can(CapMergePR) {
+		t.Error("PR read/write should be allowed")
+	}
+	if p.Can(CapReadChecks) || !strings.Contains(p.Reason(CapReadChecks), "Checks") {
+		t.Errorf("checks: can=%v reason=%q", p.Can(CapReadChecks), p.Reason(CapReadChecks))
+	}
+	if branch, _ := svc.GetDefaultBranch(context.Background()); branch != "main" {
+		t.Errorf("default branch not cached: %q", branch)
+	}
+}
+
+func TestProbePermissions_NoAccess(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	}))
+	defer server.Close()
+
+	svc := newTestServiceWithBaseURL(t, "owner", "repo", server.URL)
+	p, err := svc.ProbePermissions(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range Capabilities {
+		if p.Can(c) {
+			t.Errorf("%s should be denied", c)
+		}
+	}
+}
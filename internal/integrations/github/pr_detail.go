@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/madicen/jj-tui/internal"
+	"github.com/shurcooL/githubv4"
+)
+
+// checkContextNode is one entry of a status check rollup: a check run or a commit status.
+type checkContextNode struct {
+	Typename string `graphql:"__typename"`
+	CheckRun struct {
+		Name       string
+		Status     string
+		Conclusion string
+		DetailsUrl string
+	} `graphql:"... on CheckRun"`
+	StatusContext struct {
+		Context   string
+		State     string
+		TargetUrl string
+	} `graphql:"... on StatusContext"`
+}
+
+// reviewThreadNode is a review thread with its first comment.
+type reviewThreadNode struct {
+	IsResolved   bool
+	IsOutdated   bool
+	Path         string
+	Line         int
+	OriginalLine int
+	Comments     struct {
+		TotalCount int
+		Nodes      []struct {
+			Author struct{ Login string }
+			Body   string
+		}
+	} `graphql:"comments(first: 1)"`
+}
+
+// GetPullRequestDetail loads the checks of the head commit, the review threads, the conversation
+// comments, and the changed files of a pull request (the first 100 of each).
+func (s *Service) GetPullRequestDetail(ctx context.Context, prNumber int) (*internal.PRDetail, error) {
+	if s == nil {
+		return nil, fmt.Errorf("github service unavailable")
+	}
+	var query struct {
+		Repository struct {
+			PullRequest struct {
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup struct {
+								Contexts struct {
+									Nodes []checkContextNode
+								} `graphql:"contexts(first: 100)"`
+							}
+						}
+					}
+				} `graphql:"commits(last: 1)"`
+				ReviewThreads struct {
+					Nodes []reviewThreadNode
+				} `graphql:"reviewThreads(first: 100)"`
+				Comments struct {
+					Nodes []struct {
+						Author    struct{ Login string }
+						Body      string
+						CreatedAt time.Time
+					}
+				} `graphql:"comments(first: 100)"`
+				Files struct {
+					Nodes []struct {
+						Path       string
+						Additions  int
+						Deletions  int
+						ChangeType string
+					}
+				} `graphql:"files(first: 100)"`
+			} `graphql:"pullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	owner, repo := s.prRepo()
+	variables := map[string]any{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repo),
+		"number": githubv4.Int(prNumber),
+	}
+	if err := s.graphqlClient.Query(ctx, &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to load details for PR #%d: %w", prNumber, err)
+	}
+
+	pr := query.Repository.PullRequest
+	detail := &internal.PRDetail{Number: prNumber}
+	if len(pr.Commits.Nodes) > 0 {
+		for _, n := range pr.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+			detail.Checks = append(detail.Checks, checkRunFrom(n))
+		}
+	}
+	for _, n := range pr.ReviewThreads.Nodes {
+		if t, ok := reviewThreadFrom(n); ok {
+			detail.Threads = append(detail.Threads, t)
+		}
+	}
+	for _, c := range pr.Comments.Nodes {
+		detail.Comments = append(detail.Comments, internal.PRComment{Author: c.Author.Login, Body: c.Body, CreatedAt: c.CreatedAt})
+	}
+	for _, f := range pr.Files.Nodes {
+		detail.Files = append(detail.Files, internal.PRFile{
+			Path:       f.Path,
+			Additions:  f.Additions,
+			Deletions:  f.Deletions,
+			ChangeType: strings.ToLower(f.ChangeType),
+		})
+	}
+	return detail, nil
+}
+
+// checkRunFrom converts a rollup entry. A check run reports its conclusion once it completed and
+// its status (queued, in progress) before; a commit status only has a state.
+func checkRunFrom(n checkContextNode) internal.CheckRun {
+	if n.Typename == "StatusContext" {
+		state := strings.ToUpper(n.StatusContext.State)
+		return internal.CheckRun{
+			Name:   n.StatusContext.Context,
+			Status: checkStatusFrom(state),
+			State:  strings.ToLower(state),
+			URL:    n.StatusContext.TargetUrl,
+		}
+	}
+	state := strings.ToUpper(n.CheckRun.Conclusion)
+	if state == "" {
+		state = strings.ToUpper(n.CheckRun.Status)
+	}
+	return internal.CheckRun{
+		Name:   n.CheckRun.Name,
+		Status: checkStatusFrom(state),
+		State:  strings.ToLower(state),
+		URL:    n.CheckRun.DetailsUrl,
+	}
+}
+
+// checkStatusFrom maps a check conclusion, check status, or commit status state to a CheckStatus.
+func checkStatusFrom(state string) internal.CheckStatus {
+	switch state {
+	case "SUCCESS", "NEUTRAL", "SKIPPED":
+		return internal.CheckStatusSuccess
+	case "FAILURE", "ERROR", "TIMED_OUT", "CANCELLED", "ACTION_REQUIRED", "STARTUP_FAILURE", "STALE":
+		return internal.CheckStatusFailure
+	case "PENDING", "EXPECTED", "QUEUED", "IN_PROGRESS", "WAITING", "REQUESTED":
+		return internal.CheckStatusPending
+	}
+	return internal.CheckStatusNone
+}
+
+// reviewThreadFrom converts a review thread; threads without a comment report false.
+func reviewThreadFrom(n reviewThreadNode) (internal.ReviewThread, bool) {
+	if len(n.Comments.Nodes) == 0 {
+		return internal.ReviewThread{}, false
+	}
+	line := n.Line
+	if line == 0 {
+		line = n.OriginalLine
+	}
+	first := n.Comments.Nodes[0]
+	return internal.ReviewThread{
+		Path:     n.Path,
+		Line:     line,
+		Resolved: n.IsResolved,
+		Outdated: n.IsOutdated,
+		Author:   first.Author.Login,
+		Body:     first.Body,
+		Replies:  max(n.Comments.TotalCount-1, 0),
+	}, true
+}
@@ -0,0 +1,49 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+func TestCheckRunFrom(t *testing.T) {
+	var run checkContextNode
+	run.Typename = "CheckRun"
+	run.CheckRun.Name = "build"
+	run.CheckRun.Status = "COMPLETED"
+	run.CheckRun.Conclusion = "TIMED_OUT"
+	if c := checkRunFrom(run); c.Name != "build" || c.Status != internal.CheckStatusFailure || c.State != "timed_out" {
+		t.Fatalf("completed run = %+v", c)
+	}
+	run.CheckRun.Conclusion = ""
+	run.CheckRun.Status = "IN_PROGRESS"
+	if c := checkRunFrom(run); c.Status != internal.CheckStatusPending || c.State != "in_progress" {
+		t.Fatalf("running check = %+v", c)
+	}
+
+	var status checkContextNode
+	status.Typename = "StatusContext"
+	status.StatusContext.Context = "ci/deploy"
+	status.StatusContext.State = "SUCCESS"
+	status.StatusContext.TargetUrl = "https://ci.example/1"
+	if c := checkRunFrom(status); c.Name != "ci/deploy" || c.Status != internal.CheckStatusSuccess || c.URL != "https://ci.example/1" {
+		t.Fatalf("commit status = %+v", c)
+	}
+}
+
+func TestReviewThreadFrom(t *testing.T) {
+	var n reviewThreadNode
+	if _, ok := reviewThreadFrom(n); ok {
+		t.Fatal("a thread without comments should be skipped")
+	}
+	n.Path, n.OriginalLine, n.IsOutdated = "main.go", 7, true
+	n.Comments.TotalCount = 3
+	n.Comments.Nodes = append(n.Comments.Nodes, struct {
+		Author struct{ Login string }
+		Body   string
+	}{Author: struct{ Login string }{"alice"}, Body: "rename this"})
+	th, ok := reviewThreadFrom(n)
+	if !ok || th.Line != 7 || !th.Outdated || th.Author != "alice" || th.Replies != 2 {
+		t.Fatalf("thread = %+v, %v", th, ok)
+	}
+}
@@ -0,0 +1,25 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// GetPullRequestDiff returns the git diff of a pull request as GitHub shows it (head against the
+// merge base with base). GitHub refuses very large diffs; callers can fall back to jj.
+func (s *Service) GetPullRequestDiff(ctx context.Context, prNumber int) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("github service unavailable")
+	}
+	owner, repo := s.prRepo()
+	diff, resp, err := s.client.PullRequests.GetRaw(ctx, owner, repo, prNumber, github.RawOptions{Type: github.Diff})
+	if err != nil {
+		if resp != nil && (resp.StatusCode == 401 || resp.StatusCode == 403) {
+			return "", NewAuthError(fmt.Errorf("failed to get PR diff: %w", err), resp.StatusCode)
+		}
+		return "", fmt.Errorf("failed to get diff of PR #%d: %w", prNumber, err)
+	}
+	return diff, nil
+}
@@ -0,0 +1,83 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/madicen/jj-tui/internal"
+)
+
+// GetReviewComments lists the review threads of a pull request that point at a file line, oldest
+// first, as their first comment. File-level comments without a line are left out.
+func (s *Service) GetReviewComments(ctx context.Context, prNumber int) ([]internal.ReviewComment, error) {
+	if s == nil {
+		return nil, fmt.Errorf("github service unavailable")
+	}
+	owner, repo := s.prRepo()
+	opts := &github.PullRequestListCommentsOptions{
+		Sort:        "created",
+		Direction:   "asc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var out []internal.ReviewComment
+	for {
+		comments, resp, err := s.client.PullRequests.ListComments(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			if resp != nil && (resp.StatusCode == 401 || resp.StatusCode == 403) {
+				return nil, NewAuthError(fmt.Errorf("failed to list review comments: %w", err), resp.StatusCode)
+			}
+			return nil, fmt.Errorf("failed to list review comments for PR #%d: %w", prNumber, err)
+		}
+		for _, c := range comments {
+			if rc, ok := reviewCommentFrom(c); ok {
+				out = append(out, rc)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return out, nil
+}
+
+// reviewCommentFrom converts the first comment of a line-level review thread; replies and
+// file-level comments report false.
+func reviewCommentFrom(c *github.PullRequestComment) (internal.ReviewComment, bool) {
+	if c.InReplyTo != nil || c.GetPath() == "" {
+		return internal.ReviewComment{}, false
+	}
+	rc := internal.ReviewComment{
+		ID:     c.GetID(),
+		Path:   c.GetPath(),
+		Line:   c.GetLine(),
+		Author: c.GetUser().GetLogin(),
+		Body:   c.GetBody(),
+		URL:    c.GetHTMLURL(),
+	}
+	if rc.Line == 0 {
+		rc.Line = c.GetOriginalLine()
+		rc.Outdated = true
+	}
+	if rc.Line == 0 {
+		return internal.ReviewComment{}, false
+	}
+	return rc, true
+}
+
+// ReplyToReviewComment posts body as a reply in the review thread started by commentID.
+func (s *Service) ReplyToReviewComment(ctx context.Context, prNumber int, commentID int64, body string) error {
+	if s == nil {
+		return fmt.Errorf("github service unavailable")
+	}
+	owner, repo := s.prRepo()
+	_, resp, err := s.client.PullRequests.CreateCommentInReplyTo(ctx, owner, repo, prNumber, body, commentID)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == 401 || resp.StatusCode == 403) {
+			return NewAuthError(fmt.Errorf("failed to reply to review comment: %w", err), resp.StatusCode)
+		}
+		return fmt.Errorf("failed to reply on PR #%d: %s", prNumber, summarize422(err))
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+)
+
+func TestReviewCommentFrom(t *testing.T) {
+	login := &github.User{Login: github.String("alice")}
+	rc, ok := reviewCommentFrom(&github.PullRequestComment{ID: github.Int64(7), Path: github.String("main.go"), Line: github.Int(12), User: login, Body: github.String("wrap this")})
+	if !ok || rc.Path != "main.go" || rc.Line != 12 || rc.Outdated || rc.Author != "alice" {
+		t.Fatalf("line comment = %+v, %v", rc, ok)
+	}
+	rc, ok = reviewCommentFrom(&github.PullRequestComment{Path: github.String("main.go"), OriginalLine: github.Int(9)})
+	if !ok || rc.Line != 9 || !rc.Outdated {
+		t.Fatalf("outdated comment = %+v, %v", rc, ok)
+	}
+	if _, ok := reviewCommentFrom(&github.PullRequestComment{Path: github.String("main.go"), Line: github.Int(3), InReplyTo: github.Int64(7)}); ok {
+		t.Error("replies should be skipped")
+	}
+	if _, ok := reviewCommentFrom(&github.PullRequestComment{Path: github.String("main.go")}); ok {
+		t.Error("file-level comments should be skipped")
+	}
+}
@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/madicen/jj-tui/internal/forge"
+)
+
+// Review events accepted by SubmitReview.
+const (
+	ReviewComment        = forge.ReviewComment
+	ReviewApprove        = forge.ReviewApprove
+	ReviewRequestChanges = forge.ReviewRequestChanges
+)
+
+// SubmitReview submits a review on a pull request: event is one of ReviewComment, ReviewApprove
+// or ReviewRequestChanges. GitHub requires a body for comments and change requests.
+func (s *Service) SubmitReview(ctx context.Context, prNumber int, event, body string) error {
+	if s == nil {
+		return fmt.Errorf("github service unavailable")
+	}
+	review := &github.PullRequestReviewRequest{Event: github.String(event)}
+	if body != "" {
+		review.Body = github.String(body)
+	}
+	owner, repo := s.prRepo()
+	_, resp, err := s.client.PullRequests.CreateReview(ctx, owner, repo, prNumber, review)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == 401 || resp.StatusCode == 403) {
+			return NewAuthError(fmt.Errorf("failed to submit review: %w", err), resp.StatusCode)
+		}
+		return fmt.Errorf("failed to submit review on PR #%d: %s", prNumber, summarize422(err))
+	}
+	return nil
+}
+
+// RequestReviewers asks users (logins) and teams (slugs) to review a pull request the user just
+// opened; toUpstream must match how the PR was created.
+func (s *Service) RequestReviewers(ctx context.Context, prNumber int, users, teams []string, toUpstream bool) error {
+	if len(users) == 0 && len(teams) == 0 {
+		return nil
+	}
+	if s == nil {
+		return fmt.Errorf("github service unavailable")
+	}
+	owner, repo, _ := s.createRepo(toUpstream, "")
+	_, resp, err := s.client.PullRequests.RequestReviewers(ctx, owner, repo, prNumber, github.ReviewersRequest{Reviewers: users, TeamReviewers: teams})
+	if err != nil {
+		if resp != nil && (resp.StatusCode == 401 || resp.StatusCode == 403) {
+			return NewAuthError(fmt.Errorf("failed to request reviewers: %w", err), resp.StatusCode)
+		}
+		return fmt.Errorf("failed to request reviewers on PR #%d: %s", prNumber, summarize422(err))
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/google/go-github/v66/github"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/forge"
 	"github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
 )
@@ -68,12 +69,7 @@ func NewAuthError(err error, statusCode int) *AuthError {
 }
 
 // PRFilterOptions contains options for filtering PRs
-type PRFilterOptions struct {
-	OnlyMine   bool // Only show PRs created by the authenticated user
-	Limit      int  // Maximum number of PRs to fetch (0 = no limit)
-	ShowMerged bool // Include merged PRs
-	ShowClosed bool // Include closed PRs
-}
+type PRFilterOptions = forge.PRFilterOptions
 
 // Service handles GitHub API interactions
 type Service struct {
@@ -87,13 +83,22 @@ type Service struct {
 	// re-hit the API). Empty defaultBranch means "not fetched yet" — callers should fall back
 	// to a sensible default (usually "main") if a fetch fails.
 	defaultBranch string
+	// Fork support (see fork.go): upstream is origin's parent when origin is a fork, and
+	// prsOnUpstream sends PR list/update/merge/close calls there instead of to origin.
+	upstream      *Upstream
+	prsOnUpstream bool
 }
 
+var _ forge.Service = (*Service)(nil)
+
+// Name returns the forge's display name.
+func (s *Service) Name() string { return "GitHub" }
+
 // CreatePullRequest creates a new pull request
 func (s *Service) CreatePullRequest(ctx context.Context, req *internal.CreatePRRequest) (*internal.GitHubPR, error) {
 	// For same-repo PRs, head can be just the branch name
 	// But some GitHub configurations require owner:branch format
-	headRef := req.HeadBranch
+	owner, repo, headRef := s.createRepo(req.ToUpstream, req.HeadBranch)
 
 	newPR := &github.NewPullRequest{
 		Title:               github.String(req.Title),
@@ -104,7 +109,7 @@ func (s *Service) CreatePullRequest(ctx context.Context, req *internal.CreatePRR
 		Draft:               github.Bool(req.Draft),
 	}
 
-	pr, resp, err := s.client.PullRequests.Create(ctx, s.owner, s.repo, newPR)
+	pr, resp, err := s.client.PullRequests.Create(ctx, owner, repo, newPR)
 	if err != nil {
 		// Decode 422 validation errors into a single user-friendly string so callers can
 		// distinguish base- vs head-related failures. Without this, the only signal is the
@@ -115,9 +120,9 @@ func (s *Service) CreatePullRequest(ctx context.Context, req *internal.CreatePRR
 			detail := summarize422(err)
 			// Only retry the head-ref/owner-prefix dance for head-related issues — retrying
 			// a missing-or-invalid base branch will just 422 again every time.
-			if isHeadRefRetryable(err, detail) {
+			if isHeadRefRetryable(err, detail) && headRef == req.HeadBranch {
 				newPR.Head = github.String(s.owner + ":" + req.HeadBranch)
-				pr, _, err = s.client.PullRequests.Create(ctx, s.owner, s.repo, newPR)
+				pr, _, err = s.client.PullRequests.Create(ctx, owner, repo, newPR)
 				if err != nil {
 					return nil, fmt.Errorf("failed to create pull request (tried both head formats): %s", summarize422(err))
 				}
@@ -136,6 +141,7 @@ func (s *Service) CreatePullRequest(ctx context.Context, req *internal.CreatePRR
 		State:      pr.GetState(),
 		BaseBranch: pr.GetBase().GetRef(),
 		HeadBranch: pr.GetHead().GetRef(),
+		HeadSHA:    pr.GetHead().GetSHA(),
 		CommitIDs:  req.CommitIDs,
 	}, nil
 }
@@ -228,7 +234,8 @@ func (s *Service) UpdatePullRequest(ctx context.Context, prNumber int, req *inte
 		updatePR.Body = github.String(req.Body)
 	}
 
-	pr, _, err := s.client.PullRequests.Edit(ctx, s.owner, s.repo, prNumber, updatePR)
+	owner, repo := s.prRepo()
+	pr, _, err := s.client.PullRequests.Edit(ctx, owner, repo, prNumber, updatePR)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update pull request: %w", err)
 	}
@@ -240,18 +247,16 @@ func (s *Service) UpdatePullRequest(ctx context.Context, prNumber int, req *inte
 		State:      pr.GetState(),
 		BaseBranch: pr.GetBase().GetRef(),
 		HeadBranch: pr.GetHead().GetRef(),
+		HeadSHA:    pr.GetHead().GetSHA(),
 		CommitIDs:  req.CommitIDs,
 	}, nil
 }
 
-// MergePullRequest merges a pull request using the default merge method
-func (s *Service) MergePullRequest(ctx context.Context, prNumber int) error {
-	// Use default merge commit method
-	options := &github.PullRequestOptions{
-		MergeMethod: "merge",
-	}
-
-	_, _, err := s.client.PullRequests.Merge(ctx, s.owner, s.repo, prNumber, "", options)
+// MergePullRequest merges a pull request with the method and commit message in opts.
+func (s *Service) MergePullRequest(ctx context.Context, prNumber int, opts MergeOptions) error {
+	options, message := mergeRequestOptions(opts)
+	owner, repo := s.prRepo()
+	_, _, err := s.client.PullRequests.Merge(ctx, owner, repo, prNumber, message, options)
 	if err != nil {
 		if errResp, ok := err.(*github.ErrorResponse); ok {
 			// If the error is a GitHub API error, read the body for more context.
@@ -275,7 +280,8 @@ func (s *Service) ClosePullRequest(ctx context.Context, prNumber int) error {
 		State: github.String("closed"),
 	}
 
-	_, _, err := s.client.PullRequests.Edit(ctx, s.owner, s.repo, prNumber, updatePR)
+	owner, repo := s.prRepo()
+	_, _, err := s.client.PullRequests.Edit(ctx, owner, repo, prNumber, updatePR)
 	if err != nil {
 		return fmt.Errorf("failed to close pull request: %w", err)
 	}
@@ -407,18 +413,23 @@ func (s *Service) queryPullRequestsGraphQL(ctx context.Context, states []githubv
 					EndCursor   githubv4.String
 				}
 				Nodes []struct {
-					Number      int
-					Title       string
-					Body        string
-					Url         string
-					State       string
-					BaseRefName string
-					HeadRefName string
-					Merged      bool
-					IsDraft     bool
-					Author      struct {
+					Number              int
+					Title               string
+					Body                string
+					Url                 string
+					State               string
+					BaseRefName         string
+					HeadRefName         string
+					HeadRefOid          string
+					HeadRepositoryOwner struct {
 						Login string
 					}
+					Merged  bool
+					IsDraft bool
+					Author  struct {
+						Login     string
+						AvatarUrl string
+					}
 					Commits struct {
 						Nodes []struct {
 							Commit struct {
@@ -447,9 +458,10 @@ func (s *Service) queryPullRequestsGraphQL(ctx context.Context, states []githubv
 		first = limit
 	}
 
+	owner, repo := s.prRepo()
 	variables := map[string]any{
-		"owner":  githubv4.String(s.owner),
-		"name":   githubv4.String(s.repo),
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repo),
 		"first":  githubv4.Int(first),
 		"after":  (*githubv4.String)(nil),
 		"states": states,
@@ -498,10 +510,13 @@ func (s *Service) queryPullRequestsGraphQL(ctx context.Context, states []githubv
 				URL:          pr.Url,
 				State:        state,
 				BaseBranch:   pr.BaseRefName,
-				HeadBranch:   pr.HeadRefName,
+				HeadBranch:   s.headName(pr.HeadRepositoryOwner.Login, pr.HeadRefName),
+				HeadSHA:      pr.HeadRefOid,
 				CheckStatus:  checkStatus,
 				ReviewStatus: reviewStatus,
 				IsDraft:      pr.IsDraft,
+				Author:       pr.Author.Login,
+				AuthorAvatar: pr.Author.AvatarUrl,
 			})
 
 			// Check limit
@@ -589,11 +604,12 @@ func (s *Service) queryPullRequestsREST(ctx context.Context, stateFilter string,
 		},
 	}
 
+	owner, repo := s.prRepo()
 	for {
-		prs, resp, err := s.client.PullRequests.List(ctx, s.owner, s.repo, opts)
+		prs, resp, err := s.client.PullRequests.List(ctx, owner, repo, opts)
 		if err != nil {
 			if resp != nil && resp.StatusCode == http.StatusNotFound {
-				return nil, fmt.Errorf("failed to list pull requests for %s/%s: not found or access denied (GitHub returns 404 when the repository does not exist or the token cannot read it; for private org repos approve the OAuth app for the organization and complete SSO authorization if required): %w", s.owner, s.repo, err)
+				return nil, fmt.Errorf("failed to list pull requests for %s/%s: not found or access denied (GitHub returns 404 when the repository does not exist or the token cannot read it; for private org repos approve the OAuth app for the organization and complete SSO authorization if required): %w", owner, repo, err)
 			}
 			return nil, fmt.Errorf("failed to list pull requests: %w", err)
 		}
@@ -623,10 +639,13 @@ func (s *Service) queryPullRequestsREST(ctx context.Context, stateFilter string,
 				URL:          pr.GetHTMLURL(),
 				State:        state,
 				BaseBranch:   pr.GetBase().GetRef(),
-				HeadBranch:   pr.GetHead().GetRef(),
+				HeadBranch:   s.headName(pr.GetHead().GetRepo().GetOwner().GetLogin(), pr.GetHead().GetRef()),
+				HeadSHA:      pr.GetHead().GetSHA(),
 				CheckStatus:  internal.CheckStatusNone,  // Not available with REST fallback
 				ReviewStatus: internal.ReviewStatusNone, // Not available with REST fallback
 				IsDraft:      pr.GetDraft(),
+				Author:       pr.GetUser().GetLogin(),
+				AuthorAvatar: pr.GetUser().GetAvatarURL(),
 			})
 
 			// Check limit
@@ -706,7 +725,9 @@ func (s *Service) GetOpenPRForBranch(ctx context.Context, branch string) (*inter
 	if branch == "" {
 		return nil, nil
 	}
-	if s.graphqlClient != nil {
+	// The GraphQL headRefName filter can't tell forks apart, so upstream lookups use REST's
+	// "owner:branch" head filter.
+	if s.graphqlClient != nil && !s.prsOnUpstream {
 		pr, err := s.getOpenPRForBranchGraphQL(ctx, branch)
 		if err == nil {
 			return pr, nil
@@ -735,6 +756,7 @@ func (s *Service) getOpenPRForBranchGraphQL(ctx context.Context, branch string)
 					State       string
 					BaseRefName string
 					HeadRefName string
+					HeadRefOid  string
 					Commits     struct {
 						Nodes []struct {
 							Commit struct {
@@ -787,6 +809,7 @@ func (s *Service) getOpenPRForBranchGraphQL(ctx context.Context, branch string)
 		State:        strings.ToLower(pr.State),
 		BaseBranch:   pr.BaseRefName,
 		HeadBranch:   pr.HeadRefName,
+		HeadSHA:      pr.HeadRefOid,
 		CheckStatus:  checkStatus,
 		ReviewStatus: parseReviewStatus(pr.Reviews.Nodes),
 	}, nil
@@ -798,7 +821,8 @@ func (s *Service) getOpenPRForBranchREST(ctx context.Context, branch string) (*i
 		Head:        s.owner + ":" + branch,
 		ListOptions: github.ListOptions{PerPage: 1},
 	}
-	prs, resp, err := s.client.PullRequests.List(ctx, s.owner, s.repo, opts)
+	owner, repo := s.prRepo()
+	prs, resp, err := s.client.PullRequests.List(ctx, owner, repo, opts)
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return nil, nil
@@ -817,6 +841,7 @@ func (s *Service) getOpenPRForBranchREST(ctx context.Context, branch string) (*i
 		State:        pr.GetState(),
 		BaseBranch:   pr.GetBase().GetRef(),
 		HeadBranch:   pr.GetHead().GetRef(),
+		HeadSHA:      pr.GetHead().GetSHA(),
 		CheckStatus:  internal.CheckStatusNone,
 		ReviewStatus: internal.ReviewStatusNone,
 	}, nil
@@ -824,7 +849,8 @@ func (s *Service) getOpenPRForBranchREST(ctx context.Context, branch string) (*i
 
 // GetPullRequest retrieves a specific pull request
 func (s *Service) GetPullRequest(ctx context.Context, prNumber int) (*internal.GitHubPR, error) {
-	pr, _, err := s.client.PullRequests.Get(ctx, s.owner, s.repo, prNumber)
+	owner, repo := s.prRepo()
+	pr, _, err := s.client.PullRequests.Get(ctx, owner, repo, prNumber)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pull request: %w", err)
 	}
@@ -842,6 +868,8 @@ func (s *Service) GetPullRequest(ctx context.Context, prNumber int) (*internal.G
 		State:      pr.GetState(),
 		BaseBranch: pr.GetBase().GetRef(),
 		HeadBranch: pr.GetHead().GetRef(),
+		HeadSHA:    pr.GetHead().GetSHA(),
+		Body:       pr.GetBody(),
 		CommitIDs:  commits,
 	}, nil
 }
@@ -850,9 +878,10 @@ func (s *Service) GetPullRequest(ctx context.Context, prNumber int) (*internal.G
 func (s *Service) getPullRequestCommits(ctx context.Context, prNumber int) ([]string, error) {
 	opts := &github.ListOptions{PerPage: 100}
 
+	owner, repo := s.prRepo()
 	var commitIDs []string
 	for {
-		commits, resp, err := s.client.PullRequests.ListCommits(ctx, s.owner, s.repo, prNumber, opts)
+		commits, resp, err := s.client.PullRequests.ListCommits(ctx, owner, repo, prNumber, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -872,17 +901,146 @@ func (s *Service) getPullRequestCommits(ctx context.Context, prNumber int) ([]st
 
 // BranchExists checks if a branch exists on GitHub
 func (s *Service) BranchExists(ctx context.Context, branch string) (bool, error) {
-	_, resp, err := s.client.Repositories.GetBranch(ctx, s.owner, s.repo, branch, 0)
+	return s.branchExists(ctx, s.owner, s.repo, branch)
+}
+
+func (s *Service) branchExists(ctx context.Context, owner, repo, branch string) (bool, error) {
+	_, resp, err := s.client.Repositories.GetBranch(ctx, owner, repo, branch, 0)
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			return false, nil
 		}
 		// Log more details for debugging
-		return false, fmt.Errorf("branch check failed for %s/%s branch %s: %w", s.owner, s.repo, branch, err)
+		return false, fmt.Errorf("branch check failed for %s/%s branch %s: %w", owner, repo, branch, err)
 	}
 	return true, nil
 }
 
+// maxDeploymentsPerRef caps how many deployments GetDeployments inspects for one ref. Each one
+// costs a statuses round-trip, and only the newest per environment is shown.
+const maxDeploymentsPerRef = 20
+
+// GetDeployments returns the latest deployment status per environment for ref, newest first.
+// ref may be a branch/tag name or a full commit SHA (40 hex chars), which is matched against the
+// deployment's sha instead of the ref it was created with. Deployments with no status yet are
+// reported as "pending".
+func (s *Service) GetDeployments(ctx context.Context, ref string) ([]internal.Deployment, error) {
+	if s == nil {
+		return nil, fmt.Errorf("github service unavailable")
+	}
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, nil
+	}
+	opts := &github.DeploymentsListOptions{ListOptions: github.ListOptions{PerPage: maxDeploymentsPerRef}}
+	if isFullSHA(ref) {
+		opts.SHA = ref
+	} else {
+		opts.Ref = ref
+	}
+	deployments, resp, err := s.client.Repositories.ListDeployments(ctx, s.owner, s.repo, opts)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == 401 || resp.StatusCode == 403) {
+			return nil, NewAuthError(fmt.Errorf("failed to list deployments: %w", err), resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to list deployments for %s: %w", ref, err)
+	}
+
+	var result []internal.Deployment
+	seen := make(map[string]bool)
+	for _, d := range deployments {
+		env := d.GetEnvironment()
+		if seen[env] {
+			// The API lists newest first; an older deployment to the same environment is stale.
+			continue
+		}
+		seen[env] = true
+		dep := internal.Deployment{
+			Environment: env,
+			State:       "pending",
+			Ref:         d.GetRef(),
+			SHA:         d.GetSHA(),
+			UpdatedAt:   d.GetUpdatedAt().Time,
+		}
+		statuses, _, err := s.client.Repositories.ListDeploymentStatuses(ctx, s.owner, s.repo, d.GetID(), &github.ListOptions{PerPage: 1})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status for deployment %d: %w", d.GetID(), err)
+		}
+		if len(statuses) > 0 {
+			st := statuses[0]
+			dep.State = st.GetState()
+			dep.URL = st.GetEnvironmentURL()
+			if dep.URL == "" {
+				dep.URL = st.GetLogURL()
+			}
+			if t := st.GetUpdatedAt().Time; !t.IsZero() {
+				dep.UpdatedAt = t
+			}
+		}
+		result = append(result, dep)
+	}
+	return result, nil
+}
+
+// isFullSHA reports whether s looks like a full 40-character hex commit SHA.
+func isFullSHA(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxPullRequestsPerCommit caps how many PRs PullRequestsForCommit asks for; a commit is usually
+// merged by one PR, occasionally cherry-picked into a few release branches.
+const maxPullRequestsPerCommit = 10
+
+// PullRequestsForCommit returns the merged pull requests that brought sha into the repository,
+// for history views of immutable commits. Open and closed-unmerged PRs that merely contain the
+// commit are left out.
+func (s *Service) PullRequestsForCommit(ctx context.Context, sha string) ([]internal.GitHubPR, error) {
+	if s == nil {
+		return nil, fmt.Errorf("github service unavailable")
+	}
+	sha = strings.TrimSpace(sha)
+	if sha == "" {
+		return nil, nil
+	}
+	owner, repo := s.prRepo()
+	prs, resp, err := s.client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, sha, &github.ListOptions{PerPage: maxPullRequestsPerCommit})
+	if err != nil {
+		if resp != nil && (resp.StatusCode == 401 || resp.StatusCode == 403) {
+			return nil, NewAuthError(fmt.Errorf("failed to list pull requests for commit: %w", err), resp.StatusCode)
+		}
+		if resp != nil && resp.StatusCode == 422 {
+			// GitHub doesn't know the commit (never pushed here); nothing merged it.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list pull requests for %s: %w", sha, err)
+	}
+	var result []internal.GitHubPR
+	for _, pr := range prs {
+		if pr.MergedAt == nil {
+			continue
+		}
+		result = append(result, internal.GitHubPR{
+			Number:     pr.GetNumber(),
+			Title:      pr.GetTitle(),
+			URL:        pr.GetHTMLURL(),
+			State:      "merged",
+			BaseBranch: pr.GetBase().GetRef(),
+			HeadBranch: pr.GetHead().GetRef(),
+			HeadSHA:    pr.GetHead().GetSHA(),
+			Author:     pr.GetUser().GetLogin(),
+		})
+	}
+	return result, nil
+}
+
 // ParseGitHubURL extracts owner and repo from a GitHub URL
 func ParseGitHubURL(remoteURL string) (owner, repo string, err error) {
 	// Handle various GitHub URL formats
@@ -240,6 +240,128 @@ func TestGetDefaultBranch_NilService(t *testing.T) {
 	}
 }
 
+// --- GetDeployments --------------------------------------------------------------------------
+
+// TestGetDeployments_LatestPerEnvironment verifies only the newest deployment per environment
+// is reported, with its latest status and environment URL, and that a deployment with no status
+// yet shows as pending. An older staging deployment listed second must not shadow the newer one.
+func TestGetDeployments_LatestPerEnvironment(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/owner/repo/deployments":
+			if got := r.URL.Query().Get("ref"); got != "feature" {
+				t.Errorf("ref query = %q, want %q", got, "feature")
+			}
+			fmt.Fprint(w, `[
+				{"id":3,"environment":"staging","ref":"feature","sha":"abc"},
+				{"id":2,"environment":"production","ref":"feature","sha":"abc"},
+				{"id":1,"environment":"staging","ref":"feature","sha":"old"}
+			]`)
+		case "/repos/owner/repo/deployments/3/statuses":
+			fmt.Fprint(w, `[{"state":"success","environment_url":"https://staging.example.com"}]`)
+		case "/repos/owner/repo/deployments/2/statuses":
+			fmt.Fprint(w, `[]`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc := newTestServiceWithBaseURL(t, "owner", "repo", server.URL)
+	got, err := svc.GetDeployments(context.Background(), "feature")
+	if err != nil {
+		t.Fatalf("GetDeployments err = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("GetDeployments returned %d deployments, want 2: %+v", len(got), got)
+	}
+	if got[0].Environment != "staging" || got[0].State != "success" || got[0].URL != "https://staging.example.com" || got[0].SHA != "abc" {
+		t.Errorf("staging = %+v, want newest success deployment with environment URL", got[0])
+	}
+	if got[1].Environment != "production" || got[1].State != "pending" {
+		t.Errorf("production = %+v, want pending (no statuses)", got[1])
+	}
+}
+
+// TestGetDeployments_SHAQuery verifies a full commit SHA is sent as the sha filter rather than
+// ref: deployments created by CI for a trunk commit usually carry the branch name as ref, so a
+// ref match on a SHA would miss them.
+func TestGetDeployments_SHAQuery(t *testing.T) {
+	t.Parallel()
+	sha := strings.Repeat("a1", 20)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("sha") != sha || q.Get("ref") != "" {
+			t.Errorf("query = %q, want sha=%s and no ref", r.URL.RawQuery, sha)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	svc := newTestServiceWithBaseURL(t, "owner", "repo", server.URL)
+	got, err := svc.GetDeployments(context.Background(), sha)
+	if err != nil {
+		t.Fatalf("GetDeployments err = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("GetDeployments = %+v, want none", got)
+	}
+}
+
+// --- PullRequestsForCommit ----------------------------------------------------------------------
+
+// TestPullRequestsForCommit_MergedOnly verifies only PRs that actually merged the commit are
+// returned; an open PR that also contains it (e.g. a stacked branch) is not "the PR that merged it".
+func TestPullRequestsForCommit_MergedOnly(t *testing.T) {
+	t.Parallel()
+	sha := strings.Repeat("b2", 20)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/repos/owner/repo/commits/" + sha + "/pulls"; r.URL.Path != want {
+			t.Errorf("path = %q, want %q", r.URL.Path, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[
+			{"number":7,"title":"Add parser","html_url":"https://github.com/owner/repo/pull/7","state":"closed","merged_at":"2026-01-02T03:04:05Z","base":{"ref":"main"},"head":{"ref":"parser","sha":"`+sha+`"},"user":{"login":"octo"}},
+			{"number":9,"title":"Stacked follow-up","state":"open","base":{"ref":"parser"},"head":{"ref":"follow-up"}}
+		]`)
+	}))
+	defer server.Close()
+
+	svc := newTestServiceWithBaseURL(t, "owner", "repo", server.URL)
+	got, err := svc.PullRequestsForCommit(context.Background(), sha)
+	if err != nil {
+		t.Fatalf("PullRequestsForCommit err = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("PullRequestsForCommit returned %d PRs, want 1: %+v", len(got), got)
+	}
+	if got[0].Number != 7 || got[0].State != "merged" || got[0].BaseBranch != "main" || got[0].HeadSHA != sha || got[0].Author != "octo" {
+		t.Errorf("PR = %+v, want #7 at %s merged into main by octo", got[0], sha)
+	}
+}
+
+// TestPullRequestsForCommit_UnknownCommit verifies a commit GitHub has never seen (422) reads as
+// "no PRs" rather than an error, since local-only immutable commits are common.
+func TestPullRequestsForCommit_UnknownCommit(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"No commit found for SHA"}`)
+	}))
+	defer server.Close()
+
+	svc := newTestServiceWithBaseURL(t, "owner", "repo", server.URL)
+	got, err := svc.PullRequestsForCommit(context.Background(), strings.Repeat("c3", 20))
+	if err != nil || len(got) != 0 {
+		t.Errorf("PullRequestsForCommit = %+v, %v; want none, nil", got, err)
+	}
+}
+
 // --- helpers ----------------------------------------------------------------------------------
 
 // newTestServiceWithBaseURL wires a Service to point at a test HTTP server. Used by the
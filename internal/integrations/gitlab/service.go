@@ -0,0 +1,385 @@
+// Package gitlab implements forge.Service for GitLab merge requests over the REST API v4.
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/forge"
+)
+
+// DefaultBaseURL is the GitLab instance used when the remote isn't a configured self-managed one.
+const DefaultBaseURL = "https://gitlab.com"
+
+// Service handles GitLab API interactions for one project.
+type Service struct {
+	baseURL  string // instance root, e.g. https://gitlab.com (no trailing slash)
+	project  string // namespace path, e.g. group/subgroup/repo
+	token    string
+	client   *http.Client
+	username string // cached authenticated username
+}
+
+var (
+	_ forge.Service    = (*Service)(nil)
+	_ forge.AutoMerger = (*Service)(nil)
+)
+
+// NewService creates a GitLab service for project (its full namespace path) on the instance at
+// baseURL, authenticating with a personal, project or group access token.
+func NewService(baseURL, project, token string) (*Service, error) {
+	if token == "" {
+		return nil, fmt.Errorf("GitLab token is required")
+	}
+	if project == "" {
+		return nil, fmt.Errorf("GitLab project path is required")
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Service{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		project: project,
+		token:   token,
+		client:  &http.Client{},
+	}, nil
+}
+
+// Name returns the forge's display name.
+func (s *Service) Name() string { return "GitLab" }
+
+// Project returns the project's namespace path.
+func (s *Service) Project() string { return s.project }
+
+// ParseGitLabURL extracts the instance URL and project path from a git remote URL. gitlabURL is
+// the configured self-managed instance (may be empty); when the remote is on its host the
+// instance URL is taken from it, so installs under a path prefix work.
+func ParseGitLabURL(remoteURL, gitlabURL string) (baseURL, project string, err error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+	host := forge.RemoteHost(remoteURL)
+	if host == "" {
+		return "", "", fmt.Errorf("invalid GitLab URL: %s", remoteURL)
+	}
+	var path string
+	if strings.Contains(remoteURL, "://") {
+		u, perr := url.Parse(remoteURL)
+		if perr != nil {
+			return "", "", fmt.Errorf("invalid GitLab URL: %s", remoteURL)
+		}
+		path = u.Path
+	} else {
+		_, path, _ = strings.Cut(remoteURL, ":")
+	}
+	baseURL = "https://" + host
+	if u, perr := url.Parse(strings.TrimSpace(gitlabURL)); perr == nil && u.Host != "" && strings.EqualFold(u.Hostname(), host) {
+		baseURL = strings.TrimSuffix(u.String(), "/")
+		// HTTPS remotes of a relative-URL install repeat the prefix before the project path.
+		path = strings.TrimPrefix(path, u.Path)
+	}
+	project = strings.Trim(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+	if !strings.Contains(project, "/") {
+		return "", "", fmt.Errorf("invalid GitLab URL: %s", remoteURL)
+	}
+	return baseURL, project, nil
+}
+
+// mergeRequest is the subset of GitLab's merge request JSON the PR tab uses.
+type mergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	State        string `json:"state"` // opened, closed, locked, merged
+	WebURL       string `json:"web_url"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	Draft        bool   `json:"draft"`
+	SHA          string `json:"sha"`
+	Author       struct {
+		Username  string `json:"username"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"author"`
+}
+
+// toPR converts a merge request to the PR shape the UI shows. GitLab's "opened" and "locked" are
+// both open; merge requests are numbered by their project-scoped IID.
+func (mr mergeRequest) toPR() internal.GitHubPR {
+	state := mr.State
+	switch state {
+	case "opened", "locked":
+		state = "open"
+	}
+	pr := internal.GitHubPR{
+		Number:       mr.IID,
+		Title:        mr.Title,
+		Body:         mr.Description,
+		URL:          mr.WebURL,
+		State:        state,
+		BaseBranch:   mr.TargetBranch,
+		HeadBranch:   mr.SourceBranch,
+		HeadSHA:      mr.SHA,
+		CheckStatus:  internal.CheckStatusNone,
+		ReviewStatus: internal.ReviewStatusNone,
+		IsDraft:      mr.Draft,
+		Author:       mr.Author.Username,
+		AuthorAvatar: mr.Author.AvatarURL,
+	}
+	if mr.SHA != "" {
+		pr.CommitIDs = []string{mr.SHA}
+	}
+	return pr
+}
+
+// GetPullRequestsWithOptions lists the project's merge requests, newest first.
+func (s *Service) GetPullRequestsWithOptions(ctx context.Context, filterOpts forge.PRFilterOptions) ([]internal.GitHubPR, error) {
+	state := "all"
+	if !filterOpts.ShowMerged && !filterOpts.ShowClosed {
+		state = "opened"
+	}
+	query := url.Values{}
+	query.Set("state", state)
+	query.Set("order_by", "created_at")
+	query.Set("sort", "desc")
+	query.Set("per_page", "100")
+	if filterOpts.OnlyMine {
+		query.Set("scope", "created_by_me")
+	}
+
+	var prs []internal.GitHubPR
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+		var mrs []mergeRequest
+		resp, err := s.do(ctx, http.MethodGet, s.projectPath("/merge_requests?"+query.Encode()), nil, &mrs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list merge requests for %s: %w", s.project, err)
+		}
+		for _, mr := range mrs {
+			pr := mr.toPR()
+			if (pr.State == "merged" && !filterOpts.ShowMerged) || (pr.State == "closed" && !filterOpts.ShowClosed) {
+				continue
+			}
+			prs = append(prs, pr)
+			if filterOpts.Limit > 0 && len(prs) >= filterOpts.Limit {
+				return prs, nil
+			}
+		}
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return prs, nil
+}
+
+// GetOpenPRForBranch returns the open merge request from branch, or nil when there is none.
+func (s *Service) GetOpenPRForBranch(ctx context.Context, branch string) (*internal.GitHubPR, error) {
+	if branch == "" {
+		return nil, nil
+	}
+	query := url.Values{}
+	query.Set("state", "opened")
+	query.Set("source_branch", branch)
+	var mrs []mergeRequest
+	if _, err := s.do(ctx, http.MethodGet, s.projectPath("/merge_requests?"+query.Encode()), nil, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to look up merge request for %s: %w", branch, err)
+	}
+	if len(mrs) == 0 {
+		return nil, nil
+	}
+	pr := mrs[0].toPR()
+	return &pr, nil
+}
+
+// CreatePullRequest opens a merge request. Drafts are marked with GitLab's "Draft:" title prefix.
+func (s *Service) CreatePullRequest(ctx context.Context, req *internal.CreatePRRequest) (*internal.GitHubPR, error) {
+	title := req.Title
+	if req.Draft && !strings.HasPrefix(strings.ToLower(title), "draft:") {
+		title = "Draft: " + title
+	}
+	body := map[string]any{
+		"source_branch": req.HeadBranch,
+		"target_branch": req.BaseBranch,
+		"title":         title,
+		"description":   req.Body,
+	}
+	var mr mergeRequest
+	if _, err := s.do(ctx, http.MethodPost, s.projectPath("/merge_requests"), body, &mr); err != nil {
+		return nil, fmt.Errorf("failed to create merge request: %w", err)
+	}
+	pr := mr.toPR()
+	return &pr, nil
+}
+
+// MergePullRequest merges a merge request now. GitLab applies the project's merge method, so
+// only merge and squash can be requested; rebase is refused rather than silently ignored.
+func (s *Service) MergePullRequest(ctx context.Context, prNumber int, opts forge.MergeOptions) error {
+	body, err := mergeBody(opts)
+	if err != nil {
+		return err
+	}
+	if _, err := s.do(ctx, http.MethodPut, s.mergeRequestPath(prNumber, "/merge"), body, nil); err != nil {
+		return fmt.Errorf("failed to merge merge request !%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// EnableAutoMerge sets a merge request to merge once its pipeline succeeds.
+func (s *Service) EnableAutoMerge(ctx context.Context, prNumber int, opts forge.MergeOptions) error {
+	body, err := mergeBody(opts)
+	if err != nil {
+		return err
+	}
+	body["merge_when_pipeline_succeeds"] = true
+	if _, err := s.do(ctx, http.MethodPut, s.mergeRequestPath(prNumber, "/merge"), body, nil); err != nil {
+		return fmt.Errorf("failed to enable auto-merge on merge request !%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// mergeBody returns the merge endpoint's parameters for opts.
+func mergeBody(opts forge.MergeOptions) (map[string]any, error) {
+	message := opts.CommitTitle
+	if opts.CommitMessage != "" {
+		if message != "" {
+			message += "\n\n"
+		}
+		message += opts.CommitMessage
+	}
+	body := map[string]any{}
+	switch opts.Method {
+	case forge.MergeMethodSquash:
+		body["squash"] = true
+		if message != "" {
+			body["squash_commit_message"] = message
+		}
+	case forge.MergeMethodMerge, "":
+		if message != "" {
+			body["merge_commit_message"] = message
+		}
+	default:
+		return nil, fmt.Errorf("GitLab can't %s-merge from jj-tui; the project's merge method applies, so pick merge or squash", opts.Method)
+	}
+	return body, nil
+}
+
+// ClosePullRequest closes a merge request without merging.
+func (s *Service) ClosePullRequest(ctx context.Context, prNumber int) error {
+	body := map[string]any{"state_event": "close"}
+	if _, err := s.do(ctx, http.MethodPut, s.mergeRequestPath(prNumber, ""), body, nil); err != nil {
+		return fmt.Errorf("failed to close merge request !%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// SubmitReview approves a merge request or comments on it. GitLab has no "request changes"
+// review, so that event (like a comment) is posted as a note; an approval's body, if any, is
+// posted as a note too.
+func (s *Service) SubmitReview(ctx context.Context, prNumber int, event, body string) error {
+	if event == forge.ReviewApprove {
+		if _, err := s.do(ctx, http.MethodPost, s.mergeRequestPath(prNumber, "/approve"), nil, nil); err != nil {
+			return fmt.Errorf("failed to approve merge request !%d: %w", prNumber, err)
+		}
+	}
+	if strings.TrimSpace(body) == "" {
+		if event == forge.ReviewApprove {
+			return nil
+		}
+		return fmt.Errorf("a comment is required")
+	}
+	note := map[string]any{"body": body}
+	if _, err := s.do(ctx, http.MethodPost, s.mergeRequestPath(prNumber, "/notes"), note, nil); err != nil {
+		return fmt.Errorf("failed to comment on merge request !%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// GetAuthenticatedUsername returns the username the token belongs to.
+func (s *Service) GetAuthenticatedUsername(ctx context.Context) (string, error) {
+	if s.username != "" {
+		return s.username, nil
+	}
+	var user struct {
+		Username string `json:"username"`
+	}
+	if _, err := s.do(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+		return "", fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+	s.username = user.Username
+	return s.username, nil
+}
+
+// projectPath returns the API path of a project sub-resource. The project is addressed by its
+// URL-encoded namespace path.
+func (s *Service) projectPath(suffix string) string {
+	return "/projects/" + url.PathEscape(s.project) + suffix
+}
+
+func (s *Service) mergeRequestPath(iid int, suffix string) string {
+	return s.projectPath("/merge_requests/" + strconv.Itoa(iid) + suffix)
+}
+
+// do sends an API request with an optional JSON body and decodes a JSON response into out
+// (when non-nil). Non-2xx responses become errors carrying GitLab's message.
+func (s *Service) do(ctx context.Context, method, path string, body, out any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+"/api/v4"+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", s.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, apiError(resp.StatusCode, data)
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// apiError turns an error response into an error, preferring GitLab's "message" or "error" field
+// over the raw body.
+func apiError(status int, data []byte) error {
+	var payload struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+	detail := strings.TrimSpace(string(data))
+	if json.Unmarshal(data, &payload) == nil {
+		switch {
+		case payload.Message != nil:
+			detail = fmt.Sprint(payload.Message)
+		case payload.Error != "":
+			detail = payload.Error
+		}
+	}
+	if status == http.StatusUnauthorized {
+		return fmt.Errorf("GitLab authentication failed (status %d): %s", status, detail)
+	}
+	return fmt.Errorf("status %d: %s", status, detail)
+}
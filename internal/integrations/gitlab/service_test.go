@@ -0,0 +1,162 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/forge"
+)
+
+func TestParseGitLabURL(t *testing.T) {
+	tests := []struct {
+		remote, gitlabURL  string
+		wantBase, wantProj string
+		wantErr            bool
+	}{
+		{remote: "git@gitlab.com:group/repo.git", wantBase: "https://gitlab.com", wantProj: "group/repo"},
+		{remote: "https://gitlab.com/group/sub/repo.git", wantBase: "https://gitlab.com", wantProj: "group/sub/repo"},
+		{remote: "ssh://git@gitlab.example.com:2222/team/repo.git", wantBase: "https://gitlab.example.com", wantProj: "team/repo"},
+		{remote: "https://git.corp.io/gitlab/team/repo.git", gitlabURL: "https://git.corp.io/gitlab/", wantBase: "https://git.corp.io/gitlab", wantProj: "team/repo"},
+		{remote: "git@git.corp.io:team/repo.git", gitlabURL: "http://git.corp.io", wantBase: "http://git.corp.io", wantProj: "team/repo"},
+		{remote: "git@gitlab.com:repo.git", wantErr: true},
+		{remote: "not a url", wantErr: true},
+	}
+	for _, tt := range tests {
+		base, proj, err := ParseGitLabURL(tt.remote, tt.gitlabURL)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseGitLabURL(%q) = %q, %q; want error", tt.remote, base, proj)
+			}
+			continue
+		}
+		if err != nil || base != tt.wantBase || proj != tt.wantProj {
+			t.Errorf("ParseGitLabURL(%q, %q) = %q, %q, %v; want %q, %q", tt.remote, tt.gitlabURL, base, proj, err, tt.wantBase, tt.wantProj)
+		}
+	}
+}
+
+func newTestService(t *testing.T, handler http.HandlerFunc) *Service {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	svc, err := NewService(srv.URL, "group/repo", "tok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestGetPullRequestsWithOptionsMapsMergeRequests(t *testing.T) {
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("PRIVATE-TOKEN"); got != "tok" {
+			t.Errorf("PRIVATE-TOKEN = %q", got)
+		}
+		if !strings.HasPrefix(r.URL.RawPath, "/api/v4/projects/group%2Frepo/merge_requests") {
+			t.Errorf("path = %q, want the URL-encoded project", r.URL.RawPath)
+		}
+		if r.URL.Query().Get("scope") != "created_by_me" {
+			t.Errorf("OnlyMine should scope to created_by_me, query = %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			w.Write([]byte(`[{"iid":7,"title":"Fix","state":"opened","web_url":"https://gitlab.com/group/repo/-/merge_requests/7","source_branch":"fix","target_branch":"main","draft":true,"sha":"abc","author":{"username":"ana"}},
+				{"iid":6,"title":"Old","state":"closed","source_branch":"old","target_branch":"main"}]`))
+			return
+		}
+		w.Write([]byte(`[{"iid":5,"title":"Done","state":"merged","source_branch":"done","target_branch":"main"}]`))
+	})
+	prs, err := svc.GetPullRequestsWithOptions(context.Background(), forge.PRFilterOptions{OnlyMine: true, ShowMerged: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("got %d PRs, want 2 (closed one filtered): %+v", len(prs), prs)
+	}
+	first := prs[0]
+	if first.Number != 7 || first.State != "open" || first.HeadBranch != "fix" || first.BaseBranch != "main" || !first.IsDraft || first.Author != "ana" {
+		t.Errorf("first PR = %+v", first)
+	}
+	if len(first.CommitIDs) != 1 || first.CommitIDs[0] != "abc" {
+		t.Errorf("CommitIDs = %v, want [abc]", first.CommitIDs)
+	}
+	if prs[1].Number != 5 || prs[1].State != "merged" {
+		t.Errorf("second PR = %+v", prs[1])
+	}
+}
+
+func TestCreatePullRequestMarksDrafts(t *testing.T) {
+	var got map[string]any
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"iid":9,"title":"Draft: Add x","state":"opened","source_branch":"add-x","target_branch":"main"}`))
+	})
+	pr, err := svc.CreatePullRequest(context.Background(), &internal.CreatePRRequest{Title: "Add x", HeadBranch: "add-x", BaseBranch: "main", Draft: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Number != 9 || pr.State != "open" {
+		t.Errorf("PR = %+v", pr)
+	}
+	if got["title"] != "Draft: Add x" || got["source_branch"] != "add-x" || got["target_branch"] != "main" {
+		t.Errorf("request body = %v", got)
+	}
+}
+
+func TestMergePullRequest(t *testing.T) {
+	var got map[string]any
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.Path, "/merge_requests/4/merge") {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{}`))
+	})
+	opts := forge.MergeOptions{Method: forge.MergeMethodSquash, CommitTitle: "Fix (!4)", CommitMessage: "Body"}
+	if err := svc.MergePullRequest(context.Background(), 4, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got["squash"] != true || got["squash_commit_message"] != "Fix (!4)\n\nBody" {
+		t.Errorf("request body = %v", got)
+	}
+	if err := svc.MergePullRequest(context.Background(), 4, forge.MergeOptions{Method: forge.MergeMethodRebase}); err == nil {
+		t.Error("rebase merge should be refused")
+	}
+}
+
+func TestSubmitReviewApprovesAndComments(t *testing.T) {
+	var calls []string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path[strings.LastIndex(r.URL.Path, "/"):])
+		w.Write([]byte(`{}`))
+	})
+	if err := svc.SubmitReview(context.Background(), 3, forge.ReviewApprove, "LGTM"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SubmitReview(context.Background(), 3, forge.ReviewRequestChanges, "Please rename"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"POST /approve", "POST /notes", "POST /notes"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestAPIErrorUsesMessage(t *testing.T) {
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"message":"405 Method Not Allowed"}`))
+	})
+	err := svc.ClosePullRequest(context.Background(), 2)
+	if err == nil || !strings.Contains(err.Error(), "405 Method Not Allowed") {
+		t.Errorf("err = %v", err)
+	}
+}
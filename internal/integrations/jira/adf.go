@@ -0,0 +1,194 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// adfNode is one node of an Atlassian Document Format tree (the v3 API's rich-text
+// representation for fields like description).
+type adfNode struct {
+	Type    string         `json:"type"`
+	Text    string         `json:"text"`
+	Attrs   map[string]any `json:"attrs"`
+	Marks   []adfMark      `json:"marks"`
+	Content []adfNode      `json:"content"`
+}
+
+// adfMark is an inline formatting mark (strong, em, code, link, ...).
+type adfMark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs"`
+}
+
+// plainText flattens the document to the text of each top-level block's inline children,
+// joined by spaces. This is the compact form shown in the ticket list.
+func (n *adfNode) plainText() string {
+	if n == nil {
+		return ""
+	}
+	var parts []string
+	for _, block := range n.Content {
+		for _, inline := range block.Content {
+			if inline.Text != "" {
+				parts = append(parts, inline.Text)
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// markdown renders the document as GitHub-flavored markdown. Unknown node types fall back
+// to their children so content is never dropped.
+func (n *adfNode) markdown() string {
+	if n == nil {
+		return ""
+	}
+	var blocks []string
+	for _, child := range n.Content {
+		if s := strings.TrimRight(renderADFBlock(child, ""), "\n"); s != "" {
+			blocks = append(blocks, s)
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// renderADFBlock renders a block-level node; indent prefixes every line (nested lists).
+func renderADFBlock(n adfNode, indent string) string {
+	switch n.Type {
+	case "paragraph":
+		return indent + renderADFInline(n.Content)
+	case "heading":
+		level := 1
+		if v, ok := n.Attrs["level"].(float64); ok && v >= 1 && v <= 6 {
+			level = int(v)
+		}
+		return strings.Repeat("#", level) + " " + renderADFInline(n.Content)
+	case "bulletList", "orderedList":
+		var lines []string
+		for i, item := range n.Content {
+			marker := "- "
+			if n.Type == "orderedList" {
+				marker = fmt.Sprintf("%d. ", i+1)
+			}
+			lines = append(lines, renderADFListItem(item, indent, marker))
+		}
+		return strings.Join(lines, "\n")
+	case "taskList":
+		var lines []string
+		for _, item := range n.Content {
+			marker := "- [ ] "
+			if state, _ := item.Attrs["state"].(string); state == "DONE" {
+				marker = "- [x] "
+			}
+			lines = append(lines, indent+marker+renderADFInline(item.Content))
+		}
+		return strings.Join(lines, "\n")
+	case "codeBlock":
+		lang, _ := n.Attrs["language"].(string)
+		return "```" + lang + "\n" + renderADFInline(n.Content) + "\n```"
+	case "blockquote":
+		var lines []string
+		for _, child := range n.Content {
+			for _, l := range strings.Split(renderADFBlock(child, ""), "\n") {
+				lines = append(lines, "> "+l)
+			}
+		}
+		return strings.Join(lines, "\n")
+	case "rule":
+		return "---"
+	case "panel":
+		var parts []string
+		for _, child := range n.Content {
+			parts = append(parts, renderADFBlock(child, indent))
+		}
+		return strings.Join(parts, "\n\n")
+	default:
+		if n.Text != "" || len(n.Content) == 0 {
+			return indent + renderADFInline([]adfNode{n})
+		}
+		var parts []string
+		for _, child := range n.Content {
+			parts = append(parts, renderADFBlock(child, indent))
+		}
+		return strings.Join(parts, "\n")
+	}
+}
+
+// renderADFListItem renders a listItem: the first paragraph after the marker, nested lists indented.
+func renderADFListItem(item adfNode, indent, marker string) string {
+	var lines []string
+	first := true
+	for _, child := range item.Content {
+		if child.Type == "bulletList" || child.Type == "orderedList" || child.Type == "taskList" {
+			lines = append(lines, renderADFBlock(child, indent+"  "))
+			continue
+		}
+		text := renderADFBlock(child, "")
+		if first {
+			lines = append(lines, indent+marker+text)
+			first = false
+		} else {
+			lines = append(lines, indent+"  "+text)
+		}
+	}
+	if first {
+		lines = append([]string{indent + marker}, lines...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderADFInline renders inline nodes (text with marks, hard breaks, mentions, emoji).
+func renderADFInline(nodes []adfNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Type {
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention":
+			if t, _ := n.Attrs["text"].(string); t != "" {
+				b.WriteString(t)
+			}
+		case "emoji":
+			if t, _ := n.Attrs["text"].(string); t != "" {
+				b.WriteString(t)
+			} else if t, _ := n.Attrs["shortName"].(string); t != "" {
+				b.WriteString(t)
+			}
+		case "inlineCard":
+			if u, _ := n.Attrs["url"].(string); u != "" {
+				b.WriteString(u)
+			}
+		default:
+			if n.Text != "" {
+				b.WriteString(applyADFMarks(n.Text, n.Marks))
+			} else if len(n.Content) > 0 {
+				b.WriteString(renderADFInline(n.Content))
+			}
+		}
+	}
+	return b.String()
+}
+
+// applyADFMarks wraps text in the markdown equivalent of each mark.
+func applyADFMarks(text string, marks []adfMark) string {
+	var href string
+	for _, m := range marks {
+		switch m.Type {
+		case "code":
+			text = "`" + text + "`"
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "_" + text + "_"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "link":
+			href, _ = m.Attrs["href"].(string)
+		}
+	}
+	if href != "" {
+		return "[" + text + "](" + href + ")"
+	}
+	return text
+}
@@ -0,0 +1,32 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestADFMarkdown(t *testing.T) {
+	raw := `{"type":"doc","version":1,"content":[
+		{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Acceptance criteria"}]},
+		{"type":"bulletList","content":[
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"Login "},{"type":"text","text":"works","marks":[{"type":"strong"}]}]}]},
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]}]}]}
+		]},
+		{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x := 1"}]}
+	]}`
+	var doc adfNode
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatal(err)
+	}
+	want := "## Acceptance criteria\n\n- Login **works**\n- [docs](https://example.com)\n\n```go\nx := 1\n```"
+	if got := doc.markdown(); got != want {
+		t.Errorf("markdown() =\n%s\nwant\n%s", got, want)
+	}
+	if got := doc.plainText(); got != "Acceptance criteria x := 1" {
+		t.Errorf("plainText() = %q", got)
+	}
+	var nilDoc *adfNode
+	if nilDoc.markdown() != "" || nilDoc.plainText() != "" {
+		t.Error("nil document should render empty")
+	}
+}
@@ -0,0 +1,79 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/madicen/jj-tui/internal/tickets"
+)
+
+// jiraTimeLayout is how the REST API formats timestamps (e.g. 2024-05-01T09:30:00.000+0000).
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// issueDetailResponse is the subset of an issue the detail view needs.
+type issueDetailResponse struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Description *adfNode `json:"description"`
+		Assignee    *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
+		Labels  []string `json:"labels"`
+		Comment struct {
+			Comments []struct {
+				Author *struct {
+					DisplayName string `json:"displayName"`
+				} `json:"author"`
+				Body    *adfNode `json:"body"`
+				Created string   `json:"created"`
+			} `json:"comments"`
+		} `json:"comment"`
+	} `json:"fields"`
+}
+
+// GetTicketDetail fetches an issue's full description, assignee, labels and comments.
+func (s *Service) GetTicketDetail(ctx context.Context, key string) (*tickets.TicketDetail, error) {
+	endpoint := "/rest/api/3/issue/" + key + "?fields=description,assignee,labels,comment"
+
+	resp, err := s.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("jira API error (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var issue issueDetailResponse
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return issue.detail(), nil
+}
+
+// detail converts the response, rendering the ADF description and comment bodies as markdown.
+func (r issueDetailResponse) detail() *tickets.TicketDetail {
+	d := &tickets.TicketDetail{
+		Key:                 r.Key,
+		DescriptionMarkdown: r.Fields.Description.markdown(),
+		Labels:              r.Fields.Labels,
+	}
+	if r.Fields.Assignee != nil {
+		d.Assignee = r.Fields.Assignee.DisplayName
+	}
+	for _, c := range r.Fields.Comment.Comments {
+		comment := tickets.TicketComment{Body: c.Body.markdown()}
+		if c.Author != nil {
+			comment.Author = c.Author.DisplayName
+		}
+		comment.Created, _ = time.Parse(jiraTimeLayout, c.Created)
+		d.Comments = append(d.Comments, comment)
+	}
+	return d
+}
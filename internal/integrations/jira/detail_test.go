@@ -0,0 +1,31 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIssueDetail(t *testing.T) {
+	raw := `{"key":"PROJ-7","fields":{
+		"description":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Steps","marks":[{"type":"strong"}]}]}]},
+		"assignee":{"displayName":"Ada Lovelace"},
+		"labels":["backend","p1"],
+		"comment":{"comments":[
+			{"author":{"displayName":"Grace"},"created":"2024-05-01T09:30:00.000+0000","body":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Repro'd"}]}]}},
+			{"created":"bad","body":null}
+		]}}}`
+	var r issueDetailResponse
+	if err := json.Unmarshal([]byte(raw), &r); err != nil {
+		t.Fatal(err)
+	}
+	d := r.detail()
+	if d.Key != "PROJ-7" || d.DescriptionMarkdown != "**Steps**" || d.Assignee != "Ada Lovelace" || len(d.Labels) != 2 {
+		t.Fatalf("detail = %+v", d)
+	}
+	if len(d.Comments) != 2 || d.Comments[0].Author != "Grace" || d.Comments[0].Body != "Repro'd" || d.Comments[0].Created.Year() != 2024 {
+		t.Fatalf("comments = %+v", d.Comments)
+	}
+	if d.Comments[1].Author != "" || !d.Comments[1].Created.IsZero() {
+		t.Fatalf("comment without author/date = %+v", d.Comments[1])
+	}
+}
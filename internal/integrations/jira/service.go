@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/madicen/jj-tui/internal/tickets"
 )
@@ -20,6 +22,9 @@ type Service struct {
 	username string
 	token    string
 	client   *http.Client
+
+	// delta is the assigned list PollAssignedTickets keeps up to date.
+	delta tickets.DeltaList
 }
 
 // NewService creates a new Jira service
@@ -100,6 +105,12 @@ func (s *Service) checkPermissions() error {
 
 // buildJQL constructs the JQL query with optional project and custom filters
 func (s *Service) buildJQL() string {
+	return s.jqlConditions() + " ORDER BY updated DESC"
+}
+
+// jqlConditions returns the assigned-tickets filter: assignee, then the optional project and
+// custom filters, joined with AND.
+func (s *Service) jqlConditions() string {
 	var conditions []string
 
 	// Base condition: assigned to current user
@@ -130,9 +141,13 @@ func (s *Service) buildJQL() string {
 		conditions = append(conditions, customJQL)
 	}
 
-	// Combine all conditions with AND, then add ORDER BY
-	jql := strings.Join(conditions, " AND ") + " ORDER BY updated DESC"
-	return jql
+	return strings.Join(conditions, " AND ")
+}
+
+// deltaJQL is buildJQL narrowed to tickets updated in the last minutes. Jira resolves the
+// relative date on its own clock.
+func (s *Service) deltaJQL(minutes int) string {
+	return fmt.Sprintf("(%s) AND updated >= \"-%dm\" ORDER BY updated DESC", s.jqlConditions(), minutes)
 }
 
 // searchResponse represents the response from Jira search API v3
@@ -140,15 +155,9 @@ type searchResponse struct {
 	Issues []struct {
 		Key    string `json:"key"`
 		Fields struct {
-			Summary     string `json:"summary"`
-			Description *struct {
-				Content []struct {
-					Content []struct {
-						Text string `json:"text"`
-					} `json:"content"`
-				} `json:"content"`
-			} `json:"description"`
-			Status *struct {
+			Summary     string   `json:"summary"`
+			Description *adfNode `json:"description"`
+			Status      *struct {
 				Name string `json:"name"`
 			} `json:"status"`
 			Priority *struct {
@@ -178,15 +187,61 @@ func (s *Service) doRequest(ctx context.Context, method, endpoint string, body i
 	return s.client.Do(req)
 }
 
+// searchMaxResults is the page size of ticket searches; a delta that fills it is replaced by a
+// full fetch.
+const searchMaxResults = 50
+
 // GetAssignedTickets fetches tickets assigned to the current user using API v3
 func (s *Service) GetAssignedTickets(ctx context.Context) ([]tickets.Ticket, error) {
-	// Build JQL query with optional filters
-	jql := s.buildJQL()
+	start := time.Now()
+	ticketList, err := s.searchTickets(ctx, s.buildJQL())
+	if err != nil {
+		return nil, err
+	}
+	s.delta.Reset(ticketList, start)
+	return ticketList, nil
+}
 
+// PollAssignedTickets searches only for assigned tickets updated since the last change it saw
+// (JQL `updated >= "-Nm"`) and merges them into the list GetAssignedTickets fetched; a ticket
+// moved to an excluded status comes back with it and is filtered out like on a full load.
+// changed is false (and list nil) when nothing changed. Without a list yet, every
+// tickets.DeltaFullSyncInterval, or when the delta fills a page, the whole list is fetched instead.
+func (s *Service) PollAssignedTickets(ctx context.Context) ([]tickets.Ticket, bool, error) {
+	start := time.Now()
+	since, full := s.delta.Since(start)
+	if !full {
+		minutes := int(math.Ceil(start.Sub(since).Minutes()))
+		updated, err := s.searchTickets(ctx, s.deltaJQL(max(minutes, 1)))
+		if err != nil {
+			return nil, false, err
+		}
+		if len(updated) < searchMaxResults {
+			list, changed := s.delta.Apply(updated, nil, start)
+			if !changed {
+				return nil, false, nil
+			}
+			return list, true, nil
+		}
+	}
+	ticketList, err := s.searchTickets(ctx, s.buildJQL())
+	if err != nil {
+		return nil, false, err
+	}
+	if !s.delta.Reset(ticketList, start) {
+		return nil, false, nil
+	}
+	return ticketList, true, nil
+}
+
+var _ tickets.ChangePoller = (*Service)(nil)
+
+// searchTickets runs a JQL search and returns the first searchMaxResults tickets.
+func (s *Service) searchTickets(ctx context.Context, jql string) ([]tickets.Ticket, error) {
 	// Use the new /rest/api/3/search/jql endpoint
 	// Must explicitly request fields - the v3 API returns minimal data by default
 	fields := "key,summary,status,priority,issuetype,description"
-	endpoint := "/rest/api/3/search/jql?jql=" + url.QueryEscape(jql) + "&maxResults=50&fields=" + fields
+	endpoint := "/rest/api/3/search/jql?jql=" + url.QueryEscape(jql) + fmt.Sprintf("&maxResults=%d&fields=", searchMaxResults) + fields
 
 	resp, err := s.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
@@ -223,17 +278,8 @@ func (s *Service) GetAssignedTickets(ctx context.Context) ([]tickets.Ticket, err
 		}
 
 		// Extract description text from Atlassian Document Format (ADF)
-		if issue.Fields.Description != nil && len(issue.Fields.Description.Content) > 0 {
-			var descParts []string
-			for _, block := range issue.Fields.Description.Content {
-				for _, inline := range block.Content {
-					if inline.Text != "" {
-						descParts = append(descParts, inline.Text)
-					}
-				}
-			}
-			ticket.Description = strings.Join(descParts, " ")
-		}
+		ticket.Description = issue.Fields.Description.plainText()
+		ticket.DescriptionMarkdown = issue.Fields.Description.markdown()
 
 		ticketList = append(ticketList, ticket)
 	}
@@ -245,15 +291,9 @@ func (s *Service) GetAssignedTickets(ctx context.Context) ([]tickets.Ticket, err
 type issueResponse struct {
 	Key    string `json:"key"`
 	Fields struct {
-		Summary     string `json:"summary"`
-		Description *struct {
-			Content []struct {
-				Content []struct {
-					Text string `json:"text"`
-				} `json:"content"`
-			} `json:"content"`
-		} `json:"description"`
-		Status *struct {
+		Summary     string   `json:"summary"`
+		Description *adfNode `json:"description"`
+		Status      *struct {
 			Name string `json:"name"`
 		} `json:"status"`
 		Priority *struct {
@@ -302,17 +342,8 @@ func (s *Service) GetTicket(ctx context.Context, key string) (*tickets.Ticket, e
 	}
 
 	// Extract description text from Atlassian Document Format (ADF)
-	if issue.Fields.Description != nil && len(issue.Fields.Description.Content) > 0 {
-		var descParts []string
-		for _, block := range issue.Fields.Description.Content {
-			for _, inline := range block.Content {
-				if inline.Text != "" {
-					descParts = append(descParts, inline.Text)
-				}
-			}
-		}
-		ticket.Description = strings.Join(descParts, " ")
-	}
+	ticket.Description = issue.Fields.Description.plainText()
+	ticket.DescriptionMarkdown = issue.Fields.Description.markdown()
 
 	return ticket, nil
 }
@@ -402,6 +433,7 @@ type createIssueRequest struct {
 		Project     map[string]string `json:"project"`
 		Summary     string            `json:"summary"`
 		IssueType   map[string]string `json:"issuetype"`
+		Priority    map[string]string `json:"priority,omitempty"`
 		Description *adfDocument      `json:"description,omitempty"`
 	} `json:"fields"`
 }
@@ -435,7 +467,31 @@ func (s *Service) CanCreateTicket() bool {
 	return project != ""
 }
 
-// CreateTicket creates a new Jira issue. Uses JIRA_PROJECT for project key and JIRA_ISSUE_TYPE for type (default "Task").
+// jiraPriorities are the priorities of Jira's default priority scheme.
+var jiraPriorities = []string{"Highest", "High", "Medium", "Low", "Lowest"}
+
+// defaultIssueType is JIRA_ISSUE_TYPE, or "Task".
+func defaultIssueType() string {
+	if t := os.Getenv("JIRA_ISSUE_TYPE"); t != "" {
+		return t
+	}
+	return "Task"
+}
+
+// CreateOptions offers the default issue type first, then the standard ones, and the default
+// priority scheme.
+func (s *Service) CreateOptions() tickets.CreateOptions {
+	types := []string{defaultIssueType()}
+	for _, t := range []string{"Task", "Bug", "Story", "Epic"} {
+		if !strings.EqualFold(t, types[0]) {
+			types = append(types, t)
+		}
+	}
+	return tickets.CreateOptions{Types: types, Priorities: jiraPriorities}
+}
+
+// CreateTicket creates a new Jira issue. Uses JIRA_PROJECT for project key and input.Type or
+// JIRA_ISSUE_TYPE for type (default "Task").
 func (s *Service) CreateTicket(ctx context.Context, input *tickets.CreateTicketInput) (*tickets.Ticket, error) {
 	if input == nil || strings.TrimSpace(input.Summary) == "" {
 		return nil, fmt.Errorf("summary is required")
@@ -444,13 +500,16 @@ func (s *Service) CreateTicket(ctx context.Context, input *tickets.CreateTicketI
 	if projectKey == "" {
 		return nil, fmt.Errorf("JIRA_PROJECT is required to create issues")
 	}
-	issueType := os.Getenv("JIRA_ISSUE_TYPE")
+	issueType := input.Type
 	if issueType == "" {
-		issueType = "Task"
+		issueType = defaultIssueType()
 	}
 	reqBody := createIssueRequest{}
 	reqBody.Fields.Project = map[string]string{"key": strings.TrimSpace(projectKey)}
 	reqBody.Fields.IssueType = map[string]string{"name": issueType}
+	if input.Priority != "" {
+		reqBody.Fields.Priority = map[string]string{"name": input.Priority}
+	}
 	reqBody.Fields.Summary = strings.TrimSpace(input.Summary)
 	if input.Description != "" {
 		reqBody.Fields.Description = &adfDocument{
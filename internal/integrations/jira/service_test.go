@@ -0,0 +1,52 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// After the first full search, polls only ask for tickets updated since then (plus the overlap,
+// rounded up to whole minutes) and merge them in; an empty delta is no change.
+func TestPollAssignedTickets(t *testing.T) {
+	t.Setenv("JIRA_PROJECT_FILTER", "")
+	t.Setenv("JIRA_JQL", "")
+	delta := `{"issues":[]}`
+	var searches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			http.NotFound(w, r)
+			return
+		}
+		jql := r.URL.Query().Get("jql")
+		searches = append(searches, jql)
+		if !strings.Contains(jql, "updated >=") {
+			fmt.Fprint(w, `{"issues":[{"key":"PROJ-1","fields":{"summary":"One","status":{"name":"To Do"}}},{"key":"PROJ-2","fields":{"summary":"Two","status":{"name":"To Do"}}}]}`)
+			return
+		}
+		fmt.Fprint(w, delta)
+	}))
+	defer server.Close()
+	svc := &Service{baseURL: server.URL, username: "me@example.com", token: "t", client: server.Client()}
+
+	list, changed, err := svc.PollAssignedTickets(context.Background())
+	if err != nil || !changed || len(list) != 2 {
+		t.Fatalf("first poll = %+v, %v, %v", list, changed, err)
+	}
+	if list, changed, err = svc.PollAssignedTickets(context.Background()); err != nil || changed || list != nil {
+		t.Fatalf("unchanged poll = %+v, %v, %v", list, changed, err)
+	}
+	want := `(assignee = "me@example.com") AND updated >= "-2m" ORDER BY updated DESC`
+	if len(searches) != 2 || searches[1] != want {
+		t.Fatalf("searches = %q, want the delta %q", searches, want)
+	}
+
+	delta = `{"issues":[{"key":"PROJ-2","fields":{"summary":"Two","status":{"name":"Done"}}}]}`
+	list, changed, err = svc.PollAssignedTickets(context.Background())
+	if err != nil || !changed || len(list) != 2 || list[0].Key != "PROJ-2" || list[0].Status != "Done" || list[1].Key != "PROJ-1" {
+		t.Fatalf("changed poll = %+v, %v, %v", list, changed, err)
+	}
+}
@@ -0,0 +1,130 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// absorbRebasedRe matches the "Rebased N descendant commits" line jj absorb prints.
+var absorbRebasedRe = regexp.MustCompile(`Rebased (\d+) descendant`)
+
+// AbsorbDestination is a commit that `jj absorb` moved working-copy changes into.
+type AbsorbDestination struct {
+	ChangeID string
+	CommitID string // after the absorb
+	Summary  string
+	Files    []string // files whose changes it received (nil when they could not be listed)
+}
+
+// AbsorbResult is what an absorb did, or would do when previewed.
+type AbsorbResult struct {
+	Destinations []AbsorbDestination // as jj lists them
+	Rebased      int                 // descendants jj rebased onto the rewritten commits
+	Remaining    []string            // files still changed in the working copy afterwards
+}
+
+// Absorb runs `jj absorb` on the working copy: each hunk moves into the closest mutable ancestor
+// that last changed those lines, and hunks with no such ancestor stay in the working copy.
+func (s *Service) Absorb(ctx context.Context) (AbsorbResult, error) {
+	s.ops.mu.Lock()
+	defer s.ops.mu.Unlock()
+	before, err := s.opLog(ctx, 1)
+	if err != nil {
+		return AbsorbResult{}, err
+	}
+	return s.absorb(ctx, before[0].ID)
+}
+
+// PreviewAbsorb reports what Absorb would do without keeping it. jj absorb has no dry run, so
+// this runs it and immediately restores the operation before it; both operations stay in the op
+// log, and the files on disk are the same before and after.
+func (s *Service) PreviewAbsorb(ctx context.Context) (AbsorbResult, error) {
+	s.ops.mu.Lock()
+	defer s.ops.mu.Unlock()
+	before, err := s.opLog(ctx, 1)
+	if err != nil {
+		return AbsorbResult{}, err
+	}
+	res, err := s.absorb(ctx, before[0].ID)
+	if after, lerr := s.opLog(ctx, 1); lerr == nil && after[0].ID == before[0].ID {
+		return res, err
+	}
+	if rerr := s.runJJ(ctx, "op", "restore", before[0].ID); rerr != nil {
+		return AbsorbResult{}, fmt.Errorf("absorb preview could not restore operation %s (run jj op restore yourself): %w", shortOpID(before[0].ID), rerr)
+	}
+	return res, err
+}
+
+// absorb runs `jj absorb` and lists, per destination, the files it received by comparing the
+// destination's diff with its diff at operation before.
+func (s *Service) absorb(ctx context.Context, before string) (AbsorbResult, error) {
+	out, err := s.runJJCombined(ctx, "absorb", "--from", "@")
+	if err != nil {
+		return AbsorbResult{}, err
+	}
+	res := parseAbsorbOutput(out)
+	for i := range res.Destinations {
+		d := &res.Destinations[i]
+		old, err := s.runJJOutputNoHistoryWithGlobal(ctx, []string{"--at-op", before, "--ignore-working-copy"},
+			"log", "-r", d.ChangeID, "--no-graph", "-T", "commit_id")
+		if err != nil {
+			continue
+		}
+		names, err := s.runJJOutputNoHistory(ctx, "interdiff", "--from", strings.TrimSpace(old), "--to", d.ChangeID, "--name-only")
+		if err == nil {
+			d.Files = nonEmptyLines(names)
+		}
+	}
+	if names, err := s.runJJOutputNoHistory(ctx, "diff", "-r", "@", "--name-only"); err == nil {
+		res.Remaining = nonEmptyLines(names)
+	}
+	return res, nil
+}
+
+// parseAbsorbOutput reads the destinations and rebase count from jj absorb's status output:
+//
+//	Absorbed changes into 2 revisions:
+//	  zsuskuln 3027ce6e Fix parser
+//	  kkmpptxz 2a8ef0e5 Add lexer
+//	Rebased 1 descendant commits.
+func parseAbsorbOutput(out string) AbsorbResult {
+	var res AbsorbResult
+	inList := false
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, "Absorbed changes into") {
+			inList = true
+			continue
+		}
+		if inList && strings.HasPrefix(line, "  ") {
+			fields := strings.SplitN(strings.TrimSpace(line), " ", 3)
+			if len(fields) >= 2 {
+				d := AbsorbDestination{ChangeID: fields[0], CommitID: fields[1]}
+				if len(fields) == 3 {
+					d.Summary = fields[2]
+				}
+				res.Destinations = append(res.Destinations, d)
+			}
+			continue
+		}
+		inList = false
+		if m := absorbRebasedRe.FindStringSubmatch(line); m != nil {
+			res.Rebased, _ = strconv.Atoi(m[1])
+		}
+	}
+	return res
+}
+
+// nonEmptyLines returns the trimmed non-empty lines of out.
+func nonEmptyLines(out string) []string {
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,51 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AbsorbFileTarget is the ancestor AbsorbFile squashes a file into.
+type AbsorbFileTarget struct {
+	ChangeID string
+	ShortID  string
+	Summary  string
+}
+
+// absorbFileTargetRevset selects the closest ancestors of commitID (excluding it) that touched path.
+func absorbFileTargetRevset(commitID, path string) string {
+	return fmt.Sprintf("heads(::(%s)- & files(root-file:%s))", commitID, quoteRevsetString(path))
+}
+
+// FindAbsorbFileTarget returns the closest ancestor of commitID that last changed path. It fails
+// when no ancestor touched the file or when that ancestor is immutable.
+func (s *Service) FindAbsorbFileTarget(ctx context.Context, commitID, path string) (AbsorbFileTarget, error) {
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", absorbFileTargetRevset(commitID, path), "--no-graph", "--limit", "1",
+		"-T", `change_id ++ "\t" ++ change_id.shortest(8) ++ "\t" ++ if(immutable, "immutable", "") ++ "\t" ++ description.first_line() ++ "\n"`)
+	if err != nil {
+		return AbsorbFileTarget{}, err
+	}
+	fields := strings.SplitN(strings.TrimSpace(out), "\t", 4)
+	if len(fields) < 4 || fields[0] == "" {
+		return AbsorbFileTarget{}, fmt.Errorf("no ancestor changed %s", path)
+	}
+	if fields[2] == "immutable" {
+		return AbsorbFileTarget{}, fmt.Errorf("%s was last changed in immutable commit %s", path, fields[1])
+	}
+	return AbsorbFileTarget{ChangeID: fields[0], ShortID: fields[1], Summary: fields[3]}, nil
+}
+
+// AbsorbFile squashes commitID's changes to path into the ancestor that last changed it, a
+// single-file alternative to `jj absorb`. The ancestor keeps its description; commitID is
+// abandoned by jj if the file was its only change.
+func (s *Service) AbsorbFile(ctx context.Context, commitID, path string) (AbsorbFileTarget, error) {
+	target, err := s.FindAbsorbFileTarget(ctx, commitID, path)
+	if err != nil {
+		return AbsorbFileTarget{}, err
+	}
+	if err := s.runJJ(ctx, "squash", "--from", commitID, "--into", target.ChangeID, "--use-destination-message", "--", path); err != nil {
+		return AbsorbFileTarget{}, fmt.Errorf("failed to squash %s into %s: %w", path, target.ShortID, err)
+	}
+	return target, nil
+}
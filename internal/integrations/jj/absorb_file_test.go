@@ -0,0 +1,11 @@
+package jj
+
+import "testing"
+
+func TestAbsorbFileTargetRevset(t *testing.T) {
+	got := absorbFileTargetRevset("abc", `dir/a "b".go`)
+	want := `heads(::(abc)- & files(root-file:"dir/a \"b\".go"))`
+	if got != want {
+		t.Fatalf("revset = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAbsorbOutput(t *testing.T) {
+	out := "Absorbed changes into 2 revisions:\n" +
+		"  zsuskuln 3027ce6e Fix parser\n" +
+		"  kkmpptxz 2a8ef0e5 (no description set)\n" +
+		"Rebased 1 descendant commits.\n" +
+		"Working copy  (@) now at: vruxwmqv 7d8c1a2b (empty) (no description set)\n"
+	got := parseAbsorbOutput(out)
+	want := AbsorbResult{
+		Destinations: []AbsorbDestination{
+			{ChangeID: "zsuskuln", CommitID: "3027ce6e", Summary: "Fix parser"},
+			{ChangeID: "kkmpptxz", CommitID: "2a8ef0e5", Summary: "(no description set)"},
+		},
+		Rebased: 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseAbsorbOutput() = %+v, want %+v", got, want)
+	}
+	if got := parseAbsorbOutput("Nothing changed.\n"); len(got.Destinations) != 0 {
+		t.Fatalf("nothing absorbed = %+v", got)
+	}
+}
+
+// The preview runs jj absorb, lists what moved where, then restores the operation before it.
+func TestPreviewAbsorbRestoresOperation(t *testing.T) {
+	log := fakeJJ(t, `case "$*" in
+"op log"*) if [ -f "$log.absorbed" ] && [ ! -f "$log.restored" ]; then printf 'op2\tabsorb\n'; else printf 'op1\tsnapshot\n'; fi ;;
+absorb*) touch "$log.absorbed"; printf 'Absorbed changes into 1 revisions:\n  zsuskuln 3027ce6e Fix parser\n' >&2 ;;
+"op restore"*) touch "$log.restored" ;;
+--at-op*) printf 'oldc0mmit' ;;
+interdiff*) printf 'src/a.go\n' ;;
+diff*) printf 'new.txt\n' ;;
+esac`)
+	s := &Service{RepoPath: t.TempDir()}
+
+	got, err := s.PreviewAbsorb(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := AbsorbResult{
+		Destinations: []AbsorbDestination{{ChangeID: "zsuskuln", CommitID: "3027ce6e", Summary: "Fix parser", Files: []string{"src/a.go"}}},
+		Remaining:    []string{"new.txt"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("PreviewAbsorb() = %+v, want %+v", got, want)
+	}
+	all := strings.Join(calls(t, log), "\n")
+	for _, part := range []string{"absorb --from @", "--at-op op1 --ignore-working-copy log -r zsuskuln", "interdiff --from oldc0mmit --to zsuskuln --name-only", "op restore op1"} {
+		if !strings.Contains(all, part) {
+			t.Errorf("jj calls missing %q:\n%s", part, all)
+		}
+	}
+}
+
+// Nothing absorbed means no new operation, so there is nothing to restore.
+func TestPreviewAbsorbNothingToRestore(t *testing.T) {
+	log := fakeJJ(t, `case "$*" in
+"op log"*) printf 'op1\tsnapshot\n' ;;
+absorb*) echo 'Nothing changed.' >&2 ;;
+esac`)
+	s := &Service{RepoPath: t.TempDir()}
+
+	got, err := s.PreviewAbsorb(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Destinations) != 0 {
+		t.Fatalf("PreviewAbsorb() = %+v", got)
+	}
+	for _, c := range calls(t, log) {
+		if strings.HasPrefix(c, "op restore") {
+			t.Fatalf("restored although nothing changed: %q", c)
+		}
+	}
+}
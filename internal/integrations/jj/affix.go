@@ -0,0 +1,52 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AffixSubject adds prefix before and suffix after the first line of desc, separated by a
+// space, and keeps the body as is. A prefix or suffix the subject already has is not added
+// again, so running the same bulk edit twice is harmless. "(no description)" counts as empty.
+func AffixSubject(desc, prefix, suffix string) string {
+	desc = strings.TrimSpace(desc)
+	if desc == "(no description)" {
+		desc = ""
+	}
+	subject, body, hasBody := strings.Cut(desc, "\n")
+	subject = strings.TrimSpace(subject)
+	if p := strings.TrimSpace(prefix); p != "" && !strings.HasPrefix(subject, p) {
+		subject = strings.TrimSpace(p + " " + subject)
+	}
+	if s := strings.TrimSpace(suffix); s != "" && !strings.HasSuffix(subject, s) {
+		subject = strings.TrimSpace(subject + " " + s)
+	}
+	if hasBody {
+		return subject + "\n" + body
+	}
+	return subject
+}
+
+// AffixDescriptions runs AffixSubject over each change's description and describes the ones that
+// change, in order. changeIDs are change IDs (not commit IDs), which stay valid while earlier
+// describes rewrite descendants. Returns how many were rewritten; on error, the ones before the
+// failing change have already been updated.
+func (s *Service) AffixDescriptions(ctx context.Context, changeIDs []string, prefix, suffix string) (int, error) {
+	updated := 0
+	for _, id := range changeIDs {
+		desc, err := s.GetCommitDescription(ctx, id)
+		if err != nil {
+			return updated, fmt.Errorf("read description of %s: %w", id, err)
+		}
+		next := AffixSubject(desc, prefix, suffix)
+		if next == desc {
+			continue
+		}
+		if err := s.DescribeCommit(ctx, id, next); err != nil {
+			return updated, fmt.Errorf("describe %s: %w", id, err)
+		}
+		updated++
+	}
+	return updated, nil
+}
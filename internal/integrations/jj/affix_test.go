@@ -0,0 +1,24 @@
+package jj
+
+import "testing"
+
+func TestAffixSubject(t *testing.T) {
+	tests := []struct {
+		name, desc, prefix, suffix, want string
+	}{
+		{"prefix", "fix login", "PROJ-1:", "", "PROJ-1: fix login"},
+		{"suffix", "fix login", "", "(#12)", "fix login (#12)"},
+		{"both", "fix login", "PROJ-1", "[skip ci]", "PROJ-1 fix login [skip ci]"},
+		{"body kept", "fix login\n\nlonger body\nline 2", "PROJ-1:", "", "PROJ-1: fix login\n\nlonger body\nline 2"},
+		{"already prefixed", "PROJ-1: fix login", "PROJ-1:", "", "PROJ-1: fix login"},
+		{"already suffixed", "fix login (#12)", "", " (#12) ", "fix login (#12)"},
+		{"empty", "", "PROJ-1:", "", "PROJ-1:"},
+		{"no description", "(no description)", "", "wip", "wip"},
+		{"nothing to add", "fix login", " ", "", "fix login"},
+	}
+	for _, tt := range tests {
+		if got := AffixSubject(tt.desc, tt.prefix, tt.suffix); got != tt.want {
+			t.Errorf("%s: AffixSubject(%q, %q, %q) = %q, want %q", tt.name, tt.desc, tt.prefix, tt.suffix, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Alias is one user or repo alias from jj's config: a command alias (`aliases.NAME`) or a
+// revset alias (`revset-aliases.NAME`). Definition is the config value as jj prints it.
+type Alias struct {
+	Name       string
+	Definition string
+}
+
+// ParseConfigAliases picks the command and revset aliases out of `jj config list` output
+// (`key = value` lines). Revset aliases that take parameters are skipped since they can't be
+// used as a filter on their own. Both lists are sorted by name.
+func ParseConfigAliases(out string) (commands, revsets []Alias) {
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " = ")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(key, "aliases."):
+			if name := unquoteConfigKey(strings.TrimPrefix(key, "aliases.")); name != "" {
+				commands = append(commands, Alias{Name: name, Definition: value})
+			}
+		case strings.HasPrefix(key, "revset-aliases."):
+			name := unquoteConfigKey(strings.TrimPrefix(key, "revset-aliases."))
+			if name == "" || (strings.Contains(name, "(") && !strings.HasSuffix(name, "()")) {
+				continue
+			}
+			revsets = append(revsets, Alias{Name: name, Definition: value})
+		}
+	}
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
+	sort.Slice(revsets, func(i, j int) bool { return revsets[i].Name < revsets[j].Name })
+	return commands, revsets
+}
+
+// unquoteConfigKey strips TOML key quotes ('mine()' or "mine()").
+func unquoteConfigKey(k string) string {
+	k = strings.TrimSpace(k)
+	if len(k) >= 2 && (k[0] == '\'' || k[0] == '"') && k[len(k)-1] == k[0] {
+		return k[1 : len(k)-1]
+	}
+	return k
+}
+
+// ListAliases returns the command and revset aliases configured for this repo (user and repo
+// config; jj's built-in defaults are not listed).
+func (s *Service) ListAliases(ctx context.Context) (commands, revsets []Alias, err error) {
+	out, err := s.runJJOutputNoHistory(ctx, "config", "list")
+	if err != nil {
+		return nil, nil, fmt.Errorf("read jj config: %w", err)
+	}
+	commands, revsets = ParseConfigAliases(out)
+	return commands, revsets, nil
+}
+
+// RunAlias runs the command alias name (`jj NAME`) and returns its output.
+func (s *Service) RunAlias(ctx context.Context, name string) (string, error) {
+	return s.runJJOutput(ctx, name)
+}
+
+// ApplyRevsetAliasToRevset narrows base (DefaultGraphRevset when empty) to the revset alias
+// name, keeping @ visible. An empty name returns base unchanged.
+func ApplyRevsetAliasToRevset(base, name string) string {
+	if name == "" {
+		return base
+	}
+	base = strings.TrimSpace(base)
+	if base == "" {
+		base = DefaultGraphRevset
+	}
+	return fmt.Sprintf("((%s) & (%s)) | @", base, name)
+}
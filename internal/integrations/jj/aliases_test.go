@@ -0,0 +1,44 @@
+package jj
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConfigAliases(t *testing.T) {
+	out := `user.name = "Ada"
+aliases.sync = ["git", "fetch", "--all-remotes"]
+aliases.l = ["log", "-r", "mine()"]
+revset-aliases.'stack()' = "trunk()..@"
+revset-aliases."wip" = "description(glob:'wip*')"
+revset-aliases.'mine_in(x)' = "mine() & x"
+ui.default-command = "log"
+`
+	commands, revsets := ParseConfigAliases(out)
+	wantCommands := []Alias{
+		{Name: "l", Definition: `["log", "-r", "mine()"]`},
+		{Name: "sync", Definition: `["git", "fetch", "--all-remotes"]`},
+	}
+	wantRevsets := []Alias{
+		{Name: "stack()", Definition: `"trunk()..@"`},
+		{Name: "wip", Definition: `"description(glob:'wip*')"`},
+	}
+	if !reflect.DeepEqual(commands, wantCommands) {
+		t.Errorf("commands = %v, want %v", commands, wantCommands)
+	}
+	if !reflect.DeepEqual(revsets, wantRevsets) {
+		t.Errorf("revsets = %v, want %v", revsets, wantRevsets)
+	}
+}
+
+func TestApplyRevsetAliasToRevset(t *testing.T) {
+	if got := ApplyRevsetAliasToRevset("all()", ""); got != "all()" {
+		t.Errorf("no alias: %q", got)
+	}
+	if got, want := ApplyRevsetAliasToRevset("all()", "stack()"), "((all()) & (stack())) | @"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := ApplyRevsetAliasToRevset("", "wip"), "(("+DefaultGraphRevset+") & (wip)) | @"; got != want {
+		t.Errorf("default base: got %q, want %q", got, want)
+	}
+}
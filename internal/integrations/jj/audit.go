@@ -0,0 +1,168 @@
+package jj
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditEntry is one line of the audit log: a mutating jj command jj-tui ran, who ran it and how
+// it ended.
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	User       string    `json:"user,omitempty"`  // jj's user.name <user.email>
+	Login      string    `json:"login,omitempty"` // OS user@host
+	Command    string    `json:"command"`
+	Revisions  []string  `json:"revisions,omitempty"` // the revisions it named (change IDs for most actions)
+	Op         string    `json:"op,omitempty"`        // the operation it created, for `jj op show`
+	DurationMS int64     `json:"duration_ms"`
+	OK         bool      `json:"ok"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// auditLog appends AuditEntry lines to a file; see EnableAuditLog.
+type auditLog struct {
+	path     string
+	mu       sync.Mutex
+	identity sync.Once
+	user     string
+	login    string
+}
+
+// AuditLogPath returns the audit log for the repository at repoPath: jj-tui/audit.jsonl in the
+// repo's .jj/repo store, which every workspace (and everyone using a shared checkout) shares, and
+// which jj never snapshots into the working copy.
+func AuditLogPath(repoPath string) (string, error) {
+	heads, err := opHeadsDir(repoPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(filepath.Dir(heads)), "jj-tui", "audit.jsonl"), nil
+}
+
+// EnableAuditLog appends every mutating jj command this service runs (not ones declined in
+// preview mode or refused on a stale working copy) to the repository's audit log. The log is
+// append-only: jj-tui never rewrites or prunes it.
+func (s *Service) EnableAuditLog() error {
+	path, err := AuditLogPath(s.RepoPath)
+	if err != nil {
+		return err
+	}
+	s.audit = &auditLog{path: path}
+	return nil
+}
+
+// AuditLogPath returns the audit log this service appends to, or "" when it is off.
+func (s *Service) AuditLogPath() string {
+	if s.audit == nil {
+		return ""
+	}
+	return s.audit.path
+}
+
+// recordAudit appends a finished mutating command to the audit log. Errors are ignored: the log
+// is best effort and must not fail the command it describes.
+func (s *Service) recordAudit(args []string, start time.Time, stdout, stderr string, err error) {
+	a := s.audit
+	if a == nil {
+		return
+	}
+	a.identity.Do(func() { a.user, a.login = s.auditIdentity() })
+	e := AuditEntry{
+		Time:       start,
+		User:       a.user,
+		Login:      a.login,
+		Command:    CommandLine(args),
+		Revisions:  CommandRevisions(args),
+		DurationMS: time.Since(start).Milliseconds(),
+		OK:         err == nil,
+	}
+	if err == nil {
+		e.Op = s.opHeadID()
+	} else {
+		e.Error = commandError(stdout, stderr, err)
+	}
+	line, merr := json.Marshal(e)
+	if merr != nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if os.MkdirAll(filepath.Dir(a.path), 0o755) != nil {
+		return
+	}
+	// Group-writable so everyone sharing the checkout can append.
+	f, ferr := os.OpenFile(a.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o664)
+	if ferr != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
+
+// auditIdentity returns who is running jj-tui: jj's configured author ("name <email>") and the
+// OS login ("user@host").
+func (s *Service) auditIdentity() (jjUser, login string) {
+	ctx := context.Background()
+	name, _ := s.runJJOutputNoHistory(ctx, "config", "get", "user.name")
+	email, _ := s.runJJOutputNoHistory(ctx, "config", "get", "user.email")
+	name, email = strings.TrimSpace(name), strings.TrimSpace(email)
+	switch {
+	case name != "" && email != "":
+		jjUser = name + " <" + email + ">"
+	default:
+		jjUser = name + email
+	}
+	if u, err := user.Current(); err == nil {
+		login = u.Username
+	}
+	if host, err := os.Hostname(); err == nil && login != "" {
+		login += "@" + host
+	}
+	return jjUser, login
+}
+
+// ReadAuditLog returns the newest limit entries of the audit log at path (all when limit <= 0),
+// newest first. Lines that don't parse are skipped.
+func ReadAuditLog(path string, limit int) ([]AuditEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []AuditEntry
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for sc.Scan() {
+		var e AuditEntry
+		if json.Unmarshal(sc.Bytes(), &e) != nil || e.Command == "" {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, sc.Err()
+}
+
+// commandError returns jj's own error message for a failed command, or err's text when jj printed
+// none; "" when err is nil.
+func commandError(stdout, stderr string, err error) string {
+	if err == nil {
+		return ""
+	}
+	if msg := extractErrorMessage(stderr + "\n" + stdout); msg != "" {
+		return msg
+	}
+	return err.Error()
+}
@@ -0,0 +1,55 @@
+package jj
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// Mutating commands are recorded with who ran them, the revisions they named and the operation
+// they created; reads and commands declined in preview mode are not.
+func TestAuditLogRecordsMutations(t *testing.T) {
+	fakeJJ(t, `case "$*" in
+"config get user.name") echo Ada ;;
+"config get user.email") echo ada@example.com ;;
+abandon*) echo "Error: Revision zzz is immutable" >&2; exit 1 ;;
+esac`)
+	s, _ := cacheTestService(t)
+	if err := s.EnableAuditLog(); err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(s.RepoPath, ".jj", "repo", "jj-tui", "audit.jsonl"); s.AuditLogPath() != want {
+		t.Fatalf("path = %s, want %s", s.AuditLogPath(), want)
+	}
+	ctx := context.Background()
+	if err := s.runJJ(ctx, "rebase", "-s", "abc", "-d", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.runJJOutput(ctx, "log", "-r", "@"); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.runJJ(ctx, "abandon", "zzz")
+	s.PreviewCommand = func(context.Context, CommandPreview) bool { return false }
+	s.SetPreviewCommands(true)
+	_ = s.runJJ(ctx, "new")
+
+	entries, err := ReadAuditLog(s.AuditLogPath(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries: %+v", len(entries), entries)
+	}
+	failed, rebase := entries[0], entries[1]
+	if rebase.Command != "jj rebase -s abc -d main" || !rebase.OK || rebase.Op != "op1" ||
+		!slices.Equal(rebase.Revisions, []string{"abc", "main"}) || rebase.User != "Ada <ada@example.com>" {
+		t.Errorf("rebase entry = %+v", rebase)
+	}
+	if failed.Command != "jj abandon zzz" || failed.OK || failed.Error != "Revision zzz is immutable" || failed.Op != "" {
+		t.Errorf("abandon entry = %+v", failed)
+	}
+	if entries, _ := ReadAuditLog(s.AuditLogPath(), 1); len(entries) != 1 || entries[0].Command != "jj abandon zzz" {
+		t.Errorf("limited read = %+v", entries)
+	}
+}
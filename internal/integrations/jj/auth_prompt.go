@@ -0,0 +1,109 @@
+package jj
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// AuthPromptError is returned (wrapped) for a `jj git push` or `jj git fetch` that failed because
+// the remote wanted a passphrase, password or host key confirmation while InteractiveAuth had
+// prompts turned off. Run Args again with InteractiveCommand to let the user answer.
+type AuthPromptError struct {
+	Args   []string // the jj arguments, without the "jj"
+	Prompt string   // the line that gave the prompt away
+	err    error
+}
+
+func (e *AuthPromptError) Error() string {
+	return fmt.Sprintf("%s needs credentials from the terminal: %s", CommandLine(e.Args), e.Prompt)
+}
+
+func (e *AuthPromptError) Unwrap() error { return e.err }
+
+// wantsAuthPrompt reports whether err is (or wraps) an AuthPromptError. The git fallback is not
+// tried for those: plain git would need the same credentials.
+func wantsAuthPrompt(err error) bool {
+	var authErr *AuthPromptError
+	return errors.As(err, &authErr)
+}
+
+// authPromptMarkers are what git and ssh print when a prompt they would have shown was turned
+// off (GIT_TERMINAL_PROMPT=0, ssh BatchMode), matched case-insensitively.
+var authPromptMarkers = []string{
+	"terminal prompts disabled",
+	"could not read username",
+	"could not read password",
+	"passphrase",
+	"permission denied (publickey",
+	"host key verification failed",
+	"authentication required",
+}
+
+// authPromptLine returns the first line of output that shows the remote wanted a prompt, or "".
+func authPromptLine(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		lower := strings.ToLower(line)
+		for _, m := range authPromptMarkers {
+			if strings.Contains(lower, m) {
+				return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "Error:"))
+			}
+		}
+	}
+	return ""
+}
+
+// talksToRemote reports whether args is a `jj git push` or `jj git fetch`.
+func talksToRemote(args []string) bool {
+	var words []string
+	for _, a := range args {
+		if !strings.HasPrefix(a, "-") {
+			words = append(words, a)
+		}
+	}
+	return len(words) >= 2 && words[0] == "git" && (words[1] == "push" || words[1] == "fetch")
+}
+
+// noPromptEnv turns off the prompts git and ssh would otherwise wait on with no terminal to
+// answer them. A GIT_SSH_COMMAND the user set is left alone.
+func noPromptEnv() []string {
+	env := []string{"GIT_TERMINAL_PROMPT=0", "SSH_ASKPASS_REQUIRE=never"}
+	if os.Getenv("GIT_SSH_COMMAND") == "" {
+		env = append(env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	}
+	return env
+}
+
+// InteractiveCommand returns jj with args, to run with the terminal attached (tea.ExecProcess)
+// after an AuthPromptError, and done, which records the run in command history and the audit log
+// and turns a failure into jj's error message. jj's stderr still reaches the terminal.
+func (s *Service) InteractiveCommand(args []string) (cmd *exec.Cmd, done func(error) error) {
+	var stderr bytes.Buffer
+	cmd = exec.Command("jj", args...)
+	cmd.Dir = s.RepoPath
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	start := time.Now()
+	return cmd, func(err error) error {
+		entry := CommandHistoryEntry{
+			Command:   CommandLine(args),
+			Timestamp: start,
+			Duration:  time.Since(start),
+			Success:   err == nil,
+		}
+		if err != nil {
+			entry.Error = extractErrorMessage(stderr.String())
+			if entry.Error == "" {
+				entry.Error = err.Error()
+			}
+			err = fmt.Errorf("%s failed: %s", CommandLine(args), entry.Error)
+		}
+		s.addToHistory(entry)
+		s.recordAudit(args, start, "", stderr.String(), err)
+		return err
+	}
+}
@@ -0,0 +1,66 @@
+package jj
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// With InteractiveAuth on, fetches run with prompts turned off and a remote that wanted one comes
+// back as an AuthPromptError (the git fallback is not tried); the terminal re-run is recorded in
+// command history with jj's error.
+func TestInteractiveAuth(t *testing.T) {
+	log := fakeJJ(t, `case "$*" in
+"git fetch")
+  if [ "$GIT_TERMINAL_PROMPT" = 0 ]; then
+    echo "Error: fatal: could not read Username for 'https://example.com': terminal prompts disabled" >&2; exit 1
+  fi
+  echo "Error: Authentication failed" >&2; exit 1 ;;
+esac`)
+	fakeGit(t, log, "exit 1")
+	s := gitFallbackService(t)
+	s.maxHistory = 100
+	ctx := context.Background()
+
+	if _, err := s.FetchFromGit(ctx); wantsAuthPrompt(err) {
+		t.Fatalf("off: prompts should stay on, got %v", err)
+	}
+
+	s.InteractiveAuth, s.GitFallback = true, true
+	_, err := s.FetchFromGit(ctx)
+	var authErr *AuthPromptError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("on: err = %v, want an AuthPromptError", err)
+	}
+	if !slices.Equal(authErr.Args, []string{"git", "fetch"}) || !strings.Contains(authErr.Prompt, "terminal prompts disabled") {
+		t.Errorf("auth error = %+v", authErr)
+	}
+	if got := gitCalls(calls(t, log)); len(got) != 0 {
+		t.Errorf("git fallback ran: %v", got)
+	}
+
+	cmd, done := s.InteractiveCommand(authErr.Args)
+	err = done(cmd.Run())
+	if err == nil || !strings.Contains(err.Error(), "Authentication failed") {
+		t.Fatalf("terminal run err = %v", err)
+	}
+	history := s.GetCommandHistory()
+	if last := history[0]; last.Command != "jj git fetch" || last.Success || last.Error != "Authentication failed" {
+		t.Errorf("history entry = %+v", last)
+	}
+}
+
+func TestTalksToRemote(t *testing.T) {
+	for args, want := range map[string]bool{
+		"git push --bookmark main": true,
+		"--quiet git fetch":        true,
+		"git remote list":          false,
+		"log -r git":               false,
+	} {
+		if got := talksToRemote(strings.Fields(args)); got != want {
+			t.Errorf("talksToRemote(%q) = %v, want %v", args, got, want)
+		}
+	}
+}
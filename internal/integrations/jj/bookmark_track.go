@@ -0,0 +1,56 @@
+package jj
+
+import (
+	"context"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// TrackPushedBookmarks tracks name@remote for each pushed bookmark that the remote now has but jj
+// doesn't track yet, so ahead/behind counts work right after the first push of a new bookmark.
+// Recent jj versions track new remote bookmarks on push themselves, leaving nothing to do. It is
+// best-effort: the push already succeeded, so failures are ignored. Returns the names it tracked.
+func (s *Service) TrackPushedBookmarks(ctx context.Context, remote string, names ...string) []string {
+	if remote == "" {
+		remote = "origin"
+	}
+	args := []string{"bookmark", "list", "--all-remotes"}
+	for _, name := range names {
+		if name = util.LocalBookmarkName(util.BookmarkNameForRevset(strings.TrimSpace(name))); name != "" {
+			args = append(args, util.JJExactBookmarkPattern(name))
+		}
+	}
+	if len(args) == 3 {
+		return nil
+	}
+	out, err := s.runJJOutputNoHistory(ctx, args...)
+	if err != nil {
+		return nil
+	}
+	var tracked []string
+	for _, name := range untrackedRemoteBookmarks(out, remote) {
+		if s.TrackBranch(ctx, name, remote) == nil {
+			tracked = append(tracked, name)
+		}
+	}
+	return tracked
+}
+
+// untrackedRemoteBookmarks returns the bookmarks `jj bookmark list --all-remotes` shows as
+// untracked on remote: a top-level "name@remote: …" line, where tracked ones are an indented
+// "  @remote: …" line under the local bookmark.
+func untrackedRemoteBookmarks(out, remote string) []string {
+	var names []string
+	suffix := "@" + remote
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		head, _, ok := strings.Cut(line, ":")
+		if name, found := strings.CutSuffix(strings.TrimSpace(head), suffix); ok && found && name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
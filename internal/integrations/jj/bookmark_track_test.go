@@ -0,0 +1,29 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// Only bookmarks listed as untracked "name@origin" get tracked; one already tracked (the
+// indented "@origin" line under the local bookmark) is left alone.
+func TestTrackPushedBookmarks(t *testing.T) {
+	log := fakeJJ(t, `case "$2" in list) printf '%s\n' \
+		'feat: kxqv 1a2b Add feature' 'feat@origin: kxqv 1a2b Add feature' \
+		'fix: zzpl 3c4d Fix bug' '  @origin: zzpl 3c4d Fix bug' \
+		'wip@upstream: qqrs 5e6f WIP';; esac`)
+	s := &Service{RepoPath: t.TempDir()}
+
+	got := s.TrackPushedBookmarks(context.Background(), "origin", "feat", "fix", "wip")
+	if !reflect.DeepEqual(got, []string{"feat"}) {
+		t.Errorf("tracked = %q, want [feat]", got)
+	}
+	want := []string{
+		"bookmark list --all-remotes exact:feat exact:fix exact:wip",
+		"bookmark track feat@origin",
+	}
+	if c := calls(t, log); !reflect.DeepEqual(c, want) {
+		t.Errorf("calls = %q, want %q", c, want)
+	}
+}
@@ -0,0 +1,74 @@
+package jj
+
+import (
+	"context"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+// branchStatsTemplate prints each commit's ID, whether it is on trunk ("t") or not ("-"), its
+// parents' IDs, and the bookmarks on it ("name" or "name@remote"), tab-separated.
+const branchStatsTemplate = `commit_id.short(8) ++ "\t" ++ if(self.contained_in("::trunk()"), "t", "-") ++ "\t" ++ ` +
+	`parents.map(|p| p.commit_id().short(8)).join(",") ++ "\t" ++ ` +
+	`local_bookmarks.map(|b| b.name()).join(" ") ++ " " ++ remote_bookmarks.map(|b| b.name() ++ "@" ++ b.remote()).join(" ") ++ "\n"`
+
+// branchStatsRevset selects the branches ListBranches lists (the same remote bookmarks the
+// bookmark list flag shows), their commits not on trunk, and every trunk commit from their fork
+// points up to trunk().
+func (s *Service) branchStatsRevset() string {
+	refs := "bookmarks() | remote_bookmarks()"
+	if s.BookmarkListPreferTracked {
+		refs = "bookmarks() | tracked_remote_bookmarks() | (remote_bookmarks() & mine())"
+	}
+	stack := "trunk()..(" + refs + ")"
+	return "(" + stack + ") | (((" + refs + ") & ::trunk()) | roots(" + stack + ")-)::trunk()"
+}
+
+// enrichBranchStats sets Ahead and Behind (relative to trunk) on every branch from one query over
+// all their stacks and the trunk commits since their fork points. Both stay zero when the query
+// fails.
+func (s *Service) enrichBranchStats(ctx context.Context, branches []internal.Branch) {
+	if len(branches) == 0 {
+		return
+	}
+	out, err := s.runJJOutputCached(ctx, "log", "-r", s.branchStatsRevset(), "--no-graph", "-T", branchStatsTemplate)
+	if err != nil {
+		return
+	}
+	applyBranchStats(branches, out)
+}
+
+// applyBranchStats computes branch distances from branchStatsTemplate output. A conflicted
+// bookmark counts from all of its targets.
+func applyBranchStats(branches []internal.Branch, out string) {
+	g := newTrunkGraph()
+	tips := make(map[string][]string)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 4 {
+			continue
+		}
+		var parents []string
+		if fields[2] != "" {
+			parents = strings.Split(fields[2], ",")
+		}
+		g.add(fields[0], fields[1] == "t", parents)
+		for _, ref := range strings.Fields(fields[3]) {
+			tips[ref] = append(tips[ref], fields[0])
+		}
+	}
+	for i := range branches {
+		b := &branches[i]
+		ref := b.Name
+		if !b.IsLocal {
+			if b.Remote == "" {
+				continue
+			}
+			ref += "@" + b.Remote
+		}
+		if ids := tips[ref]; len(ids) > 0 {
+			b.Ahead, b.Behind = g.distance(ids...)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package jj
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+func TestBranchStatsRevset(t *testing.T) {
+	s := &Service{}
+	want := "(trunk()..(bookmarks() | remote_bookmarks())) | (((bookmarks() | remote_bookmarks()) & ::trunk()) | roots(trunk()..(bookmarks() | remote_bookmarks()))-)::trunk()"
+	if got := s.branchStatsRevset(); got != want {
+		t.Fatalf("revset = %s, want %s", got, want)
+	}
+	s.BookmarkListPreferTracked = true
+	if got := s.branchStatsRevset(); !strings.Contains(got, "tracked_remote_bookmarks() | (remote_bookmarks() & mine())") {
+		t.Fatalf("tracked revset = %s", got)
+	}
+}
+
+// Trunk t3 ← t2 ← t1 (main and main@origin at t3). feat (a2 ← a1) forked at t1 and is pushed
+// one commit behind (feat@origin at a1), old sits on trunk at t2, and split is conflicted between
+// b1 (on t3) and a1.
+func TestApplyBranchStats(t *testing.T) {
+	out := "b1\t-\tt3\tsplit \n" +
+		"a2\t-\ta1\tfeat \n" +
+		"t3\tt\tt2\tmain main@origin main@git\n" +
+		"t2\tt\tt1\told \n" +
+		"a1\t-\tt1\tsplit feat@origin\n" +
+		"t1\tt\t\t \n"
+	branches := []internal.Branch{
+		{Name: "feat", IsLocal: true},
+		{Name: "feat", Remote: "origin", IsTracked: true},
+		{Name: "main", IsLocal: true},
+		{Name: "main", Remote: "origin", IsTracked: true},
+		{Name: "old", IsLocal: true},
+		{Name: "split", IsLocal: true, HasConflict: true},
+		{Name: "gone", IsLocal: true}, // not in the output
+	}
+	applyBranchStats(branches, out)
+	want := [][2]int{{2, 2}, {1, 2}, {0, 0}, {0, 0}, {0, 1}, {2, 0}, {0, 0}}
+	for i, b := range branches {
+		if got := [2]int{b.Ahead, b.Behind}; got != want[i] {
+			t.Errorf("%s@%s: ahead/behind = %v, want %v", b.Name, b.Remote, got, want[i])
+		}
+	}
+}
+
+// All branches' stats come from a single jj log, however many branches there are.
+func TestListBranchesStatsInOneQuery(t *testing.T) {
+	log := fakeJJ(t, `case "$1" in
+bookmark) printf 'a: kkk 111 one\nb: lll 222 two\nc: mmm 333 three\n' ;;
+*) printf 'x\tt\t\ta b c\n' ;;
+esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	branches, err := s.ListBranches(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(branches) != 3 {
+		t.Fatalf("got %d branches", len(branches))
+	}
+	logs := 0
+	for _, c := range calls(t, log) {
+		if strings.HasPrefix(c, "log ") && strings.Contains(c, "trunk()") {
+			logs++
+		}
+	}
+	if logs != 1 {
+		t.Fatalf("ran %d stats queries, want 1:\n%s", logs, strings.Join(calls(t, log), "\n"))
+	}
+}
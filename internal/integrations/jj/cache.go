@@ -0,0 +1,130 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// queryCacheMaxEntries bounds the query cache; it is emptied when full.
+const queryCacheMaxEntries = 1024
+
+// queryCache holds read-only jj query output for one operation: every entry was produced at
+// op (the operation head IDs and the jj config files' modification times), so a new operation
+// (from jj-tui or any other jj process) or a config change makes them all stale and the next
+// lookup starts over. Config matters because revset aliases such as immutable_heads() and trunk()
+// change what a query returns without creating an operation.
+//
+// jj snapshots working-copy edits into a new operation only when a command runs, so the cache is
+// also cleared when the repo watcher sees an edit (see WatchRepo). Without a watcher, the periodic
+// graph reload snapshots them.
+type queryCache struct {
+	mu      sync.Mutex
+	op      string
+	entries map[string]string
+}
+
+func (c *queryCache) get(op, key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.op != op {
+		return "", false
+	}
+	out, ok := c.entries[key]
+	return out, ok
+}
+
+func (c *queryCache) put(op, key, out string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.op != op || len(c.entries) >= queryCacheMaxEntries {
+		c.op, c.entries = op, make(map[string]string)
+	}
+	c.entries[key] = out
+}
+
+func (c *queryCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.op, c.entries = "", nil
+}
+
+// InvalidateCache drops cached query output, for callers that know the repo changed in a way the
+// operation head doesn't show yet.
+func (s *Service) InvalidateCache() {
+	s.cache.clear()
+}
+
+// opHeadID identifies the repo's current operation by its op head file names ("" when they can't
+// be read, which turns caching off).
+func (s *Service) opHeadID() string {
+	dir, err := opHeadsDir(s.RepoPath)
+	if err != nil {
+		return ""
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) == 0 {
+		return ""
+	}
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.Name()
+	}
+	slices.Sort(names)
+	return strings.Join(names, ",")
+}
+
+// configStamp identifies the current state of the jj config files that can define revset
+// aliases: the repo's config.toml and the user config, by modification time.
+func configStamp(opHeads string) string {
+	paths := []string{filepath.Join(filepath.Dir(filepath.Dir(opHeads)), "config.toml")}
+	if env := os.Getenv("JJ_CONFIG"); env != "" {
+		paths = append(paths, filepath.SplitList(env)...)
+	} else {
+		if dir, err := os.UserConfigDir(); err == nil {
+			paths = append(paths, filepath.Join(dir, "jj", "config.toml"), filepath.Join(dir, "jj", "conf.d"))
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			paths = append(paths, filepath.Join(home, ".jjconfig.toml"))
+		}
+	}
+	var b strings.Builder
+	for _, p := range paths {
+		b.WriteByte('|')
+		if info, err := os.Stat(p); err == nil {
+			b.WriteString(strconv.FormatInt(info.ModTime().UnixNano(), 36))
+		}
+	}
+	return b.String()
+}
+
+// isConfigWrite reports whether args is `jj config set` or `jj config unset`.
+func isConfigWrite(args []string) bool {
+	return len(args) >= 2 && args[0] == "config" && (args[1] == "set" || args[1] == "unset")
+}
+
+// runJJOutputCached is runJJOutput for read-only queries whose output only depends on the
+// operation they run at: repeated calls within one operation are answered from the cache. Only
+// successful output is cached, and cache hits are not added to command history.
+func (s *Service) runJJOutputCached(ctx context.Context, args ...string) (string, error) {
+	op := s.opHeadID()
+	if op == "" {
+		return s.runJJOutput(ctx, args...)
+	}
+	if heads, err := opHeadsDir(s.RepoPath); err == nil {
+		op += configStamp(heads)
+	}
+	key := strings.Join(args, "\x00")
+	if out, ok := s.cache.get(op, key); ok {
+		return out, nil
+	}
+	out, err := s.runJJOutput(ctx, args...)
+	if err == nil {
+		s.cache.put(op, key, out)
+	}
+	return out, err
+}
@@ -0,0 +1,131 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// cacheTestService returns a service whose repo has one op head, and a func that moves it on.
+func cacheTestService(t *testing.T) (*Service, func(op string)) {
+	t.Helper()
+	dir := t.TempDir()
+	heads := filepath.Join(dir, ".jj", "repo", "op_heads", "heads")
+	if err := os.MkdirAll(heads, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	setOp := func(op string) {
+		entries, _ := os.ReadDir(heads)
+		for _, e := range entries {
+			_ = os.Remove(filepath.Join(heads, e.Name()))
+		}
+		if err := os.WriteFile(filepath.Join(heads, op), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	setOp("op1")
+	return &Service{RepoPath: dir}, setOp
+}
+
+// Repeated queries within one operation run jj once; a new operation or an invalidation runs it
+// again.
+func TestQueryCacheFollowsOpHead(t *testing.T) {
+	log := fakeJJ(t, `echo xx`)
+	s, setOp := cacheTestService(t)
+	ctx := context.Background()
+
+	for range 3 {
+		if ahead, behind := s.StackStats(ctx, "main"); ahead != 2 || behind != 2 {
+			t.Fatalf("stats = %d, %d", ahead, behind)
+		}
+	}
+	if n := len(calls(t, log)); n != 2 {
+		t.Fatalf("jj ran %d times, want 2 (ahead and behind once each)", n)
+	}
+
+	setOp("op2")
+	s.StackStats(ctx, "main")
+	if n := len(calls(t, log)); n != 4 {
+		t.Fatalf("a new operation should re-run the queries, jj ran %d times", n)
+	}
+
+	s.InvalidateCache()
+	s.IsCommitMutable(ctx, "abc")
+	s.IsCommitMutable(ctx, "abc")
+	if n := len(calls(t, log)); n != 5 {
+		t.Fatalf("jj ran %d times, want 5", n)
+	}
+}
+
+// Failed queries are not cached, and without readable op heads nothing is.
+func TestQueryCacheSkipsErrorsAndUnknownOps(t *testing.T) {
+	log := fakeJJ(t, `exit 1`)
+	s, _ := cacheTestService(t)
+	ctx := context.Background()
+	s.IsCommitMutable(ctx, "abc")
+	s.IsCommitMutable(ctx, "abc")
+	if n := len(calls(t, log)); n < 2 {
+		t.Fatalf("errors should not be cached, jj ran %d times", n)
+	}
+
+	log = fakeJJ(t, `echo x`)
+	s = &Service{RepoPath: t.TempDir()}
+	s.IsCommitMutable(ctx, "abc")
+	s.IsCommitMutable(ctx, "abc")
+	if n := len(calls(t, log)); n != 2 {
+		t.Fatalf("without op heads every call should run jj, ran %d times", n)
+	}
+}
+
+// Without a repo watcher (safe mode, demo, or a failed watch) nothing clears the cache on a
+// config change, so config writes and edited config files must start it over by themselves:
+// immutable_heads() and trunk() change query results without a new operation.
+func TestQueryCacheFollowsConfigWithoutWatcher(t *testing.T) {
+	log := fakeJJ(t, `echo x`)
+	userConfig := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("JJ_CONFIG", userConfig)
+	s, _ := cacheTestService(t)
+	ctx := context.Background()
+	mutable := func() {
+		t.Helper()
+		s.IsCommitMutable(ctx, "abc")
+		s.IsCommitMutable(ctx, "abc")
+	}
+
+	mutable()
+	if n := len(calls(t, log)); n != 1 {
+		t.Fatalf("jj ran %d times, want 1", n)
+	}
+
+	if err := s.SetImmutableHeads(ctx, "main"); err != nil {
+		t.Fatal(err)
+	}
+	before := len(calls(t, log))
+	mutable()
+	if n := len(calls(t, log)); n != before+1 {
+		t.Fatalf("jj config set should start the cache over, jj ran %d more times", n-before)
+	}
+
+	for _, path := range []string{filepath.Join(s.RepoPath, ".jj", "repo", "config.toml"), userConfig} {
+		if err := os.WriteFile(path, []byte("[revset-aliases]\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		before = len(calls(t, log))
+		mutable()
+		if n := len(calls(t, log)); n != before+1 {
+			t.Fatalf("editing %s should start the cache over, jj ran %d more times", path, n-before)
+		}
+	}
+
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(userConfig, later, later); err != nil {
+		t.Fatal(err)
+	}
+	before = len(calls(t, log))
+	mutable()
+	if n := len(calls(t, log)); n != before+1 {
+		t.Fatalf("a changed config file should start the cache over, jj ran %d more times", n-before)
+	}
+}
@@ -0,0 +1,145 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// outgoingChangesLimit caps how many changes the Push tab lists.
+const outgoingChangesLimit = 100
+
+// outgoingChangesTemplate prints one change per line: short change ID, full commit ID, "empty",
+// "conflict", local bookmark names (space-separated) and the description's first line, tab-separated.
+const outgoingChangesTemplate = `change_id.shortest(8) ++ "\t" ++ commit_id ++ "\t" ++ if(empty, "empty") ++ "\t" ++ if(conflict, "conflict") ++ "\t" ++ local_bookmarks.map(|b| b.name()).join(" ") ++ "\t" ++ description.first_line() ++ "\n"`
+
+// OutgoingChange is a change in the working copy's stack that is not on the remote yet.
+type OutgoingChange struct {
+	PushPreviewCommit
+	Bookmarks []string // local bookmarks pointing at the change
+}
+
+// outgoingChangesRevset is the working copy's mutable ancestors not reachable from remote's bookmarks.
+func outgoingChangesRevset(head, remote string) string {
+	return fmt.Sprintf("(::%s ~ ::remote_bookmarks(remote=%s)) & mutable()", head, util.RevsetExactPattern(remote))
+}
+
+// OutgoingChanges lists the changes in @'s stack that are not on any of remote's bookmarks, newest
+// first, leaving out an empty undescribed working copy. truncated is set when there were more than
+// outgoingChangesLimit.
+func (s *Service) OutgoingChanges(ctx context.Context, remote string) (changes []OutgoingChange, truncated bool, err error) {
+	if remote == "" {
+		remote = "origin"
+	}
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", outgoingChangesRevset("@", remote), "--no-graph",
+		"--limit", fmt.Sprint(outgoingChangesLimit+1), "-T", outgoingChangesTemplate)
+	if err != nil {
+		return nil, false, err
+	}
+	changes = parseOutgoingChanges(out)
+	if len(changes) > outgoingChangesLimit {
+		changes, truncated = changes[:outgoingChangesLimit], true
+	}
+	return changes, truncated, nil
+}
+
+// parseOutgoingChanges parses outgoingChangesTemplate output.
+func parseOutgoingChanges(out string) []OutgoingChange {
+	var changes []OutgoingChange
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 6)
+		if len(parts) < 6 {
+			continue
+		}
+		c := OutgoingChange{
+			PushPreviewCommit: PushPreviewCommit{
+				ChangeID: parts[0],
+				CommitID: parts[1],
+				Empty:    parts[2] == "empty",
+				Conflict: parts[3] == "conflict",
+				Summary:  strings.TrimSpace(parts[5]),
+			},
+			Bookmarks: strings.Fields(parts[4]),
+		}
+		if c.Empty && c.Summary == "" && len(c.Bookmarks) == 0 {
+			continue
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// PushChange pushes a single change to remote with `jj git push --change`, which creates (or moves)
+// a bookmark named after the change. Returns jj's output.
+func (s *Service) PushChange(ctx context.Context, changeID, remote string) (string, error) {
+	if changeID == "" {
+		return "", fmt.Errorf("change ID is required")
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+	if err := s.checkSecretsBeforeChangePush(ctx, changeID, remote); err != nil {
+		return "", err
+	}
+	out, err := s.runJJOutput(ctx, "git", "push", "--change", changeID, "--remote", remote)
+	if err != nil {
+		return out, fmt.Errorf("push failed: %w", err)
+	}
+	return out, nil
+}
+
+// PushChangeForReview pushes commitID to refs/for/<branch> on remote, the Gerrit way of uploading
+// a change for review. It pushes with git directly because jj only pushes bookmarks. Gerrit needs
+// a Change-Id trailer on each commit; jj adds one when templates.commit_trailers is configured.
+// Returns git's output, which includes the review URL.
+func (s *Service) PushChangeForReview(ctx context.Context, commitID, remote, branch string) (string, error) {
+	if commitID == "" || branch == "" {
+		return "", fmt.Errorf("commit and target branch are required")
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+	gitDir := s.gitStoreDir()
+	if gitDir == "" {
+		return "", fmt.Errorf("no git store found in %s", s.RepoPath)
+	}
+	if err := s.checkSecretsBeforeChangePush(ctx, commitID, remote); err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, "git", "--git-dir", gitDir, "push", remote, commitID+":refs/for/"+branch)
+	cmd.Dir = s.RepoPath
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("push to refs/for/%s failed: %w\n%s", branch, err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// checkSecretsBeforeChangePush scans what pushing rev to remote would publish.
+func (s *Service) checkSecretsBeforeChangePush(ctx context.Context, rev, remote string) error {
+	if !s.SecretScan.Enabled {
+		return nil
+	}
+	revset := fmt.Sprintf("::%s ~ ::remote_bookmarks(remote=%s)", rev, util.RevsetExactPattern(remote))
+	return s.scanRevsetForSecrets(ctx, []string{rev}, revset)
+}
+
+// ReviewURL returns the first URL in the "remote:" lines of git push output, which is where
+// Gerrit reports the change it created ("" when there is none).
+func ReviewURL(pushOutput string) string {
+	for _, line := range strings.Split(pushOutput, "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), "remote:")
+		if !ok {
+			continue
+		}
+		for _, f := range strings.Fields(rest) {
+			if strings.HasPrefix(f, "https://") || strings.HasPrefix(f, "http://") {
+				return f
+			}
+		}
+	}
+	return ""
+}
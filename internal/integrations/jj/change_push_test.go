@@ -0,0 +1,36 @@
+package jj
+
+import "testing"
+
+func TestParseOutgoingChanges(t *testing.T) {
+	out := "kxqpmwvz\t0123456789abcdef0123456789abcdef01234567\t\t\tfeature\tAdd parser\n" +
+		"zzyyxxww\tfedcba9876543210fedcba9876543210fedcba98\tempty\t\t\t\n" +
+		"qrstuvwx\t1111111111111111111111111111111111111111\t\tconflict\ta b\tWIP: half done\n"
+	changes := parseOutgoingChanges(out)
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v, want 2 (empty working copy skipped)", changes)
+	}
+	first := changes[0]
+	if first.ChangeID != "kxqpmwvz" || first.CommitID != "0123456789abcdef0123456789abcdef01234567" || first.Summary != "Add parser" || len(first.Bookmarks) != 1 || first.Bookmarks[0] != "feature" {
+		t.Errorf("first = %+v", first)
+	}
+	second := changes[1]
+	if !second.Conflict || len(second.Bookmarks) != 2 || !second.LooksWIP() {
+		t.Errorf("second = %+v", second)
+	}
+}
+
+func TestReviewURL(t *testing.T) {
+	out := "remote: Processing changes: refs: 1, new: 1, done\n" +
+		"remote:\n" +
+		"remote: SUCCESS\n" +
+		"remote:\n" +
+		"remote:   https://review.example.org/c/project/+/4711 Add parser [NEW]\n" +
+		"To ssh://review.example.org:29418/project\n"
+	if got := ReviewURL(out); got != "https://review.example.org/c/project/+/4711" {
+		t.Errorf("ReviewURL = %q", got)
+	}
+	if got := ReviewURL("Everything up-to-date\n"); got != "" {
+		t.Errorf("ReviewURL = %q, want empty", got)
+	}
+}
@@ -5,12 +5,12 @@ import (
 )
 
 func TestParseChangedFilesStatLogOutput(t *testing.T) {
-	const sample = "README.md\tM\t4\t0\n src/main.go\tA\t8\t0\n"
+	const sample = "M\t4\t0\tREADME.md\nA\t8\t0\t src/main.go\nA\t1\t0\tdocs/tab\there.md\n"
 	files, err := parseChangedFilesStatLogOutput(sample)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(files) != 2 {
+	if len(files) != 3 {
 		t.Fatalf("len %d", len(files))
 	}
 	if files[0].Path != "README.md" || files[0].Status != "M" || files[0].LinesAdded != 4 || files[0].LinesRemoved != 0 || !files[0].StatsOK {
@@ -19,4 +19,7 @@ func TestParseChangedFilesStatLogOutput(t *testing.T) {
 	if files[1].Path != "src/main.go" || files[1].Status != "A" || files[1].LinesAdded != 8 || !files[1].StatsOK {
 		t.Errorf("second: %+v", files[1])
 	}
+	if files[2].Path != "docs/tab\there.md" || files[2].LinesAdded != 1 {
+		t.Errorf("third: %+v", files[2])
+	}
 }
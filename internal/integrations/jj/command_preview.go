@@ -0,0 +1,184 @@
+package jj
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal/events"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// The *Args functions build the jj arguments for destructive operations. The service runs them
+// and confirmation prompts show them (via CommandLine), so what the user approves is what runs.
+
+// AbandonArgs returns the arguments AbandonCommit runs.
+func AbandonArgs(changeID string) []string {
+	return []string{"abandon", changeID}
+}
+
+// SquashArgs returns the arguments SquashCommitWithMessage runs (SquashCommit passes it
+// SquashMessage).
+func SquashArgs(changeID, message string) []string {
+	return []string{"squash", "-r", changeID, jjMessageArg(message)}
+}
+
+// DeleteBookmarkArgs returns the arguments DeleteBookmark runs.
+func DeleteBookmarkArgs(name string) []string {
+	return []string{"bookmark", "delete", util.JJExactBookmarkPattern(name)}
+}
+
+// RevertFileArgs returns the arguments RevertFile runs: restore filePath in changeID from its
+// parents.
+func RevertFileArgs(changeID, filePath string) []string {
+	return []string{"restore", "--to", changeID, "--from", fmt.Sprintf("parents(%s)", changeID), "--", filePath}
+}
+
+// ForgetWorkspaceArgs returns the arguments ForgetWorkspace runs.
+func ForgetWorkspaceArgs(name string) []string {
+	return []string{"workspace", "forget", name}
+}
+
+// ResetSparseArgs returns the arguments ResetSparsePatterns runs.
+func ResetSparseArgs() []string {
+	return []string{"sparse", "reset"}
+}
+
+// MoveHunksArgs returns the two commands MoveHunks runs: the empty commit the hunks go to, then
+// the squash that moves them (MoveHunks adds the --config-file defining the tool).
+func MoveHunksArgs(commitID string, toChild bool) (newArgs, squashArgs []string) {
+	insert := "--insert-before"
+	if toChild {
+		insert = "--insert-after"
+	}
+	return []string{"new", insert, commitID, "-m", "(split)"},
+		[]string{"squash", "--from", commitID, "-m", "(split)", "--tool", "jj-tui-hunk-split"}
+}
+
+// DeleteMergedBookmarkArgs returns the two commands DeleteMergedBookmark runs: the local delete
+// and the push that deletes the bookmark on remote ("" = jj's default remote).
+func DeleteMergedBookmarkArgs(name, remote string) (deleteArgs, pushArgs []string) {
+	pattern := util.JJExactBookmarkPattern(name)
+	pushArgs = []string{"git", "push", "--bookmark", pattern}
+	if remote != "" {
+		pushArgs = append(pushArgs, "--remote", remote)
+	}
+	return []string{"bookmark", "delete", pattern}, pushArgs
+}
+
+// CommandLine formats args as the jj command line shown to the user, the way command history
+// records it.
+func CommandLine(args []string) string {
+	return "jj " + strings.Join(args, " ")
+}
+
+// ErrCommandCancelled is returned for a mutating command the user declined in preview mode.
+var ErrCommandCancelled = errors.New("command cancelled")
+
+// CommandPreview is a mutating jj command waiting for the user's approval in preview mode.
+type CommandPreview struct {
+	Command   string   // the command line, as CommandLine formats it
+	Revisions []string // "<change id> <first line>" for the revisions the command names
+}
+
+// previewRevisionLimit bounds how many revisions a preview lists.
+const previewRevisionLimit = 10
+
+// revisionFlags are the jj options whose value is a revset.
+var revisionFlags = map[string]bool{
+	"-r": true, "--revision": true, "--revisions": true, "-s": true, "--source": true,
+	"-b": true, "--branch": true, "-d": true, "--destination": true, "-o": true, "--onto": true,
+	"-A": true, "--insert-after": true, "-B": true, "--insert-before": true,
+	"-f": true, "--from": true, "--into": true, "-t": true, "--to": true,
+}
+
+// positionalRevisionCommands take their revisions as positional arguments.
+var positionalRevisionCommands = map[string]bool{
+	"abandon": true, "describe": true, "duplicate": true, "edit": true, "new": true, "parallelize": true,
+}
+
+// CommandRevisions returns the revsets a jj command names through revision options (-r, --from,
+// -d, …) and, for commands like abandon and new, its positional arguments. Paths after "--" and
+// option values that are not revsets are skipped.
+func CommandRevisions(args []string) []string {
+	var revs []string
+	command := ""
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			break
+		}
+		if name, value, ok := strings.Cut(a, "="); ok && revisionFlags[name] {
+			revs = append(revs, value)
+			continue
+		}
+		if revisionFlags[a] {
+			if i+1 < len(args) {
+				revs = append(revs, args[i+1])
+			}
+			i++
+			continue
+		}
+		if strings.HasPrefix(a, "-") {
+			if a == "-m" || a == "--message" {
+				i++
+			}
+			continue
+		}
+		if command == "" {
+			command = a
+		} else if positionalRevisionCommands[command] {
+			revs = append(revs, a)
+		}
+	}
+	return revs
+}
+
+// previewCommand asks PreviewCommand to approve a mutating command before execJJ runs it, when
+// preview mode is on. Read-only commands and `jj workspace update-stale` run without asking.
+func (s *Service) previewCommand(ctx context.Context, args []string) error {
+	if !s.previewCommands.Load() || s.PreviewCommand == nil || events.ReadOnly(args) || isUpdateStale(args) {
+		return nil
+	}
+	p := CommandPreview{Command: CommandLine(args), Revisions: s.describeRevisions(ctx, CommandRevisions(args))}
+	if !s.PreviewCommand(ctx, p) {
+		return ErrCommandCancelled
+	}
+	return nil
+}
+
+// SetPreviewCommands turns preview mode on or off; see PreviewCommand.
+func (s *Service) SetPreviewCommands(on bool) {
+	s.previewCommands.Store(on)
+}
+
+// PreviewsCommands reports whether preview mode is on.
+func (s *Service) PreviewsCommands() bool {
+	return s.previewCommands.Load()
+}
+
+// describeRevisions lists "<change id> <first line>" for the revisions in revsets, nil when
+// there are none or they don't resolve (e.g. a bookmark that doesn't exist yet).
+func (s *Service) describeRevisions(ctx context.Context, revsets []string) []string {
+	if len(revsets) == 0 {
+		return nil
+	}
+	parts := make([]string, len(revsets))
+	for i, r := range revsets {
+		parts[i] = "(" + r + ")"
+	}
+	out, err := s.runJJOutputNoHistory(ctx, "log", "--no-graph", "-r", strings.Join(parts, " | "),
+		"--limit", fmt.Sprint(previewRevisionLimit),
+		"-T", `change_id.shortest(8) ++ " " ++ if(description, description.first_line(), "(no description)") ++ "\n"`)
+	if err != nil {
+		return nil
+	}
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
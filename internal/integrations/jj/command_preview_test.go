@@ -0,0 +1,59 @@
+package jj
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCommandRevisions(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want []string
+	}{
+		{AbandonArgs("kxqv"), []string{"kxqv"}},
+		{SquashArgs("kxqv", "msg -r x"), []string{"kxqv"}},
+		{RevertFileArgs("kxqv", "-r.go"), []string{"kxqv", "parents(kxqv)"}},
+		{[]string{"rebase", "-s", "a", "-d", "b"}, []string{"a", "b"}},
+		{[]string{"new", "-m", "wip", "a", "b"}, []string{"a", "b"}},
+		{[]string{"bookmark", "set", "feat", "--revision=@-"}, []string{"@-"}},
+		{[]string{"git", "fetch"}, nil},
+	} {
+		if got := CommandRevisions(tc.args); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("CommandRevisions(%q) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestPreviewCommandCancels(t *testing.T) {
+	log := fakeJJ(t, `case "$1" in log) echo "kxqv Fix the parser";; esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	var asked []CommandPreview
+	s.PreviewCommand = func(_ context.Context, p CommandPreview) bool {
+		asked = append(asked, p)
+		return false
+	}
+	s.SetPreviewCommands(true)
+
+	if err := s.runJJ(context.Background(), AbandonArgs("kxqv")...); !errors.Is(err, ErrCommandCancelled) {
+		t.Fatalf("err = %v, want ErrCommandCancelled", err)
+	}
+	if _, err := s.runJJOutput(context.Background(), "log", "-r", "@"); err != nil {
+		t.Fatalf("read-only command: %v", err)
+	}
+	want := []CommandPreview{{Command: "jj abandon kxqv", Revisions: []string{"kxqv Fix the parser"}}}
+	if !reflect.DeepEqual(asked, want) {
+		t.Errorf("previews = %+v, want %+v", asked, want)
+	}
+	for _, c := range calls(t, log) {
+		if c == "abandon kxqv" {
+			t.Error("cancelled command ran")
+		}
+	}
+
+	s.SetPreviewCommands(false)
+	if err := s.runJJ(context.Background(), AbandonArgs("kxqv")...); err != nil || len(asked) != 1 {
+		t.Errorf("preview off: err=%v previews=%d", err, len(asked))
+	}
+}
@@ -0,0 +1,284 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConflictTake picks which side(s) of every conflict in a file to keep.
+type ConflictTake int
+
+const (
+	ConflictTakeLeft  ConflictTake = iota // first side (usually the destination / "ours")
+	ConflictTakeRight                     // second side (usually the rebased change / "theirs")
+	ConflictTakeBoth                      // first side followed by the second
+)
+
+func (t ConflictTake) String() string {
+	switch t {
+	case ConflictTakeLeft:
+		return "left"
+	case ConflictTakeRight:
+		return "right"
+	}
+	return "both"
+}
+
+// ConflictSide is one side of a conflict hunk: its label from the marker line and its content.
+type ConflictSide struct {
+	Label string
+	Lines []string
+}
+
+// ConflictHunk is one <<<<<<< … >>>>>>> region. Base is the common ancestor's content (empty
+// when the markers don't include it).
+type ConflictHunk struct {
+	Base  []string
+	Sides []ConflictSide
+}
+
+// ConflictRegion is either a run of plain lines (Hunk nil) or a conflict hunk.
+type ConflictRegion struct {
+	Lines []string
+	Hunk  *ConflictHunk
+}
+
+// ConflictFile is a file with conflict markers split into plain regions and hunks.
+type ConflictFile struct {
+	Regions         []ConflictRegion
+	trailingNewline bool
+}
+
+// Hunks returns the file's conflict hunks in order.
+func (f *ConflictFile) Hunks() []*ConflictHunk {
+	var out []*ConflictHunk
+	for _, r := range f.Regions {
+		if r.Hunk != nil {
+			out = append(out, r.Hunk)
+		}
+	}
+	return out
+}
+
+// Resolve returns the file's content with every hunk replaced by the side(s) take picks. Only
+// two-sided conflicts can be resolved this way; anything else needs a merge tool.
+func (f *ConflictFile) Resolve(take ConflictTake) (string, error) {
+	var lines []string
+	for _, r := range f.Regions {
+		if r.Hunk == nil {
+			lines = append(lines, r.Lines...)
+			continue
+		}
+		if len(r.Hunk.Sides) != 2 {
+			return "", fmt.Errorf("%d-sided conflict needs a merge tool", len(r.Hunk.Sides))
+		}
+		switch take {
+		case ConflictTakeLeft:
+			lines = append(lines, r.Hunk.Sides[0].Lines...)
+		case ConflictTakeRight:
+			lines = append(lines, r.Hunk.Sides[1].Lines...)
+		default:
+			lines = append(lines, r.Hunk.Sides[0].Lines...)
+			lines = append(lines, r.Hunk.Sides[1].Lines...)
+		}
+	}
+	out := strings.Join(lines, "\n")
+	if f.trailingNewline && len(lines) > 0 {
+		out += "\n"
+	}
+	return out, nil
+}
+
+// ParseConflictMarkers splits text into plain regions and conflict hunks. It understands jj's
+// "diff" marker style (%%%%%%% sections holding a diff from the base to a side, +++++++ side
+// snapshots, ------- base snapshots) and the git style (<<<<<<<, |||||||, =======, >>>>>>>).
+// Markers may be longer than 7 characters when the file itself contains marker-like lines.
+func ParseConflictMarkers(text string) (*ConflictFile, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	f := &ConflictFile{trailingNewline: strings.HasSuffix(text, "\n")}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	var plain []string
+	for i := 0; i < len(lines); i++ {
+		n, ok := markerLen(lines[i], '<')
+		if !ok {
+			plain = append(plain, lines[i])
+			continue
+		}
+		hunk, next, err := parseConflictHunk(lines, i, n)
+		if err != nil {
+			return nil, err
+		}
+		if len(plain) > 0 {
+			f.Regions = append(f.Regions, ConflictRegion{Lines: plain})
+			plain = nil
+		}
+		f.Regions = append(f.Regions, ConflictRegion{Hunk: hunk})
+		i = next
+	}
+	if len(plain) > 0 {
+		f.Regions = append(f.Regions, ConflictRegion{Lines: plain})
+	}
+	return f, nil
+}
+
+// parseConflictHunk parses the hunk whose <<<<<<< marker (n characters) is lines[start] and
+// returns it with the index of its >>>>>>> line.
+func parseConflictHunk(lines []string, start, n int) (*ConflictHunk, int, error) {
+	hunk := &ConflictHunk{}
+	if start+1 < len(lines) && isJJSectionMarker(lines[start+1], n) {
+		return parseJJConflictHunk(hunk, lines, start, n)
+	}
+
+	// Git style: side 1, optional ||||||| base, =======, side 2.
+	cur := &ConflictSide{Label: markerLabel(lines[start], n)}
+	var inBase bool
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case isMarker(line, '|', n):
+			hunk.Sides = append(hunk.Sides, *cur)
+			inBase = true
+		case isMarker(line, '=', n):
+			if !inBase {
+				hunk.Sides = append(hunk.Sides, *cur)
+			}
+			inBase = false
+			cur = &ConflictSide{}
+		case isMarker(line, '>', n):
+			cur.Label = markerLabel(line, n)
+			hunk.Sides = append(hunk.Sides, *cur)
+			return hunk, i, nil
+		case inBase:
+			hunk.Base = append(hunk.Base, line)
+		default:
+			cur.Lines = append(cur.Lines, line)
+		}
+	}
+	return nil, 0, fmt.Errorf("conflict starting on line %d has no closing marker", start+1)
+}
+
+func parseJJConflictHunk(hunk *ConflictHunk, lines []string, start, n int) (*ConflictHunk, int, error) {
+	const (
+		sectionNone = iota
+		sectionDiff
+		sectionSide
+		sectionBase
+	)
+	section := sectionNone
+	var cur *ConflictSide
+	flush := func() {
+		if cur != nil {
+			hunk.Sides = append(hunk.Sides, *cur)
+			cur = nil
+		}
+	}
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case isMarker(line, '%', n):
+			flush()
+			section = sectionDiff
+			cur = &ConflictSide{Label: fmt.Sprintf("side #%d", len(hunk.Sides)+1)}
+			if label := markerLabel(line, n); strings.HasPrefix(label, "Changes from base to ") {
+				cur.Label = strings.TrimPrefix(label, "Changes from base to ")
+			}
+		case isMarker(line, '\\', n):
+			// "to: <side>" continuation of a %%%%%%% header (jj 0.25+).
+			if cur != nil {
+				cur.Label = strings.TrimSpace(strings.TrimPrefix(markerLabel(line, n), "to:"))
+			}
+		case isMarker(line, '+', n):
+			flush()
+			section = sectionSide
+			cur = &ConflictSide{Label: strings.TrimPrefix(markerLabel(line, n), "Contents of ")}
+		case isMarker(line, '-', n):
+			flush()
+			section = sectionBase
+		case isMarker(line, '>', n):
+			flush()
+			return hunk, i, nil
+		case section == sectionDiff:
+			switch {
+			case strings.HasPrefix(line, "+"):
+				cur.Lines = append(cur.Lines, line[1:])
+			case strings.HasPrefix(line, "-"):
+				hunk.Base = append(hunk.Base, line[1:])
+			default:
+				ctx := strings.TrimPrefix(line, " ")
+				cur.Lines = append(cur.Lines, ctx)
+				hunk.Base = append(hunk.Base, ctx)
+			}
+		case section == sectionSide:
+			cur.Lines = append(cur.Lines, line)
+		case section == sectionBase:
+			hunk.Base = append(hunk.Base, line)
+		}
+	}
+	return nil, 0, fmt.Errorf("conflict starting on line %d has no closing marker", start+1)
+}
+
+// markerLen reports whether line is a run of at least 7 c's followed by a space or the end of
+// the line, and how long the run is.
+func markerLen(line string, c byte) (int, bool) {
+	n := 0
+	for n < len(line) && line[n] == c {
+		n++
+	}
+	if n < 7 || (n < len(line) && line[n] != ' ') {
+		return 0, false
+	}
+	return n, true
+}
+
+// isMarker reports whether line is a marker of exactly n c's.
+func isMarker(line string, c byte, n int) bool {
+	got, ok := markerLen(line, c)
+	return ok && got == n
+}
+
+func isJJSectionMarker(line string, n int) bool {
+	return isMarker(line, '%', n) || isMarker(line, '+', n) || isMarker(line, '-', n)
+}
+
+func markerLabel(line string, n int) string {
+	return strings.TrimSpace(line[n:])
+}
+
+// ReadConflictedFile returns path's content at revision with jj's conflict markers materialized.
+func (s *Service) ReadConflictedFile(ctx context.Context, revision, path string) (string, error) {
+	return s.runJJOutputNoHistory(ctx, "file", "show", "-r", revision, "--", path)
+}
+
+// ResolveWorkingCopyConflict rewrites path in the working copy with take's side(s) of every
+// conflict and snapshots it, which resolves the conflict in @.
+func (s *Service) ResolveWorkingCopyConflict(ctx context.Context, path string, take ConflictTake) error {
+	text, err := s.ReadConflictedFile(ctx, "@", path)
+	if err != nil {
+		return err
+	}
+	f, err := ParseConflictMarkers(text)
+	if err != nil {
+		return err
+	}
+	if len(f.Hunks()) == 0 {
+		return fmt.Errorf("%s has no conflict markers", path)
+	}
+	resolved, err := f.Resolve(take)
+	if err != nil {
+		return err
+	}
+	full := filepath.Join(s.RepoPath, filepath.FromSlash(path))
+	info, err := os.Stat(full)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(full, []byte(resolved), info.Mode().Perm()); err != nil {
+		return err
+	}
+	// Any jj command snapshots the working copy; status is the cheapest that does nothing else.
+	_, err = s.runJJOutput(ctx, "status")
+	return err
+}
@@ -0,0 +1,90 @@
+package jj
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConflictMarkers(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		labels     []string
+		base       string
+		left, both string
+	}{
+		{
+			name:   "jj diff style (old labels)",
+			text:   "head\n<<<<<<< Conflict 1 of 1\n%%%%%%% Changes from base to side #1\n keep\n-old\n+new left\n+++++++ Contents of side #2\nkeep\nnew right\n>>>>>>> Conflict 1 of 1 ends\ntail\n",
+			labels: []string{"side #1", "side #2"},
+			base:   "keep\nold",
+			left:   "head\nkeep\nnew left\ntail\n",
+			both:   "head\nkeep\nnew left\nkeep\nnew right\ntail\n",
+		},
+		{
+			name:   "jj diff style (commit labels)",
+			text:   "<<<<<<< conflict 1 of 1\n%%%%%%% diff from: vpx 38d4 \"base\"\n\\\\\\\\\\\\\\        to: rts 2768 \"mine\"\n-old\n+mine\n+++++++ ysr 7a20 \"theirs\"\ntheirs\n>>>>>>> conflict 1 of 1 ends\n",
+			labels: []string{`rts 2768 "mine"`, `ysr 7a20 "theirs"`},
+			base:   "old",
+			left:   "mine\n",
+			both:   "mine\ntheirs\n",
+		},
+		{
+			name:   "git style with base",
+			text:   "<<<<<<< Side #1 (Conflict 1 of 1)\nours\n||||||| Base\nold\n=======\ntheirs\n>>>>>>> Side #2 (Conflict 1 of 1 ends)",
+			labels: []string{"Side #1 (Conflict 1 of 1)", "Side #2 (Conflict 1 of 1 ends)"},
+			base:   "old",
+			left:   "ours",
+			both:   "ours\ntheirs",
+		},
+		{
+			name:   "longer markers",
+			text:   "<<<<<<<<<<< Conflict 1 of 1\n+++++++++++ left\n<<<<<<< not a marker here\n----------- base\nb\n+++++++++++ right\nr\n>>>>>>>>>>> Conflict 1 of 1 ends\n",
+			labels: []string{"left", "right"},
+			base:   "b",
+			left:   "<<<<<<< not a marker here\n",
+			both:   "<<<<<<< not a marker here\nr\n",
+		},
+	}
+	for _, tt := range tests {
+		f, err := ParseConflictMarkers(tt.text)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		hunks := f.Hunks()
+		if len(hunks) != 1 || len(hunks[0].Sides) != 2 {
+			t.Fatalf("%s: hunks = %+v", tt.name, hunks)
+		}
+		for i, want := range tt.labels {
+			if got := hunks[0].Sides[i].Label; got != want {
+				t.Errorf("%s: side %d label = %q, want %q", tt.name, i, got, want)
+			}
+		}
+		if got := strings.Join(hunks[0].Base, "\n"); got != tt.base {
+			t.Errorf("%s: base = %q, want %q", tt.name, got, tt.base)
+		}
+		if got, _ := f.Resolve(ConflictTakeLeft); got != tt.left {
+			t.Errorf("%s: left = %q, want %q", tt.name, got, tt.left)
+		}
+		if got, _ := f.Resolve(ConflictTakeBoth); got != tt.both {
+			t.Errorf("%s: both = %q, want %q", tt.name, got, tt.both)
+		}
+	}
+}
+
+func TestParseConflictMarkers_Errors(t *testing.T) {
+	if _, err := ParseConflictMarkers("a\n<<<<<<< Conflict 1 of 1\n+++++++ side\nx\n"); err == nil {
+		t.Fatal("an unterminated conflict should fail to parse")
+	}
+	f, err := ParseConflictMarkers("<<<<<<< c\n+++++++ a\n1\n+++++++ b\n2\n+++++++ c\n3\n>>>>>>> c ends\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Resolve(ConflictTakeLeft); err == nil || !strings.Contains(err.Error(), "3-sided") {
+		t.Fatalf("three-sided resolve error = %v", err)
+	}
+	f, _ = ParseConflictMarkers("no markers\n")
+	if len(f.Hunks()) != 0 {
+		t.Fatal("plain text has no hunks")
+	}
+}
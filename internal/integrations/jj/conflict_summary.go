@@ -0,0 +1,59 @@
+package jj
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+// NewlyConflicted returns the commits in after that have conflicts but did not in before. Only
+// changes already in before count, so commits that merely came into view (a filter change, a
+// fetch) are not reported; a rebase or squash that conflicts keeps the change IDs it rewrites.
+func NewlyConflicted(before, after []internal.Commit) []internal.Commit {
+	wasConflicted := make(map[string]bool, len(before))
+	for _, c := range before {
+		wasConflicted[c.ChangeID] = wasConflicted[c.ChangeID] || c.Conflicts
+	}
+	var out []internal.Commit
+	for _, c := range after {
+		if was, seen := wasConflicted[c.ChangeID]; c.Conflicts && seen && !was {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// resolveListLineRE splits a `jj resolve --list` line into the path and the conflict
+// description ("2-sided conflict", "2-sided conflict including 1 deletion", …).
+var resolveListLineRE = regexp.MustCompile(`^(.*?)\s{2,}\S.*$`)
+
+// parseResolveList returns the paths in `jj resolve --list` output.
+func parseResolveList(out string) []string {
+	var paths []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if m := resolveListLineRE.FindStringSubmatch(line); m != nil {
+			paths = append(paths, m[1])
+		} else {
+			paths = append(paths, strings.TrimSpace(line))
+		}
+	}
+	return paths
+}
+
+// ConflictedPaths lists the conflicted files in revision (`jj resolve --list`).
+func (s *Service) ConflictedPaths(ctx context.Context, revision string) ([]string, error) {
+	out, err := s.runJJOutputNoHistory(ctx, "resolve", "--list", "-r", revision)
+	if err != nil {
+		if strings.Contains(err.Error(), "No conflicts found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseResolveList(out), nil
+}
@@ -0,0 +1,34 @@
+package jj
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+func TestNewlyConflicted(t *testing.T) {
+	before := []internal.Commit{
+		{ChangeID: "aaa"},
+		{ChangeID: "bbb", Conflicts: true},
+		{ChangeID: "ccc"},
+	}
+	after := []internal.Commit{
+		{ChangeID: "aaa", Conflicts: true}, // rebased into a conflict
+		{ChangeID: "bbb", Conflicts: true}, // already conflicted
+		{ChangeID: "ccc"},
+		{ChangeID: "ddd", Conflicts: true}, // not in the graph before
+	}
+	got := NewlyConflicted(before, after)
+	if len(got) != 1 || got[0].ChangeID != "aaa" {
+		t.Fatalf("NewlyConflicted = %+v, want only aaa", got)
+	}
+}
+
+func TestParseResolveList(t *testing.T) {
+	out := "src/main.go    2-sided conflict\nREADME with spaces.md    2-sided conflict including 1 deletion\n\n"
+	want := []string{"src/main.go", "README with spaces.md"}
+	if got := parseResolveList(out); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseResolveList = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,125 @@
+package jj
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateRevsetLayout is how range bounds are written into committer_date() patterns. Ranges are
+// whole local days, and jj reads a bare date as local midnight.
+const dateRevsetLayout = "2006-01-02"
+
+// DateRange restricts the commit graph to commits whose committer date is in [After, Before).
+// A zero bound leaves that side open; the zero DateRange means "no filter".
+type DateRange struct {
+	After  time.Time
+	Before time.Time
+	Label  string // shown in the graph header, e.g. "today" or "last 7 days"
+}
+
+// IsZero reports whether r filters nothing.
+func (r DateRange) IsZero() bool {
+	return r.After.IsZero() && r.Before.IsZero()
+}
+
+// Revset returns the committer_date() revset for r, or "" when r is zero.
+func (r DateRange) Revset() string {
+	var parts []string
+	if !r.After.IsZero() {
+		parts = append(parts, fmt.Sprintf("committer_date(after:%q)", r.After.Format(dateRevsetLayout)))
+	}
+	if !r.Before.IsZero() {
+		parts = append(parts, fmt.Sprintf("committer_date(before:%q)", r.Before.Format(dateRevsetLayout)))
+	}
+	return strings.Join(parts, " & ")
+}
+
+// ApplyToRevset narrows base (empty = DefaultGraphRevset) to commits in r. The working copy is
+// always kept so the graph never comes back empty. Returns base unchanged when r is zero.
+func (r DateRange) ApplyToRevset(base string) string {
+	clause := r.Revset()
+	if clause == "" {
+		return base
+	}
+	base = strings.TrimSpace(base)
+	if base == "" {
+		base = DefaultGraphRevset
+	}
+	return fmt.Sprintf("((%s) & %s) | @", base, clause)
+}
+
+// ParseDateRange turns what the user typed into the graph's date filter into a DateRange,
+// relative to now's local day. Accepted forms:
+//
+//	""/"all"              no filter
+//	"today", "yesterday"  that calendar day
+//	"week", "month"       the last 7 / 30 days including today
+//	"14d"                 the last N days including today
+//	"2026-10-01"          that day
+//	"2026-10-01..2026-10-08", "2026-10-01..", "..2026-10-08"  inclusive day range, either end open
+func ParseDateRange(input string, now time.Time) (DateRange, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	lastDays := func(n int) DateRange {
+		return DateRange{After: today.AddDate(0, 0, -(n - 1)), Label: fmt.Sprintf("last %d days", n)}
+	}
+	switch s {
+	case "", "all":
+		return DateRange{}, nil
+	case "today":
+		return DateRange{After: today, Label: "today"}, nil
+	case "yesterday":
+		return DateRange{After: today.AddDate(0, 0, -1), Before: today, Label: "yesterday"}, nil
+	case "week", "last week":
+		return lastDays(7), nil
+	case "month", "last month":
+		return lastDays(30), nil
+	}
+	if n, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil && strings.HasSuffix(s, "d") {
+		if n < 1 {
+			return DateRange{}, fmt.Errorf("day count must be at least 1")
+		}
+		return lastDays(n), nil
+	}
+
+	from, to, isRange := strings.Cut(s, "..")
+	if !isRange {
+		to = from
+	}
+	var r DateRange
+	if from = strings.TrimSpace(from); from != "" {
+		d, err := time.ParseInLocation(dateRevsetLayout, from, now.Location())
+		if err != nil {
+			return DateRange{}, badDateRange(input)
+		}
+		r.After = d
+	}
+	if to = strings.TrimSpace(to); to != "" {
+		d, err := time.ParseInLocation(dateRevsetLayout, to, now.Location())
+		if err != nil {
+			return DateRange{}, badDateRange(input)
+		}
+		r.Before = d.AddDate(0, 0, 1) // the end day is inclusive
+	}
+	switch {
+	case r.IsZero():
+		return DateRange{}, badDateRange(input)
+	case !r.After.IsZero() && !r.Before.IsZero() && !r.Before.After(r.After):
+		return DateRange{}, fmt.Errorf("date range ends before it starts")
+	case !isRange:
+		r.Label = from
+	case r.Before.IsZero():
+		r.Label = "since " + from
+	case r.After.IsZero():
+		r.Label = "until " + to
+	default:
+		r.Label = from + " – " + to
+	}
+	return r, nil
+}
+
+func badDateRange(input string) error {
+	return fmt.Errorf("unrecognized date range %q (try today, week, 14d, or 2026-10-01..2026-10-08)", strings.TrimSpace(input))
+}
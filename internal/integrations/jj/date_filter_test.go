@@ -0,0 +1,54 @@
+package jj
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2026, 10, 15, 14, 30, 0, 0, time.UTC)
+	cases := []struct {
+		in     string
+		revset string
+		label  string
+	}{
+		{"", "", ""},
+		{"today", `committer_date(after:"2026-10-15")`, "today"},
+		{"Yesterday", `committer_date(after:"2026-10-14") & committer_date(before:"2026-10-15")`, "yesterday"},
+		{"week", `committer_date(after:"2026-10-09")`, "last 7 days"},
+		{"14d", `committer_date(after:"2026-10-02")`, "last 14 days"},
+		{"2026-10-01", `committer_date(after:"2026-10-01") & committer_date(before:"2026-10-02")`, "2026-10-01"},
+		{"2026-10-01..2026-10-08", `committer_date(after:"2026-10-01") & committer_date(before:"2026-10-09")`, "2026-10-01 – 2026-10-08"},
+		{"2026-10-01..", `committer_date(after:"2026-10-01")`, "since 2026-10-01"},
+		{"..2026-10-08", `committer_date(before:"2026-10-09")`, "until 2026-10-08"},
+	}
+	for _, tc := range cases {
+		r, err := ParseDateRange(tc.in, now)
+		if err != nil {
+			t.Errorf("ParseDateRange(%q): %v", tc.in, err)
+			continue
+		}
+		if r.Revset() != tc.revset || r.Label != tc.label {
+			t.Errorf("ParseDateRange(%q) = %q (%q), want %q (%q)", tc.in, r.Revset(), r.Label, tc.revset, tc.label)
+		}
+	}
+
+	for _, bad := range []string{"soon", "0d", "2026-13-01", "2026-10-08..2026-10-01", ".."} {
+		if _, err := ParseDateRange(bad, now); err == nil {
+			t.Errorf("ParseDateRange(%q) should fail", bad)
+		}
+	}
+}
+
+func TestDateRangeApplyToRevset(t *testing.T) {
+	var none DateRange
+	if got := none.ApplyToRevset("all()"); got != "all()" {
+		t.Fatalf("zero range changed the revset: %q", got)
+	}
+	r, _ := ParseDateRange("today", time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))
+	got := r.ApplyToRevset("")
+	if !strings.HasPrefix(got, "(("+DefaultGraphRevset+") & committer_date(") || !strings.HasSuffix(got, " | @") {
+		t.Fatalf("ApplyToRevset = %q", got)
+	}
+}
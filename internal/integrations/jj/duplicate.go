@@ -0,0 +1,37 @@
+package jj
+
+import (
+	"context"
+	"strings"
+)
+
+// DuplicateCommit copies sourceCommitID onto destCommitID (`jj duplicate <source> -d <dest>`)
+// and returns the new commit's change ID, or "" when jj's output could not be parsed.
+func (s *Service) DuplicateCommit(ctx context.Context, sourceCommitID, destCommitID string) (string, error) {
+	out, err := s.runJJCombined(ctx, "duplicate", sourceCommitID, "-d", destCommitID)
+	if err != nil {
+		return "", err
+	}
+	return parseDuplicateOutput(out), nil
+}
+
+// parseDuplicateOutput returns the new change ID from "Duplicated <commit> as <change> <commit> ...".
+func parseDuplicateOutput(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 4 && fields[0] == "Duplicated" && fields[2] == "as" {
+			return fields[3]
+		}
+	}
+	return ""
+}
+
+// BackoutCommit creates a commit on top of the working copy that reverses commitID's changes.
+// It runs `jj revert`, falling back to `jj backout` on jj versions before revert existed.
+func (s *Service) BackoutCommit(ctx context.Context, commitID string) error {
+	_, err := s.runJJCombined(ctx, "revert", "-r", commitID, "-d", "@")
+	if err != nil && strings.Contains(err.Error(), "unrecognized subcommand") {
+		return s.runJJ(ctx, "backout", "-r", commitID, "-d", "@")
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseDuplicateOutput(t *testing.T) {
+	out := "Duplicated 3027ce6e as kpqxywon 5c7e2b1f Fix parser\n"
+	if got := parseDuplicateOutput(out); got != "kpqxywon" {
+		t.Fatalf("parseDuplicateOutput() = %q", got)
+	}
+	if got := parseDuplicateOutput("Nothing changed.\n"); got != "" {
+		t.Fatalf("unparseable output = %q", got)
+	}
+}
+
+func TestDuplicateCommit(t *testing.T) {
+	log := fakeJJ(t, `echo "Duplicated 3027ce6e as kpqxywon 5c7e2b1f Fix parser" >&2`)
+	s := &Service{RepoPath: t.TempDir()}
+	got, err := s.DuplicateCommit(context.Background(), "zsuskuln", "trunk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "kpqxywon" {
+		t.Fatalf("DuplicateCommit() = %q", got)
+	}
+	if want := []string{"duplicate zsuskuln -d trunk"}; !reflect.DeepEqual(calls(t, log), want) {
+		t.Fatalf("calls = %q, want %q", calls(t, log), want)
+	}
+}
+
+// jj versions before `jj revert` fall back to `jj backout`.
+func TestBackoutCommitFallsBackToBackout(t *testing.T) {
+	log := fakeJJ(t, `case "$1" in revert) echo "error: unrecognized subcommand 'revert'" >&2; exit 2 ;; esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	if err := s.BackoutCommit(context.Background(), "zsuskuln"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"revert -r zsuskuln -d @", "backout -r zsuskuln -d @"}
+	if got := calls(t, log); !reflect.DeepEqual(got, want) {
+		t.Fatalf("calls = %q, want %q", got, want)
+	}
+}
@@ -13,13 +13,16 @@ import (
 const EvologHunkSplitSpecEnv = "JJ_TUI_EVOLOG_HUNK_SPEC"
 
 // EvologHunkSplitSpec is written to a temp file; the diff-editor subcommand reads it from EvologHunkSplitSpecEnv.
+// SelectedByPath, when set, picks arbitrary hunks (0-based indexes) per path instead of a prefix.
 type EvologHunkSplitSpec struct {
-	GitDiff      string         `json:"git_diff"`
-	PrefixByPath map[string]int `json:"prefix_by_path"`
+	GitDiff        string           `json:"git_diff"`
+	PrefixByPath   map[string]int   `json:"prefix_by_path"`
+	SelectedByPath map[string][]int `json:"selected_by_path,omitempty"`
 }
 
 // RunEvologHunkSplitDiffEditor is the entry point for `jj-tui diff-editor-evolog-hunk-split $left $right $output`.
-// It rewrites files under outputDir to match parent + the first k hunks per path (see spec).
+// It rewrites files under outputDir to match parent + the first k hunks per path, or parent + the
+// selected hunks per path when the spec has SelectedByPath.
 func RunEvologHunkSplitDiffEditor(leftDir, rightDir, outputDir string) error {
 	specPath := strings.TrimSpace(os.Getenv(EvologHunkSplitSpecEnv))
 	if specPath == "" {
@@ -44,7 +47,7 @@ func RunEvologHunkSplitDiffEditor(leftDir, rightDir, outputDir string) error {
 	if prefix == nil {
 		prefix = map[string]int{}
 	}
-	return writeHunkSplitOutputDirs(leftDir, rightDir, outputDir, hunksPerPath, binaryPaths, prefix)
+	return writeHunkSplitOutputDirs(leftDir, rightDir, outputDir, hunksPerPath, binaryPaths, prefix, spec.SelectedByPath)
 }
 
 // normalizeTreeTextForHunkSplit aligns working-tree bytes with git-unified parsing (CRLF, UTF-8 BOM).
@@ -54,7 +57,7 @@ func normalizeTreeTextForHunkSplit(s string) string {
 	return s
 }
 
-func writeHunkSplitOutputDirs(leftDir, rightDir, outputDir string, hunksPerPath map[string][]UnifiedHunk, binaryPaths map[string]struct{}, prefix map[string]int) error {
+func writeHunkSplitOutputDirs(leftDir, rightDir, outputDir string, hunksPerPath map[string][]UnifiedHunk, binaryPaths map[string]struct{}, prefix map[string]int, selected map[string][]int) error {
 	seen := make(map[string]struct{})
 	process := func(rel string) error {
 		rel = filepath.ToSlash(rel)
@@ -64,7 +67,7 @@ func writeHunkSplitOutputDirs(leftDir, rightDir, outputDir string, hunksPerPath
 		if _, ok := seen[rel]; ok {
 			return nil
 		}
-		if err := processHunkSplitRel(rel, leftDir, rightDir, outputDir, hunksPerPath, binaryPaths, prefix); err != nil {
+		if err := processHunkSplitRel(rel, leftDir, rightDir, outputDir, hunksPerPath, binaryPaths, prefix, selected); err != nil {
 			return err
 		}
 		seen[rel] = struct{}{}
@@ -112,7 +115,7 @@ func writeHunkSplitOutputDirs(leftDir, rightDir, outputDir string, hunksPerPath
 		if _, ok := seen[k]; ok {
 			continue
 		}
-		if err := processHunkSplitRel(k, leftDir, rightDir, outputDir, hunksPerPath, binaryPaths, prefix); err != nil {
+		if err := processHunkSplitRel(k, leftDir, rightDir, outputDir, hunksPerPath, binaryPaths, prefix, selected); err != nil {
 			return err
 		}
 		seen[k] = struct{}{}
@@ -120,7 +123,7 @@ func writeHunkSplitOutputDirs(leftDir, rightDir, outputDir string, hunksPerPath
 	return nil
 }
 
-func processHunkSplitRel(rel string, leftDir, rightDir, outputDir string, hunksPerPath map[string][]UnifiedHunk, binaryPaths map[string]struct{}, prefix map[string]int) error {
+func processHunkSplitRel(rel string, leftDir, rightDir, outputDir string, hunksPerPath map[string][]UnifiedHunk, binaryPaths map[string]struct{}, prefix map[string]int, selected map[string][]int) error {
 	rel = filepath.ToSlash(rel)
 	if rel == "." || rel == "" {
 		return nil
@@ -195,6 +198,9 @@ func processHunkSplitRel(rel string, leftDir, rightDir, outputDir string, hunksP
 		}
 		return os.WriteFile(outPath, rightRaw, 0o644)
 	}
+	if selected != nil {
+		return writeSelectedHunks(rel, outPath, leftStr, rightStr, lerr, rerr, hunks, selected[rel])
+	}
 	if k < 0 || k > len(hunks) {
 		return fmt.Errorf("%s: hunk prefix k=%d exceeds %d @@ hunks (k is how many leading hunks to peel, not a line number)", rel, k, len(hunks))
 	}
@@ -217,6 +223,29 @@ func processHunkSplitRel(rel string, leftDir, rightDir, outputDir string, hunksP
 	return os.WriteFile(outPath, []byte(outStr), 0o644)
 }
 
+// writeSelectedHunks writes parent + the selected hunks of rel to outPath. A file that only exists
+// on one side is left out of the output when that side's content is what results (an added file
+// with no hunks picked, or a deleted file with its hunk picked).
+func writeSelectedHunks(rel, outPath, leftStr, rightStr string, lerr, rerr error, hunks []UnifiedHunk, picked []int) error {
+	if err := VerifyUnifiedHunksReconstructRight(leftStr, rightStr, hunks); err != nil {
+		return fmt.Errorf("%s: %w", rel, err)
+	}
+	if (len(picked) == 0 && os.IsNotExist(lerr)) || (len(picked) == len(hunks) && os.IsNotExist(rerr)) {
+		if err := os.Remove(outPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	outStr, err := ApplyUnifiedHunkSelection(leftStr, hunks, picked)
+	if err != nil {
+		return fmt.Errorf("%s: %w", rel, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, []byte(outStr), 0o644)
+}
+
 func copyFileToOutput(leftDir, rightDir, outputDir, rel string) error {
 	// Prefer right (matches jj initial output copy); fall back to left.
 	for _, dir := range []string{rightDir, leftDir} {
@@ -0,0 +1,100 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// fileHistoryFieldSep separates template fields in FileHistory and AnnotateFile output.
+const fileHistoryFieldSep = "\x1f"
+
+// fileHistoryTemplate prints one line per commit: change ID, commit ID, author, age, and subject.
+const fileHistoryTemplate = `change_id.short(8) ++ "` + fileHistoryFieldSep + `" ++ ` +
+	`commit_id.short(8) ++ "` + fileHistoryFieldSep + `" ++ ` +
+	`author.name() ++ "` + fileHistoryFieldSep + `" ++ ` +
+	`author.timestamp().ago() ++ "` + fileHistoryFieldSep + `" ++ ` +
+	`if(description, description.first_line(), "(no description)") ++ "\n"`
+
+// annotateTemplate prints one line per file line: the commit that last changed it, its line
+// number, and the content (which keeps its own trailing newline).
+const annotateTemplate = `commit.change_id().short(8) ++ "` + fileHistoryFieldSep + `" ++ ` +
+	`commit.commit_id().short(8) ++ "` + fileHistoryFieldSep + `" ++ ` +
+	`commit.author().name() ++ "` + fileHistoryFieldSep + `" ++ ` +
+	`commit.author().timestamp().ago() ++ "` + fileHistoryFieldSep + `" ++ ` +
+	`line_number ++ "` + fileHistoryFieldSep + `" ++ content`
+
+// FileCommit is one commit in a file's history.
+type FileCommit struct {
+	ChangeID string
+	CommitID string
+	Author   string
+	Age      string // e.g. "3 days ago"
+	Summary  string
+}
+
+// AnnotatedLine is one line of a file with the commit that last changed it.
+type AnnotatedLine struct {
+	FileCommit
+	Line    int // 1-based
+	Content string
+}
+
+// FileHistory returns the commits among revision's ancestors that changed path, newest first
+// (`jj log -r ::revision -- path`). limit <= 0 means no limit.
+func (s *Service) FileHistory(ctx context.Context, revision, path string, limit int) ([]FileCommit, error) {
+	args := []string{"log", "-r", "::" + revision, "--no-graph", "-T", fileHistoryTemplate}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	args = append(args, "--", "root-file:"+quoteRevsetString(path))
+	out, err := s.runJJOutput(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	var commits []FileCommit
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimRight(line, "\r"), fileHistoryFieldSep, 5)
+		if len(parts) < 5 {
+			continue
+		}
+		commits = append(commits, FileCommit{ChangeID: parts[0], CommitID: parts[1], Author: parts[2], Age: parts[3], Summary: parts[4]})
+	}
+	return commits, nil
+}
+
+// AnnotateFile returns every line of path at revision with the commit that last changed it
+// (`jj file annotate`). path is relative to the repository root.
+func (s *Service) AnnotateFile(ctx context.Context, revision, path string) ([]AnnotatedLine, error) {
+	out, err := s.runJJOutput(ctx, "file", "annotate", "-r", revision, "-T", annotateTemplate, "--", path)
+	if err != nil {
+		return nil, err
+	}
+	return parseAnnotate(out)
+}
+
+// parseAnnotate parses annotateTemplate output. Content may itself contain the field separator,
+// so only the first five are split off.
+func parseAnnotate(out string) ([]AnnotatedLine, error) {
+	var lines []AnnotatedLine
+	for _, row := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if row == "" && len(lines) == 0 {
+			continue
+		}
+		parts := strings.SplitN(row, fileHistoryFieldSep, 6)
+		if len(parts) < 6 {
+			return nil, fmt.Errorf("unexpected jj file annotate output: %q", row)
+		}
+		n, err := strconv.Atoi(parts[4])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected jj file annotate line number: %q", parts[4])
+		}
+		lines = append(lines, AnnotatedLine{
+			FileCommit: FileCommit{ChangeID: parts[0], CommitID: parts[1], Author: parts[2], Age: parts[3]},
+			Line:       n,
+			Content:    strings.TrimRight(parts[5], "\r"),
+		})
+	}
+	return lines, nil
+}
@@ -0,0 +1,61 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFileHistory(t *testing.T) {
+	log := fakeJJ(t, `printf 'kxqpmwvz\03712ab34cd\037Ada\0373 days ago\037Fix parser\nzzzzzzzz\037deadbeef\037Bob\0371 year ago\037(no description)\n'`)
+	s := &Service{RepoPath: t.TempDir()}
+
+	got, err := s.FileHistory(context.Background(), "@", "src/a b.go", 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FileCommit{
+		{ChangeID: "kxqpmwvz", CommitID: "12ab34cd", Author: "Ada", Age: "3 days ago", Summary: "Fix parser"},
+		{ChangeID: "zzzzzzzz", CommitID: "deadbeef", Author: "Bob", Age: "1 year ago", Summary: "(no description)"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FileHistory() = %+v, want %+v", got, want)
+	}
+	b, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := string(b)
+	for _, part := range []string{"log -r ::@ --no-graph", "-n 50", `-- root-file:"src/a b.go"`} {
+		if !strings.Contains(call, part) {
+			t.Errorf("jj call %q missing %q", call, part)
+		}
+	}
+}
+
+func TestParseAnnotate(t *testing.T) {
+	out := "kxqpmwvz\x1f12ab34cd\x1fAda\x1f3 days ago\x1f1\x1fpackage main\n" +
+		"zzzzzzzz\x1fdeadbeef\x1fBob\x1f1 year ago\x1f2\x1f\n" +
+		"kxqpmwvz\x1f12ab34cd\x1fAda\x1f3 days ago\x1f3\x1ffunc main() {} // a\x1fb"
+	got, err := parseAnnotate(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("parseAnnotate() returned %d lines, want 3", len(got))
+	}
+	if got[0].ChangeID != "kxqpmwvz" || got[0].Line != 1 || got[0].Content != "package main" {
+		t.Errorf("line 1 = %+v", got[0])
+	}
+	if got[1].Author != "Bob" || got[1].Content != "" {
+		t.Errorf("an empty line should keep its commit, got %+v", got[1])
+	}
+	if got[2].Content != "func main() {} // a\x1fb" {
+		t.Errorf("content with the separator was split: %q", got[2].Content)
+	}
+	if _, err := parseAnnotate("garbage"); err == nil {
+		t.Error("unexpected output should be an error")
+	}
+}
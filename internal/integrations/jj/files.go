@@ -0,0 +1,27 @@
+package jj
+
+import (
+	"context"
+	"strings"
+)
+
+// ListFiles returns every file in revision's tree (`jj file list`), relative to the repository
+// root, in jj's (sorted) order.
+func (s *Service) ListFiles(ctx context.Context, revision string) ([]string, error) {
+	out, err := s.runJJOutput(ctx, "file", "list", "-r", revision)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, line := range strings.Split(out, "\n") {
+		if p := strings.TrimRight(line, "\r"); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// ShowFile returns path's content at revision. path is taken literally (not as a fileset pattern).
+func (s *Service) ShowFile(ctx context.Context, revision, path string) (string, error) {
+	return s.runJJOutput(ctx, "file", "show", "-r", revision, "--", "root-file:"+quoteRevsetString(path))
+}
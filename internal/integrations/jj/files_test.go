@@ -0,0 +1,25 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestListAndShowFiles(t *testing.T) {
+	log := fakeJJ(t, `[ "$2" = list ] && printf 'README.md\nsrc/a b.go\n'; [ "$2" = show ] && printf 'content'; exit 0`)
+	s := &Service{RepoPath: t.TempDir()}
+	ctx := context.Background()
+
+	paths, err := s.ListFiles(ctx, "kxqpmwvz")
+	if err != nil || !reflect.DeepEqual(paths, []string{"README.md", "src/a b.go"}) {
+		t.Fatalf("ListFiles() = %q, %v", paths, err)
+	}
+	if out, err := s.ShowFile(ctx, "kxqpmwvz", "src/a b.go"); err != nil || out != "content" {
+		t.Fatalf("ShowFile() = %q, %v", out, err)
+	}
+	want := []string{"file list -r kxqpmwvz", `file show -r kxqpmwvz -- root-file:"src/a b.go"`}
+	if got := calls(t, log); !reflect.DeepEqual(got, want) {
+		t.Errorf("jj calls = %q, want %q", got, want)
+	}
+}
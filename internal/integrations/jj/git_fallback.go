@@ -0,0 +1,101 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/madicen/jj-tui/internal/events"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// gitFallbackPush pushes branch with plain git after `jj git push` failed: jj exports the
+// bookmark to the repo's git store, git pushes it to jj's push remote (git.push, else origin),
+// and jj imports the updated remote ref so branch@<remote> moves too. Like jj, the push is
+// leased on the commit jj last saw on the remote, so work someone else pushed there since is
+// never overwritten. The git push is reported on the --events stream as jj's would be. Only used
+// when GitFallback is on.
+func (s *Service) gitFallbackPush(ctx context.Context, branch string) (string, error) {
+	remote := s.pushRemote(ctx)
+	expected, err := s.remoteBookmarkCommit(ctx, branch, remote)
+	if err != nil {
+		return "", err
+	}
+	if err := s.runJJ(ctx, "git", "export"); err != nil {
+		return "", err
+	}
+	ref := "refs/heads/" + branch
+	args := []string{"push", "--force-with-lease=" + ref + ":" + expected, remote, ref + ":" + ref}
+	start := time.Now()
+	out, err := s.runGit(ctx, args...)
+	e := events.Event{
+		Type:       events.TypePushFinished,
+		Repo:       s.RepoPath,
+		Command:    "git " + strings.Join(args, " "),
+		DurationMS: time.Since(start).Milliseconds(),
+		OK:         events.Bool(err == nil),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	events.Emit(e)
+	if err != nil {
+		return out, err
+	}
+	return out, s.runJJ(ctx, "git", "import")
+}
+
+// pushRemote returns the remote `jj git push` uses by default: git.push from jj config, or origin.
+func (s *Service) pushRemote(ctx context.Context) string {
+	out, err := s.runJJOutputNoHistory(ctx, "config", "get", "git.push")
+	if remote := strings.TrimSpace(out); err == nil && remote != "" {
+		return remote
+	}
+	return "origin"
+}
+
+// remoteBookmarkCommit returns the full commit ID of branch@remote as jj last saw it, or "" when
+// the remote has no such bookmark (so a lease on it expects the ref not to exist).
+func (s *Service) remoteBookmarkCommit(ctx context.Context, branch, remote string) (string, error) {
+	rev := fmt.Sprintf("remote_bookmarks(%s, %s)", util.RevsetExactPattern(branch), util.RevsetExactPattern(remote))
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", rev, "--no-graph", "-T", `commit_id ++ "\n"`)
+	if err != nil {
+		return "", fmt.Errorf("read %s@%s: %w", branch, remote, err)
+	}
+	ids := strings.Fields(out)
+	if len(ids) > 1 {
+		return "", fmt.Errorf("%s@%s is conflicted; resolve it before pushing with git", branch, remote)
+	}
+	if len(ids) == 0 {
+		return "", nil
+	}
+	return ids[0], nil
+}
+
+// gitFallbackFetch fetches origin with plain git after `jj git fetch` failed, then imports the
+// fetched refs. Only used when GitFallback is on.
+func (s *Service) gitFallbackFetch(ctx context.Context) (string, error) {
+	out, err := s.runGit(ctx, "fetch", "origin")
+	if err != nil {
+		return out, err
+	}
+	return out, s.runJJ(ctx, "git", "import")
+}
+
+// runGit runs git against the repo's git store (.git when colocated, else .jj/repo/store/git) and
+// returns its combined output.
+func (s *Service) runGit(ctx context.Context, args ...string) (string, error) {
+	gitDir := s.gitStoreDir()
+	if gitDir == "" {
+		return "", fmt.Errorf("no git store found in %s", s.RepoPath)
+	}
+	cmd := exec.CommandContext(ctx, "git", append([]string{"--git-dir", gitDir}, args...)...)
+	cmd.Dir = s.RepoPath
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("git %s: %w\n%s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
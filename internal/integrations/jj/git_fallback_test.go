@@ -0,0 +1,162 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/events"
+)
+
+// fakeGit puts a git on PATH that logs "git <args>" to log (shared with fakeJJ) and runs body.
+func fakeGit(t *testing.T, log, body string) {
+	t.Helper()
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"git $*\" >> " + log + "\n" + body + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "git"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// gitFallbackService returns a service for a non-colocated repo (git store under .jj/repo).
+func gitFallbackService(t *testing.T) *Service {
+	t.Helper()
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".jj", "repo", "store", "git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return &Service{RepoPath: repo}
+}
+
+func gitCalls(all []string) []string {
+	var out []string
+	for _, c := range all {
+		if strings.HasPrefix(c, "git --git-dir") {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func TestPushToGitRunsOnlyJJ(t *testing.T) {
+	log := fakeJJ(t, `case "$*" in "bookmark list --all") echo "feat: abc" ;; esac`)
+	fakeGit(t, log, "")
+	s := gitFallbackService(t)
+	s.GitFallback = true
+	if _, err := s.PushToGit(context.Background(), "feat"); err != nil {
+		t.Fatal(err)
+	}
+	if g := gitCalls(calls(t, log)); len(g) != 0 {
+		t.Errorf("git ran after a successful jj push: %v", g)
+	}
+}
+
+func TestPushToGitFallback(t *testing.T) {
+	jjBody := `case "$*" in
+"bookmark list --all") echo "feat: abc" ;;
+"git push"*) echo "Error: failed to authenticate" >&2; exit 1 ;;
+esac`
+
+	t.Run("off", func(t *testing.T) {
+		log := fakeJJ(t, jjBody)
+		fakeGit(t, log, "")
+		_, err := gitFallbackService(t).PushToGit(context.Background(), "feat")
+		if err == nil || !strings.Contains(err.Error(), "failed to authenticate") {
+			t.Fatalf("err = %v, want jj's error", err)
+		}
+		if g := gitCalls(calls(t, log)); len(g) != 0 {
+			t.Errorf("git ran with the fallback off: %v", g)
+		}
+	})
+
+	t.Run("on", func(t *testing.T) {
+		log := fakeJJ(t, jjBody)
+		fakeGit(t, log, "")
+		emitted := captureEvents(t)
+		s := gitFallbackService(t)
+		s.GitFallback = true
+		if _, err := s.PushToGit(context.Background(), "feat"); err != nil {
+			t.Fatal(err)
+		}
+		got := calls(t, log)
+		gitDir := filepath.Join(s.RepoPath, ".jj", "repo", "store", "git")
+		push := "git --git-dir " + gitDir + " push --force-with-lease=refs/heads/feat: origin refs/heads/feat:refs/heads/feat"
+		i, j, k := slices.Index(got, "git export"), slices.Index(got, push), slices.Index(got, "git import")
+		if i < 0 || j < i || k < j {
+			t.Errorf("want jj git export, git push, jj git import in order; calls: %v", got)
+		}
+		var pushes []string
+		for _, e := range emitted() {
+			if e.Type == events.TypePushFinished {
+				pushes = append(pushes, fmt.Sprintf("%s ok=%v", e.Command, e.OK != nil && *e.OK))
+			}
+		}
+		wantPushes := []string{
+			"jj git push --bookmark exact:feat ok=false",
+			"git push --force-with-lease=refs/heads/feat: origin refs/heads/feat:refs/heads/feat ok=true",
+		}
+		if !slices.Equal(pushes, wantPushes) {
+			t.Errorf("push events = %q, want %q", pushes, wantPushes)
+		}
+	})
+
+	t.Run("push remote and lease", func(t *testing.T) {
+		log := fakeJJ(t, `case "$*" in
+"bookmark list --all") echo "feat: abc" ;;
+"git push"*) echo "Error: failed to authenticate" >&2; exit 1 ;;
+"config get git.push") echo "upstream" ;;
+"log -r remote_bookmarks(exact:\"feat\", exact:\"upstream\")"*) echo "0123abcd" ;;
+esac`)
+		fakeGit(t, log, "")
+		s := gitFallbackService(t)
+		s.GitFallback = true
+		if _, err := s.PushToGit(context.Background(), "feat"); err != nil {
+			t.Fatal(err)
+		}
+		g := gitCalls(calls(t, log))
+		if len(g) != 1 || !strings.HasSuffix(g[0], " push --force-with-lease=refs/heads/feat:0123abcd upstream refs/heads/feat:refs/heads/feat") {
+			t.Errorf("git calls = %v, want a push to upstream leased on 0123abcd", g)
+		}
+	})
+
+	t.Run("both fail", func(t *testing.T) {
+		log := fakeJJ(t, jjBody)
+		fakeGit(t, log, `echo "fatal: could not read Username" >&2; exit 128`)
+		s := gitFallbackService(t)
+		s.GitFallback = true
+		_, err := s.PushToGit(context.Background(), "feat")
+		if err == nil || !strings.Contains(err.Error(), "failed to authenticate") || !strings.Contains(err.Error(), "could not read Username") {
+			t.Fatalf("err = %v, want both errors", err)
+		}
+	})
+}
+
+func TestFetchFromGitFallback(t *testing.T) {
+	jjBody := `case "$*" in "git fetch") echo "Error: Failed to update refs" >&2; exit 1 ;; esac`
+
+	log := fakeJJ(t, jjBody)
+	fakeGit(t, log, "")
+	if _, err := gitFallbackService(t).FetchFromGit(context.Background()); err == nil {
+		t.Fatal("fetch should fail with the fallback off")
+	}
+	if g := gitCalls(calls(t, log)); len(g) != 0 {
+		t.Errorf("git ran with the fallback off: %v", g)
+	}
+
+	log = fakeJJ(t, jjBody)
+	fakeGit(t, log, "")
+	s := gitFallbackService(t)
+	s.GitFallback = true
+	if _, err := s.FetchFromGit(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got := calls(t, log)
+	if g := gitCalls(got); len(g) != 1 || !strings.HasSuffix(g[0], " fetch origin") || !slices.Contains(got, "git import") {
+		t.Errorf("calls = %v, want git fetch origin then jj git import", got)
+	}
+}
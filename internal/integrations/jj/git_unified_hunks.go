@@ -177,6 +177,36 @@ func ApplyUnifiedHunkPrefix(orig string, hunks []UnifiedHunk, prefix int) (strin
 	return joinPatchLines(nl), nil
 }
 
+// ApplyUnifiedHunkSelection returns orig with only the hunks at the given indexes applied (any
+// subset, in any order; duplicates are ignored). An empty selection yields orig unchanged.
+func ApplyUnifiedHunkSelection(orig string, hunks []UnifiedHunk, selected []int) (string, error) {
+	pick := make([]bool, len(hunks))
+	n := 0
+	for _, i := range selected {
+		if i < 0 || i >= len(hunks) {
+			return "", fmt.Errorf("hunk %d out of range (0..%d)", i, len(hunks)-1)
+		}
+		if !pick[i] {
+			pick[i] = true
+			n++
+		}
+	}
+	if n == 0 {
+		return orig, nil
+	}
+	subset := make([]UnifiedHunk, 0, n)
+	for i, h := range hunks {
+		if pick[i] {
+			subset = append(subset, h)
+		}
+	}
+	nl, err := applyHunksToOriginalLines(splitPatchLines(orig), subset)
+	if err != nil {
+		return "", err
+	}
+	return joinPatchLines(nl), nil
+}
+
 func joinPatchLines(lines []string) string {
 	if len(lines) == 0 {
 		return ""
@@ -190,6 +220,10 @@ func applyHunksToOriginalLines(orig []string, hunks []UnifiedHunk) ([]string, er
 	for hi := range hunks {
 		h := hunks[hi]
 		wantStart := h.OldStart - 1
+		if h.OldCount == 0 {
+			// Pure insertion: OldStart is the line the new lines follow (0 = top of file).
+			wantStart = h.OldStart
+		}
 		if wantStart < idx {
 			return nil, fmt.Errorf("hunk %d: overlapping or out-of-order patch (cursor %d, want %d)", hi, idx, wantStart)
 		}
@@ -254,3 +254,38 @@ func TestSanitizeHunkPrefixMapAgainstDiff_clampsOversizedK(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// Pure insertions (OldCount 0) go after line OldStart, so a prefix of them applies at the right
+// place, including into an empty file, as the evolog prefix split needs.
+func TestApplyUnifiedHunkPrefixPureInsertion(t *testing.T) {
+	const git = `diff --git a/x.txt b/x.txt
+--- a/x.txt
++++ b/x.txt
+@@ -1,0 +2 @@
++b
+@@ -3,0 +5 @@
++d
+diff --git a/empty.txt b/empty.txt
+--- a/empty.txt
++++ b/empty.txt
+@@ -0,0 +1,2 @@
++first
++second
+`
+	hm, _, err := ParseGitUnifiedHunksPerPath(git)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left := "a\nc\ne\n"
+	got, err := ApplyUnifiedHunkPrefix(left, hm["x.txt"], 1)
+	if err != nil || got != "a\nb\nc\ne\n" {
+		t.Fatalf("prefix 1: %v %q", err, got)
+	}
+	if err := VerifyUnifiedHunksReconstructRight(left, "a\nb\nc\ne\nd\n", hm["x.txt"]); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ApplyUnifiedHunkPrefix("", hm["empty.txt"], 1)
+	if err != nil || got != "first\nsecond\n" {
+		t.Fatalf("empty file: %v %q", err, got)
+	}
+}
@@ -0,0 +1,32 @@
+package jj
+
+import (
+	"context"
+)
+
+// ParallelizeCommits makes a connected range of commits siblings (`jj parallelize`): each keeps
+// its changes but is rebased onto the range's parents, and the range's children get all of them
+// as parents.
+func (s *Service) ParallelizeCommits(ctx context.Context, changeIDs []string) error {
+	return s.runJJ(ctx, append([]string{"parallelize"}, changeIDs...)...)
+}
+
+// InsertEmptyCommit creates an empty commit directly before (as the new parent of) or after (as
+// the new child of) commitID and makes it the working copy; jj rebases the neighbours around it.
+func (s *Service) InsertEmptyCommit(ctx context.Context, commitID string, before bool) error {
+	flag := "--insert-after"
+	if before {
+		flag = "--insert-before"
+	}
+	return s.runJJ(ctx, "new", flag, commitID)
+}
+
+// NewMergeCommit creates a working-copy commit whose parents are all of revs (`jj new <rev>...`),
+// described with description unless it is empty.
+func (s *Service) NewMergeCommit(ctx context.Context, revs []string, description string) error {
+	args := []string{"new"}
+	if description != "" {
+		args = append(args, "-m", description)
+	}
+	return s.runJJ(ctx, append(args, revs...)...)
+}
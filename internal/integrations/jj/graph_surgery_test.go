@@ -0,0 +1,38 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGraphSurgeryCommands(t *testing.T) {
+	log := fakeJJ(t, `exit 0`)
+	s := &Service{RepoPath: t.TempDir()}
+	ctx := context.Background()
+	if err := s.ParallelizeCommits(ctx, []string{"kkmpptxz", "zsuskuln"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.InsertEmptyCommit(ctx, "kkmpptxz", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.InsertEmptyCommit(ctx, "zsuskuln", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.NewMergeCommit(ctx, []string{"kkmpptxz", "main", "zsuskuln"}, "Merge main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.NewMergeCommit(ctx, []string{"kkmpptxz", "zsuskuln"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"parallelize kkmpptxz zsuskuln",
+		"new --insert-before kkmpptxz",
+		"new --insert-after zsuskuln",
+		"new -m Merge main kkmpptxz main zsuskuln",
+		"new kkmpptxz zsuskuln",
+	}
+	if got := calls(t, log); !reflect.DeepEqual(got, want) {
+		t.Fatalf("calls = %q, want %q", got, want)
+	}
+}
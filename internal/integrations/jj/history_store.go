@@ -0,0 +1,164 @@
+package jj
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// persistedEntry is one line of the on-disk command history (JSON Lines).
+type persistedEntry struct {
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"`
+	DurationMS int64     `json:"duration_ms"`
+	OK         bool      `json:"ok"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// autoRefreshPrefixes are the background refresh commands; they are hidden from Help → History and
+// never written to the history file.
+var autoRefreshPrefixes = []string{
+	"jj log -r mutable()",
+	"jj log -r empty()",
+}
+
+// IsAutoRefreshCommand returns true if the command is part of auto-refresh (filtered from history).
+func IsAutoRefreshCommand(cmd string) bool {
+	for _, pattern := range autoRefreshPrefixes {
+		if strings.HasPrefix(cmd, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// HistoryFilePath returns the history file for repoPath: jj-tui/history/<hash>.jsonl in the user
+// cache directory, so each repo keeps its own history outside the working copy.
+func HistoryFilePath(repoPath string) string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	abs, err := filepath.Abs(repoPath)
+	if err != nil {
+		abs = repoPath
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(base, "jj-tui", "history", hex.EncodeToString(sum[:8])+".jsonl")
+}
+
+// EnableHistoryFile loads earlier sessions' commands from path (dropping entries older than
+// maxAge, when > 0, and all but the newest maxEntries) and appends this session's commands to it.
+// The file is rewritten on load when entries were dropped, so it never grows past one session
+// beyond the limits.
+func (s *Service) EnableHistoryFile(path string, maxAge time.Duration, maxEntries int) error {
+	if maxEntries <= 0 {
+		return nil
+	}
+	entries, pruned, err := loadHistoryFile(path, maxAge, maxEntries, time.Now())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if pruned {
+		if err := writeHistoryFile(path, entries); err != nil {
+			return err
+		}
+	}
+	s.historyMu.Lock()
+	defer s.historyMu.Unlock()
+	s.historyFile = path
+	s.maxHistory = max(s.maxHistory, maxEntries)
+	s.commandHistory = append(entries, s.commandHistory...)
+	if len(s.commandHistory) > s.maxHistory {
+		s.commandHistory = append([]CommandHistoryEntry(nil), s.commandHistory[len(s.commandHistory)-s.maxHistory:]...)
+	}
+	return nil
+}
+
+// loadHistoryFile reads path oldest first and reports whether any lines were dropped (expired,
+// over the cap, or unreadable).
+func loadHistoryFile(path string, maxAge time.Duration, maxEntries int, now time.Time) ([]CommandHistoryEntry, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	var out []CommandHistoryEntry
+	pruned := false
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for sc.Scan() {
+		var p persistedEntry
+		if json.Unmarshal(sc.Bytes(), &p) != nil || p.Command == "" {
+			pruned = true
+			continue
+		}
+		if maxAge > 0 && now.Sub(p.Time) > maxAge {
+			pruned = true
+			continue
+		}
+		out = append(out, CommandHistoryEntry{
+			Command:   p.Command,
+			Timestamp: p.Time,
+			Duration:  time.Duration(p.DurationMS) * time.Millisecond,
+			Success:   p.OK,
+			Error:     p.Error,
+		})
+	}
+	if len(out) > maxEntries {
+		out = out[len(out)-maxEntries:]
+		pruned = true
+	}
+	return out, pruned, sc.Err()
+}
+
+func writeHistoryFile(path string, entries []CommandHistoryEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, e := range entries {
+		writeHistoryLine(&buf, e)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// appendHistoryFile adds one entry to path. Errors are ignored: history is best effort and must
+// not fail the jj command that produced it.
+func appendHistoryFile(path string, e CommandHistoryEntry) {
+	if IsAutoRefreshCommand(e.Command) {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	var buf bytes.Buffer
+	writeHistoryLine(&buf, e)
+	_, _ = f.Write(buf.Bytes())
+}
+
+func writeHistoryLine(buf *bytes.Buffer, e CommandHistoryEntry) {
+	line, _ := json.Marshal(persistedEntry{
+		Time:       e.Timestamp,
+		Command:    e.Command,
+		DurationMS: e.Duration.Milliseconds(),
+		OK:         e.Success,
+		Error:      e.Error,
+	})
+	buf.Write(line)
+	buf.WriteByte('\n')
+}
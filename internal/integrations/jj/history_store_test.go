@@ -0,0 +1,60 @@
+package jj
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnableHistoryFileLoadsAndPrunes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Now()
+	for _, e := range []CommandHistoryEntry{
+		{Command: "jj old", Timestamp: now.Add(-40 * 24 * time.Hour), Success: true},
+		{Command: "jj first", Timestamp: now.Add(-2 * time.Hour), Success: true},
+		{Command: "jj second", Timestamp: now.Add(-time.Hour), Duration: 1500 * time.Millisecond, Error: "boom"},
+		{Command: "jj third", Timestamp: now.Add(-time.Minute), Success: true},
+	} {
+		appendHistoryFile(path, e)
+	}
+
+	s := &Service{maxHistory: 100}
+	if err := s.EnableHistoryFile(path, 30*24*time.Hour, 2); err != nil {
+		t.Fatal(err)
+	}
+	got := s.GetCommandHistory() // most recent first
+	if len(got) != 2 || got[0].Command != "jj third" || got[1].Command != "jj second" {
+		t.Fatalf("loaded %+v", got)
+	}
+	if got[1].Success || got[1].Error != "boom" || got[1].Duration != 1500*time.Millisecond {
+		t.Fatalf("fields not round-tripped: %+v", got[1])
+	}
+
+	data, _ := os.ReadFile(path)
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Fatalf("file should be pruned to 2 lines, has %d:\n%s", n, data)
+	}
+
+	s.addToHistory(CommandHistoryEntry{Command: "jj new", Timestamp: now, Success: true})
+	s.addToHistory(CommandHistoryEntry{Command: "jj log -r mutable() --no-graph", Timestamp: now, Success: true})
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), `"command":"jj new"`) {
+		t.Fatalf("new command not appended:\n%s", data)
+	}
+	if strings.Contains(string(data), "mutable()") {
+		t.Fatalf("auto-refresh command should not be saved:\n%s", data)
+	}
+}
+
+func TestEnableHistoryFileMissing(t *testing.T) {
+	s := &Service{maxHistory: 100}
+	path := filepath.Join(t.TempDir(), "sub", "history.jsonl")
+	if err := s.EnableHistoryFile(path, time.Hour, 10); err != nil {
+		t.Fatalf("missing file should not be an error: %v", err)
+	}
+	if len(s.GetCommandHistory()) != 0 {
+		t.Fatal("expected empty history")
+	}
+}
@@ -0,0 +1,112 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// HunkSplitFile is one file of a commit's diff as offered by the interactive hunk split: its @@
+// hunks in order, or Binary when the diff has none to pick.
+type HunkSplitFile struct {
+	Path   string
+	Hunks  []UnifiedHunk
+	Binary bool
+}
+
+// ParseHunkSplitFiles lists the files of a git unified diff with their hunks, sorted by path.
+// Files with neither hunks nor a binary marker (mode changes, empty files) are left out; they
+// stay in the commit when hunks are moved.
+func ParseHunkSplitFiles(gitDiff string) ([]HunkSplitFile, error) {
+	hunksPerPath, binaryPaths, err := ParseGitUnifiedHunksPerPath(gitDiff)
+	if err != nil {
+		return nil, err
+	}
+	var files []HunkSplitFile
+	for path, hunks := range hunksPerPath {
+		files = append(files, HunkSplitFile{Path: path, Hunks: hunks})
+	}
+	for path := range binaryPaths {
+		if _, ok := hunksPerPath[path]; !ok {
+			files = append(files, HunkSplitFile{Path: path, Binary: true})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// HunkSplitDiff returns the git diff of commitID against its parent(s) and its files with hunks.
+// Pass the same diff back to MoveHunks so it can tell whether the commit changed in between.
+func (s *Service) HunkSplitDiff(ctx context.Context, commitID string) (string, []HunkSplitFile, error) {
+	diff, err := s.runJJOutputNoHistory(ctx, "diff", "-r", commitID, "--git", "--color", "never")
+	if err != nil {
+		return "", nil, err
+	}
+	if len(diff) > evologHunkSplitDiffMaxBytes {
+		return "", nil, fmt.Errorf("diff is too large to split by hunk (%d bytes)", len(diff))
+	}
+	files, err := ParseHunkSplitFiles(diff)
+	if err != nil {
+		return "", nil, err
+	}
+	return diff, files, nil
+}
+
+// MoveHunks moves the selected hunks (0-based indexes per path) of commitID into a new parent
+// commit, or a new child commit when toChild is set, the hunk-level counterpart of
+// SplitFileToParent and MoveFileToChild. diff is the HunkSplitDiff the selection was made on; if
+// the commit's diff no longer matches it, nothing is changed. At least one hunk must stay behind.
+// If moving the hunks fails, the empty commit made for them is undone by restoring the operation
+// before it.
+func (s *Service) MoveHunks(ctx context.Context, commitID, diff string, selected map[string][]int, toChild bool) error {
+	picked := 0
+	for _, idx := range selected {
+		picked += len(idx)
+	}
+	if picked == 0 {
+		return fmt.Errorf("no hunks selected")
+	}
+	current, files, err := s.HunkSplitDiff(ctx, commitID)
+	if err != nil {
+		return err
+	}
+	if current != diff {
+		return fmt.Errorf("the commit changed since its hunks were loaded; reopen the hunk split")
+	}
+	total := 0
+	for _, f := range files {
+		total += len(f.Hunks)
+		if f.Binary {
+			total++
+		}
+	}
+	if picked >= total {
+		return fmt.Errorf("every hunk is selected; leave at least one in the commit")
+	}
+
+	specEnv, cfgPath, cleanup, err := writeHunkSplitToolFiles(EvologHunkSplitSpec{GitDiff: diff, SelectedByPath: selected})
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	s.ops.mu.Lock()
+	defer s.ops.mu.Unlock()
+	before, err := s.opLog(ctx, 1)
+	if err != nil {
+		return err
+	}
+	newArgs, squashArgs := MoveHunksArgs(commitID, toChild)
+	if err := s.runJJ(ctx, newArgs...); err != nil {
+		return fmt.Errorf("failed to create new commit: %w", err)
+	}
+	// The new commit is @; squash the selected hunks of commitID into it.
+	args := append([]string{"--config-file", cfgPath}, squashArgs...)
+	if err := s.runJJWithExtraEnv(ctx, []string{specEnv}, args); err != nil {
+		if rerr := s.runJJ(ctx, "op", "restore", before[0].ID); rerr != nil {
+			return fmt.Errorf("failed to move hunks: %w (and could not remove the empty split commit; run jj op restore %s yourself: %v)", err, shortOpID(before[0].ID), rerr)
+		}
+		return fmt.Errorf("failed to move hunks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const hunkSplitTestDiff = `diff --git a/a.txt b/a.txt
+--- a/a.txt
++++ b/a.txt
+@@ -1,3 +1,3 @@
+-one
++ONE
+ two
+ three
+@@ -6,3 +6,3 @@
+ six
+ seven
+-eight
++EIGHT
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+diff --git a/logo.png b/logo.png
+Binary files a/logo.png and b/logo.png differ
+diff --git a/new.txt b/new.txt
+new file mode 100644
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++hello
+`
+
+func TestParseHunkSplitFiles(t *testing.T) {
+	files, err := ParseHunkSplitFiles(hunkSplitTestDiff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range files {
+		got = append(got, f.Path)
+	}
+	if len(files) != 4 || got[0] != "a.txt" || got[2] != "logo.png" || !files[2].Binary || len(files[0].Hunks) != 2 {
+		t.Fatalf("files = %v (%+v)", got, files)
+	}
+}
+
+// The editor writes parent + the selected hunks: the second hunk of a.txt only, the deletion of
+// gone.txt, and nothing of new.txt (which then must not exist on the moved side).
+func TestWriteHunkSplitOutputDirs_Selection(t *testing.T) {
+	root := t.TempDir()
+	left, right, out := filepath.Join(root, "left"), filepath.Join(root, "right"), filepath.Join(root, "out")
+	write := func(dir, name, body string) {
+		t.Helper()
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(left, "a.txt", "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\n")
+	write(right, "a.txt", "ONE\ntwo\nthree\nfour\nfive\nsix\nseven\nEIGHT\n")
+	write(left, "gone.txt", "bye\n")
+	write(left, "logo.png", "old")
+	write(right, "logo.png", "new")
+	write(right, "new.txt", "hello\n")
+	// jj starts the output as a copy of the right side.
+	write(out, "a.txt", "ONE\ntwo\nthree\nfour\nfive\nsix\nseven\nEIGHT\n")
+	write(out, "logo.png", "new")
+	write(out, "new.txt", "hello\n")
+
+	hunks, binary, err := ParseGitUnifiedHunksPerPath(hunkSplitTestDiff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected := map[string][]int{"a.txt": {1}, "gone.txt": {0}}
+	if err := writeHunkSplitOutputDirs(left, right, out, hunks, binary, map[string]int{}, selected); err != nil {
+		t.Fatal(err)
+	}
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(out, name))
+		if os.IsNotExist(err) {
+			return "<missing>"
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	for name, want := range map[string]string{
+		"a.txt":    "one\ntwo\nthree\nfour\nfive\nsix\nseven\nEIGHT\n",
+		"gone.txt": "<missing>",
+		"logo.png": "old",
+		"new.txt":  "<missing>",
+	} {
+		if got := read(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestApplyUnifiedHunkSelection(t *testing.T) {
+	hunks, _, err := ParseGitUnifiedHunksPerPath(hunkSplitTestDiff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\n"
+	got, err := ApplyUnifiedHunkSelection(left, hunks["a.txt"], []int{0})
+	if err != nil || got != "ONE\ntwo\nthree\nfour\nfive\nsix\nseven\neight\n" {
+		t.Fatalf("first hunk: %v %q", err, got)
+	}
+	if got, err := ApplyUnifiedHunkSelection(left, hunks["a.txt"], nil); err != nil || got != left {
+		t.Fatalf("no hunks: %v %q", err, got)
+	}
+	if _, err := ApplyUnifiedHunkSelection(left, hunks["a.txt"], []int{2}); err == nil {
+		t.Fatal("out-of-range hunk should fail")
+	}
+}
+
+// When the squash that moves the hunks fails, the empty "(split)" commit is undone by restoring
+// the operation from before it.
+func TestMoveHunksRestoresOnFailure(t *testing.T) {
+	diffFile := filepath.Join(t.TempDir(), "diff")
+	if err := os.WriteFile(diffFile, []byte(hunkSplitTestDiff), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	log := fakeJJ(t, `case "$*" in
+diff*) cat `+diffFile+` ;;
+"op log"*) printf 'op-before\tsnapshot\n' ;;
+*squash*) echo "Error: tool failed" >&2; exit 1 ;;
+esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	err := s.MoveHunks(context.Background(), "abc", hunkSplitTestDiff, map[string][]int{"a.txt": {0}}, false)
+	if err == nil || !strings.Contains(err.Error(), "failed to move hunks") {
+		t.Fatalf("err = %v", err)
+	}
+	got := calls(t, log)
+	if last := got[len(got)-1]; last != "op restore op-before" {
+		t.Fatalf("last call = %q, want the operation before the split restored (calls %q)", last, got)
+	}
+}
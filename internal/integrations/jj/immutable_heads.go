@@ -0,0 +1,63 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// immutableHeadsKey is the jj config key of the immutable_heads() revset alias. Commits it
+// reaches are immutable, which is what "commit is immutable" errors are about.
+const immutableHeadsKey = `revset-aliases."immutable_heads()"`
+
+// BuiltinImmutableHeads is jj's default immutable_heads() definition.
+const BuiltinImmutableHeads = "builtin_immutable_heads()"
+
+// ImmutableHeadsPreset is a named immutable_heads() definition offered in Settings → Advanced.
+type ImmutableHeadsPreset struct {
+	Name        string
+	Revset      string // "" unsets the repo override so jj's default applies
+	Description string
+}
+
+// ImmutableHeadsPresets are common immutable_heads() choices, jj's default first.
+var ImmutableHeadsPresets = []ImmutableHeadsPreset{
+	{Name: "jj default", Revset: "", Description: "trunk, tags, and untracked remote bookmarks"},
+	{Name: "Trunk + tags", Revset: "present(trunk()) | tags()", Description: "remote bookmarks you haven't tracked stay editable"},
+	{Name: "Trunk only", Revset: "present(trunk())", Description: "only commits already in trunk are protected"},
+	{Name: "Release branches", Revset: BuiltinImmutableHeads + ` | remote_bookmarks(glob:"release/*")`, Description: "also protect pushed release/* branches"},
+	{Name: "Others' work", Revset: BuiltinImmutableHeads + " | (trunk().. & ~mine())", Description: "also protect commits authored by someone else"},
+}
+
+// ImmutableHeads returns the immutable_heads() definition jj uses in this repo (from any config
+// layer, or jj's built-in default).
+func (s *Service) ImmutableHeads(ctx context.Context) (string, error) {
+	out, err := s.runJJOutputNoHistory(ctx, "config", "get", immutableHeadsKey)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return BuiltinImmutableHeads, nil
+		}
+		return "", fmt.Errorf("read immutable_heads(): %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// SetImmutableHeads writes revset as immutable_heads() in the repo config after checking that jj
+// accepts it. An empty revset removes the repo override so user config or jj's default applies.
+func (s *Service) SetImmutableHeads(ctx context.Context, revset string) error {
+	revset = strings.TrimSpace(revset)
+	if revset == "" {
+		err := s.runJJ(ctx, "config", "unset", "--repo", immutableHeadsKey)
+		if err != nil && !strings.Contains(err.Error(), "not found") && !strings.Contains(err.Error(), "doesn't exist") {
+			return fmt.Errorf("reset immutable_heads(): %w", err)
+		}
+		return nil
+	}
+	if _, err := s.runJJOutputNoHistory(ctx, "log", "-r", revset, "--no-graph", "-T", "commit_id", "--limit", "1"); err != nil {
+		return fmt.Errorf("invalid revset: %w", err)
+	}
+	if err := s.runJJ(ctx, "config", "set", "--repo", immutableHeadsKey, revset); err != nil {
+		return fmt.Errorf("set immutable_heads(): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package jj
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// largeFilesLimit caps how many commits LargeFiles inspects.
+const largeFilesLimit = pushPreviewLimit
+
+// largeFilesTemplate prints a "commit" line per commit followed by one line per file it adds or
+// changes: status and path, tab-separated.
+const largeFilesTemplate = `"commit\t" ++ commit_id ++ "\t" ++ change_id.shortest(8) ++ "\n" ++ self.diff().files().map(|f| f.status() ++ "\t" ++ f.path().display() ++ "\n").join("")`
+
+// DefaultLargeFilePatterns are the paths flagged regardless of size: archives, database dumps,
+// binaries and key material that rarely belong in a commit.
+var DefaultLargeFilePatterns = []string{
+	"*.zip", "*.tar", "*.tar.gz", "*.tgz", "*.7z", "*.rar",
+	"*.sql", "*.dump", "*.sqlite", "*.db",
+	"*.exe", "*.dll", "*.so", "*.dylib", "*.jar",
+	"*.pem", "*.key", "*.p12", "*.pfx", ".env",
+}
+
+// LargeFileRules decides which files a push warns about: files over MaxBytes (0 = no size
+// limit) and files matching one of Patterns. A pattern without "/" matches the base name, one
+// with "/" the whole path (path.Match syntax).
+type LargeFileRules struct {
+	MaxBytes int64
+	Patterns []string
+}
+
+// IsZero reports whether r flags nothing.
+func (r LargeFileRules) IsZero() bool {
+	return r.MaxBytes <= 0 && len(r.Patterns) == 0
+}
+
+// match returns the first pattern p matches ("" when none).
+func (r LargeFileRules) match(p string) string {
+	for _, pat := range r.Patterns {
+		target := p
+		if !strings.Contains(pat, "/") {
+			target = path.Base(p)
+		}
+		if ok, _ := path.Match(pat, target); ok {
+			return pat
+		}
+	}
+	return ""
+}
+
+// LargeFile is a file a commit adds or changes that LargeFileRules flags.
+type LargeFile struct {
+	ChangeID string // short change ID of the commit with the flagged version
+	Path     string
+	Size     int64  // bytes; -1 when unknown
+	Pattern  string // pattern the path matched ("" when flagged for its size alone)
+}
+
+// Reason says why f was flagged, e.g. "12.4 MB" or "matches *.sql".
+func (f LargeFile) Reason() string {
+	var parts []string
+	if f.Size >= 0 {
+		parts = append(parts, formatByteSize(f.Size))
+	}
+	if f.Pattern != "" {
+		parts = append(parts, "matches "+f.Pattern)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// changedPath is a file version added or modified by a commit.
+type changedPath struct {
+	commitID string
+	changeID string
+	path     string
+}
+
+// LargeFiles returns the files the commits in revset add or change that s.LargeFileRules flags,
+// largest first. Every commit counts, so a dump added and deleted again later is still reported:
+// pushing publishes it in history either way. Sizes come from git (the repo's .git, or jj's
+// internal git store); without one, only patterns apply.
+func (s *Service) LargeFiles(ctx context.Context, revset string) ([]LargeFile, error) {
+	rules := s.LargeFileRules
+	if rules.IsZero() || strings.TrimSpace(revset) == "" {
+		return nil, nil
+	}
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", revset, "--no-graph", "--limit", fmt.Sprint(largeFilesLimit), "-T", largeFilesTemplate)
+	if err != nil {
+		return nil, err
+	}
+	changed := parseLargeFilesLog(out)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	var sizes []int64
+	if rules.MaxBytes > 0 {
+		sizes = s.blobSizes(ctx, changed)
+	}
+	byPath := map[string]LargeFile{}
+	for i, c := range changed {
+		size := int64(-1)
+		if sizes != nil {
+			size = sizes[i]
+		}
+		f := LargeFile{ChangeID: c.changeID, Path: c.path, Size: size, Pattern: rules.match(c.path)}
+		if f.Pattern == "" && (rules.MaxBytes <= 0 || size <= rules.MaxBytes) {
+			continue
+		}
+		if prev, ok := byPath[f.Path]; ok && prev.Size >= f.Size {
+			continue
+		}
+		byPath[f.Path] = f
+	}
+	files := make([]LargeFile, 0, len(byPath))
+	for _, f := range byPath {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Size != files[j].Size {
+			return files[i].Size > files[j].Size
+		}
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+// parseLargeFilesLog parses largeFilesTemplate output into the added and modified file versions;
+// removed files are skipped since pushing them publishes nothing new.
+func parseLargeFilesLog(out string) []changedPath {
+	var changed []changedPath
+	var commitID, changeID string
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 3)
+		switch {
+		case len(parts) == 3 && parts[0] == "commit":
+			commitID, changeID = parts[1], parts[2]
+		case len(parts) == 2 && commitID != "" && parts[0] != "removed" && parts[1] != "":
+			changed = append(changed, changedPath{commitID: commitID, changeID: changeID, path: parts[1]})
+		}
+	}
+	return changed
+}
+
+// blobSizes returns the size of each file version in bytes (-1 when unknown), asking git for all
+// of them in one `git cat-file --batch-check`. Returns nil when there is no git store to ask.
+func (s *Service) blobSizes(ctx context.Context, changed []changedPath) []int64 {
+	gitDir := s.gitStoreDir()
+	if gitDir == "" {
+		return nil
+	}
+	var in strings.Builder
+	for _, c := range changed {
+		in.WriteString(c.commitID + ":" + c.path + "\n")
+	}
+	cmd := exec.CommandContext(ctx, "git", "--git-dir", gitDir, "cat-file", "--batch-check")
+	cmd.Stdin = strings.NewReader(in.String())
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil
+	}
+	return parseBatchCheckSizes(out.String(), len(changed))
+}
+
+// parseBatchCheckSizes reads `git cat-file --batch-check` output, one "<oid> <type> <size>" or
+// "<object> missing" line per requested object, into n sizes.
+func parseBatchCheckSizes(out string, n int) []int64 {
+	sizes := make([]int64, n)
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	for i := range sizes {
+		sizes[i] = -1
+		if i >= len(lines) {
+			continue
+		}
+		fields := strings.Fields(lines[i])
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		if size, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			sizes[i] = size
+		}
+	}
+	return sizes
+}
+
+// gitStoreDir returns the git directory holding the repo's objects: .git in colocated repos,
+// otherwise jj's internal git backend store. "" when neither exists.
+func (s *Service) gitStoreDir() string {
+	for _, dir := range []string{
+		filepath.Join(s.RepoPath, ".git"),
+		filepath.Join(s.RepoPath, ".jj", "repo", "store", "git"),
+	} {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
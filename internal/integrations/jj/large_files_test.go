@@ -0,0 +1,60 @@
+package jj
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLargeFilesLog(t *testing.T) {
+	out := "commit\tc2\tkkkk\nadded\tdump.sql\nmodified\tsrc/main.go\n" +
+		"commit\tc1\tmmmm\nremoved\told.bin\nadded\tassets/logo.png\n"
+	want := []changedPath{
+		{commitID: "c2", changeID: "kkkk", path: "dump.sql"},
+		{commitID: "c2", changeID: "kkkk", path: "src/main.go"},
+		{commitID: "c1", changeID: "mmmm", path: "assets/logo.png"},
+	}
+	if got := parseLargeFilesLog(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLargeFilesLog = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseBatchCheckSizes(t *testing.T) {
+	out := "e69de29 blob 12\nc1:gone missing\n4b825dc tree 0\n"
+	want := []int64{12, -1, -1, -1}
+	if got := parseBatchCheckSizes(out, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBatchCheckSizes = %v, want %v", got, want)
+	}
+}
+
+func TestLargeFileRulesMatch(t *testing.T) {
+	r := LargeFileRules{Patterns: []string{"*.sql", "build/*", ".env"}}
+	tests := map[string]string{
+		"db/dump.sql":     "*.sql",
+		"build/app":       "build/*",
+		"src/build/app":   "",
+		"config/.env":     ".env",
+		"src/main.go":     "",
+		"docs/schema.sql": "*.sql",
+	}
+	for p, want := range tests {
+		if got := r.match(p); got != want {
+			t.Errorf("match(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestLargeFileReason(t *testing.T) {
+	tests := []struct {
+		f    LargeFile
+		want string
+	}{
+		{LargeFile{Size: 12 << 20}, "12.0 MB"},
+		{LargeFile{Size: -1, Pattern: "*.pem"}, "matches *.pem"},
+		{LargeFile{Size: 2048, Pattern: "*.sql"}, "2.0 KB, matches *.sql"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.Reason(); got != tt.want {
+			t.Errorf("Reason(%+v) = %q, want %q", tt.f, got, tt.want)
+		}
+	}
+}
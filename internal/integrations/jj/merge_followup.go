@@ -0,0 +1,39 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DeleteMergedBookmark deletes a merged PR's bookmark locally and pushes the deletion to remote.
+// Either side may already be gone (GitHub deleting the head branch and a fetch removes both), so a
+// missing bookmark is not an error; deleted reports whether anything was removed.
+func (s *Service) DeleteMergedBookmark(ctx context.Context, name, remote string) (deleted bool, err error) {
+	deleteArgs, pushArgs := DeleteMergedBookmarkArgs(name, remote)
+	if err := s.runJJ(ctx, deleteArgs...); err != nil {
+		if !isNoSuchBookmark(err) {
+			return false, err
+		}
+	} else {
+		deleted = true
+	}
+	if err := s.runJJ(ctx, pushArgs...); err != nil {
+		if !isNoSuchBookmark(err) {
+			return deleted, err
+		}
+		return deleted, nil
+	}
+	return true, nil
+}
+
+func isNoSuchBookmark(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "no matching bookmark") || strings.Contains(msg, "no such bookmark")
+}
+
+// RebaseDependentsOnto rebases the mutable children of commitID (and their descendants) onto
+// dest, abandoning commits the rebase empties, e.g. after the merged PR landed on trunk.
+func (s *Service) RebaseDependentsOnto(ctx context.Context, commitID, dest string) error {
+	return s.runJJ(ctx, "rebase", "-s", fmt.Sprintf("children(%s) & mutable()", commitID), "-d", dest, "--skip-emptied")
+}
@@ -0,0 +1,28 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// A bookmark GitHub already deleted (and the fetch removed locally) is not an error.
+func TestDeleteMergedBookmark(t *testing.T) {
+	log := fakeJJ(t, `case "$1" in bookmark) echo "Error: No matching bookmarks for names: fix" >&2; exit 1;; esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	deleted, err := s.DeleteMergedBookmark(context.Background(), "fix", "origin")
+	if err != nil || !deleted {
+		t.Fatalf("DeleteMergedBookmark() = %v, %v; want the push to delete it", deleted, err)
+	}
+	if err := s.RebaseDependentsOnto(context.Background(), "abc123", "trunk()"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"bookmark delete exact:fix",
+		"git push --bookmark exact:fix --remote origin",
+		"rebase -s children(abc123) & mutable() -d trunk() --skip-emptied",
+	}
+	if got := calls(t, log); !reflect.DeepEqual(got, want) {
+		t.Fatalf("calls = %q, want %q", got, want)
+	}
+}
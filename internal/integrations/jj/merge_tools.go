@@ -0,0 +1,111 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// MergeTool is a merge editor the conflict viewer can open a conflicted file in. jj ships a
+// merge-tools config for each of these, so picking one only passes `jj resolve --tool <Name>`;
+// nothing is written to the user's jj config.
+type MergeTool struct {
+	Name    string // jj merge-tools name
+	Label   string
+	Program string // executable that must be on PATH
+	// MergeArgs mirror jj's built-in merge-args for the tool: $left, $base, $right and $output
+	// stand for file paths. Used to test-launch the tool outside jj.
+	MergeArgs []string
+}
+
+// MergeTools are the merge tools offered in Settings → Advanced, in display order.
+var MergeTools = []MergeTool{
+	{Name: "meld", Label: "Meld", Program: "meld",
+		MergeArgs: []string{"$left", "$base", "$right", "-o", "$output", "--auto-merge"}},
+	{Name: "kdiff3", Label: "KDiff3", Program: "kdiff3",
+		MergeArgs: []string{"$base", "$left", "$right", "-o", "$output", "--auto"}},
+	{Name: "vimdiff", Label: "vimdiff", Program: "vim",
+		MergeArgs: []string{"-f", "-d", "$output", "-M", "$left", "$base", "$right",
+			"-c", "wincmd J", "-c", "set modifiable", "-c", "set write"}},
+	{Name: "vscode", Label: "VS Code", Program: "code",
+		MergeArgs: []string{"--wait", "--merge", "$left", "$right", "$base", "$output"}},
+}
+
+// LookupMergeTool finds a MergeTools entry by name (case-insensitive; "code" is VS Code).
+func LookupMergeTool(name string) (MergeTool, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "code" {
+		name = "vscode"
+	}
+	for _, t := range MergeTools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return MergeTool{}, false
+}
+
+// Installed reports whether the tool's program is on PATH.
+func (t MergeTool) Installed() bool {
+	_, err := exec.LookPath(t.Program)
+	return err == nil
+}
+
+// ResolveArgs returns `jj resolve` for path in the working copy, with --tool when tool is set
+// (empty uses jj's ui.merge-editor).
+func ResolveArgs(tool, path string) []string {
+	args := []string{"resolve"}
+	if tool != "" {
+		args = append(args, "--tool", tool)
+	}
+	return append(args, "--", "root-file:"+quoteRevsetString(path))
+}
+
+// ResolveCommand builds `jj resolve` for path to run interactively (the TUI hands the terminal
+// to it, which terminal tools like vimdiff need), so it does not go through runJJ.
+func (s *Service) ResolveCommand(tool, path string) *exec.Cmd {
+	cmd := exec.Command("jj", ResolveArgs(tool, path)...)
+	cmd.Dir = s.RepoPath
+	return cmd
+}
+
+// Sample three-way merge the test launch opens: both sides change the same line.
+const (
+	mergeToolTestBase  = "Merge tool test\ngreeting = hello\n"
+	mergeToolTestLeft  = "Merge tool test\ngreeting = hello from the left\n"
+	mergeToolTestRight = "Merge tool test\ngreeting = hello from the right\n"
+)
+
+// TestLaunchCommand opens t on a sample conflict in a temporary directory, the way jj would call
+// it, so the user can check the tool starts before relying on it for a real conflict. Call
+// cleanup once the command has exited.
+func (t MergeTool) TestLaunchCommand(ctx context.Context) (cmd *exec.Cmd, cleanup func(), err error) {
+	if !t.Installed() {
+		return nil, nil, fmt.Errorf("%s not found in PATH", t.Program)
+	}
+	dir, err := os.MkdirTemp("", "jj-tui-merge-tool-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup = func() { _ = os.RemoveAll(dir) }
+	files := map[string]string{"$base": "base.txt", "$left": "left.txt", "$right": "right.txt", "$output": "output.txt"}
+	contents := map[string]string{"$base": mergeToolTestBase, "$left": mergeToolTestLeft, "$right": mergeToolTestRight, "$output": mergeToolTestBase}
+	for v, name := range files {
+		files[v] = filepath.Join(dir, name)
+		if err := os.WriteFile(files[v], []byte(contents[v]), 0o644); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+	}
+	args := make([]string, len(t.MergeArgs))
+	for i, a := range t.MergeArgs {
+		if p, ok := files[a]; ok {
+			a = p
+		}
+		args[i] = a
+	}
+	return exec.CommandContext(ctx, t.Program, args...), cleanup, nil
+}
@@ -0,0 +1,68 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveArgs(t *testing.T) {
+	got := ResolveArgs("meld", `src/a "b".go`)
+	want := []string{"resolve", "--tool", "meld", "--", `root-file:"src/a \"b\".go"`}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ResolveArgs = %q, want %q", got, want)
+	}
+	if got := ResolveArgs("", "a.txt"); slices.Contains(got, "--tool") {
+		t.Fatalf("no tool should leave the choice to jj: %q", got)
+	}
+}
+
+func TestLookupMergeTool(t *testing.T) {
+	for name, want := range map[string]string{"Meld": "meld", " code ": "vscode", "vimdiff": "vimdiff"} {
+		if got, ok := LookupMergeTool(name); !ok || got.Name != want {
+			t.Errorf("LookupMergeTool(%q) = %q, %v; want %q", name, got.Name, ok, want)
+		}
+	}
+	if _, ok := LookupMergeTool(""); ok {
+		t.Error("empty name should be jj's default, not a tool")
+	}
+}
+
+// The test launch fills the tool's merge-args with sample files the way jj would.
+func TestMergeToolTestLaunchCommand(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "kdiff3"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	tool, _ := LookupMergeTool("kdiff3")
+	cmd, cleanup, err := tool.TestLaunchCommand(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := cmd.Args[1:]
+	if len(args) != len(tool.MergeArgs) || args[3] != "-o" || args[5] != "--auto" {
+		t.Fatalf("args = %q", args)
+	}
+	for i, name := range map[int]string{0: "base.txt", 1: "left.txt", 2: "right.txt", 4: "output.txt"} {
+		if filepath.Base(args[i]) != name {
+			t.Fatalf("arg %d = %q, want %s", i, args[i], name)
+		}
+	}
+	left, err := os.ReadFile(args[1])
+	if err != nil || !strings.Contains(string(left), "from the left") {
+		t.Fatalf("left file = %q, %v", left, err)
+	}
+	cleanup()
+	if _, err := os.Stat(args[0]); !os.IsNotExist(err) {
+		t.Fatalf("cleanup left %s behind", args[0])
+	}
+
+	if _, _, err := MergeTools[0].TestLaunchCommand(context.Background()); err == nil {
+		t.Fatal("a tool missing from PATH should not launch")
+	}
+}
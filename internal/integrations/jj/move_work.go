@@ -0,0 +1,34 @@
+package jj
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MoveWorkingCopyOnto moves the uncommitted work onto destChangeID, for work started on the
+// wrong base: `jj new <dest>`, then `jj squash --from <old @> --into @`. The old working-copy
+// commit is abandoned once emptied, and its description moves along with its changes.
+func (s *Service) MoveWorkingCopyOnto(ctx context.Context, destChangeID string) error {
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", "@", "--no-graph", "-T", `commit_id ++ "\n" ++ change_id.short() ++ "\n" ++ description`)
+	if err != nil {
+		return err
+	}
+	fields := strings.SplitN(out, "\n", 3)
+	for len(fields) < 3 {
+		fields = append(fields, "")
+	}
+	oldID, oldChange, desc := strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1]), fields[2]
+	if oldID == "" {
+		return errors.New("could not read the working-copy commit")
+	}
+	if err := s.runJJ(ctx, "new", destChangeID); err != nil {
+		return err
+	}
+	// Pass the message so jj never opens an editor to combine descriptions.
+	if err := s.runJJ(ctx, "squash", "--from", oldID, "--into", "@", jjMessageArg(strings.TrimSpace(desc))); err != nil {
+		return fmt.Errorf("started a new commit on %s, but moving the changes failed (they are still in %s): %w", destChangeID, oldChange, err)
+	}
+	return nil
+}
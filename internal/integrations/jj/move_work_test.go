@@ -0,0 +1,34 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMoveWorkingCopyOnto(t *testing.T) {
+	log := fakeJJ(t, `case "$1" in log) printf 'abc123def456\nqpvuntsm\nWIP parser\n' ;; esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	if err := s.MoveWorkingCopyOnto(context.Background(), "trunk"); err != nil {
+		t.Fatal(err)
+	}
+	got := calls(t, log)
+	if !strings.HasPrefix(got[0], "log -r @") {
+		t.Fatalf("first call = %q", got[0])
+	}
+	want := []string{"new trunk", "squash --from abc123def456 --into @ --message=WIP parser"}
+	if tail := got[len(got)-2:]; !reflect.DeepEqual(tail, want) {
+		t.Fatalf("calls = %q, want %q", tail, want)
+	}
+}
+
+// When the squash fails the new commit exists but the work stays put; the error says where.
+func TestMoveWorkingCopyOntoSquashFails(t *testing.T) {
+	fakeJJ(t, `case "$1" in log) printf 'abc123def456\nqpvuntsm\n' ;; squash) echo "Error: conflict" >&2; exit 1 ;; esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	err := s.MoveWorkingCopyOnto(context.Background(), "trunk")
+	if err == nil || !strings.Contains(err.Error(), "still in qpvuntsm") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// opLogScanLimit bounds how many operations UndoN looks back through.
+const opLogScanLimit = 500
+
+// OpEntry is one operation from `jj op log`.
+type OpEntry struct {
+	ID          string
+	Description string
+}
+
+// opHistory is jj-tui's undo/redo stack over the operation log. Undo and redo are both
+// `jj op restore` to an earlier operation, so repeated undos keep stepping back (plain `jj undo`
+// would undo the previous undo) and redo can step forward again through every undone operation.
+type opHistory struct {
+	mu sync.Mutex
+	// cursor is the operation whose repo state is current; after an undo it is older than the
+	// op log head (which is our restore operation).
+	cursor OpEntry
+	// redo holds the undone operations, most recently undone last.
+	redo []OpEntry
+	// restoreHead is the op log head right after our last restore ("" = none). When the head
+	// moves on from it, something else ran an operation and the stacks start over.
+	restoreHead string
+}
+
+// sync starts the stacks over from head unless head is still our last restore.
+func (h *opHistory) sync(head OpEntry) {
+	if h.restoreHead != "" && head.ID == h.restoreHead {
+		return
+	}
+	h.cursor = head
+	h.redo = nil
+	h.restoreHead = ""
+}
+
+// planUndo returns the operation to restore to undo n operations from the cursor, and the
+// undone operations newest first. log is the op log, newest first, with the cursor in it.
+func (h *opHistory) planUndo(log []OpEntry, n int) (target OpEntry, undone []OpEntry, err error) {
+	at := -1
+	for i, e := range log {
+		if e.ID == h.cursor.ID {
+			at = i
+			break
+		}
+	}
+	if at < 0 {
+		return OpEntry{}, nil, fmt.Errorf("operation %s is not in the last %d operations", shortOpID(h.cursor.ID), len(log))
+	}
+	if at+n >= len(log) {
+		return OpEntry{}, nil, fmt.Errorf("nothing to undo")
+	}
+	return log[at+n], log[at : at+n], nil
+}
+
+// applyUndo records that the repo was restored to target, undoing undone (newest first).
+func (h *opHistory) applyUndo(target OpEntry, undone []OpEntry, head string) {
+	for _, e := range undone {
+		h.redo = append(h.redo, e)
+	}
+	h.cursor = target
+	h.restoreHead = head
+}
+
+// planRedo returns the operation to restore to redo n undone operations, and those operations
+// oldest first.
+func (h *opHistory) planRedo(n int) (target OpEntry, redone []OpEntry, err error) {
+	if len(h.redo) == 0 {
+		return OpEntry{}, nil, fmt.Errorf("nothing to redo")
+	}
+	n = min(n, len(h.redo))
+	for i := len(h.redo) - 1; i >= len(h.redo)-n; i-- {
+		redone = append(redone, h.redo[i])
+	}
+	return redone[len(redone)-1], redone, nil
+}
+
+// applyRedo records that the repo was restored to target after redoing n operations.
+func (h *opHistory) applyRedo(target OpEntry, n int, head string) {
+	h.redo = h.redo[:len(h.redo)-n]
+	h.cursor = target
+	h.restoreHead = head
+}
+
+func shortOpID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// opLog lists up to limit operations, newest first. Like `jj undo`, it snapshots the working
+// copy first, so unsnapshotted edits become an operation of their own.
+func (s *Service) opLog(ctx context.Context, limit int) ([]OpEntry, error) {
+	out, err := s.runJJOutputNoHistory(ctx, "op", "log", "--no-graph", "--limit", fmt.Sprint(limit), "-T", `id ++ "\t" ++ description.first_line() ++ "\n"`)
+	if err != nil {
+		return nil, err
+	}
+	var entries []OpEntry
+	for _, line := range strings.Split(out, "\n") {
+		id, desc, ok := strings.Cut(strings.TrimRight(line, "\r"), "\t")
+		if !ok || strings.TrimSpace(id) == "" {
+			continue
+		}
+		entries = append(entries, OpEntry{ID: strings.TrimSpace(id), Description: strings.TrimSpace(desc)})
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("empty operation log")
+	}
+	return entries, nil
+}
+
+// UndoN restores the repository to its state before the last n operations and returns the
+// undone operations, newest first. Calling it again keeps stepping back rather than undoing
+// the undo; any other operation in between starts the undo/redo stack over.
+func (s *Service) UndoN(ctx context.Context, n int) ([]OpEntry, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+	s.ops.mu.Lock()
+	defer s.ops.mu.Unlock()
+	log, err := s.opLog(ctx, opLogScanLimit)
+	if err != nil {
+		return nil, err
+	}
+	s.ops.sync(log[0])
+	target, undone, err := s.ops.planUndo(log, n)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.runJJ(ctx, "op", "restore", target.ID); err != nil {
+		return nil, err
+	}
+	head, err := s.opLog(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	s.ops.applyUndo(target, undone, head[0].ID)
+	ids := make([]string, len(undone))
+	for i, e := range undone {
+		ids[i] = e.ID
+	}
+	s.forgetTrash(ids)
+	return undone, nil
+}
+
+// RedoN re-applies the last n undone operations (fewer if fewer were undone) and returns them,
+// oldest first. It fails once another operation has run since the undo.
+func (s *Service) RedoN(ctx context.Context, n int) ([]OpEntry, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("nothing to redo")
+	}
+	s.ops.mu.Lock()
+	defer s.ops.mu.Unlock()
+	head, err := s.opLog(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	s.ops.sync(head[0])
+	target, redone, err := s.ops.planRedo(n)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.runJJ(ctx, "op", "restore", target.ID); err != nil {
+		return nil, err
+	}
+	head, err = s.opLog(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	s.ops.applyRedo(target, len(redone), head[0].ID)
+	return redone, nil
+}
+
+// RedoDepth returns how many undone operations RedoN can re-apply, as of the last undo/redo.
+func (s *Service) RedoDepth() int {
+	s.ops.mu.Lock()
+	defer s.ops.mu.Unlock()
+	return len(s.ops.redo)
+}
@@ -0,0 +1,74 @@
+package jj
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Undo steps back through the op log past its own restore operations, redo walks forward again
+// through everything undone, and a new operation starts the stacks over.
+func TestOpHistory(t *testing.T) {
+	a := OpEntry{ID: "a", Description: "new empty commit"}
+	b := OpEntry{ID: "b", Description: "describe commit 1"}
+	c := OpEntry{ID: "c", Description: "squash commits"}
+	var h opHistory
+
+	log := []OpEntry{c, b, a}
+	h.sync(log[0])
+	target, undone, err := h.planUndo(log, 1)
+	if err != nil || target != b || !reflect.DeepEqual(undone, []OpEntry{c}) {
+		t.Fatalf("first undo: %v %v %v", target, undone, err)
+	}
+	h.applyUndo(target, undone, "r1")
+
+	// Our restore is now the head; a second undo keeps going back instead of undoing it.
+	log = []OpEntry{{ID: "r1", Description: "restore to operation b"}, c, b, a}
+	h.sync(log[0])
+	target, undone, err = h.planUndo(log, 1)
+	if err != nil || target != a || !reflect.DeepEqual(undone, []OpEntry{b}) {
+		t.Fatalf("second undo: %v %v %v", target, undone, err)
+	}
+	h.applyUndo(target, undone, "r2")
+	if len(h.redo) != 2 {
+		t.Fatalf("redo depth = %d, want 2", len(h.redo))
+	}
+	log = append([]OpEntry{{ID: "r2"}}, log...)
+	h.sync(log[0])
+	if _, _, err := h.planUndo(log, 1); err == nil {
+		t.Fatal("undo past the first operation should fail")
+	}
+
+	target, redone, err := h.planRedo(1)
+	if err != nil || target != b || !reflect.DeepEqual(redone, []OpEntry{b}) {
+		t.Fatalf("first redo: %v %v %v", target, redone, err)
+	}
+	h.applyRedo(target, len(redone), "r3")
+	h.sync(OpEntry{ID: "r3"})
+	target, redone, err = h.planRedo(5)
+	if err != nil || target != c || !reflect.DeepEqual(redone, []OpEntry{c}) {
+		t.Fatalf("second redo: %v %v %v", target, redone, err)
+	}
+	h.applyRedo(target, len(redone), "r4")
+	if _, _, err := h.planRedo(1); err == nil {
+		t.Fatal("nothing should be left to redo")
+	}
+
+	// Undo twice, then another operation: redo is gone and undo starts from the new head.
+	h.sync(OpEntry{ID: "r4"})
+	log = []OpEntry{{ID: "r4"}, {ID: "r3"}, {ID: "r2"}, {ID: "r1"}, c, b, a}
+	h.cursor = c
+	target, undone, _ = h.planUndo(log, 2)
+	if target != a || !reflect.DeepEqual(undone, []OpEntry{c, b}) {
+		t.Fatalf("undo 2: %v %v", target, undone)
+	}
+	h.applyUndo(target, undone, "r5")
+	d := OpEntry{ID: "d", Description: "commit working copy"}
+	h.sync(d)
+	if len(h.redo) != 0 || h.cursor != d {
+		t.Fatalf("a new operation should reset the stacks: cursor=%v redo=%v", h.cursor, h.redo)
+	}
+	target, _, _ = h.planUndo([]OpEntry{d, {ID: "r5"}}, 1)
+	if target.ID != "r5" {
+		t.Fatalf("undo after a new operation should restore the state before it, got %v", target)
+	}
+}
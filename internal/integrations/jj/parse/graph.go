@@ -0,0 +1,93 @@
+// Package parse decodes jj output produced with jj-tui's own templates into typed values.
+//
+// Templates emit each record as a JSON object (strings go through jj's escape_json), so commit
+// descriptions, author names and bookmark names can contain any character, including the `|`
+// and tab separators the older line formats split on. escape_json() needs jj 0.24 or newer; on
+// older versions the template fails and callers fall back (getCommitGraph reads jj's default log
+// output instead).
+//
+// The tab-separated formats still parsed in package jj stay there because they can't be broken
+// that way: their fields are IDs, flags and counts, and the one free-text field (a description's
+// first line or a path) comes last and is split off with SplitN or Cut.
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CommitMarker separates the graph art jj draws in front of a row from the row's JSON.
+const CommitMarker = "<<<COMMIT>>>"
+
+// GraphTemplate is the `jj log -T` template GraphRows reads: CommitMarker, then one GraphCommit
+// as a JSON object, then a newline. Use it with the graph on (no --no-graph). It needs jj 0.24 or
+// newer for escape_json().
+const GraphTemplate = `concat(
+	"` + CommitMarker + `{",
+	"\"change_id\":", change_id.short(8).escape_json(), ",",
+	"\"commit_id\":", commit_id.short(8).escape_json(), ",",
+	"\"author\":", stringify(author.email()).escape_json(), ",",
+	"\"timestamp\":", author.timestamp().format("%Y-%m-%dT%H:%M:%S%:z").escape_json(), ",",
+	"\"description\":", stringify(if(description, description.first_line(), "(no description)")).escape_json(), ",",
+	"\"parents\":[", parents.map(|p| p.commit_id().short(8).escape_json()).join(","), "],",
+	"\"bookmarks\":[", bookmarks.map(|b| stringify(b).escape_json()).join(","), "],",
+	"\"working_copy\":", if(current_working_copy, "true", "false"), ",",
+	"\"conflict\":", if(conflict, "true", "false"), ",",
+	"\"immutable\":", if(immutable, "true", "false"), ",",
+	"\"divergent\":", if(divergent, "true", "false"), ",",
+	"\"mine\":", if(mine, "true", "false"),
+	"}\n"
+)`
+
+// GraphCommit is one commit as GraphTemplate writes it.
+type GraphCommit struct {
+	ChangeID    string    `json:"change_id"`
+	CommitID    string    `json:"commit_id"`
+	Author      string    `json:"author"` // email
+	Timestamp   time.Time `json:"timestamp"`
+	Description string    `json:"description"` // first line, or "(no description)"
+	Parents     []string  `json:"parents"`     // short commit IDs
+	// Bookmarks as jj displays them: "name", "name@remote", with a trailing "*" (ahead of the
+	// remote) or "?"/"??" (conflicted) on some versions.
+	Bookmarks   []string `json:"bookmarks"`
+	WorkingCopy bool     `json:"working_copy"`
+	Conflict    bool     `json:"conflict"`
+	Immutable   bool     `json:"immutable"`
+	Divergent   bool     `json:"divergent"`
+	Mine        bool     `json:"mine"`
+}
+
+// GraphRow is a commit with the graph art around it.
+type GraphRow struct {
+	Commit GraphCommit
+	// Prefix is the art on the commit's own line, e.g. "│ ○  ".
+	Prefix string
+	// Connectors are the art-only lines between this commit and the next one, e.g. "├─╯" or
+	// "~  (elided revisions)", with trailing spaces removed.
+	Connectors []string
+}
+
+// GraphRows parses `jj log -T GraphTemplate` output. Art-only lines before the first commit are
+// dropped. A row whose JSON does not decode is an error naming its line, so callers can fall back
+// rather than show a graph with commits missing.
+func GraphRows(out string) ([]GraphRow, error) {
+	var rows []GraphRow
+	for i, line := range strings.Split(out, "\n") {
+		prefix, data, ok := strings.Cut(line, CommitMarker)
+		if !ok {
+			if art := strings.TrimRight(line, " "); art != "" && len(rows) > 0 {
+				last := &rows[len(rows)-1]
+				last.Connectors = append(last.Connectors, art)
+			}
+			continue
+		}
+		var c GraphCommit
+		if err := json.Unmarshal([]byte(data), &c); err != nil {
+			return nil, fmt.Errorf("jj log line %d: %w", i+1, err)
+		}
+		rows = append(rows, GraphRow{Commit: c, Prefix: prefix})
+	}
+	return rows, nil
+}
@@ -0,0 +1,217 @@
+package parse
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Fixtures are hand-written `jj log -T GraphTemplate` output in the graph styles jj draws: the
+// node glyphs and connector styles change between releases and with ui.graph.style, the JSON does
+// not. Output captured from real jj releases is in testdata/graph (see TestGraphRowsCaptured).
+const (
+	// Curved graph, "◉" for plain commits, "◆" for immutable ones.
+	graphCurved = `@  <<<COMMIT>>>{"change_id":"kxqvmtsz","commit_id":"1a2b3c4d","author":"me@example.com","timestamp":"2024-12-04T10:15:00+01:00","description":"Parse a|b|c tables","parents":["5e6f7a8b"],"bookmarks":["feat*"],"working_copy":true,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+◉  <<<COMMIT>>>{"change_id":"zzplqrwn","commit_id":"5e6f7a8b","author":"me@example.com","timestamp":"2024-12-03T18:00:00+01:00","description":"(no description)","parents":["9c0d1e2f"],"bookmarks":["feat@origin"],"working_copy":false,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+◆  <<<COMMIT>>>{"change_id":"ttuvwxyz","commit_id":"9c0d1e2f","author":"other@example.com","timestamp":"2024-12-01T09:00:00Z","description":"Release 1.0","parents":["00000000"],"bookmarks":["main","main@origin"],"working_copy":false,"conflict":false,"immutable":true,"divergent":false,"mine":false}
+│
+~
+`
+
+	// "○" for plain commits, "×" for conflicts, a merge and a side branch.
+	graphMerge = `@    <<<COMMIT>>>{"change_id":"mmnnoopp","commit_id":"aaaa1111","author":"me@example.com","timestamp":"2025-06-10T08:30:00-07:00","description":"Merge \"left\" and right","parents":["bbbb2222","cccc3333"],"bookmarks":[],"working_copy":true,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+├─╮
+│ ×  <<<COMMIT>>>{"change_id":"qqrrsstt","commit_id":"cccc3333","author":"me@example.com","timestamp":"2025-06-09T17:00:00-07:00","description":"Tab\there, backslash \\ and ünïcödé","parents":["dddd4444"],"bookmarks":["fix??","fix@origin"],"working_copy":false,"conflict":true,"immutable":false,"divergent":true,"mine":true}
+○ │  <<<COMMIT>>>{"change_id":"uuvvwwxx","commit_id":"bbbb2222","author":"other@example.com","timestamp":"2025-06-08T12:00:00-07:00","description":"Left side","parents":["dddd4444"],"bookmarks":[],"working_copy":false,"conflict":false,"immutable":false,"divergent":false,"mine":false}
+├─╯
+◆  <<<COMMIT>>>{"change_id":"yyzzaabb","commit_id":"dddd4444","author":"other@example.com","timestamp":"2025-06-01T00:00:00Z","description":"Base","parents":[],"bookmarks":["main"],"working_copy":false,"conflict":false,"immutable":true,"divergent":false,"mine":false}
+`
+
+	// ui.graph.style = "ascii" and elided revisions between commits.
+	graphASCII = `@  <<<COMMIT>>>{"change_id":"abcdefgh","commit_id":"12345678","author":"me@example.com","timestamp":"2025-09-01T12:00:00+00:00","description":"WIP","parents":["87654321"],"bookmarks":[],"working_copy":true,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+o  <<<COMMIT>>>{"change_id":"hgfedcba","commit_id":"87654321","author":"me@example.com","timestamp":"2025-08-31T12:00:00+00:00","description":"Start","parents":["0f0f0f0f"],"bookmarks":[],"working_copy":false,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+~  (elided revisions)
++  <<<COMMIT>>>{"change_id":"onetwoth","commit_id":"0f0f0f0f","author":"","timestamp":"2025-01-01T00:00:00+00:00","description":"Initial","parents":[],"bookmarks":["main"],"working_copy":false,"conflict":false,"immutable":true,"divergent":false,"mine":false}
+`
+)
+
+func TestGraphRows(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		out  string
+		want []GraphRow
+	}{
+		{"curved", graphCurved, []GraphRow{
+			{Prefix: "@  ", Commit: GraphCommit{
+				ChangeID: "kxqvmtsz", CommitID: "1a2b3c4d", Author: "me@example.com",
+				Timestamp: time.Date(2024, 12, 4, 10, 15, 0, 0, time.FixedZone("", 3600)), Description: "Parse a|b|c tables",
+				Parents: []string{"5e6f7a8b"}, Bookmarks: []string{"feat*"}, WorkingCopy: true, Mine: true,
+			}},
+			{Prefix: "◉  ", Commit: GraphCommit{
+				ChangeID: "zzplqrwn", CommitID: "5e6f7a8b", Author: "me@example.com",
+				Timestamp: time.Date(2024, 12, 3, 18, 0, 0, 0, time.FixedZone("", 3600)), Description: "(no description)",
+				Parents: []string{"9c0d1e2f"}, Bookmarks: []string{"feat@origin"}, Mine: true,
+			}},
+			{Prefix: "◆  ", Connectors: []string{"│", "~"}, Commit: GraphCommit{
+				ChangeID: "ttuvwxyz", CommitID: "9c0d1e2f", Author: "other@example.com",
+				Timestamp: time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC), Description: "Release 1.0",
+				Parents: []string{"00000000"}, Bookmarks: []string{"main", "main@origin"}, Immutable: true,
+			}},
+		}},
+		{"merge", graphMerge, []GraphRow{
+			{Prefix: "@    ", Connectors: []string{"├─╮"}, Commit: GraphCommit{
+				ChangeID: "mmnnoopp", CommitID: "aaaa1111", Author: "me@example.com",
+				Timestamp: time.Date(2025, 6, 10, 8, 30, 0, 0, time.FixedZone("", -7*3600)), Description: `Merge "left" and right`,
+				Parents: []string{"bbbb2222", "cccc3333"}, Bookmarks: []string{}, WorkingCopy: true, Mine: true,
+			}},
+			{Prefix: "│ ×  ", Commit: GraphCommit{
+				ChangeID: "qqrrsstt", CommitID: "cccc3333", Author: "me@example.com",
+				Timestamp: time.Date(2025, 6, 9, 17, 0, 0, 0, time.FixedZone("", -7*3600)), Description: "Tab\there, backslash \\ and ünïcödé",
+				Parents: []string{"dddd4444"}, Bookmarks: []string{"fix??", "fix@origin"}, Conflict: true, Divergent: true, Mine: true,
+			}},
+			{Prefix: "○ │  ", Connectors: []string{"├─╯"}, Commit: GraphCommit{
+				ChangeID: "uuvvwwxx", CommitID: "bbbb2222", Author: "other@example.com",
+				Timestamp: time.Date(2025, 6, 8, 12, 0, 0, 0, time.FixedZone("", -7*3600)), Description: "Left side",
+				Parents: []string{"dddd4444"}, Bookmarks: []string{},
+			}},
+			{Prefix: "◆  ", Commit: GraphCommit{
+				ChangeID: "yyzzaabb", CommitID: "dddd4444", Author: "other@example.com",
+				Timestamp: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Description: "Base",
+				Parents: []string{}, Bookmarks: []string{"main"}, Immutable: true,
+			}},
+		}},
+		{"ascii", graphASCII, []GraphRow{
+			{Prefix: "@  ", Commit: GraphCommit{
+				ChangeID: "abcdefgh", CommitID: "12345678", Author: "me@example.com",
+				Timestamp: time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC), Description: "WIP",
+				Parents: []string{"87654321"}, Bookmarks: []string{}, WorkingCopy: true, Mine: true,
+			}},
+			{Prefix: "o  ", Connectors: []string{"~  (elided revisions)"}, Commit: GraphCommit{
+				ChangeID: "hgfedcba", CommitID: "87654321", Author: "me@example.com",
+				Timestamp: time.Date(2025, 8, 31, 12, 0, 0, 0, time.UTC), Description: "Start",
+				Parents: []string{"0f0f0f0f"}, Bookmarks: []string{}, Mine: true,
+			}},
+			{Prefix: "+  ", Commit: GraphCommit{
+				ChangeID: "onetwoth", CommitID: "0f0f0f0f",
+				Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Description: "Initial",
+				Parents: []string{}, Bookmarks: []string{"main"}, Immutable: true,
+			}},
+		}},
+		{"empty", "", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := GraphRows(tc.out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d rows, want %d", len(got), len(tc.want))
+			}
+			for i := range got {
+				if !got[i].Commit.Timestamp.Equal(tc.want[i].Commit.Timestamp) {
+					t.Errorf("row %d timestamp = %v, want %v", i, got[i].Commit.Timestamp, tc.want[i].Commit.Timestamp)
+				}
+				got[i].Commit.Timestamp, tc.want[i].Commit.Timestamp = time.Time{}, time.Time{}
+				if !reflect.DeepEqual(got[i], tc.want[i]) {
+					t.Errorf("row %d:\n got %+v\nwant %+v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestGraphRowsCaptured reads the real `jj log -T GraphTemplate` output saved per jj release in
+// testdata/graph by integration_tests' TestGraphTemplateCapture (`make graph-fixtures`). Every
+// capture is of the same repository, so only the graph art and IDs may differ between versions.
+func TestGraphRowsCaptured(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "graph", "jj-*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no captured jj output in testdata/graph; run `make graph-fixtures`")
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			out, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows, err := GraphRows(string(out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var descs []string
+			for _, r := range rows {
+				descs = append(descs, r.Commit.Description)
+			}
+			// Left and Right are siblings, so jj may list them either way round.
+			sides := slices.Clone(descs[2:min(4, len(descs))])
+			slices.Sort(sides)
+			if len(rows) != 6 || descs[0] != "(no description)" || descs[1] != `Merge "left" and right` ||
+				!slices.Equal(sides, []string{"Left side", "Right side"}) || descs[4] != "Initial commit" {
+				t.Fatalf("descriptions = %q", descs)
+			}
+			wc, merge, trunk, root := rows[0].Commit, rows[1].Commit, rows[4].Commit, rows[5].Commit
+			if !wc.WorkingCopy || !wc.Mine || wc.Author != "fixtures@example.com" || len(wc.Parents) != 1 || wc.Parents[0] != merge.CommitID {
+				t.Errorf("working copy = %+v, want @ on the merge", wc)
+			}
+			if !merge.Conflict || len(merge.Parents) != 2 || merge.Immutable {
+				t.Errorf("merge = %+v, want a mutable conflicted merge of 2 parents", merge)
+			}
+			if !trunk.Immutable || !slices.Contains(trunk.Bookmarks, "main") || trunk.Timestamp.IsZero() {
+				t.Errorf("trunk = %+v, want immutable main", trunk)
+			}
+			for _, r := range rows[2:4] {
+				if want := r.Commit.Description == "Left side"; slices.Contains(r.Commit.Bookmarks, "feat") != want {
+					t.Errorf("%q bookmarks = %q, want feat on Left side only", r.Commit.Description, r.Commit.Bookmarks)
+				}
+			}
+			if !root.Immutable || len(root.Parents) != 0 {
+				t.Errorf("root = %+v, want the immutable root commit last", root)
+			}
+			for _, r := range rows {
+				if len(r.Commit.ChangeID) != 8 || len(r.Commit.CommitID) != 8 || r.Prefix == "" {
+					t.Errorf("row %+v: want 8-character IDs and graph art", r)
+				}
+			}
+		})
+	}
+}
+
+func TestGraphRowsRejectsBadJSON(t *testing.T) {
+	out := "@  " + CommitMarker + `{"change_id":"kxqvmtsz"}` + "\n○  " + CommitMarker + `{"change_id":"zzpl` + "\n"
+	if _, err := GraphRows(out); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("err = %v, want an error for line 2", err)
+	}
+}
+
+// Every GraphCommit field must be written by GraphTemplate under its JSON name, and every string
+// the template writes must go through escape_json.
+func TestGraphTemplateMatchesGraphCommit(t *testing.T) {
+	keys := map[string]bool{}
+	for _, m := range regexp.MustCompile(`"\\"(\w+)\\":`).FindAllStringSubmatch(GraphTemplate, -1) {
+		keys[m[1]] = true
+	}
+	typ := reflect.TypeOf(GraphCommit{})
+	for i := range typ.NumField() {
+		tag := typ.Field(i).Tag.Get("json")
+		if !keys[tag] {
+			t.Errorf("GraphTemplate does not write %q (%s)", tag, typ.Field(i).Name)
+		}
+		delete(keys, tag)
+	}
+	for k := range keys {
+		t.Errorf("GraphTemplate writes %q, which GraphCommit does not have", k)
+	}
+	for _, line := range strings.Split(GraphTemplate, "\n") {
+		if strings.Contains(line, `\":",`) && !strings.Contains(line, "escape_json()") && !strings.Contains(line, `"true", "false"`) {
+			t.Errorf("template line writes an unescaped value: %s", strings.TrimSpace(line))
+		}
+	}
+}
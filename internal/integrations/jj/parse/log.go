@@ -0,0 +1,52 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LogTemplate is the `jj log --no-graph -T` template LogCommits reads: one LogCommit per line as
+// a JSON object.
+const LogTemplate = `concat(
+	"{",
+	"\"change_id\":", change_id.short(8).escape_json(), ",",
+	"\"commit_id\":", stringify(commit_id).escape_json(), ",",
+	"\"author\":", stringify(author.email()).escape_json(), ",",
+	"\"timestamp\":", author.timestamp().format("%Y-%m-%dT%H:%M:%S%:z").escape_json(), ",",
+	"\"description\":", stringify(if(description, description.first_line(), "(no description)")).escape_json(), ",",
+	"\"parents\":[", parents.map(|p| p.commit_id().short(8).escape_json()).join(","), "],",
+	"\"bookmarks\":[", bookmarks.map(|b| stringify(b).escape_json()).join(","), "],",
+	"\"immutable\":", if(immutable, "true", "false"),
+	"}\n"
+)`
+
+// LogCommit is one commit as LogTemplate writes it.
+type LogCommit struct {
+	ChangeID    string    `json:"change_id"`
+	CommitID    string    `json:"commit_id"` // full
+	Author      string    `json:"author"`    // email
+	Timestamp   time.Time `json:"timestamp"`
+	Description string    `json:"description"` // first line, or "(no description)"
+	Parents     []string  `json:"parents"`     // short commit IDs
+	Bookmarks   []string  `json:"bookmarks"`   // as jj displays them (see GraphCommit)
+	Immutable   bool      `json:"immutable"`
+}
+
+// LogCommits parses `jj log --no-graph -T LogTemplate` output. A line whose JSON does not decode
+// is an error naming its line.
+func LogCommits(out string) ([]LogCommit, error) {
+	var commits []LogCommit
+	for i, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var c LogCommit
+		if err := json.Unmarshal([]byte(line), &c); err != nil {
+			return nil, fmt.Errorf("jj log line %d: %w", i+1, err)
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
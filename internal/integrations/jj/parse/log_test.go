@@ -0,0 +1,59 @@
+package parse
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogCommits(t *testing.T) {
+	out := `{"change_id":"kxqvmtsz","commit_id":"1a2b3c4d5e6f","author":"me@example.com","timestamp":"2025-06-09T17:00:00-07:00","description":"Tab\there | pipe","parents":["5e6f7a8b"],"bookmarks":["feat??"],"immutable":false}
+{"change_id":"kxqvmtsz","commit_id":"9c0d1e2f3a4b","author":"","timestamp":"2025-06-08T12:00:00Z","description":"(no description)","parents":[],"bookmarks":[],"immutable":true}
+`
+	got, err := LogCommits(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []LogCommit{
+		{ChangeID: "kxqvmtsz", CommitID: "1a2b3c4d5e6f", Author: "me@example.com", Description: "Tab\there | pipe",
+			Parents: []string{"5e6f7a8b"}, Bookmarks: []string{"feat??"}},
+		{ChangeID: "kxqvmtsz", CommitID: "9c0d1e2f3a4b", Description: "(no description)",
+			Parents: []string{}, Bookmarks: []string{}, Immutable: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d commits, want %d", len(got), len(want))
+	}
+	if ts := time.Date(2025, 6, 9, 17, 0, 0, 0, time.FixedZone("", -7*3600)); !got[0].Timestamp.Equal(ts) {
+		t.Errorf("timestamp = %v, want %v", got[0].Timestamp, ts)
+	}
+	for i := range got {
+		got[i].Timestamp = time.Time{}
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("commit %d:\n got %+v\nwant %+v", i, got[i], want[i])
+		}
+	}
+	if _, err := LogCommits(out + `{"change_id":` + "\n"); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("err = %v, want an error for line 3", err)
+	}
+}
+
+// Every LogCommit field must be written by LogTemplate under its JSON name.
+func TestLogTemplateMatchesLogCommit(t *testing.T) {
+	keys := map[string]bool{}
+	for _, m := range regexp.MustCompile(`"\\"(\w+)\\":`).FindAllStringSubmatch(LogTemplate, -1) {
+		keys[m[1]] = true
+	}
+	typ := reflect.TypeOf(LogCommit{})
+	for i := range typ.NumField() {
+		tag := typ.Field(i).Tag.Get("json")
+		if !keys[tag] {
+			t.Errorf("LogTemplate does not write %q (%s)", tag, typ.Field(i).Name)
+		}
+		delete(keys, tag)
+	}
+	for k := range keys {
+		t.Errorf("LogTemplate writes %q, which LogCommit does not have", k)
+	}
+}
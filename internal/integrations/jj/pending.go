@@ -0,0 +1,147 @@
+package jj
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// pendingScanMaxEntries bounds how much of a huge tree PendingChanges walks per call.
+const pendingScanMaxEntries = 200_000
+
+// PendingChanges describes files edited on disk since jj last snapshotted the working copy.
+type PendingChanges struct {
+	Files int
+	Bytes int64
+}
+
+// IsZero reports whether nothing is waiting to be snapshotted.
+func (p PendingChanges) IsZero() bool {
+	return p.Files == 0
+}
+
+// String summarizes p for the status bar, e.g. "3 files, 12.4 KB".
+func (p PendingChanges) String() string {
+	noun := "files"
+	if p.Files == 1 {
+		noun = "file"
+	}
+	return fmt.Sprintf("%d %s, %s", p.Files, noun, formatByteSize(p.Bytes))
+}
+
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+// markSnapshot records that a jj command which snapshots the working copy is starting.
+func (s *Service) markSnapshot(at time.Time) {
+	s.lastSnapshot.Store(at.UnixNano())
+}
+
+// LastSnapshot returns when the latest graph load started (zero before the first): working-copy
+// edits before it are in the graph.
+func (s *Service) LastSnapshot() time.Time {
+	if ns := s.lastSnapshot.Load(); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
+// PendingChanges finds files modified after the last graph load, which is the last time jj-tui
+// let jj snapshot the working copy. It only compares modification times, so it never snapshots
+// itself (and never rewrites @). In colocated repos git's ignore rules filter the result;
+// deletions aren't detected. Returns zero before the first load.
+func (s *Service) PendingChanges(ctx context.Context) (PendingChanges, error) {
+	since := s.lastSnapshot.Load()
+	if since == 0 || s.RepoPath == "" {
+		return PendingChanges{}, nil
+	}
+	var paths []string
+	sizes := map[string]int64{}
+	seen := 0
+	err := filepath.WalkDir(s.RepoPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if seen++; seen > pendingScanMaxEntries {
+			return filepath.SkipAll
+		}
+		if d.IsDir() {
+			if name := d.Name(); name == ".jj" || name == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().UnixNano() <= since {
+			return nil
+		}
+		rel, err := filepath.Rel(s.RepoPath, path)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		paths = append(paths, rel)
+		sizes[rel] = info.Size()
+		return nil
+	})
+	if err != nil {
+		return PendingChanges{}, err
+	}
+	if len(paths) > 0 {
+		for _, ignored := range s.gitIgnored(ctx, paths) {
+			delete(sizes, ignored)
+		}
+	}
+	var p PendingChanges
+	for _, size := range sizes {
+		p.Files++
+		p.Bytes += size
+	}
+	return p, nil
+}
+
+// gitIgnored returns the paths git's ignore rules exclude, or nil when the repo has no git store
+// (or git is unavailable). Outside colocated repos it asks jj's internal git store about the
+// working copy.
+func (s *Service) gitIgnored(ctx context.Context, paths []string) []string {
+	args := []string{"check-ignore", "--stdin"}
+	if _, err := os.Stat(filepath.Join(s.RepoPath, ".git")); err != nil {
+		store := filepath.Join(s.RepoPath, ".jj", "repo", "store", "git")
+		if info, err := os.Stat(store); err != nil || !info.IsDir() {
+			return nil
+		}
+		args = append([]string{"--git-dir", store, "--work-tree", s.RepoPath}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = s.RepoPath
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\n") + "\n")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	_ = cmd.Run() // exit status 1 just means nothing is ignored
+	var ignored []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		if line != "" {
+			ignored = append(ignored, line)
+		}
+	}
+	return ignored
+}
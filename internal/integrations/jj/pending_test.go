@@ -0,0 +1,56 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPendingChanges(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, content string, mtime time.Time) {
+		t.Helper()
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := &Service{RepoPath: dir}
+	if p, err := s.PendingChanges(context.Background()); err != nil || !p.IsZero() {
+		t.Fatalf("before first load: %+v, %v", p, err)
+	}
+
+	snap := time.Now().Add(-time.Hour)
+	s.markSnapshot(snap)
+	write("old.txt", "old", snap.Add(-time.Minute))
+	write("src/new.go", "package x\n", snap.Add(time.Minute))
+	write("edited.txt", "hello", snap.Add(time.Minute))
+	write(".jj/working_copy/state", "internal", snap.Add(time.Minute))
+
+	p, err := s.PendingChanges(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Files != 2 || p.Bytes != int64(len("package x\n")+len("hello")) {
+		t.Fatalf("got %+v", p)
+	}
+	if got := p.String(); got != "2 files, 15 B" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	for n, want := range map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KB", 5 << 20: "5.0 MB"} {
+		if got := formatByteSize(n); got != want {
+			t.Errorf("formatByteSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
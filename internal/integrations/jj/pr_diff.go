@@ -0,0 +1,43 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PullRequestDiff returns, computed locally, the git diff a pull request from head into base
+// merges: from the fork point of base and head to head. The remote-tracking bookmarks
+// (name@origin) are preferred since they are what the PR shows; otherwise the local head bookmark
+// and, for base, trunk() are used.
+func (s *Service) PullRequestDiff(ctx context.Context, base, head string) (string, error) {
+	if head == "" {
+		return "", fmt.Errorf("the PR has no head branch")
+	}
+	headID := s.firstResolvedCommit(ctx, quoteRevsetString(head)+"@origin", quoteRevsetString(head))
+	if headID == "" {
+		return "", fmt.Errorf("branch %s is not in this repository; fetch it first", head)
+	}
+	var baseRevs []string
+	if base != "" {
+		baseRevs = append(baseRevs, quoteRevsetString(base)+"@origin", quoteRevsetString(base))
+	}
+	baseID := s.firstResolvedCommit(ctx, append(baseRevs, "trunk()")...)
+	if baseID == "" {
+		return "", fmt.Errorf("cannot find base branch %s", base)
+	}
+	from := fmt.Sprintf("fork_point(%s | %s)", baseID, headID)
+	return s.runJJOutputNoHistory(ctx, "diff", "--from", from, "--to", headID, "--git", "--color", "never")
+}
+
+// firstResolvedCommit returns the commit ID of the first revset in revs that resolves to a
+// commit, or "" when none does.
+func (s *Service) firstResolvedCommit(ctx context.Context, revs ...string) string {
+	for _, rev := range revs {
+		out, err := s.runJJOutputNoHistory(ctx, "log", "-r", rev, "--no-graph", "-T", "commit_id", "--limit", "1")
+		if id := strings.TrimSpace(out); err == nil && id != "" {
+			return id
+		}
+	}
+	return ""
+}
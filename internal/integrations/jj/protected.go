@@ -0,0 +1,28 @@
+package jj
+
+import "path"
+
+// ProtectedBookmarks names the bookmarks a push asks the user to confirm by typing the bookmark's
+// name: the repo's trunk and any bookmark matching one of Patterns (path.Match globs, so
+// "release/*" covers release/1.2). The zero value protects nothing.
+type ProtectedBookmarks struct {
+	Patterns []string
+	Trunk    bool
+}
+
+// Protects reports whether pushing bookmark needs the typed confirmation. trunk is the repo's
+// trunk bookmark name ("" when unknown).
+func (p ProtectedBookmarks) Protects(bookmark, trunk string) bool {
+	if bookmark == "" {
+		return false
+	}
+	if p.Trunk && bookmark == trunk {
+		return true
+	}
+	for _, pat := range p.Patterns {
+		if ok, _ := path.Match(pat, bookmark); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package jj
+
+import "testing"
+
+func TestProtectedBookmarks(t *testing.T) {
+	p := ProtectedBookmarks{Patterns: []string{"master", "release/*"}, Trunk: true}
+	for _, tc := range []struct {
+		bookmark, trunk string
+		want            bool
+	}{
+		{"develop", "develop", true},
+		{"master", "main", true},
+		{"release/1.2", "main", true},
+		{"release/1.2/hotfix", "main", false},
+		{"feature", "main", false},
+		{"", "", false},
+	} {
+		if got := p.Protects(tc.bookmark, tc.trunk); got != tc.want {
+			t.Errorf("Protects(%q, %q) = %v, want %v", tc.bookmark, tc.trunk, got, tc.want)
+		}
+	}
+	if (ProtectedBookmarks{}).Protects("main", "main") {
+		t.Error("the zero value should protect nothing")
+	}
+}
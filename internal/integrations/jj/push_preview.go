@@ -0,0 +1,142 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// pushPreviewLimit caps how many commits a push preview lists.
+const pushPreviewLimit = 50
+
+// pushPreviewTemplate prints one commit per line: short change ID, short commit ID, "empty",
+// "conflict", and the description's first line, tab-separated.
+const pushPreviewTemplate = `change_id.shortest(8) ++ "\t" ++ commit_id.short(8) ++ "\t" ++ if(empty, "empty") ++ "\t" ++ if(conflict, "conflict") ++ "\t" ++ description.first_line() ++ "\n"`
+
+// PushPreviewCommit is a commit that becomes visible on the remote when a bookmark is pushed.
+type PushPreviewCommit struct {
+	ChangeID string
+	CommitID string
+	Summary  string // first line of the description ("" when there is none)
+	Empty    bool
+	Conflict bool
+}
+
+// wipPrefixes are description starts that mark work not meant to be published.
+var wipPrefixes = []string{"wip", "fixup!", "squash!", "amend!", "tmp", "temp", "do not merge", "dnm", "xxx"}
+
+// LooksWIP reports whether the commit looks unfinished: no description, a WIP-style one, no
+// changes, or unresolved conflicts.
+func (c PushPreviewCommit) LooksWIP() bool {
+	if c.Summary == "" || c.Empty || c.Conflict {
+		return true
+	}
+	lower := strings.ToLower(c.Summary)
+	for _, p := range wipPrefixes {
+		rest, ok := strings.CutPrefix(lower, p)
+		if ok && (rest == "" || !unicode.IsLetter([]rune(rest)[0])) {
+			return true
+		}
+	}
+	return false
+}
+
+// PushPreview is what pushing a bookmark would change on a remote.
+type PushPreview struct {
+	Bookmark string
+	Remote   string
+	// New is set when the remote has no such bookmark yet; Commits then lists what is not on any
+	// of the remote's bookmarks.
+	New bool
+	// Commits become visible on the remote bookmark (remote..local), newest first; Truncated
+	// is set when there were more than pushPreviewLimit.
+	Commits   []PushPreviewCommit
+	Truncated bool
+	// Replaced counts the commits on the remote bookmark that the push drops (local..remote),
+	// i.e. history that was rewritten or abandoned locally.
+	Replaced int
+	// LargeFiles are the files the published commits add that LargeFileRules flags.
+	LargeFiles []LargeFile
+	// Protected is set by the caller when the bookmark is one ProtectedBookmarks guards; the push
+	// then needs the bookmark's name typed to confirm.
+	Protected bool
+}
+
+// PushPreview computes what pushing bookmark to remote would publish, without pushing.
+func (s *Service) PushPreview(ctx context.Context, bookmark, remote string) (*PushPreview, error) {
+	bookmark = util.LocalBookmarkName(util.BookmarkNameForRevset(bookmark))
+	if bookmark == "" {
+		return nil, fmt.Errorf("bookmark name is required")
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+	p := &PushPreview{Bookmark: bookmark, Remote: remote}
+	added, isNew, err := s.outgoingRevset(ctx, bookmark, remote)
+	if err != nil {
+		return nil, err
+	}
+	p.New = isNew
+	if !isNew {
+		pat := util.RevsetExactPattern(bookmark)
+		local := fmt.Sprintf("bookmarks(%s)", pat)
+		remoteRev := fmt.Sprintf("remote_bookmarks(%s, %s)", pat, util.RevsetExactPattern(remote))
+		dropped, err := s.runJJOutputNoHistory(ctx, "log", "-r", fmt.Sprintf("%s..%s", local, remoteRev), "--no-graph", "-T", `commit_id ++ "\n"`)
+		if err != nil {
+			return nil, err
+		}
+		p.Replaced = len(strings.Fields(dropped))
+	}
+
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", added, "--no-graph", "--limit", fmt.Sprint(pushPreviewLimit+1), "-T", pushPreviewTemplate)
+	if err != nil {
+		return nil, err
+	}
+	p.Commits = parsePushPreviewLog(out)
+	if len(p.Commits) > pushPreviewLimit {
+		p.Commits = p.Commits[:pushPreviewLimit]
+		p.Truncated = true
+	}
+	// The large-file check only adds warnings; failing it shouldn't block the push.
+	p.LargeFiles, _ = s.LargeFiles(ctx, added)
+	return p, nil
+}
+
+// outgoingRevset returns the revset of commits pushing bookmark to remote would make visible there:
+// remote..local, or when the remote has no such bookmark yet (isNew) everything not already on one
+// of the remote's bookmarks.
+func (s *Service) outgoingRevset(ctx context.Context, bookmark, remote string) (revset string, isNew bool, err error) {
+	pat := util.RevsetExactPattern(bookmark)
+	local := fmt.Sprintf("bookmarks(%s)", pat)
+	remoteRev := fmt.Sprintf("remote_bookmarks(%s, %s)", pat, util.RevsetExactPattern(remote))
+	onRemote, err := s.runJJOutputNoHistory(ctx, "log", "-r", remoteRev, "--no-graph", "-T", `commit_id ++ "\n"`)
+	if err != nil {
+		return "", false, err
+	}
+	if strings.TrimSpace(onRemote) == "" {
+		return fmt.Sprintf("::%s ~ ::remote_bookmarks(remote=%s)", local, util.RevsetExactPattern(remote)), true, nil
+	}
+	return fmt.Sprintf("%s..%s", remoteRev, local), false, nil
+}
+
+// parsePushPreviewLog parses pushPreviewTemplate output.
+func parsePushPreviewLog(out string) []PushPreviewCommit {
+	var commits []PushPreviewCommit
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 5)
+		if len(parts) < 5 {
+			continue
+		}
+		commits = append(commits, PushPreviewCommit{
+			ChangeID: parts[0],
+			CommitID: parts[1],
+			Empty:    parts[2] == "empty",
+			Conflict: parts[3] == "conflict",
+			Summary:  strings.TrimSpace(parts[4]),
+		})
+	}
+	return commits
+}
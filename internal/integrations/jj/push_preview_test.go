@@ -0,0 +1,37 @@
+package jj
+
+import "testing"
+
+func TestParsePushPreviewLog(t *testing.T) {
+	out := "kxqv\tabc12345\t\t\tAdd the flag\nzzyw\tdef67890\tempty\t\t\nqqrs\t01234567\t\tconflict\tWIP: try another approach\n"
+	commits := parsePushPreviewLog(out)
+	if len(commits) != 3 {
+		t.Fatalf("commits = %+v", commits)
+	}
+	if c := commits[0]; c.ChangeID != "kxqv" || c.CommitID != "abc12345" || c.Summary != "Add the flag" || c.LooksWIP() {
+		t.Fatalf("first = %+v", c)
+	}
+	if c := commits[1]; !c.Empty || c.Summary != "" || !c.LooksWIP() {
+		t.Fatalf("second = %+v", c)
+	}
+	if c := commits[2]; !c.Conflict || !c.LooksWIP() {
+		t.Fatalf("third = %+v", c)
+	}
+}
+
+func TestPushPreviewCommit_LooksWIP(t *testing.T) {
+	for summary, want := range map[string]bool{
+		"Fix the parser":          false,
+		"wip":                     true,
+		"fixup! Fix the parser":   true,
+		"DO NOT MERGE: debug":     true,
+		"tmp: extra logging":      true,
+		"Tmpfs support":           false,
+		"Tidy up the importer":    false,
+		"Remove temp directories": false,
+	} {
+		if got := (PushPreviewCommit{Summary: summary}).LooksWIP(); got != want {
+			t.Errorf("LooksWIP(%q) = %v, want %v", summary, got, want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+)
+
+// What jj rebase moves (its -r / -s / -b flags).
+const (
+	RebaseRevision = "-r" // only the revision; its children move onto its parents
+	RebaseSource   = "-s" // the revision and its descendants
+	RebaseBranch   = "-b" // the whole branch: everything not already on the destination
+)
+
+// RebaseOptions choose how RebaseWithOptions moves a revision. Several destinations make the
+// moved root a merge of them; InsertAfter puts the revision between each destination and its
+// children (-A) instead of on top of it (-d). Mode "" means RebaseSource.
+type RebaseOptions struct {
+	Mode         string
+	Destinations []string
+	InsertAfter  bool
+}
+
+// RebaseWithOptions rebases source as opts describe.
+func (s *Service) RebaseWithOptions(ctx context.Context, source string, opts RebaseOptions) error {
+	mode := opts.Mode
+	if mode == "" {
+		mode = RebaseSource
+	}
+	if len(opts.Destinations) == 0 {
+		return fmt.Errorf("no rebase destination")
+	}
+	if opts.InsertAfter && mode == RebaseBranch {
+		return fmt.Errorf("jj rebase -b can't insert after a commit")
+	}
+	destFlag := "-d"
+	if opts.InsertAfter {
+		destFlag = "-A"
+	}
+	args := []string{"rebase", mode, source}
+	for _, d := range opts.Destinations {
+		args = append(args, destFlag, d)
+	}
+	return s.runJJ(ctx, args...)
+}
@@ -0,0 +1,33 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRebaseWithOptions(t *testing.T) {
+	log := fakeJJ(t, `exit 0`)
+	s := &Service{RepoPath: t.TempDir()}
+	ctx := context.Background()
+	if err := s.RebaseWithOptions(ctx, "src", RebaseOptions{Destinations: []string{"a"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RebaseWithOptions(ctx, "src", RebaseOptions{Mode: RebaseRevision, Destinations: []string{"a", "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RebaseWithOptions(ctx, "src", RebaseOptions{Mode: RebaseSource, Destinations: []string{"a"}, InsertAfter: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RebaseWithOptions(ctx, "src", RebaseOptions{Mode: RebaseBranch, Destinations: []string{"a"}, InsertAfter: true}); err == nil {
+		t.Fatal("-b with insert after should be refused")
+	}
+	want := []string{
+		"rebase -s src -d a",
+		"rebase -r src -d a -d b",
+		"rebase -s src -A a",
+	}
+	if got := calls(t, log); !reflect.DeepEqual(got, want) {
+		t.Fatalf("calls = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package jj
+
+import (
+	"context"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+// remoteStateTemplate prints the change and commit ID of each commit on one line.
+const remoteStateTemplate = `change_id.short(8) ++ " " ++ commit_id.short(8) ++ "\n"`
+
+// enrichCommitsRemoteState sets RemoteState on the graph's commits. One query lists the commits
+// reachable from a remote bookmark, limited to the graph's revset plus every mutable commit (so
+// the remote versions of rewritten changes are included without walking the whole remote
+// history). A commit in that list is pushed; a commit that is not, but whose change ID is, was
+// rewritten after it was pushed. States stay unknown when the repository has no remotes or the
+// query fails.
+func (s *Service) enrichCommitsRemoteState(ctx context.Context, commits []internal.Commit, revset, bookmarkList string) {
+	if len(commits) == 0 || !bookmarkListHasRemote(bookmarkList) {
+		return
+	}
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", "::remote_bookmarks() & (("+revset+") | mutable())", "--no-graph", "-T", remoteStateTemplate)
+	if err != nil {
+		return
+	}
+	applyRemoteState(commits, out)
+}
+
+// applyRemoteState classifies commits from remoteStateTemplate output.
+func applyRemoteState(commits []internal.Commit, out string) {
+	onRemote := make(map[string]bool)
+	changeOnRemote := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		changeOnRemote[fields[0]] = true
+		onRemote[fields[1]] = true
+	}
+	for i := range commits {
+		c := &commits[i]
+		switch {
+		case onRemote[c.ID]:
+			c.RemoteState = internal.RemoteStatePushed
+		case changeOnRemote[c.ChangeID]:
+			c.RemoteState = internal.RemoteStateDiverged
+		default:
+			c.RemoteState = internal.RemoteStateLocal
+		}
+	}
+}
+
+// bookmarkListHasRemote reports whether `jj bookmark list` output shows a bookmark on any remote
+// other than the git backend's own (@git in colocated repositories): either an indented
+// "  @origin: …" line under a local bookmark or an untracked "name@origin: …" line.
+func bookmarkListHasRemote(listOutput string) bool {
+	for _, line := range strings.Split(listOutput, "\n") {
+		t := strings.TrimSpace(line)
+		if remote, _, ok := parseBookmarkListRemoteLine(t); ok {
+			if remote != "git" {
+				return true
+			}
+			continue
+		}
+		head, _, ok := strings.Cut(t, ":")
+		if !ok {
+			continue
+		}
+		if i := strings.LastIndex(head, "@"); i > 0 && head[i+1:] != "git" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package jj
+
+import (
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+func TestApplyRemoteState(t *testing.T) {
+	commits := []internal.Commit{
+		{ID: "aaaa1111", ChangeID: "pushedcc"},
+		{ID: "bbbb2222", ChangeID: "rewrittn"},
+		{ID: "cccc3333", ChangeID: "localchg"},
+	}
+	applyRemoteState(commits, "pushedcc aaaa1111\nrewrittn 9999ffff\n")
+	want := []internal.RemoteState{internal.RemoteStatePushed, internal.RemoteStateDiverged, internal.RemoteStateLocal}
+	for i, c := range commits {
+		if c.RemoteState != want[i] {
+			t.Errorf("%s: RemoteState = %q, want %q", c.ChangeID, c.RemoteState, want[i])
+		}
+	}
+}
+
+func TestBookmarkListHasRemote(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want bool
+	}{
+		{"no bookmarks", "", false},
+		{"local only", "main: qpvuntsm 230dd059 Add parser\n", false},
+		{"colocated git only", "main: qpvuntsm 230dd059 Add parser\n  @git: qpvuntsm 230dd059 Add parser\n", false},
+		{"tracked origin", "main: qpvuntsm 230dd059 Add parser\n  @origin (ahead by 1, behind by 0): rlvkpnrz 1a2b3c4d Old\n", true},
+		{"untracked origin", "feature@origin: rlvkpnrz 1a2b3c4d WIP\n", true},
+	}
+	for _, tt := range tests {
+		if got := bookmarkListHasRemote(tt.out); got != tt.want {
+			t.Errorf("%s: bookmarkListHasRemote() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
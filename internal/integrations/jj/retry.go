@@ -0,0 +1,147 @@
+package jj
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/madicen/jj-tui/internal/events"
+)
+
+// jjRetryDelays are the waits before each retry of a jj command that failed because another jj
+// process held a lock. Its length bounds the number of retries.
+var jjRetryDelays = []time.Duration{150 * time.Millisecond, 400 * time.Millisecond, 1 * time.Second}
+
+// jjFailureKind classifies why a jj command failed, for deciding whether to retry it.
+type jjFailureKind int
+
+const (
+	jjFailurePermanent jjFailureKind = iota
+	jjFailureLocked                  // another jj process holds the working copy or op heads lock
+	jjFailureStale                   // the working copy was not updated after another operation
+)
+
+// jjLockMessages are lowercase fragments of the errors jj prints when another jj process holds
+// its working copy or op heads lock. git's lock errors (ref locks, index.lock) are not listed: git
+// may hit them after it has already changed something, e.g. partway through a push.
+var jjLockMessages = []string{
+	"failed to lock",
+}
+
+// classifyJJFailure inspects a failed jj command's output for transient causes.
+func classifyJJFailure(output string) jjFailureKind {
+	lower := strings.ToLower(output)
+	if strings.Contains(lower, "working copy is stale") {
+		return jjFailureStale
+	}
+	for _, m := range jjLockMessages {
+		if strings.Contains(lower, m) {
+			return jjFailureLocked
+		}
+	}
+	return jjFailurePermanent
+}
+
+// execJJ runs jj with args in the repository and returns its stdout and stderr; with combined,
+// stderr is written into stdout as with CombinedOutput. A command that fails because another jj
+// process holds jj's own lock is retried after each of jjRetryDelays: jj takes that lock before
+// changing anything. `jj git push` and `jj git fetch` are never retried, since they may already
+// have updated the remote or the local refs.
+//
+// A command that finds the working copy stale marks it so (see WorkingCopyStale): a read-only one
+// is rerun with --ignore-working-copy so the views still load, and mutating ones are refused with
+// ErrWorkingCopyStale until `jj workspace update-stale` runs, rather than failing partway through
+// a multi-step action. In preview mode a mutating command first waits for the user's approval
+// (see previewCommand). Mutating commands that run are recorded in the audit log (see
+// EnableAuditLog) and reported on the --events stream, whether or not they go into the history.
+func (s *Service) execJJ(ctx context.Context, args, extraEnv []string, combined bool) (stdout, stderr string, err error) {
+	readOnly := events.ReadOnly(args)
+	if s.stale.Load() && !readOnly && !isUpdateStale(args) {
+		return "", "", ErrWorkingCopyStale
+	}
+	if err := s.previewCommand(ctx, args); err != nil {
+		return "", "", err
+	}
+	if !readOnly {
+		start := time.Now()
+		defer func() {
+			s.recordAudit(args, start, stdout, stderr, err)
+			s.emitCommandEvent(CommandLine(args), start, commandError(stdout, stderr, err))
+		}()
+	}
+	if s.InteractiveAuth && talksToRemote(args) {
+		extraEnv = append(noPromptEnv(), extraEnv...)
+		defer func() {
+			if err == nil {
+				return
+			}
+			if prompt := authPromptLine(stderr + "\n" + stdout); prompt != "" {
+				err = &AuthPromptError{Args: args, Prompt: prompt, err: err}
+			}
+		}()
+	}
+	for attempt := 0; ; attempt++ {
+		stdout, stderr, err = s.execJJOnce(ctx, args, extraEnv, combined)
+		if err == nil {
+			if isUpdateStale(args) || loadsWorkingCopy(args) {
+				s.stale.Store(false)
+			}
+			if isConfigWrite(args) {
+				// Revset aliases may have changed without a new operation.
+				s.InvalidateCache()
+			}
+			return stdout, stderr, nil
+		}
+		if attempt >= len(jjRetryDelays) || ctx.Err() != nil || talksToRemote(args) {
+			return stdout, stderr, err
+		}
+		switch classifyJJFailure(stderr + "\n" + stdout) {
+		case jjFailureLocked:
+		case jjFailureStale:
+			if isUpdateStale(args) {
+				return stdout, stderr, err
+			}
+			s.stale.Store(true)
+			if !readOnly || !loadsWorkingCopy(args) {
+				return stdout, stderr, err
+			}
+			return s.execJJOnce(ctx, append([]string{"--ignore-working-copy"}, args...), extraEnv, combined)
+		default:
+			return stdout, stderr, err
+		}
+		select {
+		case <-ctx.Done():
+			return stdout, stderr, err
+		case <-time.After(jjRetryDelays[attempt]):
+		}
+	}
+}
+
+func isUpdateStale(args []string) bool {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "workspace" && args[i+1] == "update-stale" {
+			return true
+		}
+	}
+	return false
+}
+
+// execJJOnce runs jj once; see execJJ.
+func (s *Service) execJJOnce(ctx context.Context, args, extraEnv []string, combined bool) (string, string, error) {
+	cmd := exec.CommandContext(ctx, "jj", args...)
+	cmd.Dir = s.RepoPath
+	if len(extraEnv) > 0 {
+		cmd.Env = append(append([]string{}, os.Environ()...), extraEnv...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if combined {
+		cmd.Stderr = &stdout
+	}
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
@@ -0,0 +1,198 @@
+package jj
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/madicen/jj-tui/internal/events"
+)
+
+func TestClassifyJJFailure(t *testing.T) {
+	tests := []struct {
+		out  string
+		want jjFailureKind
+	}{
+		{"Error: The working copy is stale (not updated since operation 1234).\nHint: Run `jj workspace update-stale`", jjFailureStale},
+		{"Error: Failed to lock working copy", jjFailureLocked},
+		{"fatal: Unable to create '/repo/.git/index.lock': File exists.", jjFailurePermanent},
+		{"error: cannot lock ref 'refs/heads/main'", jjFailurePermanent},
+		{"Error: Revision `nope` doesn't exist", jjFailurePermanent},
+	}
+	for _, tt := range tests {
+		if got := classifyJJFailure(tt.out); got != tt.want {
+			t.Errorf("classifyJJFailure(%q) = %v, want %v", tt.out, got, tt.want)
+		}
+	}
+}
+
+// fakeJJ puts a jj script on PATH that runs body with $log naming a file that records each call.
+func fakeJJ(t *testing.T, body string) (log string) {
+	t.Helper()
+	dir := t.TempDir()
+	log = filepath.Join(dir, "calls")
+	script := "#!/bin/sh\nlog=" + log + "\necho \"$*\" >> \"$log\"\n" + body + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "jj"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	saved := jjRetryDelays
+	jjRetryDelays = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	t.Cleanup(func() { jjRetryDelays = saved })
+	return log
+}
+
+func calls(t *testing.T, log string) []string {
+	t.Helper()
+	b, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(b)), "\n")
+}
+
+func TestRunJJRetriesLockContention(t *testing.T) {
+	log := fakeJJ(t, `[ $(wc -l < "$log") -lt 3 ] && { echo "Error: Failed to lock working copy" >&2; exit 1; }; echo ok`)
+	s := &Service{RepoPath: t.TempDir()}
+	out, err := s.runJJOutput(context.Background(), "log", "-r", "@")
+	if err != nil || strings.TrimSpace(out) != "ok" {
+		t.Fatalf("out=%q err=%v", out, err)
+	}
+	if got := calls(t, log); len(got) != 3 {
+		t.Errorf("calls = %q, want 3 attempts", got)
+	}
+}
+
+func TestRunJJGivesUpAfterRetries(t *testing.T) {
+	log := fakeJJ(t, `echo "Error: Failed to lock working copy" >&2; exit 1`)
+	s := &Service{RepoPath: t.TempDir()}
+	err := s.runJJ(context.Background(), "describe", "-m", "x")
+	if err == nil || !strings.Contains(err.Error(), "Failed to lock") {
+		t.Fatalf("err = %v", err)
+	}
+	if got := calls(t, log); len(got) != len(jjRetryDelays)+1 {
+		t.Errorf("calls = %d, want %d", len(got), len(jjRetryDelays)+1)
+	}
+}
+
+func TestRunJJNeverRetriesRemoteCommands(t *testing.T) {
+	log := fakeJJ(t, `echo "Error: Failed to lock working copy" >&2; exit 1`)
+	s := &Service{RepoPath: t.TempDir()}
+	if err := s.runJJ(context.Background(), "git", "push", "--bookmark", "main"); err == nil {
+		t.Fatal("expected the push to fail")
+	}
+	if got := calls(t, log); len(got) != 1 {
+		t.Errorf("calls = %q, want a single attempt", got)
+	}
+}
+
+// staleJJ is a fake jj whose working copy is stale until `jj workspace update-stale` runs, except
+// for commands run with --ignore-working-copy.
+const staleJJ = `case "$*" in
+"workspace update-stale") touch "$log.fixed"; exit 0;;
+--ignore-working-copy*) echo ignored; exit 0;;
+esac
+[ -f "$log.fixed" ] || { echo "Error: The working copy is stale (not updated since operation 0a1b)." >&2; exit 1; }
+echo done`
+
+func TestRunJJRefusesMutationsWhileStale(t *testing.T) {
+	log := fakeJJ(t, staleJJ)
+	s := &Service{RepoPath: t.TempDir()}
+	ctx := context.Background()
+	if err := s.runJJ(ctx, "new"); err == nil || !s.WorkingCopyStale() {
+		t.Fatalf("err = %v, stale = %v", err, s.WorkingCopyStale())
+	}
+	if err := s.runJJ(ctx, "describe", "-m", "x"); !errors.Is(err, ErrWorkingCopyStale) {
+		t.Fatalf("err = %v, want ErrWorkingCopyStale", err)
+	}
+	if err := s.UpdateStale(ctx); err != nil || s.WorkingCopyStale() {
+		t.Fatalf("err = %v, stale = %v", err, s.WorkingCopyStale())
+	}
+	if err := s.runJJ(ctx, "new"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"new", "workspace update-stale", "new"}
+	if got := calls(t, log); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+}
+
+func TestReadOnlyCommandIgnoresStaleWorkingCopy(t *testing.T) {
+	log := fakeJJ(t, staleJJ)
+	s := &Service{RepoPath: t.TempDir()}
+	out, err := s.runJJOutputNoHistory(context.Background(), "log", "-r", "@")
+	if err != nil || strings.TrimSpace(out) != "ignored" {
+		t.Fatalf("out = %q, err = %v", out, err)
+	}
+	if !s.WorkingCopyStale() {
+		t.Error("working copy not marked stale")
+	}
+	want := []string{"log -r @", "--ignore-working-copy log -r @"}
+	if got := calls(t, log); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+}
+
+func TestRunJJDoesNotRetryOtherErrors(t *testing.T) {
+	log := fakeJJ(t, "echo \"Error: Revision `nope` doesn't exist\" >&2; exit 1")
+	s := &Service{RepoPath: t.TempDir()}
+	if err := s.runJJ(context.Background(), "edit", "nope"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := calls(t, log); len(got) != 1 {
+		t.Errorf("calls = %q, want 1", got)
+	}
+}
+
+// captureEvents directs the --events stream to a buffer for the test and returns a func that
+// decodes what was written so far.
+func captureEvents(t *testing.T) func() []events.Event {
+	t.Helper()
+	var buf bytes.Buffer
+	events.SetOutput(&buf)
+	t.Cleanup(func() { events.SetOutput(nil) })
+	return func() []events.Event {
+		var got []events.Event
+		dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+		for dec.More() {
+			var e events.Event
+			if err := dec.Decode(&e); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, e)
+		}
+		return got
+	}
+}
+
+func TestExecJJEmitsEventsForMutations(t *testing.T) {
+	fakeJJ(t, "")
+	got := captureEvents(t)
+	s := &Service{RepoPath: t.TempDir()}
+	ctx := context.Background()
+	if _, err := s.runJJOutputNoHistory(ctx, "file", "show", "-r", "@", "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.runJJOutputNoHistory(ctx, "file", "track", "b.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.runJJ(ctx, "new", "@"); err != nil {
+		t.Fatal(err)
+	}
+	var cmds []string
+	for _, e := range got() {
+		if e.Type != events.TypeOpExecuted || e.Repo != s.RepoPath {
+			t.Errorf("event = %+v, want op_executed for %s", e, s.RepoPath)
+		}
+		cmds = append(cmds, e.Command)
+	}
+	if want := []string{"jj file track b.txt", "jj new @"}; strings.Join(cmds, "|") != strings.Join(want, "|") {
+		t.Errorf("events for %v, want %v", cmds, want)
+	}
+}
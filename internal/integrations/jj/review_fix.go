@@ -0,0 +1,46 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+// ReviewFixMessage is the description of the commit StartReviewFix creates for comment.
+func ReviewFixMessage(c internal.ReviewComment) string {
+	summary := strings.TrimSpace(c.Body)
+	if i := strings.IndexByte(summary, '\n'); i >= 0 {
+		summary = strings.TrimSpace(summary[:i])
+	}
+	if r := []rune(summary); len(r) > 60 {
+		summary = string(r[:59]) + "…"
+	}
+	msg := fmt.Sprintf("Address review: %s:%d", c.Path, c.Line)
+	if summary != "" {
+		msg += "\n\n" + summary
+	}
+	return msg
+}
+
+// StartReviewFix checks out a new working-copy commit on top of bookmark to address a review
+// comment; squash it into its parent (`jj squash`) to amend the PR's commit. When the bookmark
+// only exists on origin (someone else's PR), it is fetched and the new commit goes on
+// bookmark@origin.
+func (s *Service) StartReviewFix(ctx context.Context, bookmark, message string) error {
+	if bookmark == "" {
+		return fmt.Errorf("the PR has no head branch")
+	}
+	err := s.runJJ(ctx, "new", bookmark, jjMessageArg(message))
+	if err == nil {
+		return nil
+	}
+	if !strings.Contains(err.Error(), "doesn't exist") {
+		return err
+	}
+	if ferr := s.runJJ(ctx, "git", "fetch", "-b", bookmark); ferr != nil {
+		return fmt.Errorf("bookmark %s is not local and fetching it failed: %w", bookmark, ferr)
+	}
+	return s.runJJ(ctx, "new", bookmark+"@origin", jjMessageArg(message))
+}
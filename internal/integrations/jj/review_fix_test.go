@@ -0,0 +1,17 @@
+package jj
+
+import (
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+func TestReviewFixMessage(t *testing.T) {
+	c := internal.ReviewComment{Path: "main.go", Line: 12, Body: "Nit: wrap this error.\n\nIt loses the path otherwise."}
+	if got, want := ReviewFixMessage(c), "Address review: main.go:12\n\nNit: wrap this error."; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := ReviewFixMessage(internal.ReviewComment{Path: "a.go", Line: 1}), "Address review: a.go:1"; got != want {
+		t.Errorf("empty body: got %q, want %q", got, want)
+	}
+}
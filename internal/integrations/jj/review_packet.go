@@ -0,0 +1,79 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// reviewCommitTemplate prints one commit per record: short change ID, short commit ID, author
+// name, author date, and the full description, separated by unit separators.
+const reviewCommitTemplate = `change_id.shortest(8) ++ "\x1f" ++ commit_id.short(8) ++ "\x1f" ++ author.name() ++ "\x1f" ++ ` +
+	`author.timestamp().format("%Y-%m-%d") ++ "\x1f" ++ description ++ "\x1e"`
+
+// ReviewCommit is one commit of a bookmark under review.
+type ReviewCommit struct {
+	ChangeID    string
+	CommitID    string
+	Author      string
+	Date        string // YYYY-MM-DD
+	Description string // full description, trailing newline trimmed
+}
+
+// ReviewPacket is what a reviewer needs to read a bookmark without the forge's UI: the commits
+// it adds to trunk and their combined diff.
+type ReviewPacket struct {
+	Bookmark string // name, or name@remote for a remote bookmark
+	Commits  []ReviewCommit
+	Diff     string // git format, from the fork point with trunk to the bookmark
+}
+
+// ReviewPacket collects the commits bookmark (on remote, when remote is non-empty) adds to trunk,
+// newest first, and their combined diff.
+func (s *Service) ReviewPacket(ctx context.Context, bookmark, remote string) (*ReviewPacket, error) {
+	bookmark = util.LocalBookmarkName(util.BookmarkNameForRevset(bookmark))
+	if bookmark == "" {
+		return nil, fmt.Errorf("bookmark name is required")
+	}
+	p := &ReviewPacket{Bookmark: bookmark}
+	ref := fmt.Sprintf("bookmarks(%s)", util.RevsetExactPattern(bookmark))
+	if remote != "" {
+		p.Bookmark += "@" + remote
+		ref = fmt.Sprintf("remote_bookmarks(%s, %s)", util.RevsetExactPattern(bookmark), util.RevsetExactPattern(remote))
+	}
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", "trunk()..("+ref+")", "--no-graph", "-T", reviewCommitTemplate)
+	if err != nil {
+		return nil, err
+	}
+	p.Commits = parseReviewCommits(out)
+	if len(p.Commits) == 0 {
+		return nil, fmt.Errorf("%s has no commits that are not on trunk", p.Bookmark)
+	}
+	from := fmt.Sprintf("fork_point(trunk() | %s)", ref)
+	p.Diff, err = s.runJJOutputNoHistory(ctx, "diff", "--from", from, "--to", ref, "--git", "--color", "never")
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parseReviewCommits parses reviewCommitTemplate output.
+func parseReviewCommits(out string) []ReviewCommit {
+	var commits []ReviewCommit
+	for _, rec := range strings.Split(out, "\x1e") {
+		parts := strings.SplitN(strings.TrimLeft(rec, "\r\n"), "\x1f", 5)
+		if len(parts) < 5 {
+			continue
+		}
+		commits = append(commits, ReviewCommit{
+			ChangeID:    parts[0],
+			CommitID:    parts[1],
+			Author:      parts[2],
+			Date:        parts[3],
+			Description: strings.TrimRight(parts[4], "\n"),
+		})
+	}
+	return commits
+}
@@ -0,0 +1,49 @@
+package jj
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestReviewPacket(t *testing.T) {
+	log := fakeJJ(t, `case "$1" in
+log) printf 'kkk\037abc12345\037Ada\0372025-01-02\037Fix the parser\n\nIt dropped the last token.\n\036lll\037def67890\037Bob\0372025-01-01\037\036' ;;
+diff) printf 'diff --git a/p.go b/p.go\n' ;;
+esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	p, err := s.ReviewPacket(context.Background(), "feat", "origin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Bookmark != "feat@origin" || !strings.HasPrefix(p.Diff, "diff --git") {
+		t.Fatalf("packet = %+v", p)
+	}
+	want := []ReviewCommit{
+		{ChangeID: "kkk", CommitID: "abc12345", Author: "Ada", Date: "2025-01-02", Description: "Fix the parser\n\nIt dropped the last token."},
+		{ChangeID: "lll", CommitID: "def67890", Author: "Bob", Date: "2025-01-01"},
+	}
+	if len(p.Commits) != len(want) {
+		t.Fatalf("commits = %+v", p.Commits)
+	}
+	for i := range want {
+		if p.Commits[i] != want[i] {
+			t.Errorf("commit %d = %+v, want %+v", i, p.Commits[i], want[i])
+		}
+	}
+	c := calls(t, log)
+	if len(c) != 2 || !strings.Contains(c[0], `trunk()..(remote_bookmarks(exact:"feat", exact:"origin"))`) {
+		t.Fatalf("calls = %q", c)
+	}
+	if !strings.Contains(c[1], `--from fork_point(trunk() | remote_bookmarks(exact:"feat", exact:"origin"))`) {
+		t.Fatalf("diff call = %q", c[1])
+	}
+}
+
+func TestReviewPacketNothingToReview(t *testing.T) {
+	fakeJJ(t, "exit 0")
+	s := &Service{RepoPath: t.TempDir()}
+	if _, err := s.ReviewPacket(context.Background(), "main", ""); err == nil {
+		t.Fatal("expected an error for a bookmark with no commits off trunk")
+	}
+}
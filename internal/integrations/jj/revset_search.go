@@ -0,0 +1,62 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+// SearchTextRevset returns a revset matching commits whose description or author contains text
+// (case-insensitive).
+func SearchTextRevset(text string) string {
+	lit := quoteRevsetString(text)
+	return fmt.Sprintf("description(substring-i:%s) | author(substring-i:%s)", lit, lit)
+}
+
+// quoteRevsetString returns s as a double-quoted revset (and fileset) string literal.
+func quoteRevsetString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// ApplySearchToRevset widens base (DefaultGraphRevset when empty) with the commits matching
+// search, so matches show up in context. An empty search returns base unchanged.
+func ApplySearchToRevset(base, search string) string {
+	if search == "" {
+		return base
+	}
+	base = strings.TrimSpace(base)
+	if base == "" {
+		base = DefaultGraphRevset
+	}
+	return fmt.Sprintf("(%s) | (%s)", base, search)
+}
+
+// GetCommitGraphWithRevset loads the commit graph for exactly revset, ignoring the graph
+// filters. Unlike the graph loads, a revset jj rejects is an error instead of a fallback.
+func (s *Service) GetCommitGraphWithRevset(ctx context.Context, revset string) (*internal.CommitGraph, error) {
+	if strings.TrimSpace(revset) == "" {
+		return nil, fmt.Errorf("empty revset")
+	}
+	if _, err := s.runJJOutputNoHistory(ctx, "log", "-r", revset, "--no-graph", "--limit", "1", "-T", `""`); err != nil {
+		return nil, err
+	}
+	return s.getCommitGraph(ctx, revset, true)
+}
+
+// ResolveGraphSearch runs a graph search: query is tried as a revset first and, when jj rejects
+// it, as free text matched against descriptions and authors. It returns the revset used and the
+// matching commits; the error is jj's for the revset when neither form works.
+func (s *Service) ResolveGraphSearch(ctx context.Context, query string) (string, []internal.Commit, error) {
+	graph, err := s.GetCommitGraphWithRevset(ctx, query)
+	if err == nil {
+		return query, graph.Commits, nil
+	}
+	text := SearchTextRevset(query)
+	graph, textErr := s.GetCommitGraphWithRevset(ctx, text)
+	if textErr != nil {
+		return "", nil, err
+	}
+	return text, graph.Commits, nil
+}
@@ -0,0 +1,20 @@
+package jj
+
+import "testing"
+
+func TestSearchTextRevset(t *testing.T) {
+	got := SearchTextRevset(`fix "auth" \ bug`)
+	want := `description(substring-i:"fix \"auth\" \\ bug") | author(substring-i:"fix \"auth\" \\ bug")`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestApplySearchToRevset(t *testing.T) {
+	if got := ApplySearchToRevset("all()", ""); got != "all()" {
+		t.Errorf("no search: %q", got)
+	}
+	if got, want := ApplySearchToRevset("", "author(alice)"), "("+DefaultGraphRevset+") | (author(alice))"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package jj
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj/parse"
+)
+
+// DescribedRevset selects what describing changeID rewrites: the change and its descendants (jj
+// rebases them, so their commit IDs change too).
+func DescribedRevset(changeID string) string {
+	return "descendants(" + changeID + ")"
+}
+
+// RefreshCommits re-reads the commits matching revset and patches them into repo's graph, so
+// an action that only touched a few commits (describe, a bookmark move) doesn't pay for a full
+// GetRepository. Patched commits keep their graph art and are enriched the way a graph load
+// enriches them; commits matching revset that the graph doesn't show are ignored.
+//
+// ok is false when the graph can't be patched and the caller should do a full load: nothing in
+// revset is in the graph, a change is divergent, the working copy moved, or a commit's parents
+// changed in number (the graph art around it would be wrong).
+func (s *Service) RefreshCommits(ctx context.Context, repo *internal.Repository, revset string) (refreshed *internal.Repository, ok bool, err error) {
+	if repo == nil {
+		return nil, false, nil
+	}
+	var bmOut string
+	var bmErr error
+	var bmWG sync.WaitGroup
+	bmWG.Add(1)
+	go func() {
+		defer bmWG.Done()
+		bmOut, bmErr = s.runJJOutputNoHistory(ctx, "bookmark", "list", s.BookmarkListRemoteFlag())
+	}()
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", revset, "--no-graph", "-T", parse.GraphTemplate)
+	bmWG.Wait()
+	if err != nil {
+		return nil, false, err
+	}
+	rows, err := parse.GraphRows(out)
+	if err != nil {
+		return nil, false, err
+	}
+
+	index := make(map[string]int, len(repo.Graph.Commits))
+	for i, c := range repo.Graph.Commits {
+		if _, dup := index[c.ChangeID]; dup {
+			index[c.ChangeID] = -1 // divergent: can't tell which row to patch
+			continue
+		}
+		index[c.ChangeID] = i
+	}
+	var at []int
+	var patched []internal.Commit
+	for _, row := range rows {
+		i, shown := index[row.Commit.ChangeID]
+		if !shown {
+			continue
+		}
+		c := graphRowCommit(row)
+		if i < 0 || c.Divergent {
+			return nil, false, nil
+		}
+		old := repo.Graph.Commits[i]
+		if len(c.Parents) != len(old.Parents) || c.IsWorking != old.IsWorking {
+			return nil, false, nil
+		}
+		c.GraphPrefix, c.GraphLines = old.GraphPrefix, old.GraphLines
+		at = append(at, i)
+		patched = append(patched, c)
+	}
+	if len(patched) == 0 {
+		return nil, false, nil
+	}
+	s.enrichGraphCommits(ctx, patched, revset, bmOut, bmErr)
+
+	commits := slices.Clone(repo.Graph.Commits)
+	for j, i := range at {
+		commits[i] = patched[j]
+	}
+	connections := make(map[string][]string)
+	for _, c := range commits {
+		for _, parent := range c.Parents {
+			connections[parent] = append(connections[parent], c.ID)
+		}
+	}
+	next := *repo
+	next.Graph = internal.CommitGraph{Commits: commits, Connections: connections, HasMore: repo.Graph.HasMore}
+	for _, c := range commits {
+		if c.IsWorking {
+			next.WorkingCopy = c
+			break
+		}
+	}
+	return &next, true, nil
+}
@@ -0,0 +1,67 @@
+package jj
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+func scopedTestRepo() *internal.Repository {
+	return &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ID: "1a2b3c4d", ChangeID: "kxqvmtsz", Summary: "Top", Parents: []string{"5e6f7a8b"}, IsWorking: true, GraphPrefix: "@  "},
+		{ID: "5e6f7a8b", ChangeID: "zzplqrwn", Summary: "Old message", Parents: []string{"9c0d1e2f"}, GraphPrefix: "○  ", GraphLines: []string{"│"}},
+		{ID: "9c0d1e2f", ChangeID: "yyplqrwn", Summary: "Base", Immutable: true, GraphPrefix: "◆  "},
+	}}}
+}
+
+// Describing the middle commit rewrites it and the working copy on top; both are patched in with
+// their graph art, the rest of the graph and commits outside it are left alone.
+func TestRefreshCommitsPatchesGraph(t *testing.T) {
+	fakeJJ(t, `case "$*" in *COMMIT*) cat <<'OUT'
+<<<COMMIT>>>{"change_id":"kxqvmtsz","commit_id":"aaaa1111","author":"me@example.com","timestamp":"2025-06-10T08:30:00Z","description":"Top","parents":["bbbb2222"],"bookmarks":[],"working_copy":true,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+<<<COMMIT>>>{"change_id":"zzplqrwn","commit_id":"bbbb2222","author":"me@example.com","timestamp":"2025-06-01T00:00:00Z","description":"New message","parents":["9c0d1e2f"],"bookmarks":["feat"],"working_copy":false,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+<<<COMMIT>>>{"change_id":"nnnnnnnn","commit_id":"cccc3333","author":"me@example.com","timestamp":"2025-06-01T00:00:00Z","description":"Hidden","parents":["bbbb2222"],"bookmarks":[],"working_copy":false,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+OUT
+;; esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	repo := scopedTestRepo()
+	got, ok, err := s.RefreshCommits(context.Background(), repo, DescribedRevset("zzplqrwn"))
+	if err != nil || !ok {
+		t.Fatalf("ok=%v err=%v", ok, err)
+	}
+	if len(got.Graph.Commits) != 3 {
+		t.Fatalf("commits = %+v", got.Graph.Commits)
+	}
+	mid := got.Graph.Commits[1]
+	if mid.ID != "bbbb2222" || mid.Summary != "New message" || !slices.Equal(mid.Branches, []string{"feat"}) ||
+		mid.GraphPrefix != "○  " || !slices.Equal(mid.GraphLines, []string{"│"}) {
+		t.Errorf("middle commit = %+v", mid)
+	}
+	if got.WorkingCopy.ID != "aaaa1111" || got.Graph.Commits[2].ID != "9c0d1e2f" {
+		t.Errorf("working copy = %+v, base = %+v", got.WorkingCopy, got.Graph.Commits[2])
+	}
+	if !slices.Equal(got.Graph.Connections["bbbb2222"], []string{"aaaa1111"}) {
+		t.Errorf("connections = %v", got.Graph.Connections)
+	}
+	if repo.Graph.Commits[1].Summary != "Old message" {
+		t.Error("the original repository should not be modified")
+	}
+}
+
+// A commit whose parents changed in number can't keep its graph art: fall back to a full load.
+func TestRefreshCommitsFallsBackWhenShapeChanges(t *testing.T) {
+	fakeJJ(t, `case "$*" in *COMMIT*) cat <<'OUT'
+<<<COMMIT>>>{"change_id":"zzplqrwn","commit_id":"bbbb2222","author":"me@example.com","timestamp":"2025-06-01T00:00:00Z","description":"Merge","parents":["9c0d1e2f","dddd4444"],"bookmarks":[],"working_copy":false,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+OUT
+;; esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	if _, ok, err := s.RefreshCommits(context.Background(), scopedTestRepo(), "zzplqrwn"); ok || err != nil {
+		t.Fatalf("ok=%v err=%v, want a fallback", ok, err)
+	}
+	fakeJJ(t, `exit 0`)
+	if _, ok, _ := s.RefreshCommits(context.Background(), scopedTestRepo(), "none()"); ok {
+		t.Fatal("nothing in the graph to patch should fall back too")
+	}
+}
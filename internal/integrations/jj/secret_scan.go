@@ -0,0 +1,246 @@
+package jj
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// secretScanLimit caps how many commits a pre-push secret scan reads. A push with more outgoing
+// commits is blocked rather than partly scanned.
+const secretScanLimit = 200
+
+// secretScanCommitMarker starts the line secretScanTemplate prints before each commit's diff.
+const secretScanCommitMarker = "jj-tui-commit\t"
+
+const secretScanTemplate = `"jj-tui-commit\t" ++ change_id.shortest(8) ++ "\n"`
+
+// SecretPattern is a named regular expression for a kind of credential.
+type SecretPattern struct {
+	Name string
+	Re   *regexp.Regexp
+}
+
+// DefaultSecretPatterns are the credentials the built-in scanner looks for in added lines.
+var DefaultSecretPatterns = []SecretPattern{
+	{"AWS access key ID", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"GitLab token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)},
+	{"Stripe live key", regexp.MustCompile(`\b[sr]k_live_[A-Za-z0-9]{20,}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY( BLOCK)?-----`)},
+	{"hard-coded secret", regexp.MustCompile(`(?i)\b(?:api[_-]?key|secret|passw(?:or)?d|access[_-]?token|auth[_-]?token)\b["']?\s*(?::=|[:=])\s*["'][^"'\s]{12,}["']`)},
+}
+
+// SecretScanRules configures the pre-push secret scan. When Enabled, pushes run the built-in
+// patterns plus Patterns (extra regular expressions) over the outgoing diff, and Command (run
+// with sh, the diff on stdin; non-zero exit means secrets were found) when it is set.
+type SecretScanRules struct {
+	Enabled  bool
+	Patterns []string
+	Command  string
+}
+
+// SecretFinding is a potential credential on a line the pushed commits add.
+type SecretFinding struct {
+	ChangeID string
+	Path     string
+	Line     int    // line in the new file; 0 when unknown
+	Rule     string // name of the pattern that matched
+	Match    string // the match, redacted
+}
+
+// SecretsFoundError blocks a push whose outgoing changes look like they contain credentials.
+// Its message is the report shown to the user.
+type SecretsFoundError struct {
+	Bookmarks []string
+	Findings  []SecretFinding
+	// ToolOutput is what the external scanner printed when it flagged the diff.
+	ToolOutput string
+}
+
+func (e *SecretsFoundError) Error() string {
+	var b strings.Builder
+	n := len(e.Findings)
+	switch {
+	case n > 0:
+		fmt.Fprintf(&b, "Push of %s blocked: %d potential %s in the outgoing changes.\n\n", strings.Join(e.Bookmarks, ", "), n, pluralSecrets(n))
+		for _, f := range e.Findings {
+			loc := f.Path
+			if f.Line > 0 {
+				loc += ":" + strconv.Itoa(f.Line)
+			}
+			fmt.Fprintf(&b, "  %s  %s  %s (%s)\n", f.ChangeID, loc, f.Rule, f.Match)
+		}
+	default:
+		fmt.Fprintf(&b, "Push of %s blocked: the secret scanner flagged the outgoing changes.\n", strings.Join(e.Bookmarks, ", "))
+	}
+	if out := strings.TrimSpace(e.ToolOutput); out != "" {
+		fmt.Fprintf(&b, "\nScanner output:\n%s\n", out)
+	}
+	b.WriteString("\nRemove the secret from the commit (and rotate it), or turn off secret_scan in the config to push anyway.")
+	return b.String()
+}
+
+func pluralSecrets(n int) string {
+	if n == 1 {
+		return "secret"
+	}
+	return "secrets"
+}
+
+// CheckSecretsBeforePush scans what pushing bookmarks to origin would publish and returns a
+// *SecretsFoundError when it finds potential credentials. It returns nil when scanning is off.
+func (s *Service) CheckSecretsBeforePush(ctx context.Context, bookmarks ...string) error {
+	if !s.SecretScan.Enabled || len(bookmarks) == 0 {
+		return nil
+	}
+	var revsets []string
+	for _, b := range bookmarks {
+		if strings.TrimSpace(b) == "" {
+			continue
+		}
+		r, _, err := s.outgoingRevset(ctx, b, "origin")
+		if err != nil {
+			return fmt.Errorf("secret scan: %w", err)
+		}
+		revsets = append(revsets, "("+r+")")
+	}
+	if len(revsets) == 0 {
+		return nil
+	}
+	return s.scanRevsetForSecrets(ctx, bookmarks, strings.Join(revsets, " | "))
+}
+
+// scanRevsetForSecrets scans the commits in revset; targets names what is being pushed in the report.
+// More than secretScanLimit commits is an error, since the rest could not be checked.
+func (s *Service) scanRevsetForSecrets(ctx context.Context, targets []string, revset string) error {
+	ids, err := s.runJJOutputNoHistory(ctx, "log", "-r", revset, "--no-graph",
+		"--limit", strconv.Itoa(secretScanLimit+1), "-T", `change_id ++ "\n"`)
+	if err != nil {
+		return fmt.Errorf("secret scan: %w", err)
+	}
+	if n := len(strings.Fields(ids)); n > secretScanLimit {
+		return fmt.Errorf("secret scan: pushing %s would publish more than %d commits, which is more than the scan checks; push a smaller range or turn off secret_scan to push anyway",
+			strings.Join(targets, ", "), secretScanLimit)
+	}
+	diff, err := s.runJJOutputNoHistory(ctx, "log", "-r", revset, "--no-graph",
+		"--git", "--color", "never", "-T", secretScanTemplate)
+	if err != nil {
+		return fmt.Errorf("secret scan: %w", err)
+	}
+	patterns, err := s.SecretScan.patterns()
+	if err != nil {
+		return err
+	}
+	found := &SecretsFoundError{Bookmarks: targets, Findings: scanDiffForSecrets(diff, patterns)}
+	if s.SecretScan.Command != "" {
+		flagged, out, err := s.runSecretScanCommand(ctx, diff)
+		if err != nil {
+			return err
+		}
+		if flagged {
+			found.ToolOutput = out
+		}
+	}
+	if len(found.Findings) == 0 && found.ToolOutput == "" {
+		return nil
+	}
+	return found
+}
+
+// patterns returns the built-in patterns followed by the configured ones.
+func (r SecretScanRules) patterns() ([]SecretPattern, error) {
+	out := append([]SecretPattern(nil), DefaultSecretPatterns...)
+	for _, p := range r.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret_scan_patterns entry %q: %w", p, err)
+		}
+		out = append(out, SecretPattern{Name: "pattern " + p, Re: re})
+	}
+	return out, nil
+}
+
+// runSecretScanCommand pipes diff to the configured scanner. A non-zero exit means the scanner
+// flagged something; failing to start it is an error, so a broken scanner never lets a push through.
+func (s *Service) runSecretScanCommand(ctx context.Context, diff string) (flagged bool, output string, err error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", s.SecretScan.Command)
+	cmd.Dir = s.RepoPath
+	cmd.Stdin = strings.NewReader(diff)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return false, "", nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() != 126 && exitErr.ExitCode() != 127:
+		return true, out.String(), nil
+	default:
+		return false, "", fmt.Errorf("secret scan command %q failed: %v\n%s", s.SecretScan.Command, err, strings.TrimSpace(out.String()))
+	}
+}
+
+// scanDiffForSecrets matches patterns against the lines added in secretScanTemplate log output
+// with git diffs. Matches are redacted to their first four characters.
+func scanDiffForSecrets(diff string, patterns []SecretPattern) []SecretFinding {
+	var findings []SecretFinding
+	var changeID, path string
+	line := 0
+	for _, l := range strings.Split(diff, "\n") {
+		l = strings.TrimRight(l, "\r")
+		switch {
+		case strings.HasPrefix(l, secretScanCommitMarker):
+			changeID, path, line = strings.TrimPrefix(l, secretScanCommitMarker), "", 0
+		case strings.HasPrefix(l, "+++ "):
+			path = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/")
+		case strings.HasPrefix(l, "@@"):
+			line = hunkNewStart(l)
+		case strings.HasPrefix(l, "+"):
+			for _, p := range patterns {
+				if m := p.Re.FindString(l[1:]); m != "" {
+					findings = append(findings, SecretFinding{ChangeID: changeID, Path: path, Line: line, Rule: p.Name, Match: redactSecret(m)})
+					break
+				}
+			}
+			if line > 0 {
+				line++
+			}
+		case strings.HasPrefix(l, " "):
+			if line > 0 {
+				line++
+			}
+		}
+	}
+	return findings
+}
+
+// hunkNewStart returns the new-file start line of a "@@ -a,b +c,d @@" header (0 if unparsable).
+func hunkNewStart(header string) int {
+	_, rest, ok := strings.Cut(header, " +")
+	if !ok {
+		return 0
+	}
+	end := strings.IndexAny(rest, ", ")
+	if end < 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(rest[:end])
+	return n
+}
+
+func redactSecret(m string) string {
+	r := []rune(m)
+	if len(r) <= 8 {
+		return strings.Repeat("*", len(r))
+	}
+	return string(r[:4]) + "…" + strings.Repeat("*", 4)
+}
@@ -1,21 +1,23 @@
 package jj
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
 
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/events"
+	"github.com/madicen/jj-tui/internal/integrations/jj/parse"
 	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
@@ -33,7 +35,8 @@ type Service struct {
 	RepoPath       string
 	commandHistory []CommandHistoryEntry
 	historyMu      sync.RWMutex
-	maxHistory     int // Maximum number of commands to keep
+	maxHistory     int    // Maximum number of commands to keep
+	historyFile    string // when set, commands are also appended here (see EnableHistoryFile)
 
 	// BookmarkListPreferTracked, when true, makes helpers that need a bookmark
 	// listing call `jj bookmark list --tracked` instead of `--all-remotes`. The
@@ -47,6 +50,75 @@ type Service struct {
 	// (see data.InitializeServices). The zero value is false to preserve legacy
 	// behavior for tests / direct NewService callers.
 	BookmarkListPreferTracked bool
+
+	// GraphDateFilter narrows every graph load (foreground and quiet refresh) to commits
+	// committed in the range; see DateRange.ApplyToRevset. Set from the graph tab's date filter.
+	// The zero value shows the configured revset unchanged.
+	GraphDateFilter DateRange
+
+	// GraphOnlyMine narrows every graph load to commits authored by the current user (plus @);
+	// see ApplyOnlyMineToRevset. Set from the graph tab's author mode.
+	GraphOnlyMine bool
+
+	// GraphRevsetAlias narrows every graph load to a revset alias from the user's jj config
+	// (see ApplyRevsetAliasToRevset). Set from the graph tab's alias picker; "" = off.
+	GraphRevsetAlias string
+
+	// GraphSearch widens every graph load with the commits matching a revset (see
+	// ApplySearchToRevset). Set from the graph tab's search (/); "" = off.
+	GraphSearch string
+
+	// GraphLimit caps every graph load at this many commits (jj log --limit); the graph sets
+	// CommitGraph.HasMore when commits were left out. 0 = no limit. Set from config.GraphPage and
+	// raised a page at a time by the graph's "Load more" row.
+	GraphLimit int
+
+	// LargeFileRules decides which files push previews and the Create PR form warn about (see
+	// LargeFiles). Set from config.LargeFileWarnBytes and config.LargeFileWarnPatterns.
+	LargeFileRules LargeFileRules
+
+	// SecretScan configures the scan pushes run first (see CheckSecretsBeforePush). Set from
+	// config.SecretScanEnabled, secret_scan_patterns and secret_scan_command.
+	SecretScan SecretScanRules
+
+	// ProtectedBookmarks decides which bookmark pushes need the typed confirmation. Set from
+	// config.ProtectedBookmarkPatterns and config.ConfirmProtectedPush.
+	ProtectedBookmarks ProtectedBookmarks
+
+	// GitFallback makes PushToGit and FetchFromGit retry with plain git against the repo's git
+	// store when the jj command fails, e.g. for a remote jj can't authenticate to. Set from
+	// config.GitFallbackEnabled. Off by default: jj's error is returned as is.
+	GitFallback bool
+
+	// InteractiveAuth runs `jj git push` / `jj git fetch` with git's and ssh's prompts turned off
+	// and returns an *AuthPromptError when the remote wanted one, so the TUI can run the command
+	// again with the terminal (see InteractiveCommand). Set from config.InteractiveAuthEnabled.
+	InteractiveAuth bool
+
+	// PreviewCommand approves each mutating jj command before it runs while preview mode is on
+	// (see SetPreviewCommands); returning false cancels it with ErrCommandCancelled. Set by the TUI,
+	// which shows the command and waits for Confirm/Cancel.
+	PreviewCommand  func(ctx context.Context, p CommandPreview) bool
+	previewCommands atomic.Bool
+
+	// lastSnapshot is when the latest graph load started (UnixNano); jj snapshots the working
+	// copy at the start of it. PendingChanges compares file times against it.
+	lastSnapshot atomic.Int64
+
+	// ops is the undo/redo stack behind UndoN and RedoN.
+	ops opHistory
+
+	// trash lists the commits abandoned through AbandonToTrash, for RestoreFromTrash.
+	trash trashList
+
+	// cache answers repeated read-only queries within one operation (see runJJOutputCached).
+	cache queryCache
+
+	// stale is set while the working copy is stale (see WorkingCopyStale).
+	stale atomic.Bool
+
+	// audit records mutating commands when the audit log is on (see EnableAuditLog).
+	audit *auditLog
 }
 
 // BookmarkListRemoteFlag returns the flag to pass to `jj bookmark list`
@@ -172,6 +244,9 @@ func (s *Service) addToHistory(entry CommandHistoryEntry) {
 	defer s.historyMu.Unlock()
 
 	s.commandHistory = append(s.commandHistory, entry)
+	if s.historyFile != "" {
+		appendHistoryFile(s.historyFile, entry)
+	}
 
 	// Trim history if it exceeds the limit; copy to new slice to release backing array
 	if len(s.commandHistory) > s.maxHistory {
@@ -180,6 +255,15 @@ func (s *Service) addToHistory(entry CommandHistoryEntry) {
 	}
 }
 
+// emitCommandEvent mirrors a finished mutating command to the --events stream (no-op when it is
+// off). execJJ calls it for every command it runs, so runs kept out of the history are reported too.
+func (s *Service) emitCommandEvent(command string, start time.Time, errMsg string) {
+	if e, ok := events.CommandEvent(command, time.Since(start), errMsg); ok {
+		e.Repo = s.RepoPath
+		events.Emit(e)
+	}
+}
+
 // GetRepository retrieves the current repository state.
 // revset: optional jj revset for the graph; if empty, a default is used that focuses on
 // your work (mutable ancestors of @), bookmarks, and main to reduce noise from others' merges.
@@ -195,6 +279,13 @@ func (s *Service) GetRepositoryQuiet(ctx context.Context, revset string) (*inter
 }
 
 func (s *Service) getRepository(ctx context.Context, revset string, recordGraphInHistory bool) (*internal.Repository, error) {
+	s.markSnapshot(time.Now())
+	revset = s.GraphDateFilter.ApplyToRevset(revset)
+	if s.GraphOnlyMine {
+		revset = ApplyOnlyMineToRevset(revset)
+	}
+	revset = ApplyRevsetAliasToRevset(revset, s.GraphRevsetAlias)
+	revset = ApplySearchToRevset(revset, s.GraphSearch)
 	graph, err := s.getCommitGraph(ctx, revset, recordGraphInHistory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get commit graph: %w", err)
@@ -233,24 +324,7 @@ func (s *Service) CreateNewCommit(ctx context.Context, description string) error
 // DescribeCommit sets a new description for a commit (non-interactive)
 func (s *Service) DescribeCommit(ctx context.Context, commitID string, message string) error {
 	_, err := s.runJJOutput(ctx, "describe", commitID, jjMessageArg(message))
-	if err == nil {
-		return nil
-	}
-	if !isJJStaleWorkingCopyError(err) {
-		return err
-	}
-	if uerr := s.runJJ(ctx, "workspace", "update-stale"); uerr != nil {
-		return fmt.Errorf("%w\n\nCould not refresh stale working copy (jj workspace update-stale): %v", err, uerr)
-	}
-	_, err2 := s.runJJOutput(ctx, "describe", commitID, jjMessageArg(message))
-	return err2
-}
-
-func isJJStaleWorkingCopyError(err error) bool {
-	if err == nil {
-		return false
-	}
-	return strings.Contains(strings.ToLower(err.Error()), "working copy is stale")
+	return err
 }
 
 // GetCommitDescription gets the full description of a commit
@@ -262,6 +336,11 @@ func (s *Service) GetCommitDescription(ctx context.Context, commitID string) (st
 	return strings.TrimSpace(out), nil
 }
 
+// ShowRevision returns `jj show` output (description and diff) for a revision, with color.
+func (s *Service) ShowRevision(ctx context.Context, revision string) (string, error) {
+	return s.runJJOutputNoHistory(ctx, "show", "--color", "always", revision)
+}
+
 // GitFormatDiffForRevision returns a git-format unified diff for the revision against its parents.
 // If maxBytes > 0 and the output exceeds maxBytes, the diff is truncated and a trailer is appended.
 func (s *Service) GitFormatDiffForRevision(ctx context.Context, revision string, maxBytes int) (string, error) {
@@ -384,24 +463,6 @@ func (s *Service) GetCurrentOperationID(ctx context.Context) (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
-// Undo undoes the last jj operation and returns the operation ID that was undone (for Redo)
-func (s *Service) Undo(ctx context.Context) (string, error) {
-	// Capture current op ID before undoing so we can restore to it if needed
-	opID, err := s.GetCurrentOperationID(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to get current op id: %w", err)
-	}
-	return opID, s.runJJ(ctx, "undo")
-}
-
-// Redo restores to a specific operation ID
-func (s *Service) Redo(ctx context.Context, opID string) error {
-	if opID == "" {
-		return fmt.Errorf("no operation ID provided for redo")
-	}
-	return s.runJJ(ctx, "op", "restore", opID)
-}
-
 // ChangedFile represents a file changed in a commit
 type ChangedFile struct {
 	Path         string // File path
@@ -482,7 +543,7 @@ func compactWhenDisplay(ts string) string {
 	layouts := []string{time.RFC3339Nano, time.RFC3339, "2006-01-02 15:04:05.999999999 -0700 MST"}
 	for _, layout := range layouts {
 		if t, err := time.Parse(layout, ts); err == nil {
-			return t.Local().Format("Jan 02 2006 15:04")
+			return compactWhen(t)
 		}
 	}
 	if len(ts) > 20 {
@@ -491,13 +552,22 @@ func compactWhenDisplay(ts string) string {
 	return ts
 }
 
-// Template for one revision: per-file path, status char, lines added, lines removed (tab-separated lines).
+// compactWhen formats a commit timestamp for the divergent and bookmark-conflict views.
+func compactWhen(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("Jan 02 2006 15:04")
+}
+
+// Template for one revision: per-file status char, lines added, lines removed, path (tab-separated lines).
+// The path comes last so a tab in it stays part of the path.
 // Uses one jj invocation vs diff --summary + separate stat work; requires a jj build with Commit.diff().stat().
-const changedFilesStatLogTemplate = `self.diff().stat().files().map(|f| f.path().display() ++ "\t" ++ f.status_char() ++ "\t" ++ f.lines_added() ++ "\t" ++ f.lines_removed() ++ "\n")`
+const changedFilesStatLogTemplate = `self.diff().stat().files().map(|f| f.status_char() ++ "\t" ++ f.lines_added() ++ "\t" ++ f.lines_removed() ++ "\t" ++ f.path().display() ++ "\n")`
 
 // GetChangedFiles gets changed files for a revision vs its parents, with per-file line stats when supported.
 func (s *Service) GetChangedFiles(ctx context.Context, commitID string) ([]ChangedFile, error) {
-	out, err := s.runJJOutput(ctx, "log", "-r", commitID, "--no-graph", "-T", changedFilesStatLogTemplate)
+	out, err := s.runJJOutputCached(ctx, "log", "-r", commitID, "--no-graph", "-T", changedFilesStatLogTemplate)
 	if err == nil && strings.TrimSpace(out) != "" {
 		if files, perr := parseChangedFilesStatLogOutput(out); perr == nil && len(files) > 0 {
 			return files, nil
@@ -514,14 +584,14 @@ func parseChangedFilesStatLogOutput(out string) ([]ChangedFile, error) {
 		if strings.TrimSpace(line) == "" {
 			continue
 		}
-		parts := strings.Split(line, "\t")
+		parts := strings.SplitN(line, "\t", 4)
 		if len(parts) != 4 {
 			return nil, fmt.Errorf("expected 4 tab fields, got %d", len(parts))
 		}
-		path := strings.TrimSpace(parts[0])
-		status := strings.TrimSpace(parts[1])
-		added, err1 := strconv.Atoi(strings.TrimSpace(parts[2]))
-		removed, err2 := strconv.Atoi(strings.TrimSpace(parts[3]))
+		status := strings.TrimSpace(parts[0])
+		added, err1 := strconv.Atoi(strings.TrimSpace(parts[1]))
+		removed, err2 := strconv.Atoi(strings.TrimSpace(parts[2]))
+		path := strings.TrimSpace(parts[3])
 		if err1 != nil || err2 != nil {
 			return nil, fmt.Errorf("invalid line counts")
 		}
@@ -543,7 +613,7 @@ func parseChangedFilesStatLogOutput(out string) ([]ChangedFile, error) {
 }
 
 func (s *Service) getChangedFilesSummaryOnly(ctx context.Context, commitID string) ([]ChangedFile, error) {
-	out, err := s.runJJOutput(ctx, "diff", "--summary", "-r", commitID)
+	out, err := s.runJJOutputCached(ctx, "diff", "--summary", "-r", commitID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get changed files: %w", err)
 	}
@@ -720,7 +790,7 @@ func (s *Service) DiffRevisionFile(ctx context.Context, revision, path string) (
 // IsCommitMutable checks if a commit can be modified
 func (s *Service) IsCommitMutable(ctx context.Context, commitID string) bool {
 	// Try a no-op describe to see if the commit is mutable
-	_, err := s.runJJOutput(ctx, "log", "-r", commitID, "--no-graph", "-T", "if(immutable, \"immutable\", \"mutable\")")
+	_, err := s.runJJOutputCached(ctx, "log", "-r", commitID, "--no-graph", "-T", "if(immutable, \"immutable\", \"mutable\")")
 	return err == nil
 }
 
@@ -794,7 +864,7 @@ func (s *Service) MoveBookmark(ctx context.Context, bookmarkName, commitID strin
 
 // DeleteBookmark deletes a bookmark
 func (s *Service) DeleteBookmark(ctx context.Context, bookmarkName string) error {
-	return s.runJJ(ctx, "bookmark", "delete", util.JJExactBookmarkPattern(bookmarkName))
+	return s.runJJ(ctx, DeleteBookmarkArgs(bookmarkName)...)
 }
 
 // ResolveBookmarkConflictKeepLocal resolves a diverged/conflicted bookmark by collapsing the
@@ -835,34 +905,26 @@ func (s *Service) ResolveBookmarkConflictResetToRemote(ctx context.Context, book
 	return s.runJJ(ctx, "bookmark", "set", util.BookmarkArgForSetMove(bookmarkName), "-r", remoteRev)
 }
 
-// joinConflictTabLog parses jj log lines as change_id\tsummary\ttimestamp (tab-separated).
-func joinConflictTabLog(out string) (idJoined, summaryJoined, whenJoined string) {
+// joinConflictLog parses `jj log -T parse.LogTemplate` output for one side of a bookmark
+// conflict, joining the fields when the side points at several commits.
+func joinConflictLog(out string) (idJoined, summaryJoined, whenJoined string, err error) {
+	commits, err := parse.LogCommits(out)
+	if err != nil {
+		return "", "", "", err
+	}
 	var ids, sums, whens []string
-	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		parts := strings.Split(line, "\t")
-		ids = append(ids, strings.TrimSpace(parts[0]))
-		sum := ""
-		if len(parts) >= 2 {
-			sum = strings.TrimSpace(parts[1])
-		}
-		sums = append(sums, sum)
-		w := ""
-		if len(parts) >= 3 {
-			w = compactWhenDisplay(strings.TrimSpace(parts[2]))
-		}
-		whens = append(whens, w)
+	for _, c := range commits {
+		ids = append(ids, c.ChangeID)
+		sums = append(sums, strings.TrimSpace(c.Description))
+		whens = append(whens, compactWhen(c.Timestamp))
 	}
 	if len(ids) == 0 {
-		return "", "", ""
+		return "", "", "", nil
 	}
 	if len(ids) == 1 {
-		return ids[0], sums[0], whens[0]
+		return ids[0], sums[0], whens[0], nil
 	}
-	return strings.Join(ids, ", "), strings.Join(sums, " · "), strings.Join(whens, " · ")
+	return strings.Join(ids, ", "), strings.Join(sums, " · "), strings.Join(whens, " · "), nil
 }
 
 // GetBookmarkConflictInfo retrieves information about a conflicted bookmark
@@ -873,22 +935,25 @@ func (s *Service) GetBookmarkConflictInfo(ctx context.Context, bookmarkName stri
 	if bookmarkName == "" {
 		return "", "", "", "", "", "", fmt.Errorf("bookmark name is required")
 	}
-	logT := `change_id.short(8) ++ "\t" ++ if(description, description.first_line(), "(no description)") ++ "\t" ++ author.timestamp()`
 	// Conflicted bookmarks need bookmarks()/remote_bookmarks(), not a bare symbol (slashes, multi-target).
 	localRev := fmt.Sprintf("bookmarks(%s)", util.RevsetExactPattern(bookmarkName))
 	remoteRev := fmt.Sprintf("remote_bookmarks(%s, %s)",
 		util.RevsetExactPattern(bookmarkName), util.RevsetExactPattern("origin"))
-	localOut, err := s.runJJOutput(ctx, "log", "-r", localRev, "--no-graph", "-T", logT)
+	localOut, err := s.runJJOutput(ctx, "log", "-r", localRev, "--no-graph", "-T", parse.LogTemplate)
+	if err == nil {
+		localID, localSummary, localWhen, err = joinConflictLog(localOut)
+	}
 	if err != nil {
 		return "", "", "", "", "", "", fmt.Errorf("failed to get local bookmark info: %w", err)
 	}
-	localID, localSummary, localWhen = joinConflictTabLog(localOut)
 
-	remoteOut, err := s.runJJOutput(ctx, "log", "-r", remoteRev, "--no-graph", "-T", logT)
+	remoteOut, err := s.runJJOutput(ctx, "log", "-r", remoteRev, "--no-graph", "-T", parse.LogTemplate)
+	if err == nil {
+		remoteID, remoteSummary, remoteWhen, err = joinConflictLog(remoteOut)
+	}
 	if err != nil {
 		return localID, "", localSummary, "", localWhen, "", fmt.Errorf("failed to get remote bookmark info: %w", err)
 	}
-	remoteID, remoteSummary, remoteWhen = joinConflictTabLog(remoteOut)
 
 	return localID, remoteID, localSummary, remoteSummary, localWhen, remoteWhen, nil
 }
@@ -900,41 +965,24 @@ func (s *Service) GetDivergentCommitDetails(ctx context.Context, changeID string
 	if changeID == "" {
 		return nil, fmt.Errorf("change ID is required")
 	}
-	const template = `commit_id ++ "\t" ++ commit_id.short(12) ++ "\t" ++ if(description, description.first_line(), "(no description)") ++ "\t" ++ author.email() ++ "\t" ++ author.timestamp() ++ "\t" ++ parents.map(|p| p.commit_id().short(8)).join(",") ++ "\t" ++ bookmarks.join(",") ++ "\t" ++ if(immutable, "true", "false") ++ "\n"`
-	out, err := s.runJJOutput(ctx, "log", "-r", fmt.Sprintf("change_id(%s)", changeID), "--no-graph", "-T", template)
+	out, err := s.runJJOutput(ctx, "log", "-r", fmt.Sprintf("change_id(%s)", changeID), "--no-graph", "-T", parse.LogTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get divergent commit info: %w", err)
 	}
+	commits, err := parse.LogCommits(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse divergent commit info: %w", err)
+	}
 
 	var versions []DivergentVersion
-	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		parts := strings.Split(line, "\t")
-		if len(parts) < 5 {
-			continue
-		}
-		fullID := strings.TrimSpace(parts[0])
+	for _, c := range commits {
+		fullID := c.CommitID
 		if fullID == "" {
 			continue
 		}
-		short := strings.TrimSpace(parts[1])
-		summary := strings.TrimSpace(parts[2])
-		author := strings.TrimSpace(parts[3])
-		tsRaw := strings.TrimSpace(parts[4])
-		parents := ""
-		bookmarks := ""
-		if len(parts) > 5 {
-			parents = strings.TrimSpace(parts[5])
-		}
-		if len(parts) > 6 {
-			bookmarks = strings.TrimSpace(parts[6])
-		}
-		immutable := false
-		if len(parts) > 7 {
-			immutable = strings.TrimSpace(parts[7]) == "true"
+		short := fullID
+		if len(short) > 12 {
+			short = short[:12]
 		}
 
 		files, ferr := s.GetChangedFiles(ctx, fullID)
@@ -952,12 +1000,12 @@ func (s *Service) GetDivergentCommitDetails(ctx context.Context, changeID string
 		versions = append(versions, DivergentVersion{
 			CommitID:      fullID,
 			CommitIDShort: short,
-			Summary:       summary,
-			Author:        author,
-			WhenDisplay:   compactWhenDisplay(tsRaw),
-			ParentsShort:  parents,
-			Bookmarks:     bookmarks,
-			Immutable:     immutable,
+			Summary:       strings.TrimSpace(c.Description),
+			Author:        c.Author,
+			WhenDisplay:   compactWhen(c.Timestamp),
+			ParentsShort:  strings.Join(c.Parents, ","),
+			Bookmarks:     strings.Join(c.Bookmarks, ","),
+			Immutable:     c.Immutable,
 			ChangedFiles:  storedFiles,
 			FilesLine:     filesLine,
 		})
@@ -1005,33 +1053,45 @@ func (s *Service) ResolveDivergentCommit(ctx context.Context, changeID, keepComm
 
 // SquashCommit squashes a commit into its parent
 func (s *Service) SquashCommit(ctx context.Context, commitID string) error {
+	message, err := s.SquashMessage(ctx, commitID)
+	if err != nil {
+		return err
+	}
+	return s.SquashCommitWithMessage(ctx, commitID, message)
+}
+
+// SquashCommitWithMessage squashes a commit into its parent with message, as shown by a
+// confirmation built from SquashArgs.
+func (s *Service) SquashCommitWithMessage(ctx context.Context, commitID, message string) error {
+	// Explicit message to avoid the interactive editor
+	return s.runJJ(ctx, SquashArgs(commitID, message)...)
+}
+
+// SquashMessage returns the description SquashCommit gives the squashed commit: the parent's
+// description followed by the commit's.
+func (s *Service) SquashMessage(ctx context.Context, commitID string) (string, error) {
 	// Get the description of the commit being squashed
 	sourceDesc, err := s.runJJOutput(ctx, "log", "-r", commitID, "--no-graph", "-T", "description")
 	if err != nil {
-		return err
+		return "", err
 	}
 	sourceDesc = strings.TrimSpace(sourceDesc)
 
 	// Get the description of the parent (destination)
 	parentDesc, err := s.runJJOutput(ctx, "log", "-r", fmt.Sprintf("parents(%s)", commitID), "--no-graph", "-T", "description")
 	if err != nil {
-		return err
+		return "", err
 	}
 	parentDesc = strings.TrimSpace(parentDesc)
 
 	// Combine descriptions - prefer parent's if it exists, otherwise use source's
 	// If both have descriptions, combine them with the parent first
-	var combinedDesc string
 	if parentDesc != "" && sourceDesc != "" {
-		combinedDesc = parentDesc + "\n\n" + sourceDesc
+		return parentDesc + "\n\n" + sourceDesc, nil
 	} else if parentDesc != "" {
-		combinedDesc = parentDesc
-	} else {
-		combinedDesc = sourceDesc
+		return parentDesc, nil
 	}
-
-	// Squash the commit into its parent with explicit message to avoid interactive editor
-	return s.runJJ(ctx, "squash", "-r", commitID, jjMessageArg(combinedDesc))
+	return sourceDesc, nil
 }
 
 // NewCommit creates a new commit. If parentCommitID is provided, creates a child of that commit.
@@ -1048,7 +1108,7 @@ func (s *Service) NewCommit(ctx context.Context, parentCommitID string) error {
 
 // AbandonCommit abandons a commit, removing it from the repository
 func (s *Service) AbandonCommit(ctx context.Context, commitID string) error {
-	return s.runJJ(ctx, "abandon", commitID)
+	return s.runJJ(ctx, AbandonArgs(commitID)...)
 }
 
 // AbandonOldCommitsBatch runs one `jj abandon` over every mutable commit in the **current graph**
@@ -1698,8 +1758,7 @@ func commitIDsEquivalent(a, b string) bool {
 func (s *Service) RevertFile(ctx context.Context, commitID, filePath string) error {
 	// jj restore --to <commit> --from parents(<commit>) -- <file>
 	// Using parents() function instead of ~ suffix to avoid revset parsing issues
-	parentRev := fmt.Sprintf("parents(%s)", commitID)
-	return s.runJJ(ctx, "restore", "--to", commitID, "--from", parentRev, "--", filePath)
+	return s.runJJ(ctx, RevertFileArgs(commitID, filePath)...)
 }
 
 // GetGitRemoteURL returns the URL of the git remote (origin)
@@ -1793,52 +1852,43 @@ func (s *Service) PushToGit(ctx context.Context, branch string) (string, error)
 	if !bookmarkExists {
 		return "", fmt.Errorf("bookmark '%s' does not exist. Create it first with 'm' (Bookmark)", branch)
 	}
+	if err := s.CheckSecretsBeforePush(ctx, branch); err != nil {
+		return "", err
+	}
 
 	// Naming the bookmark explicitly with --bookmark is enough for jj to create it on the
 	// remote if it's new (the old --allow-new flag is deprecated/removed in current jj).
 	// Use runJJOutput to capture any output/errors
 	pushOut, err := s.runJJOutput(ctx, "git", "push", "--bookmark", util.JJExactBookmarkPattern(branch))
 	if err != nil {
-		return pushOut, fmt.Errorf("push failed: %w", err)
-	}
-
-	// Also run a direct git push to ensure the branch is synced
-	// This helps when jj's git integration has timing issues
-	gitPushCmd := exec.CommandContext(ctx, "git", "push", "origin", branch)
-	gitPushCmd.Dir = s.RepoPath
-	gitOut, gitErr := gitPushCmd.CombinedOutput()
-	if gitErr != nil {
-		// If git push fails with "up to date", that's fine
-		if !strings.Contains(string(gitOut), "up-to-date") && !strings.Contains(string(gitOut), "Everything up-to-date") {
-			pushOut += "\nGit push output: " + string(gitOut)
+		if !s.GitFallback || skippedCommand(err) || wantsAuthPrompt(err) {
+			return pushOut, fmt.Errorf("push failed: %w", err)
 		}
+		gitOut, gitErr := s.gitFallbackPush(ctx, branch)
+		if gitErr != nil {
+			return pushOut + gitOut, fmt.Errorf("push failed: %w (git fallback: %v)", err, gitErr)
+		}
+		pushOut += gitOut
 	}
+	s.TrackPushedBookmarks(ctx, "origin", branch)
 
 	return pushOut, nil
 }
 
-// FetchFromGit fetches updates from the remote git repository.
-// When jj git fetch fails (e.g. "Failed to update refs" with many remotes), we fall back to
-// git fetch origin so callers can still compare to bookmark@origin without a blocking error.
+// FetchFromGit fetches updates from the remote git repository with `jj git fetch`. When that
+// fails (e.g. "Failed to update refs" with many remotes) and GitFallback is on, it fetches origin
+// with plain git instead so callers can still compare to bookmark@origin.
 func (s *Service) FetchFromGit(ctx context.Context) (string, error) {
 	out, err := s.runJJOutput(ctx, "git", "fetch")
 	if err != nil {
-		gitOut, gitErr := s.runGitFetchOrigin(ctx)
-		if gitErr == nil {
-			_ = s.cleanupAfterFetch(ctx)
-			return out + string(gitOut), nil
+		if !s.GitFallback || skippedCommand(err) || wantsAuthPrompt(err) {
+			return out, fmt.Errorf("fetch failed: %w", err)
 		}
-		return out, fmt.Errorf("fetch failed: %w", err)
-	}
-
-	gitOut, gitErr := s.runGitFetchOrigin(ctx)
-	if gitErr != nil {
-		// Fetch failures are usually not fatal (e.g., no new changes)
-		// Only append output if it's a real network/permission issue
-		sGit := string(gitOut)
-		if !strings.Contains(sGit, "Fetching from") && !strings.Contains(sGit, "up-to-date") {
-			out += "\nGit fetch output: " + sGit
+		gitOut, gitErr := s.gitFallbackFetch(ctx)
+		if gitErr != nil {
+			return out + gitOut, fmt.Errorf("fetch failed: %w (git fallback: %v)", err, gitErr)
 		}
+		out += gitOut
 	}
 
 	_ = s.cleanupAfterFetch(ctx)
@@ -1846,12 +1896,6 @@ func (s *Service) FetchFromGit(ctx context.Context) (string, error) {
 	return out, nil
 }
 
-func (s *Service) runGitFetchOrigin(ctx context.Context) ([]byte, error) {
-	cmd := exec.CommandContext(ctx, "git", "fetch", "origin")
-	cmd.Dir = s.RepoPath
-	return cmd.CombinedOutput()
-}
-
 // cleanupAfterFetch handles post-fetch cleanup:
 // 1. Moves working copy if it's on an immutable commit
 func (s *Service) cleanupAfterFetch(ctx context.Context) error {
@@ -1882,6 +1926,22 @@ func (s *Service) cleanupAfterFetch(ctx context.Context) error {
 // bookmark for every origin/* PR branch and would balloon the graph to 1000+ rows.
 const DefaultGraphRevset = `(mutable() & (ancestors(@) | descendants(@) | (parents(@)+)::)) | (bookmarks() & mine()) | trunk()`
 
+// GraphRevsetPreset is a named graph revset offered in Settings → Advanced.
+type GraphRevsetPreset struct {
+	Name   string
+	Revset string // empty = DefaultGraphRevset
+}
+
+// GraphRevsetPresets are the one-click choices for the graph revset setting. Each keeps @ and
+// trunk() so the working copy and its base stay visible; "Recent 50" bounds the load by
+// committer date for large monorepos.
+var GraphRevsetPresets = []GraphRevsetPreset{
+	{Name: "Default"},
+	{Name: "All", Revset: "all()"},
+	{Name: "Mine", Revset: "mine() | trunk() | @"},
+	{Name: "Recent 50", Revset: "latest(all(), 50) | trunk() | @"},
+}
+
 // graphMineFilterAncestors is the ancestor depth used when intersecting the configured
 // graph revset with the "mine() | trunk() | @" pin set (see ApplyMineFilterToRevset).
 // 2 matches the upstream jj `revsets.log` default (`ancestors(immutable_heads().., 2)`)
@@ -1908,6 +1968,17 @@ func ApplyMineFilterToRevset(base string) string {
 	)
 }
 
+// ApplyOnlyMineToRevset narrows base (empty = DefaultGraphRevset) to commits authored by the
+// current user, keeping the working copy. Unlike ApplyMineFilterToRevset it adds no ancestor
+// context or trunk pin: it backs the graph's strict "only mine" author mode.
+func ApplyOnlyMineToRevset(base string) string {
+	base = strings.TrimSpace(base)
+	if base == "" {
+		base = DefaultGraphRevset
+	}
+	return fmt.Sprintf("((%s) & mine()) | @", base)
+}
+
 // Caps for per-commit jj subprocess work during getCommitGraph. After the main jj log, we run
 // enrichCommitsDeltaVsOrigin and enrichCommitsEvologSplitViable; each mutable commit with a feature
 // bookmark can trigger several jj log/diff/evolog calls. Large revsets (deep ancestors(@), many
@@ -1918,36 +1989,19 @@ const (
 )
 
 // jjLogWithGraphTemplate runs jj log with the graph ASCII template; recordInHistory controls command history.
-func (s *Service) jjLogWithGraphTemplate(ctx context.Context, recordInHistory bool, revsetArg, template string) (string, error) {
+func (s *Service) jjLogWithGraphTemplate(ctx context.Context, recordInHistory bool, revsetArg, template string, extra ...string) (string, error) {
+	args := append([]string{"log", "-r", revsetArg, "-T", template}, extra...)
 	if recordInHistory {
-		return s.runJJOutput(ctx, "log", "-r", revsetArg, "-T", template)
+		return s.runJJOutput(ctx, args...)
 	}
-	return s.runJJOutputNoHistory(ctx, "log", "-r", revsetArg, "-T", template)
+	return s.runJJOutputNoHistory(ctx, args...)
 }
 
 // getCommitGraph retrieves the commit graph with real jj data.
 // revset: if non-empty, used as the -r revset; if empty, a default is used.
 // recordGraphInHistory: when false, the primary (and fallback) jj log calls are not added to command history.
+// Rows come back as JSON (parse.GraphTemplate); output that doesn't parse falls back to getCommitGraphSimple.
 func (s *Service) getCommitGraph(ctx context.Context, revset string, recordGraphInHistory bool) (*internal.CommitGraph, error) {
-	// Use a custom template with a unique marker to separate graph prefix from data
-	// The marker "<<<COMMIT>>>" lets us identify where the graph ends and data begins
-	// Format after marker: change_id|commit_id|author|date|description|parents|bookmarks|is_working|has_conflict|immutable|divergent
-	template := `concat(
-		"<<<COMMIT>>>",
-		change_id.short(8), "|",
-		commit_id.short(8), "|",
-		author.email(), "|",
-		author.timestamp(), "|",
-		if(description, description.first_line(), "(no description)"), "|",
-		parents.map(|p| p.commit_id().short(8)).join(","), "|",
-		bookmarks.join(","), "|",
-		if(self.current_working_copy(), "true", "false"), "|",
-		if(self.conflict(), "true", "false"), "|",
-		if(immutable, "true", "false"), "|",
-		if(divergent, "true", "false"),
-		"\n"
-	)`
-
 	// Run bookmark list concurrently with log; enrichment needs it later and it does not depend on log output.
 	// Uses --tracked when BookmarkListPreferTracked is set: divergence enrichment only inspects
 	// local→@origin pairs (see bookmarkListParseOriginDivergence), so untracked origin/* entries
@@ -1968,14 +2022,19 @@ func (s *Service) getCommitGraph(ctx context.Context, revset string, recordGraph
 	} else {
 		revsetArg = DefaultGraphRevset
 	}
-	out, err := s.jjLogWithGraphTemplate(ctx, recordGraphInHistory, revsetArg, template)
+	// Ask for one commit past the limit: if it comes back, there is more to load.
+	var limitArgs []string
+	if s.GraphLimit > 0 {
+		limitArgs = []string{"--limit", strconv.Itoa(s.GraphLimit + 1)}
+	}
+	out, err := s.jjLogWithGraphTemplate(ctx, recordGraphInHistory, revsetArg, parse.GraphTemplate, limitArgs...)
 	if err != nil {
 		if revset != "" {
 			// Custom revset failed; try a broad safe revset so the app still loads
-			out, err = s.jjLogWithGraphTemplate(ctx, recordGraphInHistory, "mutable() | bookmarks()", template)
+			out, err = s.jjLogWithGraphTemplate(ctx, recordGraphInHistory, "mutable() | bookmarks()", parse.GraphTemplate, limitArgs...)
 		} else {
 			// Default may fail if main@origin is missing; omit trunk tip from the revset
-			out, err = s.jjLogWithGraphTemplate(ctx, recordGraphInHistory, "mutable() | bookmarks()", template)
+			out, err = s.jjLogWithGraphTemplate(ctx, recordGraphInHistory, "mutable() | bookmarks()", parse.GraphTemplate, limitArgs...)
 		}
 	}
 	bmWG.Wait()
@@ -1983,129 +2042,38 @@ func (s *Service) getCommitGraph(ctx context.Context, revset string, recordGraph
 		return s.getCommitGraphSimple(ctx, revset, recordGraphInHistory)
 	}
 
-	commits := []internal.Commit{}
+	rows, err := parse.GraphRows(out)
+	if err != nil {
+		return s.getCommitGraphSimple(ctx, revset, recordGraphInHistory)
+	}
+	hasMore := s.GraphLimit > 0 && len(rows) > s.GraphLimit
+	if hasMore {
+		rows = rows[:s.GraphLimit]
+	}
+	commits := make([]internal.Commit, 0, len(rows))
 	connections := make(map[string][]string)
-	var pendingGraphLines []string // Graph lines between commits
-
-	lines := strings.Split(out, "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		// Check if this line contains commit data (has our marker)
-		markerIdx := strings.Index(line, "<<<COMMIT>>>")
-		if markerIdx == -1 {
-			// This is a graph-only line (connector between commits)
-			// Store it to attach to the previous commit (connects it to the next one below)
-			graphLine := strings.TrimRight(line, " ")
-			if graphLine != "" {
-				pendingGraphLines = append(pendingGraphLines, graphLine)
-			}
-			continue
-		}
-
-		// Attach pending graph lines to the previous commit first
-		if len(commits) > 0 && len(pendingGraphLines) > 0 {
-			commits[len(commits)-1].GraphLines = pendingGraphLines
-			pendingGraphLines = nil
-		}
-
-		// Extract the graph prefix (everything before the marker)
-		graphPrefix := line[:markerIdx]
-
-		// Extract the data (everything after the marker)
-		data := line[markerIdx+len("<<<COMMIT>>>"):]
-
-		parts := strings.Split(data, "|")
-		if len(parts) < 11 {
-			continue
-		}
-
-		changeID := strings.TrimSpace(parts[0])
-		commitID := strings.TrimSpace(parts[1])
-		author := strings.TrimSpace(parts[2])
-		dateStr := strings.TrimSpace(parts[3])
-		description := strings.TrimSpace(parts[4])
-		parentsStr := strings.TrimSpace(parts[5])
-		branchesStr := strings.TrimSpace(parts[6])
-		isWorking := strings.TrimSpace(parts[7]) == "true"
-		hasConflict := strings.TrimSpace(parts[8]) == "true"
-		isImmutable := strings.TrimSpace(parts[9]) == "true"
-		isDivergent := strings.TrimSpace(parts[10]) == "true"
-
-		// Parse parents
-		var parents []string
-		if parentsStr != "" {
-			parents = strings.Split(parentsStr, ",")
-		}
-
-		// Parse branches/bookmarks
-		// Strip @remote suffixes (e.g., "main@origin" -> "main")
-		// Strip * suffix (indicates current bookmark)
-		// Track ? suffix (indicates conflicted/diverged bookmark)
-		var branches []string
-		var conflictedBranches []string
-		if branchesStr != "" {
-			for _, raw := range strings.Split(branchesStr, ",") {
-				b, isConflicted := util.NormalizeBookmarkListToken(raw)
-				// Keep @remote suffixes (e.g. feature@origin) so the graph can distinguish
-				// local bookmark tips from remote-tracking positions on different commits.
-				// Avoid duplicates
-				found := false
-				for _, existing := range branches {
-					if existing == b {
-						found = true
-						break
-					}
-				}
-				if !found && b != "" {
-					branches = append(branches, b)
-					if isConflicted {
-						conflictedBranches = append(conflictedBranches, b)
-					}
-				}
-			}
-		}
-
-		// Parse date
-		var date time.Time
-		if dateStr != "" {
-			date, _ = time.Parse(time.RFC3339, dateStr)
-		}
-
-		commit := internal.Commit{
-			ID:                 commitID,
-			ShortID:            commitID,
-			ChangeID:           changeID,
-			Author:             author,
-			Email:              author,
-			Date:               date,
-			Summary:            description,
-			Description:        description,
-			Parents:            parents,
-			Branches:           branches,
-			ConflictedBranches: conflictedBranches,
-			IsWorking:          isWorking,
-			Conflicts:          hasConflict,
-			Immutable:          isImmutable,
-			Divergent:          isDivergent,
-			GraphPrefix:        graphPrefix,
-		}
-
-		commits = append(commits, commit)
-
-		// Build connections
-		for _, parent := range parents {
-			connections[parent] = append(connections[parent], commitID)
+	for _, row := range rows {
+		c := graphRowCommit(row)
+		commits = append(commits, c)
+		for _, parent := range c.Parents {
+			connections[parent] = append(connections[parent], c.ID)
 		}
 	}
 
-	// Attach any remaining graph lines to the last commit
-	if len(commits) > 0 && len(pendingGraphLines) > 0 {
-		commits[len(commits)-1].GraphLines = pendingGraphLines
-	}
+	s.enrichGraphCommits(ctx, commits, revsetArg, bmOut, bmErr)
 
+	return &internal.CommitGraph{
+		Commits:     commits,
+		Connections: connections,
+		HasMore:     hasMore,
+	}, nil
+}
+
+// enrichGraphCommits fills in what the graph template can't tell: bookmarks diverged from origin,
+// "Forgot New Commit?" and evolog split offers, remote state and trunk distance. revsetArg is the
+// revset commits were read with; bmOut is `jj bookmark list` output (bookmark-derived state is
+// skipped when bmErr is set).
+func (s *Service) enrichGraphCommits(ctx context.Context, commits []internal.Commit, revsetArg, bmOut string, bmErr error) {
 	originDiverged := map[string]bool{}
 	var suppressForkAfterAheadBehindList map[string]bool
 	if bmErr == nil {
@@ -2124,11 +2092,64 @@ func (s *Service) getCommitGraph(ctx context.Context, revset string, recordGraph
 	s.enrichConflictedBookmarks(ctx, commits, originDiverged, suppressForkAfterAheadBehindList)
 	s.enrichCommitsDeltaVsOrigin(ctx, commits)
 	s.enrichCommitsEvologSplitViable(ctx, commits)
+	if bmErr == nil {
+		s.enrichCommitsRemoteState(ctx, commits, revsetArg, bmOut)
+	}
+	s.enrichCommitsTrunkDistance(ctx, commits, revsetArg)
+}
 
-	return &internal.CommitGraph{
-		Commits:     commits,
-		Connections: connections,
-	}, nil
+// GraphLog returns the `jj log` output a graph load parses (parse.GraphTemplate with the graph art)
+// for revset, without recording it in command history. `jj-tui bench graph` times it on its own.
+func (s *Service) GraphLog(ctx context.Context, revset string) (string, error) {
+	return s.jjLogWithGraphTemplate(ctx, false, revset, parse.GraphTemplate)
+}
+
+// CommitsFromGraphRows converts parsed graph rows to commits the way a graph load does, before the
+// enrichment that asks jj about bookmarks.
+func CommitsFromGraphRows(rows []parse.GraphRow) []internal.Commit {
+	commits := make([]internal.Commit, len(rows))
+	for i, row := range rows {
+		commits[i] = graphRowCommit(row)
+	}
+	return commits
+}
+
+// graphRowCommit converts a parsed jj log row to a graph commit. Bookmarks keep their @remote
+// suffix (so the graph can tell local tips from remote-tracking positions on other commits) but
+// lose jj's display markers; "?" marks a conflicted bookmark.
+func graphRowCommit(row parse.GraphRow) internal.Commit {
+	rc := row.Commit
+	var branches, conflictedBranches []string
+	for _, raw := range rc.Bookmarks {
+		b, isConflicted := util.NormalizeBookmarkListToken(raw)
+		if b == "" || slices.Contains(branches, b) {
+			continue
+		}
+		branches = append(branches, b)
+		if isConflicted {
+			conflictedBranches = append(conflictedBranches, b)
+		}
+	}
+	return internal.Commit{
+		ID:                 rc.CommitID,
+		ShortID:            rc.CommitID,
+		ChangeID:           rc.ChangeID,
+		Author:             rc.Author,
+		Email:              rc.Author,
+		Mine:               rc.Mine,
+		Date:               rc.Timestamp,
+		Summary:            rc.Description,
+		Description:        rc.Description,
+		Parents:            rc.Parents,
+		Branches:           branches,
+		ConflictedBranches: conflictedBranches,
+		IsWorking:          rc.WorkingCopy,
+		Conflicts:          rc.Conflict,
+		Immutable:          rc.Immutable,
+		Divergent:          rc.Divergent,
+		GraphPrefix:        row.Prefix,
+		GraphLines:         row.Connectors,
+	}
 }
 
 // enrichCommitsEvologSplitViable sets EvologSplitViable for mutable commits (cached per change id).
@@ -2317,23 +2338,20 @@ func (s *Service) runJJOutputNoHistory(ctx context.Context, args ...string) (str
 // runJJOutputNoHistoryWithGlobal is like runJJOutputNoHistory but prepends global jj flags.
 func (s *Service) runJJOutputNoHistoryWithGlobal(ctx context.Context, global []string, args ...string) (string, error) {
 	merged := jjMergeGlobalArgs(global, args)
-	cmd := exec.CommandContext(ctx, "jj", merged...)
-	cmd.Dir = s.RepoPath
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	err := cmd.Run()
+	stdout, stderr, err := s.execJJ(ctx, merged, nil, false)
 	if err != nil {
-		errOut := stderr.String()
+		errOut := stderr
 		if errOut == "" {
-			errOut = stdout.String()
+			errOut = stdout
 		}
 		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(errOut))
 	}
-	return stdout.String(), nil
+	return stdout, nil
 }
 
-// getCommitGraphSimple is a fallback that uses simpler parsing
+// getCommitGraphSimple is a fallback that uses simpler parsing: it reads jj's default `log`
+// output rather than a template, so it keeps working on jj releases older than 0.24 (no
+// escape_json). That is also why it stays here instead of in package parse.
 func (s *Service) getCommitGraphSimple(ctx context.Context, revset string, recordInHistory bool) (*internal.CommitGraph, error) {
 	revsetArg := "mutable() | bookmarks()"
 	if revset != "" {
@@ -2419,9 +2437,10 @@ func (s *Service) runJJWithGlobal(ctx context.Context, global []string, args ...
 	cmdStr := "jj " + strings.Join(merged, " ")
 	startTime := time.Now()
 
-	cmd := exec.CommandContext(ctx, "jj", merged...)
-	cmd.Dir = s.RepoPath
-	out, err := cmd.CombinedOutput()
+	out, _, err := s.execJJ(ctx, merged, nil, true)
+	if skippedCommand(err) {
+		return err
+	}
 	duration := time.Since(startTime)
 
 	entry := CommandHistoryEntry{
@@ -2431,7 +2450,7 @@ func (s *Service) runJJWithGlobal(ctx context.Context, global []string, args ...
 		Success:   err == nil,
 	}
 	if err != nil {
-		errMsg := extractErrorMessage(string(out))
+		errMsg := extractErrorMessage(out)
 		if errMsg != "" {
 			entry.Error = errMsg
 			s.addToHistory(entry)
@@ -2452,14 +2471,10 @@ func (s *Service) runJJOutputWithGlobal(ctx context.Context, global []string, ar
 	cmdStr := "jj " + strings.Join(merged, " ")
 	startTime := time.Now()
 
-	cmd := exec.CommandContext(ctx, "jj", merged...)
-	cmd.Dir = s.RepoPath
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
+	stdout, stderr, err := s.execJJ(ctx, merged, nil, false)
+	if skippedCommand(err) {
+		return "", err
+	}
 	duration := time.Since(startTime)
 
 	entry := CommandHistoryEntry{
@@ -2470,9 +2485,9 @@ func (s *Service) runJJOutputWithGlobal(ctx context.Context, global []string, ar
 	}
 
 	if err != nil {
-		errOutput := stderr.String()
+		errOutput := stderr
 		if errOutput == "" {
-			errOutput = stdout.String()
+			errOutput = stdout
 		}
 		entry.Error = extractErrorMessage(errOutput)
 		if entry.Error == "" {
@@ -2484,17 +2499,25 @@ func (s *Service) runJJOutputWithGlobal(ctx context.Context, global []string, ar
 	}
 
 	s.addToHistory(entry)
-	return stdout.String(), nil
+	return stdout, nil
 }
 
 // runJJ executes a jj command and returns a clean error if it fails
 func (s *Service) runJJ(ctx context.Context, args ...string) error {
+	_, err := s.runJJCombined(ctx, args...)
+	return err
+}
+
+// runJJCombined is runJJ for commands that report what they did on stderr (e.g. `jj absorb`): it
+// also returns stdout and stderr combined.
+func (s *Service) runJJCombined(ctx context.Context, args ...string) (string, error) {
 	cmdStr := "jj " + strings.Join(args, " ")
 	startTime := time.Now()
 
-	cmd := exec.CommandContext(ctx, "jj", args...)
-	cmd.Dir = s.RepoPath
-	out, err := cmd.CombinedOutput()
+	out, _, err := s.execJJ(ctx, args, nil, true)
+	if skippedCommand(err) {
+		return out, err
+	}
 	duration := time.Since(startTime)
 
 	// Log the command to history
@@ -2506,19 +2529,22 @@ func (s *Service) runJJ(ctx context.Context, args ...string) error {
 	}
 	if err != nil {
 		// Extract just the main error message
-		errMsg := extractErrorMessage(string(out))
+		errMsg := extractErrorMessage(out)
 		if errMsg != "" {
 			entry.Error = errMsg
 			s.addToHistory(entry)
-			return fmt.Errorf("%s", errMsg)
+			if wantsAuthPrompt(err) {
+				return out, err
+			}
+			return out, fmt.Errorf("%s", errMsg)
 		}
 		entry.Error = err.Error()
 		s.addToHistory(entry)
-		return fmt.Errorf("command failed: %w", err)
+		return out, fmt.Errorf("command failed: %w", err)
 	}
 
 	s.addToHistory(entry)
-	return nil
+	return out, nil
 }
 
 // extractErrorMessage extracts the main error message from jj output
@@ -2546,15 +2572,11 @@ func (s *Service) runJJOutput(ctx context.Context, args ...string) (string, erro
 	cmdStr := "jj " + strings.Join(args, " ")
 	startTime := time.Now()
 
-	cmd := exec.CommandContext(ctx, "jj", args...)
-	cmd.Dir = s.RepoPath
-
 	// Capture stdout and stderr separately
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
+	stdout, stderr, err := s.execJJ(ctx, args, nil, false)
+	if skippedCommand(err) {
+		return "", err
+	}
 	duration := time.Since(startTime)
 
 	// Log the command to history
@@ -2567,9 +2589,9 @@ func (s *Service) runJJOutput(ctx context.Context, args ...string) (string, erro
 
 	if err != nil {
 		// Include stderr in error message for debugging
-		errOutput := stderr.String()
+		errOutput := stderr
 		if errOutput == "" {
-			errOutput = stdout.String()
+			errOutput = stdout
 		}
 		entry.Error = extractErrorMessage(errOutput)
 		if entry.Error == "" {
@@ -2582,7 +2604,7 @@ func (s *Service) runJJOutput(ctx context.Context, args ...string) (string, erro
 
 	s.addToHistory(entry)
 	// Return only stdout - hints/warnings go to stderr
-	return stdout.String(), nil
+	return stdout, nil
 }
 
 // listMineUntrackedRemoteBookmarks returns one Branch per (remote_bookmark, remote)
@@ -2639,15 +2661,14 @@ func (s *Service) listMineUntrackedRemoteBookmarks(ctx context.Context) ([]inter
 	return branches, nil
 }
 
-// ListBranches returns all local and remote branches.
-//
-// statsLimit controls how many branches get ahead/behind stats calculated (0 = all).
+// ListBranches returns all local and remote branches, with their ahead/behind counts relative
+// to trunk.
 //
 // When BookmarkListPreferTracked is set the listing uses `jj bookmark list --tracked`
 // (cheap; ~tens of rows even on 1000-branch repos) and then augments the result with
 // any remote bookmarks whose tip you authored (via a separate `remote_bookmarks() & mine()`
 // jj log query) so you don't lose visibility of your own un-tracked PR branches.
-func (s *Service) ListBranches(ctx context.Context, statsLimit int) ([]internal.Branch, error) {
+func (s *Service) ListBranches(ctx context.Context) ([]internal.Branch, error) {
 	out, err := s.runJJOutput(ctx, "bookmark", "list", s.BookmarkListRemoteFlag())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
@@ -2786,118 +2807,7 @@ func (s *Service) ListBranches(ctx context.Context, statsLimit int) ([]internal.
 		}
 	}
 
-	// Optimization: Filter remote branches by recency, always keep local branches
-	// Also keep remote counterparts of local branches
-	if statsLimit > 0 {
-		// Build a set of local branch names to keep their remote counterparts
-		localBranchNames := make(map[string]bool)
-		var localBranches, remoteBranches, remoteCounterparts []internal.Branch
-
-		for _, b := range branches {
-			if b.IsLocal {
-				localBranches = append(localBranches, b)
-				localBranchNames[b.Name] = true
-			}
-		}
-
-		// Separate remote branches: counterparts of local vs others
-		for _, b := range branches {
-			if !b.IsLocal {
-				if localBranchNames[b.Name] {
-					// This is a remote counterpart of a local branch - always keep
-					remoteCounterparts = append(remoteCounterparts, b)
-				} else {
-					remoteBranches = append(remoteBranches, b)
-				}
-			}
-		}
-
-		// Calculate remaining slots for other remote branches
-		remoteLimit := max(statsLimit-len(localBranches)-len(remoteCounterparts), 0)
-
-		if len(remoteBranches) > remoteLimit && remoteLimit > 0 {
-			// Query timestamps for remote branches using branch ref directly
-			// Format: "branch@remote|timestamp\n"
-			var revsets []string
-			branchToRef := make(map[string]string) // ref -> branch index key
-			for _, b := range remoteBranches {
-				if b.Remote != "" {
-					ref := fmt.Sprintf("%s@%s", b.Name, b.Remote)
-					revsets = append(revsets, ref)
-					branchToRef[ref] = ref
-				}
-			}
-
-			if len(revsets) > 0 {
-				// Use a different template that includes the branch ref for matching
-				revset := strings.Join(revsets, " | ")
-				out, err := s.runJJOutput(ctx, "log", "-r", revset, "--no-graph",
-					"-T", `if(bookmarks, bookmarks ++ "|" ++ committer.timestamp().utc().format("%s") ++ "\n", "")`)
-				if err == nil {
-					// Parse timestamps into a map keyed by branch@remote
-					timestamps := make(map[string]int64)
-					for _, line := range strings.Split(out, "\n") {
-						line = strings.TrimSpace(line)
-						if line == "" {
-							continue
-						}
-						parts := strings.Split(line, "|")
-						if len(parts) == 2 {
-							branchRef := strings.TrimSpace(parts[0])
-							if ts, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64); err == nil {
-								timestamps[branchRef] = ts
-							}
-						}
-					}
-
-					// Sort remote branches by timestamp (most recent first)
-					sort.Slice(remoteBranches, func(i, j int) bool {
-						refI := fmt.Sprintf("%s@%s", remoteBranches[i].Name, remoteBranches[i].Remote)
-						refJ := fmt.Sprintf("%s@%s", remoteBranches[j].Name, remoteBranches[j].Remote)
-						tsI, okI := timestamps[refI]
-						tsJ, okJ := timestamps[refJ]
-						// Branches with timestamps come before those without
-						if okI != okJ {
-							return okI
-						}
-						return tsI > tsJ // Descending - most recent first
-					})
-				}
-				// If timestamp query fails, branches stay in original order
-			}
-
-			// Keep only the N most recent remote branches
-			remoteBranches = remoteBranches[:remoteLimit]
-		} else if len(remoteBranches) > remoteLimit {
-			remoteBranches = remoteBranches[:remoteLimit]
-		}
-
-		// Recombine: local + their remote counterparts + other recent remotes
-		branches = append(localBranches, remoteCounterparts...)
-		branches = append(branches, remoteBranches...)
-	}
-
-	// Calculate ahead/behind stats with parallel fetching
-	const maxConcurrent = 10
-	sem := make(chan struct{}, maxConcurrent)
-	var wg sync.WaitGroup
-
-	for i := range branches {
-		wg.Add(1)
-		go func(idx int) {
-			defer wg.Done()
-			sem <- struct{}{}        // Acquire semaphore
-			defer func() { <-sem }() // Release semaphore
-
-			branch := &branches[idx]
-			if branch.IsLocal {
-				branch.Ahead, branch.Behind = s.GetBranchStats(ctx, branch.Name, "")
-			} else if branch.Remote != "" {
-				branch.Ahead, branch.Behind = s.GetBranchStats(ctx, branch.Name, branch.Remote)
-			}
-		}(i)
-	}
-	wg.Wait()
+	s.enrichBranchStats(ctx, branches)
 
 	stated, ahBoth := bookmarkListParseOriginDivergence(out)
 	originDiverged := s.originDivergedResolved(ctx, stated, ahBoth)
@@ -3255,7 +3165,7 @@ func parseCommitInfo(info string) (changeID, shortID string) {
 
 // countRevisions counts the number of revisions matching a revset
 func (s *Service) countRevisions(ctx context.Context, revset string) int {
-	out, err := s.runJJOutput(ctx, "log", "-r", revset, "--no-graph", "-T", `"x"`)
+	out, err := s.runJJOutputCached(ctx, "log", "-r", revset, "--no-graph", "-T", `"x"`)
 	if err != nil {
 		return 0
 	}
@@ -3263,29 +3173,6 @@ func (s *Service) countRevisions(ctx context.Context, revset string) int {
 	return strings.Count(out, "x")
 }
 
-// GetBranchStats calculates ahead/behind counts for a branch relative to trunk
-// For local branches, pass empty string for remoteName
-// For remote branches, pass the remote name (e.g., "origin")
-func (s *Service) GetBranchStats(ctx context.Context, branchName string, remoteName string) (ahead, behind int) {
-	// Use trunk() as the base reference (usually main@origin)
-	var branchRef string
-	if remoteName == "" {
-		// Local branch
-		branchRef = branchName
-	} else {
-		// Remote branch: use name@remote format
-		branchRef = fmt.Sprintf("%s@%s", branchName, remoteName)
-	}
-
-	// Commits in branch that are not in trunk (ahead)
-	ahead = s.countRevisions(ctx, fmt.Sprintf("(%s)..(%s)", "trunk()", branchRef))
-
-	// Commits in trunk that are not in branch (behind)
-	behind = s.countRevisions(ctx, fmt.Sprintf("(%s)..(%s)", branchRef, "trunk()"))
-
-	return ahead, behind
-}
-
 // TrackBranch starts tracking a remote branch
 func (s *Service) TrackBranch(ctx context.Context, branchName, remote string) error {
 	remoteBranch := fmt.Sprintf("%s@%s", branchName, remote)
@@ -3328,9 +3215,34 @@ func (s *Service) RestoreLocalBranch(ctx context.Context, branchName, commitID s
 
 // PushBranch pushes a local branch to remote. Naming the bookmark explicitly with --bookmark is
 // enough for jj to create it on the remote if it's new (the old --allow-new flag is deprecated/
-// removed in current jj).
+// removed in current jj). A bookmark the push created on origin is tracked afterwards.
 func (s *Service) PushBranch(ctx context.Context, branchName string) error {
-	return s.runJJ(ctx, "git", "push", "--bookmark", util.JJExactBookmarkPattern(branchName))
+	if err := s.CheckSecretsBeforePush(ctx, branchName); err != nil {
+		return err
+	}
+	if err := s.runJJ(ctx, "git", "push", "--bookmark", util.JJExactBookmarkPattern(branchName)); err != nil {
+		return err
+	}
+	s.TrackPushedBookmarks(ctx, "origin", branchName)
+	return nil
+}
+
+// PushBookmarks pushes the named bookmarks in one `jj git push` (jj's default selection when
+// names is empty) and tracks the ones it created on origin. Returns jj's output.
+func (s *Service) PushBookmarks(ctx context.Context, names ...string) (string, error) {
+	if err := s.CheckSecretsBeforePush(ctx, names...); err != nil {
+		return "", err
+	}
+	args := []string{"git", "push"}
+	for _, name := range names {
+		args = append(args, "--bookmark", name)
+	}
+	out, err := s.runJJCombined(ctx, args...)
+	if err != nil {
+		return out, err
+	}
+	s.TrackPushedBookmarks(ctx, "origin", names...)
+	return out, nil
 }
 
 // FetchFromRemote fetches updates from a remote
@@ -3343,6 +3255,52 @@ func (s *Service) FetchAllRemotes(ctx context.Context) error {
 	return s.runJJ(ctx, "git", "fetch", "--all-remotes")
 }
 
+// HasRemote reports whether a git remote with the given name is configured.
+func (s *Service) HasRemote(ctx context.Context, name string) bool {
+	out, err := s.runJJOutput(ctx, "git", "remote", "list")
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncTrunkFromRemote brings the local trunk bookmark up to date with trunk@remote (typically a
+// fork's "upstream" remote) and pushes it to origin. The bookmark only moves forward: jj refuses
+// to move it backwards or sideways, so local commits on trunk are never discarded.
+func (s *Service) SyncTrunkFromRemote(ctx context.Context, remote, trunk string) error {
+	if err := s.runJJ(ctx, "git", "fetch", "--remote", remote, "--branch", trunk); err != nil {
+		return fmt.Errorf("failed to fetch %s from %s: %w", trunk, remote, err)
+	}
+	target := fmt.Sprintf("%s@%s", util.BookmarkNameForRevset(trunk), remote)
+	if err := s.runJJ(ctx, "bookmark", "set", util.BookmarkArgForSetMove(trunk), "-r", target); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", trunk, target, err)
+	}
+	if err := s.runJJ(ctx, "git", "push", "--bookmark", util.JJExactBookmarkPattern(trunk), "--remote", "origin"); err != nil {
+		return fmt.Errorf("failed to push %s to origin: %w", trunk, err)
+	}
+	return nil
+}
+
+// StackStats counts the working copy's stack against the trunk bookmark: commits on the stack that
+// aren't in trunk (ahead) and trunk commits the stack isn't based on yet (behind).
+func (s *Service) StackStats(ctx context.Context, trunk string) (ahead, behind int) {
+	ref := util.BookmarkNameForRevset(trunk)
+	ahead = s.countRevisions(ctx, fmt.Sprintf("(%s)..(@) ~ empty()", ref))
+	behind = s.countRevisions(ctx, fmt.Sprintf("(@)..(%s)", ref))
+	return ahead, behind
+}
+
+// RebaseStackOnto rebases the working copy's whole branch (every ancestor not already in dest) onto dest.
+func (s *Service) RebaseStackOnto(ctx context.Context, dest string) error {
+	// jj rebase -b @ -d <dest>
+	return s.runJJ(ctx, "rebase", "-b", "@", "-d", dest)
+}
+
 // isJJRepo checks if a directory is a jj repository
 func isJJRepo(path string) bool {
 	jjDir := filepath.Join(path, ".jj")
@@ -3356,3 +3314,29 @@ func isJJRepo(path string) bool {
 	}
 	return false
 }
+
+// CheckRepo reports whether the repository the service was opened in is still usable: the
+// directory must exist and an enclosing .jj directory must still hold its repo store. It returns
+// a descriptive error when the directory was removed or moved, or the .jj directory was deleted
+// or damaged, so callers can stop refreshing instead of failing on every tick.
+func (s *Service) CheckRepo() error {
+	info, err := os.Stat(s.RepoPath)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("repository directory no longer exists: %s", s.RepoPath)
+	}
+	for dir := s.RepoPath; ; {
+		jjDir := filepath.Join(dir, ".jj")
+		if info, err := os.Stat(jjDir); err == nil && info.IsDir() {
+			// "repo" is a directory in the main workspace and a pointer file in secondary ones.
+			if _, err := os.Stat(filepath.Join(jjDir, "repo")); err != nil {
+				return fmt.Errorf("jj repository is damaged (missing %s)", filepath.Join(jjDir, "repo"))
+			}
+			return nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return fmt.Errorf("not a jujutsu repository anymore: %s", s.RepoPath)
+		}
+		dir = parent
+	}
+}
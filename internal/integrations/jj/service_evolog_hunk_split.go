@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -51,63 +50,83 @@ func (s *Service) SplitRevisionByHunkPrefix(ctx context.Context, revision, messa
 	if err := ValidateHunkPrefixPlan(diff, sanitized); err != nil {
 		return err
 	}
-	spec := EvologHunkSplitSpec{GitDiff: diff, PrefixByPath: sanitized}
-	specFile, err := os.CreateTemp("", "jj-tui-hunk-spec-*.json")
+	specEnv, cfgPath, cleanup, err := writeHunkSplitToolFiles(EvologHunkSplitSpec{GitDiff: diff, PrefixByPath: sanitized})
 	if err != nil {
 		return err
 	}
-	specPath := specFile.Name()
-	defer func() { _ = os.Remove(specPath) }()
+	defer cleanup()
+	rev := strings.TrimSpace(revision)
+	if rev == "" {
+		rev = "@"
+	}
+	msg := strings.TrimSpace(message)
+	if msg == "" {
+		msg = EvologSplitDefaultMessage
+	}
+	args := []string{
+		"--config-file", cfgPath,
+		"split", "-r", rev, "-m", msg,
+		"--tool", "jj-tui-hunk-split",
+	}
+	args = s.appendSplitInsertBeforeArgs(ctx, args, rev)
+	return s.runJJWithExtraEnv(ctx, []string{specEnv}, args)
+}
+
+// writeHunkSplitToolFiles writes spec and a jj config file that makes this executable the
+// jj-tui-hunk-split diff editor. It returns the environment entry pointing the editor at the
+// spec, the config path for --config-file, and a cleanup func removing both files.
+func writeHunkSplitToolFiles(spec EvologHunkSplitSpec) (specEnv, cfgPath string, cleanup func(), err error) {
+	var paths []string
+	cleanup = func() {
+		for _, p := range paths {
+			_ = os.Remove(p)
+		}
+	}
+	defer func() {
+		if err != nil {
+			cleanup()
+		}
+	}()
+	specFile, err := os.CreateTemp("", "jj-tui-hunk-spec-*.json")
+	if err != nil {
+		return "", "", nil, err
+	}
+	paths = append(paths, specFile.Name())
 	enc, err := json.Marshal(&spec)
 	if err != nil {
 		_ = specFile.Close()
-		return err
+		return "", "", nil, err
 	}
 	if _, err := specFile.Write(enc); err != nil {
 		_ = specFile.Close()
-		return err
+		return "", "", nil, err
 	}
 	if err := specFile.Close(); err != nil {
-		return err
+		return "", "", nil, err
 	}
 	cfgFile, err := os.CreateTemp("", "jj-tui-hunk-cfg-*.toml")
 	if err != nil {
-		return err
+		return "", "", nil, err
 	}
-	cfgPath := cfgFile.Name()
-	defer func() { _ = os.Remove(cfgPath) }()
+	paths = append(paths, cfgFile.Name())
 	exe, err := os.Executable()
 	if err != nil {
-		return fmt.Errorf("executable path: %w", err)
+		_ = cfgFile.Close()
+		return "", "", nil, fmt.Errorf("executable path: %w", err)
 	}
 	exeAbs, err := filepath.Abs(exe)
 	if err != nil {
-		return err
+		_ = cfgFile.Close()
+		return "", "", nil, err
 	}
-	cfgBody := buildEvologHunkSplitMergeToolToml(exeAbs)
-	if _, err := cfgFile.WriteString(cfgBody); err != nil {
+	if _, err := cfgFile.WriteString(buildEvologHunkSplitMergeToolToml(exeAbs)); err != nil {
 		_ = cfgFile.Close()
-		return err
+		return "", "", nil, err
 	}
 	if err := cfgFile.Close(); err != nil {
-		return err
-	}
-	rev := strings.TrimSpace(revision)
-	if rev == "" {
-		rev = "@"
+		return "", "", nil, err
 	}
-	msg := strings.TrimSpace(message)
-	if msg == "" {
-		msg = EvologSplitDefaultMessage
-	}
-	args := []string{
-		"--config-file", cfgPath,
-		"split", "-r", rev, "-m", msg,
-		"--tool", "jj-tui-hunk-split",
-	}
-	args = s.appendSplitInsertBeforeArgs(ctx, args, rev)
-	env := EvologHunkSplitSpecEnv + "=" + specPath
-	return s.runJJWithExtraEnv(ctx, []string{env}, args)
+	return EvologHunkSplitSpecEnv + "=" + specFile.Name(), cfgFile.Name(), cleanup, nil
 }
 
 // appendSplitInsertBeforeArgs appends --insert-before when rev has exactly one direct child commit.
@@ -150,10 +169,10 @@ func (s *Service) directRevisionChildrenCommitIDs(ctx context.Context, rev strin
 func (s *Service) runJJWithExtraEnv(ctx context.Context, extraEnv []string, args []string) error {
 	cmdStr := "jj " + strings.Join(args, " ")
 	startTime := time.Now()
-	cmd := exec.CommandContext(ctx, "jj", args...)
-	cmd.Dir = s.RepoPath
-	cmd.Env = append(append([]string{}, os.Environ()...), extraEnv...)
-	out, err := cmd.CombinedOutput()
+	out, _, err := s.execJJ(ctx, args, extraEnv, true)
+	if skippedCommand(err) {
+		return err
+	}
 	duration := time.Since(startTime)
 	entry := CommandHistoryEntry{
 		Command:   cmdStr,
@@ -162,7 +181,7 @@ func (s *Service) runJJWithExtraEnv(ctx context.Context, extraEnv []string, args
 		Success:   err == nil,
 	}
 	if err != nil {
-		errMsg := extractErrorMessage(string(out))
+		errMsg := extractErrorMessage(out)
 		if errMsg != "" {
 			entry.Error = errMsg
 			s.addToHistory(entry)
@@ -1,6 +1,10 @@
 package jj
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -150,3 +154,107 @@ func TestServiceBookmarkListRemoteFlag(t *testing.T) {
 		t.Errorf("BookmarkListPreferTracked=true should yield --tracked; got %q", got)
 	}
 }
+
+func TestCheckRepo(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".jj", "repo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{RepoPath: sub}
+	if err := s.CheckRepo(); err != nil {
+		t.Fatalf("healthy repo: %v", err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(root, ".jj", "repo")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CheckRepo(); err == nil || !strings.Contains(err.Error(), "damaged") {
+		t.Fatalf("missing .jj/repo: got %v", err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(root, ".jj")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CheckRepo(); err == nil {
+		t.Fatal("expected an error once .jj is gone")
+	}
+
+	if err := os.RemoveAll(filepath.Join(root, "src")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CheckRepo(); err == nil || !strings.Contains(err.Error(), "no longer exists") {
+		t.Fatalf("removed directory: got %v", err)
+	}
+}
+
+func TestApplyOnlyMineToRevset(t *testing.T) {
+	if got := ApplyOnlyMineToRevset("all()"); got != "((all()) & mine()) | @" {
+		t.Fatalf("ApplyOnlyMineToRevset(all()) = %q", got)
+	}
+	if got := ApplyOnlyMineToRevset(" "); !strings.Contains(got, DefaultGraphRevset) {
+		t.Fatalf("empty base should use DefaultGraphRevset, got %q", got)
+	}
+}
+
+// Descriptions may contain the characters the old pipe-separated template split on; the JSON rows
+// keep them intact, and bookmarks lose jj's display markers.
+func TestGetCommitGraphParsesJSONRows(t *testing.T) {
+	fakeJJ(t, `case "$*" in *COMMIT*) cat <<'OUT'
+@  <<<COMMIT>>>{"change_id":"kxqvmtsz","commit_id":"1a2b3c4d","author":"me@example.com","timestamp":"2025-06-10T08:30:00Z","description":"Split a|b on \"|\"","parents":["5e6f7a8b"],"bookmarks":["feat*","fix??","feat*"],"working_copy":true,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+│
+◆  <<<COMMIT>>>{"change_id":"zzplqrwn","commit_id":"5e6f7a8b","author":"other@example.com","timestamp":"2025-06-01T00:00:00Z","description":"Base","parents":[],"bookmarks":["main@origin"],"working_copy":false,"conflict":false,"immutable":true,"divergent":false,"mine":false}
+OUT
+;; esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	graph, err := s.getCommitGraph(context.Background(), "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.Commits) != 2 {
+		t.Fatalf("commits = %+v", graph.Commits)
+	}
+	c := graph.Commits[0]
+	if c.Summary != `Split a|b on "|"` || !c.IsWorking || !c.Mine || c.GraphPrefix != "@  " || !slices.Equal(c.GraphLines, []string{"│"}) {
+		t.Errorf("first commit = %+v", c)
+	}
+	if !slices.Equal(c.Branches, []string{"feat", "fix"}) {
+		t.Errorf("branches = %q", c.Branches)
+	}
+	if !slices.Equal(graph.Connections["5e6f7a8b"], []string{"1a2b3c4d"}) || !graph.Commits[1].Immutable {
+		t.Errorf("connections = %v, second = %+v", graph.Connections, graph.Commits[1])
+	}
+}
+
+// With GraphLimit set the log asks for one commit past the page; getting it back means HasMore,
+// and the extra commit is left out.
+func TestGetCommitGraphLimit(t *testing.T) {
+	log := fakeJJ(t, `case "$*" in *COMMIT*) cat <<'OUT'
+@  <<<COMMIT>>>{"change_id":"kxqvmtsz","commit_id":"1a2b3c4d","author":"me@example.com","timestamp":"2025-06-10T08:30:00Z","description":"Top","parents":["5e6f7a8b"],"bookmarks":[],"working_copy":true,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+○  <<<COMMIT>>>{"change_id":"zzplqrwn","commit_id":"5e6f7a8b","author":"me@example.com","timestamp":"2025-06-01T00:00:00Z","description":"Middle","parents":["9c0d1e2f"],"bookmarks":[],"working_copy":false,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+○  <<<COMMIT>>>{"change_id":"yyplqrwn","commit_id":"9c0d1e2f","author":"me@example.com","timestamp":"2025-05-01T00:00:00Z","description":"Bottom","parents":[],"bookmarks":[],"working_copy":false,"conflict":false,"immutable":false,"divergent":false,"mine":true}
+OUT
+;; esac`)
+	s := &Service{RepoPath: t.TempDir(), GraphLimit: 2}
+	graph, err := s.getCommitGraph(context.Background(), "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.Commits) != 2 || !graph.HasMore {
+		t.Fatalf("commits = %d, HasMore = %v", len(graph.Commits), graph.HasMore)
+	}
+	if !strings.Contains(strings.Join(calls(t, log), "\n"), "--limit 3") {
+		t.Errorf("jj log was not limited: %q", calls(t, log))
+	}
+
+	s.GraphLimit = 3
+	if graph, err = s.getCommitGraph(context.Background(), "", false); err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.Commits) != 3 || graph.HasMore {
+		t.Errorf("commits = %d, HasMore = %v", len(graph.Commits), graph.HasMore)
+	}
+}
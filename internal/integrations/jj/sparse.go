@@ -0,0 +1,44 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SparsePatterns returns the paths checked out in the service's workspace (`jj sparse list`).
+// A full checkout is the single pattern ".".
+func (s *Service) SparsePatterns(ctx context.Context) ([]string, error) {
+	out, err := s.runJJOutput(ctx, "sparse", "list")
+	if err != nil {
+		return nil, err
+	}
+	var patterns []string
+	for _, line := range strings.Split(out, "\n") {
+		if p := strings.TrimSpace(line); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns, nil
+}
+
+// SetSparsePatterns adds and removes workspace-relative paths from the sparse patterns; jj then
+// checks out or removes the affected files. Paths with uncommitted changes are kept by jj.
+func (s *Service) SetSparsePatterns(ctx context.Context, add, remove []string) error {
+	args := []string{"sparse", "set"}
+	for _, p := range add {
+		args = append(args, "--add", p)
+	}
+	for _, p := range remove {
+		args = append(args, "--remove", p)
+	}
+	if len(args) == 2 {
+		return fmt.Errorf("no sparse paths to add or remove")
+	}
+	return s.runJJ(ctx, args...)
+}
+
+// ResetSparsePatterns checks out the whole repository again (`jj sparse reset`).
+func (s *Service) ResetSparsePatterns(ctx context.Context) error {
+	return s.runJJ(ctx, ResetSparseArgs()...)
+}
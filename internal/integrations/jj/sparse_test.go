@@ -0,0 +1,31 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSparsePatterns(t *testing.T) {
+	log := fakeJJ(t, `case "$1" in sparse) [ "$2" = list ] && printf 'src/app\ndocs\n\n' ;; esac; exit 0`)
+	s := &Service{RepoPath: t.TempDir()}
+	ctx := context.Background()
+
+	got, err := s.SparsePatterns(ctx)
+	if err != nil || !reflect.DeepEqual(got, []string{"src/app", "docs"}) {
+		t.Fatalf("SparsePatterns() = %v, %v", got, err)
+	}
+	if err := s.SetSparsePatterns(ctx, []string{"lib"}, []string{"docs"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSparsePatterns(ctx, nil, nil); err == nil {
+		t.Error("SetSparsePatterns with nothing to change should fail without running jj")
+	}
+	if err := s.ResetSparsePatterns(ctx); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"sparse list", "sparse set --add lib --remove docs", "sparse reset"}
+	if got := calls(t, log); !reflect.DeepEqual(got, want) {
+		t.Errorf("jj calls = %q, want %q", got, want)
+	}
+}
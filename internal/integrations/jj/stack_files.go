@@ -0,0 +1,68 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// StackFilesMaxCommits caps how many commits StackFiles loads files for (one jj call each).
+const StackFilesMaxCommits = 50
+
+// StackCommitFiles is one commit of a base..head range with the files it changes.
+type StackCommitFiles struct {
+	ChangeIDShort string
+	Subject       string
+	Files         []ChangedFile
+}
+
+// StackFiles lists the commits in trunk()..head, oldest first, each with its changed files.
+// Truncated reports that the range had more than StackFilesMaxCommits commits and only the
+// oldest ones were loaded.
+func (s *Service) StackFiles(ctx context.Context, head string) (commits []StackCommitFiles, truncated bool, err error) {
+	chain, err := s.ListChainCommits(ctx, "trunk()", head)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(chain) > StackFilesMaxCommits {
+		chain, truncated = chain[:StackFilesMaxCommits], true
+	}
+	for _, c := range chain {
+		files, err := s.GetChangedFiles(ctx, c.ChangeIDShort)
+		if err != nil {
+			return nil, false, fmt.Errorf("changed files of %s: %w", c.ChangeIDShort, err)
+		}
+		commits = append(commits, StackCommitFiles{ChangeIDShort: c.ChangeIDShort, Subject: c.Subject, Files: files})
+	}
+	return commits, truncated, nil
+}
+
+// StackPathTouch is a path changed by more than one commit of a stack (a squash candidate).
+type StackPathTouch struct {
+	Path      string
+	ChangeIDs []string // short change IDs, oldest first
+}
+
+// MultiTouchedPaths returns the paths more than one commit in stack changes, most-touched first
+// (then by path).
+func MultiTouchedPaths(stack []StackCommitFiles) []StackPathTouch {
+	byPath := map[string][]string{}
+	for _, c := range stack {
+		for _, f := range c.Files {
+			byPath[f.Path] = append(byPath[f.Path], c.ChangeIDShort)
+		}
+	}
+	var out []StackPathTouch
+	for p, ids := range byPath {
+		if len(ids) > 1 {
+			out = append(out, StackPathTouch{Path: p, ChangeIDs: ids})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i].ChangeIDs) != len(out[j].ChangeIDs) {
+			return len(out[i].ChangeIDs) > len(out[j].ChangeIDs)
+		}
+		return out[i].Path < out[j].Path
+	})
+	return out
+}
@@ -0,0 +1,25 @@
+package jj
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMultiTouchedPaths(t *testing.T) {
+	stack := []StackCommitFiles{
+		{ChangeIDShort: "aaaa", Files: []ChangedFile{{Path: "go.mod"}, {Path: "a.go"}}},
+		{ChangeIDShort: "bbbb", Files: []ChangedFile{{Path: "a.go"}, {Path: "b.go"}}},
+		{ChangeIDShort: "cccc", Files: []ChangedFile{{Path: "a.go"}, {Path: "go.mod"}}},
+	}
+	got := MultiTouchedPaths(stack)
+	want := []StackPathTouch{
+		{Path: "a.go", ChangeIDs: []string{"aaaa", "bbbb", "cccc"}},
+		{Path: "go.mod", ChangeIDs: []string{"aaaa", "cccc"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if got := MultiTouchedPaths(stack[:1]); len(got) != 0 {
+		t.Fatalf("single commit: %+v", got)
+	}
+}
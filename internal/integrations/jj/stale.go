@@ -0,0 +1,49 @@
+package jj
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrWorkingCopyStale is returned, without running jj, for a mutating command while the working
+// copy is stale: another workspace or jj process moved the repo past the operation @ was last
+// updated at. Running `jj workspace update-stale` (UpdateStale) clears it.
+var ErrWorkingCopyStale = errors.New("the working copy is stale; run jj workspace update-stale first")
+
+// WorkingCopyStale reports whether the last jj command that loaded the working copy found it
+// stale. Read-only commands keep working meanwhile (see execJJ); mutating ones are refused with
+// ErrWorkingCopyStale.
+func (s *Service) WorkingCopyStale() bool {
+	return s.stale.Load()
+}
+
+// UpdateStale runs `jj workspace update-stale`, which checks out the commit the repo now says @
+// is, and lets mutating commands run again.
+func (s *Service) UpdateStale(ctx context.Context) error {
+	return s.runJJ(ctx, "workspace", "update-stale")
+}
+
+// skippedCommand reports errors execJJ returns without running jj (a command declined in preview
+// mode or refused on a stale working copy). Callers pass them through as they are and leave them
+// out of command history.
+func skippedCommand(err error) bool {
+	return errors.Is(err, ErrCommandCancelled) || errors.Is(err, ErrWorkingCopyStale)
+}
+
+// loadsWorkingCopy reports whether jj loads (and snapshots) the working copy for args, so that
+// whether it succeeds says whether the working copy is stale.
+func loadsWorkingCopy(args []string) bool {
+	for _, a := range args {
+		if a == "--ignore-working-copy" || a == "--at-op" || a == "--at-operation" ||
+			strings.HasPrefix(a, "--at-op=") || strings.HasPrefix(a, "--at-operation=") {
+			return false
+		}
+	}
+	for _, a := range args {
+		if !strings.HasPrefix(a, "-") {
+			return a != "config" && a != "version" && a != "help" && a != "util"
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// tagsContainingTemplate prints each commit's tags on one line, space-separated.
+const tagsContainingTemplate = `if(tags, tags ++ "\n")`
+
+// TagsContaining returns the tags whose commit contains commitID (the commit or one of its
+// descendants is tagged), oldest first, so the first is the release the commit first shipped in.
+func (s *Service) TagsContaining(ctx context.Context, commitID string) ([]string, error) {
+	if strings.TrimSpace(commitID) == "" {
+		return nil, nil
+	}
+	revset := fmt.Sprintf("tags() & descendants(%s)", commitID)
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", revset, "--no-graph", "--reversed", "-T", tagsContainingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return parseTagsContaining(out), nil
+}
+
+// parseTagsContaining splits TagsContaining output into tag names, dropping duplicates and any
+// conflict marker ("v1.0??") jj appends.
+func parseTagsContaining(out string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Fields(out) {
+		name = strings.TrimRight(name, "?*")
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+	return tags
+}
@@ -0,0 +1,25 @@
+package jj
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTagsContaining(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"one per commit", "v1.0.0\nv1.1.0\n", []string{"v1.0.0", "v1.1.0"}},
+		{"several on one commit", "v1.0.0 stable\nv1.1.0\n", []string{"v1.0.0", "stable", "v1.1.0"}},
+		{"conflicted tag", "v2.0.0??\n", []string{"v2.0.0"}},
+		{"duplicates", "v1.0.0\nv1.0.0\n", []string{"v1.0.0"}},
+	}
+	for _, tt := range tests {
+		if got := parseTagsContaining(tt.out); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseTagsContaining(%q) = %q, want %q", tt.name, tt.out, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package jj
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// trashMax caps how many abandoned commits the trash remembers.
+const trashMax = 50
+
+// TrashEntry is a commit abandoned from jj-tui, with the operation that abandoned it.
+type TrashEntry struct {
+	ChangeID string
+	Summary  string
+	OpID     string
+	At       time.Time
+}
+
+// trashList holds this session's abandoned commits, oldest first.
+type trashList struct {
+	mu      sync.Mutex
+	entries []TrashEntry
+}
+
+// AbandonToTrash abandons changeID like AbandonCommit and remembers the operation, so
+// RestoreFromTrash can bring the commit back later. summary is shown in the trash list.
+func (s *Service) AbandonToTrash(ctx context.Context, changeID, summary string) error {
+	if err := s.AbandonCommit(ctx, changeID); err != nil {
+		return err
+	}
+	head, err := s.opLog(ctx, 1)
+	if err != nil {
+		// The abandon itself worked; the commit just can't be restored from the trash.
+		return nil
+	}
+	s.trash.mu.Lock()
+	defer s.trash.mu.Unlock()
+	s.trash.entries = append(s.trash.entries, TrashEntry{ChangeID: changeID, Summary: summary, OpID: head[0].ID, At: time.Now()})
+	if len(s.trash.entries) > trashMax {
+		s.trash.entries = slices.Clone(s.trash.entries[len(s.trash.entries)-trashMax:])
+	}
+	return nil
+}
+
+// Trash returns the abandoned commits that can still be restored, newest first.
+func (s *Service) Trash() []TrashEntry {
+	s.trash.mu.Lock()
+	defer s.trash.mu.Unlock()
+	out := slices.Clone(s.trash.entries)
+	slices.Reverse(out)
+	return out
+}
+
+// RestoreFromTrash reverts the operation that abandoned a trash entry, leaving every later
+// operation in place. It runs `jj op revert`, falling back to `jj undo <op>` on jj versions
+// before op revert existed.
+func (s *Service) RestoreFromTrash(ctx context.Context, opID string) error {
+	_, err := s.runJJCombined(ctx, "op", "revert", opID)
+	if err != nil && strings.Contains(err.Error(), "unrecognized subcommand") {
+		err = s.runJJ(ctx, "undo", opID)
+	}
+	if err != nil {
+		return err
+	}
+	s.forgetTrash([]string{opID})
+	return nil
+}
+
+// forgetTrash drops the entries abandoned by the given operations (restored or undone).
+func (s *Service) forgetTrash(opIDs []string) {
+	s.trash.mu.Lock()
+	defer s.trash.mu.Unlock()
+	s.trash.entries = slices.DeleteFunc(s.trash.entries, func(e TrashEntry) bool {
+		return slices.Contains(opIDs, e.OpID)
+	})
+}
@@ -0,0 +1,45 @@
+package jj
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// An abandon is remembered with its operation; restoring reverts that operation (falling back to
+// `jj undo <op>` on older jj) and takes the entry out of the trash.
+func TestTrash(t *testing.T) {
+	log := fakeJJ(t, `case "$*" in
+*"op log"*) echo "op1	abandon commit" ;;
+*"op revert"*) echo "error: unrecognized subcommand 'revert'" >&2; exit 2 ;;
+esac`)
+	s := &Service{RepoPath: t.TempDir()}
+	ctx := context.Background()
+	if err := s.AbandonToTrash(ctx, "xyz", "fix typo"); err != nil {
+		t.Fatal(err)
+	}
+	trash := s.Trash()
+	if len(trash) != 1 || trash[0].ChangeID != "xyz" || trash[0].Summary != "fix typo" || trash[0].OpID != "op1" {
+		t.Fatalf("trash = %+v", trash)
+	}
+	if err := s.RestoreFromTrash(ctx, "op1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Trash()) != 0 {
+		t.Fatal("a restored commit should leave the trash")
+	}
+	got := calls(t, log)
+	if got[0] != "abandon xyz" || !reflect.DeepEqual(got[len(got)-2:], []string{"op revert op1", "undo op1"}) {
+		t.Fatalf("calls = %q", got)
+	}
+}
+
+// Undoing the abandon with Ctrl+Z takes it out of the trash too.
+func TestTrashForgetsUndoneAbandon(t *testing.T) {
+	s := &Service{}
+	s.trash.entries = []TrashEntry{{ChangeID: "a", OpID: "op1"}, {ChangeID: "b", OpID: "op2"}}
+	s.forgetTrash([]string{"op2"})
+	if trash := s.Trash(); len(trash) != 1 || trash[0].ChangeID != "a" {
+		t.Fatalf("trash = %+v", trash)
+	}
+}
@@ -0,0 +1,133 @@
+package jj
+
+import (
+	"context"
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+// trunkDistanceTemplate prints each commit's ID, whether it is on trunk ("t") or not ("-"), and
+// its parents' IDs.
+const trunkDistanceTemplate = `commit_id.short(8) ++ " " ++ if(self.contained_in("::trunk()"), "t", "-") ++ " " ++ ` +
+	`parents.map(|p| p.commit_id().short(8)).join(",") ++ "\n"`
+
+// trunkDistanceRevset selects the graph's mutable commits and their ancestors not on trunk, plus
+// every trunk commit from their fork points up to trunk().
+func trunkDistanceRevset(revset string) string {
+	stack := "trunk()..((" + revset + ") & mutable())"
+	return "(" + stack + ") | (roots(" + stack + ")-)::trunk()"
+}
+
+// enrichCommitsTrunkDistance sets TrunkAhead and TrunkBehind on the graph's mutable commits from
+// one query over their stacks and the trunk commits since their fork points. Both stay zero when
+// trunk() cannot be resolved or the query fails.
+func (s *Service) enrichCommitsTrunkDistance(ctx context.Context, commits []internal.Commit, revset string) {
+	mutable := false
+	for _, c := range commits {
+		mutable = mutable || !c.Immutable
+	}
+	if !mutable {
+		return
+	}
+	out, err := s.runJJOutputNoHistory(ctx, "log", "-r", trunkDistanceRevset(revset), "--no-graph", "-T", trunkDistanceTemplate)
+	if err != nil {
+		return
+	}
+	applyTrunkDistance(commits, out)
+}
+
+// applyTrunkDistance computes the distances from trunkDistanceTemplate output (see
+// trunkGraph.distance).
+func applyTrunkDistance(commits []internal.Commit, out string) {
+	g := newTrunkGraph()
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		var parents []string
+		if len(fields) == 3 {
+			parents = strings.Split(fields[2], ",")
+		}
+		g.add(fields[0], fields[1] == "t", parents)
+	}
+	for i := range commits {
+		c := &commits[i]
+		if c.Immutable || !g.has(c.ID) {
+			continue
+		}
+		c.TrunkAhead, c.TrunkBehind = g.distance(c.ID)
+	}
+}
+
+// trunkGraph is a slice of history around trunk: some commits, their parents, and which of them
+// are on trunk. Commits must be added newest first (jj log order).
+type trunkGraph struct {
+	parents  map[string][]string
+	onTrunk  map[string]bool
+	trunkIDs []string // newest first
+}
+
+func newTrunkGraph() *trunkGraph {
+	return &trunkGraph{parents: make(map[string][]string), onTrunk: make(map[string]bool)}
+}
+
+func (g *trunkGraph) add(id string, onTrunk bool, parents []string) {
+	g.parents[id] = parents
+	if onTrunk {
+		g.onTrunk[id] = true
+		g.trunkIDs = append(g.trunkIDs, id)
+	}
+}
+
+func (g *trunkGraph) has(id string) bool {
+	_, ok := g.parents[id]
+	return ok
+}
+
+// distance counts how far tips are from trunk: ahead by their ancestors (tips included) that are
+// not on trunk, and behind by the trunk commits in the graph that are not their ancestors. Tips
+// missing from the graph are ignored.
+func (g *trunkGraph) distance(tips ...string) (ahead, behind int) {
+	seen := make(map[string]bool)
+	var queue []string
+	for _, id := range tips {
+		if g.has(id) && !seen[id] {
+			seen[id] = true
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if g.onTrunk[id] {
+			continue
+		}
+		ahead++
+		for _, p := range g.parents[id] {
+			if g.has(p) && !seen[p] {
+				seen[p] = true
+				queue = append(queue, p)
+			}
+		}
+	}
+	// Trunk commits reached so far are the fork points; their trunk ancestors are shared
+	// history too. trunkIDs is newest first, so parents are visited after their children.
+	for _, id := range g.trunkIDs {
+		if !seen[id] {
+			continue
+		}
+		for _, p := range g.parents[id] {
+			if g.onTrunk[p] {
+				seen[p] = true
+			}
+		}
+	}
+	for _, id := range g.trunkIDs {
+		if !seen[id] {
+			behind++
+		}
+	}
+	return ahead, behind
+}
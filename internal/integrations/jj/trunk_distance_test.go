@@ -0,0 +1,39 @@
+package jj
+
+import (
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+func TestTrunkDistanceRevset(t *testing.T) {
+	got := trunkDistanceRevset("@ | main")
+	want := "(trunk()..((@ | main) & mutable())) | (roots(trunk()..((@ | main) & mutable()))-)::trunk()"
+	if got != want {
+		t.Fatalf("revset = %s, want %s", got, want)
+	}
+}
+
+// Trunk t3 ← t2 ← t1. Stack a1 ← a2 forked at t1, b1 sits on trunk's head t3, and m1 merges t2
+// into a1, so it is missing only t3.
+func TestApplyTrunkDistance(t *testing.T) {
+	out := "m1 - a1,t2\n" +
+		"b1 - t3\n" +
+		"a2 - a1\n" +
+		"t3 t t2\n" +
+		"t2 t t1\n" +
+		"a1 - t1\n" +
+		"t1 t t0\n"
+	commits := []internal.Commit{
+		{ID: "m1"}, {ID: "b1"}, {ID: "a2"}, {ID: "a1"},
+		{ID: "t3", Immutable: true},
+		{ID: "zz"}, // not in the output: unknown
+	}
+	applyTrunkDistance(commits, out)
+	want := map[string][2]int{"m1": {2, 1}, "b1": {1, 0}, "a2": {2, 2}, "a1": {1, 2}, "t3": {0, 0}, "zz": {0, 0}}
+	for _, c := range commits {
+		if got := [2]int{c.TrunkAhead, c.TrunkBehind}; got != want[c.ID] {
+			t.Errorf("%s: ahead/behind = %v, want %v", c.ID, got, want[c.ID])
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package jj
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchDebounce is how long the repo must be quiet before WatchRepo delivers a change.
+const WatchDebounce = 250 * time.Millisecond
+
+// WatchMaxDirs bounds the working-copy directories watched. fsnotify watches one directory at a
+// time and inotify caps watches per user, so in a huge tree the periodic refresh covers the rest.
+const WatchMaxDirs = 4096
+
+// watchMaxIgnoreCheck bounds the edited paths checked against .gitignore per debounced change; a
+// burst bigger than that is delivered without asking git.
+const watchMaxIgnoreCheck = 512
+
+// RepoChange says what changed on disk since the last one was delivered.
+type RepoChange struct {
+	// Operation is set when jj recorded an operation (a new op head), from jj-tui or anywhere else.
+	Operation bool
+	// WorkingCopy is set when files in the working copy changed; jj hasn't snapshotted them yet.
+	WorkingCopy bool
+	// OpAt and EditAt are when the latest operation and working-copy edit were seen.
+	OpAt, EditAt time.Time
+}
+
+// IsZero reports whether nothing changed.
+func (c RepoChange) IsZero() bool {
+	return !c.Operation && !c.WorkingCopy
+}
+
+// Merge adds d to c.
+func (c RepoChange) Merge(d RepoChange) RepoChange {
+	if d.Operation {
+		c.Operation, c.OpAt = true, later(c.OpAt, d.OpAt)
+	}
+	if d.WorkingCopy {
+		c.WorkingCopy, c.EditAt = true, later(c.EditAt, d.EditAt)
+	}
+	return c
+}
+
+// Since drops what a graph load that started at loadStart and finished at loadEnd already shows:
+// edits from before it snapshotted the working copy, and operations from before it finished
+// (including the snapshot operation the load itself records).
+func (c RepoChange) Since(loadStart, loadEnd time.Time) RepoChange {
+	if c.Operation && !c.OpAt.After(loadEnd) {
+		c.Operation, c.OpAt = false, time.Time{}
+	}
+	if c.WorkingCopy && !c.EditAt.After(loadStart) {
+		c.WorkingCopy, c.EditAt = false, time.Time{}
+	}
+	return c
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// RepoWatcher reports changes to a repository: new operations (files under
+// .jj/repo/op_heads/heads) and edits in the working copy. Changes are debounced, so a burst of
+// writes arrives as one RepoChange.
+type RepoWatcher struct {
+	RepoPath string
+
+	fw        *fsnotify.Watcher
+	opHeads   string
+	onChange  func() // called from the event loop for every change, before debouncing
+	changes   chan RepoChange
+	closeOnce sync.Once
+	done      chan struct{}
+	dirs      int // working-copy directories watched; only the event loop touches it after start
+	truncated atomic.Bool
+	// ignored returns the paths .gitignore excludes (nil: none). Edits only under ignored paths,
+	// such as build output, are not working-copy changes.
+	ignored func(paths []string) []string
+	edits   map[string]bool // working-copy paths edited since the last delivery (event loop only)
+}
+
+// WatchRepo starts watching s's repository. Close the watcher when the repository is no longer
+// shown. Every change also clears s's query cache, since working-copy edits don't show in the
+// operation head until jj snapshots them.
+func (s *Service) WatchRepo(debounce time.Duration) (*RepoWatcher, error) {
+	opHeads, err := opHeadsDir(s.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Add(opHeads); err != nil {
+		_ = fw.Close()
+		return nil, err
+	}
+	w := &RepoWatcher{
+		RepoPath: s.RepoPath,
+		fw:       fw,
+		opHeads:  opHeads,
+		onChange: s.InvalidateCache,
+		changes:  make(chan RepoChange, 1),
+		done:     make(chan struct{}),
+		ignored: func(paths []string) []string {
+			return s.gitIgnored(context.Background(), paths)
+		},
+		edits: map[string]bool{},
+	}
+	w.addTree(s.RepoPath)
+	go w.run(debounce)
+	return w, nil
+}
+
+// opHeadsDir finds the op heads of the repo at repoPath. In a secondary workspace .jj/repo is a
+// file holding the path of the main workspace's .jj/repo (relative to .jj).
+func opHeadsDir(repoPath string) (string, error) {
+	repo := filepath.Join(repoPath, ".jj", "repo")
+	info, err := os.Stat(repo)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(repo)
+		if err != nil {
+			return "", err
+		}
+		repo = strings.TrimSpace(string(data))
+		if !filepath.IsAbs(repo) {
+			repo = filepath.Join(repoPath, ".jj", repo)
+		}
+	}
+	dir := filepath.Join(repo, "op_heads", "heads")
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", errors.New("no operation heads in " + repo)
+	}
+	return dir, nil
+}
+
+// Changes delivers debounced changes. It is closed when the watcher is.
+func (w *RepoWatcher) Changes() <-chan RepoChange {
+	return w.changes
+}
+
+// Close stops watching. It is safe to call more than once.
+func (w *RepoWatcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.fw.Close()
+	})
+	return err
+}
+
+// Truncated reports whether the working copy has more directories than the watcher will watch
+// (WatchMaxDirs); edits in the rest only show on the periodic refresh.
+func (w *RepoWatcher) Truncated() bool {
+	return w.truncated.Load()
+}
+
+// addTree watches dir and its subdirectories, skipping .jj and .git (jj's own writes there are
+// seen through the op heads instead) and directories .gitignore excludes, such as node_modules or
+// build output. It goes one level at a time so git is asked about a whole level at once.
+func (w *RepoWatcher) addTree(dir string) {
+	for level := []string{dir}; len(level) > 0; {
+		var next []string
+		for _, d := range w.unignored(level) {
+			if w.dirs >= WatchMaxDirs {
+				w.truncated.Store(true)
+				return
+			}
+			if w.fw.Add(d) == nil {
+				w.dirs++
+			}
+			entries, err := os.ReadDir(d)
+			if err != nil {
+				continue
+			}
+			for _, e := range entries {
+				if e.IsDir() && e.Name() != ".jj" && e.Name() != ".git" {
+					next = append(next, filepath.Join(d, e.Name()))
+				}
+			}
+		}
+		level = next
+	}
+}
+
+// unignored returns the paths .gitignore doesn't exclude.
+func (w *RepoWatcher) unignored(paths []string) []string {
+	if w.ignored == nil || len(paths) == 0 {
+		return paths
+	}
+	ignored := map[string]bool{}
+	for _, p := range w.ignored(paths) {
+		ignored[p] = true
+	}
+	if len(ignored) == 0 {
+		return paths
+	}
+	var kept []string
+	for _, p := range paths {
+		if !ignored[p] {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// dropIgnoredEdits clears c's working-copy edit when every path edited since the last delivery is
+// ignored, so writes to build output don't reload the graph.
+func (w *RepoWatcher) dropIgnoredEdits(c RepoChange) RepoChange {
+	edits := w.edits
+	w.edits = map[string]bool{}
+	if !c.WorkingCopy || len(edits) == 0 || len(edits) > watchMaxIgnoreCheck {
+		return c
+	}
+	paths := make([]string, 0, len(edits))
+	for p := range edits {
+		paths = append(paths, p)
+	}
+	if len(w.unignored(paths)) == 0 {
+		c.WorkingCopy, c.EditAt = false, time.Time{}
+	}
+	return c
+}
+
+// classify turns an fsnotify event into the change it stands for, watching directories created in
+// the working copy as they appear.
+func (w *RepoWatcher) classify(ev fsnotify.Event) RepoChange {
+	if filepath.Dir(ev.Name) == w.opHeads {
+		return RepoChange{Operation: true, OpAt: time.Now()}
+	}
+	if name := filepath.Base(ev.Name); ev.Op == fsnotify.Chmod || name == ".jj" || name == ".git" {
+		return RepoChange{}
+	}
+	if ev.Op.Has(fsnotify.Create) {
+		if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+			w.addTree(ev.Name)
+		}
+	}
+	return RepoChange{WorkingCopy: true, EditAt: time.Now()}
+}
+
+// run collects events until the tree has been quiet for debounce, then delivers them as one
+// change. If the receiver is slow, changes pile up into the pending value instead of blocking.
+func (w *RepoWatcher) run(debounce time.Duration) {
+	defer close(w.changes)
+	var pending RepoChange
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-w.done:
+			return
+		case ev, ok := <-w.fw.Events:
+			if !ok {
+				return
+			}
+			c := w.classify(ev)
+			if c.IsZero() {
+				continue
+			}
+			if w.onChange != nil {
+				w.onChange()
+			}
+			if c.WorkingCopy && w.edits != nil && len(w.edits) <= watchMaxIgnoreCheck {
+				w.edits[ev.Name] = true
+			}
+			pending = pending.Merge(c)
+			timer = time.After(debounce)
+		case _, ok := <-w.fw.Errors:
+			if !ok {
+				return
+			}
+		case <-timer:
+			timer = nil
+			if pending = w.dropIgnoredEdits(pending); pending.IsZero() {
+				continue
+			}
+			select {
+			case w.changes <- pending:
+				pending = RepoChange{}
+			case <-w.done:
+				return
+			default:
+				// The last change hasn't been read yet; retry once more events (or time) pass.
+				timer = time.After(debounce)
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package jj
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func nextChange(t *testing.T, w *RepoWatcher) RepoChange {
+	t.Helper()
+	select {
+	case c := <-w.Changes():
+		return c
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported")
+		return RepoChange{}
+	}
+}
+
+// New op heads and working-copy edits (including in directories created after the watch
+// started) arrive as separate debounced changes; jj's own directories are not working copy.
+func TestWatchRepo(t *testing.T) {
+	dir := t.TempDir()
+	heads := filepath.Join(dir, ".jj", "repo", "op_heads", "heads")
+	if err := os.MkdirAll(heads, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	svc := &Service{RepoPath: dir}
+	w, err := svc.WatchRepo(20 * time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	write := func(path string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(heads, "abc"))
+	if c := nextChange(t, w); !c.Operation || c.WorkingCopy {
+		t.Fatalf("op head change = %+v", c)
+	}
+
+	// Edits don't move the op head until jj snapshots them, so they clear the query cache.
+	svc.cache.put("abc", "key", "out")
+	write(filepath.Join(dir, "a.txt"))
+	write(filepath.Join(dir, "b.txt"))
+	if c := nextChange(t, w); c.Operation || !c.WorkingCopy {
+		t.Fatalf("working copy change = %+v", c)
+	}
+	if _, ok := svc.cache.get("abc", "key"); ok {
+		t.Fatal("a working-copy edit should clear the query cache")
+	}
+
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	nextChange(t, w)
+	write(filepath.Join(sub, "c.txt"))
+	if c := nextChange(t, w); !c.WorkingCopy {
+		t.Fatalf("new directory change = %+v", c)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-w.Changes(); ok {
+		t.Fatal("Changes should close with the watcher")
+	}
+}
+
+// A secondary workspace finds the op heads through the .jj/repo pointer file.
+func TestOpHeadsDirWorkspace(t *testing.T) {
+	main := t.TempDir()
+	heads := filepath.Join(main, ".jj", "repo", "op_heads", "heads")
+	if err := os.MkdirAll(heads, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ws := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(ws, ".jj"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(ws, ".jj", "repo"), []byte(filepath.Join(main, ".jj", "repo")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := opHeadsDir(ws); err != nil || got != heads {
+		t.Fatalf("opHeadsDir = %q, %v; want %q", got, err, heads)
+	}
+	if _, err := opHeadsDir(t.TempDir()); err == nil {
+		t.Fatal("a directory without .jj should not be watchable")
+	}
+}
+
+// Since keeps only what happened after a load: edits after it snapshotted, operations after it
+// finished.
+func TestRepoChangeSince(t *testing.T) {
+	start := time.Now()
+	end := start.Add(time.Second)
+	c := RepoChange{}.
+		Merge(RepoChange{Operation: true, OpAt: start.Add(500 * time.Millisecond)}).
+		Merge(RepoChange{WorkingCopy: true, EditAt: start.Add(500 * time.Millisecond)})
+	if got := c.Since(start, end); got.Operation || !got.WorkingCopy {
+		t.Fatalf("Since = %+v", got)
+	}
+	if got := c.Since(end, end); !got.IsZero() {
+		t.Fatalf("a later load shows everything, got %+v", got)
+	}
+}
+
+// Directories .gitignore excludes aren't watched, and edits only to ignored files (build output)
+// don't count as working-copy changes.
+func TestWatchRepoSkipsIgnored(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+	for _, d := range []string{filepath.Join(".jj", "repo", "op_heads", "heads"), "node_modules", "src"} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("node_modules/\n*.log\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	svc := &Service{RepoPath: dir}
+	w, err := svc.WatchRepo(20 * time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if w.dirs != 2 || w.Truncated() {
+		t.Fatalf("watching %d directories (truncated %v), want the root and src", w.dirs, w.Truncated())
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "build.log"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case c := <-w.Changes():
+		t.Fatalf("ignored edit reported as %+v", c)
+	case <-time.After(300 * time.Millisecond):
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if c := nextChange(t, w); !c.WorkingCopy {
+		t.Fatalf("edit = %+v", c)
+	}
+}
@@ -0,0 +1,115 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Workspace is one entry of `jj workspace list`.
+type Workspace struct {
+	Name     string
+	ChangeID string // working-copy change
+	CommitID string
+	Summary  string // rest of jj's line: "(empty) (no description set)" or the description
+	// Path is the workspace's root directory; empty when this jj cannot report it.
+	Path    string
+	Current bool // the workspace the service runs in
+	Missing bool // Path is known but the directory no longer exists
+}
+
+// ListWorkspaces returns the repository's workspaces in jj's order, with their root directories
+// when jj can report them (`jj workspace root --name`), and which one the service runs in.
+func (s *Service) ListWorkspaces(ctx context.Context) ([]Workspace, error) {
+	out, err := s.runJJOutput(ctx, "workspace", "list", "--color", "never")
+	if err != nil {
+		return nil, err
+	}
+	workspaces := parseWorkspaceList(out)
+	root := s.workspaceRoot(ctx, "")
+	for i := range workspaces {
+		w := &workspaces[i]
+		w.Path = s.workspaceRoot(ctx, w.Name)
+		if w.Path != "" {
+			_, statErr := os.Stat(w.Path)
+			w.Missing = os.IsNotExist(statErr)
+			w.Current = root != "" && filepath.Clean(w.Path) == filepath.Clean(root)
+		}
+	}
+	return workspaces, nil
+}
+
+// parseWorkspaceList parses `jj workspace list` lines ("name: change_id commit_id summary").
+func parseWorkspaceList(out string) []Workspace {
+	var workspaces []Workspace
+	for _, line := range strings.Split(out, "\n") {
+		name, rest, ok := strings.Cut(strings.TrimRight(line, "\r"), ": ")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		fields := strings.SplitN(strings.TrimSpace(rest), " ", 3)
+		if len(fields) < 2 {
+			continue
+		}
+		w := Workspace{Name: strings.TrimSpace(name), ChangeID: fields[0], CommitID: fields[1]}
+		if len(fields) > 2 {
+			w.Summary = strings.TrimSpace(fields[2])
+		}
+		workspaces = append(workspaces, w)
+	}
+	return workspaces
+}
+
+// workspaceRoot returns the root of workspace name (the service's own workspace when name is
+// empty), or "" when jj cannot tell; `--name` needs a recent jj.
+func (s *Service) workspaceRoot(ctx context.Context, name string) string {
+	args := []string{"workspace", "root"}
+	if name != "" {
+		args = append(args, "--name", name)
+	}
+	out, err := s.runJJOutputNoHistory(ctx, args...)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// AddWorkspace creates a workspace at path (relative paths are resolved against the repository)
+// with a new working-copy change on top of revision (@'s parents when empty). jj names the
+// workspace after the directory when name is empty. Returns the absolute path.
+func (s *Service) AddWorkspace(ctx context.Context, path, name, revision string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", fmt.Errorf("workspace path is required")
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.RepoPath, path)
+	}
+	args := []string{"workspace", "add"}
+	if name = strings.TrimSpace(name); name != "" {
+		args = append(args, "--name", name)
+	}
+	if revision = strings.TrimSpace(revision); revision != "" {
+		args = append(args, "-r", revision)
+	}
+	if err := s.runJJ(ctx, append(args, path)...); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ForgetWorkspace stops tracking workspace name. jj leaves its directory on disk; the
+// workspace's working-copy change stays in the repository.
+func (s *Service) ForgetWorkspace(ctx context.Context, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+	return s.runJJ(ctx, ForgetWorkspaceArgs(name)...)
+}
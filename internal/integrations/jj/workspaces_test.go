@@ -0,0 +1,20 @@
+package jj
+
+import "testing"
+
+func TestParseWorkspaceList(t *testing.T) {
+	out := "default: rlvkpnrz 7d5a0c8e (empty) (no description set)\n" +
+		"feature-x: kxqpmwvz 0a1b2c3d Add parser\n" +
+		"\n" +
+		"Warning: something\n"
+	got := parseWorkspaceList(out)
+	if len(got) != 2 {
+		t.Fatalf("workspaces = %+v, want 2", got)
+	}
+	if w := got[0]; w.Name != "default" || w.ChangeID != "rlvkpnrz" || w.CommitID != "7d5a0c8e" || w.Summary != "(empty) (no description set)" {
+		t.Errorf("first = %+v", w)
+	}
+	if w := got[1]; w.Name != "feature-x" || w.ChangeID != "kxqpmwvz" || w.Summary != "Add parser" {
+		t.Errorf("second = %+v", w)
+	}
+}
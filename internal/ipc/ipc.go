@@ -0,0 +1,206 @@
+// Package ipc exposes a local control socket so editor plugins and scripts can drive an
+// already-running jj-tui ("select revision X", "open PR view", "refresh") instead of spawning
+// a new instance. The protocol is one text command per line; each gets a one-line reply,
+// "ok" or "error: <reason>".
+package ipc
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Kind is the action a Command asks for.
+type Kind int
+
+const (
+	KindSelect  Kind = iota // select a revision (change ID, commit ID prefix, or bookmark) in the graph
+	KindView                // switch to a tab
+	KindRefresh             // reload the repository
+)
+
+// Views accepted by "view <name>".
+var Views = []string{"graph", "prs", "tickets", "branches", "workspaces", "settings", "help"}
+
+// Command is a parsed control command. The TUI receives it as a tea message.
+type Command struct {
+	Kind Kind
+	Arg  string // revision for KindSelect, view name for KindView
+}
+
+// Parse parses one command line: "select <rev>", "view <name>", or "refresh".
+func Parse(line string) (Command, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return Command{}, errors.New("empty command")
+	}
+	switch strings.ToLower(fields[0]) {
+	case "select":
+		if len(fields) != 2 {
+			return Command{}, errors.New("usage: select <revision>")
+		}
+		return Command{Kind: KindSelect, Arg: fields[1]}, nil
+	case "view":
+		if len(fields) != 2 {
+			return Command{}, fmt.Errorf("usage: view <%s>", strings.Join(Views, "|"))
+		}
+		name := strings.ToLower(fields[1])
+		for _, v := range Views {
+			if v == name {
+				return Command{Kind: KindView, Arg: name}, nil
+			}
+		}
+		return Command{}, fmt.Errorf("unknown view %q (want one of %s)", fields[1], strings.Join(Views, ", "))
+	case "refresh":
+		if len(fields) != 1 {
+			return Command{}, errors.New("usage: refresh")
+		}
+		return Command{Kind: KindRefresh}, nil
+	}
+	return Command{}, fmt.Errorf("unknown command %q", fields[0])
+}
+
+// DefaultSocketPath returns the per-user socket path for the repository at repoRoot, so a
+// client started anywhere in the same repo finds the running instance.
+func DefaultSocketPath(repoRoot string) string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), fmt.Sprintf("jj-tui-%d", os.Getuid()))
+	} else {
+		dir = filepath.Join(dir, "jj-tui")
+	}
+	sum := sha256.Sum256([]byte(filepath.Clean(repoRoot)))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])[:16]+".sock")
+}
+
+// Server accepts control connections on a Unix socket.
+type Server struct {
+	path     string
+	listener net.Listener
+	handle   func(Command)
+	wg       sync.WaitGroup
+}
+
+// Listen creates the socket at path (removing a stale one left by a crashed instance) and
+// starts serving. handle is called for every valid command, from connection goroutines. The
+// socket's directory must belong to the current user with mode 0700 (it is created that way when
+// missing), so no other user can reach the socket, even before it is chmod'ed to 0600.
+func Listen(path string, handle func(Command)) (*Server, error) {
+	if err := privateDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("another jj-tui is already listening on %s", path)
+	}
+	_ = os.Remove(path)
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	_ = os.Chmod(path, 0o600)
+	s := &Server{path: path, listener: l, handle: handle}
+	s.wg.Add(1)
+	go s.acceptLoop()
+	return s, nil
+}
+
+// privateDir creates dir with mode 0700 when it is missing and otherwise checks that it is a real
+// directory (not a symlink) owned by the current user with mode 0700. A shared temp directory
+// lets another user create $TMPDIR/jj-tui-<uid> first, so an existing one is not trusted.
+func privateDir(dir string) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if err := os.Mkdir(dir, 0o700); err != nil && !errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	fi, err := os.Lstat(dir)
+	if err != nil {
+		return fmt.Errorf("failed to check socket directory: %w", err)
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("socket directory %s is not a directory", dir)
+	}
+	owner, err := fileOwner(fi)
+	if err != nil {
+		return err
+	}
+	if uid := os.Getuid(); owner != uid {
+		return fmt.Errorf("socket directory %s belongs to uid %d, not to you (uid %d)", dir, owner, uid)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o700 {
+		return fmt.Errorf("socket directory %s has mode %#o; it must be 0700 so other users can't reach the socket", dir, perm)
+	}
+	return nil
+}
+
+// Path returns the socket path.
+func (s *Server) Path() string { return s.path }
+
+// Close stops accepting connections and removes the socket file.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	_ = os.Remove(s.path)
+	return err
+}
+
+func (s *Server) acceptLoop() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serve(conn)
+	}
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer conn.Close()
+	sc := bufio.NewScanner(conn)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		cmd, err := Parse(line)
+		if err != nil {
+			fmt.Fprintf(conn, "error: %v\n", err)
+			continue
+		}
+		s.handle(cmd)
+		fmt.Fprintln(conn, "ok")
+	}
+}
+
+// Send connects to the socket at path, sends one command line, and returns the reply error
+// (nil for "ok").
+func Send(path, line string) error {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return fmt.Errorf("no running jj-tui at %s: %w", path, err)
+	}
+	defer conn.Close()
+	if _, err := fmt.Fprintln(conn, strings.TrimSpace(line)); err != nil {
+		return err
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read reply: %w", err)
+	}
+	reply = strings.TrimSpace(reply)
+	if reply == "ok" {
+		return nil
+	}
+	return errors.New(strings.TrimPrefix(reply, "error: "))
+}
@@ -0,0 +1,92 @@
+package ipc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    Command
+		wantErr bool
+	}{
+		{line: "select qpvuntsm", want: Command{Kind: KindSelect, Arg: "qpvuntsm"}},
+		{line: "VIEW PRs", want: Command{Kind: KindView, Arg: "prs"}},
+		{line: "refresh", want: Command{Kind: KindRefresh}},
+		{line: "select", wantErr: true},
+		{line: "view nowhere", wantErr: true},
+		{line: "launch", wantErr: true},
+		{line: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) err = %v, wantErr %v", tt.line, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestServer_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run", "ctl.sock")
+	got := make(chan Command, 1)
+	srv, err := Listen(path, func(c Command) { got <- c })
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer srv.Close()
+
+	if err := Send(path, "view graph"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if c := <-got; c.Kind != KindView || c.Arg != "graph" {
+		t.Errorf("handled %+v, want view graph", c)
+	}
+	if err := Send(path, "bogus"); err == nil {
+		t.Error("Send(bogus) err = nil, want server error")
+	}
+	if _, err := Listen(path, func(Command) {}); err == nil {
+		t.Error("second Listen on a live socket should fail")
+	}
+}
+
+// Listen refuses a socket directory other users can reach (or that is a symlink) and creates a
+// missing one with mode 0700.
+func TestListenChecksSocketDir(t *testing.T) {
+	base := t.TempDir()
+
+	created := filepath.Join(base, "new")
+	srv, err := Listen(filepath.Join(created, "ctl.sock"), func(Command) {})
+	if err != nil {
+		t.Fatalf("Listen in a new directory: %v", err)
+	}
+	srv.Close()
+	if fi, err := os.Stat(created); err != nil || fi.Mode().Perm() != 0o700 {
+		t.Errorf("created directory: %v, err %v; want mode 0700", fi.Mode(), err)
+	}
+
+	open := filepath.Join(base, "open")
+	if err := os.Mkdir(open, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(open, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Listen(filepath.Join(open, "ctl.sock"), func(Command) {}); err == nil || !strings.Contains(err.Error(), "0700") {
+		t.Errorf("Listen in a 0755 directory: err = %v, want a mode error", err)
+	}
+
+	link := filepath.Join(base, "link")
+	if err := os.Symlink(created, link); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Listen(filepath.Join(link, "ctl.sock"), func(Command) {}); err == nil {
+		t.Error("Listen through a symlinked directory should fail")
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package ipc
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fileOwner returns the uid that owns fi.
+func fileOwner(fi os.FileInfo) (int, error) {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, fmt.Errorf("cannot read the owner of %s", fi.Name())
+	}
+	return int(st.Uid), nil
+}
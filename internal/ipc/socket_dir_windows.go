@@ -0,0 +1,11 @@
+//go:build windows
+
+package ipc
+
+import "os"
+
+// fileOwner returns the current uid: Windows has no uid owner to compare, and its directory ACLs
+// are not inspected.
+func fileOwner(os.FileInfo) (int, error) {
+	return os.Getuid(), nil
+}
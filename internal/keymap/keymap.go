@@ -0,0 +1,378 @@
+// Package keymap names the TUI's keyboard actions ("commit.squash", "tab.graph", "pr.merge", …)
+// and maps them to keys. Tabs keep matching their built-in keys; the main model translates a
+// rebound key back to its action's built-in key before the tab sees it, and the help view and
+// action bars label actions with their active key.
+//
+// Bindings come from ~/.config/jj-tui/keymap.json and the "keybindings" object in config.json
+// (which wins), both mapping an action name to a key in Bubble Tea's notation ("Q", "ctrl+e",
+// "space"). Overrides that collide with another binding in the same scope are dropped, so the
+// active keymap never has two actions on one key.
+package keymap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/playback"
+)
+
+// Scope is where an action's key is live. Global keys work on every tab; the others only on
+// their tab (the graph tab has one scope per pane). A tab's own key shadows a global one.
+type Scope string
+
+const (
+	ScopeGlobal     Scope = "global"
+	ScopeGraph      Scope = "graph"
+	ScopeFiles      Scope = "files"
+	ScopePRs        Scope = "prs"
+	ScopeTickets    Scope = "tickets"
+	ScopeBranches   Scope = "branches"
+	ScopeWorkspaces Scope = "workspaces"
+)
+
+// Action is one rebindable command. Key is its built-in key, the one the tab matches.
+type Action struct {
+	Name        string
+	Scope       Scope
+	Key         string
+	Description string
+}
+
+// Actions lists every rebindable action, grouped by scope.
+var Actions = []Action{
+	{"app.quit", ScopeGlobal, "ctrl+q", "Quit"},
+	{"app.refresh", ScopeGlobal, "ctrl+r", "Refresh"},
+	{"app.undo", ScopeGlobal, "ctrl+z", "Undo last jj operation"},
+	{"app.redo", ScopeGlobal, "ctrl+y", "Redo the last undone jj operation"},
+	{"app.messages", ScopeGlobal, "ctrl+l", "Show the status message log"},
+	{"app.preview_commands", ScopeGlobal, "ctrl+e", "Toggle previewing jj commands before they run"},
+	{"app.update_stale", ScopeGlobal, "ctrl+w", "Update a stale working copy (jj workspace update-stale)"},
+	{"app.switch_repo", ScopeGlobal, "ctrl+o", "Switch to another repository"},
+	{"tab.graph", ScopeGlobal, "g", "Go to commit graph"},
+	{"tab.prs", ScopeGlobal, "p", "Go to pull requests"},
+	{"tab.tickets", ScopeGlobal, "t", "Go to Tickets"},
+	{"tab.branches", ScopeGlobal, "b", "Go to Branches"},
+	{"tab.workspaces", ScopeGlobal, "w", "Go to Workspaces"},
+	{"tab.settings", ScopeGlobal, ",", "Open settings"},
+	{"tab.help", ScopeGlobal, "h", "Show help"},
+
+	{"commit.new", ScopeGraph, "n", "Create new commit from selected"},
+	{"commit.edit", ScopeGraph, "e", "Edit selected commit (jj edit)"},
+	{"commit.describe", ScopeGraph, "d", "Edit description"},
+	{"commit.squash", ScopeGraph, "s", "Squash into parent"},
+	{"commit.abandon", ScopeGraph, "a", "Abandon commit"},
+	{"commit.trash", ScopeGraph, "U", "Restore a commit abandoned this session"},
+	{"commit.bookmark", ScopeGraph, "m", "Create/move bookmark on commit"},
+	{"commit.delete_bookmark", ScopeGraph, "x", "Delete bookmark from commit"},
+	{"commit.rebase", ScopeGraph, "r", "Rebase onto a destination"},
+	{"commit.duplicate", ScopeGraph, "y", "Duplicate onto a destination"},
+	{"commit.backout", ScopeGraph, "R", "Back out (jj revert)"},
+	{"commit.move_work", ScopeGraph, "W", "Move my uncommitted work onto the selected commit"},
+	{"commit.merge", ScopeGraph, "M", "Merge from / merge marked commits"},
+	{"commit.insert_before", ScopeGraph, "I", "Insert empty commit before"},
+	{"commit.insert_after", ScopeGraph, "N", "Insert empty commit after"},
+	{"commit.parallelize", ScopeGraph, "P", "Parallelize marked commits"},
+	{"commit.absorb", ScopeGraph, "i", "Preview and run jj absorb"},
+	{"commit.create_pr", ScopeGraph, "c", "Create new PR from commit chain"},
+	{"commit.update_pr", ScopeGraph, "u", "Update existing PR with new commits"},
+	{"commit.resolve_bookmark", ScopeGraph, "C", "Resolve diverged bookmark"},
+	{"commit.stack_on_origin", ScopeGraph, "f", "Stack on bookmark@origin"},
+	{"commit.evolog_split", ScopeGraph, "z", "Split by evolog"},
+	{"commit.mark", ScopeGraph, " ", "Mark/unmark commit for bulk actions"},
+	{"commit.search", ScopeGraph, "/", "Search the graph"},
+	{"commit.date_filter", ScopeGraph, "D", "Date filter"},
+	{"commit.author_mode", ScopeGraph, "A", "Cycle author mode"},
+	{"commit.wrap_subjects", ScopeGraph, "E", "Wrap long subjects onto a second line"},
+	{"commit.stack_files", ScopeGraph, "S", "Stack files"},
+	{"commit.aliases", ScopeGraph, ":", "jj aliases"},
+	{"commit.bulk_describe", ScopeGraph, "B", "Bulk describe marked commits"},
+	{"commit.hunk_split", ScopeGraph, "H", "Split hunks"},
+	{"commit.select_lines", ScopeGraph, "V", "Select graph lines to copy"},
+	{"commit.browse_files", ScopeGraph, "T", "Browse every file in the commit"},
+
+	{"file.diff", ScopeFiles, "o", "View diff of the selected file"},
+	{"file.open_editor", ScopeFiles, "O", "Open the file in the external editor"},
+	{"file.history", ScopeFiles, "L", "History of the selected file"},
+	{"file.move_to_parent", ScopeFiles, "[", "Move the file's changes to the parent"},
+	{"file.move_to_child", ScopeFiles, "]", "Move the file's changes to the child"},
+	{"file.revert", ScopeFiles, "v", "Revert the file's changes"},
+	{"file.absorb", ScopeFiles, "i", "Absorb the file into its ancestors"},
+	{"file.status_filter", ScopeFiles, "f", "Cycle added / modified / deleted only"},
+	{"file.filter", ScopeFiles, "/", "Filter files by path glob"},
+
+	{"pr.open", ScopePRs, "o", "Open PR in browser"},
+	{"pr.read", ScopePRs, "v", "Read the PR body in the pager"},
+	{"pr.details", ScopePRs, "d", "PR details"},
+	{"pr.diff", ScopePRs, "f", "PR diff"},
+	{"pr.review", ScopePRs, "r", "Review the PR"},
+	{"pr.comments", ScopePRs, "R", "Review comments"},
+	{"pr.merge", ScopePRs, "M", "Merge the PR"},
+	{"pr.close", ScopePRs, "X", "Close the PR"},
+	{"pr.deployments", ScopePRs, "D", "Load deployment status"},
+
+	{"ticket.open", ScopeTickets, "o", "Open ticket in browser"},
+	{"ticket.read", ScopeTickets, "v", "Read the description in the pager"},
+	{"ticket.details", ScopeTickets, "d", "Ticket details"},
+	{"ticket.new", ScopeTickets, "n", "Create a ticket"},
+	{"ticket.status", ScopeTickets, "c", "Change ticket status"},
+
+	{"branch.track", ScopeBranches, "T", "Track remote branch"},
+	{"branch.track_by_name", ScopeBranches, "t", "Pull & track remote branch by name"},
+	{"branch.untrack", ScopeBranches, "U", "Untrack remote branch"},
+	{"branch.restore", ScopeBranches, "L", "Restore deleted local branch"},
+	{"branch.delete", ScopeBranches, "x", "Delete local bookmark"},
+	{"branch.push", ScopeBranches, "P", "Push local branch to remote"},
+	{"branch.fetch", ScopeBranches, "F", "Fetch from all remotes"},
+	{"branch.sync_fork", ScopeBranches, "S", "Sync fork trunk with upstream"},
+	{"branch.resolve", ScopeBranches, "c", "Resolve conflicted bookmark"},
+	{"branch.review_packet", ScopeBranches, "E", "Export a review packet"},
+	{"branch.review_gist", ScopeBranches, "G", "Share a review packet as a gist"},
+
+	{"workspace.add", ScopeWorkspaces, "a", "Add a workspace"},
+	{"workspace.forget", ScopeWorkspaces, "x", "Forget the selected workspace"},
+	{"workspace.reload", ScopeWorkspaces, "r", "Reload the workspace list"},
+	{"workspace.sparse", ScopeWorkspaces, "s", "Sparse patterns of this workspace"},
+}
+
+// reserved keys move, confirm and cancel everywhere; they can't be bound to an action.
+var reserved = []string{"j", "k", "up", "down", "enter", "esc", "tab", "shift+tab", "ctrl+c"}
+
+var (
+	mu     sync.RWMutex
+	active = map[string]string{} // action name -> key, rebound actions only
+)
+
+// Lookup returns the action with the given name.
+func Lookup(name string) (Action, bool) {
+	i := slices.IndexFunc(Actions, func(a Action) bool { return a.Name == name })
+	if i < 0 {
+		return Action{}, false
+	}
+	return Actions[i], true
+}
+
+// Key returns the active key of the named action ("" for unknown names).
+func Key(name string) string {
+	mu.RLock()
+	key, ok := active[name]
+	mu.RUnlock()
+	if ok {
+		return key
+	}
+	a, _ := Lookup(name)
+	return a.Key
+}
+
+// Rebound reports whether the named action has a key other than its built-in one.
+func Rebound(name string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := active[name]
+	return ok
+}
+
+// Load activates the bindings from ~/.config/jj-tui/keymap.json, then overrides (config.json's
+// "keybindings"). Unknown actions, unparsable keys, reserved keys and conflicting overrides are
+// left at their built-in key and reported in the returned error; the rest still apply.
+func Load(overrides map[string]string) error {
+	bindings := userKeymap()
+	for name, key := range overrides {
+		bindings[name] = key
+	}
+	return Set(bindings)
+}
+
+// Set replaces the active bindings with the given action -> key map (see Load).
+func Set(bindings map[string]string) error {
+	var errs []error
+	next := map[string]string{}
+	for _, name := range sortedKeys(bindings) {
+		a, ok := Lookup(name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown action %q", name))
+			continue
+		}
+		key, err := normalize(bindings[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if key != a.Key {
+			next[name] = key
+		}
+	}
+	errs = append(errs, dropConflicts(next)...)
+	mu.Lock()
+	active = next
+	mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// dropConflicts removes rebound actions whose key is also bound to another action they can
+// meet (same scope, or either is global), until no conflict is left. Built-in keys never
+// conflict with each other, so this ends with at worst the built-in keymap.
+func dropConflicts(rebound map[string]string) []error {
+	var errs []error
+	for {
+		var drop []string
+		conflicts := map[string]bool{}
+		for _, a := range Actions {
+			for _, b := range Actions {
+				if a.Name >= b.Name || !overlaps(a.Scope, b.Scope) {
+					continue
+				}
+				_, ra := rebound[a.Name]
+				_, rb := rebound[b.Name]
+				if !ra && !rb || keyIn(rebound, a) != keyIn(rebound, b) {
+					continue
+				}
+				errs = append(errs, fmt.Errorf("%s and %s are both bound to %q", a.Name, b.Name, Label(keyIn(rebound, a))))
+				for _, n := range []string{a.Name, b.Name} {
+					if _, ok := rebound[n]; ok && !conflicts[n] {
+						conflicts[n] = true
+						drop = append(drop, n)
+					}
+				}
+			}
+		}
+		if len(drop) == 0 {
+			return errs
+		}
+		for _, n := range drop {
+			delete(rebound, n)
+		}
+	}
+}
+
+func overlaps(a, b Scope) bool {
+	return a == b || a == ScopeGlobal || b == ScopeGlobal
+}
+
+func keyIn(rebound map[string]string, a Action) string {
+	if key, ok := rebound[a.Name]; ok {
+		return key
+	}
+	return a.Key
+}
+
+// normalize turns a configured key into the form tea.KeyMsg.String() reports ("space" -> " ").
+func normalize(key string) (string, error) {
+	if strings.HasPrefix(key, "type ") {
+		return "", fmt.Errorf("%q is not a single key", key)
+	}
+	msg, err := playback.ParseKey(key)
+	if err != nil {
+		return "", err
+	}
+	s := msg.String()
+	if slices.Contains(reserved, s) {
+		return "", fmt.Errorf("%q is reserved for navigation", key)
+	}
+	return s, nil
+}
+
+// Translate maps a key pressed in scope (plus global keys) to the built-in key the tabs match.
+// A key bound to an action becomes that action's built-in key; the built-in key of an action
+// that was moved elsewhere is dropped (ok is false) so it doesn't still trigger the action.
+// Other keys pass through unchanged.
+func Translate(msg tea.KeyMsg, scope Scope) (tea.KeyMsg, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if len(active) == 0 {
+		return msg, true
+	}
+	pressed := msg.String()
+	var moved bool
+	for _, s := range []Scope{scope, ScopeGlobal} {
+		for _, a := range Actions {
+			if a.Scope != s {
+				continue
+			}
+			key, rebound := active[a.Name]
+			if !rebound {
+				key = a.Key
+			}
+			if key == pressed {
+				if !rebound {
+					return msg, true
+				}
+				builtin, err := playback.ParseKey(a.Key)
+				if err != nil {
+					return msg, true
+				}
+				return builtin, true
+			}
+			if rebound && a.Key == pressed {
+				moved = true
+			}
+		}
+	}
+	return msg, !moved
+}
+
+// Label is how the help view and action bars show a key ("ctrl+r" -> "^r", " " -> "Space").
+func Label(key string) string {
+	switch {
+	case key == " ":
+		return "Space"
+	case strings.HasPrefix(key, "ctrl+"):
+		return "^" + strings.TrimPrefix(key, "ctrl+")
+	}
+	return key
+}
+
+// Help returns label, the help view's key column for the named actions, unless one of them is
+// rebound; then it lists their active keys ("s / I / N / P").
+func Help(label string, names ...string) string {
+	if !slices.ContainsFunc(names, Rebound) {
+		return label
+	}
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = Label(Key(name))
+	}
+	return strings.Join(keys, " / ")
+}
+
+// Button swaps the "(key)" at the end of an action bar label for the named action's active key.
+func Button(label, name string) string {
+	if !Rebound(name) {
+		return label
+	}
+	i := strings.LastIndex(label, "(")
+	if i < 0 || !strings.HasSuffix(label, ")") {
+		return label
+	}
+	return label[:i] + "(" + Label(Key(name)) + ")"
+}
+
+// userKeymap reads ~/.config/jj-tui/keymap.json; a missing or unreadable file means no bindings.
+func userKeymap() map[string]string {
+	bindings := map[string]string{}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return bindings
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".config", "jj-tui", "keymap.json"))
+	if err != nil {
+		return bindings
+	}
+	_ = json.Unmarshal(data, &bindings)
+	return bindings
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,100 @@
+package keymap
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// Built-in keys must not conflict, or dropConflicts could not fall back to them. (Branches' t
+// shadows the global t on purpose: it is only reachable there.)
+func TestBuiltinKeymapHasNoConflicts(t *testing.T) {
+	seen := map[string]bool{}
+	for _, a := range Actions {
+		if seen[a.Name] {
+			t.Errorf("duplicate action %s", a.Name)
+		}
+		seen[a.Name] = true
+		for _, b := range Actions {
+			if a.Name < b.Name && a.Key == b.Key && overlaps(a.Scope, b.Scope) && !(a.Scope != b.Scope && a.Key == "t") {
+				t.Errorf("%s and %s share %q", a.Name, b.Name, a.Key)
+			}
+		}
+	}
+}
+
+func TestSetAndTranslate(t *testing.T) {
+	t.Cleanup(func() { _ = Set(nil) })
+	if err := Set(map[string]string{"commit.squash": "Q", "commit.abandon": "s", "tab.graph": "ctrl+g"}); err != nil {
+		t.Fatal(err)
+	}
+	if Key("commit.squash") != "Q" || !Rebound("commit.squash") || Rebound("commit.new") {
+		t.Fatalf("squash = %q", Key("commit.squash"))
+	}
+	for _, tc := range []struct {
+		key   tea.KeyMsg
+		scope Scope
+		want  string
+		ok    bool
+	}{
+		{runes("Q"), ScopeGraph, "s", true}, // squash
+		{runes("s"), ScopeGraph, "a", true}, // abandon took squash's key
+		{runes("a"), ScopeGraph, "", false}, // abandon's old key does nothing
+		{runes("n"), ScopeGraph, "n", true}, // untouched
+		{tea.KeyMsg{Type: tea.KeyCtrlG}, ScopePRs, "g", true},
+		{runes("g"), ScopePRs, "", false},
+		{runes("Q"), ScopePRs, "Q", true}, // graph bindings don't apply on other tabs
+	} {
+		got, ok := Translate(tc.key, tc.scope)
+		if ok != tc.ok || ok && got.String() != tc.want {
+			t.Errorf("Translate(%q, %s) = %q, %v; want %q, %v", tc.key.String(), tc.scope, got.String(), ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestSetRejectsConflictsAndBadKeys(t *testing.T) {
+	t.Cleanup(func() { _ = Set(nil) })
+	err := Set(map[string]string{
+		"commit.squash": "n",     // commit.new keeps n
+		"tab.prs":       "D",     // global vs the graph's date filter
+		"pr.merge":      "enter", // reserved
+		"pr.bogus":      "z",
+		"pr.close":      "space",
+		"branch.push":   "type hi",
+	})
+	if err == nil {
+		t.Fatal("want errors")
+	}
+	for _, want := range []string{`commit.new and commit.squash are both bound to "n"`, `"D"`, "reserved", `unknown action "pr.bogus"`, "not a single key"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q lacks %q", err, want)
+		}
+	}
+	for _, name := range []string{"commit.squash", "tab.prs", "pr.merge", "branch.push"} {
+		if Rebound(name) {
+			t.Errorf("%s should keep its built-in key", name)
+		}
+	}
+	if Key("pr.close") != " " {
+		t.Errorf("pr.close = %q", Key("pr.close"))
+	}
+}
+
+func TestLabels(t *testing.T) {
+	t.Cleanup(func() { _ = Set(nil) })
+	if Help("s / I", "commit.squash", "commit.insert_before") != "s / I" || Button("Squash (s)", "commit.squash") != "Squash (s)" {
+		t.Fatal("built-in labels should be kept")
+	}
+	_ = Set(map[string]string{"commit.squash": "ctrl+s"})
+	if got := Help("s / I", "commit.squash", "commit.insert_before"); got != "^s / I" {
+		t.Errorf("Help = %q", got)
+	}
+	if got := Button("Squash (s)", "commit.squash"); got != "Squash (^s)" {
+		t.Errorf("Button = %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/go-github/v66/github"
@@ -281,6 +282,7 @@ func DemoPullRequests() []internal.GitHubPR {
 			State:        "open",
 			BaseBranch:   "main",
 			HeadBranch:   "vhs/feature",
+			Author:       "demo-user",
 			CommitIDs:    []string{},
 			CheckStatus:  internal.CheckStatusPending,
 			ReviewStatus: internal.ReviewStatusNone,
@@ -288,11 +290,12 @@ func DemoPullRequests() []internal.GitHubPR {
 		{
 			Number:       142,
 			Title:        "Add dark mode support to dashboard",
-			Body:         "Implements dark mode theme with system preference detection.\n\nCloses PROJ-142",
+			Body:         "Implements dark mode theme with system preference detection.\n\n- [x] Theme tokens\n- [x] System preference detection\n- [ ] Screenshots in the docs\n\nCloses PROJ-142",
 			URL:          "https://github.com/demo-org/awesome-project/pull/142",
 			State:        "open",
 			BaseBranch:   "main",
 			HeadBranch:   "feature/dark-mode",
+			Author:       "alice-chen",
 			CommitIDs:    []string{"abc123", "def456"},
 			CheckStatus:  internal.CheckStatusSuccess,
 			ReviewStatus: internal.ReviewStatusApproved,
@@ -305,6 +308,7 @@ func DemoPullRequests() []internal.GitHubPR {
 			State:        "open",
 			BaseBranch:   "main",
 			HeadBranch:   "fix/pagination",
+			Author:       "bob-martinez",
 			CommitIDs:    []string{"ghi789"},
 			CheckStatus:  internal.CheckStatusSuccess,
 			ReviewStatus: internal.ReviewStatusChangesRequested,
@@ -317,6 +321,7 @@ func DemoPullRequests() []internal.GitHubPR {
 			State:        "open",
 			BaseBranch:   "main",
 			HeadBranch:   "feature/settings",
+			Author:       "demo-user",
 			CommitIDs:    []string{"jkl012"},
 			CheckStatus:  internal.CheckStatusPending,
 			ReviewStatus: internal.ReviewStatusPending,
@@ -330,6 +335,7 @@ func DemoPullRequests() []internal.GitHubPR {
 			State:        "merged",
 			BaseBranch:   "main",
 			HeadBranch:   "feature/csv-export",
+			Author:       "carol-white",
 			CommitIDs:    []string{"mno345"},
 			CheckStatus:  internal.CheckStatusSuccess,
 			ReviewStatus: internal.ReviewStatusApproved,
@@ -342,9 +348,85 @@ func DemoPullRequests() []internal.GitHubPR {
 			State:        "closed",
 			BaseBranch:   "main",
 			HeadBranch:   "fix/auth-bug",
+			Author:       "dave-kim",
 			CommitIDs:    []string{"pqr678"},
 			CheckStatus:  internal.CheckStatusFailure,
 			ReviewStatus: internal.ReviewStatusNone,
 		},
 	}
 }
+
+// DemoDeployments returns demo deployment statuses for ref: trunk is live on staging and production,
+// feature branches have a preview environment still rolling out.
+func DemoDeployments(ref string) []internal.Deployment {
+	if ref == "main" {
+		return []internal.Deployment{
+			{Environment: "production", State: "success", URL: "https://demo-org.example.com", Ref: ref},
+			{Environment: "staging", State: "success", URL: "https://staging.demo-org.example.com", Ref: ref},
+		}
+	}
+	return []internal.Deployment{
+		{Environment: "preview", State: "in_progress", Ref: ref},
+	}
+}
+
+// DemoReviewComments returns demo review threads: a couple of line comments to try the quick fix
+// flow on.
+func DemoReviewComments() []internal.ReviewComment {
+	return []internal.ReviewComment{
+		{ID: 1, Path: "README.md", Line: 3, Author: "alice-chen", Body: "Can we mention the new flag here?"},
+		{ID: 2, Path: "main.go", Line: 12, Author: "bob-smith", Body: "Nit: wrap this error with the file name."},
+	}
+}
+
+// DemoPRDetail returns demo checks, review threads, comments, and files for the PR detail view.
+func DemoPRDetail(prNumber int) *internal.PRDetail {
+	return &internal.PRDetail{
+		Number: prNumber,
+		Checks: []internal.CheckRun{
+			{Name: "build", Status: internal.CheckStatusSuccess, State: "success"},
+			{Name: "test (ubuntu-latest)", Status: internal.CheckStatusSuccess, State: "success"},
+			{Name: "test (windows-latest)", Status: internal.CheckStatusFailure, State: "failure"},
+			{Name: "lint", Status: internal.CheckStatusPending, State: "in_progress"},
+		},
+		Threads: []internal.ReviewThread{
+			{Path: "README.md", Line: 3, Author: "alice-chen", Body: "Can we mention the new flag here?", Replies: 1},
+			{Path: "main.go", Line: 12, Author: "bob-smith", Body: "Nit: wrap this error with the file name.", Resolved: true},
+		},
+		Comments: []internal.PRComment{
+			{Author: "alice-chen", Body: "Looks good overall, a couple of nits inline.", CreatedAt: time.Now().Add(-3 * time.Hour)},
+		},
+		Files: []internal.PRFile{
+			{Path: "README.md", Additions: 4, Deletions: 1, ChangeType: "modified"},
+			{Path: "main.go", Additions: 22, Deletions: 6, ChangeType: "modified"},
+			{Path: "flags.go", Additions: 40, ChangeType: "added"},
+		},
+	}
+}
+
+// DemoPRDiff returns a small git diff for the demo PR diff view.
+func DemoPRDiff(prNumber int) string {
+	return fmt.Sprintf(`diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1,4 +1,7 @@
+ # demo
+ 
+-Run the tool.
++Run the tool with --verbose for more output.
++
++See PR #%d for the flag.
++
+ ## Usage
+diff --git a/flags.go b/flags.go
+new file mode 100644
+--- /dev/null
++++ b/flags.go
+@@ -0,0 +1,5 @@
++package main
++
++import "flag"
++
++var verbose = flag.Bool("verbose", false, "print more output")
+`, prNumber)
+}
@@ -4,6 +4,8 @@ package mock
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/madicen/jj-tui/internal/tickets"
 )
@@ -130,6 +132,18 @@ func (s *TicketService) CanCreateTicket() bool {
 	return true
 }
 
+// CreateOptions offers the same choices as the real provider.
+func (s *TicketService) CreateOptions() tickets.CreateOptions {
+	switch s.provider {
+	case "codecks":
+		return tickets.CreateOptions{Priorities: []string{"Highest", "High", "Medium", "Low", "Lowest"}}
+	case "github_issues":
+		return tickets.CreateOptions{Types: []string{"Bug", "Feature", "Documentation"}, Priorities: []string{"High", "Medium", "Low"}}
+	default:
+		return tickets.CreateOptions{Types: []string{"Task", "Bug", "Story", "Epic"}, Priorities: []string{"Highest", "High", "Medium", "Low", "Lowest"}}
+	}
+}
+
 // CreateTicket adds a new demo ticket and returns it.
 func (s *TicketService) CreateTicket(ctx context.Context, input *tickets.CreateTicketInput) (*tickets.Ticket, error) {
 	if input == nil || input.Summary == "" {
@@ -155,11 +169,37 @@ func (s *TicketService) CreateTicket(ctx context.Context, input *tickets.CreateT
 		Description: input.Description,
 		Status:      "To Do",
 		Type:        "Task",
+		Priority:    input.Priority,
+	}
+	if input.Type != "" {
+		t.Type = input.Type
 	}
 	s.tickets = append([]tickets.Ticket{t}, s.tickets...)
 	return &t, nil
 }
 
+// GetTicketDetail returns a demo detail: the ticket's description, a demo assignee and a couple of
+// comments.
+func (s *TicketService) GetTicketDetail(ctx context.Context, key string) (*tickets.TicketDetail, error) {
+	for _, t := range s.tickets {
+		if t.Key != key && t.DisplayKey != key {
+			continue
+		}
+		created := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+		return &tickets.TicketDetail{
+			Key:                 t.Key,
+			DescriptionMarkdown: t.MarkdownDescription(),
+			Assignee:            "Demo User",
+			Labels:              []string{strings.ToLower(t.Type), "demo"},
+			Comments: []tickets.TicketComment{
+				{Author: "Alex Reviewer", Body: "Can we get this into the next release?", Created: created},
+				{Author: "Demo User", Body: "Working on it — a PR is up for review.", Created: created.Add(26 * time.Hour)},
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("ticket %s not found", key)
+}
+
 // jiraTickets returns demo Jira-style tickets
 func jiraTickets() []tickets.Ticket {
 	return []tickets.Ticket{
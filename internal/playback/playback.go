@@ -0,0 +1,161 @@
+// Package playback replays scripted key presses into the TUI and records a session's keys in the
+// same format, for VHS recordings and reproducible bug reports. A script has one key per line,
+// preceded by the delay since the previous one:
+//
+//	# open the PRs tab, then select the second PR
+//	1s    p
+//	300ms j
+//	300ms enter
+//	500ms type fix the typo
+//
+// Keys use Bubble Tea's names ("enter", "ctrl+r", "shift+tab", "alt+j", "space"); "type <text>"
+// types text as one input. Blank lines and lines starting with # are ignored.
+package playback
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Event is one scripted key press, sent Delay after the previous event (or playback start).
+type Event struct {
+	Delay time.Duration
+	Key   tea.KeyMsg
+}
+
+// keyTypes maps Bubble Tea key names ("enter", "ctrl+r") to their key types.
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for kt := tea.KeyType(-256); kt < 256; kt++ {
+		if name := kt.String(); name != "" && name != " " && kt != tea.KeyRunes {
+			types[name] = kt
+		}
+	}
+	types["space"] = tea.KeySpace
+	return types
+}()
+
+// Load reads a script file.
+func Load(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads a script. Errors name the offending line.
+func Parse(r io.Reader) ([]Event, error) {
+	var events []Event
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		delayText, keyText, _ := strings.Cut(line, " ")
+		delay, err := time.ParseDuration(delayText)
+		if err != nil || delay < 0 {
+			return nil, fmt.Errorf("line %d: %q is not a delay (e.g. 250ms, 1s)", n, delayText)
+		}
+		key, err := ParseKey(strings.TrimLeft(keyText, " \t"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		events = append(events, Event{Delay: delay, Key: key})
+	}
+	return events, sc.Err()
+}
+
+// ParseKey turns a key name ("j", "enter", "alt+ctrl+c", "space") or "type <text>" into a key message.
+func ParseKey(s string) (tea.KeyMsg, error) {
+	if text, ok := strings.CutPrefix(s, "type "); ok && text != "" {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)}, nil
+	}
+	if s == "" {
+		return tea.KeyMsg{}, fmt.Errorf("missing key")
+	}
+	alt := false
+	if rest, ok := strings.CutPrefix(s, "alt+"); ok && rest != "" {
+		alt, s = true, rest
+	}
+	if kt, ok := keyTypes[s]; ok {
+		return tea.KeyMsg{Type: kt, Alt: alt}, nil
+	}
+	if utf8.RuneCountInString(s) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s), Alt: alt}, nil
+	}
+	return tea.KeyMsg{}, fmt.Errorf("unknown key %q (use a key name like enter or ctrl+r, or type <text>)", s)
+}
+
+// FormatKey is ParseKey's inverse: the script form of a key message.
+func FormatKey(k tea.KeyMsg) string {
+	switch {
+	case k.Type == tea.KeySpace && !k.Alt:
+		return "space"
+	case k.Type == tea.KeySpace:
+		return "alt+space"
+	case k.Type == tea.KeyRunes && len(k.Runes) > 1 && !k.Alt:
+		return "type " + string(k.Runes)
+	case k.Type == tea.KeyRunes:
+		prefix := ""
+		if k.Alt {
+			prefix = "alt+"
+		}
+		return prefix + string(k.Runes)
+	}
+	return k.String()
+}
+
+// Play sends the events to send, each after its delay, until they run out or ctx is done.
+func Play(ctx context.Context, events []Event, send func(tea.Msg)) {
+	for _, e := range events {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.Delay):
+		}
+		send(e.Key)
+	}
+}
+
+// Recorder writes the key presses a program receives as a script (see Filter).
+type Recorder struct {
+	mu   sync.Mutex
+	w    io.Writer
+	last time.Time
+	now  func() time.Time
+}
+
+// NewRecorder returns a recorder writing to w. Delays count from now.
+func NewRecorder(w io.Writer) *Recorder {
+	r := &Recorder{w: w, now: time.Now}
+	r.last = r.now()
+	fmt.Fprintf(w, "# jj-tui input recording, %s\n", r.last.Format(time.RFC3339))
+	return r
+}
+
+// Filter is a tea.WithFilter function: it records each key message and passes every message on
+// unchanged. Lines are written as keys arrive so a crash still leaves the keys that led to it.
+func (r *Recorder) Filter(_ tea.Model, msg tea.Msg) tea.Msg {
+	k, ok := msg.(tea.KeyMsg)
+	if !ok || (k.Type == tea.KeyRunes && len(k.Runes) == 0) {
+		return msg
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	fmt.Fprintf(r.w, "%s %s\n", now.Sub(r.last).Round(time.Millisecond), FormatKey(k))
+	r.last = now
+	return msg
+}
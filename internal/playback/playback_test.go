@@ -0,0 +1,90 @@
+package playback
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(`
+# comment
+1s p
+250ms   ctrl+r
+0s space
+10ms alt+j
+10ms shift+tab
+5ms type fix the typo
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Event{
+		{time.Second, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")}},
+		{250 * time.Millisecond, tea.KeyMsg{Type: tea.KeyCtrlR}},
+		{0, tea.KeyMsg{Type: tea.KeySpace}},
+		{10 * time.Millisecond, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j"), Alt: true}},
+		{10 * time.Millisecond, tea.KeyMsg{Type: tea.KeyShiftTab}},
+		{5 * time.Millisecond, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("fix the typo")}},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v", events)
+	}
+	for i := range want {
+		if events[i].Delay != want[i].Delay || events[i].Key.String() != want[i].Key.String() || events[i].Key.Type != want[i].Key.Type {
+			t.Errorf("event %d = %+v, want %+v", i, events[i], want[i])
+		}
+	}
+
+	for _, bad := range []string{"soon j", "1s", "1s bogus", "-1s j"} {
+		if _, err := Parse(strings.NewReader("# ok\n" + bad)); err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+			t.Errorf("Parse(%q) error = %v", bad, err)
+		}
+	}
+}
+
+// What the recorder writes plays back as the same keys.
+func TestRecorderRoundTrip(t *testing.T) {
+	var out strings.Builder
+	clock := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	r := &Recorder{w: &out, last: clock, now: func() time.Time { return clock }}
+	keys := []tea.Msg{
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")},
+		tea.WindowSizeMsg{Width: 80},
+		tea.KeyMsg{Type: tea.KeyEnter},
+		tea.KeyMsg{Type: tea.KeySpace},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hi there")},
+		tea.KeyMsg{Type: tea.KeyCtrlC},
+	}
+	for _, k := range keys {
+		clock = clock.Add(120 * time.Millisecond)
+		if got := r.Filter(nil, k); got == nil {
+			t.Fatal("Filter must pass messages on")
+		}
+	}
+	want := "120ms j\n240ms enter\n120ms space\n120ms type hi there\n120ms ctrl+c\n"
+	if out.String() != want {
+		t.Fatalf("recorded:\n%s\nwant:\n%s", out.String(), want)
+	}
+	events, err := Parse(strings.NewReader(out.String()))
+	if err != nil || len(events) != 5 || events[3].Key.String() != "hi there" || events[4].Key.Type != tea.KeyCtrlC {
+		t.Fatalf("playback = %+v, %v", events, err)
+	}
+}
+
+func TestPlay(t *testing.T) {
+	var sent []string
+	Play(context.Background(), []Event{
+		{0, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}},
+		{time.Millisecond, tea.KeyMsg{Type: tea.KeyEnter}},
+	}, func(msg tea.Msg) { sent = append(sent, msg.(tea.KeyMsg).String()) })
+	if strings.Join(sent, ",") != "j,enter" {
+		t.Fatalf("sent = %v", sent)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	Play(ctx, []Event{{time.Hour, tea.KeyMsg{Type: tea.KeyEnter}}}, func(tea.Msg) { t.Fatal("cancelled playback sent a key") })
+}
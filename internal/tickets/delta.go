@@ -0,0 +1,101 @@
+package tickets
+
+import (
+	"sync"
+	"time"
+)
+
+// DeltaFullSyncInterval is how often a delta-polling provider fetches the whole assigned list
+// anyway. Deltas only see tickets that still match the query, so one reassigned to someone else
+// (or no longer matching a custom filter) only leaves the list on the next full fetch.
+const DeltaFullSyncInterval = 10 * time.Minute
+
+// DeltaOverlap is how far back each delta reaches before the previous one started, to allow for
+// clock skew between jj-tui and the provider. Tickets seen twice are merged, not duplicated.
+const DeltaOverlap = time.Minute
+
+// DeltaList is the assigned-ticket list a provider keeps between delta polls: it asks only for
+// tickets updated since the last change it saw and merges them in. The zero value is empty and
+// asks for a full fetch first.
+type DeltaList struct {
+	mu       sync.Mutex
+	tickets  []Ticket
+	since    time.Time // the next delta asks for tickets updated at or after this
+	fullSync time.Time // when tickets was last fetched whole; zero = never
+}
+
+// Since returns the time the next delta starts from, or full=true when the whole list should be
+// fetched instead (never fetched, or DeltaFullSyncInterval passed).
+func (d *DeltaList) Since(now time.Time) (since time.Time, full bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.fullSync.IsZero() || now.Sub(d.fullSync) >= DeltaFullSyncInterval {
+		return time.Time{}, true
+	}
+	return d.since, false
+}
+
+// Reset replaces the list with a full fetch started at start. changed is false when the list is
+// the same as before.
+func (d *DeltaList) Reset(list []Ticket, start time.Time) (changed bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	changed = d.fullSync.IsZero() || !sameTickets(d.tickets, list)
+	d.tickets = append([]Ticket(nil), list...)
+	d.since = start.Add(-DeltaOverlap)
+	d.fullSync = start
+	return changed
+}
+
+// Apply merges a delta fetched at start: updated tickets replace the ones with the same key (or
+// join the list) and move to the front, in the order given; removed keys leave the list. The
+// window only moves forward when the delta had something in it, so an unchanged provider keeps
+// getting the same request (which lets GitHub answer 304 Not Modified). changed is false when no
+// ticket was added, edited or removed, e.g. for tickets seen again in the overlap.
+func (d *DeltaList) Apply(updated []Ticket, removed []string, start time.Time) (list []Ticket, changed bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(updated) > 0 || len(removed) > 0 {
+		d.since = start.Add(-DeltaOverlap)
+	}
+	old := make(map[string]Ticket, len(d.tickets))
+	for _, t := range d.tickets {
+		old[t.Key] = t
+	}
+	drop := make(map[string]bool, len(updated)+len(removed))
+	for _, key := range removed {
+		drop[key] = true
+		if _, ok := old[key]; ok {
+			changed = true
+		}
+	}
+	merged := make([]Ticket, 0, len(d.tickets)+len(updated))
+	for _, t := range updated {
+		if !drop[t.Key] {
+			merged = append(merged, t)
+			drop[t.Key] = true
+			if prev, ok := old[t.Key]; !ok || prev != t {
+				changed = true
+			}
+		}
+	}
+	for _, t := range d.tickets {
+		if !drop[t.Key] {
+			merged = append(merged, t)
+		}
+	}
+	d.tickets = merged
+	return append([]Ticket(nil), merged...), changed
+}
+
+func sameTickets(a, b []Ticket) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
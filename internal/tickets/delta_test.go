@@ -0,0 +1,58 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+)
+
+func keys(list []Ticket) string {
+	s := ""
+	for _, t := range list {
+		s += t.Key + " "
+	}
+	return s
+}
+
+// A delta moves edited and new tickets to the front and drops removed ones; tickets seen again
+// unchanged (the overlap) are not a change, and the window only moves when something came back.
+func TestDeltaList(t *testing.T) {
+	var d DeltaList
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	if _, full := d.Since(start); !full {
+		t.Fatal("an empty list should ask for a full fetch")
+	}
+	if !d.Reset([]Ticket{{Key: "A"}, {Key: "B"}, {Key: "C"}}, start) {
+		t.Error("the first fetch is a change")
+	}
+	since, full := d.Since(start.Add(time.Minute))
+	if full || !since.Equal(start.Add(-DeltaOverlap)) {
+		t.Fatalf("since = %v, full = %v", since, full)
+	}
+
+	list, changed := d.Apply([]Ticket{{Key: "D"}, {Key: "B", Status: "Done"}}, []string{"C"}, start.Add(time.Minute))
+	if !changed || keys(list) != "D B A " || list[1].Status != "Done" {
+		t.Fatalf("delta = %v, %v", list, changed)
+	}
+	if _, changed = d.Apply([]Ticket{{Key: "D"}}, nil, start.Add(2*time.Minute)); changed {
+		t.Error("a ticket seen again unchanged is not a change")
+	}
+	if _, changed = d.Apply(nil, []string{"Z"}, start.Add(3*time.Minute)); changed {
+		t.Error("removing a ticket that isn't listed is not a change")
+	}
+	if since, _ = d.Since(start.Add(4 * time.Minute)); !since.Equal(start.Add(3*time.Minute - DeltaOverlap)) {
+		t.Errorf("since = %v, want the last non-empty delta's start minus the overlap", since)
+	}
+	if _, changed = d.Apply(nil, nil, start.Add(4*time.Minute)); changed {
+		t.Error("an empty delta is not a change")
+	}
+	if since, _ = d.Since(start.Add(5 * time.Minute)); !since.Equal(start.Add(3*time.Minute - DeltaOverlap)) {
+		t.Errorf("an empty delta moved the window to %v", since)
+	}
+
+	if _, full = d.Since(start.Add(DeltaFullSyncInterval)); !full {
+		t.Error("a full fetch is due after DeltaFullSyncInterval")
+	}
+	if d.Reset([]Ticket{{Key: "D"}, {Key: "B", Status: "Done"}, {Key: "A"}}, start.Add(DeltaFullSyncInterval)) {
+		t.Error("a full fetch that matches the list is not a change")
+	}
+}
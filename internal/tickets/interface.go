@@ -1,7 +1,11 @@
 // Package tickets provides a common interface for ticket/issue tracking services
 package tickets
 
-import "context"
+import (
+	"context"
+	"strings"
+	"time"
+)
 
 // Ticket represents a generic ticket from any provider
 type Ticket struct {
@@ -13,6 +17,55 @@ type Ticket struct {
 	Type        string
 	Description string
 	DeckID      string // Codecks: deck ID for URL construction
+	// DescriptionMarkdown is Description converted to GitHub markdown (Jira ADF, Codecks card
+	// content without its title line). Empty when the provider has nothing richer than Description.
+	DescriptionMarkdown string
+}
+
+// MarkdownDescription returns the ticket description as GitHub markdown, falling back to the
+// plain Description for providers that don't fill DescriptionMarkdown.
+func (t Ticket) MarkdownDescription() string {
+	if s := strings.TrimSpace(t.DescriptionMarkdown); s != "" {
+		return s
+	}
+	return strings.TrimSpace(t.Description)
+}
+
+// TicketComment is one comment on a ticket. Body is GitHub markdown.
+type TicketComment struct {
+	Author  string
+	Body    string
+	Created time.Time
+}
+
+// TicketDetail is what the ticket detail view shows beyond the list fields. It is fetched on
+// demand since it costs an extra request per ticket.
+type TicketDetail struct {
+	Key                 string
+	DescriptionMarkdown string // full description; empty keeps the list's
+	Assignee            string // display name; empty when unassigned
+	Labels              []string
+	Comments            []TicketComment // oldest first
+}
+
+// DetailService is implemented by providers that can fetch a ticket's detail (comments,
+// assignee, labels).
+type DetailService interface {
+	GetTicketDetail(ctx context.Context, key string) (*TicketDetail, error)
+}
+
+// ChangePoller is implemented by providers that can cheaply ask whether the assigned tickets
+// changed, so the Tickets list can follow changes made elsewhere (e.g. an issue closed on
+// GitHub). changed is false when nothing changed; tickets is then nil.
+type ChangePoller interface {
+	PollAssignedTickets(ctx context.Context) (tickets []Ticket, changed bool, err error)
+}
+
+// MergeCloser is implemented by providers that close a ticket once the pull request for its
+// branch merges, leaving a comment that links the PR. closed is false when the ticket was
+// already closed.
+type MergeCloser interface {
+	CloseForMergedPR(ctx context.Context, ticketKey string, prNumber int) (closed bool, err error)
 }
 
 // Transition represents a possible status transition for a ticket
@@ -26,6 +79,20 @@ type Transition struct {
 type CreateTicketInput struct {
 	Summary     string
 	Description string
+	Type        string // one of CreateOptions.Types; "" uses the provider default
+	Priority    string // one of CreateOptions.Priorities; "" uses the provider default
+}
+
+// CreateOptions lists the types and priorities a provider accepts for new tickets. An empty list
+// means the provider has no such field.
+type CreateOptions struct {
+	Types      []string
+	Priorities []string
+}
+
+// CreateOptionsService is implemented by providers whose new tickets take a type or priority.
+type CreateOptionsService interface {
+	CreateOptions() CreateOptions
 }
 
 // Service is the interface that all ticket providers must implement
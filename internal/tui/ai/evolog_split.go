@@ -12,10 +12,12 @@ import (
 	"github.com/madicen/jj-tui/internal/config"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/integrations/llm"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // EvologSplitSuggestMsg is sent when the LLM finishes suggesting an evolog split row.
 type EvologSplitSuggestMsg struct {
+	state.RepoResult
 	ReqID     int
 	NoSplit   bool
 	PickIndex int // evolog list row index (same as UI): 1 .. len-1 when !NoSplit
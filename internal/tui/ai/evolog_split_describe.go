@@ -12,6 +12,7 @@ import (
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/integrations/llm"
 	"github.com/madicen/jj-tui/internal/tui/aiprompts"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 const evologDescribeSplitMaxDiff = 60_000
@@ -41,6 +42,7 @@ Rules:
 
 // EvologDescribeSplitDoneMsg is sent after optional AI-generated descriptions for @- and/or @.
 type EvologDescribeSplitDoneMsg struct {
+	state.RepoResult
 	Repository *internal.Repository
 	OnlyChild  bool // true when @- was skipped (immutable parent)
 	Err        error
@@ -48,6 +50,7 @@ type EvologDescribeSplitDoneMsg struct {
 
 // EvologDescribeSplitPreviewMsg carries LLM-proposed descriptions before the user applies them.
 type EvologDescribeSplitPreviewMsg struct {
+	state.RepoResult
 	ParentDescription  string
 	ChildDescription   string
 	SkipParentDescribe bool // when true, @- is immutable; apply only runs jj describe on @
@@ -0,0 +1,249 @@
+// Package avatar draws small inline images (PR author avatars, ticket type icons) in detail views.
+// Terminals with the kitty graphics protocol or sixel get a real image two cells wide and one row
+// tall; everywhere else, and until an image has loaded, a colored initials badge of the same size
+// takes its place so layouts never shift.
+package avatar
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"image"
+	_ "image/gif"  // register decoders for avatar downloads
+	_ "image/jpeg" // register decoders for avatar downloads
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Protocol is the terminal graphics protocol used for inline images.
+type Protocol int
+
+const (
+	ProtocolNone  Protocol = iota // colored initials only
+	ProtocolKitty                 // kitty graphics protocol with Unicode placeholders (kitty, ghostty)
+	ProtocolSixel                 // DEC sixel (foot, WezTerm, mlterm, iTerm2, contour)
+)
+
+// Cols is the width in cells of every image and badge.
+const Cols = 2
+
+// LoadedMsg is sent when an avatar download finishes (successfully or not) so the view redraws.
+type LoadedMsg struct {
+	URL string
+}
+
+var (
+	mu       sync.Mutex
+	protocol Protocol
+	images   = map[string]image.Image{} // url -> decoded image; nil = failed, fall back to initials
+	pending  = map[string]bool{}
+	rendered = map[string]string{} // cache key -> escape sequence + placeholder cells
+	ids      = map[string]uint32{} // kitty image IDs by cache key
+	nextID   uint32
+
+	httpClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+// Configure sets the protocol from the inline_images config value: "off" (initials only), "auto"
+// (detect from the environment), "kitty", or "sixel".
+func Configure(mode string) {
+	p := ProtocolNone
+	switch mode {
+	case "auto":
+		p = DetectProtocol(os.Getenv)
+	case "kitty":
+		p = ProtocolKitty
+	case "sixel":
+		p = ProtocolSixel
+	}
+	mu.Lock()
+	protocol = p
+	clear(rendered)
+	mu.Unlock()
+}
+
+// Enabled reports whether a graphics protocol is active.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return protocol != ProtocolNone
+}
+
+// DetectProtocol guesses the graphics protocol from environment variables. Multiplexers (tmux,
+// screen, zellij) report None: they drop or mangle graphics sequences without passthrough.
+func DetectProtocol(getenv func(string) string) Protocol {
+	term := strings.ToLower(getenv("TERM"))
+	prog := strings.ToLower(getenv("TERM_PROGRAM"))
+	if getenv("TMUX") != "" || getenv("ZELLIJ") != "" || strings.HasPrefix(term, "screen") || strings.HasPrefix(term, "tmux") {
+		return ProtocolNone
+	}
+	switch {
+	case term == "xterm-kitty", getenv("KITTY_WINDOW_ID") != "", prog == "ghostty", term == "xterm-ghostty":
+		return ProtocolKitty
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"), strings.HasPrefix(term, "contour"),
+		prog == "wezterm", prog == "iterm.app":
+		return ProtocolSixel
+	}
+	return ProtocolNone
+}
+
+// FetchCmd downloads the given image URLs that aren't cached or in flight yet. It returns nil when
+// inline images are off or there's nothing to fetch.
+func FetchCmd(urls ...string) tea.Cmd {
+	mu.Lock()
+	defer mu.Unlock()
+	if protocol == ProtocolNone {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, u := range urls {
+		if u == "" || pending[u] {
+			continue
+		}
+		if _, ok := images[u]; ok {
+			continue
+		}
+		pending[u] = true
+		cmds = append(cmds, fetch(u))
+	}
+	return tea.Batch(cmds...)
+}
+
+func fetch(url string) tea.Cmd {
+	return func() tea.Msg {
+		img, _ := download(url)
+		mu.Lock()
+		images[url] = img
+		delete(pending, url)
+		mu.Unlock()
+		return LoadedMsg{URL: url}
+	}
+}
+
+func download(url string) (image.Image, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("avatar: %s: %s", url, resp.Status)
+	}
+	img, _, err := image.Decode(io.LimitReader(resp.Body, 1<<20))
+	return img, err
+}
+
+// Avatar renders the image downloaded from url (see FetchCmd), or name's initials badge when
+// inline images are off or the download hasn't finished or failed.
+func Avatar(url, name string) string {
+	mu.Lock()
+	p := protocol
+	img := images[url]
+	mu.Unlock()
+	if p == ProtocolNone || img == nil {
+		return Badge(Initials(name), colorFor(name))
+	}
+	return render(p, "url:"+url, img)
+}
+
+// TypeIcon renders a ticket type icon (bug, story, task, epic, ...), or the type's initials in
+// the type color when inline images are off.
+func TypeIcon(ticketType string) string {
+	color := typeColor(ticketType)
+	mu.Lock()
+	p := protocol
+	mu.Unlock()
+	if p == ProtocolNone {
+		return Badge(Initials(ticketType), color)
+	}
+	return render(p, "type:"+strings.ToLower(ticketType), typeIconImage(ticketType, color))
+}
+
+// render encodes img for p once per key; later calls reuse the cached sequence.
+func render(p Protocol, key string, img image.Image) string {
+	mu.Lock()
+	s, ok := rendered[key]
+	mu.Unlock()
+	if ok {
+		return s
+	}
+	switch p {
+	case ProtocolKitty:
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, scale(img, kittyPixels, kittyPixels)); err != nil {
+			return Badge("?", "#6B778C")
+		}
+		s = kittyImage(imageID(key), buf.Bytes())
+	case ProtocolSixel:
+		s = sixelImage(scale(img, sixelWidth, sixelHeight))
+	}
+	mu.Lock()
+	rendered[key] = s
+	mu.Unlock()
+	return s
+}
+
+// imageID returns a stable kitty image ID for key. IDs are sent as a 24-bit foreground color, so
+// they stay below 1<<24 and avoid 0.
+func imageID(key string) uint32 {
+	mu.Lock()
+	defer mu.Unlock()
+	if id, ok := ids[key]; ok {
+		return id
+	}
+	nextID = nextID%(1<<24-1) + 1
+	ids[key] = nextID
+	return nextID
+}
+
+// Initials returns up to two uppercase letters for name: the first letters of its first two words
+// ("jane-doe" -> "JD"), or the first two letters of a single word ("octocat" -> "OC").
+func Initials(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var out []rune
+	switch {
+	case len(words) == 0:
+		return "?"
+	case len(words) == 1:
+		out = []rune(words[0])
+		if len(out) > 2 {
+			out = out[:2]
+		}
+	default:
+		out = []rune{[]rune(words[0])[0], []rune(words[1])[0]}
+	}
+	return strings.ToUpper(string(out))
+}
+
+// Badge renders text (one or two letters) as a Cols-wide colored block.
+func Badge(text, background string) string {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color(background)).
+		Width(Cols).
+		MaxWidth(Cols).
+		Align(lipgloss.Center).
+		Render(text)
+}
+
+// badgeColors are readable backgrounds for white initials.
+var badgeColors = []string{"#C0392B", "#D35400", "#B7950B", "#1E8449", "#117A65", "#2471A3", "#6C3483", "#A93226"}
+
+// colorFor picks a stable badge color for name so the same author always gets the same color.
+func colorFor(name string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.ToLower(name)))
+	return badgeColors[h.Sum32()%uint32(len(badgeColors))]
+}
@@ -0,0 +1,93 @@
+package avatar
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestInitials(t *testing.T) {
+	tests := map[string]string{
+		"octocat":    "OC",
+		"jane-doe":   "JD",
+		"Sub-task":   "ST",
+		"x":          "X",
+		"":           "?",
+		"--":         "?",
+		"émile zola": "ÉZ",
+	}
+	for in, want := range tests {
+		if got := Initials(in); got != want {
+			t.Errorf("Initials(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDetectProtocol(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want Protocol
+	}{
+		{map[string]string{"TERM": "xterm-kitty"}, ProtocolKitty},
+		{map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "ghostty"}, ProtocolKitty},
+		{map[string]string{"TERM": "foot"}, ProtocolSixel},
+		{map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm"}, ProtocolSixel},
+		{map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux-1000/default,1,0"}, ProtocolNone},
+		{map[string]string{"TERM": "xterm-256color"}, ProtocolNone},
+	}
+	for _, tt := range tests {
+		if got := DetectProtocol(func(k string) string { return tt.env[k] }); got != tt.want {
+			t.Errorf("DetectProtocol(%v) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
+
+// Every rendering must take exactly Cols cells so details boxes line up with or without images.
+func TestRenderWidth(t *testing.T) {
+	defer Configure("off")
+	src := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	for i := range src.Pix {
+		src.Pix[i] = 0x80
+	}
+	images["https://example.com/a.png"] = src
+	defer delete(images, "https://example.com/a.png")
+
+	for _, mode := range []string{"off", "kitty", "sixel"} {
+		Configure(mode)
+		for name, s := range map[string]string{
+			"avatar":         Avatar("https://example.com/a.png", "octocat"),
+			"missing avatar": Avatar("https://example.com/missing.png", "octocat"),
+			"type icon":      TypeIcon("Bug"),
+		} {
+			if w := lipgloss.Width(s); w != Cols {
+				t.Errorf("%s %s: width %d, want %d (%q)", mode, name, w, Cols, s)
+			}
+		}
+	}
+
+	Configure("kitty")
+	if s := Avatar("https://example.com/a.png", "octocat"); !strings.Contains(s, "\x1b_Ga=T,U=1") {
+		t.Errorf("kitty avatar missing transmit: %q", s)
+	}
+	Configure("sixel")
+	if s := Avatar("https://example.com/a.png", "octocat"); !strings.Contains(s, "\x1bP0;1;0q") {
+		t.Errorf("sixel avatar missing DCS: %q", s)
+	}
+}
+
+func TestSixelImageSingleColor(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 6))
+	for y := range 6 {
+		for x := range 8 {
+			img.Set(x, y, color.RGBA{0xff, 0, 0, 0xff})
+		}
+	}
+	got := sixelImage(img)
+	// Pure red is palette entry 5*36 = 180; one full band of 8 columns is "!8~".
+	if !strings.Contains(got, "#180;2;100;0;0") || !strings.Contains(got, "#180!8~-") {
+		t.Errorf("sixelImage = %q", got)
+	}
+}
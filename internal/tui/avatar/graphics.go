@@ -0,0 +1,218 @@
+package avatar
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"slices"
+	"strings"
+)
+
+const (
+	kittyChunk  = 4096 // max base64 payload per kitty graphics escape
+	kittyPixels = 40   // avatars are downscaled to this square before sending to kitty
+	// Sixel has no way to size an image in cells, so assume a common 10x20px cell: Cols cells
+	// wide and three 6px sixel bands tall keeps the image inside one row.
+	sixelWidth  = 10 * Cols
+	sixelHeight = 18
+)
+
+// kittyPlaceholder is the Unicode placeholder character; each cell also carries a row and a
+// column diacritic (see kittyDiacritics) and the image ID as its foreground color.
+const kittyPlaceholder = "\U0010EEEE"
+
+// kittyDiacritics are the first entries of kitty's rowcolumn-diacritics table (index = row/col).
+var kittyDiacritics = []string{"\u0305", "\u030d", "\u030e", "\u0310"}
+
+// kittyImage transmits png with a virtual placement (U=1) and returns it followed by Cols
+// placeholder cells, so the image moves and disappears with the surrounding text when
+// Bubble Tea repaints. The transmit is repeated on every paint of the line; kitty replaces the
+// image with the same ID, which is cheap at avatar size.
+func kittyImage(id uint32, png []byte) string {
+	enc := base64.StdEncoding.EncodeToString(png)
+	var b strings.Builder
+	for i := 0; i < len(enc); i += kittyChunk {
+		end := min(i+kittyChunk, len(enc))
+		more := 0
+		if end < len(enc) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=T,U=1,f=100,q=2,i=%d,c=%d,r=1,m=%d;%s\x1b\\", id, Cols, more, enc[i:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, enc[i:end])
+		}
+	}
+	fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%dm", id>>16&0xff, id>>8&0xff, id&0xff)
+	for col := range Cols {
+		b.WriteString(kittyPlaceholder + kittyDiacritics[0] + kittyDiacritics[col])
+	}
+	b.WriteString("\x1b[39m")
+	return b.String()
+}
+
+// sixelImage returns Cols blank cells with img drawn over them. The cursor is saved and restored
+// around the sixel data so the terminal's post-image cursor movement can't shift the rest of the
+// line.
+func sixelImage(img image.Image) string {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	idx := make([]int, w*h)
+	var used []int
+	for y := range h {
+		for x := range w {
+			r, g, bl, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			if a < 0x8000 {
+				idx[y*w+x] = -1
+				continue
+			}
+			c := cubeLevel(r)*36 + cubeLevel(g)*6 + cubeLevel(bl)
+			idx[y*w+x] = c
+			if !slices.Contains(used, c) {
+				used = append(used, c)
+			}
+		}
+	}
+	slices.Sort(used)
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", Cols))
+	fmt.Fprintf(&b, "\x1b7\x1b[%dD", Cols)
+	// P2=1: pixels not set by any color stay transparent.
+	fmt.Fprintf(&b, "\x1bP0;1;0q\"1;1;%d;%d", w, h)
+	for _, c := range used {
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", c, c/36*20, c/6%6*20, c%6*20)
+	}
+	row := make([]byte, w)
+	for band := 0; band < h; band += 6 {
+		first := true
+		for _, c := range used {
+			set := false
+			for x := range w {
+				bits := 0
+				for dy := range 6 {
+					if y := band + dy; y < h && idx[y*w+x] == c {
+						bits |= 1 << dy
+					}
+				}
+				row[x] = byte(63 + bits)
+				set = set || bits != 0
+			}
+			if !set {
+				continue
+			}
+			if !first {
+				b.WriteByte('$')
+			}
+			first = false
+			fmt.Fprintf(&b, "#%d", c)
+			writeSixelRLE(&b, row)
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\\x1b8")
+	return b.String()
+}
+
+// cubeLevel maps a 16-bit color channel to a 0-5 level of the 6x6x6 sixel palette.
+func cubeLevel(v uint32) int {
+	return int((v>>8)*5+127) / 255
+}
+
+// writeSixelRLE writes a sixel row, collapsing runs of four or more with the "!n" repeat.
+func writeSixelRLE(b *strings.Builder, row []byte) {
+	for i := 0; i < len(row); {
+		j := i
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		if n := j - i; n > 3 {
+			fmt.Fprintf(b, "!%d%c", n, row[i])
+		} else {
+			b.Write(row[i:j])
+		}
+		i = j
+	}
+}
+
+// scale resizes src to w x h with nearest-neighbor sampling (good enough at avatar sizes).
+func scale(src image.Image, w, h int) image.Image {
+	b := src.Bounds()
+	if b.Dx() == w && b.Dy() == h {
+		return src
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			dst.Set(x, y, src.At(b.Min.X+x*b.Dx()/w, b.Min.Y+y*b.Dy()/h))
+		}
+	}
+	return dst
+}
+
+// typeColor returns the Jira-style color for a ticket type.
+func typeColor(ticketType string) string {
+	t := strings.ToLower(ticketType)
+	switch {
+	case strings.Contains(t, "bug"):
+		return "#E5493A"
+	case strings.Contains(t, "story"), strings.Contains(t, "feature"):
+		return "#63BA3C"
+	case strings.Contains(t, "epic"):
+		return "#904EE2"
+	case strings.Contains(t, "task"):
+		return "#4BADE8"
+	}
+	return "#6B778C"
+}
+
+// typeIconImage draws a 16x16 rounded square in hex with a white mark for the type: a dot for
+// bugs, a bookmark for stories, a diamond for epics, a square for tasks.
+func typeIconImage(ticketType, hex string) image.Image {
+	const size = 16
+	var bg color.RGBA
+	_, _ = fmt.Sscanf(hex, "#%02x%02x%02x", &bg.R, &bg.G, &bg.B)
+	bg.A = 0xff
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	t := strings.ToLower(ticketType)
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		for x := range size {
+			// Rounded corners: clear the three pixels nearest each corner.
+			cx, cy := min(x, size-1-x), min(y, size-1-y)
+			if cx+cy < 2 {
+				continue
+			}
+			c := bg
+			dx, dy := float64(x)-7.5, float64(y)-7.5
+			switch {
+			case strings.Contains(t, "bug"):
+				if dx*dx+dy*dy <= 16 {
+					c = white
+				}
+			case strings.Contains(t, "story"), strings.Contains(t, "feature"):
+				if x >= 5 && x <= 10 && y >= 3 && y <= 12 && !(y > 9 && abs(dx) < float64(y-9)) {
+					c = white
+				}
+			case strings.Contains(t, "epic"):
+				if abs(dx)+abs(dy) <= 5 {
+					c = white
+				}
+			case strings.Contains(t, "task"):
+				if x >= 5 && x <= 10 && y >= 5 && y <= 10 {
+					c = white
+				}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
@@ -10,6 +10,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // RemoteOp identifies which operation a RemoteOpResultMsg corresponds to. Main uses this to
@@ -34,6 +35,7 @@ const (
 // up, but pushing local bookmarks failed (e.g. network blip, auth issue). The user can press
 // the Push all bookmarks button to retry without losing the new repo.
 type RemoteOpResultMsg struct {
+	state.RepoResult
 	Op          RemoteOp
 	NewURL      string
 	PreviousURL string
@@ -49,6 +51,7 @@ type RemoteOpResultMsg struct {
 // from RemoteOpResultMsg so the two flows have independent status text and so the auto-push
 // after Create can be styled distinctly from a deliberate user-initiated push.
 type PushResultMsg struct {
+	state.RepoResult
 	// All records user intent: true => "Push all bookmarks" (we enumerated and pushed each via
 	// --bookmark <name>), false => "Push current bookmark" (default jj behavior, no flag).
 	All         bool
@@ -85,7 +88,7 @@ func ApplyOriginCmd(svc *jj.Service, url string) tea.Cmd {
 			}
 		}
 		// Best-effort: ignore errors so an unreachable URL still leaves origin configured.
-		_ = runJJ(ctx, svc, "git", "fetch")
+		fetchOrigin(ctx, svc)
 		return msg
 	}
 }
@@ -132,7 +135,7 @@ func CreateGhRepoCmd(svc *jj.Service, name string, private bool) tea.Cmd {
 		}
 		newURL, _ := readOriginURL(ctx, svc)
 		msg.NewURL = newURL
-		_ = runJJ(ctx, svc, "git", "fetch")
+		fetchOrigin(ctx, svc)
 
 		// Auto-push: only attempt when there's at least one local bookmark to push. Empty repos
 		// (no commits, no bookmarks) hit a clean "nothing to push" status without surfacing an
@@ -215,27 +218,25 @@ func PushBookmarksCmd(svc *jj.Service, all bool) tea.Cmd {
 // every bookmark-era jj accepts the singular `--bookmark` flag. Naming a bookmark explicitly also
 // replaces the deprecated `--allow-new` flag, which current jj removes. The helper is shared
 // between CreateGhRepoCmd's auto-push step and PushBookmarksCmd so both entry points produce
-// identical jj invocations.
+// identical jj invocations. Bookmarks the push created on origin are tracked afterwards.
 func pushBookmarks(ctx context.Context, svc *jj.Service, names []string) (string, error) {
 	if svc == nil {
 		return "", fmt.Errorf("jj service unavailable")
 	}
-	args := []string{"git", "push"}
+	var trimmed []string
 	for _, name := range names {
-		name = strings.TrimSpace(name)
-		if name == "" {
-			continue
+		if name = strings.TrimSpace(name); name != "" {
+			trimmed = append(trimmed, name)
 		}
-		args = append(args, "--bookmark", name)
-	}
-	cmd := exec.CommandContext(ctx, "jj", args...)
-	if svc.RepoPath != "" {
-		cmd.Dir = svc.RepoPath
 	}
-	out, err := cmd.CombinedOutput()
-	output := strings.TrimSpace(string(out))
+	out, err := svc.PushBookmarks(ctx, trimmed...)
+	output := strings.TrimSpace(out)
 	if err != nil {
-		return output, fmt.Errorf("jj %s: %s", strings.Join(args, " "), output)
+		args := []string{"git", "push"}
+		for _, name := range trimmed {
+			args = append(args, "--bookmark", name)
+		}
+		return output, fmt.Errorf("jj %s: %w", strings.Join(args, " "), err)
 	}
 	return output, nil
 }
@@ -336,6 +337,14 @@ func runJJRemote(ctx context.Context, svc *jj.Service, args ...string) error {
 	return runJJ(ctx, svc, full...)
 }
 
+// fetchOrigin fetches origin, ignoring failures, through the jj service so interactive_auth
+// applies.
+func fetchOrigin(ctx context.Context, svc *jj.Service) {
+	if svc != nil {
+		_ = svc.FetchFromRemote(ctx, "origin")
+	}
+}
+
 // runJJ executes a jj subcommand by name and surfaces stderr in the returned error so the user
 // sees the actual git-remote / fetch / network message rather than just `exit status 1`. The jj
 // service exposes runJJOutput as a method but it's package-private; we call into the public
@@ -11,8 +11,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/forge"
 	"github.com/madicen/jj-tui/internal/integrations/codecks"
 	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/gitlab"
 	"github.com/madicen/jj-tui/internal/integrations/jira"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/mock"
@@ -41,6 +43,14 @@ func InitializeServices(demoMode bool) tea.Cmd {
 		}
 
 		cfg, _ := config.Load()
+		if maxAge, maxEntries := cfg.CommandHistoryRetention(); !demoMode && maxAge > 0 {
+			// Best effort: a broken history file only costs earlier sessions' entries.
+			_ = jjSvc.EnableHistoryFile(jj.HistoryFilePath(jjSvc.RepoPath), maxAge, maxEntries)
+		}
+		if !demoMode && cfg.AuditLogEnabled() {
+			// Best effort: without a .jj/repo directory there is nowhere shared to write it.
+			_ = jjSvc.EnableAuditLog()
+		}
 		revset := ""
 		if cfg != nil {
 			revset = cfg.GraphRevset
@@ -53,6 +63,13 @@ func InitializeServices(demoMode bool) tea.Cmd {
 				revset = jj.ApplyMineFilterToRevset(revset)
 			}
 		}
+		jjSvc.GraphLimit = cfg.GraphPage()
+		jjSvc.LargeFileRules = LargeFileRules(cfg)
+		jjSvc.SecretScan = SecretScanRules(cfg)
+		jjSvc.ProtectedBookmarks = ProtectedBookmarks(cfg)
+		jjSvc.GitFallback = cfg.GitFallbackEnabled()
+		jjSvc.InteractiveAuth = cfg.InteractiveAuthEnabled()
+		jjSvc.SetPreviewCommands(cfg.PreviewsCommands())
 
 		// Run the two slow jj operations in parallel so we can show the UI as soon as both complete.
 		var repo *internal.Repository
@@ -74,6 +91,10 @@ func InitializeServices(demoMode bool) tea.Cmd {
 		if repoErr != nil {
 			return InitErrorMsg{Err: repoErr}
 		}
+		if !demoMode {
+			// Best effort: the welcome screen's recent list is a convenience.
+			_ = config.AddRecentRepo(jjSvc.RepoPath)
+		}
 
 		owner, repoName := "", ""
 		githubInfoFromURL := "no remote configured"
@@ -93,13 +114,15 @@ func InitializeServices(demoMode bool) tea.Cmd {
 			Owner:             owner,
 			RepoName:          repoName,
 			GitHubInfoFromURL: githubInfoFromURL,
+			RemoteURL:         remoteURL,
 		}
 	}
 }
 
 // LoadAuxServicesCmd returns a cmd that loads GitHub and ticket services (after RepoReadyMsg).
 // Run this after handling RepoReadyMsg so the graph is already visible; GitHub/ticket load in the background.
-func LoadAuxServicesCmd(demoMode bool, owner, repoName, githubInfoFromURL string) tea.Cmd {
+// When remoteURL isn't on GitHub but on GitLab, a GitLab service is loaded instead.
+func LoadAuxServicesCmd(demoMode bool, owner, repoName, remoteURL, githubInfoFromURL string) tea.Cmd {
 	return func() tea.Msg {
 		if demoMode {
 			cfg, _ := config.Load()
@@ -117,8 +140,8 @@ func LoadAuxServicesCmd(demoMode bool, owner, repoName, githubInfoFromURL string
 
 		var ghSvc *github.Service
 		githubInfo := githubInfoFromURL
+		cfg, _ := config.Load()
 		if owner != "" && repoName != "" {
-			cfg, _ := config.Load()
 			token, tokenSource := config.GitHubTokenForAPI(cfg)
 			if token != "" {
 				tokenPreview := token[:min(8, len(token))] + "..."
@@ -128,14 +151,28 @@ func LoadAuxServicesCmd(demoMode bool, owner, repoName, githubInfoFromURL string
 				githubInfo = fmt.Sprintf("repo=%s/%s (no token)", owner, repoName)
 			}
 		}
+		var glSvc *gitlab.Service
+		if owner == "" && remoteURL != "" && forge.Detect(remoteURL, gitLabURL(cfg)) == forge.KindGitLab {
+			glSvc, githubInfo = createGitLabService(cfg, remoteURL)
+		}
 
 		// Pre-resolve the GitHub repo's default branch (best effort; failure leaves DefaultBranch
 		// empty and the Create PR form falls back to "main"). Doing this here means the form
 		// open path stays free of network I/O — the user can pop the modal up instantly and
 		// see the right base branch by the time they're typing a title.
 		defaultBranch := ""
+		var perms *github.Permissions
 		if ghSvc != nil {
 			ctx, cancel := context.WithTimeout(context.Background(), defaultBranchLookupTimeout)
+			// When origin is a fork, PRs go to its parent unless github_pr_target is "origin".
+			// Fork detection runs first so the probe checks the repository PRs actually target.
+			if up, _ := ghSvc.DetectFork(ctx); up != nil {
+				ghSvc.SetPRTarget(cfg.GitHubPRsOnUpstream())
+				githubInfo += " upstream=" + up.FullName()
+			}
+			// The permission probe reads the same repo metadata, so it also warms the
+			// default-branch cache. A failed probe leaves perms nil, which allows everything.
+			perms, _ = ghSvc.ProbePermissions(ctx)
 			if branch, derr := ghSvc.GetDefaultBranch(ctx); derr == nil {
 				defaultBranch = branch
 			}
@@ -145,14 +182,41 @@ func LoadAuxServicesCmd(demoMode bool, owner, repoName, githubInfoFromURL string
 		ticketSvc, ticketErr := CreateTicketService(owner, repoName)
 		return AuxServicesReadyMsg{
 			GitHubService: ghSvc,
+			GitLabService: glSvc,
 			TicketService: ticketSvc,
 			TicketError:   ticketErr,
 			GitHubInfo:    githubInfo,
 			DefaultBranch: defaultBranch,
+			Permissions:   perms,
 		}
 	}
 }
 
+// createGitLabService connects to the GitLab project origin points at. It returns nil with an
+// explanation in info when the URL can't be parsed or no token is configured.
+func createGitLabService(cfg *config.Config, remoteURL string) (svc *gitlab.Service, info string) {
+	baseURL, project, err := gitlab.ParseGitLabURL(remoteURL, gitLabURL(cfg))
+	if err != nil {
+		return nil, fmt.Sprintf("remote=%s (unrecognized GitLab URL)", remoteURL)
+	}
+	token := config.GitLabTokenForAPI(cfg)
+	if token == "" {
+		return nil, fmt.Sprintf("gitlab=%s (no token; set GITLAB_TOKEN or gitlab_token)", project)
+	}
+	svc, err = gitlab.NewService(baseURL, project, token)
+	if err != nil {
+		return nil, fmt.Sprintf("gitlab=%s (%v)", project, err)
+	}
+	return svc, fmt.Sprintf("gitlab=%s token=%s...", project, token[:min(8, len(token))])
+}
+
+func gitLabURL(cfg *config.Config) string {
+	if cfg == nil {
+		return ""
+	}
+	return cfg.GitLabURL
+}
+
 // CreateTicketService creates the appropriate ticket service based on configuration.
 func CreateTicketService(owner, repo string) (tickets.Service, error) {
 	cfg, _ := config.Load()
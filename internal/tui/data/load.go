@@ -2,12 +2,39 @@ package data
 
 import (
 	"context"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 )
 
+// LargeFileRules builds the jj service's large-file warning rules from cfg (nil = defaults).
+func LargeFileRules(cfg *config.Config) jj.LargeFileRules {
+	patterns, useDefault := cfg.LargeFileWarnPatterns()
+	if useDefault {
+		patterns = jj.DefaultLargeFilePatterns
+	}
+	return jj.LargeFileRules{MaxBytes: cfg.LargeFileWarnBytes(), Patterns: patterns}
+}
+
+// SecretScanRules builds the jj service's pre-push secret scan settings from cfg (nil = off).
+func SecretScanRules(cfg *config.Config) jj.SecretScanRules {
+	if !cfg.SecretScanEnabled() {
+		return jj.SecretScanRules{}
+	}
+	return jj.SecretScanRules{Enabled: true, Patterns: cfg.SecretScanPatterns, Command: strings.TrimSpace(cfg.SecretScanCommand)}
+}
+
+// ProtectedBookmarks builds the jj service's protected-push rules from cfg (nil = defaults).
+func ProtectedBookmarks(cfg *config.Config) jj.ProtectedBookmarks {
+	if !cfg.ConfirmProtectedPush() {
+		return jj.ProtectedBookmarks{}
+	}
+	return jj.ProtectedBookmarks{Patterns: cfg.ProtectedBookmarkPatterns(), Trunk: true}
+}
+
 // LoadRepository loads or refreshes repository data. Returns a cmd that sends RepositoryLoadedMsg.
 // Uses config.GraphRevset when set; otherwise jj.DefaultGraphRevset (see jj.DefaultGraphRevset).
 // When config.GraphFilterToMine() is true (the default), the revset is wrapped via
@@ -29,19 +56,46 @@ func LoadRepository(jjService *jj.Service) tea.Cmd {
 			// from the Settings tab takes effect without restarting jj-tui.
 			jjService.BookmarkListPreferTracked = cfg.BranchesFilterToTrackedAndMine()
 		}
+		jjService.LargeFileRules = LargeFileRules(cfg)
+		jjService.SecretScan = SecretScanRules(cfg)
+		jjService.ProtectedBookmarks = ProtectedBookmarks(cfg)
+		jjService.GitFallback = cfg.GitFallbackEnabled()
+		jjService.InteractiveAuth = cfg.InteractiveAuthEnabled()
+		jjService.SetPreviewCommands(cfg.PreviewsCommands())
 		repo, err := jjService.GetRepository(context.Background(), revset)
 		if err != nil {
+			if lost := jjService.CheckRepo(); lost != nil {
+				return RepoLostMsg{Err: lost, RepoPath: jjService.RepoPath}
+			}
 			return InitErrorMsg{Err: err}
 		}
 		return RepositoryLoadedMsg{Repository: repo}
 	}
 }
 
+// RefreshCommitsCmd re-reads only the commits matching revset after an action that touched just
+// those (see jj.Service.RefreshCommits) and sends RepositoryLoadedMsg with repo patched. When the
+// graph can't be patched it does a full LoadRepository instead.
+func RefreshCommitsCmd(jjService *jj.Service, repo *internal.Repository, revset string) tea.Cmd {
+	if jjService == nil {
+		return nil
+	}
+	full := LoadRepository(jjService)
+	return func() tea.Msg {
+		refreshed, ok, err := jjService.RefreshCommits(context.Background(), repo, revset)
+		if err != nil || !ok {
+			return full()
+		}
+		return RepositoryLoadedMsg{Repository: refreshed}
+	}
+}
+
 // LoadRepositorySilent loads repository without surfacing errors (for background refresh).
 // revset is the graph revset to use (e.g. from app config); empty uses jj default.
 // Pass revset from app state to avoid reading config from disk every tick.
 // Always returns SilentRepositoryLoadedMsg so the UI can clear in-flight refresh state;
-// Repository is nil when GetRepository fails.
+// Repository is nil when GetRepository fails. When the failure is because the repository itself
+// is gone (see jj.Service.CheckRepo), RepoLostMsg is returned instead.
 func LoadRepositorySilent(jjService *jj.Service, revset string) tea.Cmd {
 	if jjService == nil {
 		return nil
@@ -50,8 +104,68 @@ func LoadRepositorySilent(jjService *jj.Service, revset string) tea.Cmd {
 		// Quiet refresh: same graph load as GetRepository but do not spam command history every tick.
 		repo, err := jjService.GetRepositoryQuiet(context.Background(), revset)
 		if err != nil {
+			if lost := jjService.CheckRepo(); lost != nil {
+				return RepoLostMsg{Err: lost, RepoPath: jjService.RepoPath}
+			}
 			return SilentRepositoryLoadedMsg{Repository: nil}
 		}
 		return SilentRepositoryLoadedMsg{Repository: repo}
 	}
 }
+
+// WatchRepoCmd starts watching jjService's repository for new operations and working-copy edits.
+// Setting up the watches walks the working copy, so it runs off the UI goroutine.
+func WatchRepoCmd(jjService *jj.Service) tea.Cmd {
+	if jjService == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		w, err := jjService.WatchRepo(jj.WatchDebounce)
+		return RepoWatchStartedMsg{RepoPath: jjService.RepoPath, Watcher: w, Err: err}
+	}
+}
+
+// NextRepoChangeCmd waits for w's next change. It returns no message once w is closed.
+func NextRepoChangeCmd(w *jj.RepoWatcher) tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		c, ok := <-w.Changes()
+		if !ok {
+			return nil
+		}
+		return RepoChangedMsg{Watcher: w, Change: c}
+	}
+}
+
+// CheckPendingChangesCmd looks for working-copy edits made since the last graph load without
+// snapshotting them. Errors count as "nothing pending".
+func CheckPendingChangesCmd(jjService *jj.Service) tea.Cmd {
+	if jjService == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		pending, _ := jjService.PendingChanges(context.Background())
+		return PendingChangesMsg{Pending: pending}
+	}
+}
+
+// ConflictSummaryCmd counts the conflicted files of commits that an operation left conflicted.
+// A count of -1 means it couldn't be read.
+func ConflictSummaryCmd(jjService *jj.Service, commits []internal.Commit) tea.Cmd {
+	if jjService == nil || len(commits) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		counts := make([]int, len(commits))
+		for i, c := range commits {
+			paths, err := jjService.ConflictedPaths(context.Background(), c.ChangeID)
+			counts[i] = len(paths)
+			if err != nil {
+				counts[i] = -1
+			}
+		}
+		return ConflictSummaryMsg{Commits: commits, FileCounts: counts}
+	}
+}
@@ -3,8 +3,10 @@ package data
 import (
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/gitlab"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // InitErrorMsg is sent when initialization fails (e.g. not a jj repo). Main converts to model error.
@@ -14,6 +16,7 @@ import (
 // error: the init screen is dismissed, services are reloaded, and the error modal surfaces the
 // follow-up failure so the user can address it without losing the (already-applied) jj init.
 type InitErrorMsg struct {
+	state.RepoResult
 	Err            error
 	NotJJRepo      bool
 	CurrentPath    string
@@ -23,6 +26,7 @@ type InitErrorMsg struct {
 // ServicesInitializedMsg is sent when jj, GitHub, and ticket services are initialized.
 // Deprecated: initialization is now two-phase (RepoReadyMsg then AuxServicesReadyMsg).
 type ServicesInitializedMsg struct {
+	state.RepoResult
 	JJService     *jj.Service
 	GitHubService *github.Service
 	TicketService tickets.Service
@@ -35,12 +39,14 @@ type ServicesInitializedMsg struct {
 // RepoReadyMsg is sent as soon as jj service and repository are ready so the UI can show the graph.
 // A follow-up LoadAuxServicesCmd continues loading GitHub and ticket services in the background.
 type RepoReadyMsg struct {
+	state.RepoResult
 	JJService         *jj.Service
 	Repository        *internal.Repository
 	DemoMode          bool
 	Owner             string // for GitHub/ticket; may be empty
 	RepoName          string
 	GitHubInfoFromURL string // e.g. "repo=owner/name (no token)" or "no remote configured"
+	RemoteURL         string // origin's URL, for detecting a GitLab remote
 }
 
 // AuxServicesReadyMsg is sent after GitHub and ticket services are ready (after RepoReadyMsg).
@@ -49,23 +55,70 @@ type RepoReadyMsg struct {
 // "trunk") when it could be resolved during this load. Empty means "couldn't resolve" — main
 // caches that as-is and falls back to "main" later when opening the Create PR form, matching
 // the legacy hardcoded behavior.
+//
+// Permissions is what the GitHub token can do in this repository (nil when not probed or the
+// probe failed; nil allows everything).
+//
+// GitLabService is set instead of GitHubService when origin is on GitLab.
 type AuxServicesReadyMsg struct {
+	state.RepoResult
 	GitHubService *github.Service
+	GitLabService *gitlab.Service
 	TicketService tickets.Service
 	TicketError   error
 	GitHubInfo    string
 	DefaultBranch string
+	Permissions   *github.Permissions
 }
 
 // RepositoryLoadedMsg is sent when repository data is loaded (refresh).
 type RepositoryLoadedMsg struct {
+	state.RepoResult
 	Repository *internal.Repository
 }
 
 // SilentRepositoryLoadedMsg is for background refresh (no status update).
 type SilentRepositoryLoadedMsg struct {
+	state.RepoResult
 	Repository *internal.Repository
 }
 
+// RepoWatchStartedMsg is sent when WatchRepoCmd finishes. Err is set when the repo can't be
+// watched; background refresh then falls back to polling.
+type RepoWatchStartedMsg struct {
+	RepoPath string
+	Watcher  *jj.RepoWatcher
+	Err      error
+}
+
+// RepoChangedMsg is a debounced change seen by Watcher (see NextRepoChangeCmd).
+type RepoChangedMsg struct {
+	Watcher *jj.RepoWatcher
+	Change  jj.RepoChange
+}
+
+// PendingChangesMsg reports files edited on disk that jj hasn't snapshotted yet.
+type PendingChangesMsg struct {
+	state.RepoResult
+	Pending jj.PendingChanges
+}
+
+// ConflictSummaryMsg lists the commits an operation left conflicted, with the number of
+// conflicted files in each (-1 = unknown).
+type ConflictSummaryMsg struct {
+	state.RepoResult
+	Commits    []internal.Commit
+	FileCounts []int
+}
+
+// RepoLostMsg is sent instead of a load result when the repository disappeared while jj-tui was
+// running (directory removed or moved, .jj deleted or damaged). Main drops the jj service and
+// re-runs InitializeServices from the current directory, which lands on the welcome screen or
+// reopens the repo at its new location.
+type RepoLostMsg struct {
+	Err      error
+	RepoPath string
+}
+
 // JJInitSuccessMsg is sent when jj git init succeeds.
 type JJInitSuccessMsg struct{}
@@ -239,10 +239,10 @@ func Render(zoneManager *zone.Manager, profiles []config.AIProfile, activeName s
 		BorderForeground(styles.ColorPrimary).
 		Padding(0, 1)
 
-	rowStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F8F8F2"))
+	rowStyle := lipgloss.NewStyle().Foreground(styles.ColorText)
 	dimStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted)
-	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F8F8F2")).Background(styles.ColorPrimary)
-	hoverDim := lipgloss.NewStyle().Foreground(lipgloss.Color("#CCCCCC")).Background(styles.ColorPrimary)
+	hoverStyle := lipgloss.NewStyle().Foreground(styles.ColorOnAccent).Background(styles.ColorPrimary)
+	hoverDim := lipgloss.NewStyle().Foreground(styles.ColorOnAccent).Background(styles.ColorPrimary)
 	activeMark := lipgloss.NewStyle().Foreground(styles.ColorSecondary)
 
 	header := lipgloss.NewStyle().
@@ -3,10 +3,12 @@ package model
 import (
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/forge"
 	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tickets"
 	"github.com/madicen/jj-tui/internal/tui/state"
+	bookmarktab "github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
 	helptab "github.com/madicen/jj-tui/internal/tui/tabs/help"
 	"github.com/madicen/jj-tui/internal/tui/util"
 )
@@ -104,6 +106,11 @@ func (m *Model) GetCreatePRBranch() string {
 	return m.graphTabModel.GetCreatePRBranch()
 }
 
+// GetGitHubPermissions returns what the GitHub token may do (nil = not probed; for tab context providers).
+func (m *Model) GetGitHubPermissions() *github.Permissions {
+	return m.appState.GitHubPermissions
+}
+
 // IsDemoMode returns whether the app is in demo mode (for tab context providers).
 func (m *Model) IsDemoMode() bool {
 	return m.appState.DemoMode
@@ -114,21 +121,26 @@ func (m *Model) GetConfig() *config.Config {
 	return m.appState.Config
 }
 
+// GetForge returns the service PRs are read from, GitHub or GitLab (for tab context providers).
+func (m *Model) GetForge() forge.Service {
+	return m.appState.Forge()
+}
+
 // GetGitHubService returns the GitHub service (for tab context providers).
 func (m *Model) GetGitHubService() *github.Service {
 	return m.appState.GitHubService
 }
 
+// GetDefaultBranch returns origin's default branch, or "" when not resolved yet (for tab context providers).
+func (m *Model) GetDefaultBranch() string {
+	return m.appState.DefaultBranch
+}
+
 // GetGitHubInfo returns the GitHub diagnostic info (for tab context providers).
 func (m *Model) GetGitHubInfo() string {
 	return m.appState.GithubInfo
 }
 
-// GetBranchLimit returns the configured branch limit (for Branches tab EnterTab).
-func (m *Model) GetBranchLimit() int {
-	return m.settingsTabModel.GetSettingsBranchLimit()
-}
-
 // GetTickets returns the tickets list (for tab context providers).
 func (m *Model) GetTickets() []tickets.Ticket {
 	return m.ticketsTabModel.GetTickets()
@@ -229,9 +241,9 @@ func (m *Model) GetPRFormTitle() string {
 	return m.prFormModal.GetTitle()
 }
 
-// SetJiraBookmarkTitlesForTest sets the bookmark modal's Jira title map (for testing PR default title).
-func (m *Model) SetJiraBookmarkTitlesForTest(titles map[string]string) {
-	m.bookmarkModal.SetJiraBookmarkTitles(titles)
+// SetTicketBookmarkRefsForTest sets the bookmark modal's ticket ref map (for testing PR default title).
+func (m *Model) SetTicketBookmarkRefsForTest(refs map[string]bookmarktab.TicketRef) {
+	m.bookmarkModal.SetTicketBookmarkRefs(refs)
 }
 
 // GetError returns the current error
@@ -254,6 +266,14 @@ func (m *Model) SetTicketList(list []tickets.Ticket) {
 	m.ticketsTabModel.UpdateTickets(list)
 }
 
+// SetGitHubPermissions records the probed token permissions and hands them to the graph and PRs
+// tabs, which disable the actions the token can't perform.
+func (m *Model) SetGitHubPermissions(p *github.Permissions) {
+	m.appState.GitHubPermissions = p
+	m.graphTabModel.SetGitHubPermissions(p)
+	m.prsTabModel.SetPermissions(p)
+}
+
 // SetGitHubService sets the GitHub service for testing and syncs to the PRs tab so it shows the PR list instead of "GitHub not connected".
 func (m *Model) SetGitHubService(svc *github.Service) {
 	m.appState.GitHubService = svc
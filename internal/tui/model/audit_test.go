@@ -0,0 +1,50 @@
+package model
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+func TestHelpAuditTab(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncase \"$*\" in\n'config get user.name') echo Ada ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(bin, "jj"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".jj", "repo", "op_heads", "heads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	svc := &jj.Service{RepoPath: repo}
+	if err := svc.EnableAuditLog(); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AbandonCommit(context.Background(), "abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = svc
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	if cmd == nil {
+		t.Fatal("opening the Audit sub-tab should load the log")
+	}
+	_, cmd = m.Update(cmd())
+	m.Update(cmd())
+	view := m.View()
+	for _, want := range []string{"Audit Log", "Ada", "jj abandon abc"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// authPromptDoneMsg is sent when a push or fetch run again in the terminal exits.
+type authPromptDoneMsg struct {
+	state.RepoResult
+	command string
+	err     error
+}
+
+// authPromptError returns the AuthPromptError err wraps, or nil. Every result message that can
+// carry a failed push or fetch checks it before reporting the failure.
+func authPromptError(err error) *jj.AuthPromptError {
+	var authErr *jj.AuthPromptError
+	if errors.As(err, &authErr) {
+		return authErr
+	}
+	return nil
+}
+
+// handleAuthPrompt suspends the TUI and runs the push or fetch behind authErr again with the
+// terminal attached, so the user can answer git's or ssh's prompt (interactive_auth).
+func (m *Model) handleAuthPrompt(authErr *jj.AuthPromptError) (tea.Model, tea.Cmd) {
+	svc := m.appState.JJService
+	m.appState.Loading = false
+	if svc == nil {
+		return m, nil
+	}
+	command := jj.CommandLine(authErr.Args)
+	m.appState.StatusMessage = "The remote needs credentials: answer the prompt for " + command
+	cmd, done := svc.InteractiveCommand(authErr.Args)
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return authPromptDoneMsg{command: command, err: done(err)}
+	})
+}
+
+// handleAuthPromptDone reloads the repository once the terminal run succeeded, or reports why it
+// failed. Whatever the original action was going to do after the push (e.g. open the PR) is left
+// for the user to run again.
+func (m *Model) handleAuthPromptDone(msg authPromptDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, func() tea.Msg { return errorMsg{Err: msg.err} }
+	}
+	cmd := m.refreshRepository()
+	m.appState.StatusMessage = msg.command + " finished"
+	return m, cmd
+}
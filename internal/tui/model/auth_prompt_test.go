@@ -0,0 +1,44 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+)
+
+// With interactive_auth on, a Branches tab push that needs a password suspends the TUI and runs
+// the push again in the terminal instead of failing.
+func TestBranchPushAsksForCredentialsInTerminal(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\n" +
+		`[ "$1 $2" = "git push" ] && [ "$GIT_TERMINAL_PROMPT" = 0 ] && { echo "fatal: could not read Username for 'https://example.com': terminal prompts disabled" >&2; exit 1; }` + "\n"
+	if err := os.WriteFile(filepath.Join(bin, "jj"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	m := newTestModel()
+	defer m.Close()
+	svc := &jj.Service{RepoPath: t.TempDir(), InteractiveAuth: true}
+	m.appState.JJService = svc
+
+	msg := branchestab.PushBranch(svc, "feature")()
+	if authPromptError(msg.(branchestab.BranchActionMsg).Err) == nil {
+		t.Fatalf("push error = %v, want an AuthPromptError", msg.(branchestab.BranchActionMsg).Err)
+	}
+	_, cmd := m.Update(msg)
+	if !strings.Contains(m.appState.StatusMessage, "jj git push --bookmark") {
+		t.Errorf("status = %q", m.appState.StatusMessage)
+	}
+	if cmd == nil {
+		t.Fatal("expected the push to be rerun in the terminal")
+	}
+	if got := fmt.Sprintf("%T", cmd()); got != "tea.execMsg" {
+		t.Errorf("command sent %s, want tea.ExecProcess's message", got)
+	}
+}
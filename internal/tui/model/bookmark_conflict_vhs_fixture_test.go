@@ -79,7 +79,7 @@ func TestVHSTapeBookmarkConflictFixture_ModalAppears(t *testing.T) {
 	if err != nil {
 		t.Fatalf("GetRepository: %v", err)
 	}
-	branches, err := svc.ListBranches(ctx, 0)
+	branches, err := svc.ListBranches(ctx)
 	if err != nil {
 		t.Fatalf("ListBranches: %v", err)
 	}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	confirmtab "github.com/madicen/jj-tui/internal/tui/tabs/confirm"
+)
+
+// commandPreviews hands mutating jj commands from the service (running in a command's goroutine)
+// to Update while preview mode is on, and carries the user's answer back.
+type commandPreviews struct {
+	requests chan commandPreviewMsg
+}
+
+// commandPreviewMsg is a jj command waiting for Confirm/Cancel; the answer goes to reply.
+type commandPreviewMsg struct {
+	preview jj.CommandPreview
+	reply   chan bool
+}
+
+// commandPreviewAnsweredMsg is sent once the user answered; main then waits for the next command.
+type commandPreviewAnsweredMsg struct{}
+
+func newCommandPreviews() *commandPreviews {
+	return &commandPreviews{requests: make(chan commandPreviewMsg)}
+}
+
+// approve is the jj service's PreviewCommand: it blocks the command until the user answers.
+func (c *commandPreviews) approve(ctx context.Context, p jj.CommandPreview) bool {
+	reply := make(chan bool, 1)
+	select {
+	case c.requests <- commandPreviewMsg{preview: p, reply: reply}:
+	case <-ctx.Done():
+		return false
+	}
+	select {
+	case ok := <-reply:
+		return ok
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// next waits for the next command to preview. Only one is shown at a time: main calls next again
+// after the answer, so concurrent commands queue up in approve.
+func (c *commandPreviews) next() tea.Cmd {
+	return func() tea.Msg { return <-c.requests }
+}
+
+// attachCommandPreview routes svc's preview mode through the confirmation modal.
+func (m *Model) attachCommandPreview(svc *jj.Service) {
+	if svc != nil && svc.PreviewCommand == nil {
+		svc.PreviewCommand = m.commandPreviews.approve
+	}
+}
+
+// showCommandPreview opens the confirmation modal for a command held by preview mode.
+func (m *Model) showCommandPreview(msg commandPreviewMsg) {
+	answer := func(ok bool) tea.Cmd {
+		return func() tea.Msg {
+			msg.reply <- ok
+			return commandPreviewAnsweredMsg{}
+		}
+	}
+	m.confirmModal.Show(confirmtab.Prompt{
+		Title:     "Preview command",
+		Message:   "Preview mode is on (Ctrl+e turns it off). Run this jj command?",
+		Command:   msg.preview.Command,
+		Revisions: msg.preview.Revisions,
+		OnConfirm: answer(true),
+		OnCancel:  answer(false),
+	})
+}
+
+// togglePreviewCommands turns preview mode on or off for the session and in the saved config.
+func (m *Model) togglePreviewCommands() {
+	on := !m.appState.Config.PreviewsCommands()
+	if m.appState.Config == nil {
+		m.appState.Config = &config.Config{}
+	}
+	m.appState.Config.PreviewCommands = &on
+	if saved, _ := config.Load(); saved != nil {
+		saved.PreviewCommands = &on
+		_ = saved.Save()
+	}
+	if svc := m.appState.JJService; svc != nil {
+		m.attachCommandPreview(svc)
+		svc.SetPreviewCommands(on)
+	}
+	if on {
+		m.appState.StatusMessage = "Preview mode on: jj commands are shown for confirmation before they run"
+	} else {
+		m.appState.StatusMessage = "Preview mode off"
+	}
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
+)
+
+// Ctrl+e turns preview mode on and saves it; a mutating command then waits in the confirm modal,
+// showing its command line and revisions, and Cancel makes it fail quietly.
+func TestCommandPreview(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("JJ_TUI_CONFIG", "")
+	m := newTestModel()
+	defer m.Close()
+	m.appState.Config = &config.Config{}
+	svc := &jj.Service{RepoPath: t.TempDir()}
+	m.appState.JJService = svc
+	m.graphTabModel.SelectCommit(1)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if !svc.PreviewsCommands() || svc.PreviewCommand == nil {
+		t.Fatal("ctrl+e should turn preview mode on")
+	}
+	if saved, _ := config.Load(); !saved.PreviewsCommands() {
+		t.Error("preview mode should be saved")
+	}
+
+	// The destructive-action prompt is skipped: the preview shows the command instead.
+	m.processGraphRequest(graphtab.Request{Abandon: true})
+	if m.confirmModal.IsShown() {
+		t.Fatal("abandon should not ask twice in preview mode")
+	}
+
+	answered := make(chan bool)
+	go func() {
+		answered <- svc.PreviewCommand(context.Background(), jj.CommandPreview{Command: "jj abandon def4", Revisions: []string{"def4 Second commit"}})
+	}()
+	m.Update(m.commandPreviews.next()())
+	if key, content, title, _ := m.chromedSlot(); key != "confirm" || title != "Preview command" ||
+		!strings.Contains(content, "$ jj abandon def4") || !strings.Contains(content, "def4 Second commit") {
+		t.Fatalf("chromed slot = %q %q:\n%s", key, title, content)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	_, cmd = m.Update(cmd())
+	if _, ok := cmd().(commandPreviewAnsweredMsg); !ok || <-answered {
+		t.Fatal("cancel should decline the command")
+	}
+	m.Update(errorMsg{Err: fmt.Errorf("failed to abandon commit: %w", jj.ErrCommandCancelled)})
+	if m.errorModal.GetError() != nil || m.appState.StatusMessage != "Cancelled" {
+		t.Errorf("status = %q, error modal = %v", m.appState.StatusMessage, m.errorModal.GetError())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if svc.PreviewsCommands() {
+		t.Error("ctrl+e should turn preview mode off again")
+	}
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	confirmtab "github.com/madicen/jj-tui/internal/tui/tabs/confirm"
+	filedifftab "github.com/madicen/jj-tui/internal/tui/tabs/filediff"
+	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
+	workspacestab "github.com/madicen/jj-tui/internal/tui/tabs/workspaces"
+)
+
+// Abandon waits for the confirmation, which names the jj command; "don't ask again" turns the
+// prompt off in the saved config so the next abandon runs straight away.
+func TestConfirmAbandon(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("JJ_TUI_CONFIG", "")
+	m := newTestModel()
+	defer m.Close()
+	m.appState.Config = &config.Config{}
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir()}
+	m.graphTabModel.SelectCommit(1)
+
+	m.processGraphRequest(graphtab.Request{Abandon: true})
+	if !m.confirmModal.IsShown() || m.appState.Loading {
+		t.Fatal("abandon should wait for the confirmation")
+	}
+	if key, content, title, _ := m.chromedSlot(); key != "confirm" || title != "Abandon commit" || !strings.Contains(content, "$ jj abandon def4") {
+		t.Fatalf("chromed slot = %q %q:\n%s", key, title, content)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if m.confirmModal.IsShown() || cmd == nil {
+		t.Fatal("y should close the confirmation")
+	}
+	confirmed, ok := cmd().(confirmtab.ConfirmedMsg)
+	if !ok || !confirmed.DontAskAgain {
+		t.Fatalf("msg = %#v", cmd())
+	}
+	_, cmd = m.Update(confirmed)
+	if _, ok := cmd().(confirmedGraphResult); !ok {
+		t.Fatal("confirming should apply the held abandon")
+	}
+
+	if m.appState.Config.ConfirmsAction("abandon") {
+		t.Error("don't ask again should turn the abandon prompt off")
+	}
+	if saved, _ := config.Load(); saved.ConfirmsAction("abandon") || !saved.ConfirmsAction("squash") {
+		t.Errorf("saved confirm_actions = %v", saved.ConfirmActions)
+	}
+	m.processGraphRequest(graphtab.Request{Abandon: true})
+	if m.confirmModal.IsShown() {
+		t.Error("abandon should no longer ask")
+	}
+}
+
+func TestConfirmCancel(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir()}
+	m.graphTabModel.SelectCommit(1)
+
+	m.processGraphRequest(graphtab.Request{Abandon: true})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m.Update(cmd())
+	if m.confirmModal.IsShown() || m.appState.StatusMessage != "Cancelled" {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
+
+// The squash prompt shows the command with the combined description it will actually pass, and
+// confirming runs that command.
+func TestConfirmSquashShowsRealMessage(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncase \"$*\" in\n*parents*) echo 'Parent subject' ;;\n*) echo 'Child subject' ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(bin, "jj"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir()}
+	m.graphTabModel.SelectCommit(1)
+
+	_, cmd := m.processGraphRequest(graphtab.Request{Squash: true})
+	if cmd == nil || m.confirmModal.IsShown() {
+		t.Fatal("squash should load its message before asking")
+	}
+	ask, ok := cmd().(confirmtab.AskMsg)
+	if !ok {
+		t.Fatalf("msg = %#v", cmd())
+	}
+	want := jj.CommandLine(jj.SquashArgs("def4", "Parent subject\n\nChild subject"))
+	if ask.Prompt.Command != want {
+		t.Fatalf("command = %q, want %q", ask.Prompt.Command, want)
+	}
+	m.Update(ask)
+	if !m.confirmModal.IsShown() {
+		t.Fatal("the squash prompt should be shown")
+	}
+}
+
+// Closing a PR, forgetting a workspace and taking one side of a conflict all ask first.
+func TestConfirmOtherDestructiveActions(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir()}
+
+	m.Update(filedifftab.ConflictTakeMsg{Path: "a.go", Take: jj.ConflictTakeLeft})
+	if !m.confirmModal.IsShown() || m.appState.Loading {
+		t.Fatal("taking one side should ask first")
+	}
+	m.confirmModal.Hide()
+	if _, cmd := m.Update(filedifftab.ConflictTakeMsg{Path: "a.go", Take: jj.ConflictTakeBoth}); cmd == nil || m.confirmModal.IsShown() {
+		t.Fatal("keeping both sides loses nothing and should not ask")
+	}
+
+	_, cmd := workspacestab.ExecuteRequest(workspacestab.Request{Forget: true}, &workspacestab.RequestContext{
+		JJService: m.appState.JJService,
+		Selected:  &jj.Workspace{Name: "feature"},
+	})
+	ask, ok := cmd().(confirmtab.AskMsg)
+	if !ok || ask.Prompt.Action != "forget_workspace" || ask.Prompt.Command != "jj workspace forget feature" {
+		t.Fatalf("forget msg = %#v", cmd())
+	}
+	m.Update(ask)
+	if !m.confirmModal.IsShown() {
+		t.Fatal("forget should ask first")
+	}
+}
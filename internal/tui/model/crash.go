@@ -0,0 +1,36 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// crashHistoryLimit caps how many jj commands go into a crash report.
+const crashHistoryLimit = 30
+
+// crashHistory returns a func that formats recent jj commands for a crash report. It captures the
+// service now so it is safe to call from command goroutines.
+func (m *Model) crashHistory() func() []string {
+	svc := m.appState.JJService
+	return func() []string {
+		if svc == nil {
+			return nil
+		}
+		var out []string
+		for i, e := range svc.GetCommandHistory() {
+			if i == crashHistoryLimit {
+				break
+			}
+			status := "ok"
+			if !e.Success {
+				status = "FAIL"
+			}
+			line := fmt.Sprintf("%s [%s %s] %s", e.Timestamp.Format("15:04:05"), status, e.Duration.Round(time.Millisecond), e.Command)
+			if e.Error != "" {
+				line += " — " + e.Error
+			}
+			out = append(out, line)
+		}
+		return out
+	}
+}
@@ -3,18 +3,24 @@ package model
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/data"
 	"github.com/madicen/jj-tui/internal/tui/state"
+	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
 	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
 	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
 	settingstab "github.com/madicen/jj-tui/internal/tui/tabs/settings"
+	ticketstab "github.com/madicen/jj-tui/internal/tui/tabs/tickets"
+	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
 // handleDataServicesInitializedMsg applies initialized services and repository; starts tick and PR load.
@@ -22,29 +28,33 @@ import (
 func (m *Model) handleDataServicesInitializedMsg(msg data.ServicesInitializedMsg) (tea.Model, tea.Cmd) {
 	m.silentReloadInFlight = false
 	m.appState.JJService = msg.JJService
+	m.attachCommandPreview(msg.JJService)
 	m.appState.GitHubService = msg.GitHubService
 	m.appState.TicketService = msg.TicketService
 	m.appState.Repository = msg.Repository
 	m.appState.GithubInfo = msg.GitHubInfo
 	m.appState.DemoMode = msg.DemoMode
 	m.appState.Loading = false
-	m.appState.StatusMessage = fmt.Sprintf("Loaded %d commits", len(msg.Repository.Graph.Commits))
+	m.appState.StatusMessage = i18n.T("status.loaded_commits", len(msg.Repository.Graph.Commits))
 	if m.appState.DemoMode {
-		m.appState.StatusMessage += " (demo mode)"
+		m.appState.StatusMessage += i18n.T("status.suffix_demo_mode")
+	} else if m.appState.SafeMode {
+		m.appState.StatusMessage += i18n.T("status.suffix_safe_mode")
 	} else if m.appState.GitHubService != nil {
-		m.appState.StatusMessage += " (GitHub connected)"
+		m.appState.StatusMessage += i18n.T("status.suffix_connected", "GitHub")
 	} else if msg.GitHubInfo != "" {
-		m.appState.StatusMessage += fmt.Sprintf(" (GitHub: %s)", msg.GitHubInfo)
+		m.appState.StatusMessage += i18n.T("status.suffix_github_info", msg.GitHubInfo)
 	}
 	if m.appState.TicketService != nil {
-		m.appState.StatusMessage += fmt.Sprintf(" (%s connected)", m.appState.TicketService.GetProviderName())
+		m.appState.StatusMessage += i18n.T("status.suffix_connected", m.appState.TicketService.GetProviderName())
 	} else if msg.TicketError != nil {
-		m.appState.StatusMessage += fmt.Sprintf(" (Tickets error: %v)", msg.TicketError)
+		m.appState.StatusMessage += i18n.T("status.suffix_tickets_error", msg.TicketError)
 	}
+	m.noteRepositoryLoaded()
 	var cmds []tea.Cmd
-	cmds = append(cmds, m.tickCmd())
-	if m.isGitHubAvailable() {
-		cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.GitHubService, m.appState.GithubInfo, m.appState.DemoMode, 0)))
+	cmds = append(cmds, m.tickCmd(), m.watchRepoCmd())
+	if m.isGitHubAvailable() && !m.lowBandwidth() {
+		cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.Forge(), m.appState.GithubInfo, m.appState.DemoMode, 0)))
 		cmds = append(cmds, prstab.PrTickCmd())
 	}
 	if m.graphTabModel.GetSelectedCommit() < 0 && len(msg.Repository.Graph.Commits) > 0 {
@@ -52,6 +62,7 @@ func (m *Model) handleDataServicesInitializedMsg(msg data.ServicesInitializedMsg
 		commit := msg.Repository.Graph.Commits[0]
 		cmds = append(cmds, graphtab.LoadChangedFilesCmd(m.appState.JJService, commit.ChangeID))
 	}
+	cmds = append(cmds, m.enterPopupView(true))
 	return m, tea.Batch(cmds...)
 }
 
@@ -60,13 +71,16 @@ func (m *Model) handleDataServicesInitializedMsg(msg data.ServicesInitializedMsg
 func (m *Model) handleRepoReadyMsg(msg data.RepoReadyMsg) (tea.Model, tea.Cmd) {
 	m.silentReloadInFlight = false
 	m.appState.JJService = msg.JJService
+	m.attachCommandPreview(msg.JJService)
 	m.appState.Repository = msg.Repository
 	m.appState.DemoMode = msg.DemoMode
 	m.appState.Loading = false
-	m.appState.StatusMessage = fmt.Sprintf("Loaded %d commits", len(msg.Repository.Graph.Commits))
+	m.appState.StatusMessage = i18n.T("status.loaded_commits", len(msg.Repository.Graph.Commits))
 	if m.appState.Repository != nil {
 		m.appState.Repository.PRs = nil
 	}
+	m.graphTabModel.SetBookmarkPrefix(m.appState.Config.BookmarkNamespace())
+	m.graphTabModel.SetWrapSubjects(m.appState.Config.WrapsGraphSubjects())
 	m.graphTabModel.UpdateRepository(m.appState.Repository)
 	m.prsTabModel.UpdateRepository(m.appState.Repository)
 	m.prsTabModel.SetGithubService(false)
@@ -74,43 +88,68 @@ func (m *Model) handleRepoReadyMsg(msg data.RepoReadyMsg) (tea.Model, tea.Cmd) {
 	m.ticketsTabModel.UpdateRepository(m.appState.Repository)
 	m.settingsTabModel.UpdateRepository(m.appState.Repository)
 	m.helpTabModel.UpdateRepository(m.appState.Repository)
+	m.noteRepositoryLoaded()
 	var cmds []tea.Cmd
-	cmds = append(cmds, m.tickCmd())
+	cmds = append(cmds, m.tickCmd(), m.watchRepoCmd())
 	if m.graphTabModel.GetSelectedCommit() < 0 && len(msg.Repository.Graph.Commits) > 0 {
 		m.graphTabModel.SelectCommit(0)
 	}
 	// Load changed files on next frame so the graph is painted first; then we run jj diff --summary for the selected commit.
 	cmds = append(cmds, tea.Tick(0, func(time.Time) tea.Msg { return loadChangedFilesTriggerMsg{} }))
-	cmds = append(cmds, data.LoadAuxServicesCmd(msg.DemoMode, msg.Owner, msg.RepoName, msg.GitHubInfoFromURL))
+	if m.appState.SafeMode {
+		cmds = append(cmds, func() tea.Msg { return data.AuxServicesReadyMsg{} })
+	} else {
+		cmds = append(cmds, data.LoadAuxServicesCmd(msg.DemoMode, msg.Owner, msg.RepoName, msg.RemoteURL, msg.GitHubInfoFromURL))
+	}
+	cmds = append(cmds, m.enterPopupView(false))
 	return m, tea.Batch(cmds...)
 }
 
 // handleAuxServicesReadyMsg applies GitHub and ticket services after they load in the background.
 func (m *Model) handleAuxServicesReadyMsg(msg data.AuxServicesReadyMsg) (tea.Model, tea.Cmd) {
 	m.appState.GitHubService = msg.GitHubService
+	m.appState.GitLabService = msg.GitLabService
 	m.appState.TicketService = msg.TicketService
 	m.appState.GithubInfo = msg.GitHubInfo
 	m.appState.DefaultBranch = msg.DefaultBranch
+	m.SetGitHubPermissions(msg.Permissions)
+	m.prsTabModel.SetUpstreamSource("")
+	if m.appState.GitHubService.TargetsUpstream() {
+		m.prsTabModel.SetUpstreamSource(m.appState.GitHubService.PRTargetFullName())
+	}
 	// Append GitHub/ticket info to existing "Loaded N commits" status
 	if m.appState.DemoMode {
-		m.appState.StatusMessage += " (demo mode)"
+		m.appState.StatusMessage += i18n.T("status.suffix_demo_mode")
+	} else if m.appState.SafeMode {
+		m.appState.StatusMessage += i18n.T("status.suffix_safe_mode")
+	} else if m.appState.GitHubService != nil && !msg.Permissions.Can(github.CapReadPRs) {
+		m.appState.StatusMessage += i18n.T("status.suffix_prs_unavailable", msg.Permissions.Reason(github.CapReadPRs))
 	} else if m.appState.GitHubService != nil {
-		m.appState.StatusMessage += " (GitHub connected)"
+		m.appState.StatusMessage += i18n.T("status.suffix_connected", "GitHub")
+	} else if m.appState.GitLabService != nil {
+		m.appState.StatusMessage += i18n.T("status.suffix_connected", "GitLab")
 	} else if msg.GitHubInfo != "" {
-		m.appState.StatusMessage += fmt.Sprintf(" (GitHub: %s)", msg.GitHubInfo)
+		m.appState.StatusMessage += i18n.T("status.suffix_github_info", msg.GitHubInfo)
 	}
 	if m.appState.TicketService != nil {
-		m.appState.StatusMessage += fmt.Sprintf(" (%s connected)", m.appState.TicketService.GetProviderName())
+		m.appState.StatusMessage += i18n.T("status.suffix_connected", m.appState.TicketService.GetProviderName())
 	} else if msg.TicketError != nil {
-		m.appState.StatusMessage += fmt.Sprintf(" (Tickets error: %v)", msg.TicketError)
+		m.appState.StatusMessage += i18n.T("status.suffix_tickets_error", msg.TicketError)
 	}
 	var cmds []tea.Cmd
 	cmds = append(cmds, m.tickCmd())
-	if m.isGitHubAvailable() {
-		cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.GitHubService, m.appState.GithubInfo, m.appState.DemoMode, 0)))
+	// Don't poll for PRs the token can't read; the PRs tab explains why instead. Low-bandwidth mode
+	// leaves PRs and tickets until their tab is opened.
+	if m.isGitHubAvailable() && m.canReadPRs() && !m.lowBandwidth() {
+		cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.Forge(), m.appState.GithubInfo, m.appState.DemoMode, 0)))
 		cmds = append(cmds, prstab.PrTickCmd())
 	}
 	m.prsTabModel.SetGithubService(m.isGitHubAvailable())
+	// Fetch tickets quietly so bookmarks named after one are linked before the Tickets tab is opened.
+	if svc := m.appState.TicketService; svc != nil && !util.IsNilInterface(svc) && !m.lowBandwidth() {
+		cmds = append(cmds, ticketstab.PrefetchTicketsCmd(svc))
+	}
+	cmds = append(cmds, m.enterPopupView(true))
 	return m, tea.Batch(cmds...)
 }
 
@@ -127,6 +166,11 @@ func (m *Model) handleAuxServicesReadyMsg(msg data.AuxServicesReadyMsg) (tea.Mod
 // in place so the user can retry via the Push all bookmarks button without re-creating.
 func (m *Model) handleRemoteOpResultMsg(msg data.RemoteOpResultMsg) (tea.Model, tea.Cmd) {
 	m.appState.Loading = false
+	if authErr := authPromptError(msg.PushErr); authErr != nil && msg.Err == nil {
+		// The repo was created; only the push needs credentials.
+		m.refreshSettingsOriginURL()
+		return m.handleAuthPrompt(authErr)
+	}
 	if msg.Err != nil {
 		m.errorModal.SetError(msg.Err, false, "")
 		// Refresh anyway so the panel shows whatever state we ended up in (e.g. the user
@@ -137,30 +181,30 @@ func (m *Model) handleRemoteOpResultMsg(msg data.RemoteOpResultMsg) (tea.Model,
 	switch msg.Op {
 	case data.RemoteOpApply:
 		if msg.PreviousURL == "" {
-			m.appState.StatusMessage = fmt.Sprintf("Added origin %s", msg.NewURL)
+			m.appState.StatusMessage = i18n.T("status.origin_added", msg.NewURL)
 		} else if msg.PreviousURL != msg.NewURL {
-			m.appState.StatusMessage = fmt.Sprintf("Updated origin → %s", msg.NewURL)
+			m.appState.StatusMessage = i18n.T("status.origin_updated", msg.NewURL)
 		} else {
-			m.appState.StatusMessage = "Origin already set to that URL; refreshed"
+			m.appState.StatusMessage = i18n.T("status.origin_unchanged")
 		}
 	case data.RemoteOpCreateGh:
-		base := "Created GitHub repo"
+		base := i18n.T("status.gh_repo_created")
 		if msg.NewURL != "" {
-			base = fmt.Sprintf("Created GitHub repo (%s)", msg.NewURL)
+			base = i18n.T("status.gh_repo_created_url", msg.NewURL)
 		}
 		switch {
 		case msg.PushErr != nil:
 			// Soft-failure: create succeeded, push didn't. Status reads the success-side, the
 			// modal carries the failure detail so the user knows to retry the push.
-			m.appState.StatusMessage = base + "; push failed (see error)"
+			m.appState.StatusMessage = i18n.T("status.gh_repo_push_failed", base)
 			m.errorModal.SetError(fmt.Errorf("post-create push failed: %w\nUse Push all bookmarks to retry once you've resolved the underlying issue", msg.PushErr), false, "")
 		case msg.PushedCount > 0:
-			m.appState.StatusMessage = fmt.Sprintf("%s and pushed %d bookmark(s): %s", base, msg.PushedCount, strings.Join(msg.PushedNames, ", "))
+			m.appState.StatusMessage = i18n.T("status.and_pushed_bookmarks", base, msg.PushedCount, strings.Join(msg.PushedNames, ", "))
 		default:
-			m.appState.StatusMessage = base + " (no bookmarks to push yet)"
+			m.appState.StatusMessage = i18n.T("status.gh_repo_nothing_pushed", base)
 		}
 	case data.RemoteOpRemove:
-		m.appState.StatusMessage = fmt.Sprintf("Removed origin (was %s)", msg.PreviousURL)
+		m.appState.StatusMessage = i18n.T("status.origin_removed", msg.PreviousURL)
 		// Clear the input so the user doesn't re-Apply the same URL by accident on the next
 		// keystroke. They can retype if they want to re-add it.
 		m.settingsTabModel.GetGitHubModel().SetOriginURL("")
@@ -179,41 +223,94 @@ func (m *Model) handleRemoteOpResultMsg(msg data.RemoteOpResultMsg) (tea.Model,
 // changes — only the remote bookmarks and the repo PRs view.
 func (m *Model) handlePushResultMsg(msg data.PushResultMsg) (tea.Model, tea.Cmd) {
 	m.appState.Loading = false
+	if authErr := authPromptError(msg.Err); authErr != nil {
+		return m.handleAuthPrompt(authErr)
+	}
 	if msg.Err != nil {
 		m.errorModal.SetError(msg.Err, false, "")
 		return m, nil
 	}
 	switch {
 	case msg.PushedCount == 0:
-		m.appState.StatusMessage = "Nothing to push (no local bookmarks yet)"
+		m.appState.StatusMessage = i18n.T("status.nothing_to_push")
 		return m, nil
 	case msg.All:
 		if len(msg.PushedNames) > 0 {
-			m.appState.StatusMessage = fmt.Sprintf("Pushed %d bookmark(s) to origin: %s", msg.PushedCount, strings.Join(msg.PushedNames, ", "))
+			m.appState.StatusMessage = i18n.T("status.pushed_bookmarks_named", msg.PushedCount, strings.Join(msg.PushedNames, ", "))
 		} else {
-			m.appState.StatusMessage = fmt.Sprintf("Pushed %d bookmark(s) to origin", msg.PushedCount)
+			m.appState.StatusMessage = i18n.T("status.pushed_bookmarks", msg.PushedCount)
 		}
 	default:
 		if len(msg.PushedNames) > 0 {
-			m.appState.StatusMessage = fmt.Sprintf("Pushed bookmark %s to origin", msg.PushedNames[0])
+			m.appState.StatusMessage = i18n.T("status.pushed_bookmark", msg.PushedNames[0])
 		} else {
-			m.appState.StatusMessage = "Pushed current bookmark to origin"
+			m.appState.StatusMessage = i18n.T("status.pushed_current_bookmark")
 		}
 	}
-	// Reload the repo so the graph picks up new remote-tracking bookmarks (e.g. main@origin).
-	return m, data.LoadRepository(m.appState.JJService)
+	// Reload the repo so the graph picks up new remote-tracking bookmarks (e.g. main@origin), and
+	// the branches so ones the push started tracking show their ahead/behind counts.
+	return m, tea.Batch(
+		data.LoadRepository(m.appState.JJService),
+		branchestab.LoadBranchesCmd(m.appState.JJService),
+	)
 }
 
 // handleDataRepositoryLoadedMsg delegates to shared applyRepositoryLoaded.
 func (m *Model) handleDataRepositoryLoadedMsg(msg data.RepositoryLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.fromOtherRepo(msg.Repository) {
+		return m, nil
+	}
 	return m.applyRepositoryLoaded(msg.Repository)
 }
 
 // handleActionsRepositoryLoadedMsg delegates to shared applyRepositoryLoaded.
 func (m *Model) handleActionsRepositoryLoadedMsg(msg graphtab.RepositoryLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.popupView != "" {
+		// Popup mode exits once an action has changed the repo.
+		return m, m.popupQuit()
+	}
 	return m.applyRepositoryLoaded(msg.Repository)
 }
 
+// handleConflictSummaryMsg shows the commits an operation left conflicted, with their conflicted
+// file counts, in the warning modal; Enter jumps to one in the graph. An error takes precedence.
+func (m *Model) handleConflictSummaryMsg(msg data.ConflictSummaryMsg) (tea.Model, tea.Cmd) {
+	if len(msg.Commits) == 0 {
+		return m, nil
+	}
+	m.appState.StatusMessage = i18n.T("status.new_conflicts", len(msg.Commits))
+	if m.errorModal.GetError() != nil || m.warningModal.IsShown() {
+		return m, nil
+	}
+	details := make([]string, len(msg.Commits))
+	for i := range msg.Commits {
+		if i < len(msg.FileCounts) && msg.FileCounts[i] >= 0 {
+			details[i] = i18n.T("modal.warning.conflicted_files", msg.FileCounts[i])
+		}
+	}
+	m.warningModal.ShowJump(i18n.T("modal.warning.new_conflicts"), i18n.T("modal.warning.new_conflicts_message"), msg.Commits, details)
+	return m, nil
+}
+
+// handleReviewFixStartedMsg follows up on a review comment quick fix: the new commit on the PR
+// branch is @, so main shows the graph, reloads it and opens the commented line in the editor.
+func (m *Model) handleReviewFixStartedMsg(msg prstab.ReviewFixStartedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.errorModal.SetError(fmt.Errorf("quick fix for %s:%d: %w", msg.Comment.Path, msg.Comment.Line, msg.Err), false, "")
+		return m, nil
+	}
+	m.appState.ViewMode = state.ViewCommitGraph
+	m.statusAfterReload = fmt.Sprintf("Fixing %s:%d on %s; jj squash amends the PR commit", msg.Comment.Path, msg.Comment.Line, msg.PR.HeadBranch)
+	cmds := []tea.Cmd{data.LoadRepository(m.appState.JJService)}
+	abs, err := util.RepoAbsPath(m.appState.JJService.RepoPath, msg.Comment.Path)
+	if err != nil {
+		m.statusAfterReload += " (" + err.Error() + ")"
+	} else {
+		cmds = append(cmds, util.OpenFileAtLineCmd(abs, msg.Comment.Line, m.appState.Config))
+	}
+	return m, tea.Batch(cmds...)
+}
+
 // handleOpenPRsResolvedMsg merges targeted per-branch open-PR lookups into the repository's PR list
 // (deduped by PR number) so the graph can offer "Update PR" for branches whose PR was missing from
 // the bulk list. Existing entries win to avoid clobbering richer data (e.g. merged/closed state).
@@ -244,7 +341,13 @@ func (m *Model) handleOpenPRsResolvedMsg(msg prstab.OpenPRsResolvedMsg) (tea.Mod
 // handleDataSilentRepositoryLoadedMsg applies silent repo update and propagates to all tabs.
 func (m *Model) handleDataSilentRepositoryLoadedMsg(msg data.SilentRepositoryLoadedMsg) (tea.Model, tea.Cmd) {
 	m.silentReloadInFlight = false
+	m.graphTabModel.EndLoadMore()
+	if m.fromOtherRepo(msg.Repository) {
+		return m, nil
+	}
 	if msg.Repository != nil {
+		m.noteRepositoryLoaded()
+		m.pendingChanges = jj.PendingChanges{}
 		oldCount := 0
 		var oldPRs []internal.GitHubPR
 		if m.appState.Repository != nil {
@@ -260,16 +363,61 @@ func (m *Model) handleDataSilentRepositoryLoadedMsg(msg data.SilentRepositoryLoa
 		m.ticketsTabModel.UpdateRepository(m.appState.Repository)
 		m.settingsTabModel.UpdateRepository(m.appState.Repository)
 		m.helpTabModel.UpdateRepository(m.appState.Repository)
+		m.linkTicketBookmarks()
 		newCount := len(msg.Repository.Graph.Commits)
 		if newCount != oldCount && m.errorModal.GetError() == nil {
-			m.appState.StatusMessage = fmt.Sprintf("Updated: %d commits", newCount)
+			m.appState.StatusMessage = i18n.T("status.updated_commits", newCount)
 		}
 	}
-	return m, nil
+	// Changes seen while this reload ran.
+	return m, m.refreshRepoCmd(false)
+}
+
+// handleRepoLostMsg drops the jj service when its repository disappeared and re-runs service
+// initialization from the current directory: a moved repo is reopened at its new location, and
+// anything else lands on the welcome screen instead of failing on every refresh.
+func (m *Model) handleRepoLostMsg(msg data.RepoLostMsg) (tea.Model, tea.Cmd) {
+	m.silentReloadInFlight = false
+	if m.appState.JJService == nil || m.appState.JJService.RepoPath != msg.RepoPath {
+		return m, nil // already handled, or a late result from a service we replaced
+	}
+	m.closeRepo()
+	m.errorModal.SetError(nil, false, "")
+	m.appState.ViewMode = state.ViewCommitGraph
+	// A deleted working directory can't host the welcome screen (or jj init); move to the
+	// nearest directory that still exists.
+	if _, err := os.Getwd(); err != nil {
+		_ = os.Chdir(nearestExistingDir(msg.RepoPath))
+	}
+	m.appState.Loading = true
+	m.appState.StatusMessage = i18n.T("status.repo_lost", msg.Err)
+	return m, data.InitializeServices(m.appState.DemoMode)
+}
+
+// nearestExistingDir returns path or its closest ancestor that is still a directory.
+func nearestExistingDir(path string) string {
+	for {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
 }
 
 // handleTickMsg runs auto-refresh and ensures changed files for selected commit; forwards PR tick to PRs tab.
+// It stops rescheduling itself while the user is idle.
 func (m *Model) handleTickMsg() (tea.Model, tea.Cmd) {
+	// Idle: drop the tick loop (and the PR poll it drives) until input resumes it.
+	if m.checkIdle() {
+		m.idleState.tickSuspended = true
+		return m, nil
+	}
+	m.maybeCheckForUpdates()
+	segmentsCmd := m.refreshStatusSegments()
 	// Don't run background refresh/updates if a modal is showing or we're in a blocking flow
 	isBlockingView := m.appState.ViewMode == state.ViewEditDescription ||
 		m.appState.ViewMode == state.ViewCreatePR ||
@@ -280,9 +428,9 @@ func (m *Model) handleTickMsg() (tea.Model, tea.Cmd) {
 		m.graphTabModel.IsInMergeMode()
 
 	if m.errorModal.GetError() != nil || isBlockingView {
-		return m, m.tickCmd()
+		return m, tea.Batch(data.CheckPendingChangesCmd(m.appState.JJService), segmentsCmd, m.tickCmd())
 	}
-	var cmds []tea.Cmd
+	cmds := []tea.Cmd{segmentsCmd}
 	if m.appState.ViewMode == state.ViewCommitGraph && m.appState.Repository != nil && m.appState.JJService != nil {
 		commits := m.appState.Repository.Graph.Commits
 		idx := m.graphTabModel.GetSelectedCommit()
@@ -293,27 +441,21 @@ func (m *Model) handleTickMsg() (tea.Model, tea.Cmd) {
 			}
 		}
 	}
-	if !m.silentReloadInFlight && !m.appState.Loading && !m.aiGenOverlayActive && m.appState.JJService != nil && m.appState.ViewMode != state.ViewEditDescription && m.appState.ViewMode != state.ViewCreatePR && m.appState.ViewMode != state.ViewCreateTicket && m.appState.ViewMode != state.ViewCreateBookmark && m.appState.ViewMode != state.ViewFileDiff && (m.appState.ViewMode != state.ViewEvologSplit || !m.evologSplitModal.SuggestLoading()) && !m.graphTabModel.IsInRebaseMode() && !m.graphTabModel.IsInMergeMode() {
-		revset := ""
-		if m.appState.Config != nil {
-			revset = m.appState.Config.GraphRevset
-			// Mirror LoadRepository's mine() intersection so the silent background
-			// refresh produces the same graph as the foreground load. Without this,
-			// the periodic tick would silently widen the revset and reintroduce
-			// other contributors' commits between user-initiated reloads.
-			if m.appState.Config.GraphFilterToMine() {
-				revset = jj.ApplyMineFilterToRevset(revset)
-			}
-			m.appState.JJService.BookmarkListPreferTracked = m.appState.Config.BranchesFilterToTrackedAndMine()
+	if m.canSilentReload() {
+		// Watched changes normally reload the graph as they happen; this catches up on any left
+		// pending (e.g. while a modal was open) and runs the periodic fallback reload.
+		if cmd := m.refreshRepoCmd(m.repoRefreshDue(time.Now())); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
-		m.silentReloadInFlight = true
-		cmds = append(cmds, data.LoadRepositorySilent(m.appState.JJService, revset))
+	} else if !m.silentReloadInFlight && !m.appState.Loading {
+		// No reload this tick, so nothing snapshots: check the disk for edits the graph doesn't show yet.
+		cmds = append(cmds, data.CheckPendingChangesCmd(m.appState.JJService))
 	}
 	prInput := prstab.PrTickInput{
 		IsPRView:      m.appState.ViewMode == state.ViewPullRequests,
 		Loading:       m.appState.Loading,
 		HasError:      m.errorModal.GetError() != nil,
-		GitHubService: m.appState.GitHubService,
+		Forge:         m.appState.Forge(),
 		GithubInfo:    m.appState.GithubInfo,
 		DemoMode:      m.appState.DemoMode,
 		ExistingCount: 0,
@@ -325,6 +467,9 @@ func (m *Model) handleTickMsg() (tea.Model, tea.Cmd) {
 	if prCmd != nil {
 		cmds = append(cmds, prCmd)
 	}
+	if pollCmd := m.pollTicketsCmd(); pollCmd != nil {
+		cmds = append(cmds, pollCmd)
+	}
 	cmds = append(cmds, m.tickCmd())
 	return m, tea.Batch(cmds...)
 }
@@ -340,6 +485,8 @@ func (m *Model) handleReauthNeededEffect(e prstab.ApplyReauthNeededEffect) (tea.
 	}
 	_ = os.Unsetenv("GITHUB_TOKEN")
 	m.appState.GitHubService = nil
+	m.SetGitHubPermissions(nil)
+	m.prsTabModel.SetUpstreamSource("")
 	src := config.GitHubTokenSourceSaved
 	if cfg != nil {
 		src = cfg.GitHubTokenSourceOrDefault()
@@ -0,0 +1,36 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/tui/tabs/help/diagnostics"
+)
+
+// diagnosticsInput describes the running session for the Help tab's Diagnostics report.
+func (m *Model) diagnosticsInput() diagnostics.Input {
+	in := diagnostics.Input{
+		Config:     m.appState.Config,
+		GitHubInfo: m.appState.GithubInfo,
+		GitHub:     m.appState.GitHubService != nil,
+		GitLab:     m.appState.GitLabService != nil,
+		DemoMode:   m.appState.DemoMode,
+		SafeMode:   m.appState.SafeMode,
+	}
+	if m.appState.JJService != nil {
+		in.RepoPath = m.appState.JJService.RepoPath
+	}
+	if m.appState.TicketService != nil {
+		in.TicketProvider = m.appState.TicketService.GetProviderName()
+	}
+	return in
+}
+
+func (m *Model) handleDiagnosticsRequest(r diagnostics.Request) (tea.Model, tea.Cmd) {
+	if r.Refresh {
+		return m, diagnostics.LoadCmd(m.diagnosticsInput())
+	}
+	statusMsg, cmd := diagnostics.ExecuteRequest(r)
+	if statusMsg != "" {
+		m.appState.StatusMessage = statusMsg
+	}
+	return m, cmd
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/tabs/help/diagnostics"
+)
+
+func TestHelpDiagnostics(t *testing.T) {
+	bin := t.TempDir()
+	jjScript := "#!/bin/sh\necho 'jj 0.99.0'\n"
+	if err := os.WriteFile(filepath.Join(bin, "jj"), []byte(jjScript), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("JJ_TUI_CONFIG", "")
+	t.Setenv("GITHUB_TOKEN", "ghp_secret")
+	t.Setenv("JIRA_TOKEN", "")
+
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = &jj.Service{RepoPath: "/work/repo"}
+	m.appState.Config = &config.Config{GitHubTokenSource: config.GitHubTokenSourceEnv, TicketProvider: "jira", JiraToken: "jira-secret"}
+	m.appState.GithubInfo = "repo=o/r token=ghp_secr...(env:GITHUB_TOKEN)"
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	if cmd == nil {
+		t.Fatal("entering Help should collect diagnostics")
+	}
+	loaded, ok := cmd().(diagnostics.LoadedMsg)
+	if !ok {
+		t.Fatalf("cmd msg = %T, want diagnostics.LoadedMsg", cmd())
+	}
+	m.Update(loaded)
+
+	report := m.helpTabModel.DiagnosticsReport().String()
+	for _, want := range []string{
+		"jj: jj 0.99.0 (" + filepath.Join(bin, "jj") + ")",
+		"git: not found on PATH",
+		"Repository: /work/repo",
+		"GitHub: not connected, token from $GITHUB_TOKEN",
+		"Remote: repo=o/r token=<redacted>(env:GITHUB_TOKEN)",
+		"Tickets: none, token from config",
+		"global: ",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "ghp_secr") || strings.Contains(report, "jira-secret") {
+		t.Errorf("report leaks a token:\n%s", report)
+	}
+
+	// ctrl+k cycles Shortcuts -> History -> Audit -> Diagnostics; y copies the plain-text report.
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	if !strings.Contains(m.View(), "Color profile") {
+		t.Error("Diagnostics sub-tab should show the report")
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("y should copy the report")
+	}
+	if req, ok := cmd().(diagnostics.Request); !ok || req.Copy != report {
+		t.Errorf("y msg = %#v, want copy of the report", req)
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	filehistorytab "github.com/madicen/jj-tui/internal/tui/tabs/filehistory"
+	"github.com/madicen/jj-tui/internal/tui/xref"
+)
+
+// openFileHistory shows the history of path as of commit and starts loading it. Closing it returns
+// to the current view (the graph or the file browser).
+func (m *Model) openFileHistory(commit internal.Commit, path string) (tea.Model, tea.Cmd) {
+	if m.appState.JJService == nil || path == "" {
+		return m, nil
+	}
+	if m.appState.ViewMode != state.ViewFileHistory {
+		m.fileHistoryReturnView = m.appState.ViewMode
+	}
+	var seq int
+	m.fileHistoryModal, seq = m.fileHistoryModal.SetDimensions(m.width, m.height).Open(commit, path)
+	m.appState.ViewMode = state.ViewFileHistory
+	m.appState.StatusMessage = m.fileHistoryModal.LoadStatus()
+	return m, filehistorytab.LoadHistoryCmd(m.appState.JJService, seq, commit.ID, path)
+}
+
+// handleFileHistoryRequest runs annotate and copy requests, and jumps to a commit in the graph.
+// A jump to a commit outside the graph's revset leaves the view open with a status message.
+func (m *Model) handleFileHistoryRequest(r filehistorytab.Request) (tea.Model, tea.Cmd) {
+	if r.Jump != "" {
+		model, cmd := m.followXRef(xref.Ref{Kind: xref.KindChange, Value: r.Jump})
+		if m.appState.ViewMode == state.ViewCommitGraph {
+			m.fileHistoryModal.Hide()
+			m.fileTreeModal.Hide()
+		}
+		return model, cmd
+	}
+	ctx := &filehistorytab.RequestContext{
+		JJService: m.appState.JJService,
+		Path:      m.fileHistoryModal.Path(),
+	}
+	statusMsg, cmd := filehistorytab.ExecuteRequest(r, ctx)
+	if statusMsg != "" {
+		m.appState.StatusMessage = statusMsg
+	}
+	return m, cmd
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
+)
+
+func TestFileHistoryView(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncase \"$1 $2\" in\n" +
+		"'file annotate') printf 'def4\\037def45678\\037Ada\\0371 day ago\\0371\\037package main\\n' ;;\n" +
+		"log*) printf 'def4\\037def45678\\037Ada\\0371 day ago\\037Second commit\\nabc1\\037abc12345\\037Bob\\0372 days ago\\037First commit\\n' ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(bin, "jj"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir()}
+	commit := m.appState.Repository.Graph.Commits[m.graphTabModel.GetSelectedCommit()]
+	m.Update(graphtab.ChangedFilesLoadedMsg{CommitID: commit.ChangeID, Files: []jj.ChangedFile{{Path: "main.go", Status: "M"}}})
+	m.graphTabModel.SetGraphFocused(false)
+
+	run := func(cmd tea.Cmd) tea.Msg {
+		t.Helper()
+		if cmd == nil {
+			t.Fatal("expected a command")
+		}
+		return cmd()
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	_, cmd = m.Update(run(cmd))
+	if m.appState.ViewMode != state.ViewFileHistory {
+		t.Fatalf("view = %v, want file history", m.appState.ViewMode)
+	}
+	m.Update(run(cmd))
+	view := m.View()
+	for _, want := range []string{"History of main.go", "Second commit", "First commit", "2 commits changed main.go"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Tab annotates the file at the opened revision.
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	_, cmd = m.Update(run(cmd))
+	m.Update(run(cmd))
+	if view := m.View(); !strings.Contains(view, "Annotate main.go") || !strings.Contains(view, "package main") {
+		t.Fatalf("annotate view:\n%s", view)
+	}
+
+	// Enter selects the line's commit in the graph and closes the view.
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(run(cmd))
+	if m.appState.ViewMode != state.ViewCommitGraph {
+		t.Fatalf("after Enter view = %v, want graph", m.appState.ViewMode)
+	}
+	if got := m.appState.Repository.Graph.Commits[m.graphTabModel.GetSelectedCommit()].ChangeID; got != "def4" {
+		t.Errorf("selected commit = %s, want def4", got)
+	}
+}
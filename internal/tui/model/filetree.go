@@ -0,0 +1,47 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	filetreetab "github.com/madicen/jj-tui/internal/tui/tabs/filetree"
+)
+
+// openFileTree shows the full-screen browser for commit's tree and starts listing its files.
+func (m *Model) openFileTree(commit internal.Commit) (tea.Model, tea.Cmd) {
+	if m.appState.JJService == nil {
+		return m, nil
+	}
+	var seq int
+	m.fileTreeModal, seq = m.fileTreeModal.SetDimensions(m.width, m.height).Open(commit)
+	m.appState.ViewMode = state.ViewFileTree
+	m.appState.StatusMessage = m.fileTreeModal.LoadStatus()
+	return m, filetreetab.LoadFilesCmd(m.appState.JJService, seq, commit.ID)
+}
+
+func (m *Model) handleFileTreeRequest(r filetreetab.Request) (tea.Model, tea.Cmd) {
+	ctx := &filetreetab.RequestContext{
+		JJService: m.appState.JJService,
+		Config:    m.appState.Config,
+		Revision:  m.fileTreeModal.Commit().ID,
+		Label:     m.fileTreeModal.RevisionLabel(),
+	}
+	statusMsg, cmd := filetreetab.ExecuteRequest(r, ctx)
+	if statusMsg != "" {
+		m.appState.StatusMessage = statusMsg
+	}
+	return m, cmd
+}
+
+// showFileContent opens a file read by the browser in the pager, which returns to the browser.
+func (m *Model) showFileContent(msg filetreetab.FileContentMsg) (tea.Model, tea.Cmd) {
+	if m.appState.ViewMode != state.ViewFileTree {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.appState.StatusMessage = "Failed to read " + msg.Path + ": " + msg.Err.Error()
+		return m, nil
+	}
+	m.appState.StatusMessage = ""
+	return m.handleNavigate(state.NavigateTarget{Kind: state.NavigateOpenPager, PagerTitle: msg.Title, PagerContent: msg.Content})
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	filetreetab "github.com/madicen/jj-tui/internal/tui/tabs/filetree"
+)
+
+func TestFileTreeBrowser(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncase \"$1 $2\" in\n" +
+		"'file list') printf 'README.md\\nsrc/main.go\\nsrc/util/str.go\\n' ;;\n" +
+		"'file show') echo 'package main' ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(bin, "jj"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir()}
+
+	run := func(cmd tea.Cmd) tea.Msg {
+		t.Helper()
+		if cmd == nil {
+			t.Fatal("expected a command")
+		}
+		return cmd()
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	_, cmd = m.Update(run(cmd))
+	if m.appState.ViewMode != state.ViewFileTree {
+		t.Fatalf("view = %v, want file tree", m.appState.ViewMode)
+	}
+	m.Update(run(cmd))
+	view := m.View()
+	for _, want := range []string{"Files @", "src/", "README.md", "3 files in"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "main.go") {
+		t.Error("directories should start collapsed")
+	}
+
+	// Enter expands src/ (listed first); its subdirectory comes before its files.
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if !strings.Contains(m.View(), "main.go") {
+		t.Fatal("Enter on a directory should expand it")
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	req, ok := run(cmd).(filetreetab.Request)
+	if !ok || req.View != "src/main.go" {
+		t.Fatalf("Enter on a file = %#v, want view of src/main.go", req)
+	}
+	_, cmd = m.Update(req)
+	m.Update(run(cmd))
+	if m.appState.ViewMode != state.ViewPager || !strings.Contains(m.View(), "package main") {
+		t.Fatalf("file content should open in the pager (view %v)", m.appState.ViewMode)
+	}
+
+	// The pager returns to the browser, and q there returns to the graph.
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m.Update(run(cmd))
+	if m.appState.ViewMode != state.ViewFileTree {
+		t.Fatalf("closing the pager: view = %v, want file tree", m.appState.ViewMode)
+	}
+	// Editing a file the working copy doesn't have explains why.
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	t.Setenv("EDITOR", "true")
+	m.Update(run(cmd))
+	if got := m.appState.StatusMessage; got != "src/main.go is not in the working copy" {
+		t.Errorf("status = %q", got)
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m.Update(run(cmd))
+	if m.appState.ViewMode != state.ViewCommitGraph {
+		t.Errorf("q: view = %v, want graph", m.appState.ViewMode)
+	}
+}
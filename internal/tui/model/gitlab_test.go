@@ -0,0 +1,28 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/gitlab"
+	"github.com/madicen/jj-tui/internal/tui/data"
+)
+
+func TestAuxServicesReadyWithGitLabEnablesPRs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	svc, err := gitlab.NewService("http://127.0.0.1:1", "group/repo", "tok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newTestModel()
+	m.Update(data.AuxServicesReadyMsg{GitLabService: svc})
+
+	if !m.IsGitHubAvailable() {
+		t.Fatal("PR features should be available with a GitLab service")
+	}
+	if f := m.GetForge(); f == nil || f.Name() != "GitLab" {
+		t.Fatalf("forge = %v, want GitLab", f)
+	}
+	if m.GetGitHubService() != nil {
+		t.Fatal("GitHub-only features should stay off")
+	}
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/i18n"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+	"github.com/madicen/jj-tui/internal/version"
+)
+
+// updateCheckInterval is how often a long-running session re-checks GitHub for a newer release.
+const updateCheckInterval = 24 * time.Hour
+
+// idleState tracks user input so background work stops in forgotten terminal panes.
+type idleState struct {
+	lastActivity time.Time
+	idle         bool
+	// tickSuspended / prTickSuspended record which timer loops were dropped while idle, so resume
+	// restarts exactly those (restarting a loop that is still running would double its rate).
+	tickSuspended   bool
+	prTickSuspended bool
+}
+
+// trackActivity records key and mouse input. When it ends an idle period it returns the command
+// that restarts the suspended refresh loops; otherwise nil.
+func (m *Model) trackActivity(msg tea.Msg) tea.Cmd {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+	default:
+		return nil
+	}
+	m.idleState.lastActivity = time.Now()
+	if !m.idleState.idle {
+		return nil
+	}
+	m.idleState.idle = false
+	m.appState.StatusMessage = i18n.T("status.idle_resumed")
+	var cmds []tea.Cmd
+	if m.idleState.tickSuspended {
+		m.idleState.tickSuspended = false
+		cmds = append(cmds, func() tea.Msg { return tickMsg(time.Now()) })
+	}
+	if m.idleState.prTickSuspended {
+		m.idleState.prTickSuspended = false
+		cmds = append(cmds, func() tea.Msg { return prstab.PrTickMsg(time.Now()) })
+	}
+	return tea.Batch(cmds...)
+}
+
+// checkIdle reports whether no input has arrived within the configured idle timeout, and shows
+// the paused status the first time it does.
+func (m *Model) checkIdle() bool {
+	if m.idleState.idle {
+		return true
+	}
+	timeout := m.appState.Config.IdleTimeout()
+	if m.idleState.lastActivity.IsZero() {
+		m.idleState.lastActivity = time.Now()
+	}
+	if timeout <= 0 || time.Since(m.idleState.lastActivity) < timeout {
+		return false
+	}
+	m.idleState.idle = true
+	m.appState.StatusMessage = i18n.T("status.idle_paused")
+	return true
+}
+
+// maybeCheckForUpdates re-runs the release check when the last result is older than
+// updateCheckInterval. It does nothing until the startup check has finished, or in low-bandwidth mode.
+func (m *Model) maybeCheckForUpdates() {
+	info := version.GetUpdateInfo()
+	if info == nil || m.lowBandwidth() || time.Since(info.CheckedAt) < updateCheckInterval {
+		return
+	}
+	version.CheckForUpdates(m.ctx)
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+func TestIdleSuspendsTicksUntilInput(t *testing.T) {
+	m := newTestModel()
+	one := 1
+	m.appState.Config = &config.Config{IdleTimeoutMinutes: &one}
+	m.idleState.lastActivity = time.Now().Add(-2 * time.Minute)
+
+	if _, cmd := m.Update(tickMsg(time.Now())); cmd != nil {
+		t.Fatalf("idle tick should not reschedule, got a command")
+	}
+	if _, cmd := m.Update(prstab.PrTickMsg(time.Now())); cmd != nil {
+		t.Fatalf("idle PR tick should not reschedule, got a command")
+	}
+	if !m.idleState.idle || !m.idleState.tickSuspended || !m.idleState.prTickSuspended {
+		t.Fatalf("expected both loops suspended, got %+v", m.idleState)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatalf("input after idle should restart the refresh loops")
+	}
+	if m.idleState.idle || m.idleState.tickSuspended || m.idleState.prTickSuspended {
+		t.Fatalf("expected idle state cleared, got %+v", m.idleState)
+	}
+}
+
+func TestIdleDisabled(t *testing.T) {
+	m := newTestModel()
+	zero := 0
+	m.appState.Config = &config.Config{IdleTimeoutMinutes: &zero}
+	m.idleState.lastActivity = time.Now().Add(-24 * time.Hour)
+	if m.checkIdle() {
+		t.Fatalf("idle_timeout_minutes 0 should never go idle")
+	}
+}
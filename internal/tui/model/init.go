@@ -9,24 +9,30 @@ import (
 	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/statuslog"
 	bookmarktab "github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
 	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+	confirmtab "github.com/madicen/jj-tui/internal/tui/tabs/confirm"
 	conflicttab "github.com/madicen/jj-tui/internal/tui/tabs/conflict"
 	descedittab "github.com/madicen/jj-tui/internal/tui/tabs/descedit"
 	divergenttab "github.com/madicen/jj-tui/internal/tui/tabs/divergent"
+	errortab "github.com/madicen/jj-tui/internal/tui/tabs/error"
 	evologsplittab "github.com/madicen/jj-tui/internal/tui/tabs/evologsplit"
 	filedifftab "github.com/madicen/jj-tui/internal/tui/tabs/filediff"
-	errortab "github.com/madicen/jj-tui/internal/tui/tabs/error"
-	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
+	filehistorytab "github.com/madicen/jj-tui/internal/tui/tabs/filehistory"
+	filetreetab "github.com/madicen/jj-tui/internal/tui/tabs/filetree"
 	githublogintab "github.com/madicen/jj-tui/internal/tui/tabs/githublogin"
+	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
 	helptab "github.com/madicen/jj-tui/internal/tui/tabs/help"
-	initrepotab 	"github.com/madicen/jj-tui/internal/tui/tabs/initrepo"
+	initrepotab "github.com/madicen/jj-tui/internal/tui/tabs/initrepo"
+	pagertab "github.com/madicen/jj-tui/internal/tui/tabs/pager"
 	prformtab "github.com/madicen/jj-tui/internal/tui/tabs/prform"
 	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
 	settingstab "github.com/madicen/jj-tui/internal/tui/tabs/settings"
 	ticketformtab "github.com/madicen/jj-tui/internal/tui/tabs/ticketform"
 	ticketstab "github.com/madicen/jj-tui/internal/tui/tabs/tickets"
 	warningtab "github.com/madicen/jj-tui/internal/tui/tabs/warning"
+	workspacestab "github.com/madicen/jj-tui/internal/tui/tabs/workspaces"
 )
 
 // New creates a new Model
@@ -40,36 +46,44 @@ func New(ctx context.Context) *Model {
 	settingsTabModel := settingstab.NewModelWithConfig(cfg)
 
 	m := &Model{
-		ctx:              ctx,
-		zoneManager:      zm,
-		busySpinner:      newBusySpinner(),
+		ctx:         ctx,
+		zoneManager: zm,
+		busySpinner: newBusySpinner(),
 		appState: state.AppState{
 			ViewMode:      state.ViewCommitGraph,
 			StatusMessage: "Initializing...",
 			Loading:       false,
 		},
-		graphTabModel:    graphTabModel,
-		prsTabModel:      prstab.NewModel(zm),
-		branchesTabModel: branchestab.NewModel(zm),
-		ticketsTabModel:  ticketstab.NewModel(zm),
-		settingsTabModel: settingsTabModel,
-		helpTabModel:     helptab.NewModel(zm),
-		initRepoModel:    initrepotab.NewModel(),
-		errorModal:       errortab.NewModel(),
-		warningModal:     warningtab.NewModel(),
-		conflictModal:    conflicttab.NewModel(zm),
-		divergentModal:   divergenttab.NewModel(zm),
-		evologSplitModal: evologsplittab.NewModel(zm),
-		fileDiffModal:    filedifftab.NewModel(zm),
-		bookmarkModal:    bookmarktab.NewModel(zm),
-		prFormModal:      prformtab.NewModel(zm),
-		ticketFormModal:  ticketformtab.NewModel(zm),
-		desceditModal:    descedittab.NewModel(zm),
-		githubLoginModel: githublogintab.NewModel(zm),
+		graphTabModel:      graphTabModel,
+		prsTabModel:        prstab.NewModel(zm),
+		branchesTabModel:   branchestab.NewModel(zm),
+		ticketsTabModel:    ticketstab.NewModel(zm),
+		settingsTabModel:   settingsTabModel,
+		helpTabModel:       helptab.NewModel(zm),
+		workspacesTabModel: workspacestab.NewModel(zm),
+		initRepoModel:      initrepotab.NewModel(),
+		errorModal:         errortab.NewModel(),
+		warningModal:       warningtab.NewModel(),
+		confirmModal:       confirmtab.NewModel(),
+		commandPreviews:    newCommandPreviews(),
+		statusLog:          statuslog.New(statuslog.DefaultMax),
+		conflictModal:      conflicttab.NewModel(zm),
+		divergentModal:     divergenttab.NewModel(zm),
+		evologSplitModal:   evologsplittab.NewModel(zm),
+		fileDiffModal:      filedifftab.NewModel(zm),
+		pagerModal:         pagertab.NewModel(),
+		fileTreeModal:      filetreetab.NewModel(),
+		fileHistoryModal:   filehistorytab.NewModel(),
+		bookmarkModal:      bookmarktab.NewModel(zm),
+		prFormModal:        prformtab.NewModel(zm),
+		ticketFormModal:    ticketformtab.NewModel(zm),
+		desceditModal:      descedittab.NewModel(zm),
+		githubLoginModel:   githublogintab.NewModel(zm),
 	}
 	m.errorModal.SetZoneManager(zm)
 	m.initRepoModel.SetZoneManager(zm)
 	m.warningModal.SetZoneManager(zm)
+	m.confirmModal.SetZoneManager(zm)
 	m.settingsTabModel.SetZoneManager(zm)
 	m.githubLoginModel.SetZoneManager(zm)
 	m.appState.Config = cfg
@@ -88,6 +102,7 @@ func New(ctx context.Context) *Model {
 func NewWithServices(ctx context.Context, jjSvc *jj.Service, ghSvc *github.Service) *Model {
 	m := New(ctx)
 	m.appState.JJService = jjSvc
+	m.attachCommandPreview(jjSvc)
 	m.appState.GitHubService = ghSvc
 	return m
 }
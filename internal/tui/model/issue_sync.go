@@ -0,0 +1,123 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+	ticketstab "github.com/madicen/jj-tui/internal/tui/tabs/tickets"
+)
+
+// ticketPollInterval is how often the auto-refresh tick asks the ticket provider whether the
+// assigned tickets changed. Only providers with delta polling (GitHub Issues, Jira) are polled:
+// each poll transfers just the tickets updated since the last change, so it can run often.
+const ticketPollInterval = 15 * time.Second
+
+// issueSyncState keeps the Tickets list and GitHub issues in step with PRs: the ticket poll and
+// which merged PRs already had their issue closed.
+type issueSyncState struct {
+	lastPoll    time.Time
+	polling     bool
+	closedForPR map[int]bool
+}
+
+// pollTicketsCmd polls the ticket provider when ticketPollInterval has passed since the last poll.
+// Low-bandwidth mode doesn't poll.
+func (m *Model) pollTicketsCmd() tea.Cmd {
+	if m.appState.TicketService == nil || m.appState.DemoMode || m.lowBandwidth() || m.issueSync.polling ||
+		time.Since(m.issueSync.lastPoll) < ticketPollInterval {
+		return nil
+	}
+	cmd := ticketstab.PollTicketsCmd(m.appState.TicketService)
+	if cmd != nil {
+		m.issueSync.polling = true
+		m.issueSync.lastPoll = time.Now()
+	}
+	return cmd
+}
+
+// handleTicketsPolledMsg replaces the Tickets list when the poll found changes, e.g. an issue
+// closed on GitHub.
+func (m *Model) handleTicketsPolledMsg(msg ticketstab.TicketsPolledMsg) (tea.Model, tea.Cmd) {
+	m.issueSync.polling = false
+	if msg.Changed {
+		m.ticketsTabModel.UpdateTickets(msg.Tickets)
+		m.linkTicketBookmarks()
+	}
+	return m, nil
+}
+
+// handleIssueClosedForPRMsg reports the close and refreshes the Tickets list so the issue leaves it.
+func (m *Model) handleIssueClosedForPRMsg(msg ticketstab.IssueClosedForPRMsg) (tea.Model, tea.Cmd) {
+	m.appState.StatusMessage = msg.Status()
+	if msg.Err != nil {
+		delete(m.issueSync.closedForPR, msg.PRNumber)
+		return m, nil
+	}
+	m.issueSync.lastPoll = time.Time{}
+	return m, m.pollTicketsCmd()
+}
+
+// closeIssueForMergedPRCmd closes the issue linked to a merged PR's head bookmark, once per PR.
+// Returns nil when closing is turned off, the provider can't close issues, or no issue is linked.
+func (m *Model) closeIssueForMergedPRCmd(prNumber int, headBranch string) tea.Cmd {
+	if m.appState.TicketService == nil || m.appState.DemoMode || !m.appState.Config.CloseIssuesOnMerge() ||
+		m.issueSync.closedForPR[prNumber] {
+		return nil
+	}
+	key, label := m.ticketForBookmark(headBranch)
+	cmd := ticketstab.CloseIssueForMergedPRCmd(m.appState.TicketService, key, label, prNumber)
+	if cmd != nil {
+		if m.issueSync.closedForPR == nil {
+			m.issueSync.closedForPR = make(map[int]bool)
+		}
+		m.issueSync.closedForPR[prNumber] = true
+	}
+	return cmd
+}
+
+// closeIssuesForMergedPRsCmd closes the linked issues of PRs that were open in the previous PR
+// list and are merged in the current one, i.e. PRs merged outside jj-tui while it was running.
+func (m *Model) closeIssuesForMergedPRsCmd(wasOpen map[int]bool) tea.Cmd {
+	if m.appState.Repository == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, pr := range m.appState.Repository.PRs {
+		if pr.State == "merged" && wasOpen[pr.Number] {
+			cmds = append(cmds, m.closeIssueForMergedPRCmd(pr.Number, pr.HeadBranch))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// closeIssueAfterMerge closes the issue of a PR merged from the PRs tab. A merge that only enabled
+// auto-merge is left to the PR poll; one whose follow-up transitions the ticket is left to that.
+func (m *Model) closeIssueAfterMerge(msg prstab.PrMergedMsg) tea.Cmd {
+	if msg.AutoMerge {
+		return nil
+	}
+	if msg.FollowUp && slices.Contains(m.appState.Config.PRMergeFollowUpSteps(), config.MergeFollowUpTransitionTicket) {
+		if m.issueSync.closedForPR == nil {
+			m.issueSync.closedForPR = make(map[int]bool)
+		}
+		m.issueSync.closedForPR[msg.PRNumber] = true
+		return nil
+	}
+	return m.closeIssueForMergedPRCmd(msg.PRNumber, msg.HeadBranch)
+}
+
+func openPRNumbers(repo *internal.Repository) map[int]bool {
+	open := make(map[int]bool)
+	if repo != nil {
+		for _, pr := range repo.PRs {
+			if pr.State == "open" {
+				open[pr.Number] = true
+			}
+		}
+	}
+	return open
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tickets"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+	ticketstab "github.com/madicen/jj-tui/internal/tui/tabs/tickets"
+)
+
+// syncingTickets is a ticket service that can close issues for merged PRs and be polled.
+type syncingTickets struct {
+	*mock.TicketService
+	closed []string
+	list   []tickets.Ticket
+}
+
+func (s *syncingTickets) CloseForMergedPR(_ context.Context, key string, _ int) (bool, error) {
+	s.closed = append(s.closed, key)
+	return true, nil
+}
+
+func (s *syncingTickets) PollAssignedTickets(context.Context) ([]tickets.Ticket, bool, error) {
+	return s.list, true, nil
+}
+
+// runCmd runs cmd and any batch it returns, collecting the messages.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := unscoped(cmd())
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, runCmd(c)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
+
+// A PR seen open and then merged closes the issue its branch is named after, once; the poll that
+// follows drops the issue from the Tickets list.
+func TestIssueClosedWhenPRMerges(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := newTestModel()
+	defer m.Close()
+	svc := &syncingTickets{TicketService: mock.NewTicketService("github_issues")}
+	m.appState.TicketService = svc
+	m.ticketsTabModel.UpdateTickets([]tickets.Ticket{{Key: "#4", DisplayKey: "#4", Status: "Open"}})
+	m.appState.Repository.PRs = []internal.GitHubPR{
+		{Number: 12, State: "open", HeadBranch: "4-fix-typo"},
+		{Number: 13, State: "open", HeadBranch: "refactor"},
+	}
+
+	merged := prstab.PrsLoadedMsg{Prs: []internal.GitHubPR{
+		{Number: 12, State: "merged", HeadBranch: "4-fix-typo"},
+		{Number: 13, State: "merged", HeadBranch: "refactor"},
+	}}
+	_, cmd := m.Update(merged)
+	var closedMsg *ticketstab.IssueClosedForPRMsg
+	for _, msg := range runCmd(cmd) {
+		if c, ok := msg.(ticketstab.IssueClosedForPRMsg); ok {
+			closedMsg = &c
+		}
+	}
+	if closedMsg == nil || len(svc.closed) != 1 || svc.closed[0] != "#4" {
+		t.Fatalf("closed = %v, msg = %+v", svc.closed, closedMsg)
+	}
+
+	_, cmd = m.Update(*closedMsg)
+	if m.appState.StatusMessage != "PR #12 merged; closed #4" {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+	for _, msg := range runCmd(cmd) {
+		m.Update(msg)
+	}
+	if len(m.ticketsTabModel.GetTickets()) != 0 {
+		t.Fatalf("the poll should drop the closed issue, tickets = %+v", m.ticketsTabModel.GetTickets())
+	}
+	if m.closeIssueForMergedPRCmd(12, "4-fix-typo") != nil {
+		t.Fatal("an issue should be closed only once per PR")
+	}
+}
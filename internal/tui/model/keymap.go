@@ -0,0 +1,74 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/keymap"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// remapKey translates a key rebound in the keymap to the built-in key the active tab matches.
+// ok is false for the built-in key of an action that was moved elsewhere; it is dropped.
+// Keys typed into an input or answering a prompt are left alone.
+func (m *Model) remapKey(msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	scope, commands := m.keyScope()
+	if !commands {
+		return msg, true
+	}
+	return keymap.Translate(msg, scope)
+}
+
+// keyScope returns the keymap scope of the active tab, "" when only global keys apply (a
+// sub-view such as PR details or rebase destination picking, whose keys mean something else),
+// and commands=false when the tab is taking text or a prompt owns the keyboard.
+func (m *Model) keyScope() (scope keymap.Scope, commands bool) {
+	switch m.appState.ViewMode {
+	case state.ViewCommitGraph:
+		g := &m.graphTabModel
+		switch {
+		case m.graphTyping():
+			return "", false
+		case g.IsInRebaseMode() || g.IsInMergeMode():
+			return "", true
+		case g.IsGraphFocused():
+			return keymap.ScopeGraph, true
+		case g.IsStackFilesOpen():
+			return "", true
+		}
+		return keymap.ScopeFiles, true
+	case state.ViewPullRequests:
+		p := &m.prsTabModel
+		switch {
+		case m.prsTyping():
+			return "", false
+		case p.IsReviewCommentsOpen() || p.IsPRDetailOpen() || p.IsPushMode():
+			return "", true
+		}
+		return keymap.ScopePRs, true
+	case state.ViewTickets:
+		if m.ticketsTabModel.IsStatusChangeMode() || m.ticketsTabModel.IsTicketDetailOpen() {
+			return "", true
+		}
+		return keymap.ScopeTickets, true
+	case state.ViewBranches:
+		return keymap.ScopeBranches, !m.branchesTabModel.IsCapturingKeys()
+	case state.ViewWorkspaces:
+		if m.workspacesTabModel.IsSparseOpen() {
+			return "", !m.workspacesTabModel.IsCapturingKeys()
+		}
+		return keymap.ScopeWorkspaces, !m.workspacesTabModel.IsCapturingKeys()
+	case state.ViewHelp:
+		return "", !m.helpTabModel.IsFiltering()
+	}
+	return "", false
+}
+
+// graphTyping reports whether a graph tab input or dialog owns the keyboard.
+func (m *Model) graphTyping() bool {
+	g := &m.graphTabModel
+	return g.IsEditingDateFilter() || g.IsBulkDescribeOpen() || g.IsMergeDialogOpen() || g.IsEditingFileFilter() || g.IsAliasPickerOpen() || g.IsTrashOpen() || g.IsAbsorbPreviewOpen() || g.IsHunkSplitFocused() || g.IsEditingGraphSearch()
+}
+
+// prsTyping reports whether the review, reply or merge form owns the keyboard.
+func (m *Model) prsTyping() bool {
+	return m.prsTabModel.IsReviewFormOpen() || m.prsTabModel.IsReviewReplyOpen() || m.prsTabModel.IsMergeFormOpen()
+}
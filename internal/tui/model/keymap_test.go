@@ -0,0 +1,52 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/keymap"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/tabs/help/shortcuts"
+)
+
+// A rebound key runs its action and the action's old key does nothing; keys typed into an
+// input are not translated. The help view, tab bar and action bar show the active keys.
+func TestKeymapRebinding(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := keymap.Set(map[string]string{"tab.prs": "ctrl+p", "commit.abandon": "Q"}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = keymap.Set(nil) })
+	m := newTestModel()
+	defer m.Close()
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if m.appState.ViewMode != state.ViewCommitGraph {
+		t.Fatal("p should no longer open the PRs tab")
+	}
+	if !strings.Contains(m.View(), "Abandon (Q)") {
+		t.Error("the action bar should show the rebound abandon key")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if m.appState.ViewMode != state.ViewPullRequests {
+		t.Fatal("ctrl+p should open the PRs tab")
+	}
+
+	m.appState.ViewMode = state.ViewCommitGraph
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !m.graphTabModel.IsEditingGraphSearch() {
+		t.Fatal("/ should open the graph search")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Q")})
+	if _, commands := m.keyScope(); commands || !m.graphTabModel.IsEditingGraphSearch() {
+		t.Fatal("Q typed into the search should stay text")
+	}
+
+	if !strings.Contains(m.View(), "PRs (^p)") {
+		t.Error("the tab bar should show the rebound PRs tab key")
+	}
+	if help := strings.Join(shortcuts.NewModel(nil).Lines(), "\n"); !strings.Contains(help, "^p") {
+		t.Error("help should list the rebound PRs tab key")
+	}
+}
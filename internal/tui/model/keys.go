@@ -2,6 +2,7 @@ package model
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/i18n"
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/util"
 )
@@ -31,6 +32,13 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Overlay: confirmation before a destructive action. Returns ConfirmedMsg or CancelledMsg.
+	if m.confirmModal.IsShown() {
+		updated, cmd := m.confirmModal.Update(msg)
+		m.confirmModal = updated
+		return m, cmd
+	}
+
 	// View-specific modals: forward to the active view's submodel.
 	switch m.appState.ViewMode {
 	case state.ViewEditDescription:
@@ -76,6 +84,14 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Popup mode is pinned to one view: tab switching keys do nothing.
+	if m.popupView != "" {
+		switch msg.String() {
+		case "g", "p", "t", "b", "w", ",", "h", "?", "tab":
+			return m, nil
+		}
+	}
+
 	// Global shortcuts (and Esc/Tab when not in a modal).
 	switch msg.String() {
 	case "ctrl+q", "ctrl+c":
@@ -89,6 +105,8 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleNavigateToTicketsTab()
 	case "b":
 		return m.handleNavigateToBranchesTab()
+	case "w":
+		return m.handleNavigateToWorkspacesTab()
 	case ",":
 		return m.handleNavigateToSettingsTab()
 	case "h", "?":
@@ -99,13 +117,22 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleUndo()
 	case "ctrl+y":
 		return m.handleRedo()
+	case "ctrl+l":
+		return m, m.openStatusLog()
+	case "ctrl+e":
+		m.togglePreviewCommands()
+		return m, nil
+	case "ctrl+w":
+		return m.handleUpdateStale()
+	case "ctrl+o":
+		return m.openRepoSwitcher()
 	case "esc":
 		if m.appState.ViewMode == state.ViewTickets && m.ticketsTabModel.IsStatusChangeMode() {
 			m.ticketsTabModel.SetStatusChangeMode(false)
-			m.appState.StatusMessage = "Ready"
+			m.appState.StatusMessage = i18n.T("status.ready")
 			return m, nil
 		}
-		if m.appState.ViewMode != state.ViewCommitGraph {
+		if m.appState.ViewMode != state.ViewCommitGraph && m.popupView == "" {
 			m.appState.ViewMode = state.ViewCommitGraph
 		}
 	case "tab":
@@ -64,6 +64,10 @@ func (m *Model) showCenteredBusyOverlay() bool {
 	if m.width <= 0 || m.height <= 0 {
 		return false
 	}
+	// A command held by preview mode keeps Loading set; its confirmation must stay visible.
+	if m.confirmModal.IsShown() {
+		return false
+	}
 	if m.appState.Loading && shouldShowLoadingOverlay(m.appState.ViewMode, m.appState.StatusMessage) {
 		return true
 	}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+// lowBandwidth reports whether background network use is off (Settings → Advanced → Low-bandwidth
+// mode): PRs and tickets are fetched when their tab is opened or on Ctrl+r, never on a timer.
+func (m *Model) lowBandwidth() bool {
+	return m.appState.Config.LowBandwidthMode()
+}
+
+// resumePRTickCmd restarts the PR auto-refresh loop when saving settings turned low-bandwidth mode
+// off. The loop stopped at its first tick after the mode was turned on.
+func (m *Model) resumePRTickCmd(wasLow bool) tea.Cmd {
+	if !wasLow || m.lowBandwidth() || !m.isGitHubAvailable() || !m.canReadPRs() {
+		return nil
+	}
+	return prstab.PrTickCmd()
+}
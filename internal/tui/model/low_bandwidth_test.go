@@ -0,0 +1,44 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/gitlab"
+	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tui/data"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+func TestLowBandwidthModeSkipsBackgroundFetches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"low_bandwidth": true}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("JJ_TUI_CONFIG", path)
+	if prstab.PrTickCmd() != nil {
+		t.Fatal("PR auto-refresh should be off")
+	}
+	gl, err := gitlab.NewService("http://127.0.0.1:1", "group/repo", "tok")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newTestModel()
+	defer m.Close()
+	m.appState.Config, _ = config.Load()
+	m.Update(data.AuxServicesReadyMsg{GitLabService: gl, TicketService: mock.NewTicketService("jira")})
+	if m.appState.Loading {
+		t.Error("PRs should not load until the PRs tab is opened")
+	}
+	if m.pollTicketsCmd() != nil {
+		t.Error("tickets should not be polled")
+	}
+
+	// Opening the tab is an explicit request, so it still loads.
+	if _, cmd := m.handleNavigateToPRTab(); cmd == nil {
+		t.Error("opening the PRs tab should load PRs")
+	}
+}
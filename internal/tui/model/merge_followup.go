@@ -0,0 +1,77 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	bookmarktab "github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
+	confirmtab "github.com/madicen/jj-tui/internal/tui/tabs/confirm"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// mergeFollowUpCmd runs the pr_merge_follow_up steps for a merged PR. The head commit and the
+// commits stacked on it are taken from the graph as it was before the merge, and the ticket from
+// the bookmark's ticket link or its name.
+func (m *Model) mergeFollowUpCmd(msg prstab.PrMergedMsg) tea.Cmd {
+	cfg := m.appState.Config
+	f := prstab.MergeFollowUp{
+		PRNumber:     msg.PRNumber,
+		Steps:        cfg.PRMergeFollowUpSteps(),
+		Bookmark:     msg.HeadBranch,
+		Remote:       cfg.PushRemoteOrDefault(),
+		TicketStatus: cfg.PRMergeFollowUpTicketStatusOrDefault(),
+	}
+	if repo := m.appState.Repository; repo != nil && f.Bookmark != "" {
+		for _, c := range repo.Graph.Commits {
+			for _, b := range c.Branches {
+				if util.LocalBookmarkName(util.BookmarkNameForRevset(b)) == f.Bookmark {
+					f.HeadCommitID = c.ID
+				}
+			}
+		}
+		for _, c := range repo.Graph.Commits {
+			if f.HeadCommitID != "" && !c.Immutable && slices.Contains(c.Parents, f.HeadCommitID) {
+				f.HasDependents = true
+			}
+		}
+	}
+	f.TicketKey, f.TicketLabel = m.ticketForBookmark(f.Bookmark)
+	run := prstab.MergeFollowUpCmd(m.appState.JJService, m.appState.TicketService, f, m.appState.DemoMode)
+	if f.Bookmark == "" || m.appState.DemoMode || !slices.Contains(f.Steps, config.MergeFollowUpDeleteBookmark) {
+		return run
+	}
+	// Deleting the bookmark asks first; declining runs the other steps.
+	keep := f
+	keep.Steps = slices.DeleteFunc(slices.Clone(f.Steps), func(s string) bool { return s == config.MergeFollowUpDeleteBookmark })
+	deleteArgs, pushArgs := jj.DeleteMergedBookmarkArgs(f.Bookmark, f.Remote)
+	return m.askConfirm(confirmtab.Prompt{
+		Action:    "delete_bookmark",
+		Title:     "Delete merged bookmark",
+		Message:   fmt.Sprintf("PR #%d merged. Delete bookmark %s here and on %s?", f.PRNumber, f.Bookmark, f.Remote),
+		Command:   jj.CommandLine(deleteArgs) + " && " + jj.CommandLine(pushArgs),
+		OnConfirm: run,
+		OnCancel:  prstab.MergeFollowUpCmd(m.appState.JJService, m.appState.TicketService, keep, m.appState.DemoMode),
+	})
+}
+
+// ticketForBookmark returns the key and display label of the ticket linked to a bookmark, from
+// the bookmark's ticket link or its name ("" when none).
+func (m *Model) ticketForBookmark(bookmark string) (key, label string) {
+	if bookmark == "" {
+		return "", ""
+	}
+	if ref, ok := m.bookmarkModal.GetTicketBookmarkRefs()[bookmark]; ok {
+		return ref.ID, ref.Key
+	}
+	if t, ok := bookmarktab.TicketForBookmarkName(bookmark, m.ticketsTabModel.GetTickets()); ok {
+		if t.DisplayKey == "" {
+			return t.Key, t.Key
+		}
+		return t.Key, t.DisplayKey
+	}
+	return "", ""
+}
@@ -4,6 +4,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	overlay "github.com/madicen/bubble-overlay"
 	"github.com/madicen/jj-tui/internal/tui/state"
+	confirmtab "github.com/madicen/jj-tui/internal/tui/tabs/confirm"
 	"github.com/madicen/jj-tui/internal/tui/tabs/githublogin"
 )
 
@@ -16,6 +17,10 @@ import (
 // converge on the same teardown.
 func (m *Model) chromedSlot() (key, content, title string, closeCmd tea.Cmd) {
 	if m.initRepoModel.Path() != "" {
+		if m.initRepoModel.Switching() {
+			return "initrepo", m.initRepoModel.View(), "Switch repository",
+				state.NavigateTarget{Kind: state.NavigateDismissInit}.Cmd()
+		}
 		return "initrepo", m.initRepoModel.View(), "Initialize repository",
 			state.NavigateTarget{Kind: state.NavigateDismissInit, StatusMessage: "Init cancelled"}.Cmd()
 	}
@@ -33,6 +38,10 @@ func (m *Model) chromedSlot() (key, content, title string, closeCmd tea.Cmd) {
 		return "warning", m.warningModal.View(), title,
 			state.NavigateTarget{Kind: state.NavigateWarningCancel, StatusMessage: "Warning dismissed"}.Cmd()
 	}
+	if m.confirmModal.IsShown() {
+		return "confirm", m.confirmModal.View(), m.confirmModal.GetTitle(),
+			func() tea.Msg { return confirmtab.CancelledMsg{} }
+	}
 	switch m.appState.ViewMode {
 	case state.ViewEditDescription:
 		return "descedit", m.desceditModal.View(), "Edit description",
@@ -77,7 +86,9 @@ func (m *Model) chromedSlot() (key, content, title string, closeCmd tea.Cmd) {
 		// Callers (e.g. evolog split) can set a context-specific overlay
 		// title; the chrome tab mirrors it, falling back to "File diff".
 		title := m.fileDiffModal.OverlayTitle()
-		if title == "" {
+		if title == "" && m.fileDiffModal.IsConflict() {
+			title = "Conflict"
+		} else if title == "" {
 			title = "File diff"
 		}
 		return "filediff", m.fileDiffModal.View(), title,
@@ -109,13 +120,24 @@ func (m *Model) layoutContentMode() state.ViewMode {
 			}
 		}
 		return state.ViewCommitGraph
-	case state.ViewDivergentCommit, state.ViewEvologSplit, state.ViewFileDiff:
+	case state.ViewFileDiff:
+		return m.fileDiffUnderlay()
+	case state.ViewDivergentCommit, state.ViewEvologSplit:
 		return state.ViewCommitGraph
 	default:
 		return m.appState.ViewMode
 	}
 }
 
+// fileDiffUnderlay is the tab under the file diff viewer: the PR list for a PR diff (f), else the
+// graph (commit files, evolog split patches).
+func (m *Model) fileDiffUnderlay() state.ViewMode {
+	if m.modalUnderlayValid && m.modalUnderlayView == state.ViewPullRequests && !m.evologSplitModal.IsShown() {
+		return state.ViewPullRequests
+	}
+	return state.ViewCommitGraph
+}
+
 // tabHighlightMode selects which tab appears active in the header (under form modals, show prior tab).
 func (m *Model) tabHighlightMode() state.ViewMode {
 	if m.initRepoModel.Path() != "" {
@@ -131,7 +153,9 @@ func (m *Model) tabHighlightMode() state.ViewMode {
 		if m.bookmarkConflictReturnValid {
 			return m.bookmarkConflictReturnView
 		}
-	case state.ViewDivergentCommit, state.ViewEvologSplit, state.ViewFileDiff:
+	case state.ViewFileDiff:
+		return m.fileDiffUnderlay()
+	case state.ViewDivergentCommit, state.ViewEvologSplit:
 		return state.ViewCommitGraph
 	}
 	return vm
@@ -2,9 +2,11 @@ package model
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,31 +18,47 @@ import (
 	overlay "github.com/madicen/bubble-overlay"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/crash"
+	"github.com/madicen/jj-tui/internal/events"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/ipc"
+	"github.com/madicen/jj-tui/internal/tickets"
 	aitab "github.com/madicen/jj-tui/internal/tui/ai"
+	"github.com/madicen/jj-tui/internal/tui/avatar"
 	"github.com/madicen/jj-tui/internal/tui/data"
 	"github.com/madicen/jj-tui/internal/tui/genmenu"
 	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/statuslog"
 	bookmarktab "github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
 	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+	confirmtab "github.com/madicen/jj-tui/internal/tui/tabs/confirm"
 	conflicttab "github.com/madicen/jj-tui/internal/tui/tabs/conflict"
 	descedittab "github.com/madicen/jj-tui/internal/tui/tabs/descedit"
 	divergenttab "github.com/madicen/jj-tui/internal/tui/tabs/divergent"
 	errortab "github.com/madicen/jj-tui/internal/tui/tabs/error"
 	evologsplittab "github.com/madicen/jj-tui/internal/tui/tabs/evologsplit"
 	filedifftab "github.com/madicen/jj-tui/internal/tui/tabs/filediff"
+	filehistorytab "github.com/madicen/jj-tui/internal/tui/tabs/filehistory"
+	filetreetab "github.com/madicen/jj-tui/internal/tui/tabs/filetree"
 	githublogintab "github.com/madicen/jj-tui/internal/tui/tabs/githublogin"
 	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
 	helptab "github.com/madicen/jj-tui/internal/tui/tabs/help"
+	"github.com/madicen/jj-tui/internal/tui/tabs/help/audit"
 	"github.com/madicen/jj-tui/internal/tui/tabs/help/commandhistory"
+	"github.com/madicen/jj-tui/internal/tui/tabs/help/diagnostics"
 	initrepotab "github.com/madicen/jj-tui/internal/tui/tabs/initrepo"
+	pagertab "github.com/madicen/jj-tui/internal/tui/tabs/pager"
 	prformtab "github.com/madicen/jj-tui/internal/tui/tabs/prform"
 	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
 	settingstab "github.com/madicen/jj-tui/internal/tui/tabs/settings"
 	ticketformtab "github.com/madicen/jj-tui/internal/tui/tabs/ticketform"
 	ticketstab "github.com/madicen/jj-tui/internal/tui/tabs/tickets"
 	warningtab "github.com/madicen/jj-tui/internal/tui/tabs/warning"
+	workspacestab "github.com/madicen/jj-tui/internal/tui/tabs/workspaces"
 	"github.com/madicen/jj-tui/internal/tui/util"
+	"github.com/madicen/jj-tui/internal/tui/xref"
 )
 
 // Model is the main TUI model using bubblezone for mouse handling.
@@ -62,10 +80,31 @@ type Model struct {
 	modalUnderlayValid bool
 	modalUnderlayView  state.ViewMode
 	// Selection state lives in tab models: graph (commit/file), prs, tickets, branches
-	redoOperationID string
+	// redoDepth is how many undone operations ^y can redo (0 hides it); other operations reset it.
+	redoDepth int
+	// statusAfterReload replaces "Loaded N commits" once after the next foreground reload, so
+	// the result of the action that triggered it (e.g. which operation undo reverted) stays visible.
+	statusAfterReload string
+	// statusLog keeps the recent status messages for the message log (see status_log.go);
+	// loggedStatus is the last StatusMessage recorded there.
+	statusLog    *statuslog.Log
+	loggedStatus string
 	// Silent background graph refresh (handleTickMsg) runs concurrently per Bubble Tea Batch;
 	// without this guard, overlapping GetRepository calls can retain multi-copy graphs and spike RSS.
 	silentReloadInFlight bool
+	// repoRefresh reloads the graph when the repo watcher sees a change (see refresh.go).
+	repoRefresh repoRefreshState
+	// pendingChanges counts working-copy edits jj hasn't snapshotted yet (status bar dirty indicator).
+	pendingChanges jj.PendingChanges
+	// issueSync polls the ticket list and closes the issues of merged PRs (see issue_sync.go).
+	issueSync issueSyncState
+	// idleState suspends the refresh loops after a period without key or mouse input (see idle.go).
+	idleState idleState
+	// repoGen counts repository switches; results started for an earlier repository are dropped
+	// (see scopeToRepo).
+	repoGen int
+	// statusSegments are the configured extra status bar items (see status_segments.go).
+	statusSegments []statusSegment
 	// Monotonic id for optional LLM requests; stale responses are ignored.
 	aiGenReqID int
 	// aiGenOverlayActive shows the centered spinner while Generate*Cmd runs (form modals + description editor).
@@ -81,17 +120,21 @@ type Model struct {
 	pendingAIRetryOverrideProfile string
 
 	// Tab-specific models (own all tab/modal state; main model does not duplicate)
-	graphTabModel    graphtab.GraphModel
-	prsTabModel      prstab.Model
-	branchesTabModel branchestab.Model
-	ticketsTabModel  ticketstab.Model
-	settingsTabModel settingstab.Model
-	helpTabModel     helptab.Model
+	graphTabModel      graphtab.GraphModel
+	prsTabModel        prstab.Model
+	branchesTabModel   branchestab.Model
+	ticketsTabModel    ticketstab.Model
+	settingsTabModel   settingstab.Model
+	helpTabModel       helptab.Model
+	workspacesTabModel workspacestab.Model
 
 	// Modal models (dialogs and modals)
-	initRepoModel    initrepotab.Model
-	errorModal       errortab.Model
-	warningModal     warningtab.Model
+	initRepoModel initrepotab.Model
+	errorModal    errortab.Model
+	warningModal  warningtab.Model
+	confirmModal  confirmtab.Model
+	// commandPreviews carries jj commands held by preview mode (Ctrl+e) to the confirm modal.
+	commandPreviews  *commandPreviews
 	conflictModal    conflicttab.Model
 	divergentModal   divergenttab.Model
 	evologSplitModal evologsplittab.Model
@@ -115,6 +158,14 @@ type Model struct {
 	ticketFormModal                 ticketformtab.Model
 	desceditModal                   descedittab.Model
 	githubLoginModel                githublogintab.Model
+	pagerModal                      pagertab.Model
+	// pagerReturnView is the view (tab or modal) restored when the pager closes.
+	pagerReturnView state.ViewMode
+	fileTreeModal   filetreetab.Model
+	// fileHistoryModal traces one file; fileHistoryReturnView is the graph or file browser it was
+	// opened from.
+	fileHistoryModal      filehistorytab.Model
+	fileHistoryReturnView state.ViewMode
 
 	busySpinner spinner.Model
 
@@ -145,6 +196,12 @@ type Model struct {
 	// at the placeholder's height ("mostly minimized"). Comparing the signature
 	// per frame lets View() re-seed only when the natural size actually changed.
 	lastEvologContentSig string
+
+	// Popup mode (--popup): popupView is the pinned view ("" = off), popupEntered is set once its
+	// data load has been started, and popupResult is the item picked with Enter.
+	popupView    string
+	popupEntered bool
+	popupResult  string
 }
 
 // doPollMsg is a message used to trigger a GitHub token poll.
@@ -153,6 +210,9 @@ type doPollMsg struct{}
 // estimatedContentHeight returns height available for tab content (excluding header/status).
 // Used in Update() when delegating to tabs so viewport/list dimensions are correct for scroll handling.
 func (m *Model) estimatedContentHeight() int {
+	if m.popupView != "" {
+		return max(m.height-1, 1)
+	}
 	return max(m.height-4, 1)
 }
 
@@ -326,6 +386,7 @@ func (m *Model) buildSettingsViewOpts() settingstab.ViewOpts {
 		Config:            m.appState.Config,
 		ContentHeight:     m.estimatedContentHeight(),
 		GhAvailable:       ghLookErr == nil,
+		GitHubPermissions: m.appState.GitHubPermissions,
 	}
 }
 
@@ -335,14 +396,30 @@ const autoRefreshInterval = 5 * time.Second
 
 // tickCmd returns a command that sends a tick after the refresh interval.
 func (m *Model) tickCmd() tea.Cmd {
+	if m.appState.SafeMode {
+		return nil
+	}
 	return tea.Tick(autoRefreshInterval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
-// isGitHubAvailable returns true if GitHub functionality is available (real service or demo mode).
+// isGitHubAvailable returns true if PR functionality is available (a GitHub or GitLab service, or
+// demo mode). GitHub-only features check GitHubService themselves.
 func (m *Model) isGitHubAvailable() bool {
-	return m.appState.GitHubService != nil || m.appState.DemoMode
+	return m.appState.Forge() != nil || m.appState.DemoMode
+}
+
+// syncPushMode hands push_mode to the PRs tab, which becomes the Push tab for plain git and
+// Gerrit workflows.
+func (m *Model) syncPushMode() {
+	cfg := m.appState.Config
+	m.prsTabModel.SetPushMode(cfg.PushModeOrDefault(), cfg.PushRemoteOrDefault(), cfg.GerritBranchOrDefault())
+}
+
+// canReadPRs reports whether the GitHub token may list PRs (true until a probe says otherwise).
+func (m *Model) canReadPRs() bool {
+	return m.appState.GitHubPermissions.Can(github.CapReadPRs)
 }
 
 // isSelectedCommitValid returns true if selected commit index points to a valid commit.
@@ -355,9 +432,13 @@ func (m *Model) isSelectedCommitValid() bool {
 // applyRepositoryLoaded applies a loaded repository from data or actions package (shared logic).
 func (m *Model) applyRepositoryLoaded(repo *internal.Repository) (*Model, tea.Cmd) {
 	m.silentReloadInFlight = false
+	m.noteRepositoryLoaded()
+	m.pendingChanges = jj.PendingChanges{}
 	var oldPRs []internal.GitHubPR
+	var newlyConflicted []internal.Commit
 	if m.appState.Repository != nil {
 		oldPRs = m.appState.Repository.PRs
+		newlyConflicted = jj.NewlyConflicted(m.appState.Repository.Graph.Commits, repo.Graph.Commits)
 	}
 	m.appState.Repository = repo
 	m.appState.Repository.PRs = oldPRs
@@ -365,23 +446,32 @@ func (m *Model) applyRepositoryLoaded(repo *internal.Repository) (*Model, tea.Cm
 	if m.appState.JJService == nil {
 		jjSvc, _ := jj.NewService("")
 		m.appState.JJService = jjSvc
+		m.attachCommandPreview(jjSvc)
+	}
+	m.appState.StatusMessage = i18n.T("status.loaded_commits", len(repo.Graph.Commits))
+	if m.statusAfterReload != "" {
+		m.appState.StatusMessage = m.statusAfterReload
+		m.statusAfterReload = ""
 	}
-	m.appState.StatusMessage = fmt.Sprintf("Loaded %d commits", len(repo.Graph.Commits))
+	m.graphTabModel.SetBookmarkPrefix(m.appState.Config.BookmarkNamespace())
+	m.graphTabModel.SetWrapSubjects(m.appState.Config.WrapsGraphSubjects())
 	m.graphTabModel.UpdateRepository(m.appState.Repository)
 	m.prsTabModel.UpdateRepository(m.appState.Repository)
 	m.prsTabModel.SetGithubService(m.isGitHubAvailable())
+	m.syncPushMode()
 	m.branchesTabModel.UpdateRepository(m.appState.Repository)
 	m.ticketsTabModel.UpdateRepository(m.appState.Repository)
 	m.settingsTabModel.UpdateRepository(m.appState.Repository)
 	m.helpTabModel.UpdateRepository(m.appState.Repository)
+	m.linkTicketBookmarks()
 	var cmds []tea.Cmd
 	cmds = append(cmds, m.tickCmd())
-	if m.appState.GitHubService != nil {
+	if m.appState.Forge() != nil && m.canReadPRs() && !m.lowBandwidth() {
 		existing := 0
 		if m.appState.Repository != nil {
 			existing = len(m.appState.Repository.PRs)
 		}
-		cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.GitHubService, m.appState.GithubInfo, m.appState.DemoMode, existing)))
+		cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.Forge(), m.appState.GithubInfo, m.appState.DemoMode, existing)))
 	}
 	commits := repo.Graph.Commits
 	if len(commits) > 0 {
@@ -392,9 +482,18 @@ func (m *Model) applyRepositoryLoaded(repo *internal.Repository) (*Model, tea.Cm
 		m.graphTabModel.SelectCommit(idx)
 		cmds = append(cmds, graphtab.LoadChangedFilesCmd(m.appState.JJService, commits[idx].ChangeID))
 	}
+	if len(newlyConflicted) > 0 {
+		// A rebase, squash or similar left commits conflicted; summarize them once counted.
+		cmds = append(cmds, data.ConflictSummaryCmd(m.appState.JJService, newlyConflicted))
+	}
 	return m, tea.Batch(cmds...)
 }
 
+// linkTicketBookmarks links bookmarks whose names encode a loaded ticket's key to that ticket.
+func (m *Model) linkTicketBookmarks() {
+	m.bookmarkModal.LinkTicketBookmarks(m.appState.Repository, m.ticketsTabModel.GetTickets())
+}
+
 // bookmarksNeedingPRLookup collects local bookmark names in the graph that should be resolved to an
 // open PR via a targeted query. It skips the default branch and bookmarks already matched to an open
 // PR in the current list, and caps the count so a graph with many bookmarks can't fan out unboundedly.
@@ -438,7 +537,7 @@ func (m *Model) bookmarksNeedingPRLookup() []string {
 
 // refreshRepository starts a refresh of the repository data.
 func (m *Model) refreshRepository() tea.Cmd {
-	m.appState.StatusMessage = "Refreshing..."
+	m.appState.StatusMessage = i18n.T("status.refreshing")
 	var cmds []tea.Cmd
 	if m.appState.JJService == nil {
 		cmds = append(cmds, data.InitializeServices(m.appState.DemoMode))
@@ -446,14 +545,17 @@ func (m *Model) refreshRepository() tea.Cmd {
 		cmds = append(cmds, data.LoadRepository(m.appState.JJService))
 		// Branches tab keeps its own list (trunk graph, HasConflict); ^r must reload it too or diverged
 		// bookmarks look stale after resolve until the user switches tabs or something else loads branches.
-		cmds = append(cmds, branchestab.LoadBranchesCmd(m.appState.JJService, m.settingsTabModel.GetSettingsBranchLimit()))
+		cmds = append(cmds, branchestab.LoadBranchesCmd(m.appState.JJService))
+		if m.prsTabModel.IsPushMode() {
+			cmds = append(cmds, prstab.LoadChangesCmd(m.appState.JJService, m.appState.Config.PushRemoteOrDefault()))
+		}
 	}
-	if m.isGitHubAvailable() {
+	if m.isGitHubAvailable() && m.canReadPRs() {
 		existing := 0
 		if m.appState.Repository != nil {
 			existing = len(m.appState.Repository.PRs)
 		}
-		cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.GitHubService, m.appState.GithubInfo, m.appState.DemoMode, existing)))
+		cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.Forge(), m.appState.GithubInfo, m.appState.DemoMode, existing)))
 	}
 	svc := m.appState.TicketService
 	if svc != nil && !util.IsNilInterface(svc) {
@@ -474,15 +576,104 @@ func (m *Model) createIsZoneClickedFuncWithEvent(event tea.MouseMsg) func(string
 
 // processGraphRequest runs a graph request via the graph tab; ApplyResult mutates app and returns cmd.
 func (m *Model) processGraphRequest(r graphtab.Request) (tea.Model, tea.Cmd) {
-	if r.Checkout || r.Squash || r.Abandon || r.NewCommit || r.PerformRebase || r.DragRebase || r.ResolveDivergent != nil || r.CreateBookmark || r.DeleteBookmark || r.CreatePR || r.UpdatePR || r.MoveFileUp || r.MoveFileDown || r.RevertFile || r.MoveDeltaOntoOrigin || r.StartEvologSplit || r.ResolveBookmarkConflict {
-		m.redoOperationID = ""
+	if r.Checkout || r.Squash || r.Abandon || r.NewCommit || r.PerformRebase || r.DragRebase || r.ResolveDivergent != nil || r.CreateBookmark || r.DeleteBookmark || r.CreatePR || r.UpdatePR || r.MoveFileUp || r.MoveFileDown || r.RevertFile || r.AbsorbFile || r.Absorb || r.PerformDuplicate || r.Backout || r.MoveWorkOnto || r.Parallelize != nil || r.MergeCommits != nil || r.RestoreTrash != nil || r.InsertBefore || r.InsertAfter || r.MoveDeltaOntoOrigin || r.StartEvologSplit || r.ResolveBookmarkConflict {
+		m.redoDepth = 0
 	}
 	ctx := graphtab.BuildRequestContextFrom(m)
 	res := graphtab.HandleRequest(r, ctx)
+	if res.Cmd != nil && res.Status == "" {
+		// Destructive requests wait for the confirmation; the result (and its pinned change IDs)
+		// is applied as-is once the user accepts.
+		if p, ok := graphtab.ConfirmPrompt(r, ctx); ok {
+			p.OnConfirm = func() tea.Msg { return confirmedGraphResult{res: res} }
+			if r.Squash && m.asksConfirm(p) {
+				return m, loadSquashPromptCmd(ctx.JJService, p, res, ctx.Repository.Graph.Commits[ctx.SelectedCommit].ChangeID)
+			}
+			return m, m.askConfirm(p)
+		}
+	}
 	cmd := graphtab.ApplyResult(res, &m.graphTabModel, ctx, &m.appState)
 	return m, m.wrapGraphTabCmd(cmd)
 }
 
+// confirmedGraphResult carries a graph request's result past its confirmation prompt.
+type confirmedGraphResult struct {
+	res graphtab.Result
+}
+
+// loadSquashPromptCmd loads the message the squash will use, so the prompt shows the exact jj
+// command, and has the held result run that same command on confirm.
+func loadSquashPromptCmd(svc *jj.Service, p confirmtab.Prompt, res graphtab.Result, changeID string) tea.Cmd {
+	return func() tea.Msg {
+		message, err := svc.SquashMessage(context.Background(), changeID)
+		if err != nil {
+			return util.ErrorMsg{Err: fmt.Errorf("failed to squash: %w", err)}
+		}
+		p.Command = jj.CommandLine(jj.SquashArgs(changeID, message))
+		res.Cmd = graphtab.SquashWithMessage(svc, changeID, message)
+		p.OnConfirm = func() tea.Msg { return confirmedGraphResult{res: res} }
+		return confirmtab.AskMsg{Prompt: p}
+	}
+}
+
+// asksConfirm reports whether askConfirm would show the modal for p. In preview mode a jj
+// command is shown before it runs anyway, so prompts naming one don't ask twice.
+func (m *Model) asksConfirm(p confirmtab.Prompt) bool {
+	if p.Command != "" && m.appState.JJService != nil && m.appState.JJService.PreviewsCommands() {
+		return false
+	}
+	return m.appState.Config.ConfirmsAction(p.Action)
+}
+
+// askConfirm shows the confirmation modal for p, or runs p.OnConfirm right away when the user
+// turned the prompt off for p.Action.
+func (m *Model) askConfirm(p confirmtab.Prompt) tea.Cmd {
+	if !m.asksConfirm(p) {
+		if p.Status != "" {
+			m.appState.StatusMessage = p.Status
+		}
+		return p.OnConfirm
+	}
+	m.confirmModal.Show(p)
+	return nil
+}
+
+// handleConfirmed runs an accepted prompt and, when "don't ask again" was ticked, turns the
+// prompt off in the loaded and the saved config.
+func (m *Model) handleConfirmed(msg confirmtab.ConfirmedMsg) tea.Cmd {
+	if msg.DontAskAgain && msg.Prompt.Action != "" {
+		if m.appState.Config == nil {
+			m.appState.Config = &config.Config{}
+		}
+		setConfirmAction(m.appState.Config, msg.Prompt.Action)
+		if saved, _ := config.Load(); saved != nil {
+			setConfirmAction(saved, msg.Prompt.Action)
+			_ = saved.Save()
+		}
+	}
+	if msg.Prompt.Status != "" {
+		m.appState.StatusMessage = msg.Prompt.Status
+	}
+	return msg.Prompt.OnConfirm
+}
+
+func setConfirmAction(cfg *config.Config, action string) {
+	if cfg.ConfirmActions == nil {
+		cfg.ConfirmActions = map[string]bool{}
+	}
+	cfg.ConfirmActions[action] = false
+}
+
+// conflictTakeConfirmedMsg carries a conflict resolution past its confirmation prompt.
+type conflictTakeConfirmedMsg filedifftab.ConflictTakeMsg
+
+// resolveConflict rewrites the conflicted file with the chosen side(s).
+func (m *Model) resolveConflict(msg filedifftab.ConflictTakeMsg) tea.Cmd {
+	m.appState.Loading = true
+	m.appState.StatusMessage = fmt.Sprintf("Resolving %s (taking %s)…", msg.Path, msg.Take)
+	return filedifftab.ResolveConflictCmd(m.appState.JJService, msg.Path, msg.Take)
+}
+
 func (m *Model) handleHelpRequest(r commandhistory.Request) (tea.Model, tea.Cmd) {
 	statusMsg, cmd := commandhistory.ExecuteRequest(r)
 	if statusMsg != "" {
@@ -501,12 +692,17 @@ func (m *Model) handleSettingsRequest(r settingstab.Request) (tea.Model, tea.Cmd
 
 func (m *Model) handleNavigateToGraphTab() (tea.Model, tea.Cmd) {
 	m.appState.ViewMode = state.ViewCommitGraph
-	m.appState.StatusMessage = "Loading commit graph"
+	m.appState.StatusMessage = i18n.T("status.loading_graph")
 	return m, m.refreshRepository()
 }
 
 func (m *Model) handleNavigateToPRTab() (tea.Model, tea.Cmd) {
 	m.appState.ViewMode = state.ViewPullRequests
+	m.syncPushMode()
+	if m.prsTabModel.IsPushMode() {
+		m.appState.StatusMessage = "Loading outgoing changes..."
+		return m, prstab.LoadChangesCmd(m.appState.JJService, m.appState.Config.PushRemoteOrDefault())
+	}
 	status, cmd := prstab.EnterTab(m)
 	m.appState.StatusMessage = status
 	if cmd != nil {
@@ -521,7 +717,7 @@ func (m *Model) handleNavigateToTicketsTab() (tea.Model, tea.Cmd) {
 	m.appState.StatusMessage = status
 	if cmd != nil && !m.appState.TicketsLoadedOnce {
 		m.appState.Loading = true
-		m.appState.StatusMessage = "Loading tickets…"
+		m.appState.StatusMessage = i18n.T("status.loading_tickets")
 		return m, tea.Batch(cmd, m.startBusySpinnerCmd())
 	}
 	return m, cmd
@@ -561,8 +757,8 @@ func (m *Model) handleNavigateToHelpTab() (tea.Model, tea.Cmd) {
 	m.appState.ViewMode = state.ViewHelp
 	m.helpTabModel.SetCommandHistoryEntries(helptab.BuildCommandHistoryEntries(m.appState.JJService))
 	m.helpTabModel.SetSelectedCommand(0)
-	m.appState.StatusMessage = "Loaded Help"
-	return m, nil
+	m.appState.StatusMessage = i18n.T("status.loaded_help")
+	return m, diagnostics.LoadCmd(m.diagnosticsInput())
 }
 
 func (m *Model) handleNavigateToBranchesTab() (tea.Model, tea.Cmd) {
@@ -572,10 +768,115 @@ func (m *Model) handleNavigateToBranchesTab() (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// handleControlCommand applies a command received on the --control-socket. Commands are ignored
+// while a modal owns the screen so an editor plugin can't yank the user out of a half-filled form.
+func (m *Model) handleControlCommand(c ipc.Command) (tea.Model, tea.Cmd) {
+	if m.isFormModalView() || m.errorModal.GetError() != nil || m.initRepoModel.Path() != "" || m.appState.ViewMode == state.ViewPager || m.appState.ViewMode == state.ViewFileTree || m.appState.ViewMode == state.ViewFileHistory {
+		m.appState.StatusMessage = i18n.T("status.ctl_ignored_dialog")
+		return m, nil
+	}
+	switch c.Kind {
+	case ipc.KindRefresh:
+		return m, m.refreshRepository()
+	case ipc.KindView:
+		switch c.Arg {
+		case "graph":
+			return m.handleNavigateToGraphTab()
+		case "prs":
+			return m.handleNavigateToPRTab()
+		case "tickets":
+			return m.handleNavigateToTicketsTab()
+		case "branches":
+			return m.handleNavigateToBranchesTab()
+		case "settings":
+			return m.handleNavigateToSettingsTab()
+		case "help":
+			return m.handleNavigateToHelpTab()
+		case "workspaces":
+			return m.handleNavigateToWorkspacesTab()
+		}
+	case ipc.KindSelect:
+		if m.appState.Repository != nil {
+			for i, commit := range m.appState.Repository.Graph.Commits {
+				if strings.HasPrefix(commit.ChangeID, c.Arg) || strings.HasPrefix(commit.ID, c.Arg) || slices.Contains(commit.Branches, c.Arg) {
+					m.appState.ViewMode = state.ViewCommitGraph
+					m.graphTabModel.SetGraphFocused(true)
+					idx := i
+					return m.processGraphRequest(graphtab.Request{SelectCommit: &idx})
+				}
+			}
+		}
+		m.appState.StatusMessage = i18n.T("status.revision_not_in_graph", c.Arg)
+	}
+	return m, nil
+}
+
+// followXRef jumps to the PR, ticket, or commit a clicked cross-reference names when it is loaded
+// locally, and otherwise opens it in the browser (PRs and tickets only; change IDs are only ever
+// linked when they are in the graph).
+func (m *Model) followXRef(ref xref.Ref) (tea.Model, tea.Cmd) {
+	switch ref.Kind {
+	case xref.KindPR:
+		n := ref.Number()
+		if m.appState.Repository != nil {
+			for i, pr := range m.appState.Repository.PRs {
+				if pr.Number == n {
+					m.appState.ViewMode = state.ViewPullRequests
+					m.prsTabModel.UpdateRepository(m.appState.Repository)
+					m.prsTabModel.SetSelectedPR(i)
+					m.appState.StatusMessage = fmt.Sprintf("PR #%d: %s", pr.Number, pr.Title)
+					return m, nil
+				}
+			}
+		}
+		svc := m.appState.GitHubService
+		if svc == nil || svc.GetOwner() == "" || m.appState.DemoMode {
+			m.appState.StatusMessage = i18n.T("status.pr_not_loaded", ref.Text)
+			return m, nil
+		}
+		// GitHub redirects /pull/N to /issues/N when N is an issue, so one URL covers both.
+		m.appState.StatusMessage = i18n.T("status.opening", ref.Text)
+		return m, util.OpenURL(fmt.Sprintf("https://github.com/%s/pull/%d", svc.PRTargetFullName(), n))
+	case xref.KindTicket:
+		for i, tk := range m.ticketsTabModel.GetTickets() {
+			if tk.DisplayKey == ref.Value || tk.Key == ref.Value {
+				m.appState.ViewMode = state.ViewTickets
+				m.ticketsTabModel.SetSelectedTicket(i)
+				m.appState.StatusMessage = fmt.Sprintf("%s: %s", ref.Value, tk.Summary)
+				return m, nil
+			}
+		}
+		if m.appState.TicketService == nil || m.appState.DemoMode {
+			m.appState.StatusMessage = i18n.T("status.ticket_not_loaded", ref.Value)
+			return m, nil
+		}
+		url := m.appState.TicketService.GetTicketURL(tickets.Ticket{Key: ref.Value, DisplayKey: ref.Value})
+		if url == "" {
+			m.appState.StatusMessage = i18n.T("status.no_url", ref.Value)
+			return m, nil
+		}
+		m.appState.StatusMessage = i18n.T("status.opening", ref.Value)
+		return m, util.OpenURL(url)
+	case xref.KindChange:
+		if m.appState.Repository != nil {
+			for i, c := range m.appState.Repository.Graph.Commits {
+				if xref.MatchesChange(ref.Value, c.ChangeID) {
+					m.appState.ViewMode = state.ViewCommitGraph
+					m.graphTabModel.SetGraphFocused(true)
+					idx := i
+					return m.processGraphRequest(graphtab.Request{SelectCommit: &idx})
+				}
+			}
+		}
+		m.appState.StatusMessage = i18n.T("status.change_not_in_graph", ref.Value)
+	}
+	return m, nil
+}
+
 // handleNavigate performs view changes that only main can do (it owns modals and cross-tab state).
 func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 	if t.Kind == state.NavigateSaveDescription || t.Kind == state.NavigateSubmitBookmark || t.Kind == state.NavigateSubmitPR || t.Kind == state.NavigateSubmitTicket || t.Kind == state.NavigateResolveConflict || t.Kind == state.NavigateResolveDivergent || t.Kind == state.NavigateRunInit || t.Kind == state.NavigatePerformEvologSplit {
-		m.redoOperationID = ""
+		m.redoDepth = 0
 	}
 	switch t.Kind {
 	case state.NavigateEditDescription:
@@ -592,19 +893,18 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		return m.startEditingDescription(t.Commit)
 	case state.NavigateCreateBookmark:
 		m.startCreateBookmark()
-		return m, branchestab.LoadBranchesCmd(m.appState.JJService, m.settingsTabModel.GetSettingsBranchLimit())
+		return m, branchestab.LoadBranchesCmd(m.appState.JJService)
 	case state.NavigateCreateBookmarkFromTicket:
 		m.beginModalUnderlay()
 		m.appState.ViewMode = state.ViewCreateBookmark
-		m.appState.StatusMessage = bookmarktab.OpenCreateBookmarkFromTicket(&m.bookmarkModal, m.appState.Repository, t.TicketKey, t.TicketTitle, t.TicketDisplayKey, m.branchesTabModel.BuildBookmarkNameConflictSources(), m.appState.Config != nil && m.appState.Config.ShouldSanitizeBookmarkNames(), ModalInnerWidth(m.width))
+		m.appState.StatusMessage = bookmarktab.OpenCreateBookmarkFromTicket(&m.bookmarkModal, m.appState.Repository, t.TicketKey, t.TicketTitle, t.TicketDisplayKey, m.branchesTabModel.BuildBookmarkNameConflictSources(), m.appState.Config != nil && m.appState.Config.ShouldSanitizeBookmarkNames(), m.appState.Config.BookmarkNamespace(), ModalInnerWidth(m.width))
 		m.pushAIProfilesToFormModals()
 		return m, nil
 	case state.NavigateWarning:
 		m.warningModal.Show(t.WarningTitle, t.WarningMessage, t.WarningCommits)
 		return m, nil
 	case state.NavigateCreatePR:
-		m.startCreatePR()
-		return m, nil
+		return m, m.startCreatePR()
 	case state.NavigateBackToGraph:
 		m.clearAIGenOverlay()
 		m.clearPendingAIRetry()
@@ -634,8 +934,36 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		descDef := m.appState.Config != nil && m.appState.Config.DefaultEvologPostSplitDescribe()
 		m.evologSplitModal.Show(t.Commit, bn, descDef)
 		m.appState.ViewMode = state.ViewEvologSplit
-		m.appState.StatusMessage = "Loading jj evolog…"
+		m.appState.StatusMessage = i18n.T("status.loading_evolog")
 		return m, evologsplittab.LoadEvologCmd(m.appState.JJService, bn, t.Commit)
+	case state.NavigateOpenPager:
+		if m.appState.ViewMode != state.ViewPager {
+			m.pagerReturnView = m.appState.ViewMode
+		}
+		m.pagerModal = m.pagerModal.SetDimensions(m.width, m.height).Open(t.PagerTitle, t.PagerContent)
+		if t.PagerSelect {
+			m.pagerModal = m.pagerModal.StartSelection(t.PagerSelectLine)
+		}
+		m.appState.ViewMode = state.ViewPager
+		return m, nil
+	case state.NavigateClosePager:
+		m.pagerModal.Hide()
+		m.appState.ViewMode = m.pagerReturnView
+		return m, nil
+	case state.NavigateOpenFileTree:
+		return m.openFileTree(t.Commit)
+	case state.NavigateCloseFileTree:
+		m.fileTreeModal.Hide()
+		m.appState.ViewMode = state.ViewCommitGraph
+		m.appState.StatusMessage = ""
+		return m, nil
+	case state.NavigateOpenFileHistory:
+		return m.openFileHistory(t.Commit, t.FileHistoryPath)
+	case state.NavigateCloseFileHistory:
+		m.fileHistoryModal.Hide()
+		m.appState.ViewMode = m.fileHistoryReturnView
+		m.appState.StatusMessage = ""
+		return m, nil
 	case state.NavigateCloseFileDiff:
 		m.fileDiffModal.Hide()
 		if isStaleFileDiffGlobalStatus(m.appState.StatusMessage) {
@@ -649,6 +977,10 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		return m, nil
 	case state.NavigateOpenFileDiff:
 		if raw := strings.TrimSpace(t.FileDiffRawGit); raw != "" {
+			if m.appState.ViewMode == state.ViewPullRequests {
+				// PR diff (f): Esc returns to the PR list.
+				m.beginModalUnderlay()
+			}
 			m.fileDiffModal = m.fileDiffModal.SetDimensions(m.width, m.height)
 			m.fileDiffModal = m.fileDiffModal.ShowPreloadedStyledDiff(
 				strings.TrimSpace(t.FileDiffOverlayTitle),
@@ -661,14 +993,14 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		}
 		path := strings.TrimSpace(t.FileDiffPath)
 		if path == "" || m.appState.JJService == nil {
-			m.appState.StatusMessage = "Cannot open file diff"
+			m.appState.StatusMessage = i18n.T("status.cannot_open_file_diff")
 			return m, nil
 		}
 		m.fileDiffModal = m.fileDiffModal.SetDimensions(m.width, m.height)
 		seq := m.fileDiffModal.BeginLoad(t.Commit, path)
 		m.appState.ViewMode = state.ViewFileDiff
-		m.appState.StatusMessage = "Loading file diff…"
-		return m, filedifftab.LoadFileDiffCmd(m.appState.JJService, seq, t.Commit.ChangeID, path)
+		m.appState.StatusMessage = i18n.T("status.loading_file_diff")
+		return m, filedifftab.LoadFileDiffCmd(m.appState.JJService, seq, t.Commit.ChangeID, path, t.Commit.Conflicts)
 	case state.NavigatePerformEvologSplit:
 		m.evologSplitModal.ResetOutcomePreviewForPerformSplit()
 		m.evologPostSplitDescribe = t.EvologDescribeAfterSplit
@@ -676,7 +1008,7 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		m.evologPrecomputedDescribeChild = strings.TrimSpace(t.EvologPrecomputedDescribeChild)
 		m.evologStepwiseRemainderAfterSplit = append([]string(nil), t.EvologStepwiseRemainder...)
 		m.evologStepwiseBookmarkName = t.EvologBookmarkName
-		m.appState.StatusMessage = "Splitting change…"
+		m.appState.StatusMessage = i18n.T("status.splitting")
 		m.appState.Loading = true
 		return m, tea.Batch(
 			evologsplittab.PerformEvologSplitCmd(
@@ -739,6 +1071,19 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 			m.appState.StatusMessage = t.StatusMessage
 		}
 		return m, m.tickCmd()
+	case state.NavigateOpenRepo:
+		// Picked on the welcome screen or the switcher (Ctrl+O): switch to that directory and
+		// start over as if launched there. A directory that isn't a jj repo comes back as
+		// InitErrorMsg and re-shows the welcome screen for it.
+		if err := os.Chdir(t.RepoPath); err != nil {
+			m.appState.StatusMessage = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		resize := m.switchRepo()
+		m.initRepoModel.SetPath("")
+		m.appState.Loading = true
+		m.appState.StatusMessage = i18n.T("status.opening_repo", t.RepoPath)
+		return m, tea.Batch(resize, data.InitializeServices(m.appState.DemoMode), m.startBusySpinnerCmd())
 	case state.NavigateGitHubLoginCancel:
 		m.githubLoginModel.ClearFlow()
 		m.clearModalUnderlay()
@@ -755,7 +1100,7 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		}
 		if t.SaveCommitID != "" && m.appState.JJService != nil {
 			m.appState.Loading = true
-			m.appState.StatusMessage = "Saving description…"
+			m.appState.StatusMessage = i18n.T("status.saving_description")
 			cmd := graphtab.SaveDescriptionCmd(m.appState.JJService, t.SaveCommitID, t.SaveDescription)
 			return m, tea.Batch(cmd, m.startBusySpinnerCmd())
 		}
@@ -780,10 +1125,10 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case state.NavigateResolveConflict:
-		m.appState.StatusMessage = "Resolving bookmark conflict..."
+		m.appState.StatusMessage = i18n.T("status.resolving_bookmark_conflict")
 		return m, conflicttab.ResolveBookmarkConflictCmd(m.appState.JJService, t.ConflictBookmarkName, t.ConflictResolution)
 	case state.NavigateResolveDivergent:
-		m.appState.StatusMessage = "Resolving divergent commit..."
+		m.appState.StatusMessage = i18n.T("status.resolving_divergent")
 		return m, divergenttab.ResolveDivergentCommitCmd(m.appState.JJService, t.DivergentChangeID, t.DivergentKeepCommitID)
 	case state.NavigateWarningCancel:
 		if t.StatusMessage != "" {
@@ -794,11 +1139,11 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		m.appState.Loading = true
 		switch {
 		case t.InitGhCreateRepo:
-			m.appState.StatusMessage = "Initializing repository and creating GitHub repo…"
+			m.appState.StatusMessage = i18n.T("status.init_repo_github")
 		case strings.TrimSpace(t.InitRemoteURL) != "":
-			m.appState.StatusMessage = "Initializing repository and adding remote…"
+			m.appState.StatusMessage = i18n.T("status.init_repo_remote")
 		default:
-			m.appState.StatusMessage = "Initializing repository…"
+			m.appState.StatusMessage = i18n.T("status.init_repo")
 		}
 		opts := data.InitOptions{
 			Colocate:      t.InitColocate,
@@ -818,28 +1163,28 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 			if gh.GetCurrentOrigin() != "" {
 				return m, data.RemoveOriginCmd(m.appState.JJService)
 			}
-			m.appState.StatusMessage = "Enter a remote URL first"
+			m.appState.StatusMessage = i18n.T("status.enter_remote_url")
 			return m, nil
 		}
 		m.appState.Loading = true
-		m.appState.StatusMessage = "Configuring origin remote…"
+		m.appState.StatusMessage = i18n.T("status.configuring_origin")
 		return m, tea.Batch(data.ApplyOriginCmd(m.appState.JJService, url), m.startBusySpinnerCmd())
 	case state.NavigateRemoteCreateGh:
 		m.appState.Loading = true
-		m.appState.StatusMessage = "Creating GitHub repository…"
+		m.appState.StatusMessage = i18n.T("status.creating_github_repo")
 		// Repo name is implicitly the current working directory; the data layer derives it from
 		// filepath.Base when name is empty so we don't need to plumb it through here.
 		return m, tea.Batch(data.CreateGhRepoCmd(m.appState.JJService, "", t.RemoteRepoPrivate), m.startBusySpinnerCmd())
 	case state.NavigateRemoteRemove:
 		m.appState.Loading = true
-		m.appState.StatusMessage = "Removing origin remote…"
+		m.appState.StatusMessage = i18n.T("status.removing_origin")
 		return m, tea.Batch(data.RemoveOriginCmd(m.appState.JJService), m.startBusySpinnerCmd())
 	case state.NavigatePushBookmarks:
 		m.appState.Loading = true
 		if t.PushAll {
-			m.appState.StatusMessage = "Pushing all bookmarks to origin…"
+			m.appState.StatusMessage = i18n.T("status.pushing_all_bookmarks")
 		} else {
-			m.appState.StatusMessage = "Pushing current bookmark to origin…"
+			m.appState.StatusMessage = i18n.T("status.pushing_current_bookmark")
 		}
 		return m, tea.Batch(data.PushBookmarksCmd(m.appState.JJService, t.PushAll), m.startBusySpinnerCmd())
 	case state.NavigateRetryError:
@@ -871,6 +1216,20 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 			m.appState.StatusMessage = t.StatusMessage
 		}
 		return m, nil
+	case state.NavigateInsertTicketIntoPR:
+		ref := m.prFormModal.GetTicket()
+		if ref.ID == "" {
+			m.appState.StatusMessage = i18n.T("status.no_linked_ticket")
+			return m, nil
+		}
+		if m.appState.TicketService == nil {
+			m.appState.StatusMessage = i18n.T("status.no_ticket_provider")
+			return m, nil
+		}
+		m.appState.StatusMessage = i18n.T("status.loading_ticket_description", ref.Key)
+		return m, prformtab.LoadTicketDescriptionCmd(m.appState.TicketService, ref)
+	case state.NavigateFollowXRef:
+		return m.followXRef(t.XRef)
 	case state.NavigateCreateTicket:
 		m.startCreateTicket()
 		return m, nil
@@ -892,7 +1251,7 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		return m, m.submitTicket()
 	case state.NavigateGenerateCommitDescription:
 		if m.appState.Config == nil || !m.appState.Config.AIConfiguredForGeneration() {
-			m.appState.StatusMessage = fmt.Sprintf("Enable AI in Settings → AI and set an API key (or %s)", config.EnvAIAPIKey)
+			m.appState.StatusMessage = i18n.T("status.ai_not_configured", config.EnvAIAPIKey)
 			return m, nil
 		}
 		changeID := m.desceditModal.GetEditingCommitID()
@@ -913,7 +1272,7 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		)
 	case state.NavigateGeneratePRForm:
 		if m.appState.Config == nil || !m.appState.Config.AIConfiguredForGeneration() {
-			m.appState.StatusMessage = fmt.Sprintf("Enable AI in Settings → AI and set an API key (or %s)", config.EnvAIAPIKey)
+			m.appState.StatusMessage = i18n.T("status.ai_not_configured", config.EnvAIAPIKey)
 			return m, nil
 		}
 		repo := m.appState.Repository
@@ -936,7 +1295,7 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		)
 	case state.NavigateGenerateBookmarkName:
 		if m.appState.Config == nil || !m.appState.Config.AIConfiguredForGeneration() {
-			m.appState.StatusMessage = fmt.Sprintf("Enable AI in Settings → AI and set an API key (or %s)", config.EnvAIAPIKey)
+			m.appState.StatusMessage = i18n.T("status.ai_not_configured", config.EnvAIAPIKey)
 			return m, nil
 		}
 		repo := m.appState.Repository
@@ -963,7 +1322,7 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 		)
 	case state.NavigateGenerateTicketForm:
 		if m.appState.Config == nil || !m.appState.Config.AIConfiguredForGeneration() {
-			m.appState.StatusMessage = fmt.Sprintf("Enable AI in Settings → AI and set an API key (or %s)", config.EnvAIAPIKey)
+			m.appState.StatusMessage = i18n.T("status.ai_not_configured", config.EnvAIAPIKey)
 			return m, nil
 		}
 		repo := m.appState.Repository
@@ -999,17 +1358,17 @@ func (m *Model) handleNavigate(t state.NavigateTarget) (tea.Model, tea.Cmd) {
 func (m *Model) handleUndo() (tea.Model, tea.Cmd) {
 	if m.appState.JJService != nil {
 		m.appState.Loading = true
-		m.appState.StatusMessage = "Undoing..."
+		m.appState.StatusMessage = i18n.T("status.undoing")
 		return m, tea.Batch(graphtab.UndoCmd(m.appState.JJService), m.startBusySpinnerCmd())
 	}
 	return m, nil
 }
 
 func (m *Model) handleRedo() (tea.Model, tea.Cmd) {
-	if m.appState.JJService != nil && m.redoOperationID != "" {
+	if m.appState.JJService != nil && m.redoDepth > 0 {
 		m.appState.Loading = true
-		m.appState.StatusMessage = "Redoing..."
-		return m, tea.Batch(graphtab.RedoCmd(m.appState.JJService, m.redoOperationID), m.startBusySpinnerCmd())
+		m.appState.StatusMessage = i18n.T("status.redoing")
+		return m, tea.Batch(graphtab.RedoCmd(m.appState.JJService), m.startBusySpinnerCmd())
 	}
 	return m, nil
 }
@@ -1030,33 +1389,46 @@ func (m *Model) startEditingDescription(commit internal.Commit) (tea.Model, tea.
 // startCreateBookmark opens the bookmark creation dialog for the selected commit.
 func (m *Model) startCreateBookmark() {
 	if !m.isSelectedCommitValid() {
-		m.appState.StatusMessage = "No commit selected"
+		m.appState.StatusMessage = i18n.T("status.no_commit_selected")
 		return
 	}
 	m.beginModalUnderlay()
 	idx := m.GetSelectedCommit()
 	m.appState.ViewMode = state.ViewCreateBookmark
-	m.appState.StatusMessage = bookmarktab.OpenCreateBookmark(&m.bookmarkModal, m.appState.Repository, idx, m.branchesTabModel.BuildBookmarkNameConflictSources(), m.appState.Config != nil && m.appState.Config.ShouldSanitizeBookmarkNames(), ModalInnerWidth(m.width))
+	m.appState.StatusMessage = bookmarktab.OpenCreateBookmark(&m.bookmarkModal, m.appState.Repository, idx, m.branchesTabModel.BuildBookmarkNameConflictSources(), m.appState.Config != nil && m.appState.Config.ShouldSanitizeBookmarkNames(), m.appState.Config.BookmarkNamespace(), ModalInnerWidth(m.width))
 	m.pushAIProfilesToFormModals()
 }
 
-// startCreatePR opens the PR creation dialog for the selected commit's bookmark.
-func (m *Model) startCreatePR() {
+// startCreatePR opens the PR creation dialog for the selected commit's bookmark and returns the
+// command that looks up CODEOWNERS reviewer suggestions.
+func (m *Model) startCreatePR() tea.Cmd {
 	if !m.isSelectedCommitValid() {
-		m.appState.StatusMessage = "No commit selected"
-		return
+		m.appState.StatusMessage = i18n.T("status.no_commit_selected")
+		return nil
 	}
 	idx := m.GetSelectedCommit()
 	contentHeight := m.estimatedContentHeight()
-	res := prformtab.OpenCreatePR(&m.prFormModal, m.appState.Repository, idx, m.bookmarkModal.GetJiraBookmarkTitles(), m.appState.DefaultBranch, ModalInnerWidth(m.width), contentHeight)
+	res := prformtab.OpenCreatePR(&m.prFormModal, m.appState.Repository, idx, m.bookmarkModal.GetTicketBookmarkRefs(), m.appState.Config, m.appState.DefaultBranch, m.appState.GitHubService, ModalInnerWidth(m.width), contentHeight)
 	if !res.Ok {
 		m.appState.StatusMessage = res.StatusMessage
-		return
+		return nil
 	}
 	m.beginModalUnderlay()
 	m.appState.ViewMode = state.ViewCreatePR
 	m.appState.StatusMessage = res.StatusMessage
 	m.pushAIProfilesToFormModals()
+	if m.appState.DemoMode {
+		return nil
+	}
+	cmd := prformtab.LoadReviewerSuggestionsCmd(m.appState.JJService, m.appState.GitHubService, res.ReviewersHead)
+	if cmd != nil {
+		m.prFormModal.BeginReviewerSuggestions(res.ReviewersHead)
+	}
+	if largeCmd := prformtab.LoadLargeFilesCmd(m.appState.JJService, res.ReviewersHead); largeCmd != nil {
+		m.prFormModal.BeginLargeFilesCheck(res.ReviewersHead)
+		cmd = tea.Batch(cmd, largeCmd)
+	}
+	return cmd
 }
 
 // submitPR runs the PR creation command.
@@ -1066,7 +1438,7 @@ func (m *Model) submitPR() tea.Cmd {
 	if m.appState.ViewMode == state.ViewCreatePR && m.appState.Loading {
 		return nil
 	}
-	res := prformtab.SubmitPR(&m.prFormModal, m.appState.Repository, m.appState.JJService, m.appState.GitHubService, m.appState.DemoMode)
+	res := prformtab.SubmitPR(&m.prFormModal, m.appState.Repository, m.appState.JJService, m.appState.Forge(), m.appState.DemoMode)
 	m.appState.StatusMessage = res.StatusMessage
 	if res.Cmd == nil {
 		return nil
@@ -1127,17 +1499,25 @@ func (m *Model) confirmCleanup() tea.Cmd {
 
 // handleClipboardCopiedMsg sets status (or error modal copied flag) from copy result; kept in main (generic).
 func (m *Model) handleClipboardCopiedMsg(msg util.ClipboardCopiedMsg) (tea.Model, tea.Cmd) {
+	if m.appState.ViewMode == state.ViewPager {
+		if msg.Success {
+			m.pagerModal.SetStatus("Copied to clipboard")
+		} else {
+			m.pagerModal.SetStatus(i18n.T("status.copy_failed", msg.Err))
+		}
+		return m, nil
+	}
 	if msg.Success {
 		if m.appState.ViewMode == state.ViewGitHubLogin {
-			m.appState.StatusMessage = "Code copied to clipboard! Paste it in your browser."
+			m.appState.StatusMessage = i18n.T("status.code_copied")
 		} else if m.errorModal.GetError() != nil {
 			m.errorModal.SetCopied(true)
-			m.appState.StatusMessage = "Error copied to clipboard!"
+			m.appState.StatusMessage = i18n.T("status.error_copied")
 		} else {
-			m.appState.StatusMessage = "Copied to clipboard!"
+			m.appState.StatusMessage = i18n.T("status.copied")
 		}
 	} else {
-		m.appState.StatusMessage = fmt.Sprintf("Failed to copy: %v", msg.Err)
+		m.appState.StatusMessage = i18n.T("status.copy_failed", msg.Err)
 	}
 	return m, nil
 }
@@ -1156,18 +1536,42 @@ func (m *Model) SetRepository(repo *internal.Repository) {
 
 // Init implements tea.Model
 func (m *Model) Init() tea.Cmd {
-	return tea.Batch(
+	return crash.GuardCmd(tea.Batch(
 		data.InitializeServices(m.appState.DemoMode),
 		m.tickCmd(),
-	)
+		m.commandPreviews.next(),
+	), m.crashHistory())
+}
+
+// Update implements tea.Model. Key and mouse input first resets the idle timer (idle.go), then
+// update routes the message. Repository results from before a repository switch are dropped
+// (see scopeToRepo). Panics here and in the returned commands are written to a crash report (see
+// crash.go) before Bubble Tea restores the terminal.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if scoped, ok := msg.(repoScopedMsg); ok {
+		if scoped.gen != m.repoGen {
+			return m, nil // started for the repository jj-tui switched away from
+		}
+		msg = scoped.msg
+	}
+	crash.RecordMsg(msg)
+	history := m.crashHistory()
+	defer crash.Recover(history)
+	resume := m.trackActivity(msg)
+	model, cmd := m.update(msg)
+	cmd = m.scopeToRepo(cmd)
+	if resume != nil {
+		cmd = tea.Batch(cmd, resume)
+	}
+	return model, crash.GuardCmd(cmd, history)
 }
 
-// Update implements tea.Model.
+// update routes one message.
 // Message responsibility: see internal/tui/model/RESPONSIBILITY.md.
 // Flow: globals (SetStatus, WindowSize) → state.NavigateMsg (from submodels) →
 // modal request messages (descedit/bookmark/prform/warning forward to modals) →
 // async result messages (data.*, graphtab.*, prstab.*, etc.) → zone/key routing.
-func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Lock the spinner label across the rest of this Update invocation. Submodels
 	// throughout the tree set m.appState.Loading directly and write progress text to
 	// StatusMessage, but StatusMessage is *also* the footer; any later footer update
@@ -1176,12 +1580,20 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// keep the spinner text stable for the entire duration of the loading operation
 	// without having to plumb a separate setter through every call site.
 	defer m.snapshotSpinnerMessage()
+	defer m.recordStatus()
 
 	switch msg := msg.(type) {
 	case SetStatusMsg:
 		m.appState.StatusMessage = msg.Status
 		return m, nil
 
+	case ipc.Command:
+		return m.handleControlCommand(msg)
+
+	case avatar.LoadedMsg:
+		// The image is cached in the avatar package; returning triggers the redraw that shows it.
+		return m, nil
+
 	case spinner.TickMsg:
 		if !m.appState.Loading && !m.aiGenOverlayActive {
 			return m, nil
@@ -1215,8 +1627,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.prFormModal.GetBodyInput().SetHeight(bodyH)
 		}
 		if m.appState.ViewMode == state.ViewCreateTicket {
-			const fixedFormLines = 12
-			bodyH := contentHeight - fixedFormLines
+			bodyH := contentHeight - m.ticketFormModal.FixedFormLines()
 			if bodyH < 3 {
 				bodyH = 3
 			}
@@ -1242,6 +1653,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.helpTabModel.SetDimensions(m.width, contentHeight)
 		m.evologSplitModal = m.evologSplitModal.SetDimensions(m.width, m.height).WithSuggestConfig(m.appState.Config)
 		m.fileDiffModal = m.fileDiffModal.SetDimensions(m.width, m.height)
+		m.pagerModal = m.pagerModal.SetDimensions(m.width, m.height)
+		m.fileTreeModal = m.fileTreeModal.SetDimensions(m.width, m.height)
+		m.fileHistoryModal = m.fileHistoryModal.SetDimensions(m.width, m.height)
 		m.divergentModal = m.divergentModal.SetDimensions(m.width, m.height)
 		m.conflictModal = m.conflictModal.SetDimensions(m.width, m.height)
 		if len(cmds) > 0 {
@@ -1250,6 +1664,36 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// The pager covers everything (including an error modal it was opened from), so it gets
+		// keys before any overlay.
+		if m.appState.ViewMode == state.ViewPager {
+			if msg.String() == "ctrl+q" || msg.String() == "ctrl+c" {
+				util.FlushMouse()
+				return m, tea.Quit
+			}
+			updated, cmd := m.pagerModal.Update(msg)
+			m.pagerModal = updated
+			return m, cmd
+		}
+		// The file browser is full screen too (and opens the pager on top of itself).
+		if m.appState.ViewMode == state.ViewFileTree {
+			if msg.String() == "ctrl+q" || msg.String() == "ctrl+c" {
+				util.FlushMouse()
+				return m, tea.Quit
+			}
+			updated, cmd := m.fileTreeModal.Update(msg)
+			m.fileTreeModal = updated
+			return m, cmd
+		}
+		if m.appState.ViewMode == state.ViewFileHistory {
+			if msg.String() == "ctrl+q" || msg.String() == "ctrl+c" {
+				util.FlushMouse()
+				return m, tea.Quit
+			}
+			updated, cmd := m.fileHistoryModal.Update(msg)
+			m.fileHistoryModal = updated
+			return m, cmd
+		}
 		// Window chrome keyboard nudge (Alt+arrow to move, Alt+Shift+arrow
 		// to resize) is consumed before any modal/tab handling so the
 		// keystroke can never collide with a textinput's own bindings —
@@ -1269,7 +1713,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.evologDescribeSkipParent = false
 				m.evologDescribeParent, m.evologDescribeChild = "", ""
 				m.appState.Loading = true
-				m.appState.StatusMessage = "Applying descriptions…"
+				m.appState.StatusMessage = i18n.T("status.applying_descriptions")
 				return m, tea.Batch(
 					aitab.ApplyEvologSplitDescriptionsCmd(0, m.appState.JJService, m.appState.Config, pd, cd, skipP),
 					m.startBusySpinnerCmd(),
@@ -1279,14 +1723,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.evologDescribePreviewFromPlan = false
 				m.evologDescribeSkipParent = false
 				m.evologDescribeParent, m.evologDescribeChild = "", ""
-				m.appState.StatusMessage = "Descriptions preview dismissed"
+				m.appState.StatusMessage = i18n.T("status.descriptions_dismissed")
 				return m, nil
 			default:
 				return m, nil
 			}
 		}
 		// When an overlay or blocking modal is showing, route keys to handleKeyMsg (init, error, warning) or view modals.
-		if m.initRepoModel.Path() != "" || m.errorModal.GetError() != nil || m.warningModal.IsShown() {
+		if m.initRepoModel.Path() != "" || m.errorModal.GetError() != nil || m.warningModal.IsShown() || m.confirmModal.IsShown() {
 			return m.handleKeyMsg(msg)
 		}
 		// View-specific modals (divergent, bookmark conflict): route keys to handleKeyMsg so the modal gets them.
@@ -1299,29 +1743,77 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m.handleNavigate(state.NavigateTarget{Kind: state.NavigateBackToGraph, StatusMessage: "Settings cancelled"})
 			}
 		}
+		if handled, cmd := m.handlePopupKey(msg); handled {
+			return m, cmd
+		}
+		// Keys rebound in the keymap become the built-in keys the tabs match.
+		msg, ok := m.remapKey(msg)
+		if !ok {
+			return m, nil
+		}
 		// Delegate to tab models for their specific views (tabs own selection state)
 		switch m.appState.ViewMode {
 		case state.ViewCommitGraph:
+			typing := m.graphTyping()
 			updated, cmd := m.graphTabModel.UpdateWithApp(msg, &m.appState)
 			m.graphTabModel = updated
 			if cmd != nil {
 				return m, m.wrapGraphTabCmd(cmd)
 			}
+			// Keys typed into the date filter, bulk describe dialog, files path glob, alias
+			// picker, absorb preview or hunk split (including Esc to close them) stay in the tab.
+			if typing {
+				return m, nil
+			}
 		case state.ViewPullRequests:
+			reviewOpen := m.prsTabModel.IsReviewCommentsOpen() || m.prsTabModel.IsPRDetailOpen()
+			typing := m.prsTyping()
 			updated, cmd := m.prsTabModel.UpdateWithApp(msg, &m.appState)
 			m.prsTabModel = updated
 			if cmd != nil {
 				return m, cmd
 			}
+			// Esc closed the review comment list or PR detail view; stay on the tab.
+			if reviewOpen && msg.String() == "esc" {
+				return m, nil
+			}
+			// Keys typed into the review or merge form (including Esc to close it) stay in the tab.
+			if typing {
+				return m, nil
+			}
 			// Fall through to handleKeyMsg for non-delegated keys
 		case state.ViewBranches:
+			capturing := m.branchesTabModel.IsCapturingKeys()
 			updated, cmd := m.branchesTabModel.UpdateWithApp(msg, &m.appState)
 			m.branchesTabModel = updated
 			if cmd != nil {
 				return m, m.wrapBranchFetchCmd(cmd)
 			}
+			// Keys answering a prompt (n, Esc, …) must not fall through to the global bindings.
+			if capturing {
+				return m, nil
+			}
+		case state.ViewWorkspaces:
+			capturing := m.workspacesTabModel.IsCapturingKeys()
+			sparseOpen := m.workspacesTabModel.IsSparseOpen()
+			updated, cmd := m.workspacesTabModel.UpdateWithApp(msg, &m.appState)
+			m.workspacesTabModel = updated
+			if cmd != nil {
+				return m, cmd
+			}
+			// Keys typed into the add input or answering a prompt stay in the tab, as does the
+			// Esc that closes the sparse patterns panel.
+			if capturing || (sparseOpen && msg.String() == "esc") {
+				return m, nil
+			}
 		case state.ViewTickets:
 			wasStatusChange := m.ticketsTabModel.IsStatusChangeMode()
+			wasDetail := m.ticketsTabModel.IsTicketDetailOpen()
+			ticketBookmarks := map[string]string{}
+			for name, ref := range m.bookmarkModal.GetTicketBookmarkRefs() {
+				ticketBookmarks[name] = ref.ID
+			}
+			m.ticketsTabModel.SetTicketBookmarks(ticketBookmarks)
 			updated, cmd := m.ticketsTabModel.UpdateWithApp(msg, &m.appState)
 			m.ticketsTabModel = updated
 			if cmd != nil {
@@ -1330,6 +1822,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if msg.String() == "esc" && wasStatusChange && !m.ticketsTabModel.IsStatusChangeMode() {
 				return m, nil
 			}
+			// Scroll keys and Esc in the detail view stay in the tab.
+			if wasDetail && msg.String() != "ctrl+c" && msg.String() != "q" {
+				return m, nil
+			}
 		case state.ViewSettings:
 			cmds := util.PropagateUpdate(msg, &m.settingsTabModel)
 			if len(cmds) > 0 && cmds[0] != nil {
@@ -1337,10 +1833,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		case state.ViewHelp:
+			typing := m.helpTabModel.IsFiltering()
 			cmds := util.PropagateUpdate(msg, &m.helpTabModel)
 			if len(cmds) > 0 && cmds[0] != nil {
 				return m, cmds[0]
 			}
+			// Keys typed into the History filter (including Esc to close it) stay in the tab.
+			if typing {
+				return m, nil
+			}
 			// Tab/shift+tab switch help sub-tab; don't fall through to handleKeyMsg (which would switch to graph)
 			if msg.String() == "tab" || msg.String() == "shift+tab" {
 				return m, nil
@@ -1349,6 +1850,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKeyMsg(msg)
 
 	case tea.MouseMsg:
+		// The pager is full screen: it only takes the wheel, nothing underneath is clickable.
+		if m.appState.ViewMode == state.ViewPager {
+			updated, cmd := m.pagerModal.Update(msg)
+			m.pagerModal = updated
+			return m, cmd
+		}
+		if m.appState.ViewMode == state.ViewFileTree {
+			updated, cmd := m.fileTreeModal.Update(msg)
+			m.fileTreeModal = updated
+			return m, cmd
+		}
+		if m.appState.ViewMode == state.ViewFileHistory {
+			updated, cmd := m.fileHistoryModal.Update(msg)
+			m.fileHistoryModal = updated
+			return m, cmd
+		}
 		// Window chrome (title-bar drag, [x] close, edge resize) gets first
 		// look so a drag started on the tab keeps consuming subsequent
 		// motion / release events even if they cross over an underlying
@@ -1421,7 +1938,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Blocking overlays and modal views: run zone check on release first so clicks reach the modal, not the tab.
 		if msg.Action == tea.MouseActionRelease &&
-			(m.initRepoModel.Path() != "" || m.errorModal.GetError() != nil || m.warningModal.IsShown() ||
+			(m.initRepoModel.Path() != "" || m.errorModal.GetError() != nil || m.warningModal.IsShown() || m.confirmModal.IsShown() ||
 				m.appState.ViewMode == state.ViewCreatePR || m.appState.ViewMode == state.ViewCreateTicket || m.appState.ViewMode == state.ViewEditDescription || m.appState.ViewMode == state.ViewCreateBookmark || m.appState.ViewMode == state.ViewDivergentCommit || m.appState.ViewMode == state.ViewBookmarkConflict || m.appState.ViewMode == state.ViewEvologSplit || m.appState.ViewMode == state.ViewFileDiff) {
 			return m.zoneManager.AnyInBoundsAndUpdate(m, msg)
 		}
@@ -1460,6 +1977,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if len(cmds) > 0 && cmds[0] != nil {
 					return m, cmds[0]
 				}
+			case state.ViewWorkspaces:
+				m.workspacesTabModel.SetDimensions(m.width, contentHeight)
+				updated, cmd := m.workspacesTabModel.UpdateWithApp(msg, &m.appState)
+				m.workspacesTabModel = updated
+				if cmd != nil {
+					return m, cmd
+				}
 			case state.ViewTickets:
 				m.ticketsTabModel.SetDimensions(m.width, contentHeight)
 				updated, cmd := m.ticketsTabModel.UpdateWithApp(msg, &m.appState)
@@ -1552,7 +2076,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		// Blocking overlays (init, error, warning) get zone clicks first so tabs don't consume them
-		if m.initRepoModel.Path() != "" || m.errorModal.GetError() != nil || m.warningModal.IsShown() {
+		if m.initRepoModel.Path() != "" || m.errorModal.GetError() != nil || m.warningModal.IsShown() || m.confirmModal.IsShown() {
 			return m.handleZoneClick(msg)
 		}
 		// View modals (divergent, conflict) get zone clicks so they're not consumed by the tab
@@ -1598,6 +2122,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.wrapBranchFetchCmd(cmd)
 			}
 		}
+		if m.appState.ViewMode == state.ViewWorkspaces {
+			updated, cmd := m.workspacesTabModel.UpdateWithApp(msg, &m.appState)
+			m.workspacesTabModel = updated
+			if cmd != nil {
+				return m, cmd
+			}
+		}
 		if m.appState.ViewMode == state.ViewTickets {
 			updated, cmd := m.ticketsTabModel.UpdateWithApp(msg, &m.appState)
 			m.ticketsTabModel = updated
@@ -1664,7 +2195,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				m.desceditModal.SetDescription(cur + "\n\n" + next)
 			}
-			m.appState.StatusMessage = "Description generated (review, then save)"
+			m.appState.StatusMessage = i18n.T("status.ai_description_generated")
 		case aitab.KindPR:
 			if m.appState.ViewMode != state.ViewCreatePR {
 				return m, nil
@@ -1675,7 +2206,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if b := strings.TrimSpace(msg.Body); b != "" {
 				m.prFormModal.SetBody(b)
 			}
-			m.appState.StatusMessage = "PR fields generated (review, then create)"
+			m.appState.StatusMessage = i18n.T("status.ai_pr_generated")
 		case aitab.KindBookmark:
 			if m.appState.ViewMode != state.ViewCreateBookmark {
 				return m, nil
@@ -1687,7 +2218,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			name = jj.TruncateBookmarkName(name)
 			m.bookmarkModal.SetBookmarkName(name)
 			m.bookmarkModal.UpdateNameExistsFromInput(m.appState.Config != nil && m.appState.Config.ShouldSanitizeBookmarkNames())
-			m.appState.StatusMessage = "Bookmark name suggested (edit if needed)"
+			m.appState.StatusMessage = i18n.T("status.ai_bookmark_suggested")
 		case aitab.KindTicket:
 			if m.appState.ViewMode != state.ViewCreateTicket {
 				return m, nil
@@ -1698,12 +2229,43 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if b := strings.TrimSpace(msg.Body); b != "" {
 				m.ticketFormModal.SetDescription(b)
 			}
-			m.appState.StatusMessage = "Ticket fields generated from graph revision (review, then create)"
+			m.appState.StatusMessage = i18n.T("status.ai_ticket_generated")
 		}
 		return m, nil
 
 	case commandhistory.Request:
 		return m.handleHelpRequest(msg)
+	case diagnostics.Request:
+		return m.handleDiagnosticsRequest(msg)
+	case audit.Request:
+		return m, audit.LoadCmd(m.appState.JJService)
+	case filetreetab.FilesLoadedMsg:
+		m.fileTreeModal, _ = m.fileTreeModal.Update(msg)
+		if m.appState.ViewMode == state.ViewFileTree {
+			m.appState.StatusMessage = m.fileTreeModal.LoadStatus()
+		}
+		return m, nil
+	case filetreetab.FileContentMsg:
+		return m.showFileContent(msg)
+	case filetreetab.Request:
+		return m.handleFileTreeRequest(msg)
+	case filehistorytab.HistoryLoadedMsg:
+		m.fileHistoryModal, _ = m.fileHistoryModal.Update(msg)
+		if m.appState.ViewMode == state.ViewFileHistory {
+			m.appState.StatusMessage = m.fileHistoryModal.LoadStatus()
+		}
+		return m, nil
+	case filehistorytab.AnnotateLoadedMsg:
+		m.fileHistoryModal, _ = m.fileHistoryModal.Update(msg)
+		if m.appState.ViewMode == state.ViewFileHistory {
+			m.appState.StatusMessage = ""
+		}
+		return m, nil
+	case filehistorytab.Request:
+		return m.handleFileHistoryRequest(msg)
+	case diagnostics.LoadedMsg, audit.LoadedMsg:
+		m.helpTabModel, _ = m.helpTabModel.Update(msg)
+		return m, nil
 
 	case settingstab.Request:
 		return m.handleSettingsRequest(msg)
@@ -1740,6 +2302,32 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.bookmarkModal.UpdateNameExistsFromInput(m.appState.Config != nil && m.appState.Config.ShouldSanitizeBookmarkNames())
 		return m, cmd
 
+	case prformtab.TicketDescriptionLoadedMsg:
+		if m.appState.ViewMode != state.ViewCreatePR {
+			return m, nil
+		}
+		switch {
+		case msg.Err != nil:
+			m.appState.StatusMessage = i18n.T("status.ticket_load_failed", msg.Ticket.Key, msg.Err)
+		case msg.Markdown == "":
+			m.appState.StatusMessage = i18n.T("status.ticket_no_description", msg.Ticket.Key)
+		case m.prFormModal.InsertTicketDescription(prformtab.TicketSection(msg.Ticket, msg.Markdown)):
+			m.appState.StatusMessage = i18n.T("status.ticket_description_inserted", msg.Ticket.Key)
+		default:
+			m.appState.StatusMessage = i18n.T("status.ticket_description_present", msg.Ticket.Key)
+		}
+		return m, nil
+
+	case prformtab.ReviewerSuggestionsLoadedMsg:
+		m.prFormModal.SetReviewerSuggestions(msg)
+		return m, nil
+
+	case prformtab.LargeFilesLoadedMsg:
+		if status := m.prFormModal.SetLargeFiles(msg); status != "" {
+			m.appState.StatusMessage = status
+		}
+		return m, nil
+
 	case prformtab.CancelRequestedMsg, prformtab.SubmitRequestedMsg:
 		updated, cmd := m.prFormModal.Update(msg)
 		m.prFormModal = updated
@@ -1758,6 +2346,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case settingstab.RequestSetStatusMsg:
 		m.appState.StatusMessage = msg.Status
 		return m, nil
+	case settingstab.RequestLoadImmutableHeadsMsg:
+		return m, settingstab.LoadImmutableHeadsCmd(m.appState.JJService)
+	case settingstab.RequestTestMergeToolMsg:
+		m.appState.StatusMessage = "Opening merge tool on a sample conflict…"
+		return m, settingstab.TestMergeToolCmd(msg.Tool)
+	case settingstab.RequestSaveImmutableHeadsMsg:
+		m.appState.StatusMessage = "Saving immutable_heads()..."
+		return m, settingstab.SaveImmutableHeadsCmd(m.appState.JJService, msg.Revset)
 
 	case errortab.RequestCopyMsg:
 		if m.errorModal.GetError() != nil {
@@ -1766,12 +2362,43 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case confirmtab.AskMsg:
+		return m, m.askConfirm(msg.Prompt)
+
+	case confirmtab.ConfirmedMsg:
+		return m, m.handleConfirmed(msg)
+
+	case confirmtab.CancelledMsg:
+		m.confirmModal.Hide()
+		m.appState.StatusMessage = i18n.T("status.cancelled")
+		return m, msg.Prompt.OnCancel
+
+	case commandPreviewMsg:
+		m.showCommandPreview(msg)
+		return m, nil
+
+	case commandPreviewAnsweredMsg:
+		return m, m.commandPreviews.next()
+
+	case workingCopyUpdatedMsg:
+		return m.handleWorkingCopyUpdated(msg)
+
+	case authPromptDoneMsg:
+		return m.handleAuthPromptDone(msg)
+
+	case confirmedGraphResult:
+		ctx := graphtab.BuildRequestContextFrom(m)
+		return m, m.wrapGraphTabCmd(graphtab.ApplyResult(msg.res, &m.graphTabModel, ctx, &m.appState))
+
 	case warningtab.EditCommitRequestedMsg:
 		updated, cmd := m.warningModal.Update(msg)
 		m.warningModal = updated
 		return m, cmd
 
 	case graphtab.EditCompletedMsg:
+		if m.popupView != "" {
+			return m, m.popupQuit()
+		}
 		// Preserve PRs from previous repository
 		var oldPRs []internal.GitHubPR
 		if m.appState.Repository != nil {
@@ -1798,7 +2425,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.appState.Loading = false
-		m.appState.StatusMessage = "Now editing working copy"
+		m.appState.StatusMessage = i18n.T("status.editing_working_copy")
 
 		var cmds []tea.Cmd
 		cmds = append(cmds, m.tickCmd())
@@ -1807,17 +2434,33 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Also refresh PRs when GitHub is connected (needed for Update PR button)
-		if m.appState.GitHubService != nil {
+		if m.appState.Forge() != nil && m.canReadPRs() && !m.lowBandwidth() {
 			existingPRs := 0
 			if m.appState.Repository != nil {
 				existingPRs = len(m.appState.Repository.PRs)
 			}
-			cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.GitHubService, m.appState.GithubInfo, m.appState.DemoMode, existingPRs)))
+			cmds = append(cmds, m.wrapFirstPRLoadCmd(prstab.LoadPRsCmd(m.appState.Forge(), m.appState.GithubInfo, m.appState.DemoMode, existingPRs)))
 		}
 
 		return m, tea.Batch(cmds...)
 
 	case errorMsg:
+		if errors.Is(msg.Err, jj.ErrCommandCancelled) {
+			// Declined in preview mode: the modal already said so; nothing failed.
+			m.appState.Loading = false
+			m.appState.StatusMessage = i18n.T("status.cancelled")
+			return m, nil
+		}
+		if errors.Is(msg.Err, jj.ErrWorkingCopyStale) {
+			// Refused before anything ran; the header banner says how to fix it.
+			m.appState.Loading = false
+			m.appState.StatusMessage = staleBlockedStatus()
+			return m, nil
+		}
+		if authErr := authPromptError(msg.Err); authErr != nil {
+			// interactive_auth: the remote wants a passphrase or password; ask in the terminal.
+			return m.handleAuthPrompt(authErr)
+		}
 		m.evologDescribePreviewActive = false
 		m.evologDescribePreviewFromPlan = false
 		m.evologDescribeSkipParent = false
@@ -1826,6 +2469,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.appState.ViewMode == state.ViewEvologSplit || m.appState.ViewMode == state.ViewFileDiff || m.appState.ViewMode == state.ViewEditDescription {
 			m.appState.Loading = false
 		}
+		// A failing jj command may mean the repository itself went away; recover from that
+		// instead of showing one error per attempt.
+		if svc := m.appState.JJService; svc != nil {
+			if lost := svc.CheckRepo(); lost != nil {
+				return m.handleRepoLostMsg(data.RepoLostMsg{Err: lost, RepoPath: svc.RepoPath})
+			}
+		}
 		cmd, info := errortab.HandleError(errortab.ErrorInput{NotJJRepo: msg.NotJJRepo, CurrentPath: msg.CurrentPath, Err: msg.Err}, &m.appState)
 		if info != nil {
 			if info.NotJJRepo {
@@ -1843,7 +2493,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.JJInitialized {
 			m.initRepoModel.SetPath("")
 			m.errorModal.SetError(msg.Err, false, "")
-			m.appState.StatusMessage = "Repository initialized; remote setup failed"
+			m.appState.StatusMessage = i18n.T("status.init_remote_failed")
 			return m, data.InitializeServices(m.appState.DemoMode)
 		}
 		cmd, info := initrepotab.HandleInitError(msg, &m.appState)
@@ -1865,6 +2515,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handlePushResultMsg(msg)
 	case data.RepoReadyMsg:
 		return m.handleRepoReadyMsg(msg)
+	case data.RepoLostMsg:
+		return m.handleRepoLostMsg(msg)
 	case data.AuxServicesReadyMsg:
 		return m.handleAuxServicesReadyMsg(msg)
 	case data.ServicesInitializedMsg:
@@ -1875,22 +2527,63 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleActionsRepositoryLoadedMsg(msg)
 	case data.SilentRepositoryLoadedMsg:
 		return m.handleDataSilentRepositoryLoadedMsg(msg)
+	case data.RepoWatchStartedMsg:
+		return m.handleRepoWatchStartedMsg(msg)
+	case data.RepoChangedMsg:
+		return m.handleRepoChangedMsg(msg)
+	case data.ConflictSummaryMsg:
+		return m.handleConflictSummaryMsg(msg)
 
 	case prstab.PrsLoadedMsg:
 		m.appState.PRsLoadedOnce = true
 		m.appState.Loading = false
+		wasOpen := openPRNumbers(m.appState.Repository)
 		updated, cmd := m.prsTabModel.UpdateWithApp(msg, &m.appState)
 		m.prsTabModel = updated
 		m.prsTabModel.UpdateRepository(m.appState.Repository)
+		if closeCmd := m.closeIssuesForMergedPRsCmd(wasOpen); closeCmd != nil {
+			cmd = tea.Batch(cmd, closeCmd)
+		}
 		// The bulk list just replaced Repository.PRs; resolve any still-unmatched local bookmarks to
 		// their open PR via targeted lookups so the graph can offer "Update PR" for branches the
 		// limited bulk fetch omitted. Run after the bulk load so PrsLoadedMsg can't clobber the result.
-		if resolveCmd := prstab.ResolveOpenPRsForBookmarksCmd(m.appState.GitHubService, m.bookmarksNeedingPRLookup(), m.appState.DemoMode); resolveCmd != nil {
+		// Low-bandwidth mode makes do with the one list query.
+		if m.lowBandwidth() {
+			return m, cmd
+		}
+		if resolveCmd := prstab.ResolveOpenPRsForBookmarksCmd(m.appState.Forge(), m.bookmarksNeedingPRLookup(), m.appState.DemoMode); resolveCmd != nil {
 			cmd = tea.Batch(cmd, resolveCmd)
 		}
 		return m, cmd
 	case prstab.OpenPRsResolvedMsg:
 		return m.handleOpenPRsResolvedMsg(msg)
+	case prstab.DeploymentsLoadedMsg, prstab.ReviewCommentsLoadedMsg, prstab.ReviewSubmittedMsg, prstab.ReviewRepliedMsg, prstab.PRDetailLoadedMsg, prstab.PRDiffLoadedMsg, prstab.MergeFormRequestedMsg, prstab.ChecklistToggledMsg:
+		updated, cmd := m.prsTabModel.UpdateWithApp(msg, &m.appState)
+		m.prsTabModel = updated
+		return m, cmd
+	case prstab.ReviewFixRequestedMsg:
+		if m.appState.JJService == nil {
+			return m, nil
+		}
+		m.appState.StatusMessage = fmt.Sprintf("Starting a fix on %s…", msg.PR.HeadBranch)
+		return m, prstab.StartReviewFixCmd(m.appState.JJService, msg.PR, msg.Comment)
+	case prstab.ReviewFixStartedMsg:
+		return m.handleReviewFixStartedMsg(msg)
+	case prstab.ChangesLoadedMsg:
+		updated, cmd := m.prsTabModel.UpdateWithApp(msg, &m.appState)
+		m.prsTabModel = updated
+		return m, cmd
+	case prstab.ChangePushedMsg:
+		updated, cmd := m.prsTabModel.UpdateWithApp(msg, &m.appState)
+		m.prsTabModel = updated
+		if authErr := authPromptError(msg.Err); authErr != nil {
+			return m.handleAuthPrompt(authErr)
+		}
+		if msg.Err != nil {
+			m.errorModal.SetError(msg.Err, false, "")
+			return m, nil
+		}
+		return m, tea.Batch(cmd, data.LoadRepository(m.appState.JJService))
 	case prstab.PrMergedMsg, prstab.PrClosedMsg:
 		updated, cmd := m.prsTabModel.UpdateWithApp(msg, &m.appState)
 		m.prsTabModel = updated
@@ -1905,7 +2598,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.errorModal.SetError(err, false, "")
 			return m, nil
 		}
+		if mmsg, ok := msg.(prstab.PrMergedMsg); ok {
+			cmd = tea.Batch(cmd, m.closeIssueAfterMerge(mmsg))
+			if mmsg.FollowUp && !mmsg.AutoMerge {
+				return m, tea.Batch(cmd, m.mergeFollowUpCmd(mmsg))
+			}
+		}
 		return m, cmd
+	case prstab.MergeFollowUpDoneMsg:
+		m.appState.StatusMessage = msg.Status()
+		if authErr := authPromptError(msg.Err); authErr != nil {
+			// The steps after the fetch or bookmark delete are not resumed.
+			return m.handleAuthPrompt(authErr)
+		}
+		if msg.Err != nil {
+			errCmd := func() tea.Msg {
+				return util.ErrorMsg{Err: fmt.Errorf("PR #%d follow-up failed at %s: %w", msg.PRNumber, msg.FailedStep, msg.Err)}
+			}
+			if len(msg.Done) == 0 {
+				return m, errCmd
+			}
+			return m, tea.Batch(errCmd, data.LoadRepository(m.appState.JJService))
+		}
+		m.statusAfterReload = msg.Status()
+		return m, data.LoadRepository(m.appState.JJService)
 	case prstab.LoadErrorMsg:
 		m.appState.PRsLoadedOnce = true
 		m.appState.Loading = false
@@ -1916,13 +2632,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case prstab.ReauthNeededMsg:
 		updated, _ := m.prsTabModel.UpdateWithApp(msg, &m.appState)
 		m.prsTabModel = updated
-		return m.handleReauthNeededEffect(prstab.ApplyReauthNeededEffect(msg))
+		return m.handleReauthNeededEffect(prstab.ApplyReauthNeededEffect{Reason: msg.Reason})
 	case prstab.PrTickMsg:
+		if m.checkIdle() {
+			m.idleState.prTickSuspended = true
+			return m, nil
+		}
 		prInput := prstab.PrTickInput{
 			IsPRView:      m.appState.ViewMode == state.ViewPullRequests,
 			Loading:       m.appState.Loading,
 			HasError:      m.errorModal.GetError() != nil,
-			GitHubService: m.appState.GitHubService,
+			Forge:         m.appState.Forge(),
 			GithubInfo:    m.appState.GithubInfo,
 			DemoMode:      m.appState.DemoMode,
 			ExistingCount: 0,
@@ -1948,6 +2668,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		updated, cmd := m.ticketsTabModel.UpdateWithApp(input, &m.appState)
 		m.ticketsTabModel = updated
+		m.linkTicketBookmarks()
+		return m, cmd
+	case ticketstab.TicketsPrefetchedMsg:
+		// The Tickets tab still does its own (filtered) load when first opened.
+		if !m.appState.TicketsLoadedOnce {
+			m.ticketsTabModel.UpdateTickets(msg.Tickets)
+		}
+		m.bookmarkModal.LinkTicketBookmarks(m.appState.Repository, msg.Tickets)
+		return m, nil
+	case ticketstab.TicketsPolledMsg:
+		return m.handleTicketsPolledMsg(msg)
+	case ticketstab.IssueClosedForPRMsg:
+		return m.handleIssueClosedForPRMsg(msg)
+	case ticketstab.TicketDetailLoadedMsg:
+		updated, cmd := m.ticketsTabModel.UpdateWithApp(msg, &m.appState)
+		m.ticketsTabModel = updated
 		return m, cmd
 	case ticketstab.TransitionsLoadedMsg:
 		updated, cmd := m.ticketsTabModel.UpdateWithApp(msg, &m.appState)
@@ -1967,7 +2703,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		updated, _ := m.ticketsTabModel.UpdateWithApp(msg, &m.appState)
 		m.ticketsTabModel = updated
 		m.errorModal.SetError(msg.Err, false, "")
-		m.appState.StatusMessage = fmt.Sprintf("Error: %v", msg.Err)
+		m.appState.StatusMessage = i18n.T("status.error", msg.Err)
 		return m, nil
 
 	case branchestab.BranchesLoadedMsg:
@@ -1983,29 +2719,238 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.bookmarkModal.UpdateNameExistsFromInput(m.appState.Config != nil && m.appState.Config.ShouldSanitizeBookmarkNames())
 		}
 		return m, cmd
+	case workspacestab.WorkspacesLoadedMsg, workspacestab.WorkspaceActionMsg, workspacestab.SparseLoadedMsg, workspacestab.SparseActionMsg:
+		updated, cmd := m.workspacesTabModel.UpdateWithApp(msg, &m.appState)
+		m.workspacesTabModel = updated
+		return m, cmd
+	case workspacestab.SwitchWorkspaceMsg:
+		return m.switchWorkspace(msg)
 	case branchestab.BranchActionMsg:
 		updated, _ := m.branchesTabModel.UpdateWithApp(msg, &m.appState)
 		m.branchesTabModel = updated
 		if msg.Action == "fetch" {
 			m.appState.BranchRemoteFetchPending = false
 		}
+		if authErr := authPromptError(msg.Err); authErr != nil {
+			return m.handleAuthPrompt(authErr)
+		}
 		if msg.Err != nil {
 			// Branches tab already set StatusMessage (e.g. "Failed to push branch: ...").
 			m.appState.Loading = false
 			return m, nil
 		}
 		return m, tea.Batch(
-			branchestab.LoadBranchesCmd(m.appState.JJService, m.settingsTabModel.GetSettingsBranchLimit()),
+			branchestab.LoadBranchesCmd(m.appState.JJService),
+			data.LoadRepository(m.appState.JJService),
+		)
+	case graphtab.AuthorModeChangedMsg:
+		if label := msg.Mode.Label(); label != "" {
+			m.appState.StatusMessage = fmt.Sprintf("Graph: %s", label)
+		} else {
+			m.appState.StatusMessage = "Graph: all authors"
+		}
+		onlyMine := msg.Mode == graphtab.AuthorModeMineOnly
+		if m.appState.JJService == nil || m.appState.JJService.GraphOnlyMine == onlyMine {
+			return m, nil
+		}
+		m.appState.JJService.GraphOnlyMine = onlyMine
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.WrapSubjectsChangedMsg:
+		// Saved so the choice survives a restart.
+		if m.appState.Config == nil {
+			m.appState.Config = &config.Config{}
+		}
+		on := msg.On
+		m.appState.Config.GraphWrapSubjects = &on
+		if saved, _ := config.Load(); saved != nil {
+			saved.GraphWrapSubjects = &on
+			_ = saved.Save()
+		}
+		if on {
+			m.appState.StatusMessage = "Graph: long subjects wrap onto a second line"
+		} else {
+			m.appState.StatusMessage = "Graph: long subjects are cut off"
+		}
+		return m, nil
+	case graphtab.DescriptionsAffixedMsg:
+		// Reload even on failure: the descriptions before the failing one were already rewritten.
+		reload := data.LoadRepository(m.appState.JJService)
+		if msg.Err != nil {
+			err := fmt.Errorf("updated %d of %d descriptions: %w", msg.Updated, msg.Total, msg.Err)
+			return m, tea.Batch(reload, func() tea.Msg { return util.ErrorMsg{Err: err} })
+		}
+		m.appState.StatusMessage = fmt.Sprintf("Updated %d of %d descriptions", msg.Updated, msg.Total)
+		return m, reload
+	case data.PendingChangesMsg:
+		m.pendingChanges = msg.Pending
+		return m, nil
+	case graphtab.DateFilterChangedMsg:
+		if m.appState.JJService == nil {
+			return m, nil
+		}
+		m.appState.JJService.GraphDateFilter = msg.Range
+		if msg.Range.IsZero() {
+			m.appState.StatusMessage = "Date filter cleared"
+		} else {
+			m.appState.StatusMessage = fmt.Sprintf("Graph filtered to %s", msg.Range.Label)
+		}
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.LoadMoreCommitsMsg:
+		return m.handleLoadMoreCommits()
+	case graphtab.RevsetAliasChangedMsg:
+		if m.appState.JJService == nil {
+			return m, nil
+		}
+		m.appState.JJService.GraphRevsetAlias = msg.Name
+		if msg.Name == "" {
+			m.appState.StatusMessage = "Revset alias cleared"
+		} else {
+			m.appState.StatusMessage = fmt.Sprintf("Graph filtered to revset %s", msg.Name)
+		}
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.GraphSearchMsg:
+		m.graphTabModel.Update(msg)
+		if m.appState.JJService == nil {
+			return m, nil
+		}
+		switch {
+		case msg.Err != nil:
+			m.appState.StatusMessage = "Search failed"
+			return m, nil
+		case msg.Query == "":
+			m.appState.JJService.GraphSearch = ""
+			m.appState.StatusMessage = "Search cleared"
+		default:
+			m.appState.JJService.GraphSearch = msg.Revset
+			m.appState.StatusMessage = fmt.Sprintf("%d commits match %q", len(msg.Matches), msg.Query)
+		}
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.AbsorbPreviewLoadedMsg:
+		m.appState.Loading = false
+		m.graphTabModel.Update(msg)
+		if msg.Err != nil {
+			return m, func() tea.Msg { return util.ErrorMsg{Err: fmt.Errorf("absorb preview failed: %w", msg.Err)} }
+		}
+		if m.graphTabModel.IsAbsorbPreviewOpen() {
+			m.appState.StatusMessage = fmt.Sprintf("Absorb would change %d commit(s)", len(msg.Result.Destinations))
+		} else {
+			m.appState.StatusMessage = graphtab.AbsorbSummary(msg.Result)
+		}
+		return m, nil
+	case graphtab.AbsorbedMsg:
+		reload := data.LoadRepository(m.appState.JJService)
+		if msg.Err != nil {
+			return m, tea.Batch(reload, func() tea.Msg { return util.ErrorMsg{Err: fmt.Errorf("absorb failed: %w", msg.Err)} })
+		}
+		m.appState.StatusMessage = graphtab.AbsorbSummary(msg.Result)
+		return m, reload
+	case graphtab.ParallelizedMsg:
+		if msg.Err != nil {
+			m.appState.Loading = false
+			return m, func() tea.Msg { return util.ErrorMsg{Err: fmt.Errorf("failed to parallelize: %w", msg.Err)} }
+		}
+		m.statusAfterReload = msg.Status()
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.MergedCommitsMsg:
+		if msg.Err != nil {
+			m.appState.Loading = false
+			return m, func() tea.Msg { return util.ErrorMsg{Err: fmt.Errorf("failed to merge: %w", msg.Err)} }
+		}
+		m.statusAfterReload = msg.Status()
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.AbandonedMsg:
+		if msg.Err != nil {
+			m.appState.Loading = false
+			return m, func() tea.Msg { return util.ErrorMsg{Err: fmt.Errorf("failed to abandon: %w", msg.Err)} }
+		}
+		m.statusAfterReload = msg.Status()
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.TrashRestoredMsg:
+		if msg.Err != nil {
+			m.appState.Loading = false
+			return m, func() tea.Msg {
+				return util.ErrorMsg{Err: fmt.Errorf("failed to restore %s: %w", msg.Entry.ChangeID, msg.Err)}
+			}
+		}
+		m.statusAfterReload = msg.Status()
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.InsertedEmptyMsg:
+		if msg.Err != nil {
+			m.appState.Loading = false
+			return m, func() tea.Msg { return util.ErrorMsg{Err: fmt.Errorf("failed to insert commit: %w", msg.Err)} }
+		}
+		m.statusAfterReload = msg.Status()
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.DuplicatedMsg:
+		if msg.Err != nil {
+			m.appState.Loading = false
+			return m, func() tea.Msg { return util.ErrorMsg{Err: fmt.Errorf("failed to duplicate: %w", msg.Err)} }
+		}
+		m.statusAfterReload = msg.Status()
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.BackedOutMsg:
+		if msg.Err != nil {
+			m.appState.Loading = false
+			return m, func() tea.Msg { return util.ErrorMsg{Err: fmt.Errorf("failed to back out: %w", msg.Err)} }
+		}
+		m.statusAfterReload = msg.Status()
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.MovedWorkMsg:
+		if msg.Err != nil {
+			m.appState.Loading = false
+			return m, func() tea.Msg { return util.ErrorMsg{Err: fmt.Errorf("failed to move your work: %w", msg.Err)} }
+		}
+		m.statusAfterReload = msg.Status()
+		return m, data.LoadRepository(m.appState.JJService)
+	case graphtab.AliasRanMsg:
+		m.appState.Loading = false
+		content := msg.Output
+		if msg.Err != nil {
+			m.appState.StatusMessage = fmt.Sprintf("jj %s failed", msg.Name)
+			content = msg.Err.Error()
+		} else {
+			m.appState.StatusMessage = fmt.Sprintf("Ran jj %s", msg.Name)
+			if strings.TrimSpace(content) == "" {
+				content = "(no output)"
+			}
+		}
+		return m, tea.Batch(
+			state.NavigateTarget{Kind: state.NavigateOpenPager, PagerTitle: "jj " + msg.Name, PagerContent: content}.Cmd(),
+			data.LoadRepository(m.appState.JJService),
+		)
+	case branchestab.PushPreviewLoadedMsg, branchestab.ReviewPacketExportedMsg:
+		updated, _ := m.branchesTabModel.UpdateWithApp(msg, &m.appState)
+		m.branchesTabModel = updated
+		return m, nil
+	case branchestab.ReviewPacketRequestedMsg:
+		return m, m.exportReviewPacketCmd(msg)
+	case branchestab.ForkSyncedMsg:
+		updated, _ := m.branchesTabModel.UpdateWithApp(msg, &m.appState)
+		m.branchesTabModel = updated
+		m.appState.BranchRemoteFetchPending = false
+		if authErr := authPromptError(msg.Err); authErr != nil {
+			return m.handleAuthPrompt(authErr)
+		}
+		if msg.Err != nil {
+			m.appState.Loading = false
+			return m, nil
+		}
+		return m, tea.Batch(
+			branchestab.LoadBranchesCmd(m.appState.JJService),
 			data.LoadRepository(m.appState.JJService),
 		)
 
 	case settingstab.SettingsSavedMsg:
 		wasSettings := m.appState.ViewMode == state.ViewSettings
+		wasLowBandwidth := m.lowBandwidth()
 		cmd, errInfo := settingstab.HandleSettingsSavedMsg(msg, &m.appState)
 		if errInfo != nil {
 			m.errorModal.SetError(errInfo.Err, false, "")
 			return m, nil
 		}
+		if tickCmd := m.resumePRTickCmd(wasLowBandwidth); tickCmd != nil {
+			cmd = tea.Batch(cmd, tickCmd)
+		}
 		// Reloaded config pointer; keep evolog split modal in sync if user returns to split (z) after saving AI settings.
 		m.evologSplitModal = m.evologSplitModal.WithSuggestConfig(m.appState.Config)
 		// Propagate the new AI profile list to any open generate-bearing modal so
@@ -2018,7 +2963,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// to the live service and reload the branch list so the change is reflected immediately.
 		if m.appState.JJService != nil && m.appState.Config != nil {
 			m.appState.JJService.BookmarkListPreferTracked = m.appState.Config.BranchesFilterToTrackedAndMine()
-			cmd = tea.Batch(cmd, branchestab.LoadBranchesCmd(m.appState.JJService, m.settingsTabModel.GetSettingsBranchLimit()))
+			cmd = tea.Batch(cmd, branchestab.LoadBranchesCmd(m.appState.JJService))
 		}
 		return m, cmd
 
@@ -2026,7 +2971,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.beginModalUnderlay()
 		m.githubLoginModel.SetDeviceFlow(msg.DeviceCode, msg.UserCode, msg.VerificationURL, msg.Interval)
 		m.appState.ViewMode = state.ViewGitHubLogin
-		m.appState.StatusMessage = "Waiting for GitHub authorization..."
+		m.appState.StatusMessage = i18n.T("status.github_waiting")
 		// Do not auto-open the browser; user can press Enter or click "Copy Code & Open Browser" on the login screen.
 		return m, settingstab.PollGitHubTokenCmd(m.githubLoginModel.GetDeviceCode())
 
@@ -2034,7 +2979,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.beginModalUnderlay()
 		m.githubLoginModel.SetGhCLILoginMode()
 		m.appState.ViewMode = state.ViewGitHubLogin
-		m.appState.StatusMessage = "GitHub CLI: press Enter or click Run to start gh auth login."
+		m.appState.StatusMessage = i18n.T("status.gh_cli_prompt")
 		return m, nil
 
 	case githublogintab.GhCLIAuthFinishedMsg:
@@ -2068,7 +3013,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cfg.GitHubTokenSource = config.GitHubTokenSourceGhCLI
 		cfg.GitHubAuthMethod = config.GitHubAuthGhCLI
 		if err := cfg.Save(); err != nil {
-			m.appState.StatusMessage = fmt.Sprintf("could not save config: %v", err)
+			m.appState.StatusMessage = i18n.T("status.config_save_failed", err)
 			m.errorModal.SetError(err, false, "")
 			return m, nil
 		}
@@ -2076,7 +3021,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.settingsTabModel.GetGitHubModel().SetTokenSource(config.GitHubTokenSourceGhCLI)
 		m.settingsTabModel.GetGitHubModel().SetToken("")
 		m.settingsTabModel.SetSettingInputValue(0, "")
-		m.appState.StatusMessage = "GitHub CLI login successful!"
+		m.appState.StatusMessage = i18n.T("status.gh_cli_login_ok")
 		return m, data.InitializeServices(m.appState.DemoMode)
 
 	case settingstab.GitHubLoginPollMsg:
@@ -2101,7 +3046,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.clearModalUnderlay()
 		m.appState.ViewMode = state.ViewSettings
 		m.settingsTabModel.SetViewOpts(m.buildSettingsViewOpts())
-		m.appState.StatusMessage = "GitHub login successful!"
+		m.appState.StatusMessage = i18n.T("status.github_login_ok")
 		cfg, _ := config.Load()
 		cfg.SetGitHubToken(msg.Token, config.GitHubAuthDeviceFlow)
 		_ = cfg.Save()
@@ -2113,11 +3058,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.githubLoginModel.ClearFlow()
 		m.clearModalUnderlay()
 		m.appState.ViewMode = state.ViewSettings
-		m.appState.StatusMessage = fmt.Sprintf("GitHub login error: %v", msg.Err)
+		m.appState.StatusMessage = i18n.T("status.github_login_error", msg.Err)
 		m.errorModal.SetError(msg.Err, false, "")
 		return m, nil
 
 	case prformtab.PRCreatedMsg:
+		if msg.PR != nil && !m.appState.DemoMode {
+			events.Emit(events.Event{Type: events.TypePRCreated, PRNumber: msg.PR.Number, URL: msg.PR.URL, Branch: msg.PR.HeadBranch, Base: msg.PR.BaseBranch})
+		}
 		m.clearAIGenOverlay()
 		m.prFormModal.Hide()
 		m.clearModalUnderlay()
@@ -2129,7 +3077,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.appState.Loading = false
 		m.appState.ViewMode = state.ViewTickets
 		if msg.Ticket != nil {
-			m.appState.StatusMessage = fmt.Sprintf("Created %s: %s", msg.Ticket.DisplayKey, msg.Ticket.Summary)
+			m.appState.StatusMessage = i18n.T("status.ticket_created", msg.Ticket.DisplayKey, msg.Ticket.Summary)
 			cmd := ticketformtab.HandleTicketCreatedMsg(msg.Ticket, m.appState.TicketService, m.appState.DemoMode)
 			if cmd != nil {
 				return m, tea.Batch(cmd, ticketstab.LoadTicketsCmd(m.appState.TicketService, m.appState.DemoMode))
@@ -2138,13 +3086,31 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, ticketstab.LoadTicketsCmd(m.appState.TicketService, m.appState.DemoMode)
 	case prstab.BranchPushedMsg:
-		return m, branchestab.HandleBranchPushedMsg(msg, &m.appState)
+		return m, tea.Batch(
+			branchestab.HandleBranchPushedMsg(msg, &m.appState),
+			branchestab.LoadBranchesCmd(m.appState.JJService),
+		)
 	case bookmarktab.BookmarkCreatedMsg:
 		m.clearAIGenOverlay()
 		m.bookmarkModal.Hide()
 		m.clearModalUnderlay()
 		m.appState.Loading = false
-		return m, bookmarktab.HandleBookmarkCreatedMsg(msg, &m.appState)
+		// A bookmark named after a ticket ("proj-123-fix") counts as created from it.
+		linked := ""
+		if msg.TicketKey == "" && !msg.WasMoved {
+			if t, ok := bookmarktab.TicketForBookmarkName(msg.BookmarkName, m.ticketsTabModel.GetTickets()); ok {
+				msg.TicketKey = t.Key
+				linked = t.DisplayKey
+				if linked == "" {
+					linked = t.Key
+				}
+			}
+		}
+		cmd := bookmarktab.HandleBookmarkCreatedMsg(msg, &m.appState)
+		if linked != "" {
+			m.appState.StatusMessage += fmt.Sprintf(" (linked to %s)", linked)
+		}
+		return m, cmd
 	case bookmarktab.BookmarkDeletedMsg:
 		return m, branchestab.HandleBookmarkDeletedMsg(msg, &m.appState)
 	case branchestab.BookmarkConflictInfoMsg:
@@ -2173,7 +3139,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Err != nil {
 			m.errorModal.SetError(msg.Err, false, "")
 		}
-		return m, conflicttab.HandleBookmarkConflictResolvedMsg(msg, &m.appState, m.settingsTabModel.GetSettingsBranchLimit())
+		return m, conflicttab.HandleBookmarkConflictResolvedMsg(msg, &m.appState)
 	case graphtab.DivergentCommitInfoMsg:
 		cmd, info := divergenttab.HandleDivergentCommitInfoMsg(msg, &m.appState)
 		if info != nil {
@@ -2189,9 +3155,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		updated, cmd := m.evologSplitModal.Update(msg)
 		m.evologSplitModal = updated
 		if msg.Err == nil {
-			m.appState.StatusMessage = "Pick parent (j/k, Enter); o step diff; s AI suggest; p plan preview (opens after suggest)"
+			m.appState.StatusMessage = i18n.T("status.evolog_pick_parent")
 		} else {
-			m.appState.StatusMessage = "Evolog load failed"
+			m.appState.StatusMessage = i18n.T("status.evolog_load_failed")
 		}
 		return m, cmd
 	case evologsplittab.EvologDiffLoadRequestedMsg:
@@ -2269,18 +3235,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			warnCmd = state.NavigateTarget{
 				Kind:           state.NavigateWarning,
-				WarningTitle:   "AI suggest split failed",
-				WarningMessage: msgText + "\n\nPress Esc to dismiss.",
+				WarningTitle:   i18n.T("modal.warning.ai_split_failed"),
+				WarningMessage: msgText + "\n\n" + i18n.T("modal.warning.press_esc"),
 				WarningCommits: nil,
 			}.Cmd()
 		}
 		updated, sub := m.evologSplitModal.Update(msg)
 		m.evologSplitModal = updated
 		if msg.Err == nil && !msg.NoSplit && msg.PickIndex > 0 {
-			m.appState.StatusMessage = "AI plan: preview opened — Esc closes overlay, then Enter to split or adjust row"
+			m.appState.StatusMessage = i18n.T("status.evolog_plan_opened")
 		}
 		if msg.Err == nil && msg.NoSplit {
-			m.appState.StatusMessage = "AI: no split — use p for WC files or pick another row"
+			m.appState.StatusMessage = i18n.T("status.evolog_no_split")
 		}
 		if warnCmd != nil && sub != nil {
 			return m, tea.Batch(sub, warnCmd)
@@ -2296,7 +3262,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.evologSplitModal.SetPendingMultiSplitIDs(rem)
 			m2, cmd := m.applyRepositoryLoaded(msg.Repository)
 			m2.appState.ViewMode = state.ViewEvologSplit
-			m2.appState.StatusMessage = fmt.Sprintf("Stepwise split: %d base(s) left — review evolog, then Enter", len(rem))
+			m2.appState.StatusMessage = i18n.T("status.evolog_stepwise_left", len(rem))
 			wc := msg.Repository.WorkingCopy
 			bn := m2.evologStepwiseBookmarkName
 			loadCmd := evologsplittab.LoadEvologCmd(m2.appState.JJService, bn, wc)
@@ -2309,7 +3275,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.evologSplitModal.Hide()
 		m.appState.ViewMode = state.ViewCommitGraph
 		m2, cmd := m.applyRepositoryLoaded(msg.Repository)
-		m2.appState.StatusMessage = "Split complete — Graph (g) shows what jj did; compare to the plan you saw in Preview (p) before split"
+		m2.appState.StatusMessage = i18n.T("status.evolog_split_complete")
 		if m2.evologPostSplitDescribe && m2.appState.JJService != nil && m2.appState.Config != nil && m2.appState.Config.AIConfiguredForGeneration() {
 			m2.evologPostSplitDescribe = false
 			preChild := strings.TrimSpace(m2.evologPrecomputedDescribeChild)
@@ -2436,7 +3402,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.clearAIGenOverlay()
 		m.desceditModal.Hide()
 		m.clearModalUnderlay()
-		// Keep Loading true through the LoadRepository reload returned above so the busy
+		// Keep Loading true through the graph refresh returned above so the busy
 		// overlay stays up (now over the graph) until applyRepositoryLoaded renders the
 		// updated description. Re-batch a spinner tick in case clearAIGenOverlay stopped it.
 		return m, tea.Batch(cmd, m.startBusySpinnerCmd())
@@ -2465,13 +3431,56 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleClipboardCopiedMsg(msg)
 	case settingstab.CleanupCompletedMsg:
 		return m, settingstab.HandleCleanupCompletedMsg(msg, &m.appState)
+	case settingstab.ImmutableHeadsLoadedMsg:
+		m.settingsTabModel.GetAdvancedModel().SetImmutableHeads(msg.Revset, msg.Err)
+		return m, nil
+	case settingstab.ImmutableHeadsSavedMsg:
+		return m, settingstab.HandleImmutableHeadsSavedMsg(msg, &m.settingsTabModel, &m.appState)
+	case settingstab.MergeToolTestedMsg:
+		if msg.Err != nil {
+			m.appState.StatusMessage = fmt.Sprintf("%s test launch failed: %v", msg.Tool, msg.Err)
+		} else {
+			m.appState.StatusMessage = fmt.Sprintf("%s launched and exited cleanly", msg.Tool)
+		}
+		return m, nil
 
+	case graphtab.StackFilesLoadedMsg:
+		m.graphTabModel.Update(msg)
+		if msg.Err != nil {
+			m.appState.StatusMessage = "Stack files failed"
+		} else {
+			m.appState.StatusMessage = fmt.Sprintf("Stack files: %d commits", len(msg.Commits))
+		}
+		return m, nil
+	case graphtab.HunkSplitLoadedMsg:
+		m.graphTabModel.Update(msg)
+		if msg.Err != nil {
+			m.appState.StatusMessage = i18n.T("status.hunk_split_load_failed")
+		} else {
+			m.appState.StatusMessage = i18n.T("status.hunk_split_loaded", len(msg.Files))
+		}
+		return m, nil
+	case graphtab.TrashLoadedMsg:
+		m.graphTabModel.Update(msg)
+		return m, nil
+	case graphtab.AliasesLoadedMsg:
+		m.graphTabModel.Update(msg)
+		if msg.Err != nil {
+			m.appState.StatusMessage = "Reading jj aliases failed"
+		}
+		return m, nil
 	case graphtab.ChangedFilesLoadedMsg:
 		updated, cmd := m.graphTabModel.Update(msg)
 		if g, ok := updated.(*graphtab.GraphModel); ok {
 			m.graphTabModel = *g
 		}
+		if commitID, ok := m.graphTabModel.CommitHistoryToLoad(); ok {
+			cmd = tea.Batch(cmd, graphtab.LoadCommitHistoryCmd(m.appState.JJService, m.appState.GitHubService, commitID))
+		}
 		return m, cmd
+	case graphtab.CommitHistoryLoadedMsg:
+		m.graphTabModel.Update(msg)
+		return m, nil
 	case filedifftab.FileDiffLoadedMsg:
 		updated, cmd := m.fileDiffModal.Update(msg)
 		m.fileDiffModal = updated
@@ -2486,6 +3495,43 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.appState.StatusMessage = ""
 		}
 		return m, cmd
+	case filedifftab.ConflictTakeMsg:
+		if msg.Take == jj.ConflictTakeBoth {
+			return m, m.resolveConflict(msg)
+		}
+		return m, m.askConfirm(confirmtab.Prompt{
+			Action:    "resolve_conflict",
+			Title:     "Resolve conflict",
+			Message:   fmt.Sprintf("Keep only the %s side of every conflict in %s? The other side's changes in those hunks are dropped.", msg.Take, msg.Path),
+			OnConfirm: func() tea.Msg { return conflictTakeConfirmedMsg(msg) },
+		})
+	case conflictTakeConfirmedMsg:
+		return m, m.resolveConflict(filedifftab.ConflictTakeMsg(msg))
+	case filedifftab.OpenMergeToolMsg:
+		tool := ""
+		if m.appState.Config != nil {
+			t, _ := jj.LookupMergeTool(m.appState.Config.MergeTool)
+			tool = t.Name
+		}
+		return m, filedifftab.OpenMergeToolCmd(m.appState.JJService, tool, msg.Path)
+	case filedifftab.MergeToolExitedMsg:
+		if msg.Err != nil {
+			err := fmt.Errorf("merge tool failed on %s: %w", msg.Path, msg.Err)
+			return m, func() tea.Msg { return util.ErrorMsg{Err: err} }
+		}
+		m.fileDiffModal.Hide()
+		m.restoreModalUnderlayOrGraph()
+		m.appState.StatusMessage = fmt.Sprintf("Merge tool finished on %s", msg.Path)
+		return m, data.LoadRepository(m.appState.JJService)
+	case filedifftab.ConflictResolvedMsg:
+		if msg.Err != nil {
+			err := fmt.Errorf("failed to resolve %s: %w", msg.Path, msg.Err)
+			return m, func() tea.Msg { return util.ErrorMsg{Err: err} }
+		}
+		m.fileDiffModal.Hide()
+		m.restoreModalUnderlayOrGraph()
+		m.appState.StatusMessage = fmt.Sprintf("Resolved %s (took %s)", msg.Path, msg.Take)
+		return m, data.LoadRepository(m.appState.JJService)
 	case loadChangedFilesTriggerMsg:
 		if m.appState.JJService != nil && m.appState.Repository != nil {
 			commits := m.appState.Repository.Graph.Commits
@@ -2497,6 +3543,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case tickMsg:
 		return m.handleTickMsg()
+	case statusSegmentMsg:
+		m.handleStatusSegmentMsg(msg)
+		return m, nil
 	case graphtab.UndoCompletedMsg:
 		cmd, errInfo := graphtab.HandleUndoCompletedMsg(msg, &m.appState)
 		if errInfo != nil {
@@ -2504,11 +3553,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.errorModal.SetError(errInfo.Err, false, "")
 			return m, nil
 		}
-		if msg.Message == "Undo completed" {
-			m.redoOperationID = msg.RedoOpID
-		} else {
-			m.redoOperationID = ""
-		}
+		m.redoDepth = msg.RedoDepth
+		m.statusAfterReload = msg.Message
 		return m, cmd
 
 	// Handle our custom messages
@@ -2519,6 +3565,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if msg.Tab == state.ViewHelp {
 			m.helpTabModel.SetCommandHistoryEntries(helptab.BuildCommandHistoryEntries(m.appState.JJService))
+			return m, diagnostics.LoadCmd(m.diagnosticsInput())
 		}
 		return m, nil
 
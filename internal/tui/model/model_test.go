@@ -660,7 +660,7 @@ func TestHelpViewContent(t *testing.T) {
 	m.appState.ViewMode = state.ViewHelp
 	// Use a tall height so the scroll window includes the Navigation section (Quit) in the visible area
 	m.width = 100
-	m.height = 120
+	m.height = 200
 	m.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
 
 	view := m.View()
@@ -1890,3 +1890,106 @@ func TestBookmarkConflictDialogNotHiddenByLoadingOverlay(t *testing.T) {
 		t.Fatalf("conflict dialog should appear above loading state; view snippet: %.200q", view)
 	}
 }
+
+// TestPopupMode verifies --popup pins the view and Enter picks the selected change and quits.
+func TestPopupMode(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+
+	if err := m.SetPopup("settings"); err == nil {
+		t.Fatal("SetPopup(settings) should fail")
+	}
+	if err := m.SetPopup("graph"); err != nil {
+		t.Fatalf("SetPopup(graph): %v", err)
+	}
+	m.graphTabModel.SelectCommit(1)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = newModel.(*Model)
+	if m.GetViewMode() != state.ViewCommitGraph {
+		t.Errorf("Expected popup to stay on the graph, got %v", m.GetViewMode())
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(*Model)
+	if m.PopupResult() != "def4" {
+		t.Errorf("Expected pick def4, got %q", m.PopupResult())
+	}
+	if cmd == nil {
+		t.Fatal("Expected quit command after pick")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected tea.QuitMsg after pick")
+	}
+}
+
+// TestPagerOpensFromPRTabAndReturns verifies v opens the selected PR in the pager and q returns to the PR tab.
+func TestPagerOpensFromPRTabAndReturns(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.ViewMode = state.ViewPullRequests
+	m.prsTabModel.SetSelectedPR(0)
+
+	run := func(msg tea.Msg) {
+		t.Helper()
+		newModel, cmd := m.Update(msg)
+		m = newModel.(*Model)
+		for cmd != nil {
+			next := cmd()
+			if _, ok := next.(state.NavigateMsg); !ok {
+				return
+			}
+			newModel, cmd = m.Update(next)
+			m = newModel.(*Model)
+		}
+	}
+
+	run(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if m.GetViewMode() != state.ViewPager {
+		t.Fatalf("Expected pager after v, got %v", m.GetViewMode())
+	}
+	if !strings.Contains(m.View(), "PR #1: Test PR") {
+		t.Error("Expected pager title with PR number and title")
+	}
+	run(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if m.GetViewMode() != state.ViewPullRequests {
+		t.Errorf("Expected PR tab after closing pager, got %v", m.GetViewMode())
+	}
+}
+
+// TestGraphTextSelection verifies V opens the graph in the pager with a selection on the selected
+// commit, and Esc cancels the selection before a second Esc closes the pager.
+func TestGraphTextSelection(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+
+	run := func(msg tea.Msg) {
+		t.Helper()
+		newModel, cmd := m.Update(msg)
+		m = newModel.(*Model)
+		for cmd != nil {
+			next := cmd()
+			if _, ok := next.(state.NavigateMsg); !ok {
+				return
+			}
+			newModel, cmd = m.Update(next)
+			m = newModel.(*Model)
+		}
+	}
+
+	run(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	if m.GetViewMode() != state.ViewPager || !m.pagerModal.IsSelecting() {
+		t.Fatalf("Expected a pager selection after V, got view %v", m.GetViewMode())
+	}
+	if !strings.Contains(m.View(), "-- VISUAL -- 1 line") {
+		t.Error("Expected the selection footer")
+	}
+	run(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.GetViewMode() != state.ViewPager || m.pagerModal.IsSelecting() {
+		t.Fatal("Esc should cancel the selection and keep the pager open")
+	}
+	run(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.GetViewMode() != state.ViewCommitGraph {
+		t.Errorf("Expected the graph after closing the pager, got %v", m.GetViewMode())
+	}
+}
@@ -90,10 +90,15 @@ func (m *Model) handleZoneClick(msg zone.MsgZoneInBounds) (tea.Model, tea.Cmd) {
 		m.warningModal = updated
 		return m, cmd
 	}
+	if m.confirmModal.IsShown() {
+		updated, cmd := m.confirmModal.Update(msg)
+		m.confirmModal = updated
+		return m, cmd
+	}
 
 	// ——— Global zones (tab nav, status bar actions) ———
 	tabZone := userClicked(mouse.ZoneTabGraph) || userClicked(mouse.ZoneTabPRs) || userClicked(mouse.ZoneTabJira) ||
-		userClicked(mouse.ZoneTabBranches) || userClicked(mouse.ZoneTabSettings) || userClicked(mouse.ZoneTabHelp)
+		userClicked(mouse.ZoneTabBranches) || userClicked(mouse.ZoneTabWorkspaces) || userClicked(mouse.ZoneTabSettings) || userClicked(mouse.ZoneTabHelp)
 	if tabZone && (m.initRepoModel.Path() != "" || m.isFormModalView()) {
 		return m, nil
 	}
@@ -109,6 +114,9 @@ func (m *Model) handleZoneClick(msg zone.MsgZoneInBounds) (tea.Model, tea.Cmd) {
 	if userClicked(mouse.ZoneTabBranches) {
 		return m.handleNavigateToBranchesTab()
 	}
+	if userClicked(mouse.ZoneTabWorkspaces) {
+		return m.handleNavigateToWorkspacesTab()
+	}
 	if userClicked(mouse.ZoneTabSettings) {
 		return m.handleNavigateToSettingsTab()
 	}
@@ -131,6 +139,12 @@ func (m *Model) handleZoneClick(msg zone.MsgZoneInBounds) (tea.Model, tea.Cmd) {
 	if userClicked(mouse.ZoneActionRedo) {
 		return m.handleRedo()
 	}
+	if userClicked(mouse.ZoneActionStatusLog) {
+		return m, m.openStatusLog()
+	}
+	if userClicked(mouse.ZoneActionUpdateStale) {
+		return m.handleUpdateStale()
+	}
 
 	// ——— Forward zone to active view's submodel (by viewMode) ———
 	// Graph, PRs, Branches, and Tickets already receive zone.MsgZoneInBounds via
@@ -3,6 +3,7 @@ package model
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	overlay "github.com/madicen/bubble-overlay"
+	"github.com/madicen/jj-tui/internal/i18n"
 )
 
 // applyBubbleOverlayCentered composites modalView over fullView at the center (full terminal size).
@@ -19,7 +20,7 @@ func (m *Model) wrapFirstPRLoadCmd(prCmd tea.Cmd) tea.Cmd {
 		return prCmd
 	}
 	m.appState.Loading = true
-	m.appState.StatusMessage = "Loading pull requests…"
+	m.appState.StatusMessage = i18n.T("status.loading_prs")
 	return tea.Batch(prCmd, m.startBusySpinnerCmd())
 }
 
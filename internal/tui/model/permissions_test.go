@@ -0,0 +1,51 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+func TestGitHubPermissionsDisablePRActions(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.prsTabModel.SetGithubService(true)
+	m.prsTabModel.SetSelectedPR(0)
+	m.SetGitHubPermissions(github.NewPermissions(github.TokenKindClassic, map[github.Capability]string{
+		github.CapMergePR: "token needs the \"repo\" scope",
+	}))
+	m.appState.ViewMode = state.ViewPullRequests
+
+	if view := m.View(); !strings.Contains(view, "Merge PRs unavailable: token needs the \"repo\" scope") {
+		t.Error("PR detail should explain why Merge is disabled")
+	}
+
+	ctx := prstab.BuildRequestContext(&prstab.ContextInput{
+		Repository:  m.appState.Repository,
+		SelectedPR:  0,
+		GitHubOK:    true,
+		Permissions: m.appState.GitHubPermissions,
+	})
+	if status, cmd := prstab.ExecuteRequest(prstab.Request{MergePR: true}, ctx); cmd != nil || !strings.HasPrefix(status, "Can't merge:") {
+		t.Fatalf("merge should be refused up front, got status %q", status)
+	}
+}
+
+func TestCanReadPRsFollowsPermissions(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.SetGitHubPermissions(github.NewPermissions(github.TokenKindFineGrained, map[github.Capability]string{
+		github.CapReadPRs: "token lacks the \"Pull requests: read\" permission",
+	}))
+	if m.canReadPRs() {
+		t.Fatal("PR reads should be disabled")
+	}
+	m.SetGitHubPermissions(nil)
+	if !m.canReadPRs() {
+		t.Fatal("an unprobed token should be allowed to read PRs")
+	}
+}
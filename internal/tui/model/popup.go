@@ -0,0 +1,150 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// PopupViews are the views --popup can start on.
+var PopupViews = []string{"graph", "prs", "tickets", "branches"}
+
+// SetPopup turns on popup mode (tmux display-popup / zellij floating pane): the tab bar is hidden,
+// the model stays on view, Enter picks the selected item (see PopupResult), and Esc/q or any
+// repository-changing action quits. Call before the program starts.
+func (m *Model) SetPopup(view string) error {
+	if !slices.Contains(PopupViews, view) {
+		return fmt.Errorf("unknown popup view %q (want one of %s)", view, strings.Join(PopupViews, ", "))
+	}
+	m.popupView = view
+	switch view {
+	case "prs":
+		m.appState.ViewMode = state.ViewPullRequests
+	case "tickets":
+		m.appState.ViewMode = state.ViewTickets
+	case "branches":
+		m.appState.ViewMode = state.ViewBranches
+	}
+	return nil
+}
+
+// PopupResult returns what the user picked with Enter in popup mode (change ID, PR URL, ticket key,
+// or bookmark name), or "" when they quit without picking.
+func (m *Model) PopupResult() string {
+	return m.popupResult
+}
+
+// popupViewMode is the view mode popup mode is pinned to.
+func (m *Model) popupViewMode() state.ViewMode {
+	switch m.popupView {
+	case "prs":
+		return state.ViewPullRequests
+	case "tickets":
+		return state.ViewTickets
+	case "branches":
+		return state.ViewBranches
+	}
+	return state.ViewCommitGraph
+}
+
+// enterPopupView loads the popup's start view once the services it needs are up: branches need
+// the jj service (repo ready), tickets need the ticket service (aux services ready). The graph and
+// PR list load on startup anyway.
+func (m *Model) enterPopupView(auxReady bool) tea.Cmd {
+	if m.popupEntered {
+		return nil
+	}
+	var cmd tea.Cmd
+	switch m.popupView {
+	case "branches":
+		_, cmd = m.handleNavigateToBranchesTab()
+	case "tickets":
+		if !auxReady {
+			return nil
+		}
+		_, cmd = m.handleNavigateToTicketsTab()
+	default:
+		return nil
+	}
+	m.popupEntered = true
+	return cmd
+}
+
+// handlePopupKey handles Enter (pick) and Esc/q (quit) in popup mode. It returns handled=false
+// when the key belongs to the tab, e.g. Esc closing a context menu or leaving rebase mode.
+func (m *Model) handlePopupKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.popupView == "" || m.appState.ViewMode != m.popupViewMode() {
+		return false, nil
+	}
+	switch m.appState.ViewMode {
+	case state.ViewCommitGraph:
+		if m.graphTabModel.HasContextMenu() || m.graphTabModel.GetSelectionMode() != graphtab.SelectionNormal || m.graphTabModel.IsEditingDateFilter() || m.graphTabModel.IsBulkDescribeOpen() || m.graphTabModel.IsMergeDialogOpen() || m.graphTabModel.IsEditingFileFilter() || m.graphTabModel.IsAliasPickerOpen() || m.graphTabModel.IsTrashOpen() || m.graphTabModel.IsAbsorbPreviewOpen() || m.graphTabModel.IsHunkSplitFocused() || m.graphTabModel.IsEditingGraphSearch() {
+			return false, nil
+		}
+	case state.ViewPullRequests:
+		if m.prsTabModel.HasContextMenu() || m.prsTabModel.IsReviewFormOpen() || m.prsTabModel.IsReviewReplyOpen() || m.prsTabModel.IsMergeFormOpen() {
+			return false, nil
+		}
+	case state.ViewTickets:
+		if m.ticketsTabModel.IsStatusChangeMode() {
+			return false, nil
+		}
+	case state.ViewBranches:
+		if m.branchesTabModel.IsCapturingKeys() {
+			return false, nil
+		}
+	}
+	switch msg.String() {
+	case "esc", "q":
+		return true, m.popupQuit()
+	case "enter":
+		pick := m.popupPick()
+		if pick == "" {
+			return true, nil
+		}
+		m.popupResult = pick
+		return true, m.popupQuit()
+	}
+	return false, nil
+}
+
+// popupPick returns the selected item of the popup view in the form a calling script can use.
+func (m *Model) popupPick() string {
+	switch m.popupView {
+	case "graph":
+		idx := m.graphTabModel.GetSelectedCommit()
+		if m.appState.Repository != nil && idx >= 0 && idx < len(m.appState.Repository.Graph.Commits) {
+			return m.appState.Repository.Graph.Commits[idx].ChangeID
+		}
+	case "prs":
+		idx := m.prsTabModel.GetSelectedPR()
+		if m.appState.Repository != nil && idx >= 0 && idx < len(m.appState.Repository.PRs) {
+			return m.appState.Repository.PRs[idx].URL
+		}
+	case "tickets":
+		list := m.ticketsTabModel.GetTickets()
+		if idx := m.ticketsTabModel.GetSelectedTicket(); idx >= 0 && idx < len(list) {
+			return list[idx].DisplayKey
+		}
+	case "branches":
+		list := m.branchesTabModel.GetBranches()
+		if idx := m.branchesTabModel.GetSelectedBranch(); idx >= 0 && idx < len(list) {
+			b := list[idx]
+			if !b.IsLocal && b.Remote != "" {
+				return b.Name + "@" + b.Remote
+			}
+			return b.Name
+		}
+	}
+	return ""
+}
+
+func (m *Model) popupQuit() tea.Cmd {
+	util.FlushMouse()
+	return tea.Quit
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+// d loads the PR detail into the list area; Esc closes it without leaving the tab.
+func TestPRDetailView(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.appState.GitHubService = &github.Service{}
+	m.prsTabModel.SetGithubService(true)
+	m.prsTabModel.SetSelectedPR(0)
+	m.appState.ViewMode = state.ViewPullRequests
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if cmd == nil {
+		t.Fatal("d should load the PR detail")
+	}
+	msg, ok := unscoped(cmd()).(prstab.PRDetailLoadedMsg)
+	if !ok || msg.PRNumber != 1 {
+		t.Fatalf("loaded = %+v", msg)
+	}
+	m.Update(msg)
+	if !m.prsTabModel.IsPRDetailOpen() {
+		t.Fatal("the detail view should open for the selected PR")
+	}
+	view := m.View()
+	for _, want := range []string{"Checks (2 of 4 passed)", "test (windows-latest)", "Review threads (2, 1 resolved)", "Comments (1)", "flags.go"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail view is missing %q", want)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.prsTabModel.IsPRDetailOpen() || m.appState.ViewMode != state.ViewPullRequests {
+		t.Fatalf("Esc should close the detail view and stay on the tab (view %v)", m.appState.ViewMode)
+	}
+}
+
+// A PR body's task list shows as a done/total badge in the list; in the detail view c moves to an
+// item and x toggles it through a body update.
+func TestPRChecklistToggle(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.appState.GitHubService = &github.Service{}
+	m.prsTabModel.SetGithubService(true)
+	m.appState.Repository.PRs[0].Body = "Steps:\n- [x] Parse\n- [ ] Render\n* [ ] Docs\n```\n- [ ] not a task\n```"
+	m.prsTabModel.SetSelectedPR(0)
+	m.appState.ViewMode = state.ViewPullRequests
+
+	if view := m.View(); !strings.Contains(view, "☑ 1/3") {
+		t.Fatal("the PR row should show checklist progress 1/3")
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m.Update(cmd())
+	if view := m.View(); !strings.Contains(view, "Checklist (1/3)") {
+		t.Fatal("the detail view should list the checklist")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if cmd == nil {
+		t.Fatal("x should toggle the checklist item under the cursor")
+	}
+	m.Update(cmd())
+	if got := m.appState.Repository.PRs[0].Body; !strings.Contains(got, "- [x] Render") || !strings.Contains(got, "- [ ] not a task") {
+		t.Errorf("body = %q, want Render checked and the code block untouched", got)
+	}
+	if !strings.Contains(m.appState.StatusMessage, "2/3 done") {
+		t.Errorf("status = %q", m.appState.StatusMessage)
+	}
+}
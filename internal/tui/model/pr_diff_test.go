@@ -0,0 +1,47 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+// f opens the selected PR's diff in the file diff viewer; closing it returns to the PR list.
+func TestPRDiffView(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.appState.GitHubService = &github.Service{}
+	m.prsTabModel.SetGithubService(true)
+	m.prsTabModel.SetSelectedPR(0)
+	m.appState.ViewMode = state.ViewPullRequests
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if cmd == nil {
+		t.Fatal("f should load the PR diff")
+	}
+	msg, ok := unscoped(cmd()).(prstab.PRDiffLoadedMsg)
+	if !ok || msg.PR.Number != 1 {
+		t.Fatalf("loaded = %+v", msg)
+	}
+	_, cmd = m.Update(msg)
+	if cmd == nil {
+		t.Fatal("a loaded diff should open the diff viewer")
+	}
+	m.Update(cmd())
+	if m.appState.ViewMode != state.ViewFileDiff || m.tabHighlightMode() != state.ViewPullRequests {
+		t.Fatalf("view = %v, tab = %v", m.appState.ViewMode, m.tabHighlightMode())
+	}
+	if view := m.View(); !strings.Contains(view, "PR #1 diff") || !strings.Contains(view, "flags.go") {
+		t.Fatalf("diff viewer:\n%s", view)
+	}
+
+	m.Update(state.NavigateMsg{Target: state.NavigateTarget{Kind: state.NavigateCloseFileDiff}})
+	if m.appState.ViewMode != state.ViewPullRequests {
+		t.Fatalf("closing the diff should return to the PR list, got %v", m.appState.ViewMode)
+	}
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+// M opens the merge form on the configured method; switching to squash refills the commit title,
+// Ctrl+S merges with it, and the method is saved as the new default.
+func TestPRMergeForm(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.appState.GitHubService = &github.Service{}
+	m.appState.Config = &config.Config{PRMergeMethod: github.MergeMethodMerge}
+	m.prsTabModel.SetGithubService(true)
+	m.prsTabModel.SetSelectedPR(0)
+	m.appState.ViewMode = state.ViewPullRequests
+	press := func(k tea.KeyMsg) tea.Cmd {
+		_, cmd := m.Update(k)
+		return cmd
+	}
+
+	cmd := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	if cmd == nil {
+		t.Fatal("M should request the merge form")
+	}
+	m.Update(cmd())
+	if !m.prsTabModel.IsMergeFormOpen() || !strings.Contains(m.View(), "(•) Merge commit") {
+		t.Fatal("the merge form should open on the configured method")
+	}
+	// Merge commit is last; → wraps around to squash.
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if m.appState.ViewMode != state.ViewPullRequests || !strings.Contains(m.View(), "Test PR (#1)") {
+		t.Fatal("switching to squash should fill in the squash commit title")
+	}
+	cmd = press(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("ctrl+s should merge")
+	}
+	msg, ok := unscoped(cmd()).(prstab.PrMergedMsg)
+	if !ok || msg.PRNumber != 1 || msg.Method != github.MergeMethodSquash || msg.AutoMerge {
+		t.Fatalf("merged = %+v", msg)
+	}
+	m.Update(msg)
+	if m.prsTabModel.IsMergeFormOpen() || m.appState.StatusMessage != "Merged PR #1 (squash)" {
+		t.Fatalf("form open = %v, status = %q", m.prsTabModel.IsMergeFormOpen(), m.appState.StatusMessage)
+	}
+	saved, err := config.Load()
+	if err != nil || saved.PRMergeMethod != github.MergeMethodSquash || m.appState.Config.PRMergeMethod != github.MergeMethodSquash {
+		t.Fatalf("merge method not saved: %v", err)
+	}
+}
+
+func TestPRMergeFormAutoMerge(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.appState.GitHubService = &github.Service{}
+	m.prsTabModel.SetGithubService(true)
+	m.prsTabModel.SetSelectedPR(0)
+	m.appState.ViewMode = state.ViewPullRequests
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	m.Update(cmd())
+	m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	msg, ok := unscoped(cmd()).(prstab.PrMergedMsg)
+	if !ok || !msg.AutoMerge || msg.Method != github.MergeMethodMerge {
+		t.Fatalf("merged = %+v", msg)
+	}
+	m.Update(msg)
+	if !strings.HasPrefix(m.appState.StatusMessage, "Auto-merge enabled for PR #1") {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
+
+// With pr_merge_follow_up configured the merge form offers the steps (on by default), and a merge
+// runs them afterwards; Space on the follow-up line skips them for that merge.
+func TestPRMergeFormFollowUp(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.appState.GitHubService = &github.Service{}
+	m.appState.Config = &config.Config{PRMergeFollowUp: []string{"fetch", "Delete_Bookmark", "bogus", "transition_ticket"}}
+	m.prsTabModel.SetGithubService(true)
+	m.prsTabModel.SetSelectedPR(0)
+	m.appState.ViewMode = state.ViewPullRequests
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	m.Update(cmd())
+	if !strings.Contains(m.View(), "[x] Then: fetch → delete bookmark → transition ticket") {
+		t.Fatalf("the merge form should offer the follow-up:\n%s", m.View())
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	merged, ok := unscoped(cmd()).(prstab.PrMergedMsg)
+	if !ok || !merged.FollowUp {
+		t.Fatalf("merged = %+v, want FollowUp", merged)
+	}
+	_, cmd = m.Update(merged)
+	var done *prstab.MergeFollowUpDoneMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := unscoped(c()).(prstab.MergeFollowUpDoneMsg); ok {
+			done = &msg
+		}
+	}
+	if done == nil || len(done.Done) != 3 || done.Err != nil {
+		t.Fatalf("follow-up = %+v", done)
+	}
+	m.Update(*done)
+	if !strings.HasPrefix(m.appState.StatusMessage, "PR #1 follow-up: fetch (demo)") {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+
+	// Shift+Tab from Method lands on the follow-up line; Space turns it off.
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	m.Update(cmd())
+	m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if merged, ok := unscoped(cmd()).(prstab.PrMergedMsg); !ok || merged.FollowUp {
+		t.Fatalf("merged = %+v, want no follow-up", merged)
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+// r in the review comment list opens a reply; Tab fills in the configured templates with the PR
+// head's change ID, and Ctrl+S sends the reply and goes back to the list.
+func TestPRReviewReplyTemplates(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.appState.GitHubService = &github.Service{}
+	m.appState.Config = &config.Config{ReviewReplyTemplates: []string{"Thanks @{author}!", "Addressed in {change_id}"}}
+	m.prsTabModel.SetGithubService(true)
+	m.prsTabModel.SetSelectedPR(0)
+	m.appState.ViewMode = state.ViewPullRequests
+	m.appState.Repository.PRs[0].HeadBranch = "fix-errors"
+	pr := m.appState.Repository.PRs[0]
+	m.appState.Repository.Graph.Commits = append(m.appState.Repository.Graph.Commits,
+		internal.Commit{ID: "5c7e2b1f", ChangeID: "kpqxywon", Branches: []string{"fix-errors"}})
+	m.prsTabModel.UpdateRepository(m.appState.Repository)
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	press := func(k tea.KeyMsg) tea.Cmd {
+		_, cmd := m.Update(k)
+		return cmd
+	}
+
+	m.Update(prstab.ReviewCommentsLoadedMsg{PRNumber: pr.Number, Comments: []internal.ReviewComment{
+		{ID: 7, Path: "main.go", Line: 12, Author: "bob-smith", Body: "Nit: wrap this error."},
+	}})
+	press(runes("r"))
+	if !m.prsTabModel.IsReviewReplyOpen() {
+		t.Fatal("r should open the reply composer")
+	}
+	press(tea.KeyMsg{Type: tea.KeyTab})
+	press(tea.KeyMsg{Type: tea.KeyTab})
+	if view := m.View(); !strings.Contains(view, "Addressed in kpqxywon") {
+		t.Fatalf("second template not expanded into the reply:\n%s", view)
+	}
+	press(runes("b"))
+	if m.appState.ViewMode != state.ViewPullRequests {
+		t.Fatal("typing into the reply should not switch tabs")
+	}
+	cmd := press(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("ctrl+s should send the reply")
+	}
+	msg, ok := unscoped(cmd()).(prstab.ReviewRepliedMsg)
+	if !ok || msg.PRNumber != pr.Number || msg.CommentID != 7 {
+		t.Fatalf("replied = %+v", msg)
+	}
+	m.Update(msg)
+	if m.prsTabModel.IsReviewReplyOpen() || !m.prsTabModel.IsReviewCommentsOpen() {
+		t.Fatal("a sent reply should close the composer and keep the comment list")
+	}
+	if !strings.Contains(m.appState.StatusMessage, "Replied to the review comment on main.go:12") {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
+
+func TestExpandReplyTemplate(t *testing.T) {
+	vars := prstab.ReplyTemplateVars{ChangeID: "kpqxywon", CommitID: "5c7e2b1f", CommitURL: "https://github.com/o/r/commit/5c7e2b1f", Author: "bob"}
+	got := prstab.ExpandReplyTemplate("@{author} fixed in {change_id} ({commit_url}) {unknown}", vars)
+	if want := "@bob fixed in kpqxywon (https://github.com/o/r/commit/5c7e2b1f) {unknown}"; got != want {
+		t.Fatalf("ExpandReplyTemplate() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+// r opens the review form; typed keys (even tab-switching ones) go into the body, and Ctrl+S
+// submits the review and closes the form.
+func TestPRReviewForm(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.appState.GitHubService = &github.Service{}
+	m.prsTabModel.SetGithubService(true)
+	m.prsTabModel.SetSelectedPR(0)
+	m.appState.ViewMode = state.ViewPullRequests
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	press := func(k tea.KeyMsg) tea.Cmd {
+		_, cmd := m.Update(k)
+		return cmd
+	}
+
+	press(runes("r"))
+	if !m.prsTabModel.IsReviewFormOpen() {
+		t.Fatal("r should open the review form")
+	}
+	if cmd := press(tea.KeyMsg{Type: tea.KeyCtrlS}); cmd != nil || !strings.Contains(m.View(), "Comment needs a review body") {
+		t.Fatal("a comment without a body should not be submitted")
+	}
+	for _, r := range "good" {
+		press(runes(string(r)))
+	}
+	if m.appState.ViewMode != state.ViewPullRequests {
+		t.Fatal("typing into the review body should not switch tabs")
+	}
+	cmd := press(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("ctrl+s should submit the review")
+	}
+	msg, ok := unscoped(cmd()).(prstab.ReviewSubmittedMsg)
+	if !ok || msg.PRNumber != 1 || msg.Event != github.ReviewComment {
+		t.Fatalf("submitted = %+v", msg)
+	}
+	m.Update(msg)
+	if m.prsTabModel.IsReviewFormOpen() || m.appState.StatusMessage != "Commented on PR #1" {
+		t.Fatalf("form open = %v, status = %q", m.prsTabModel.IsReviewFormOpen(), m.appState.StatusMessage)
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+)
+
+// push_mode gerrit turns the PRs tab into a Push tab that lists outgoing changes and pushes the
+// selected one to refs/for/<gerrit_branch>.
+func TestPushModeReplacesPRsTab(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := newTestModel()
+	defer m.Close()
+	m.appState.DemoMode = true
+	m.appState.Config = &config.Config{PushMode: "gerrit", GerritBranch: "develop"}
+	m.appState.JJService = &jj.Service{}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !m.prsTabModel.IsPushMode() {
+		t.Fatal("push_mode gerrit should switch the PRs tab to the Push tab")
+	}
+	m.Update(prstab.ChangesLoadedMsg{Changes: []jj.OutgoingChange{
+		{PushPreviewCommit: jj.PushPreviewCommit{ChangeID: "kxqpmwvz", CommitID: "0123abcd", Summary: "Add parser"}},
+	}})
+	view := m.View()
+	for _, want := range []string{"Push (p)", "Push for review (Gerrit)", "origin refs/for/develop", "kxqpmwvz", "Add parser"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.appState.StatusMessage; !strings.Contains(got, "Would push kxqpmwvz") {
+		t.Errorf("status = %q, want the demo-mode push message", got)
+	}
+
+	m.appState.Config = &config.Config{}
+	m.syncPushMode()
+	if m.prsTabModel.IsPushMode() {
+		t.Error("clearing push_mode should bring back the PR list")
+	}
+}
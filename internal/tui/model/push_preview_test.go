@@ -0,0 +1,126 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+)
+
+// A loaded push preview lists the commits with WIP ones flagged; "y" pushes and "n" cancels
+// without reaching the global key bindings.
+func TestPushPreviewConfirm(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.ViewMode = state.ViewBranches
+	m.branchesTabModel.UpdateBranches([]internal.Branch{{Name: "feature", IsLocal: true}})
+	m.branchesTabModel.SetSelectedBranch(0)
+
+	preview := &jj.PushPreview{Bookmark: "feature", Remote: "origin", Replaced: 1, Commits: []jj.PushPreviewCommit{
+		{ChangeID: "kxqv", Summary: "Add the flag"},
+		{ChangeID: "zzyw", Summary: "wip: debugging"},
+	}}
+	newModel, _ := m.Update(branchestab.PushPreviewLoadedMsg{Bookmark: "feature", Preview: preview})
+	m = newModel.(*Model)
+	if m.appState.StatusMessage != "Pushing feature would publish 2 commits (1 look unfinished) — push? (y/n)" {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+	view := m.View()
+	for _, want := range []string{"Add the flag", "wip: debugging", "⚠ WIP?", "1 commit on feature@origin will be dropped"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("view missing %q", want)
+		}
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(*Model)
+	if m.branchesTabModel.IsCapturingKeys() || m.appState.ViewMode != state.ViewBranches {
+		t.Fatal("n should close the preview and stay on the Branches tab")
+	}
+
+	m.Update(branchestab.PushPreviewLoadedMsg{Bookmark: "feature", Preview: preview})
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(*Model)
+	if m.branchesTabModel.IsCapturingKeys() || m.appState.StatusMessage != "Pushing branch feature..." {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
+
+func TestPushPreviewUpToDate(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.ViewMode = state.ViewBranches
+
+	newModel, _ := m.Update(branchestab.PushPreviewLoadedMsg{Bookmark: "feature", Preview: &jj.PushPreview{Bookmark: "feature", Remote: "origin"}})
+	m = newModel.(*Model)
+	if m.appState.StatusMessage != "feature is already up to date on origin" || m.branchesTabModel.IsCapturingKeys() {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
+
+// Large or sensitive files the push would publish are listed with why they were flagged.
+func TestPushPreviewLargeFiles(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.ViewMode = state.ViewBranches
+	m.branchesTabModel.UpdateBranches([]internal.Branch{{Name: "feature", IsLocal: true}})
+	m.branchesTabModel.SetSelectedBranch(0)
+
+	preview := &jj.PushPreview{Bookmark: "feature", Remote: "origin",
+		Commits: []jj.PushPreviewCommit{{ChangeID: "kxqv", Summary: "Add fixtures"}},
+		LargeFiles: []jj.LargeFile{
+			{ChangeID: "kxqv", Path: "testdata/prod.sql", Size: 48 << 20, Pattern: "*.sql"},
+			{ChangeID: "kxqv", Path: "certs/server.pem", Size: 1200, Pattern: "*.pem"},
+		}}
+	newModel, _ := m.Update(branchestab.PushPreviewLoadedMsg{Bookmark: "feature", Preview: preview})
+	m = newModel.(*Model)
+	if !strings.Contains(m.appState.StatusMessage, "⚠ 2 large or sensitive files") {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+	view := m.View()
+	for _, want := range []string{"testdata/prod.sql", "48.0 MB, matches *.sql", "certs/server.pem"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+// Pushing a protected bookmark ignores "y" and only pushes once the bookmark's name is typed.
+func TestPushPreviewProtectedBookmark(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.ViewMode = state.ViewBranches
+	m.branchesTabModel.UpdateBranches([]internal.Branch{{Name: "main", IsLocal: true}})
+	m.branchesTabModel.SetSelectedBranch(0)
+
+	preview := &jj.PushPreview{Bookmark: "main", Remote: "origin", Protected: true,
+		Commits: []jj.PushPreviewCommit{{ChangeID: "kxqv", Summary: "Add the flag"}}}
+	newModel, _ := m.Update(branchestab.PushPreviewLoadedMsg{Bookmark: "main", Preview: preview})
+	m = newModel.(*Model)
+	if !strings.HasSuffix(m.appState.StatusMessage, "main is protected; type its name to push") {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+	if view := m.View(); !strings.Contains(view, "Type main to confirm") {
+		t.Fatal("view should ask for the bookmark name")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.branchesTabModel.IsCapturingKeys() {
+		t.Fatal("a wrong name should keep the confirmation open")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	for _, r := range "main" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(*Model)
+	if m.branchesTabModel.IsCapturingKeys() || m.appState.StatusMessage != "Pushing branch main..." {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
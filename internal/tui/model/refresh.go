@@ -0,0 +1,186 @@
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/data"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
+)
+
+// Periodic graph reload intervals when config doesn't set repo_refresh_interval: a watched repo
+// reloads on changes and only occasionally as a fallback; an unwatched one is polled on every tick.
+const (
+	watchedRepoRefreshInterval   = 30 * time.Second
+	unwatchedRepoRefreshInterval = autoRefreshInterval
+)
+
+// repoRefreshState drives background graph reloads: changes seen by the repo watcher, plus a
+// periodic reload for anything the watcher misses.
+type repoRefreshState struct {
+	watcher *jj.RepoWatcher
+	// pending collects watcher changes the graph doesn't show yet; they are dispatched as soon as
+	// a background reload is allowed (not idle, no modal, no load in flight).
+	pending jj.RepoChange
+	// loadStart / loadEnd bound the last graph load applied, to drop changes it already shows.
+	loadStart, loadEnd time.Time
+	// lastReload is when the last background reload was dispatched.
+	lastReload time.Time
+}
+
+// watchRepoCmd starts watching the current repository unless it is already watched. Any watcher
+// for another repository is closed.
+func (m *Model) watchRepoCmd() tea.Cmd {
+	svc := m.appState.JJService
+	if m.appState.SafeMode || m.appState.DemoMode || svc == nil {
+		return nil
+	}
+	if w := m.repoRefresh.watcher; w != nil {
+		if w.RepoPath == svc.RepoPath {
+			return nil
+		}
+		m.stopRepoWatch()
+	}
+	return data.WatchRepoCmd(svc)
+}
+
+// stopRepoWatch closes the repo watcher (repository closed, lost or replaced).
+func (m *Model) stopRepoWatch() {
+	if m.repoRefresh.watcher != nil {
+		_ = m.repoRefresh.watcher.Close()
+	}
+	m.repoRefresh.watcher = nil
+	m.repoRefresh.pending = jj.RepoChange{}
+}
+
+func (m *Model) handleRepoWatchStartedMsg(msg data.RepoWatchStartedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, nil // keep polling
+	}
+	svc := m.appState.JJService
+	if svc == nil || svc.RepoPath != msg.RepoPath || m.repoRefresh.watcher != nil {
+		_ = msg.Watcher.Close() // started for a repository we've left, or twice
+		return m, nil
+	}
+	m.repoRefresh.watcher = msg.Watcher
+	if msg.Watcher.Truncated() {
+		m.appState.StatusMessage = i18n.T("status.watch_truncated", jj.WatchMaxDirs)
+	}
+	return m, data.NextRepoChangeCmd(msg.Watcher)
+}
+
+func (m *Model) handleRepoChangedMsg(msg data.RepoChangedMsg) (tea.Model, tea.Cmd) {
+	if msg.Watcher != m.repoRefresh.watcher {
+		return m, nil // closed since
+	}
+	r := &m.repoRefresh
+	r.pending = r.pending.Merge(msg.Change).Since(r.loadStart, r.loadEnd)
+	return m, tea.Batch(data.NextRepoChangeCmd(msg.Watcher), m.refreshRepoCmd(false))
+}
+
+// noteRepositoryLoaded records a graph load that just finished (foreground or background) and
+// drops the pending changes it already shows.
+func (m *Model) noteRepositoryLoaded() {
+	r := &m.repoRefresh
+	r.loadEnd = time.Now()
+	if m.appState.JJService != nil {
+		r.loadStart = m.appState.JJService.LastSnapshot()
+	}
+	r.pending = r.pending.Since(r.loadStart, r.loadEnd)
+}
+
+// canSilentReload reports whether a background graph reload may start now: no load already in
+// flight and nothing on screen that a graph swap would disturb.
+func (m *Model) canSilentReload() bool {
+	switch m.appState.ViewMode {
+	case state.ViewEditDescription, state.ViewCreatePR, state.ViewCreateTicket, state.ViewCreateBookmark, state.ViewFileDiff:
+		return false
+	case state.ViewEvologSplit:
+		if m.evologSplitModal.SuggestLoading() {
+			return false
+		}
+	}
+	return !m.silentReloadInFlight && !m.appState.Loading && !m.aiGenOverlayActive && m.appState.JJService != nil &&
+		!m.graphTabModel.IsInRebaseMode() && !m.graphTabModel.IsInMergeMode()
+}
+
+// repoRefreshDue reports whether the periodic graph reload is due at now.
+func (m *Model) repoRefreshDue(now time.Time) bool {
+	interval, set := m.appState.Config.RepoRefresh()
+	switch {
+	case set && interval == 0:
+		return false
+	case !set && m.repoRefresh.watcher != nil:
+		interval = watchedRepoRefreshInterval
+	case !set:
+		interval = unwatchedRepoRefreshInterval
+	}
+	// The tick is the resolution: anything up to one tick apart reloads on every tick.
+	return interval <= autoRefreshInterval || now.Sub(m.repoRefresh.lastReload) >= interval
+}
+
+// refreshRepoCmd starts the queries that pending changes call for, all at once: the graph for
+// any change, the selected commit's files when they may have changed, and the branch list after
+// an operation. periodic reloads the graph even with nothing pending. It returns nil (keeping
+// the changes pending) while idle or when a reload can't start yet.
+func (m *Model) refreshRepoCmd(periodic bool) tea.Cmd {
+	r := &m.repoRefresh
+	if (r.pending.IsZero() && !periodic) || m.idleState.idle || !m.canSilentReload() {
+		return nil
+	}
+	change := r.pending
+	r.pending = jj.RepoChange{}
+	r.lastReload = time.Now()
+
+	svc := m.appState.JJService
+	cmds := []tea.Cmd{m.silentReloadCmd()}
+	if repo := m.appState.Repository; repo != nil && m.appState.ViewMode == state.ViewCommitGraph {
+		idx := m.graphTabModel.GetSelectedCommit()
+		if idx >= 0 && idx < len(repo.Graph.Commits) {
+			c := repo.Graph.Commits[idx]
+			if change.Operation || (change.WorkingCopy && c.IsWorking) {
+				cmds = append(cmds, graphtab.LoadChangedFilesCmd(svc, c.ChangeID))
+			}
+		}
+	}
+	if change.Operation && m.appState.ViewMode == state.ViewBranches {
+		cmds = append(cmds, branchestab.LoadBranchesCmd(svc))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleLoadMoreCommits raises the graph's page limit by a page and reloads it in the background,
+// so the next commits are appended below without a loading screen.
+func (m *Model) handleLoadMoreCommits() (tea.Model, tea.Cmd) {
+	svc := m.appState.JJService
+	page := m.appState.Config.GraphPage()
+	if svc == nil || svc.GraphLimit == 0 || page == 0 || m.silentReloadInFlight {
+		m.graphTabModel.EndLoadMore()
+		return m, nil
+	}
+	svc.GraphLimit += page
+	m.appState.StatusMessage = "Loading more commits..."
+	return m, m.silentReloadCmd()
+}
+
+// silentReloadCmd reloads the graph in the background with the configured revset.
+func (m *Model) silentReloadCmd() tea.Cmd {
+	revset := ""
+	if m.appState.Config != nil {
+		revset = m.appState.Config.GraphRevset
+		// Mirror LoadRepository's mine() intersection so the silent background
+		// refresh produces the same graph as the foreground load. Without this,
+		// the periodic tick would silently widen the revset and reintroduce
+		// other contributors' commits between user-initiated reloads.
+		if m.appState.Config.GraphFilterToMine() {
+			revset = jj.ApplyMineFilterToRevset(revset)
+		}
+		m.appState.JJService.BookmarkListPreferTracked = m.appState.Config.BranchesFilterToTrackedAndMine()
+	}
+	m.silentReloadInFlight = true
+	return data.LoadRepositorySilent(m.appState.JJService, revset)
+}
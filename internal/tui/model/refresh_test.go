@@ -0,0 +1,107 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/data"
+	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
+)
+
+// watchedTestModel returns a model whose repo is watched (the watcher is never read from here).
+func watchedTestModel(t *testing.T) *Model {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".jj", "repo", "op_heads", "heads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	m := newTestModel()
+	m.appState.JJService = &jj.Service{RepoPath: dir}
+	w, err := m.appState.JJService.WatchRepo(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = w.Close() })
+	m.Update(data.RepoWatchStartedMsg{RepoPath: dir, Watcher: w})
+	if m.repoRefresh.watcher != w {
+		t.Fatal("watcher not kept")
+	}
+	return m
+}
+
+// A watched change reloads the graph at once, or waits while a load is in flight; changes the
+// last load already shows (e.g. the operation jj-tui itself just ran) are dropped.
+func TestRepoChangeReloadsGraph(t *testing.T) {
+	m := watchedTestModel(t)
+	w := m.repoRefresh.watcher
+
+	m.appState.Loading = true
+	if _, cmd := m.Update(data.RepoChangedMsg{Watcher: w, Change: jj.RepoChange{WorkingCopy: true, EditAt: time.Now()}}); cmd == nil {
+		t.Fatal("expected the watcher to be re-armed")
+	}
+	if m.silentReloadInFlight || !m.repoRefresh.pending.WorkingCopy {
+		t.Fatalf("change during a load should stay pending, got inFlight=%v pending=%+v", m.silentReloadInFlight, m.repoRefresh.pending)
+	}
+	m.appState.Loading = false
+	m.Update(data.RepoChangedMsg{Watcher: w, Change: jj.RepoChange{Operation: true, OpAt: time.Now()}})
+	if !m.silentReloadInFlight || !m.repoRefresh.pending.IsZero() {
+		t.Fatalf("expected a background reload, got inFlight=%v pending=%+v", m.silentReloadInFlight, m.repoRefresh.pending)
+	}
+
+	m.silentReloadInFlight = false
+	m.repoRefresh.loadEnd = time.Now()
+	m.Update(data.RepoChangedMsg{Watcher: w, Change: jj.RepoChange{Operation: true, OpAt: time.Now().Add(-time.Second)}})
+	if m.silentReloadInFlight {
+		t.Fatal("an operation from before the last load finished should not reload")
+	}
+
+	m.Update(data.RepoChangedMsg{Watcher: &jj.RepoWatcher{}, Change: jj.RepoChange{Operation: true, OpAt: time.Now()}})
+	if m.silentReloadInFlight {
+		t.Fatal("changes from a replaced watcher should be ignored")
+	}
+}
+
+func TestRepoRefreshDue(t *testing.T) {
+	m := newTestModel()
+	now := time.Now()
+	m.repoRefresh.lastReload = now.Add(-10 * time.Second)
+	if !m.repoRefreshDue(now) {
+		t.Fatal("an unwatched repo should reload on every tick")
+	}
+
+	m = watchedTestModel(t)
+	m.repoRefresh.lastReload = now.Add(-10 * time.Second)
+	if m.repoRefreshDue(now) {
+		t.Fatal("a watched repo should only reload every 30s by default")
+	}
+	if !m.repoRefreshDue(now.Add(25 * time.Second)) {
+		t.Fatal("expected the fallback reload after 30s")
+	}
+	zero := 0
+	m.appState.Config = &config.Config{RepoRefreshInterval: &zero}
+	if m.repoRefreshDue(now.Add(time.Hour)) {
+		t.Fatal("repo_refresh_interval 0 should only reload on changes")
+	}
+}
+
+// Load more raises the service's graph limit by a page and reloads in the background.
+func TestLoadMoreCommitsRaisesGraphLimit(t *testing.T) {
+	m := newTestModel()
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir(), GraphLimit: config.DefaultGraphPageSize}
+	m.Update(graphtab.LoadMoreCommitsMsg{})
+	if got := m.appState.JJService.GraphLimit; got != 2*config.DefaultGraphPageSize {
+		t.Fatalf("GraphLimit = %d", got)
+	}
+	if !m.silentReloadInFlight {
+		t.Fatal("expected a background reload")
+	}
+
+	m.Update(graphtab.LoadMoreCommitsMsg{})
+	if got := m.appState.JJService.GraphLimit; got != 2*config.DefaultGraphPageSize {
+		t.Fatalf("a second request during the reload should wait, GraphLimit = %d", got)
+	}
+}
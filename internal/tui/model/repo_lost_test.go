@@ -0,0 +1,39 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/data"
+)
+
+func TestRepoLostDropsServiceAndReinitializes(t *testing.T) {
+	m := newTestModel()
+	m.appState.JJService = &jj.Service{RepoPath: "/gone/repo"}
+	m.silentReloadInFlight = true
+
+	_, cmd := m.Update(data.RepoLostMsg{Err: errors.New("repository directory no longer exists"), RepoPath: "/gone/repo"})
+	if cmd == nil {
+		t.Fatal("expected InitializeServices to be scheduled")
+	}
+	if m.appState.JJService != nil || m.appState.Repository != nil {
+		t.Fatal("expected jj service and repository to be cleared")
+	}
+	if m.silentReloadInFlight || !m.appState.Loading {
+		t.Fatalf("inFlight=%v loading=%v", m.silentReloadInFlight, m.appState.Loading)
+	}
+}
+
+func TestRepoLostIgnoresStaleService(t *testing.T) {
+	m := newTestModel()
+	svc := &jj.Service{RepoPath: "/new/repo"}
+	m.appState.JJService = svc
+
+	if _, cmd := m.Update(data.RepoLostMsg{Err: errors.New("gone"), RepoPath: "/old/repo"}); cmd != nil {
+		t.Fatal("a late result for a replaced service should be ignored")
+	}
+	if m.appState.JJService != svc {
+		t.Fatal("current service should be kept")
+	}
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"os"
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+	graphtab "github.com/madicen/jj-tui/internal/tui/tabs/graph"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+	settingstab "github.com/madicen/jj-tui/internal/tui/tabs/settings"
+	ticketstab "github.com/madicen/jj-tui/internal/tui/tabs/tickets"
+	workspacestab "github.com/madicen/jj-tui/internal/tui/tabs/workspaces"
+)
+
+// openRepoSwitcher (Ctrl+O) shows the recent repositories and the directory browser over the
+// open one; picking a repository sends NavigateOpenRepo.
+func (m *Model) openRepoSwitcher() (tea.Model, tea.Cmd) {
+	current, err := os.Getwd()
+	if svc := m.appState.JJService; svc != nil {
+		current, err = svc.RepoPath, nil
+	}
+	if err != nil || m.appState.DemoMode {
+		return m, nil
+	}
+	m.initRepoModel.OpenSwitcher(current)
+	return m, nil
+}
+
+// closeRepo drops the services and data of the open repository and stops watching it. Late
+// results from its jj service are ignored once JJService no longer matches.
+func (m *Model) closeRepo() {
+	m.repoGen++
+	m.stopRepoWatch()
+	m.silentReloadInFlight = false
+	m.statusSegments = nil
+	m.issueSync = issueSyncState{}
+	m.appState.JJService = nil
+	m.appState.Repository = nil
+	m.appState.GitHubService = nil
+	m.appState.GitLabService = nil
+	m.appState.TicketService = nil
+	m.appState.GithubInfo = ""
+	m.appState.DefaultBranch = ""
+	m.appState.PRsLoadedOnce = false
+	m.appState.TicketsLoadedOnce = false
+	m.SetGitHubPermissions(nil)
+	m.prsTabModel.SetUpstreamSource("")
+	m.prsTabModel.SetGithubService(false)
+}
+
+// repoScopedMsg is a repository result tagged with the repoGen its command was started under.
+type repoScopedMsg struct {
+	gen int
+	msg tea.Msg
+}
+
+// scopeToRepo tags the repository results cmd produces (messages embedding state.RepoResult)
+// with the current repoGen, so update drops the ones that arrive after a switch instead of
+// showing another repository's PRs, branches or tickets in the new tabs.
+func (m *Model) scopeToRepo(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	gen := m.repoGen
+	return func() tea.Msg { return scopeMsg(gen, cmd()) }
+}
+
+// cmdType is the element type of tea.BatchMsg and of the message tea.Sequence returns.
+var cmdType = reflect.TypeFor[tea.Cmd]()
+
+// scopeMsg tags msg with gen when it is a repository result. The commands of a batch or sequence
+// are wrapped so their results are tagged when they arrive. Timer loops and UI messages are left
+// untagged so a switch doesn't stop them.
+func scopeMsg(gen int, msg tea.Msg) tea.Msg {
+	if state.IsRepoResult(msg) {
+		return repoScopedMsg{gen: gen, msg: msg}
+	}
+	// tea.Sequence's message type is unexported, so batches and sequences are both recognised
+	// as slices of commands and rebuilt with the same type.
+	v := reflect.ValueOf(msg)
+	if !v.IsValid() || v.Kind() != reflect.Slice || v.Type().Elem() != cmdType {
+		return msg
+	}
+	scoped := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	for i := range v.Len() {
+		if cmd := v.Index(i).Interface().(tea.Cmd); cmd != nil {
+			scoped.Index(i).Set(reflect.ValueOf(tea.Cmd(func() tea.Msg { return scopeMsg(gen, cmd()) })))
+		}
+	}
+	return scoped.Interface()
+}
+
+// fromOtherRepo reports whether repo was loaded for a repository (or workspace) jj-tui has since
+// switched away from, so a late load doesn't replace the current one.
+func (m *Model) fromOtherRepo(repo *internal.Repository) bool {
+	svc := m.appState.JJService
+	return repo != nil && repo.Path != "" && svc != nil && repo.Path != svc.RepoPath
+}
+
+// switchRepo tears the open repository down before services are initialized for another one
+// (the process has already moved there): the tabs that hold per-repository state start over, and
+// the config is reloaded so the new repository's .jj-tui.json applies.
+func (m *Model) switchRepo() tea.Cmd {
+	m.closeRepo()
+	m.appState.ViewMode = state.ViewCommitGraph
+	if cfg, err := config.Load(); err == nil {
+		m.appState.Config = cfg
+	}
+	zm := m.zoneManager
+	m.graphTabModel = graphtab.NewGraphModel(zm)
+	m.prsTabModel = prstab.NewModel(zm)
+	m.branchesTabModel = branchestab.NewModel(zm)
+	m.ticketsTabModel = ticketstab.NewModel(zm)
+	m.workspacesTabModel = workspacestab.NewModel(zm)
+	m.settingsTabModel = settingstab.NewModelWithConfig(m.appState.Config)
+	m.settingsTabModel.SetZoneManager(zm)
+	if m.width == 0 {
+		return nil
+	}
+	// The new tabs need the window size the old ones were given.
+	_, cmd := m.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	return cmd
+}
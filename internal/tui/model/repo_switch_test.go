@@ -0,0 +1,101 @@
+package model
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tui/data"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+	prstab "github.com/madicen/jj-tui/internal/tui/tabs/prs"
+	ticketformtab "github.com/madicen/jj-tui/internal/tui/tabs/ticketform"
+)
+
+// unscoped returns the message inside a repoScopedMsg, for tests that inspect a command's result.
+func unscoped(msg tea.Msg) tea.Msg {
+	if scoped, ok := msg.(repoScopedMsg); ok {
+		return scoped.msg
+	}
+	return msg
+}
+
+// Ctrl+O opens the switcher; picking a repository drops the open one's services and per-repo tab
+// state before services start for the new one, and a late load from the old one is ignored.
+func TestSwitchRepository(t *testing.T) {
+	t.Setenv("JJ_TUI_CONFIG", filepath.Join(t.TempDir(), "config.json"))
+	t.Chdir(t.TempDir())
+	m := newTestModel()
+	m.appState.JJService = &jj.Service{RepoPath: "/test/repo"}
+	m.appState.TicketService = mock.NewTicketService("jira")
+	m.graphTabModel.SelectCommit(2)
+	staleBranches := m.scopeToRepo(func() tea.Msg {
+		return branchestab.BranchesLoadedMsg{Branches: []internal.Branch{{Name: "old-repo-branch"}}}
+	})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !m.initRepoModel.Switching() {
+		t.Fatal("ctrl+o should open the repository switcher")
+	}
+
+	next := t.TempDir()
+	_, cmd := m.Update(state.NavigateMsg{Target: state.NavigateTarget{Kind: state.NavigateOpenRepo, RepoPath: next}})
+	if cmd == nil || !m.appState.Loading {
+		t.Fatal("expected services to be initialized for the new repository")
+	}
+	if m.appState.JJService != nil || m.appState.Repository != nil || m.appState.TicketService != nil {
+		t.Fatal("the old repository's services and data should be dropped")
+	}
+	if m.initRepoModel.Path() != "" || m.graphTabModel.GetSelectedCommit() == 2 {
+		t.Fatal("the switcher should close and the graph start over")
+	}
+
+	m.appState.JJService = &jj.Service{RepoPath: next}
+	m.Update(data.RepositoryLoadedMsg{Repository: &internal.Repository{Path: "/test/repo"}})
+	if m.appState.Repository != nil {
+		t.Fatal("a late load from the old repository should be ignored")
+	}
+	m.Update(staleBranches())
+	if len(m.GetBranches()) != 0 {
+		t.Fatalf("branches from the old repository reached the new tab: %v", m.GetBranches())
+	}
+	m.Update(unscoped(staleBranches()))
+	if len(m.GetBranches()) != 1 {
+		t.Fatal("the same result started for the new repository should be applied")
+	}
+}
+
+// Repository results are tagged by the RepoResult they embed, including the ones a batch or
+// sequence delivers later; timer messages pass through so their loops survive a switch.
+func TestScopeMsgTagsRepoResults(t *testing.T) {
+	if _, ok := scopeMsg(3, prstab.PrMergedMsg{PRNumber: 7}).(repoScopedMsg); !ok {
+		t.Error("a PR merge result should be tagged")
+	}
+	if _, ok := scopeMsg(3, tickMsg(time.Time{})).(tickMsg); !ok {
+		t.Error("a tick should pass through untagged")
+	}
+
+	seq := tea.Sequence(
+		func() tea.Msg { return ticketformtab.TicketCreatedMsg{} },
+		func() tea.Msg { return tickMsg(time.Time{}) },
+	)()
+	scoped := scopeMsg(3, seq)
+	if reflect.TypeOf(scoped) != reflect.TypeOf(seq) {
+		t.Fatalf("scoped sequence has type %T, want %T", scoped, seq)
+	}
+	v := reflect.ValueOf(scoped)
+	if v.Len() != 2 {
+		t.Fatalf("scoped sequence has %d commands, want 2", v.Len())
+	}
+	if got, ok := v.Index(0).Interface().(tea.Cmd)().(repoScopedMsg); !ok || got.gen != 3 {
+		t.Errorf("sequenced ticket result = %#v, want it tagged with gen 3", got)
+	}
+	if _, ok := v.Index(1).Interface().(tea.Cmd)().(tickMsg); !ok {
+		t.Error("a sequenced tick should pass through untagged")
+	}
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+)
+
+// exportReviewPacketCmd exports the requested review packet with the ticket linked to the
+// bookmark, taken from the loaded ticket list when it is there.
+func (m *Model) exportReviewPacketCmd(msg branchestab.ReviewPacketRequestedMsg) tea.Cmd {
+	in := branchestab.ReviewPacketInput{
+		Bookmark:      msg.Bookmark,
+		Remote:        msg.Remote,
+		Gist:          msg.Gist,
+		TicketService: m.appState.TicketService,
+	}
+	in.TicketKey, _ = m.ticketForBookmark(msg.Bookmark)
+	if in.TicketKey != "" {
+		for _, t := range m.ticketsTabModel.GetTickets() {
+			if t.Key == in.TicketKey {
+				in.Ticket = &t
+				break
+			}
+		}
+	}
+	return branchestab.ExportReviewPacketCmd(m.appState.JJService, in)
+}
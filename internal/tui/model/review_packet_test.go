@@ -0,0 +1,89 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+)
+
+// "E" on a branch exports its commits and diff to a markdown file under the cache dir and reports
+// the path.
+func TestExportReviewPacket(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncase \"$1\" in\n" +
+		"log) printf 'kkk\\037abc12345\\037Ada\\0372025-01-02\\037Fix the parser\\n\\nIt dropped the last token.\\n\\036' ;;\n" +
+		"diff) printf 'diff --git a/p.go b/p.go\\n+fixed\\n' ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(bin, "jj"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir()}
+	m.appState.ViewMode = state.ViewBranches
+	m.branchesTabModel.UpdateBranches([]internal.Branch{{Name: "alice/fix", IsLocal: true}})
+	m.branchesTabModel.SetSelectedBranch(0)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	if m.appState.StatusMessage != "Exporting review packet for alice/fix..." {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+	req, ok := unscoped(cmd()).(branchestab.ReviewPacketRequestedMsg)
+	if !ok || req.Bookmark != "alice/fix" || req.Gist {
+		t.Fatalf("request = %#v", req)
+	}
+	_, cmd = m.Update(req)
+	done, ok := unscoped(cmd()).(branchestab.ReviewPacketExportedMsg)
+	if !ok || done.Err != nil {
+		t.Fatalf("export = %#v", done)
+	}
+	if filepath.Dir(done.Path) != branchestab.ReviewPacketDir() || !strings.HasPrefix(filepath.Base(done.Path), "alice-fix-") {
+		t.Fatalf("path = %s", done.Path)
+	}
+	data, err := os.ReadFile(done.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Review: alice/fix", "### Fix the parser", "It dropped the last token.", "```diff\ndiff --git a/p.go b/p.go\n+fixed\n```"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("packet missing %q:\n%s", want, data)
+		}
+	}
+
+	m.Update(done)
+	if m.appState.StatusMessage != "Wrote review packet for alice/fix to "+done.Path {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
+
+func TestRenderReviewPacketTicketAndFence(t *testing.T) {
+	p := &jj.ReviewPacket{
+		Bookmark: "proj-12-docs",
+		Commits:  []jj.ReviewCommit{{ChangeID: "kkk", CommitID: "abc", Author: "Ada", Date: "2025-01-02"}},
+		Diff:     "+```go\n+code\n+```\n",
+	}
+	ticket := &tickets.Ticket{Key: "10012", DisplayKey: "PROJ-12", Summary: "Document the API", Status: "In Progress", Description: "Cover every endpoint."}
+	out := branchestab.RenderReviewPacket(p, ticket, "https://jira.example.com/browse/PROJ-12")
+	for _, want := range []string{
+		"1 commit on top of trunk.",
+		"**[PROJ-12](https://jira.example.com/browse/PROJ-12)**: Document the API (In Progress)",
+		"Cover every endpoint.",
+		"### (no description)",
+		"````diff\n+```go",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("packet missing %q:\n%s", want, out)
+		}
+	}
+}
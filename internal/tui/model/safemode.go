@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// SetSafeMode turns on safe mode (--safe-mode): GitHub and ticket services are never loaded and
+// the auto-refresh tick (which also drives PR polling and release checks) never starts. The
+// graph still loads and Ctrl+r still refreshes. Call before the program starts.
+func (m *Model) SetSafeMode() {
+	m.appState.SafeMode = true
+}
+
+// safeModeBadge is the header banner shown in safe mode, or "".
+func (m *Model) safeModeBadge() string {
+	if !m.appState.SafeMode {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Background(styles.ColorWarning).
+		Foreground(styles.ColorOnBright).
+		Bold(true).
+		Render(" SAFE MODE ") + " "
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/tui/data"
+)
+
+func TestSafeModeSkipsTickAndShowsBanner(t *testing.T) {
+	m := newTestModel()
+	m.SetSafeMode()
+
+	if m.tickCmd() != nil {
+		t.Fatal("safe mode should not start the auto-refresh tick")
+	}
+	if !strings.Contains(m.renderHeader(), "SAFE MODE") {
+		t.Fatal("header should show the safe mode banner")
+	}
+
+	m.appState.StatusMessage = "Loaded 3 commits"
+	m.Update(data.AuxServicesReadyMsg{})
+	if m.appState.GitHubService != nil || m.appState.TicketService != nil {
+		t.Fatal("safe mode should not have network services")
+	}
+	if !strings.Contains(m.appState.StatusMessage, "safe mode") {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/keymap"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// workingCopyUpdatedMsg is sent when `jj workspace update-stale` finishes.
+type workingCopyUpdatedMsg struct {
+	state.RepoResult
+	err error
+}
+
+// staleBadge is the header banner shown while the working copy is stale (another workspace or
+// jj process moved the repo on), or "". Clicking it runs update-stale like its key.
+func (m *Model) staleBadge() string {
+	svc := m.appState.JJService
+	if svc == nil || !svc.WorkingCopyStale() {
+		return ""
+	}
+	label := " STALE WORKING COPY: " + keymap.Label(keymap.Key("app.update_stale")) + " to update "
+	return m.zoneManager.Mark(mouse.ZoneActionUpdateStale, lipgloss.NewStyle().
+		Background(styles.ColorWarning).
+		Foreground(styles.ColorOnBright).
+		Bold(true).
+		Render(label)) + " "
+}
+
+// staleBlockedStatus is the status line for an action refused because the working copy is stale.
+func staleBlockedStatus() string {
+	return "The working copy is stale: press " + keymap.Label(keymap.Key("app.update_stale")) +
+		" to run jj workspace update-stale, then try again"
+}
+
+// handleUpdateStale runs `jj workspace update-stale`; the repository reloads when it's done.
+func (m *Model) handleUpdateStale() (tea.Model, tea.Cmd) {
+	svc := m.appState.JJService
+	if svc == nil {
+		return m, nil
+	}
+	m.appState.Loading = true
+	m.appState.StatusMessage = "Updating stale working copy..."
+	update := func() tea.Msg {
+		return workingCopyUpdatedMsg{err: svc.UpdateStale(context.Background())}
+	}
+	return m, tea.Batch(update, m.startBusySpinnerCmd())
+}
+
+// handleWorkingCopyUpdated reloads the repository after update-stale, or reports why it failed.
+func (m *Model) handleWorkingCopyUpdated(msg workingCopyUpdatedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.appState.Loading = false
+		return m, func() tea.Msg { return errorMsg{Err: msg.err} }
+	}
+	cmd := m.refreshRepository()
+	m.appState.StatusMessage = "Working copy updated"
+	return m, cmd
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+func TestStaleWorkingCopyBanner(t *testing.T) {
+	bin := t.TempDir()
+	fixed := filepath.Join(t.TempDir(), "fixed")
+	script := "#!/bin/sh\ncase \"$*\" in\n" +
+		"'workspace update-stale') touch " + fixed + "; exit 0 ;;\n" +
+		"esac\n" +
+		"[ -f " + fixed + " ] || { echo 'Error: The working copy is stale (not updated since operation 0a1b).' >&2; exit 1; }\n"
+	if err := os.WriteFile(filepath.Join(bin, "jj"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	m := newTestModel()
+	defer m.Close()
+	svc := &jj.Service{RepoPath: t.TempDir()}
+	m.appState.JJService = svc
+	if strings.Contains(m.renderHeader(), "STALE") {
+		t.Fatal("banner shown before the working copy went stale")
+	}
+
+	// The first command to find @ stale marks it; the next mutation is refused without running.
+	_ = svc.DescribeCommit(context.Background(), "@", "x")
+	err := svc.DescribeCommit(context.Background(), "@", "x")
+	if !errors.Is(err, jj.ErrWorkingCopyStale) {
+		t.Fatalf("err = %v, want ErrWorkingCopyStale", err)
+	}
+	if !strings.Contains(m.renderHeader(), "STALE WORKING COPY: ^w to update") {
+		t.Fatalf("header missing the stale banner:\n%s", m.renderHeader())
+	}
+	m.Update(ErrorMsg(err))
+	if m.errorModal.GetError() != nil || !strings.Contains(m.appState.StatusMessage, "working copy is stale") {
+		t.Fatalf("status = %q, error modal = %v", m.appState.StatusMessage, m.errorModal.GetError())
+	}
+
+	m.Update(workingCopyUpdatedMsg{err: svc.UpdateStale(context.Background())})
+	if svc.WorkingCopyStale() || strings.Contains(m.renderHeader(), "STALE") {
+		t.Fatal("banner still shown after update-stale")
+	}
+	if m.appState.StatusMessage != "Working copy updated" {
+		t.Errorf("status = %q", m.appState.StatusMessage)
+	}
+}
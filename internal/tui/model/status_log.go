@@ -0,0 +1,23 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// recordStatus adds the status message to the message log when it changed during this update.
+// Like snapshotSpinnerMessage it runs deferred in update, so call sites keep assigning
+// StatusMessage directly.
+func (m *Model) recordStatus() {
+	if m.statusLog == nil || m.appState.StatusMessage == m.loggedStatus {
+		return
+	}
+	m.loggedStatus = m.appState.StatusMessage
+	m.statusLog.Record(m.loggedStatus)
+}
+
+// openStatusLog shows the recent status messages, newest first, in the pager.
+func (m *Model) openStatusLog() tea.Cmd {
+	m.recordStatus()
+	return state.NavigateTarget{Kind: state.NavigateOpenPager, PagerTitle: "Messages", PagerContent: m.statusLog.String()}.Cmd()
+}
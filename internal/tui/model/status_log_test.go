@@ -0,0 +1,36 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// Status changes are logged as they happen; Ctrl+l opens the log in the pager, newest first,
+// with repeated messages folded into one line.
+func TestStatusLog(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+
+	for _, s := range []string{"Loaded 3 commits", "Loaded 4 commits", "Pushed feature"} {
+		m.Update(SetStatusMsg{Status: s})
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if cmd == nil {
+		t.Fatal("ctrl+l should open the message log")
+	}
+	m.Update(cmd())
+	if m.appState.ViewMode != state.ViewPager {
+		t.Fatalf("view = %v, want the pager", m.appState.ViewMode)
+	}
+	view := m.pagerModal.View()
+	pushed, loaded := strings.Index(view, "Pushed feature"), strings.Index(view, "Loaded 4 commits  ×2")
+	if pushed < 0 || loaded < 0 || pushed > loaded {
+		t.Fatalf("log should list the push, then the folded loads:\n%s", view)
+	}
+	if strings.Contains(view, "Loaded 3 commits") {
+		t.Error("the earlier load should be folded into the later one")
+	}
+}
@@ -0,0 +1,199 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// statusSegmentTimeout bounds one segment command so a hung one can't keep its segment stale forever.
+const statusSegmentTimeout = 10 * time.Second
+
+// statusSegment is the last value of one configured status bar segment (status_segments).
+type statusSegment struct {
+	spec    config.StatusSegment
+	text    string
+	ran     time.Time
+	running bool
+}
+
+// statusSegmentMsg carries a segment's new value back from its command.
+type statusSegmentMsg struct {
+	state.RepoResult
+	index int
+	spec  config.StatusSegment
+	text  string
+}
+
+// refreshStatusSegments starts the segments whose interval has passed since their last run. It
+// runs on the auto-refresh tick, so segments stop with it while idle. Segments start over when
+// the configured list changes.
+func (m *Model) refreshStatusSegments() tea.Cmd {
+	var specs []config.StatusSegment
+	if m.appState.Config != nil {
+		specs = m.appState.Config.StatusSegments
+	}
+	if !slices.EqualFunc(specs, m.statusSegments, func(spec config.StatusSegment, seg statusSegment) bool {
+		return spec == seg.spec
+	}) {
+		m.statusSegments = make([]statusSegment, len(specs))
+		for i, spec := range specs {
+			m.statusSegments[i].spec = spec
+		}
+	}
+	dir := ""
+	if m.appState.JJService != nil {
+		dir = m.appState.JJService.RepoPath
+	}
+	now := time.Now()
+	var cmds []tea.Cmd
+	for i := range m.statusSegments {
+		seg := &m.statusSegments[i]
+		if seg.spec.Command == "" && seg.spec.Builtin == "bookmark" {
+			continue // read from the loaded graph when the bar is drawn
+		}
+		if seg.running || now.Sub(seg.ran) < seg.spec.Interval() {
+			continue
+		}
+		seg.running, seg.ran = true, now
+		index, spec, ctx := i, seg.spec, m.ctx
+		cmds = append(cmds, func() tea.Msg {
+			return statusSegmentMsg{index: index, spec: spec, text: statusSegmentValue(ctx, spec, dir)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleStatusSegmentMsg stores a segment's value unless the segment was reconfigured meanwhile.
+func (m *Model) handleStatusSegmentMsg(msg statusSegmentMsg) {
+	if msg.index >= len(m.statusSegments) || m.statusSegments[msg.index].spec != msg.spec {
+		return
+	}
+	seg := &m.statusSegments[msg.index]
+	seg.text, seg.running = msg.text, false
+}
+
+// statusSegmentTexts returns the segments to draw in the status bar, as "label value". Segments
+// with no value are left out.
+func (m *Model) statusSegmentTexts() []string {
+	var out []string
+	for _, seg := range m.statusSegments {
+		text := seg.text
+		if seg.spec.Command == "" && seg.spec.Builtin == "bookmark" {
+			text = currentBookmark(m.appState.Repository)
+		}
+		if text == "" {
+			continue
+		}
+		if seg.spec.Label != "" {
+			text = seg.spec.Label + " " + text
+		}
+		out = append(out, text)
+	}
+	return out
+}
+
+// statusSegmentValue runs a command segment, or reads a built-in one, and returns the first line
+// of the result. A failed command shows as "✗".
+func statusSegmentValue(ctx context.Context, spec config.StatusSegment, dir string) string {
+	if spec.Command != "" {
+		ctx, cancel := context.WithTimeout(ctx, statusSegmentTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "sh", "-c", spec.Command)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		if err != nil {
+			return "✗"
+		}
+		return firstLine(out)
+	}
+	switch spec.Builtin {
+	case "kube_context":
+		return kubeContext()
+	case "file":
+		path := os.ExpandEnv(spec.Path)
+		if path == "" {
+			return ""
+		}
+		if !filepath.IsAbs(path) && dir != "" {
+			path = filepath.Join(dir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return ""
+		}
+		return firstLine(data)
+	}
+	return ""
+}
+
+// firstLine returns the first line of out, trimmed.
+func firstLine(out []byte) string {
+	line, _, _ := bytes.Cut(out, []byte("\n"))
+	return strings.TrimSpace(string(line))
+}
+
+// kubeContext returns current-context from the first $KUBECONFIG file, or ~/.kube/config.
+func kubeContext() string {
+	path := ""
+	if list := filepath.SplitList(os.Getenv("KUBECONFIG")); len(list) > 0 {
+		path = list[0]
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		path = filepath.Join(home, ".kube", "config")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "current-context:"); ok {
+			return strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return ""
+}
+
+// currentBookmark returns the first bookmark on @ or, failing that, on its nearest first-parent
+// ancestor in the loaded graph.
+func currentBookmark(repo *internal.Repository) string {
+	if repo == nil {
+		return ""
+	}
+	byID := make(map[string]*internal.Commit, len(repo.Graph.Commits))
+	var c *internal.Commit
+	for i := range repo.Graph.Commits {
+		commit := &repo.Graph.Commits[i]
+		byID[commit.ID] = commit
+		if commit.IsWorking {
+			c = commit
+		}
+	}
+	for seen := 0; c != nil && seen < len(byID); seen++ {
+		if len(c.Branches) > 0 {
+			return c.Branches[0]
+		}
+		if len(c.Parents) == 0 {
+			break
+		}
+		c = byID[c.Parents[0]]
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+)
+
+func TestStatusSegments(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "status"), []byte("12 passed\nmore\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := newTestModel()
+	m.width = 160
+	m.appState.Repository.Graph.Commits[0].Branches = []string{"feature"}
+	m.appState.Config = &config.Config{StatusSegments: []config.StatusSegment{
+		{Label: "cmd", Command: "echo hello; echo world"},
+		{Label: "tests", Builtin: "file", Path: filepath.Join(dir, "status")},
+		{Label: "on", Builtin: "bookmark"},
+		{Command: "exit 1"},
+		{Builtin: "file", Path: filepath.Join(dir, "missing")},
+	}}
+
+	for _, msg := range runBatch(m.refreshStatusSegments()) {
+		m.Update(msg)
+	}
+	want := []string{"cmd hello", "tests 12 passed", "on feature", "✗"}
+	if got := m.statusSegmentTexts(); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("segments = %q, want %q", got, want)
+	}
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "tests 12 passed") {
+		t.Errorf("status bar doesn't show the segments: %q", bar)
+	}
+
+	if cmd := m.refreshStatusSegments(); cmd != nil {
+		t.Errorf("segments ran again before their interval passed")
+	}
+	m.statusSegments[0].ran = time.Now().Add(-time.Minute)
+	if msgs := runBatch(m.refreshStatusSegments()); len(msgs) != 1 {
+		t.Errorf("expected only the due segment to run, got %d", len(msgs))
+	}
+
+	// A result for a segment that was reconfigured meanwhile is dropped.
+	m.appState.Config.StatusSegments[0].Command = "echo other"
+	m.refreshStatusSegments()
+	m.Update(statusSegmentMsg{index: 0, spec: config.StatusSegment{Label: "cmd", Command: "echo hello; echo world"}, text: "stale"})
+	if got := m.statusSegmentTexts(); len(got) > 0 && got[0] == "cmd stale" {
+		t.Errorf("stale segment result was applied")
+	}
+}
+
+// runBatch runs cmd and, for a tea.Batch, each of its commands, returning the messages.
+func runBatch(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, runBatch(c)...)
+	}
+	return msgs
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	branchestab "github.com/madicen/jj-tui/internal/tui/tabs/branches"
+)
+
+// A sync that leaves the working-copy stack behind trunk offers a rebase on the Branches tab;
+// answering "y" sends the rebase and closes the prompt.
+func TestSyncForkOffersRebase(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.ViewMode = state.ViewBranches
+	m.branchesTabModel.UpdateBranches([]internal.Branch{{Name: "main", IsLocal: true}})
+
+	newModel, _ := m.Update(branchestab.ForkSyncedMsg{Trunk: "main", Via: "GitHub", MergeType: "fast-forward", Ahead: 2, Behind: 3})
+	m = newModel.(*Model)
+	if !strings.Contains(m.appState.StatusMessage, "Synced main with upstream (via GitHub)") {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+	if !m.branchesTabModel.IsCapturingKeys() {
+		t.Fatal("rebase prompt should own the keyboard")
+	}
+	if view := m.View(); !strings.Contains(view, "Rebase your stack (2 commits) onto main?") {
+		t.Fatal("rebase prompt should be rendered")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(*Model)
+	if m.branchesTabModel.IsCapturingKeys() {
+		t.Fatal("prompt should close after answering")
+	}
+	if m.appState.StatusMessage != "Rebasing your stack onto main..." {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
+
+func TestSyncForkUpToDateOrFailedNoPrompt(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.ViewMode = state.ViewBranches
+
+	newModel, _ := m.Update(branchestab.ForkSyncedMsg{Trunk: "main", Via: "GitHub", MergeType: "none"})
+	m = newModel.(*Model)
+	if m.appState.StatusMessage != "main is already up to date with upstream" || m.branchesTabModel.IsCapturingKeys() {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+
+	newModel, _ = m.Update(branchestab.ForkSyncedMsg{Trunk: "main", Ahead: 1, Behind: 1, Err: errors.New("no upstream remote")})
+	m = newModel.(*Model)
+	if !strings.HasPrefix(m.appState.StatusMessage, "Failed to sync fork:") || m.branchesTabModel.IsCapturingKeys() {
+		t.Fatalf("status = %q", m.appState.StatusMessage)
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	bookmarktab "github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
+	ticketstab "github.com/madicen/jj-tui/internal/tui/tabs/tickets"
+)
+
+// Bookmarks named after a ticket are linked once tickets are known, and the status bar shows the key.
+func TestPrefetchedTicketsLinkBookmarksByName(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	repo := m.appState.Repository
+	repo.Graph.Commits[2].Branches = []string{"proj-142-dark-mode"}
+	repo.PRs = nil
+	m.graphTabModel.SelectCommit(2)
+
+	svc := mock.NewTicketService("jira")
+	m.Update(ticketstab.PrefetchTicketsCmd(svc)())
+
+	ref, ok := m.bookmarkModal.TicketForBookmark("proj-142-dark-mode")
+	if !ok || ref.Key != "PROJ-142" {
+		t.Fatalf("bookmark not linked to PROJ-142: %+v", ref)
+	}
+	if !strings.Contains(m.renderStatusBar(), "◆ PROJ-142") {
+		t.Error("status bar should show the linked ticket")
+	}
+	if m.appState.TicketsLoadedOnce {
+		t.Error("prefetch must not count as the Tickets tab's load")
+	}
+}
+
+func TestBookmarkCreatedWithTicketName_LinksTicket(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	svc := mock.NewTicketService("jira")
+	list, _ := svc.GetAssignedTickets(context.Background())
+	m.appState.TicketService = svc
+	m.ticketsTabModel.UpdateTickets(list)
+	m.appState.ViewMode = state.ViewCreateBookmark
+
+	m.Update(bookmarktab.BookmarkCreatedMsg{BookmarkName: "proj-142-dark-mode", CommitID: "ghi789012345"})
+	if !strings.Contains(m.appState.StatusMessage, "(linked to PROJ-142)") {
+		t.Errorf("status = %q, want the linked ticket", m.appState.StatusMessage)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/crash"
+	"github.com/madicen/jj-tui/internal/keymap"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/styles"
+	bookmarktab "github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
 	"github.com/madicen/jj-tui/internal/version"
 	"github.com/mattn/go-runewidth"
 )
@@ -40,9 +43,21 @@ func chromeHorizontalRow(width int, inner string, leftBG, midBG, rightBG, midFG
 
 // View implements tea.Model
 func (m *Model) View() string {
+	defer crash.Recover(m.crashHistory())
 	if m.width == 0 || m.height == 0 {
 		return "Loading..."
 	}
+	if m.appState.ViewMode == state.ViewPager {
+		return m.pagerModal.View()
+	}
+	if m.appState.ViewMode == state.ViewFileTree {
+		m.fileTreeModal.SetStatus(m.appState.StatusMessage)
+		return m.fileTreeModal.View()
+	}
+	if m.appState.ViewMode == state.ViewFileHistory {
+		m.fileHistoryModal.SetStatus(m.appState.StatusMessage)
+		return m.fileHistoryModal.View()
+	}
 
 	// chromedSlot picks one modal for WindowChrome; it's used both to skip
 	// that modal in applyFormModalsOverlay (so it isn't double-painted) and
@@ -68,6 +83,11 @@ func (m *Model) View() string {
 			v = applyBubbleOverlayCentered(v, warningContent, m.width, m.height)
 		}
 	}
+	if key != "confirm" {
+		if confirmContent := m.confirmModal.View(); confirmContent != "" {
+			v = applyBubbleOverlayCentered(v, confirmContent, m.width, m.height)
+		}
+	}
 	if key != "error" {
 		if errorContent := m.errorModal.View(); errorContent != "" {
 			v = applyBubbleOverlayCentered(v, errorContent, m.width, m.height)
@@ -176,6 +196,9 @@ func (m *Model) renderEvologDescribePreview() string {
 
 // renderMainLayoutView builds header + tab content + status (no error/warning/divergent full-screen branches).
 func (m *Model) renderMainLayoutView() string {
+	if m.popupView != "" {
+		return m.renderPopupLayoutView()
+	}
 	header := m.renderHeader()
 	statusBar := m.renderStatusBar()
 	headerHeight := strings.Count(header, "\n") + 1
@@ -188,6 +211,7 @@ func (m *Model) renderMainLayoutView() string {
 	m.ticketsTabModel.SetDimensions(m.width, contentHeight)
 	m.settingsTabModel.SetDimensions(m.width, contentHeight)
 	m.helpTabModel.SetDimensions(m.width, contentHeight)
+	m.workspacesTabModel.SetDimensions(m.width, contentHeight)
 
 	var content string
 	switch m.layoutContentMode() {
@@ -197,6 +221,8 @@ func (m *Model) renderMainLayoutView() string {
 		content = m.prsTabModel.View()
 	case state.ViewBranches:
 		content = m.branchesTabModel.View()
+	case state.ViewWorkspaces:
+		content = m.workspacesTabModel.View()
 	case state.ViewTickets:
 		content = m.ticketsTabModel.View()
 	case state.ViewSettings:
@@ -231,21 +257,59 @@ func (m *Model) renderMainLayoutView() string {
 	)
 }
 
+// renderPopupLayoutView is the compact --popup layout: the pinned view's content and the status
+// bar, without the tab bar or spacer lines.
+func (m *Model) renderPopupLayoutView() string {
+	statusBar := m.renderStatusBar()
+	contentHeight := max(m.height-strings.Count(statusBar, "\n")-1, 1)
+
+	var content string
+	switch m.popupViewMode() {
+	case state.ViewPullRequests:
+		m.prsTabModel.SetDimensions(m.width, contentHeight)
+		content = m.prsTabModel.View()
+	case state.ViewBranches:
+		m.branchesTabModel.SetDimensions(m.width, contentHeight)
+		content = m.branchesTabModel.View()
+	case state.ViewTickets:
+		m.ticketsTabModel.SetDimensions(m.width, contentHeight)
+		content = m.ticketsTabModel.View()
+	default:
+		m.graphTabModel.SetDimensions(m.width, contentHeight)
+		content = m.graphTabModel.View()
+	}
+
+	contentLines := strings.Split(content, "\n")
+	for len(contentLines) < contentHeight {
+		contentLines = append(contentLines, "")
+	}
+	if len(contentLines) > contentHeight {
+		contentLines = contentLines[:contentHeight]
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, strings.Join(contentLines, "\n"), statusBar)
+}
+
 // renderHeader renders the header with clickable tabs
 func (m *Model) renderHeader() string {
 	// Spaces inside TitleStyle (bar gutters are separate; see chromeHorizontalRow).
-	title := styles.TitleStyle.Render(" jj-tui  ")
+	title := styles.TitleStyle.Render(" jj-tui  ") + m.safeModeBadge() + m.staleBadge()
 
 	// Create tabs wrapped in zones (with keyboard shortcuts)
 	tm := m.tabHighlightMode()
-	graphTabActive := tm == state.ViewCommitGraph || m.appState.ViewMode == state.ViewEvologSplit || m.appState.ViewMode == state.ViewFileDiff
+	graphTabActive := tm == state.ViewCommitGraph || m.appState.ViewMode == state.ViewEvologSplit
+	prsLabel := "PRs (p)"
+	if m.prsTabModel.IsPushMode() {
+		prsLabel = "Push (p)"
+	}
+	prsLabel = keymap.Button(prsLabel, "tab.prs")
 	tabs := []string{
-		m.zoneManager.Mark(mouse.ZoneTabGraph, m.renderTab("Graph (g)", graphTabActive)),
-		m.zoneManager.Mark(mouse.ZoneTabPRs, m.renderTab("PRs (p)", tm == state.ViewPullRequests)),
-		m.zoneManager.Mark(mouse.ZoneTabJira, m.renderTab("Tickets (t)", tm == state.ViewTickets)),
-		m.zoneManager.Mark(mouse.ZoneTabBranches, m.renderTab("Branches (b)", tm == state.ViewBranches)),
-		m.zoneManager.Mark(mouse.ZoneTabSettings, m.renderTab("Settings (,)", tm == state.ViewSettings)),
-		m.zoneManager.Mark(mouse.ZoneTabHelp, m.renderTab("Help (h)", tm == state.ViewHelp)),
+		m.zoneManager.Mark(mouse.ZoneTabGraph, m.renderTab(keymap.Button("Graph (g)", "tab.graph"), graphTabActive)),
+		m.zoneManager.Mark(mouse.ZoneTabPRs, m.renderTab(prsLabel, tm == state.ViewPullRequests)),
+		m.zoneManager.Mark(mouse.ZoneTabJira, m.renderTab(keymap.Button("Tickets (t)", "tab.tickets"), tm == state.ViewTickets)),
+		m.zoneManager.Mark(mouse.ZoneTabBranches, m.renderTab(keymap.Button("Branches (b)", "tab.branches"), tm == state.ViewBranches)),
+		m.zoneManager.Mark(mouse.ZoneTabWorkspaces, m.renderTab(keymap.Button("Workspaces (w)", "tab.workspaces"), tm == state.ViewWorkspaces)),
+		m.zoneManager.Mark(mouse.ZoneTabSettings, m.renderTab(keymap.Button("Settings (,)", "tab.settings"), tm == state.ViewSettings)),
+		m.zoneManager.Mark(mouse.ZoneTabHelp, m.renderTab(keymap.Button("Help (h)", "tab.help"), tm == state.ViewHelp)),
 	}
 
 	tabsStr := lipgloss.JoinHorizontal(lipgloss.Right, tabs...)
@@ -294,30 +358,53 @@ func (m *Model) renderStatusBar() string {
 
 	// Add keyboard shortcuts with ^ notation and | separators
 	// Start with undo/redo if in Graph view, then quit and refresh
-	if (m.tabHighlightMode() == state.ViewCommitGraph || m.appState.ViewMode == state.ViewEvologSplit || m.appState.ViewMode == state.ViewFileDiff) && m.appState.JJService != nil {
-		if m.redoOperationID != "" {
+	if (m.tabHighlightMode() == state.ViewCommitGraph || m.appState.ViewMode == state.ViewEvologSplit) && m.appState.JJService != nil {
+		if m.redoDepth > 0 {
 			shortcuts = append(shortcuts,
-				m.zoneManager.Mark(mouse.ZoneActionRedo, "^y redo"),
+				m.zoneManager.Mark(mouse.ZoneActionRedo, keymap.Help("^y", "app.redo")+" redo"),
 				" │ ",
 			)
 		}
 		shortcuts = append(shortcuts,
-			m.zoneManager.Mark(mouse.ZoneActionUndo, "^z undo"),
+			m.zoneManager.Mark(mouse.ZoneActionUndo, keymap.Help("^z", "app.undo")+" undo"),
 			" │ ",
 		)
 	}
 
+	// Dirty indicator: edits on disk the next jj command (refresh, push, …) will snapshot into @
+	if !m.pendingChanges.IsZero() {
+		shortcuts = append(shortcuts,
+			lipgloss.NewStyle().Foreground(styles.ColorWarning).Render("● "+m.pendingChanges.String()+" not snapshotted"),
+			" │ ",
+		)
+	}
+
+	// Ticket indicator: the ticket linked to the selected commit's bookmark (created from it or named after it)
+	if m.tabHighlightMode() == state.ViewCommitGraph {
+		if name := bookmarktab.FindBookmarkForCommit(m.appState.Repository, m.graphTabModel.GetSelectedCommit()); name != "" {
+			if ref, ok := m.bookmarkModal.TicketForBookmark(name); ok {
+				shortcuts = append(shortcuts,
+					lipgloss.NewStyle().Foreground(styles.ColorPrimary).Render("◆ "+ref.Key),
+					" │ ",
+				)
+			}
+		}
+	}
+
 	// Always add quit and refresh (in same position for all tabs)
 	shortcuts = append(shortcuts,
-		m.zoneManager.Mark(mouse.ZoneActionRefresh, "^r refresh"),
+		m.zoneManager.Mark(mouse.ZoneActionRefresh, keymap.Help("^r", "app.refresh")+" refresh"),
 		" │ ",
-		m.zoneManager.Mark(mouse.ZoneActionQuit, "^q quit"),
+		m.zoneManager.Mark(mouse.ZoneActionQuit, keymap.Help("^q", "app.quit")+" quit"),
 	)
 
-	// Add update notification if available
+	// Configured status segments (status_segments), then the update notification if available
+	for _, seg := range m.statusSegmentTexts() {
+		shortcuts = append(shortcuts, " │ "+seg)
+	}
 	if updateInfo := version.GetUpdateInfo(); updateInfo != nil && updateInfo.UpdateAvailable {
 		updateNotice := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFB86C")).
+			Foreground(styles.ColorWarning).
 			Bold(true).
 			Render(fmt.Sprintf(" │ Update: %s", updateInfo.LatestVersion))
 		shortcuts = append(shortcuts, updateNotice)
@@ -345,7 +432,8 @@ func (m *Model) renderStatusBar() string {
 	// Layout: status on left, shortcuts on right
 	padding := max(m.width-lipgloss.Width(status)-lipgloss.Width(scrollIndicator)-lipgloss.Width(shortcutsStr)-2, 0)
 
-	line := status + scrollIndicator + strings.Repeat(" ", padding) + shortcutsStr
+	// Clicking the status text opens the message log (also ^l).
+	line := m.zoneManager.Mark(mouse.ZoneActionStatusLog, status) + scrollIndicator + strings.Repeat(" ", padding) + shortcutsStr
 	return chromeHorizontalRow(m.width, line,
 		styles.StatusBarBackground, styles.StatusBarBackground, styles.StatusBarBackground,
 		styles.ColorMuted)
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	workspacestab "github.com/madicen/jj-tui/internal/tui/tabs/workspaces"
+)
+
+func (m *Model) handleNavigateToWorkspacesTab() (tea.Model, tea.Cmd) {
+	m.appState.ViewMode = state.ViewWorkspaces
+	status, cmd := workspacestab.EnterTab(m.appState.JJService)
+	m.appState.StatusMessage = status
+	return m, cmd
+}
+
+// switchWorkspace moves jj-tui into another workspace of the same repository: jj commands run
+// there from now on and the graph reloads with that workspace's working copy. GitHub and ticket
+// services stay as they are, since workspaces share the repository and its remotes.
+func (m *Model) switchWorkspace(msg workspacestab.SwitchWorkspaceMsg) (tea.Model, tea.Cmd) {
+	if m.appState.JJService == nil {
+		return m, nil
+	}
+	// Helpers that shell out without a directory (git remotes, jj init) use the process's.
+	if err := os.Chdir(msg.Path); err != nil {
+		m.appState.StatusMessage = fmt.Sprintf("Failed to switch to workspace %s: %v", msg.Name, err)
+		return m, nil
+	}
+	m.appState.JJService.RepoPath = msg.Path
+	m.appState.ViewMode = state.ViewCommitGraph
+	m.statusAfterReload = fmt.Sprintf("Switched to workspace %s (%s)", msg.Name, msg.Path)
+	return m, m.refreshRepository()
+}
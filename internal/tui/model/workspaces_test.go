@@ -0,0 +1,114 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	workspacestab "github.com/madicen/jj-tui/internal/tui/tabs/workspaces"
+)
+
+func TestWorkspacesTab(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir()}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if m.appState.ViewMode != state.ViewWorkspaces {
+		t.Fatalf("view = %v, want workspaces", m.appState.ViewMode)
+	}
+	other := t.TempDir()
+	m.Update(workspacestab.WorkspacesLoadedMsg{Workspaces: []jj.Workspace{
+		{Name: "default", ChangeID: "rlvkpnrz", Summary: "(no description set)", Path: m.appState.JJService.RepoPath, Current: true},
+		{Name: "feature", ChangeID: "kxqpmwvz", Summary: "Add parser", Path: other},
+		{Name: "old", ChangeID: "zzzzzzzz", Path: "/gone/old", Missing: true},
+	}})
+	view := m.View()
+	for _, want := range []string{"Workspaces (w)", "default", "(current)", "feature", "Add parser", "⚠ missing: /gone/old"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	// Enter on the current workspace stays put.
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.appState.StatusMessage; !strings.Contains(got, "Already in workspace default") {
+		t.Errorf("status = %q", got)
+	}
+
+	// The current workspace can't be forgotten, so x refuses without asking.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if got := m.appState.StatusMessage; !strings.Contains(got, "Can't forget the workspace jj-tui is running in") || m.confirmModal.IsShown() {
+		t.Errorf("status = %q", got)
+	}
+
+	// Switching moves the service into the other workspace.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on another workspace should switch to it")
+	}
+	sw, ok := cmd().(workspacestab.SwitchWorkspaceMsg)
+	if !ok || sw.Name != "feature" {
+		t.Fatalf("cmd msg = %#v", sw)
+	}
+	t.Chdir(t.TempDir()) // restore the working directory after the switch
+	m.Update(sw)
+	if m.appState.JJService.RepoPath != other || m.appState.ViewMode != state.ViewCommitGraph {
+		t.Errorf("RepoPath = %q view = %v after switch", m.appState.JJService.RepoPath, m.appState.ViewMode)
+	}
+}
+
+func TestWorkspacesSparsePanel(t *testing.T) {
+	m := newTestModel()
+	defer m.Close()
+	m.appState.JJService = &jj.Service{RepoPath: t.TempDir()}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if cmd == nil || !strings.Contains(m.View(), "Sparse patterns") {
+		t.Fatal("s should open the sparse panel and load the patterns")
+	}
+	m.Update(workspacestab.SparseLoadedMsg{Patterns: []string{"."}})
+	if view := m.View(); !strings.Contains(view, "(whole repository)") || !strings.Contains(view, "Full checkout.") {
+		t.Errorf("full checkout not explained:\n%s", view)
+	}
+	// R does nothing on a full checkout.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if strings.Contains(m.View(), "Check out the whole repository again?") {
+		t.Error("R should not prompt when already on a full checkout")
+	}
+
+	// a opens the input; Enter adds the typed path.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("src/app")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.appState.StatusMessage; got != "Adding src/app to the sparse patterns..." {
+		t.Errorf("status = %q", got)
+	}
+
+	m.Update(workspacestab.SparseLoadedMsg{Patterns: []string{".", "src/app"}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if got := m.appState.StatusMessage; got != "Removing . from the sparse patterns..." {
+		t.Errorf("status = %q", got)
+	}
+	m.Update(workspacestab.SparseLoadedMsg{Patterns: []string{"src/app"}})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m.Update(cmd())
+	if !strings.Contains(m.View(), "Check out the whole repository again?") {
+		t.Fatal("R should ask before resetting")
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m.Update(cmd())
+	if got := m.appState.StatusMessage; got != "Checking out the whole repository..." {
+		t.Errorf("status = %q", got)
+	}
+
+	// Esc closes the panel but stays on the tab.
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.appState.ViewMode != state.ViewWorkspaces || strings.Contains(m.View(), "Sparse patterns") {
+		t.Errorf("Esc should return to the workspace list (view %v)", m.appState.ViewMode)
+	}
+}
@@ -8,12 +8,13 @@ import "fmt"
 
 const (
 	// Tab zones
-	ZoneTabGraph    = "zone:tab:graph"
-	ZoneTabPRs      = "zone:tab:prs"
-	ZoneTabJira     = "zone:tab:jira"
-	ZoneTabBranches = "zone:tab:branches"
-	ZoneTabSettings = "zone:tab:settings"
-	ZoneTabHelp     = "zone:tab:help"
+	ZoneTabGraph      = "zone:tab:graph"
+	ZoneTabPRs        = "zone:tab:prs"
+	ZoneTabJira       = "zone:tab:jira"
+	ZoneTabBranches   = "zone:tab:branches"
+	ZoneTabSettings   = "zone:tab:settings"
+	ZoneTabHelp       = "zone:tab:help"
+	ZoneTabWorkspaces = "zone:tab:workspaces"
 
 	// Status bar action zones
 	ZoneActionQuit         = "zone:action:quit"
@@ -24,16 +25,24 @@ const (
 	ZoneActionRetry        = "zone:action:retry"
 	ZoneActionUndo         = "zone:action:undo"
 	ZoneActionRedo         = "zone:action:redo"
+	ZoneActionStatusLog    = "zone:action:statuslog"
+	ZoneActionUpdateStale  = "zone:action:updatestale"
 
 	// Commit action zones
 	ZoneActionCheckout = "zone:action:checkout"
 	ZoneActionEdit     = "zone:action:edit"
 	ZoneActionDescribe = "zone:action:describe"
 	ZoneActionSquash   = "zone:action:squash"
+	ZoneActionAbsorb   = "zone:action:absorb"
 	ZoneActionRebase   = "zone:action:rebase"
 	ZoneActionMerge    = "zone:action:merge"
 	ZoneActionAbandon  = "zone:action:abandon"
 
+	ZoneActionInsertBefore = "zone:action:insertbefore"
+	ZoneActionInsertAfter  = "zone:action:insertafter"
+	ZoneActionParallelize  = "zone:action:parallelize"
+	ZoneActionMergeMarked  = "zone:action:mergemarked"
+
 	// Description editor zones
 	ZoneDescSave     = "zone:desc:save"
 	ZoneDescCancel   = "zone:desc:cancel"
@@ -43,10 +52,13 @@ const (
 	// PR creation zones
 	ZonePRTitle        = "zone:pr:title"
 	ZonePRBody         = "zone:pr:body"
+	ZonePRReviewers    = "zone:pr:reviewers"
 	ZonePRDraft        = "zone:pr:draft"
 	ZonePRSubmit       = "zone:pr:submit"
 	ZonePRCancel       = "zone:pr:cancel"
 	ZonePRGenerate     = "zone:pr:generate"
+	ZonePRInsertTicket = "zone:pr:insertticket"
+	ZonePRTarget       = "zone:pr:target"
 	ZoneActionCreatePR = "zone:action:createpr"
 
 	// Bookmark creation zones
@@ -65,11 +77,18 @@ const (
 	ZoneActionInitURLInput         = "zone:action:init_url_input"
 	ZoneActionInitGhRepoCreate     = "zone:action:init_gh_repo_create"
 	ZoneActionInitVisibilityToggle = "zone:action:init_visibility_toggle"
+	ZoneActionInitBrowse           = "zone:action:init_browse"
+	ZoneActionInitBrowseOpenDir    = "zone:action:init_browse_open_dir"
 
 	// Warning modal zones
 	ZoneWarningGoToCommit = "zone:warning:goto_commit"
 	ZoneWarningDismiss    = "zone:warning:dismiss"
 
+	// Confirmation modal zones
+	ZoneConfirmYes     = "zone:confirm:yes"
+	ZoneConfirmNo      = "zone:confirm:no"
+	ZoneConfirmDontAsk = "zone:confirm:dont_ask"
+
 	// Evolog split modal (prefix zone:evologsplit:entry: for dynamic row zones)
 	ZoneEvologSplitSuggest        = "zone:evologsplit:suggest"
 	ZoneEvologSplitConfirm        = "zone:evologsplit:confirm"
@@ -81,10 +100,14 @@ const (
 	ZoneGraphPane = "zone:graph:pane"
 	ZoneFilesPane = "zone:files:pane"
 
+	// "Load more" row at the bottom of a paged commit graph
+	ZoneGraphLoadMore = "zone:graph:loadmore"
+
 	// Changed file action zones
 	ZoneActionMoveFileUp           = "zone:action:movefileup"
 	ZoneActionMoveFileDown         = "zone:action:movefiledown"
 	ZoneActionRevertFile           = "zone:action:revertfile"
+	ZoneActionAbsorbFile           = "zone:action:absorbfile"
 	ZoneActionViewFileDiff         = "zone:action:viewfilediff"
 	ZoneActionOpenInExternalEditor = "zone:action:openinexternaleditor"
 
@@ -95,6 +118,7 @@ const (
 	ZoneJiraCreateBranch   = "zone:jira:createbranch"
 	ZoneTicketNew          = "zone:ticket:new"
 	ZoneTicketOpenBrowser  = "zone:jira:openbrowser"
+	ZoneTicketRead         = "zone:ticket:read"
 	ZoneJiraSetInProgress  = "zone:jira:setinprogress"
 	ZoneJiraSetDone        = "zone:jira:setdone"
 	ZoneJiraChangeStatus   = "zone:jira:changestatus" // Toggle status change mode
@@ -105,6 +129,27 @@ const (
 	ZonePROpenBrowser = "zone:pr:openbrowser"
 	ZonePRMerge       = "zone:pr:merge"
 	ZonePRClose       = "zone:pr:close"
+	ZonePRDeployments = "zone:pr:deployments"
+	ZonePRRead        = "zone:pr:read"
+	ZonePRReview      = "zone:pr:review"
+	ZonePRDetails     = "zone:pr:details"
+	ZonePRDiff        = "zone:pr:diff"
+
+	// Push tab (push_mode git/gerrit) zones
+	ZonePushChange  = "zone:push:change"
+	ZonePushRefresh = "zone:push:refresh"
+
+	// PR review form zones
+	ZonePRReviewSubmit = "zone:pr:review:submit"
+	ZonePRReviewCancel = "zone:pr:review:cancel"
+
+	// PR merge form zones
+	ZonePRMergeTitle   = "zone:pr:merge:title"
+	ZonePRMergeMessage = "zone:pr:merge:message"
+	ZonePRMergeAuto    = "zone:pr:merge:auto"
+	ZonePRMergeFollow  = "zone:pr:merge:followup"
+	ZonePRMergeSubmit  = "zone:pr:merge:submit"
+	ZonePRMergeCancel  = "zone:pr:merge:cancel"
 
 	// Branch action zones
 	ZoneBranchTrack           = "zone:branch:track"
@@ -114,8 +159,20 @@ const (
 	ZoneBranchDelete          = "zone:branch:delete"
 	ZoneBranchPush            = "zone:branch:push"
 	ZoneBranchFetch           = "zone:branch:fetch"
+	ZoneBranchSyncFork        = "zone:branch:sync_fork"
 	ZoneBranchResolveConflict = "zone:branch:resolve_conflict"
 
+	// Workspace action zones
+	ZoneWorkspaceSwitch  = "zone:workspace:switch"
+	ZoneWorkspaceAdd     = "zone:workspace:add"
+	ZoneWorkspaceForget  = "zone:workspace:forget"
+	ZoneWorkspaceRefresh = "zone:workspace:refresh"
+	ZoneWorkspaceSparse  = "zone:workspace:sparse"
+	ZoneSparseAdd        = "zone:sparse:add"
+	ZoneSparseRemove     = "zone:sparse:remove"
+	ZoneSparseReset      = "zone:sparse:reset"
+	ZoneSparseClose      = "zone:sparse:close"
+
 	// Settings sub-tab zones (order in UI: GitHub, Jira, Codecks, Tickets, Branches, Theme, AI, Advanced)
 	ZoneSettingsTabGitHub   = "zone:settings:tab:github"
 	ZoneSettingsTabJira     = "zone:settings:tab:jira"
@@ -133,11 +190,15 @@ const (
 	ZoneSettingsThemePrimaryDefault   = "zone:settings:theme:primary_default"
 	ZoneSettingsThemeSecondaryDefault = "zone:settings:theme:secondary_default"
 	ZoneSettingsThemeMutedDefault     = "zone:settings:theme:muted_default"
+	ZoneSettingsThemePalette          = "zone:settings:theme:palette"
+	ZoneSettingsThemeName             = "zone:settings:theme:name"
 
 	// Help sub-tab zones
-	ZoneHelpTabShortcuts = "zone:help:tab:shortcuts"
-	ZoneHelpTabCommands  = "zone:help:tab:commands"
-	ZoneHelpCommandCopy  = "zone:help:command:copy:" // Prefix for copy buttons
+	ZoneHelpTabShortcuts   = "zone:help:tab:shortcuts"
+	ZoneHelpTabCommands    = "zone:help:tab:commands"
+	ZoneHelpTabAudit       = "zone:help:tab:audit"
+	ZoneHelpTabDiagnostics = "zone:help:tab:diagnostics"
+	ZoneHelpCommandCopy    = "zone:help:command:copy:" // Prefix for copy buttons
 
 	// Ticket provider selection (single dropdown trigger)
 	ZoneSettingsTicketProvider            = "zone:settings:ticket_provider"
@@ -145,8 +206,6 @@ const (
 	ZoneSettingsGitHubIssuesExcludedClear = "zone:settings:github_issues_excluded_clear"
 
 	// Branch settings zones
-	ZoneSettingsBranchLimitDecrease  = "zone:settings:branch_limit_decrease"
-	ZoneSettingsBranchLimitIncrease  = "zone:settings:branch_limit_increase"
 	ZoneSettingsBranchShowAllRemotes = "zone:settings:branch_show_all_remotes"
 
 	// Advanced/Maintenance operations
@@ -156,11 +215,21 @@ const (
 	ZoneSettingsAdvancedConfirmNo         = "zone:settings:advanced:confirm_no"
 	ZoneSettingsGraphRevset               = "zone:settings:graph_revset"
 	ZoneSettingsGraphRevsetClear          = "zone:settings:graph_revset_clear"
+	ZoneSettingsGraphRevsetPresetPrefix   = "zone:settings:graph_revset_preset:"
+	// Merge tool for the conflict viewer (Settings → Advanced)
+	ZoneSettingsMergeToolPrefix = "zone:settings:merge_tool:"
+	ZoneSettingsMergeToolTest   = "zone:settings:merge_tool_test"
+	// immutable_heads() editor (Settings → Advanced)
+	ZoneSettingsImmutableHeadsEdit         = "zone:settings:immutable_heads:edit"
+	ZoneSettingsImmutableHeadsSave         = "zone:settings:immutable_heads:save"
+	ZoneSettingsImmutableHeadsCancel       = "zone:settings:immutable_heads:cancel"
+	ZoneSettingsImmutableHeadsPresetPrefix = "zone:settings:immutable_heads:preset:"
 	// External editor preset (single dropdown trigger)
 	ZoneSettingsExternalEditor           = "zone:settings:external_editor"
 	ZoneSettingsExternalEditorCustom     = "zone:settings:external_editor_custom"
 	ZoneSettingsAutoInProgress           = "zone:settings:auto_in_progress"
 	ZoneSettingsSanitizeBookmarks        = "zone:settings:sanitize_bookmarks"
+	ZoneSettingsLowBandwidth             = "zone:settings:low_bandwidth"
 	ZoneSettingsAIEnabled                = "zone:settings:ai:enabled"
 	ZoneSettingsAIBaseURL                = "zone:settings:ai:base_url"
 	ZoneSettingsAIModel                  = "zone:settings:ai:model"
@@ -254,6 +323,8 @@ const (
 	ZoneTicketFormSubmit   = "zone:ticketform:submit"
 	ZoneTicketFormCancel   = "zone:ticketform:cancel"
 	ZoneTicketFormGenerate = "zone:ticketform:generate"
+	ZoneTicketFormType     = "zone:ticketform:type"
+	ZoneTicketFormPriority = "zone:ticketform:priority"
 
 	// Push action zone
 	ZoneActionPush = "zone:action:push"
@@ -267,6 +338,22 @@ func ZoneSettingsAIProfileRow(index int) string {
 	return fmt.Sprintf("%s%d", ZoneSettingsAIProfileRowPrefix, index)
 }
 
+// ZoneSettingsGraphRevsetPreset returns the zone ID for the graph revset preset button at the given index (Settings → Advanced).
+func ZoneSettingsGraphRevsetPreset(index int) string {
+	return fmt.Sprintf("%s%d", ZoneSettingsGraphRevsetPresetPrefix, index)
+}
+
+// ZoneSettingsMergeTool returns the zone ID for merge tool option i (Settings → Advanced); 0 is
+// jj's default merge editor, i > 0 is jj.MergeTools[i-1].
+func ZoneSettingsMergeTool(i int) string {
+	return fmt.Sprintf("%s%d", ZoneSettingsMergeToolPrefix, i)
+}
+
+// ZoneSettingsImmutableHeadsPreset returns the zone ID for the immutable_heads() preset button at the given index (Settings → Advanced).
+func ZoneSettingsImmutableHeadsPreset(index int) string {
+	return fmt.Sprintf("%s%d", ZoneSettingsImmutableHeadsPresetPrefix, index)
+}
+
 // ZoneGenMenuItemPrefix is the prefix for long-press generate-button menu rows.
 const ZoneGenMenuItemPrefix = "zone:genmenu:"
 
@@ -281,11 +368,27 @@ func ZoneExistingBookmark(index int) string {
 	return fmt.Sprintf("zone:bookmark:existing:%d", index)
 }
 
+// ZoneWarningCommit returns the zone ID for the commit row at the given index in the warning modal
+func ZoneWarningCommit(index int) string {
+	return fmt.Sprintf("zone:warning:commit:%d", index)
+}
+
 // ZoneCommit returns the zone ID for a commit at the given index
 func ZoneCommit(index int) string {
 	return fmt.Sprintf("zone:commit:%d", index)
 }
 
+// ZoneCommitRef returns the zone ID for the refIndex-th cross-reference token in a commit row's
+// summary. The "commit-ref" prefix sorts before "commit:" so the token wins the overlapping release.
+func ZoneCommitRef(commitIndex, refIndex int) string {
+	return fmt.Sprintf("zone:commit-ref:%d:%d", commitIndex, refIndex)
+}
+
+// ZonePRBodyRef returns the zone ID for the refIndex-th cross-reference token in the selected PR's body.
+func ZonePRBodyRef(refIndex int) string {
+	return fmt.Sprintf("zone:pr-ref:%d", refIndex)
+}
+
 // ZoneActionMoveOntoOriginAt returns the zone ID for the inline "Forgot New Commit?" control on a commit row.
 func ZoneActionMoveOntoOriginAt(index int) string {
 	return fmt.Sprintf("zone:action:move_onto_origin:%d", index)
@@ -306,6 +409,36 @@ func ZonePR(index int) string {
 	return fmt.Sprintf("zone:pr:%d", index)
 }
 
+// ZonePRChecklistItem returns the zone ID for a task list item in the PR detail view
+func ZonePRChecklistItem(index int) string {
+	return fmt.Sprintf("zone:pr:checklist:%d", index)
+}
+
+// ZonePushRow returns the zone ID for an outgoing change at the given index on the Push tab
+func ZonePushRow(index int) string {
+	return fmt.Sprintf("zone:push:row:%d", index)
+}
+
+// ZoneWorkspace returns the zone ID for a workspace at the given index on the Workspaces tab
+func ZoneWorkspace(index int) string {
+	return fmt.Sprintf("zone:workspace:%d", index)
+}
+
+// ZoneInitRecentRepo returns the zone ID for a recent repository at the given index on the welcome screen
+func ZoneInitRecentRepo(index int) string {
+	return fmt.Sprintf("zone:init:recent:%d", index)
+}
+
+// ZoneInitBrowseEntry returns the zone ID for a directory at the given index in the welcome screen's browser
+func ZoneInitBrowseEntry(index int) string {
+	return fmt.Sprintf("zone:init:browse:%d", index)
+}
+
+// ZoneSparsePattern returns the zone ID for a sparse pattern at the given index on the Workspaces tab
+func ZoneSparsePattern(index int) string {
+	return fmt.Sprintf("zone:sparse:pattern:%d", index)
+}
+
 // ZoneJiraTicket returns the zone ID for a Jira ticket at the given index
 func ZoneJiraTicket(index int) string {
 	return fmt.Sprintf("zone:jira:ticket:%d", index)
@@ -341,6 +474,18 @@ func ZoneCommitCtxMenuItem(index int) string {
 	return fmt.Sprintf("zone:commitctxmenu:%d", index)
 }
 
+// ZonePRReviewEvent returns the zone ID for a review kind option (comment, approve, request
+// changes) in the PR review form.
+func ZonePRReviewEvent(index int) string {
+	return fmt.Sprintf("zone:pr:review:event:%d", index)
+}
+
+// ZonePRMergeMethod returns the zone ID for a merge method option (squash, rebase, merge) in
+// the PR merge form.
+func ZonePRMergeMethod(index int) string {
+	return fmt.Sprintf("zone:pr:merge:method:%d", index)
+}
+
 // ZonePRCtxMenuItem returns the zone ID for a PR context menu item at the given index.
 func ZonePRCtxMenuItem(index int) string {
 	return fmt.Sprintf("zone:prctxmenu:%d", index)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
 )
 
 // DefaultDoubleClickWindow is the max delay between two left releases for a double-click.
@@ -73,3 +74,51 @@ func (d *DoubleClick) ObserveLeftRelease(key string, e tea.MouseMsg, now time.Ti
 	d.lastTime = now
 	return false
 }
+
+// Click is what a release on a list row means once the configured mouse bindings are applied.
+type Click int
+
+const (
+	ClickSelect  Click = iota // select the row (plain click)
+	ClickPrimary              // run the row's primary action (jj edit, open in editor or browser)
+	ClickView                 // open the row's detail view in the pager or diff view
+	ClickCopy                 // copy the row's ID to the clipboard
+	ClickIgnore               // do nothing
+)
+
+// Bindings maps double-clicks and middle-clicks to actions (config mouse_double_click and
+// mouse_middle_click). The zero value uses the defaults.
+type Bindings struct {
+	DoubleClick string // config.MouseClickEdit (default), MouseClickView or MouseClickNone
+	MiddleClick string // config.MouseClickCopy (default), MouseClickSelect or MouseClickNone
+}
+
+// BindingsFrom reads the mouse bindings from cfg (nil-safe).
+func BindingsFrom(cfg *config.Config) Bindings {
+	return Bindings{DoubleClick: cfg.MouseDoubleClickAction(), MiddleClick: cfg.MouseMiddleClickAction()}
+}
+
+// Classify observes a release on the row identified by key and returns what it should do under
+// b. A left double-click maps to b.DoubleClick (a plain select when that is none), a middle
+// release to b.MiddleClick; anything else selects, as a single click always has.
+func (d *DoubleClick) Classify(key string, e tea.MouseMsg, now time.Time, b Bindings) Click {
+	if e.Button == tea.MouseButtonMiddle {
+		switch b.MiddleClick {
+		case config.MouseClickSelect:
+			return ClickSelect
+		case config.MouseClickNone:
+			return ClickIgnore
+		}
+		return ClickCopy
+	}
+	if !d.ObserveLeftRelease(key, e, now, DefaultDoubleClickWindow) {
+		return ClickSelect
+	}
+	switch b.DoubleClick {
+	case config.MouseClickView:
+		return ClickView
+	case config.MouseClickNone:
+		return ClickSelect
+	}
+	return ClickPrimary
+}
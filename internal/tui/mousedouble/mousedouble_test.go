@@ -5,6 +5,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
 )
 
 func TestOverlapRelease(t *testing.T) {
@@ -61,3 +62,37 @@ func TestObserveLeftReleaseEmptyKey(t *testing.T) {
 		t.Fatal("empty key should not count as double")
 	}
 }
+
+func TestClassify(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	left := tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease}
+	middle := tea.MouseMsg{Button: tea.MouseButtonMiddle, Action: tea.MouseActionRelease}
+	double := func(b Bindings) Click {
+		var d DoubleClick
+		d.Classify("row:1", left, t0, b)
+		return d.Classify("row:1", left, t0.Add(100*time.Millisecond), b)
+	}
+
+	var d DoubleClick
+	if got := d.Classify("row:1", left, t0, Bindings{}); got != ClickSelect {
+		t.Fatalf("single click = %v, want select", got)
+	}
+	if got := double(Bindings{}); got != ClickPrimary {
+		t.Fatalf("default double-click = %v, want primary", got)
+	}
+	if got := double(Bindings{DoubleClick: config.MouseClickView}); got != ClickView {
+		t.Fatalf("view double-click = %v, want view", got)
+	}
+	if got := double(Bindings{DoubleClick: config.MouseClickNone}); got != ClickSelect {
+		t.Fatalf("disabled double-click = %v, want select", got)
+	}
+	if got := d.Classify("row:1", middle, t0, Bindings{}); got != ClickCopy {
+		t.Fatalf("default middle-click = %v, want copy", got)
+	}
+	if got := d.Classify("row:1", middle, t0, Bindings{MiddleClick: config.MouseClickSelect}); got != ClickSelect {
+		t.Fatalf("select middle-click = %v, want select", got)
+	}
+	if got := d.Classify("row:1", middle, t0, BindingsFrom(&config.Config{MouseMiddleClick: " NONE "})); got != ClickIgnore {
+		t.Fatalf("disabled middle-click = %v, want ignore", got)
+	}
+}
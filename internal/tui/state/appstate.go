@@ -3,7 +3,9 @@ package state
 import (
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/forge"
 	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/gitlab"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tickets"
 )
@@ -16,6 +18,7 @@ type AppState struct {
 	Repository    *internal.Repository
 	JJService     *jj.Service
 	GitHubService *github.Service
+	GitLabService *gitlab.Service // set instead of GitHubService when origin is on GitLab
 	TicketService tickets.Service
 	Config        *config.Config
 
@@ -37,7 +40,9 @@ type AppState struct {
 	// the next Update tick will re-snapshot from the fresh StatusMessage.
 	SpinnerMessage string
 	DemoMode       bool
-	GithubInfo     string
+	// SafeMode (--safe-mode) skips GitHub/ticket services and the auto-refresh tick.
+	SafeMode   bool
+	GithubInfo string
 
 	// DefaultBranch is the resolved default branch of the GitHub repository (e.g. "main",
 	// "master", "trunk"). Populated by LoadAuxServicesCmd after the GitHub service is
@@ -47,6 +52,11 @@ type AppState struct {
 	// pick a base branch that actually exists on the remote.
 	DefaultBranch string
 
+	// GitHubPermissions is what the GitHub token can do in this repository, probed when the
+	// service connects. Nil (not probed, demo mode) allows everything; actions it denies are
+	// shown disabled and explain why instead of failing at click time.
+	GitHubPermissions *github.Permissions
+
 	// PRsLoadedOnce is set after the first GitHub PR list load completes (success or error).
 	PRsLoadedOnce bool
 	// TicketsLoadedOnce is set after the first ticket list load completes (success or error).
@@ -60,3 +70,15 @@ func (a *AppState) HasRepository() bool { return a.Repository != nil }
 
 // HasJJ returns true if the jj service is available.
 func (a *AppState) HasJJ() bool { return a.JJService != nil }
+
+// Forge returns the service pull requests are read from and opened on: GitHubService, else
+// GitLabService, else nil (never a typed nil).
+func (a *AppState) Forge() forge.Service {
+	if a.GitHubService != nil {
+		return a.GitHubService
+	}
+	if a.GitLabService != nil {
+		return a.GitLabService
+	}
+	return nil
+}
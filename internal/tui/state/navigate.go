@@ -3,6 +3,7 @@ package state
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/xref"
 )
 
 // NavigateKind is the discriminant for a navigation request from a submodel.
@@ -61,6 +62,28 @@ const (
 	// flow so users can retry pushes after configuration changes without re-creating the
 	// GitHub repo.
 	NavigatePushBookmarks
+	// NavigateInsertTicketIntoPR fetches the Create PR form's linked ticket and appends its
+	// description (as GitHub markdown) to the PR body.
+	NavigateInsertTicketIntoPR
+	// NavigateFollowXRef jumps to the PR, ticket, or commit named by a clicked cross-reference
+	// token (XRef) in a commit description or PR body, or opens it in the browser when it is
+	// not loaded locally.
+	NavigateFollowXRef
+	// NavigateOpenPager shows PagerContent full screen under PagerTitle; NavigateClosePager returns
+	// to the view (or modal) the pager was opened from.
+	NavigateOpenPager
+	NavigateClosePager
+	// NavigateOpenFileTree browses every file in Commit's tree; NavigateCloseFileTree returns to
+	// the graph.
+	NavigateOpenFileTree
+	NavigateCloseFileTree
+	// NavigateOpenFileHistory shows the history and annotation of FileHistoryPath as of Commit;
+	// NavigateCloseFileHistory returns to the graph or file browser it was opened from.
+	NavigateOpenFileHistory
+	NavigateCloseFileHistory
+	// NavigateOpenRepo moves jj-tui to RepoPath and loads it like at startup (from the welcome
+	// screen's recent repositories and directory browser).
+	NavigateOpenRepo
 )
 
 // NavigateTarget describes a navigation request. Only main can perform these
@@ -134,12 +157,24 @@ type NavigateTarget struct {
 	InitGhCreateRepo bool   // run `gh repo create` after init (requires gh CLI in PATH)
 	InitGhRepoName   string // name passed to `gh repo create`; empty -> filepath.Base(cwd)
 	InitGhRepoPrivate bool  // visibility for `gh repo create`: true => --private, else --public
+	// RepoPath is the directory NavigateOpenRepo opens.
+	RepoPath string
 	// File diff modal (graph): path relative to repo; Commit holds change id / short id.
 	FileDiffPath string
 	// When non-empty, NavigateOpenFileDiff shows this git unified diff immediately (no jj call). Used by evolog split.
 	FileDiffRawGit          string
 	FileDiffOverlayTitle    string // e.g. "Evolog step"; empty => default "File diff"
 	FileDiffOverlaySubtitle string // e.g. "abc… → def…"; empty => path @ change id
+	// XRef is the clicked reference for NavigateFollowXRef.
+	XRef xref.Ref
+	// Pager payload for NavigateOpenPager; PagerContent may contain ANSI styling.
+	PagerTitle   string
+	PagerContent string
+	// PagerSelect opens the pager with a line selection started at PagerSelectLine.
+	PagerSelect     bool
+	PagerSelectLine int
+	// FileHistoryPath is the repo-relative file for NavigateOpenFileHistory.
+	FileHistoryPath string
 }
 
 // NavigateMsg is the only callback from submodels to main: they request a view change or
@@ -0,0 +1,15 @@
+package state
+
+// RepoResult is embedded in every message that carries data loaded from the open repository or
+// its forge and ticket services, or the outcome of an action on them. The main model tags such
+// messages with the repository its command was started for and drops them after a repository
+// switch, so a late result never lands in the new repository's tabs.
+type RepoResult struct{}
+
+func (RepoResult) repoResult() {}
+
+// IsRepoResult reports whether msg embeds RepoResult.
+func IsRepoResult(msg any) bool {
+	_, ok := msg.(interface{ repoResult() })
+	return ok
+}
@@ -19,6 +19,10 @@ const (
 	ViewDivergentCommit  // Divergent commit resolution dialog
 	ViewEvologSplit      // Experimental evolog-driven stack split (FAQ-style)
 	ViewFileDiff         // Full-file diff for selected changed file (graph overlay)
+	ViewPager            // Full-screen pager for long content (PR body, ticket description, diff, error output)
+	ViewWorkspaces       // jj workspaces of the repository
+	ViewFileTree         // Full-screen browser of every file in a revision (from the graph)
+	ViewFileHistory      // Full-screen history and annotation of one file (from the graph or file browser)
 )
 
 func (v ViewMode) String() string {
@@ -53,6 +57,14 @@ func (v ViewMode) String() string {
 		return "evolog_split"
 	case ViewFileDiff:
 		return "file_diff"
+	case ViewPager:
+		return "pager"
+	case ViewWorkspaces:
+		return "workspaces"
+	case ViewFileTree:
+		return "file_tree"
+	case ViewFileHistory:
+		return "file_history"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,106 @@
+// Package statuslog keeps the status bar's recent messages for the message log (Ctrl+l, or a
+// click on the status text). Status text is still set by assigning AppState.StatusMessage; the
+// main model records each change here after every update.
+//
+// The log stays readable under noisy updates: a message identical to the previous entry, or
+// one that only differs from it in its numbers ("Loaded 41 commits" after "Loaded 40 commits")
+// and arrives within NoisyWindow, bumps that entry's count and time instead of adding a line.
+package statuslog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultMax is how many entries the main model's log keeps.
+const DefaultMax = 200
+
+// NoisyWindow is how soon a message that only differs in its numbers must follow the previous
+// one to be folded into it.
+const NoisyWindow = 30 * time.Second
+
+// Entry is one line of the log.
+type Entry struct {
+	Text  string
+	At    time.Time // when the message, or its latest repeat, was shown
+	Count int       // times shown in a row; 1 = once
+}
+
+// Log is a bounded list of status messages, oldest first.
+type Log struct {
+	entries []Entry
+	max     int
+	// Now returns the current time; tests replace it.
+	Now func() time.Time
+}
+
+// New returns an empty log that keeps the newest max entries.
+func New(max int) *Log {
+	return &Log{max: max, Now: time.Now}
+}
+
+// Record adds text to the log, or folds it into the previous entry (see the package comment).
+// Blank text is ignored. It reports whether a new entry was added.
+func (l *Log) Record(text string) bool {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return false
+	}
+	now := l.Now()
+	if n := len(l.entries); n > 0 {
+		last := &l.entries[n-1]
+		if last.Text == text || (shape(last.Text) == shape(text) && now.Sub(last.At) < NoisyWindow) {
+			last.Text = text
+			last.At = now
+			last.Count++
+			return false
+		}
+	}
+	l.entries = append(l.entries, Entry{Text: text, At: now, Count: 1})
+	if l.max > 0 && len(l.entries) > l.max {
+		l.entries = append([]Entry(nil), l.entries[len(l.entries)-l.max:]...)
+	}
+	return true
+}
+
+// Entries returns a copy of the log, oldest first.
+func (l *Log) Entries() []Entry {
+	return append([]Entry(nil), l.entries...)
+}
+
+// String renders the log for the pager, newest first: time, message, and "×N" for repeats.
+func (l *Log) String() string {
+	if len(l.entries) == 0 {
+		return "No status messages yet."
+	}
+	var b strings.Builder
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		e := l.entries[i]
+		fmt.Fprintf(&b, "%s  %s", e.At.Format("15:04:05"), e.Text)
+		if e.Count > 1 {
+			fmt.Fprintf(&b, "  ×%d", e.Count)
+		}
+		b.WriteByte('\n')
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// shape returns text with every run of digits replaced by "#".
+func shape(text string) string {
+	var b strings.Builder
+	inDigits := false
+	for _, r := range text {
+		if unicode.IsDigit(r) {
+			if !inDigits {
+				b.WriteByte('#')
+			}
+			inDigits = true
+			continue
+		}
+		inDigits = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
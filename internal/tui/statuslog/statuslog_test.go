@@ -0,0 +1,51 @@
+package statuslog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecord(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+	l := New(3)
+	l.Now = func() time.Time { return now }
+
+	l.Record("Loaded 40 commits")
+	now = now.Add(5 * time.Second)
+	if l.Record("Loaded 41 commits") {
+		t.Error("a number-only change within the window should fold into the previous entry")
+	}
+	l.Record("Pushed feature")
+	l.Record("Pushed feature")
+	now = now.Add(time.Minute)
+	if !l.Record("Pushed fix") || !l.Record("Loaded 41 commits") {
+		t.Error("different messages should add entries")
+	}
+
+	got := l.Entries()
+	if len(got) != 3 {
+		t.Fatalf("entries = %+v, want the newest 3", got)
+	}
+	if got[0].Text != "Pushed feature" || got[0].Count != 2 {
+		t.Errorf("entry 0 = %+v, want the repeated push with count 2", got[0])
+	}
+	want := "09:31:05  Loaded 41 commits\n09:31:05  Pushed fix\n09:30:05  Pushed feature  ×2"
+	if s := l.String(); s != want {
+		t.Errorf("String() =\n%s\nwant\n%s", s, want)
+	}
+}
+
+// Messages with the same shape stop folding once the window has passed.
+func TestRecordNoisyWindow(t *testing.T) {
+	now := time.Now()
+	l := New(DefaultMax)
+	l.Now = func() time.Time { return now }
+	l.Record("Loaded 14 PRs")
+	now = now.Add(NoisyWindow)
+	if !l.Record("Loaded 15 PRs") {
+		t.Error("a repeat after the window should add an entry")
+	}
+	if l.Record("  ") {
+		t.Error("blank messages are not logged")
+	}
+}
@@ -0,0 +1,42 @@
+package styles
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// RenderMarkdown renders markdown (PR bodies, ticket descriptions) lightly for the detail views:
+// headings in bold, list bullets as •, fenced code muted, everything wrapped to width and indented
+// by two columns.
+func RenderMarkdown(body string, width int) []string {
+	muted := lipgloss.NewStyle().Foreground(ColorMuted)
+	wrap := lipgloss.NewStyle().Width(width - 2)
+	var out []string
+	inCode := false
+	for _, line := range strings.Split(strings.ReplaceAll(strings.TrimSpace(body), "\r", ""), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		var rendered string
+		switch {
+		case inCode:
+			out = append(out, "    "+muted.Render(runewidth.Truncate(line, width-4, "…")))
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			rendered = lipgloss.NewStyle().Bold(true).Render(strings.TrimSpace(strings.TrimLeft(trimmed, "#")))
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			indent := strings.Repeat(" ", len(line)-len(strings.TrimLeft(line, " ")))
+			rendered = wrap.Render(indent + "• " + trimmed[2:])
+		default:
+			rendered = wrap.Render(line)
+		}
+		for _, l := range strings.Split(rendered, "\n") {
+			out = append(out, "  "+strings.TrimRight(l, " "))
+		}
+	}
+	return out
+}
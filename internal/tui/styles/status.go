@@ -0,0 +1,148 @@
+package styles
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/tui/theme"
+)
+
+// Status colors (updated by SetPalette). Every status that uses one of these is also drawn with a
+// distinct glyph below, so no state is told apart by color alone.
+var (
+	ColorSuccess  = lipgloss.Color(theme.Dark.Success)  // checks passed, approved, PR open
+	ColorFailure  = lipgloss.Color(theme.Dark.Failure)  // checks failed, changes requested, PR closed
+	ColorPending  = lipgloss.Color(theme.Dark.Pending)  // checks or review pending
+	ColorNeutral  = lipgloss.Color(theme.Dark.Neutral)  // no checks, no reviews, draft
+	ColorMerged   = lipgloss.Color(theme.Dark.Merged)   // PR merged
+	ColorPositive = lipgloss.Color(theme.Dark.Positive) // commits ahead, added lines/files
+	ColorNegative = lipgloss.Color(theme.Dark.Negative) // conflicts, removed lines/files
+	ColorWarning  = lipgloss.Color(theme.Dark.Warning)  // commits behind, modified files
+)
+
+// Status glyphs paired with the status colors. All are single-cell and respect the foreground color.
+const (
+	GlyphSuccess  = "✓"
+	GlyphFailure  = "✗"
+	GlyphPending  = "○"
+	GlyphNone     = "·"
+	GlyphConflict = "⚠"
+	GlyphAhead    = "↑"
+	GlyphBehind   = "↓"
+)
+
+// PR list state marks: one shape per state so open/draft/closed/merged read without color.
+const (
+	PRStateOpenMark   = "●"
+	PRStateDraftMark  = "◌"
+	PRStateClosedMark = "⊘"
+	PRStateMergedMark = "◆"
+)
+
+// Graph remote-state marks for mutable commits: pushed (on a remote, rewriting needs a force
+// push), local only (safe to rewrite), and diverged (pushed, then rewritten locally).
+const (
+	RemotePushedMark   = "⇡"
+	RemoteLocalMark    = "+"
+	RemoteDivergedMark = "⇅"
+)
+
+// Palette is a named set of status colors (hex).
+type Palette struct {
+	Name        string
+	Description string
+	Success     string
+	Failure     string
+	Pending     string
+	Neutral     string
+	Merged      string
+	Positive    string
+	Negative    string
+	Warning     string
+}
+
+// Palettes lists the built-in status palettes; the first is the default, which takes its colors
+// from the active theme (the ones listed are the dark theme's). The color-vision
+// presets use the Okabe-Ito set (red-green) and a red/teal split (blue-yellow), which keep
+// pass/fail and ahead/behind on opposite sides of the affected color axis.
+var Palettes = []Palette{
+	{
+		Name: "default", Description: "The theme's own status colors",
+		Success: "#2ea44f", Failure: "#cb2431", Pending: "#dbab09", Neutral: "#6a737d", Merged: "#6f42c1",
+		Positive: "#50FA7B", Negative: "#FF5555", Warning: "#FFB86C",
+	},
+	{
+		Name: "deuteranopia", Description: "Red-green safe (deuteranopia, protanopia): blue/orange",
+		Success: "#56B4E9", Failure: "#E69F00", Pending: "#F0E442", Neutral: "#999999", Merged: "#CC79A7",
+		Positive: "#56B4E9", Negative: "#D55E00", Warning: "#F0E442",
+	},
+	{
+		Name: "tritanopia", Description: "Blue-yellow safe (tritanopia): teal/red",
+		Success: "#00B8B8", Failure: "#F0506E", Pending: "#F4A7C0", Neutral: "#A0A0A0", Merged: "#9E9EFF",
+		Positive: "#00B8B8", Negative: "#F0506E", Warning: "#F4A7C0",
+	},
+	{
+		Name: "monochrome", Description: "No status colors; glyphs only",
+		Success: "#E0E0E0", Failure: "#FFFFFF", Pending: "#B0B0B0", Neutral: "#808080", Merged: "#C8C8C8",
+		Positive: "#E0E0E0", Negative: "#FFFFFF", Warning: "#B0B0B0",
+	},
+}
+
+var paletteName = Palettes[0].Name
+
+// SetPalette switches the status colors to the named palette and rebuilds the styles. Unknown or
+// empty names select the default palette.
+func SetPalette(name string) {
+	p := Palettes[0]
+	if i := slices.IndexFunc(Palettes, func(p Palette) bool { return p.Name == name }); i >= 0 {
+		p = Palettes[i]
+	}
+	if p.Name == Palettes[0].Name {
+		t := active
+		p.Success, p.Failure, p.Pending, p.Neutral, p.Merged = t.Success, t.Failure, t.Pending, t.Neutral, t.Merged
+		p.Positive, p.Negative, p.Warning = t.Positive, t.Negative, t.Warning
+	}
+	paletteName = p.Name
+	ColorSuccess = lipgloss.Color(p.Success)
+	ColorFailure = lipgloss.Color(p.Failure)
+	ColorPending = lipgloss.Color(p.Pending)
+	ColorNeutral = lipgloss.Color(p.Neutral)
+	ColorMerged = lipgloss.Color(p.Merged)
+	ColorPositive = lipgloss.Color(p.Positive)
+	ColorNegative = lipgloss.Color(p.Negative)
+	ColorWarning = lipgloss.Color(p.Warning)
+	rebuildThemeStyles()
+}
+
+// PaletteName returns the active palette name.
+func PaletteName() string {
+	return paletteName
+}
+
+// NextPalette returns the palette after name in Palettes, wrapping around.
+func NextPalette(name string) string {
+	i := slices.IndexFunc(Palettes, func(p Palette) bool { return p.Name == name })
+	return Palettes[(i+1)%len(Palettes)].Name
+}
+
+// PaletteDescription returns the description of the named palette, or "".
+func PaletteDescription(name string) string {
+	for _, p := range Palettes {
+		if p.Name == name {
+			return p.Description
+		}
+	}
+	return ""
+}
+
+// StatusPreview renders every status glyph in its palette color, for the theme settings.
+func StatusPreview() string {
+	st := func(c lipgloss.Color, s string) string { return lipgloss.NewStyle().Foreground(c).Render(s) }
+	return st(ColorSuccess, GlyphSuccess+" passed") + "  " +
+		st(ColorFailure, GlyphFailure+" failed") + "  " +
+		st(ColorPending, GlyphPending+" pending") + "  " +
+		st(ColorNeutral, GlyphNone+" none") + "  " +
+		st(ColorPositive, GlyphAhead+"2 ahead") + "  " +
+		st(ColorWarning, GlyphBehind+"1 behind") + "  " +
+		st(ColorNegative, GlyphConflict+" conflict")
+}
@@ -0,0 +1,77 @@
+package styles
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/tui/theme"
+)
+
+func TestSetPalette(t *testing.T) {
+	t.Cleanup(func() { SetPalette("default") })
+
+	SetPalette("deuteranopia")
+	if PaletteName() != "deuteranopia" || ColorSuccess != lipgloss.Color("#56B4E9") {
+		t.Fatalf("palette = %q, success = %q", PaletteName(), ColorSuccess)
+	}
+	SetPalette("no-such-palette")
+	if PaletteName() != "default" || ColorSuccess != lipgloss.Color(Palettes[0].Success) {
+		t.Fatalf("unknown palette should fall back to default, got %q", PaletteName())
+	}
+}
+
+func TestNextPaletteWraps(t *testing.T) {
+	seen := map[string]bool{}
+	name := Palettes[0].Name
+	for range Palettes {
+		seen[name] = true
+		name = NextPalette(name)
+	}
+	if name != Palettes[0].Name || len(seen) != len(Palettes) {
+		t.Fatalf("cycle visited %v and ended on %q", seen, name)
+	}
+	if NextPalette("unknown") != Palettes[0].Name {
+		t.Fatalf("unknown palette should cycle to the first")
+	}
+}
+
+// Pass/fail and ahead/behind must stay distinguishable by glyph even where a palette makes them
+// the same color.
+func TestStatusGlyphsDistinct(t *testing.T) {
+	glyphs := []string{GlyphSuccess, GlyphFailure, GlyphPending, GlyphNone, GlyphConflict, GlyphAhead, GlyphBehind}
+	pr := []string{PRStateOpenMark, PRStateDraftMark, PRStateClosedMark, PRStateMergedMark}
+	remote := []string{RemotePushedMark, RemoteLocalMark, RemoteDivergedMark}
+	for _, set := range [][]string{glyphs, pr, remote} {
+		seen := map[string]bool{}
+		for _, g := range set {
+			if seen[g] {
+				t.Fatalf("duplicate glyph %q", g)
+			}
+			seen[g] = true
+			if w := lipgloss.Width(g); w != 1 {
+				t.Fatalf("glyph %q is %d cells wide", g, w)
+			}
+		}
+	}
+}
+
+// ApplyTheme recolors the styles, reruns OnThemeChange hooks, and the default palette takes the
+// theme's status colors while a color-vision palette keeps its own.
+func TestApplyTheme(t *testing.T) {
+	t.Cleanup(func() { SetPalette("default"); ApplyTheme(theme.Dark) })
+	var hooked lipgloss.TerminalColor
+	OnThemeChange(func() { hooked = ColorText })
+
+	ApplyTheme(theme.Light)
+	if ThemeName() != "light" || ColorText != lipgloss.Color(theme.Light.Text) || hooked != ColorText {
+		t.Fatalf("theme = %q, text = %q, hook saw %v", ThemeName(), ColorText, hooked)
+	}
+	if ColorSuccess != lipgloss.Color(theme.Light.Success) || TabActiveStyle.GetBackground() != lipgloss.Color(theme.Light.Primary) {
+		t.Fatalf("success = %q, tab = %v", ColorSuccess, TabActiveStyle.GetBackground())
+	}
+	SetPalette("deuteranopia")
+	ApplyTheme(theme.HighContrast)
+	if ColorSuccess != lipgloss.Color("#56B4E9") {
+		t.Fatalf("the palette should survive a theme change, success = %q", ColorSuccess)
+	}
+}
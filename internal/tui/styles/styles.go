@@ -5,15 +5,49 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/tui/theme"
 )
 
-// Colors (updated by SetTheme when config or theme picker changes)
+// Colors (set by ApplyTheme from the active theme; SetTheme overrides the first three)
 var (
-	ColorPrimary   = lipgloss.Color("#7E00AF")
-	ColorSecondary = lipgloss.Color("#50FA7B")
-	ColorMuted     = lipgloss.Color("#6272A4")
+	ColorPrimary   = lipgloss.Color(theme.Dark.Primary)
+	ColorSecondary = lipgloss.Color(theme.Dark.Secondary)
+	ColorMuted     = lipgloss.Color(theme.Dark.Muted)
+
+	ColorAccent    = lipgloss.Color(theme.Dark.Accent)
+	ColorSpecial   = lipgloss.Color(theme.Dark.Special)
+	ColorAI        = lipgloss.Color(theme.Dark.AI)
+	ColorAttention = lipgloss.Color(theme.Dark.Attention)
+	ColorHighlight = lipgloss.Color(theme.Dark.Highlight)
+
+	ColorText     = lipgloss.Color(theme.Dark.Text)
+	ColorTextDim  = lipgloss.Color(theme.Dark.TextDim)
+	ColorSubtle   = lipgloss.Color(theme.Dark.Subtle)
+	ColorOnAccent = lipgloss.Color(theme.Dark.OnAccent)
+	ColorOnBright = lipgloss.Color(theme.Dark.OnBright)
+
+	ColorPanel      = lipgloss.Color(theme.Dark.Panel)
+	ColorSeparator  = lipgloss.Color(theme.Dark.Separator)
+	ColorSelection  = lipgloss.Color(theme.Dark.Selection)
+	ColorCursor     = lipgloss.Color(theme.Dark.Cursor)
+	ColorConfirm    = lipgloss.Color(theme.Dark.Confirm)
+	ColorAction     = lipgloss.Color(theme.Dark.Action)
+	ColorDanger     = lipgloss.Color(theme.Dark.Danger)
+	ColorRebaseSrc  = lipgloss.Color(theme.Dark.RebaseSource)
+	ColorRebaseDest = lipgloss.Color(theme.Dark.RebaseDest)
+
+	ColorDiffAdd        = lipgloss.Color(theme.Dark.DiffAdd)
+	ColorDiffDelete     = lipgloss.Color(theme.Dark.DiffDelete)
+	ColorDiffContext    = lipgloss.Color(theme.Dark.DiffContext)
+	ColorDiffMeta       = lipgloss.Color(theme.Dark.DiffMeta)
+	ColorDiffHunk       = lipgloss.Color(theme.Dark.DiffHunk)
+	ColorConflictOurs   = lipgloss.Color(theme.Dark.ConflictOurs)
+	ColorConflictTheirs = lipgloss.Color(theme.Dark.ConflictTheirs)
 )
 
+// active is the theme last passed to ApplyTheme; its status colors back the "default" palette.
+var active = theme.Dark
+
 // DivergentMark prefixes "divergent" in the graph and resolver. U+2442 (OCR FORK, "⑂") is missing
 // from many monospace fonts and from VHS GIF output (replacement boxes). U+2260 is widely supported.
 const DivergentMark = "≠"
@@ -38,7 +72,7 @@ const AIAssistGlyph = AIGenerateMark
 // StyleAIGenerateIcon renders a compact AI control on the theme primary pill (regular weight so ^g is not bolded).
 func StyleAIGenerateIcon(label string) string {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFFFFF")).
+		Foreground(ColorOnAccent).
 		Background(ColorPrimary).
 		Padding(0, 1).
 		Render(label)
@@ -64,8 +98,57 @@ func SpreadRow(totalWidth int, left, right string) string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, left, strings.Repeat(" ", gap), right)
 }
 
-// SetTheme updates the global theme colors and rebuilds styles that use them.
-// Pass hex strings (e.g. "#7E00AF"). Empty strings are ignored (keep current).
+// ApplyTheme makes t the active theme: every color above, the "default" status palette and
+// every style built from them (here and in the tabs, via OnThemeChange).
+func ApplyTheme(t theme.Theme) {
+	active = t
+	ColorPrimary = lipgloss.Color(t.Primary)
+	ColorSecondary = lipgloss.Color(t.Secondary)
+	ColorMuted = lipgloss.Color(t.Muted)
+	ColorAccent = lipgloss.Color(t.Accent)
+	ColorSpecial = lipgloss.Color(t.Special)
+	ColorAI = lipgloss.Color(t.AI)
+	ColorAttention = lipgloss.Color(t.Attention)
+	ColorHighlight = lipgloss.Color(t.Highlight)
+	ColorText = lipgloss.Color(t.Text)
+	ColorTextDim = lipgloss.Color(t.TextDim)
+	ColorSubtle = lipgloss.Color(t.Subtle)
+	ColorOnAccent = lipgloss.Color(t.OnAccent)
+	ColorOnBright = lipgloss.Color(t.OnBright)
+	HeaderBarBackground = lipgloss.Color(t.Bar)
+	HeaderBarForeground = lipgloss.Color(t.BarText)
+	StatusBarBackground = lipgloss.Color(t.Bar)
+	ColorPanel = lipgloss.Color(t.Panel)
+	ColorSeparator = lipgloss.Color(t.Separator)
+	ColorSelection = lipgloss.Color(t.Selection)
+	ColorCursor = lipgloss.Color(t.Cursor)
+	ColorConfirm = lipgloss.Color(t.Confirm)
+	ColorAction = lipgloss.Color(t.Action)
+	ColorDanger = lipgloss.Color(t.Danger)
+	ColorRebaseSrc = lipgloss.Color(t.RebaseSource)
+	ColorRebaseDest = lipgloss.Color(t.RebaseDest)
+	ColorDiffAdd = lipgloss.Color(t.DiffAdd)
+	ColorDiffDelete = lipgloss.Color(t.DiffDelete)
+	ColorDiffContext = lipgloss.Color(t.DiffContext)
+	ColorDiffMeta = lipgloss.Color(t.DiffMeta)
+	ColorDiffHunk = lipgloss.Color(t.DiffHunk)
+	ColorConflictOurs = lipgloss.Color(t.ConflictOurs)
+	ColorConflictTheirs = lipgloss.Color(t.ConflictTheirs)
+	SetPalette(paletteName) // re-reads the theme's status colors for "default"
+}
+
+// ThemeName returns the name of the active theme.
+func ThemeName() string {
+	return active.Name
+}
+
+// ActiveTheme returns the active theme (without SetTheme overrides).
+func ActiveTheme() theme.Theme {
+	return active
+}
+
+// SetTheme overrides the primary, secondary and muted colors of the active theme and rebuilds
+// styles that use them. Pass hex strings (e.g. "#7E00AF"). Empty strings are ignored (keep current).
 func SetTheme(primary, secondary, muted string) {
 	if primary != "" {
 		ColorPrimary = lipgloss.Color(primary)
@@ -79,109 +162,123 @@ func SetTheme(primary, secondary, muted string) {
 	rebuildThemeStyles()
 }
 
+var themeHooks []func()
+
+// OnThemeChange registers rebuild to run whenever the theme or palette changes, so a package's
+// own style variables follow the active colors. Call it from init; it also runs rebuild once.
+func OnThemeChange(rebuild func()) {
+	themeHooks = append(themeHooks, rebuild)
+	rebuild()
+}
+
 func rebuildThemeStyles() {
 	TitleStyle = lipgloss.NewStyle().
 		Bold(true).
 		Foreground(ColorPrimary)
+	CommitStyle = lipgloss.NewStyle().
+		Foreground(ColorText)
+	CommitSelectedStyle = lipgloss.NewStyle().
+		Foreground(ColorText).
+		Background(ColorSelection)
 	CommitIDStyle = lipgloss.NewStyle().
 		Foreground(ColorPrimary).
 		Bold(true)
+	ButtonStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(active.ButtonText)).
+		Background(lipgloss.Color(active.Button)).
+		Padding(0, 1).
+		MarginRight(1)
+	ButtonSecondaryStyle = ButtonStyle.
+		Foreground(ColorOnAccent).
+		Background(ColorMuted)
+	ButtonConfirmStyle = ButtonStyle.Foreground(ColorOnAccent).Background(ColorConfirm)
+	ButtonActionStyle = ButtonStyle.Foreground(ColorOnAccent).Background(ColorAction)
+	ButtonDangerStyle = ButtonStyle.Foreground(ColorOnAccent).Background(ColorDanger)
+	ButtonDisabledStyle = ButtonStyle.
+		Foreground(lipgloss.Color(active.Disabled)).
+		Background(lipgloss.Color(active.DisabledBg)).
+		Strikethrough(true)
 	HelpKeyStyle = lipgloss.NewStyle().
 		Foreground(ColorPrimary).
 		Bold(true)
+	HelpDescStyle = lipgloss.NewStyle().
+		Foreground(ColorText)
 	GraphStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+	RebaseSourceStyle = lipgloss.NewStyle().
+		Background(ColorRebaseSrc).
+		Foreground(ColorOnAccent).
+		Bold(true)
+	RebaseDestStyle = lipgloss.NewStyle().
+		Background(ColorRebaseDest).
+		Foreground(ColorOnAccent)
+	RebaseHeaderStyle = lipgloss.NewStyle().
+		Foreground(ColorAttention).
+		Bold(true)
+	HeaderStyle = lipgloss.NewStyle().
+		Background(HeaderBarBackground).
+		Foreground(HeaderBarForeground).
+		Padding(0, 0)
+	TabStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(active.Tab)).
+		Padding(0, 2)
 	TabActiveStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F9FAFB")).
+		Foreground(ColorOnAccent).
 		Background(ColorPrimary).
 		Padding(0, 2)
 	StatusBarStyle = lipgloss.NewStyle().
 		Background(StatusBarBackground).
 		Foreground(ColorMuted).
 		Padding(0, 0)
+	for _, rebuild := range themeHooks {
+		rebuild()
+	}
 }
 
-// Styles (TitleStyle, CommitIDStyle, HelpKeyStyle, GraphStyle, TabActiveStyle, StatusBarStyle rebuilt in rebuildThemeStyles)
+// Styles (all rebuilt from the active colors in rebuildThemeStyles)
 var (
-	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary)
-
-	CommitStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F8F8F2"))
-
-	CommitSelectedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#F8F8F2")).
-				Background(lipgloss.Color("#44475A"))
-
-	CommitIDStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true)
-
-	ButtonStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F8F8F2")).
-			Background(lipgloss.Color("#44475A")).
-			Padding(0, 1).
-			MarginRight(1)
-
-	ButtonSecondaryStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#F8F8F2")).
-				Background(lipgloss.Color("#6272A4")).
-				Padding(0, 1).
-				MarginRight(1)
-
-	HelpKeyStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true)
-
-	HelpDescStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F8F8F2"))
-
-	GraphStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+	TitleStyle          lipgloss.Style
+	CommitStyle         lipgloss.Style
+	CommitSelectedStyle lipgloss.Style
+	CommitIDStyle       lipgloss.Style
+
+	ButtonStyle          lipgloss.Style
+	ButtonSecondaryStyle lipgloss.Style
+	// ButtonConfirmStyle, ButtonActionStyle and ButtonDangerStyle color buttons that create,
+	// push or destroy something.
+	ButtonConfirmStyle lipgloss.Style
+	ButtonActionStyle  lipgloss.Style
+	ButtonDangerStyle  lipgloss.Style
+	// ButtonDisabledStyle is for actions that are shown but can't run (e.g. the GitHub token
+	// lacks the permission); clicking one explains why in the status bar.
+	ButtonDisabledStyle lipgloss.Style
+
+	HelpKeyStyle  lipgloss.Style
+	HelpDescStyle lipgloss.Style
+	GraphStyle    lipgloss.Style
 
 	// Special styles for rebase mode
-	RebaseSourceStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("#5555AA")).
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Bold(true)
-	RebaseDestStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("#55AA55")).
-			Foreground(lipgloss.Color("#FFFFFF"))
-
-	RebaseHeaderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFAA00")).
-				Bold(true)
+	RebaseSourceStyle lipgloss.Style
+	RebaseDestStyle   lipgloss.Style
+	RebaseHeaderStyle lipgloss.Style
 
 	// Header and layout (main model view)
 	// Bar backgrounds: horizontal “padding” is not lipgloss Padding(0,1) (that paints two extra
 	// background cells). Gutter colors are set in view_helpers.chromeHorizontalRow.
-	HeaderBarBackground = lipgloss.Color("#1F2937")
-	HeaderBarForeground = lipgloss.Color("#F9FAFB")
-	StatusBarBackground = lipgloss.Color("#1F2937")
+	HeaderBarBackground = lipgloss.Color(theme.Dark.Bar)
+	HeaderBarForeground = lipgloss.Color(theme.Dark.BarText)
+	StatusBarBackground = lipgloss.Color(theme.Dark.Bar)
 
 	// Top bar, rightmost column: NoColor leaves background unset so the terminal default shows through.
 	HeaderGutterRightBackground lipgloss.TerminalColor = lipgloss.NoColor{}
 
-	HeaderStyle = lipgloss.NewStyle().
-			Background(HeaderBarBackground).
-			Foreground(HeaderBarForeground).
-			Padding(0, 0)
-
-	TabStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#9CA3AF")).
-			Padding(0, 2)
-
-	TabActiveStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F9FAFB")).
-			Background(ColorPrimary).
-			Padding(0, 2)
+	HeaderStyle    lipgloss.Style
+	TabStyle       lipgloss.Style
+	TabActiveStyle lipgloss.Style
 
 	ContentStyle = lipgloss.NewStyle().
 			Padding(1, 2)
 
-	StatusBarStyle = lipgloss.NewStyle().
-			Background(StatusBarBackground).
-			Foreground(ColorMuted).
-			Padding(0, 0)
+	StatusBarStyle lipgloss.Style
 )
 
 func init() {
@@ -192,13 +289,13 @@ func init() {
 func GetStatusStyle(status string) (lipgloss.Style, string) {
 	switch status {
 	case "M":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C")), "M" // Orange for modified
+		return lipgloss.NewStyle().Foreground(ColorWarning), "M" // Orange for modified
 	case "A":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")), "A" // Green for added
+		return lipgloss.NewStyle().Foreground(ColorPositive), "A" // Green for added
 	case "D":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")), "D" // Red for deleted
+		return lipgloss.NewStyle().Foreground(ColorNegative), "D" // Red for deleted
 	case "R":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#8BE9FD")), "R" // Cyan for renamed
+		return lipgloss.NewStyle().Foreground(ColorAccent), "R" // Cyan for renamed
 	default:
 		return lipgloss.NewStyle().Foreground(ColorMuted), status
 	}
@@ -211,8 +308,8 @@ func DiffStatsSuffix(added, removed int, ok bool) string {
 		return ""
 	}
 	var parts []string
-	addSt := lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B"))
-	remSt := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555"))
+	addSt := lipgloss.NewStyle().Foreground(ColorPositive)
+	remSt := lipgloss.NewStyle().Foreground(ColorNegative)
 	if added > 0 {
 		parts = append(parts, addSt.Render(fmt.Sprintf("+%d", added)))
 	}
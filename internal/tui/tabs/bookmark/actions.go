@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
@@ -43,10 +44,11 @@ type SubmitInput struct {
 	JiraKey                   string
 	JiraTitle                 string
 	DisplayKey                string
-	JiraBookmarkTitles        map[string]string
+	TicketBookmarkRefs        map[string]TicketRef
 	TicketBookmarkDisplayKeys map[string]string
 	JJService                 *jj.Service
 	SanitizeBookmarks         bool
+	BookmarkPrefix            string // namespace prepended to new names ("" = none)
 }
 
 // IndexOfWorkingCopy returns the graph index of the working copy commit, or -1 if not found.
@@ -65,14 +67,7 @@ func IndexOfWorkingCopy(repo *internal.Repository) int {
 // SubmitCmd validates and runs the appropriate command (CreateBookmarkCmd or CreateBookmarkFromTicketCmd).
 // Returns (cmd, ""); if validation fails returns (nil, validationError). Caller sets status from validationError.
 func SubmitCmd(input SubmitInput) (tea.Cmd, string) {
-	bookmarkName := strings.TrimSpace(input.BookmarkName)
-	if input.SanitizeBookmarks {
-		bookmarkName = jj.SanitizeBookmarkName(bookmarkName)
-	}
-	// Unconditional length backstop, applied after sanitize so the truncation can rely on
-	// a normalized character set. Catches any path that bypassed the AI-cmd / Jira-default
-	// cap (e.g. user pastes a long name into the input).
-	bookmarkName = jj.TruncateBookmarkName(bookmarkName)
+	bookmarkName := finalBookmarkName(input.BookmarkName, input.SanitizeBookmarks, input.BookmarkPrefix)
 	if err := ValidateBookmarkName(bookmarkName); err != "" {
 		return nil, err
 	}
@@ -86,11 +81,36 @@ func SubmitCmd(input SubmitInput) (tea.Cmd, string) {
 	return CreateBookmarkCmd(input.JJService, bookmarkName, input.CommitID), ""
 }
 
+// finalBookmarkName is the bookmark created for a typed name: sanitized when enabled, namespaced
+// with prefix (unless the user already typed it) and capped at jj.MaxBookmarkNameLen. The prefix
+// is added after sanitizing, which would drop its "/".
+func finalBookmarkName(name string, sanitize bool, prefix string) string {
+	name = strings.TrimSpace(name)
+	if prefix != "" {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	if sanitize {
+		name = jj.SanitizeBookmarkName(name)
+	}
+	// Unconditional length backstop, applied after sanitize so the truncation can rely on
+	// a normalized character set. Catches any path that bypassed the AI-cmd / Jira-default
+	// cap (e.g. user pastes a long name into the input).
+	if prefix == "" {
+		return jj.TruncateBookmarkName(name)
+	}
+	name = jj.TruncateBookmarkNameTo(name, jj.MaxBookmarkNameLen-utf8.RuneCountInString(prefix))
+	if name == "" {
+		return ""
+	}
+	return prefix + name
+}
+
 // OpenCreateBookmark prepares and shows the bookmark creation dialog for the given commit.
 // Caller sets view mode and status message from the returned value.
-func OpenCreateBookmark(modal *Model, repo *internal.Repository, commitIdx int, conflictSources []string, sanitize bool, width int) string {
+func OpenCreateBookmark(modal *Model, repo *internal.Repository, commitIdx int, conflictSources []string, sanitize bool, prefix string, width int) string {
 	data := PrepareShow(repo, commitIdx)
 	modal.Show(commitIdx, data.ExistingBookmarks)
+	modal.SetNamePrefix(prefix)
 	modal.UpdateRepository(repo)
 	modal.SetNameConflictSources(conflictSources)
 	modal.UpdateNameExistsFromInput(sanitize)
@@ -103,10 +123,11 @@ func OpenCreateBookmark(modal *Model, repo *internal.Repository, commitIdx int,
 
 // OpenCreateBookmarkFromTicket prepares and shows the bookmark creation dialog to create a branch (bookmark) on the current commit for the given ticket.
 // Caller sets view mode and status message from the returned value.
-func OpenCreateBookmarkFromTicket(modal *Model, repo *internal.Repository, ticketKey, title, displayKey string, conflictSources []string, sanitize bool, width int) string {
+func OpenCreateBookmarkFromTicket(modal *Model, repo *internal.Repository, ticketKey, title, displayKey string, conflictSources []string, sanitize bool, prefix string, width int) string {
 	workingCopyIdx := IndexOfWorkingCopy(repo)
 	if workingCopyIdx < 0 {
 		modal.Show(-1, nil)
+		modal.SetNamePrefix(prefix)
 		modal.SetFromJira(ticketKey, title, displayKey)
 		modal.UpdateRepository(repo)
 		modal.SetNameConflictSources(conflictSources)
@@ -119,6 +140,7 @@ func OpenCreateBookmarkFromTicket(modal *Model, repo *internal.Repository, ticke
 	}
 	existingBookmarks := GetExistingBookmarks(repo, workingCopyIdx)
 	modal.Show(workingCopyIdx, existingBookmarks)
+	modal.SetNamePrefix(prefix)
 	modal.SetFromJira(ticketKey, title, displayKey)
 	defaultName := strings.TrimSpace(title)
 	if defaultName == "" {
@@ -178,6 +200,7 @@ func SubmitBookmark(modal *Model, repo *internal.Repository, cfg *config.Config,
 	if cfg != nil {
 		sanitize = cfg.ShouldSanitizeBookmarkNames()
 	}
+	prefix := cfg.BookmarkNamespace()
 	input := SubmitInput{
 		BookmarkName:              modal.GetBookmarkName(),
 		CommitIdx:                 commitIdx,
@@ -186,32 +209,29 @@ func SubmitBookmark(modal *Model, repo *internal.Repository, cfg *config.Config,
 		JiraKey:                   modal.GetJiraKey(),
 		JiraTitle:                 modal.GetJiraTicketTitle(),
 		DisplayKey:                modal.GetTicketDisplayKey(),
-		JiraBookmarkTitles:        modal.GetJiraBookmarkTitles(),
+		TicketBookmarkRefs:        modal.GetTicketBookmarkRefs(),
 		TicketBookmarkDisplayKeys: modal.GetTicketBookmarkDisplayKeys(),
 		JJService:                 jjService,
 		SanitizeBookmarks:         sanitize,
+		BookmarkPrefix:            prefix,
 	}
 	if input.FromJira {
-		bookmarkName := strings.TrimSpace(input.BookmarkName)
-		if sanitize {
-			bookmarkName = jj.SanitizeBookmarkName(bookmarkName)
-		}
-		// Mirror SubmitCmd's backstop so the Jira-title and ticket-key maps store the
-		// same key SubmitCmd ends up creating; otherwise a long un-truncated name here
-		// would diverge from the truncated bookmark actually created on disk, and later
-		// lookups against JiraBookmarkTitles[truncatedName] would miss.
-		bookmarkName = jj.TruncateBookmarkName(bookmarkName)
+		// Use SubmitCmd's final name so the Jira-title and ticket-key maps store the
+		// same key SubmitCmd ends up creating; otherwise a long un-truncated or
+		// un-prefixed name here would diverge from the bookmark actually created on
+		// disk, and later lookups against TicketBookmarkRefs[finalName] would miss.
+		bookmarkName := finalBookmarkName(input.BookmarkName, sanitize, prefix)
 		if input.JiraTitle != "" && input.JiraKey != "" {
 			keyForTitle := input.JiraKey
 			if input.DisplayKey != "" {
 				keyForTitle = input.DisplayKey
 			}
-			titles := modal.GetJiraBookmarkTitles()
-			if titles == nil {
-				titles = make(map[string]string)
+			refs := modal.GetTicketBookmarkRefs()
+			if refs == nil {
+				refs = make(map[string]TicketRef)
 			}
-			titles[bookmarkName] = keyForTitle + " - " + input.JiraTitle
-			modal.SetJiraBookmarkTitles(titles)
+			refs[bookmarkName] = TicketRef{ID: input.JiraKey, Key: keyForTitle, Title: input.JiraTitle}
+			modal.SetTicketBookmarkRefs(refs)
 		}
 		if input.DisplayKey != "" {
 			keys := modal.GetTicketBookmarkDisplayKeys()
@@ -227,10 +247,14 @@ func SubmitBookmark(modal *Model, repo *internal.Repository, cfg *config.Config,
 	if errStr != "" {
 		return nil, errStr
 	}
+	name := strings.TrimSpace(input.BookmarkName)
+	if prefix != "" {
+		name = finalBookmarkName(name, sanitize, prefix)
+	}
 	if input.FromJira {
-		return cmd, fmt.Sprintf("Creating bookmark '%s' on current commit...", strings.TrimSpace(input.BookmarkName))
+		return cmd, fmt.Sprintf("Creating bookmark '%s' on current commit...", name)
 	}
-	return cmd, fmt.Sprintf("Creating bookmark '%s'...", strings.TrimSpace(input.BookmarkName))
+	return cmd, fmt.Sprintf("Creating bookmark '%s'...", name)
 }
 
 // ValidateBookmarkName returns error message if invalid, empty if valid.
@@ -371,11 +395,34 @@ func HandleBookmarkCreatedMsg(msg BookmarkCreatedMsg, app *state.AppState) tea.C
 		statusMsg = fmt.Sprintf("Bookmark '%s' moved", msg.BookmarkName)
 	}
 	app.StatusMessage = statusMsg
+	reload := data.LoadRepository(app.JJService)
+	if msg.CommitID != "main" {
+		// Only the bookmark's old and new commits changed.
+		reload = data.RefreshCommitsCmd(app.JJService, app.Repository, bookmarkRefreshRevset(app.Repository, msg.BookmarkName, msg.CommitID))
+	}
 	if msg.TicketKey != "" && app.TicketService != nil && app.Config != nil && app.Config.AutoInProgressOnBranch() {
 		return tea.Batch(
-			data.LoadRepository(app.JJService),
+			reload,
 			tickets.TransitionTicketToInProgressCmd(app.TicketService, msg.TicketKey),
 		)
 	}
-	return data.LoadRepository(app.JJService)
+	return reload
+}
+
+// bookmarkRefreshRevset selects the commits a bookmark create or move on commitID touched: the
+// target and the commits the graph still shows the bookmark on.
+func bookmarkRefreshRevset(repo *internal.Repository, name, commitID string) string {
+	revs := []string{commitID}
+	if repo != nil {
+		name = util.LocalBookmarkName(name)
+		for _, c := range repo.Graph.Commits {
+			for _, b := range c.Branches {
+				if util.LocalBookmarkName(b) == name && !strings.Contains(b, "@") {
+					revs = append(revs, c.ID)
+					break
+				}
+			}
+		}
+	}
+	return strings.Join(revs, " | ")
 }
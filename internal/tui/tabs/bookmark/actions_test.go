@@ -2,9 +2,12 @@ package bookmark
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
 )
 
 // TestGetExistingBookmarks_RemoteTrackingDoesNotFilterLocal is the regression for the
@@ -70,3 +73,53 @@ func TestGetExistingBookmarks_BothLocalAndRemoteOnTarget(t *testing.T) {
 		t.Fatalf("GetExistingBookmarks(local+remote on target) = %v, want %v", got, want)
 	}
 }
+
+// bookmark_prefix is added after sanitizing (which drops "/"), only once, and the ticket maps
+// are keyed by the prefixed name that gets created.
+func TestSubmitBookmark_Prefix(t *testing.T) {
+	cases := map[string]string{
+		"Fix login bug": "alice/Fix_login_bug",
+		"alice/fix":     "alice/fix",
+		"  ":            "",
+	}
+	for in, want := range cases {
+		if got := finalBookmarkName(in, true, "alice/"); got != want {
+			t.Errorf("finalBookmarkName(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := finalBookmarkName(strings.Repeat("x", 80), false, "alice/"); len(got) != jj.MaxBookmarkNameLen || !strings.HasPrefix(got, "alice/") {
+		t.Errorf("long name = %q, want capped at %d with the prefix kept", got, jj.MaxBookmarkNameLen)
+	}
+
+	repo := &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ChangeID: "wc", ShortID: "wc", IsWorking: true},
+	}}}
+	modal := NewModel(nil)
+	OpenCreateBookmarkFromTicket(&modal, repo, "PROJ-1", "Fix login", "", nil, true, "alice/", 40)
+	if !strings.Contains(modal.View(), "alice/") {
+		t.Error("the form should show the prefix before the name")
+	}
+	cmd, status := SubmitBookmark(&modal, repo, &config.Config{BookmarkPrefix: "alice/"}, &jj.Service{})
+	if cmd == nil || status != "Creating bookmark 'alice/Fix_login' on current commit..." {
+		t.Fatalf("SubmitBookmark() = %v, %q", cmd, status)
+	}
+	if ref, ok := modal.GetTicketBookmarkRefs()["alice/Fix_login"]; !ok || ref.ID != "PROJ-1" {
+		t.Fatalf("ticket refs = %v", modal.GetTicketBookmarkRefs())
+	}
+}
+
+// A bookmark move refreshes its target and the commits the graph shows its local bookmark on
+// (not remote positions of it).
+func TestBookmarkRefreshRevset(t *testing.T) {
+	repo := &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ID: "aaaa1111", Branches: []string{"feat"}},
+		{ID: "bbbb2222", Branches: []string{"feat@origin"}},
+		{ID: "cccc3333", Branches: []string{"other"}},
+	}}}
+	if got := bookmarkRefreshRevset(repo, "feat", "kxqvmtsz"); got != "kxqvmtsz | aaaa1111" {
+		t.Errorf("revset = %q", got)
+	}
+	if got := bookmarkRefreshRevset(nil, "feat", "kxqvmtsz"); got != "kxqvmtsz" {
+		t.Errorf("revset without a graph = %q", got)
+	}
+}
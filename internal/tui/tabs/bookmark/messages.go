@@ -1,9 +1,12 @@
 package bookmark
 
+import "github.com/madicen/jj-tui/internal/tui/state"
+
 import tea "github.com/charmbracelet/bubbletea"
 
 // BookmarkCreatedMsg indicates bookmark was created/moved.
 type BookmarkCreatedMsg struct {
+	state.RepoResult
 	BookmarkName string
 	CommitID     string
 	WasMoved     bool
@@ -12,6 +15,7 @@ type BookmarkCreatedMsg struct {
 
 // BookmarkDeletedMsg indicates bookmark was deleted.
 type BookmarkDeletedMsg struct {
+	state.RepoResult
 	BookmarkName string
 }
 
@@ -12,7 +12,7 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
-	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/i18n"
 	"github.com/madicen/jj-tui/internal/tui/genmenu"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/state"
@@ -24,18 +24,19 @@ import (
 type Model struct {
 	shown                     bool
 	nameInput                 textinput.Model
-	commitIdx                 int               // Index of commit to create bookmark on
-	existingBookmarks         []string          // List of existing bookmarks
-	selectedBookmarkIdx       int               // Index of selected existing bookmark (-1 for new)
-	fromJira                  bool              // True if creating bookmark from Jira ticket
-	jiraTicketKey             string            // Jira ticket key if creating from Jira
-	jiraTicketTitle           string            // Jira ticket summary if creating from Jira
-	ticketDisplayKey          string            // Short display key (e.g., "$12u" for Codecks)
-	bookmarkNameExists        bool              // True if entered name matches an existing bookmark
-	jiraBookmarkTitles        map[string]string // Maps bookmark names to formatted PR titles ("KEY - Title")
-	ticketBookmarkDisplayKeys map[string]string // Maps bookmark names to ticket short IDs for commit messages
+	commitIdx                 int                  // Index of commit to create bookmark on
+	existingBookmarks         []string             // List of existing bookmarks
+	selectedBookmarkIdx       int                  // Index of selected existing bookmark (-1 for new)
+	fromJira                  bool                 // True if creating bookmark from Jira ticket
+	jiraTicketKey             string               // Jira ticket key if creating from Jira
+	jiraTicketTitle           string               // Jira ticket summary if creating from Jira
+	ticketDisplayKey          string               // Short display key (e.g., "$12u" for Codecks)
+	bookmarkNameExists        bool                 // True if entered name matches an existing bookmark
+	ticketBookmarkRefs        map[string]TicketRef // Maps bookmark names to the ticket they were created from (PR title/body templates)
+	ticketBookmarkDisplayKeys map[string]string    // Maps bookmark names to ticket short IDs for commit messages
 	repository                *internal.Repository
 	nameConflictSources       []string // Branch names + commit branch names (set by main); used for "name exists" check
+	namePrefix                string   // bookmark_prefix namespace added to new names; shown before the input
 	zoneManager               *zone.Manager
 	// contentWidth is the available width inside the wrapping FrameFormModal (set by main on
 	// tea.WindowSizeMsg). The inner "Target:" / "Jira Ticket:" rounded boxes pin their Width to
@@ -412,10 +413,13 @@ func (m *Model) UpdateNameExistsFromInput(sanitize bool) {
 		m.bookmarkNameExists = false
 		return
 	}
-	if sanitize {
-		name = jj.SanitizeBookmarkName(name)
-	}
-	m.bookmarkNameExists = nameExists(name, m.nameConflictSources, m.existingBookmarks)
+	m.bookmarkNameExists = nameExists(finalBookmarkName(name, sanitize, m.namePrefix), m.nameConflictSources, m.existingBookmarks)
+}
+
+// SetNamePrefix sets the namespace added to new bookmark names; the input's prompt shows it.
+func (m *Model) SetNamePrefix(prefix string) {
+	m.namePrefix = prefix
+	m.nameInput.Prompt = "> " + prefix
 }
 
 // nameExists returns true if name is in branchNamesOrCommitBranches or existingBookmarks.
@@ -429,13 +433,21 @@ func nameExists(name string, branchNamesOrCommitBranches, existingBookmarks []st
 	return slices.Contains(existingBookmarks, name)
 }
 
-// JiraBookmarkTitles / TicketBookmarkDisplayKeys (for PR title formatting from bookmarks)
-func (m *Model) GetJiraBookmarkTitles() map[string]string { return m.jiraBookmarkTitles }
-func (m *Model) SetJiraBookmarkTitles(mp map[string]string) {
+// TicketRef is the ticket a bookmark was created from. ID is the provider key used for API
+// calls; Key is the display key when the provider has one (e.g. "$12u" for Codecks), otherwise ID.
+type TicketRef struct {
+	ID    string
+	Key   string
+	Title string
+}
+
+// TicketBookmarkRefs / TicketBookmarkDisplayKeys (for PR title formatting from bookmarks)
+func (m *Model) GetTicketBookmarkRefs() map[string]TicketRef { return m.ticketBookmarkRefs }
+func (m *Model) SetTicketBookmarkRefs(mp map[string]TicketRef) {
 	if mp != nil {
-		m.jiraBookmarkTitles = mp
+		m.ticketBookmarkRefs = mp
 	} else {
-		m.jiraBookmarkTitles = make(map[string]string)
+		m.ticketBookmarkRefs = make(map[string]TicketRef)
 	}
 }
 func (m *Model) GetTicketBookmarkDisplayKeys() map[string]string { return m.ticketBookmarkDisplayKeys }
@@ -588,7 +600,7 @@ func (m Model) renderBookmark() string {
 	}
 	lines = append(lines, mark(m.zoneManager, mouse.ZoneBookmarkName, "  "+m.nameInput.View()))
 	if m.bookmarkNameExists {
-		warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#E3B341")).Bold(true)
+		warningStyle := lipgloss.NewStyle().Foreground(styles.ColorWarning).Bold(true)
 		lines = append(lines, "")
 		lines = append(lines, warningStyle.Render("⚠ A bookmark with this name already exists"))
 		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("  Creating will move the existing bookmark to this commit"))
@@ -603,7 +615,7 @@ func (m Model) renderBookmark() string {
 		submitLabel = "Create (Enter)"
 	}
 	submitButton := mark(m.zoneManager, mouse.ZoneBookmarkSubmit, styles.ButtonStyle.Render(submitLabel))
-	cancelButton := mark(m.zoneManager, mouse.ZoneBookmarkCancel, styles.ButtonStyle.Render("Cancel (Esc)"))
+	cancelButton := mark(m.zoneManager, mouse.ZoneBookmarkCancel, styles.ButtonStyle.Render(i18n.T("action.cancel")))
 	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Left, submitButton, " ", cancelButton))
 	return strings.Join(lines, "\n")
 }
@@ -0,0 +1,81 @@
+package bookmark
+
+import (
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+	ticketdomain "github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// TicketForBookmarkName returns the ticket whose key the bookmark name starts with, ignoring case
+// and any "feature/"-style prefix: "proj-123-fix-thing" for PROJ-123, "12u-polish" for Codecks
+// card $12u, "42-typo" for issue #42. Only tickets in known match, so a name that merely looks
+// like a key ("release-2") isn't associated with anything.
+func TicketForBookmarkName(name string, known []ticketdomain.Ticket) (ticketdomain.Ticket, bool) {
+	base := strings.ToLower(util.LocalBookmarkName(name))
+	if i := strings.LastIndex(base, "/"); i >= 0 {
+		base = base[i+1:]
+	}
+	var best ticketdomain.Ticket
+	bestLen := 0
+	for _, t := range known {
+		key := t.DisplayKey
+		if key == "" {
+			key = t.Key
+		}
+		key = strings.ToLower(strings.TrimLeft(key, "$#"))
+		if key == "" || len(key) <= bestLen || !strings.HasPrefix(base, key) {
+			continue
+		}
+		// The key must end at a separator so PROJ-12 doesn't claim proj-123-….
+		if rest := base[len(key):]; rest != "" && rest[0] != '-' && rest[0] != '_' {
+			continue
+		}
+		best, bestLen = t, len(key)
+	}
+	return best, bestLen > 0
+}
+
+// LinkTicketBookmarks associates the repository's bookmarks whose names encode a ticket key with
+// that ticket, as if they had been created from it: PR titles are prefilled from the ticket and
+// commit descriptions get its key. Bookmarks already linked keep their ticket. Returns how many
+// bookmarks were newly linked.
+func (m *Model) LinkTicketBookmarks(repo *internal.Repository, known []ticketdomain.Ticket) int {
+	if repo == nil || len(known) == 0 {
+		return 0
+	}
+	if m.ticketBookmarkRefs == nil {
+		m.ticketBookmarkRefs = make(map[string]TicketRef)
+	}
+	if m.ticketBookmarkDisplayKeys == nil {
+		m.ticketBookmarkDisplayKeys = make(map[string]string)
+	}
+	linked := 0
+	for _, c := range repo.Graph.Commits {
+		for _, b := range c.Branches {
+			name := util.LocalBookmarkName(b)
+			if _, ok := m.ticketBookmarkRefs[name]; ok {
+				continue
+			}
+			t, ok := TicketForBookmarkName(name, known)
+			if !ok {
+				continue
+			}
+			key := t.DisplayKey
+			if key == "" {
+				key = t.Key
+			}
+			m.ticketBookmarkRefs[name] = TicketRef{ID: t.Key, Key: key, Title: t.Summary}
+			m.ticketBookmarkDisplayKeys[name] = key
+			linked++
+		}
+	}
+	return linked
+}
+
+// TicketForBookmark returns the ticket linked to bookmark name, created from it or named after it.
+func (m *Model) TicketForBookmark(name string) (TicketRef, bool) {
+	ref, ok := m.ticketBookmarkRefs[util.LocalBookmarkName(name)]
+	return ref, ok
+}
@@ -0,0 +1,71 @@
+package bookmark
+
+import (
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tickets"
+)
+
+var knownTickets = []tickets.Ticket{
+	{Key: "PROJ-12", DisplayKey: "PROJ-12", Summary: "Short key"},
+	{Key: "PROJ-123", DisplayKey: "PROJ-123", Summary: "Fix the thing"},
+	{Key: "card-uuid-1", DisplayKey: "$12u", Summary: "Polish menus"},
+	{Key: "#42", DisplayKey: "#42", Summary: "Typo in README"},
+}
+
+func TestTicketForBookmarkName(t *testing.T) {
+	cases := []struct {
+		name string
+		want string // ticket Key, "" for no match
+	}{
+		{"proj-123-fix-thing", "PROJ-123"},
+		{"PROJ-123", "PROJ-123"},
+		{"feature/PROJ-123_fix", "PROJ-123"},
+		{"proj-123-fix@origin", "PROJ-123"},
+		{"proj-12-short", "PROJ-12"},
+		{"proj-1234-other", ""},
+		{"12u-polish-menus", "card-uuid-1"},
+		{"42-typo", "#42"},
+		{"release-2", ""},
+		{"fix-proj-123", ""},
+	}
+	for _, tc := range cases {
+		got, ok := TicketForBookmarkName(tc.name, knownTickets)
+		if tc.want == "" {
+			if ok {
+				t.Errorf("%q: matched %s, want no match", tc.name, got.Key)
+			}
+			continue
+		}
+		if !ok || got.Key != tc.want {
+			t.Errorf("%q: got %q (ok=%v), want %q", tc.name, got.Key, ok, tc.want)
+		}
+	}
+}
+
+func TestLinkTicketBookmarks_KeepsExistingLinks(t *testing.T) {
+	repo := &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ID: "a", Branches: []string{"proj-123-fix", "proj-123-fix@origin"}},
+		{ID: "b", Branches: []string{"12u-polish", "main"}},
+	}}}
+	var m Model
+	m.SetTicketBookmarkRefs(map[string]TicketRef{"12u-polish": {ID: "other", Key: "$99", Title: "Picked by hand"}})
+
+	if n := m.LinkTicketBookmarks(repo, knownTickets); n != 1 {
+		t.Fatalf("linked %d bookmarks, want 1", n)
+	}
+	ref, ok := m.TicketForBookmark("proj-123-fix@origin")
+	if !ok || ref != (TicketRef{ID: "PROJ-123", Key: "PROJ-123", Title: "Fix the thing"}) {
+		t.Errorf("proj-123-fix linked to %+v", ref)
+	}
+	if m.GetTicketBookmarkDisplayKeys()["proj-123-fix"] != "PROJ-123" {
+		t.Error("display key not recorded for commit descriptions")
+	}
+	if ref, _ := m.TicketForBookmark("12u-polish"); ref.Key != "$99" {
+		t.Errorf("existing link replaced: %+v", ref)
+	}
+	if _, ok := m.TicketForBookmark("main"); ok {
+		t.Error("main should not be linked")
+	}
+}
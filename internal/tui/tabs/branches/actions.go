@@ -8,10 +8,12 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/data"
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
+	"github.com/madicen/jj-tui/internal/tui/tabs/confirm"
 	"github.com/madicen/jj-tui/internal/tui/tabs/prs"
 	"github.com/madicen/jj-tui/internal/tui/util"
 )
@@ -42,13 +44,13 @@ func SortBranchList(branches []internal.Branch) {
 }
 
 // LoadBranchesCmd returns a command that lists branches (with sorting) and sends BranchesLoadedMsg.
-func LoadBranchesCmd(jjSvc *jj.Service, branchLimit int) tea.Cmd {
+func LoadBranchesCmd(jjSvc *jj.Service) tea.Cmd {
 	if jjSvc == nil {
 		return nil
 	}
 	svc := jjSvc
 	return func() tea.Msg {
-		branches, err := svc.ListBranches(context.Background(), branchLimit)
+		branches, err := svc.ListBranches(context.Background())
 		if err != nil {
 			return BranchesLoadedMsg{Err: err}
 		}
@@ -196,6 +198,59 @@ func FetchAndTrackBranch(svc *jj.Service, branchName, remote string) tea.Cmd {
 	}
 }
 
+// SyncForkCmd returns a command that updates the trunk bookmark from the fork's upstream and
+// reports how far the working copy's stack is from it (ForkSyncedMsg). When origin is a GitHub fork
+// the merge-upstream API updates it server-side and a fetch brings trunk down; otherwise, or when
+// GitHub can't fast-forward, trunk is fetched from an "upstream" jj remote and pushed to origin.
+func SyncForkCmd(jjSvc *jj.Service, ghSvc *github.Service, trunk string) tea.Cmd {
+	if jjSvc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := ForkSyncedMsg{Trunk: trunk}
+		var ghErr error
+		if ghSvc.Upstream() != nil {
+			msg.MergeType, ghErr = ghSvc.SyncFork(ctx, trunk)
+			if ghErr == nil {
+				msg.Via = "GitHub"
+				if err := jjSvc.FetchFromRemote(ctx, "origin"); err != nil {
+					msg.Err = fmt.Errorf("synced on GitHub but failed to fetch origin: %w", err)
+					return msg
+				}
+			}
+		}
+		if msg.Via == "" {
+			if !jjSvc.HasRemote(ctx, "upstream") {
+				if ghErr != nil {
+					msg.Err = ghErr
+				} else {
+					msg.Err = fmt.Errorf("origin is not a GitHub fork and there is no \"upstream\" remote to sync from")
+				}
+				return msg
+			}
+			if err := jjSvc.SyncTrunkFromRemote(ctx, "upstream", trunk); err != nil {
+				msg.Err = err
+				return msg
+			}
+			msg.Via = "upstream"
+		}
+		msg.Ahead, msg.Behind = jjSvc.StackStats(ctx, trunk)
+		return msg
+	}
+}
+
+// RebaseStackCmd returns a command that rebases the working copy's stack onto trunk.
+func RebaseStackCmd(jjSvc *jj.Service, trunk string) tea.Cmd {
+	if jjSvc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		err := jjSvc.RebaseStackOnto(context.Background(), util.BookmarkNameForRevset(trunk))
+		return BranchActionMsg{Action: "rebase", Branch: trunk, Err: err}
+	}
+}
+
 // LoadBookmarkConflictInfo loads information about a conflicted bookmark.
 func LoadBookmarkConflictInfo(svc *jj.Service, bookmarkName string) tea.Cmd {
 	if svc == nil {
@@ -250,6 +305,16 @@ func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.C
 		return fmt.Sprintf("Fetching and tracking %s...", name), FetchAndTrackBranchCmd(ctx.JJService, name, remote)
 	}
 
+	if r.SyncFork {
+		trunk := ctx.Trunk()
+		return fmt.Sprintf("Syncing %s with upstream...", trunk), SyncForkCmd(ctx.JJService, ctx.GitHubService, trunk)
+	}
+
+	if r.RebaseStack {
+		trunk := ctx.Trunk()
+		return fmt.Sprintf("Rebasing your stack onto %s...", trunk), RebaseStackCmd(ctx.JJService, trunk)
+	}
+
 	if !ctx.SelectedBranchValid() {
 		return "", nil
 	}
@@ -278,12 +343,31 @@ func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.C
 		if !branch.IsLocal {
 			return "Can only delete local bookmarks", nil
 		}
-		return fmt.Sprintf("Deleting bookmark %s...", branch.Name), DeleteBranchBookmarkCmd(ctx.JJService, branch.Name)
+		// Main shows the confirmation (or skips it per confirm_actions) and then runs the delete.
+		return "", confirm.Ask(confirm.Prompt{
+			Action:    "delete_bookmark",
+			Title:     "Delete bookmark",
+			Message:   fmt.Sprintf("Delete bookmark %s? The next push deletes it on the remote too.", branch.Name),
+			Command:   jj.CommandLine(jj.DeleteBookmarkArgs(branch.Name)),
+			Status:    fmt.Sprintf("Deleting bookmark %s...", branch.Name),
+			OnConfirm: DeleteBranchBookmarkCmd(ctx.JJService, branch.Name),
+		})
 	case r.PushBranch:
 		if !branch.IsLocal {
 			return "Can only push local branches", nil
 		}
 		return fmt.Sprintf("Pushing branch %s...", branch.Name), PushBranchCmd(ctx.JJService, branch.Name)
+	case r.PreviewPush:
+		if !branch.IsLocal {
+			return "Can only push local branches", nil
+		}
+		return fmt.Sprintf("Checking what pushing %s would publish...", branch.Name), LoadPushPreviewCmd(ctx.JJService, branch.Name, ctx.Trunk())
+	case r.ExportReviewPacket:
+		req := ReviewPacketRequestedMsg{Bookmark: branch.Name, Gist: r.ReviewGist}
+		if !branch.IsLocal {
+			req.Remote = branch.Remote
+		}
+		return fmt.Sprintf("Exporting review packet for %s...", branch.Name), func() tea.Msg { return req }
 	case r.ResolveBookmarkConflict:
 		if !branch.HasConflict {
 			return "This bookmark is not conflicted", nil
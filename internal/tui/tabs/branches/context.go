@@ -3,6 +3,7 @@ package branches
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/state"
 )
@@ -12,6 +13,8 @@ type ContextProvider interface {
 	GetBranches() []internal.Branch
 	GetSelectedBranch() int
 	GetJJService() *jj.Service
+	GetGitHubService() *github.Service
+	GetDefaultBranch() string
 }
 
 // BuildRequestContextFromApp builds RequestContext from app state and the branches tab model (for UpdateWithApp flow).
@@ -23,6 +26,8 @@ func BuildRequestContextFromApp(app *state.AppState, m *Model) *RequestContext {
 		BranchList:     m.GetBranches(),
 		SelectedBranch: m.GetSelectedBranch(),
 		JJService:      app.JJService,
+		GitHubService:  app.GitHubService,
+		DefaultBranch:  app.DefaultBranch,
 	})
 }
 
@@ -35,13 +40,14 @@ func BuildRequestContextFrom(p ContextProvider) *RequestContext {
 		BranchList:     p.GetBranches(),
 		SelectedBranch: p.GetSelectedBranch(),
 		JJService:      p.GetJJService(),
+		GitHubService:  p.GetGitHubService(),
+		DefaultBranch:  p.GetDefaultBranch(),
 	})
 }
 
 // EnterTabProvider is implemented by main for EnterTab (status + load cmd).
 type EnterTabProvider interface {
 	GetJJService() *jj.Service
-	GetBranchLimit() int
 }
 
 // EnterTab returns status message and load command when navigating to the Branches tab.
@@ -50,7 +56,7 @@ func EnterTab(p EnterTabProvider) (status string, cmd tea.Cmd) {
 	if p == nil || p.GetJJService() == nil {
 		return status, nil
 	}
-	return status, LoadBranchesCmd(p.GetJJService(), p.GetBranchLimit())
+	return status, LoadBranchesCmd(p.GetJJService())
 }
 
 // RequestContext is passed from the main model so the Branches tab can validate
@@ -59,6 +65,8 @@ type RequestContext struct {
 	BranchList     []internal.Branch
 	SelectedBranch int
 	JJService      *jj.Service
+	GitHubService  *github.Service
+	DefaultBranch  string // origin's default branch; empty when unknown
 }
 
 // ContextInput is the data needed to build a RequestContext. Main passes this from its state.
@@ -66,6 +74,8 @@ type ContextInput struct {
 	BranchList     []internal.Branch
 	SelectedBranch int
 	JJService      *jj.Service
+	GitHubService  *github.Service
+	DefaultBranch  string // origin's default branch; empty when unknown
 }
 
 // BuildRequestContext builds RequestContext from input. The Branches tab owns what context it needs.
@@ -77,6 +87,8 @@ func BuildRequestContext(input *ContextInput) *RequestContext {
 		BranchList:     input.BranchList,
 		SelectedBranch: input.SelectedBranch,
 		JJService:      input.JJService,
+		GitHubService:  input.GitHubService,
+		DefaultBranch:  input.DefaultBranch,
 	}
 }
 
@@ -98,3 +110,15 @@ func (c *RequestContext) SelectedBranchData() *internal.Branch {
 	b := c.BranchList[c.SelectedBranch]
 	return &b
 }
+
+// Trunk returns the name of the trunk bookmark: origin's default branch, else the upstream's,
+// else "main".
+func (c *RequestContext) Trunk() string {
+	if c.DefaultBranch != "" {
+		return c.DefaultBranch
+	}
+	if up := c.GitHubService.Upstream(); up != nil && up.DefaultBranch != "" {
+		return up.DefaultBranch
+	}
+	return "main"
+}
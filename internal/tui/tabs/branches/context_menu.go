@@ -41,7 +41,7 @@ func branchContextMenuItems(branch internal.Branch) []branchContextMenuItem {
 
 	if branch.IsLocal {
 		items = append(items,
-			branchContextMenuItem{Label: "Push", Key: "P", Request: Request{PushBranch: true}},
+			branchContextMenuItem{Label: "Push", Key: "P", Request: Request{PreviewPush: true}},
 			branchContextMenuItem{Label: "Delete", Key: "x", Request: Request{DeleteBranchBookmark: true}},
 		)
 		if branch.HasConflict {
@@ -65,6 +65,8 @@ func branchContextMenuItems(branch internal.Branch) []branchContextMenuItem {
 	}
 
 	items = append(items,
+		branchContextMenuItem{Label: "Export Review Packet", Key: "E", Request: Request{ExportReviewPacket: true}},
+		branchContextMenuItem{Label: "Share Review Packet as Gist", Key: "G", Request: Request{ExportReviewPacket: true, ReviewGist: true}},
 		branchContextMenuItem{Label: "Fetch All", Key: "F", Request: Request{FetchAll: true}},
 	)
 
@@ -89,12 +91,12 @@ func (m *Model) renderContextMenu() string {
 		Padding(0, 1)
 
 	itemStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2"))
+		Foreground(styles.ColorText)
 	hoverStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	hoverKeyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#CCCCCC")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	keyStyle := lipgloss.NewStyle().
 		Foreground(styles.ColorMuted)
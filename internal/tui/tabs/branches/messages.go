@@ -3,17 +3,33 @@ package branches
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // BranchActionMsg is sent when a branch action completes (track, untrack, restore, delete, push, fetch).
 type BranchActionMsg struct {
-	Action string // "track", "untrack", "restore", "delete", "push", "fetch"
+	state.RepoResult
+	Action string // "track", "untrack", "restore", "delete", "push", "fetch", "rebase"
 	Branch string
 	Err    error
 }
 
+// ForkSyncedMsg is sent when a "Sync fork" action completes. Via says how origin's trunk was
+// updated ("GitHub" or the name of the jj remote it was fetched from); Ahead and Behind count the
+// working copy's stack against the synced trunk so the tab can offer a rebase.
+type ForkSyncedMsg struct {
+	state.RepoResult
+	Trunk     string
+	Via       string
+	MergeType string // from GitHub's merge-upstream API; empty when synced through a remote
+	Ahead     int
+	Behind    int
+	Err       error
+}
+
 // BookmarkConflictInfoMsg contains info about a conflicted bookmark.
 type BookmarkConflictInfoMsg struct {
+	state.RepoResult
 	BookmarkName  string
 	LocalID       string
 	RemoteID      string
@@ -26,6 +42,7 @@ type BookmarkConflictInfoMsg struct {
 
 // BranchesLoadedMsg is sent when branches have been loaded (or load failed with Err).
 type BranchesLoadedMsg struct {
+	state.RepoResult
 	Branches []internal.Branch
 	Err      error
 }
@@ -43,6 +60,18 @@ type Request struct {
 	// holds the raw user entry ("name" or "name@remote"); no selected branch is required.
 	FetchAndTrack     bool
 	RemoteBranchInput string
+	// SyncFork updates the trunk bookmark (locally and on origin) from the fork's upstream.
+	// RebaseStack rebases the working copy's stack onto trunk; sent after the user accepts the
+	// prompt shown when a sync leaves the stack behind.
+	SyncFork    bool
+	RebaseStack bool
+	// PreviewPush lists the commits a push of the selected branch would publish and asks before
+	// pushing (which then sends PushBranch).
+	PreviewPush bool
+	// ExportReviewPacket writes the selected branch's commits, diff and linked ticket to a
+	// markdown file; ReviewGist also publishes it as a gist.
+	ExportReviewPacket bool
+	ReviewGist         bool
 }
 
 // Cmd returns a tea.Cmd that sends this request.
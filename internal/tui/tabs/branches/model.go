@@ -9,6 +9,8 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	overlay "github.com/madicen/bubble-overlay"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/util"
@@ -35,6 +37,23 @@ type Model struct {
 	// captures all keystrokes; Enter submits a FetchAndTrack request, Esc cancels.
 	addingRemote bool
 	remoteInput  textinput.Model
+
+	// rebaseOffer is set after a fork sync leaves the working copy's stack behind trunk. While
+	// set, y/Enter rebases the stack onto trunk and n/Esc dismisses the prompt.
+	rebaseOffer *rebaseOffer
+
+	// pushPreview lists the commits a push of the selected bookmark would publish. While set,
+	// y/Enter pushes and n/Esc cancels; a protected bookmark instead needs its name typed into
+	// confirmInput before Enter pushes.
+	pushPreview *jj.PushPreview
+	// confirmInput takes the bookmark name typed to confirm pushing a protected bookmark.
+	confirmInput textinput.Model
+}
+
+// rebaseOffer is the inline "rebase your stack onto the synced trunk?" prompt.
+type rebaseOffer struct {
+	Trunk   string
+	Commits int
 }
 
 // NewModel creates a new Branches tab model. zoneManager may be nil (e.g. in tests).
@@ -45,6 +64,10 @@ func NewModel(zoneManager *zone.Manager) Model {
 	remoteInput.CharLimit = 200
 	remoteInput.Width = 40
 
+	confirmInput := textinput.New()
+	confirmInput.CharLimit = 200
+	confirmInput.Width = 40
+
 	return Model{
 		zoneManager:        zoneManager,
 		selectedBranch:     -1,
@@ -52,6 +75,7 @@ func NewModel(zoneManager *zone.Manager) Model {
 		height:             24,
 		longPressItemIndex: -1,
 		remoteInput:        remoteInput,
+		confirmInput:       confirmInput,
 	}
 }
 
@@ -105,7 +129,7 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 		m.UpdateBranches(msg.Branches)
 		statusMsg := ""
 		if !msg.HasError && !msg.InCreateBookmarkView {
-			statusMsg = fmt.Sprintf("Loaded %d branches", len(msg.Branches))
+			statusMsg = i18n.T("status.loaded_branches", len(msg.Branches))
 		}
 		if app != nil {
 			app.StatusMessage = statusMsg
@@ -159,6 +183,43 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 		}
 		return m, ApplyBranchActionEffect{StatusMessage: statusMsg}.Cmd()
 
+	case ForkSyncedMsg:
+		m.rebaseOffer = nil
+		var statusMsg string
+		switch {
+		case msg.Err != nil:
+			statusMsg = fmt.Sprintf("Failed to sync fork: %v", msg.Err)
+		case msg.MergeType == "none":
+			statusMsg = fmt.Sprintf("%s is already up to date with upstream", msg.Trunk)
+		default:
+			statusMsg = fmt.Sprintf("Synced %s with upstream (via %s)", msg.Trunk, msg.Via)
+		}
+		if msg.Err == nil && msg.Ahead > 0 && msg.Behind > 0 {
+			m.rebaseOffer = &rebaseOffer{Trunk: msg.Trunk, Commits: msg.Ahead}
+			statusMsg += fmt.Sprintf(" — your stack is %d behind; rebase it? (y/n)", msg.Behind)
+		}
+		if app != nil {
+			app.StatusMessage = statusMsg
+			return m, nil
+		}
+		return m, ApplyBranchActionEffect{Err: msg.Err, StatusMessage: statusMsg}.Cmd()
+
+	case PushPreviewLoadedMsg:
+		statusMsg := m.applyPushPreview(msg)
+		if app != nil {
+			app.StatusMessage = statusMsg
+			return m, nil
+		}
+		return m, ApplyBranchActionEffect{Err: msg.Err, StatusMessage: statusMsg}.Cmd()
+
+	case ReviewPacketExportedMsg:
+		statusMsg := reviewPacketStatus(msg)
+		if app != nil {
+			app.StatusMessage = statusMsg
+			return m, nil
+		}
+		return m, ApplyBranchActionEffect{Err: msg.Err, StatusMessage: statusMsg}.Cmd()
+
 	case tea.WindowSizeMsg:
 		return m, nil
 	case tea.KeyMsg:
@@ -169,7 +230,7 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 			if statusMsg != "" {
 				app.StatusMessage = statusMsg
 			}
-			if (req.FetchAll || req.FetchAndTrack) && runCmd != nil {
+			if (req.FetchAll || req.FetchAndTrack || req.SyncFork) && runCmd != nil {
 				app.BranchRemoteFetchPending = true
 				app.Loading = true
 			}
@@ -187,7 +248,7 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 			if statusMsg != "" {
 				app.StatusMessage = statusMsg
 			}
-			if (req.FetchAll || req.FetchAndTrack) && runCmd != nil {
+			if (req.FetchAll || req.FetchAndTrack || req.SyncFork) && runCmd != nil {
 				app.BranchRemoteFetchPending = true
 				app.Loading = true
 			}
@@ -241,6 +302,44 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
 		m.contextMenu = nil
 		return m, nil, nil
 	}
+	// The post-sync rebase prompt owns the keyboard until answered.
+	if m.rebaseOffer != nil {
+		switch msg.String() {
+		case "y", "Y", "enter":
+			m.rebaseOffer = nil
+			return m, &Request{RebaseStack: true}, nil
+		case "n", "N", "esc":
+			m.rebaseOffer = nil
+		}
+		return m, nil, nil
+	}
+	// So does the push confirmation.
+	if m.pushPreview != nil && m.pushPreview.Protected {
+		switch msg.String() {
+		case "enter":
+			if !m.confirmsProtectedPush() {
+				return m, nil, nil
+			}
+			m.pushPreview = nil
+			return m, &Request{PushBranch: true}, nil
+		case "esc":
+			m.pushPreview = nil
+			return m, nil, nil
+		}
+		var cmd tea.Cmd
+		m.confirmInput, cmd = m.confirmInput.Update(msg)
+		return m, nil, cmd
+	}
+	if m.pushPreview != nil {
+		switch msg.String() {
+		case "y", "Y", "enter":
+			m.pushPreview = nil
+			return m, &Request{PushBranch: true}, nil
+		case "n", "N", "esc":
+			m.pushPreview = nil
+		}
+		return m, nil, nil
+	}
 	// While the inline track-by-name input is open, it owns the keyboard.
 	if m.addingRemote {
 		switch msg.String() {
@@ -279,13 +378,19 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
 	case "L":
 		return m, &Request{RestoreLocalBranch: true}, nil
 	case "P":
-		return m, &Request{PushBranch: true}, nil
+		return m, &Request{PreviewPush: true}, nil
 	case "F":
 		return m, &Request{FetchAll: true}, nil
+	case "S":
+		return m, &Request{SyncFork: true}, nil
 	case "c":
 		return m, &Request{ResolveBookmarkConflict: true}, nil
 	case "x":
 		return m, &Request{DeleteBranchBookmark: true}, nil
+	case "E":
+		return m, &Request{ExportReviewPacket: true}, nil
+	case "G":
+		return m, &Request{ExportReviewPacket: true, ReviewGist: true}, nil
 	}
 	return m, nil, nil
 }
@@ -330,6 +435,12 @@ func (m Model) handleZoneClick(z *zone.ZoneInfo, event tea.MouseMsg) (Model, *Re
 		return m, nil, nil
 	}
 
+	// Clicking elsewhere cancels the push confirmation.
+	if m.pushPreview != nil {
+		m.pushPreview = nil
+		return m, nil, nil
+	}
+
 	if m.zoneManager == nil || z == nil {
 		return m, nil, nil
 	}
@@ -352,7 +463,7 @@ func (m Model) handleZoneClick(z *zone.ZoneInfo, event tea.MouseMsg) (Model, *Re
 		return m, &Request{DeleteBranchBookmark: true}, nil
 	}
 	if m.zoneManager.Get(mouse.ZoneBranchPush) == z {
-		return m, &Request{PushBranch: true}, nil
+		return m, &Request{PreviewPush: true}, nil
 	}
 	if m.zoneManager.Get(mouse.ZoneBranchTrackRemote) == z {
 		return m.openRemoteInput()
@@ -360,6 +471,9 @@ func (m Model) handleZoneClick(z *zone.ZoneInfo, event tea.MouseMsg) (Model, *Re
 	if m.zoneManager.Get(mouse.ZoneBranchFetch) == z {
 		return m, &Request{FetchAll: true}, nil
 	}
+	if m.zoneManager.Get(mouse.ZoneBranchSyncFork) == z {
+		return m, &Request{SyncFork: true}, nil
+	}
 	if m.zoneManager.Get(mouse.ZoneBranchResolveConflict) == z {
 		return m, &Request{ResolveBookmarkConflict: true}, nil
 	}
@@ -378,6 +492,12 @@ func (m *Model) GetListYOffset() int {
 	return m.listYOffset
 }
 
+// IsCapturingKeys reports whether the context menu, the track-by-name input, the rebase prompt,
+// or the push confirmation owns the keyboard
+func (m *Model) IsCapturingKeys() bool {
+	return m.contextMenu != nil || m.addingRemote || m.rebaseOffer != nil || m.pushPreview != nil
+}
+
 // SetSelectedBranch sets the selected branch index
 func (m *Model) SetSelectedBranch(idx int) {
 	if idx >= 0 && idx < len(m.branchList) {
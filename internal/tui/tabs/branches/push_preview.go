@@ -0,0 +1,157 @@
+package branches
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// PushPreviewLoadedMsg is sent when LoadPushPreviewCmd finishes.
+type PushPreviewLoadedMsg struct {
+	state.RepoResult
+	Bookmark string
+	Preview  *jj.PushPreview
+	Err      error
+}
+
+// LoadPushPreviewCmd computes which commits pushing branchName to origin would publish, and
+// whether the push needs the protected-bookmark confirmation (trunk is the repo's trunk name).
+func LoadPushPreviewCmd(svc *jj.Service, branchName, trunk string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		p, err := svc.PushPreview(context.Background(), branchName, "origin")
+		if p != nil {
+			p.Protected = svc.ProtectedBookmarks.Protects(p.Bookmark, trunk)
+		}
+		return PushPreviewLoadedMsg{Bookmark: branchName, Preview: p, Err: err}
+	}
+}
+
+// applyPushPreview opens the push confirmation for a loaded preview and returns the status line.
+// Nothing is opened when the push would not publish or drop any commits.
+func (m *Model) applyPushPreview(msg PushPreviewLoadedMsg) string {
+	m.pushPreview = nil
+	if msg.Err != nil {
+		return fmt.Sprintf("Failed to preview push of %s: %v", msg.Bookmark, msg.Err)
+	}
+	p := msg.Preview
+	if p == nil || (len(p.Commits) == 0 && p.Replaced == 0) {
+		return fmt.Sprintf("%s is already up to date on origin", msg.Bookmark)
+	}
+	m.pushPreview = p
+	m.confirmInput.SetValue("")
+	m.confirmInput.Blur()
+	if p.Protected {
+		m.confirmInput.Focus()
+	}
+	wip := 0
+	for _, c := range p.Commits {
+		if c.LooksWIP() {
+			wip++
+		}
+	}
+	status := fmt.Sprintf("Pushing %s would publish %s", p.Bookmark, pluralCommits(len(p.Commits), p.Truncated))
+	if wip > 0 {
+		status += fmt.Sprintf(" (%d look unfinished)", wip)
+	}
+	if n := len(p.LargeFiles); n > 0 {
+		status += fmt.Sprintf(" ⚠ %d large or sensitive %s", n, pluralFiles(n))
+	}
+	if p.Protected {
+		return status + fmt.Sprintf(" — %s is protected; type its name to push", p.Bookmark)
+	}
+	return status + " — push? (y/n)"
+}
+
+// confirmsProtectedPush reports whether the typed confirmation matches the protected bookmark.
+func (m Model) confirmsProtectedPush() bool {
+	return strings.TrimSpace(m.confirmInput.Value()) == m.pushPreview.Bookmark
+}
+
+func pluralFiles(n int) string {
+	if n == 1 {
+		return "file"
+	}
+	return "files"
+}
+
+func pluralCommits(n int, more bool) string {
+	s := fmt.Sprintf("%d commit", n)
+	if n != 1 {
+		s += "s"
+	}
+	if more {
+		s = "over " + s
+	}
+	return s
+}
+
+// renderPushPreview renders the inline push confirmation: the commits that will become visible on
+// the remote bookmark, with unfinished-looking ones flagged.
+func (m Model) renderPushPreview() string {
+	p := m.pushPreview
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(0, 1)
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	warn := lipgloss.NewStyle().Foreground(styles.ColorWarning)
+
+	target := fmt.Sprintf("%s@%s", p.Bookmark, p.Remote)
+	title := fmt.Sprintf("Push %s — %s become visible on %s", p.Bookmark, pluralCommits(len(p.Commits), p.Truncated), target)
+	if p.New {
+		title += " (new bookmark)"
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render(title)}
+	for _, c := range p.Commits {
+		summary := c.Summary
+		if summary == "" {
+			summary = "(no description)"
+		}
+		row := fmt.Sprintf("  %s %s", lipgloss.NewStyle().Foreground(styles.ColorSecondary).Render(c.ChangeID), summary)
+		if c.LooksWIP() {
+			var why []string
+			if c.Empty {
+				why = append(why, "empty")
+			}
+			if c.Conflict {
+				why = append(why, "conflict")
+			}
+			flag := "⚠ WIP?"
+			if len(why) > 0 {
+				flag = "⚠ " + strings.Join(why, ", ")
+			}
+			row += " " + warn.Render(flag)
+		}
+		lines = append(lines, row)
+	}
+	if p.Truncated {
+		lines = append(lines, muted.Render("  … and more"))
+	}
+	if p.Replaced > 0 {
+		lines = append(lines, warn.Render(fmt.Sprintf("⚠ %s on %s will be dropped (force push)", pluralCommits(p.Replaced, false), target)))
+	}
+	if len(p.LargeFiles) > 0 {
+		lines = append(lines, warn.Render(fmt.Sprintf("⚠ %d large or sensitive %s would be published:", len(p.LargeFiles), pluralFiles(len(p.LargeFiles)))))
+		for _, f := range p.LargeFiles {
+			lines = append(lines, fmt.Sprintf("  %s %s %s", f.Path, muted.Render("("+f.Reason()+")"),
+				lipgloss.NewStyle().Foreground(styles.ColorSecondary).Render(f.ChangeID)))
+		}
+	}
+	if p.Protected {
+		lines = append(lines, warn.Render(fmt.Sprintf("⚠ %s is a protected bookmark. Type %s to confirm:", p.Bookmark, p.Bookmark)),
+			"  "+m.confirmInput.View(),
+			muted.Render("Enter to push · Esc to cancel"))
+		return box.Render(strings.Join(lines, "\n"))
+	}
+	lines = append(lines, muted.Render("y/Enter to push · n/Esc to cancel"))
+	return box.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,207 @@
+package branches
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// ReviewPacketRequestedMsg asks main to export a review packet for a branch. Main knows which
+// ticket the bookmark is linked to; it fills that in and runs ExportReviewPacketCmd.
+type ReviewPacketRequestedMsg struct {
+	Bookmark string
+	Remote   string // empty for a local bookmark
+	Gist     bool
+}
+
+// ReviewPacketInput is what ExportReviewPacketCmd exports.
+type ReviewPacketInput struct {
+	Bookmark string
+	Remote   string
+	// Gist also publishes the packet as a secret gist with the gh CLI.
+	Gist bool
+	// Ticket is the linked ticket when it is already loaded; otherwise TicketKey (when known) is
+	// fetched from TicketService.
+	Ticket        *tickets.Ticket
+	TicketKey     string
+	TicketService tickets.Service
+}
+
+// ReviewPacketExportedMsg is sent when ExportReviewPacketCmd finishes. URL is the gist's, when
+// one was requested and created.
+type ReviewPacketExportedMsg struct {
+	state.RepoResult
+	Bookmark string
+	Path     string
+	URL      string
+	Err      error
+}
+
+// ReviewPacketDir returns the directory review packets are written to:
+// $XDG_CACHE_HOME/jj-tui/review (or the platform cache dir), falling back to the temp dir. They
+// are kept out of the repository so jj doesn't snapshot them into the working copy.
+func ReviewPacketDir() string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "jj-tui", "review")
+}
+
+// ExportReviewPacketCmd writes the bookmark's commits, combined diff and linked ticket to a
+// markdown file for reviewers who don't use the forge's UI, and optionally publishes it as a gist.
+func ExportReviewPacketCmd(svc *jj.Service, in ReviewPacketInput) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := ReviewPacketExportedMsg{Bookmark: in.Bookmark}
+		p, err := svc.ReviewPacket(ctx, in.Bookmark, in.Remote)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		msg.Bookmark = p.Bookmark
+		t := in.Ticket
+		if t == nil && in.TicketKey != "" && in.TicketService != nil {
+			// A missing ticket only leaves its section out of the packet.
+			t, _ = in.TicketService.GetTicket(ctx, in.TicketKey)
+		}
+		var ticketURL string
+		if t != nil && in.TicketService != nil {
+			ticketURL = in.TicketService.GetTicketURL(*t)
+		}
+
+		dir := ReviewPacketDir()
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			msg.Err = err
+			return msg
+		}
+		name := fmt.Sprintf("%s-%s.md", reviewPacketFileName(p.Bookmark), time.Now().Format("20060102-150405"))
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(RenderReviewPacket(p, t, ticketURL)), 0o644); err != nil {
+			msg.Err = err
+			return msg
+		}
+		msg.Path = path
+		if in.Gist {
+			msg.URL, msg.Err = createGist(msg.Path, "Review packet for "+p.Bookmark)
+		}
+		return msg
+	}
+}
+
+// RenderReviewPacket formats a review packet as markdown: the linked ticket (when t is non-nil),
+// each commit's full message, and the combined diff.
+func RenderReviewPacket(p *jj.ReviewPacket, t *tickets.Ticket, ticketURL string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Review: %s\n\n", p.Bookmark)
+	fmt.Fprintf(&b, "%s on top of trunk.\n", pluralCommits(len(p.Commits), false))
+
+	if t != nil {
+		key := t.DisplayKey
+		if key == "" {
+			key = t.Key
+		}
+		if ticketURL != "" {
+			key = fmt.Sprintf("[%s](%s)", key, ticketURL)
+		}
+		fmt.Fprintf(&b, "\n## Ticket\n\n**%s**: %s", key, t.Summary)
+		if t.Status != "" {
+			fmt.Fprintf(&b, " (%s)", t.Status)
+		}
+		b.WriteString("\n")
+		if desc := t.MarkdownDescription(); desc != "" {
+			fmt.Fprintf(&b, "\n%s\n", desc)
+		}
+	}
+
+	b.WriteString("\n## Commits\n")
+	for _, c := range p.Commits {
+		title, body, _ := strings.Cut(c.Description, "\n")
+		if strings.TrimSpace(title) == "" {
+			title = "(no description)"
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n`%s` `%s` by %s on %s\n", title, c.ChangeID, c.CommitID, c.Author, c.Date)
+		if body = strings.TrimSpace(body); body != "" {
+			fmt.Fprintf(&b, "\n%s\n", body)
+		}
+	}
+
+	b.WriteString("\n## Diff\n\n")
+	diff := strings.TrimRight(p.Diff, "\n")
+	if diff == "" {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+	fence := codeFence(diff)
+	fmt.Fprintf(&b, "%sdiff\n%s\n%s\n", fence, diff, fence)
+	return b.String()
+}
+
+// codeFence returns a backtick fence longer than any backtick run in s, so a diff of markdown
+// files can't close the block early.
+func codeFence(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// reviewPacketFileName turns a bookmark name ("alice/fix@origin") into a file name stem.
+func reviewPacketFileName(bookmark string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '-'
+	}, bookmark)
+}
+
+// createGist publishes path as a secret gist with `gh gist create` and returns its URL.
+func createGist(path, desc string) (string, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return "", fmt.Errorf("gh CLI not found in PATH (install gh to share review packets as gists): %w", err)
+	}
+	out, err := exec.Command("gh", "gist", "create", "--desc", desc, path).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("gh gist create failed: %s", strings.TrimSpace(string(out)))
+	}
+	// gh reports progress first and prints the URL last.
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 || !strings.HasPrefix(fields[len(fields)-1], "https://") {
+		return "", fmt.Errorf("gh gist create printed no URL: %s", strings.TrimSpace(string(out)))
+	}
+	return fields[len(fields)-1], nil
+}
+
+// reviewPacketStatus is the status line for a finished export.
+func reviewPacketStatus(msg ReviewPacketExportedMsg) string {
+	switch {
+	case msg.Err != nil && msg.Path != "":
+		return fmt.Sprintf("Wrote review packet for %s to %s, but sharing it failed: %v", msg.Bookmark, msg.Path, msg.Err)
+	case msg.Err != nil:
+		return fmt.Sprintf("Failed to export review packet for %s: %v", msg.Bookmark, msg.Err)
+	case msg.URL != "":
+		return fmt.Sprintf("Shared review packet for %s: %s", msg.Bookmark, msg.URL)
+	default:
+		return fmt.Sprintf("Wrote review packet for %s to %s", msg.Bookmark, msg.Path)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/keymap"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/styles"
 )
@@ -39,6 +41,18 @@ func (m Model) renderAddRemoteInput() string {
 	return box.Render(strings.Join([]string{label, m.remoteInput.View(), hint}, "\n"))
 }
 
+// renderRebaseOffer renders the inline prompt shown when a fork sync left the stack behind trunk.
+func (m Model) renderRebaseOffer() string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(0, 1)
+	label := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).
+		Render(fmt.Sprintf("Rebase your stack (%d commits) onto %s?", m.rebaseOffer.Commits, m.rebaseOffer.Trunk))
+	hint := lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("y/Enter to rebase · n/Esc to keep it where it is")
+	return box.Render(strings.Join([]string{label, hint}, "\n"))
+}
+
 func (m Model) renderBranches() string {
 	if len(m.branchList) == 0 {
 		content := []string{
@@ -48,11 +62,18 @@ func (m Model) renderBranches() string {
 		if m.addingRemote {
 			content = append(content, m.renderAddRemoteInput(), "")
 		}
+		if m.rebaseOffer != nil {
+			content = append(content, m.renderRebaseOffer(), "")
+		}
+		if m.pushPreview != nil {
+			content = append(content, m.renderPushPreview(), "")
+		}
 		content = append(content,
 			"No branches found.",
 			"",
 			"Press 't' to pull and track a remote branch by name.",
 			"Press 'F' to fetch from all remotes.",
+			"Press 'S' to sync a fork's trunk with upstream.",
 		)
 		return strings.Join(content, "\n")
 	}
@@ -61,6 +82,12 @@ func (m Model) renderBranches() string {
 	if m.addingRemote {
 		headerLines = append(headerLines, m.renderAddRemoteInput())
 	}
+	if m.rebaseOffer != nil {
+		headerLines = append(headerLines, m.renderRebaseOffer())
+	}
+	if m.pushPreview != nil {
+		headerLines = append(headerLines, m.renderPushPreview())
+	}
 
 	if m.selectedBranch >= 0 && m.selectedBranch < len(m.branchList) {
 		branch := m.branchList[m.selectedBranch]
@@ -68,15 +95,15 @@ func (m Model) renderBranches() string {
 		var detailLines []string
 		var typeLabel string
 		if branch.IsLocal {
-			typeLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Render("[local]")
+			typeLabel = lipgloss.NewStyle().Foreground(styles.ColorPositive).Render("[local]")
 		} else if branch.IsTracked {
 			if branch.LocalDeleted {
-				typeLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C")).Render("[tracked, local deleted]")
+				typeLabel = lipgloss.NewStyle().Foreground(styles.ColorWarning).Render("[tracked, local deleted]")
 			} else {
-				typeLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("#8BE9FD")).Render("[tracked]")
+				typeLabel = lipgloss.NewStyle().Foreground(styles.ColorAccent).Render("[tracked]")
 			}
 		} else {
-			typeLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("#6272A4")).Render("[remote]")
+			typeLabel = lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("[remote]")
 		}
 		detailLines = append(detailLines, fmt.Sprintf("%s %s",
 			lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render(branch.Name),
@@ -98,44 +125,45 @@ func (m Model) renderBranches() string {
 			Render(strings.Join(detailLines, "\n"))
 		headerLines = append(headerLines, detailsBox)
 
-		separatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#444444"))
+		separatorStyle := lipgloss.NewStyle().Foreground(styles.ColorSeparator)
 		separatorWidth := m.width - 4
 		if separatorWidth < 20 {
 			separatorWidth = 80
 		}
 		separator := separatorStyle.Render(strings.Repeat("─", separatorWidth))
 		headerLines = append(headerLines, separator)
-		headerLines = append(headerLines, "Actions:")
+		headerLines = append(headerLines, i18n.T("label.actions"))
 
 		var actionButtons []string
 		if branch.IsLocal {
 			actionButtons = append(actionButtons,
-				mark(m.zoneManager, mouse.ZoneBranchPush, styles.ButtonStyle.Render("Push (P)")),
-				mark(m.zoneManager, mouse.ZoneBranchDelete, styles.ButtonStyle.Render("Delete (x)")),
+				mark(m.zoneManager, mouse.ZoneBranchPush, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.push"), "branch.push"))),
+				mark(m.zoneManager, mouse.ZoneBranchDelete, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.delete"), "branch.delete"))),
 			)
 			if branch.HasConflict {
-				conflictBtnStyle := styles.ButtonStyle.Background(lipgloss.Color("#FF5555"))
+				conflictBtnStyle := styles.ButtonStyle.Background(styles.ColorNegative)
 				actionButtons = append(actionButtons,
 					mark(m.zoneManager, mouse.ZoneBranchResolveConflict, conflictBtnStyle.Render("Resolve Conflict (c)")),
 				)
 			}
 		} else if branch.IsTracked {
 			actionButtons = append(actionButtons,
-				mark(m.zoneManager, mouse.ZoneBranchUntrack, styles.ButtonStyle.Render("Untrack (U)")),
+				mark(m.zoneManager, mouse.ZoneBranchUntrack, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.untrack"), "branch.untrack"))),
 			)
 			if branch.LocalDeleted {
 				actionButtons = append(actionButtons,
-					mark(m.zoneManager, mouse.ZoneBranchRestore, styles.ButtonStyle.Render("Restore Local (L)")),
+					mark(m.zoneManager, mouse.ZoneBranchRestore, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.restore_local"), "branch.restore"))),
 				)
 			}
 		} else {
 			actionButtons = append(actionButtons,
-				mark(m.zoneManager, mouse.ZoneBranchTrack, styles.ButtonStyle.Render("Track (T)")),
+				mark(m.zoneManager, mouse.ZoneBranchTrack, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.track"), "branch.track"))),
 			)
 		}
 		actionButtons = append(actionButtons,
-			mark(m.zoneManager, mouse.ZoneBranchTrackRemote, styles.ButtonStyle.Render("Track by name (t)")),
-			mark(m.zoneManager, mouse.ZoneBranchFetch, styles.ButtonStyle.Render("Fetch All (F)")),
+			mark(m.zoneManager, mouse.ZoneBranchTrackRemote, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.track_by_name"), "branch.track_by_name"))),
+			mark(m.zoneManager, mouse.ZoneBranchFetch, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.fetch_all"), "branch.fetch"))),
+			mark(m.zoneManager, mouse.ZoneBranchSyncFork, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.sync_fork"), "branch.sync_fork"))),
 		)
 		headerLines = append(headerLines, strings.Join(actionButtons, " "))
 		headerLines = append(headerLines, separator)
@@ -186,13 +214,13 @@ func (m Model) renderBranchGraph() string {
 	if len(m.branchList) == 0 {
 		return ""
 	}
-	trunkStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#8BE9FD"))
-	localStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B"))
-	trackedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#8BE9FD"))
-	remoteStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6272A4"))
-	aheadStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B"))
-	behindStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C"))
-	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FF79C6"))
+	trunkStyle := lipgloss.NewStyle().Foreground(styles.ColorAccent)
+	localStyle := lipgloss.NewStyle().Foreground(styles.ColorPositive)
+	trackedStyle := lipgloss.NewStyle().Foreground(styles.ColorAccent)
+	remoteStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	aheadStyle := lipgloss.NewStyle().Foreground(styles.ColorPositive)
+	behindStyle := lipgloss.NewStyle().Foreground(styles.ColorWarning)
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorSpecial)
 	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
 
 	var localBranches, remoteBranches []internal.Branch
@@ -214,10 +242,10 @@ func (m Model) renderBranchGraph() string {
 	buildStatus := func(branch internal.Branch) string {
 		var statusParts []string
 		if branch.Ahead > 0 {
-			statusParts = append(statusParts, aheadStyle.Render(fmt.Sprintf("+%d", branch.Ahead)))
+			statusParts = append(statusParts, aheadStyle.Render(fmt.Sprintf("%s%d ahead", styles.GlyphAhead, branch.Ahead)))
 		}
 		if branch.Behind > 0 {
-			statusParts = append(statusParts, behindStyle.Render(fmt.Sprintf("-%d behind", branch.Behind)))
+			statusParts = append(statusParts, behindStyle.Render(fmt.Sprintf("%s%d behind", styles.GlyphBehind, branch.Behind)))
 		}
 		if len(statusParts) > 0 {
 			line := " (" + strings.Join(statusParts, ", ") + ")"
@@ -304,7 +332,7 @@ func (m Model) renderGraphBranch(branch internal.Branch, idx int, isSelected, is
 	}
 	conflictIndicator := ""
 	if branch.HasConflict {
-		conflictIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Render(" ⚠ diverged")
+		conflictIndicator = lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(" " + styles.GlyphConflict + " diverged")
 	}
 	branchLine := fmt.Sprintf("    %s─%s %s%s%s",
 		trunkStyle.Render(connector),
@@ -0,0 +1,30 @@
+package confirm
+
+import "github.com/madicen/jj-tui/internal/tui/state"
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// AskMsg asks main to confirm Prompt before running its OnConfirm command. Main skips the modal
+// and runs the command straight away when the prompt's action is turned off in confirm_actions.
+type AskMsg struct {
+	state.RepoResult
+	Prompt Prompt
+}
+
+// Ask returns a command that sends AskMsg for p.
+func Ask(p Prompt) tea.Cmd {
+	return func() tea.Msg { return AskMsg{Prompt: p} }
+}
+
+// ConfirmedMsg is sent when the user accepts the prompt (modal already hid itself). Main sets
+// the prompt's status, saves DontAskAgain to config, and runs OnConfirm.
+type ConfirmedMsg struct {
+	Prompt       Prompt
+	DontAskAgain bool
+}
+
+// CancelledMsg is sent when the user declines the prompt; main sets status to "Cancelled" and
+// runs the prompt's OnCancel.
+type CancelledMsg struct {
+	Prompt Prompt
+}
@@ -0,0 +1,172 @@
+package confirm
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// Prompt describes a destructive action (or, in preview mode, any mutating jj command) waiting
+// for the user's go-ahead.
+type Prompt struct {
+	// Action is the confirm_actions name ("abandon", "squash", …) that "don't ask again" turns off.
+	// Without one the box is not shown.
+	Action string
+	// Title goes in the window tab, Message says what will be lost, and Command is the jj command
+	// line that will run. Revisions lists the revisions it names, one per line.
+	Title     string
+	Message   string
+	Command   string
+	Revisions []string
+	// Status is shown when the action starts ("" leaves the status to OnConfirm's caller).
+	Status    string
+	OnConfirm tea.Cmd
+	// OnCancel, when set, runs after the user declines.
+	OnCancel tea.Cmd
+}
+
+// Model is the confirmation modal shown before destructive actions.
+type Model struct {
+	prompt      *Prompt
+	dontAsk     bool
+	zoneManager *zone.Manager // set by main (zones may be in main's view)
+}
+
+// NewModel creates a new confirmation modal.
+func NewModel() Model {
+	return Model{}
+}
+
+// Show opens the modal for p.
+func (m *Model) Show(p Prompt) {
+	m.prompt = &p
+	m.dontAsk = false
+}
+
+// Hide closes the modal without answering it.
+func (m *Model) Hide() {
+	m.prompt = nil
+	m.dontAsk = false
+}
+
+// IsShown returns whether the modal is displayed.
+func (m *Model) IsShown() bool {
+	return m.prompt != nil
+}
+
+// GetTitle returns the open prompt's title ("" when not shown), used for the chromed window tab.
+func (m *Model) GetTitle() string {
+	if m.prompt == nil {
+		return ""
+	}
+	return m.prompt.Title
+}
+
+// SetZoneManager sets the zone manager used to resolve clicks (main's manager).
+func (m *Model) SetZoneManager(zm *zone.Manager) {
+	m.zoneManager = zm
+}
+
+// Update handles keys and clicks while the modal is shown.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.prompt == nil {
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "Y", "enter":
+			return m.answer(true)
+		case "n", "N", "esc":
+			return m.answer(false)
+		case "a", " ":
+			m.dontAsk = !m.dontAsk && m.prompt.Action != ""
+		case "ctrl+q", "ctrl+c":
+			util.FlushMouse()
+			return m, tea.Quit
+		}
+	case zone.MsgZoneInBounds:
+		switch m.resolveClickedZone(msg) {
+		case mouse.ZoneConfirmYes:
+			return m.answer(true)
+		case mouse.ZoneConfirmNo:
+			return m.answer(false)
+		case mouse.ZoneConfirmDontAsk:
+			m.dontAsk = !m.dontAsk && m.prompt.Action != ""
+		}
+	}
+	return m, nil
+}
+
+// answer closes the modal and reports the user's choice to main.
+func (m Model) answer(ok bool) (Model, tea.Cmd) {
+	p, dontAsk := *m.prompt, m.dontAsk
+	m.Hide()
+	if !ok {
+		return m, func() tea.Msg { return CancelledMsg{Prompt: p} }
+	}
+	return m, func() tea.Msg { return ConfirmedMsg{Prompt: p, DontAskAgain: dontAsk} }
+}
+
+func (m Model) resolveClickedZone(msg zone.MsgZoneInBounds) string {
+	if msg.Zone == nil || m.zoneManager == nil {
+		return ""
+	}
+	for _, id := range []string{mouse.ZoneConfirmYes, mouse.ZoneConfirmNo, mouse.ZoneConfirmDontAsk} {
+		if z := m.zoneManager.Get(id); z != nil && z.InBounds(msg.Event) {
+			return id
+		}
+	}
+	return ""
+}
+
+// View renders the modal: the message, the jj command that will run, the "don't ask again" box
+// and the buttons. The title lives in the chrome tab (see chromedSlot in main).
+func (m Model) View() string {
+	if m.prompt == nil {
+		return ""
+	}
+	p := m.prompt
+	muted := lipgloss.NewStyle().Foreground(styles.ColorSubtle)
+	lines := []string{lipgloss.NewStyle().Foreground(styles.ColorText).Width(66).Render(p.Message)}
+	if p.Command != "" {
+		lines = append(lines, "", muted.Render("Runs:"), lipgloss.NewStyle().Foreground(styles.ColorSecondary).Render("  $ "+p.Command))
+	}
+	if len(p.Revisions) > 0 {
+		lines = append(lines, "", muted.Render("Revisions:"))
+		for _, r := range p.Revisions {
+			lines = append(lines, "  "+r)
+		}
+	}
+	if p.Action != "" {
+		box := "[ ]"
+		if m.dontAsk {
+			box = "[x]"
+		}
+		lines = append(lines, "", m.mark(mouse.ZoneConfirmDontAsk, muted.Render(box+" Don't ask again (a)")))
+	}
+	buttons := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.mark(mouse.ZoneConfirmYes, styles.ButtonDangerStyle.Render("Confirm (y)")),
+		"  ",
+		m.mark(mouse.ZoneConfirmNo, styles.ButtonSecondaryStyle.Render("Cancel (n)")))
+	lines = append(lines, "", buttons)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorDanger).
+		Padding(1, 2).
+		Width(70).
+		Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) mark(id, s string) string {
+	if m.zoneManager == nil {
+		return s
+	}
+	return m.zoneManager.Mark(id, s)
+}
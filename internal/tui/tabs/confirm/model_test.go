@@ -0,0 +1,42 @@
+package confirm
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The modal shows the command that will run; "a" ticks "don't ask again" and "y" reports it
+// along with the prompt.
+func TestModel_Confirm(t *testing.T) {
+	m := NewModel()
+	m.Show(Prompt{Action: "abandon", Title: "Abandon commit", Message: "Abandon kxqv?", Command: "jj abandon kxqv"})
+	if view := m.View(); !strings.Contains(view, "$ jj abandon kxqv") || !strings.Contains(view, "[ ] Don't ask again") {
+		t.Fatalf("view:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !strings.Contains(m.View(), "[x] Don't ask again") {
+		t.Fatal("a should tick don't ask again")
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if m.IsShown() || cmd == nil {
+		t.Fatal("y should close the modal and confirm")
+	}
+	got, ok := cmd().(ConfirmedMsg)
+	if !ok || got.Prompt.Action != "abandon" || !got.DontAskAgain {
+		t.Fatalf("msg = %#v", cmd())
+	}
+}
+
+func TestModel_Cancel(t *testing.T) {
+	m := NewModel()
+	m.Show(Prompt{Action: "squash", Title: "Squash"})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsShown() || cmd == nil {
+		t.Fatal("esc should close the modal")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Fatalf("msg = %#v", cmd())
+	}
+}
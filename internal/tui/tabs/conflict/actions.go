@@ -63,8 +63,7 @@ func HandleBookmarkConflictInfoMsg(msg branches.BookmarkConflictInfoMsg, app *st
 
 // HandleBookmarkConflictResolvedMsg mutates app StatusMessage and returns the Cmd to run.
 // Main sets ViewMode to the tab the user was on when opening the dialog.
-// branchLimit is used for LoadBranchesCmd (e.g. from settings).
-func HandleBookmarkConflictResolvedMsg(msg BookmarkConflictResolvedMsg, app *state.AppState, branchLimit int) tea.Cmd {
+func HandleBookmarkConflictResolvedMsg(msg BookmarkConflictResolvedMsg, app *state.AppState) tea.Cmd {
 	if msg.Err != nil {
 		app.StatusMessage = fmt.Sprintf("Error resolving conflict: %v", msg.Err)
 		return nil
@@ -77,6 +76,6 @@ func HandleBookmarkConflictResolvedMsg(msg BookmarkConflictResolvedMsg, app *sta
 	// Sequence so graph reload applies before branch list (trunk view uses branchList, not repo alone).
 	return tea.Sequence(
 		data.LoadRepository(app.JJService),
-		branches.LoadBranchesCmd(app.JJService, branchLimit),
+		branches.LoadBranchesCmd(app.JJService),
 	)
 }
@@ -1,5 +1,7 @@
 package conflict
 
+import "github.com/madicen/jj-tui/internal/tui/state"
+
 import tea "github.com/charmbracelet/bubbletea"
 
 // PerformCancelMsg is sent when the user cancels the conflict dialog (esc); main sets view and status.
@@ -23,6 +25,7 @@ func PerformResolveCmd(bookmarkName, resolution string) tea.Cmd {
 
 // BookmarkConflictResolvedMsg is sent when a bookmark conflict has been resolved (keep local or reset to remote).
 type BookmarkConflictResolvedMsg struct {
+	state.RepoResult
 	BookmarkName string
 	Resolution   string // "keep_local" or "reset_remote"
 	Err          error
@@ -36,11 +36,12 @@ func SaveDescriptionCmd(svc *jj.Service, commitID, description string) tea.Cmd {
 	}
 }
 
-// HandleDescriptionSavedMsg mutates app (ViewMode, StatusMessage) and returns the Cmd to run.
+// HandleDescriptionSavedMsg mutates app (ViewMode, StatusMessage) and returns the Cmd to run:
+// a refresh of the described commit and its descendants only.
 func HandleDescriptionSavedMsg(msg DescriptionSavedMsg, app *state.AppState) tea.Cmd {
 	app.ViewMode = state.ViewCommitGraph
 	app.StatusMessage = fmt.Sprintf("Description updated for %s", msg.CommitID)
-	return data.LoadRepository(app.JJService)
+	return data.RefreshCommitsCmd(app.JJService, app.Repository, jj.DescribedRevset(msg.CommitID))
 }
 
 // DescriptionLoadedInput is the context main sends when forwarding DescriptionLoadedMsg (for building suggested description).
@@ -1,15 +1,19 @@
 package descedit
 
+import "github.com/madicen/jj-tui/internal/tui/state"
+
 import tea "github.com/charmbracelet/bubbletea"
 
 // DescriptionLoadedMsg contains loaded description.
 type DescriptionLoadedMsg struct {
+	state.RepoResult
 	CommitID    string
 	Description string
 }
 
 // DescriptionSavedMsg indicates description was saved.
 type DescriptionSavedMsg struct {
+	state.RepoResult
 	CommitID string
 }
 
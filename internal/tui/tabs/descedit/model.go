@@ -9,6 +9,7 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/i18n"
 	"github.com/madicen/jj-tui/internal/tui/genmenu"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/state"
@@ -235,9 +236,9 @@ func (m Model) View() string {
 	commitLine := styles.SpreadRow(contentW, subtitleStyle.Render(fmt.Sprintf("Commit: %s", commitInfo)), genChip)
 	actionButtons := lipgloss.JoinHorizontal(
 		lipgloss.Left,
-		mark(mouse.ZoneDescSave, styles.ButtonStyle.Render("Save (Ctrl+S)")),
-		mark(mouse.ZoneDescClear, styles.ButtonStyle.Render("Clear (Ctrl+Shift+U)")),
-		mark(mouse.ZoneDescCancel, styles.ButtonStyle.Render("Cancel (Esc)")),
+		mark(mouse.ZoneDescSave, styles.ButtonStyle.Render(i18n.T("action.save"))),
+		mark(mouse.ZoneDescClear, styles.ButtonStyle.Render(i18n.T("action.clear"))),
+		mark(mouse.ZoneDescCancel, styles.ButtonStyle.Render(i18n.T("action.cancel"))),
 	)
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -1,5 +1,7 @@
 package divergent
 
+import "github.com/madicen/jj-tui/internal/tui/state"
+
 import tea "github.com/charmbracelet/bubbletea"
 
 // PerformCancelMsg is sent when the user cancels the divergent dialog (esc); main sets view and status.
@@ -23,6 +25,7 @@ func PerformResolveCmd(changeID, keepCommitID string) tea.Cmd {
 
 // DivergentCommitResolvedMsg is sent when a divergent commit has been resolved (kept one version).
 type DivergentCommitResolvedMsg struct {
+	state.RepoResult
 	ChangeID     string
 	KeptCommitID string
 	Err          error
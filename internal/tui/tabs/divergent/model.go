@@ -116,7 +116,7 @@ func (m *Model) mark(id, content string) string {
 // body opens directly with the action hint.
 func (m *Model) renderDivergent() string {
 	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
-	cidStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#8BE9FD"))
+	cidStyle := lipgloss.NewStyle().Foreground(styles.ColorAccent)
 	normalBorder := styles.ColorMuted
 
 	modalW := min(max(48, m.termW-8), 78)
@@ -75,6 +75,8 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 		return m, state.NavigateTarget{Kind: state.NavigateDismissError, StatusMessage: "Error dismissed"}.Cmd()
 	case "c":
 		return m, RequestCopyCmd()
+	case "v":
+		return m, state.NavigateTarget{Kind: state.NavigateOpenPager, PagerTitle: "Error", PagerContent: m.err.Error()}.Cmd()
 	}
 	return m, nil
 }
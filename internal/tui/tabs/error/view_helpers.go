@@ -6,7 +6,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal/i18n"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/styles"
 	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
@@ -40,15 +42,15 @@ func renderModal(zm *zone.Manager, width, height int, errStr string, copied, has
 	modalWidth := min(max(width-8, 50), 80)
 
 	errorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFFFFF")).
+		Foreground(styles.ColorText).
 		Width(modalWidth - 4)
 
 	mutedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#8B949E"))
+		Foreground(styles.ColorSubtle)
 
 	buttonStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#30363d")).
+		Foreground(styles.ColorText).
+		Background(styles.ColorPanel).
 		Padding(0, 1).
 		Bold(true)
 
@@ -70,7 +72,7 @@ func renderModal(zm *zone.Manager, width, height int, errStr string, copied, has
 			avail = 1
 		}
 		bodyLines = bodyLines[:min(avail, len(bodyLines))]
-		bodyLines = append(bodyLines, mutedStyle.Render("… truncated — press c to copy the full message"))
+		bodyLines = append(bodyLines, mutedStyle.Render(i18n.T("modal.error.truncated")))
 	}
 	errBody := strings.Join(bodyLines, "\n")
 
@@ -89,23 +91,23 @@ func renderModal(zm *zone.Manager, width, height int, errStr string, copied, has
 		return s
 	}
 
-	dismissBtn := mark(mouse.ZoneActionDismissError, buttonStyle.Render("Dismiss (Esc)"))
+	dismissBtn := mark(mouse.ZoneActionDismissError, buttonStyle.Render(i18n.T("modal.error.dismiss")))
 
 	var copyBtn string
 	if copied {
 		copiedStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#2ea44f")).
+			Foreground(styles.ColorSuccess).
 			Bold(true)
-		copyBtn = copiedStyle.Render("✓ Copied!")
+		copyBtn = copiedStyle.Render(i18n.T("modal.copied"))
 	} else {
-		copyBtn = mark(mouse.ZoneActionCopyError, buttonStyle.Render("Copy (c)"))
+		copyBtn = mark(mouse.ZoneActionCopyError, buttonStyle.Render(i18n.T("modal.error.copy")))
 	}
 
-	quitBtn := mark(mouse.ZoneActionQuit, buttonStyle.Background(lipgloss.Color("#c9302c")).Render("Quit (^q)"))
+	quitBtn := mark(mouse.ZoneActionQuit, buttonStyle.Foreground(styles.ColorOnAccent).Background(styles.ColorDanger).Render(i18n.T("modal.error.quit")))
 
 	row := dismissBtn + "  " + copyBtn
 	if hasRetry {
-		retryBtn := mark(mouse.ZoneActionRetry, buttonStyle.Render("Retry (^r)"))
+		retryBtn := mark(mouse.ZoneActionRetry, buttonStyle.Render(i18n.T("modal.error.retry")))
 		row += "  " + retryBtn
 	}
 	row += "  " + quitBtn
@@ -113,7 +115,7 @@ func renderModal(zm *zone.Manager, width, height int, errStr string, copied, has
 
 	modalBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#FF5555")).
+		BorderForeground(styles.ColorNegative).
 		Padding(1, 2).
 		Width(modalWidth).
 		Render(content.String())
@@ -10,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
@@ -20,6 +21,7 @@ type EvologOutcomePreviewRequestedMsg struct {
 
 // EvologOutcomePreviewLoadedMsg carries working-copy diff summary lines for the outcome preview overlay.
 type EvologOutcomePreviewLoadedMsg struct {
+	state.RepoResult
 	Seq    int
 	Lines  []string
 	Err    error
@@ -77,6 +79,7 @@ func OverlaySpinCmd() tea.Cmd {
 // EvologSplitDiffLoadedMsg carries jj diff --summary for selected row vs the newer neighbor above it,
 // plus the full git unified diff for the same revision pair (colored in the modal).
 type EvologSplitDiffLoadedMsg struct {
+	state.RepoResult
 	Seq     int
 	Files   []jj.ChangedFile
 	GitDiff string
@@ -111,6 +114,7 @@ func LoadEvologSplitDiffCmd(svc *jj.Service, seq int, fromCommitID, toCommitID,
 
 // EvologLoadedMsg is sent when evolog listing finishes (success or failure).
 type EvologLoadedMsg struct {
+	state.RepoResult
 	Entries       []jj.EvologEntry
 	Err           error
 	ChangeID      string // revision passed to evolog -r
@@ -142,6 +146,7 @@ func LoadEvologCmd(svc *jj.Service, bookmarkName string, tip internal.Commit) te
 
 // EvologSplitCompletedMsg is sent after a successful evolog split + reload.
 type EvologSplitCompletedMsg struct {
+	state.RepoResult
 	Repository *internal.Repository
 }
 
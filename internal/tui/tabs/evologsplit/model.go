@@ -846,7 +846,7 @@ func formatSplitModalFileLine(f jj.ChangedFile, pathMax int) string {
 // buildEvologSplitRightColumn returns exactly vr+1 lines (title + vr body rows) so layout
 // matches the history column; the last body row is always used (overflow count or "—").
 func buildEvologSplitRightColumn(m Model, vr, rightW int, muted lipgloss.Style) []string {
-	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F85149"))
+	errStyle := lipgloss.NewStyle().Foreground(styles.ColorNegative)
 	title := muted.Render("Files vs row above (step →)")
 	if vr < 1 {
 		vr = 1
@@ -1022,9 +1022,9 @@ func (m Model) renderOutcomePreviewOverlay(base string, innerContentW int) strin
 		window = append(window, pad...)
 	}
 
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#8BE9FD"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorAccent)
 	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
-	atSty := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#50FA7B"))
+	atSty := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPositive)
 	oSty := lipgloss.NewStyle().Foreground(styles.ColorSecondary)
 	diaSty := lipgloss.NewStyle().Foreground(styles.ColorMuted)
 	pipe := muted.Render("  │")
@@ -1145,7 +1145,7 @@ func (m Model) View() string {
 			lines = append(lines, muted.Render(fmt.Sprintf("Change: %s", m.tipChangeID)))
 		}
 		lines = append(lines, "")
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#F85149")).Render("Error: "+m.loadErr))
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorNegative).Render("Error: "+m.loadErr))
 		lines = append(lines, "")
 		lines = append(lines, muted.Render("Esc or Enter to close"))
 		box := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(styles.ColorMuted).Padding(1, 2).Width(modalW)
@@ -1186,7 +1186,7 @@ func (m Model) View() string {
 
 	selStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
 	normal := lipgloss.NewStyle().Foreground(styles.ColorMuted)
-	cidStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#8BE9FD"))
+	cidStyle := lipgloss.NewStyle().Foreground(styles.ColorAccent)
 
 	var leftLines []string
 	leftLines = append(leftLines, muted.Render("History (j/k · PgUp/PgDn scroll)"))
@@ -1243,10 +1243,10 @@ func (m Model) View() string {
 	lines = append(lines, "")
 	textWrapW := max(16, modalW-6)
 	if m.suggestNoSplit {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#E3B341")).Render("AI: no split")+"  "+
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorWarning).Render("AI: no split")+"  "+
 			muted.Render("p — current WC files · Enter again here to split anyway, or j/k for another row"))
 	} else if !m.suggestNoSplit && (m.hasAISplitPlan() || strings.TrimSpace(m.suggestRationale) != "") {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#BD93F9")).Render("AI plan")+"  "+
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorAI).Render("AI plan")+"  "+
 			muted.Render("p toggles preview · c clears plan"))
 	}
 	if m.suggestErrLine != "" {
@@ -1254,7 +1254,7 @@ func (m Model) View() string {
 			m.suggestErrLine,
 			textWrapW,
 			evologAIWrapMaxLines,
-			lipgloss.NewStyle().Foreground(lipgloss.Color("#F85149")),
+			lipgloss.NewStyle().Foreground(styles.ColorNegative),
 			muted,
 		))
 	}
@@ -0,0 +1,136 @@
+package filediff
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// ConflictTakeMsg asks main to resolve the open conflicted file by keeping Take's side(s) of
+// every conflict (l / r / b in the conflict viewer).
+type ConflictTakeMsg struct {
+	Path string
+	Take jj.ConflictTake
+}
+
+// ConflictResolvedMsg is sent when ResolveConflictCmd finishes.
+type ConflictResolvedMsg struct {
+	state.RepoResult
+	Path string
+	Take jj.ConflictTake
+	Err  error
+}
+
+// OpenMergeToolMsg asks main to run the configured merge tool on the open conflicted file (m in
+// the conflict viewer).
+type OpenMergeToolMsg struct {
+	Path string
+}
+
+// MergeToolExitedMsg is sent when OpenMergeToolCmd's `jj resolve` exits.
+type MergeToolExitedMsg struct {
+	state.RepoResult
+	Path string
+	Err  error
+}
+
+// OpenMergeToolCmd runs `jj resolve` on path with tool ("" = jj's ui.merge-editor), suspending the
+// TUI until the tool exits.
+func OpenMergeToolCmd(svc *jj.Service, tool, path string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return tea.ExecProcess(svc.ResolveCommand(tool, path), func(err error) tea.Msg {
+		return MergeToolExitedMsg{Path: path, Err: err}
+	})
+}
+
+// ResolveConflictCmd rewrites path in the working copy with take's side(s) and snapshots it.
+func ResolveConflictCmd(svc *jj.Service, path string, take jj.ConflictTake) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		err := svc.ResolveWorkingCopyConflict(context.Background(), path, take)
+		return ConflictResolvedMsg{Path: path, Take: take, Err: err}
+	}
+}
+
+// loadConflict reads path at changeID and parses its conflict markers. It returns nil (and no
+// error) when the file has no conflicts, so the caller falls back to the normal diff.
+func loadConflict(svc *jj.Service, changeID, path string) (*jj.ConflictFile, string, error) {
+	text, err := svc.ReadConflictedFile(context.Background(), changeID, path)
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := jj.ParseConflictMarkers(text)
+	if err != nil || len(f.Hunks()) == 0 {
+		return nil, "", nil
+	}
+	return f, text, nil
+}
+
+// StyleConflict renders a conflicted file with each hunk's sides labeled and colored: plain
+// lines as context, then per hunk the left side, the base (when the markers carry it) and the
+// right side.
+func StyleConflict(f *jj.ConflictFile, contentWidth int) string {
+	if contentWidth < 8 {
+		contentWidth = 8
+	}
+	fg := styles.ColorText
+	ctxSt := lipgloss.NewStyle().Background(styles.ColorDiffContext).Foreground(fg)
+	hunkSt := lipgloss.NewStyle().Background(styles.ColorDiffHunk).Foreground(styles.ColorAccent).Bold(true)
+	baseSt := lipgloss.NewStyle().Background(styles.ColorDiffMeta).Foreground(styles.ColorMuted)
+	sideSts := []lipgloss.Style{
+		lipgloss.NewStyle().Background(styles.ColorConflictOurs).Foreground(fg),
+		lipgloss.NewStyle().Background(styles.ColorConflictTheirs).Foreground(fg),
+	}
+	sideName := func(i int) string {
+		switch i {
+		case 0:
+			return "left (l)"
+		case 1:
+			return "right (r)"
+		}
+		return fmt.Sprintf("side #%d", i+1)
+	}
+	const gap = "  "
+
+	total := len(f.Hunks())
+	var out []string
+	n := 0
+	for _, r := range f.Regions {
+		if r.Hunk == nil {
+			for _, line := range r.Lines {
+				out = append(out, styleGitDiffLine(line, gap, contentWidth, ctxSt))
+			}
+			continue
+		}
+		n++
+		out = append(out, styleGitDiffLine(fmt.Sprintf("Conflict %d of %d", n, total), gap, contentWidth, hunkSt))
+		for i, side := range r.Hunk.Sides {
+			st := sideSts[i%len(sideSts)]
+			label := sideName(i)
+			if side.Label != "" {
+				label += ": " + side.Label
+			}
+			out = append(out, styleGitDiffLine(label, "▌ ", contentWidth, st.Bold(true)))
+			for _, line := range side.Lines {
+				out = append(out, styleGitDiffLine(line, "▌ ", contentWidth, st))
+			}
+			if i == 0 && len(r.Hunk.Base) > 0 {
+				out = append(out, styleGitDiffLine("base", "┆ ", contentWidth, baseSt.Bold(true)))
+				for _, line := range r.Hunk.Base {
+					out = append(out, styleGitDiffLine(line, "┆ ", contentWidth, baseSt))
+				}
+			}
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,49 @@
+package filediff
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// A conflicted working-copy file renders both sides labeled and l/r/b (or m, via the merge tool) ask main to resolve it;
+// outside the working copy the keys only scroll.
+func TestConflictView(t *testing.T) {
+	text := "<<<<<<< Conflict 1 of 1\n%%%%%%% Changes from base to side #1\n-old\n+ours\n+++++++ Contents of side #2\ntheirs\n>>>>>>> Conflict 1 of 1 ends\n"
+	f, err := jj.ParseConflictMarkers(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := StyleConflict(f, 60)
+	for _, want := range []string{"Conflict 1 of 1", "left (l): side #1", "ours", "base", "right (r): side #2", "theirs"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	m := NewModel(nil)
+	seq := m.BeginLoad(internal.Commit{ShortID: "abc", IsWorking: true}, "a.txt")
+	m, _ = m.Update(FileDiffLoadedMsg{Seq: seq, Text: text, Conflict: f})
+	if !m.IsConflict() || !strings.Contains(m.View(), "b take both") {
+		t.Fatalf("expected conflict view:\n%s", m.View())
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if msg, ok := cmd().(ConflictTakeMsg); !ok || msg.Path != "a.txt" || msg.Take != jj.ConflictTakeRight {
+		t.Fatalf("r sent %#v", msg)
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if msg, ok := cmd().(OpenMergeToolMsg); !ok || msg.Path != "a.txt" {
+		t.Fatalf("m sent %#v", msg)
+	}
+
+	seq = m.BeginLoad(internal.Commit{ShortID: "def"}, "a.txt")
+	m, _ = m.Update(FileDiffLoadedMsg{Seq: seq, Text: text, Conflict: f})
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}); cmd != nil {
+		if _, ok := cmd().(ConflictTakeMsg); ok {
+			t.Fatal("conflicts outside the working copy are read-only")
+		}
+	}
+}
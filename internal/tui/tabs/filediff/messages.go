@@ -6,17 +6,22 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
-// FileDiffLoadedMsg carries jj diff output for the graph file-diff modal.
+// FileDiffLoadedMsg carries jj diff output for the graph file-diff modal. Conflict is set (and
+// Text holds the file with its markers) when the file is conflicted at that revision.
 type FileDiffLoadedMsg struct {
-	Seq  int
-	Text string
-	Err  error
+	state.RepoResult
+	Seq      int
+	Text     string
+	Conflict *jj.ConflictFile
+	Err      error
 }
 
-// LoadFileDiffCmd runs jj diff for one file at a revision and sends FileDiffLoadedMsg.
-func LoadFileDiffCmd(svc *jj.Service, seq int, changeID, path string) tea.Cmd {
+// LoadFileDiffCmd runs jj diff for one file at a revision and sends FileDiffLoadedMsg. When the
+// commit has conflicts and this file has markers, it loads the conflict view instead.
+func LoadFileDiffCmd(svc *jj.Service, seq int, changeID, path string, conflicted bool) tea.Cmd {
 	if svc == nil || seq <= 0 {
 		return nil
 	}
@@ -26,6 +31,11 @@ func LoadFileDiffCmd(svc *jj.Service, seq int, changeID, path string) tea.Cmd {
 		return nil
 	}
 	return func() tea.Msg {
+		if conflicted {
+			if f, text, err := loadConflict(svc, ch, p); err == nil && f != nil {
+				return FileDiffLoadedMsg{Seq: seq, Text: text, Conflict: f}
+			}
+		}
 		text, err := svc.DiffRevisionFile(context.Background(), ch, p)
 		if err != nil {
 			return FileDiffLoadedMsg{Seq: seq, Err: err}
@@ -7,11 +7,14 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
 // Horizontal layout: leave fileDiffTermSideColumns on each side of the bordered modal,
@@ -69,6 +72,12 @@ type Model struct {
 	naturalOuterW   int
 	naturalOuterH   int
 	naturalDimsSeq  int
+	// conflict is set when the file was loaded as a conflict (see LoadFileDiffCmd); l/r/b
+	// resolve it when conflictResolvable (the file is in the working copy).
+	conflict           *jj.ConflictFile
+	conflictResolvable bool
+	// sel is the keyboard line selection (V to start, y to copy).
+	sel util.LineSelection
 }
 
 // NewModel creates a file diff modal. zoneManager may be nil (no close button zone).
@@ -94,11 +103,79 @@ func (m Model) SetDimensions(w, h int) Model {
 	m.termW, m.termH = w, h
 	m.layoutViewport()
 	if m.shown && !m.loading && m.errMsg == "" && m.body != "" {
-		m.vp.SetContent(StyleGitUnifiedDiff(m.body, m.innerW))
+		m.refreshContent()
 	}
 	return m
 }
 
+// styledBody renders the loaded body: the conflict view for a conflicted file, else the diff.
+func (m Model) styledBody(width int) string {
+	if m.conflict != nil {
+		return StyleConflict(m.conflict, width)
+	}
+	return StyleGitUnifiedDiff(m.body, width)
+}
+
+// refreshContent renders the body into the viewport with the selection highlighted.
+func (m *Model) refreshContent() {
+	lines := strings.Split(m.styledBody(m.innerW), "\n")
+	m.vp.SetContent(strings.Join(m.sel.Highlight(lines), "\n"))
+}
+
+// plainLines is the text a selection copies: the raw diff lines (without the line-number gutter),
+// or the conflict view as shown.
+func (m Model) plainLines() []string {
+	if m.conflict == nil {
+		return strings.Split(strings.ReplaceAll(m.body, "\r\n", "\n"), "\n")
+	}
+	lines := strings.Split(m.styledBody(m.innerW), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(ansi.Strip(l), " ")
+	}
+	return lines
+}
+
+// IsSelecting reports whether a line selection is in progress (Esc then cancels it instead of
+// closing the modal).
+func (m *Model) IsSelecting() bool { return m.sel.Active() }
+
+// handleSelectionKey moves or yanks the active selection; other keys are swallowed so conflict
+// actions can't fire mid-selection.
+func (m Model) handleSelectionKey(k string) (Model, tea.Cmd) {
+	total := len(m.plainLines())
+	page := max(m.vp.Height, 1)
+	switch k {
+	case "esc", "V":
+		m.sel.Cancel()
+	case "y":
+		text := m.sel.Text(m.plainLines())
+		m.sel.Cancel()
+		m.refreshContent()
+		return m, util.CopyToClipboard(text)
+	case "j", "down":
+		m.sel.Move(1, total)
+	case "k", "up":
+		m.sel.Move(-1, total)
+	case "pgdown", "f", " ":
+		m.sel.Move(page, total)
+	case "pgup", "b":
+		m.sel.Move(-page, total)
+	case "g", "home":
+		m.sel.Move(-total, total)
+	case "G", "end":
+		m.sel.Move(total, total)
+	default:
+		return m, nil
+	}
+	if c := m.sel.Cursor(); c < m.vp.YOffset {
+		m.vp.SetYOffset(c)
+	} else if c >= m.vp.YOffset+m.vp.Height {
+		m.vp.SetYOffset(c - m.vp.Height + 1)
+	}
+	m.refreshContent()
+	return m, nil
+}
+
 // ShowPreloadedStyledDiff shows a git unified diff that is already loaded (no async jj call).
 // title/subtitle appear in the header; empty title defaults to "Patch" in View.
 func (m Model) ShowPreloadedStyledDiff(title, subtitle, rawGit string) Model {
@@ -111,9 +188,11 @@ func (m Model) ShowPreloadedStyledDiff(title, subtitle, rawGit string) Model {
 	m.overlaySub = strings.TrimSpace(subtitle)
 	m.filePath = ""
 	m.shortID = ""
+	m.conflict = nil
+	m.sel.Cancel()
 	m.vp.GotoTop()
 	m.layoutViewport()
-	m.vp.SetContent(StyleGitUnifiedDiff(rawGit, m.innerW))
+	m.refreshContent()
 	return m
 }
 
@@ -212,6 +291,9 @@ func (m *Model) BeginLoad(commit internal.Commit, path string) int {
 	m.filePath = strings.TrimSpace(path)
 	m.overlayTitle = ""
 	m.overlaySub = ""
+	m.conflict = nil
+	m.conflictResolvable = commit.IsWorking
+	m.sel.Cancel()
 	m.seq++
 	m.vp.SetContent("")
 	m.vp.GotoTop()
@@ -228,9 +310,13 @@ func (m *Model) Hide() {
 	m.seq = 0
 	m.overlayTitle = ""
 	m.overlaySub = ""
+	m.conflict = nil
 	m.vp.SetContent("")
 }
 
+// IsConflict reports whether the modal is showing a conflicted file.
+func (m *Model) IsConflict() bool { return m.conflict != nil }
+
 // IsShown reports whether the modal is active.
 func (m *Model) IsShown() bool { return m.shown }
 
@@ -258,13 +344,17 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		} else {
 			m.errMsg = ""
 			m.body = msg.Text
+			m.conflict = msg.Conflict
 			m.layoutViewport()
-			m.vp.SetContent(StyleGitUnifiedDiff(msg.Text, m.innerW))
+			m.refreshContent()
 			m.vp.GotoTop()
 		}
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.sel.Active() {
+			return m.handleSelectionKey(msg.String())
+		}
 		switch msg.String() {
 		case "esc", "q":
 			m.shown = false
@@ -274,6 +364,38 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if m.loading || m.errMsg != "" {
 			return m, nil
 		}
+		if msg.String() == "V" {
+			m.sel.Start(m.vp.YOffset)
+			m.refreshContent()
+			return m, nil
+		}
+		if msg.String() == "v" {
+			return m, state.NavigateTarget{
+				Kind:         state.NavigateOpenPager,
+				PagerTitle:   m.pagerTitle(),
+				PagerContent: m.styledBody(m.termW),
+			}.Cmd()
+		}
+		if m.conflict != nil && m.conflictResolvable {
+			var take jj.ConflictTake
+			switch msg.String() {
+			case "l":
+				take = jj.ConflictTakeLeft
+			case "r":
+				take = jj.ConflictTakeRight
+			case "b":
+				take = jj.ConflictTakeBoth
+			case "m":
+				path := m.filePath
+				return m, func() tea.Msg { return OpenMergeToolMsg{Path: path} }
+			default:
+				var cmd tea.Cmd
+				m.vp, cmd = m.vp.Update(msg)
+				return m, cmd
+			}
+			path := m.filePath
+			return m, func() tea.Msg { return ConflictTakeMsg{Path: path, Take: take} }
+		}
 		var cmd tea.Cmd
 		m.vp, cmd = m.vp.Update(msg)
 		return m, cmd
@@ -301,6 +423,17 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// pagerTitle names the diff when it is opened full screen in the pager.
+func (m Model) pagerTitle() string {
+	if m.overlayTitle != "" {
+		if m.overlaySub != "" {
+			return m.overlayTitle + ": " + m.overlaySub
+		}
+		return m.overlayTitle
+	}
+	return fmt.Sprintf("%s @ %s", m.filePath, m.shortID)
+}
+
 // View renders the centered modal.
 func (m Model) View() string {
 	if !m.shown {
@@ -324,6 +457,9 @@ func (m Model) View() string {
 			pathDisp = "(unknown path)"
 		}
 		subLine = fmt.Sprintf("%s  @ %s", pathDisp, m.shortID)
+		if m.conflict != nil {
+			subLine += fmt.Sprintf("  · %d conflict(s)", len(m.conflict.Hunks()))
+		}
 	}
 	sub := lipgloss.NewStyle().Foreground(styles.ColorMuted).Width(maxOuterW - fileDiffOuterInnerDelta).Render(subLine)
 
@@ -331,7 +467,7 @@ func (m Model) View() string {
 	if m.loading {
 		body = lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Loading diff…")
 	} else if m.errMsg != "" {
-		body = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Width(m.innerW).Render(m.errMsg)
+		body = lipgloss.NewStyle().Foreground(styles.ColorNegative).Width(m.innerW).Render(m.errMsg)
 	} else {
 		body = m.vp.View()
 	}
@@ -340,7 +476,16 @@ func (m Model) View() string {
 	if m.zm != nil {
 		closeLabel = m.zm.Mark(mouse.ZoneFileDiffClose, styles.ButtonStyle.Render("Close"))
 	}
-	footer := lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Esc · j/k · PgUp/PgDn scroll  ") + closeLabel
+	hint := "Esc · j/k · PgUp/PgDn scroll · V select · v full screen  "
+	switch {
+	case m.sel.Active():
+		hint = m.sel.Status() + "  "
+	case m.conflict != nil && m.conflictResolvable:
+		hint = "l take left · r take right · b take both · m merge tool · " + hint
+	case m.conflict != nil:
+		hint = "edit this commit (e) to resolve here · " + hint
+	}
+	footer := lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(hint) + closeLabel
 
 	inner := lipgloss.JoinVertical(lipgloss.Left, sub, "", body, "", footer)
 	// Width is fixed (we picked m.outerW to fit the diff); height is left to
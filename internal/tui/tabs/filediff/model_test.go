@@ -0,0 +1,39 @@
+package filediff
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLineSelectionCopiesRawDiff(t *testing.T) {
+	raw := "diff --git a/x.txt b/x.txt\n--- a/x.txt\n+++ b/x.txt\n@@ -1,2 +1,2 @@\n keep\n-old\n+new"
+	m := NewModel(nil).SetDimensions(100, 40).ShowPreloadedStyledDiff("Patch", "", raw)
+	press := func(k string) tea.Cmd {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return cmd
+	}
+
+	press("V")
+	if !m.IsSelecting() {
+		t.Fatal("V should start a selection")
+	}
+	for range 6 {
+		press("j")
+	}
+	press("k")
+	if got := m.sel.Text(m.plainLines()); got != "diff --git a/x.txt b/x.txt\n--- a/x.txt\n+++ b/x.txt\n@@ -1,2 +1,2 @@\n keep\n-old" {
+		t.Errorf("selection = %q, want the raw lines without the gutter", got)
+	}
+
+	// Esc cancels the selection instead of closing the modal.
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil || m.IsSelecting() || !m.IsShown() {
+		t.Error("Esc should only cancel the selection")
+	}
+	press("V")
+	if press("y") == nil || m.IsSelecting() {
+		t.Error("y should copy and end the selection")
+	}
+}
@@ -119,12 +119,12 @@ func StyleGitUnifiedDiff(text string, contentWidth int) string {
 
 	gap := strings.Repeat(" ", unifiedDiffGutterColumns)
 
-	fg := lipgloss.Color("#F8F8F2")
-	addSt := lipgloss.NewStyle().Background(lipgloss.Color("#1B4332")).Foreground(fg)
-	delSt := lipgloss.NewStyle().Background(lipgloss.Color("#4A232C")).Foreground(fg)
-	ctxSt := lipgloss.NewStyle().Background(lipgloss.Color("#21222C")).Foreground(fg)
-	metaSt := lipgloss.NewStyle().Background(lipgloss.Color("#2D303E")).Foreground(styles.ColorMuted)
-	hunkSt := lipgloss.NewStyle().Background(lipgloss.Color("#44475A")).Foreground(lipgloss.Color("#8BE9FD")).Bold(true)
+	fg := styles.ColorText
+	addSt := lipgloss.NewStyle().Background(styles.ColorDiffAdd).Foreground(fg)
+	delSt := lipgloss.NewStyle().Background(styles.ColorDiffDelete).Foreground(fg)
+	ctxSt := lipgloss.NewStyle().Background(styles.ColorDiffContext).Foreground(fg)
+	metaSt := lipgloss.NewStyle().Background(styles.ColorDiffMeta).Foreground(styles.ColorMuted)
+	hunkSt := lipgloss.NewStyle().Background(styles.ColorDiffHunk).Foreground(styles.ColorAccent).Bold(true)
 
 	out := make([]string, 0, len(lines))
 	var oldLine, newLine int
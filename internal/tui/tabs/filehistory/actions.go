@@ -0,0 +1,68 @@
+package filehistory
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// historyLimit caps how many commits are listed for one file.
+const historyLimit = 500
+
+// RequestContext is passed from the main model so the view can run requests without depending
+// on the model package.
+type RequestContext struct {
+	JJService *jj.Service
+	Path      string // repository-relative file path
+}
+
+// LoadHistoryCmd lists the commits among revision's ancestors that changed path.
+func LoadHistoryCmd(svc *jj.Service, seq int, revision, path string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		commits, err := svc.FileHistory(context.Background(), revision, path, historyLimit)
+		return HistoryLoadedMsg{Seq: seq, Commits: commits, Err: err}
+	}
+}
+
+// LoadAnnotateCmd annotates path at revision.
+func LoadAnnotateCmd(svc *jj.Service, seq int, revision, label, path string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		lines, err := svc.AnnotateFile(context.Background(), revision, path)
+		return AnnotateLoadedMsg{Seq: seq, Label: label, Lines: lines, Err: err}
+	}
+}
+
+// ExecuteRequest runs an annotate or copy request and returns the status message and command.
+// Jumps are performed by main, which owns the graph.
+func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.Cmd) {
+	if ctx == nil || ctx.JJService == nil {
+		return "", nil
+	}
+	switch {
+	case r.Annotate != "":
+		return fmt.Sprintf("Annotating %s @ %s…", ctx.Path, r.Label), LoadAnnotateCmd(ctx.JJService, r.Seq, r.Annotate, r.Label, ctx.Path)
+	case r.Copy != "":
+		return "Copied: " + r.Copy, util.CopyToClipboard(r.Copy)
+	}
+	return "", nil
+}
+
+// historyCount is the status line after the history loads.
+func historyCount(n int, path string) string {
+	switch {
+	case n == 1:
+		return fmt.Sprintf("1 commit changed %s", path)
+	case n >= historyLimit:
+		return fmt.Sprintf("Latest %d commits that changed %s", n, path)
+	}
+	return fmt.Sprintf("%d commits changed %s", n, path)
+}
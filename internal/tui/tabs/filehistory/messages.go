@@ -0,0 +1,39 @@
+package filehistory
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// HistoryLoadedMsg is sent when LoadHistoryCmd finishes. Seq matches the Open call it answers.
+type HistoryLoadedMsg struct {
+	state.RepoResult
+	Seq     int
+	Commits []jj.FileCommit
+	Err     error
+}
+
+// AnnotateLoadedMsg is sent when LoadAnnotateCmd finishes. Seq matches the Request that started
+// it, so only the latest annotation is shown.
+type AnnotateLoadedMsg struct {
+	state.RepoResult
+	Seq   int
+	Label string
+	Lines []jj.AnnotatedLine
+	Err   error
+}
+
+// Request is sent to the main model for actions that need services or leave the view.
+type Request struct {
+	Annotate string // annotate the file at this revision
+	Label    string // short name of Annotate for the title
+	Seq      int    // AnnotateLoadedMsg.Seq to answer with
+	Jump     string // change ID to select in the graph (closes the view)
+	Copy     string // copy this change ID to the clipboard
+}
+
+// Cmd returns a tea.Cmd that sends this request.
+func (r Request) Cmd() tea.Cmd {
+	return func() tea.Msg { return r }
+}
@@ -0,0 +1,358 @@
+// Package filehistory is a full-screen view of one file's history: the commits that changed it
+// (`jj log -- path`) and a line-by-line annotation (`jj file annotate`), each with jump-to-commit.
+package filehistory
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// pane is the list being shown.
+type pane int
+
+const (
+	paneHistory pane = iota
+	paneAnnotate
+)
+
+// list is the cursor and scroll offset of one pane.
+type list struct {
+	selected int
+	yOffset  int
+}
+
+// Model is the file history view state.
+type Model struct {
+	shown  bool
+	commit internal.Commit
+	path   string
+	pane   pane
+
+	seq         int
+	histLoading bool
+	histErr     error
+	history     []jj.FileCommit
+	hist        list
+
+	annSeq     int
+	annLoading bool
+	annErr     error
+	annLabel   string
+	annotated  []jj.AnnotatedLine
+	ann        list
+
+	width  int
+	height int
+	status string
+}
+
+// NewModel creates a hidden view.
+func NewModel() Model {
+	return Model{width: 80, height: 24}
+}
+
+// Open shows the history of path as of commit and returns the sequence number LoadHistoryCmd
+// must carry.
+func (m Model) Open(commit internal.Commit, path string) (Model, int) {
+	m.seq++
+	m.shown = true
+	m.commit, m.path = commit, path
+	m.pane = paneHistory
+	m.histLoading, m.histErr, m.history = true, nil, nil
+	m.annLoading, m.annErr, m.annLabel, m.annotated = false, nil, "", nil
+	m.hist, m.ann = list{}, list{}
+	m.status = ""
+	return m, m.seq
+}
+
+// Hide closes the view.
+func (m *Model) Hide() {
+	m.shown = false
+	m.history, m.annotated = nil, nil
+}
+
+// IsShown reports whether the view is open.
+func (m *Model) IsShown() bool { return m.shown }
+
+// Path returns the file being traced.
+func (m *Model) Path() string { return m.path }
+
+// IsAnnotating reports whether the annotate pane is showing.
+func (m *Model) IsAnnotating() bool { return m.pane == paneAnnotate }
+
+// SetStatus sets the footer status line (main mirrors its status message here while shown).
+func (m *Model) SetStatus(s string) { m.status = s }
+
+// LoadStatus is the status line once the history has loaded (or failed to).
+func (m *Model) LoadStatus() string {
+	switch {
+	case m.histLoading:
+		return fmt.Sprintf("Loading history of %s…", m.path)
+	case m.histErr != nil:
+		return fmt.Sprintf("Failed to load history: %v", m.histErr)
+	}
+	return historyCount(len(m.history), m.path)
+}
+
+// SetDimensions sets the full terminal size.
+func (m Model) SetDimensions(w, h int) Model {
+	m.width, m.height = max(w, 1), max(h, 4)
+	m.clamp(&m.hist, len(m.history))
+	m.clamp(&m.ann, len(m.annotated))
+	return m
+}
+
+// bodyHeight is the number of rows between the title bar and the two footer lines.
+func (m *Model) bodyHeight() int {
+	return max(m.height-3, 1)
+}
+
+// current returns the active pane's cursor and row count.
+func (m *Model) current() (*list, int) {
+	if m.pane == paneAnnotate {
+		return &m.ann, len(m.annotated)
+	}
+	return &m.hist, len(m.history)
+}
+
+func (m *Model) clamp(l *list, n int) {
+	h := m.bodyHeight()
+	l.selected = max(min(l.selected, n-1), 0)
+	if l.selected < l.yOffset {
+		l.yOffset = l.selected
+	} else if l.selected >= l.yOffset+h {
+		l.yOffset = l.selected - h + 1
+	}
+	l.yOffset = max(min(l.yOffset, n-h), 0)
+}
+
+func (m *Model) move(delta int) {
+	l, n := m.current()
+	if n == 0 {
+		return
+	}
+	l.selected += delta
+	m.clamp(l, n)
+}
+
+// selectedCommit is the commit under the cursor: a history entry, or the commit that last changed
+// the selected line.
+func (m *Model) selectedCommit() *jj.FileCommit {
+	if m.pane == paneAnnotate {
+		if m.ann.selected < len(m.annotated) {
+			return &m.annotated[m.ann.selected].FileCommit
+		}
+		return nil
+	}
+	if m.hist.selected < len(m.history) {
+		return &m.history[m.hist.selected]
+	}
+	return nil
+}
+
+// annotate starts annotating the file at revision, shown as label.
+func (m *Model) annotate(revision, label string) tea.Cmd {
+	m.annSeq++
+	m.pane = paneAnnotate
+	m.annLoading, m.annErr, m.annLabel, m.annotated = true, nil, label, nil
+	m.ann = list{}
+	return Request{Annotate: revision, Label: label, Seq: m.annSeq}.Cmd()
+}
+
+// Update handles loads, keys, and the mouse wheel. q/Esc closes via NavigateCloseFileHistory.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.shown {
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case HistoryLoadedMsg:
+		if msg.Seq != m.seq {
+			return m, nil
+		}
+		m.histLoading, m.histErr, m.history = false, msg.Err, msg.Commits
+		m.clamp(&m.hist, len(m.history))
+		return m, nil
+	case AnnotateLoadedMsg:
+		if msg.Seq != m.annSeq {
+			return m, nil
+		}
+		m.annLoading, m.annErr, m.annotated = false, msg.Err, msg.Lines
+		m.clamp(&m.ann, len(m.annotated))
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.move(-3)
+		case tea.MouseButtonWheelDown:
+			m.move(3)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	c := m.selectedCommit()
+	switch msg.String() {
+	case "q", "esc":
+		return m, state.NavigateTarget{Kind: state.NavigateCloseFileHistory}.Cmd()
+	case "j", "down":
+		m.move(1)
+	case "k", "up":
+		m.move(-1)
+	case "pgdown", "ctrl+d", " ":
+		m.move(m.bodyHeight())
+	case "pgup", "ctrl+u", "b":
+		m.move(-m.bodyHeight())
+	case "g", "home":
+		_, n := m.current()
+		m.move(-n)
+	case "G", "end":
+		_, n := m.current()
+		m.move(n)
+	case "tab":
+		if m.pane == paneAnnotate {
+			m.pane = paneHistory
+			return m, nil
+		}
+		if m.annotated == nil && !m.annLoading && m.annErr == nil {
+			return m, m.annotate(m.commit.ID, m.revisionLabel())
+		}
+		m.pane = paneAnnotate
+	case "a":
+		// History: annotate as of the selected commit. Annotate: go back to before the commit
+		// that last changed the selected line, to see what it said earlier.
+		if c == nil {
+			return m, nil
+		}
+		if m.pane == paneHistory {
+			return m, m.annotate(c.CommitID, c.ChangeID)
+		}
+		return m, m.annotate(c.CommitID+"-", c.ChangeID+"-")
+	case "enter":
+		if c != nil {
+			return m, Request{Jump: c.ChangeID}.Cmd()
+		}
+	case "y":
+		if c != nil {
+			return m, Request{Copy: c.ChangeID}.Cmd()
+		}
+	}
+	return m, nil
+}
+
+// revisionLabel is the short name of the revision the view was opened at.
+func (m *Model) revisionLabel() string {
+	if m.commit.ChangeID != "" {
+		return m.commit.ChangeID
+	}
+	return m.commit.ShortID
+}
+
+// View renders the view full screen: title bar, list, status line, and key hints.
+func (m Model) View() string {
+	if !m.shown {
+		return ""
+	}
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	title := fmt.Sprintf(" History of %s @ %s", m.path, m.revisionLabel())
+	if m.pane == paneAnnotate {
+		title = fmt.Sprintf(" Annotate %s @ %s", m.path, m.annLabel)
+	}
+	pos := ""
+	if l, n := m.current(); n > 0 {
+		pos = fmt.Sprintf(" %d/%d ", l.selected+1, n)
+	}
+	title = ansi.Truncate(title, max(m.width-lipgloss.Width(pos), 0), "…")
+	gap := max(m.width-lipgloss.Width(title)-lipgloss.Width(pos), 0)
+	header := styles.TitleStyle.Render(title + strings.Repeat(" ", gap) + pos)
+
+	var body []string
+	if m.pane == paneAnnotate {
+		body = m.annotateRows()
+	} else {
+		body = m.historyRows()
+	}
+	for len(body) < m.bodyHeight() {
+		body = append(body, "")
+	}
+
+	hints := "j/k move · Enter select in graph · a annotate at commit · Tab annotate · y copy change ID · q close"
+	if m.pane == paneAnnotate {
+		hints = "j/k move · Enter select line's commit in graph · a annotate before that commit · Tab history · y copy change ID · q close"
+	}
+	footer := []string{
+		ansi.Truncate(m.status, m.width, "…"),
+		muted.Render(ansi.Truncate(hints, m.width, "…")),
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, append(append([]string{header}, body...), footer...)...)
+}
+
+func (m Model) historyRows() []string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	switch {
+	case m.histLoading:
+		return []string{muted.Render("  Loading history…")}
+	case m.histErr != nil:
+		return []string{lipgloss.NewStyle().Foreground(styles.ColorNegative).Render("  Failed to load history: " + m.histErr.Error())}
+	case len(m.history) == 0:
+		return []string{muted.Render("  No commits changed this file")}
+	}
+	idStyle := lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+	var rows []string
+	end := min(m.hist.yOffset+m.bodyHeight(), len(m.history))
+	for i := m.hist.yOffset; i < end; i++ {
+		c := m.history[i]
+		line := idStyle.Render(c.ChangeID) + "  " + muted.Render(c.CommitID) + "  " +
+			muted.Render(fmt.Sprintf("%-14s", ansi.Truncate(c.Age, 14, "…"))) + "  " +
+			fmt.Sprintf("%-16s", ansi.Truncate(c.Author, 16, "…")) + "  " + c.Summary
+		rows = append(rows, m.row(line, i == m.hist.selected))
+	}
+	return rows
+}
+
+func (m Model) annotateRows() []string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	switch {
+	case m.annLoading:
+		return []string{muted.Render("  Annotating…")}
+	case m.annErr != nil:
+		return []string{lipgloss.NewStyle().Foreground(styles.ColorNegative).Render("  Failed to annotate: " + m.annErr.Error())}
+	case len(m.annotated) == 0:
+		return []string{muted.Render("  The file is empty")}
+	}
+	idStyle := lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+	numW := len(fmt.Sprint(m.annotated[len(m.annotated)-1].Line))
+	var rows []string
+	end := min(m.ann.yOffset+m.bodyHeight(), len(m.annotated))
+	for i := m.ann.yOffset; i < end; i++ {
+		l := m.annotated[i]
+		// Commit details only on the first line of each run from the same commit, like blame UIs.
+		who := strings.Repeat(" ", 8+2+12+2)
+		if i == m.ann.yOffset || m.annotated[i-1].ChangeID != l.ChangeID {
+			who = idStyle.Render(l.ChangeID) + "  " + muted.Render(fmt.Sprintf("%-12s", ansi.Truncate(l.Author, 12, "…"))) + "  "
+		}
+		line := fmt.Sprintf("%s%s │ %s", who, muted.Render(fmt.Sprintf("%*d", numW, l.Line)), strings.ReplaceAll(l.Content, "\t", "    "))
+		rows = append(rows, m.row(line, i == m.ann.selected))
+	}
+	return rows
+}
+
+// row renders one list line with the selection marker, truncated to the width.
+func (m Model) row(line string, selected bool) string {
+	prefix := "  "
+	if selected {
+		prefix = "► "
+		line = styles.CommitSelectedStyle.Render(ansi.Strip(line))
+	}
+	return ansi.Truncate(prefix+line, m.width, "…")
+}
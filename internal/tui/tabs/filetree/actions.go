@@ -0,0 +1,85 @@
+package filetree
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// binarySniffLen is how much of a file is checked for NUL bytes before showing it as text.
+const binarySniffLen = 8000
+
+// RequestContext is passed from the main model so the browser can run requests without depending
+// on the model package.
+type RequestContext struct {
+	JJService *jj.Service
+	Config    *config.Config
+	Revision  string // commit ID being browsed
+	Label     string // short revision label for titles
+}
+
+// LoadFilesCmd lists the files in revision's tree.
+func LoadFilesCmd(svc *jj.Service, seq int, revision string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		paths, err := svc.ListFiles(context.Background(), revision)
+		return FilesLoadedMsg{Seq: seq, Paths: paths, Err: err}
+	}
+}
+
+// LoadFileContentCmd reads path at revision for the pager. Binary files are summarized instead.
+func LoadFileContentCmd(svc *jj.Service, revision, label, path string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		content, err := svc.ShowFile(context.Background(), revision, path)
+		if err == nil && strings.IndexByte(content[:min(len(content), binarySniffLen)], 0) >= 0 {
+			content = fmt.Sprintf("(binary file, %d bytes)", len(content))
+		}
+		return FileContentMsg{Path: path, Title: fmt.Sprintf("%s @ %s", path, label), Content: content, Err: err}
+	}
+}
+
+// ExecuteRequest validates r and returns the status message and command that runs it.
+func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.Cmd) {
+	if ctx == nil || ctx.JJService == nil {
+		return "", nil
+	}
+	switch {
+	case r.View != "":
+		return fmt.Sprintf("Reading %s…", r.View), LoadFileContentCmd(ctx.JJService, ctx.Revision, ctx.Label, r.View)
+	case r.Copy != "":
+		return "Copied: " + r.Copy, util.CopyToClipboard(r.Copy)
+	case r.Open != "":
+		if !util.HasFileEditor(ctx.Config) {
+			return "Set $EDITOR, or an external editor in Settings → Advanced", nil
+		}
+		abs, err := util.RepoAbsPath(ctx.JJService.RepoPath, r.Open)
+		if err != nil {
+			return err.Error(), nil
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return fmt.Sprintf("%s is not in the working copy", r.Open), nil
+		}
+		return fmt.Sprintf("Opening %s…", filepath.Base(abs)), util.OpenFileInExternalEditorCmd(abs, ctx.Config)
+	}
+	return "", nil
+}
+
+// fileCount is the status line after a tree loads.
+func fileCount(n int, label string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 file in %s", label)
+	}
+	return fmt.Sprintf("%d files in %s", n, label)
+}
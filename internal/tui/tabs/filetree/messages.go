@@ -0,0 +1,35 @@
+package filetree
+
+import "github.com/madicen/jj-tui/internal/tui/state"
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// FilesLoadedMsg is sent when LoadFilesCmd finishes. Seq matches the Open call it answers, so a
+// slow load for a previous commit is ignored.
+type FilesLoadedMsg struct {
+	state.RepoResult
+	Seq   int
+	Paths []string
+	Err   error
+}
+
+// FileContentMsg is sent when LoadFileContentCmd finishes; main shows Content in the pager.
+type FileContentMsg struct {
+	state.RepoResult
+	Path    string
+	Title   string
+	Content string
+	Err     error
+}
+
+// Request is sent to the main model for actions that need services or config.
+type Request struct {
+	View string // show this file's content at the browsed revision
+	Open string // open this file from the working copy in the editor
+	Copy string // copy this path to the clipboard
+}
+
+// Cmd returns a tea.Cmd that sends this request.
+func (r Request) Cmd() tea.Cmd {
+	return func() tea.Msg { return r }
+}
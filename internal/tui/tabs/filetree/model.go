@@ -0,0 +1,391 @@
+// Package filetree is a full-screen browser for every file in a revision's tree (`jj file list`),
+// with collapsible directories, file contents at that revision, and open-in-editor.
+package filetree
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// node is a file or directory in the tree.
+type node struct {
+	name     string
+	path     string // repository-relative, slash-separated
+	dir      bool
+	files    int // files below a directory
+	parent   *node
+	children []*node
+	expanded bool
+}
+
+// row is a visible node at its depth.
+type row struct {
+	node  *node
+	depth int
+}
+
+// Model is the file tree browser state.
+type Model struct {
+	shown    bool
+	commit   internal.Commit
+	seq      int
+	loading  bool
+	err      error
+	root     *node
+	rows     []row
+	selected int
+	yOffset  int
+	width    int
+	height   int
+	status   string
+}
+
+// NewModel creates a hidden browser.
+func NewModel() Model {
+	return Model{width: 80, height: 24}
+}
+
+// Open shows the browser for commit and returns the sequence number LoadFilesCmd must carry.
+func (m Model) Open(commit internal.Commit) (Model, int) {
+	m.seq++
+	m.shown = true
+	m.commit = commit
+	m.loading, m.err = true, nil
+	m.root, m.rows = nil, nil
+	m.selected, m.yOffset = 0, 0
+	m.status = ""
+	return m, m.seq
+}
+
+// Hide closes the browser.
+func (m *Model) Hide() {
+	m.shown = false
+	m.root, m.rows = nil, nil
+}
+
+// IsShown reports whether the browser is open.
+func (m *Model) IsShown() bool { return m.shown }
+
+// Commit returns the revision being browsed.
+func (m *Model) Commit() internal.Commit { return m.commit }
+
+// RevisionLabel is the short name of the browsed revision for titles and status lines.
+func (m *Model) RevisionLabel() string {
+	if m.commit.ChangeID != "" {
+		return m.commit.ChangeID
+	}
+	return m.commit.ShortID
+}
+
+// SetStatus sets the footer status line (main mirrors its status message here while shown).
+func (m *Model) SetStatus(s string) { m.status = s }
+
+// LoadStatus is the status line once the file list has loaded (or failed to).
+func (m *Model) LoadStatus() string {
+	switch {
+	case m.loading:
+		return fmt.Sprintf("Loading files of %s…", m.RevisionLabel())
+	case m.err != nil:
+		return fmt.Sprintf("Failed to list files: %v", m.err)
+	}
+	return fileCount(m.root.files, m.RevisionLabel())
+}
+
+// SetDimensions sets the full terminal size.
+func (m Model) SetDimensions(w, h int) Model {
+	m.width, m.height = max(w, 1), max(h, 4)
+	m.ensureVisible()
+	return m
+}
+
+// bodyHeight is the number of tree rows between the title bar and the two footer lines.
+func (m *Model) bodyHeight() int {
+	return max(m.height-3, 1)
+}
+
+// buildTree turns sorted file paths into a tree, directories first at each level.
+func buildTree(paths []string) *node {
+	root := &node{dir: true, expanded: true}
+	dirs := map[string]*node{"": root}
+	var dirFor func(path string) *node
+	dirFor = func(path string) *node {
+		if d, ok := dirs[path]; ok {
+			return d
+		}
+		parentPath, name := "", path
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			parentPath, name = path[:i], path[i+1:]
+		}
+		parent := dirFor(parentPath)
+		d := &node{name: name, path: path, dir: true, parent: parent}
+		parent.children = append(parent.children, d)
+		dirs[path] = d
+		return d
+	}
+	for _, p := range paths {
+		dirPath, name := "", p
+		if i := strings.LastIndex(p, "/"); i >= 0 {
+			dirPath, name = p[:i], p[i+1:]
+		}
+		parent := dirFor(dirPath)
+		parent.children = append(parent.children, &node{name: name, path: p, parent: parent})
+		for d := parent; d != nil; d = d.parent {
+			d.files++
+		}
+	}
+	var sortChildren func(n *node)
+	sortChildren = func(n *node) {
+		sort.SliceStable(n.children, func(i, j int) bool {
+			a, b := n.children[i], n.children[j]
+			if a.dir != b.dir {
+				return a.dir
+			}
+			return a.name < b.name
+		})
+		for _, c := range n.children {
+			if c.dir {
+				sortChildren(c)
+			}
+		}
+	}
+	sortChildren(root)
+	return root
+}
+
+// flatten recomputes the visible rows, keeping the selection on the same node when possible.
+func (m *Model) flatten() {
+	var keep *node
+	if m.selected >= 0 && m.selected < len(m.rows) {
+		keep = m.rows[m.selected].node
+	}
+	m.rows = nil
+	var walk func(n *node, depth int)
+	walk = func(n *node, depth int) {
+		for _, c := range n.children {
+			m.rows = append(m.rows, row{node: c, depth: depth})
+			if c.dir && c.expanded {
+				walk(c, depth+1)
+			}
+		}
+	}
+	if m.root != nil {
+		walk(m.root, 0)
+	}
+	m.selected = min(m.selected, len(m.rows)-1)
+	for i, r := range m.rows {
+		if r.node == keep {
+			m.selected = i
+		}
+	}
+	m.selected = max(m.selected, 0)
+	m.ensureVisible()
+}
+
+func (m *Model) selectedNode() *node {
+	if m.selected < 0 || m.selected >= len(m.rows) {
+		return nil
+	}
+	return m.rows[m.selected].node
+}
+
+func (m *Model) ensureVisible() {
+	h := m.bodyHeight()
+	if m.selected < m.yOffset {
+		m.yOffset = m.selected
+	} else if m.selected >= m.yOffset+h {
+		m.yOffset = m.selected - h + 1
+	}
+	m.yOffset = max(min(m.yOffset, len(m.rows)-h), 0)
+}
+
+func (m *Model) move(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	m.selected = max(min(m.selected+delta, len(m.rows)-1), 0)
+	m.ensureVisible()
+}
+
+// Update handles the file list load, keys, and the mouse wheel. q/Esc closes via
+// NavigateCloseFileTree.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.shown {
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case FilesLoadedMsg:
+		if msg.Seq != m.seq {
+			return m, nil
+		}
+		m.loading, m.err = false, msg.Err
+		if msg.Err == nil {
+			m.root = buildTree(msg.Paths)
+			m.flatten()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.move(-3)
+		case tea.MouseButtonWheelDown:
+			m.move(3)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	n := m.selectedNode()
+	switch msg.String() {
+	case "q", "esc":
+		return m, state.NavigateTarget{Kind: state.NavigateCloseFileTree}.Cmd()
+	case "j", "down":
+		m.move(1)
+	case "k", "up":
+		m.move(-1)
+	case "pgdown", "ctrl+d", " ":
+		m.move(m.bodyHeight())
+	case "pgup", "ctrl+u", "b":
+		m.move(-m.bodyHeight())
+	case "g", "home":
+		m.move(-len(m.rows))
+	case "G", "end":
+		m.move(len(m.rows))
+	case "enter":
+		switch {
+		case n == nil:
+		case n.dir:
+			n.expanded = !n.expanded
+			m.flatten()
+		default:
+			return m, Request{View: n.path}.Cmd()
+		}
+	case "l", "right":
+		if n != nil && n.dir && !n.expanded {
+			n.expanded = true
+			m.flatten()
+		}
+	case "h", "left":
+		switch {
+		case n == nil:
+		case n.dir && n.expanded:
+			n.expanded = false
+			m.flatten()
+		case n.parent != nil && n.parent != m.root:
+			for i, r := range m.rows {
+				if r.node == n.parent {
+					m.selected = i
+					m.ensureVisible()
+				}
+			}
+		}
+	case "E":
+		if m.root != nil {
+			setExpanded(m.root, true)
+			m.flatten()
+		}
+	case "C":
+		if m.root != nil {
+			setExpanded(m.root, false)
+			m.root.expanded = true
+			m.flatten()
+		}
+	case "v":
+		if n != nil && !n.dir {
+			return m, Request{View: n.path}.Cmd()
+		}
+	case "O", "e":
+		if n != nil && !n.dir {
+			return m, Request{Open: n.path}.Cmd()
+		}
+	case "L":
+		if n != nil && !n.dir {
+			return m, state.NavigateTarget{Kind: state.NavigateOpenFileHistory, Commit: m.commit, FileHistoryPath: n.path}.Cmd()
+		}
+	case "y":
+		if n != nil {
+			return m, Request{Copy: n.path}.Cmd()
+		}
+	}
+	return m, nil
+}
+
+func setExpanded(n *node, expanded bool) {
+	n.expanded = expanded
+	for _, c := range n.children {
+		if c.dir {
+			setExpanded(c, expanded)
+		}
+	}
+}
+
+// View renders the browser full screen: title bar, tree, status line, and key hints.
+func (m Model) View() string {
+	if !m.shown {
+		return ""
+	}
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	title := " Files @ " + m.RevisionLabel()
+	if s := strings.TrimSpace(m.commit.Summary); s != "" {
+		title += "  " + s
+	}
+	pos := ""
+	if len(m.rows) > 0 {
+		pos = fmt.Sprintf(" %d/%d ", m.selected+1, len(m.rows))
+	}
+	title = ansi.Truncate(title, max(m.width-lipgloss.Width(pos), 0), "…")
+	gap := max(m.width-lipgloss.Width(title)-lipgloss.Width(pos), 0)
+	header := styles.TitleStyle.Render(title + strings.Repeat(" ", gap) + pos)
+
+	var body []string
+	switch {
+	case m.loading:
+		body = append(body, muted.Render("  Loading files…"))
+	case m.err != nil:
+		body = append(body, lipgloss.NewStyle().Foreground(styles.ColorNegative).Render("  Failed to list files: "+m.err.Error()))
+	case len(m.rows) == 0:
+		body = append(body, muted.Render("  No files in this revision"))
+	}
+	dirStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	end := min(m.yOffset+m.bodyHeight(), len(m.rows))
+	for i := m.yOffset; i < end; i++ {
+		r := m.rows[i]
+		indent := strings.Repeat("  ", r.depth)
+		var line string
+		if r.node.dir {
+			arrow := "▸ "
+			if r.node.expanded {
+				arrow = "▾ "
+			}
+			line = indent + arrow + dirStyle.Render(r.node.name+"/") + muted.Render(fmt.Sprintf("  %d", r.node.files))
+		} else {
+			line = indent + "  " + r.node.name
+		}
+		prefix := "  "
+		if i == m.selected {
+			prefix = "► "
+			line = styles.CommitSelectedStyle.Render(ansi.Strip(line))
+		}
+		body = append(body, ansi.Truncate(prefix+line, m.width, "…"))
+	}
+	for len(body) < m.bodyHeight() {
+		body = append(body, "")
+	}
+
+	hints := "j/k move · Enter open/toggle · h/l collapse/expand · E/C expand/collapse all · v view · L history · O edit working copy · y copy path · q close"
+	footer := []string{
+		ansi.Truncate(m.status, m.width, "…"),
+		muted.Render(ansi.Truncate(hints, m.width, "…")),
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, append(append([]string{header}, body...), footer...)...)
+}
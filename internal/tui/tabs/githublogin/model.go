@@ -92,7 +92,7 @@ func (m Model) View() string {
 	if m.userCode != "" {
 		lines = append(lines, lipgloss.NewStyle().Bold(true).Render("1. Visit this URL in your browser:"))
 		lines = append(lines, "")
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#58A6FF")).Render("   "+m.verificationURL))
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorAccent).Render("   "+m.verificationURL))
 		lines = append(lines, "")
 		lines = append(lines, "")
 		lines = append(lines, lipgloss.NewStyle().Bold(true).Render("2. Enter this code:"))
@@ -100,8 +100,8 @@ func (m Model) View() string {
 
 		codeStyle := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#F0F6FC")).
-			Background(lipgloss.Color("#238636")).
+			Foreground(styles.ColorOnAccent).
+			Background(styles.ColorConfirm).
 			Padding(1, 3).
 			MarginLeft(3)
 		lines = append(lines, codeStyle.Render(m.userCode))
@@ -117,26 +117,26 @@ func (m Model) View() string {
 		lines = append(lines, "")
 
 		if m.polling {
-			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#8B949E")).Italic(true).Render("   Waiting for authorization..."))
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorSubtle).Italic(true).Render("   Waiting for authorization..."))
 		}
 	} else {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#8B949E")).Render("   Starting GitHub login..."))
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("   Starting GitHub login..."))
 	}
 
 	lines = append(lines, "")
 	lines = append(lines, "")
-	lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#8B949E")).Render("Press Esc to cancel"))
+	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("Press Esc to cancel"))
 
 	return strings.Join(lines, "\n")
 }
 
 func (m Model) viewGhCLI() string {
 	var lines []string
-	lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#8B949E")).Render("This will temporarily suspend jj-tui and run:"))
+	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("This will temporarily suspend jj-tui and run:"))
 	lines = append(lines, "")
 	lines = append(lines, lipgloss.NewStyle().Bold(true).Render("   gh auth login"))
 	lines = append(lines, "")
-	lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#8B949E")).Render("Complete the prompts in your terminal; jj-tui will then verify `gh auth token`."))
+	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("Complete the prompts in your terminal; jj-tui will then verify `gh auth token`."))
 	lines = append(lines, "")
 	runBtn := styles.ButtonStyle.Render("Run gh auth login (Enter)")
 	cancelBtn := styles.ButtonStyle.Render("Cancel (Esc)")
@@ -146,7 +146,7 @@ func (m Model) viewGhCLI() string {
 	}
 	lines = append(lines, "   "+lipgloss.JoinHorizontal(lipgloss.Left, runBtn, "  ", cancelBtn))
 	lines = append(lines, "")
-	lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#8B949E")).Render("Press Esc to cancel without running gh."))
+	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("Press Esc to cancel without running gh."))
 	return strings.Join(lines, "\n")
 }
 
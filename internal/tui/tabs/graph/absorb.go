@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// absorbPreviewMaxFiles caps how many files the absorb preview lists per commit.
+const absorbPreviewMaxFiles = 6
+
+// AbsorbPreviewLoadedMsg is sent when LoadAbsorbPreviewCmd finishes.
+type AbsorbPreviewLoadedMsg struct {
+	state.RepoResult
+	Result jj.AbsorbResult
+	Err    error
+}
+
+// AbsorbedMsg is sent when AbsorbCmd finishes; main reports the summary and reloads the graph.
+type AbsorbedMsg struct {
+	state.RepoResult
+	Result jj.AbsorbResult
+	Err    error
+}
+
+// LoadAbsorbPreviewCmd works out where `jj absorb` would move the working copy's changes.
+func LoadAbsorbPreviewCmd(svc *jj.Service) tea.Cmd {
+	return func() tea.Msg {
+		res, err := svc.PreviewAbsorb(context.Background())
+		return AbsorbPreviewLoadedMsg{Result: res, Err: err}
+	}
+}
+
+// AbsorbCmd runs `jj absorb` on the working copy.
+func AbsorbCmd(svc *jj.Service) tea.Cmd {
+	return func() tea.Msg {
+		res, err := svc.Absorb(context.Background())
+		return AbsorbedMsg{Result: res, Err: err}
+	}
+}
+
+// AbsorbSummary is the status line after an absorb, e.g. "Absorbed into zsuskuln, kkmpptxz ·
+// 1 file left in the working copy".
+func AbsorbSummary(res jj.AbsorbResult) string {
+	if len(res.Destinations) == 0 {
+		return "Nothing to absorb: no mutable ancestor last changed these lines"
+	}
+	ids := make([]string, len(res.Destinations))
+	for i, d := range res.Destinations {
+		ids[i] = d.ChangeID
+	}
+	s := "Absorbed into " + strings.Join(ids, ", ")
+	switch n := len(res.Remaining); n {
+	case 0:
+		s += " · working copy is now empty"
+	case 1:
+		s += " · 1 file left in the working copy"
+	default:
+		s += fmt.Sprintf(" · %d files left in the working copy", n)
+	}
+	return s
+}
+
+// setAbsorbPreview opens the absorb preview for a loaded result. Nothing opens when there is
+// nothing to absorb; main reports that in the status line instead.
+func (m *GraphModel) setAbsorbPreview(msg AbsorbPreviewLoadedMsg) {
+	if msg.Err != nil || len(msg.Result.Destinations) == 0 {
+		m.absorbPreview = nil
+		return
+	}
+	res := msg.Result
+	m.absorbPreview = &res
+}
+
+// handleAbsorbPreviewKey handles keys while the absorb preview is open: Enter or y applies, Esc,
+// n, or q cancels.
+func (m GraphModel) handleAbsorbPreviewKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		m.absorbPreview = nil
+		return m, &Request{Absorb: true}, nil
+	case "esc", "n", "q":
+		m.absorbPreview = nil
+	}
+	return m, nil, nil
+}
+
+// renderAbsorbPreview renders the absorb preview: each commit that would receive changes with
+// its files, then what stays in the working copy.
+func (m *GraphModel) renderAbsorbPreview() string {
+	res := m.absorbPreview
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(styles.ColorSecondary).Render("Absorb working copy"),
+		muted.Render("Each hunk moves into the mutable ancestor that last changed its lines."),
+		"",
+	}
+	for _, d := range res.Destinations {
+		lines = append(lines, CommitIDStyle.Render(d.ChangeID)+" "+d.Summary)
+		for i, f := range d.Files {
+			if i == absorbPreviewMaxFiles {
+				lines = append(lines, muted.Render(fmt.Sprintf("    … and %d more", len(d.Files)-i)))
+				break
+			}
+			lines = append(lines, "    "+f)
+		}
+	}
+	if res.Rebased > 0 {
+		lines = append(lines, "", muted.Render(fmt.Sprintf("%d descendant commit(s) will be rebased.", res.Rebased)))
+	}
+	lines = append(lines, "")
+	if len(res.Remaining) == 0 {
+		lines = append(lines, muted.Render("Nothing stays in the working copy."))
+	} else {
+		lines = append(lines, "Stays in the working copy:")
+		for i, f := range res.Remaining {
+			if i == absorbPreviewMaxFiles {
+				lines = append(lines, muted.Render(fmt.Sprintf("    … and %d more", len(res.Remaining)-i)))
+				break
+			}
+			lines = append(lines, "    "+f)
+		}
+	}
+	lines = append(lines, "", muted.Render("Enter to absorb · Esc to cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// IsAbsorbPreviewOpen reports whether the absorb preview owns the keyboard.
+func (m *GraphModel) IsAbsorbPreviewOpen() bool {
+	return m.absorbPreview != nil
+}
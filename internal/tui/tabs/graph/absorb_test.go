@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// i in the graph pane previews absorbing the working copy; the preview opens only when something
+// would move, and Enter asks to apply it.
+func TestGraphModel_AbsorbPreview(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{{ChangeID: "a"}}}}
+	m.graphFocused = true
+	_, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if req == nil || !req.AbsorbPreview {
+		t.Fatalf("graph pane i = %+v, want AbsorbPreview", req)
+	}
+	ctx := &RequestContext{JJService: &jj.Service{}, Repository: m.repository}
+	if res := HandleRequest(*req, ctx); res.Cmd != nil || res.Status == "" {
+		t.Fatalf("absorb outside the working copy = %+v, want a status only", res)
+	}
+	m.repository.Graph.Commits[0].IsWorking = true
+	if res := HandleRequest(*req, ctx); res.Cmd == nil || !res.Loading {
+		t.Fatalf("absorb preview in the working copy = %+v, want a loading command", res)
+	}
+
+	m.setAbsorbPreview(AbsorbPreviewLoadedMsg{})
+	if m.IsAbsorbPreviewOpen() {
+		t.Fatal("nothing to absorb should not open the preview")
+	}
+	m.setAbsorbPreview(AbsorbPreviewLoadedMsg{Result: jj.AbsorbResult{
+		Destinations: []jj.AbsorbDestination{{ChangeID: "zsuskuln", Summary: "Fix parser", Files: []string{"src/a.go"}}},
+		Remaining:    []string{"new.txt"},
+	}})
+	if !m.IsAbsorbPreviewOpen() {
+		t.Fatal("preview should open")
+	}
+	view := ansi.Strip(m.renderAbsorbPreview())
+	for _, want := range []string{"zsuskuln Fix parser", "src/a.go", "Stays in the working copy:", "new.txt"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("preview missing %q:\n%s", want, view)
+		}
+	}
+	updated, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || !req.Absorb || updated.IsAbsorbPreviewOpen() {
+		t.Fatalf("Enter = %+v, open=%v; want Absorb and the preview closed", req, updated.IsAbsorbPreviewOpen())
+	}
+	m.setAbsorbPreview(AbsorbPreviewLoadedMsg{Result: jj.AbsorbResult{Destinations: []jj.AbsorbDestination{{ChangeID: "z"}}}})
+	if updated, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); req != nil || updated.IsAbsorbPreviewOpen() {
+		t.Fatalf("Esc = %+v, want the preview closed without a request", req)
+	}
+}
+
+func TestAbsorbSummary(t *testing.T) {
+	tests := []struct {
+		res  jj.AbsorbResult
+		want string
+	}{
+		{jj.AbsorbResult{}, "Nothing to absorb: no mutable ancestor last changed these lines"},
+		{jj.AbsorbResult{Destinations: []jj.AbsorbDestination{{ChangeID: "zs"}, {ChangeID: "kk"}}}, "Absorbed into zs, kk · working copy is now empty"},
+		{jj.AbsorbResult{Destinations: []jj.AbsorbDestination{{ChangeID: "zs"}}, Remaining: []string{"a", "b"}}, "Absorbed into zs · 2 files left in the working copy"},
+	}
+	for _, tt := range tests {
+		if got := AbsorbSummary(tt.res); got != tt.want {
+			t.Errorf("AbsorbSummary(%+v) = %q, want %q", tt.res, got, tt.want)
+		}
+	}
+}
@@ -10,6 +10,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/data"
 	"github.com/madicen/jj-tui/internal/tui/state"
@@ -39,7 +41,7 @@ func HandleRequest(r Request, ctx *RequestContext) Result {
 		commit := ctx.Repository.Graph.Commits[idx]
 		return Result{FollowUp: FollowUpLoadChangedFiles, ChangeID: commit.ChangeID, CommitIndex: idx}
 	}
-	if ctx.JJService == nil && !r.StartEditDescription && !r.StartRebaseMode && !r.StartMergeMode && r.ResolveDivergent == nil && !r.DragRebase {
+	if ctx.JJService == nil && !r.StartEditDescription && !r.StartRebaseMode && !r.StartMergeMode && !r.StartDuplicateMode && r.ResolveDivergent == nil && !r.DragRebase {
 		if r.Checkout {
 			return Result{Status: "Cannot edit: not in a jj repository"}
 		}
@@ -48,6 +50,51 @@ func HandleRequest(r Request, ctx *RequestContext) Result {
 		}
 		return Result{}
 	}
+	if r.LoadAliases {
+		return Result{Cmd: LoadAliasesCmd(ctx.JJService)}
+	}
+	if r.LoadTrash {
+		return Result{Cmd: LoadTrashCmd(ctx.JJService)}
+	}
+	if r.RestoreTrash != nil {
+		return Result{Cmd: RestoreFromTrashCmd(ctx.JJService, *r.RestoreTrash), SuccessStatus: "Restoring " + r.RestoreTrash.ChangeID + "…", Loading: true}
+	}
+	if r.SearchGraph != nil {
+		return Result{Cmd: SearchGraphCmd(ctx.JJService, *r.SearchGraph), SuccessStatus: "Searching…"}
+	}
+	if r.RunAlias != nil {
+		return Result{Cmd: RunAliasCmd(ctx.JJService, *r.RunAlias), SuccessStatus: "Running jj " + *r.RunAlias + "…", Loading: true}
+	}
+	if r.LoadHunkSplit != nil {
+		return Result{Cmd: LoadHunkSplitCmd(ctx.JJService, *r.LoadHunkSplit), SuccessStatus: i18n.T("status.loading_hunks")}
+	}
+	if r.MoveHunks != nil {
+		mv := *r.MoveHunks
+		status := i18n.T("status.moving_hunks_parent", mv.Count)
+		if mv.ToChild {
+			status = i18n.T("status.moving_hunks_child", mv.Count)
+		}
+		return Result{Cmd: MoveHunksCmd(ctx.JJService, mv), SuccessStatus: status, Loading: true}
+	}
+	if r.AbsorbPreview {
+		if !ctx.IsSelectedCommitValid() || !ctx.Repository.Graph.Commits[ctx.SelectedCommit].IsWorking {
+			return Result{Status: "Absorb works on the working copy: select @"}
+		}
+		return Result{Cmd: LoadAbsorbPreviewCmd(ctx.JJService), SuccessStatus: "Previewing absorb…", Loading: true}
+	}
+	if r.Absorb {
+		return Result{Cmd: AbsorbCmd(ctx.JJService), SuccessStatus: "Absorbing…", Loading: true}
+	}
+	if r.LoadStackFiles != nil {
+		return Result{Cmd: LoadStackFilesCmd(ctx.JJService, *r.LoadStackFiles), SuccessStatus: "Loading stack files…"}
+	}
+	if r.MergeCommits != nil {
+		return Result{Cmd: NewMergeCmd(ctx.JJService, *r.MergeCommits), SuccessStatus: fmt.Sprintf("Merging %d commits…", len(r.MergeCommits.ChangeIDs)), Loading: true}
+	}
+	if r.BulkDescribe != nil {
+		n := len(r.BulkDescribe.ChangeIDs)
+		return Result{Cmd: BulkDescribeCmd(ctx.JJService, *r.BulkDescribe), SuccessStatus: fmt.Sprintf("Updating %d descriptions…", n), Loading: true}
+	}
 	if r.Checkout {
 		cmd, status := executeCheckout(ctx)
 		return Result{Cmd: cmd, Status: status, SuccessStatus: "Editing working copy…", Loading: true}
@@ -67,17 +114,54 @@ func HandleRequest(r Request, ctx *RequestContext) Result {
 		return Result{Cmd: cmd, Status: status, SuccessStatus: "Abandoning commit…", Loading: true}
 	}
 	if r.PerformRebase {
-		cmd, status := executePerformRebase(r.RebaseDestIndex, ctx)
+		cmd, status, progress := executePerformRebase(r, ctx)
 		if status != "" {
 			return Result{Status: status}
 		}
-		if cmd != nil && ctx.RebaseSourceCommit >= 0 && ctx.RebaseSourceCommit < len(ctx.Repository.Graph.Commits) &&
-			r.RebaseDestIndex >= 0 && r.RebaseDestIndex < len(ctx.Repository.Graph.Commits) {
-			src := ctx.Repository.Graph.Commits[ctx.RebaseSourceCommit]
-			dst := ctx.Repository.Graph.Commits[r.RebaseDestIndex]
-			return Result{Cmd: cmd, SuccessStatus: fmt.Sprintf("Rebasing %s onto %s...", src.ShortID, dst.ShortID), PerformRebase: true, Loading: true}
+		return Result{Cmd: cmd, SuccessStatus: progress, PerformRebase: true, Loading: true}
+	}
+	if r.Parallelize != nil {
+		if ctx.Repository == nil {
+			return Result{}
 		}
-		return Result{Cmd: cmd, PerformRebase: true, Loading: true}
+		if status := parallelizeStatus(ctx.Repository, r.Parallelize); status != "" {
+			return Result{Status: status}
+		}
+		return Result{Cmd: ParallelizeCmd(ctx.JJService, r.Parallelize), SuccessStatus: fmt.Sprintf("Parallelizing %d commits…", len(r.Parallelize)), Loading: true}
+	}
+	if r.InsertBefore || r.InsertAfter {
+		if !ctx.IsSelectedCommitValid() {
+			return Result{}
+		}
+		commit := ctx.Repository.Graph.Commits[ctx.SelectedCommit]
+		if r.InsertBefore && commit.Immutable {
+			return Result{Status: "Cannot insert before an immutable commit"}
+		}
+		where := "after"
+		if r.InsertBefore {
+			where = "before"
+		}
+		return Result{Cmd: InsertEmptyCmd(ctx.JJService, commit.ChangeID, commit.ShortID, r.InsertBefore), SuccessStatus: fmt.Sprintf("Inserting an empty commit %s %s…", where, commit.ShortID), Loading: true}
+	}
+	if r.PerformDuplicate {
+		return executePerformDuplicate(r.RebaseDestIndex, ctx)
+	}
+	if r.Backout {
+		if !ctx.IsSelectedCommitValid() {
+			return Result{}
+		}
+		commit := ctx.Repository.Graph.Commits[ctx.SelectedCommit]
+		return Result{Cmd: BackoutCmd(ctx.JJService, commit.ChangeID, commit.ShortID), SuccessStatus: fmt.Sprintf("Backing out %s…", commit.ShortID), Loading: true}
+	}
+	if r.MoveWorkOnto {
+		if !ctx.IsSelectedCommitValid() {
+			return Result{}
+		}
+		commit := ctx.Repository.Graph.Commits[ctx.SelectedCommit]
+		if commit.IsWorking {
+			return Result{Status: "Select the commit to move your work onto (not @)"}
+		}
+		return Result{Cmd: MoveWorkCmd(ctx.JJService, commit.ChangeID, commit.ShortID), SuccessStatus: fmt.Sprintf("Moving your work onto %s…", commit.ShortID), Loading: true}
 	}
 	if r.DragRebase {
 		if ctx.JJService == nil {
@@ -136,6 +220,16 @@ func HandleRequest(r Request, ctx *RequestContext) Result {
 		}
 		return Result{Status: status}
 	}
+	if r.ShowCommit != nil {
+		return Result{Cmd: ShowCommitCmd(ctx.JJService, *r.ShowCommit), SuccessStatus: "Loading jj show…"}
+	}
+	if r.AbsorbFile {
+		cmd, status := executeAbsorbFile(ctx)
+		if cmd != nil {
+			return Result{Cmd: cmd, Status: status, SuccessStatus: "Absorbing file into ancestor…", Loading: true}
+		}
+		return Result{Status: status}
+	}
 	if r.ViewFileDiff {
 		if ctx.JJService == nil {
 			return Result{Status: "Cannot show diff: jj not available"}
@@ -158,6 +252,25 @@ func HandleRequest(r Request, ctx *RequestContext) Result {
 			FileDiffPath: ctx.ChangedFiles[ctx.SelectedFile].Path,
 		}
 	}
+	if r.FileHistory {
+		if ctx.GraphFocused || ctx.SelectedFile < 0 || ctx.SelectedFile >= len(ctx.ChangedFiles) {
+			return Result{Status: "Select a file in the changed-files list"}
+		}
+		if !ctx.IsSelectedCommitValid() {
+			return Result{Status: "No commit selected"}
+		}
+		return Result{
+			FollowUp:     FollowUpFileHistory,
+			CommitIndex:  ctx.SelectedCommit,
+			FileDiffPath: ctx.ChangedFiles[ctx.SelectedFile].Path,
+		}
+	}
+	if r.BrowseFiles {
+		if !ctx.IsSelectedCommitValid() {
+			return Result{Status: "No commit selected"}
+		}
+		return Result{FollowUp: FollowUpBrowseFiles, CommitIndex: ctx.SelectedCommit}
+	}
 	if r.OpenInExternalEditor {
 		if ctx.GraphFocused {
 			return Result{Status: "Press Tab to focus files, select a file, then press O"}
@@ -259,6 +372,12 @@ func HandleRequest(r Request, ctx *RequestContext) Result {
 		}
 		return Result{FollowUp: FollowUpStartRebaseMode}
 	}
+	if r.StartDuplicateMode {
+		if !ctx.IsSelectedCommitValid() {
+			return Result{}
+		}
+		return Result{FollowUp: FollowUpStartDuplicateMode}
+	}
 	if r.StartMergeMode {
 		if !ctx.IsSelectedCommitValid() {
 			return Result{}
@@ -289,12 +408,15 @@ func HandleRequest(r Request, ctx *RequestContext) Result {
 		if ctx.CreatePRBranch != "" && isDefaultBranch(ctx.CreatePRBranch) {
 			return Result{Status: "Create PR is not available for main/master; use a feature branch"}
 		}
+		if !ctx.GitHubPermissions.Can(github.CapCreatePR) {
+			return Result{Status: "Can't create PRs: " + ctx.GitHubPermissions.Reason(github.CapCreatePR)}
+		}
 		emptyDescCommits := FindCommitsWithEmptyDescriptions(ctx.Repository, ctx.SelectedCommit)
 		if len(emptyDescCommits) > 0 {
 			return Result{
 				FollowUp:       FollowUpShowEmptyDescWarning,
-				WarningTitle:   "Commits Need Descriptions",
-				WarningMessage: "GitHub requires commit descriptions. Please add descriptions before creating a PR.",
+				WarningTitle:   i18n.T("modal.warning.need_descriptions"),
+				WarningMessage: i18n.T("modal.warning.need_descriptions_create"),
 				WarningCommits: emptyDescCommits,
 			}
 		}
@@ -308,8 +430,8 @@ func HandleRequest(r Request, ctx *RequestContext) Result {
 		if len(emptyDescCommits) > 0 {
 			return Result{
 				FollowUp:       FollowUpShowEmptyDescWarning,
-				WarningTitle:   "Commits Need Descriptions",
-				WarningMessage: "GitHub requires commit descriptions. Please add descriptions before updating the PR.",
+				WarningTitle:   i18n.T("modal.warning.need_descriptions"),
+				WarningMessage: i18n.T("modal.warning.need_descriptions_update"),
 				WarningCommits: emptyDescCommits,
 			}
 		}
@@ -366,21 +488,61 @@ func executeAbandon(ctx *RequestContext) (tea.Cmd, string) {
 	if commit.Divergent {
 		return nil, "__divergent__"
 	}
-	return Abandon(ctx.JJService, commit.ChangeID), ""
+	return Abandon(ctx.JJService, commit.ChangeID, commit.Summary), ""
+}
+
+// executePerformRebase returns the rebase command, a status when it can't run, and the
+// progress status shown while it runs.
+func executePerformRebase(r Request, ctx *RequestContext) (tea.Cmd, string, string) {
+	commits := ctx.Repository.Graph.Commits
+	if !ctx.IsSelectedCommitValid() || ctx.RebaseSourceCommit < 0 ||
+		ctx.RebaseSourceCommit >= len(commits) ||
+		r.RebaseDestIndex < 0 || r.RebaseDestIndex >= len(commits) {
+		return nil, "", ""
+	}
+	dests := rebaseDestinations(r, len(commits))
+	if slices.Contains(dests, ctx.RebaseSourceCommit) {
+		return nil, "Cannot rebase commit onto itself", ""
+	}
+	if r.RebaseInsertAfter && r.RebaseFlag == jj.RebaseBranch {
+		return nil, "Cannot insert a whole branch after a commit", ""
+	}
+	sourceCommit := commits[ctx.RebaseSourceCommit]
+	if len(dests) == 1 && !r.RebaseInsertAfter && (r.RebaseFlag == "" || r.RebaseFlag == jj.RebaseSource) {
+		destCommit := commits[dests[0]]
+		return Rebase(ctx.JJService, sourceCommit.ChangeID, destCommit.ChangeID), "",
+			fmt.Sprintf("Rebasing %s onto %s...", sourceCommit.ShortID, destCommit.ShortID)
+	}
+	opts := jj.RebaseOptions{Mode: r.RebaseFlag, InsertAfter: r.RebaseInsertAfter}
+	shortIDs := make([]string, 0, len(dests))
+	for _, d := range dests {
+		opts.Destinations = append(opts.Destinations, commits[d].ChangeID)
+		shortIDs = append(shortIDs, commits[d].ShortID)
+	}
+	verb := "onto"
+	if r.RebaseInsertAfter {
+		verb = "after"
+	}
+	progress := fmt.Sprintf("Rebasing %s (%s) %s %s...", sourceCommit.ShortID, rebaseFlagOrDefault(r.RebaseFlag), verb, strings.Join(shortIDs, ", "))
+	return RebaseWithOptions(ctx.JJService, sourceCommit.ChangeID, opts), "", progress
 }
 
-func executePerformRebase(destIndex int, ctx *RequestContext) (tea.Cmd, string) {
+// executePerformDuplicate copies the destination-mode source onto destIndex. Unlike rebase, the
+// source may be immutable and may be duplicated onto itself (the copy becomes its child).
+func executePerformDuplicate(destIndex int, ctx *RequestContext) Result {
 	if !ctx.IsSelectedCommitValid() || ctx.RebaseSourceCommit < 0 ||
 		ctx.RebaseSourceCommit >= len(ctx.Repository.Graph.Commits) ||
 		destIndex < 0 || destIndex >= len(ctx.Repository.Graph.Commits) {
-		return nil, ""
+		return Result{}
 	}
-	if ctx.RebaseSourceCommit == destIndex {
-		return nil, "Cannot rebase commit onto itself"
+	src := ctx.Repository.Graph.Commits[ctx.RebaseSourceCommit]
+	dst := ctx.Repository.Graph.Commits[destIndex]
+	return Result{
+		Cmd:           DuplicateCmd(ctx.JJService, src.ChangeID, dst.ChangeID, src.ShortID, dst.ShortID),
+		SuccessStatus: fmt.Sprintf("Duplicating %s onto %s…", src.ShortID, dst.ShortID),
+		PerformRebase: true,
+		Loading:       true,
 	}
-	sourceCommit := ctx.Repository.Graph.Commits[ctx.RebaseSourceCommit]
-	destCommit := ctx.Repository.Graph.Commits[destIndex]
-	return Rebase(ctx.JJService, sourceCommit.ChangeID, destCommit.ChangeID), ""
 }
 
 func executePerformMerge(sourceIndex int, ctx *RequestContext) (tea.Cmd, string) {
@@ -494,6 +656,23 @@ func executeRevertFile(ctx *RequestContext) (tea.Cmd, string) {
 	return RevertFile(ctx.JJService, commit.ChangeID, ctx.ChangedFiles[ctx.SelectedFile].Path), ""
 }
 
+func executeAbsorbFile(ctx *RequestContext) (tea.Cmd, string) {
+	if ctx.GraphFocused || len(ctx.ChangedFiles) == 0 {
+		return nil, ""
+	}
+	if ctx.SelectedFile < 0 || ctx.SelectedFile >= len(ctx.ChangedFiles) {
+		return nil, ""
+	}
+	if !ctx.IsSelectedCommitValid() {
+		return nil, ""
+	}
+	commit := ctx.Repository.Graph.Commits[ctx.SelectedCommit]
+	if !commit.IsWorking {
+		return nil, "Absorb into ancestor works on the working copy's files"
+	}
+	return AbsorbFile(ctx.JJService, commit.ChangeID, ctx.ChangedFiles[ctx.SelectedFile].Path), ""
+}
+
 func executeNewCommit(ctx *RequestContext) (tea.Cmd, string) {
 	parentCommitID := ""
 	if ctx.IsSelectedCommitValid() {
@@ -609,6 +788,12 @@ func ApplyResult(res Result, graphModel *GraphModel, ctx *RequestContext, app *s
 			app.StatusMessage = RebaseModeStartMessage(ctx.Repository.Graph.Commits[ctx.SelectedCommit].ShortID)
 		}
 		return nil
+	case FollowUpStartDuplicateMode:
+		if ctx != nil && ctx.Repository != nil && ctx.SelectedCommit >= 0 && ctx.SelectedCommit < len(ctx.Repository.Graph.Commits) {
+			graphModel.StartDuplicateMode(ctx.SelectedCommit)
+			app.StatusMessage = DuplicateModeStartMessage(ctx.Repository.Graph.Commits[ctx.SelectedCommit].ShortID)
+		}
+		return nil
 	case FollowUpStartMergeMode:
 		if ctx != nil && ctx.Repository != nil && ctx.SelectedCommit >= 0 && ctx.SelectedCommit < len(ctx.Repository.Graph.Commits) {
 			graphModel.StartMergeMode(ctx.SelectedCommit)
@@ -643,6 +828,17 @@ func ApplyResult(res Result, graphModel *GraphModel, ctx *RequestContext, app *s
 			return state.NavigateTarget{Kind: state.NavigateOpenFileDiff, Commit: c, FileDiffPath: res.FileDiffPath}.Cmd()
 		}
 		return nil
+	case FollowUpFileHistory:
+		if ctx != nil && ctx.Repository != nil && res.CommitIndex >= 0 && res.CommitIndex < len(ctx.Repository.Graph.Commits) && strings.TrimSpace(res.FileDiffPath) != "" {
+			c := ctx.Repository.Graph.Commits[res.CommitIndex]
+			return state.NavigateTarget{Kind: state.NavigateOpenFileHistory, Commit: c, FileHistoryPath: res.FileDiffPath}.Cmd()
+		}
+		return nil
+	case FollowUpBrowseFiles:
+		if ctx != nil && ctx.Repository != nil && res.CommitIndex >= 0 && res.CommitIndex < len(ctx.Repository.Graph.Commits) {
+			return state.NavigateTarget{Kind: state.NavigateOpenFileTree, Commit: ctx.Repository.Graph.Commits[res.CommitIndex]}.Cmd()
+		}
+		return nil
 	case FollowUpUpdatePR:
 		if ctx == nil || ctx.Repository == nil || !ctx.IsSelectedCommitValid() {
 			return nil
@@ -730,8 +926,18 @@ func Checkout(svc *jj.Service, changeID string) tea.Cmd {
 
 // Squash squashes the specified commit into its parent.
 func Squash(svc *jj.Service, changeID string) tea.Cmd {
+	return squashCmd(svc, func(ctx context.Context) error { return svc.SquashCommit(ctx, changeID) })
+}
+
+// SquashWithMessage squashes the specified commit into its parent with message, the one a
+// confirmation prompt showed.
+func SquashWithMessage(svc *jj.Service, changeID, message string) tea.Cmd {
+	return squashCmd(svc, func(ctx context.Context) error { return svc.SquashCommitWithMessage(ctx, changeID, message) })
+}
+
+func squashCmd(svc *jj.Service, squash func(context.Context) error) tea.Cmd {
 	return func() tea.Msg {
-		if err := svc.SquashCommit(context.Background(), changeID); err != nil {
+		if err := squash(context.Background()); err != nil {
 			return util.ErrorMsg{Err: fmt.Errorf("failed to squash: %w", err)}
 		}
 		repo, err := svc.GetRepository(context.Background(), "")
@@ -742,11 +948,27 @@ func Squash(svc *jj.Service, changeID string) tea.Cmd {
 	}
 }
 
-// Abandon abandons the specified commit.
-func Abandon(svc *jj.Service, changeID string) tea.Cmd {
+// BulkDescribeCmd adds the request's prefix/suffix to each change's subject and sends
+// DescriptionsAffixedMsg.
+func BulkDescribeCmd(svc *jj.Service, req BulkDescribe) tea.Cmd {
 	return func() tea.Msg {
-		if err := svc.AbandonCommit(context.Background(), changeID); err != nil {
-			return util.ErrorMsg{Err: fmt.Errorf("failed to abandon: %w", err)}
+		n, err := svc.AffixDescriptions(context.Background(), req.ChangeIDs, req.Prefix, req.Suffix)
+		return DescriptionsAffixedMsg{Updated: n, Total: len(req.ChangeIDs), Err: err}
+	}
+}
+
+// Abandon abandons the specified commit, keeping it in the trash so it can be restored (U).
+func Abandon(svc *jj.Service, changeID, summary string) tea.Cmd {
+	return func() tea.Msg {
+		return AbandonedMsg{ChangeID: changeID, Err: svc.AbandonToTrash(context.Background(), changeID, summary)}
+	}
+}
+
+// Rebase rebases the source commit onto the destination.
+func Rebase(svc *jj.Service, sourceChangeID, destChangeID string) tea.Cmd {
+	return func() tea.Msg {
+		if err := svc.RebaseCommit(context.Background(), sourceChangeID, destChangeID); err != nil {
+			return util.ErrorMsg{Err: fmt.Errorf("failed to rebase: %w", err)}
 		}
 		repo, err := svc.GetRepository(context.Background(), "")
 		if err != nil {
@@ -756,10 +978,10 @@ func Abandon(svc *jj.Service, changeID string) tea.Cmd {
 	}
 }
 
-// Rebase rebases the source commit onto the destination.
-func Rebase(svc *jj.Service, sourceChangeID, destChangeID string) tea.Cmd {
+// RebaseWithOptions rebases the source commit as opts describe (-r/-s/-b, -d/-A, several parents).
+func RebaseWithOptions(svc *jj.Service, sourceChangeID string, opts jj.RebaseOptions) tea.Cmd {
 	return func() tea.Msg {
-		if err := svc.RebaseCommit(context.Background(), sourceChangeID, destChangeID); err != nil {
+		if err := svc.RebaseWithOptions(context.Background(), sourceChangeID, opts); err != nil {
 			return util.ErrorMsg{Err: fmt.Errorf("failed to rebase: %w", err)}
 		}
 		repo, err := svc.GetRepository(context.Background(), "")
@@ -826,6 +1048,32 @@ func RevertFile(svc *jj.Service, commitID, filePath string) tea.Cmd {
 	}
 }
 
+// AbsorbFile squashes a file's changes into the ancestor commit that last changed it.
+func AbsorbFile(svc *jj.Service, commitID, filePath string) tea.Cmd {
+	return func() tea.Msg {
+		target, err := svc.AbsorbFile(context.Background(), commitID, filePath)
+		if err != nil {
+			return util.ErrorMsg{Err: fmt.Errorf("failed to absorb file: %w", err)}
+		}
+		repo, err := svc.GetRepository(context.Background(), "")
+		if err != nil {
+			return util.ErrorMsg{Err: err}
+		}
+		return FileMoveCompletedMsg{Repository: repo, FilePath: filePath, Into: target.ShortID}
+	}
+}
+
+// ShowCommitCmd opens `jj show` for commitID in the pager.
+func ShowCommitCmd(svc *jj.Service, commitID string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := svc.ShowRevision(context.Background(), commitID)
+		if err != nil {
+			return util.ErrorMsg{Err: err}
+		}
+		return state.NavigateMsg{Target: state.NavigateTarget{Kind: state.NavigateOpenPager, PagerTitle: "jj show " + commitID, PagerContent: out}}
+	}
+}
+
 // FindPRBranchForCommit finds the PR branch for a commit (BFS over ancestors for an open PR head branch).
 func FindPRBranchForCommit(repo *internal.Repository, commitIndex int) string {
 	if repo == nil || commitIndex < 0 || commitIndex >= len(repo.Graph.Commits) {
@@ -896,6 +1144,9 @@ func HandleFileMoveCompletedMsg(input FileMoveInput, app *state.AppState) tea.Cm
 		directionText = "new child commit"
 	}
 	app.StatusMessage = fmt.Sprintf("Moved %s to %s", input.FilePath, directionText)
+	if input.Into != "" {
+		app.StatusMessage = fmt.Sprintf("Absorbed %s into %s", input.FilePath, input.Into)
+	}
 	app.Loading = false
 	return nil
 }
@@ -0,0 +1,233 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/mattn/go-runewidth"
+)
+
+// aliasPickerMaxRows caps how many entries the alias picker lists at once.
+const aliasPickerMaxRows = 12
+
+// AliasesLoadedMsg is sent when LoadAliasesCmd finishes.
+type AliasesLoadedMsg struct {
+	state.RepoResult
+	Commands []jj.Alias
+	Revsets  []jj.Alias
+	Err      error
+}
+
+// LoadAliasesCmd reads the repo's command and revset aliases from jj's config.
+func LoadAliasesCmd(svc *jj.Service) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		commands, revsets, err := svc.ListAliases(context.Background())
+		return AliasesLoadedMsg{Commands: commands, Revsets: revsets, Err: err}
+	}
+}
+
+// RevsetAliasChangedMsg is sent when a revset alias is picked as the graph filter (Name "" =
+// cleared). Main sets it on the jj service and reloads the graph.
+type RevsetAliasChangedMsg struct {
+	Name string
+}
+
+// AliasRanMsg is sent when RunAliasCmd finishes; main shows Output in the pager and reloads.
+type AliasRanMsg struct {
+	state.RepoResult
+	Name   string
+	Output string
+	Err    error
+}
+
+// RunAliasCmd runs the command alias name (`jj NAME`).
+func RunAliasCmd(svc *jj.Service, name string) tea.Cmd {
+	if svc == nil || name == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		out, err := svc.RunAlias(context.Background(), name)
+		return AliasRanMsg{Name: name, Output: out, Err: err}
+	}
+}
+
+// aliasEntry is one row of the alias picker.
+type aliasEntry struct {
+	revset bool // revset alias (filters the graph) vs command alias (runs jj NAME)
+	clear  bool // "show all commits" row, offered while a revset alias filters the graph
+	alias  jj.Alias
+}
+
+// aliasPickerState is the open : picker: a filter input over the repo's jj aliases.
+type aliasPickerState struct {
+	input    textinput.Model
+	loaded   bool
+	err      string
+	commands []jj.Alias
+	revsets  []jj.Alias
+	cursor   int
+}
+
+// openAliasPicker opens the alias picker and asks main to load the aliases.
+func (m GraphModel) openAliasPicker() (GraphModel, *Request, tea.Cmd) {
+	input := textinput.New()
+	input.Placeholder = "filter aliases"
+	input.CharLimit = 60
+	input.Width = 40
+	cmd := input.Focus()
+	m.aliasPicker = &aliasPickerState{input: input}
+	return m, &Request{LoadAliases: true}, tea.Batch(cmd, textinput.Blink)
+}
+
+// setAliases fills the open picker with loaded aliases.
+func (m *GraphModel) setAliases(msg AliasesLoadedMsg) {
+	if m.aliasPicker == nil {
+		return
+	}
+	m.aliasPicker.loaded = true
+	m.aliasPicker.commands = msg.Commands
+	m.aliasPicker.revsets = msg.Revsets
+	if msg.Err != nil {
+		m.aliasPicker.err = msg.Err.Error()
+	}
+}
+
+// aliasEntries returns the picker rows matching the typed filter: revset aliases first, then
+// command aliases.
+func (m *GraphModel) aliasEntries() []aliasEntry {
+	st := m.aliasPicker
+	query := strings.ToLower(strings.TrimSpace(st.input.Value()))
+	match := func(a jj.Alias) bool {
+		return query == "" || strings.Contains(strings.ToLower(a.Name), query) || strings.Contains(strings.ToLower(a.Definition), query)
+	}
+	var out []aliasEntry
+	if m.revsetAlias != "" && query == "" {
+		out = append(out, aliasEntry{revset: true, clear: true})
+	}
+	for _, a := range st.revsets {
+		if match(a) {
+			out = append(out, aliasEntry{revset: true, alias: a})
+		}
+	}
+	for _, a := range st.commands {
+		if match(a) {
+			out = append(out, aliasEntry{alias: a})
+		}
+	}
+	return out
+}
+
+// handleAliasPickerKey handles keys while the alias picker is open. Enter on a revset alias
+// filters the graph to it; on a command alias it asks main to run it.
+func (m GraphModel) handleAliasPickerKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd) {
+	st := m.aliasPicker
+	entries := m.aliasEntries()
+	switch msg.String() {
+	case "esc":
+		m.aliasPicker = nil
+		return m, nil, nil
+	case "up", "ctrl+p":
+		if st.cursor > 0 {
+			st.cursor--
+		}
+		return m, nil, nil
+	case "down", "ctrl+n":
+		if st.cursor < len(entries)-1 {
+			st.cursor++
+		}
+		return m, nil, nil
+	case "enter":
+		if st.cursor < 0 || st.cursor >= len(entries) {
+			return m, nil, nil
+		}
+		e := entries[st.cursor]
+		m.aliasPicker = nil
+		if !e.revset {
+			name := e.alias.Name
+			return m, &Request{RunAlias: &name}, nil
+		}
+		m.revsetAlias = e.alias.Name
+		name := m.revsetAlias
+		return m, nil, func() tea.Msg { return RevsetAliasChangedMsg{Name: name} }
+	}
+	var cmd tea.Cmd
+	st.input, cmd = st.input.Update(msg)
+	st.cursor = max(0, min(st.cursor, len(m.aliasEntries())-1))
+	return m, nil, cmd
+}
+
+// renderAliasPicker renders the alias picker dialog.
+func (m *GraphModel) renderAliasPicker() string {
+	st := m.aliasPicker
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	selected := lipgloss.NewStyle().Background(styles.ColorCursor).Foreground(styles.ColorText)
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(styles.ColorSecondary).Render("jj aliases"),
+		st.input.View(),
+		"",
+	}
+	switch {
+	case !st.loaded:
+		lines = append(lines, muted.Render("Loading aliases…"))
+	case st.err != "":
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(st.err))
+	default:
+		entries := m.aliasEntries()
+		if len(entries) == 0 {
+			if len(st.commands)+len(st.revsets) == 0 {
+				lines = append(lines, muted.Render("No aliases in your jj config ([aliases] or [revset-aliases])."))
+			} else {
+				lines = append(lines, muted.Render("No aliases match."))
+			}
+		}
+		start := max(0, st.cursor-aliasPickerMaxRows+1)
+		for i := start; i < len(entries) && i < start+aliasPickerMaxRows; i++ {
+			e := entries[i]
+			var row string
+			switch {
+			case e.clear:
+				row = "revset   (all commits: clear " + m.revsetAlias + ")"
+			case e.revset:
+				row = "revset   " + e.alias.Name
+				if e.alias.Name == m.revsetAlias {
+					row += " ✓"
+				}
+			default:
+				row = "command  jj " + e.alias.Name
+			}
+			def := ""
+			if !e.clear {
+				def = " " + runewidth.Truncate(e.alias.Definition, 40, "…")
+			}
+			if i == st.cursor {
+				lines = append(lines, selected.Render(row)+muted.Render(def))
+			} else {
+				lines = append(lines, row+muted.Render(def))
+			}
+		}
+		if n := len(entries); n > aliasPickerMaxRows {
+			lines = append(lines, muted.Render(fmt.Sprintf("%d aliases", n)))
+		}
+	}
+	lines = append(lines, "", muted.Render("Enter: filter graph (revset) / run (command) · Esc to close"))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// IsAliasPickerOpen reports whether the alias picker owns the keyboard.
+func (m *GraphModel) IsAliasPickerOpen() bool {
+	return m.aliasPicker != nil
+}
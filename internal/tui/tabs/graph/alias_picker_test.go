@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// : lists revset aliases before command aliases; Enter on a revset alias filters the graph and
+// on a command alias asks main to run it.
+func TestGraphModel_AliasPicker(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	m, req, _ := m.handleKeyMsg(runes(":"))
+	if req == nil || !req.LoadAliases || !m.IsAliasPickerOpen() {
+		t.Fatalf(": should open the picker and load aliases, got %+v", req)
+	}
+	m.setAliases(AliasesLoadedMsg{
+		Commands: []jj.Alias{{Name: "sync", Definition: `["git", "fetch"]`}},
+		Revsets:  []jj.Alias{{Name: "stack()", Definition: `"trunk()..@"`}},
+	})
+
+	m, _, cmd := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsAliasPickerOpen() || m.revsetAlias != "stack()" || cmd == nil {
+		t.Fatalf("Enter on a revset alias should filter the graph: open=%v alias=%q", m.IsAliasPickerOpen(), m.revsetAlias)
+	}
+	if msg, ok := cmd().(RevsetAliasChangedMsg); !ok || msg.Name != "stack()" {
+		t.Fatalf("got %#v", cmd())
+	}
+
+	m, _, _ = m.handleKeyMsg(runes(":"))
+	m.setAliases(AliasesLoadedMsg{
+		Commands: []jj.Alias{{Name: "sync", Definition: `["git", "fetch"]`}},
+		Revsets:  []jj.Alias{{Name: "stack()", Definition: `"trunk()..@"`}},
+	})
+	if entries := m.aliasEntries(); len(entries) != 3 || !entries[0].clear {
+		t.Fatalf("an active revset alias should offer a clear row first: %+v", entries)
+	}
+	for _, r := range "syn" {
+		m, _, _ = m.handleKeyMsg(runes(string(r)))
+	}
+	m, req, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || req.RunAlias == nil || *req.RunAlias != "sync" {
+		t.Fatalf("Enter on a command alias should run it, got %+v", req)
+	}
+}
@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AuthorMode is how the graph treats commits by other authors. A cycles through the modes.
+type AuthorMode int
+
+const (
+	AuthorModeAll       AuthorMode = iota // every commit rendered normally
+	AuthorModeHighlight                   // other authors' commits dimmed and labelled with the author
+	AuthorModeMineOnly                    // graph narrowed to your commits (jj's mine()) plus @
+)
+
+// Label describes the mode for the graph header and status bar ("" for AuthorModeAll).
+func (a AuthorMode) Label() string {
+	switch a {
+	case AuthorModeHighlight:
+		return "mine highlighted"
+	case AuthorModeMineOnly:
+		return "only mine"
+	}
+	return ""
+}
+
+func (a AuthorMode) next() AuthorMode {
+	return (a + 1) % 3
+}
+
+// cycleAuthorMode moves to the next author mode and tells main so it can reload the graph when
+// the mine-only filter turns on or off.
+func (m GraphModel) cycleAuthorMode() (GraphModel, *Request, tea.Cmd) {
+	m.authorMode = m.authorMode.next()
+	mode := m.authorMode
+	return m, nil, func() tea.Msg { return AuthorModeChangedMsg{Mode: mode} }
+}
+
+// GetAuthorMode returns the graph's author mode.
+func (m *GraphModel) GetAuthorMode() AuthorMode {
+	return m.authorMode
+}
+
+// authorName shortens an author email to its local part for the highlight-mode label.
+func authorName(email string) string {
+	if name, _, ok := strings.Cut(email, "@"); ok && name != "" {
+		return name
+	}
+	return email
+}
@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+)
+
+// A cycles all → highlight → mine only → all, and highlight mode tags other authors' rows.
+func TestGraphModel_AuthorMode(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.repository = &internal.Repository{
+		Graph: internal.CommitGraph{
+			Commits: []internal.Commit{
+				{ID: "mine", ChangeID: "mine", Summary: "my change", Author: "me@example.com", Mine: true, IsWorking: true},
+				{ID: "theirs", ChangeID: "theirs", Summary: "their change", Author: "alice@example.com"},
+			},
+		},
+	}
+	m.selectedCommit = 0
+
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}}
+	want := []AuthorMode{AuthorModeHighlight, AuthorModeMineOnly, AuthorModeAll}
+	for _, mode := range want {
+		var cmd tea.Cmd
+		m, _, cmd = m.handleKeyMsg(key)
+		if m.GetAuthorMode() != mode {
+			t.Fatalf("author mode = %v, want %v", m.GetAuthorMode(), mode)
+		}
+		if msg, ok := cmd().(AuthorModeChangedMsg); !ok || msg.Mode != mode {
+			t.Fatalf("cmd sent %#v", msg)
+		}
+	}
+
+	m.authorMode = AuthorModeHighlight
+	graph := m.Graph(m.buildGraphData()).GraphContent
+	if !strings.Contains(graph, "· alice") || strings.Contains(graph, "· me") {
+		t.Fatalf("only other authors should be tagged:\n%s", graph)
+	}
+	if !strings.Contains(graph, "mine highlighted (A)") {
+		t.Fatalf("header should show the author mode:\n%s", graph)
+	}
+}
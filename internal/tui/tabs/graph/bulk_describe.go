@@ -0,0 +1,166 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// bulkDescribePreviewMax caps how many subjects the bulk describe dialog previews.
+const bulkDescribePreviewMax = 8
+
+// BulkDescribe asks main to add Prefix/Suffix to the subject of each change in ChangeIDs.
+type BulkDescribe struct {
+	ChangeIDs []string
+	Prefix    string
+	Suffix    string
+}
+
+// bulkDescribeState is the open B dialog: one input whose text goes before or after each
+// subject (Tab switches).
+type bulkDescribeState struct {
+	input  textinput.Model
+	suffix bool
+}
+
+// toggleMark marks or unmarks the selected commit for bulk actions (Space).
+func (m GraphModel) toggleMark() (GraphModel, *Request, tea.Cmd) {
+	if !m.graphFocused || m.repository == nil || m.selectedCommit < 0 || m.selectedCommit >= len(m.repository.Graph.Commits) {
+		return m, nil, nil
+	}
+	id := m.repository.Graph.Commits[m.selectedCommit].ChangeID
+	if m.marked[id] {
+		delete(m.marked, id)
+	} else {
+		if m.marked == nil {
+			m.marked = make(map[string]bool)
+		}
+		m.marked[id] = true
+	}
+	return m, nil, nil
+}
+
+// bulkTargets returns the commits B acts on, in graph order: the marked commits, or the
+// selected one when nothing is marked.
+func (m *GraphModel) bulkTargets() []internal.Commit {
+	if m.repository == nil {
+		return nil
+	}
+	var out []internal.Commit
+	for i, c := range m.repository.Graph.Commits {
+		if m.marked[c.ChangeID] || (len(m.marked) == 0 && i == m.selectedCommit) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// openBulkDescribe opens the prefix/suffix dialog for the bulk targets.
+func (m GraphModel) openBulkDescribe() (GraphModel, *Request, tea.Cmd) {
+	if len(m.bulkTargets()) == 0 {
+		return m, nil, nil
+	}
+	input := textinput.New()
+	input.Placeholder = "e.g. PROJ-123:"
+	input.CharLimit = 80
+	input.Width = 40
+	cmd := input.Focus()
+	m.bulkDescribe = &bulkDescribeState{input: input}
+	return m, nil, tea.Batch(cmd, textinput.Blink)
+}
+
+// handleBulkDescribeKey handles keys while the bulk describe dialog is open. Enter sends the
+// mutable targets to main and clears the marks; Tab switches between prefix and suffix.
+func (m GraphModel) handleBulkDescribeKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.bulkDescribe = nil
+		return m, nil, nil
+	case "tab":
+		m.bulkDescribe.suffix = !m.bulkDescribe.suffix
+		return m, nil, nil
+	case "enter":
+		text := strings.TrimSpace(m.bulkDescribe.input.Value())
+		if text == "" {
+			return m, nil, nil
+		}
+		req := &BulkDescribe{}
+		if m.bulkDescribe.suffix {
+			req.Suffix = text
+		} else {
+			req.Prefix = text
+		}
+		for _, c := range m.bulkTargets() {
+			if !c.Immutable {
+				req.ChangeIDs = append(req.ChangeIDs, c.ChangeID)
+			}
+		}
+		m.bulkDescribe = nil
+		if len(req.ChangeIDs) == 0 {
+			return m, nil, nil
+		}
+		m.marked = nil
+		return m, &Request{BulkDescribe: req}, nil
+	}
+	var cmd tea.Cmd
+	m.bulkDescribe.input, cmd = m.bulkDescribe.input.Update(msg)
+	return m, nil, cmd
+}
+
+// renderBulkDescribe renders the bulk describe dialog with a preview of the resulting subjects.
+func (m *GraphModel) renderBulkDescribe() string {
+	st := m.bulkDescribe
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	active := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	prefixLabel, suffixLabel := active.Render("Prefix"), muted.Render("Suffix")
+	if st.suffix {
+		prefixLabel, suffixLabel = muted.Render("Prefix"), active.Render("Suffix")
+	}
+	targets := m.bulkTargets()
+
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(styles.ColorSecondary).Render(fmt.Sprintf("Describe %d commit(s)", len(targets))),
+		prefixLabel + muted.Render(" / ") + suffixLabel + muted.Render(" (Tab)"),
+		st.input.View(),
+		"",
+	}
+	text := st.input.Value()
+	for i, c := range targets {
+		if i == bulkDescribePreviewMax {
+			lines = append(lines, muted.Render(fmt.Sprintf("… and %d more", len(targets)-i)))
+			break
+		}
+		subject := c.Summary
+		if c.Immutable {
+			subject = muted.Render(subject + " (immutable, skipped)")
+		} else if st.suffix {
+			subject = jj.AffixSubject(subject, "", text)
+		} else {
+			subject = jj.AffixSubject(subject, text, "")
+		}
+		lines = append(lines, CommitIDStyle.Render(c.ShortID)+" "+subject)
+	}
+	lines = append(lines, "", muted.Render("Enter to apply · Esc to cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// IsBulkDescribeOpen reports whether the bulk describe dialog owns the keyboard.
+func (m *GraphModel) IsBulkDescribeOpen() bool {
+	return m.bulkDescribe != nil
+}
+
+// MarkedCount returns how many commits are marked for bulk actions.
+func (m *GraphModel) MarkedCount() int {
+	return len(m.marked)
+}
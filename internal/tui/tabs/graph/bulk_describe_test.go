@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+)
+
+// Space marks commits; B previews the prefixed subjects and Enter requests a describe of the
+// mutable marked changes only, clearing the marks.
+func TestGraphModel_BulkDescribe(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.width, m.height = 100, 40
+	m.repository = &internal.Repository{
+		Graph: internal.CommitGraph{
+			Commits: []internal.Commit{
+				{ID: "a", ChangeID: "aaaa", ShortID: "aaaa", Summary: "fix login"},
+				{ID: "b", ChangeID: "bbbb", ShortID: "bbbb", Summary: "add tests"},
+				{ID: "c", ChangeID: "cccc", ShortID: "cccc", Summary: "release", Immutable: true},
+			},
+		},
+	}
+	press := func(k tea.KeyMsg) *Request {
+		var req *Request
+		m, req, _ = m.handleKeyMsg(k)
+		return req
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	press(space)
+	press(down)
+	press(down)
+	press(space)
+	if m.MarkedCount() != 2 {
+		t.Fatalf("marked = %d, want 2", m.MarkedCount())
+	}
+	if graph := m.Graph(m.buildGraphData()).GraphContent; !strings.Contains(graph, "2 marked") {
+		t.Fatalf("header should show the mark count:\n%s", graph)
+	}
+
+	press(runes("B"))
+	if !m.IsBulkDescribeOpen() {
+		t.Fatal("B should open the bulk describe dialog")
+	}
+	for _, r := range "PROJ-7:" {
+		press(runes(string(r)))
+	}
+	preview := m.renderBulkDescribe()
+	if !strings.Contains(preview, "PROJ-7: fix login") || !strings.Contains(preview, "immutable, skipped") {
+		t.Fatalf("preview:\n%s", preview)
+	}
+
+	req := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || req.BulkDescribe == nil {
+		t.Fatal("Enter should request a bulk describe")
+	}
+	if got := req.BulkDescribe; strings.Join(got.ChangeIDs, ",") != "aaaa" || got.Prefix != "PROJ-7:" || got.Suffix != "" {
+		t.Fatalf("request = %+v", got)
+	}
+	if m.IsBulkDescribeOpen() || m.MarkedCount() != 0 {
+		t.Fatal("applying should close the dialog and clear the marks")
+	}
+
+	// Tab switches to suffix; with nothing marked the selected commit is the target.
+	m.selectedCommit = 1
+	press(runes("B"))
+	press(tea.KeyMsg{Type: tea.KeyTab})
+	press(runes("(wip)"))
+	req = press(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || req.BulkDescribe == nil || strings.Join(req.BulkDescribe.ChangeIDs, ",") != "bbbb" || req.BulkDescribe.Suffix != "(wip)" {
+		t.Fatalf("suffix request = %+v", req)
+	}
+}
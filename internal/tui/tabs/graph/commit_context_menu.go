@@ -43,6 +43,10 @@ func commitContextMenuItems() []commitContextMenuItem {
 		{Label: "Squash", Key: "s", Request: Request{Squash: true}, Mutable: true, HideWhenFirstParentImmutable: true},
 		{Label: "Rebase", Key: "r", Request: Request{StartRebaseMode: true}, Mutable: true},
 		{Label: "Merge from", Key: "M", Request: Request{StartMergeMode: true}, Mutable: true},
+		{Label: "Duplicate", Key: "y", Request: Request{StartDuplicateMode: true}},
+		{Label: "Back out", Key: "R", Request: Request{Backout: true}},
+		{Label: "Insert before", Key: "I", Request: Request{InsertBefore: true}, Mutable: true},
+		{Label: "Insert after", Key: "N", Request: Request{InsertAfter: true}},
 		{Label: "Abandon", Key: "a", Request: Request{Abandon: true}, Mutable: true},
 		{Label: "Bookmark", Key: "m", Request: Request{CreateBookmark: true}, Mutable: true},
 	}
@@ -61,9 +65,15 @@ func (m *GraphModel) commitContextMenuRows(ci int, firstParentImmutable bool) []
 	if m.repository == nil || ci < 0 || ci >= len(m.repository.Graph.Commits) {
 		return out
 	}
+	if !m.repository.Graph.Commits[ci].IsWorking {
+		out = append(out, commitContextMenuItem{Label: "Move my work here", Key: "W", Request: Request{MoveWorkOnto: true}})
+	}
 	if m.repository.Graph.Commits[ci].Immutable {
 		return out
 	}
+	if m.repository.Graph.Commits[ci].IsWorking {
+		out = append(out, commitContextMenuItem{Label: "Absorb", Key: "i", Request: Request{AbsorbPreview: true}})
+	}
 	data := m.buildGraphData()
 	prBranch := ""
 	if data.CommitPRBranch != nil {
@@ -103,12 +113,12 @@ func (m *GraphModel) renderCommitContextMenu(isMutable bool, firstParentImmutabl
 		Padding(0, 1)
 
 	itemStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2"))
+		Foreground(styles.ColorText)
 	hoverStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	hoverKeyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#CCCCCC")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	disabledStyle := lipgloss.NewStyle().
 		Foreground(styles.ColorMuted)
@@ -0,0 +1,174 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// CommitHistoryLoadedMsg is sent when LoadCommitHistoryCmd finishes for an immutable commit.
+// PRsChecked is false when no GitHub service was available to ask.
+type CommitHistoryLoadedMsg struct {
+	state.RepoResult
+	CommitID   string
+	Tags       []string
+	PRs        []internal.GitHubPR
+	PRsChecked bool
+	Err        error
+}
+
+// LoadCommitHistoryCmd looks up the tags that contain commitID and, with a GitHub service, the
+// pull requests that merged it. gh may be nil.
+func LoadCommitHistoryCmd(svc *jj.Service, gh *github.Service, commitID string) tea.Cmd {
+	if svc == nil || commitID == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := CommitHistoryLoadedMsg{CommitID: commitID}
+		msg.Tags, msg.Err = svc.TagsContaining(ctx, commitID)
+		if gh != nil {
+			prs, err := gh.PullRequestsForCommit(ctx, commitID)
+			if err != nil && msg.Err == nil {
+				msg.Err = err
+			}
+			msg.PRs, msg.PRsChecked = prs, err == nil
+		}
+		return msg
+	}
+}
+
+// commitHistory is the read-only history shown for an immutable commit in place of its edit
+// actions. An entry exists from when the load is requested; loaded is set when it finishes.
+type commitHistory struct {
+	loaded     bool
+	tags       []string
+	prs        []internal.GitHubPR
+	prsChecked bool
+	err        string
+}
+
+// CommitHistoryToLoad returns the selected commit's ID when it is immutable and its history
+// hasn't been requested yet, marking it requested. Main runs LoadCommitHistoryCmd for it.
+func (m *GraphModel) CommitHistoryToLoad() (string, bool) {
+	if m.repository == nil || m.selectedCommit < 0 || m.selectedCommit >= len(m.repository.Graph.Commits) {
+		return "", false
+	}
+	c := m.repository.Graph.Commits[m.selectedCommit]
+	if !c.Immutable || c.ID == "" {
+		return "", false
+	}
+	if _, ok := m.commitHistories[c.ID]; ok {
+		return "", false
+	}
+	if m.commitHistories == nil {
+		m.commitHistories = make(map[string]*commitHistory)
+	}
+	m.commitHistories[c.ID] = &commitHistory{}
+	return c.ID, true
+}
+
+// setCommitHistory stores a history load result. Histories are kept for the session: a commit
+// ID's tags and merging PRs rarely change while jj-tui is open.
+func (m *GraphModel) setCommitHistory(msg CommitHistoryLoadedMsg) {
+	if m.commitHistories == nil {
+		m.commitHistories = make(map[string]*commitHistory)
+	}
+	h := &commitHistory{loaded: true, tags: msg.Tags, prs: msg.PRs, prsChecked: msg.PRsChecked}
+	if msg.Err != nil {
+		h.err = msg.Err.Error()
+	}
+	m.commitHistories[msg.CommitID] = h
+}
+
+// renderCommitHistory renders the history panel for the selected immutable commit: the releases
+// containing it, the PRs that merged it and its diff stats. Returns "" when nothing is selected.
+func (m *GraphModel) renderCommitHistory() string {
+	if m.repository == nil || m.selectedCommit < 0 || m.selectedCommit >= len(m.repository.Graph.Commits) {
+		return ""
+	}
+	c := m.repository.Graph.Commits[m.selectedCommit]
+	if !c.Immutable {
+		return ""
+	}
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	label := lipgloss.NewStyle().Bold(true).Width(13)
+	lines := []string{muted.Render("◆ Read-only history (commit is immutable)")}
+
+	h := m.commitHistories[c.ID]
+	switch {
+	case h == nil || !h.loaded:
+		lines = append(lines, muted.Render("  Loading history..."))
+	default:
+		lines = append(lines, "  "+label.Render("Released in")+releaseSummary(h.tags))
+		if h.prsChecked {
+			lines = append(lines, "  "+label.Render("Merged by")+mergedBySummary(h.prs))
+		}
+		if h.err != "" {
+			lines = append(lines, muted.Render("  History incomplete: "+h.err))
+		}
+	}
+	if m.changedFilesCommitID == c.ChangeID {
+		lines = append(lines, "  "+label.Render("Changes")+diffStatSummary(m.allChangedFiles))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// releaseSummary names the first tag containing the commit (the release it first shipped in) and
+// how many later ones also contain it.
+func releaseSummary(tags []string) string {
+	switch len(tags) {
+	case 0:
+		return "not in any tagged release yet"
+	case 1:
+		return tags[0]
+	default:
+		return fmt.Sprintf("%s (+%d later: %s)", tags[0], len(tags)-1, tags[len(tags)-1])
+	}
+}
+
+// mergedBySummary lists the PRs that merged the commit as "#N title → base".
+func mergedBySummary(prs []internal.GitHubPR) string {
+	if len(prs) == 0 {
+		return "no pull request (pushed directly)"
+	}
+	parts := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		s := fmt.Sprintf("#%d %s", pr.Number, pr.Title)
+		if pr.BaseBranch != "" {
+			s += " → " + pr.BaseBranch
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// diffStatSummary totals the commit's changed files as "N files, +A -D"; line counts are left out
+// when any file has no stats (binary or failed to diff).
+func diffStatSummary(files []jj.ChangedFile) string {
+	noun := "files"
+	if len(files) == 1 {
+		noun = "file"
+	}
+	s := fmt.Sprintf("%d %s", len(files), noun)
+	added, removed := 0, 0
+	for _, f := range files {
+		if !f.StatsOK {
+			return s
+		}
+		added += f.LinesAdded
+		removed += f.LinesRemoved
+	}
+	if len(files) > 0 {
+		s += fmt.Sprintf(", +%d -%d", added, removed)
+	}
+	return s
+}
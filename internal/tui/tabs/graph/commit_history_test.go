@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// An immutable commit shows its history panel instead of the bare immutable notice: requested
+// once, "Loading" until the result arrives, then the releases, merging PRs and diff stats.
+func TestGraphModel_CommitHistory(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.width, m.height = 140, 40
+	m.repository = &internal.Repository{
+		Graph: internal.CommitGraph{Commits: []internal.Commit{
+			{ID: "c0ffee", ChangeID: "kkkkkkkkkkkk", ShortID: "kkkk", Summary: "add parser", Immutable: true},
+			{ID: "beef", ChangeID: "mmmmmmmmmmmm", ShortID: "mmmm", Summary: "wip"},
+		}},
+	}
+	id, ok := m.CommitHistoryToLoad()
+	if !ok || id != "c0ffee" {
+		t.Fatalf("CommitHistoryToLoad() = %q, %v; want c0ffee, true", id, ok)
+	}
+	if _, ok := m.CommitHistoryToLoad(); ok {
+		t.Fatal("history should only be requested once per commit")
+	}
+	if actions := m.Graph(m.buildGraphData()).ActionsBar; !strings.Contains(actions, "Loading history") {
+		t.Fatalf("expected a loading line:\n%s", actions)
+	}
+
+	m.SetChangedFiles([]jj.ChangedFile{
+		{Path: "parser.go", Status: "A", LinesAdded: 120, StatsOK: true},
+		{Path: "main.go", Status: "M", LinesAdded: 3, LinesRemoved: 1, StatsOK: true},
+	}, "kkkkkkkkkkkk")
+	m.setCommitHistory(CommitHistoryLoadedMsg{
+		CommitID:   "c0ffee",
+		Tags:       []string{"v1.2.0", "v1.3.0", "v2.0.0"},
+		PRs:        []internal.GitHubPR{{Number: 42, Title: "Add parser", BaseBranch: "main"}},
+		PRsChecked: true,
+	})
+	actions := m.Graph(m.buildGraphData()).ActionsBar
+	for _, want := range []string{"v1.2.0 (+2 later: v2.0.0)", "#42 Add parser → main", "2 files, +123 -1"} {
+		if !strings.Contains(actions, want) {
+			t.Errorf("history panel missing %q:\n%s", want, actions)
+		}
+	}
+
+	m.SelectCommit(1)
+	if _, ok := m.CommitHistoryToLoad(); ok {
+		t.Error("mutable commits have no history panel")
+	}
+	if actions := m.Graph(m.buildGraphData()).ActionsBar; strings.Contains(actions, "Read-only history") {
+		t.Errorf("mutable commit should not show the history panel:\n%s", actions)
+	}
+}
+
+func TestReleaseAndMergeSummaries(t *testing.T) {
+	if got := releaseSummary(nil); got != "not in any tagged release yet" {
+		t.Errorf("releaseSummary(nil) = %q", got)
+	}
+	if got := releaseSummary([]string{"v1.0.0"}); got != "v1.0.0" {
+		t.Errorf("releaseSummary(one) = %q", got)
+	}
+	if got := mergedBySummary(nil); got != "no pull request (pushed directly)" {
+		t.Errorf("mergedBySummary(nil) = %q", got)
+	}
+	if got := diffStatSummary([]jj.ChangedFile{{Path: "logo.png", Status: "A"}}); got != "1 file" {
+		t.Errorf("diffStatSummary(binary) = %q, want line counts left out", got)
+	}
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/tabs/confirm"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// ConfirmPrompt returns the confirmation to show before a destructive request runs (abandon,
+// squash, bookmark delete, file revert, hunk move). ok is false for every other request. Main
+// only asks when HandleRequest produced a command, so the prompt can assume the selection is
+// valid. The squash prompt has no Command: its message combines both full descriptions, which
+// the graph doesn't hold, so main loads it (SquashMessage) before showing the prompt.
+func ConfirmPrompt(r Request, ctx *RequestContext) (p confirm.Prompt, ok bool) {
+	if mv := r.MoveHunks; mv != nil {
+		newArgs, squashArgs := jj.MoveHunksArgs(mv.ChangeID, mv.ToChild)
+		where := "parent"
+		if mv.ToChild {
+			where = "child"
+		}
+		return confirm.Prompt{
+			Action:  "move_hunks",
+			Title:   "Move hunks",
+			Message: fmt.Sprintf("Move %s out of %s into a new %s commit?", pluralHunks(mv.Count), mv.ChangeID, where),
+			Command: jj.CommandLine(newArgs) + " && " + jj.CommandLine(squashArgs),
+		}, true
+	}
+	if ctx == nil || !ctx.IsSelectedCommitValid() {
+		return confirm.Prompt{}, false
+	}
+	commit := ctx.Repository.Graph.Commits[ctx.SelectedCommit]
+	label := commit.ShortID
+	if commit.Summary != "" {
+		label += fmt.Sprintf(" %q", commit.Summary)
+	}
+	switch {
+	case r.Abandon:
+		return confirm.Prompt{
+			Action:  "abandon",
+			Title:   "Abandon commit",
+			Message: fmt.Sprintf("Abandon %s? Its descendants move onto its parent. Restore it from the trash (U) while jj-tui is open.", label),
+			Command: jj.CommandLine(jj.AbandonArgs(commit.ChangeID)),
+		}, true
+	case r.Squash:
+		return confirm.Prompt{
+			Action:  "squash",
+			Title:   "Squash commit",
+			Message: fmt.Sprintf("Squash %s into its parent? The two commits become one, with both descriptions.", label),
+		}, true
+	case r.DeleteBookmark:
+		name := util.FirstOperableBookmarkName(commit.Branches)
+		return confirm.Prompt{
+			Action:  "delete_bookmark",
+			Title:   "Delete bookmark",
+			Message: fmt.Sprintf("Delete bookmark %s? The next push deletes it on the remote too.", name),
+			Command: jj.CommandLine(jj.DeleteBookmarkArgs(name)),
+		}, true
+	case r.RevertFile:
+		path := ctx.ChangedFiles[ctx.SelectedFile].Path
+		return confirm.Prompt{
+			Action:  "revert_file",
+			Title:   "Revert file",
+			Message: fmt.Sprintf("Discard every change to %s in %s?", path, label),
+			Command: jj.CommandLine(jj.RevertFileArgs(commit.ChangeID, path)),
+		}, true
+	}
+	return confirm.Prompt{}, false
+}
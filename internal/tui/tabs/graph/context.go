@@ -3,6 +3,7 @@ package graph
 import (
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/state"
 )
@@ -20,6 +21,7 @@ type ContextProvider interface {
 	IsGraphFocused() bool
 	IsGitHubAvailable() bool
 	GetCreatePRBranch() string
+	GetGitHubPermissions() *github.Permissions
 	IsDemoMode() bool
 	GetConfig() *config.Config
 }
@@ -41,6 +43,7 @@ func BuildRequestContextFrom(p ContextProvider) *RequestContext {
 		GraphFocused:         p.IsGraphFocused(),
 		GitHubAvailable:      p.IsGitHubAvailable(),
 		CreatePRBranch:       p.GetCreatePRBranch(),
+		GitHubPermissions:    p.GetGitHubPermissions(),
 		DemoMode:             p.IsDemoMode(),
 		Config:               p.GetConfig(),
 	})
@@ -59,7 +62,8 @@ type RequestContext struct {
 	SelectedFile         int
 	GraphFocused         bool
 	GitHubAvailable      bool
-	CreatePRBranch       string // branch that would be used for Create PR for selected commit (to block main/master)
+	CreatePRBranch       string              // branch that would be used for Create PR for selected commit (to block main/master)
+	GitHubPermissions    *github.Permissions // nil allows everything
 	DemoMode             bool
 	Config               *config.Config
 }
@@ -77,6 +81,7 @@ type ContextInput struct {
 	GraphFocused         bool
 	GitHubAvailable      bool
 	CreatePRBranch       string
+	GitHubPermissions    *github.Permissions
 	DemoMode             bool
 	Config               *config.Config
 }
@@ -98,6 +103,7 @@ func BuildRequestContext(input *ContextInput) *RequestContext {
 		GraphFocused:         input.GraphFocused,
 		GitHubAvailable:      input.GitHubAvailable,
 		CreatePRBranch:       input.CreatePRBranch,
+		GitHubPermissions:    input.GitHubPermissions,
 		DemoMode:             input.DemoMode,
 		Config:               input.Config,
 	}
@@ -117,7 +123,7 @@ func BuildRequestContextFromApp(app *state.AppState, m *GraphModel) *RequestCont
 	if app == nil || app.Repository == nil {
 		return nil
 	}
-	githubAvailable := app.GitHubService != nil || app.DemoMode
+	githubAvailable := app.Forge() != nil || app.DemoMode
 	return BuildRequestContext(&ContextInput{
 		JJService:            app.JJService,
 		Repository:           app.Repository,
@@ -40,9 +40,11 @@ func contextMenuItems() []contextMenuItem {
 	return []contextMenuItem{
 		{Label: "View diff", Key: "o", Request: Request{ViewFileDiff: true}},
 		{Label: "Open in editor", Key: "O", Request: Request{OpenInExternalEditor: true}},
+		{Label: "History / annotate", Key: "L", Request: Request{FileHistory: true}},
 		{Label: "Move to Parent", Key: "[", Request: Request{MoveFileUp: true}, Mutable: true},
 		{Label: "Move to Child", Key: "]", Request: Request{MoveFileDown: true}, Mutable: true},
 		{Label: "Revert Changes", Key: "v", Request: Request{RevertFile: true}, Mutable: true},
+		{Label: "Absorb into Ancestor", Key: "i", Request: Request{AbsorbFile: true}, Mutable: true},
 	}
 }
 
@@ -56,12 +58,12 @@ func (m *GraphModel) renderContextMenu(isMutable bool) string {
 		Padding(0, 1)
 
 	itemStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2"))
+		Foreground(styles.ColorText)
 	hoverStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	hoverKeyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#CCCCCC")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	disabledStyle := lipgloss.NewStyle().
 		Foreground(styles.ColorMuted)
@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// openDateFilter shows the inline date filter input, pre-filled with the applied range.
+func (m GraphModel) openDateFilter() (GraphModel, *Request, tea.Cmd) {
+	m.editingDateFilter = true
+	m.dateFilterErr = ""
+	m.dateFilterInput.SetValue(m.dateFilterText)
+	m.dateFilterInput.CursorEnd()
+	cmd := m.dateFilterInput.Focus()
+	return m, nil, tea.Batch(cmd, textinput.Blink)
+}
+
+// handleDateFilterKey handles keys while the date filter input is open. Enter applies the typed
+// range (an empty entry clears the filter); a range that doesn't parse keeps the input open with
+// the error shown.
+func (m GraphModel) handleDateFilterKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDateFilter()
+		return m, nil, nil
+	case "enter":
+		text := strings.TrimSpace(m.dateFilterInput.Value())
+		rng, err := jj.ParseDateRange(text, time.Now())
+		if err != nil {
+			m.dateFilterErr = err.Error()
+			return m, nil, nil
+		}
+		m.closeDateFilter()
+		m.dateFilter = rng
+		m.dateFilterText = text
+		if rng.IsZero() {
+			m.dateFilterText = ""
+		}
+		return m, nil, func() tea.Msg { return DateFilterChangedMsg{Range: rng} }
+	}
+	m.dateFilterErr = ""
+	var cmd tea.Cmd
+	m.dateFilterInput, cmd = m.dateFilterInput.Update(msg)
+	return m, nil, cmd
+}
+
+func (m *GraphModel) closeDateFilter() {
+	m.editingDateFilter = false
+	m.dateFilterErr = ""
+	m.dateFilterInput.Blur()
+}
+
+// renderDateFilterEditor renders the one-line input that replaces the graph header while the
+// date filter is being edited, or "" when it isn't.
+func (m GraphModel) renderDateFilterEditor() string {
+	if !m.editingDateFilter {
+		return ""
+	}
+	label := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Date range:")
+	hint := "Enter to apply · empty to clear · Esc to cancel"
+	if m.dateFilterErr != "" {
+		return label + " " + m.dateFilterInput.View() + " " + lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(m.dateFilterErr)
+	}
+	return label + " " + m.dateFilterInput.View() + " " + lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(hint)
+}
+
+// IsEditingDateFilter reports whether the date filter input owns the keyboard.
+func (m *GraphModel) IsEditingDateFilter() bool {
+	return m.editingDateFilter
+}
+
+// GetDateFilter returns the applied date filter (zero when none).
+func (m *GraphModel) GetDateFilter() jj.DateRange {
+	return m.dateFilter
+}
@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// DuplicatedMsg is sent when DuplicateCmd finishes; main reports it and reloads the graph.
+type DuplicatedMsg struct {
+	state.RepoResult
+	Source      string // short ID of the duplicated commit
+	Dest        string // short ID of the destination
+	NewChangeID string // "" when jj's output could not be parsed
+	Err         error
+}
+
+// BackedOutMsg is sent when BackoutCmd finishes; main reports it and reloads the graph.
+type BackedOutMsg struct {
+	state.RepoResult
+	Source string // short ID of the reversed commit
+	Err    error
+}
+
+// MovedWorkMsg is sent when MoveWorkCmd finishes; main reports it and reloads the graph.
+type MovedWorkMsg struct {
+	state.RepoResult
+	Dest string // short ID of the commit the work now sits on
+	Err  error
+}
+
+// DuplicateCmd copies the source commit onto the destination (jj duplicate).
+func DuplicateCmd(svc *jj.Service, sourceChangeID, destChangeID, sourceShortID, destShortID string) tea.Cmd {
+	return func() tea.Msg {
+		newID, err := svc.DuplicateCommit(context.Background(), sourceChangeID, destChangeID)
+		return DuplicatedMsg{Source: sourceShortID, Dest: destShortID, NewChangeID: newID, Err: err}
+	}
+}
+
+// BackoutCmd creates a commit on top of the working copy that reverses the commit (jj revert).
+func BackoutCmd(svc *jj.Service, changeID, shortID string) tea.Cmd {
+	return func() tea.Msg {
+		return BackedOutMsg{Source: shortID, Err: svc.BackoutCommit(context.Background(), changeID)}
+	}
+}
+
+// MoveWorkCmd moves the working-copy changes into a new child of the destination
+// (jj new + jj squash --from).
+func MoveWorkCmd(svc *jj.Service, destChangeID, destShortID string) tea.Cmd {
+	return func() tea.Msg {
+		return MovedWorkMsg{Dest: destShortID, Err: svc.MoveWorkingCopyOnto(context.Background(), destChangeID)}
+	}
+}
+
+// Status is the status line after moving the working-copy changes.
+func (msg MovedWorkMsg) Status() string {
+	return fmt.Sprintf("Moved your uncommitted work onto %s", msg.Dest)
+}
+
+// Status is the status line after a duplicate.
+func (msg DuplicatedMsg) Status() string {
+	if msg.NewChangeID == "" {
+		return fmt.Sprintf("Duplicated %s onto %s", msg.Source, msg.Dest)
+	}
+	return fmt.Sprintf("Duplicated %s onto %s as %s", msg.Source, msg.Dest, msg.NewChangeID)
+}
+
+// Status is the status line after a backout.
+func (msg BackedOutMsg) Status() string {
+	return fmt.Sprintf("Backed out %s in a new commit on top of the working copy", msg.Source)
+}
+
+// DuplicateModeStartMessage returns the status message when entering duplicate mode.
+func DuplicateModeStartMessage(shortID string) string {
+	return fmt.Sprintf("Select destination for duplicating %s (Esc to cancel)", shortID)
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// y starts destination mode for duplicating; picking a destination asks to duplicate, not rebase,
+// and Esc leaves the mode.
+func TestGraphModel_DuplicateMode(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ChangeID: "a", ShortID: "aaaa"},
+		{ChangeID: "b", ShortID: "bbbb", Immutable: true},
+	}}}
+	m.graphFocused = true
+	m.selectedCommit = 1
+	_, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if req == nil || !req.StartDuplicateMode {
+		t.Fatalf("y = %+v, want StartDuplicateMode", req)
+	}
+	ctx := &RequestContext{JJService: &jj.Service{}, Repository: m.repository, SelectedCommit: 1, RebaseSourceCommit: -1}
+	app := &state.AppState{}
+	res := HandleRequest(*req, ctx)
+	if res.FollowUp != FollowUpStartDuplicateMode {
+		t.Fatalf("duplicating an immutable commit = %+v, want duplicate mode", res)
+	}
+	ApplyResult(res, &m, ctx, app)
+	if !m.IsInRebaseMode() || !m.buildGraphData().DuplicateMode {
+		t.Fatal("duplicate mode should be on")
+	}
+	if app.StatusMessage != DuplicateModeStartMessage("bbbb") {
+		t.Errorf("status = %q", app.StatusMessage)
+	}
+
+	m.selectedCommit = 0
+	_, req, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || !req.PerformDuplicate || req.PerformRebase || req.RebaseDestIndex != 0 {
+		t.Fatalf("Enter = %+v, want PerformDuplicate onto 0", req)
+	}
+	ctx.SelectedCommit, ctx.RebaseSourceCommit = 0, 1
+	res = HandleRequest(*req, ctx)
+	if res.Cmd == nil || !strings.Contains(res.SuccessStatus, "Duplicating bbbb onto aaaa") {
+		t.Fatalf("PerformDuplicate = %+v", res)
+	}
+	ApplyResult(res, &m, ctx, app)
+	if m.IsInRebaseMode() || m.rebaseDuplicate {
+		t.Fatal("duplicating should leave destination mode")
+	}
+
+	m.StartDuplicateMode(1)
+	updated, _, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.IsInRebaseMode() || updated.rebaseDuplicate {
+		t.Fatal("Esc should leave duplicate mode")
+	}
+	m.StartRebaseMode(1)
+	if _, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter}); req == nil || !req.PerformRebase {
+		t.Fatalf("Enter in rebase mode after duplicate mode = %+v, want PerformRebase", req)
+	}
+}
+
+func TestGraphModel_Backout(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{{ChangeID: "a", ShortID: "aaaa", Immutable: true}}}}
+	m.graphFocused = true
+	_, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	if req == nil || !req.Backout {
+		t.Fatalf("R = %+v, want Backout", req)
+	}
+	res := HandleRequest(*req, &RequestContext{JJService: &jj.Service{}, Repository: m.repository})
+	if res.Cmd == nil || !res.Loading || res.SuccessStatus != "Backing out aaaa…" {
+		t.Fatalf("Backout = %+v", res)
+	}
+}
+
+// W on another commit moves the working copy's changes onto it; on @ itself it only explains.
+func TestGraphModel_MoveWorkOnto(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ChangeID: "w", ShortID: "wwww", IsWorking: true},
+		{ChangeID: "m", ShortID: "mmmm", Immutable: true},
+	}}}
+	m.graphFocused = true
+	m.selectedCommit = 1
+	_, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	if req == nil || !req.MoveWorkOnto {
+		t.Fatalf("W = %+v, want MoveWorkOnto", req)
+	}
+	ctx := &RequestContext{JJService: &jj.Service{}, Repository: m.repository, SelectedCommit: 1}
+	if res := HandleRequest(*req, ctx); res.Cmd == nil || !res.Loading || res.SuccessStatus != "Moving your work onto mmmm…" {
+		t.Fatalf("MoveWorkOnto = %+v", res)
+	}
+	ctx.SelectedCommit = 0
+	if res := HandleRequest(*req, ctx); res.Cmd != nil || !strings.Contains(res.Status, "not @") {
+		t.Fatalf("MoveWorkOnto on @ = %+v", res)
+	}
+	var labels []string
+	for _, item := range m.commitContextMenuRows(1, false) {
+		labels = append(labels, item.Label)
+	}
+	if !strings.Contains(strings.Join(labels, ","), "Move my work here") {
+		t.Errorf("context menu of an immutable commit = %v", labels)
+	}
+}
+
+func TestDuplicatedMsgStatus(t *testing.T) {
+	if got := (DuplicatedMsg{Source: "aaaa", Dest: "bbbb", NewChangeID: "kpqxywon"}).Status(); got != "Duplicated aaaa onto bbbb as kpqxywon" {
+		t.Errorf("Status() = %q", got)
+	}
+	if got := (DuplicatedMsg{Source: "aaaa", Dest: "bbbb"}).Status(); got != "Duplicated aaaa onto bbbb" {
+		t.Errorf("Status() without a new change ID = %q", got)
+	}
+}
@@ -0,0 +1,220 @@
+package graph
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// FileStatusFilter narrows the files pane to one kind of change (f cycles through them).
+type FileStatusFilter int
+
+const (
+	FileStatusAll FileStatusFilter = iota
+	FileStatusAdded
+	FileStatusModified // modified, renamed or copied
+	FileStatusDeleted
+)
+
+// Label names the filter for the files header ("" for all files).
+func (f FileStatusFilter) Label() string {
+	switch f {
+	case FileStatusAdded:
+		return "added"
+	case FileStatusModified:
+		return "modified"
+	case FileStatusDeleted:
+		return "deleted"
+	}
+	return ""
+}
+
+func (f FileStatusFilter) matches(status string) bool {
+	switch f {
+	case FileStatusAdded:
+		return status == "A"
+	case FileStatusModified:
+		return status != "A" && status != "D"
+	case FileStatusDeleted:
+		return status == "D"
+	}
+	return true
+}
+
+// FileCounts holds per-status counts of the selected commit's changed files (before filtering).
+type FileCounts struct {
+	Total, Added, Modified, Deleted int
+}
+
+func countFiles(files []jj.ChangedFile) FileCounts {
+	c := FileCounts{Total: len(files)}
+	for _, f := range files {
+		switch {
+		case FileStatusAdded.matches(f.Status):
+			c.Added++
+		case FileStatusDeleted.matches(f.Status):
+			c.Deleted++
+		default:
+			c.Modified++
+		}
+	}
+	return c
+}
+
+// fileGlobMatch reports whether p passes the space-separated glob patterns. A file is kept when
+// it matches any plain pattern (or there are none) and no "!" pattern. A pattern matches the
+// path, any of its parent directories, or, when it has no "/", the file's base name; so
+// "internal/tui", "*.go" and "!*_test.go" all work as expected.
+func fileGlobMatch(patterns, p string) bool {
+	included, haveInclude := false, false
+	for _, pat := range strings.Fields(patterns) {
+		exclude := strings.HasPrefix(pat, "!")
+		pat = strings.TrimSuffix(strings.TrimPrefix(pat, "!"), "/")
+		if pat == "" {
+			continue
+		}
+		if exclude {
+			if globMatchPath(pat, p) {
+				return false
+			}
+			continue
+		}
+		haveInclude = true
+		if !included && globMatchPath(pat, p) {
+			included = true
+		}
+	}
+	return included || !haveInclude
+}
+
+func globMatchPath(pat, p string) bool {
+	if !strings.Contains(pat, "/") {
+		if ok, _ := path.Match(pat, path.Base(p)); ok {
+			return true
+		}
+	}
+	for q := p; q != "." && q != "/" && q != ""; q = path.Dir(q) {
+		if ok, _ := path.Match(pat, q); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fileFilterActive reports whether a status filter or path glob hides some changed files.
+func (m *GraphModel) fileFilterActive() bool {
+	return m.fileStatusFilter != FileStatusAll || strings.TrimSpace(m.fileGlob) != ""
+}
+
+// applyFileFilter recomputes the visible changed files from allChangedFiles, keeping the
+// selected file selected when it is still visible.
+func (m *GraphModel) applyFileFilter() {
+	selectedPath := ""
+	if m.selectedFile >= 0 && m.selectedFile < len(m.changedFiles) {
+		selectedPath = m.changedFiles[m.selectedFile].Path
+	}
+	var visible []jj.ChangedFile
+	for _, f := range m.allChangedFiles {
+		if m.fileStatusFilter.matches(f.Status) && fileGlobMatch(m.fileGlob, f.Path) {
+			visible = append(visible, f)
+		}
+	}
+	m.changedFiles = visible
+	m.selectedFile = 0
+	for i, f := range visible {
+		if f.Path == selectedPath {
+			m.selectedFile = i
+			break
+		}
+	}
+	m.scrollToSelectedFile = true
+}
+
+// cycleFileStatusFilter steps the files pane through all → added → modified → deleted (f).
+func (m GraphModel) cycleFileStatusFilter() (GraphModel, *Request, tea.Cmd) {
+	m.fileStatusFilter = (m.fileStatusFilter + 1) % (FileStatusDeleted + 1)
+	m.applyFileFilter()
+	return m, nil, nil
+}
+
+// clearFileFilter drops the status filter and path glob.
+func (m *GraphModel) clearFileFilter() {
+	m.fileStatusFilter = FileStatusAll
+	m.fileGlob = ""
+	m.applyFileFilter()
+}
+
+// openFileGlobFilter shows the inline path glob input in the files header (/).
+func (m GraphModel) openFileGlobFilter() (GraphModel, *Request, tea.Cmd) {
+	m.editingFileGlob = true
+	m.fileGlobInput.SetValue(m.fileGlob)
+	m.fileGlobInput.CursorEnd()
+	cmd := m.fileGlobInput.Focus()
+	return m, nil, tea.Batch(cmd, textinput.Blink)
+}
+
+// handleFileGlobKey handles keys while the path glob input is open. The visible files follow
+// the input as it is typed; Enter keeps the glob and Esc restores the previous one.
+func (m GraphModel) handleFileGlobKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.editingFileGlob = false
+		m.fileGlobInput.Blur()
+		m.applyFileFilter()
+		return m, nil, nil
+	case "enter":
+		m.editingFileGlob = false
+		m.fileGlobInput.Blur()
+		m.fileGlob = strings.TrimSpace(m.fileGlobInput.Value())
+		m.applyFileFilter()
+		return m, nil, nil
+	}
+	var cmd tea.Cmd
+	m.fileGlobInput, cmd = m.fileGlobInput.Update(msg)
+	applied := m.fileGlob
+	m.fileGlob = m.fileGlobInput.Value()
+	m.applyFileFilter()
+	m.fileGlob = applied
+	return m, nil, cmd
+}
+
+// renderFilesFilterLine renders what follows "Changed Files" in the files header: the glob
+// input while it is open, otherwise the per-status counts with the active filter highlighted.
+func (m GraphModel) renderFilesFilterLine(counts FileCounts, shown int) string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	if m.editingFileGlob {
+		label := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Path glob:")
+		return label + " " + m.fileGlobInput.View() + " " + muted.Render(fmt.Sprintf("%d of %d · Enter to keep · Esc to cancel", shown, counts.Total))
+	}
+	active := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	part := func(f FileStatusFilter, text string) string {
+		if m.fileStatusFilter == f {
+			return active.Render(text)
+		}
+		return muted.Render(text)
+	}
+	parts := []string{
+		part(FileStatusAdded, fmt.Sprintf("+%d added", counts.Added)),
+		part(FileStatusModified, fmt.Sprintf("~%d modified", counts.Modified)),
+		part(FileStatusDeleted, fmt.Sprintf("-%d deleted", counts.Deleted)),
+	}
+	line := strings.Join(parts, muted.Render(" · "))
+	if m.fileGlob != "" {
+		line += muted.Render(" · ") + active.Render(m.fileGlob)
+	}
+	if m.fileFilterActive() {
+		line += muted.Render(fmt.Sprintf(" · showing %d of %d (f, /, Esc to clear)", shown, counts.Total))
+	}
+	return line
+}
+
+// IsEditingFileFilter reports whether the files pane path glob input owns the keyboard.
+func (m *GraphModel) IsEditingFileFilter() bool {
+	return m.editingFileGlob
+}
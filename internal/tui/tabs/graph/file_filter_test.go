@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+func TestFileGlobMatch(t *testing.T) {
+	tests := []struct {
+		patterns, path string
+		want           bool
+	}{
+		{"", "a/b.go", true},
+		{"*.go", "internal/tui/model.go", true},
+		{"*.go", "README.md", false},
+		{"internal/tui", "internal/tui/tabs/graph/keys.go", true},
+		{"internal/tui/", "internal/tui/model.go", true},
+		{"internal/*", "internal/tui/model.go", true},
+		{"internal/tui", "internal/integrations/jj/pending.go", false},
+		{"!*_test.go", "a/keys_test.go", false},
+		{"!*_test.go", "a/keys.go", true},
+		{"internal *.md !*_test.go", "README.md", true},
+		{"internal *.md !*_test.go", "internal/a_test.go", false},
+	}
+	for _, tt := range tests {
+		if got := fileGlobMatch(tt.patterns, tt.path); got != tt.want {
+			t.Errorf("fileGlobMatch(%q, %q) = %v, want %v", tt.patterns, tt.path, got, tt.want)
+		}
+	}
+}
+
+// f cycles the status filter and / narrows by glob as it is typed; the header keeps the
+// whole commit's counts and Esc in the files pane clears both filters.
+func TestGraphModel_FileFilters(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.width, m.height = 120, 40
+	m.repository = &internal.Repository{
+		Graph: internal.CommitGraph{Commits: []internal.Commit{{ID: "a", ChangeID: "aaaa", ShortID: "aaaa", Summary: "refactor"}}},
+	}
+	m.SetChangedFiles([]jj.ChangedFile{
+		{Path: "README.md", Status: "M"},
+		{Path: "internal/new.go", Status: "A"},
+		{Path: "internal/new_test.go", Status: "A"},
+		{Path: "internal/old.go", Status: "D"},
+		{Path: "internal/moved.go", Status: "R"},
+	}, "aaaa")
+	m.graphFocused = false
+	press := func(k tea.KeyMsg) {
+		m, _, _ = m.handleKeyMsg(k)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	paths := func() string {
+		var out []string
+		for _, f := range m.GetChangedFiles() {
+			out = append(out, f.Path)
+		}
+		return strings.Join(out, ",")
+	}
+
+	press(runes("f"))
+	if got := paths(); got != "internal/new.go,internal/new_test.go" {
+		t.Fatalf("added only: %s", got)
+	}
+	press(runes("f"))
+	if got := paths(); got != "internal/moved.go,README.md" {
+		t.Fatalf("modified only: %s", got)
+	}
+	press(runes("f"))
+	press(runes("f"))
+	if got := paths(); strings.Count(got, ",") != 4 {
+		t.Fatalf("f should cycle back to all files: %s", got)
+	}
+
+	press(runes("/"))
+	if !m.IsEditingFileFilter() {
+		t.Fatal("/ should open the glob input")
+	}
+	for _, r := range "internal !*_test.go" {
+		press(runes(string(r)))
+	}
+	if got := paths(); got != "internal/moved.go,internal/new.go,internal/old.go" {
+		t.Fatalf("glob while typing: %s", got)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	press(runes("f"))
+	if got := paths(); got != "internal/new.go" {
+		t.Fatalf("glob and status combined: %s", got)
+	}
+	files := m.Graph(m.buildGraphData()).FilesContent
+	for _, want := range []string{"+2 added", "~2 modified", "-1 deleted", "showing 1 of 5"} {
+		if !strings.Contains(files, want) {
+			t.Errorf("files header missing %q:\n%s", want, files)
+		}
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := paths(); strings.Count(got, ",") != 4 {
+		t.Fatalf("Esc should clear the filters: %s", got)
+	}
+}
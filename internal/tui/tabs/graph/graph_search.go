@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// GraphSearchMsg is sent when a graph search (/) resolves or is cleared (empty Query). Main sets
+// Revset on the jj service so graph loads include the matches, and reloads the graph.
+type GraphSearchMsg struct {
+	state.RepoResult
+	Query   string
+	Revset  string
+	Matches []string // change IDs of the matching commits
+	Err     error
+}
+
+// SearchGraphCmd resolves query as a revset, or as free text when jj rejects it as one.
+func SearchGraphCmd(svc *jj.Service, query string) tea.Cmd {
+	if svc == nil || query == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		revset, commits, err := svc.ResolveGraphSearch(context.Background(), query)
+		msg := GraphSearchMsg{Query: query, Revset: revset, Err: err}
+		for _, c := range commits {
+			msg.Matches = append(msg.Matches, c.ChangeID)
+		}
+		return msg
+	}
+}
+
+// openGraphSearch shows the inline search input in the graph header (/), pre-filled with the
+// applied search.
+func (m GraphModel) openGraphSearch() (GraphModel, *Request, tea.Cmd) {
+	m.editingSearch = true
+	m.searchPending = false
+	m.searchErr = ""
+	m.searchInput.SetValue(m.searchQuery)
+	m.searchInput.CursorEnd()
+	cmd := m.searchInput.Focus()
+	return m, nil, tea.Batch(cmd, textinput.Blink)
+}
+
+// handleGraphSearchKey handles keys while the search input is open. Enter asks main to resolve
+// the query (the input stays open until it does, so a failing revset can be fixed); an empty
+// query clears the search. Esc cancels.
+func (m GraphModel) handleGraphSearchKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeGraphSearch()
+		return m, nil, nil
+	case "enter":
+		query := strings.TrimSpace(m.searchInput.Value())
+		if query == "" {
+			m.closeGraphSearch()
+			return m.clearGraphSearch()
+		}
+		m.searchPending = true
+		m.searchErr = ""
+		return m, &Request{SearchGraph: &query}, nil
+	}
+	m.searchErr = ""
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	return m, nil, cmd
+}
+
+func (m *GraphModel) closeGraphSearch() {
+	m.editingSearch = false
+	m.searchPending = false
+	m.searchErr = ""
+	m.searchInput.Blur()
+}
+
+// clearGraphSearch drops the applied search and tells main to reload without it.
+func (m GraphModel) clearGraphSearch() (GraphModel, *Request, tea.Cmd) {
+	if m.searchQuery == "" {
+		return m, nil, nil
+	}
+	m.searchQuery = ""
+	m.searchMatches = nil
+	return m, nil, func() tea.Msg { return GraphSearchMsg{} }
+}
+
+// setGraphSearch applies a search result: an error keeps the input open with jj's message.
+func (m *GraphModel) setGraphSearch(msg GraphSearchMsg) {
+	if msg.Query == "" {
+		return
+	}
+	m.searchPending = false
+	if msg.Err != nil {
+		m.searchErr = firstLine(msg.Err.Error())
+		return
+	}
+	m.closeGraphSearch()
+	m.searchQuery = msg.Query
+	m.searchMatches = make(map[string]bool, len(msg.Matches))
+	for _, id := range msg.Matches {
+		m.searchMatches[id] = true
+	}
+}
+
+// renderGraphSearchEditor renders the one-line input that replaces the graph header while the
+// search is being edited, or "" when it isn't.
+func (m GraphModel) renderGraphSearchEditor() string {
+	if !m.editingSearch {
+		return ""
+	}
+	label := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Search:")
+	switch {
+	case m.searchErr != "":
+		return label + " " + m.searchInput.View() + " " + lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(m.searchErr)
+	case m.searchPending:
+		return label + " " + m.searchInput.View() + " " + lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Searching…")
+	}
+	hint := "revset or text · Enter to search · empty to clear · Esc to cancel"
+	return label + " " + m.searchInput.View() + " " + lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(hint)
+}
+
+// searchLabel describes the applied search for the graph header ("" = none).
+func (m GraphModel) searchLabel() string {
+	if m.searchQuery == "" {
+		return ""
+	}
+	matches := fmt.Sprintf("%d matches", len(m.searchMatches))
+	if len(m.searchMatches) == 1 {
+		matches = "1 match"
+	}
+	return fmt.Sprintf("search %q: %s (/, Esc to clear)", m.searchQuery, matches)
+}
+
+// IsEditingGraphSearch reports whether the graph search input owns the keyboard.
+func (m *GraphModel) IsEditingGraphSearch() bool {
+	return m.editingSearch
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+)
+
+// / opens the search input; Enter asks main to resolve the query, a result highlights the
+// matches in the header and Esc clears the search again.
+func TestGraphModel_Search(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.repository = &internal.Repository{
+		Graph: internal.CommitGraph{Commits: []internal.Commit{{ChangeID: "aaaa", ShortID: "aaaa", Summary: "fix auth"}}},
+	}
+	press := func(k tea.KeyMsg) (*Request, tea.Cmd) {
+		var req *Request
+		var cmd tea.Cmd
+		m, req, cmd = m.handleKeyMsg(k)
+		return req, cmd
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !m.IsEditingGraphSearch() {
+		t.Fatal("/ in the graph pane should open the search")
+	}
+	for _, r := range "auth" {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	req, _ := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || req.SearchGraph == nil || *req.SearchGraph != "auth" {
+		t.Fatalf("enter should request the search, got %+v", req)
+	}
+
+	m.setGraphSearch(GraphSearchMsg{Query: "auth", Err: errors.New("Error: Revision `auth` doesn't exist\nHint: …")})
+	if !m.IsEditingGraphSearch() || !strings.Contains(m.renderGraphSearchEditor(), "doesn't exist") {
+		t.Fatalf("a failed search should keep the input open with the error: %q", m.renderGraphSearchEditor())
+	}
+	m.setGraphSearch(GraphSearchMsg{Query: "auth", Revset: "description(auth)", Matches: []string{"aaaa"}})
+	if m.IsEditingGraphSearch() || !m.buildGraphData().SearchMatches["aaaa"] {
+		t.Fatal("a resolved search should close the input and highlight the matches")
+	}
+	if label := m.buildGraphData().SearchLabel; !strings.Contains(label, `"auth": 1 match`) {
+		t.Fatalf("label = %q", label)
+	}
+
+	_, cmd := press(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("esc should clear the search")
+	}
+	if msg, ok := cmd().(GraphSearchMsg); !ok || msg.Query != "" || m.searchQuery != "" {
+		t.Fatalf("esc should send a cleared search, got %#v", cmd())
+	}
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// ParallelizedMsg is sent when ParallelizeCmd finishes; main reports it and reloads the graph.
+type ParallelizedMsg struct {
+	state.RepoResult
+	Count int
+	Err   error
+}
+
+// InsertedEmptyMsg is sent when InsertEmptyCmd finishes; main reports it and reloads the graph.
+type InsertedEmptyMsg struct {
+	state.RepoResult
+	Ref    string // short ID of the commit the new one was inserted next to
+	Before bool
+	Err    error
+}
+
+// ParallelizeCmd makes the commits siblings (jj parallelize).
+func ParallelizeCmd(svc *jj.Service, changeIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		return ParallelizedMsg{Count: len(changeIDs), Err: svc.ParallelizeCommits(context.Background(), changeIDs)}
+	}
+}
+
+// InsertEmptyCmd inserts an empty working-copy commit before or after the commit (jj new
+// --insert-before/--insert-after).
+func InsertEmptyCmd(svc *jj.Service, changeID, shortID string, before bool) tea.Cmd {
+	return func() tea.Msg {
+		return InsertedEmptyMsg{Ref: shortID, Before: before, Err: svc.InsertEmptyCommit(context.Background(), changeID, before)}
+	}
+}
+
+// Status is the status line after a parallelize.
+func (msg ParallelizedMsg) Status() string {
+	return fmt.Sprintf("Parallelized %d commits into siblings", msg.Count)
+}
+
+// Status is the status line after inserting an empty commit.
+func (msg InsertedEmptyMsg) Status() string {
+	if msg.Before {
+		return fmt.Sprintf("Inserted an empty commit before %s; it is now the working copy", msg.Ref)
+	}
+	return fmt.Sprintf("Inserted an empty commit after %s; it is now the working copy", msg.Ref)
+}
+
+// markedChangeIDs returns the marked change IDs in graph order.
+func (m *GraphModel) markedChangeIDs() []string {
+	if m.repository == nil {
+		return nil
+	}
+	var ids []string
+	for _, c := range m.repository.Graph.Commits {
+		if m.marked[c.ChangeID] {
+			ids = append(ids, c.ChangeID)
+		}
+	}
+	return ids
+}
+
+// parallelizeStatus explains why changeIDs can't be parallelized, or returns "".
+func parallelizeStatus(repo *internal.Repository, changeIDs []string) string {
+	if len(changeIDs) < 2 {
+		return "Mark at least two connected commits (Space) to parallelize"
+	}
+	for _, c := range repo.Graph.Commits {
+		if c.Immutable && slices.Contains(changeIDs, c.ChangeID) {
+			return fmt.Sprintf("Cannot parallelize: %s is immutable", c.ShortID)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// P needs two marked mutable commits and clears the marks once it sends them; I refuses immutable
+// commits while N works anywhere.
+func TestGraphModel_ParallelizeAndInsert(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ChangeID: "a", ShortID: "aaaa"},
+		{ChangeID: "b", ShortID: "bbbb"},
+		{ChangeID: "c", ShortID: "cccc", Immutable: true},
+	}}}
+	m.graphFocused = true
+	ctx := &RequestContext{JJService: &jj.Service{}, Repository: m.repository, SelectedCommit: 2}
+	press := func(r rune) *Request {
+		t.Helper()
+		updated, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated
+		if req == nil {
+			t.Fatalf("%c sent no request", r)
+		}
+		return req
+	}
+
+	m.marked = map[string]bool{"b": true}
+	if res := HandleRequest(*press('P'), ctx); res.Cmd != nil || !strings.Contains(res.Status, "at least two") {
+		t.Fatalf("P with one mark = %+v", res)
+	}
+	m.marked = map[string]bool{"b": true, "c": true}
+	if res := HandleRequest(*press('P'), ctx); res.Cmd != nil || res.Status != "Cannot parallelize: cccc is immutable" {
+		t.Fatalf("P with an immutable mark = %+v", res)
+	}
+	if m.MarkedCount() != 2 {
+		t.Fatal("a refused parallelize should keep the marks")
+	}
+	m.marked = map[string]bool{"b": true, "a": true}
+	req := press('P')
+	if strings.Join(req.Parallelize, ",") != "a,b" || m.MarkedCount() != 0 {
+		t.Fatalf("P = %+v, marked = %d; want a,b in graph order and marks cleared", req, m.MarkedCount())
+	}
+	if res := HandleRequest(*req, ctx); res.Cmd == nil || res.SuccessStatus != "Parallelizing 2 commits…" {
+		t.Fatalf("parallelize = %+v", res)
+	}
+
+	m.selectedCommit = 2
+	if res := HandleRequest(*press('I'), ctx); res.Cmd != nil || res.Status != "Cannot insert before an immutable commit" {
+		t.Fatalf("I on an immutable commit = %+v", res)
+	}
+	req = press('N')
+	if !req.InsertAfter || req.InsertBefore {
+		t.Fatalf("N = %+v", req)
+	}
+	if res := HandleRequest(*req, ctx); res.Cmd == nil || res.SuccessStatus != "Inserting an empty commit after cccc…" {
+		t.Fatalf("N on an immutable commit = %+v", res)
+	}
+}
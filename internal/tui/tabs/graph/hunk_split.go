@@ -0,0 +1,296 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// hunkPreviewLines caps how many diff lines the hunk split shows under each hunk header.
+const hunkPreviewLines = 8
+
+// HunkSplitLoadedMsg is sent when LoadHunkSplitCmd finishes.
+type HunkSplitLoadedMsg struct {
+	state.RepoResult
+	ChangeID string
+	Diff     string
+	Files    []jj.HunkSplitFile
+	Err      error
+}
+
+// LoadHunkSplitCmd loads a commit's diff split into hunks for the hunk split view.
+func LoadHunkSplitCmd(svc *jj.Service, changeID string) tea.Cmd {
+	if svc == nil || changeID == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		diff, files, err := svc.HunkSplitDiff(context.Background(), changeID)
+		return HunkSplitLoadedMsg{ChangeID: changeID, Diff: diff, Files: files, Err: err}
+	}
+}
+
+// MoveHunks is a request to move the selected hunks of a commit into a new parent or child commit.
+type MoveHunks struct {
+	ChangeID string
+	Diff     string           // the diff the hunks were picked from
+	Selected map[string][]int // hunk indexes per path
+	Count    int
+	ToChild  bool
+}
+
+// MoveHunksCmd runs the hunk move and reports it like a file move.
+func MoveHunksCmd(svc *jj.Service, mv MoveHunks) tea.Cmd {
+	return func() tea.Msg {
+		if err := svc.MoveHunks(context.Background(), mv.ChangeID, mv.Diff, mv.Selected, mv.ToChild); err != nil {
+			return util.ErrorMsg{Err: err}
+		}
+		repo, err := svc.GetRepository(context.Background(), "")
+		if err != nil {
+			return util.ErrorMsg{Err: err}
+		}
+		direction := "up"
+		if mv.ToChild {
+			direction = "down"
+		}
+		return FileMoveCompletedMsg{Repository: repo, FilePath: pluralHunks(mv.Count), Direction: direction}
+	}
+}
+
+func pluralHunks(n int) string {
+	if n == 1 {
+		return "1 hunk"
+	}
+	return fmt.Sprintf("%d hunks", n)
+}
+
+// hunkRef is one selectable hunk: files[file].Hunks[hunk].
+type hunkRef struct {
+	file, hunk int
+}
+
+// hunkSplitState is the open hunk split view (H): the files pane lists the selected commit's
+// hunks so some of them can be moved into a new parent or child commit.
+type hunkSplitState struct {
+	changeID string
+	label    string
+	loaded   bool
+	err      string
+	diff     string
+	files    []jj.HunkSplitFile
+	hunks    []hunkRef // selectable hunks in display order
+	cursor   int
+	selected map[hunkRef]bool
+}
+
+// toggleHunkSplit opens the hunk split view for the selected commit, or closes it when open.
+func (m GraphModel) toggleHunkSplit() (GraphModel, *Request, tea.Cmd) {
+	if m.hunkSplit != nil {
+		m.hunkSplit = nil
+		return m, nil, nil
+	}
+	if m.repository == nil || m.selectedCommit < 0 || m.selectedCommit >= len(m.repository.Graph.Commits) {
+		return m, nil, nil
+	}
+	c := m.repository.Graph.Commits[m.selectedCommit]
+	m.stackFiles = nil
+	m.graphFocused = false
+	m.filesViewport.YOffset = 0
+	m.hunkSplit = &hunkSplitState{changeID: c.ChangeID, label: c.ShortID, selected: map[hunkRef]bool{}}
+	if c.Immutable {
+		m.hunkSplit.loaded = true
+		m.hunkSplit.err = "Cannot split: commit is immutable"
+		return m, nil, nil
+	}
+	id := c.ChangeID
+	return m, &Request{LoadHunkSplit: &id}, nil
+}
+
+// setHunkSplit applies a load result to the open view (ignoring results for another commit).
+func (m *GraphModel) setHunkSplit(msg HunkSplitLoadedMsg) {
+	st := m.hunkSplit
+	if st == nil || st.changeID != msg.ChangeID {
+		return
+	}
+	st.loaded = true
+	st.err = ""
+	if msg.Err != nil {
+		st.err = msg.Err.Error()
+		return
+	}
+	st.diff = msg.Diff
+	st.files = msg.Files
+	st.hunks = nil
+	for fi, f := range msg.Files {
+		for hi := range f.Hunks {
+			st.hunks = append(st.hunks, hunkRef{file: fi, hunk: hi})
+		}
+	}
+	st.cursor = 0
+	st.selected = map[hunkRef]bool{}
+}
+
+// handleHunkSplitKey handles files pane keys while the hunk split view is open: j/k move between
+// hunks, Space toggles one, a toggles every hunk of the current file, p/c move the selection into
+// a new parent/child commit, and H or Esc close it. The single-file actions are ignored.
+func (m GraphModel) handleHunkSplitKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd, bool) {
+	st := m.hunkSplit
+	switch msg.String() {
+	case "j", "down":
+		if st.cursor < len(st.hunks)-1 {
+			st.cursor++
+		}
+	case "k", "up":
+		if st.cursor > 0 {
+			st.cursor--
+		}
+	case " ":
+		if st.cursor < len(st.hunks) {
+			h := st.hunks[st.cursor]
+			st.selected[h] = !st.selected[h]
+		}
+	case "a":
+		if st.cursor < len(st.hunks) {
+			file := st.hunks[st.cursor].file
+			all := true
+			for _, h := range st.hunks {
+				if h.file == file && !st.selected[h] {
+					all = false
+				}
+			}
+			for _, h := range st.hunks {
+				if h.file == file {
+					st.selected[h] = !all
+				}
+			}
+		}
+	case "p", "c":
+		mv := st.moveRequest(msg.String() == "c")
+		if mv.Count == 0 {
+			return m, nil, nil, true
+		}
+		m.hunkSplit = nil
+		return m, &Request{MoveHunks: &mv}, nil, true
+	case "esc", "q", "H":
+		m.hunkSplit = nil
+		return m, nil, nil, true
+	case "o", "O", "enter", "v", "[", "]", "i", "f", "/", "L":
+		return m, nil, nil, true
+	default:
+		return m, nil, nil, false
+	}
+	m.scrollHunkCursorIntoView()
+	return m, nil, nil, true
+}
+
+// moveRequest builds the MoveHunks request for the current selection.
+func (st *hunkSplitState) moveRequest(toChild bool) MoveHunks {
+	mv := MoveHunks{ChangeID: st.changeID, Diff: st.diff, Selected: map[string][]int{}, ToChild: toChild}
+	for _, h := range st.hunks {
+		if st.selected[h] {
+			path := st.files[h.file].Path
+			mv.Selected[path] = append(mv.Selected[path], h.hunk)
+			mv.Count++
+		}
+	}
+	return mv
+}
+
+// scrollHunkCursorIntoView keeps the hunk under the cursor visible in the files pane.
+func (m *GraphModel) scrollHunkCursorIntoView() {
+	_, line := m.renderHunkSplit(true)
+	if line < 0 || m.filesViewport.Height <= 0 {
+		return
+	}
+	if line < m.filesViewport.YOffset {
+		m.filesViewport.YOffset = line
+	} else if line >= m.filesViewport.YOffset+m.filesViewport.Height {
+		m.filesViewport.YOffset = line - m.filesViewport.Height + 1
+	}
+}
+
+// renderHunkSplit renders the files pane content for the hunk split view and the line index of
+// the hunk under the cursor (-1 when there is none).
+func (m GraphModel) renderHunkSplit(focused bool) (string, int) {
+	st := m.hunkSplit
+	focusIndicator := "  "
+	if focused {
+		focusIndicator = "► "
+	}
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	header := lipgloss.NewStyle().Bold(true).Render(focusIndicator + "Split hunks: " + st.label)
+	if !st.loaded {
+		return header + "\n" + muted.Render("  Loading hunks…"), -1
+	}
+	if st.err != "" {
+		return header + muted.Render(" (H to close)") + "\n" + lipgloss.NewStyle().Foreground(styles.ColorNegative).Render("  "+st.err), -1
+	}
+	if len(st.hunks) == 0 {
+		return header + muted.Render(" (H to close)") + "\n" + muted.Render("  No text hunks in this commit."), -1
+	}
+
+	n := 0
+	for _, v := range st.selected {
+		if v {
+			n++
+		}
+	}
+	lines := []string{
+		header + muted.Render(fmt.Sprintf(" · %d of %d selected", n, len(st.hunks))),
+		muted.Render("  Space select · a whole file · p move to new parent · c move to new child · H/Esc close"),
+	}
+	cursorLine := -1
+	added := lipgloss.NewStyle().Foreground(styles.ColorPositive)
+	removed := lipgloss.NewStyle().Foreground(styles.ColorNegative)
+	cursorStyle := lipgloss.NewStyle().Background(styles.ColorCursor).Foreground(styles.ColorText)
+	for fi, f := range st.files {
+		if f.Binary {
+			lines = append(lines, "  "+f.Path+muted.Render(" (binary; stays in the commit)"))
+			continue
+		}
+		lines = append(lines, "  "+lipgloss.NewStyle().Bold(true).Render(f.Path))
+		for hi, h := range f.Hunks {
+			ref := hunkRef{file: fi, hunk: hi}
+			box := "[ ]"
+			if st.selected[ref] {
+				box = "[x]"
+			}
+			row := fmt.Sprintf("%s @@ -%d,%d +%d,%d @@", box, h.OldStart, h.OldCount, h.NewStart, h.NewCount)
+			if st.cursor < len(st.hunks) && st.hunks[st.cursor] == ref {
+				cursorLine = len(lines)
+				row = cursorStyle.Render(row)
+			} else if st.selected[ref] {
+				row = added.Render(row)
+			}
+			lines = append(lines, "    "+row)
+			for i, l := range h.Lines {
+				if i == hunkPreviewLines {
+					lines = append(lines, muted.Render(fmt.Sprintf("        … %d more lines", len(h.Lines)-i)))
+					break
+				}
+				switch {
+				case strings.HasPrefix(l, "+"):
+					l = added.Render(l)
+				case strings.HasPrefix(l, "-"):
+					l = removed.Render(l)
+				default:
+					l = muted.Render(l)
+				}
+				lines = append(lines, "      "+l)
+			}
+		}
+	}
+	return strings.Join(lines, "\n"), cursorLine
+}
+
+// IsHunkSplitFocused reports whether the hunk split view is open in the focused files pane, where
+// it owns the keyboard (p and c must not switch tabs).
+func (m *GraphModel) IsHunkSplitFocused() bool {
+	return m.hunkSplit != nil && !m.graphFocused
+}
@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// H lists the commit's hunks in the files pane; Space and a pick hunks and p asks main to move
+// them into a new parent commit.
+func TestGraphModel_HunkSplit(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.width, m.height = 120, 40
+	m.repository = &internal.Repository{
+		Graph: internal.CommitGraph{Commits: []internal.Commit{{ID: "a", ChangeID: "aaaa", ShortID: "aaaa", Summary: "refactor"}}},
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	press := func(k tea.KeyMsg) *Request {
+		var req *Request
+		m, req, _ = m.handleKeyMsg(k)
+		return req
+	}
+
+	if req := press(runes("H")); req == nil || req.LoadHunkSplit == nil || *req.LoadHunkSplit != "aaaa" || !m.IsHunkSplitFocused() {
+		t.Fatalf("H should open the hunk split and load hunks, got %+v", req)
+	}
+	two := []jj.UnifiedHunk{{OldStart: 1, OldCount: 1, NewStart: 1, NewCount: 1, Lines: []string{"-a", "+A"}}, {OldStart: 9, OldCount: 1, NewStart: 9, NewCount: 1, Lines: []string{"-b", "+B"}}}
+	m.setHunkSplit(HunkSplitLoadedMsg{ChangeID: "aaaa", Diff: "diff", Files: []jj.HunkSplitFile{
+		{Path: "a.go", Hunks: two},
+		{Path: "logo.png", Binary: true},
+		{Path: "b.go", Hunks: two},
+	}})
+	if view := m.Graph(m.buildGraphData()).FilesContent; !strings.Contains(view, "0 of 4 selected") || !strings.Contains(view, "binary") {
+		t.Fatalf("hunk split view:\n%s", view)
+	}
+
+	press(runes("j"))
+	press(runes(" "))
+	press(runes("j"))
+	press(runes("a"))
+	if req := press(runes("p")); req == nil || req.MoveHunks == nil {
+		t.Fatalf("p should request the move, got %+v", req)
+	} else {
+		mv := req.MoveHunks
+		want := map[string][]int{"a.go": {1}, "b.go": {0, 1}}
+		if mv.ToChild || mv.Count != 3 || mv.Diff != "diff" || !reflect.DeepEqual(mv.Selected, want) {
+			t.Fatalf("move = %+v", mv)
+		}
+	}
+	if m.IsHunkSplitFocused() {
+		t.Fatal("the view should close once the move is requested")
+	}
+}
@@ -6,6 +6,43 @@ import (
 
 // handleKeyMsg handles keyboard input; returns (updated model, optional request, direct cmd).
 func (m GraphModel) handleKeyMsg(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd) {
+	if m.editingDateFilter {
+		return m.handleDateFilterKey(msg)
+	}
+	if m.bulkDescribe != nil {
+		return m.handleBulkDescribeKey(msg)
+	}
+	if m.mergeDialog != nil {
+		return m.handleMergeDialogKey(msg)
+	}
+	if m.aliasPicker != nil {
+		return m.handleAliasPickerKey(msg)
+	}
+	if m.trash != nil {
+		return m.handleTrashKey(msg)
+	}
+	if m.absorbPreview != nil {
+		return m.handleAbsorbPreviewKey(msg)
+	}
+	if m.editingFileGlob {
+		return m.handleFileGlobKey(msg)
+	}
+	if m.editingSearch {
+		return m.handleGraphSearchKey(msg)
+	}
+	if m.hunkSplit != nil && !m.graphFocused {
+		if updated, req, cmd, handled := m.handleHunkSplitKey(msg); handled {
+			return updated, req, cmd
+		}
+	}
+	if m.stackFiles != nil && !m.graphFocused {
+		if updated, req, cmd, handled := m.handleStackFilesKey(msg); handled {
+			return updated, req, cmd
+		}
+	}
+	if updated, handled := m.handleRebaseOptionKey(msg); handled {
+		return updated, nil, nil
+	}
 	switch msg.String() {
 	// Navigation keys
 	case "j", "down":
@@ -23,6 +60,8 @@ func (m GraphModel) handleKeyMsg(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd)
 				commitID := m.repository.Graph.Commits[m.selectedCommit].ChangeID
 				return m, &Request{LoadChangedFiles: &commitID}, nil
 			}
+			// Moving down past the last commit of a paged graph loads the next page.
+			return m.requestLoadMore()
 		}
 		return m, nil, nil
 
@@ -48,6 +87,30 @@ func (m GraphModel) handleKeyMsg(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd)
 		m.graphFocused = !m.graphFocused
 		return m, nil, nil
 
+	case "D":
+		return m.openDateFilter()
+
+	case "A":
+		return m.cycleAuthorMode()
+
+	case "E":
+		return m.toggleWrapSubjects()
+
+	case " ":
+		return m.toggleMark()
+
+	case "S":
+		return m.toggleStackFiles()
+
+	case ":":
+		return m.openAliasPicker()
+
+	case "H":
+		return m.toggleHunkSplit()
+
+	case "B":
+		return m.openBulkDescribe()
+
 	case "pgup", "pgdown", "ctrl+u", "ctrl+d", "home", "end", "ctrl+f", "ctrl+b":
 		if m.graphFocused {
 			var cmd tea.Cmd
@@ -68,9 +131,22 @@ func (m GraphModel) handleKeyMsg(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd)
 			m.commitContextMenu = nil
 			return m, nil, nil
 		}
+		if !m.graphFocused && m.fileFilterActive() {
+			m.clearFileFilter()
+			return m, nil, nil
+		}
+		if m.selectionMode == SelectionNormal && m.rebaseDragSource < 0 && len(m.marked) > 0 {
+			m.marked = nil
+			return m, nil, nil
+		}
+		if m.graphFocused && m.selectionMode == SelectionNormal && m.searchQuery != "" {
+			return m.clearGraphSearch()
+		}
 		if m.selectionMode == SelectionRebaseDestination {
 			m.selectionMode = SelectionNormal
 			m.rebaseSourceCommit = -1
+			m.rebaseDuplicate = false
+			m.resetRebaseOptions()
 		}
 		if m.selectionMode == SelectionMergeSource {
 			m.selectionMode = SelectionNormal
@@ -88,21 +164,65 @@ func (m GraphModel) handleKeyMsg(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd)
 		return m, nil, nil
 
 	case "M":
+		if m.graphFocused && m.repository != nil && len(m.marked) >= 2 {
+			return m.openMergeDialog()
+		}
 		if m.repository != nil && m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) {
 			return m, &Request{StartMergeMode: true}, nil
 		}
 		return m, nil, nil
 
+	case "y":
+		if m.graphFocused && m.repository != nil && m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) {
+			return m, &Request{StartDuplicateMode: true}, nil
+		}
+		return m, nil, nil
+
+	case "P":
+		if m.graphFocused && m.repository != nil {
+			ids := append([]string{}, m.markedChangeIDs()...)
+			if parallelizeStatus(m.repository, ids) == "" {
+				m.marked = nil
+			}
+			return m, &Request{Parallelize: ids}, nil
+		}
+		return m, nil, nil
+
+	case "I", "N":
+		if m.graphFocused && m.repository != nil && m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) {
+			return m, &Request{InsertBefore: msg.String() == "I", InsertAfter: msg.String() == "N"}, nil
+		}
+		return m, nil, nil
+
+	case "R":
+		if m.graphFocused && m.repository != nil && m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) {
+			return m, &Request{Backout: true}, nil
+		}
+		return m, nil, nil
+
+	case "W":
+		if m.graphFocused && m.repository != nil && m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) {
+			return m, &Request{MoveWorkOnto: true}, nil
+		}
+		return m, nil, nil
+
 	case "enter", "e":
 		if m.graphFocused && m.repository != nil && m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) {
 			if m.selectionMode == SelectionRebaseDestination {
-				return m, &Request{PerformRebase: true, RebaseDestIndex: m.selectedCommit}, nil
+				if m.rebaseDuplicate {
+					return m, &Request{PerformDuplicate: true, RebaseDestIndex: m.selectedCommit}, nil
+				}
+				return m, m.performRebaseRequest(m.selectedCommit), nil
 			}
 			if m.selectionMode == SelectionMergeSource {
 				return m, &Request{PerformMerge: true, MergeSourceIndex: m.selectedCommit}, nil
 			}
 			return m, &Request{Checkout: true}, nil
 		}
+		// Enter on a changed file opens its diff, same as o.
+		if !m.graphFocused && msg.String() == "enter" {
+			return m, &Request{ViewFileDiff: true}, nil
+		}
 		return m, nil, nil
 
 	case "n":
@@ -157,6 +277,9 @@ func (m GraphModel) handleKeyMsg(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd)
 			}
 		}
 	case "f":
+		if !m.graphFocused {
+			return m.cycleFileStatusFilter()
+		}
 		if m.graphFocused && m.repository != nil && m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) {
 			c := m.repository.Graph.Commits[m.selectedCommit]
 			if c.HasDeltaVsBookmarkOrigin {
@@ -182,6 +305,15 @@ func (m GraphModel) handleKeyMsg(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd)
 		if !m.graphFocused {
 			return m, &Request{RevertFile: true}, nil
 		}
+	case "V":
+		if m.graphFocused {
+			return m, nil, m.selectGraphTextCmd()
+		}
+	case "i":
+		if !m.graphFocused {
+			return m, &Request{AbsorbFile: true}, nil
+		}
+		return m, &Request{AbsorbPreview: true}, nil
 	case "o":
 		if !m.graphFocused {
 			return m, &Request{ViewFileDiff: true}, nil
@@ -190,6 +322,19 @@ func (m GraphModel) handleKeyMsg(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd)
 		if !m.graphFocused {
 			return m, &Request{OpenInExternalEditor: true}, nil
 		}
+	case "L":
+		if !m.graphFocused {
+			return m, &Request{FileHistory: true}, nil
+		}
+	case "T":
+		return m, &Request{BrowseFiles: true}, nil
+	case "U":
+		return m.openTrash()
+	case "/":
+		if !m.graphFocused {
+			return m.openFileGlobFilter()
+		}
+		return m.openGraphSearch()
 	}
 
 	return m, nil, nil
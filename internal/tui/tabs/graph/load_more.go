@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// LoadMoreCommitsMsg is sent when the "Load more" row of a paged graph is clicked, or j/down
+// moves past its last commit. Main raises the jj service's graph limit by a page and reloads in
+// the background; the new commits are appended below with the selection left where it was.
+type LoadMoreCommitsMsg struct{}
+
+// requestLoadMore asks main for the next page, once until the reload lands.
+func (m GraphModel) requestLoadMore() (GraphModel, *Request, tea.Cmd) {
+	if m.repository == nil || !m.repository.Graph.HasMore || m.loadingMore {
+		return m, nil, nil
+	}
+	m.loadingMore = true
+	return m, nil, func() tea.Msg { return LoadMoreCommitsMsg{} }
+}
+
+// EndLoadMore re-enables the "Load more" row when a reload finished without a new repository
+// (UpdateRepository does this otherwise).
+func (m *GraphModel) EndLoadMore() {
+	m.loadingMore = false
+}
+
+// renderLoadMoreRow draws the clickable row under the last commit of a paged graph.
+func (m GraphModel) renderLoadMoreRow(shown int, loading bool) string {
+	label := fmt.Sprintf("↓ Load more commits (%d shown)", shown)
+	if loading {
+		label = "Loading more commits…"
+	}
+	return m.zoneManager.Mark(mouse.ZoneGraphLoadMore, "  "+lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(label))
+}
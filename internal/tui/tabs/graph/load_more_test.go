@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+)
+
+// A paged graph ends in a "Load more" row; moving down past the last commit asks main for the
+// next page once, and the reload keeps the selection.
+func TestGraphModel_LoadMore(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.graphFocused = true
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{
+		Commits: []internal.Commit{{ID: "a", ChangeID: "a", Summary: "first"}, {ID: "b", ChangeID: "b", Summary: "second"}},
+		HasMore: true,
+	}}
+	if graph := m.Graph(m.buildGraphData()).GraphContent; !strings.Contains(graph, "Load more commits (2 shown)") {
+		t.Fatalf("missing load more row:\n%s", graph)
+	}
+
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	m, _, _ = m.handleKeyMsg(down)
+	m.changedFilesCommitID = "b"
+	m, _, cmd := m.handleKeyMsg(down)
+	if cmd == nil {
+		t.Fatal("down on the last commit should load more")
+	}
+	if _, ok := cmd().(LoadMoreCommitsMsg); !ok {
+		t.Fatalf("cmd sent %T", cmd())
+	}
+	if _, _, cmd = m.handleKeyMsg(down); cmd != nil {
+		t.Fatal("a page already loading should not be requested again")
+	}
+	if graph := m.Graph(m.buildGraphData()).GraphContent; !strings.Contains(graph, "Loading more commits") {
+		t.Fatalf("row should show the load in progress:\n%s", graph)
+	}
+
+	m.UpdateRepository(&internal.Repository{Graph: internal.CommitGraph{
+		Commits: []internal.Commit{{ID: "a", ChangeID: "a"}, {ID: "b", ChangeID: "b"}, {ID: "c", ChangeID: "c"}},
+	}})
+	if m.loadingMore || m.selectedCommit != 1 {
+		t.Fatalf("loadingMore = %v, selected = %d", m.loadingMore, m.selectedCommit)
+	}
+	if graph := m.Graph(m.buildGraphData()).GraphContent; strings.Contains(graph, "Load more") {
+		t.Fatalf("row should go once everything is loaded:\n%s", graph)
+	}
+}
@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// MergeCommits asks main to create a merge working copy whose parents are ChangeIDs (graph order).
+type MergeCommits struct {
+	ChangeIDs   []string
+	Description string
+}
+
+// MergedCommitsMsg is sent when NewMergeCmd finishes; main reports it and reloads the graph.
+type MergedCommitsMsg struct {
+	state.RepoResult
+	Count int
+	Err   error
+}
+
+// mergeDialogState is the open merge dialog (M with two or more marked commits): the parents
+// and the description input.
+type mergeDialogState struct {
+	parents []internal.Commit
+	input   textinput.Model
+}
+
+// NewMergeCmd creates the merge working copy (jj new <rev>...).
+func NewMergeCmd(svc *jj.Service, req MergeCommits) tea.Cmd {
+	return func() tea.Msg {
+		return MergedCommitsMsg{Count: len(req.ChangeIDs), Err: svc.NewMergeCommit(context.Background(), req.ChangeIDs, req.Description)}
+	}
+}
+
+// Status is the status line after creating a merge.
+func (msg MergedCommitsMsg) Status() string {
+	return fmt.Sprintf("Created a merge of %d commits; it is now the working copy", msg.Count)
+}
+
+// openMergeDialog opens the merge dialog for the marked commits. Immutable commits (e.g. trunk)
+// are fine as parents.
+func (m GraphModel) openMergeDialog() (GraphModel, *Request, tea.Cmd) {
+	var parents []internal.Commit
+	for _, c := range m.repository.Graph.Commits {
+		if m.marked[c.ChangeID] {
+			parents = append(parents, c)
+		}
+	}
+	if len(parents) < 2 {
+		return m, nil, nil
+	}
+	input := textinput.New()
+	input.Placeholder = "Merge description (optional)"
+	input.CharLimit = 200
+	input.Width = 50
+	cmd := input.Focus()
+	m.mergeDialog = &mergeDialogState{parents: parents, input: input}
+	return m, nil, tea.Batch(cmd, textinput.Blink)
+}
+
+// handleMergeDialogKey handles keys while the merge dialog is open. Enter sends the merge to
+// main and clears the marks.
+func (m GraphModel) handleMergeDialogKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mergeDialog = nil
+		return m, nil, nil
+	case "enter":
+		req := &MergeCommits{Description: strings.TrimSpace(m.mergeDialog.input.Value())}
+		for _, c := range m.mergeDialog.parents {
+			req.ChangeIDs = append(req.ChangeIDs, c.ChangeID)
+		}
+		m.mergeDialog = nil
+		m.marked = nil
+		return m, &Request{MergeCommits: req}, nil
+	}
+	var cmd tea.Cmd
+	m.mergeDialog.input, cmd = m.mergeDialog.input.Update(msg)
+	return m, nil, cmd
+}
+
+// renderMergeDialog renders the merge dialog: the parents with their bookmarks and the description.
+func (m *GraphModel) renderMergeDialog() string {
+	st := m.mergeDialog
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(styles.ColorSecondary).Render(fmt.Sprintf("Merge %d commits (jj new)", len(st.parents))),
+		"",
+	}
+	for _, c := range st.parents {
+		line := CommitIDStyle.Render(c.ShortID) + " " + c.Summary
+		if len(c.Branches) > 0 {
+			line += " " + muted.Render("("+strings.Join(c.Branches, ", ")+")")
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", st.input.View(), "", muted.Render("Enter to create the merge · Esc to cancel"))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// IsMergeDialogOpen reports whether the merge dialog owns the keyboard.
+func (m *GraphModel) IsMergeDialogOpen() bool {
+	return m.mergeDialog != nil
+}
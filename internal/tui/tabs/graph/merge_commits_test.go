@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// M with two or more marked commits opens the merge dialog instead of merge mode; Enter sends the
+// marked commits in graph order with the description and clears the marks.
+func TestGraphModel_MergeMarked(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ChangeID: "a", ShortID: "aaaa", Summary: "feature"},
+		{ChangeID: "b", ShortID: "bbbb"},
+		{ChangeID: "t", ShortID: "tttt", Summary: "trunk", Immutable: true, Branches: []string{"main"}},
+	}}}
+	m.graphFocused = true
+	key := func(k tea.KeyMsg) *Request {
+		t.Helper()
+		updated, req, _ := m.handleKeyMsg(k)
+		m = updated
+		return req
+	}
+
+	m.marked = map[string]bool{"t": true}
+	if req := key(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")}); req == nil || !req.StartMergeMode || m.IsMergeDialogOpen() {
+		t.Fatalf("M with one mark = %+v, want merge mode", req)
+	}
+
+	m.marked = map[string]bool{"t": true, "a": true}
+	key(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	if !m.IsMergeDialogOpen() || !strings.Contains(m.renderMergeDialog(), "(main)") {
+		t.Fatal("M with two marks should open the merge dialog listing the parents")
+	}
+	for _, r := range "Sync with main" {
+		key(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	req := key(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || req.MergeCommits == nil || strings.Join(req.MergeCommits.ChangeIDs, ",") != "a,t" ||
+		req.MergeCommits.Description != "Sync with main" {
+		t.Fatalf("Enter = %+v", req)
+	}
+	if m.IsMergeDialogOpen() || m.MarkedCount() != 0 {
+		t.Fatal("Enter should close the dialog and clear the marks")
+	}
+	ctx := &RequestContext{JJService: &jj.Service{}, Repository: m.repository}
+	if res := HandleRequest(*req, ctx); res.Cmd == nil || res.SuccessStatus != "Merging 2 commits…" {
+		t.Fatalf("merge = %+v", res)
+	}
+}
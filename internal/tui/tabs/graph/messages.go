@@ -6,47 +6,84 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/i18n"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // RepositoryLoadedMsg indicates the repository was loaded.
 type RepositoryLoadedMsg struct {
+	state.RepoResult
 	Repository *internal.Repository
 }
 
+// DateFilterChangedMsg is sent when the graph's date filter is applied or cleared (zero Range).
+// Main sets it on the jj service and reloads the graph.
+type DateFilterChangedMsg struct {
+	Range jj.DateRange
+}
+
+// AuthorModeChangedMsg is sent when A cycles the graph's author mode. Main turns the mine-only
+// filter on the jj service on or off and reloads the graph.
+type AuthorModeChangedMsg struct {
+	Mode AuthorMode
+}
+
+// WrapSubjectsChangedMsg is sent when E turns subject wrapping on or off. Main saves the choice.
+type WrapSubjectsChangedMsg struct {
+	On bool
+}
+
+// DescriptionsAffixedMsg is sent when a bulk describe finishes. Updated of Total descriptions
+// were rewritten before Err (if any); main reports it and reloads the graph.
+type DescriptionsAffixedMsg struct {
+	state.RepoResult
+	Updated int
+	Total   int
+	Err     error
+}
+
 // EditCompletedMsg indicates checkout/edit completed.
 type EditCompletedMsg struct {
+	state.RepoResult
 	Repository *internal.Repository
 }
 
 // FileMoveCompletedMsg indicates a file was moved to a new commit.
 type FileMoveCompletedMsg struct {
+	state.RepoResult
 	Repository *internal.Repository
 	FilePath   string
 	Direction  string // "up" or "down"
+	Into       string // set when the file was absorbed into this existing ancestor instead
 }
 
 // FileRevertedMsg indicates a file's changes were reverted.
 type FileRevertedMsg struct {
+	state.RepoResult
 	Repository *internal.Repository
 	FilePath   string
 }
 
 // ChangedFilesLoadedMsg is sent when changed files for a commit have been loaded.
 type ChangedFilesLoadedMsg struct {
+	state.RepoResult
 	Files    []jj.ChangedFile
 	CommitID string
 }
 
-// UndoCompletedMsg is sent when an undo/redo operation completes.
+// UndoCompletedMsg is sent when an undo/redo operation completes. Message names the undone or
+// redone operation; RedoDepth is how many undone operations ^y can still redo.
 type UndoCompletedMsg struct {
-	Message  string
-	Err      error
-	RedoOpID string
+	state.RepoResult
+	Message   string
+	Err       error
+	RedoDepth int
 }
 
 // DivergentCommitInfoMsg is sent when divergent commit info has been loaded (or failed).
 type DivergentCommitInfoMsg struct {
+	state.RepoResult
 	ChangeID string
 	Versions []jj.DivergentVersion
 	Err      error
@@ -80,31 +117,33 @@ func LoadDivergentCommitInfoCmd(svc *jj.Service, changeID string) tea.Cmd {
 	}
 }
 
-// UndoCmd returns a command that runs jj undo and sends UndoCompletedMsg.
+// UndoCmd returns a command that undoes the last operation (stepping back past earlier undos)
+// and sends UndoCompletedMsg.
 func UndoCmd(svc *jj.Service) tea.Cmd {
 	if svc == nil {
 		return nil
 	}
 	return func() tea.Msg {
-		opID, err := svc.Undo(context.Background())
+		undone, err := svc.UndoN(context.Background(), 1)
 		if err != nil {
 			return UndoCompletedMsg{Err: err}
 		}
-		return UndoCompletedMsg{Message: "Undo completed", RedoOpID: opID}
+		return UndoCompletedMsg{Message: i18n.T("status.undid", undone[0].Description), RedoDepth: svc.RedoDepth()}
 	}
 }
 
-// RedoCmd returns a command that runs jj redo and sends UndoCompletedMsg.
-func RedoCmd(svc *jj.Service, opID string) tea.Cmd {
+// RedoCmd returns a command that re-applies the most recently undone operation and sends
+// UndoCompletedMsg.
+func RedoCmd(svc *jj.Service) tea.Cmd {
 	if svc == nil {
 		return nil
 	}
 	return func() tea.Msg {
-		err := svc.Redo(context.Background(), opID)
+		redone, err := svc.RedoN(context.Background(), 1)
 		if err != nil {
 			return UndoCompletedMsg{Err: err}
 		}
-		return UndoCompletedMsg{Message: "Redo completed"}
+		return UndoCompletedMsg{Message: i18n.T("status.redid", redone[0].Description), RedoDepth: svc.RedoDepth()}
 	}
 }
 
@@ -120,6 +159,11 @@ type Request struct {
 	StartRebaseMode      bool
 	PerformRebase        bool
 	RebaseDestIndex      int
+	// RebaseFlag (jj.Rebase*, "" = -s), RebaseInsertAfter (-A) and RebaseExtraDests (further
+	// parents, commit indices) are the rebase options chosen before picking the destination.
+	RebaseFlag        string
+	RebaseInsertAfter bool
+	RebaseExtraDests  []int
 	// DragRebase: mouse drag from DragRebaseFrom onto DragRebaseTo (same semantics as r + pick destination).
 	DragRebase     bool
 	DragRebaseFrom int
@@ -128,6 +172,19 @@ type Request struct {
 	StartMergeMode   bool
 	PerformMerge     bool
 	MergeSourceIndex int
+	// StartDuplicateMode begins selecting a destination to copy the selected commit onto (jj duplicate);
+	// PerformDuplicate duplicates onto RebaseDestIndex.
+	StartDuplicateMode bool
+	PerformDuplicate   bool
+	// Backout: create a commit on top of the working copy that reverses the selected commit.
+	Backout bool
+	// MoveWorkOnto: move the working-copy changes into a new child of the selected commit (W).
+	MoveWorkOnto bool
+	// Parallelize: make the marked commits (change IDs in graph order) siblings (P).
+	Parallelize []string
+	// InsertBefore / InsertAfter: new empty commit as the selected commit's parent (I) or child (N).
+	InsertBefore         bool
+	InsertAfter          bool
 	ResolveDivergent     *string
 	CreateBookmark       bool
 	DeleteBookmark       bool
@@ -136,14 +193,40 @@ type Request struct {
 	MoveFileUp           bool
 	MoveFileDown         bool
 	RevertFile           bool
+	AbsorbFile           bool // squash the selected working-copy file into the ancestor that last changed it
+	AbsorbPreview        bool // preview `jj absorb` of the whole working copy
+	Absorb               bool // run `jj absorb` (confirmed from the preview)
 	ViewFileDiff         bool
 	OpenInExternalEditor bool
+	BrowseFiles          bool // browse every file in the selected commit's tree
+	FileHistory          bool // history and annotation of the selected changed file
 	// MoveDeltaOntoOrigin: new commit on bookmark@origin with same tree as selection; avoids force-push after amending a pushed branch.
 	MoveDeltaOntoOrigin bool
 	// StartEvologSplit: experimental FAQ-style split using jj evolog to pick parent revision.
 	StartEvologSplit bool
 	// ResolveBookmarkConflict: open diverged-bookmark dialog (local vs remote) for selected commit.
 	ResolveBookmarkConflict bool
+	// BulkDescribe: add a prefix/suffix to the subjects of the marked commits (B).
+	BulkDescribe *BulkDescribe
+	// MergeCommits: new working copy merging the marked commits (M with two or more marked).
+	MergeCommits *MergeCommits
+	// LoadStackFiles: load the files changed in trunk()..head for the stack files view (S).
+	LoadStackFiles *string
+	// LoadAliases: read the jj aliases for the alias picker (:); RunAlias runs a command alias.
+	LoadAliases bool
+	RunAlias    *string
+	// SearchGraph: resolve the graph search (/) query.
+	SearchGraph *string
+	// LoadHunkSplit: load the commit's hunks for the hunk split view (H); MoveHunks moves the
+	// selected ones into a new parent or child commit.
+	LoadHunkSplit *string
+	MoveHunks     *MoveHunks
+	// ShowCommit: show `jj show` for the commit ID in the pager (double-click bound to view).
+	ShowCommit *string
+	// LoadTrash: read the commits abandoned this session for the trash list (U); RestoreTrash
+	// reverts the abandon of one of them.
+	LoadTrash    bool
+	RestoreTrash *jj.TrashEntry
 }
 
 // Cmd returns a tea.Cmd that sends this request to the program.
@@ -160,6 +243,7 @@ const (
 	FollowUpStartEditDescription
 	FollowUpStartRebaseMode
 	FollowUpStartMergeMode
+	FollowUpStartDuplicateMode
 	FollowUpCreateBookmark
 	FollowUpCreatePR
 	FollowUpUpdatePR
@@ -169,6 +253,8 @@ const (
 	FollowUpStartEvologSplit
 	FollowUpResolveBookmarkConflict
 	FollowUpViewFileDiff
+	FollowUpBrowseFiles
+	FollowUpFileHistory
 )
 
 // Result is returned by HandleRequest. Main sets status from Status, runs Cmd if set, and performs the FollowUp action.
@@ -189,7 +275,8 @@ type Result struct {
 	Loading bool
 	// BookmarkConflictName is the local bookmark name when FollowUp is FollowUpResolveBookmarkConflict.
 	BookmarkConflictName string
-	// FileDiffPath is the repo-relative path when FollowUp is FollowUpViewFileDiff.
+	// FileDiffPath is the repo-relative path when FollowUp is FollowUpViewFileDiff or
+	// FollowUpFileHistory.
 	FileDiffPath string
 }
 
@@ -4,16 +4,21 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone"
 	overlay "github.com/madicen/bubble-overlay"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/mousedouble"
 	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
 	"github.com/madicen/jj-tui/internal/tui/util"
 	"github.com/mattn/go-runewidth"
 )
@@ -22,12 +27,20 @@ import (
 type GraphModel struct {
 	zoneManager *zone.Manager
 	repository  *internal.Repository
+	// isChange links change IDs in summaries; built once per repository (see changeMatcher).
+	isChange     func(string) bool
+	isChangeRepo *internal.Repository
+
+	// githubPermissions gates Create PR (nil = not probed, allowed).
+	githubPermissions *github.Permissions
 
 	width          int
 	height         int
 	selectedCommit int
 
-	// Changed files for selected commit
+	// Changed files for selected commit: allChangedFiles as loaded, changedFiles the ones the
+	// files pane filter (f status, / path glob) lets through; selection indexes changedFiles.
+	allChangedFiles      []jj.ChangedFile
 	changedFiles         []jj.ChangedFile
 	changedFilesCommitID string // Which commit the files are for
 	selectedFile         int    // Index of selected file in changed files list (-1 = none)
@@ -42,7 +55,14 @@ type GraphModel struct {
 
 	// Rebase mode state
 	selectionMode      SelectionMode
-	rebaseSourceCommit int // Index of commit being rebased
+	rebaseSourceCommit int  // Index of commit being rebased
+	rebaseDuplicate    bool // Destination mode copies the source (jj duplicate) instead of rebasing it
+	// Rebase options (not used when duplicating): rebaseFlag is the jj.Rebase* flag (m cycles it),
+	// rebaseInsertAfter uses -A instead of -d (A), and rebaseExtraDests are further parents
+	// toggled with Space so the rebased commit becomes a merge.
+	rebaseFlag        string
+	rebaseInsertAfter bool
+	rebaseExtraDests  []int
 
 	// Merge mode state: index of the commit being merged into (the destination/target).
 	mergeTargetCommit int
@@ -66,10 +86,76 @@ type GraphModel struct {
 	longPressCommitMouseY  int
 	commitContextMenu      *CommitContextMenuState
 
-	// Mouse: press generation for overlapping zone dedupe; double-click on rows.
+	// Mouse: press generation for overlapping zone dedupe; double-click on rows and the
+	// configured double/middle-click bindings (refreshed from the config on each zone click).
 	mousePressGen  uint64
 	zoneOverlap    mousedouble.OverlapRelease
 	rowDoubleClick mousedouble.DoubleClick
+	clickBindings  mousedouble.Bindings
+
+	// Date filter (D): dateFilter is the applied range shown in the graph header. While
+	// editingDateFilter is true the inline input replaces the header and owns the keyboard;
+	// Enter sends DateFilterChangedMsg, Esc cancels.
+	dateFilter        jj.DateRange
+	dateFilterText    string // what the user typed for the applied range (pre-fills the input)
+	editingDateFilter bool
+	dateFilterInput   textinput.Model
+	dateFilterErr     string
+
+	// authorMode (A) dims other authors' commits or narrows the graph to mine.
+	authorMode AuthorMode
+	// wrapSubjects (E) wraps long commit subjects onto a second line instead of cutting them off.
+	wrapSubjects bool
+
+	// marked holds the change IDs marked with Space for bulk actions; bulkDescribe is the open
+	// B dialog (nil = closed) that adds a prefix or suffix to each marked commit's subject.
+	marked       map[string]bool
+	bulkDescribe *bulkDescribeState
+	// mergeDialog is the open merge dialog (M with two or more marked commits; nil = closed).
+	mergeDialog *mergeDialogState
+
+	// bookmarkPrefix is the configured bookmark namespace, hidden in labels when unambiguous.
+	bookmarkPrefix string
+
+	// Files pane filter: fileStatusFilter (f) and fileGlob (/, edited inline in the files header).
+	fileStatusFilter FileStatusFilter
+	fileGlob         string
+	editingFileGlob  bool
+	fileGlobInput    textinput.Model
+
+	// stackFiles is the open stack files view (S; nil = closed), which replaces the files pane.
+	stackFiles *stackFilesState
+
+	// commitHistories caches the read-only history of immutable commits by commit ID.
+	commitHistories map[string]*commitHistory
+
+	// aliasPicker is the open : picker over the repo's jj aliases (nil = closed); revsetAlias is
+	// the revset alias currently filtering the graph ("" = none).
+	aliasPicker *aliasPickerState
+	revsetAlias string
+
+	// trash is the open list of commits abandoned this session (U; nil = closed).
+	trash *trashState
+
+	// absorbPreview is the open absorb preview (i on the working copy; nil = closed).
+	absorbPreview *jj.AbsorbResult
+
+	// hunkSplit is the open hunk split view (H; nil = closed), which replaces the files pane.
+	hunkSplit *hunkSplitState
+
+	// Graph search (/): searchQuery is the applied query and searchMatches the change IDs it
+	// matched, highlighted in the graph. While editingSearch is true the inline input replaces
+	// the header; searchPending is set while main resolves the query.
+	searchQuery   string
+	searchMatches map[string]bool
+	editingSearch bool
+	searchPending bool
+	searchInput   textinput.Model
+	searchErr     string
+
+	// loadingMore is set from asking main for the next page of a paged graph (the "Load more"
+	// row) until the reload lands.
+	loadingMore bool
 }
 
 // SelectionMode indicates what the user is selecting commits for
@@ -95,18 +181,49 @@ type GraphData struct {
 	Repository         *internal.Repository
 	SelectedCommit     int
 	InRebaseMode       bool            // True when selecting rebase destination
+	DuplicateMode      bool            // With InRebaseMode: the destination is for jj duplicate
 	RebaseSourceCommit int             // Index of commit being rebased
+	RebaseOptions      string          // rebase options line under the header ("" = duplicating)
+	RebaseExtraDests   map[int]bool    // extra destination parents picked with Space
 	InMergeMode        bool            // True when selecting source to merge into the target
 	MergeTargetCommit  int             // Index of commit being merged into
 	OpenPRBranches     map[string]bool // Map of branch names that have open PRs
 	CommitPRBranch     map[int]string  // Maps commit index to PR branch it can push to (including descendants)
 	CommitBookmark     map[int]string  // Maps commit index to bookmark it can create a PR with (including descendants)
+	CreatePRDenied     string          // Why the GitHub token can't create PRs ("" = allowed)
 	ChangedFiles       []ChangedFile   // Changed files for the selected commit
 	GraphFocused       bool            // True if graph pane has focus
 	SelectedFile       int             // Index of selected file in changed files list
 	// RebaseDragSource / RebaseDragHoverDest: mouse drag rebase (-1 = none)
 	RebaseDragSource    int
 	RebaseDragHoverDest int
+	// DateFilterLabel describes the applied date filter ("" = none); DateFilterEditor is the
+	// rendered inline input while the filter is being edited.
+	DateFilterLabel  string
+	DateFilterEditor string
+	AuthorMode       AuthorMode
+	WrapWidth        int             // pane width long subjects wrap at (0 = cut off)
+	RevsetAlias      string          // revset alias filtering the graph ("" = none)
+	Marked           map[string]bool // change IDs marked for bulk actions
+	BookmarkPrefix   string          // bookmark namespace left out of labels when unambiguous
+	// SearchLabel describes the applied search ("" = none) and SearchMatches holds the change
+	// IDs it matched; SearchEditor is the rendered inline input while a search is being typed.
+	SearchLabel   string
+	SearchMatches map[string]bool
+	SearchEditor  string
+	// IsChange reports whether a token is a change ID prefix in the graph (xref.ChangeMatcher).
+	IsChange func(string) bool
+	// FileCounts counts the selected commit's changed files before filtering; FilesFilterLine
+	// is the rendered counts / glob input that follows the files header.
+	FileCounts      FileCounts
+	FilesFilterLine string
+	// FilesPaneView is a rendered view shown instead of the changed files ("" = none): the
+	// stack files view (S) or the hunk split view (H).
+	FilesPaneView string
+	// CommitHistoryView is the rendered history panel of the selected immutable commit.
+	CommitHistoryView string
+	// LoadingMore is set while the next page of a paged graph loads.
+	LoadingMore bool
 }
 
 func NewGraphModel(zoneManager *zone.Manager) GraphModel {
@@ -116,6 +233,18 @@ func NewGraphModel(zoneManager *zone.Manager) GraphModel {
 	vp.MouseWheelEnabled = true
 	filesVp := viewport.New(defaultW, defaultH)
 	filesVp.MouseWheelEnabled = true
+	dateInput := textinput.New()
+	dateInput.Placeholder = "today, week, 14d, 2026-10-01..2026-10-08"
+	dateInput.CharLimit = 40
+	dateInput.Width = 30
+	globInput := textinput.New()
+	globInput.Placeholder = "internal/tui *.go !*_test.go"
+	globInput.CharLimit = 120
+	globInput.Width = 30
+	searchInput := textinput.New()
+	searchInput.Placeholder = "author(alice) & ~empty(), or text"
+	searchInput.CharLimit = 200
+	searchInput.Width = 40
 	return GraphModel{
 		zoneManager:          zoneManager,
 		graphFocused:         true, // default to graph pane focused so j/k navigate commits and wheel scrolls graph
@@ -127,6 +256,9 @@ func NewGraphModel(zoneManager *zone.Manager) GraphModel {
 		mergeTargetCommit:    -1,
 		longPressFileIndex:   -1,
 		longPressCommitIndex: -1,
+		dateFilterInput:      dateInput,
+		fileGlobInput:        globInput,
+		searchInput:          searchInput,
 	}
 }
 
@@ -142,6 +274,34 @@ func (m *GraphModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.SetChangedFiles(msg.Files, msg.CommitID)
 		return m, nil
 
+	case StackFilesLoadedMsg:
+		m.setStackFiles(msg)
+		return m, nil
+
+	case CommitHistoryLoadedMsg:
+		m.setCommitHistory(msg)
+		return m, nil
+
+	case AliasesLoadedMsg:
+		m.setAliases(msg)
+		return m, nil
+
+	case TrashLoadedMsg:
+		m.setTrash(msg)
+		return m, nil
+
+	case AbsorbPreviewLoadedMsg:
+		m.setAbsorbPreview(msg)
+		return m, nil
+
+	case HunkSplitLoadedMsg:
+		m.setHunkSplit(msg)
+		return m, nil
+
+	case GraphSearchMsg:
+		m.setGraphSearch(msg)
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -299,6 +459,9 @@ func (m *GraphModel) UpdateWithApp(msg tea.Msg, app *state.AppState) (GraphModel
 		return *m, directCmd
 
 	case zone.MsgZoneInBounds:
+		if app != nil {
+			m.clickBindings = mousedouble.BindingsFrom(app.Config)
+		}
 		updated, req, directCmd := m.handleZoneClick(msg)
 		*m = updated
 		if req != nil {
@@ -334,18 +497,102 @@ func paneZoneContent(content string, width int) string {
 }
 
 // graphLineIndexForCommit returns the line index in the graph content for the given commit index.
-// Matches the layout in data.go: each commit uses 1 line plus len(commit.GraphLines) connector lines.
-func graphLineIndexForCommit(commits []internal.Commit, commitIndex int) int {
+// Matches the layout in data.go: each commit uses its row (2 lines when its subject wraps at
+// wrapWidth, see commitRowLines) plus len(commit.GraphLines) connector lines.
+func graphLineIndexForCommit(commits []internal.Commit, commitIndex, wrapWidth int) int {
 	if commitIndex <= 0 {
 		return 0
 	}
+	showRemote := wrapWidth > 0 && hasRemoteState(commits)
 	lineIdx := 0
 	for j := 0; j < commitIndex && j < len(commits); j++ {
-		lineIdx += 1 + len(commits[j].GraphLines)
+		lineIdx += commitRowLines(commits[j], showRemote, wrapWidth) + len(commits[j].GraphLines)
 	}
 	return lineIdx
 }
 
+// graphScrollAnchor pins the graph viewport to a commit across a repository refresh: the commit's
+// change ID and the screen row it was on.
+type graphScrollAnchor struct {
+	selectedID string // the selected commit before the refresh
+	changeID   string // the commit the viewport is pinned to; empty = no anchor
+	row        int    // its content line minus the viewport offset
+}
+
+// graphScrollAnchor records where the graph viewport is before a refresh. The selected commit is
+// the anchor while it is on screen; otherwise (the user scrolled away with the wheel) the commit
+// on the top visible row is, so the view stays where they left it.
+func (m *GraphModel) graphScrollAnchor() graphScrollAnchor {
+	if m.repository == nil {
+		return graphScrollAnchor{}
+	}
+	commits := m.repository.Graph.Commits
+	var a graphScrollAnchor
+	if m.selectedCommit >= 0 && m.selectedCommit < len(commits) {
+		a.selectedID = commits[m.selectedCommit].ChangeID
+		// Content line 0 is the pane header, so commit i starts at line graphLineIndexForCommit+1.
+		row := graphLineIndexForCommit(commits, m.selectedCommit, m.wrapWidth()) + 1 - m.viewport.YOffset
+		if row >= 0 && (m.viewport.Height <= 0 || row < m.viewport.Height) {
+			a.changeID, a.row = a.selectedID, row
+			return a
+		}
+	}
+	for i := range commits {
+		line := graphLineIndexForCommit(commits, i, m.wrapWidth()) + 1
+		if line+commitRowLines(commits[i], hasRemoteState(commits), m.wrapWidth())-1+len(commits[i].GraphLines) >= m.viewport.YOffset {
+			a.changeID, a.row = commits[i].ChangeID, line-m.viewport.YOffset
+			break
+		}
+	}
+	return a
+}
+
+// restoreGraphScrollAnchor scrolls so the anchored commit is back on its row, keeping rows from
+// jumping when commits appear or disappear above it. View clamps the offset to the content.
+func (m *GraphModel) restoreGraphScrollAnchor(a graphScrollAnchor) {
+	if a.changeID == "" || m.repository == nil {
+		return
+	}
+	commits := m.repository.Graph.Commits
+	if i := commitIndexByChangeID(commits, a.changeID); i >= 0 {
+		m.viewport.YOffset = max(graphLineIndexForCommit(commits, i, m.wrapWidth())+1-a.row, 0)
+	}
+}
+
+// selectGraphTextCmd opens the graph as plain text in the pager with a line selection started on
+// the selected commit, so graph lines can be copied without the mouse.
+func (m GraphModel) selectGraphTextCmd() tea.Cmd {
+	if m.repository == nil || len(m.repository.Graph.Commits) == 0 {
+		return nil
+	}
+	lines := strings.Split(m.Graph(m.buildGraphData()).GraphContent, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(ansi.Strip(l), " ")
+	}
+	line := 0
+	if m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) {
+		// Content line 0 is the pane header.
+		line = graphLineIndexForCommit(m.repository.Graph.Commits, m.selectedCommit, m.wrapWidth()) + 1
+	}
+	return state.NavigateTarget{
+		Kind:            state.NavigateOpenPager,
+		PagerTitle:      "Graph",
+		PagerContent:    strings.Join(lines, "\n"),
+		PagerSelect:     true,
+		PagerSelectLine: line,
+	}.Cmd()
+}
+
+// commitIndexByChangeID returns the index of the commit with changeID, or -1.
+func commitIndexByChangeID(commits []internal.Commit, changeID string) int {
+	for i, c := range commits {
+		if c.ChangeID == changeID {
+			return i
+		}
+	}
+	return -1
+}
+
 // View uses a pointer receiver so viewport YOffset updates (scroll-to-selection) persist on the model.
 func (m *GraphModel) View() string {
 	// Graph view with split panes: graph (scrollable) | actions (fixed) | files (scrollable)
@@ -354,7 +601,7 @@ func (m *GraphModel) View() string {
 	// Use a minimum actions height during loading to keep layout stable
 	actionsContent := graphResult.ActionsBar
 	if actionsContent == "" {
-		actionsContent = "Actions:"
+		actionsContent = i18n.T("label.actions")
 	}
 	actionsHeight := strings.Count(actionsContent, "\n") + 1
 
@@ -385,14 +632,17 @@ func (m *GraphModel) View() string {
 	if m.scrollToSelectedCommit {
 		m.scrollToSelectedCommit = false
 		if m.repository != nil && m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) {
-			lineIdx := graphLineIndexForCommit(m.repository.Graph.Commits, m.selectedCommit)
+			commits := m.repository.Graph.Commits
+			lineIdx := graphLineIndexForCommit(commits, m.selectedCommit, m.wrapWidth())
 			contentLine := lineIdx + 1
+			// A wrapped subject's second line must be on screen too
+			lastLine := contentLine + commitRowLines(commits[m.selectedCommit], hasRemoteState(commits), m.wrapWidth()) - 1
 			if contentLine < m.viewport.YOffset {
 				// Selection above view: scroll up one line
 				m.viewport.YOffset = max(0, m.viewport.YOffset-1)
-			} else if contentLine >= m.viewport.YOffset+graphVisible {
+			} else if lastLine >= m.viewport.YOffset+graphVisible {
 				// Selection below view: scroll so it appears on last visible line (don't scroll before it goes off)
-				m.viewport.YOffset = min(contentLine-(graphVisible-1), maxGraphOffset)
+				m.viewport.YOffset = min(lastLine-(graphVisible-1), maxGraphOffset)
 			}
 		}
 	}
@@ -428,9 +678,9 @@ func (m *GraphModel) View() string {
 		loadedForSelected := m.repository != nil && m.selectedCommit >= 0 && m.selectedCommit < len(m.repository.Graph.Commits) &&
 			m.changedFilesCommitID == m.repository.Graph.Commits[m.selectedCommit].ChangeID
 		if loadedForSelected && len(m.changedFiles) == 0 {
-			filesContent = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render("  No changed files in this commit.")
+			filesContent = lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("  No changed files in this commit.")
 		} else {
-			filesContent = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render("  Loading changed files...")
+			filesContent = lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render("  Loading changed files...")
 		}
 	}
 	filesLines := strings.Split(filesContent, "\n")
@@ -480,7 +730,7 @@ func (m *GraphModel) View() string {
 
 	// Simple separator line
 	separator := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#444444")).
+		Foreground(styles.ColorSeparator).
 		Render(strings.Repeat("─", max(m.width-2, 0)))
 
 	v := lipgloss.JoinVertical(
@@ -508,6 +758,22 @@ func (m *GraphModel) View() string {
 		v = overlay.OverlayViewAtPoint(v, menuView, m.width, m.height, m.commitContextMenu.MouseY, m.commitContextMenu.MouseX)
 	}
 
+	if m.aliasPicker != nil {
+		v = overlay.OverlayViewInCenter(v, m.renderAliasPicker(), m.width, m.height)
+	}
+	if m.trash != nil {
+		v = overlay.OverlayViewInCenter(v, m.renderTrash(), m.width, m.height)
+	}
+	if m.bulkDescribe != nil {
+		v = overlay.OverlayViewInCenter(v, m.renderBulkDescribe(), m.width, m.height)
+	}
+	if m.mergeDialog != nil {
+		v = overlay.OverlayViewInCenter(v, m.renderMergeDialog(), m.width, m.height)
+	}
+	if m.absorbPreview != nil {
+		v = overlay.OverlayViewInCenter(v, m.renderAbsorbPreview(), m.width, m.height)
+	}
+
 	return v
 }
 
@@ -613,6 +879,18 @@ func (m *GraphModel) buildGraphData() GraphData {
 		}
 	}
 
+	var fileCounts FileCounts
+	filesFilterLine, filesPaneView := "", ""
+	if m.hunkSplit != nil {
+		filesPaneView, _ = m.renderHunkSplit(!m.graphFocused)
+	} else if m.stackFiles != nil {
+		filesPaneView = m.renderStackFiles(!m.graphFocused)
+	}
+	if m.changedFilesCommitID != "" && len(m.allChangedFiles) > 0 {
+		fileCounts = countFiles(m.allChangedFiles)
+		filesFilterLine = m.renderFilesFilterLine(fileCounts, len(m.changedFiles))
+	}
+
 	// Convert changed files to view format
 	var changedFiles []ChangedFile
 	for _, f := range m.changedFiles {
@@ -630,16 +908,36 @@ func (m *GraphModel) buildGraphData() GraphData {
 		SelectedCommit:      m.selectedCommit,
 		InRebaseMode:        m.selectionMode == SelectionRebaseDestination,
 		RebaseSourceCommit:  m.rebaseSourceCommit,
+		RebaseOptions:       m.rebaseOptionsLabel(),
+		RebaseExtraDests:    m.rebaseExtraDestSet(),
+		DuplicateMode:       m.rebaseDuplicate,
 		InMergeMode:         m.selectionMode == SelectionMergeSource,
 		MergeTargetCommit:   m.mergeTargetCommit,
 		OpenPRBranches:      openPRBranches,
 		CommitPRBranch:      commitPRBranch,
 		CommitBookmark:      commitBookmark,
+		CreatePRDenied:      m.githubPermissions.Reason(github.CapCreatePR),
 		ChangedFiles:        changedFiles,
 		GraphFocused:        m.graphFocused,
 		SelectedFile:        m.selectedFile,
 		RebaseDragSource:    m.rebaseDragSource,
 		RebaseDragHoverDest: m.rebaseDragHoverDest,
+		DateFilterLabel:     m.dateFilter.Label,
+		RevsetAlias:         m.revsetAlias,
+		DateFilterEditor:    m.renderDateFilterEditor(),
+		SearchLabel:         m.searchLabel(),
+		SearchMatches:       m.searchMatches,
+		IsChange:            m.changeMatcher(),
+		SearchEditor:        m.renderGraphSearchEditor(),
+		AuthorMode:          m.authorMode,
+		WrapWidth:           m.wrapWidth(),
+		Marked:              m.marked,
+		BookmarkPrefix:      m.bookmarkPrefix,
+		FileCounts:          fileCounts,
+		FilesFilterLine:     filesFilterLine,
+		FilesPaneView:       filesPaneView,
+		CommitHistoryView:   m.renderCommitHistory(),
+		LoadingMore:         m.loadingMore,
 	}
 }
 
@@ -650,14 +948,30 @@ func (m *GraphModel) GetCreatePRBranch() string {
 	return data.CommitBookmark[m.selectedCommit]
 }
 
+// changeMatcher returns the change-ID matcher for the current repository, rebuilding it only
+// when the repository was replaced.
+func (m *GraphModel) changeMatcher() func(string) bool {
+	if m.isChangeRepo != m.repository || m.isChange == nil {
+		m.isChange, m.isChangeRepo = commitChangeMatcher(m.repository), m.repository
+	}
+	return m.isChange
+}
+
 // UpdateRepository updates the graph model with new repository data.
 func (m *GraphModel) UpdateRepository(repo *internal.Repository) {
 	if repo == nil {
 		return
 	}
+	anchor := m.graphScrollAnchor()
 	oldCommitID := m.changedFilesCommitID
 	m.repository = repo
+	m.loadingMore = false
 	commits := repo.Graph.Commits
+	if oldCommitID == "" && anchor.selectedID != "" {
+		if i := commitIndexByChangeID(commits, anchor.selectedID); i >= 0 {
+			m.selectedCommit = i
+		}
+	}
 	if oldCommitID != "" && len(commits) > 0 {
 		found := false
 		for i, c := range commits {
@@ -676,11 +990,23 @@ func (m *GraphModel) UpdateRepository(repo *internal.Repository) {
 	if m.selectedCommit >= len(commits) {
 		m.selectedCommit = max(0, len(commits)-1)
 	}
+	m.restoreGraphScrollAnchor(anchor)
 	if m.rebaseDragSource >= len(commits) || m.rebasePressAnchor >= len(commits) {
 		m.rebasePressAnchor = -1
 		m.rebaseDragSource = -1
 		m.rebaseDragHoverDest = -1
 	}
+	if len(m.marked) > 0 {
+		inGraph := make(map[string]bool, len(commits))
+		for _, c := range commits {
+			inGraph[c.ChangeID] = true
+		}
+		for id := range m.marked {
+			if !inGraph[id] {
+				delete(m.marked, id)
+			}
+		}
+	}
 }
 
 // SetDimensions sets the width and height and lazy-inits viewports if needed.
@@ -713,7 +1039,8 @@ func (m *GraphModel) SetChangedFiles(files []jj.ChangedFile, commitID string) {
 	sorted := make([]jj.ChangedFile, len(files))
 	copy(sorted, files)
 	sort.Slice(sorted, func(i, j int) bool { return changedFileTreeOrderLess(sorted[i].Path, sorted[j].Path) })
-	m.changedFiles = sorted
+	m.allChangedFiles = sorted
+	m.applyFileFilter()
 	m.selectedFile = 0
 	m.scrollToSelectedFile = true
 }
@@ -766,6 +1093,11 @@ func (m *GraphModel) GetSelectionMode() SelectionMode {
 	return m.selectionMode
 }
 
+// HasContextMenu reports whether a file or commit context menu is open.
+func (m *GraphModel) HasContextMenu() bool {
+	return m.contextMenu != nil || m.commitContextMenu != nil
+}
+
 // GetRebaseSourceCommit returns the commit index being rebased.
 func (m *GraphModel) GetRebaseSourceCommit() int {
 	return m.rebaseSourceCommit
@@ -794,6 +1126,16 @@ func (m *GraphModel) IsGraphFocused() bool {
 	return m.graphFocused
 }
 
+// SetGitHubPermissions sets what the GitHub token may do; Create PR renders disabled when denied.
+func (m *GraphModel) SetGitHubPermissions(p *github.Permissions) {
+	m.githubPermissions = p
+}
+
+// SetBookmarkPrefix sets the bookmark namespace that graph labels leave out when unambiguous.
+func (m *GraphModel) SetBookmarkPrefix(prefix string) {
+	m.bookmarkPrefix = prefix
+}
+
 // SetGraphFocused sets whether the graph pane has focus.
 func (m *GraphModel) SetGraphFocused(focused bool) {
 	m.graphFocused = focused
@@ -833,15 +1175,25 @@ func (m *GraphModel) GetFilesViewport() viewport.Model {
 func (m *GraphModel) StartRebaseMode(sourceCommitIdx int) {
 	m.selectionMode = SelectionRebaseDestination
 	m.rebaseSourceCommit = sourceCommitIdx
+	m.rebaseDuplicate = false
+	m.resetRebaseOptions()
 	m.rebasePressAnchor = -1
 	m.rebaseDragSource = -1
 	m.rebaseDragHoverDest = -1
 }
 
+// StartDuplicateMode starts destination selection for duplicating the source commit.
+func (m *GraphModel) StartDuplicateMode(sourceCommitIdx int) {
+	m.StartRebaseMode(sourceCommitIdx)
+	m.rebaseDuplicate = true
+}
+
 // CancelRebaseMode cancels rebase mode.
 func (m *GraphModel) CancelRebaseMode() {
 	m.selectionMode = SelectionNormal
 	m.rebaseSourceCommit = -1
+	m.rebaseDuplicate = false
+	m.resetRebaseOptions()
 	m.rebasePressAnchor = -1
 	m.rebaseDragSource = -1
 	m.rebaseDragHoverDest = -1
@@ -1,10 +1,15 @@
 package graph
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/mousedouble"
 )
 
 func TestGraphModel_Update_HandlesMouseWheelScroll(t *testing.T) {
@@ -80,3 +85,140 @@ func TestGraphModel_SetDimensions_InitsViewports(t *testing.T) {
 		t.Errorf("after SetDimensions files viewport width want 80, got %d", m.GetFilesViewport().Width)
 	}
 }
+
+// D opens the date filter; Enter applies it (sending DateFilterChangedMsg) and the header shows the
+// range. A range that doesn't parse keeps the input open.
+func TestGraphModel_DateFilter(t *testing.T) {
+	m := NewGraphModel(nil)
+	updated, _, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if !updated.IsEditingDateFilter() {
+		t.Fatal("D should open the date filter")
+	}
+	updated.dateFilterInput.SetValue("soon")
+	updated, _, cmd := updated.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !updated.IsEditingDateFilter() || updated.dateFilterErr == "" {
+		t.Fatal("an invalid range should keep the input open with an error")
+	}
+
+	updated.dateFilterInput.SetValue("week")
+	updated, _, cmd = updated.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || updated.IsEditingDateFilter() {
+		t.Fatal("Enter should apply the range and close the input")
+	}
+	msg, ok := cmd().(DateFilterChangedMsg)
+	if !ok || msg.Range.Label != "last 7 days" {
+		t.Fatalf("cmd sent %#v", msg)
+	}
+	if data := updated.buildGraphData(); data.DateFilterLabel != "last 7 days" {
+		t.Fatalf("header label = %q", data.DateFilterLabel)
+	}
+
+	updated, _, _ = updated.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if updated.dateFilterInput.Value() != "week" {
+		t.Fatalf("input should be pre-filled with the applied range, got %q", updated.dateFilterInput.Value())
+	}
+	updated.dateFilterInput.SetValue("")
+	updated, _, cmd = updated.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg := cmd().(DateFilterChangedMsg); !msg.Range.IsZero() || !updated.GetDateFilter().IsZero() {
+		t.Fatal("an empty entry should clear the filter")
+	}
+}
+
+// Enter in the files pane opens the selected file's diff; in the graph pane it still checks out.
+func TestGraphModel_EnterOnFileOpensDiff(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{{ChangeID: "a"}}}}
+	m.changedFiles = []jj.ChangedFile{{Path: "main.go", Status: "M"}}
+	m.graphFocused = false
+	if _, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter}); req == nil || !req.ViewFileDiff {
+		t.Fatalf("files pane Enter = %+v, want ViewFileDiff", req)
+	}
+	m.graphFocused = true
+	if _, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter}); req == nil || !req.Checkout {
+		t.Fatalf("graph pane Enter = %+v, want Checkout", req)
+	}
+}
+
+// i in the files pane asks to absorb the file into its ancestor; only the working copy's files qualify.
+func TestGraphModel_AbsorbFileKey(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{{ChangeID: "a"}}}}
+	m.changedFiles = []jj.ChangedFile{{Path: "main.go", Status: "M"}}
+	m.graphFocused = false
+	_, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if req == nil || !req.AbsorbFile {
+		t.Fatalf("files pane i = %+v, want AbsorbFile", req)
+	}
+	ctx := &RequestContext{JJService: &jj.Service{}, Repository: m.repository, ChangedFiles: m.changedFiles}
+	if res := HandleRequest(*req, ctx); res.Cmd != nil || res.Status == "" {
+		t.Fatalf("absorb outside the working copy = %+v, want a status only", res)
+	}
+	m.repository.Graph.Commits[0].IsWorking = true
+	if res := HandleRequest(*req, ctx); res.Cmd == nil {
+		t.Fatalf("absorb in the working copy = %+v, want a command", res)
+	}
+}
+
+// Row clicks follow the configured bindings: middle-click copies instead of selecting, and a
+// double-click bound to view shows the commit or file diff instead of editing.
+func TestGraphModel_ClickBindings(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ChangeID: "a", ShortID: "a1"}, {ChangeID: "b", ShortID: "b1"},
+	}}}
+	m.changedFiles = []jj.ChangedFile{{Path: "main.go", Status: "M"}}
+	left := tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease}
+	middle := tea.MouseMsg{Button: tea.MouseButtonMiddle, Action: tea.MouseActionRelease}
+
+	updated, req, cmd := applyCommitRowMouseSelection(m, 1, middle)
+	if req != nil || cmd == nil || updated.selectedCommit != 0 {
+		t.Fatalf("middle-click = (%+v, %v), selected %d; want a copy without selecting", req, cmd != nil, updated.selectedCommit)
+	}
+
+	m.clickBindings = mousedouble.Bindings{DoubleClick: config.MouseClickView}
+	m, _, _ = applyCommitRowMouseSelection(m, 1, left)
+	if _, req, _ := applyCommitRowMouseSelection(m, 1, left); req == nil || req.ShowCommit == nil || *req.ShowCommit != "b1" {
+		t.Fatalf("view double-click on a commit = %+v, want ShowCommit b1", req)
+	}
+	m.changedFiles = []jj.ChangedFile{{Path: "main.go", Status: "M"}} // the commit click reloads the files
+	m, _, _ = applyChangedFileRowMouseSelection(m, 0, left)
+	if _, req, _ := applyChangedFileRowMouseSelection(m, 0, left); req == nil || !req.ViewFileDiff {
+		t.Fatalf("view double-click on a file = %+v, want ViewFileDiff", req)
+	}
+}
+
+// A refresh that adds commits above the view keeps the anchored commit on its screen row: the
+// selection while it is visible, else the top visible commit.
+func TestGraphModel_RefreshKeepsScrollAnchor(t *testing.T) {
+	repoWith := func(prefix, n int) *internal.Repository {
+		var commits []internal.Commit
+		for i := 0; i < prefix; i++ {
+			commits = append(commits, internal.Commit{ChangeID: fmt.Sprintf("new%d", i)})
+		}
+		for i := 0; i < n; i++ {
+			commits = append(commits, internal.Commit{ChangeID: fmt.Sprintf("c%d", i), GraphLines: []string{"│"}})
+		}
+		return &internal.Repository{Graph: internal.CommitGraph{Commits: commits}}
+	}
+	m := NewGraphModel(nil)
+	m.SetDimensions(80, 24)
+	m.UpdateRepository(repoWith(0, 30))
+	m.viewport.Height = 10
+
+	// Selected c7 starts at content line 15; with offset 10 it is on row 5.
+	m.selectedCommit = 7
+	m.viewport.YOffset = 10
+	m.UpdateRepository(repoWith(3, 30))
+	if m.selectedCommit != 10 || m.viewport.YOffset != 13 {
+		t.Fatalf("selection anchor: selected %d, offset %d", m.selectedCommit, m.viewport.YOffset)
+	}
+
+	// The selection (new0) is off screen, so the top visible commit (c5, line 14) is the anchor;
+	// two more new commits push it down to line 16.
+	m.selectedCommit = 0
+	m.viewport.YOffset = 14
+	m.UpdateRepository(repoWith(5, 30))
+	if m.selectedCommit != 0 || m.viewport.YOffset != 16 {
+		t.Fatalf("top row anchor: selected %d, offset %d", m.selectedCommit, m.viewport.YOffset)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/mousedouble"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/util"
+	"github.com/madicen/jj-tui/internal/tui/xref"
 )
 
 // handleZoneClick handles zone click messages; returns (updated model, optional request, direct cmd).
@@ -97,7 +100,22 @@ func (m GraphModel) handleZoneClick(msg zone.MsgZoneInBounds) (GraphModel, *Requ
 		return m, nil, nil
 	}
 
+	if z == m.zoneManager.Get(mouse.ZoneGraphLoadMore) {
+		return m.requestLoadMore()
+	}
+
 	if m.repository != nil {
+		// Cross-reference tokens in a summary (#123, PROJ-1, change IDs) take precedence over the row.
+		if m.selectionMode == SelectionNormal {
+			isChange := m.changeMatcher()
+			for commitIndex, c := range m.repository.Graph.Commits {
+				for ri, ref := range xref.Find(c.Summary, isChange) {
+					if m.zoneManager.Get(mouse.ZoneCommitRef(commitIndex, ri)) == z {
+						return m, nil, state.NavigateTarget{Kind: state.NavigateFollowXRef, XRef: ref}.Cmd()
+					}
+				}
+			}
+		}
 		for commitIndex := range m.repository.Graph.Commits {
 			if m.zoneManager.Get(mouse.ZoneCommit(commitIndex)) == z {
 				return applyCommitRowMouseSelection(m, commitIndex, event)
@@ -192,6 +210,9 @@ func (m GraphModel) handleZoneClick(msg zone.MsgZoneInBounds) (GraphModel, *Requ
 	if inBounds(mouse.ZoneActionSquash) {
 		return m, &Request{Squash: true}, nil
 	}
+	if inBounds(mouse.ZoneActionAbsorb) {
+		return m, &Request{AbsorbPreview: true}, nil
+	}
 	if inBounds(mouse.ZoneActionRebase) {
 		return m, &Request{StartRebaseMode: true}, nil
 	}
@@ -201,6 +222,23 @@ func (m GraphModel) handleZoneClick(msg zone.MsgZoneInBounds) (GraphModel, *Requ
 	if inBounds(mouse.ZoneActionAbandon) {
 		return m, &Request{Abandon: true}, nil
 	}
+	if inBounds(mouse.ZoneActionInsertBefore) {
+		return m, &Request{InsertBefore: true}, nil
+	}
+	if inBounds(mouse.ZoneActionInsertAfter) {
+		return m, &Request{InsertAfter: true}, nil
+	}
+	if inBounds(mouse.ZoneActionMergeMarked) {
+		updated, req, cmd := m.openMergeDialog()
+		return updated, req, cmd
+	}
+	if inBounds(mouse.ZoneActionParallelize) {
+		ids := append([]string{}, m.markedChangeIDs()...)
+		if parallelizeStatus(m.repository, ids) == "" {
+			m.marked = nil
+		}
+		return m, &Request{Parallelize: ids}, nil
+	}
 	if inBounds(mouse.ZoneActionBookmark) {
 		return m, &Request{CreateBookmark: true}, nil
 	}
@@ -232,6 +270,9 @@ func (m GraphModel) handleZoneClick(msg zone.MsgZoneInBounds) (GraphModel, *Requ
 	if inBounds(mouse.ZoneActionRevertFile) {
 		return m, &Request{RevertFile: true}, nil
 	}
+	if inBounds(mouse.ZoneActionAbsorbFile) {
+		return m, &Request{AbsorbFile: true}, nil
+	}
 	if inBounds(mouse.ZoneActionViewFileDiff) {
 		return m, &Request{ViewFileDiff: true}, nil
 	}
@@ -243,23 +284,37 @@ func (m GraphModel) handleZoneClick(msg zone.MsgZoneInBounds) (GraphModel, *Requ
 }
 
 func applyCommitRowMouseSelection(m GraphModel, commitIndex int, event tea.MouseMsg) (GraphModel, *Request, tea.Cmd) {
+	if m.repository == nil || commitIndex < 0 || commitIndex >= len(m.repository.Graph.Commits) {
+		return m, nil, nil
+	}
+	c := m.repository.Graph.Commits[commitIndex]
+	click := m.rowDoubleClick.Classify(fmt.Sprintf("graph:commit:%d", commitIndex), event, time.Now(), m.clickBindings)
+	switch click {
+	case mousedouble.ClickIgnore:
+		return m, nil, nil
+	case mousedouble.ClickCopy:
+		return m, nil, util.CopyToClipboard(c.ChangeID)
+	}
 	m.graphFocused = true
 	if m.selectionMode == SelectionRebaseDestination {
-		return m, &Request{PerformRebase: true, RebaseDestIndex: commitIndex}, nil
+		if m.rebaseDuplicate {
+			return m, &Request{PerformDuplicate: true, RebaseDestIndex: commitIndex}, nil
+		}
+		return m, m.performRebaseRequest(commitIndex), nil
 	}
 	if m.selectionMode == SelectionMergeSource {
 		return m, &Request{PerformMerge: true, MergeSourceIndex: commitIndex}, nil
 	}
-	if m.repository == nil || commitIndex < 0 || commitIndex >= len(m.repository.Graph.Commits) {
-		return m, nil, nil
-	}
-	key := fmt.Sprintf("graph:commit:%d", commitIndex)
-	if m.rowDoubleClick.ObserveLeftRelease(key, event, time.Now(), mousedouble.DefaultDoubleClickWindow) {
-		c := m.repository.Graph.Commits[commitIndex]
+	switch click {
+	case mousedouble.ClickPrimary:
 		if !c.Immutable && !c.IsWorking {
 			m.selectedCommit = commitIndex
 			return m, &Request{Checkout: true}, nil
 		}
+	case mousedouble.ClickView:
+		m.selectedCommit = commitIndex
+		commitID := c.ShortID
+		return m, &Request{ShowCommit: &commitID}, nil
 	}
 	m.selectedCommit = commitIndex
 	m.changedFilesCommitID = ""
@@ -269,14 +324,25 @@ func applyCommitRowMouseSelection(m GraphModel, commitIndex int, event tea.Mouse
 }
 
 func applyChangedFileRowMouseSelection(m GraphModel, fileIndex int, event tea.MouseMsg) (GraphModel, *Request, tea.Cmd) {
-	m.selectedFile = fileIndex
-	m.graphFocused = false
 	if fileIndex < 0 || fileIndex >= len(m.changedFiles) {
+		m.selectedFile = fileIndex
+		m.graphFocused = false
+		return m, nil, nil
+	}
+	click := m.rowDoubleClick.Classify(fmt.Sprintf("graph:file:%d", fileIndex), event, time.Now(), m.clickBindings)
+	switch click {
+	case mousedouble.ClickIgnore:
 		return m, nil, nil
+	case mousedouble.ClickCopy:
+		return m, nil, util.CopyToClipboard(m.changedFiles[fileIndex].Path)
 	}
-	key := fmt.Sprintf("graph:file:%d", fileIndex)
-	if m.rowDoubleClick.ObserveLeftRelease(key, event, time.Now(), mousedouble.DefaultDoubleClickWindow) {
+	m.selectedFile = fileIndex
+	m.graphFocused = false
+	switch click {
+	case mousedouble.ClickPrimary:
 		return m, &Request{OpenInExternalEditor: true}, nil
+	case mousedouble.ClickView:
+		return m, &Request{ViewFileDiff: true}, nil
 	}
 	return m, nil, nil
 }
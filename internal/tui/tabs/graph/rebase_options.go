@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// handleRebaseOptionKey handles the rebase option keys while picking a rebase destination:
+// m cycles -s / -r / -b, A toggles inserting after the destination, and Space adds or removes
+// the selected commit as an extra parent. handled is false for every other key.
+func (m GraphModel) handleRebaseOptionKey(msg tea.KeyMsg) (GraphModel, bool) {
+	if m.selectionMode != SelectionRebaseDestination || m.rebaseDuplicate || !m.graphFocused {
+		return m, false
+	}
+	switch msg.String() {
+	case "m":
+		switch rebaseFlagOrDefault(m.rebaseFlag) {
+		case jj.RebaseSource:
+			m.rebaseFlag = jj.RebaseRevision
+		case jj.RebaseRevision:
+			m.rebaseFlag = jj.RebaseBranch
+			m.rebaseInsertAfter = false
+		default:
+			m.rebaseFlag = jj.RebaseSource
+		}
+		return m, true
+	case "A":
+		if m.rebaseFlag != jj.RebaseBranch {
+			m.rebaseInsertAfter = !m.rebaseInsertAfter
+		}
+		return m, true
+	case " ":
+		if m.repository == nil || m.selectedCommit < 0 || m.selectedCommit >= len(m.repository.Graph.Commits) ||
+			m.selectedCommit == m.rebaseSourceCommit {
+			return m, true
+		}
+		if i := slices.Index(m.rebaseExtraDests, m.selectedCommit); i >= 0 {
+			m.rebaseExtraDests = slices.Delete(slices.Clone(m.rebaseExtraDests), i, i+1)
+		} else {
+			m.rebaseExtraDests = append(slices.Clone(m.rebaseExtraDests), m.selectedCommit)
+		}
+		return m, true
+	}
+	return m, false
+}
+
+// performRebaseRequest asks main to rebase onto destIndex with the chosen rebase options.
+func (m *GraphModel) performRebaseRequest(destIndex int) *Request {
+	return &Request{
+		PerformRebase:     true,
+		RebaseDestIndex:   destIndex,
+		RebaseFlag:        m.rebaseFlag,
+		RebaseInsertAfter: m.rebaseInsertAfter,
+		RebaseExtraDests:  slices.Clone(m.rebaseExtraDests),
+	}
+}
+
+func (m *GraphModel) resetRebaseOptions() {
+	m.rebaseFlag = ""
+	m.rebaseInsertAfter = false
+	m.rebaseExtraDests = nil
+}
+
+// rebaseOptionsLabel describes the rebase options for the rebase mode header ("" when duplicating).
+func (m *GraphModel) rebaseOptionsLabel() string {
+	if m.selectionMode != SelectionRebaseDestination || m.rebaseDuplicate {
+		return ""
+	}
+	what := map[string]string{
+		jj.RebaseSource:   "with descendants",
+		jj.RebaseRevision: "only this revision",
+		jj.RebaseBranch:   "whole branch",
+	}[rebaseFlagOrDefault(m.rebaseFlag)]
+	where := "-d onto"
+	if m.rebaseInsertAfter {
+		where = "-A insert after"
+	}
+	label := fmt.Sprintf("%s %s (m) · %s (A)", rebaseFlagOrDefault(m.rebaseFlag), what, where)
+	if n := len(m.rebaseExtraDests); n > 0 {
+		label += fmt.Sprintf(" · +%d parent(s) (Space)", n)
+	} else {
+		label += " · Space: add parent"
+	}
+	return label
+}
+
+func (m *GraphModel) rebaseExtraDestSet() map[int]bool {
+	if len(m.rebaseExtraDests) == 0 {
+		return nil
+	}
+	set := make(map[int]bool, len(m.rebaseExtraDests))
+	for _, i := range m.rebaseExtraDests {
+		set[i] = true
+	}
+	return set
+}
+
+// rebaseDestinations lists the destination commit indices of r: the extra parents in the order
+// they were picked, then the chosen destination, without duplicates or out-of-range indices.
+func rebaseDestinations(r Request, n int) []int {
+	var dests []int
+	for _, i := range append(slices.Clone(r.RebaseExtraDests), r.RebaseDestIndex) {
+		if i >= 0 && i < n && !slices.Contains(dests, i) {
+			dests = append(dests, i)
+		}
+	}
+	return dests
+}
+
+func rebaseFlagOrDefault(flag string) string {
+	if flag == "" {
+		return jj.RebaseSource
+	}
+	return flag
+}
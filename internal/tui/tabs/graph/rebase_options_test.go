@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// In rebase mode m cycles -s/-r/-b, A toggles -A (not with -b) and Space collects extra parents
+// that Enter sends along with the chosen destination.
+func TestGraphModel_RebaseOptions(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ChangeID: "a", ShortID: "aaaa"},
+		{ChangeID: "b", ShortID: "bbbb"},
+		{ChangeID: "c", ShortID: "cccc"},
+	}}}
+	m.graphFocused = true
+	m.StartRebaseMode(0)
+	ctx := &RequestContext{JJService: &jj.Service{}, Repository: m.repository, SelectedCommit: 0, RebaseSourceCommit: 0}
+	key := func(k tea.KeyMsg) *Request {
+		t.Helper()
+		updated, req, _ := m.handleKeyMsg(k)
+		m = updated
+		return req
+	}
+	runes := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+
+	if req := key(tea.KeyMsg{Type: tea.KeyEnter}); req == nil || req.RebaseFlag != "" || len(req.RebaseExtraDests) != 0 {
+		t.Fatalf("default Enter = %+v", req)
+	}
+
+	key(runes('m'))
+	if m.rebaseFlag != jj.RebaseRevision {
+		t.Fatalf("m once = %q, want -r", m.rebaseFlag)
+	}
+	key(runes('A'))
+	if !m.rebaseInsertAfter || !strings.Contains(m.rebaseOptionsLabel(), "-A insert after") {
+		t.Fatalf("A = %v, label %q", m.rebaseInsertAfter, m.rebaseOptionsLabel())
+	}
+	key(runes('m'))
+	if m.rebaseFlag != jj.RebaseBranch || m.rebaseInsertAfter {
+		t.Fatal("-b should drop insert-after")
+	}
+	key(runes('A'))
+	if m.rebaseInsertAfter {
+		t.Fatal("A should do nothing with -b")
+	}
+	key(runes('m'))
+	key(runes('m'))
+	if m.rebaseFlag != jj.RebaseRevision {
+		t.Fatalf("m cycle = %q, want -r", m.rebaseFlag)
+	}
+
+	m.selectedCommit = 0
+	key(tea.KeyMsg{Type: tea.KeySpace})
+	if len(m.rebaseExtraDests) != 0 {
+		t.Fatal("the source can't be an extra parent")
+	}
+	m.selectedCommit = 2
+	key(tea.KeyMsg{Type: tea.KeySpace})
+	if !m.buildGraphData().RebaseExtraDests[2] {
+		t.Fatal("Space should add the selected commit as a parent")
+	}
+	m.selectedCommit = 1
+	req := key(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || req.RebaseFlag != jj.RebaseRevision || len(req.RebaseExtraDests) != 1 || req.RebaseDestIndex != 1 {
+		t.Fatalf("Enter with options = %+v", req)
+	}
+	res := HandleRequest(*req, ctx)
+	if res.Cmd == nil || res.SuccessStatus != "Rebasing aaaa (-r) onto cccc, bbbb..." {
+		t.Fatalf("rebase with options = %+v", res)
+	}
+
+	req.RebaseExtraDests = []int{0}
+	if res := HandleRequest(*req, ctx); res.Cmd != nil || res.Status != "Cannot rebase commit onto itself" {
+		t.Fatalf("source among parents = %+v", res)
+	}
+
+	key(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.rebaseFlag != "" || m.rebaseExtraDests != nil {
+		t.Fatal("Esc should reset the rebase options")
+	}
+}
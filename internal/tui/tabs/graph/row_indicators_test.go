@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// Mutable commits show their remote state before the commit ID; immutable ones get a blank so IDs
+// line up, and graphs without remote information have no column at all.
+func TestGraph_RemoteStateColumn(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.repository = &internal.Repository{
+		Graph: internal.CommitGraph{
+			Commits: []internal.Commit{
+				{ID: "local111", ShortID: "local111", ChangeID: "l", Summary: "local", GraphPrefix: "@  ", IsWorking: true, RemoteState: internal.RemoteStateLocal},
+				{ID: "rewrt222", ShortID: "rewrt222", ChangeID: "r", Summary: "rewritten", GraphPrefix: "○  ", RemoteState: internal.RemoteStateDiverged},
+				{ID: "pushd333", ShortID: "pushd333", ChangeID: "p", Summary: "pushed", GraphPrefix: "○  ", RemoteState: internal.RemoteStatePushed},
+				{ID: "trunk444", ShortID: "trunk444", ChangeID: "t", Summary: "trunk", GraphPrefix: "◆  ", Immutable: true, RemoteState: internal.RemoteStatePushed},
+			},
+		},
+	}
+	graph := ansi.Strip(m.Graph(m.buildGraphData()).GraphContent)
+	for _, want := range []string{
+		"@  " + styles.RemoteLocalMark + " local111",
+		"○  " + styles.RemoteDivergedMark + " rewrt222",
+		"○  " + styles.RemotePushedMark + " pushd333",
+		"◆    trunk444",
+	} {
+		if !strings.Contains(graph, want) {
+			t.Errorf("graph missing %q:\n%s", want, graph)
+		}
+	}
+
+	for i := range m.repository.Graph.Commits {
+		m.repository.Graph.Commits[i].RemoteState = ""
+	}
+	graph = ansi.Strip(m.Graph(m.buildGraphData()).GraphContent)
+	if !strings.Contains(graph, "○  pushd333") {
+		t.Errorf("graph without remote states should have no column:\n%s", graph)
+	}
+}
+
+// Mutable commits show how far they are ahead of trunk, and how far behind once trunk moved on.
+func TestGraph_TrunkDistance(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.repository = &internal.Repository{
+		Graph: internal.CommitGraph{
+			Commits: []internal.Commit{
+				{ID: "stale111", ShortID: "stale111", ChangeID: "s", Summary: "stale", TrunkAhead: 2, TrunkBehind: 5},
+				{ID: "fresh222", ShortID: "fresh222", ChangeID: "f", Summary: "fresh", TrunkAhead: 1},
+				{ID: "trunk333", ShortID: "trunk333", ChangeID: "t", Summary: "trunk", Immutable: true},
+			},
+		},
+	}
+	graph := ansi.Strip(m.Graph(m.buildGraphData()).GraphContent)
+	for _, want := range []string{"stale " + styles.GlyphAhead + "2 " + styles.GlyphBehind + "5", "fresh " + styles.GlyphAhead + "1"} {
+		if !strings.Contains(graph, want) {
+			t.Errorf("graph missing %q:\n%s", want, graph)
+		}
+	}
+	if strings.Contains(graph, "trunk "+styles.GlyphAhead) || strings.Contains(graph, "fresh "+styles.GlyphAhead+"1 "+styles.GlyphBehind) {
+		t.Errorf("unexpected distance:\n%s", graph)
+	}
+}
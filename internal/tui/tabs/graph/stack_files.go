@@ -0,0 +1,164 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// StackFilesLoadedMsg is sent when LoadStackFilesCmd finishes.
+type StackFilesLoadedMsg struct {
+	state.RepoResult
+	Head      string
+	Commits   []jj.StackCommitFiles
+	Truncated bool
+	Err       error
+}
+
+// LoadStackFilesCmd loads the files changed by each commit in trunk()..head.
+func LoadStackFilesCmd(svc *jj.Service, head string) tea.Cmd {
+	if svc == nil || head == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		commits, truncated, err := svc.StackFiles(context.Background(), head)
+		return StackFilesLoadedMsg{Head: head, Commits: commits, Truncated: truncated, Err: err}
+	}
+}
+
+// stackFilesState is the open stack files view (S): the files pane lists every file changed in
+// trunk()..head grouped by commit instead of the selected commit's files.
+type stackFilesState struct {
+	head      string // revset passed to jj: a bookmark name or a change ID
+	label     string // head as shown in the header (bookmark name or short ID)
+	loaded    bool
+	commits   []jj.StackCommitFiles
+	truncated bool
+	err       string
+}
+
+// toggleStackFiles opens the stack files view for the selected commit's bookmark (the one Create
+// PR would push, falling back to the commit itself), or closes it when open.
+func (m GraphModel) toggleStackFiles() (GraphModel, *Request, tea.Cmd) {
+	if m.stackFiles != nil {
+		m.stackFiles = nil
+		return m, nil, nil
+	}
+	if m.repository == nil || m.selectedCommit < 0 || m.selectedCommit >= len(m.repository.Graph.Commits) {
+		return m, nil, nil
+	}
+	head := m.GetCreatePRBranch()
+	label := head
+	if head == "" || isDefaultBranch(head) {
+		c := m.repository.Graph.Commits[m.selectedCommit]
+		head, label = c.ChangeID, c.ShortID
+	}
+	m.hunkSplit = nil
+	m.stackFiles = &stackFilesState{head: head, label: label}
+	m.filesViewport.YOffset = 0
+	return m, &Request{LoadStackFiles: &head}, nil
+}
+
+// setStackFiles applies a load result to the open view (ignoring results for another head).
+func (m *GraphModel) setStackFiles(msg StackFilesLoadedMsg) {
+	if m.stackFiles == nil || m.stackFiles.head != msg.Head {
+		return
+	}
+	m.stackFiles.loaded = true
+	m.stackFiles.commits = msg.Commits
+	m.stackFiles.truncated = msg.Truncated
+	m.stackFiles.err = ""
+	if msg.Err != nil {
+		m.stackFiles.err = msg.Err.Error()
+	}
+}
+
+// handleStackFilesKey handles files pane keys while the stack files view is open: j/k scroll,
+// Esc closes, and the single-file actions are ignored since no file is selected.
+func (m GraphModel) handleStackFilesKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd, bool) {
+	switch msg.String() {
+	case "j", "down":
+		m.filesViewport.LineDown(1)
+	case "k", "up":
+		m.filesViewport.LineUp(1)
+	case "esc", "q":
+		m.stackFiles = nil
+	case "o", "O", "enter", "v", "[", "]", "i", "f", "/", "L":
+	default:
+		return m, nil, nil, false
+	}
+	return m, nil, nil, true
+}
+
+// renderStackFiles renders the files pane content for the stack files view: paths touched by
+// more than one commit first (squash candidates), then each commit's files, oldest first.
+func (m GraphModel) renderStackFiles(focused bool) string {
+	st := m.stackFiles
+	focusIndicator := "  "
+	if focused {
+		focusIndicator = "► "
+	}
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	warn := lipgloss.NewStyle().Foreground(styles.ColorPending)
+	header := lipgloss.NewStyle().Bold(true).Render(focusIndicator + "Stack files: trunk().." + st.label)
+	if !st.loaded {
+		return header + "\n" + muted.Render("  Loading stack files…")
+	}
+	if st.err != "" {
+		return header + "\n" + lipgloss.NewStyle().Foreground(styles.ColorNegative).Render("  "+st.err)
+	}
+	if len(st.commits) == 0 {
+		return header + muted.Render(" (S to close)") + "\n" + muted.Render("  No commits between trunk() and this commit.")
+	}
+
+	touched := jj.MultiTouchedPaths(st.commits)
+	multi := make(map[string]int, len(touched))
+	for _, t := range touched {
+		multi[t.Path] = len(t.ChangeIDs)
+	}
+	paths := map[string]bool{}
+	for _, c := range st.commits {
+		for _, f := range c.Files {
+			paths[f.Path] = true
+		}
+	}
+	summary := fmt.Sprintf(" · %d commits · %d files", len(st.commits), len(paths))
+	if st.truncated {
+		summary = fmt.Sprintf(" · first %d commits · %d files", len(st.commits), len(paths))
+	}
+	if len(touched) > 0 {
+		summary += fmt.Sprintf(" · %d touched by several commits", len(touched))
+	}
+	lines := []string{header + muted.Render(summary+" (S to close)")}
+
+	if len(touched) > 0 {
+		lines = append(lines, warn.Bold(true).Render("  Touched by several commits (squash candidates):"))
+		for _, t := range touched {
+			lines = append(lines, "    "+warn.Render(t.Path)+muted.Render(" "+strings.Join(t.ChangeIDs, ", ")))
+		}
+		lines = append(lines, "")
+	}
+	for _, c := range st.commits {
+		lines = append(lines, "  "+CommitIDStyle.Render(c.ChangeIDShort)+" "+c.Subject+muted.Render(fmt.Sprintf(" (%d files)", len(c.Files))))
+		for _, f := range c.Files {
+			statusStyle, statusChar := styles.GetStatusStyle(f.Status)
+			path := f.Path
+			if n := multi[f.Path]; n > 1 {
+				path = warn.Render(fmt.Sprintf("%s ×%d", path, n))
+			}
+			lines = append(lines, "    "+statusStyle.Render(statusChar)+" "+path+styles.DiffStatsSuffix(f.LinesAdded, f.LinesRemoved, f.StatsOK))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsStackFilesOpen reports whether the files pane shows the stack files view.
+func (m *GraphModel) IsStackFilesOpen() bool {
+	return m.stackFiles != nil
+}
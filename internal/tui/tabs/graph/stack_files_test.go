@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// S opens the stack files view for the selected commit's range; the loaded files are grouped
+// by commit with shared paths listed first, and Esc in the files pane closes it.
+func TestGraphModel_StackFiles(t *testing.T) {
+	m := NewGraphModel(zone.New())
+	m.width, m.height = 120, 40
+	m.repository = &internal.Repository{
+		Graph: internal.CommitGraph{Commits: []internal.Commit{{ID: "b", ChangeID: "bbbbbbbbbbbb", ShortID: "bbbb", Summary: "second"}}},
+	}
+	m, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if req == nil || req.LoadStackFiles == nil || *req.LoadStackFiles != "bbbbbbbbbbbb" {
+		t.Fatalf("S should request the stack files for the selected commit, got %+v", req)
+	}
+	if files := m.Graph(m.buildGraphData()).FilesContent; !strings.Contains(files, "Loading stack files") {
+		t.Fatalf("expected a loading line:\n%s", files)
+	}
+	m.setStackFiles(StackFilesLoadedMsg{Head: "bbbbbbbbbbbb", Commits: []jj.StackCommitFiles{
+		{ChangeIDShort: "aaaa", Subject: "first", Files: []jj.ChangedFile{{Path: "a.go", Status: "A"}}},
+		{ChangeIDShort: "bbbb", Subject: "second", Files: []jj.ChangedFile{{Path: "a.go", Status: "M"}, {Path: "b.go", Status: "A"}}},
+	}})
+	files := m.Graph(m.buildGraphData()).FilesContent
+	for _, want := range []string{"trunk()..bbbb", "2 commits · 2 files · 1 touched by several commits", "squash candidates", "a.go ×2", "aaaa, bbbb"} {
+		if !strings.Contains(files, want) {
+			t.Errorf("stack view missing %q:\n%s", want, files)
+		}
+	}
+	m.graphFocused = false
+	if m, req, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")}); req != nil {
+		t.Fatalf("file actions should be ignored in the stack view, got %+v", req)
+	}
+	m, _, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsStackFilesOpen() {
+		t.Fatal("Esc should close the stack files view")
+	}
+}
@@ -7,40 +7,76 @@ import (
 
 var (
 	// Special styles for rebase mode
+	RebaseSourceStyle lipgloss.Style
+	RebaseDestStyle   lipgloss.Style
+	RebaseHeaderStyle lipgloss.Style
+
+	// Special styles for merge mode
+	MergeTargetStyle lipgloss.Style
+	MergeSourceStyle lipgloss.Style
+	MergeHeaderStyle lipgloss.Style
+
+	CommitStyle         lipgloss.Style
+	CommitSelectedStyle lipgloss.Style
+
+	// OtherAuthorStyle dims commits by other authors when the graph highlights your own.
+	OtherAuthorStyle lipgloss.Style
+
+	// SearchMatchStyle highlights the commits matching the graph search (/).
+	SearchMatchStyle lipgloss.Style
+
+	// MarkedStyle is the check shown on commits marked with Space for bulk actions.
+	MarkedStyle lipgloss.Style
+
+	CommitIDStyle lipgloss.Style
+
+	// Style for graph lines (muted color)
+	GraphStyle lipgloss.Style
+)
+
+func init() {
+	styles.OnThemeChange(rebuildStyles)
+}
+
+// rebuildStyles sets the styles above from the active theme.
+func rebuildStyles() {
 	RebaseSourceStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("#5555AA")).
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Bold(true)
+		Background(styles.ColorRebaseSrc).
+		Foreground(styles.ColorOnAccent).
+		Bold(true)
 	RebaseDestStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("#55AA55")).
-			Foreground(lipgloss.Color("#FFFFFF"))
+		Background(styles.ColorRebaseDest).
+		Foreground(styles.ColorOnAccent)
 	RebaseHeaderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFAA00")).
-				Bold(true)
+		Foreground(styles.ColorAttention).
+		Bold(true)
 
-	// Special styles for merge mode
 	MergeTargetStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("#55AA55")).
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Bold(true)
+		Background(styles.ColorRebaseDest).
+		Foreground(styles.ColorOnAccent).
+		Bold(true)
 	MergeSourceStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("#5555AA")).
-				Foreground(lipgloss.Color("#FFFFFF"))
+		Background(styles.ColorRebaseSrc).
+		Foreground(styles.ColorOnAccent)
 	MergeHeaderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFAA00")).
-				Bold(true)
+		Foreground(styles.ColorAttention).
+		Bold(true)
 
 	CommitStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F8F8F2"))
-
+		Foreground(styles.ColorText)
 	CommitSelectedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#F8F8F2")).
-				Background(lipgloss.Color("#44475A"))
-
+		Foreground(styles.ColorText).
+		Background(styles.ColorSelection)
+	OtherAuthorStyle = lipgloss.NewStyle().
+		Foreground(styles.ColorMuted)
+	SearchMatchStyle = lipgloss.NewStyle().
+		Foreground(styles.ColorHighlight).
+		Bold(true)
+	MarkedStyle = lipgloss.NewStyle().
+		Foreground(styles.ColorSecondary).
+		Bold(true)
 	CommitIDStyle = lipgloss.NewStyle().
-			Foreground(styles.ColorPrimary).
-			Bold(true)
-
-	// Style for graph lines (muted color)
+		Foreground(styles.ColorPrimary).
+		Bold(true)
 	GraphStyle = lipgloss.NewStyle().Foreground(styles.ColorMuted)
-)
+}
@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/mattn/go-runewidth"
+)
+
+// trashMaxRows caps how many entries the trash lists at once.
+const trashMaxRows = 12
+
+// AbandonedMsg is sent when Abandon finishes; main reports it and reloads the graph.
+type AbandonedMsg struct {
+	state.RepoResult
+	ChangeID string
+	Err      error
+}
+
+// Status is the status line after abandoning a commit.
+func (msg AbandonedMsg) Status() string {
+	return fmt.Sprintf("Abandoned %s (U: restore from trash)", msg.ChangeID)
+}
+
+// TrashLoadedMsg carries the restorable abandoned commits for the trash list (U).
+type TrashLoadedMsg struct {
+	state.RepoResult
+	Entries []jj.TrashEntry
+}
+
+// LoadTrashCmd reads the trash from the jj service.
+func LoadTrashCmd(svc *jj.Service) tea.Cmd {
+	return func() tea.Msg {
+		return TrashLoadedMsg{Entries: svc.Trash()}
+	}
+}
+
+// TrashRestoredMsg is sent when RestoreFromTrashCmd finishes; main reports it and reloads.
+type TrashRestoredMsg struct {
+	state.RepoResult
+	Entry jj.TrashEntry
+	Err   error
+}
+
+// RestoreFromTrashCmd reverts the operation that abandoned entry.
+func RestoreFromTrashCmd(svc *jj.Service, entry jj.TrashEntry) tea.Cmd {
+	return func() tea.Msg {
+		return TrashRestoredMsg{Entry: entry, Err: svc.RestoreFromTrash(context.Background(), entry.OpID)}
+	}
+}
+
+// Status is the status line after restoring a commit.
+func (msg TrashRestoredMsg) Status() string {
+	return fmt.Sprintf("Restored %s from the trash", msg.Entry.ChangeID)
+}
+
+// trashState is the open trash list: commits abandoned this session, newest first.
+type trashState struct {
+	loaded  bool
+	entries []jj.TrashEntry
+	cursor  int
+}
+
+// openTrash opens the trash list and asks main to load it.
+func (m GraphModel) openTrash() (GraphModel, *Request, tea.Cmd) {
+	m.trash = &trashState{}
+	return m, &Request{LoadTrash: true}, nil
+}
+
+// setTrash fills the open trash list.
+func (m *GraphModel) setTrash(msg TrashLoadedMsg) {
+	if m.trash == nil {
+		return
+	}
+	m.trash.loaded = true
+	m.trash.entries = msg.Entries
+	m.trash.cursor = 0
+}
+
+// handleTrashKey handles keys while the trash list is open; Enter restores the selected commit.
+func (m GraphModel) handleTrashKey(msg tea.KeyMsg) (GraphModel, *Request, tea.Cmd) {
+	st := m.trash
+	switch msg.String() {
+	case "esc", "q", "U":
+		m.trash = nil
+	case "j", "down":
+		if st.cursor < len(st.entries)-1 {
+			st.cursor++
+		}
+	case "k", "up":
+		if st.cursor > 0 {
+			st.cursor--
+		}
+	case "enter":
+		if st.cursor < len(st.entries) {
+			entry := st.entries[st.cursor]
+			m.trash = nil
+			return m, &Request{RestoreTrash: &entry}, nil
+		}
+	}
+	return m, nil, nil
+}
+
+// renderTrash renders the trash list dialog.
+func (m *GraphModel) renderTrash() string {
+	st := m.trash
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	selected := lipgloss.NewStyle().Background(styles.ColorCursor).Foreground(styles.ColorText)
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(styles.ColorSecondary).Render("Trash: recently abandoned"),
+		"",
+	}
+	switch {
+	case !st.loaded:
+		lines = append(lines, muted.Render("Loading…"))
+	case len(st.entries) == 0:
+		lines = append(lines, muted.Render("Nothing abandoned from jj-tui this session."))
+	}
+	start := max(0, st.cursor-trashMaxRows+1)
+	for i := start; i < len(st.entries) && i < start+trashMaxRows; i++ {
+		e := st.entries[i]
+		summary := e.Summary
+		if summary == "" {
+			summary = "(no description)"
+		}
+		row := e.ChangeID + "  " + runewidth.Truncate(summary, 40, "…")
+		age := muted.Render("  " + trashAge(time.Since(e.At)))
+		if i == st.cursor {
+			lines = append(lines, selected.Render(row)+age)
+		} else {
+			lines = append(lines, row+age)
+		}
+	}
+	lines = append(lines, "", muted.Render("Enter: restore (reverts the abandon, keeps later changes) · Esc to close"))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// trashAge formats how long ago a commit was abandoned.
+func trashAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh ago", int(d.Hours()))
+}
+
+// IsTrashOpen reports whether the trash list owns the keyboard.
+func (m *GraphModel) IsTrashOpen() bool {
+	return m.trash != nil
+}
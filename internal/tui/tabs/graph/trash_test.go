@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// U opens the trash and asks for its entries; Enter restores the selected one and closes it.
+func TestGraphModel_Trash(t *testing.T) {
+	m := NewGraphModel(nil)
+	m.graphFocused = true
+	key := func(k tea.KeyMsg) *Request {
+		t.Helper()
+		updated, req, _ := m.handleKeyMsg(k)
+		m = updated
+		return req
+	}
+
+	if req := key(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")}); req == nil || !req.LoadTrash || !m.IsTrashOpen() {
+		t.Fatalf("U = %+v, want the trash to open and load", req)
+	}
+	m.setTrash(TrashLoadedMsg{Entries: []jj.TrashEntry{
+		{ChangeID: "newer", Summary: "second", OpID: "op2", At: time.Now()},
+		{ChangeID: "older", Summary: "first", OpID: "op1", At: time.Now().Add(-5 * time.Minute)},
+	}})
+	if view := m.renderTrash(); !strings.Contains(view, "newer  second") || !strings.Contains(view, "5m ago") {
+		t.Fatalf("trash view:\n%s", view)
+	}
+	key(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	req := key(tea.KeyMsg{Type: tea.KeyEnter})
+	if req == nil || req.RestoreTrash == nil || req.RestoreTrash.OpID != "op1" || m.IsTrashOpen() {
+		t.Fatalf("Enter = %+v", req)
+	}
+	ctx := &RequestContext{JJService: &jj.Service{}}
+	if res := HandleRequest(*req, ctx); res.Cmd == nil || res.SuccessStatus != "Restoring older…" {
+		t.Fatalf("restore = %+v", res)
+	}
+
+	key(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	m.setTrash(TrashLoadedMsg{})
+	if !strings.Contains(m.renderTrash(), "Nothing abandoned") {
+		t.Fatal("an empty trash should say so")
+	}
+	key(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsTrashOpen() {
+		t.Fatal("Esc should close the trash")
+	}
+}
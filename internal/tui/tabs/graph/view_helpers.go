@@ -6,10 +6,14 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/keymap"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/styles"
 	"github.com/madicen/jj-tui/internal/tui/util"
+	"github.com/madicen/jj-tui/internal/tui/xref"
 )
 
 // FindCommitsWithEmptyDescriptions finds commits from the selected commit back to
@@ -110,9 +114,15 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 	var fileLines []string
 
 	if data.InRebaseMode {
-		rebaseHeader := RebaseHeaderStyle.
-			Render("🔀 REBASE MODE - Select destination commit (Esc to cancel)")
+		header := "🔀 REBASE MODE - Select destination commit (Esc to cancel)"
+		if data.DuplicateMode {
+			header = "📋 DUPLICATE MODE - Select destination commit (Esc to cancel)"
+		}
+		rebaseHeader := RebaseHeaderStyle.Render(header)
 		graphLines = append(graphLines, rebaseHeader)
+		if data.RebaseOptions != "" {
+			graphLines = append(graphLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(data.RebaseOptions))
+		}
 		graphLines = append(graphLines, "")
 	}
 
@@ -123,6 +133,9 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 		graphLines = append(graphLines, "")
 	}
 
+	isChange := data.IsChange
+	showRemoteState := hasRemoteState(data.Repository.Graph.Commits)
+	bookmarkNames := localBookmarkNames(data.Repository.Graph.Commits, data.BookmarkPrefix)
 	for i, commit := range data.Repository.Graph.Commits {
 		style := CommitStyle
 		if data.RebaseDragSource >= 0 {
@@ -148,6 +161,10 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 			}
 		} else if i == data.SelectedCommit {
 			style = CommitSelectedStyle
+		} else if data.SearchMatches[commit.ChangeID] {
+			style = SearchMatchStyle
+		} else if data.AuthorMode == AuthorModeHighlight && !commit.Mine && !commit.IsWorking {
+			style = OtherAuthorStyle
 		}
 
 		var graphPrefix string
@@ -155,18 +172,7 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 		if commit.IsWorking {
 			graphStyle = graphStyle.Foreground(styles.ColorSecondary)
 		}
-		if commit.GraphPrefix != "" {
-			graphPrefix = graphStyle.Render(commit.GraphPrefix)
-		} else {
-			switch {
-			case commit.IsWorking:
-				graphPrefix = graphStyle.Render("@  ")
-			case commit.Immutable:
-				graphPrefix = graphStyle.Render("◆  ")
-			default:
-				graphPrefix = graphStyle.Render("○  ")
-			}
-		}
+		graphPrefix = graphStyle.Render(graphPrefixText(commit))
 
 		selectionPrefix := "  "
 		if data.RebaseDragSource >= 0 {
@@ -182,6 +188,8 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 				selectionPrefix = "⚡ "
 			case data.SelectedCommit == i:
 				selectionPrefix = "→ "
+			case data.RebaseExtraDests[i]:
+				selectionPrefix = MarkedStyle.Render("+") + " "
 			}
 		} else if data.InMergeMode {
 			switch i {
@@ -193,13 +201,16 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 		} else if i == data.SelectedCommit {
 			selectionPrefix = "► "
 		}
+		if data.Marked[commit.ChangeID] {
+			selectionPrefix = strings.TrimSuffix(selectionPrefix, " ") + MarkedStyle.Render("✓")
+		}
 
 		statusIndicator := ""
 		if commit.Conflicts {
-			statusIndicator = " ⚠"
+			statusIndicator = lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(" " + styles.GlyphConflict)
 		}
 		if commit.Divergent {
-			statusIndicator += lipgloss.NewStyle().Foreground(lipgloss.Color("#FF79C6")).Render(" " + styles.DivergentMark + " divergent")
+			statusIndicator += lipgloss.NewStyle().Foreground(styles.ColorSpecial).Render(" " + styles.DivergentMark + " divergent")
 		}
 
 		branchStr := ""
@@ -212,21 +223,48 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 			for _, b := range commit.Branches {
 				raw, _ := util.NormalizeBookmarkListToken(b)
 				bKey := util.LocalBookmarkName(strings.TrimSpace(raw))
+				label := util.ShortBookmarkLabel(b, data.BookmarkPrefix, bookmarkNames)
 				if conflictedSet[b] || conflictedSet[raw] || conflictedSet[bKey] {
-					branchParts = append(branchParts, lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Render(b+" ⚠"))
+					branchParts = append(branchParts, lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(label+" "+styles.GlyphConflict))
 				} else {
-					branchParts = append(branchParts, b)
+					branchParts = append(branchParts, label)
 				}
 			}
 			branchStr = " " + lipgloss.NewStyle().Foreground(styles.ColorSecondary).Render("["+strings.Join(branchParts, ", ")+"]")
 		}
+		if data.AuthorMode == AuthorModeHighlight && !commit.Mine && !commit.IsWorking && commit.Author != "" {
+			branchStr += OtherAuthorStyle.Render(" · " + authorName(commit.Author))
+		}
+		branchStr += trunkDistance(commit)
 
-		beforeStatus := fmt.Sprintf("%s%s%s %s%s",
+		commitIndex := i
+		refs := xref.Find(commit.Summary, isChange)
+		markRef := func(ri int, tok string) string {
+			return m.zoneManager.Mark(mouse.ZoneCommitRef(commitIndex, ri), tok)
+		}
+		summary := ""
+		// A subject too long for the pane (E) continues on a second line under itself, below
+		// the commit's graph column; bookmarks and other trailers follow it there.
+		indent := summaryIndent(commit, showRemoteState)
+		continued := ""
+		if cut := summaryBreak(commit.Summary, indent, data.WrapWidth); cut >= 0 {
+			var tail string
+			summary, tail = splitSummary(commit.Summary, cut, data.WrapWidth-indent, refs, style, markRef)
+			graphCol := continuationGraph(data.Repository.Graph.Commits, i)
+			pad := strings.Repeat(" ", max(indent-2-ansi.StringWidth(graphCol), 0))
+			continued = "\n  " + GraphStyle.Render(graphCol) + pad + tail + branchStr
+			branchStr = ""
+		} else {
+			summary = xref.Render(commit.Summary, refs, style, markRef)
+		}
+		beforeStatus := fmt.Sprintf("%s%s%s%s %s%s%s",
 			selectionPrefix,
 			graphPrefix,
+			remoteColumn(commit, showRemoteState),
 			CommitIDStyle.Render(commit.ShortID),
-			commit.Summary,
+			summary,
 			branchStr,
+			continued,
 		)
 		afterStatus := statusIndicator
 		var commitRow string
@@ -238,7 +276,7 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 		showResolveBookmark := onSelectedRow && len(commit.ConflictedBranches) > 0
 		if showForgot || showEvolog || showResolveBookmark {
 			muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
-			resolveStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555"))
+			resolveStyle := lipgloss.NewStyle().Foreground(styles.ColorNegative)
 			var parts []string
 			if showForgot {
 				parts = append(parts, m.zoneManager.Mark(mouse.ZoneActionMoveOntoOriginAt(i), muted.Render("Forgot New Commit? (f)")))
@@ -272,6 +310,9 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 			graphLines = append(graphLines, paddedLine)
 		}
 	}
+	if data.Repository.Graph.HasMore {
+		graphLines = append(graphLines, m.renderLoadMoreRow(len(data.Repository.Graph.Commits), data.LoadingMore))
+	}
 
 	if data.InRebaseMode {
 		graphLines = append(graphLines, "")
@@ -293,12 +334,12 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 		}
 	}
 
-	if !data.GraphFocused && len(data.ChangedFiles) > 0 && data.SelectedFile >= 0 {
-		actionLines = append(actionLines, "File Actions:")
+	if !data.GraphFocused && len(data.ChangedFiles) > 0 && data.SelectedFile >= 0 && data.FilesPaneView == "" {
+		actionLines = append(actionLines, i18n.T("label.file_actions"))
 		var fileActionButtons []string
 		fileActionButtons = append(fileActionButtons,
-			m.zoneManager.Mark(mouse.ZoneActionViewFileDiff, styles.ButtonStyle.Render("View diff (o)")),
-			m.zoneManager.Mark(mouse.ZoneActionOpenInExternalEditor, styles.ButtonStyle.Render("Open in editor (O)")),
+			m.zoneManager.Mark(mouse.ZoneActionViewFileDiff, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.view_diff"), "file.diff"))),
+			m.zoneManager.Mark(mouse.ZoneActionOpenInExternalEditor, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.open_in_editor"), "file.open_editor"))),
 		)
 		isMutable := false
 		if data.SelectedCommit >= 0 && data.SelectedCommit < len(data.Repository.Graph.Commits) {
@@ -308,13 +349,18 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 		if isMutable {
 			if !isFirstParentImmutable(data.Repository.Graph.Commits, data.SelectedCommit) {
 				fileActionButtons = append(fileActionButtons,
-					m.zoneManager.Mark(mouse.ZoneActionMoveFileUp, styles.ButtonStyle.Render("Move to Parent ([)")),
+					m.zoneManager.Mark(mouse.ZoneActionMoveFileUp, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.move_to_parent"), "file.move_to_parent"))),
 				)
 			}
 			fileActionButtons = append(fileActionButtons,
-				m.zoneManager.Mark(mouse.ZoneActionMoveFileDown, styles.ButtonStyle.Render("Move to Child (])")),
-				m.zoneManager.Mark(mouse.ZoneActionRevertFile, styles.ButtonStyle.Render("Revert Changes (v)")),
+				m.zoneManager.Mark(mouse.ZoneActionMoveFileDown, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.move_to_child"), "file.move_to_child"))),
+				m.zoneManager.Mark(mouse.ZoneActionRevertFile, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.revert_file"), "file.revert"))),
 			)
+			if data.Repository.Graph.Commits[data.SelectedCommit].IsWorking {
+				fileActionButtons = append(fileActionButtons,
+					m.zoneManager.Mark(mouse.ZoneActionAbsorbFile, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.absorb_file"), "file.absorb"))),
+				)
+			}
 		} else {
 			fileActionButtons = append(fileActionButtons,
 				lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("◆ Read-only commit"),
@@ -322,46 +368,71 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 		}
 		actionLines = append(actionLines, lipgloss.JoinHorizontal(lipgloss.Left, fileActionButtons...))
 	} else {
-		actionLines = append(actionLines, "Actions:")
+		actionLines = append(actionLines, i18n.T("label.actions"))
 		actionButtons := []string{
-			m.zoneManager.Mark(mouse.ZoneActionNewCommit, styles.ButtonStyle.Render("New (n)")),
+			m.zoneManager.Mark(mouse.ZoneActionNewCommit, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.new"), "commit.new"))),
 		}
 		if data.SelectedCommit >= 0 && data.SelectedCommit < len(data.Repository.Graph.Commits) {
 			commit := data.Repository.Graph.Commits[data.SelectedCommit]
 			if commit.Immutable {
+				actionButtons = append(actionButtons,
+					m.zoneManager.Mark(mouse.ZoneActionInsertAfter, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.insert_after"), "commit.insert_after"))),
+				)
 				if len(commit.Branches) > 0 {
 					actionButtons = append(actionButtons,
-						m.zoneManager.Mark(mouse.ZoneActionDelBookmark, styles.ButtonStyle.Render("Del Bookmark (x)")),
+						m.zoneManager.Mark(mouse.ZoneActionDelBookmark, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.delete_bookmark"), "commit.delete_bookmark"))),
+					)
+				}
+				if n := len(data.Marked); n >= 2 {
+					actionButtons = append(actionButtons,
+						m.zoneManager.Mark(mouse.ZoneActionMergeMarked, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.merge_marked", n), "commit.merge"))),
 					)
 				}
 				actionLines = append(actionLines, lipgloss.JoinHorizontal(lipgloss.Left, actionButtons...))
 				actionLines = append(actionLines, "")
-				actionLines = append(actionLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("◆ Selected commit is immutable (pushed to remote)"))
+				if data.CommitHistoryView != "" {
+					actionLines = append(actionLines, strings.Split(data.CommitHistoryView, "\n")...)
+				} else {
+					actionLines = append(actionLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("◆ Selected commit is immutable (pushed to remote)"))
+				}
 			} else {
 				actionButtons = append(actionButtons,
-					m.zoneManager.Mark(mouse.ZoneActionCheckout, styles.ButtonStyle.Render("Edit (e)")),
-					m.zoneManager.Mark(mouse.ZoneActionDescribe, styles.ButtonStyle.Render("Describe (d)")),
+					m.zoneManager.Mark(mouse.ZoneActionCheckout, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.edit"), "commit.edit"))),
+					m.zoneManager.Mark(mouse.ZoneActionDescribe, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.describe"), "commit.describe"))),
 				)
 				if !isFirstParentImmutable(data.Repository.Graph.Commits, data.SelectedCommit) {
 					actionButtons = append(actionButtons,
-						m.zoneManager.Mark(mouse.ZoneActionSquash, styles.ButtonStyle.Render("Squash (s)")),
+						m.zoneManager.Mark(mouse.ZoneActionSquash, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.squash"), "commit.squash"))),
+					)
+				}
+				if commit.IsWorking {
+					actionButtons = append(actionButtons,
+						m.zoneManager.Mark(mouse.ZoneActionAbsorb, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.absorb"), "commit.absorb"))),
 					)
 				}
 				actionButtons = append(actionButtons,
-					m.zoneManager.Mark(mouse.ZoneActionRebase, styles.ButtonStyle.Render("Rebase (r)")),
-					m.zoneManager.Mark(mouse.ZoneActionMerge, styles.ButtonStyle.Render("Merge from (M)")),
-					m.zoneManager.Mark(mouse.ZoneActionAbandon, styles.ButtonStyle.Render("Abandon (a)")),
-					m.zoneManager.Mark(mouse.ZoneActionBookmark, styles.ButtonStyle.Render("Bookmark (m)")),
+					m.zoneManager.Mark(mouse.ZoneActionRebase, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.rebase"), "commit.rebase"))),
+					m.zoneManager.Mark(mouse.ZoneActionMerge, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.merge_from"), "commit.merge"))),
+					m.zoneManager.Mark(mouse.ZoneActionAbandon, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.abandon"), "commit.abandon"))),
+					m.zoneManager.Mark(mouse.ZoneActionBookmark, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.bookmark"), "commit.bookmark"))),
+					m.zoneManager.Mark(mouse.ZoneActionInsertBefore, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.insert_before"), "commit.insert_before"))),
+					m.zoneManager.Mark(mouse.ZoneActionInsertAfter, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.insert_after"), "commit.insert_after"))),
 				)
+				if n := len(data.Marked); n >= 2 {
+					actionButtons = append(actionButtons,
+						m.zoneManager.Mark(mouse.ZoneActionParallelize, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.parallelize", n), "commit.parallelize"))),
+						m.zoneManager.Mark(mouse.ZoneActionMergeMarked, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.merge_marked", n), "commit.merge"))),
+					)
+				}
 				if len(commit.Branches) > 0 {
 					actionButtons = append(actionButtons,
-						m.zoneManager.Mark(mouse.ZoneActionDelBookmark, styles.ButtonStyle.Render("Del Bookmark (x)")),
+						m.zoneManager.Mark(mouse.ZoneActionDelBookmark, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.delete_bookmark"), "commit.delete_bookmark"))),
 					)
 				}
 				if commit.Divergent {
-					divergentBtnStyle := styles.ButtonStyle.Background(lipgloss.Color("#FF79C6"))
+					divergentBtnStyle := styles.ButtonStyle.Background(styles.ColorSpecial)
 					actionButtons = append(actionButtons,
-						m.zoneManager.Mark(mouse.ZoneActionResolveDivergent, divergentBtnStyle.Render("Resolve Divergent (d)")),
+						m.zoneManager.Mark(mouse.ZoneActionResolveDivergent, divergentBtnStyle.Render(keymap.Button(i18n.T("action.resolve_divergent"), "commit.describe"))),
 					)
 				}
 				prBranch := ""
@@ -369,9 +440,9 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 					prBranch = data.CommitPRBranch[data.SelectedCommit]
 				}
 				if prBranch != "" {
-					buttonLabel := "Update PR (u)"
+					buttonLabel := keymap.Button(i18n.T("action.update_pr"), "commit.update_pr")
 					if len(commit.Branches) == 0 {
-						buttonLabel = fmt.Sprintf("Update PR [%s] (u)", prBranch)
+						buttonLabel = keymap.Button(i18n.T("action.update_pr_branch", prBranch), "commit.update_pr")
 					}
 					actionButtons = append(actionButtons,
 						m.zoneManager.Mark(mouse.ZoneActionPush, styles.ButtonStyle.Render(buttonLabel)),
@@ -382,15 +453,22 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 					createPRBranch = data.CommitBookmark[data.SelectedCommit]
 				}
 				if createPRBranch != "" && !isDefaultBranch(createPRBranch) {
-					buttonLabel := "Create PR (c)"
+					buttonLabel := keymap.Button(i18n.T("action.create_pr"), "commit.create_pr")
 					if len(commit.Branches) == 0 || prBranch != "" {
-						buttonLabel = fmt.Sprintf("Create PR [%s] (c)", createPRBranch)
+						buttonLabel = keymap.Button(i18n.T("action.create_pr_branch", createPRBranch), "commit.create_pr")
+					}
+					buttonStyle := styles.ButtonStyle
+					if data.CreatePRDenied != "" {
+						buttonStyle = styles.ButtonDisabledStyle
 					}
 					actionButtons = append(actionButtons,
-						m.zoneManager.Mark(mouse.ZoneActionCreatePR, styles.ButtonStyle.Render(buttonLabel)),
+						m.zoneManager.Mark(mouse.ZoneActionCreatePR, buttonStyle.Render(buttonLabel)),
 					)
 				}
 				actionLines = append(actionLines, lipgloss.JoinHorizontal(lipgloss.Left, actionButtons...))
+				if createPRBranch != "" && !isDefaultBranch(createPRBranch) && data.CreatePRDenied != "" {
+					actionLines = append(actionLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Create PR unavailable: "+data.CreatePRDenied))
+				}
 			}
 		} else {
 			actionLines = append(actionLines, lipgloss.JoinHorizontal(lipgloss.Left, actionButtons...))
@@ -399,12 +477,21 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 
 	var fileIndexToLineIndex []int
 	var treeLines []string
-	if len(data.ChangedFiles) > 0 {
+	if data.FilesPaneView != "" {
+		fileLines = strings.Split(data.FilesPaneView, "\n")
+	} else if len(data.ChangedFiles) > 0 || data.FileCounts.Total > 0 {
 		focusIndicator := "  "
 		if !data.GraphFocused {
 			focusIndicator = "► "
 		}
-		fileLines = append(fileLines, lipgloss.NewStyle().Bold(true).Render(focusIndicator+"Changed Files (Tab to switch):"))
+		header := lipgloss.NewStyle().Bold(true).Render(focusIndicator + "Changed Files (Tab to switch):")
+		if data.FilesFilterLine != "" {
+			header += " " + data.FilesFilterLine
+		}
+		fileLines = append(fileLines, header)
+		if len(data.ChangedFiles) == 0 {
+			fileLines = append(fileLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("  No files match the filter (Esc to clear)."))
+		}
 		treeLines, fileIndexToLineIndex = m.renderFileTreeWithLineIndex(data)
 		for i := range fileIndexToLineIndex {
 			if fileIndexToLineIndex[i] >= 0 {
@@ -428,7 +515,31 @@ func (m GraphModel) Graph(data GraphData) GraphResult {
 	if data.GraphFocused {
 		focusIndicator = "► "
 	}
-	graphContent = lipgloss.NewStyle().Bold(true).Render(focusIndicator+"Graph (Tab to switch):") + "\n" + graphContent
+	header := lipgloss.NewStyle().Bold(true).Render(focusIndicator + "Graph (Tab to switch):")
+	var filters []string
+	if data.DateFilterLabel != "" {
+		filters = append(filters, data.DateFilterLabel+" (D)")
+	}
+	if label := data.AuthorMode.Label(); label != "" {
+		filters = append(filters, label+" (A)")
+	}
+	if data.RevsetAlias != "" {
+		filters = append(filters, "revset "+data.RevsetAlias+" (:)")
+	}
+	if data.SearchLabel != "" {
+		filters = append(filters, data.SearchLabel)
+	}
+	if n := len(data.Marked); n > 0 {
+		filters = append(filters, fmt.Sprintf("%d marked (B to prefix/suffix, Esc to clear)", n))
+	}
+	if data.DateFilterEditor != "" {
+		header = focusIndicator + data.DateFilterEditor
+	} else if data.SearchEditor != "" {
+		header = focusIndicator + data.SearchEditor
+	} else if len(filters) > 0 {
+		header += lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(" · " + strings.Join(filters, " · "))
+	}
+	graphContent = header + "\n" + graphContent
 
 	return GraphResult{
 		GraphContent:         graphContent,
@@ -492,7 +603,7 @@ func (m *GraphModel) renderTreeNodeWithLineIndex(node *fileTreeNode, indent stri
 			}
 			var fileLine string
 			if isSelected {
-				selectedStyle := lipgloss.NewStyle().Background(lipgloss.Color("#3d4f5f")).Foreground(lipgloss.Color("#ffffff"))
+				selectedStyle := lipgloss.NewStyle().Background(styles.ColorCursor).Foreground(styles.ColorText)
 				fileLine = fmt.Sprintf("%s%s %s%s", indent, statusStyle.Render(statusChar), selectedStyle.Render(node.name), statSuffix)
 			} else {
 				fileLine = fmt.Sprintf("%s%s %s%s", indent, statusStyle.Render(statusChar), node.name, statSuffix)
@@ -525,3 +636,78 @@ func (m *GraphModel) renderTreeNodeWithLineIndex(node *fileTreeNode, indent stri
 		m.renderTreeNodeWithLineIndex(node.children[name], newIndent, lines, false, data, lineIdx, fileIndexToLineIndex)
 	}
 }
+
+// hasRemoteState reports whether the graph was enriched with remote states; without remotes the
+// column is left out instead of showing every commit as local.
+func hasRemoteState(commits []internal.Commit) bool {
+	for _, c := range commits {
+		if c.RemoteState != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// localBookmarkNames returns the local names of every bookmark in the graph when prefix is set,
+// so labels only drop the namespace when that can't be mistaken for another bookmark.
+func localBookmarkNames(commits []internal.Commit, prefix string) map[string]bool {
+	if prefix == "" {
+		return nil
+	}
+	names := make(map[string]bool)
+	for _, c := range commits {
+		for _, b := range c.Branches {
+			name, _ := util.NormalizeBookmarkListToken(b)
+			names[util.LocalBookmarkName(name)] = true
+		}
+	}
+	return names
+}
+
+// remoteColumn is the one-cell remote-state mark and a space, before the commit ID. Immutable and
+// unknown commits get blanks so IDs stay aligned.
+func remoteColumn(commit internal.Commit, show bool) string {
+	if !show {
+		return ""
+	}
+	if commit.Immutable {
+		return "  "
+	}
+	switch commit.RemoteState {
+	case internal.RemoteStatePushed:
+		return lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(styles.RemotePushedMark) + " "
+	case internal.RemoteStateLocal:
+		return lipgloss.NewStyle().Foreground(styles.ColorPositive).Render(styles.RemoteLocalMark) + " "
+	case internal.RemoteStateDiverged:
+		return lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(styles.RemoteDivergedMark) + " "
+	}
+	return "  "
+}
+
+// trunkDistance is " ↑N" (commits ahead of trunk) and, once trunk has moved past the commit's base,
+// " ↓M" (trunk commits it is missing), for mutable commits with a known distance.
+func trunkDistance(commit internal.Commit) string {
+	if commit.Immutable || commit.TrunkAhead == 0 {
+		return ""
+	}
+	s := lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(fmt.Sprintf(" %s%d", styles.GlyphAhead, commit.TrunkAhead))
+	if commit.TrunkBehind > 0 {
+		s += lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(fmt.Sprintf(" %s%d", styles.GlyphBehind, commit.TrunkBehind))
+	}
+	return s
+}
+
+// commitChangeMatcher returns an xref change-ID matcher over the commits in repo, so only change IDs
+// that can be selected in the graph are linked.
+func commitChangeMatcher(repo *internal.Repository) func(string) bool {
+	if repo == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(repo.Graph.Commits))
+	for _, c := range repo.Graph.Commits {
+		if c.ChangeID != "" {
+			ids = append(ids, c.ChangeID)
+		}
+	}
+	return xref.ChangeMatcher(ids)
+}
@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/xref"
+	"github.com/mattn/go-runewidth"
+)
+
+// minWrapColumns is the narrowest summary column worth wrapping into; rows in a narrower pane
+// overflow as they do with wrapping off.
+const minWrapColumns = 12
+
+// toggleWrapSubjects turns wrapping of long commit subjects on or off (E) and tells main so it
+// can save the choice.
+func (m GraphModel) toggleWrapSubjects() (GraphModel, *Request, tea.Cmd) {
+	m.wrapSubjects = !m.wrapSubjects
+	m.scrollToSelectedCommit = true
+	on := m.wrapSubjects
+	return m, nil, func() tea.Msg { return WrapSubjectsChangedMsg{On: on} }
+}
+
+// SetWrapSubjects sets whether long commit subjects wrap onto a second line.
+func (m *GraphModel) SetWrapSubjects(on bool) {
+	m.wrapSubjects = on
+}
+
+// WrapsSubjects reports whether long commit subjects wrap onto a second line.
+func (m *GraphModel) WrapsSubjects() bool {
+	return m.wrapSubjects
+}
+
+// wrapWidth is the pane width long subjects wrap at, or 0 when wrapping is off.
+func (m *GraphModel) wrapWidth() int {
+	if !m.wrapSubjects {
+		return 0
+	}
+	return m.width
+}
+
+// graphPrefixText is the graph drawn before a commit's ID: jj's prefix, or a lone node glyph when
+// the graph wasn't parsed.
+func graphPrefixText(c internal.Commit) string {
+	switch {
+	case c.GraphPrefix != "":
+		return c.GraphPrefix
+	case c.IsWorking:
+		return "@  "
+	case c.Immutable:
+		return "◆  "
+	}
+	return "○  "
+}
+
+// summaryIndent is the width of a commit row before its summary: the selection marker, the graph
+// prefix, the remote column and the short commit ID with the space after it.
+func summaryIndent(c internal.Commit, showRemote bool) int {
+	w := 2 + ansi.StringWidth(graphPrefixText(c)) + ansi.StringWidth(c.ShortID) + 1
+	if showRemote {
+		w += 2
+	}
+	return w
+}
+
+// summaryBreak returns the byte offset at which summary continues on a second line so the first
+// fits width columns after indent: after the last space that fits, or mid-word when there is
+// none. It returns -1 when the summary fits or the pane is too narrow to wrap into.
+func summaryBreak(summary string, indent, width int) int {
+	avail := width - indent
+	if width <= 0 || avail < minWrapColumns || ansi.StringWidth(summary) <= avail {
+		return -1
+	}
+	cut, cols, lastSpace := -1, 0, -1
+	for i, r := range summary {
+		rw := runewidth.RuneWidth(r)
+		if cols+rw > avail {
+			cut = i
+			break
+		}
+		if r == ' ' {
+			lastSpace = i
+		}
+		cols += rw
+	}
+	if lastSpace > 0 {
+		cut = lastSpace + 1
+	}
+	if cut <= 0 {
+		return -1
+	}
+	return cut
+}
+
+// commitRowLines is how many lines a commit's row takes: 2 when its summary wraps at wrapWidth.
+func commitRowLines(c internal.Commit, showRemote bool, wrapWidth int) int {
+	if summaryBreak(c.Summary, summaryIndent(c, showRemote), wrapWidth) < 0 {
+		return 1
+	}
+	return 2
+}
+
+// splitSummary renders the two halves of a summary broken at cut, the second cut off with "…"
+// past avail columns. Cross-references keep their index into refs (and so their click zone); one
+// that would straddle the break moves to the first line.
+func splitSummary(summary string, cut, avail int, refs []xref.Ref, style lipgloss.Style, wrap func(int, string) string) (head, tail string) {
+	for _, r := range refs {
+		if r.Start < cut && cut < r.End {
+			cut = r.End
+		}
+	}
+	var headRefs, tailRefs []xref.Ref
+	for _, r := range refs {
+		if r.End <= cut {
+			headRefs = append(headRefs, r)
+		}
+	}
+	rest := strings.TrimLeft(summary[cut:], " ")
+	shift := len(summary) - len(rest)
+	kept := len(rest)
+	if runewidth.StringWidth(rest) > avail {
+		rest = runewidth.Truncate(rest, avail, "…")
+		kept = len(rest) - len("…")
+	}
+	for _, r := range refs[len(headRefs):] {
+		r.Start, r.End = r.Start-shift, r.End-shift
+		if r.End > kept {
+			break
+		}
+		tailRefs = append(tailRefs, r)
+	}
+	head = xref.Render(strings.TrimRight(summary[:cut], " "), headRefs, style, wrap)
+	offset := len(headRefs)
+	tail = xref.Render(rest, tailRefs, style, func(i int, tok string) string { return wrap(offset+i, tok) })
+	return head, tail
+}
+
+// continuationGraph is the graph column of a wrapped summary's second line: the commit's graph
+// prefix with the node replaced by the edge leaving it downwards, if any, so graph lines stay
+// unbroken.
+func continuationGraph(commits []internal.Commit, i int) string {
+	prefix := []rune(graphPrefixText(commits[i]))
+	node := -1
+	for j, r := range prefix {
+		if r != '│' && r != ' ' {
+			if node < 0 {
+				node = j
+			}
+			prefix[j] = ' '
+		}
+	}
+	if node >= 0 && edgeBelow(commits, i, node) {
+		prefix[node] = '│'
+	}
+	return string(prefix)
+}
+
+// edgeBelow reports whether the graph line under commit i's row has an edge in column col.
+func edgeBelow(commits []internal.Commit, i, col int) bool {
+	var next string
+	switch {
+	case len(commits[i].GraphLines) > 0:
+		next = commits[i].GraphLines[0]
+	case i+1 < len(commits):
+		next = graphPrefixText(commits[i+1])
+	}
+	r := []rune(next)
+	return col < len(r) && r[col] != ' '
+}
@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+)
+
+// E wraps a subject too long for the pane onto a second line aligned under it, with the graph's
+// edge continuing beside it, and later commits' line indexes account for the extra line.
+func TestGraphModel_WrapSubjects(t *testing.T) {
+	long := "Refactor the graph renderer so long subjects stay readable when narrow"
+	commits := []internal.Commit{
+		{ID: "a", ChangeID: "a", ShortID: "aaaa", Summary: long, GraphPrefix: "@  ", IsWorking: true},
+		{ID: "b", ChangeID: "b", ShortID: "bbbb", Summary: "short", GraphPrefix: "○  ", GraphLines: []string{"│"}},
+		{ID: "c", ChangeID: "c", ShortID: "cccc", Summary: "root", GraphPrefix: "◆  "},
+	}
+	commits[0].GraphLines = []string{"│"}
+	m := NewGraphModel(zone.New())
+	m.repository = &internal.Repository{Graph: internal.CommitGraph{Commits: commits}}
+	m.width = 50
+
+	if got := graphLineIndexForCommit(commits, 2, m.wrapWidth()); got != 4 {
+		t.Fatalf("line index with wrapping off = %d, want 4", got)
+	}
+	m, _, cmd := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	if msg, ok := cmd().(WrapSubjectsChangedMsg); !ok || !msg.On || !m.WrapsSubjects() {
+		t.Fatalf("E should turn wrapping on, cmd sent %#v", msg)
+	}
+	if got := graphLineIndexForCommit(commits, 2, m.wrapWidth()); got != 5 {
+		t.Fatalf("line index with wrapping on = %d, want 5", got)
+	}
+
+	var lines []string
+	for _, l := range strings.Split(m.Graph(m.buildGraphData()).GraphContent, "\n") {
+		lines = append(lines, strings.TrimRight(ansi.Strip(m.zoneManager.Scan(l)), " "))
+	}
+	first := -1
+	for i, l := range lines {
+		if strings.Contains(l, "aaaa") {
+			first = i
+			break
+		}
+	}
+	if first < 0 || first+1 >= len(lines) {
+		t.Fatalf("commit row not found:\n%s", strings.Join(lines, "\n"))
+	}
+	head, tail := lines[first], lines[first+1]
+	if ansi.StringWidth(head) > m.width {
+		t.Errorf("first line is %d columns, wider than the %d-column pane: %q", ansi.StringWidth(head), m.width, head)
+	}
+	// Compare by column: the selection marker and graph glyphs are multi-byte.
+	indent := ansi.StringWidth(head[:strings.Index(head, "Refactor")])
+	headRunes, tailRunes := []rune(head), []rune(tail)
+	if len(tailRunes) <= indent || string(tailRunes[:indent]) != "  │"+strings.Repeat(" ", indent-3) || tailRunes[indent] == ' ' {
+		t.Errorf("second line should continue the graph edge and align under the subject:\n%s\n%s", head, tail)
+	}
+	words := strings.Fields(string(headRunes[indent:]) + " " + string(tailRunes[min(indent, len(tailRunes)):]))
+	if strings.Join(words, " ") != long {
+		t.Errorf("wrapped subject = %q, want %q", strings.Join(words, " "), long)
+	}
+}
@@ -0,0 +1,152 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// maxEntries bounds how many of the newest audit entries are shown.
+const maxEntries = 500
+
+// LoadedMsg carries the newest audit log entries.
+type LoadedMsg struct {
+	state.RepoResult
+	Path    string // "" when the audit log is off
+	Entries []jj.AuditEntry
+	Err     error
+}
+
+// LoadCmd reads the service's audit log in the background.
+func LoadCmd(svc *jj.Service) tea.Cmd {
+	return func() tea.Msg {
+		if svc == nil || svc.AuditLogPath() == "" {
+			return LoadedMsg{}
+		}
+		path := svc.AuditLogPath()
+		entries, err := jj.ReadAuditLog(path, maxEntries)
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return LoadedMsg{Path: path, Entries: entries, Err: err}
+	}
+}
+
+// Request is sent to the main model for Audit actions.
+type Request struct {
+	Refresh bool // re-read the log (main passes the jj service)
+}
+
+// Cmd returns a tea.Cmd that sends this request.
+func (r Request) Cmd() tea.Cmd {
+	return func() tea.Msg { return r }
+}
+
+// Model is the Audit sub-tab: the last loaded audit entries and the scroll offset.
+type Model struct {
+	loaded  LoadedMsg
+	ok      bool
+	yOffset int
+}
+
+// NewModel creates a new Audit sub-tab model.
+func NewModel() Model {
+	return Model{}
+}
+
+// Update handles log loads, keys (r refresh, j/k scroll) and the mouse wheel.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case LoadedMsg:
+		m.loaded, m.ok = msg, true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return m, Request{Refresh: true}.Cmd()
+		case "j", "down":
+			m.yOffset++
+		case "k", "up":
+			m.yOffset = max(m.yOffset-1, 0)
+		}
+	case tea.MouseMsg:
+		if tea.MouseEvent(msg).IsWheel() {
+			if msg.Button == tea.MouseButtonWheelUp || msg.Button == tea.MouseButtonWheelLeft {
+				m.yOffset = max(m.yOffset-3, 0)
+			} else {
+				m.yOffset += 3
+			}
+		}
+	}
+	return m, nil
+}
+
+// YOffset returns the current scroll offset.
+func (m Model) YOffset() int { return m.yOffset }
+
+// Lines returns the audit log, newest first (parent applies scroll).
+func (m Model) Lines() []string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := []string{
+		styles.TitleStyle.Render("Audit Log"),
+		"",
+		muted.Render("  Every jj command that changed this repository from jj-tui, by anyone using it · r refresh"),
+	}
+	switch {
+	case !m.ok:
+		return append(lines, "", muted.Italic(true).Render("  Loading..."))
+	case m.loaded.Path == "":
+		return append(lines, "", muted.Italic(true).Render("  The audit log is off (audit_log in the config), or the repository has no .jj/repo directory."))
+	}
+	lines = append(lines, muted.Render("  "+m.loaded.Path), "")
+	if m.loaded.Err != nil {
+		return append(lines, lipgloss.NewStyle().Foreground(styles.ColorFailure).Render("  Could not read the audit log: "+m.loaded.Err.Error()))
+	}
+	if len(m.loaded.Entries) == 0 {
+		return append(lines, muted.Italic(true).Render("  Nothing recorded yet."))
+	}
+	ok := lipgloss.NewStyle().Foreground(styles.ColorSuccess).Render(styles.GlyphSuccess)
+	failed := lipgloss.NewStyle().Foreground(styles.ColorFailure).Render(styles.GlyphFailure)
+	for _, e := range m.loaded.Entries {
+		mark := ok
+		if !e.OK {
+			mark = failed
+		}
+		who := e.User
+		if e.Login != "" {
+			who = strings.TrimSpace(who + " (" + e.Login + ")")
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s  %s  %s", mark, muted.Render(e.Time.Local().Format("2006-01-02 15:04:05")), who, e.Command))
+		var details []string
+		if len(e.Revisions) > 0 {
+			details = append(details, "revisions "+strings.Join(e.Revisions, ", "))
+		}
+		if e.Op != "" {
+			details = append(details, "op "+shortOp(e.Op))
+		}
+		if e.Error != "" {
+			details = append(details, "error: "+e.Error)
+		}
+		if len(details) > 0 {
+			lines = append(lines, muted.Render("      "+strings.Join(details, " · ")))
+		}
+	}
+	return lines
+}
+
+// shortOp shortens operation IDs (comma-separated when the op heads had diverged) to 12 hex digits
+// each, enough for `jj op show`.
+func shortOp(op string) string {
+	ids := strings.Split(op, ",")
+	for i, id := range ids {
+		if len(id) > 12 {
+			ids[i] = id[:12]
+		}
+	}
+	return strings.Join(ids, ",")
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
@@ -14,6 +15,18 @@ import (
 	"github.com/mattn/go-runewidth"
 )
 
+// maxShown caps how many (filtered) entries are rendered and get click zones.
+const maxShown = 200
+
+// Status filters, cycled with f.
+const (
+	statusAll = iota
+	statusFailed
+	statusOK
+)
+
+var statusFilterNames = []string{"all", "failed", "ok"}
+
 // Entry is one entry for the command history list (display format from jj service).
 type Entry struct {
 	Command   string
@@ -48,13 +61,51 @@ type Model struct {
 	width       int
 	height      int
 	entries     []Entry
-	selectedIdx int
+	visible     []int // indices into entries that pass the filters
+	selectedIdx int   // index into visible
 	yOffset     int
+
+	filter       textinput.Model
+	filtering    bool // filter input has focus
+	statusFilter int  // statusAll, statusFailed, statusOK
 }
 
 // NewModel creates a new Command History sub-tab model.
 func NewModel(zoneManager *zone.Manager) Model {
-	return Model{zoneManager: zoneManager, selectedIdx: -1}
+	in := textinput.New()
+	in.Prompt = "/"
+	in.Placeholder = "filter commands and errors"
+	in.CharLimit = 200
+	return Model{zoneManager: zoneManager, selectedIdx: -1, filter: in}
+}
+
+// IsFiltering reports whether the filter input has focus (keys go to it, not global shortcuts).
+func (m Model) IsFiltering() bool { return m.filtering }
+
+// applyFilter recomputes visible from the text and status filters and keeps the selection in range.
+func (m *Model) applyFilter() {
+	q := strings.ToLower(strings.TrimSpace(m.filter.Value()))
+	m.visible = nil
+	for i, e := range m.entries {
+		switch {
+		case m.statusFilter == statusFailed && e.Success, m.statusFilter == statusOK && !e.Success:
+			continue
+		case q != "" && !strings.Contains(strings.ToLower(e.Command), q) && !strings.Contains(strings.ToLower(e.Error), q):
+			continue
+		}
+		m.visible = append(m.visible, i)
+	}
+	if m.selectedIdx >= len(m.visible) {
+		m.selectedIdx = len(m.visible) - 1
+	}
+}
+
+// selected returns the selected entry, if any.
+func (m Model) selected() (Entry, bool) {
+	if m.selectedIdx < 0 || m.selectedIdx >= len(m.visible) {
+		return Entry{}, false
+	}
+	return m.entries[m.visible[m.selectedIdx]], true
 }
 
 // Update handles messages for the Command History sub-tab (dimensions, keys, zone clicks, mouse wheel).
@@ -64,9 +115,36 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.width = msg.Width
 		return m, nil
 	case tea.KeyMsg:
+		if m.filtering {
+			switch msg.String() {
+			case "enter":
+				m.filtering = false
+				m.filter.Blur()
+				return m, nil
+			case "esc":
+				m.filtering = false
+				m.filter.Blur()
+				m.filter.SetValue("")
+				m.applyFilter()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.filter, cmd = m.filter.Update(msg)
+			m.applyFilter()
+			m.yOffset = 0
+			return m, cmd
+		}
 		switch msg.String() {
+		case "/":
+			m.filtering = true
+			return m, m.filter.Focus()
+		case "f":
+			m.statusFilter = (m.statusFilter + 1) % len(statusFilterNames)
+			m.applyFilter()
+			m.yOffset = 0
+			return m, nil
 		case "j", "down":
-			maxCmd := len(m.entries) - 1
+			maxCmd := min(len(m.visible), maxShown) - 1
 			if maxCmd >= 0 && m.selectedIdx < maxCmd {
 				m.selectedIdx++
 				m.ensureVisible()
@@ -79,8 +157,8 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 			return m, nil
 		case "y":
-			if len(m.entries) > 0 && m.selectedIdx >= 0 && m.selectedIdx < len(m.entries) {
-				return m, Request{CopyCommand: m.entries[m.selectedIdx].Command}.Cmd()
+			if e, ok := m.selected(); ok {
+				return m, Request{CopyCommand: e.Command}.Cmd()
 			}
 			return m, nil
 		}
@@ -115,7 +193,7 @@ func (m Model) resolveZone(msg zone.MsgZoneInBounds) string {
 	if msg.Zone == nil {
 		return ""
 	}
-	n := min(len(m.entries), 50)
+	n := min(len(m.visible), maxShown)
 	for i := range n {
 		id := fmt.Sprintf("%s%d", mouse.ZoneHelpCommandCopy, i)
 		z := m.zoneManager.Get(id)
@@ -130,8 +208,8 @@ func (m Model) handleZoneClick(zoneID string) (Model, tea.Cmd) {
 	if after, ok := strings.CutPrefix(zoneID, mouse.ZoneHelpCommandCopy); ok {
 		s := after
 		i, err := strconv.Atoi(s)
-		if err == nil && i >= 0 && i < len(m.entries) {
-			return m, Request{CopyCommand: m.entries[i].Command}.Cmd()
+		if err == nil && i >= 0 && i < len(m.visible) {
+			return m, Request{CopyCommand: m.entries[m.visible[i]].Command}.Cmd()
 		}
 	}
 	return m, nil
@@ -141,16 +219,13 @@ func (m *Model) ensureVisible() {
 	if m.selectedIdx < 0 || m.height <= 0 {
 		return
 	}
-	const headerHeight = 5
+	const headerHeight = 7
 	visualIdx := headerHeight + m.selectedIdx
 
 	// Check if the selected item has an error displayed (adds 1 extra line)
 	itemHeight := 1
-	if m.selectedIdx < len(m.entries) {
-		entry := m.entries[m.selectedIdx]
-		if !entry.Success && entry.Error != "" {
-			itemHeight = 2
-		}
+	if entry, ok := m.selected(); ok && !entry.Success && entry.Error != "" {
+		itemHeight = 2
 	}
 
 	// Scroll up if top of item is above viewport
@@ -197,11 +272,10 @@ func (m *Model) SetDimensions(width, height int) {
 }
 
 // SetEntries sets the command history entries (called by parent when entering Help or refreshing).
+// Active filters are re-applied.
 func (m *Model) SetEntries(entries []Entry) {
 	m.entries = entries
-	if m.selectedIdx >= len(entries) {
-		m.selectedIdx = max(-1, len(entries)-1)
-	}
+	m.applyFilter()
 }
 
 // GetSelectedCommand returns the selected command index.
@@ -211,7 +285,7 @@ func (m Model) GetSelectedCommand() int {
 
 // SetSelectedCommand sets the selected command index.
 func (m *Model) SetSelectedCommand(idx int) {
-	if idx >= -1 && idx < len(m.entries) {
+	if idx >= -1 && idx < len(m.visible) {
 		m.selectedIdx = idx
 	}
 }
@@ -219,7 +293,7 @@ func (m *Model) SetSelectedCommand(idx int) {
 // ZoneIDs returns zone IDs used by this sub-tab (for parent to resolve clicks).
 func (m Model) ZoneIDs() []string {
 	var ids []string
-	n := min(len(m.entries), 50)
+	n := min(len(m.visible), maxShown)
 	for i := range n {
 		ids = append(ids, fmt.Sprintf("%s%d", mouse.ZoneHelpCommandCopy, i))
 	}
@@ -236,33 +310,44 @@ func (m Model) lines() []string {
 	var lines []string
 	lines = append(lines, styles.TitleStyle.Render("Command History"))
 	lines = append(lines, "")
-	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("  Commands executed by jj-tui (excluding auto-refresh)"))
-	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("  Click [copy] or press y to copy command to clipboard"))
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines = append(lines, muted.Render("  Commands executed by jj-tui in this repo (excluding auto-refresh), kept across sessions"))
+	lines = append(lines, muted.Render("  Click [copy] or press y to copy · / filter · f status"))
+	lines = append(lines, "")
+	filterLine := "  " + muted.Render("Filter: ") + m.filter.View()
+	if !m.filtering && m.filter.Value() == "" {
+		filterLine = "  " + muted.Render("Filter: none (press /)")
+	}
+	lines = append(lines, filterLine+muted.Render(fmt.Sprintf("   Status: %s   %d of %d", statusFilterNames[m.statusFilter], len(m.visible), len(m.entries))))
 	lines = append(lines, "")
 
 	if len(m.entries) == 0 {
-		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Italic(true).Render("  No commands executed yet"))
+		lines = append(lines, muted.Italic(true).Render("  No commands executed yet"))
+		return lines
+	}
+	if len(m.visible) == 0 {
+		lines = append(lines, muted.Italic(true).Render("  No commands match the filter"))
 		return lines
 	}
 
-	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B"))
-	failStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555"))
-	timeStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted).Width(8)
+	successStyle := lipgloss.NewStyle().Foreground(styles.ColorPositive)
+	failStyle := lipgloss.NewStyle().Foreground(styles.ColorNegative)
+	timeStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted).Width(12)
 	durationStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted).Width(5)
-	cmdStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#8BE9FD"))
-	copyBtnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C")).Bold(true)
+	cmdStyle := lipgloss.NewStyle().Foreground(styles.ColorAccent)
+	copyBtnStyle := lipgloss.NewStyle().Foreground(styles.ColorWarning).Bold(true)
 
-	maxCommands := min(len(m.entries), 50)
+	maxCommands := min(len(m.visible), maxShown)
 
 	for i := range maxCommands {
-		entry := m.entries[i]
+		entry := m.entries[m.visible[i]]
 		var statusIcon string
 		var entryStyle lipgloss.Style
 		if entry.Success {
-			statusIcon = successStyle.Render("✓")
+			statusIcon = successStyle.Render(styles.GlyphSuccess)
 			entryStyle = lipgloss.NewStyle()
 		} else {
-			statusIcon = failStyle.Render("✗")
+			statusIcon = failStyle.Render(styles.GlyphFailure)
 			entryStyle = failStyle
 		}
 		prefix := "  "
@@ -275,7 +360,7 @@ func (m Model) lines() []string {
 		// Clean up command text (remove tabs, newlines, excess spaces)
 		cmdText := strings.Join(strings.Fields(entry.Command), " ")
 		// Truncate to fit: width - fixed parts (approx 27 chars) - safety margin
-		availableWidth := max(10, m.width-32)
+		availableWidth := max(10, m.width-36)
 		cmdText = runewidth.Truncate(cmdText, availableWidth, "...")
 
 		line := fmt.Sprintf("%s%s %s %s %s %s",
@@ -297,10 +382,10 @@ func (m Model) lines() []string {
 		}
 	}
 
-	if len(m.entries) > maxCommands {
+	if len(m.visible) > maxCommands {
 		lines = append(lines, "")
 		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(
-			fmt.Sprintf("  ... and %d more commands", len(m.entries)-maxCommands)))
+			fmt.Sprintf("  ... and %d more commands (filter to narrow)", len(m.visible)-maxCommands)))
 	}
 	return lines
 }
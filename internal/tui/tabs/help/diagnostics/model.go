@@ -0,0 +1,115 @@
+package diagnostics
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// LoadedMsg carries a freshly collected report.
+type LoadedMsg struct {
+	Report Report
+}
+
+// LoadCmd collects the report in the background.
+func LoadCmd(in Input) tea.Cmd {
+	return func() tea.Msg {
+		return LoadedMsg{Report: Collect(context.Background(), in)}
+	}
+}
+
+// Request is sent to the main model for Diagnostics actions.
+type Request struct {
+	Refresh bool   // re-collect (main builds the Input from app state)
+	Copy    string // report text to copy to the clipboard
+}
+
+// Cmd returns a tea.Cmd that sends this request.
+func (r Request) Cmd() tea.Cmd {
+	return func() tea.Msg { return r }
+}
+
+// ExecuteRequest handles a copy request. Returns (statusMsg, cmd); refreshes are run by main.
+func ExecuteRequest(r Request) (statusMsg string, cmd tea.Cmd) {
+	if r.Copy == "" {
+		return "", nil
+	}
+	return "Copied diagnostics to clipboard", util.CopyToClipboard(r.Copy)
+}
+
+// Model is the Diagnostics sub-tab: the last collected report and its scroll offset.
+type Model struct {
+	report  Report
+	loaded  bool
+	yOffset int
+}
+
+// NewModel creates a new Diagnostics sub-tab model.
+func NewModel() Model {
+	return Model{}
+}
+
+// Update handles report loads, keys (r refresh, y copy) and the mouse wheel.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case LoadedMsg:
+		m.report, m.loaded = msg.Report, true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return m, Request{Refresh: true}.Cmd()
+		case "y":
+			if m.loaded {
+				return m, Request{Copy: m.report.String()}.Cmd()
+			}
+		case "j", "down":
+			m.yOffset++
+		case "k", "up":
+			m.yOffset = max(m.yOffset-1, 0)
+		}
+	case tea.MouseMsg:
+		if tea.MouseEvent(msg).IsWheel() {
+			if msg.Button == tea.MouseButtonWheelUp || msg.Button == tea.MouseButtonWheelLeft {
+				m.yOffset = max(m.yOffset-3, 0)
+			} else {
+				m.yOffset += 3
+			}
+		}
+	}
+	return m, nil
+}
+
+// Report returns the last collected report (nil before the first load).
+func (m Model) Report() Report { return m.report }
+
+// YOffset returns the current scroll offset.
+func (m Model) YOffset() int { return m.yOffset }
+
+// Lines returns the full diagnostics content (parent applies scroll).
+func (m Model) Lines() []string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := []string{
+		styles.TitleStyle.Render("Diagnostics"),
+		"",
+		muted.Render("  The environment jj-tui is running in · y copy (include it in bug reports) · r refresh"),
+		"",
+	}
+	if !m.loaded {
+		return append(lines, muted.Italic(true).Render("  Collecting..."))
+	}
+	section := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary)
+	label := lipgloss.NewStyle().Foreground(styles.ColorMuted).Width(16)
+	for i, s := range m.report {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "  "+section.Render(s.Title))
+		for _, it := range s.Items {
+			lines = append(lines, "    "+label.Render(it.Label)+it.Value)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,216 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/version"
+)
+
+// toolTimeout bounds each `<tool> --version` call so a hung binary can't stall the report.
+const toolTimeout = 3 * time.Second
+
+// Input is what the main model knows about the running session.
+type Input struct {
+	RepoPath   string
+	Config     *config.Config
+	GitHubInfo string // AppState.GithubInfo; the token preview in it is redacted
+	// Connected services. TicketProvider is the ticket service's name, empty without one.
+	GitHub         bool
+	GitLab         bool
+	TicketProvider string
+	DemoMode       bool
+	SafeMode       bool
+}
+
+// Item is one "label: value" row of the report.
+type Item struct {
+	Label string
+	Value string
+}
+
+// Section is a titled group of items.
+type Section struct {
+	Title string
+	Items []Item
+}
+
+// Report is the collected environment, in display order.
+type Report []Section
+
+// String renders the report as plain text (for pasting into bug reports).
+func (r Report) String() string {
+	var b strings.Builder
+	for i, s := range r {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n", s.Title)
+		for _, it := range s.Items {
+			fmt.Fprintf(&b, "%s: %s\n", it.Label, it.Value)
+		}
+	}
+	return b.String()
+}
+
+// Collect builds the report. It runs `jj --version` and `git --version`, so call it off the UI
+// goroutine (LoadCmd does).
+func Collect(ctx context.Context, in Input) Report {
+	return Report{
+		{Title: "jj-tui", Items: []Item{
+			{"Version", version.GetVersion()},
+			{"Go", runtime.Version()},
+			{"OS/arch", runtime.GOOS + "/" + runtime.GOARCH},
+			{"Repository", orNone(in.RepoPath)},
+			{"Mode", mode(in)},
+		}},
+		{Title: "Tools", Items: []Item{
+			tool(ctx, "jj"),
+			tool(ctx, "git"),
+		}},
+		{Title: "Terminal", Items: []Item{
+			{"TERM", orNone(os.Getenv("TERM"))},
+			{"COLORTERM", orNone(os.Getenv("COLORTERM"))},
+			{"TERM_PROGRAM", orNone(os.Getenv("TERM_PROGRAM"))},
+			{"Color profile", lipgloss.ColorProfile().Name()},
+		}},
+		{Title: "Config", Items: configItems(in.Config)},
+		{Title: "Services", Items: serviceItems(in)},
+	}
+}
+
+// tool reports where name resolves on PATH and its first line of `--version` output.
+func tool(ctx context.Context, name string) Item {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return Item{name, "not found on PATH"}
+	}
+	ctx, cancel := context.WithTimeout(ctx, toolTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return Item{name, fmt.Sprintf("%s (--version failed: %v)", path, err)}
+	}
+	ver, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return Item{name, fmt.Sprintf("%s (%s)", ver, path)}
+}
+
+func mode(in Input) string {
+	switch {
+	case in.DemoMode:
+		return "demo (mock services)"
+	case in.SafeMode:
+		return "safe (no services, no auto-refresh)"
+	}
+	return "normal"
+}
+
+// configItems lists the config files Load considers, which exist, and which one settings are
+// saved to.
+func configItems(cfg *config.Config) []Item {
+	var items []Item
+	for _, f := range config.Files() {
+		state := "not present"
+		if f.Exists {
+			state = "loaded"
+		}
+		items = append(items, Item{f.Role, fmt.Sprintf("%s (%s)", f.Path, state)})
+	}
+	if cfg != nil && cfg.LoadedFrom() != "" {
+		items = append(items, Item{"Saving to", cfg.LoadedFrom()})
+	}
+	return items
+}
+
+func serviceItems(in Input) []Item {
+	cfg := in.Config
+	var c config.Config
+	if cfg != nil {
+		c = *cfg
+	}
+	github := "not connected"
+	if in.GitHub {
+		github = "connected"
+	}
+	items := []Item{
+		{"GitHub", fmt.Sprintf("%s, token from %s", github, gitHubTokenSource(cfg))},
+	}
+	if in.GitLab || c.GitLabToken != "" || os.Getenv("GITLAB_TOKEN") != "" {
+		gitlab := "not connected"
+		if in.GitLab {
+			gitlab = "connected"
+		}
+		items = append(items, Item{"GitLab", fmt.Sprintf("%s, token from %s", gitlab, tokenSource("GITLAB_TOKEN", c.GitLabToken))})
+	}
+	if in.GitHubInfo != "" {
+		items = append(items, Item{"Remote", RedactTokens(in.GitHubInfo)})
+	}
+
+	tickets := "none"
+	if in.TicketProvider != "" {
+		tickets = in.TicketProvider + " connected"
+	}
+	switch c.GetTicketProvider() {
+	case "jira":
+		tickets += ", token from " + tokenSource("JIRA_TOKEN", c.JiraToken)
+	case "codecks":
+		tickets += ", token from " + tokenSource("CODECKS_TOKEN", c.CodecksToken)
+	}
+	items = append(items, Item{"Tickets", tickets})
+
+	ai := "disabled"
+	if cfg.AIGenerationEnabled() {
+		ai = fmt.Sprintf("%s (%s), key from %s", cfg.AIProviderOrDefault(), cfg.AIModelResolved(), tokenSource(config.EnvAIAPIKey, c.AIAPIKey))
+	}
+	return append(items, Item{"AI", ai})
+}
+
+// gitHubTokenSource describes github_token_source without running `gh auth token`.
+func gitHubTokenSource(cfg *config.Config) string {
+	switch cfg.GitHubTokenSourceOrDefault() {
+	case config.GitHubTokenSourceEnv:
+		return tokenSource("GITHUB_TOKEN", "")
+	case config.GitHubTokenSourceGhCLI:
+		return "gh auth token"
+	}
+	if cfg == nil || cfg.GitHubToken == "" {
+		return "config (not set)"
+	}
+	return "config"
+}
+
+// tokenSource says where a secret comes from: the config file or the environment variable env.
+// main copies config values into unset variables at startup, so a variable holding the configured
+// value came from the config.
+func tokenSource(env, configured string) string {
+	fromEnv := os.Getenv(env)
+	switch {
+	case configured != "" && (fromEnv == "" || fromEnv == configured):
+		return "config"
+	case fromEnv != "":
+		return "$" + env
+	}
+	return "nowhere (not set)"
+}
+
+var tokenPreviewPattern = regexp.MustCompile(`token=[^\s(]+`)
+
+// RedactTokens hides the token previews GithubInfo carries ("token=ghp_abcd...(env:GITHUB_TOKEN)").
+func RedactTokens(s string) string {
+	return tokenPreviewPattern.ReplaceAllString(s, "token=<redacted>")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
@@ -8,7 +8,10 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/tabs/help/audit"
 	"github.com/madicen/jj-tui/internal/tui/tabs/help/commandhistory"
+	"github.com/madicen/jj-tui/internal/tui/tabs/help/diagnostics"
 	"github.com/madicen/jj-tui/internal/tui/tabs/help/shortcuts"
 )
 
@@ -19,15 +22,27 @@ type CommandInfo struct {
 	Usage string
 }
 
-// Model represents the state of the Help tab. It routes to Shortcuts or Command History sub-tab.
+// Help sub-tabs, in tab-bar order.
+const (
+	TabShortcuts = iota
+	TabCommands
+	TabAudit
+	TabDiagnostics
+	numTabs
+)
+
+// Model represents the state of the Help tab. It routes to the Shortcuts, Command History, Audit
+// or Diagnostics sub-tab.
 type Model struct {
 	zoneManager *zone.Manager
-	activeTab   int // 0=Shortcuts, 1=Commands
+	activeTab   int // TabShortcuts, TabCommands, TabAudit or TabDiagnostics
 	width       int
 	height      int
 
-	shortcuts shortcuts.Model
-	commands  commandhistory.Model
+	shortcuts   shortcuts.Model
+	commands    commandhistory.Model
+	audit       audit.Model
+	diagnostics diagnostics.Model
 }
 
 // NewModel creates a new Help tab model. zoneManager may be nil.
@@ -37,6 +52,8 @@ func NewModel(zoneManager *zone.Manager) Model {
 		activeTab:   0,
 		shortcuts:   shortcuts.NewModel(zoneManager),
 		commands:    commandhistory.NewModel(zoneManager),
+		audit:       audit.NewModel(),
+		diagnostics: diagnostics.NewModel(),
 	}
 }
 
@@ -45,9 +62,17 @@ func (m Model) Init() tea.Cmd {
 	return nil
 }
 
-// Update routes messages to the active sub-tab (Shortcuts or Command History).
+// Update routes messages to the active sub-tab.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case diagnostics.LoadedMsg:
+		m.diagnostics, _ = m.diagnostics.Update(msg)
+		return m, nil
+
+	case audit.LoadedMsg:
+		m.audit, _ = m.audit.Update(msg)
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -56,26 +81,36 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.activeTab == TabCommands && m.commands.IsFiltering() {
+			updated, cmd := m.commands.Update(msg)
+			m.commands = updated
+			return m, cmd
+		}
 		switch msg.String() {
 		case "ctrl+j":
-			// Previous sub-tab (wrap: 0 -> 1)
-			m.activeTab = (m.activeTab - 1 + 2) % 2
+			// Previous sub-tab (wrap: first -> last)
+			m.activeTab = (m.activeTab - 1 + numTabs) % numTabs
 			m.commands.SetSelectedCommand(0)
-			return m, nil
-		case "ctrl+k":
+			return m, m.loadAuditIfActive()
+		case "ctrl+k", "tab":
 			// Next sub-tab
-			m.activeTab = (m.activeTab + 1) % 2
+			m.activeTab = (m.activeTab + 1) % numTabs
 			m.commands.SetSelectedCommand(0)
-			return m, nil
-		case "tab":
-			m.activeTab = (m.activeTab + 1) % 2
-			m.commands.SetSelectedCommand(0)
-			return m, nil
+			return m, m.loadAuditIfActive()
 		}
-		if m.activeTab == 0 {
+		switch m.activeTab {
+		case TabShortcuts:
 			updated, cmd := m.shortcuts.Update(msg)
 			m.shortcuts = updated
 			return m, cmd
+		case TabAudit:
+			updated, cmd := m.audit.Update(msg)
+			m.audit = updated
+			return m, cmd
+		case TabDiagnostics:
+			updated, cmd := m.diagnostics.Update(msg)
+			m.diagnostics = updated
+			return m, cmd
 		}
 		updated, cmd := m.commands.Update(msg)
 		m.commands = updated
@@ -86,17 +121,25 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			zoneID := m.resolveClickedZone(msg)
 			if zoneID != "" {
 				if zoneID == mouse.ZoneHelpTabShortcuts {
-					m.activeTab = 0
+					m.activeTab = TabShortcuts
 					m.commands.SetSelectedCommand(0)
 					return m, nil
 				}
 				if zoneID == mouse.ZoneHelpTabCommands {
-					m.activeTab = 1
+					m.activeTab = TabCommands
 					m.commands.SetSelectedCommand(0)
 					return m, nil
 				}
+				if zoneID == mouse.ZoneHelpTabAudit {
+					m.activeTab = TabAudit
+					return m, m.loadAuditIfActive()
+				}
+				if zoneID == mouse.ZoneHelpTabDiagnostics {
+					m.activeTab = TabDiagnostics
+					return m, nil
+				}
 				// Forward to commands (copy buttons)
-				if m.activeTab == 1 {
+				if m.activeTab == TabCommands {
 					updated, cmd := m.commands.Update(msg)
 					m.commands = updated
 					return m, cmd
@@ -107,10 +150,19 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	case tea.MouseMsg:
 		if tea.MouseEvent(msg).IsWheel() {
-			if m.activeTab == 0 {
+			switch m.activeTab {
+			case TabShortcuts:
 				updated, cmd := m.shortcuts.Update(msg)
 				m.shortcuts = updated
 				return m, cmd
+			case TabAudit:
+				updated, cmd := m.audit.Update(msg)
+				m.audit = updated
+				return m, cmd
+			case TabDiagnostics:
+				updated, cmd := m.diagnostics.Update(msg)
+				m.diagnostics = updated
+				return m, cmd
 			}
 			updated, cmd := m.commands.Update(msg)
 			m.commands = updated
@@ -124,15 +176,22 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 // View renders the Help tab: tab bar + active sub-tab content with scroll.
 func (m Model) View() string {
 	tabBar := m.renderTabBar()
-	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Italic(true).Render("^j/^k: switch tabs")
+	hint := lipgloss.NewStyle().Foreground(styles.ColorSubtle).Italic(true).Render("^j/^k: switch tabs")
 	visibleHeight := max(1, m.height-3)
 
 	var lines []string
 	var start int
-	if m.activeTab == 0 {
+	switch m.activeTab {
+	case TabShortcuts:
 		lines = m.shortcuts.Lines()
 		start = m.shortcuts.YOffset()
-	} else {
+	case TabAudit:
+		lines = m.audit.Lines()
+		start = m.audit.YOffset()
+	case TabDiagnostics:
+		lines = m.diagnostics.Lines()
+		start = m.diagnostics.YOffset()
+	default:
 		lines = m.commands.Lines()
 		start = m.commands.YOffset()
 	}
@@ -153,7 +212,7 @@ func (m Model) View() string {
 
 // ZoneIDs returns the zone IDs this tab uses when rendering (same IDs passed to Mark). Used to resolve clicks.
 func (m Model) ZoneIDs() []string {
-	ids := []string{mouse.ZoneHelpTabShortcuts, mouse.ZoneHelpTabCommands}
+	ids := []string{mouse.ZoneHelpTabShortcuts, mouse.ZoneHelpTabCommands, mouse.ZoneHelpTabAudit, mouse.ZoneHelpTabDiagnostics}
 	ids = append(ids, m.commands.ZoneIDs()...)
 	return ids
 }
@@ -171,6 +230,15 @@ func (m Model) resolveClickedZone(msg zone.MsgZoneInBounds) string {
 	return ""
 }
 
+// loadAuditIfActive asks main to reread the audit log when the Audit sub-tab was just opened, so
+// it shows what other users of the checkout did since.
+func (m Model) loadAuditIfActive() tea.Cmd {
+	if m.activeTab != TabAudit {
+		return nil
+	}
+	return audit.Request{Refresh: true}.Cmd()
+}
+
 // Accessors
 
 // GetHelpTab returns the currently selected help tab
@@ -180,7 +248,7 @@ func (m *Model) GetHelpTab() int {
 
 // SetHelpTab sets the help tab
 func (m *Model) SetHelpTab(tab int) {
-	m.activeTab = tab % 2
+	m.activeTab = tab % numTabs
 }
 
 // SetDimensions sets the content area size (used for scroll window height).
@@ -201,6 +269,17 @@ func (m *Model) SetSelectedCommand(idx int) {
 	m.commands.SetSelectedCommand(idx)
 }
 
+// IsFiltering reports whether the History filter input has focus, so the main model keeps global
+// shortcuts (g, p, q, Esc, ...) out of it.
+func (m Model) IsFiltering() bool {
+	return m.activeTab == TabCommands && m.commands.IsFiltering()
+}
+
+// DiagnosticsReport returns the last collected diagnostics report (nil before the first load).
+func (m Model) DiagnosticsReport() diagnostics.Report {
+	return m.diagnostics.Report()
+}
+
 // SetCommandHistoryEntries sets the command history for the Commands sub-tab (called by main model)
 func (m *Model) SetCommandHistoryEntries(entries []commandhistory.Entry) {
 	m.commands.SetEntries(entries)
@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal/keymap"
 	"github.com/madicen/jj-tui/internal/tui/styles"
 )
 
@@ -84,15 +85,25 @@ func (m Model) lines() []string {
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("j/↓"), styles.HelpDescStyle.Render("Move down")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("k/↑"), styles.HelpDescStyle.Render("Move up")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Tab"), styles.HelpDescStyle.Render("Switch focus: graph ↔ files")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("o"), styles.HelpDescStyle.Render("View full jj diff for selected changed file (files pane)")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("O"), styles.HelpDescStyle.Render("Open selected file in external editor (files pane; set editor in Settings → Advanced)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("o / Enter", "file.diff")), styles.HelpDescStyle.Render("View full jj diff for selected changed file (files pane)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("l / r / b"), styles.HelpDescStyle.Render("In a conflicted file's view: take left / right / both sides (working copy only)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("m"), styles.HelpDescStyle.Render("In a conflicted file's view: open it in the merge tool (Settings → Advanced)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("O", "file.open_editor")), styles.HelpDescStyle.Render("Open selected file in external editor (files pane; set editor in Settings → Advanced)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("L", "file.history")), styles.HelpDescStyle.Render("History of the selected file (files pane); Tab annotates it, Enter selects a commit in the graph")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("T", "commit.browse_files")), styles.HelpDescStyle.Render("Browse every file in the selected commit (v view at that revision, O edit working copy)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("V", "commit.select_lines")), styles.HelpDescStyle.Render("Select graph lines to copy (opens the pager); V in the file diff selects diff lines")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("f", "file.status_filter")), styles.HelpDescStyle.Render("Files pane: cycle added / modified / deleted only")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("/", "file.filter")), styles.HelpDescStyle.Render("Files pane: filter by path glob (e.g. internal/tui *.go !*_test.go); Esc clears filters")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("i", "commit.absorb", "file.absorb")), styles.HelpDescStyle.Render("Working copy: preview and run jj absorb (each hunk into the ancestor that last changed it); files pane: just that file")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("H", "commit.hunk_split")), styles.HelpDescStyle.Render("Split hunks: Space picks hunks, p / c move them to a new parent / child commit")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Enter/e"), styles.HelpDescStyle.Render("Edit selected commit (jj edit)")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("s"), styles.HelpDescStyle.Render("Squash commit into parent")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("r"), styles.HelpDescStyle.Render("Rebase commit (with descendants)")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("M"), styles.HelpDescStyle.Render("Merge from: pick a source to merge into the selected commit (e.g. merge main into current bookmark)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("s / I / N / P", "commit.squash", "commit.insert_before", "commit.insert_after", "commit.parallelize")), styles.HelpDescStyle.Render("Squash into parent / insert empty commit before / after / parallelize marked commits")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("r / y / R / W", "commit.rebase", "commit.duplicate", "commit.backout", "commit.move_work")), styles.HelpDescStyle.Render("Rebase (m: -s/-r/-b, A: insert after, Space: add parent) / duplicate onto a destination / back out / move my work here")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("M", "commit.merge")), styles.HelpDescStyle.Render("Merge from: pick a source to merge into the selected commit; with 2+ marked, merge them all (jj new)")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("mouse"), styles.HelpDescStyle.Render("Drag a commit row onto another to rebase (same as r, then pick destination)")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("dbl-click"), styles.HelpDescStyle.Render("Commit row: edit (jj edit); changed-file row: open in external editor")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("d"), styles.HelpDescStyle.Render("Edit description; or resolve divergent when commit is divergent")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("dbl-click"), styles.HelpDescStyle.Render("Commit row: edit (jj edit); changed-file row: open in external editor (mouse_double_click)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("middle-click"), styles.HelpDescStyle.Render("Commit row: copy change ID; changed-file row: copy path (mouse_middle_click)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("d", "commit.describe")), styles.HelpDescStyle.Render("Edit description; or resolve divergent when commit is divergent")))
 	lines = append(lines, "")
 	lines = append(lines, styles.TitleStyle.Render("Commit description editor"))
 	lines = append(lines, "")
@@ -100,17 +111,25 @@ func (m Model) lines() []string {
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Esc"), styles.HelpDescStyle.Render("Cancel")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("ctrl+shift+u"), styles.HelpDescStyle.Render("Clear description text")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("✧^g"), styles.HelpDescStyle.Render("Same as the purple ✧ ^g chip beside the title (optional AI; Settings → AI + API key)")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("a"), styles.HelpDescStyle.Render("Abandon commit")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("n"), styles.HelpDescStyle.Render("Create new commit from selected")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("m"), styles.HelpDescStyle.Render("Create/move bookmark on commit")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("x"), styles.HelpDescStyle.Render("Delete bookmark from commit")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("c"), styles.HelpDescStyle.Render("Create new PR from commit chain")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("u"), styles.HelpDescStyle.Render("Update existing PR with new commits")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("f"), styles.HelpDescStyle.Render("Forgot new commit? Stack on bookmark@origin (avoid force-push)")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("z"), styles.HelpDescStyle.Render("split (experimental, when shown): jj evolog parent + step file list; o patch; p plan overlay (Enter runs split from overlay); s / ✧^g AI suggest; Graph (g) vs preview after split; FAQ bases on evolog row you pick, not main unless you choose that row; if AI says no split, Enter twice (or j/k); d optional AI describe; moves change (and feature bookmark if present)")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("C"), styles.HelpDescStyle.Render("Resolve diverged bookmark (when shown): graph pane focused; same flow as Branches (c)")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^z"), styles.HelpDescStyle.Render("Undo last jj operation")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^y"), styles.HelpDescStyle.Render("Redo jj operation")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("a / U", "commit.abandon", "commit.trash")), styles.HelpDescStyle.Render("Abandon commit / trash: restore a commit abandoned this session")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("n", "commit.new")), styles.HelpDescStyle.Render("Create new commit from selected")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("m", "commit.bookmark")), styles.HelpDescStyle.Render("Create/move bookmark on commit")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("x", "commit.delete_bookmark")), styles.HelpDescStyle.Render("Delete bookmark from commit")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("c", "commit.create_pr")), styles.HelpDescStyle.Render("Create new PR from commit chain")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("u", "commit.update_pr")), styles.HelpDescStyle.Render("Update existing PR with new commits")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("f", "commit.stack_on_origin")), styles.HelpDescStyle.Render("Forgot new commit? Stack on bookmark@origin (avoid force-push)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("z", "commit.evolog_split")), styles.HelpDescStyle.Render("split (experimental, when shown): jj evolog parent + step file list; o patch; p plan overlay (Enter runs split from overlay); s / ✧^g AI suggest; Graph (g) vs preview after split; FAQ bases on evolog row you pick, not main unless you choose that row; if AI says no split, Enter twice (or j/k); d optional AI describe; moves change (and feature bookmark if present)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("C", "commit.resolve_bookmark")), styles.HelpDescStyle.Render("Resolve diverged bookmark (when shown): graph pane focused; same flow as Branches (c)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("D", "commit.date_filter")), styles.HelpDescStyle.Render("Date filter: only show commits from today, week, Nd, or a YYYY-MM-DD..YYYY-MM-DD range (empty clears)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("A", "commit.author_mode")), styles.HelpDescStyle.Render("Author mode: all commits → dim other authors → only mine")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("E", "commit.wrap_subjects")), styles.HelpDescStyle.Render("Wrap long subjects onto a second line instead of cutting them off")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("Space", "commit.mark")), styles.HelpDescStyle.Render("Mark/unmark commit for bulk actions (Esc clears marks)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("S", "commit.stack_files")), styles.HelpDescStyle.Render("Stack files: files changed in trunk()..bookmark grouped by commit; shared files flagged")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("/", "commit.search")), styles.HelpDescStyle.Render("Graph pane: search by revset or description/author text; matches are highlighted (Esc clears)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help(":", "commit.aliases")), styles.HelpDescStyle.Render("jj aliases: filter the graph by a revset alias or run a command alias")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("B", "commit.bulk_describe")), styles.HelpDescStyle.Render("Bulk describe: add a prefix or suffix (Tab) to the marked commits' subjects, with preview")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("^z", "app.undo")), styles.HelpDescStyle.Render("Undo last jj operation")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("^y", "app.redo")), styles.HelpDescStyle.Render("Redo the last undone jj operation (repeatable after several undos)")))
 	lines = append(lines, "")
 	lines = append(lines, styles.TitleStyle.Render("Bookmark Screen"))
 	lines = append(lines, "")
@@ -132,36 +151,65 @@ func (m Model) lines() []string {
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^s"), styles.HelpDescStyle.Render("Create ticket")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("✧^g"), styles.HelpDescStyle.Render("Same as the ✧ ^g chip beside the title (uses graph revision or @)")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Esc"), styles.HelpDescStyle.Render("Cancel")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Tab"), styles.HelpDescStyle.Render("Switch title / description")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Tab"), styles.HelpDescStyle.Render("Next field: title, description, type, priority (Shift+Tab back)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("←/→"), styles.HelpDescStyle.Render("Change the focused type or priority")))
 	lines = append(lines, "")
 	lines = append(lines, styles.TitleStyle.Render("Pull Request Shortcuts"))
 	lines = append(lines, "")
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("j/↓"), styles.HelpDescStyle.Render("Move down")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("k/↑"), styles.HelpDescStyle.Render("Move up")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Enter/o"), styles.HelpDescStyle.Render("Open PR in browser")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("dbl-click"), styles.HelpDescStyle.Render("PR row: open in browser")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("Enter/o", "pr.open")), styles.HelpDescStyle.Render("Open PR in browser")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("D", "pr.deployments")), styles.HelpDescStyle.Render("Load deployment status for the PR head and base branches")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("v", "pr.read")), styles.HelpDescStyle.Render("Read the full PR body in the pager")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("R", "pr.comments")), styles.HelpDescStyle.Render("Review comments: Enter starts a quick fix (new commit on the PR branch, file opened at the line); r replies (Tab inserts a template)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("d", "pr.details")), styles.HelpDescStyle.Render("PR details: rendered description, every check, review threads, comments, and changed files")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("c / x"), styles.HelpDescStyle.Render("In PR details: next checklist item / tick or untick it (updates the PR body)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("f", "pr.diff")), styles.HelpDescStyle.Render("PR diff (head vs base) in the diff viewer; falls back to jj locally")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("r", "pr.review")), styles.HelpDescStyle.Render("Review the PR: comment, approve, or request changes (Tab kind, Ctrl+S submit)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("M", "pr.merge")), styles.HelpDescStyle.Render("Merge the PR: squash, rebase, or merge commit, commit message, optional auto-merge and pr_merge_follow_up steps (Ctrl+S)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("dbl-click"), styles.HelpDescStyle.Render("PR row: open in browser; middle-click copies the PR URL")))
+	lines = append(lines, "")
+	lines = append(lines, styles.TitleStyle.Render("Push Shortcuts (push_mode git or gerrit)"))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Enter/P"), styles.HelpDescStyle.Render("Push the selected change: jj git push --change, or refs/for/<gerrit_branch> in gerrit mode")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("r"), styles.HelpDescStyle.Render("Reload the outgoing changes")))
 	lines = append(lines, "")
 	lines = append(lines, styles.TitleStyle.Render("Tickets Shortcuts"))
 	lines = append(lines, "")
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("j/↓"), styles.HelpDescStyle.Render("Move down")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("k/↑"), styles.HelpDescStyle.Render("Move up")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Enter"), styles.HelpDescStyle.Render("Create branch from ticket")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("o"), styles.HelpDescStyle.Render("Open ticket in browser")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("dbl-click"), styles.HelpDescStyle.Render("Ticket row: open in browser (single click loads transitions)")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("c"), styles.HelpDescStyle.Render("Change ticket status")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("o", "ticket.open")), styles.HelpDescStyle.Render("Open ticket in browser")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("v", "ticket.read")), styles.HelpDescStyle.Render("Read the full description in the pager")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("d", "ticket.details")), styles.HelpDescStyle.Render("Ticket details: description, assignee, labels, linked PRs, comments (Esc back)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("dbl-click"), styles.HelpDescStyle.Render("Ticket row: open in browser (single click loads transitions); middle-click copies the key")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("c", "ticket.status")), styles.HelpDescStyle.Render("Change ticket status")))
 	lines = append(lines, "")
 	lines = append(lines, styles.TitleStyle.Render("Branches Shortcuts"))
 	lines = append(lines, "")
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("j/↓"), styles.HelpDescStyle.Render("Move down")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("k/↑"), styles.HelpDescStyle.Render("Move up")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("T"), styles.HelpDescStyle.Render("Track remote branch")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("t"), styles.HelpDescStyle.Render("Pull & track remote branch by name")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("U"), styles.HelpDescStyle.Render("Untrack remote branch")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("L"), styles.HelpDescStyle.Render("Restore deleted local branch")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("x"), styles.HelpDescStyle.Render("Delete local bookmark")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("P"), styles.HelpDescStyle.Render("Push local branch to remote")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("F"), styles.HelpDescStyle.Render("Fetch from all remotes")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("c"), styles.HelpDescStyle.Render("Resolve conflicted bookmark")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("T", "branch.track")), styles.HelpDescStyle.Render("Track remote branch")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("t", "branch.track_by_name")), styles.HelpDescStyle.Render("Pull & track remote branch by name")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("U", "branch.untrack")), styles.HelpDescStyle.Render("Untrack remote branch")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("L", "branch.restore")), styles.HelpDescStyle.Render("Restore deleted local branch")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("x", "branch.delete")), styles.HelpDescStyle.Render("Delete local bookmark")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("P", "branch.push")), styles.HelpDescStyle.Render("Push local branch to remote (previews the commits first)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("F", "branch.fetch")), styles.HelpDescStyle.Render("Fetch from all remotes")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("S", "branch.sync_fork")), styles.HelpDescStyle.Render("Sync fork trunk with upstream (offers to rebase your stack)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("c", "branch.resolve")), styles.HelpDescStyle.Render("Resolve conflicted bookmark")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("E", "branch.review_packet")), styles.HelpDescStyle.Render("Export a review packet (commits, diff, linked ticket) to markdown")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("G", "branch.review_gist")), styles.HelpDescStyle.Render("Export a review packet and share it as a secret gist (gh)")))
+	lines = append(lines, "")
+	lines = append(lines, styles.TitleStyle.Render("Workspaces Shortcuts"))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("j/↓"), styles.HelpDescStyle.Render("Move down")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("k/↑"), styles.HelpDescStyle.Render("Move up")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Enter"), styles.HelpDescStyle.Render("Switch jj-tui to the selected workspace")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("a", "workspace.add")), styles.HelpDescStyle.Render("Add a workspace at a path")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("x", "workspace.forget")), styles.HelpDescStyle.Render("Forget the selected workspace (its directory stays)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("r", "workspace.reload")), styles.HelpDescStyle.Render("Reload the workspace list")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("s", "workspace.sparse")), styles.HelpDescStyle.Render("Sparse patterns of this workspace: a add path, x remove, R reset to full, Esc back")))
 	lines = append(lines, "")
 	lines = append(lines, styles.TitleStyle.Render("Settings Shortcuts"))
 	lines = append(lines, "")
@@ -170,24 +218,36 @@ func (m Model) lines() []string {
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Tab"), styles.HelpDescStyle.Render("Next input field")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^s"), styles.HelpDescStyle.Render("Save settings (global)")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^l"), styles.HelpDescStyle.Render("Save settings (local to repo)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^o"), styles.HelpDescStyle.Render("Advanced: edit jj's immutable_heads() (presets; Enter writes repo config)")))
 	lines = append(lines, "")
 	lines = append(lines, styles.TitleStyle.Render("Help Tab"))
 	lines = append(lines, "")
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^j"), styles.HelpDescStyle.Render("Previous sub-tab (Shortcuts ↔ History)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^j"), styles.HelpDescStyle.Render("Previous sub-tab (Shortcuts, History, Diagnostics)")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^k"), styles.HelpDescStyle.Render("Next sub-tab")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Tab"), styles.HelpDescStyle.Render("Next sub-tab")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("/"), styles.HelpDescStyle.Render("Filter History (Enter keep, Esc clear); f cycles all/failed/ok")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("y / r"), styles.HelpDescStyle.Render("Diagnostics: copy the report (for bug reports) / collect again")))
 	lines = append(lines, "")
 	lines = append(lines, styles.TitleStyle.Render("Navigation"))
 	lines = append(lines, "")
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("g"), styles.HelpDescStyle.Render("Go to commit graph")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("p"), styles.HelpDescStyle.Render("Go to pull requests")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("t"), styles.HelpDescStyle.Render("Go to Tickets")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("b"), styles.HelpDescStyle.Render("Go to Branches")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(","), styles.HelpDescStyle.Render("Open settings")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("h/?"), styles.HelpDescStyle.Render("Show this help")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^r"), styles.HelpDescStyle.Render("Refresh")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("g", "tab.graph")), styles.HelpDescStyle.Render("Go to commit graph")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("p", "tab.prs")), styles.HelpDescStyle.Render("Go to pull requests")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("t", "tab.tickets")), styles.HelpDescStyle.Render("Go to Tickets")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("b", "tab.branches")), styles.HelpDescStyle.Render("Go to Branches")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("w", "tab.workspaces")), styles.HelpDescStyle.Render("Go to Workspaces")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help(",", "tab.settings")), styles.HelpDescStyle.Render("Open settings")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("h/?", "tab.help")), styles.HelpDescStyle.Render("Show this help")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("^r / ^l / ^e", "app.refresh", "app.messages", "app.preview_commands")), styles.HelpDescStyle.Render("Refresh / message log (or click the status text) / preview jj commands")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("^w", "app.update_stale")), styles.HelpDescStyle.Render("Update a stale working copy (jj workspace update-stale)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("^o", "app.switch_repo")), styles.HelpDescStyle.Render("Switch to another repository (recent repositories or browse)")))
 	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("Esc"), styles.HelpDescStyle.Render("Back to graph")))
-	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("^q"), styles.HelpDescStyle.Render("Quit")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render(keymap.Help("^q", "app.quit")), styles.HelpDescStyle.Render("Quit")))
+	lines = append(lines, "")
+	lines = append(lines, styles.TitleStyle.Render("Pager"))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("j/k"), styles.HelpDescStyle.Render("Scroll (space/b page, d/u half page, g/G top/bottom)")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("/"), styles.HelpDescStyle.Render("Search (n/N next / previous match); y copy; q close")))
+	lines = append(lines, fmt.Sprintf("  %s  %s", styles.HelpKeyStyle.Width(helpKeyColW).Render("v"), styles.HelpDescStyle.Render("Select lines with the keyboard (j/k extend, y copy them, Esc cancel)")))
 	lines = append(lines, "")
 	lines = append(lines, styles.TitleStyle.Render("Graph Symbols"))
 	lines = append(lines, "")
@@ -6,6 +6,13 @@ import (
 )
 
 var (
-	helpTabStyle      = lipgloss.NewStyle().Padding(0, 2).Foreground(lipgloss.Color("#888888"))
-	helpTabActiveStyle = lipgloss.NewStyle().Padding(0, 2).Foreground(styles.ColorPrimary).Bold(true).Underline(true)
+	helpTabStyle       lipgloss.Style
+	helpTabActiveStyle lipgloss.Style
 )
+
+func init() {
+	styles.OnThemeChange(func() {
+		helpTabStyle = lipgloss.NewStyle().Padding(0, 2).Foreground(styles.ColorSubtle)
+		helpTabActiveStyle = lipgloss.NewStyle().Padding(0, 2).Foreground(styles.ColorPrimary).Bold(true).Underline(true)
+	})
+}
@@ -2,7 +2,6 @@ package help
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -12,18 +11,20 @@ import (
 	"github.com/madicen/jj-tui/internal/tui/tabs/help/commandhistory"
 )
 
-// renderTabBar renders the Shortcuts | History tab bar.
+// renderTabBar renders the Shortcuts | History | Audit | Diagnostics tab bar.
 func (m Model) renderTabBar() string {
-	shortcutsStyle := helpTabStyle
-	commandsStyle := helpTabStyle
-	if m.activeTab == 0 {
-		shortcutsStyle = helpTabActiveStyle
-	} else {
-		commandsStyle = helpTabActiveStyle
+	tab := func(idx int, zoneID, label string) string {
+		style := helpTabStyle
+		if m.activeTab == idx {
+			style = helpTabActiveStyle
+		}
+		return mark(m.zoneManager, zoneID, style.Render(label))
 	}
-	shortcutsTab := mark(m.zoneManager, mouse.ZoneHelpTabShortcuts, shortcutsStyle.Render("Shortcuts"))
-	commandsTab := mark(m.zoneManager, mouse.ZoneHelpTabCommands, commandsStyle.Render("History"))
-	return lipgloss.JoinHorizontal(lipgloss.Left, shortcutsTab, " │ ", commandsTab)
+	return lipgloss.JoinHorizontal(lipgloss.Left,
+		tab(TabShortcuts, mouse.ZoneHelpTabShortcuts, "Shortcuts"), " │ ",
+		tab(TabCommands, mouse.ZoneHelpTabCommands, "History"), " │ ",
+		tab(TabAudit, mouse.ZoneHelpTabAudit, "Audit"), " │ ",
+		tab(TabDiagnostics, mouse.ZoneHelpTabDiagnostics, "Diagnostics"))
 }
 
 // mark wraps content in a zone for click detection. Returns content unchanged if zoneManager is nil.
@@ -34,20 +35,6 @@ func mark(z *zone.Manager, id, content string) string {
 	return z.Mark(id, content)
 }
 
-// isAutoRefreshCommand returns true if the command is part of auto-refresh (filtered from history).
-func isAutoRefreshCommand(cmd string) bool {
-	autoRefreshPatterns := []string{
-		"jj log -r mutable()",
-		"jj log -r empty()",
-	}
-	for _, pattern := range autoRefreshPatterns {
-		if strings.HasPrefix(cmd, pattern) {
-			return true
-		}
-	}
-	return false
-}
-
 // formatDuration formats a duration for display (e.g. "<1ms", "42ms", "1.2s").
 func formatDuration(d time.Duration) string {
 	if d < time.Millisecond {
@@ -59,20 +46,30 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%.1fs", d.Seconds())
 }
 
+// formatTimestamp shows the time of day for today's commands and the date too for earlier
+// sessions' (e.g. "Jan 02 15:04").
+func formatTimestamp(t, now time.Time) string {
+	if y, m, d := t.Date(); y == now.Year() && m == now.Month() && d == now.Day() {
+		return t.Format("15:04:05")
+	}
+	return t.Format("Jan 02 15:04")
+}
+
 // BuildCommandHistoryEntries builds display entries from the jj service, filtering auto-refresh and formatting.
 // Returns nil if svc is nil.
 func BuildCommandHistoryEntries(svc *jj.Service) []commandhistory.Entry {
 	if svc == nil {
 		return nil
 	}
+	now := time.Now()
 	var out []commandhistory.Entry
 	for _, e := range svc.GetCommandHistory() {
-		if isAutoRefreshCommand(e.Command) {
+		if jj.IsAutoRefreshCommand(e.Command) {
 			continue
 		}
 		out = append(out, commandhistory.Entry{
 			Command:   e.Command,
-			Timestamp: e.Timestamp.Format("15:04:05"),
+			Timestamp: formatTimestamp(e.Timestamp, now),
 			Duration:  formatDuration(e.Duration),
 			Success:   e.Success,
 			Error:     e.Error,
@@ -18,6 +18,9 @@ import (
 
 // Model is the "not a jj repo" welcome screen. Path non-empty means the screen is active.
 //
+// Recently opened repositories (config recent_repos) are listed first, with a directory browser
+// for anything else, so launching jj-tui from the wrong directory is one keypress from a repo.
+//
 // The screen offers three onboarding paths so a user lands in a useful state without leaving the
 // TUI: bare init (just `jj git init --colocate`), init plus an existing remote URL, and init plus
 // `gh repo create` for a brand-new GitHub repo. We default to colocate because every subsequent
@@ -27,8 +30,14 @@ import (
 type Model struct {
 	path        string
 	urlInput    textinput.Model
-	ghAvailable bool // cached `exec.LookPath("gh")` result; refreshed on SetPath
-	ghPrivate   bool // visibility for the `gh repo create` button (default: true => --private)
+	ghAvailable bool     // cached `exec.LookPath("gh")` result; refreshed on SetPath
+	ghPrivate   bool     // visibility for the `gh repo create` button (default: true => --private)
+	recent      []string // recent repositories that still exist, newest first; refreshed on SetPath
+	recentSel   int
+	browser     *browser // non-nil while the directory browser replaces the screen
+	// switching is set when the screen was opened from a repository (Ctrl+O) to jump to another:
+	// only the recent list and the browser are offered, not init.
+	switching   bool
 	zoneManager *zone.Manager
 }
 
@@ -93,11 +102,52 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if m.browser != nil {
+		if s := msg.String(); s == "ctrl+q" || s == "ctrl+c" {
+			util.FlushMouse()
+			return m, tea.Quit
+		}
+		return m.handleBrowserKey(msg)
+	}
+
+	if m.switching {
+		switch msg.String() {
+		case "esc":
+			return m, state.NavigateTarget{Kind: state.NavigateDismissInit}.Cmd()
+		case "i", "g", "ctrl+v", "tab", "u":
+			return m, nil
+		case "enter":
+			if len(m.recent) == 0 {
+				return m, nil
+			}
+		}
+	}
+
 	switch msg.String() {
 	case "esc":
 		return m, state.NavigateTarget{Kind: state.NavigateDismissInit, StatusMessage: "Dismissed"}.Cmd()
-	case "i", "enter":
+	case "enter":
+		// With recent repositories listed, Enter opens the highlighted one; i still initializes.
+		if len(m.recent) > 0 {
+			return m, openRepoCmd(m.recent[m.recentSel])
+		}
 		return m, m.runInitCmd()
+	case "i":
+		return m, m.runInitCmd()
+	case "up", "k":
+		m.recentSel = max(m.recentSel-1, 0)
+		return m, nil
+	case "down", "j":
+		m.recentSel = max(min(m.recentSel+1, len(m.recent)-1), 0)
+		return m, nil
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if i := int(msg.String()[0] - '1'); i < len(m.recent) {
+			return m, openRepoCmd(m.recent[i])
+		}
+		return m, nil
+	case "b":
+		m.browser = newBrowser(m.path)
+		return m, nil
 	case "g":
 		if m.ghAvailable {
 			return m, m.runGhCreateCmd()
@@ -152,7 +202,22 @@ func (m Model) resolveClickedZone(msg zone.MsgZoneInBounds) string {
 }
 
 func (m Model) zoneIDs() []string {
+	if m.browser != nil {
+		ids := []string{mouse.ZoneActionInitBrowseOpenDir}
+		for i := m.browser.offset; i < min(m.browser.offset+browserRows, len(m.browser.entries)); i++ {
+			ids = append(ids, mouse.ZoneInitBrowseEntry(i))
+		}
+		return ids
+	}
+	if m.switching {
+		ids := []string{mouse.ZoneActionInitBrowse}
+		for i := range m.recent {
+			ids = append(ids, mouse.ZoneInitRecentRepo(i))
+		}
+		return ids
+	}
 	ids := []string{
+		mouse.ZoneActionInitBrowse,
 		mouse.ZoneActionJJInit,
 		mouse.ZoneActionInitURLInput,
 		mouse.ZoneActionInitVisibilityToggle,
@@ -160,11 +225,37 @@ func (m Model) zoneIDs() []string {
 	if m.ghAvailable {
 		ids = append(ids, mouse.ZoneActionInitGhRepoCreate)
 	}
+	for i := range m.recent {
+		ids = append(ids, mouse.ZoneInitRecentRepo(i))
+	}
 	return ids
 }
 
 func (m Model) handleZoneClick(zoneID string) (Model, tea.Cmd) {
+	for i, p := range m.recent {
+		if zoneID == mouse.ZoneInitRecentRepo(i) {
+			return m, openRepoCmd(p)
+		}
+	}
+	if m.browser != nil {
+		for i := range m.browser.entries {
+			if zoneID == mouse.ZoneInitBrowseEntry(i) {
+				m.browser = m.browser.clone()
+				m.browser.move(i)
+				return m, m.browser.activate()
+			}
+		}
+	}
 	switch zoneID {
+	case mouse.ZoneActionInitBrowse:
+		m.urlInput.Blur()
+		m.browser = newBrowser(m.path)
+		return m, nil
+	case mouse.ZoneActionInitBrowseOpenDir:
+		if m.browser != nil {
+			return m, openRepoCmd(m.browser.dir)
+		}
+		return m, nil
 	case mouse.ZoneActionJJInit:
 		m.urlInput.Blur()
 		return m, m.runInitCmd()
@@ -189,8 +280,8 @@ func (m Model) View() string {
 	if m.path == "" {
 		return ""
 	}
-	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#8B949E"))
-	pathStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#58A6FF"))
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.ColorSubtle)
+	pathStyle := lipgloss.NewStyle().Foreground(styles.ColorAccent)
 	mark := func(id, s string) string {
 		if m.zoneManager == nil {
 			return s
@@ -198,18 +289,28 @@ func (m Model) View() string {
 		return m.zoneManager.Mark(id, s)
 	}
 
+	if m.browser != nil {
+		return m.browserView(mark)
+	}
+	if m.switching {
+		return m.switcherView(mark)
+	}
+
 	repoName := filepath.Base(m.path)
 	var lines []string
 	lines = append(lines, styles.TitleStyle.Render("Welcome to jj-tui"))
 	lines = append(lines, "")
 	lines = append(lines, fmt.Sprintf("Directory: %s", pathStyle.Render(m.path)))
 	lines = append(lines, "")
+	lines = append(lines, m.recentLines(mark)...)
+	lines = append(lines, mutedStyle.Render(strings.Repeat("─", 60)))
+	lines = append(lines, "")
 	lines = append(lines, "This directory is not yet a Jujutsu repository.")
 	lines = append(lines, "")
 	lines = append(lines, lipgloss.NewStyle().Bold(true).Render("Initialize"))
 	lines = append(lines, "")
 
-	initButton := styles.ButtonStyle.Background(lipgloss.Color("#238636")).Render("Initialize Repository (i)")
+	initButton := styles.ButtonConfirmStyle.Render("Initialize Repository (i)")
 	lines = append(lines, mark(mouse.ZoneActionJJInit, initButton))
 	lines = append(lines, "")
 	lines = append(lines, mutedStyle.Render("Runs `jj git init --colocate` in this directory."))
@@ -226,7 +327,7 @@ func (m Model) View() string {
 	urlBox := mark(mouse.ZoneActionInitURLInput, m.urlInput.View())
 	lines = append(lines, urlLabel+":  "+urlBox)
 	lines = append(lines, "")
-	lines = append(lines, mutedStyle.Render("Tab/u to focus, paste a URL, then press Enter or i to initialize with origin set."))
+	lines = append(lines, mutedStyle.Render("Tab/u to focus, paste a URL, then press Enter to initialize with origin set."))
 	lines = append(lines, "")
 	lines = append(lines, mutedStyle.Render(strings.Repeat("─", 60)))
 	lines = append(lines, "")
@@ -234,7 +335,7 @@ func (m Model) View() string {
 	lines = append(lines, "")
 
 	if m.ghAvailable {
-		ghButton := styles.ButtonStyle.Background(lipgloss.Color("#1f6feb")).Render(fmt.Sprintf("Create new GitHub repo (%s) (g)", repoName))
+		ghButton := styles.ButtonActionStyle.Render(fmt.Sprintf("Create new GitHub repo (%s) (g)", repoName))
 		visLabel := "Public"
 		if m.ghPrivate {
 			visLabel = "Private"
@@ -254,6 +355,100 @@ func (m Model) View() string {
 	return strings.Join(lines, "\n")
 }
 
+// switcherView renders the screen opened from a repository to switch to another one.
+func (m Model) switcherView(mark func(id, s string) string) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.ColorSubtle)
+	pathStyle := lipgloss.NewStyle().Foreground(styles.ColorAccent)
+	var lines []string
+	lines = append(lines, styles.TitleStyle.Render("Switch repository"))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("Current: %s", pathStyle.Render(displayPath(m.path))))
+	lines = append(lines, "")
+	if len(m.recent) == 0 {
+		lines = append(lines, mutedStyle.Render("No other recent repositories yet."))
+		lines = append(lines, "")
+	}
+	lines = append(lines, m.recentLines(mark)...)
+	lines = append(lines, mutedStyle.Render("Press Esc to go back · Ctrl+q to quit"))
+	return strings.Join(lines, "\n")
+}
+
+// recentLines renders the recent repositories (if any) and the browse button.
+func (m Model) recentLines(mark func(id, s string) string) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.ColorSubtle)
+	var lines []string
+	if len(m.recent) > 0 {
+		lines = append(lines, lipgloss.NewStyle().Bold(true).Render("Recent repositories"))
+		lines = append(lines, "")
+		for i, p := range m.recent {
+			num := "   "
+			if i < 9 {
+				num = fmt.Sprintf("%d  ", i+1)
+			}
+			row := "  " + num + displayPath(p)
+			if i == m.recentSel {
+				row = "► " + num + styles.CommitSelectedStyle.Render(displayPath(p))
+			}
+			lines = append(lines, mark(mouse.ZoneInitRecentRepo(i), row))
+		}
+		lines = append(lines, "")
+		lines = append(lines, mutedStyle.Render("↑/↓ to select, Enter or 1-9 to open."))
+		lines = append(lines, "")
+	}
+	browseButton := styles.ButtonStyle.Render("Browse for a repository (b)")
+	lines = append(lines, mark(mouse.ZoneActionInitBrowse, browseButton))
+	lines = append(lines, "")
+	return lines
+}
+
+// browserView renders the directory browser in place of the welcome screen.
+func (m Model) browserView(mark func(id, s string) string) string {
+	b := m.browser
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.ColorSubtle)
+	pathStyle := lipgloss.NewStyle().Foreground(styles.ColorAccent)
+	repoStyle := lipgloss.NewStyle().Foreground(styles.ColorSuccess)
+
+	var lines []string
+	lines = append(lines, styles.TitleStyle.Render("Open a repository"))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("Directory: %s", pathStyle.Render(b.dir)))
+	lines = append(lines, "")
+	switch {
+	case b.err != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorAttention).Render(b.err.Error()))
+	case len(b.entries) == 0:
+		lines = append(lines, mutedStyle.Render("No subdirectories."))
+	}
+	if b.offset > 0 {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("  ↑ %d more", b.offset)))
+	}
+	end := min(b.offset+browserRows, len(b.entries))
+	for i := b.offset; i < end; i++ {
+		e := b.entries[i]
+		name := e.name + "/"
+		if i == b.cursor {
+			name = styles.CommitSelectedStyle.Render(name)
+		}
+		row := "  " + name
+		if i == b.cursor {
+			row = "► " + name
+		}
+		if e.repo {
+			row += " " + repoStyle.Render("(jj repo)")
+		}
+		lines = append(lines, mark(mouse.ZoneInitBrowseEntry(i), row))
+	}
+	if rest := len(b.entries) - end; rest > 0 {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("  ↓ %d more", rest)))
+	}
+	lines = append(lines, "")
+	lines = append(lines, mark(mouse.ZoneActionInitBrowseOpenDir, styles.ButtonStyle.Render("Open this directory (o)")))
+	lines = append(lines, "")
+	lines = append(lines, mutedStyle.Render("j/k to move, Enter to open a repo or enter a folder, h/← for the parent, ~ for home."))
+	lines = append(lines, mutedStyle.Render("Press Esc to go back · Ctrl+q to quit"))
+	return strings.Join(lines, "\n")
+}
+
 // SetPath sets the directory path and activates the screen; empty path hides it. Refreshes the
 // gh-availability cache so we can render the GitHub option only when it would actually work.
 func (m *Model) SetPath(path string) {
@@ -264,10 +459,28 @@ func (m *Model) SetPath(path string) {
 		m.ghAvailable = err == nil
 		m.urlInput.SetValue("")
 		m.urlInput.Blur()
+		m.recent = recentRepos(path)
+		m.recentSel = 0
 	}
 	if path == "" {
 		m.urlInput.Blur()
 	}
+	m.browser = nil
+	m.switching = false
+}
+
+// OpenSwitcher activates the screen as a repository switcher for the open repository at current:
+// recent repositories and the browser, without the init options.
+func (m *Model) OpenSwitcher(current string) {
+	m.SetPath(current)
+	m.recent = recentRepos(current)
+	m.recentSel = 0
+	m.switching = true
+}
+
+// Switching reports whether the screen is the repository switcher rather than the welcome screen.
+func (m *Model) Switching() bool {
+	return m.switching
 }
 
 // Path returns the current path (empty if screen is not active).
@@ -0,0 +1,178 @@
+package initrepo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// browserRows is how many directories the browser shows at once; the list scrolls to keep the
+// cursor visible.
+const browserRows = 12
+
+// recentRepos returns the saved recent repositories other than current, skipping ones that were
+// deleted (or are no longer jj repos) since they were opened.
+func recentRepos(current string) []string {
+	var out []string
+	for _, p := range config.LoadRecentRepos() {
+		if p != current && isJJRepo(p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func isJJRepo(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, ".jj"))
+	return err == nil && info.IsDir()
+}
+
+// displayPath shortens paths under the home directory to ~/… so recent repos fit on one line.
+func displayPath(dir string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return dir
+	}
+	if dir == home {
+		return "~"
+	}
+	if rel, ok := strings.CutPrefix(dir, home+string(filepath.Separator)); ok {
+		return filepath.Join("~", rel)
+	}
+	return dir
+}
+
+// openRepoCmd asks main to switch to dir. A directory that isn't a jj repo brings the welcome
+// screen back for it, so the browser can also pick where to initialize.
+func openRepoCmd(dir string) tea.Cmd {
+	return state.NavigateTarget{Kind: state.NavigateOpenRepo, RepoPath: dir}.Cmd()
+}
+
+// browser lists the subdirectories of dir so the user can walk to a repository.
+type browser struct {
+	dir     string
+	entries []browserEntry
+	cursor  int
+	offset  int // first visible entry
+	err     error
+}
+
+type browserEntry struct {
+	name string
+	repo bool // contains a .jj directory
+}
+
+func newBrowser(dir string) *browser {
+	b := &browser{}
+	b.load(dir)
+	return b
+}
+
+// clone copies b so Model keeps value semantics (an older copy of the model keeps its listing).
+func (b *browser) clone() *browser {
+	c := *b
+	return &c
+}
+
+// load lists dir's subdirectories (hidden ones skipped, symlinks followed) and resets the cursor.
+func (b *browser) load(dir string) {
+	b.dir, b.entries, b.cursor, b.offset, b.err = dir, nil, 0, 0, nil
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		b.err = err
+		return
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if !e.IsDir() {
+			if info, err := os.Stat(path); err != nil || !info.IsDir() {
+				continue
+			}
+		}
+		b.entries = append(b.entries, browserEntry{name: e.Name(), repo: isJJRepo(path)})
+	}
+}
+
+// parent moves up a level with the cursor on the directory we came from.
+func (b *browser) parent() {
+	parent := filepath.Dir(b.dir)
+	if parent == b.dir {
+		return
+	}
+	from := filepath.Base(b.dir)
+	b.load(parent)
+	for i, e := range b.entries {
+		if e.name == from {
+			b.move(i)
+			break
+		}
+	}
+}
+
+// move puts the cursor on entry i (clamped) and scrolls it into view.
+func (b *browser) move(i int) {
+	b.cursor = max(min(i, len(b.entries)-1), 0)
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	} else if b.cursor >= b.offset+browserRows {
+		b.offset = b.cursor - browserRows + 1
+	}
+}
+
+// selected returns the path and entry under the cursor; ok is false when the directory is empty.
+func (b *browser) selected() (path string, e browserEntry, ok bool) {
+	if b.cursor >= len(b.entries) {
+		return "", browserEntry{}, false
+	}
+	e = b.entries[b.cursor]
+	return filepath.Join(b.dir, e.name), e, true
+}
+
+// activate opens the entry under the cursor if it is a repository, otherwise descends into it.
+func (b *browser) activate() tea.Cmd {
+	path, e, ok := b.selected()
+	if !ok {
+		return nil
+	}
+	if e.repo {
+		return openRepoCmd(path)
+	}
+	b.load(path)
+	return nil
+}
+
+// handleBrowserKey handles keys while the directory browser is open.
+func (m Model) handleBrowserKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	b := m.browser.clone()
+	m.browser = b
+	switch msg.String() {
+	case "esc":
+		m.browser = nil
+	case "up", "k":
+		b.move(b.cursor - 1)
+	case "down", "j":
+		b.move(b.cursor + 1)
+	case "enter":
+		return m, b.activate()
+	case "right", "l":
+		if path, _, ok := b.selected(); ok {
+			b.load(path)
+		}
+	case "left", "h", "backspace":
+		b.parent()
+	case "~":
+		if home, err := os.UserHomeDir(); err == nil {
+			b.load(home)
+		}
+	case "o":
+		return m, openRepoCmd(b.dir)
+	}
+	return m, nil
+}
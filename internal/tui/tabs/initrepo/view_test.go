@@ -1,8 +1,14 @@
 package initrepo
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // TestViewRendersWelcomeScreenContent confirms the welcome screen's three sections (init,
@@ -22,7 +28,7 @@ func TestViewRendersWelcomeScreenContent(t *testing.T) {
 		"Runs `jj git init --colocate` in this directory.",
 		"Optional: connect a remote",
 		"Remote URL",
-		"Tab/u to focus, paste a URL, then press Enter or i to initialize with origin set.",
+		"Tab/u to focus, paste a URL, then press Enter to initialize with origin set.",
 		"Or create a brand-new GitHub repo",
 		"Press Esc to dismiss",
 	}
@@ -32,3 +38,131 @@ func TestViewRendersWelcomeScreenContent(t *testing.T) {
 		}
 	}
 }
+
+// TestRecentReposAndBrowser covers the two ways to reach an existing repository from the welcome
+// screen: the recent_repos list (Enter or a digit) and the directory browser (b).
+func TestRecentReposAndBrowser(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("JJ_TUI_CONFIG", filepath.Join(home, "config.json"))
+	for _, dir := range []string{"api/.jj", "notes", "work/web/.jj", "gone"} {
+		if err := os.MkdirAll(filepath.Join(home, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	api, web := filepath.Join(home, "api"), filepath.Join(home, "work", "web")
+	for _, p := range []string{filepath.Join(home, "gone"), web, api} {
+		if err := config.AddRecentRepo(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewModel()
+	m.SetPath(home)
+	view := m.View()
+	for _, s := range []string{"Recent repositories", "1  ~/api", "2  ~/work/web", "Browse for a repository (b)"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("view missing %q:\n%s", s, view)
+		}
+	}
+	if strings.Contains(view, "gone") {
+		t.Error("directories that are no longer jj repos should not be listed")
+	}
+	if got := openedRepo(t, m, "down", "enter"); got != web {
+		t.Errorf("down, enter opened %q, want %q", got, web)
+	}
+	if got := openedRepo(t, m, "1"); got != api {
+		t.Errorf("1 opened %q, want %q", got, api)
+	}
+
+	m, _ = m.Update(keyMsg("b"))
+	if view := m.View(); !strings.Contains(view, "api/ (jj repo)") || !strings.Contains(view, "notes/") {
+		t.Fatalf("browser view:\n%s", view)
+	}
+	// config.json is not listed; gone/, notes/ and work/ follow api/.
+	if got := openedRepo(t, m, "enter"); got != api {
+		t.Errorf("enter on a jj repo opened %q, want %q", got, api)
+	}
+	if got := openedRepo(t, m, "j", "j", "j", "enter", "enter"); got != web {
+		t.Errorf("entering work/ then web/ opened %q, want %q", got, web)
+	}
+	if got := openedRepo(t, m, "j", "j", "j", "l", "h", "o"); got != home {
+		t.Errorf("h should return to %q, opened %q", home, got)
+	}
+	m, _ = m.Update(keyMsg("esc"))
+	if !strings.Contains(m.View(), "Welcome to jj-tui") {
+		t.Error("esc should close the browser")
+	}
+}
+
+// openedRepo sends keys to m and returns the RepoPath of the NavigateOpenRepo they produced.
+func openedRepo(t *testing.T, m Model, keys ...string) string {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, k := range keys {
+		m, cmd = m.Update(keyMsg(k))
+	}
+	if cmd == nil {
+		t.Fatalf("%v produced no command", keys)
+	}
+	nav, ok := cmd().(state.NavigateMsg)
+	if !ok || nav.Target.Kind != state.NavigateOpenRepo {
+		t.Fatalf("%v produced %#v, want NavigateOpenRepo", keys, nav)
+	}
+	return nav.Target.RepoPath
+}
+
+func keyMsg(k string) tea.KeyMsg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// Opened from a repository (Ctrl+O), the screen lists the other recent repositories and the
+// browser but none of the init options.
+func TestSwitcher(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("JJ_TUI_CONFIG", filepath.Join(home, "config.json"))
+	api, web := filepath.Join(home, "api"), filepath.Join(home, "web")
+	for _, p := range []string{web, api} {
+		if err := os.MkdirAll(filepath.Join(p, ".jj"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := config.AddRecentRepo(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewModel()
+	m.OpenSwitcher(api)
+	view := m.View()
+	for _, s := range []string{"Switch repository", "Current: ~/api", "1  ~/web", "Browse for a repository (b)"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("view missing %q:\n%s", s, view)
+		}
+	}
+	if strings.Contains(view, "Initialize") || strings.Contains(view, "1  ~/api") {
+		t.Errorf("switcher should offer neither init nor the open repository:\n%s", view)
+	}
+	if _, cmd := m.Update(keyMsg("i")); cmd != nil {
+		t.Error("i should not initialize from the switcher")
+	}
+	if got := openedRepo(t, m, "enter"); got != web {
+		t.Errorf("enter opened %q, want %q", got, web)
+	}
+	_, cmd := m.Update(keyMsg("esc"))
+	if nav, ok := cmd().(state.NavigateMsg); !ok || nav.Target.Kind != state.NavigateDismissInit {
+		t.Errorf("esc produced %#v, want NavigateDismissInit", nav)
+	}
+	m.SetPath("")
+	if m.Switching() {
+		t.Error("SetPath should leave switcher mode")
+	}
+}
@@ -0,0 +1,374 @@
+// Package pager is a full-screen, less-style reader for long content (PR bodies, ticket
+// descriptions, diffs, command output) that doesn't fit the split viewports.
+package pager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+// matchStyle highlights search hits; the current hit uses currentMatchStyle.
+var matchStyle, currentMatchStyle lipgloss.Style
+
+func init() {
+	styles.OnThemeChange(func() {
+		matchStyle = lipgloss.NewStyle().Background(styles.ColorSelection).Foreground(styles.ColorHighlight)
+		currentMatchStyle = lipgloss.NewStyle().Background(styles.ColorHighlight).Foreground(styles.ColorOnBright).Bold(true)
+	})
+}
+
+// Model is the pager state. Content may contain ANSI styling; search runs on the plain text.
+type Model struct {
+	shown   bool
+	title   string
+	lines   []string // content lines as given (styled)
+	plain   []string // ANSI-stripped lines for search and copy
+	vp      viewport.Model
+	width   int
+	height  int
+	input   textinput.Model
+	typing  bool   // search prompt is open
+	query   string // last submitted search
+	matches []int  // line indices containing query
+	current int    // index into matches
+	status  string // one-shot footer message (e.g. "Pattern not found")
+	sel     util.LineSelection
+}
+
+// NewModel creates a hidden pager.
+func NewModel() Model {
+	in := textinput.New()
+	in.Prompt = "/"
+	in.CharLimit = 200
+	vp := viewport.New(80, 20)
+	vp.MouseWheelEnabled = true
+	return Model{input: in, vp: vp, width: 80, height: 24}
+}
+
+// Open shows content under title, scrolled to the top with no active search.
+func (m Model) Open(title, content string) Model {
+	content = strings.ReplaceAll(strings.TrimRight(content, "\n"), "\r\n", "\n")
+	m.shown = true
+	m.title = title
+	m.lines = strings.Split(content, "\n")
+	m.plain = make([]string, len(m.lines))
+	for i, l := range m.lines {
+		m.plain[i] = ansi.Strip(l)
+	}
+	m.typing = false
+	m.query = ""
+	m.matches = nil
+	m.current = 0
+	m.status = ""
+	m.sel.Cancel()
+	m.input.SetValue("")
+	m.input.Blur()
+	m.layout()
+	m.refreshContent()
+	m.vp.GotoTop()
+	return m
+}
+
+// StartSelection begins a line selection at line (v in the pager does the same from the top of
+// the screen) and scrolls it into view.
+func (m Model) StartSelection(line int) Model {
+	if len(m.lines) == 0 {
+		return m
+	}
+	m.sel.Start(min(line, len(m.lines)-1))
+	m.followSelection()
+	m.refreshContent()
+	return m
+}
+
+// IsSelecting reports whether a line selection is in progress.
+func (m *Model) IsSelecting() bool { return m.sel.Active() }
+
+// Hide closes the pager.
+func (m *Model) Hide() {
+	m.shown = false
+	m.lines, m.plain, m.matches = nil, nil, nil
+	m.vp.SetContent("")
+}
+
+// IsShown reports whether the pager is open.
+func (m *Model) IsShown() bool { return m.shown }
+
+// SetStatus shows a one-shot message in the footer until the next key.
+func (m *Model) SetStatus(s string) { m.status = s }
+
+// SetDimensions sets the full terminal size.
+func (m Model) SetDimensions(w, h int) Model {
+	m.width, m.height = max(w, 1), max(h, 3)
+	m.layout()
+	if m.shown {
+		m.refreshContent()
+	}
+	return m
+}
+
+// layout sizes the viewport between the one-line title bar and one-line footer.
+func (m *Model) layout() {
+	m.vp.Width = m.width
+	m.vp.Height = max(m.height-2, 1)
+	m.input.Width = max(m.width-2, 1)
+}
+
+// refreshContent re-renders the viewport content with search hits highlighted.
+func (m *Model) refreshContent() {
+	out := m.lines
+	if len(m.matches) > 0 {
+		out = make([]string, len(m.lines))
+		copy(out, m.lines)
+		for i, li := range m.matches {
+			st := matchStyle
+			if i == m.current {
+				st = currentMatchStyle
+			}
+			out[li] = highlight(m.plain[li], m.query, st)
+		}
+	}
+	m.vp.SetContent(strings.Join(m.sel.Highlight(out), "\n"))
+}
+
+// followSelection scrolls so the selection cursor is on screen.
+func (m *Model) followSelection() {
+	c := m.sel.Cursor()
+	if c < m.vp.YOffset {
+		m.vp.SetYOffset(c)
+	} else if c >= m.vp.YOffset+m.vp.Height {
+		m.vp.SetYOffset(c - m.vp.Height + 1)
+	}
+}
+
+// handleSelectionKey moves or yanks an active selection. handled is false for keys that keep
+// their normal meaning (search, n/N, q).
+func (m Model) handleSelectionKey(k string) (Model, tea.Cmd, bool) {
+	page := max(m.vp.Height, 1)
+	switch k {
+	case "esc", "v", "V":
+		m.sel.Cancel()
+	case "y":
+		text, status := m.sel.Text(m.plain), m.sel.CopiedStatus()
+		m.sel.Cancel()
+		m.refreshContent()
+		m.status = status
+		return m, util.CopyToClipboard(text), true
+	case "j", "down", "enter":
+		m.sel.Move(1, len(m.lines))
+	case "k", "up":
+		m.sel.Move(-1, len(m.lines))
+	case " ", "f", "pgdown", "ctrl+f":
+		m.sel.Move(page, len(m.lines))
+	case "b", "pgup", "ctrl+b":
+		m.sel.Move(-page, len(m.lines))
+	case "d", "ctrl+d":
+		m.sel.Move(page/2, len(m.lines))
+	case "u", "ctrl+u":
+		m.sel.Move(-page/2, len(m.lines))
+	case "g", "home":
+		m.sel.Move(-len(m.lines), len(m.lines))
+	case "G", "end":
+		m.sel.Move(len(m.lines), len(m.lines))
+	default:
+		return m, nil, false
+	}
+	m.followSelection()
+	m.refreshContent()
+	return m, nil, true
+}
+
+// highlight renders line with every case-insensitive occurrence of query in st. Styling from the
+// original line is dropped on matched lines so the hits stay readable.
+func highlight(line, query string, st lipgloss.Style) string {
+	if query == "" {
+		return line
+	}
+	lower, q := strings.ToLower(line), strings.ToLower(query)
+	if len(lower) != len(line) || len(q) != len(query) {
+		// Case folding changed byte lengths; offsets would not line up, so match exactly.
+		lower, q = line, query
+	}
+	var b strings.Builder
+	pos := 0
+	for {
+		i := strings.Index(lower[pos:], q)
+		if i < 0 {
+			break
+		}
+		start := pos + i
+		b.WriteString(line[pos:start])
+		b.WriteString(st.Render(line[start : start+len(q)]))
+		pos = start + len(q)
+	}
+	b.WriteString(line[pos:])
+	return b.String()
+}
+
+// FindMatches returns the indices of lines containing query (case-insensitive).
+func FindMatches(lines []string, query string) []int {
+	if query == "" {
+		return nil
+	}
+	q := strings.ToLower(query)
+	var out []int
+	for i, l := range lines {
+		if strings.Contains(strings.ToLower(l), q) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// search runs query and jumps to the first hit at or below the top of the screen.
+func (m *Model) search(query string) {
+	m.query = query
+	m.matches = FindMatches(m.plain, query)
+	m.current = 0
+	if len(m.matches) == 0 {
+		if query != "" {
+			m.status = fmt.Sprintf("Pattern not found: %s", query)
+		}
+		m.refreshContent()
+		return
+	}
+	for i, li := range m.matches {
+		if li >= m.vp.YOffset {
+			m.current = i
+			break
+		}
+	}
+	m.jumpToCurrent()
+}
+
+// step moves to the next (dir=1) or previous (dir=-1) hit, wrapping around.
+func (m *Model) step(dir int) {
+	if len(m.matches) == 0 {
+		if m.query != "" {
+			m.status = fmt.Sprintf("Pattern not found: %s", m.query)
+		}
+		return
+	}
+	m.current = (m.current + dir + len(m.matches)) % len(m.matches)
+	m.jumpToCurrent()
+}
+
+func (m *Model) jumpToCurrent() {
+	m.refreshContent()
+	m.vp.SetYOffset(m.matches[m.current])
+	m.status = fmt.Sprintf("Match %d of %d", m.current+1, len(m.matches))
+}
+
+// Update handles keys and mouse wheel. q/Esc closes via NavigateClosePager.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.shown {
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.typing {
+			switch msg.String() {
+			case "enter":
+				m.typing = false
+				m.input.Blur()
+				m.search(strings.TrimSpace(m.input.Value()))
+				return m, nil
+			case "esc":
+				m.typing = false
+				m.input.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+		m.status = ""
+		if m.sel.Active() {
+			var cmd tea.Cmd
+			var handled bool
+			if m, cmd, handled = m.handleSelectionKey(msg.String()); handled {
+				return m, cmd
+			}
+		}
+		switch msg.String() {
+		case "q", "esc":
+			return m, state.NavigateTarget{Kind: state.NavigateClosePager}.Cmd()
+		case "/":
+			m.typing = true
+			m.input.SetValue("")
+			return m, m.input.Focus()
+		case "n":
+			m.step(1)
+		case "N":
+			m.step(-1)
+		case "j", "down", "enter":
+			m.vp.ScrollDown(1)
+		case "k", "up":
+			m.vp.ScrollUp(1)
+		case " ", "f", "pgdown", "ctrl+f":
+			m.vp.PageDown()
+		case "b", "pgup", "ctrl+b":
+			m.vp.PageUp()
+		case "d", "ctrl+d":
+			m.vp.HalfPageDown()
+		case "u", "ctrl+u":
+			m.vp.HalfPageUp()
+		case "g", "home":
+			m.vp.GotoTop()
+		case "G", "end":
+			m.vp.GotoBottom()
+		case "v", "V":
+			return m.StartSelection(m.vp.YOffset), nil
+		case "y":
+			return m, util.CopyToClipboard(strings.Join(m.plain, "\n"))
+		}
+		return m, nil
+	case tea.MouseMsg:
+		var cmd tea.Cmd
+		m.vp, cmd = m.vp.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// View renders the pager full screen: title bar, content, and a footer with the search prompt,
+// status, or key hints.
+func (m Model) View() string {
+	if !m.shown {
+		return ""
+	}
+	total := len(m.lines)
+	last := min(m.vp.YOffset+m.vp.Height, total)
+	pos := fmt.Sprintf(" %d-%d/%d  %3.f%% ", min(m.vp.YOffset+1, total), last, total, m.vp.ScrollPercent()*100)
+	title := ansi.Truncate(" "+m.title, max(m.width-lipgloss.Width(pos), 0), "…")
+	gap := max(m.width-lipgloss.Width(title)-lipgloss.Width(pos), 0)
+	header := styles.TitleStyle.Render(title + strings.Repeat(" ", gap) + pos)
+
+	var footer string
+	switch {
+	case m.typing:
+		footer = m.input.View()
+	case m.status != "":
+		footer = lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(m.status)
+	case m.sel.Active():
+		footer = lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render(m.sel.Status())
+	default:
+		footer = lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("j/k scroll · space/b page · g/G top/bottom · / search · n/N next/prev · v select · y copy · q close")
+	}
+	footer = ansi.Truncate(footer, m.width, "…")
+
+	body := m.vp.View()
+	if pad := m.vp.Height - lipgloss.Height(body); pad > 0 {
+		body += strings.Repeat("\n", pad)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
@@ -0,0 +1,112 @@
+package pager
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestFindMatches(t *testing.T) {
+	lines := []string{"Fix the parser", "no hit", "PARSER tests", ""}
+	got := FindMatches(lines, "parser")
+	if len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Errorf("FindMatches = %v, want [0 2]", got)
+	}
+	if FindMatches(lines, "") != nil {
+		t.Error("empty query should match nothing")
+	}
+}
+
+func TestSearchAndStep(t *testing.T) {
+	var lines []string
+	for i := range 100 {
+		lines = append(lines, "line")
+		if i == 40 || i == 80 {
+			lines[i] = "needle here"
+		}
+	}
+	m := NewModel().SetDimensions(80, 12).Open("Body", strings.Join(lines, "\n"))
+
+	m, _ = m.Update(key("/"))
+	for _, r := range "needle" {
+		m, _ = m.Update(key(string(r)))
+	}
+	m, _ = m.Update(key("enter"))
+	if m.vp.YOffset != 40 {
+		t.Fatalf("after search YOffset = %d, want 40", m.vp.YOffset)
+	}
+	m, _ = m.Update(key("n"))
+	if m.vp.YOffset != 80 || m.current != 1 {
+		t.Errorf("after n YOffset = %d current = %d, want 80 and 1", m.vp.YOffset, m.current)
+	}
+	m, _ = m.Update(key("n"))
+	if m.current != 0 {
+		t.Errorf("n should wrap to the first match, current = %d", m.current)
+	}
+
+	_, cmd := m.Update(key("q"))
+	if cmd == nil {
+		t.Fatal("q should close the pager")
+	}
+	nav, ok := cmd().(state.NavigateMsg)
+	if !ok || nav.Target.Kind != state.NavigateClosePager {
+		t.Errorf("q sent %#v, want NavigateClosePager", nav)
+	}
+}
+
+func TestLineSelection(t *testing.T) {
+	var lines []string
+	for i := range 30 {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	m := NewModel().SetDimensions(80, 12).Open("Body", strings.Join(lines, "\n"))
+	m.vp.SetYOffset(5)
+
+	m, _ = m.Update(key("v"))
+	if !m.IsSelecting() {
+		t.Fatal("v should start a selection")
+	}
+	m, _ = m.Update(key("j"))
+	m, _ = m.Update(key("j"))
+	if got := m.sel.Text(m.plain); got != "line 5\nline 6\nline 7" {
+		t.Errorf("selection = %q, want lines 5-7", got)
+	}
+	if !strings.Contains(m.View(), "-- VISUAL -- 3 lines") {
+		t.Error("footer should show the selection size")
+	}
+
+	m, cmd := m.Update(key("y"))
+	if cmd == nil || m.IsSelecting() {
+		t.Error("y should copy and end the selection")
+	}
+	if m.status != "Copied 3 lines" {
+		t.Errorf("status = %q", m.status)
+	}
+
+	// Esc cancels a selection without closing the pager.
+	m, _ = m.Update(key("v"))
+	m, cmd = m.Update(key("esc"))
+	if cmd != nil || m.IsSelecting() || !m.IsShown() {
+		t.Error("esc should only cancel the selection")
+	}
+
+	// Moving the cursor past the screen scrolls with it.
+	m = m.StartSelection(0)
+	m, _ = m.Update(key("G"))
+	if m.sel.Cursor() != 29 || m.vp.YOffset+m.vp.Height <= 29 {
+		t.Errorf("cursor = %d YOffset = %d, want the last line on screen", m.sel.Cursor(), m.vp.YOffset)
+	}
+}
@@ -8,8 +8,11 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/forge"
 	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tickets"
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
 	"github.com/madicen/jj-tui/internal/tui/tabs/prs"
@@ -21,13 +24,16 @@ type PrepareCreatePRResult struct {
 	HeadBranch        string
 	NeedsMoveBookmark bool
 	DefaultTitle      string
-	Ok                bool // false if no branch (e.g. no bookmark); caller should set status and not show
+	DefaultBody       string
+	Ticket            bookmark.TicketRef // zero when the head bookmark wasn't created from a ticket
+	Ok                bool               // false if no branch (e.g. no bookmark); caller should set status and not show
 }
 
-// PrepareCreatePR returns data needed to show the create-PR dialog (head branch, needs move, default title).
-// jiraTitles can be nil; if provided, default title is taken from jiraTitles[headBranch] when set.
+// PrepareCreatePR returns data needed to show the create-PR dialog (head branch, needs move, default title/body).
+// ticketRefs can be nil; when it has an entry for headBranch the ticket placeholders of the
+// cfg title/body templates are filled from it (see config.PRTitleTemplateOrDefault).
 // Bookmarks that already have an open PR are skipped so that "Create PR" targets a fresh branch.
-func PrepareCreatePR(repo *internal.Repository, commitIdx int, jiraTitles map[string]string) PrepareCreatePRResult {
+func PrepareCreatePR(repo *internal.Repository, commitIdx int, ticketRefs map[string]bookmark.TicketRef, cfg *config.Config) PrepareCreatePRResult {
 	if repo == nil || commitIdx < 0 || commitIdx >= len(repo.Graph.Commits) {
 		return PrepareCreatePRResult{}
 	}
@@ -53,16 +59,19 @@ func PrepareCreatePR(repo *internal.Repository, commitIdx int, jiraTitles map[st
 		}
 		needsMove = true
 	}
-	defaultTitle := headBranch
-	if jiraTitles != nil {
-		if t, ok := jiraTitles[headBranch]; ok && t != "" {
-			defaultTitle = t
-		}
+	vars := TemplateVars{
+		Branch:         headBranch,
+		CommitSubjects: CommitSubjectsForPR(repo, commitIdx),
 	}
+	ref := ticketRefs[headBranch]
+	vars.TicketKey = ref.Key
+	vars.TicketTitle = ref.Title
 	return PrepareCreatePRResult{
 		HeadBranch:        headBranch,
 		NeedsMoveBookmark: needsMove,
-		DefaultTitle:      defaultTitle,
+		DefaultTitle:      ExpandTitleTemplate(cfg.PRTitleTemplateOrDefault(), vars),
+		DefaultBody:       strings.TrimSpace(ExpandTemplate(cfg.PRBodyTemplateOrDefault(), vars)),
+		Ticket:            ref,
 		Ok:                true,
 	}
 }
@@ -75,10 +84,12 @@ type SubmitPRInput struct {
 	BaseBranch        string
 	NeedsMoveBookmark bool
 	Draft             bool
+	ToUpstream        bool   // open the PR in the fork's upstream repository
+	Reviewers         string // "@user, @org/team", requested once the PR exists
 	CommitChangeID    string
 	CommitIDsForDemo  []string // optional; used in demo mode for PR.CommitIDs
 	JJService         *jj.Service
-	GitHubService     *github.Service
+	Forge             forge.Service
 	DemoMode          bool
 }
 
@@ -114,14 +125,18 @@ func SubmitPRCmd(input SubmitPRInput) (tea.Cmd, string) {
 			return PRCreatedMsg{PR: demoPR}
 		}), ""
 	}
-	return CreatePRCmd(input.JJService, input.GitHubService, PRCreateParams{
+	users, teams := github.SplitReviewers(input.Reviewers)
+	return CreatePRCmd(input.JJService, input.Forge, PRCreateParams{
 		Title:             title,
 		Body:              strings.TrimSpace(input.Body),
 		HeadBranch:        input.HeadBranch,
 		BaseBranch:        input.BaseBranch,
 		NeedsMoveBookmark: input.NeedsMoveBookmark,
 		Draft:             input.Draft,
+		ToUpstream:        input.ToUpstream,
 		CommitChangeID:    input.CommitChangeID,
+		Reviewers:         users,
+		TeamReviewers:     teams,
 	}), ""
 }
 
@@ -133,7 +148,10 @@ type PRCreateParams struct {
 	BaseBranch        string
 	NeedsMoveBookmark bool
 	Draft             bool
+	ToUpstream        bool
 	CommitChangeID    string
+	Reviewers         []string // user logins
+	TeamReviewers     []string // team slugs
 }
 
 // CreatePRCmd pushes a branch and creates a PR.
@@ -145,7 +163,11 @@ type PRCreateParams struct {
 // time) before surfacing a confusing error. The preflight short-circuits that with a clear
 // actionable hint instead, and the retry loop now only kicks in for transient head-related
 // failures (the case it was actually written for).
-func CreatePRCmd(jjSvc *jj.Service, ghSvc *github.Service, params PRCreateParams) tea.Cmd {
+//
+// On GitLab the merge request is opened directly; the base-branch preflight, fork upstreams and
+// reviewer requests are GitHub-only.
+func CreatePRCmd(jjSvc *jj.Service, fg forge.Service, params PRCreateParams) tea.Cmd {
+	ghSvc, _ := fg.(*github.Service)
 	return func() tea.Msg {
 		ctx := context.Background()
 		if params.NeedsMoveBookmark && params.CommitChangeID != "" {
@@ -160,26 +182,34 @@ func CreatePRCmd(jjSvc *jj.Service, ghSvc *github.Service, params PRCreateParams
 		// Preflight base-branch existence. We swallow the bool-side error (network blips, auth
 		// hiccups) because the create call below will surface the same problem with richer
 		// detail; the preflight only short-circuits the unambiguous "base doesn't exist" case.
-		if exists, perr := ghSvc.BranchExists(ctx, params.BaseBranch); perr == nil && !exists {
-			return util.ErrorMsg{Err: fmt.Errorf(
-				"base branch %q does not exist on the remote (%s/%s).\n\n"+
-					"This usually means the GitHub repo is fresh and that branch hasn't been pushed yet. Fixes:\n"+
-					"  - Push your local %s bookmark to origin (Settings → GitHub → Push all bookmarks),\n"+
-					"  - or change the repo's default branch on GitHub to one that does exist,\n"+
-					"  - or pick a different base when creating the PR",
-				params.BaseBranch, ghSvc.GetOwner(), ghSvc.GetRepo(), params.BaseBranch,
-			)}
+		baseRepo := "origin"
+		if ghSvc != nil {
+			baseRepo = ghSvc.GetOwner() + "/" + ghSvc.GetRepo()
+			if up := ghSvc.Upstream(); params.ToUpstream && up != nil {
+				baseRepo = up.FullName()
+			}
+			if exists, perr := ghSvc.BaseBranchExists(ctx, params.BaseBranch, params.ToUpstream); perr == nil && !exists {
+				return util.ErrorMsg{Err: fmt.Errorf(
+					"base branch %q does not exist on the remote (%s).\n\n"+
+						"This usually means the GitHub repo is fresh and that branch hasn't been pushed yet. Fixes:\n"+
+						"  - Push your local %s bookmark to origin (Settings → GitHub → Push all bookmarks),\n"+
+						"  - or change the repo's default branch on GitHub to one that does exist,\n"+
+						"  - or pick a different base when creating the PR",
+					params.BaseBranch, baseRepo, params.BaseBranch,
+				)}
+			}
 		}
 		time.Sleep(3 * time.Second)
 		var pr *internal.GitHubPR
 		var lastErr error
 		for range 5 {
-			pr, lastErr = ghSvc.CreatePullRequest(ctx, &internal.CreatePRRequest{
+			pr, lastErr = fg.CreatePullRequest(ctx, &internal.CreatePRRequest{
 				Title:      params.Title,
 				Body:       params.Body,
 				HeadBranch: params.HeadBranch,
 				BaseBranch: params.BaseBranch,
 				Draft:      params.Draft,
+				ToUpstream: params.ToUpstream,
 			})
 			if lastErr == nil {
 				break
@@ -207,19 +237,26 @@ func CreatePRCmd(jjSvc *jj.Service, ghSvc *github.Service, params PRCreateParams
 			lower := strings.ToLower(detail)
 			if strings.Contains(lower, "field=base") || strings.Contains(lower, ".base=") {
 				detail += fmt.Sprintf(
-					"\n\nHint: GitHub rejected the PR's base branch %q. The branch may not exist on %s/%s yet — push it via Settings → GitHub → Push all bookmarks, or open the PR against a different base.",
-					params.BaseBranch, ghSvc.GetOwner(), ghSvc.GetRepo(),
+					"\n\nHint: GitHub rejected the PR's base branch %q. The branch may not exist on %s yet — push it via Settings → GitHub → Push all bookmarks, or open the PR against a different base.",
+					params.BaseBranch, baseRepo,
 				)
 			}
 			return util.ErrorMsg{Err: fmt.Errorf("failed to create PR: %s\nPush output: %s", detail, pushOutput)}
 		}
-		return PRCreatedMsg{PR: pr}
+		// The PR exists either way; a failed review request is reported alongside it.
+		var reviewersErr error
+		if ghSvc != nil {
+			reviewersErr = ghSvc.RequestReviewers(ctx, pr.Number, params.Reviewers, params.TeamReviewers, params.ToUpstream)
+		}
+		return PRCreatedMsg{PR: pr, ReviewersErr: reviewersErr}
 	}
 }
 
-// OpenCreatePRResult is the result of OpenCreatePR.
+// OpenCreatePRResult is the result of OpenCreatePR. ReviewersHead is the change ID whose stack
+// LoadReviewerSuggestionsCmd and LoadLargeFilesCmd should look up.
 type OpenCreatePRResult struct {
 	StatusMessage string
+	ReviewersHead string
 	Ok            bool
 }
 
@@ -227,10 +264,11 @@ type OpenCreatePRResult struct {
 // height is the content area height (available lines). The body textarea uses the rest after fixed form lines.
 // defaultBranch is the resolved GitHub default branch (e.g. "main", "master", "trunk"); when
 // empty the form falls back to "main" to preserve the legacy behavior on repos where the
-// lookup hasn't completed or the GitHub service is unavailable.
+// lookup hasn't completed or the GitHub service is unavailable. When ghSvc reports a fork
+// upstream, the form offers it as the PR target (preselected when PRs target upstream).
 // Caller sets view mode and status message from the result.
-func OpenCreatePR(modal *Model, repo *internal.Repository, commitIdx int, jiraTitles map[string]string, defaultBranch string, width, height int) OpenCreatePRResult {
-	data := PrepareCreatePR(repo, commitIdx, jiraTitles)
+func OpenCreatePR(modal *Model, repo *internal.Repository, commitIdx int, ticketRefs map[string]bookmark.TicketRef, cfg *config.Config, defaultBranch string, ghSvc *github.Service, width, height int) OpenCreatePRResult {
+	data := PrepareCreatePR(repo, commitIdx, ticketRefs, cfg)
 	if !data.Ok {
 		return OpenCreatePRResult{StatusMessage: "No bookmark found. Create one first with 'b'.", Ok: false}
 	}
@@ -239,16 +277,27 @@ func OpenCreatePR(modal *Model, repo *internal.Repository, commitIdx int, jiraTi
 		baseBranch = "main"
 	}
 	modal.Show(commitIdx, baseBranch, data.HeadBranch)
+	if up := ghSvc.Upstream(); up != nil {
+		modal.SetFork(up, ghSvc.GetOwner(), ghSvc.GetRepo(), ghSvc.TargetsUpstream())
+	} else {
+		modal.SetFork(nil, "", "", false)
+	}
 	modal.SetNeedsMoveBookmark(data.NeedsMoveBookmark)
+	modal.SetTicket(data.Ticket)
 	modal.SetTitle(data.DefaultTitle)
 	modal.GetTitleInput().Focus()
 	modal.GetBodyInput().Blur()
-	modal.SetBody("")
+	modal.SetBody(data.DefaultBody)
 	modal.GetTitleInput().Width = width
+	modal.GetReviewersInput().Width = width
 	modal.GetBodyInput().SetWidth(width)
 	// Use full content height: fixed lines (branch, "Title:", title input, "Body:",
-	// draft toggle + spacer, buttons) ≈ 13
-	const fixedFormLines = 13
+	// draft toggle + spacer, buttons) ≈ 13, the reviewers field with its suggestions, the
+	// large-file warning, plus the target line for forks
+	fixedFormLines := 13 + 3 + maxSuggestionLines + 1
+	if ghSvc.Upstream() != nil {
+		fixedFormLines++
+	}
 	bodyHeight := height - fixedFormLines
 	if bodyHeight < 3 {
 		bodyHeight = 3
@@ -258,7 +307,28 @@ func OpenCreatePR(modal *Model, repo *internal.Repository, commitIdx int, jiraTi
 	if data.NeedsMoveBookmark {
 		statusMessage = fmt.Sprintf("Creating PR for %s (will move bookmark)", data.HeadBranch)
 	}
-	return OpenCreatePRResult{StatusMessage: statusMessage, Ok: true}
+	return OpenCreatePRResult{StatusMessage: statusMessage, ReviewersHead: repo.Graph.Commits[commitIdx].ChangeID, Ok: true}
+}
+
+// LoadTicketDescriptionCmd fetches ref from the ticket provider and returns its description as
+// GitHub markdown in a TicketDescriptionLoadedMsg.
+func LoadTicketDescriptionCmd(svc tickets.Service, ref bookmark.TicketRef) tea.Cmd {
+	return func() tea.Msg {
+		t, err := svc.GetTicket(context.Background(), ref.ID)
+		if err != nil {
+			return TicketDescriptionLoadedMsg{Ticket: ref, Err: err}
+		}
+		return TicketDescriptionLoadedMsg{Ticket: ref, Markdown: t.MarkdownDescription()}
+	}
+}
+
+// TicketSection formats a ticket description as a PR body section headed by the ticket key and title.
+func TicketSection(ref bookmark.TicketRef, markdown string) string {
+	heading := "## " + ref.Key
+	if ref.Title != "" {
+		heading += ": " + ref.Title
+	}
+	return heading + "\n\n" + strings.TrimSpace(markdown)
 }
 
 // SubmitPRResult is the result of SubmitPR.
@@ -268,7 +338,7 @@ type SubmitPRResult struct {
 }
 
 // SubmitPR builds submit input from modal and repo/services and runs the PR create command.
-func SubmitPR(modal *Model, repo *internal.Repository, jjService *jj.Service, githubService *github.Service, demoMode bool) SubmitPRResult {
+func SubmitPR(modal *Model, repo *internal.Repository, jjService *jj.Service, fg forge.Service, demoMode bool) SubmitPRResult {
 	var commitChangeID string
 	var commitIDsForDemo []string
 	if repo != nil {
@@ -288,10 +358,12 @@ func SubmitPR(modal *Model, repo *internal.Repository, jjService *jj.Service, gi
 		BaseBranch:        modal.GetBaseBranch(),
 		NeedsMoveBookmark: modal.NeedsMoveBookmark(),
 		Draft:             modal.GetDraft(),
+		ToUpstream:        modal.ToUpstream(),
+		Reviewers:         modal.GetReviewers(),
 		CommitChangeID:    commitChangeID,
 		CommitIDsForDemo:  commitIDsForDemo,
 		JJService:         jjService,
-		GitHubService:     githubService,
+		Forge:             fg,
 		DemoMode:          demoMode,
 	}
 	cmd, errStr := SubmitPRCmd(input)
@@ -312,6 +384,9 @@ func HandlePRCreatedMsg(input PRCreatedInput, app *state.AppState) tea.Cmd {
 	app.Loading = false
 	app.ViewMode = state.ViewCommitGraph
 	app.StatusMessage = fmt.Sprintf("PR #%d created: %s", input.PR.Number, input.PR.Title)
+	if input.ReviewersErr != nil {
+		app.StatusMessage += fmt.Sprintf(" (reviewers not requested: %v)", input.ReviewersErr)
+	}
 	if input.DemoMode {
 		if app.Repository != nil && input.PR != nil {
 			app.Repository.PRs = append([]internal.GitHubPR{*input.PR}, app.Repository.PRs...)
@@ -322,7 +397,7 @@ func HandlePRCreatedMsg(input PRCreatedInput, app *state.AppState) tea.Cmd {
 	if app.Repository != nil {
 		existing = len(app.Repository.PRs)
 	}
-	return tea.Batch(util.OpenURL(input.PR.URL), prs.LoadPRsCmd(app.GitHubService, app.GithubInfo, app.DemoMode, existing))
+	return tea.Batch(util.OpenURL(input.PR.URL), prs.LoadPRsCmd(app.Forge(), app.GithubInfo, app.DemoMode, existing))
 }
 
 // PRCreatedInput is the context main sends when forwarding PRCreatedMsg.
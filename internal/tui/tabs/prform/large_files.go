@@ -0,0 +1,77 @@
+package prform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// maxLargeFilesListed caps how many flagged paths the form's warning line names.
+const maxLargeFilesListed = 3
+
+// LargeFilesLoadedMsg carries the large or sensitive files the PR's stack (trunk()..head) adds.
+type LargeFilesLoadedMsg struct {
+	state.RepoResult
+	Head  string
+	Files []jj.LargeFile
+	Err   error
+}
+
+// LoadLargeFilesCmd checks the commits the PR would push for files the jj service's
+// LargeFileRules flag.
+func LoadLargeFilesCmd(svc *jj.Service, head string) tea.Cmd {
+	if svc == nil || head == "" || svc.LargeFileRules.IsZero() {
+		return nil
+	}
+	return func() tea.Msg {
+		files, err := svc.LargeFiles(context.Background(), fmt.Sprintf("trunk()..%s", head))
+		return LargeFilesLoadedMsg{Head: head, Files: files, Err: err}
+	}
+}
+
+// BeginLargeFilesCheck records the commit head whose stack is being checked; results for any
+// other commit are ignored.
+func (m *Model) BeginLargeFilesCheck(head string) {
+	m.largeFilesHead = head
+	m.largeFiles = nil
+}
+
+// SetLargeFiles applies a large-file check to the open form (ignoring results for another commit)
+// and returns a status line when something was flagged.
+func (m *Model) SetLargeFiles(msg LargeFilesLoadedMsg) string {
+	if !m.shown || msg.Head != m.largeFilesHead || msg.Err != nil {
+		return ""
+	}
+	m.largeFiles = msg.Files
+	if len(msg.Files) == 0 {
+		return ""
+	}
+	noun := "files"
+	if len(msg.Files) == 1 {
+		noun = "file"
+	}
+	return fmt.Sprintf("⚠ This PR would push %d large or sensitive %s: %s", len(msg.Files), noun, msg.Files[0].Path)
+}
+
+// renderLargeFilesWarning renders the one-line warning above the title naming the flagged files,
+// or nil when there are none.
+func (m Model) renderLargeFilesWarning() []string {
+	if len(m.largeFiles) == 0 {
+		return nil
+	}
+	var names []string
+	for i, f := range m.largeFiles {
+		if i == maxLargeFilesListed {
+			names = append(names, fmt.Sprintf("+%d more", len(m.largeFiles)-i))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", f.Path, f.Reason()))
+	}
+	return []string{lipgloss.NewStyle().Foreground(styles.ColorWarning).Render("⚠ Large or sensitive files: " + strings.Join(names, ", "))}
+}
@@ -0,0 +1,38 @@
+package prform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+)
+
+// Flagged files show as one warning line naming the first few; results for another commit are
+// ignored.
+func TestSetLargeFiles(t *testing.T) {
+	m := NewModel(nil)
+	m.Show(0, "main", "feature")
+	m.BeginLargeFilesCheck("kxqv")
+	files := []jj.LargeFile{
+		{Path: "dump.sql", Size: 30 << 20, Pattern: "*.sql"},
+		{Path: "a.bin", Size: 8 << 20},
+		{Path: "b.bin", Size: 7 << 20},
+		{Path: "c.bin", Size: 6 << 20},
+	}
+	if status := m.SetLargeFiles(LargeFilesLoadedMsg{Head: "other", Files: files}); status != "" || strings.Contains(m.View(), "Large or sensitive") {
+		t.Fatalf("stale result applied: %q", status)
+	}
+	status := m.SetLargeFiles(LargeFilesLoadedMsg{Head: "kxqv", Files: files})
+	if status != "⚠ This PR would push 4 large or sensitive files: dump.sql" {
+		t.Fatalf("status = %q", status)
+	}
+	view := m.View()
+	for _, want := range []string{"dump.sql (30.0 MB, matches *.sql)", "b.bin (7.0 MB)", "+1 more"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "c.bin") {
+		t.Errorf("only %d files should be named:\n%s", maxLargeFilesListed, view)
+	}
+}
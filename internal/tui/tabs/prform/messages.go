@@ -3,11 +3,16 @@ package prform
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
 )
 
-// PRCreatedMsg indicates a PR was created.
+// PRCreatedMsg indicates a PR was created. ReviewersErr is set when the PR was created but
+// requesting its reviewers failed.
 type PRCreatedMsg struct {
-	PR *internal.GitHubPR
+	state.RepoResult
+	PR           *internal.GitHubPR
+	ReviewersErr error
 }
 
 // CancelRequestedMsg is sent when the user cancels (esc); main forwards to modal which responds with PerformCancelCmd.
@@ -41,3 +46,11 @@ func PerformCancelCmd() tea.Cmd {
 func PerformSubmitCmd() tea.Cmd {
 	return func() tea.Msg { return PerformSubmitMsg{} }
 }
+
+// TicketDescriptionLoadedMsg carries the linked ticket's description (GitHub markdown) for the PR body.
+type TicketDescriptionLoadedMsg struct {
+	state.RepoResult
+	Ticket   bookmark.TicketRef
+	Markdown string
+	Err      error
+}
@@ -2,6 +2,7 @@ package prform
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -10,10 +11,13 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/genmenu"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
 )
 
 // Model represents the PR creation dialog
@@ -24,15 +28,34 @@ type Model struct {
 	bodyInput         textarea.Model
 	baseBranch        string
 	headBranch        string
-	focusedField      int  // 0=title, 1=body
-	commitIndex       int  // Index of commit PR is being created from
-	needsMoveBookmark bool // True if we need to move the bookmark to include all commits
-	draft             bool // True if the PR should be created as a draft
+	focusedField      int                // 0=title, 1=body, 2=reviewers
+	commitIndex       int                // Index of commit PR is being created from
+	needsMoveBookmark bool               // True if we need to move the bookmark to include all commits
+	draft             bool               // True if the PR should be created as a draft
+	ticket            bookmark.TicketRef // Ticket the head bookmark was created from (zero when none)
+	// Fork workflow: when origin is a fork, the PR can target upstream (head "forkOwner:branch")
+	// or origin. originBase is origin's default branch, restored when switching back.
+	upstream   *github.Upstream
+	toUpstream bool
+	forkOwner  string
+	originRepo string
+	originBase string
 	// Long-press AI profile picker over the Generate chip; same structure used in
 	// the descedit, bookmark, and ticketform modals.
 	genMenu       genmenu.State
 	profiles      []config.AIProfile
 	activeProfile string
+	// Reviewers to request once the PR exists, pre-filled from the CODEOWNERS owners of the
+	// stack's changed files (loaded for the commit reviewersHead).
+	reviewersInput   textinput.Model
+	reviewersHead    string
+	reviewersLoading bool
+	reviewersErr     string
+	codeownersPath   string
+	suggestions      []github.ReviewerSuggestion
+	// largeFiles are the large or sensitive files the stack for largeFilesHead adds.
+	largeFilesHead string
+	largeFiles     []jj.LargeFile
 }
 
 // NewModel creates a new PR creation model. zoneManager may be nil (zones will be omitted).
@@ -48,14 +71,20 @@ func NewModel(zoneManager *zone.Manager) Model {
 	bodyInput.SetWidth(60)
 	bodyInput.SetHeight(8)
 
+	reviewersInput := textinput.New()
+	reviewersInput.Placeholder = "@user, @org/team"
+	reviewersInput.CharLimit = 500
+	reviewersInput.Width = 60
+
 	return Model{
-		zoneManager:  zoneManager,
-		shown:        false,
-		titleInput:   titleInput,
-		bodyInput:    bodyInput,
-		baseBranch:   "main",
-		focusedField: 0,
-		commitIndex:  -1,
+		zoneManager:    zoneManager,
+		shown:          false,
+		titleInput:     titleInput,
+		bodyInput:      bodyInput,
+		baseBranch:     "main",
+		focusedField:   0,
+		commitIndex:    -1,
+		reviewersInput: reviewersInput,
 	}
 }
 
@@ -103,14 +132,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 		return m.handleKeyMsg(msg)
 	}
+	return m.updateFocusedInput(msg)
+}
 
-	if m.focusedField == 0 {
-		var cmd tea.Cmd
+// updateFocusedInput forwards msg to the focused title, body or reviewers input.
+func (m Model) updateFocusedInput(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch m.focusedField {
+	case 0:
 		m.titleInput, cmd = m.titleInput.Update(msg)
-		return m, cmd
+	case 1:
+		m.bodyInput, cmd = m.bodyInput.Update(msg)
+	default:
+		m.reviewersInput, cmd = m.reviewersInput.Update(msg)
 	}
-	var cmd tea.Cmd
-	m.bodyInput, cmd = m.bodyInput.Update(msg)
 	return m, cmd
 }
 
@@ -170,8 +205,8 @@ func (m Model) View() string {
 func (m Model) renderForm() string {
 	subtitleStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted)
 	buttonStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#30363d")).
+		Foreground(styles.ColorText).
+		Background(styles.ColorPanel).
 		Padding(0, 1).
 		Bold(true)
 
@@ -187,28 +222,76 @@ func (m Model) renderForm() string {
 		contentW = 60
 	}
 	genChip := mark(mouse.ZonePRGenerate, styles.AIGenerateChip())
-	branchLine := styles.SpreadRow(contentW, subtitleStyle.Render(fmt.Sprintf("Branch: %s → %s", m.baseBranch, m.headBranch)), genChip)
+	branchLine := styles.SpreadRow(contentW, subtitleStyle.Render(fmt.Sprintf("Branch: %s → %s", m.baseBranch, m.headRef())), genChip)
 
 	titleInput := mark(mouse.ZonePRTitle, m.titleInput.View())
 	bodyInput := mark(mouse.ZonePRBody, m.bodyInput.View())
+	reviewersInput := mark(mouse.ZonePRReviewers, m.reviewersInput.View())
 	draftToggle := mark(mouse.ZonePRDraft, m.renderDraftToggle())
 	submitBtn := mark(mouse.ZonePRSubmit, buttonStyle.Render("Create PR (Ctrl+S)"))
 	cancelBtn := mark(mouse.ZonePRCancel, buttonStyle.Render("Cancel (Esc)"))
+	buttons := lipgloss.JoinHorizontal(lipgloss.Left, submitBtn, "  ", cancelBtn)
+	if m.ticket.ID != "" {
+		ticketBtn := mark(mouse.ZonePRInsertTicket, buttonStyle.Render("Insert "+m.ticket.Key+" (Ctrl+T)"))
+		buttons = lipgloss.JoinHorizontal(lipgloss.Left, submitBtn, "  ", ticketBtn, "  ", cancelBtn)
+	}
 
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		branchLine,
+	lines := []string{branchLine}
+	if m.upstream != nil {
+		lines = append(lines, mark(mouse.ZonePRTarget, m.renderTargetToggle()))
+	}
+	lines = append(lines, m.renderLargeFilesWarning()...)
+	lines = append(lines,
 		"",
 		"Title:",
 		titleInput,
 		"",
 		"Body:",
 		bodyInput,
+		"",
+		"Reviewers:",
+		reviewersInput,
+	)
+	lines = append(lines, m.renderReviewerHints()...)
+	lines = append(lines,
 		"",
 		draftToggle,
 		"",
-		lipgloss.JoinHorizontal(lipgloss.Left, submitBtn, "  ", cancelBtn),
+		buttons,
 	)
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// headRef is the head as GitHub sees it: "forkOwner:branch" for PRs against the upstream.
+func (m Model) headRef() string {
+	if m.toUpstream && m.forkOwner != "" {
+		return m.forkOwner + ":" + m.headBranch
+	}
+	return m.headBranch
+}
+
+// renderTargetToggle shows which repository the PR opens in (forks only).
+func (m Model) renderTargetToggle() string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	target := m.originRepo + " (fork)"
+	if m.toUpstream {
+		target = m.upstream.FullName() + " (upstream)"
+	}
+	return muted.Render("Target: ") + lipgloss.NewStyle().Bold(true).Render(target) + muted.Render("  (Ctrl+O to switch)")
+}
+
+// toggleTarget switches the PR between upstream and origin, moving the base to the new
+// repository's default branch unless the user picked a different base.
+func (m *Model) toggleTarget() {
+	if m.upstream == nil {
+		return
+	}
+	m.toUpstream = !m.toUpstream
+	if m.toUpstream && m.baseBranch == m.originBase && m.upstream.DefaultBranch != "" {
+		m.baseBranch = m.upstream.DefaultBranch
+	} else if !m.toUpstream && m.baseBranch == m.upstream.DefaultBranch && m.originBase != "" {
+		m.baseBranch = m.originBase
+	}
 }
 
 // renderDraftToggle renders the "Open as draft" checkbox using the same look as
@@ -216,7 +299,7 @@ func (m Model) renderForm() string {
 func (m Model) renderDraftToggle() string {
 	label := "Open as draft (Ctrl+D)"
 	if m.draft {
-		on := lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Bold(true)
+		on := lipgloss.NewStyle().Foreground(styles.ColorPositive).Bold(true)
 		return on.Render("[✓]") + " " + label
 	}
 	off := lipgloss.NewStyle().Foreground(styles.ColorMuted)
@@ -233,34 +316,25 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 	case "ctrl+d":
 		m.draft = !m.draft
 		return m, nil
+	case "ctrl+t":
+		return m, state.NavigateTarget{Kind: state.NavigateInsertTicketIntoPR}.Cmd()
+	case "ctrl+o":
+		m.toggleTarget()
+		return m, nil
 	case "ctrl+s", "ctrl+enter":
 		return m, SubmitRequestedCmd()
 	case "tab":
-		// Switch between title and body
-		m.focusedField = (m.focusedField + 1) % 2
-		if m.focusedField == 0 {
-			m.titleInput.Focus()
-			m.bodyInput.Blur()
-		} else {
-			m.titleInput.Blur()
-			m.bodyInput.Focus()
-		}
+		// Cycle title → body → reviewers
+		m.SetFocusedField((m.focusedField + 1) % 3)
 		return m, nil
 	}
 	// Forward typing and other keys to the focused input
-	if m.focusedField == 0 {
-		var cmd tea.Cmd
-		m.titleInput, cmd = m.titleInput.Update(msg)
-		return m, cmd
-	}
-	var cmd tea.Cmd
-	m.bodyInput, cmd = m.bodyInput.Update(msg)
-	return m, cmd
+	return m.updateFocusedInput(msg)
 }
 
 // ZoneIDs returns the zone IDs this modal uses when rendering. Used to resolve clicks.
 func (m Model) ZoneIDs() []string {
-	return []string{mouse.ZonePRTitle, mouse.ZonePRBody, mouse.ZonePRDraft, mouse.ZonePRSubmit, mouse.ZonePRGenerate, mouse.ZonePRCancel}
+	return []string{mouse.ZonePRTitle, mouse.ZonePRBody, mouse.ZonePRReviewers, mouse.ZonePRDraft, mouse.ZonePRSubmit, mouse.ZonePRGenerate, mouse.ZonePRInsertTicket, mouse.ZonePRTarget, mouse.ZonePRCancel}
 }
 
 func (m Model) resolveClickedZone(msg zone.MsgZoneInBounds) string {
@@ -287,6 +361,9 @@ func (m Model) handleZoneClick(zoneID string) (Model, tea.Cmd) {
 	case mouse.ZonePRBody:
 		m.SetFocusedField(1)
 		return m, nil
+	case mouse.ZonePRReviewers:
+		m.SetFocusedField(2)
+		return m, nil
 	case mouse.ZonePRDraft:
 		m.draft = !m.draft
 		return m, nil
@@ -294,6 +371,11 @@ func (m Model) handleZoneClick(zoneID string) (Model, tea.Cmd) {
 		return m, SubmitRequestedCmd()
 	case mouse.ZonePRGenerate:
 		return m, state.NavigateTarget{Kind: state.NavigateGeneratePRForm}.Cmd()
+	case mouse.ZonePRInsertTicket:
+		return m, state.NavigateTarget{Kind: state.NavigateInsertTicketIntoPR}.Cmd()
+	case mouse.ZonePRTarget:
+		m.toggleTarget()
+		return m, nil
 	case mouse.ZonePRCancel:
 		return m, CancelRequestedCmd()
 	}
@@ -332,6 +414,78 @@ func (m *Model) Reset() {
 	m.focusedField = 0
 	m.needsMoveBookmark = false
 	m.draft = false
+	m.ticket = bookmark.TicketRef{}
+	m.reviewersInput.SetValue("")
+	m.reviewersInput.Blur()
+	m.reviewersHead = ""
+	m.reviewersLoading = false
+	m.reviewersErr = ""
+	m.codeownersPath = ""
+	m.suggestions = nil
+	m.largeFilesHead = ""
+	m.largeFiles = nil
+}
+
+// BeginReviewerSuggestions marks CODEOWNERS suggestions for the commit head as loading; results
+// for any other commit are ignored.
+func (m *Model) BeginReviewerSuggestions(head string) {
+	m.reviewersHead = head
+	m.reviewersLoading = true
+}
+
+// GetReviewers returns the reviewers field ("@user, @org/team", …)
+func (m *Model) GetReviewers() string {
+	return m.reviewersInput.Value()
+}
+
+// SetReviewers sets the reviewers field
+func (m *Model) SetReviewers(reviewers string) {
+	m.reviewersInput.SetValue(reviewers)
+}
+
+// SetFork offers the upstream as PR target when origin (forkOwner/originRepo) is a fork of up.
+// toUpstream selects it initially; the base switches to upstream's default branch. Pass a nil
+// up for non-forks.
+func (m *Model) SetFork(up *github.Upstream, forkOwner, originRepo string, toUpstream bool) {
+	m.upstream = up
+	m.forkOwner = forkOwner
+	m.originRepo = forkOwner + "/" + originRepo
+	m.originBase = m.baseBranch
+	m.toUpstream = false
+	if up != nil && toUpstream {
+		m.toggleTarget()
+	}
+}
+
+// ToUpstream reports whether the PR will be opened against the upstream repository.
+func (m *Model) ToUpstream() bool {
+	return m.toUpstream && m.upstream != nil
+}
+
+// GetTicket returns the ticket linked to the head bookmark (zero value when none)
+func (m *Model) GetTicket() bookmark.TicketRef {
+	return m.ticket
+}
+
+// SetTicket sets the ticket linked to the head bookmark
+func (m *Model) SetTicket(ref bookmark.TicketRef) {
+	m.ticket = ref
+}
+
+// InsertTicketDescription appends section to the body (separated by a blank line).
+// Returns false when the body already contains it.
+func (m *Model) InsertTicketDescription(section string) bool {
+	body := strings.TrimRight(m.bodyInput.Value(), "\n ")
+	heading, _, _ := strings.Cut(section, "\n")
+	if heading != "" && strings.Contains(body, heading) {
+		return false
+	}
+	if body == "" {
+		m.bodyInput.SetValue(section)
+	} else {
+		m.bodyInput.SetValue(body + "\n\n" + section)
+	}
+	return true
 }
 
 // GetDraft returns whether the PR should be created as a draft
@@ -389,23 +543,27 @@ func (m *Model) GetCommitIndex() int {
 	return m.commitIndex
 }
 
-// GetFocusedField returns the focused field (0=title, 1=body)
+// GetFocusedField returns the focused field (0=title, 1=body, 2=reviewers)
 func (m *Model) GetFocusedField() int {
 	return m.focusedField
 }
 
 // SetFocusedField sets the focused field and syncs Focus/Blur on inputs
 func (m *Model) SetFocusedField(i int) {
-	if i != 0 && i != 1 {
+	if i < 0 || i > 2 {
 		return
 	}
 	m.focusedField = i
-	if i == 0 {
+	m.titleInput.Blur()
+	m.bodyInput.Blur()
+	m.reviewersInput.Blur()
+	switch i {
+	case 0:
 		m.titleInput.Focus()
-		m.bodyInput.Blur()
-	} else {
-		m.titleInput.Blur()
+	case 1:
 		m.bodyInput.Focus()
+	default:
+		m.reviewersInput.Focus()
 	}
 }
 
@@ -424,6 +582,11 @@ func (m *Model) GetTitleInput() *textinput.Model {
 	return &m.titleInput
 }
 
+// GetReviewersInput returns the reviewers input field
+func (m *Model) GetReviewersInput() *textinput.Model {
+	return &m.reviewersInput
+}
+
 // GetBodyInput returns the body textarea field
 func (m *Model) GetBodyInput() *textarea.Model {
 	return &m.bodyInput
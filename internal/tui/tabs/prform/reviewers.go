@@ -0,0 +1,115 @@
+package prform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// maxSuggestionLines caps how many CODEOWNERS suggestions the form lists under the reviewers field.
+const maxSuggestionLines = 3
+
+// ReviewerSuggestionsLoadedMsg carries the CODEOWNERS owners of the files the PR's stack changes.
+// Source is the CODEOWNERS path ("" when the repository has none).
+type ReviewerSuggestionsLoadedMsg struct {
+	state.RepoResult
+	Head        string
+	Source      string
+	Suggestions []github.ReviewerSuggestion
+	Err         error
+}
+
+// LoadReviewerSuggestionsCmd matches the paths changed in trunk()..head against the repository's
+// CODEOWNERS file. The authenticated user is left out since GitHub won't request the author.
+func LoadReviewerSuggestionsCmd(jjSvc *jj.Service, ghSvc *github.Service, head string) tea.Cmd {
+	if jjSvc == nil || head == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		owners, err := github.LoadCodeowners(jjSvc.RepoPath)
+		if err != nil || owners == nil {
+			return ReviewerSuggestionsLoadedMsg{Head: head, Err: err}
+		}
+		stack, _, err := jjSvc.StackFiles(ctx, head)
+		if err != nil {
+			return ReviewerSuggestionsLoadedMsg{Head: head, Source: owners.Path, Err: err}
+		}
+		seen := map[string]bool{}
+		var paths []string
+		for _, c := range stack {
+			for _, f := range c.Files {
+				if !seen[f.Path] {
+					seen[f.Path] = true
+					paths = append(paths, f.Path)
+				}
+			}
+		}
+		var author string
+		if ghSvc != nil {
+			author, _ = ghSvc.GetAuthenticatedUsername(ctx)
+		}
+		return ReviewerSuggestionsLoadedMsg{Head: head, Source: owners.Path, Suggestions: owners.SuggestReviewers(paths, author)}
+	}
+}
+
+// SetReviewerSuggestions applies loaded suggestions to the open form (ignoring results for another
+// commit). The reviewers field is pre-filled with them unless the user already typed into it.
+func (m *Model) SetReviewerSuggestions(msg ReviewerSuggestionsLoadedMsg) {
+	if !m.shown || msg.Head != m.reviewersHead {
+		return
+	}
+	m.reviewersLoading = false
+	m.codeownersPath = msg.Source
+	m.reviewersErr = ""
+	if msg.Err != nil {
+		m.reviewersErr = msg.Err.Error()
+		return
+	}
+	m.suggestions = msg.Suggestions
+	if strings.TrimSpace(m.reviewersInput.Value()) != "" {
+		return
+	}
+	owners := make([]string, 0, len(msg.Suggestions))
+	for _, s := range msg.Suggestions {
+		owners = append(owners, s.Owner)
+	}
+	m.reviewersInput.SetValue(strings.Join(owners, ", "))
+}
+
+// renderReviewerHints renders the lines under the reviewers field: each suggested owner with the
+// CODEOWNERS rule that matched, or why there are none.
+func (m Model) renderReviewerHints() []string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	switch {
+	case m.reviewersLoading:
+		return []string{muted.Render("Looking up CODEOWNERS…")}
+	case m.reviewersErr != "":
+		return []string{lipgloss.NewStyle().Foreground(styles.ColorWarning).Render("CODEOWNERS: " + m.reviewersErr)}
+	case m.codeownersPath == "":
+		return []string{muted.Render("No CODEOWNERS file; type logins or @org/team, comma-separated")}
+	case len(m.suggestions) == 0:
+		return []string{muted.Render("No CODEOWNERS rule owns the changed files")}
+	}
+	var lines []string
+	for i, s := range m.suggestions {
+		if i == maxSuggestionLines {
+			lines[len(lines)-1] += muted.Render(fmt.Sprintf("  (+%d more)", len(m.suggestions)-i))
+			break
+		}
+		files := "1 file"
+		if s.Paths != 1 {
+			files = fmt.Sprintf("%d files", s.Paths)
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", lipgloss.NewStyle().Bold(true).Render(s.Owner),
+			muted.Render(fmt.Sprintf("← %s (%s:%d, %s)", s.Rule.Pattern, m.codeownersPath, s.Rule.Line, files))))
+	}
+	return lines
+}
@@ -0,0 +1,53 @@
+package prform
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+)
+
+// CODEOWNERS suggestions fill an untouched reviewers field and list the rule behind each owner;
+// results for another commit are ignored.
+func TestSetReviewerSuggestions(t *testing.T) {
+	m := NewModel(nil)
+	m.Show(0, "main", "feature")
+	m.BeginReviewerSuggestions("kxqv")
+	if !strings.Contains(m.View(), "Looking up CODEOWNERS") {
+		t.Fatal("loading hint missing")
+	}
+	suggestions := []github.ReviewerSuggestion{
+		{Owner: "@gopher", Rule: github.CodeownersRule{Pattern: "*.go", Line: 2}, Paths: 3},
+		{Owner: "@org/web", Rule: github.CodeownersRule{Pattern: "/web/", Line: 5}, Paths: 1},
+	}
+
+	m.SetReviewerSuggestions(ReviewerSuggestionsLoadedMsg{Head: "other", Source: ".github/CODEOWNERS", Suggestions: suggestions})
+	if m.GetReviewers() != "" {
+		t.Fatalf("stale result applied: %q", m.GetReviewers())
+	}
+	m.SetReviewerSuggestions(ReviewerSuggestionsLoadedMsg{Head: "kxqv", Source: ".github/CODEOWNERS", Suggestions: suggestions})
+	if m.GetReviewers() != "@gopher, @org/web" {
+		t.Fatalf("reviewers = %q", m.GetReviewers())
+	}
+	view := m.View()
+	for _, want := range []string{"← *.go (.github/CODEOWNERS:2, 3 files)", "← /web/ (.github/CODEOWNERS:5, 1 file)"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Typed reviewers are kept when suggestions arrive.
+	m.Show(0, "main", "feature")
+	m.BeginReviewerSuggestions("kxqv")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.GetFocusedField() != 2 {
+		t.Fatalf("tab should reach the reviewers field, focused = %d", m.GetFocusedField())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("@alice")})
+	m.SetReviewerSuggestions(ReviewerSuggestionsLoadedMsg{Head: "kxqv", Source: ".github/CODEOWNERS", Suggestions: suggestions})
+	if m.GetReviewers() != "@alice" {
+		t.Fatalf("reviewers = %q", m.GetReviewers())
+	}
+}
@@ -0,0 +1,27 @@
+package prform
+
+import (
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/github"
+)
+
+func TestSetForkSwitchesTargetAndBase(t *testing.T) {
+	m := NewModel(nil)
+	m.Show(0, "main", "feature")
+	m.SetFork(&github.Upstream{Owner: "up", Repo: "proj", DefaultBranch: "trunk"}, "me", "proj", true)
+	if !m.ToUpstream() || m.GetBaseBranch() != "trunk" || m.headRef() != "me:feature" {
+		t.Fatalf("upstream target: base=%q head=%q", m.GetBaseBranch(), m.headRef())
+	}
+
+	m.toggleTarget()
+	if m.ToUpstream() || m.GetBaseBranch() != "main" || m.headRef() != "feature" {
+		t.Fatalf("origin target: base=%q head=%q", m.GetBaseBranch(), m.headRef())
+	}
+
+	m.SetBaseBranch("release")
+	m.toggleTarget()
+	if m.GetBaseBranch() != "release" {
+		t.Fatalf("a base the user picked must survive switching, got %q", m.GetBaseBranch())
+	}
+}
@@ -0,0 +1,85 @@
+package prform
+
+import (
+	"strings"
+
+	"github.com/madicen/jj-tui/internal"
+)
+
+// maxTemplateCommitSubjects caps {commit_subjects} so a bookmark sitting on a long mutable
+// stack doesn't produce a body the textarea can't scroll sensibly.
+const maxTemplateCommitSubjects = 50
+
+// TemplateVars holds the values substituted into the PR title/body templates.
+type TemplateVars struct {
+	TicketKey      string
+	TicketTitle    string
+	Branch         string
+	CommitSubjects []string // oldest first
+}
+
+// ExpandTemplate replaces {ticket_key}, {ticket_title}, {branch} and {commit_subjects} in tmpl.
+// {commit_subjects} expands to a "- subject" bullet per line. Unknown placeholders are kept.
+func ExpandTemplate(tmpl string, vars TemplateVars) string {
+	bullets := make([]string, 0, len(vars.CommitSubjects))
+	for _, s := range vars.CommitSubjects {
+		bullets = append(bullets, "- "+s)
+	}
+	r := strings.NewReplacer(
+		"{ticket_key}", vars.TicketKey,
+		"{ticket_title}", vars.TicketTitle,
+		"{branch}", vars.Branch,
+		"{commit_subjects}", strings.Join(bullets, "\n"),
+	)
+	return r.Replace(tmpl)
+}
+
+// ExpandTitleTemplate expands a title template into a single line. Separators left dangling by
+// empty placeholders (e.g. " - " when no ticket is linked) are trimmed; when nothing remains the
+// branch name is used so the form never opens with an empty title.
+func ExpandTitleTemplate(tmpl string, vars TemplateVars) string {
+	title := strings.Join(strings.Fields(ExpandTemplate(tmpl, vars)), " ")
+	title = strings.Trim(title, " -–:|/")
+	if title == "" {
+		return vars.Branch
+	}
+	return title
+}
+
+// CommitSubjectsForPR returns the summaries of the mutable commits reachable from commitIdx by
+// first parents (oldest first), i.e. the commits the PR will carry relative to trunk.
+func CommitSubjectsForPR(repo *internal.Repository, commitIdx int) []string {
+	if repo == nil || commitIdx < 0 || commitIdx >= len(repo.Graph.Commits) {
+		return nil
+	}
+	byID := make(map[string]int, len(repo.Graph.Commits)*2)
+	for i, c := range repo.Graph.Commits {
+		byID[c.ID] = i
+		byID[c.ChangeID] = i
+	}
+	var subjects []string
+	visited := make(map[int]bool)
+	idx := commitIdx
+	for len(subjects) < maxTemplateCommitSubjects && !visited[idx] {
+		visited[idx] = true
+		c := repo.Graph.Commits[idx]
+		if c.Immutable {
+			break
+		}
+		if s := strings.TrimSpace(c.Summary); s != "" && s != "(no description)" {
+			subjects = append(subjects, s)
+		}
+		if len(c.Parents) == 0 {
+			break
+		}
+		next, ok := byID[c.Parents[0]]
+		if !ok {
+			break
+		}
+		idx = next
+	}
+	for i, j := 0, len(subjects)-1; i < j; i, j = i+1, j-1 {
+		subjects[i], subjects[j] = subjects[j], subjects[i]
+	}
+	return subjects
+}
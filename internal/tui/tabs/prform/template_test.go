@@ -0,0 +1,72 @@
+package prform
+
+import (
+	"testing"
+
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/tui/tabs/bookmark"
+)
+
+func TestExpandTitleTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		vars TemplateVars
+		want string
+	}{
+		{"default with ticket", config.DefaultPRTitleTemplate, TemplateVars{TicketKey: "PROJ-1", TicketTitle: "Add auth", Branch: "add-auth"}, "PROJ-1 - Add auth"},
+		{"default without ticket falls back to branch", config.DefaultPRTitleTemplate, TemplateVars{Branch: "add-auth"}, "add-auth"},
+		{"branch placeholder", "[{ticket_key}] {branch}", TemplateVars{TicketKey: "X-9", Branch: "fix"}, "[X-9] fix"},
+		{"unknown placeholder kept", "{foo} {branch}", TemplateVars{Branch: "b"}, "{foo} b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandTitleTemplate(tt.tmpl, tt.vars); got != tt.want {
+				t.Errorf("ExpandTitleTemplate(%q) = %q, want %q", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareCreatePRTemplates(t *testing.T) {
+	repo := &internal.Repository{Graph: internal.CommitGraph{Commits: []internal.Commit{
+		{ID: "c2", ChangeID: "z2", Summary: "Wire login form", Parents: []string{"c1"}, Branches: []string{"add-auth"}},
+		{ID: "c1", ChangeID: "z1", Summary: "Add auth service", Parents: []string{"c0"}},
+		{ID: "c0", ChangeID: "z0", Summary: "trunk", Immutable: true},
+	}}}
+	refs := map[string]bookmark.TicketRef{"add-auth": {Key: "PROJ-7", Title: "Login"}}
+	cfg := &config.Config{PRBodyTemplate: "Ticket: {ticket_key}\n\n{commit_subjects}"}
+
+	res := PrepareCreatePR(repo, 0, refs, cfg)
+	if !res.Ok {
+		t.Fatal("expected Ok")
+	}
+	if res.DefaultTitle != "PROJ-7 - Login" {
+		t.Errorf("DefaultTitle = %q", res.DefaultTitle)
+	}
+	wantBody := "Ticket: PROJ-7\n\n- Add auth service\n- Wire login form"
+	if res.DefaultBody != wantBody {
+		t.Errorf("DefaultBody = %q, want %q", res.DefaultBody, wantBody)
+	}
+
+	if res := PrepareCreatePR(repo, 0, nil, nil); res.DefaultTitle != "add-auth" || res.DefaultBody != "" {
+		t.Errorf("nil config: title=%q body=%q", res.DefaultTitle, res.DefaultBody)
+	}
+}
+
+func TestInsertTicketDescription(t *testing.T) {
+	m := NewModel(nil)
+	m.Show(0, "main", "add-auth")
+	m.SetBody("Existing notes")
+	section := TicketSection(bookmark.TicketRef{ID: "10001", Key: "PROJ-7", Title: "Login"}, "- works\n")
+	if !m.InsertTicketDescription(section) {
+		t.Fatal("first insert should succeed")
+	}
+	if got, want := m.GetBody(), "Existing notes\n\n## PROJ-7: Login\n\n- works"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+	if m.InsertTicketDescription(section) {
+		t.Error("second insert should be skipped")
+	}
+}
@@ -3,35 +3,43 @@ package prs
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/forge"
 	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tui/avatar"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/tabs/confirm"
 	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
 // LoadPRsCmd returns a command that fetches PRs and sends PrsLoadedMsg, ReauthNeededMsg, or LoadErrorMsg.
 // existingPRsCount: when demoMode and > 0, send nil Prs to keep existing. githubInfo is used in error text.
-func LoadPRsCmd(ghSvc *github.Service, githubInfo string, demoMode bool, existingPRsCount int) tea.Cmd {
+func LoadPRsCmd(fg forge.Service, githubInfo string, demoMode bool, existingPRsCount int) tea.Cmd {
 	if demoMode {
 		if existingPRsCount > 0 {
 			return func() tea.Msg { return PrsLoadedMsg{Prs: nil} }
 		}
 		return func() tea.Msg { return PrsLoadedMsg{Prs: mock.DemoPullRequests()} }
 	}
-	if ghSvc == nil {
+	if fg == nil {
 		return func() tea.Msg { return PrsLoadedMsg{Prs: []internal.GitHubPR{}} }
 	}
-	svc := ghSvc
+	svc := fg
 	info := githubInfo
+	_, onGitHub := fg.(*github.Service)
 	return func() tea.Msg {
 		cfg, _ := config.Load()
-		filterOpts := github.PRFilterOptions{
+		filterOpts := forge.PRFilterOptions{
 			Limit:      100,
 			ShowMerged: true,
 			ShowClosed: true,
@@ -45,7 +53,7 @@ func LoadPRsCmd(ghSvc *github.Service, githubInfo string, demoMode bool, existin
 		}
 		prs, err := svc.GetPullRequestsWithOptions(context.Background(), filterOpts)
 		if err != nil {
-			if github.IsAuthError(err) {
+			if onGitHub && github.IsAuthError(err) {
 				cfg, _ := config.Load()
 				if cfg != nil && (cfg.UsedDeviceFlow() || cfg.UsedGhCLIAuth()) {
 					return ReauthNeededMsg{Reason: "Your GitHub authorization has expired. Please reauthorize to continue."}
@@ -65,11 +73,11 @@ func LoadPRsCmd(ghSvc *github.Service, githubInfo string, demoMode bool, existin
 // and sends OpenPRsResolvedMsg with the ones found. This guarantees the graph can detect an existing
 // PR for a local bookmark even when the bulk PR list omitted it (busy repos can have thousands of PRs,
 // so a newest-first capped fetch can push an older still-open PR out of the result).
-func ResolveOpenPRsForBookmarksCmd(ghSvc *github.Service, bookmarks []string, demoMode bool) tea.Cmd {
-	if ghSvc == nil || demoMode || len(bookmarks) == 0 {
+func ResolveOpenPRsForBookmarksCmd(fg forge.Service, bookmarks []string, demoMode bool) tea.Cmd {
+	if fg == nil || demoMode || len(bookmarks) == 0 {
 		return nil
 	}
-	svc := ghSvc
+	svc := fg
 	names := append([]string(nil), bookmarks...)
 	return func() tea.Msg {
 		ctx := context.Background()
@@ -105,44 +113,91 @@ func ResolveOpenPRsForBookmarksCmd(ghSvc *github.Service, bookmarks []string, de
 	}
 }
 
-// MergePRCmd returns a command that merges the PR and sends PrMergedMsg.
-func MergePRCmd(ghSvc *github.Service, prNumber int, demoMode bool) tea.Cmd {
+// ClosePRCmd returns a command that closes the PR and sends PrClosedMsg.
+func ClosePRCmd(fg forge.Service, prNumber int, demoMode bool) tea.Cmd {
 	if demoMode {
-		return func() tea.Msg { return PrMergedMsg{PRNumber: prNumber, Err: nil} }
+		return func() tea.Msg { return PrClosedMsg{PRNumber: prNumber, Err: nil} }
 	}
-	if ghSvc == nil {
+	if fg == nil {
 		return nil
 	}
-	svc := ghSvc
+	svc := fg
 	return func() tea.Msg {
-		err := svc.MergePullRequest(context.Background(), prNumber)
-		return PrMergedMsg{PRNumber: prNumber, Err: err}
+		err := svc.ClosePullRequest(context.Background(), prNumber)
+		return PrClosedMsg{PRNumber: prNumber, Err: err}
 	}
 }
 
-// ClosePRCmd returns a command that closes the PR and sends PrClosedMsg.
-func ClosePRCmd(ghSvc *github.Service, prNumber int, demoMode bool) tea.Cmd {
+// LoadDeploymentsCmd fetches the latest deployment per environment for each ref and sends
+// DeploymentsLoadedMsg. Refs are queried in order; the first failure aborts the batch.
+func LoadDeploymentsCmd(ghSvc *github.Service, refs []string, demoMode bool) tea.Cmd {
 	if demoMode {
-		return func() tea.Msg { return PrClosedMsg{PRNumber: prNumber, Err: nil} }
+		return func() tea.Msg {
+			out := make(map[string][]internal.Deployment, len(refs))
+			for _, ref := range refs {
+				out[ref] = mock.DemoDeployments(ref)
+			}
+			return DeploymentsLoadedMsg{Deployments: out}
+		}
 	}
-	if ghSvc == nil {
+	if ghSvc == nil || len(refs) == 0 {
 		return nil
 	}
 	svc := ghSvc
+	names := append([]string(nil), refs...)
 	return func() tea.Msg {
-		err := svc.ClosePullRequest(context.Background(), prNumber)
-		return PrClosedMsg{PRNumber: prNumber, Err: err}
+		ctx := context.Background()
+		out := make(map[string][]internal.Deployment, len(names))
+		for _, ref := range names {
+			deps, err := svc.GetDeployments(ctx, ref)
+			if err != nil {
+				return DeploymentsLoadedMsg{Err: err}
+			}
+			out[ref] = deps
+		}
+		return DeploymentsLoadedMsg{Deployments: out}
 	}
 }
 
-// PrTickCmd returns a command that sends PrTickMsg after the configured PR refresh interval, or nil if disabled.
+// LoadAvatarsCmd downloads the authors' avatars for the details box; nil when inline images are off
+// or in low-bandwidth mode.
+func LoadAvatarsCmd(prs []internal.GitHubPR) tea.Cmd {
+	if !avatar.Enabled() {
+		return nil
+	}
+	if cfg, _ := config.Load(); cfg.LowBandwidthMode() {
+		return nil
+	}
+	var urls []string
+	for _, pr := range prs {
+		if u := avatarURL(pr); u != "" && !slices.Contains(urls, u) {
+			urls = append(urls, u)
+		}
+	}
+	return avatar.FetchCmd(urls...)
+}
+
+// avatarURL asks GitHub for a small rendition of the author's avatar (the default is 460px).
+func avatarURL(pr internal.GitHubPR) string {
+	u, err := url.Parse(pr.AuthorAvatar)
+	if pr.AuthorAvatar == "" || err != nil {
+		return ""
+	}
+	q := u.Query()
+	q.Set("s", "40")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// PrTickCmd returns a command that sends PrTickMsg after the configured PR refresh interval, or nil if
+// disabled (including in low-bandwidth mode).
 func PrTickCmd() tea.Cmd {
 	cfg, _ := config.Load()
 	interval := 120
 	if cfg != nil {
 		interval = cfg.PRRefreshInterval()
 	}
-	if interval <= 0 {
+	if interval <= 0 || cfg.LowBandwidthMode() {
 		return nil
 	}
 	return tea.Tick(time.Duration(interval)*time.Second, func(t time.Time) tea.Msg {
@@ -175,6 +230,9 @@ func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.C
 	if ctx == nil {
 		return "", nil
 	}
+	if r.PushChange || r.RefreshChanges {
+		return executePushRequest(r, ctx)
+	}
 	if !ctx.GitHubOK {
 		return "GitHub service not initialized", nil
 	}
@@ -199,13 +257,112 @@ func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.C
 		if pr.State != "open" {
 			return "Can only merge open PRs", nil
 		}
-		return fmt.Sprintf("Merging PR #%d...", pr.Number), MergePRCmd(ctx.GitHubService, pr.Number, ctx.DemoMode)
+		if !ctx.Permissions.Can(github.CapMergePR) {
+			return "Can't merge: " + ctx.Permissions.Reason(github.CapMergePR), nil
+		}
+		return fmt.Sprintf("Merge PR #%d: pick a method", pr.Number), OpenMergeFormCmd(*pr, ctx.MergeMethod)
+	}
+	if r.Merge != nil {
+		if pr.State != "open" {
+			return "Can only merge open PRs", nil
+		}
+		label := strings.ToLower(mergeMethodLabels[r.Merge.Options.Method])
+		if r.Merge.AutoMerge {
+			return fmt.Sprintf("Enabling auto-merge (%s) for PR #%d...", label, r.Merge.PRNumber), MergePRCmd(ctx.Forge, *r.Merge, ctx.DemoMode)
+		}
+		return fmt.Sprintf("Merging PR #%d (%s)...", r.Merge.PRNumber, label), MergePRCmd(ctx.Forge, *r.Merge, ctx.DemoMode)
 	}
 	if r.ClosePR {
 		if pr.State != "open" {
 			return "Can only close open PRs", nil
 		}
-		return fmt.Sprintf("Closing PR #%d...", pr.Number), ClosePRCmd(ctx.GitHubService, pr.Number, ctx.DemoMode)
+		if !ctx.Permissions.Can(github.CapClosePR) {
+			return "Can't close: " + ctx.Permissions.Reason(github.CapClosePR), nil
+		}
+		// Main shows the confirmation (or skips it per confirm_actions) and then closes the PR.
+		return "", confirm.Ask(confirm.Prompt{
+			Action:    "close_pr",
+			Title:     "Close pull request",
+			Message:   fmt.Sprintf("Close PR #%d %q without merging? It can be reopened on the forge.", pr.Number, pr.Title),
+			Status:    fmt.Sprintf("Closing PR #%d...", pr.Number),
+			OnConfirm: ClosePRCmd(ctx.Forge, pr.Number, ctx.DemoMode),
+		})
+	}
+	if r.ToggleChecklist != nil {
+		if ctx.GitHubService == nil && !ctx.DemoMode {
+			return "Editing the PR body needs GitHub", nil
+		}
+		return fmt.Sprintf("Updating checklist of PR #%d...", pr.Number), ToggleChecklistItemCmd(ctx.GitHubService, *pr, r.ToggleChecklist.Index, r.ToggleChecklist.Text, ctx.DemoMode)
+	}
+	if r.LoadDeployments {
+		refs := deploymentRefs(*pr)
+		if len(refs) == 0 {
+			return "", nil
+		}
+		return fmt.Sprintf("Loading deployments for PR #%d...", pr.Number), LoadDeploymentsCmd(ctx.GitHubService, refs, ctx.DemoMode)
+	}
+	if r.ReplyToReviewComment != nil {
+		return fmt.Sprintf("Replying on PR #%d...", r.ReplyToReviewComment.PRNumber), ReplyToReviewCommentCmd(ctx.GitHubService, *r.ReplyToReviewComment, ctx.DemoMode)
+	}
+	if r.SubmitReview != nil {
+		if pr.State != "open" {
+			return "Can only review open PRs", nil
+		}
+		return fmt.Sprintf("Submitting review on PR #%d...", r.SubmitReview.PRNumber), SubmitReviewCmd(ctx.Forge, *r.SubmitReview, ctx.DemoMode)
+	}
+	if r.LoadDiff {
+		return fmt.Sprintf("Loading diff of PR #%d...", pr.Number), LoadPRDiffCmd(ctx.GitHubService, ctx.JJService, *pr, ctx.DemoMode)
+	}
+	if r.LoadDetail {
+		return fmt.Sprintf("Loading details for PR #%d...", pr.Number), LoadPRDetailCmd(ctx.GitHubService, pr.Number, ctx.DemoMode)
+	}
+	if r.LoadReviewComments {
+		return fmt.Sprintf("Loading review comments for PR #%d...", pr.Number), LoadReviewCommentsCmd(ctx.GitHubService, pr.Number, ctx.DemoMode)
 	}
 	return "", nil
 }
+
+// deploymentRefs returns the refs whose deployments are shown for pr: its head commit, then its
+// base branch (usually trunk) so "is this on staging yet" can be answered for merged PRs too.
+// The head is queried by SHA because a branch name also matches deployments of commits the
+// branch has since moved away from (or of an older branch with the same name); the branch name
+// is only used when the forge didn't report the head SHA.
+func deploymentRefs(pr internal.GitHubPR) []string {
+	head := pr.HeadSHA
+	if head == "" {
+		head = pr.HeadBranch
+	}
+	var refs []string
+	for _, ref := range []string{head, pr.BaseBranch} {
+		if ref != "" && !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// PagerTarget opens pr's title, state, branches, and full body in the pager.
+func PagerTarget(pr internal.GitHubPR) state.NavigateTarget {
+	meta := []string{pr.State}
+	if pr.IsDraft {
+		meta = append(meta, "draft")
+	}
+	if pr.HeadBranch != "" && pr.BaseBranch != "" {
+		meta = append(meta, pr.HeadBranch+" → "+pr.BaseBranch)
+	}
+	body := strings.TrimSpace(pr.Body)
+	if body == "" {
+		body = "(no description)"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n%s\n", pr.Title, strings.Join(meta, " · "))
+	if pr.URL != "" {
+		b.WriteString(pr.URL + "\n")
+	}
+	b.WriteString("\n" + body)
+	return state.NavigateTarget{
+		Kind:         state.NavigateOpenPager,
+		PagerTitle:   fmt.Sprintf("PR #%d: %s", pr.Number, pr.Title),
+		PagerContent: b.String(),
+	}
+}
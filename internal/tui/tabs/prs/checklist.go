@@ -0,0 +1,188 @@
+package prs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// checklistItemRe matches a markdown task list item: "- [ ] text", "* [x] text", "1. [X] text".
+var checklistItemRe = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*)$`)
+
+// checklistItem is a task list item in a PR body.
+type checklistItem struct {
+	Line int // index of the line in the body
+	Text string
+	Done bool
+}
+
+// parseChecklist returns the task list items in body, skipping fenced code blocks.
+func parseChecklist(body string) []checklistItem {
+	var items []checklistItem
+	inCode := false
+	for i, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		if m := checklistItemRe.FindStringSubmatch(line); m != nil {
+			items = append(items, checklistItem{Line: i, Text: strings.TrimSpace(m[4]), Done: m[2] != " "})
+		}
+	}
+	return items
+}
+
+// checklistProgress counts the done and total task list items in body.
+func checklistProgress(body string) (done, total int) {
+	for _, it := range parseChecklist(body) {
+		total++
+		if it.Done {
+			done++
+		}
+	}
+	return done, total
+}
+
+// toggleChecklistItem flips item idx of body's task list. It returns false when the body no longer
+// has that item with the same text (e.g. it was edited since it was loaded).
+func toggleChecklistItem(body string, idx int, text string) (string, bool) {
+	items := parseChecklist(body)
+	if idx < 0 || idx >= len(items) || items[idx].Text != text {
+		return body, false
+	}
+	lines := strings.Split(body, "\n")
+	check := "x"
+	if items[idx].Done {
+		check = " "
+	}
+	lines[items[idx].Line] = checklistItemRe.ReplaceAllString(lines[items[idx].Line], "${1}"+check+"${3}${4}")
+	return strings.Join(lines, "\n"), true
+}
+
+// checklistBadge renders "☑ 3/5" for a PR list row ("" when the body has no task list).
+func checklistBadge(body string) string {
+	done, total := checklistProgress(body)
+	if total == 0 {
+		return ""
+	}
+	color := styles.ColorPending
+	if done == total {
+		color = styles.ColorSuccess
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(fmt.Sprintf("☑ %d/%d", done, total))
+}
+
+// ChecklistToggledMsg is sent when ToggleChecklistItemCmd finishes; Body is the PR's new body.
+type ChecklistToggledMsg struct {
+	state.RepoResult
+	PRNumber int
+	Body     string
+	Err      error
+}
+
+// ToggleChecklistItemCmd flips a task list item in PR prNumber's body. It re-reads the body first so
+// edits made on GitHub since the list loaded are kept, and refuses when the item changed.
+func ToggleChecklistItemCmd(ghSvc *github.Service, pr internal.GitHubPR, idx int, text string, demoMode bool) tea.Cmd {
+	if demoMode {
+		return func() tea.Msg {
+			body, ok := toggleChecklistItem(pr.Body, idx, text)
+			if !ok {
+				return ChecklistToggledMsg{PRNumber: pr.Number, Err: fmt.Errorf("checklist item %q not found", text)}
+			}
+			return ChecklistToggledMsg{PRNumber: pr.Number, Body: body}
+		}
+	}
+	if ghSvc == nil {
+		return nil
+	}
+	svc := ghSvc
+	return func() tea.Msg {
+		ctx := context.Background()
+		current, err := svc.GetPullRequest(ctx, pr.Number)
+		if err != nil {
+			return ChecklistToggledMsg{PRNumber: pr.Number, Err: err}
+		}
+		body, ok := toggleChecklistItem(current.Body, idx, text)
+		if !ok {
+			return ChecklistToggledMsg{PRNumber: pr.Number, Err: fmt.Errorf("checklist item %q changed on GitHub; reload the PR and try again", text)}
+		}
+		if _, err := svc.UpdatePullRequest(ctx, pr.Number, &internal.UpdatePRRequest{Body: body}); err != nil {
+			return ChecklistToggledMsg{PRNumber: pr.Number, Err: err}
+		}
+		return ChecklistToggledMsg{PRNumber: pr.Number, Body: body}
+	}
+}
+
+// applyChecklistToggled stores a toggled body on the PR and returns the status line.
+func (m *Model) applyChecklistToggled(msg ChecklistToggledMsg) string {
+	if msg.Err != nil {
+		return fmt.Sprintf("Failed to update checklist of PR #%d: %v", msg.PRNumber, msg.Err)
+	}
+	if m.repository != nil {
+		for i := range m.repository.PRs {
+			if m.repository.PRs[i].Number == msg.PRNumber {
+				m.repository.PRs[i].Body = msg.Body
+			}
+		}
+	}
+	done, total := checklistProgress(msg.Body)
+	return fmt.Sprintf("PR #%d checklist: %d/%d done", msg.PRNumber, done, total)
+}
+
+// ChecklistToggle names the task list item to flip: its index in the body's task list and its text,
+// which must still match when the body is re-read.
+type ChecklistToggle struct {
+	Index int
+	Text  string
+}
+
+// selectedChecklistToggle returns the item under the detail view's checklist cursor, or nil.
+func (m *Model) selectedChecklistToggle() *ChecklistToggle {
+	pr := m.selectedPRData()
+	if pr == nil {
+		return nil
+	}
+	items := parseChecklist(pr.Body)
+	if m.checklistCursor < 0 || m.checklistCursor >= len(items) {
+		return nil
+	}
+	return &ChecklistToggle{Index: m.checklistCursor, Text: items[m.checklistCursor].Text}
+}
+
+// renderChecklist renders the detail view's checklist section with the cursor on m.checklistCursor.
+func (m *Model) renderChecklist(body string) []string {
+	items := parseChecklist(body)
+	if len(items) == 0 {
+		return nil
+	}
+	done, _ := checklistProgress(body)
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := []string{"", lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Checklist (%d/%d)", done, len(items))) + muted.Render(" · c next item · x toggle")}
+	for i, it := range items {
+		box := "[ ]"
+		text := it.Text
+		if it.Done {
+			box = lipgloss.NewStyle().Foreground(styles.ColorSuccess).Render("[x]")
+			text = muted.Render(text)
+		}
+		prefix := "  "
+		if i == m.checklistCursor {
+			prefix = "► "
+		}
+		lines = append(lines, mark(m.zoneManager, mouse.ZonePRChecklistItem(i), prefix+box+" "+text))
+	}
+	return lines
+}
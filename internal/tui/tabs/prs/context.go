@@ -3,7 +3,9 @@ package prs
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/forge"
 	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
@@ -14,6 +16,7 @@ type ContextProvider interface {
 	IsGitHubAvailable() bool
 	IsDemoMode() bool
 	GetGitHubService() *github.Service
+	GetForge() forge.Service
 	GetGitHubInfo() string
 }
 
@@ -22,14 +25,23 @@ func BuildRequestContextFromApp(app *state.AppState, m *Model) *RequestContext {
 	if app == nil || m == nil {
 		return nil
 	}
-	githubOK := app.GitHubService != nil
+	githubOK := app.Forge() != nil
+	var push *PushContext
+	if m.push != nil {
+		push = &PushContext{Mode: m.push.mode, Remote: m.push.remote, GerritBranch: m.push.gerritBranch, Change: m.selectedChange()}
+	}
 	return BuildRequestContext(&ContextInput{
 		Repository:    app.Repository,
 		SelectedPR:    m.GetSelectedPR(),
 		GitHubOK:      githubOK,
 		DemoMode:      app.DemoMode,
 		GitHubService: app.GitHubService,
+		Forge:         app.Forge(),
 		GitHubInfo:    app.GithubInfo,
+		Permissions:   app.GitHubPermissions,
+		JJService:     app.JJService,
+		MergeMethod:   app.Config.PRMergeMethodOrDefault(),
+		Push:          push,
 	})
 }
 
@@ -44,6 +56,7 @@ func BuildRequestContextFrom(p ContextProvider) *RequestContext {
 		GitHubOK:      p.IsGitHubAvailable(),
 		DemoMode:      p.IsDemoMode(),
 		GitHubService: p.GetGitHubService(),
+		Forge:         p.GetForge(),
 		GitHubInfo:    p.GetGitHubInfo(),
 	})
 }
@@ -52,7 +65,7 @@ func BuildRequestContextFrom(p ContextProvider) *RequestContext {
 type EnterTabProvider interface {
 	GetRepository() *internal.Repository
 	IsGitHubAvailable() bool
-	GetGitHubService() *github.Service
+	GetForge() forge.Service
 	GetGitHubInfo() string
 	IsDemoMode() bool
 }
@@ -67,7 +80,7 @@ func EnterTab(p EnterTabProvider) (status string, cmd tea.Cmd) {
 	if p.GetRepository() != nil {
 		existing = len(p.GetRepository().PRs)
 	}
-	return status, LoadPRsCmd(p.GetGitHubService(), p.GetGitHubInfo(), p.IsDemoMode(), existing)
+	return status, LoadPRsCmd(p.GetForge(), p.GetGitHubInfo(), p.IsDemoMode(), existing)
 }
 
 // RequestContext is passed from the main model so the PRs tab can validate
@@ -77,8 +90,21 @@ type RequestContext struct {
 	SelectedPR    int
 	GitHubOK      bool // whether GitHub service is available
 	DemoMode      bool
-	GitHubService *github.Service
+	GitHubService *github.Service // GitHub-only features (deployments, details, review comments)
+	Forge         forge.Service   // listing, merging, closing and reviewing; GitHub or GitLab
 	GitHubInfo    string
+	Permissions   *github.Permissions // nil allows everything
+	JJService     *jj.Service         // local fallback for PR diffs
+	MergeMethod   string              // merge method the merge form starts on
+	Push          *PushContext        // set on the Push tab (push_mode git or gerrit)
+}
+
+// PushContext is what the Push tab needs to push a change.
+type PushContext struct {
+	Mode         string // config.PushModeGit or config.PushModeGerrit
+	Remote       string
+	GerritBranch string
+	Change       *jj.OutgoingChange // selected change; nil when none
 }
 
 // ContextInput is the data needed to build a RequestContext. Main passes this from its state.
@@ -88,7 +114,12 @@ type ContextInput struct {
 	GitHubOK      bool
 	DemoMode      bool
 	GitHubService *github.Service
+	Forge         forge.Service
 	GitHubInfo    string
+	Permissions   *github.Permissions
+	JJService     *jj.Service
+	MergeMethod   string
+	Push          *PushContext
 }
 
 // BuildRequestContext builds RequestContext from input. The PRs tab owns what context it needs.
@@ -102,7 +133,12 @@ func BuildRequestContext(input *ContextInput) *RequestContext {
 		GitHubOK:      input.GitHubOK,
 		DemoMode:      input.DemoMode,
 		GitHubService: input.GitHubService,
+		Forge:         input.Forge,
 		GitHubInfo:    input.GitHubInfo,
+		Permissions:   input.Permissions,
+		JJService:     input.JJService,
+		MergeMethod:   input.MergeMethod,
+		Push:          input.Push,
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/tui/longpress"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/styles"
@@ -34,13 +35,15 @@ type prContextMenuItem struct {
 	Request Request
 	// OpenOnly: only shown when the PR is in "open" state.
 	OpenOnly bool
+	// Needs lists the token permissions the action requires; it is greyed out when any is denied.
+	Needs []github.Capability
 }
 
 func prContextMenuItems() []prContextMenuItem {
 	return []prContextMenuItem{
 		{Label: "Open in Browser", Key: "o", Request: Request{OpenInBrowser: true}},
-		{Label: "Merge", Key: "M", Request: Request{MergePR: true}, OpenOnly: true},
-		{Label: "Close", Key: "X", Request: Request{ClosePR: true}, OpenOnly: true},
+		{Label: "Merge", Key: "M", Request: Request{MergePR: true}, OpenOnly: true, Needs: []github.Capability{github.CapMergePR}},
+		{Label: "Close", Key: "X", Request: Request{ClosePR: true}, OpenOnly: true, Needs: []github.Capability{github.CapClosePR}},
 	}
 }
 
@@ -53,12 +56,12 @@ func (m *Model) renderContextMenu(prIsOpen bool) string {
 		Padding(0, 1)
 
 	itemStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2"))
+		Foreground(styles.ColorText)
 	hoverStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	hoverKeyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#CCCCCC")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	disabledStyle := lipgloss.NewStyle().
 		Foreground(styles.ColorMuted)
@@ -75,7 +78,7 @@ func (m *Model) renderContextMenu(prIsOpen bool) string {
 	for _, item := range items {
 		i := zoneIdx
 		zoneIdx++
-		if item.OpenOnly && !prIsOpen {
+		if (item.OpenOnly && !prIsOpen) || !m.canAll(item.Needs) {
 			row := disabledStyle.Render(fmt.Sprintf("  %s  %s", item.Label, item.Key))
 			rows = append(rows, row)
 			continue
@@ -167,3 +170,13 @@ func (m *Model) handleLongPress(msg tea.MouseMsg) tea.Cmd {
 	}
 	return nil
 }
+
+// canAll reports whether the token allows every capability in caps.
+func (m *Model) canAll(caps []github.Capability) bool {
+	for _, c := range caps {
+		if !m.permissions.Can(c) {
+			return false
+		}
+	}
+	return true
+}
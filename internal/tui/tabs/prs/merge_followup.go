@@ -0,0 +1,140 @@
+package prs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// MergeFollowUp is the pr_merge_follow_up sequence to run after PRNumber merged. Main fills it in
+// from the graph (the head commit and whether anything is stacked on it) and the linked ticket.
+type MergeFollowUp struct {
+	PRNumber      int
+	Steps         []string // config.MergeFollowUp*, in order
+	Bookmark      string   // the PR's head bookmark
+	Remote        string
+	HeadCommitID  string // commit the bookmark was on before the merge ("" = not in the graph)
+	HasDependents bool   // mutable commits are stacked on HeadCommitID
+	TicketKey     string // linked ticket ("" = none)
+	TicketLabel   string // display key for the status line
+	TicketStatus  string // status to move the ticket to
+}
+
+// MergeFollowUpDoneMsg is sent when MergeFollowUpCmd finishes. Done describes each step that ran
+// or was skipped; FailedStep and Err are set when a step failed and the rest did not run.
+type MergeFollowUpDoneMsg struct {
+	state.RepoResult
+	PRNumber   int
+	Done       []string
+	FailedStep string
+	Err        error
+}
+
+// Status is the status line after the follow-up.
+func (msg MergeFollowUpDoneMsg) Status() string {
+	done := strings.Join(msg.Done, " · ")
+	if msg.Err != nil {
+		if done != "" {
+			done += " · "
+		}
+		return fmt.Sprintf("PR #%d follow-up: %sstopped at %s: %v", msg.PRNumber, done, msg.FailedStep, msg.Err)
+	}
+	return fmt.Sprintf("PR #%d follow-up: %s", msg.PRNumber, done)
+}
+
+// MergeFollowUpCmd runs the follow-up steps in order, stopping at the first failure.
+func MergeFollowUpCmd(jjSvc *jj.Service, ticketSvc tickets.Service, f MergeFollowUp, demoMode bool) tea.Cmd {
+	if len(f.Steps) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		done := MergeFollowUpDoneMsg{PRNumber: f.PRNumber}
+		for _, step := range f.Steps {
+			var note string
+			var err error
+			if demoMode {
+				note = step + " (demo)"
+			} else {
+				note, err = runMergeFollowUpStep(ctx, jjSvc, ticketSvc, f, step)
+			}
+			if err != nil {
+				done.FailedStep, done.Err = step, err
+				return done
+			}
+			done.Done = append(done.Done, note)
+		}
+		return done
+	}
+}
+
+// runMergeFollowUpStep runs one step and describes what it did.
+func runMergeFollowUpStep(ctx context.Context, jjSvc *jj.Service, ticketSvc tickets.Service, f MergeFollowUp, step string) (string, error) {
+	switch step {
+	case config.MergeFollowUpFetch:
+		if err := jjSvc.FetchFromRemote(ctx, f.Remote); err != nil {
+			return "", err
+		}
+		return "fetched " + f.Remote, nil
+	case config.MergeFollowUpDeleteBookmark:
+		if f.Bookmark == "" {
+			return "no bookmark to delete", nil
+		}
+		deleted, err := jjSvc.DeleteMergedBookmark(ctx, f.Bookmark, f.Remote)
+		if err != nil {
+			return "", err
+		}
+		if !deleted {
+			return f.Bookmark + " already deleted", nil
+		}
+		return "deleted " + f.Bookmark, nil
+	case config.MergeFollowUpRebaseStack:
+		if f.HeadCommitID == "" || !f.HasDependents {
+			return "nothing stacked on the PR", nil
+		}
+		if err := jjSvc.RebaseDependentsOnto(ctx, f.HeadCommitID, "trunk()"); err != nil {
+			return "", err
+		}
+		return "rebased the stack onto trunk", nil
+	case config.MergeFollowUpTransitionTicket:
+		if f.TicketKey == "" || ticketSvc == nil {
+			return "no linked ticket", nil
+		}
+		transitions, err := ticketSvc.GetAvailableTransitions(ctx, f.TicketKey)
+		if err != nil {
+			return "", err
+		}
+		id := matchTransition(transitions, f.TicketStatus)
+		if id == "" {
+			return fmt.Sprintf("%s has no %q transition", f.TicketLabel, f.TicketStatus), nil
+		}
+		if err := ticketSvc.TransitionTicket(ctx, f.TicketKey, id); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("moved %s to %s", f.TicketLabel, f.TicketStatus), nil
+	}
+	return step + " skipped", nil
+}
+
+// matchTransition returns the ID of the transition named status (ignoring case), else of the
+// first one whose name contains it.
+func matchTransition(transitions []tickets.Transition, status string) string {
+	want := strings.ToLower(status)
+	for _, t := range transitions {
+		if strings.ToLower(t.Name) == want {
+			return t.ID
+		}
+	}
+	for _, t := range transitions {
+		if strings.Contains(strings.ToLower(t.Name), want) {
+			return t.ID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,406 @@
+package prs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/forge"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// mergeMethodLabels are the merge form's labels for github.MergeMethods.
+var mergeMethodLabels = map[string]string{
+	github.MergeMethodSquash: "Squash",
+	github.MergeMethodRebase: "Rebase",
+	github.MergeMethodMerge:  "Merge commit",
+}
+
+// Merge form fields, in the order Tab moves through them.
+const (
+	mergeFocusMethod = iota
+	mergeFocusTitle
+	mergeFocusMessage
+	mergeFocusAuto
+	mergeFocusFollowUp
+	mergeFocusCount
+)
+
+// MergeSubmission is a request to merge a PR, or to let GitHub merge it once checks pass.
+// FollowUp runs the pr_merge_follow_up steps for HeadBranch after a merge.
+type MergeSubmission struct {
+	PRNumber   int
+	Options    github.MergeOptions
+	AutoMerge  bool
+	FollowUp   bool
+	HeadBranch string
+}
+
+// MergeFormRequestedMsg opens the merge form for PR, starting on Method.
+type MergeFormRequestedMsg struct {
+	PR     internal.GitHubPR
+	Method string
+}
+
+// OpenMergeFormCmd sends MergeFormRequestedMsg.
+func OpenMergeFormCmd(pr internal.GitHubPR, method string) tea.Cmd {
+	return func() tea.Msg { return MergeFormRequestedMsg{PR: pr, Method: method} }
+}
+
+// MergePRCmd merges the PR (or enables auto-merge) and sends PrMergedMsg.
+func MergePRCmd(fg forge.Service, sub MergeSubmission, demoMode bool) tea.Cmd {
+	done := PrMergedMsg{PRNumber: sub.PRNumber, Method: sub.Options.Method, AutoMerge: sub.AutoMerge, FollowUp: sub.FollowUp, HeadBranch: sub.HeadBranch}
+	if demoMode {
+		return func() tea.Msg { return done }
+	}
+	if fg == nil {
+		return nil
+	}
+	svc := fg
+	return func() tea.Msg {
+		if sub.AutoMerge {
+			auto, ok := svc.(forge.AutoMerger)
+			if !ok {
+				done.Err = fmt.Errorf("%s doesn't support auto-merge", svc.Name())
+				return done
+			}
+			done.Err = auto.EnableAutoMerge(context.Background(), sub.PRNumber, sub.Options)
+		} else {
+			done.Err = svc.MergePullRequest(context.Background(), sub.PRNumber, sub.Options)
+		}
+		return done
+	}
+}
+
+// mergeFormState is the open merge form (M), which replaces the PR list.
+type mergeFormState struct {
+	pr            internal.GitHubPR
+	method        int // index into github.MergeMethods
+	focus         int
+	title         textinput.Model
+	message       textarea.Model
+	autoMerge     bool
+	followUp      bool // run followUpSteps after merging
+	followUpSteps []string
+	err           string
+	submitting    bool
+}
+
+// mergeDefaults returns the commit title and message GitHub would suggest for method; an empty
+// title keeps GitHub's own default.
+func mergeDefaults(pr internal.GitHubPR, method string) (title, message string) {
+	switch method {
+	case github.MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", pr.Title, pr.Number), pr.Body
+	case github.MergeMethodMerge:
+		return "", pr.Title
+	}
+	return "", ""
+}
+
+// openMergeForm opens the merge form for pr with method preselected.
+func (m *Model) openMergeForm(pr internal.GitHubPR, method string) {
+	st := &mergeFormState{pr: pr, followUpSteps: m.mergeFollowUp, followUp: len(m.mergeFollowUp) > 0}
+	for i, mm := range github.MergeMethods {
+		if mm == method {
+			st.method = i
+		}
+	}
+	st.title = textinput.New()
+	st.title.Placeholder = "GitHub's default title"
+	st.title.CharLimit = 0
+	st.title.Width = max(m.width-20, 30)
+	st.message = textarea.New()
+	st.message.Placeholder = "GitHub's default message"
+	st.message.ShowLineNumbers = false
+	st.message.CharLimit = 0
+	st.message.SetWidth(max(m.width-6, 40))
+	st.message.SetHeight(5)
+	title, msg := mergeDefaults(pr, st.methodName())
+	st.title.SetValue(title)
+	st.message.SetValue(msg)
+	m.mergeForm = st
+	m.listYOffset = 0
+}
+
+func (st *mergeFormState) methodName() string {
+	return github.MergeMethods[st.method]
+}
+
+// setMethod switches the merge method, refilling the commit fields unless they were edited.
+func (st *mergeFormState) setMethod(i int) {
+	if i == st.method {
+		return
+	}
+	title, msg := mergeDefaults(st.pr, st.methodName())
+	untouched := st.title.Value() == title && st.message.Value() == msg
+	st.method = i
+	if untouched {
+		title, msg = mergeDefaults(st.pr, st.methodName())
+		st.title.SetValue(title)
+		st.message.SetValue(msg)
+	}
+}
+
+// setFocus moves the keyboard focus to field f.
+func (st *mergeFormState) setFocus(f int) tea.Cmd {
+	st.focus = f
+	st.title.Blur()
+	st.message.Blur()
+	switch f {
+	case mergeFocusTitle:
+		return st.title.Focus()
+	case mergeFocusMessage:
+		return st.message.Focus()
+	}
+	return nil
+}
+
+// moveFocus moves focus by delta fields, skipping the commit fields when rebasing and the
+// follow-up when none is configured.
+func (st *mergeFormState) moveFocus(delta int) tea.Cmd {
+	f := st.focus
+	for {
+		f = (f + delta + mergeFocusCount) % mergeFocusCount
+		if st.methodName() == github.MergeMethodRebase && (f == mergeFocusTitle || f == mergeFocusMessage) {
+			continue
+		}
+		if f == mergeFocusFollowUp && len(st.followUpSteps) == 0 {
+			continue
+		}
+		return st.setFocus(f)
+	}
+}
+
+// submitMergeForm returns the request for the current form, or nil while a merge is in flight.
+func (m *Model) submitMergeForm() *Request {
+	st := m.mergeForm
+	if st.submitting {
+		return nil
+	}
+	st.err = ""
+	st.submitting = true
+	sub := MergeSubmission{
+		PRNumber:   st.pr.Number,
+		AutoMerge:  st.autoMerge,
+		Options:    github.MergeOptions{Method: st.methodName()},
+		FollowUp:   st.followUp && len(st.followUpSteps) > 0 && !st.autoMerge,
+		HeadBranch: st.pr.HeadBranch,
+	}
+	if st.methodName() != github.MergeMethodRebase {
+		sub.Options.CommitTitle = strings.TrimSpace(st.title.Value())
+		sub.Options.CommitMessage = strings.TrimSpace(st.message.Value())
+	}
+	return &Request{Merge: &sub}
+}
+
+// handleMergeFormKey handles keys while the merge form is open: Tab and Shift+Tab move between
+// fields, ←/→ pick the method, Space toggles auto-merge, Ctrl+S merges and Esc cancels.
+func (m Model) handleMergeFormKey(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	st := m.mergeForm
+	switch msg.String() {
+	case "esc":
+		m.mergeForm = nil
+		return m, nil, nil
+	case "tab":
+		return m, nil, st.moveFocus(1)
+	case "shift+tab":
+		return m, nil, st.moveFocus(-1)
+	case "ctrl+s":
+		return m, m.submitMergeForm(), nil
+	}
+	if st.submitting {
+		return m, nil, nil
+	}
+	var cmd tea.Cmd
+	switch st.focus {
+	case mergeFocusMethod:
+		switch msg.String() {
+		case "left", "h":
+			st.setMethod((st.method + len(github.MergeMethods) - 1) % len(github.MergeMethods))
+		case "right", "l", " ":
+			st.setMethod((st.method + 1) % len(github.MergeMethods))
+		case "enter":
+			return m, m.submitMergeForm(), nil
+		}
+	case mergeFocusTitle:
+		if msg.String() == "enter" {
+			return m, nil, st.moveFocus(1)
+		}
+		st.title, cmd = st.title.Update(msg)
+	case mergeFocusMessage:
+		st.message, cmd = st.message.Update(msg)
+	case mergeFocusAuto:
+		switch msg.String() {
+		case " ", "x":
+			st.autoMerge = !st.autoMerge
+		case "enter":
+			return m, m.submitMergeForm(), nil
+		}
+	case mergeFocusFollowUp:
+		switch msg.String() {
+		case " ", "x":
+			st.followUp = !st.followUp
+		case "enter":
+			return m, m.submitMergeForm(), nil
+		}
+	}
+	return m, nil, cmd
+}
+
+// handleMergeFormClick handles clicks on the merge form's methods, fields and buttons.
+func (m Model) handleMergeFormClick(z *zone.ZoneInfo) (Model, *Request, tea.Cmd) {
+	st := m.mergeForm
+	if m.zoneManager == nil || z == nil {
+		return m, nil, nil
+	}
+	for i := range github.MergeMethods {
+		if m.zoneManager.Get(mouse.ZonePRMergeMethod(i)) == z {
+			st.setMethod(i)
+			return m, nil, st.setFocus(mergeFocusMethod)
+		}
+	}
+	switch z {
+	case m.zoneManager.Get(mouse.ZonePRMergeTitle):
+		if st.methodName() != github.MergeMethodRebase {
+			return m, nil, st.setFocus(mergeFocusTitle)
+		}
+	case m.zoneManager.Get(mouse.ZonePRMergeMessage):
+		if st.methodName() != github.MergeMethodRebase {
+			return m, nil, st.setFocus(mergeFocusMessage)
+		}
+	case m.zoneManager.Get(mouse.ZonePRMergeAuto):
+		st.autoMerge = !st.autoMerge
+		return m, nil, st.setFocus(mergeFocusAuto)
+	case m.zoneManager.Get(mouse.ZonePRMergeFollow):
+		if len(st.followUpSteps) > 0 {
+			st.followUp = !st.followUp
+			return m, nil, st.setFocus(mergeFocusFollowUp)
+		}
+	case m.zoneManager.Get(mouse.ZonePRMergeSubmit):
+		return m, m.submitMergeForm(), nil
+	case m.zoneManager.Get(mouse.ZonePRMergeCancel):
+		m.mergeForm = nil
+	}
+	return m, nil, nil
+}
+
+// applyPrMerged closes the form after a successful merge, or keeps it open with the error so the
+// commit message is not lost. A successful method becomes the default for the next merge.
+func (m *Model) applyPrMerged(msg PrMergedMsg, app *state.AppState) {
+	if st := m.mergeForm; st != nil && st.pr.Number == msg.PRNumber {
+		if msg.Err != nil {
+			st.submitting = false
+			st.err = msg.Err.Error()
+			return
+		}
+		m.mergeForm = nil
+	}
+	if msg.Err != nil || msg.Method == "" || app == nil || app.Config == nil || app.Config.PRMergeMethodOrDefault() == msg.Method {
+		return
+	}
+	app.Config.PRMergeMethod = msg.Method
+	if saved, _ := config.Load(); saved != nil {
+		saved.PRMergeMethod = msg.Method
+		_ = saved.Save()
+	}
+}
+
+// mergedStatus is the status line after a successful merge or auto-merge request.
+func mergedStatus(msg PrMergedMsg) string {
+	method := mergeMethodLabels[msg.Method]
+	if method == "" {
+		return fmt.Sprintf("Merged PR #%d", msg.PRNumber)
+	}
+	if msg.AutoMerge {
+		return fmt.Sprintf("Auto-merge enabled for PR #%d (%s); GitHub merges it when checks pass", msg.PRNumber, strings.ToLower(method))
+	}
+	return fmt.Sprintf("Merged PR #%d (%s)", msg.PRNumber, strings.ToLower(method))
+}
+
+// renderMergeForm renders the merge form shown instead of the PR list.
+func (m *Model) renderMergeForm() []string {
+	st := m.mergeForm
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	label := func(field int, s string) string {
+		if st.focus == field {
+			return lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("▸ " + s)
+		}
+		return muted.Render("  " + s)
+	}
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Merge PR #%d: %s", st.pr.Number, st.pr.Title)) +
+			muted.Render(" · Tab field · ←/→ method · Ctrl+S merge · Esc cancel"),
+	}
+	var methods []string
+	for i, mm := range github.MergeMethods {
+		text := "( ) " + mergeMethodLabels[mm]
+		style := styles.ButtonDisabledStyle
+		if i == st.method {
+			text = "(•) " + mergeMethodLabels[mm]
+			style = styles.ButtonStyle
+		}
+		methods = append(methods, mark(m.zoneManager, mouse.ZonePRMergeMethod(i), style.Render(text)))
+	}
+	lines = append(lines, label(mergeFocusMethod, "Method  ")+strings.Join(methods, " "))
+
+	if st.methodName() == github.MergeMethodRebase {
+		lines = append(lines, muted.Render("  Rebasing keeps each commit's own message."))
+	} else {
+		lines = append(lines, mark(m.zoneManager, mouse.ZonePRMergeTitle, label(mergeFocusTitle, "Title   ")+st.title.View()))
+		lines = append(lines, label(mergeFocusMessage, "Message"))
+		box := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(styles.ColorPrimary).
+			Render(st.message.View())
+		lines = append(lines, strings.Split(mark(m.zoneManager, mouse.ZonePRMergeMessage, box), "\n")...)
+	}
+
+	check := "[ ]"
+	if st.autoMerge {
+		check = "[x]"
+	}
+	lines = append(lines, mark(m.zoneManager, mouse.ZonePRMergeAuto,
+		label(mergeFocusAuto, check+" Auto-merge when checks pass")))
+	if len(st.followUpSteps) > 0 {
+		check = "[ ]"
+		if st.followUp {
+			check = "[x]"
+		}
+		text := check + " Then: " + strings.ReplaceAll(strings.Join(st.followUpSteps, " → "), "_", " ")
+		if st.autoMerge {
+			text += muted.Render(" (not after auto-merge)")
+		}
+		lines = append(lines, mark(m.zoneManager, mouse.ZonePRMergeFollow, label(mergeFocusFollowUp, text)))
+	}
+
+	submit := "Merge"
+	switch {
+	case st.submitting:
+		submit = "Merging…"
+	case st.autoMerge:
+		submit = "Enable auto-merge"
+	}
+	lines = append(lines, mark(m.zoneManager, mouse.ZonePRMergeSubmit, styles.ButtonStyle.Render(submit))+" "+
+		mark(m.zoneManager, mouse.ZonePRMergeCancel, styles.ButtonStyle.Render("Cancel")))
+	if st.err != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(st.err))
+	}
+	return lines
+}
+
+// IsMergeFormOpen reports whether the merge form replaces the PR list; it owns the keyboard while
+// open.
+func (m *Model) IsMergeFormOpen() bool {
+	return m.mergeForm != nil
+}
@@ -5,17 +5,20 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal"
-	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/forge"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // BranchPushedMsg indicates a branch was pushed.
 type BranchPushedMsg struct {
+	state.RepoResult
 	Branch     string
 	PushOutput string
 }
 
 // PrsLoadedMsg is sent when PRs have been loaded (or load failed with LoadErrorMsg).
 type PrsLoadedMsg struct {
+	state.RepoResult
 	Prs []internal.GitHubPR
 }
 
@@ -23,28 +26,47 @@ type PrsLoadedMsg struct {
 // into the repository's PR list so the graph can offer "Update PR" for branches whose PR was not
 // in the bulk list (e.g. older open PRs crowded out by the fetch limit in busy repos).
 type OpenPRsResolvedMsg struct {
+	state.RepoResult
 	Prs []internal.GitHubPR
 }
 
-// PrMergedMsg is sent when a PR merge completes.
+// DeploymentsLoadedMsg carries the latest deployment per environment for each requested ref
+// (the selected PR's head branch and its base/trunk branch).
+type DeploymentsLoadedMsg struct {
+	state.RepoResult
+	Deployments map[string][]internal.Deployment
+	Err         error
+}
+
+// PrMergedMsg is sent when a PR merge completes. AutoMerge means GitHub will merge the PR once
+// its checks pass rather than having merged it already. FollowUp asks main to run the
+// pr_merge_follow_up steps for HeadBranch.
 type PrMergedMsg struct {
-	PRNumber int
-	Err      error
+	state.RepoResult
+	PRNumber   int
+	Method     string
+	AutoMerge  bool
+	FollowUp   bool
+	HeadBranch string
+	Err        error
 }
 
 // PrClosedMsg is sent when a PR close completes.
 type PrClosedMsg struct {
+	state.RepoResult
 	PRNumber int
 	Err      error
 }
 
 // LoadErrorMsg is sent when loading PRs fails (main shows error modal).
 type LoadErrorMsg struct {
+	state.RepoResult
 	Err error
 }
 
 // ReauthNeededMsg is sent when GitHub auth expired (main starts login flow).
 type ReauthNeededMsg struct {
+	state.RepoResult
 	Reason string
 }
 
@@ -54,8 +76,28 @@ type PrTickMsg time.Time
 // Request is sent to the main model to run PR actions (main has githubService, openURL, etc.).
 type Request struct {
 	OpenInBrowser bool
-	MergePR       bool
+	MergePR       bool // opens the merge form
 	ClosePR       bool
+	// LoadDeployments fetches deployment statuses for the selected PR's head and base branches.
+	LoadDeployments bool
+	// LoadReviewComments lists the selected PR's line review comments for the quick fix flow.
+	LoadReviewComments bool
+	// LoadDetail loads the selected PR's checks, review threads, comments, and files for the detail view.
+	LoadDetail bool
+	// LoadDiff loads the selected PR's diff (head against base) and opens it in the diff viewer.
+	LoadDiff bool
+	// ReplyToReviewComment posts a reply in a review comment's thread (review comment list, r).
+	ReplyToReviewComment *ReviewReplySubmission
+	// SubmitReview submits a review (comment, approve, request changes) from the review form.
+	SubmitReview *ReviewSubmission
+	// Merge merges the PR (or enables auto-merge) as chosen in the merge form.
+	Merge *MergeSubmission
+	// ToggleChecklist flips a task list item in the selected PR's body (detail view).
+	ToggleChecklist *ChecklistToggle
+	// PushChange pushes the selected outgoing change (Push tab, push_mode git or gerrit).
+	PushChange bool
+	// RefreshChanges reloads the Push tab's outgoing changes.
+	RefreshChanges bool
 }
 
 // Cmd returns a tea.Cmd that sends this request.
@@ -118,7 +160,7 @@ type PrTickInput struct {
 	IsPRView      bool
 	Loading       bool
 	HasError      bool
-	GitHubService *github.Service
+	Forge         forge.Service
 	GithubInfo    string
 	DemoMode      bool
 	ExistingCount int
@@ -8,9 +8,12 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	overlay "github.com/madicen/bubble-overlay"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/integrations/github"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/mousedouble"
 	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
 // Model represents the state of the PRs tab
@@ -22,9 +25,18 @@ type Model struct {
 	width         int
 	height        int
 	githubService bool // whether GitHub is connected (for rendering)
+	// permissions is what the GitHub token may do (nil = not probed, allowed); denied actions
+	// render disabled and explain themselves.
+	permissions *github.Permissions
+	// upstreamSource is the fork's upstream ("owner/repo") PRs are listed from; empty for origin.
+	upstreamSource string
 	// scrollToSelectedPR: when true, next render will adjust listYOffset to keep selection in view (key/click only; mouse scroll can move selection off screen)
 	scrollToSelectedPR bool
 
+	// isChange links change IDs in PR bodies; bodyRefs rebuilds it when repository is replaced.
+	isChange     func(string) bool
+	isChangeRepo *internal.Repository
+
 	// Long-press context menu for PR rows.
 	longPressItemIndex int
 	longPressPressID   int
@@ -33,6 +45,32 @@ type Model struct {
 	contextMenu        *ContextMenuState
 
 	rowDoubleClick mousedouble.DoubleClick
+	clickBindings  mousedouble.Bindings // configured double/middle-click actions, refreshed on each zone click
+
+	// deployments caches the latest deployment per environment keyed by ref (branch name), filled by D.
+	deployments map[string][]internal.Deployment
+
+	// reviewComments is the open review comment list (R; nil = closed).
+	reviewComments *reviewCommentsState
+	// replyTemplates are the configured review reply templates, refreshed on each key press.
+	replyTemplates []string
+
+	// detail is the open PR detail view (d; nil = closed).
+	detail *internal.PRDetail
+	// checklistCursor is the task list item c moves through and x toggles in the detail view.
+	checklistCursor int
+
+	// reviewForm is the open review form (r; nil = closed).
+	reviewForm *reviewFormState
+
+	// mergeForm is the open merge form (M; nil = closed); mergeFollowUp are the configured
+	// pr_merge_follow_up steps it offers to run afterwards.
+	mergeForm     *mergeFormState
+	mergeFollowUp []string
+
+	// push replaces the PR list with outgoing changes to push when push_mode is git or gerrit
+	// (nil = PR list).
+	push *pushModeState
 }
 
 // NewModel creates a new PRs tab model. zoneManager may be nil (e.g. in tests).
@@ -88,7 +126,7 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 		if msg.Prs == nil {
 			if app != nil {
 				if app.Repository != nil && app.StatusMessage == "" {
-					app.StatusMessage = fmt.Sprintf("PRs: %d", len(app.Repository.PRs))
+					app.StatusMessage = i18n.T("status.prs_count", len(app.Repository.PRs))
 				}
 				m.repository = app.Repository
 				return m, nil
@@ -99,15 +137,37 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 			if app.Repository != nil {
 				app.Repository.PRs = msg.Prs
 			}
-			app.StatusMessage = fmt.Sprintf("Loaded %d PRs", len(msg.Prs))
+			app.StatusMessage = i18n.T("status.loaded_prs", len(msg.Prs))
 			m.repository = app.Repository
-			return m, nil
+			return m, LoadAvatarsCmd(msg.Prs)
 		}
-		return m, ApplyPrsLoadedEffect{
+		return m, tea.Batch(ApplyPrsLoadedEffect{
 			Prs:           msg.Prs,
-			StatusMessage: fmt.Sprintf("Loaded %d PRs", len(msg.Prs)),
-		}.Cmd()
+			StatusMessage: i18n.T("status.loaded_prs", len(msg.Prs)),
+		}.Cmd(), LoadAvatarsCmd(msg.Prs))
+	case ChangesLoadedMsg:
+		status := m.applyChangesLoaded(msg)
+		if app != nil && status != "" {
+			app.StatusMessage = status
+		}
+		return m, nil
+	case ChangePushedMsg:
+		if app == nil {
+			return m, nil
+		}
+		app.StatusMessage = pushedStatus(msg)
+		if msg.Err != nil || m.push == nil {
+			return m, nil
+		}
+		return m, LoadChangesCmd(app.JJService, m.push.remote)
+	case MergeFormRequestedMsg:
+		if app != nil {
+			m.mergeFollowUp = app.Config.PRMergeFollowUpSteps()
+		}
+		m.openMergeForm(msg.PR, msg.Method)
+		return m, nil
 	case PrMergedMsg:
+		m.applyPrMerged(msg, app)
 		if msg.Err != nil {
 			if app != nil {
 				app.StatusMessage = fmt.Sprintf("Failed to merge PR #%d: %v", msg.PRNumber, msg.Err)
@@ -119,14 +179,14 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 			}.Cmd()
 		}
 		if app != nil {
-			app.StatusMessage = fmt.Sprintf("Merged PR #%d", msg.PRNumber)
+			app.StatusMessage = mergedStatus(msg)
 			existing := 0
 			if app.Repository != nil {
 				existing = len(app.Repository.PRs)
 			}
-			return m, LoadPRsCmd(app.GitHubService, app.GithubInfo, app.DemoMode, existing)
+			return m, LoadPRsCmd(app.Forge(), app.GithubInfo, app.DemoMode, existing)
 		}
-		return m, ApplyPrMergeClosedEffect{StatusMessage: fmt.Sprintf("Merged PR #%d", msg.PRNumber)}.Cmd()
+		return m, ApplyPrMergeClosedEffect{StatusMessage: mergedStatus(msg)}.Cmd()
 	case PrClosedMsg:
 		if msg.Err != nil {
 			if app != nil {
@@ -144,22 +204,93 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 			if app.Repository != nil {
 				existing = len(app.Repository.PRs)
 			}
-			return m, LoadPRsCmd(app.GitHubService, app.GithubInfo, app.DemoMode, existing)
+			return m, LoadPRsCmd(app.Forge(), app.GithubInfo, app.DemoMode, existing)
 		}
 		return m, ApplyPrMergeClosedEffect{StatusMessage: fmt.Sprintf("Closed PR #%d", msg.PRNumber)}.Cmd()
+	case DeploymentsLoadedMsg:
+		if msg.Err != nil {
+			if app != nil {
+				app.StatusMessage = fmt.Sprintf("Failed to load deployments: %v", msg.Err)
+			}
+			return m, nil
+		}
+		if m.deployments == nil {
+			m.deployments = make(map[string][]internal.Deployment)
+		}
+		n := 0
+		for ref, deps := range msg.Deployments {
+			m.deployments[ref] = deps
+			n += len(deps)
+		}
+		if app != nil {
+			app.StatusMessage = i18n.T("status.loaded_deployments", n)
+		}
+		return m, nil
+	case ReviewCommentsLoadedMsg:
+		if msg.Err != nil {
+			if app != nil {
+				app.StatusMessage = fmt.Sprintf("Failed to load review comments: %v", msg.Err)
+			}
+			return m, nil
+		}
+		m.setReviewComments(msg)
+		if app != nil {
+			app.StatusMessage = fmt.Sprintf("PR #%d: %d review comments", msg.PRNumber, len(msg.Comments))
+		}
+		return m, nil
+	case PRDiffLoadedMsg:
+		return m, applyPRDiffLoaded(msg, app)
+	case ChecklistToggledMsg:
+		status := m.applyChecklistToggled(msg)
+		if app != nil {
+			app.StatusMessage = status
+		}
+		return m, nil
+	case PRDetailLoadedMsg:
+		if msg.Err != nil {
+			if app != nil {
+				app.StatusMessage = fmt.Sprintf("Failed to load PR details: %v", msg.Err)
+			}
+			return m, nil
+		}
+		m.setPRDetail(msg)
+		if app != nil {
+			app.StatusMessage = fmt.Sprintf("PR #%d: %d checks, %d files", msg.PRNumber, len(msg.Detail.Checks), len(msg.Detail.Files))
+		}
+		return m, nil
+	case ReviewSubmittedMsg:
+		status := m.applyReviewSubmitted(msg)
+		if app == nil {
+			return m, nil
+		}
+		app.StatusMessage = status
+		if msg.Err != nil {
+			return m, nil
+		}
+		existing := 0
+		if app.Repository != nil {
+			existing = len(app.Repository.PRs)
+		}
+		return m, LoadPRsCmd(app.Forge(), app.GithubInfo, app.DemoMode, existing)
+	case ReviewRepliedMsg:
+		status := m.applyReviewReplied(msg)
+		if app != nil {
+			app.StatusMessage = status
+		}
+		return m, nil
 	case LoadErrorMsg:
 		if app != nil {
 			app.StatusMessage = fmt.Sprintf("Error: %v", msg.Err)
 			return m, nil
 		}
-		return m, ApplyPrsLoadErrorEffect(msg).Cmd()
+		return m, ApplyPrsLoadErrorEffect{Err: msg.Err}.Cmd()
 	case ReauthNeededMsg:
 		if app != nil {
 			return m, nil
 		}
-		return m, ApplyReauthNeededEffect(msg).Cmd()
+		return m, ApplyReauthNeededEffect{Reason: msg.Reason}.Cmd()
 	case PrTickInput:
-		if msg.HasError || msg.GitHubService == nil {
+		if msg.HasError || msg.Forge == nil {
 			return m, nil
 		}
 		if !msg.IsPRView || msg.Loading {
@@ -170,13 +301,13 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 		}
 		if app != nil {
 			return m, tea.Batch(
-				LoadPRsCmd(msg.GitHubService, msg.GithubInfo, msg.DemoMode, msg.ExistingCount),
+				LoadPRsCmd(msg.Forge, msg.GithubInfo, msg.DemoMode, msg.ExistingCount),
 				PrTickCmd(),
 			)
 		}
 		return m, ApplyPrTickEffect{
 			RunCmd: tea.Batch(
-				LoadPRsCmd(msg.GitHubService, msg.GithubInfo, msg.DemoMode, msg.ExistingCount),
+				LoadPRsCmd(msg.Forge, msg.GithubInfo, msg.DemoMode, msg.ExistingCount),
 				PrTickCmd(),
 			),
 		}.Cmd()
@@ -184,6 +315,9 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		return m, nil
 	case tea.KeyMsg:
+		if app != nil {
+			m.replyTemplates = app.Config.ReviewReplyTemplatesOrDefault()
+		}
 		updated, req, cmd := m.handleKeyMsg(msg)
 		if req != nil && app != nil {
 			ctx := BuildRequestContextFromApp(app, &updated)
@@ -198,6 +332,9 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 		}
 		return updated, cmd
 	case zone.MsgZoneInBounds:
+		if app != nil {
+			m.clickBindings = mousedouble.BindingsFrom(app.Config)
+		}
 		updated, req, cmd := m.handleZoneClick(msg.Zone, msg.Event)
 		if req != nil && app != nil {
 			ctx := BuildRequestContextFromApp(app, &updated)
@@ -237,6 +374,9 @@ func (m *Model) View() string {
 	if m.width == 0 || m.height == 0 {
 		return "Loading..."
 	}
+	if m.push != nil {
+		return m.renderPushChanges()
+	}
 	if m.repository == nil {
 		return "Loading pull requests..."
 	}
@@ -262,8 +402,34 @@ func (m *Model) SetGithubService(connected bool) {
 	m.githubService = connected
 }
 
+// SetUpstreamSource records the fork's upstream ("owner/repo") when PRs are listed from it; empty
+// when they come from origin.
+func (m *Model) SetUpstreamSource(fullName string) {
+	m.upstreamSource = fullName
+}
+
+// SetPermissions sets what the GitHub token may do in this repository.
+func (m *Model) SetPermissions(p *github.Permissions) {
+	m.permissions = p
+}
+
 // handleKeyMsg handles keyboard input; returns (updated model, optional request, cmd).
 func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	if m.mergeForm != nil {
+		return m.handleMergeFormKey(msg)
+	}
+	if m.reviewForm != nil {
+		return m.handleReviewFormKey(msg)
+	}
+	if m.detail != nil {
+		return m.handlePRDetailKey(msg)
+	}
+	if m.reviewComments != nil {
+		return m.handleReviewCommentsKey(msg)
+	}
+	if m.push != nil {
+		return m.handlePushKey(msg)
+	}
 	switch msg.String() {
 	case "esc":
 		if m.contextMenu != nil {
@@ -313,6 +479,36 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
 			return m, &Request{ClosePR: true}, nil
 		}
 		return m, nil, nil
+	case "D":
+		if m.repository != nil && m.selectedPR >= 0 && m.selectedPR < len(m.repository.PRs) {
+			return m, &Request{LoadDeployments: true}, nil
+		}
+		return m, nil, nil
+	case "v":
+		if m.repository != nil && m.selectedPR >= 0 && m.selectedPR < len(m.repository.PRs) {
+			return m, nil, PagerTarget(m.repository.PRs[m.selectedPR]).Cmd()
+		}
+		return m, nil, nil
+	case "R":
+		if m.repository != nil && m.selectedPR >= 0 && m.selectedPR < len(m.repository.PRs) {
+			return m, &Request{LoadReviewComments: true}, nil
+		}
+		return m, nil, nil
+	case "d":
+		if m.repository != nil && m.selectedPR >= 0 && m.selectedPR < len(m.repository.PRs) {
+			return m, &Request{LoadDetail: true}, nil
+		}
+		return m, nil, nil
+	case "f":
+		if m.repository != nil && m.selectedPR >= 0 && m.selectedPR < len(m.repository.PRs) {
+			return m, &Request{LoadDiff: true}, nil
+		}
+		return m, nil, nil
+	case "r":
+		if pr := m.selectedPRData(); pr != nil && pr.State == "open" {
+			return m, nil, m.openReviewForm()
+		}
+		return m, nil, nil
 	}
 	return m, nil, nil
 }
@@ -355,16 +551,51 @@ func (m Model) handleZoneClick(z *zone.ZoneInfo, event tea.MouseMsg) (Model, *Re
 	if m.zoneManager == nil || z == nil {
 		return m, nil, nil
 	}
+	if m.push != nil {
+		return m.handlePushClick(z)
+	}
+	if m.mergeForm != nil {
+		return m.handleMergeFormClick(z)
+	}
+	if m.reviewForm != nil {
+		return m.handleReviewFormClick(z)
+	}
+	if m.detail != nil {
+		if pr := m.selectedPRData(); pr != nil {
+			for i := range parseChecklist(pr.Body) {
+				if m.zoneManager.Get(mouse.ZonePRChecklistItem(i)) == z {
+					m.checklistCursor = i
+					return m, &Request{ToggleChecklist: m.selectedChecklistToggle()}, nil
+				}
+			}
+		}
+	}
 	for i := 0; m.repository != nil && i < len(m.repository.PRs); i++ {
 		if m.zoneManager.Get(mouse.ZonePR(i)) == z {
-			m.selectedPR = i
-			key := fmt.Sprintf("prs:%d", i)
-			if m.rowDoubleClick.ObserveLeftRelease(key, event, time.Now(), mousedouble.DefaultDoubleClickWindow) {
+			switch m.rowDoubleClick.Classify(fmt.Sprintf("prs:%d", i), event, time.Now(), m.clickBindings) {
+			case mousedouble.ClickIgnore:
+				return m, nil, nil
+			case mousedouble.ClickCopy:
+				return m, nil, util.CopyToClipboard(m.repository.PRs[i].URL)
+			case mousedouble.ClickPrimary:
+				m.selectedPR = i
 				return m, &Request{OpenInBrowser: true}, nil
+			case mousedouble.ClickView:
+				m.selectedPR = i
+				return m, nil, PagerTarget(m.repository.PRs[i]).Cmd()
 			}
+			m.selectedPR = i
 			return m, nil, nil
 		}
 	}
+	if m.repository != nil && m.selectedPR >= 0 && m.selectedPR < len(m.repository.PRs) {
+		desc := bodyExcerpt(m.repository.PRs[m.selectedPR].Body)
+		for i, ref := range m.bodyRefs(desc) {
+			if m.zoneManager.Get(mouse.ZonePRBodyRef(i)) == z {
+				return m, nil, state.NavigateTarget{Kind: state.NavigateFollowXRef, XRef: ref}.Cmd()
+			}
+		}
+	}
 	if m.zoneManager.Get(mouse.ZonePROpenBrowser) == z {
 		return m, &Request{OpenInBrowser: true}, nil
 	}
@@ -374,6 +605,21 @@ func (m Model) handleZoneClick(z *zone.ZoneInfo, event tea.MouseMsg) (Model, *Re
 	if m.zoneManager.Get(mouse.ZonePRClose) == z {
 		return m, &Request{ClosePR: true}, nil
 	}
+	if m.zoneManager.Get(mouse.ZonePRDeployments) == z {
+		return m, &Request{LoadDeployments: true}, nil
+	}
+	if m.zoneManager.Get(mouse.ZonePRDetails) == z {
+		return m, &Request{LoadDetail: true}, nil
+	}
+	if m.zoneManager.Get(mouse.ZonePRDiff) == z {
+		return m, &Request{LoadDiff: true}, nil
+	}
+	if m.zoneManager.Get(mouse.ZonePRReview) == z {
+		return m, nil, m.openReviewForm()
+	}
+	if m.zoneManager.Get(mouse.ZonePRRead) == z && m.repository != nil && m.selectedPR >= 0 && m.selectedPR < len(m.repository.PRs) {
+		return m, nil, PagerTarget(m.repository.PRs[m.selectedPR]).Cmd()
+	}
 	return m, nil, nil
 }
 
@@ -389,6 +635,11 @@ func (m *Model) GetListYOffset() int {
 	return m.listYOffset
 }
 
+// HasContextMenu reports whether the PR context menu is open
+func (m *Model) HasContextMenu() bool {
+	return m.contextMenu != nil
+}
+
 // SetSelectedPR sets the selected PR index
 func (m *Model) SetSelectedPR(idx int) {
 	if m.repository != nil && idx >= 0 && idx < len(m.repository.PRs) {
@@ -396,6 +647,12 @@ func (m *Model) SetSelectedPR(idx int) {
 	}
 }
 
+// GetDeployments returns the cached deployments for ref and whether they have been loaded.
+func (m *Model) GetDeployments(ref string) ([]internal.Deployment, bool) {
+	deps, ok := m.deployments[ref]
+	return deps, ok
+}
+
 // GetRepository returns the repository
 func (m *Model) GetRepository() *internal.Repository {
 	return m.repository
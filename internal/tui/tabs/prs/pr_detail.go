@@ -0,0 +1,257 @@
+package prs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/mattn/go-runewidth"
+)
+
+// PRDetailLoadedMsg carries the checks, review threads, comments, and files of a PR (d).
+type PRDetailLoadedMsg struct {
+	state.RepoResult
+	PRNumber int
+	Detail   *internal.PRDetail
+	Err      error
+}
+
+// LoadPRDetailCmd fetches the detail of PR prNumber.
+func LoadPRDetailCmd(ghSvc *github.Service, prNumber int, demoMode bool) tea.Cmd {
+	if demoMode {
+		return func() tea.Msg { return PRDetailLoadedMsg{PRNumber: prNumber, Detail: mock.DemoPRDetail(prNumber)} }
+	}
+	if ghSvc == nil {
+		return nil
+	}
+	svc := ghSvc
+	return func() tea.Msg {
+		detail, err := svc.GetPullRequestDetail(context.Background(), prNumber)
+		return PRDetailLoadedMsg{PRNumber: prNumber, Detail: detail, Err: err}
+	}
+}
+
+// setPRDetail opens the detail view for a load result of the selected PR.
+func (m *Model) setPRDetail(msg PRDetailLoadedMsg) {
+	pr := m.selectedPRData()
+	if msg.Err != nil || msg.Detail == nil || pr == nil || pr.Number != msg.PRNumber {
+		return
+	}
+	m.reviewComments = nil
+	m.detail = msg.Detail
+	m.listYOffset = 0
+	m.checklistCursor = 0
+}
+
+// handlePRDetailKey handles keys while the detail view is open: j/k and PgUp/PgDn scroll, v reads
+// everything in the pager, o/Enter open the PR in the browser, c and x move through and toggle the
+// body's checklist, and Esc or d close the view.
+func (m Model) handlePRDetailKey(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.listYOffset++
+	case "k", "up":
+		m.listYOffset = max(m.listYOffset-1, 0)
+	case "pgdown", "ctrl+d", "ctrl+f":
+		m.listYOffset += 10
+	case "pgup", "ctrl+u", "ctrl+b":
+		m.listYOffset = max(m.listYOffset-10, 0)
+	case "home":
+		m.listYOffset = 0
+	case "end":
+		m.listYOffset = 99999
+	case "v":
+		if pr := m.selectedPRData(); pr != nil {
+			return m, nil, prDetailPager(*pr, m.detail).Cmd()
+		}
+	case "o", "enter":
+		return m, &Request{OpenInBrowser: true}, nil
+	case "c":
+		if pr := m.selectedPRData(); pr != nil {
+			if n := len(parseChecklist(pr.Body)); n > 0 {
+				m.checklistCursor = (m.checklistCursor + 1) % n
+			}
+		}
+	case "x", " ":
+		if t := m.selectedChecklistToggle(); t != nil {
+			return m, &Request{ToggleChecklist: t}, nil
+		}
+	case "esc", "d":
+		m.detail = nil
+		m.listYOffset = 0
+		m.scrollToSelectedPR = true
+	}
+	return m, nil, nil
+}
+
+// renderPRDetail renders the detail view shown instead of the PR list.
+func (m *Model) renderPRDetail() []string {
+	d := m.detail
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	bold := lipgloss.NewStyle().Bold(true)
+	width := max(m.width-4, 40)
+	fit := func(s string) string { return runewidth.Truncate(s, width, "…") }
+	lines := []string{
+		bold.Render(fmt.Sprintf("Details of #%d", d.Number)) + muted.Render(" · j/k scroll · v read all · o open · Esc back"),
+	}
+
+	lines = append(lines, "", bold.Render("Description"))
+	if pr := m.selectedPRData(); pr != nil && strings.TrimSpace(pr.Body) != "" {
+		lines = append(lines, styles.RenderMarkdown(pr.Body, width)...)
+		lines = append(lines, m.renderChecklist(pr.Body)...)
+	} else {
+		lines = append(lines, muted.Italic(true).Render("  (No description)"))
+	}
+
+	passed := 0
+	for _, c := range d.Checks {
+		if c.Status == internal.CheckStatusSuccess {
+			passed++
+		}
+	}
+	lines = append(lines, "", bold.Render(fmt.Sprintf("Checks (%d of %d passed)", passed, len(d.Checks))))
+	if len(d.Checks) == 0 {
+		lines = append(lines, muted.Render("  No checks on the head commit."))
+	}
+	for _, c := range d.Checks {
+		lines = append(lines, fit("  "+checkGlyph(c.Status)+" "+c.Name+muted.Render(" "+strings.ReplaceAll(c.State, "_", " "))))
+	}
+
+	resolved := 0
+	for _, t := range d.Threads {
+		if t.Resolved {
+			resolved++
+		}
+	}
+	lines = append(lines, "", bold.Render(fmt.Sprintf("Review threads (%d, %d resolved)", len(d.Threads), resolved)))
+	if len(d.Threads) == 0 {
+		lines = append(lines, muted.Render("  No review threads."))
+	}
+	for _, t := range d.Threads {
+		loc := t.Path
+		if t.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", t.Path, t.Line)
+		}
+		var notes []string
+		if t.Replies > 0 {
+			notes = append(notes, fmt.Sprintf("+%d replies", t.Replies))
+		}
+		if t.Outdated {
+			notes = append(notes, "outdated")
+		}
+		if t.Resolved {
+			notes = append(notes, "resolved")
+		}
+		row := fmt.Sprintf("  %s @%s: %s", loc, t.Author, oneLine(t.Body))
+		if len(notes) > 0 {
+			row += muted.Render(" (" + strings.Join(notes, ", ") + ")")
+		}
+		if t.Resolved {
+			row = muted.Render(row)
+		}
+		lines = append(lines, fit(row))
+	}
+
+	lines = append(lines, "", bold.Render(fmt.Sprintf("Comments (%d)", len(d.Comments))))
+	if len(d.Comments) == 0 {
+		lines = append(lines, muted.Render("  No comments."))
+	}
+	for _, c := range d.Comments {
+		lines = append(lines, fit(fmt.Sprintf("  @%s %s %s", c.Author, muted.Render(c.CreatedAt.Local().Format("2006-01-02 15:04")), oneLine(c.Body))))
+	}
+
+	adds, dels := 0, 0
+	for _, f := range d.Files {
+		adds += f.Additions
+		dels += f.Deletions
+	}
+	added := lipgloss.NewStyle().Foreground(styles.ColorPositive)
+	removed := lipgloss.NewStyle().Foreground(styles.ColorNegative)
+	lines = append(lines, "", bold.Render(fmt.Sprintf("Files (%d, ", len(d.Files)))+added.Render(fmt.Sprintf("+%d", adds))+" "+removed.Render(fmt.Sprintf("-%d", dels))+bold.Render(")"))
+	for _, f := range d.Files {
+		lines = append(lines, fit(fmt.Sprintf("  %s %s %s %s", fileChangeLetter(f.ChangeType), added.Render(fmt.Sprintf("%+5d", f.Additions)), removed.Render(fmt.Sprintf("-%-4d", f.Deletions)), f.Path)))
+	}
+	return lines
+}
+
+// prDetailPager opens the PR's description and full detail (untruncated) in the pager.
+func prDetailPager(pr internal.GitHubPR, d *internal.PRDetail) state.NavigateTarget {
+	target := PagerTarget(pr)
+	var b strings.Builder
+	b.WriteString(target.PagerContent)
+	fmt.Fprintf(&b, "\n\nChecks\n")
+	for _, c := range d.Checks {
+		fmt.Fprintf(&b, "  %s %s (%s)", checkGlyph(c.Status), c.Name, c.State)
+		if c.URL != "" {
+			b.WriteString(" " + c.URL)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\nReview threads\n")
+	for _, t := range d.Threads {
+		state := ""
+		if t.Resolved {
+			state = " (resolved)"
+		}
+		fmt.Fprintf(&b, "\n%s:%d · @%s%s\n%s\n", t.Path, t.Line, t.Author, state, strings.TrimSpace(t.Body))
+		if t.Replies > 0 {
+			fmt.Fprintf(&b, "(+%d replies)\n", t.Replies)
+		}
+	}
+	fmt.Fprintf(&b, "\nComments\n")
+	for _, c := range d.Comments {
+		fmt.Fprintf(&b, "\n@%s · %s\n%s\n", c.Author, c.CreatedAt.Local().Format("2006-01-02 15:04"), strings.TrimSpace(c.Body))
+	}
+	fmt.Fprintf(&b, "\nFiles\n")
+	for _, f := range d.Files {
+		fmt.Fprintf(&b, "  %s +%d -%d %s\n", fileChangeLetter(f.ChangeType), f.Additions, f.Deletions, f.Path)
+	}
+	target.PagerContent = b.String()
+	return target
+}
+
+// checkGlyph renders the colored glyph for a check status.
+func checkGlyph(s internal.CheckStatus) string {
+	switch s {
+	case internal.CheckStatusSuccess:
+		return lipgloss.NewStyle().Foreground(styles.ColorSuccess).Render(styles.GlyphSuccess)
+	case internal.CheckStatusFailure:
+		return lipgloss.NewStyle().Foreground(styles.ColorFailure).Render(styles.GlyphFailure)
+	case internal.CheckStatusPending:
+		return lipgloss.NewStyle().Foreground(styles.ColorPending).Render(styles.GlyphPending)
+	}
+	return lipgloss.NewStyle().Foreground(styles.ColorNeutral).Render(styles.GlyphNone)
+}
+
+// fileChangeLetter abbreviates a PR file's change type like jj's diff summary.
+func fileChangeLetter(changeType string) string {
+	switch changeType {
+	case "added":
+		return "A"
+	case "deleted":
+		return "D"
+	case "renamed":
+		return "R"
+	case "copied":
+		return "C"
+	}
+	return "M"
+}
+
+// oneLine collapses whitespace so a comment fits on one row.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// IsPRDetailOpen reports whether the detail view replaces the PR list (Esc closes it instead of
+// leaving the tab).
+func (m *Model) IsPRDetailOpen() bool {
+	return m.detail != nil
+}
@@ -0,0 +1,89 @@
+package prs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// PRDiffLoadedMsg carries the diff of a PR (head against base) for the diff viewer (f).
+type PRDiffLoadedMsg struct {
+	state.RepoResult
+	PR     internal.GitHubPR
+	Diff   string
+	Source string // "GitHub" or "jj" (computed locally)
+	Err    error
+}
+
+// LoadPRDiffCmd fetches pr's diff from GitHub, falling back to jj (fork point of base and head to
+// head) when GitHub is unavailable or refuses the diff, e.g. because it is too large.
+func LoadPRDiffCmd(ghSvc *github.Service, jjSvc *jj.Service, pr internal.GitHubPR, demoMode bool) tea.Cmd {
+	if demoMode {
+		return func() tea.Msg { return PRDiffLoadedMsg{PR: pr, Diff: mock.DemoPRDiff(pr.Number), Source: "GitHub"} }
+	}
+	if ghSvc == nil && jjSvc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		var ghErr error
+		if ghSvc != nil {
+			diff, err := ghSvc.GetPullRequestDiff(ctx, pr.Number)
+			if err == nil {
+				return PRDiffLoadedMsg{PR: pr, Diff: diff, Source: "GitHub"}
+			}
+			if github.IsAuthError(err) || jjSvc == nil {
+				return PRDiffLoadedMsg{PR: pr, Err: err}
+			}
+			ghErr = err
+		}
+		diff, err := jjSvc.PullRequestDiff(ctx, pr.BaseBranch, pr.HeadBranch)
+		if err != nil {
+			if ghErr != nil {
+				err = fmt.Errorf("%w (GitHub: %v)", err, ghErr)
+			}
+			return PRDiffLoadedMsg{PR: pr, Err: err}
+		}
+		return PRDiffLoadedMsg{PR: pr, Diff: diff, Source: "jj"}
+	}
+}
+
+// prDiffTarget opens a loaded PR diff in the file diff viewer used for commits.
+func prDiffTarget(msg PRDiffLoadedMsg) state.NavigateTarget {
+	sub := msg.PR.Title
+	if msg.PR.HeadBranch != "" && msg.PR.BaseBranch != "" {
+		sub = fmt.Sprintf("%s → %s · %s", msg.PR.HeadBranch, msg.PR.BaseBranch, msg.PR.Title)
+	}
+	return state.NavigateTarget{
+		Kind:                    state.NavigateOpenFileDiff,
+		FileDiffRawGit:          msg.Diff,
+		FileDiffOverlayTitle:    fmt.Sprintf("PR #%d diff (%s)", msg.PR.Number, msg.Source),
+		FileDiffOverlaySubtitle: sub,
+	}
+}
+
+// applyPRDiffLoaded sets the status for a diff load (when app is set) and returns the cmd opening
+// the viewer.
+func applyPRDiffLoaded(msg PRDiffLoadedMsg, app *state.AppState) tea.Cmd {
+	status := ""
+	switch {
+	case msg.Err != nil:
+		status = fmt.Sprintf("Failed to load diff of PR #%d: %v", msg.PR.Number, msg.Err)
+	case strings.TrimSpace(msg.Diff) == "":
+		status = fmt.Sprintf("PR #%d has no changes", msg.PR.Number)
+	}
+	if status != "" {
+		if app != nil {
+			app.StatusMessage = status
+		}
+		return nil
+	}
+	return prDiffTarget(msg).Cmd()
+}
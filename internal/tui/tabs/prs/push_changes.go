@@ -0,0 +1,280 @@
+package prs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// ChangesLoadedMsg is sent when LoadChangesCmd finishes.
+type ChangesLoadedMsg struct {
+	state.RepoResult
+	Changes   []jj.OutgoingChange
+	Truncated bool
+	Err       error
+}
+
+// ChangePushedMsg is sent when PushChangeCmd finishes. Ref is what was pushed to
+// ("refs/for/main" or the remote); ReviewURL is the change Gerrit reported, if any.
+type ChangePushedMsg struct {
+	state.RepoResult
+	ChangeID  string
+	Ref       string
+	Output    string
+	ReviewURL string
+	Err       error
+}
+
+// pushModeState is the Push tab that replaces the PR list when push_mode is "git" or "gerrit".
+type pushModeState struct {
+	mode         string // config.PushModeGit or config.PushModeGerrit
+	remote       string
+	gerritBranch string
+	changes      []jj.OutgoingChange
+	truncated    bool
+	loaded       bool
+	selected     int
+}
+
+// LoadChangesCmd lists the changes in the working copy's stack that are not on remote yet.
+func LoadChangesCmd(svc *jj.Service, remote string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		changes, truncated, err := svc.OutgoingChanges(context.Background(), remote)
+		return ChangesLoadedMsg{Changes: changes, Truncated: truncated, Err: err}
+	}
+}
+
+// PushChangeCmd pushes one change: to refs/for/<gerritBranch> in gerrit mode, otherwise as a
+// branch with `jj git push --change`.
+func PushChangeCmd(svc *jj.Service, mode string, c jj.OutgoingChange, remote, gerritBranch string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		if mode == config.PushModeGerrit {
+			out, err := svc.PushChangeForReview(ctx, c.CommitID, remote, gerritBranch)
+			return ChangePushedMsg{ChangeID: c.ChangeID, Ref: "refs/for/" + gerritBranch, Output: out, ReviewURL: jj.ReviewURL(out), Err: err}
+		}
+		out, err := svc.PushChange(ctx, c.ChangeID, remote)
+		return ChangePushedMsg{ChangeID: c.ChangeID, Ref: remote, Output: out, Err: err}
+	}
+}
+
+// SetPushMode switches the tab between the PR list (config.PushModePR) and the Push tab for
+// plain git or Gerrit workflows.
+func (m *Model) SetPushMode(mode, remote, gerritBranch string) {
+	if mode != config.PushModeGit && mode != config.PushModeGerrit {
+		m.push = nil
+		return
+	}
+	if m.push == nil {
+		m.push = &pushModeState{selected: -1}
+	}
+	m.push.mode, m.push.remote, m.push.gerritBranch = mode, remote, gerritBranch
+}
+
+// IsPushMode reports whether the tab shows outgoing changes to push instead of PRs.
+func (m *Model) IsPushMode() bool {
+	return m.push != nil
+}
+
+// applyChangesLoaded stores a loaded change list and returns the status line.
+func (m *Model) applyChangesLoaded(msg ChangesLoadedMsg) string {
+	if m.push == nil {
+		return ""
+	}
+	if msg.Err != nil {
+		return fmt.Sprintf("Failed to load outgoing changes: %v", msg.Err)
+	}
+	p := m.push
+	p.changes, p.truncated, p.loaded = msg.Changes, msg.Truncated, true
+	switch {
+	case len(p.changes) == 0:
+		p.selected = -1
+	case p.selected < 0 || p.selected >= len(p.changes):
+		p.selected = 0
+	}
+	return fmt.Sprintf("%d outgoing %s", len(p.changes), pluralChanges(len(p.changes)))
+}
+
+// pushedStatus is the status line for a finished change push.
+func pushedStatus(msg ChangePushedMsg) string {
+	if msg.Err != nil {
+		return fmt.Sprintf("Failed to push %s: %v", msg.ChangeID, msg.Err)
+	}
+	status := fmt.Sprintf("Pushed %s to %s", msg.ChangeID, msg.Ref)
+	if msg.ReviewURL != "" {
+		status += " — " + msg.ReviewURL
+	}
+	return status
+}
+
+func pluralChanges(n int) string {
+	if n == 1 {
+		return "change"
+	}
+	return "changes"
+}
+
+// selectedChange returns the selected outgoing change or nil.
+func (m *Model) selectedChange() *jj.OutgoingChange {
+	if m.push == nil || m.push.selected < 0 || m.push.selected >= len(m.push.changes) {
+		return nil
+	}
+	return &m.push.changes[m.push.selected]
+}
+
+// pushTarget describes where the selected push mode sends a change.
+func (p *pushModeState) pushTarget() string {
+	if p.mode == config.PushModeGerrit {
+		return fmt.Sprintf("%s refs/for/%s", p.remote, p.gerritBranch)
+	}
+	return p.remote + " (jj git push --change)"
+}
+
+// handlePushKey handles keys on the Push tab.
+func (m Model) handlePushKey(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	p := m.push
+	switch msg.String() {
+	case "j", "down":
+		if p.selected < len(p.changes)-1 {
+			p.selected++
+			m.scrollToSelectedPR = true
+		}
+	case "k", "up":
+		if p.selected > 0 {
+			p.selected--
+			m.scrollToSelectedPR = true
+		}
+	case "home":
+		m.listYOffset = 0
+	case "end":
+		m.listYOffset = 99999
+	case "enter", "P":
+		if m.selectedChange() != nil {
+			return m, &Request{PushChange: true}, nil
+		}
+	case "r":
+		return m, &Request{RefreshChanges: true}, nil
+	}
+	return m, nil, nil
+}
+
+// handlePushClick handles zone clicks on the Push tab.
+func (m Model) handlePushClick(z *zone.ZoneInfo) (Model, *Request, tea.Cmd) {
+	for i := range m.push.changes {
+		if m.zoneManager.Get(mouse.ZonePushRow(i)) == z {
+			m.push.selected = i
+			return m, nil, nil
+		}
+	}
+	if m.zoneManager.Get(mouse.ZonePushChange) == z && m.selectedChange() != nil {
+		return m, &Request{PushChange: true}, nil
+	}
+	if m.zoneManager.Get(mouse.ZonePushRefresh) == z {
+		return m, &Request{RefreshChanges: true}, nil
+	}
+	return m, nil, nil
+}
+
+// renderPushChanges renders the Push tab: where changes go, the selected change's actions, and
+// one row per outgoing change.
+func (m *Model) renderPushChanges() string {
+	p := m.push
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	title := "Push changes"
+	if p.mode == config.PushModeGerrit {
+		title = "Push for review (Gerrit)"
+	}
+	header := []string{
+		styles.TitleStyle.Render(title),
+		muted.Render("Target: " + p.pushTarget()),
+	}
+	if !p.loaded {
+		return strings.Join(append(header, "", "Loading outgoing changes..."), "\n")
+	}
+	if len(p.changes) == 0 {
+		return strings.Join(append(header, "",
+			"No outgoing changes: everything in @'s stack is on "+p.remote+".",
+			"",
+			mark(m.zoneManager, mouse.ZonePushRefresh, styles.ButtonStyle.Render("Refresh (r)"))), "\n")
+	}
+	header = append(header, strings.Join([]string{
+		mark(m.zoneManager, mouse.ZonePushChange, styles.ButtonStyle.Render("Push selected (Enter)")),
+		mark(m.zoneManager, mouse.ZonePushRefresh, styles.ButtonStyle.Render("Refresh (r)")),
+	}, " "), "")
+
+	warn := lipgloss.NewStyle().Foreground(styles.ColorWarning)
+	var rows []string
+	for i, c := range p.changes {
+		prefix := "  "
+		style := styles.CommitStyle
+		if i == p.selected {
+			prefix = "► "
+			style = styles.CommitSelectedStyle
+		}
+		summary := c.Summary
+		if summary == "" {
+			summary = "(no description)"
+		}
+		row := prefix + lipgloss.NewStyle().Foreground(styles.ColorSecondary).Render(c.ChangeID) + " " + style.Render(summary)
+		if len(c.Bookmarks) > 0 {
+			row += " " + muted.Render("["+strings.Join(c.Bookmarks, ", ")+"]")
+		}
+		if c.LooksWIP() {
+			row += " " + warn.Render("⚠ WIP?")
+		}
+		rows = append(rows, mark(m.zoneManager, mouse.ZonePushRow(i), row))
+	}
+	if p.truncated {
+		rows = append(rows, muted.Render("  … and more"))
+	}
+
+	listHeight := max(m.height-len(header), 0)
+	if m.scrollToSelectedPR {
+		m.scrollToSelectedPR = false
+		if p.selected < m.listYOffset {
+			m.listYOffset = p.selected
+		} else if p.selected >= m.listYOffset+listHeight {
+			m.listYOffset = p.selected - listHeight + 1
+		}
+	}
+	m.listYOffset = max(min(m.listYOffset, len(rows)-listHeight), 0)
+	end := min(m.listYOffset+listHeight, len(rows))
+	return strings.Join(append(header, rows[m.listYOffset:end]...), "\n")
+}
+
+// executePushRequest runs a Push tab request.
+func executePushRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.Cmd) {
+	p := ctx.Push
+	if p == nil || ctx.JJService == nil {
+		return "", nil
+	}
+	if r.RefreshChanges {
+		return "Loading outgoing changes...", LoadChangesCmd(ctx.JJService, p.Remote)
+	}
+	if p.Change == nil {
+		return "", nil
+	}
+	if ctx.DemoMode {
+		return fmt.Sprintf("Would push %s (demo mode - push disabled)", p.Change.ChangeID), nil
+	}
+	target := p.Remote
+	if p.Mode == config.PushModeGerrit {
+		target = "refs/for/" + p.GerritBranch
+	}
+	return fmt.Sprintf("Pushing %s to %s...", p.Change.ChangeID, target), PushChangeCmd(ctx.JJService, p.Mode, *p.Change, p.Remote, p.GerritBranch)
+}
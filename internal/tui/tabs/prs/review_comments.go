@@ -0,0 +1,184 @@
+package prs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/mattn/go-runewidth"
+)
+
+// ReviewCommentsLoadedMsg carries the line-level review threads of a PR (R).
+type ReviewCommentsLoadedMsg struct {
+	state.RepoResult
+	PRNumber int
+	Comments []internal.ReviewComment
+	Err      error
+}
+
+// LoadReviewCommentsCmd fetches the review threads of PR prNumber.
+func LoadReviewCommentsCmd(ghSvc *github.Service, prNumber int, demoMode bool) tea.Cmd {
+	if demoMode {
+		return func() tea.Msg {
+			return ReviewCommentsLoadedMsg{PRNumber: prNumber, Comments: mock.DemoReviewComments()}
+		}
+	}
+	if ghSvc == nil {
+		return nil
+	}
+	svc := ghSvc
+	return func() tea.Msg {
+		comments, err := svc.GetReviewComments(context.Background(), prNumber)
+		return ReviewCommentsLoadedMsg{PRNumber: prNumber, Comments: comments, Err: err}
+	}
+}
+
+// ReviewFixRequestedMsg asks main to start a quick fix for a review comment: a new commit on the
+// PR's head branch, with the commented file opened at the line in the editor.
+type ReviewFixRequestedMsg struct {
+	PR      internal.GitHubPR
+	Comment internal.ReviewComment
+}
+
+// ReviewFixStartedMsg is sent when StartReviewFixCmd finishes.
+type ReviewFixStartedMsg struct {
+	state.RepoResult
+	PR      internal.GitHubPR
+	Comment internal.ReviewComment
+	Err     error
+}
+
+// StartReviewFixCmd creates the commit that addresses comment on top of the PR's head branch.
+func StartReviewFixCmd(svc *jj.Service, pr internal.GitHubPR, comment internal.ReviewComment) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		err := svc.StartReviewFix(context.Background(), pr.HeadBranch, jj.ReviewFixMessage(comment))
+		return ReviewFixStartedMsg{PR: pr, Comment: comment, Err: err}
+	}
+}
+
+// reviewCommentsState is the open review comment list (R), which replaces the PR list.
+type reviewCommentsState struct {
+	prNumber int
+	comments []internal.ReviewComment
+	cursor   int
+	reply    *reviewReplyState // open reply composer (r; nil = closed)
+}
+
+// setReviewComments opens the list for a load result of the selected PR.
+func (m *Model) setReviewComments(msg ReviewCommentsLoadedMsg) {
+	pr := m.selectedPRData()
+	if msg.Err != nil || pr == nil || pr.Number != msg.PRNumber {
+		return
+	}
+	m.reviewComments = &reviewCommentsState{prNumber: msg.PRNumber, comments: msg.Comments}
+}
+
+// handleReviewCommentsKey handles keys while the review comment list is open: j/k move, Enter or
+// f starts a quick fix for the comment, r replies, v reads it in the pager, and Esc or R close
+// the list.
+func (m Model) handleReviewCommentsKey(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	st := m.reviewComments
+	if st.reply != nil {
+		return m.handleReviewReplyKey(msg)
+	}
+	switch msg.String() {
+	case "j", "down":
+		if st.cursor < len(st.comments)-1 {
+			st.cursor++
+		}
+	case "k", "up":
+		if st.cursor > 0 {
+			st.cursor--
+		}
+	case "enter", "f":
+		pr := m.selectedPRData()
+		if pr == nil || st.cursor >= len(st.comments) {
+			return m, nil, nil
+		}
+		c := st.comments[st.cursor]
+		m.reviewComments = nil
+		return m, nil, func() tea.Msg { return ReviewFixRequestedMsg{PR: *pr, Comment: c} }
+	case "r":
+		return m, nil, m.openReviewReply()
+	case "v":
+		if st.cursor < len(st.comments) {
+			return m, nil, reviewCommentPager(st.prNumber, st.comments[st.cursor]).Cmd()
+		}
+	case "esc", "R":
+		m.reviewComments = nil
+	}
+	return m, nil, nil
+}
+
+// renderReviewComments renders the review comment list shown instead of the PR list.
+func (m *Model) renderReviewComments() []string {
+	st := m.reviewComments
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Review comments on #%d", st.prNumber)) +
+			muted.Render(" · Enter quick fix · r reply · v read · Esc back"),
+	}
+	if len(st.comments) == 0 {
+		return append(lines, muted.Render("  No line comments on this PR."))
+	}
+	width := max(m.width-4, 40)
+	for i, c := range st.comments {
+		prefix := "  "
+		style := styles.CommitStyle
+		if i == st.cursor {
+			prefix = "► "
+			style = styles.CommitSelectedStyle
+		}
+		loc := fmt.Sprintf("%s:%d", c.Path, c.Line)
+		if c.Outdated {
+			loc += " (outdated)"
+		}
+		body := strings.Join(strings.Fields(c.Body), " ")
+		row := fmt.Sprintf("%s%s @%s: %s", prefix, loc, c.Author, body)
+		lines = append(lines, style.Render(runewidth.Truncate(row, width, "…")))
+		if i == st.cursor && st.reply != nil {
+			lines = append(lines, m.renderReviewReply()...)
+		}
+	}
+	return lines
+}
+
+// reviewCommentPager opens a review comment in the pager.
+func reviewCommentPager(prNumber int, c internal.ReviewComment) state.NavigateTarget {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:%d · @%s\n", c.Path, c.Line, c.Author)
+	if c.URL != "" {
+		b.WriteString(c.URL + "\n")
+	}
+	b.WriteString("\n" + strings.TrimSpace(c.Body))
+	return state.NavigateTarget{
+		Kind:         state.NavigateOpenPager,
+		PagerTitle:   fmt.Sprintf("PR #%d review: %s:%d", prNumber, c.Path, c.Line),
+		PagerContent: b.String(),
+	}
+}
+
+// selectedPRData returns the selected PR, or nil.
+func (m *Model) selectedPRData() *internal.GitHubPR {
+	if m.repository == nil || m.selectedPR < 0 || m.selectedPR >= len(m.repository.PRs) {
+		return nil
+	}
+	return &m.repository.PRs[m.selectedPR]
+}
+
+// IsReviewCommentsOpen reports whether the review comment list replaces the PR list (Esc closes
+// it instead of leaving the tab).
+func (m *Model) IsReviewCommentsOpen() bool {
+	return m.reviewComments != nil
+}
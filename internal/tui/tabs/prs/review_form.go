@@ -0,0 +1,223 @@
+package prs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal/forge"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// reviewEvent is one of the review kinds offered by the review form.
+type reviewEvent struct {
+	event string // github.Review* constant
+	label string
+	done  string // status format after submitting, with the PR number
+}
+
+// reviewEvents lists the review kinds in the order Tab cycles through them.
+var reviewEvents = []reviewEvent{
+	{github.ReviewComment, "Comment", "Commented on PR #%d"},
+	{github.ReviewApprove, "Approve", "Approved PR #%d"},
+	{github.ReviewRequestChanges, "Request changes", "Requested changes on PR #%d"},
+}
+
+// ReviewSubmission is a request to submit a review on a PR.
+type ReviewSubmission struct {
+	PRNumber int
+	Event    string
+	Body     string
+}
+
+// ReviewSubmittedMsg is sent when SubmitReviewCmd finishes.
+type ReviewSubmittedMsg struct {
+	state.RepoResult
+	PRNumber int
+	Event    string
+	Err      error
+}
+
+// SubmitReviewCmd submits the review and sends ReviewSubmittedMsg.
+func SubmitReviewCmd(fg forge.Service, sub ReviewSubmission, demoMode bool) tea.Cmd {
+	if demoMode {
+		return func() tea.Msg { return ReviewSubmittedMsg{PRNumber: sub.PRNumber, Event: sub.Event} }
+	}
+	if fg == nil {
+		return nil
+	}
+	svc := fg
+	return func() tea.Msg {
+		err := svc.SubmitReview(context.Background(), sub.PRNumber, sub.Event, sub.Body)
+		return ReviewSubmittedMsg{PRNumber: sub.PRNumber, Event: sub.Event, Err: err}
+	}
+}
+
+// reviewFormState is the open review form (r), which replaces the PR list.
+type reviewFormState struct {
+	prNumber   int
+	event      int // index into reviewEvents
+	body       textarea.Model
+	err        string
+	submitting bool
+}
+
+// openReviewForm opens the review form for the selected PR.
+func (m *Model) openReviewForm() tea.Cmd {
+	pr := m.selectedPRData()
+	if pr == nil {
+		return nil
+	}
+	ta := textarea.New()
+	ta.Placeholder = "Leave a review comment..."
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetWidth(max(m.width-6, 40))
+	ta.SetHeight(6)
+	m.reviewForm = &reviewFormState{prNumber: pr.Number, body: ta}
+	m.listYOffset = 0
+	return m.reviewForm.body.Focus()
+}
+
+// submission validates the form and returns the review to submit; GitHub rejects comments and
+// change requests without a body.
+func (st *reviewFormState) submission() (ReviewSubmission, bool) {
+	ev := reviewEvents[st.event]
+	body := strings.TrimSpace(st.body.Value())
+	if body == "" && ev.event != github.ReviewApprove {
+		st.err = ev.label + " needs a review body"
+		return ReviewSubmission{}, false
+	}
+	st.err = ""
+	return ReviewSubmission{PRNumber: st.prNumber, Event: ev.event, Body: body}, true
+}
+
+// submitReviewForm returns the request for the current form, or nil when it is not ready.
+func (m *Model) submitReviewForm() *Request {
+	st := m.reviewForm
+	if st.submitting {
+		return nil
+	}
+	sub, ok := st.submission()
+	if !ok {
+		return nil
+	}
+	st.submitting = true
+	return &Request{SubmitReview: &sub}
+}
+
+// handleReviewFormKey handles keys while the review form is open: Tab and Shift+Tab pick the
+// review kind, Ctrl+S submits, Esc cancels, and everything else is typed into the body.
+func (m Model) handleReviewFormKey(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	st := m.reviewForm
+	switch msg.String() {
+	case "esc":
+		m.reviewForm = nil
+		return m, nil, nil
+	case "tab":
+		st.event = (st.event + 1) % len(reviewEvents)
+		return m, nil, nil
+	case "shift+tab":
+		st.event = (st.event + len(reviewEvents) - 1) % len(reviewEvents)
+		return m, nil, nil
+	case "ctrl+s":
+		return m, m.submitReviewForm(), nil
+	}
+	if st.submitting {
+		return m, nil, nil
+	}
+	var cmd tea.Cmd
+	st.body, cmd = st.body.Update(msg)
+	return m, nil, cmd
+}
+
+// handleReviewFormClick handles clicks on the review form's kind options and buttons.
+func (m Model) handleReviewFormClick(z *zone.ZoneInfo) (Model, *Request, tea.Cmd) {
+	st := m.reviewForm
+	if m.zoneManager == nil || z == nil {
+		return m, nil, nil
+	}
+	for i := range reviewEvents {
+		if m.zoneManager.Get(mouse.ZonePRReviewEvent(i)) == z {
+			st.event = i
+			return m, nil, nil
+		}
+	}
+	if m.zoneManager.Get(mouse.ZonePRReviewSubmit) == z {
+		return m, m.submitReviewForm(), nil
+	}
+	if m.zoneManager.Get(mouse.ZonePRReviewCancel) == z {
+		m.reviewForm = nil
+	}
+	return m, nil, nil
+}
+
+// applyReviewSubmitted closes the form after a successful review, or keeps it open with the
+// error so the body is not lost. It returns the status message.
+func (m *Model) applyReviewSubmitted(msg ReviewSubmittedMsg) string {
+	if msg.Err != nil {
+		if st := m.reviewForm; st != nil && st.prNumber == msg.PRNumber {
+			st.submitting = false
+			st.err = msg.Err.Error()
+		}
+		return fmt.Sprintf("Failed to submit review on PR #%d: %v", msg.PRNumber, msg.Err)
+	}
+	if m.reviewForm != nil && m.reviewForm.prNumber == msg.PRNumber {
+		m.reviewForm = nil
+	}
+	for _, ev := range reviewEvents {
+		if ev.event == msg.Event {
+			return fmt.Sprintf(ev.done, msg.PRNumber)
+		}
+	}
+	return fmt.Sprintf("Reviewed PR #%d", msg.PRNumber)
+}
+
+// renderReviewForm renders the review form shown instead of the PR list.
+func (m *Model) renderReviewForm() []string {
+	st := m.reviewForm
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Review PR #%d", st.prNumber)) +
+			muted.Render(" · Tab kind · Ctrl+S submit · Esc cancel"),
+	}
+	var kinds []string
+	for i, ev := range reviewEvents {
+		label := "( ) " + ev.label
+		style := styles.ButtonDisabledStyle
+		if i == st.event {
+			label = "(•) " + ev.label
+			style = styles.ButtonStyle
+		}
+		kinds = append(kinds, mark(m.zoneManager, mouse.ZonePRReviewEvent(i), style.Render(label)))
+	}
+	lines = append(lines, strings.Join(kinds, " "))
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Render(st.body.View())
+	lines = append(lines, strings.Split(box, "\n")...)
+	submit := "Submit review"
+	if st.submitting {
+		submit = "Submitting…"
+	}
+	lines = append(lines, mark(m.zoneManager, mouse.ZonePRReviewSubmit, styles.ButtonStyle.Render(submit))+" "+
+		mark(m.zoneManager, mouse.ZonePRReviewCancel, styles.ButtonStyle.Render("Cancel")))
+	if st.err != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(st.err))
+	}
+	return lines
+}
+
+// IsReviewFormOpen reports whether the review form replaces the PR list; it owns the keyboard
+// while open.
+func (m *Model) IsReviewFormOpen() bool {
+	return m.reviewForm != nil
+}
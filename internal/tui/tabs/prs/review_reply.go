@@ -0,0 +1,239 @@
+package prs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/util"
+	"github.com/mattn/go-runewidth"
+)
+
+// ReplyTemplateVars are the values substituted into review reply templates.
+type ReplyTemplateVars struct {
+	ChangeID  string // change ID of the PR head bookmark's commit
+	CommitID  string
+	CommitURL string // the head commit on GitHub
+	Author    string // author of the comment being replied to
+}
+
+// ExpandReplyTemplate replaces {change_id}, {commit_id}, {commit_url} and {author} in tmpl.
+// Unknown placeholders are kept.
+func ExpandReplyTemplate(tmpl string, vars ReplyTemplateVars) string {
+	return strings.NewReplacer(
+		"{change_id}", vars.ChangeID,
+		"{commit_id}", vars.CommitID,
+		"{commit_url}", vars.CommitURL,
+		"{author}", vars.Author,
+	).Replace(tmpl)
+}
+
+// replyTemplateVars resolves the placeholders for a reply to comment on pr: the head commit is
+// the graph commit carrying the PR's head bookmark, when it is loaded.
+func replyTemplateVars(repo *internal.Repository, pr internal.GitHubPR, comment internal.ReviewComment) ReplyTemplateVars {
+	vars := ReplyTemplateVars{Author: comment.Author}
+	if repo == nil || pr.HeadBranch == "" {
+		return vars
+	}
+	for _, c := range repo.Graph.Commits {
+		for _, b := range c.Branches {
+			if util.LocalBookmarkName(b) != pr.HeadBranch {
+				continue
+			}
+			vars.ChangeID, vars.CommitID = c.ChangeID, c.ID
+			if i := strings.LastIndex(pr.URL, "/pull/"); i > 0 && c.ID != "" {
+				vars.CommitURL = pr.URL[:i] + "/commit/" + c.ID
+			}
+			return vars
+		}
+	}
+	return vars
+}
+
+// ReviewReplySubmission is a request to reply in a review comment's thread.
+type ReviewReplySubmission struct {
+	PRNumber  int
+	CommentID int64
+	Location  string // path:line, for status messages
+	Body      string
+}
+
+// ReviewRepliedMsg is sent when ReplyToReviewCommentCmd finishes.
+type ReviewRepliedMsg struct {
+	state.RepoResult
+	PRNumber  int
+	CommentID int64
+	Location  string
+	Err       error
+}
+
+// ReplyToReviewCommentCmd posts the reply and sends ReviewRepliedMsg.
+func ReplyToReviewCommentCmd(ghSvc *github.Service, sub ReviewReplySubmission, demoMode bool) tea.Cmd {
+	done := func(err error) tea.Msg {
+		return ReviewRepliedMsg{PRNumber: sub.PRNumber, CommentID: sub.CommentID, Location: sub.Location, Err: err}
+	}
+	if demoMode {
+		return func() tea.Msg { return done(nil) }
+	}
+	if ghSvc == nil {
+		return nil
+	}
+	svc := ghSvc
+	return func() tea.Msg {
+		return done(svc.ReplyToReviewComment(context.Background(), sub.PRNumber, sub.CommentID, sub.Body))
+	}
+}
+
+// reviewReplyState is the reply composer opened from the review comment list (r).
+type reviewReplyState struct {
+	comment    internal.ReviewComment
+	templates  []string // expanded
+	template   int      // index of the template last inserted; -1 = none
+	body       textarea.Model
+	err        string
+	submitting bool
+}
+
+// openReviewReply opens the reply composer for the comment under the cursor.
+func (m *Model) openReviewReply() tea.Cmd {
+	st := m.reviewComments
+	pr := m.selectedPRData()
+	if st == nil || pr == nil || st.cursor >= len(st.comments) {
+		return nil
+	}
+	c := st.comments[st.cursor]
+	templates := m.replyTemplates
+	if len(templates) == 0 {
+		templates = config.DefaultReviewReplyTemplates
+	}
+	vars := replyTemplateVars(m.repository, *pr, c)
+	expanded := make([]string, len(templates))
+	for i, t := range templates {
+		expanded[i] = ExpandReplyTemplate(t, vars)
+	}
+	ta := textarea.New()
+	ta.Placeholder = "Reply to @" + c.Author + "... (Tab inserts a template)"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetWidth(max(m.width-6, 40))
+	ta.SetHeight(4)
+	st.reply = &reviewReplyState{comment: c, templates: expanded, template: -1, body: ta}
+	return st.reply.body.Focus()
+}
+
+// handleReviewReplyKey handles keys while the reply composer is open: Tab and Shift+Tab replace
+// the body with the next or previous template, Ctrl+S sends, Esc goes back to the list, and
+// everything else is typed into the body.
+func (m Model) handleReviewReplyKey(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	st := m.reviewComments
+	r := st.reply
+	switch msg.String() {
+	case "esc":
+		st.reply = nil
+		return m, nil, nil
+	case "tab", "shift+tab":
+		if len(r.templates) == 0 || r.submitting {
+			return m, nil, nil
+		}
+		switch {
+		case msg.String() == "tab":
+			r.template = (r.template + 1) % len(r.templates)
+		case r.template < 0:
+			r.template = len(r.templates) - 1
+		default:
+			r.template = (r.template + len(r.templates) - 1) % len(r.templates)
+		}
+		r.body.SetValue(r.templates[r.template])
+		return m, nil, nil
+	case "ctrl+s":
+		if r.submitting {
+			return m, nil, nil
+		}
+		body := strings.TrimSpace(r.body.Value())
+		if body == "" {
+			r.err = "The reply is empty"
+			return m, nil, nil
+		}
+		r.err = ""
+		r.submitting = true
+		return m, &Request{ReplyToReviewComment: &ReviewReplySubmission{
+			PRNumber:  st.prNumber,
+			CommentID: r.comment.ID,
+			Location:  fmt.Sprintf("%s:%d", r.comment.Path, r.comment.Line),
+			Body:      body,
+		}}, nil
+	}
+	if r.submitting {
+		return m, nil, nil
+	}
+	var cmd tea.Cmd
+	r.body, cmd = r.body.Update(msg)
+	return m, nil, cmd
+}
+
+// applyReviewReplied closes the composer after a successful reply, or keeps it open with the
+// error so the text is not lost. It returns the status message.
+func (m *Model) applyReviewReplied(msg ReviewRepliedMsg) string {
+	var r *reviewReplyState
+	if st := m.reviewComments; st != nil && st.prNumber == msg.PRNumber && st.reply != nil && st.reply.comment.ID == msg.CommentID {
+		r = st.reply
+	}
+	if msg.Err != nil {
+		if r != nil {
+			r.submitting = false
+			r.err = msg.Err.Error()
+		}
+		return fmt.Sprintf("Failed to reply on PR #%d: %v", msg.PRNumber, msg.Err)
+	}
+	if r != nil {
+		m.reviewComments.reply = nil
+	}
+	return fmt.Sprintf("Replied to the review comment on %s (PR #%d)", msg.Location, msg.PRNumber)
+}
+
+// renderReviewReply renders the reply composer below the comment it answers.
+func (m *Model) renderReviewReply() []string {
+	r := m.reviewComments.reply
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	width := max(m.width-4, 40)
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Reply to @%s on %s:%d", r.comment.Author, r.comment.Path, r.comment.Line)) +
+			muted.Render(" · Tab template · Ctrl+S send · Esc back"),
+		muted.Render(runewidth.Truncate("> "+strings.Join(strings.Fields(r.comment.Body), " "), width, "…")),
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorPrimary).
+		Render(r.body.View())
+	lines = append(lines, strings.Split(box, "\n")...)
+	if r.submitting {
+		lines = append(lines, muted.Render("Sending…"))
+	}
+	if r.err != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorNegative).Render(r.err))
+	}
+	lines = append(lines, muted.Render("Templates:"))
+	for i, t := range r.templates {
+		prefix := "  "
+		style := muted
+		if i == r.template {
+			prefix = "► "
+			style = lipgloss.NewStyle()
+		}
+		lines = append(lines, style.Render(runewidth.Truncate(prefix+strings.Join(strings.Fields(t), " "), width, "…")))
+	}
+	return lines
+}
+
+// IsReviewReplyOpen reports whether the reply composer is open; it owns the keyboard while open.
+func (m *Model) IsReviewReplyOpen() bool {
+	return m.reviewComments != nil && m.reviewComments.reply != nil
+}
@@ -7,8 +7,13 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/keymap"
+	"github.com/madicen/jj-tui/internal/tui/avatar"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/xref"
 )
 
 // mark wraps zone.Mark; if zoneManager is nil returns content unchanged
@@ -19,6 +24,16 @@ func mark(z *zone.Manager, id, content string) string {
 	return z.Mark(id, content)
 }
 
+// permissionButton renders label as a button, disabled when the token lacks c; the reason is
+// appended to unavailable so it can be shown under the buttons.
+func (m *Model) permissionButton(c github.Capability, label string, unavailable *[]string) string {
+	if m.permissions.Can(c) {
+		return styles.ButtonStyle.Render(label)
+	}
+	*unavailable = append(*unavailable, fmt.Sprintf("%s unavailable: %s", c, m.permissions.Reason(c)))
+	return styles.ButtonDisabledStyle.Render(label)
+}
+
 // renderPRs renders the PR list view (list-only scroll; details fixed)
 func (m *Model) renderPRs() string {
 	if !m.githubService {
@@ -36,6 +51,17 @@ func (m *Model) renderPRs() string {
 		}
 		return strings.Join(noGitHub, "\n")
 	}
+	if !m.permissions.Can(github.CapReadPRs) {
+		noAccess := []string{
+			styles.TitleStyle.Render("Pull Requests"),
+			"",
+			"GitHub is connected, but pull requests can't be read:",
+			lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("  " + m.permissions.Reason(github.CapReadPRs)),
+			"",
+			"Update the token's permissions, then reconnect in Settings (,) → GitHub.",
+		}
+		return strings.Join(noAccess, "\n")
+	}
 
 	if m.repository == nil || len(m.repository.PRs) == 0 {
 		emptyMsg := []string{
@@ -50,6 +76,9 @@ func (m *Model) renderPRs() string {
 	}
 
 	var headerLines []string
+	if m.upstreamSource != "" {
+		headerLines = append(headerLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("PRs on upstream "+m.upstreamSource+" (origin is a fork)"))
+	}
 
 	if m.selectedPR >= 0 && m.selectedPR < len(m.repository.PRs) {
 		pr := m.repository.PRs[m.selectedPR]
@@ -61,42 +90,53 @@ func (m *Model) renderPRs() string {
 			pr.Title,
 		)
 		if pr.IsDraft {
-			titleLine += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("#6a737d")).Render("[Draft]")
+			titleLine += "  " + lipgloss.NewStyle().Foreground(styles.ColorNeutral).Render("[Draft]")
 		}
 		detailLines = append(detailLines, titleLine)
 		detailLines = append(detailLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(pr.URL))
-		detailLines = append(detailLines, fmt.Sprintf("Base: %s ← Head: %s", pr.BaseBranch, pr.HeadBranch))
+		branchLine := fmt.Sprintf("Base: %s ← Head: %s", pr.BaseBranch, pr.HeadBranch)
+		if pr.Author != "" {
+			branchLine = avatar.Avatar(avatarURL(pr), pr.Author) + " @" + pr.Author + "  │  " + branchLine
+		}
+		detailLines = append(detailLines, branchLine)
 
 		var checkPart, reviewPart string
-		switch pr.CheckStatus {
-		case internal.CheckStatusSuccess:
-			checkPart = lipgloss.NewStyle().Foreground(lipgloss.Color("#2ea44f")).Render("✓ Checks passed")
-		case internal.CheckStatusFailure:
-			checkPart = lipgloss.NewStyle().Foreground(lipgloss.Color("#cb2431")).Render("✗ Checks failed")
-		case internal.CheckStatusPending:
-			checkPart = lipgloss.NewStyle().Foreground(lipgloss.Color("#dbab09")).Render("○ Checks pending")
+		switch {
+		case !m.permissions.Can(github.CapReadChecks):
+			checkPart = lipgloss.NewStyle().Foreground(styles.ColorNeutral).Render(styles.GlyphNone + " Checks unavailable (" + m.permissions.Reason(github.CapReadChecks) + ")")
+		case pr.CheckStatus == internal.CheckStatusSuccess:
+			checkPart = lipgloss.NewStyle().Foreground(styles.ColorSuccess).Render(styles.GlyphSuccess + " Checks passed")
+		case pr.CheckStatus == internal.CheckStatusFailure:
+			checkPart = lipgloss.NewStyle().Foreground(styles.ColorFailure).Render(styles.GlyphFailure + " Checks failed")
+		case pr.CheckStatus == internal.CheckStatusPending:
+			checkPart = lipgloss.NewStyle().Foreground(styles.ColorPending).Render(styles.GlyphPending + " Checks pending")
 		default:
-			checkPart = lipgloss.NewStyle().Foreground(lipgloss.Color("#6a737d")).Render("· No checks")
+			checkPart = lipgloss.NewStyle().Foreground(styles.ColorNeutral).Render(styles.GlyphNone + " No checks")
 		}
 		switch pr.ReviewStatus {
 		case internal.ReviewStatusApproved:
-			reviewPart = lipgloss.NewStyle().Foreground(lipgloss.Color("#2ea44f")).Render("✓ Approved")
+			reviewPart = lipgloss.NewStyle().Foreground(styles.ColorSuccess).Render(styles.GlyphSuccess + " Approved")
 		case internal.ReviewStatusChangesRequested:
-			reviewPart = lipgloss.NewStyle().Foreground(lipgloss.Color("#cb2431")).Render("✗ Changes requested")
+			reviewPart = lipgloss.NewStyle().Foreground(styles.ColorFailure).Render(styles.GlyphFailure + " Changes requested")
 		case internal.ReviewStatusPending:
-			reviewPart = lipgloss.NewStyle().Foreground(lipgloss.Color("#dbab09")).Render("○ Review pending")
+			reviewPart = lipgloss.NewStyle().Foreground(styles.ColorPending).Render(styles.GlyphPending + " Review pending")
 		default:
-			reviewPart = lipgloss.NewStyle().Foreground(lipgloss.Color("#6a737d")).Render("· No reviews")
+			reviewPart = lipgloss.NewStyle().Foreground(styles.ColorNeutral).Render(styles.GlyphNone + " No reviews")
 		}
 		detailLines = append(detailLines, checkPart+"  │  "+reviewPart)
 
-		if pr.Body != "" {
-			desc := strings.ReplaceAll(pr.Body, "\n", " ")
-			desc = strings.ReplaceAll(desc, "\r", "")
-			if len(desc) > 150 {
-				desc = desc[:150] + "..."
+		for _, ref := range deploymentRefs(pr) {
+			if deps, ok := m.deployments[ref]; ok {
+				detailLines = append(detailLines, renderDeploymentsLine(deploymentLabel(pr, ref), deps))
 			}
-			detailLines = append(detailLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(desc))
+		}
+
+		if pr.Body != "" {
+			desc := bodyExcerpt(pr.Body)
+			muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+			detailLines = append(detailLines, xref.Render(desc, m.bodyRefs(desc), muted, func(i int, tok string) string {
+				return mark(m.zoneManager, mouse.ZonePRBodyRef(i), tok)
+			}))
 		} else {
 			detailLines = append(detailLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Italic(true).Render("(No description)"))
 		}
@@ -108,75 +148,49 @@ func (m *Model) renderPRs() string {
 			Render(strings.Join(detailLines, "\n"))
 		headerLines = append(headerLines, detailsBox)
 
-		separatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#444444"))
+		separatorStyle := lipgloss.NewStyle().Foreground(styles.ColorSeparator)
 		separatorWidth := m.width - 4
 		if separatorWidth < 20 {
 			separatorWidth = 80
 		}
 		separator := separatorStyle.Render(strings.Repeat("─", separatorWidth))
 		headerLines = append(headerLines, separator)
-		headerLines = append(headerLines, "Actions:")
+		headerLines = append(headerLines, i18n.T("label.actions"))
 
 		var actionButtons []string
-		actionButtons = append(actionButtons, mark(m.zoneManager, mouse.ZonePROpenBrowser, styles.ButtonStyle.Render("Open in Browser (o)")))
+		actionButtons = append(actionButtons,
+			mark(m.zoneManager, mouse.ZonePROpenBrowser, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.open_in_browser"), "pr.open"))),
+			mark(m.zoneManager, mouse.ZonePRRead, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.read"), "pr.read"))),
+			mark(m.zoneManager, mouse.ZonePRDetails, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.pr_details"), "pr.details"))),
+			mark(m.zoneManager, mouse.ZonePRDiff, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.pr_diff"), "pr.diff"))),
+		)
+		var unavailable []string
 		if pr.State == "open" {
 			actionButtons = append(actionButtons,
-				mark(m.zoneManager, mouse.ZonePRMerge, styles.ButtonStyle.Render("Merge (M)")),
-				mark(m.zoneManager, mouse.ZonePRClose, styles.ButtonStyle.Render("Close (X)")),
+				mark(m.zoneManager, mouse.ZonePRMerge, m.permissionButton(github.CapMergePR, keymap.Button(i18n.T("action.merge_pr"), "pr.merge"), &unavailable)),
+				mark(m.zoneManager, mouse.ZonePRClose, m.permissionButton(github.CapClosePR, keymap.Button(i18n.T("action.close_pr"), "pr.close"), &unavailable)),
+				mark(m.zoneManager, mouse.ZonePRReview, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.review"), "pr.review"))),
 			)
 		}
+		actionButtons = append(actionButtons, mark(m.zoneManager, mouse.ZonePRDeployments, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.deployments"), "pr.deployments"))))
 		headerLines = append(headerLines, strings.Join(actionButtons, " "))
+		if len(unavailable) > 0 {
+			headerLines = append(headerLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(strings.Join(unavailable, "; ")))
+		}
 		headerLines = append(headerLines, separator)
 	}
 
 	var listLines []string
-	for i, pr := range m.repository.PRs {
-		prefix := "  "
-		style := styles.CommitStyle
-		if i == m.selectedPR {
-			prefix = "► "
-			style = styles.CommitSelectedStyle
-		}
-		var stateIndicator string
-		switch pr.State {
-		case "open":
-			if pr.IsDraft {
-				stateIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#6a737d")).Render("●")
-			} else {
-				stateIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#2ea44f")).Render("●")
-			}
-		case "closed":
-			stateIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#cb2431")).Render("●")
-		case "merged":
-			stateIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#6f42c1")).Render("●")
-		default:
-			stateIndicator = "○"
-		}
-		var checkIndicator string
-		switch pr.CheckStatus {
-		case internal.CheckStatusSuccess:
-			checkIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#2ea44f")).Render("✓")
-		case internal.CheckStatusFailure:
-			checkIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#cb2431")).Render("✗")
-		case internal.CheckStatusPending:
-			checkIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#dbab09")).Render("○")
-		default:
-			checkIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#6a737d")).Render("·")
-		}
-		var reviewIndicator string
-		switch pr.ReviewStatus {
-		case internal.ReviewStatusApproved:
-			reviewIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#2ea44f")).Render(styles.ReviewApprovedMark)
-		case internal.ReviewStatusChangesRequested:
-			reviewIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#cb2431")).Render(styles.ReviewChangesRequestedMark)
-		case internal.ReviewStatusPending:
-			reviewIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#dbab09")).Render(styles.ReviewPendingMark)
-		default:
-			reviewIndicator = lipgloss.NewStyle().Foreground(lipgloss.Color("#6a737d")).Render("·")
-		}
-		prLine := fmt.Sprintf("%s%s %s%s #%d %s",
-			prefix, stateIndicator, checkIndicator, reviewIndicator, pr.Number, pr.Title)
-		listLines = append(listLines, mark(m.zoneManager, mouse.ZonePR(i), style.Render(prLine)))
+	if m.mergeForm != nil {
+		listLines = m.renderMergeForm()
+	} else if m.reviewForm != nil {
+		listLines = m.renderReviewForm()
+	} else if m.detail != nil {
+		listLines = m.renderPRDetail()
+	} else if m.reviewComments != nil {
+		listLines = m.renderReviewComments()
+	} else {
+		listLines = m.renderPRRows()
 	}
 
 	fixedHeader := strings.Join(headerLines, "\n")
@@ -230,3 +244,123 @@ func (m *Model) renderPRs() string {
 	}
 	return strings.Join(outLines, "\n")
 }
+
+// renderPRRows renders one line per PR for the list under the details box.
+func (m *Model) renderPRRows() []string {
+	var listLines []string
+	for i, pr := range m.repository.PRs {
+		prefix := "  "
+		style := styles.CommitStyle
+		if i == m.selectedPR {
+			prefix = "► "
+			style = styles.CommitSelectedStyle
+		}
+		var stateIndicator string
+		switch pr.State {
+		case "open":
+			if pr.IsDraft {
+				stateIndicator = lipgloss.NewStyle().Foreground(styles.ColorNeutral).Render(styles.PRStateDraftMark)
+			} else {
+				stateIndicator = lipgloss.NewStyle().Foreground(styles.ColorSuccess).Render(styles.PRStateOpenMark)
+			}
+		case "closed":
+			stateIndicator = lipgloss.NewStyle().Foreground(styles.ColorFailure).Render(styles.PRStateClosedMark)
+		case "merged":
+			stateIndicator = lipgloss.NewStyle().Foreground(styles.ColorMerged).Render(styles.PRStateMergedMark)
+		default:
+			stateIndicator = "○"
+		}
+		var checkIndicator string
+		switch pr.CheckStatus {
+		case internal.CheckStatusSuccess:
+			checkIndicator = lipgloss.NewStyle().Foreground(styles.ColorSuccess).Render(styles.GlyphSuccess)
+		case internal.CheckStatusFailure:
+			checkIndicator = lipgloss.NewStyle().Foreground(styles.ColorFailure).Render(styles.GlyphFailure)
+		case internal.CheckStatusPending:
+			checkIndicator = lipgloss.NewStyle().Foreground(styles.ColorPending).Render(styles.GlyphPending)
+		default:
+			checkIndicator = lipgloss.NewStyle().Foreground(styles.ColorNeutral).Render(styles.GlyphNone)
+		}
+		var reviewIndicator string
+		switch pr.ReviewStatus {
+		case internal.ReviewStatusApproved:
+			reviewIndicator = lipgloss.NewStyle().Foreground(styles.ColorSuccess).Render(styles.ReviewApprovedMark)
+		case internal.ReviewStatusChangesRequested:
+			reviewIndicator = lipgloss.NewStyle().Foreground(styles.ColorFailure).Render(styles.ReviewChangesRequestedMark)
+		case internal.ReviewStatusPending:
+			reviewIndicator = lipgloss.NewStyle().Foreground(styles.ColorPending).Render(styles.ReviewPendingMark)
+		default:
+			reviewIndicator = lipgloss.NewStyle().Foreground(styles.ColorNeutral).Render(styles.GlyphNone)
+		}
+		prLine := fmt.Sprintf("%s%s %s%s #%d %s",
+			prefix, stateIndicator, checkIndicator, reviewIndicator, pr.Number, pr.Title)
+		row := style.Render(prLine)
+		if badge := checklistBadge(pr.Body); badge != "" {
+			row += " " + badge
+		}
+		listLines = append(listLines, mark(m.zoneManager, mouse.ZonePR(i), row))
+	}
+	return listLines
+}
+
+// deploymentLabel names ref in the deployments line: the head SHA reads as branch@short-sha.
+func deploymentLabel(pr internal.GitHubPR, ref string) string {
+	if ref == pr.HeadSHA && len(ref) > 8 {
+		return pr.HeadBranch + "@" + ref[:8]
+	}
+	return ref
+}
+
+// renderDeploymentsLine renders one "Deploys (ref): env state · env state" line for the details box.
+func renderDeploymentsLine(ref string, deps []internal.Deployment) string {
+	label := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Deploys (%s):", ref))
+	if len(deps) == 0 {
+		return label + " " + lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("none")
+	}
+	parts := make([]string, 0, len(deps))
+	for _, d := range deps {
+		var color lipgloss.Color
+		var glyph string
+		switch d.State {
+		case "success":
+			color, glyph = styles.ColorSuccess, styles.GlyphSuccess
+		case "failure", "error":
+			color, glyph = styles.ColorFailure, styles.GlyphFailure
+		case "inactive":
+			color, glyph = styles.ColorNeutral, styles.GlyphNone
+		default:
+			color, glyph = styles.ColorPending, styles.GlyphPending
+		}
+		part := lipgloss.NewStyle().Foreground(color).Render(fmt.Sprintf("%s %s %s", glyph, d.Environment, d.State))
+		if d.URL != "" && d.State == "success" {
+			part += " " + lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(d.URL)
+		}
+		parts = append(parts, part)
+	}
+	return label + " " + strings.Join(parts, "  ")
+}
+
+// bodyExcerpt flattens a PR body to the one-line excerpt shown in the details box.
+func bodyExcerpt(body string) string {
+	desc := strings.ReplaceAll(body, "\n", " ")
+	desc = strings.ReplaceAll(desc, "\r", "")
+	if len(desc) > 150 {
+		desc = desc[:150] + "..."
+	}
+	return desc
+}
+
+// bodyRefs finds the cross-references in a PR body excerpt; change IDs are only linked when the
+// commit is in the loaded graph.
+func (m *Model) bodyRefs(desc string) []xref.Ref {
+	if m.isChangeRepo != m.repository || m.isChange == nil {
+		var ids []string
+		if m.repository != nil {
+			for _, c := range m.repository.Graph.Commits {
+				ids = append(ids, c.ChangeID)
+			}
+		}
+		m.isChange, m.isChangeRepo = xref.ChangeMatcher(ids), m.repository
+	}
+	return xref.Find(desc, m.isChange)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/styles"
 	"github.com/madicen/jj-tui/internal/tui/tabs/settings/ai"
+	uitheme "github.com/madicen/jj-tui/internal/tui/theme"
 	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
@@ -46,17 +47,20 @@ type SettingsParams struct {
 	PRLimit                      int
 	PRRefreshInterval            int
 	AutoInProgress               bool
-	BranchLimit                  int
 	BranchesShowAllRemotes       bool
 	SanitizeBookmarks            bool
+	LowBandwidth                 bool
 	GraphRevset                  string
 	GitHubOwner                  string
 	GitHubRepo                   string
+	Theme                        string
 	ThemePrimary                 string
 	ThemeSecondary               string
 	ThemeMuted                   string
+	ThemePalette                 string
 	ExternalFileEditor           string
 	ExternalFileEditorCustom     string
+	MergeTool                    string
 	AIEnabled                    bool
 	AIBaseURL                    string
 	AIModel                      string
@@ -83,6 +87,19 @@ const (
 	CancelCleanupStatus          = "Cleanup cancelled"
 )
 
+// testMergeTool asks main to test-launch the selected merge tool, or explains why it can't.
+func (m *Model) testMergeTool() tea.Cmd {
+	adv := m.GetAdvancedModel()
+	t, ok := jj.LookupMergeTool(adv.MergeTool())
+	switch {
+	case !ok:
+		return RequestSetStatusCmd("Pick a merge tool to test (jj's default editor is only run by jj resolve)")
+	case !adv.MergeToolInstalled(t.Name):
+		return RequestSetStatusCmd(t.Label + " is not installed (" + t.Program + " not found in PATH)")
+	}
+	return RequestTestMergeToolCmd(t.Name)
+}
+
 // HandleCleanupCompletedMsg mutates app and returns the Cmd to run.
 func HandleCleanupCompletedMsg(msg CleanupCompletedMsg, app *state.AppState) tea.Cmd {
 	app.Loading = false
@@ -108,7 +125,11 @@ func HandleSettingsSavedMsg(msg SettingsSavedMsg, app *state.AppState) (tea.Cmd,
 	cfg, _ := config.Load()
 	app.Config = cfg
 	if cfg != nil {
+		_ = uitheme.Load(cfg.Themes)
+		t, _ := uitheme.Lookup(cfg.GetTheme())
+		styles.ApplyTheme(t)
 		styles.SetTheme(cfg.GetThemePrimary(), cfg.GetThemeSecondary(), cfg.GetThemeMuted())
+		styles.SetPalette(cfg.GetThemePalette())
 	}
 	if msg.Err != nil {
 		app.StatusMessage = fmt.Sprintf("Error saving settings: %v", msg.Err)
@@ -185,9 +206,9 @@ func BuildSettingsParams(m *Model, githubOwner, githubRepo string) SettingsParam
 		PRLimit:                gh.GetPRLimit(),
 		PRRefreshInterval:      gh.GetRefreshInterval(),
 		AutoInProgress:         tk.GetAutoInProgress(),
-		BranchLimit:            br.GetBranchLimit(),
 		BranchesShowAllRemotes: br.GetShowAllRemotes(),
 		SanitizeBookmarks:      adv.GetSanitizeBookmarks(),
+		LowBandwidth:           adv.GetLowBandwidth(),
 		GraphRevset:            strings.TrimSpace(adv.GetGraphRevset()),
 		GitHubOwner:            githubOwner,
 		GitHubRepo:             githubRepo,
@@ -195,6 +216,7 @@ func BuildSettingsParams(m *Model, githubOwner, githubRepo string) SettingsParam
 	preset, custom := adv.SavedExternalEditor()
 	params.ExternalFileEditor = preset
 	params.ExternalFileEditorCustom = custom
+	params.MergeTool = adv.MergeTool()
 	params.AIEnabled = aim.GetAIEnabled()
 	// Profiles + active. The visible inputs already correspond to the selected
 	// profile; aim.Profiles() commits any unsaved edits before snapshotting.
@@ -245,9 +267,9 @@ func BuildSettingsParams(m *Model, githubOwner, githubRepo string) SettingsParam
 	params.GitHubIssuesExcludedStatuses = strings.TrimSpace(tk.GetGitHubIssuesExcludedStatuses())
 	th := m.GetThemeModel()
 	if th != nil {
-		params.ThemePrimary = th.Primary()
-		params.ThemeSecondary = th.Secondary()
-		params.ThemeMuted = th.Muted()
+		params.Theme = th.Theme()
+		params.ThemePrimary, params.ThemeSecondary, params.ThemeMuted = th.Overrides()
+		params.ThemePalette = th.Palette()
 	}
 	return params
 }
@@ -343,15 +365,18 @@ func SaveSettingsCmd(params SettingsParams) tea.Cmd {
 		cfg.CodecksProject = params.CodecksProject
 		cfg.CodecksExcludedStatuses = params.CodecksExcludedStatuses
 		cfg.GitHubIssuesExcludedStatuses = params.GitHubIssuesExcludedStatuses
-		cfg.BranchStatsLimit = &params.BranchLimit
 		cfg.BranchesShowAllRemotes = &params.BranchesShowAllRemotes
 		cfg.SanitizeBookmarkNames = &params.SanitizeBookmarks
+		cfg.LowBandwidth = &params.LowBandwidth
 		cfg.GraphRevset = params.GraphRevset
 		cfg.ExternalFileEditor = params.ExternalFileEditor
 		cfg.ExternalFileEditorCustom = params.ExternalFileEditorCustom
+		cfg.MergeTool = params.MergeTool
+		cfg.Theme = params.Theme
 		cfg.ThemePrimary = params.ThemePrimary
 		cfg.ThemeSecondary = params.ThemeSecondary
 		cfg.ThemeMuted = params.ThemeMuted
+		cfg.ThemePalette = params.ThemePalette
 		aiOn := params.AIEnabled
 		cfg.AIEnabled = &aiOn
 		cfg.AIBaseURL = strings.TrimSpace(params.AIBaseURL)
@@ -419,12 +444,13 @@ func SaveSettingsLocalCmd(params SettingsParams) tea.Cmd {
 			GitHubPRLimit:                     &params.PRLimit,
 			GitHubRefreshInterval:             &params.PRRefreshInterval,
 			TicketAutoInProgress:              &params.AutoInProgress,
-			BranchStatsLimit:                  &params.BranchLimit,
 			BranchesShowAllRemotes:            &params.BranchesShowAllRemotes,
 			SanitizeBookmarkNames:             &params.SanitizeBookmarks,
+			LowBandwidth:                      &params.LowBandwidth,
 			GraphRevset:                       params.GraphRevset,
 			ExternalFileEditor:                params.ExternalFileEditor,
 			ExternalFileEditorCustom:          params.ExternalFileEditorCustom,
+			MergeTool:                         params.MergeTool,
 			AIEnabled:                         &aiOn,
 			AIBaseURL:                         strings.TrimSpace(params.AIBaseURL),
 			AIModel:                           strings.TrimSpace(params.AIModel),
@@ -438,9 +464,11 @@ func SaveSettingsLocalCmd(params SettingsParams) tea.Cmd {
 			AIEvologHunkSplitEnabled:          &evHunk,
 			AIEvologMultiSplitMax:             &evMax,
 			AIEvologMultiSplitMode:            evMode,
+			Theme:                             params.Theme,
 			ThemePrimary:                      params.ThemePrimary,
 			ThemeSecondary:                    params.ThemeSecondary,
 			ThemeMuted:                        params.ThemeMuted,
+			ThemePalette:                      params.ThemePalette,
 			JiraProject:                       params.JiraProject,
 			JiraProjectFilter:                 params.JiraProjectFilter,
 			JiraIssueType:                     params.JiraIssueType,
@@ -588,6 +616,73 @@ func AbandonOldCommitsCmd(jjSvc *jj.Service, repo *internal.Repository) tea.Cmd
 	}
 }
 
+// LoadImmutableHeadsCmd reads jj's immutable_heads() definition.
+func LoadImmutableHeadsCmd(jjSvc *jj.Service) tea.Cmd {
+	if jjSvc == nil {
+		return func() tea.Msg {
+			return ImmutableHeadsLoadedMsg{Err: fmt.Errorf("jj service not initialized")}
+		}
+	}
+	return func() tea.Msg {
+		revset, err := jjSvc.ImmutableHeads(context.Background())
+		return ImmutableHeadsLoadedMsg{Revset: revset, Err: err}
+	}
+}
+
+// SaveImmutableHeadsCmd writes immutable_heads() to the repo config ("" restores jj's default).
+func SaveImmutableHeadsCmd(jjSvc *jj.Service, revset string) tea.Cmd {
+	if jjSvc == nil {
+		return func() tea.Msg {
+			return ImmutableHeadsSavedMsg{Err: fmt.Errorf("jj service not initialized")}
+		}
+	}
+	return func() tea.Msg {
+		err := jjSvc.SetImmutableHeads(context.Background(), revset)
+		return ImmutableHeadsSavedMsg{Revset: revset, Err: err}
+	}
+}
+
+// TestMergeToolCmd opens the named merge tool on a sample conflict, handing it the terminal until
+// it exits (terminal tools like vimdiff need it).
+func TestMergeToolCmd(name string) tea.Cmd {
+	t, ok := jj.LookupMergeTool(name)
+	if !ok {
+		return nil
+	}
+	cmd, cleanup, err := t.TestLaunchCommand(context.Background())
+	if err != nil {
+		return func() tea.Msg { return MergeToolTestedMsg{Tool: t.Label, Err: err} }
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		cleanup()
+		return MergeToolTestedMsg{Tool: t.Label, Err: err}
+	})
+}
+
+// HandleImmutableHeadsSavedMsg applies a save result to the editor and app; on success it closes
+// the editor and reloads the graph, whose immutable markers depend on the setting.
+func HandleImmutableHeadsSavedMsg(msg ImmutableHeadsSavedMsg, m *Model, app *state.AppState) tea.Cmd {
+	adv := m.GetAdvancedModel()
+	if msg.Err != nil {
+		adv.SetImmutableHeadsSaveError(msg.Err)
+		app.StatusMessage = fmt.Sprintf("Failed to set immutable_heads(): %v", msg.Err)
+		return nil
+	}
+	revset := msg.Revset
+	if revset == "" {
+		revset = jj.BuiltinImmutableHeads
+		app.StatusMessage = "immutable_heads() reset to jj's default"
+	} else {
+		app.StatusMessage = fmt.Sprintf("immutable_heads() set to %s", revset)
+	}
+	adv.SetImmutableHeads(revset, nil)
+	cmd := adv.CloseImmutableHeadsEditor()
+	if app.JJService != nil {
+		return tea.Batch(cmd, data.LoadRepository(app.JJService))
+	}
+	return cmd
+}
+
 // NewInputs creates and initializes all settings input fields from config and env.
 func NewInputs(cfg *config.Config) []textinput.Model {
 	inputs := make([]textinput.Model, 15)
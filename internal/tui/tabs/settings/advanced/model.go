@@ -9,12 +9,15 @@ import (
 	bubbledropdown "github.com/madicen/bubble-dropdown"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/styles"
 )
 
-// Model represents the Advanced settings sub-tab (sanitize bookmarks, graph revset, external editor, cleanup).
+// Model represents the Advanced settings sub-tab (sanitize bookmarks, low-bandwidth mode, graph
+// revset, external editor, cleanup).
 type Model struct {
 	sanitizeBookmarks    bool
+	lowBandwidth         bool
 	confirmingCleanup    string
 	graphRevsetInput     textinput.Model
 	customEditorInput    textinput.Model
@@ -24,6 +27,21 @@ type Model struct {
 	// editorDropdown replaces the old radio rows for picking the external editor
 	// preset. The selected index maps 1:1 onto externalEditorPreset.
 	editorDropdown *bubbledropdown.Dropdown
+
+	// mergeTool is the jj.MergeTools name the conflict viewer resolves with ("" = jj's ui.merge-editor).
+	mergeTool string
+	// mergeToolsInstalled records which jj.MergeTools were on PATH when Settings opened.
+	mergeToolsInstalled map[string]bool
+
+	// immutableHeads is jj's immutable_heads() definition as last read from jj ("" = not loaded).
+	immutableHeads string
+	// The immutable_heads() editor (Ctrl+O) owns the keyboard while open; its input is not one of
+	// the global settings inputs.
+	editingImmutable   bool
+	immutableLoading   bool
+	immutableSaving    bool
+	immutableErr       string
+	immutableHeadInput textinput.Model
 }
 
 // ExternalEditorPresetLabels are UI labels for each editor preset (same order as config values below).
@@ -63,6 +81,11 @@ func NewModel() Model {
 	customIn.CharLimit = 400
 	customIn.Width = 60
 
+	immutableIn := textinput.New()
+	immutableIn.Placeholder = "empty = jj default (" + jj.BuiltinImmutableHeads + ")"
+	immutableIn.CharLimit = 500
+	immutableIn.Width = 60
+
 	return Model{
 		sanitizeBookmarks: true,
 		confirmingCleanup: "",
@@ -74,17 +97,29 @@ func NewModel() Model {
 			bubbledropdown.WithMaxVisible(len(ExternalEditorPresetLabels)),
 			bubbledropdown.WithAccentColor(string(styles.ColorPrimary)),
 		),
+		immutableHeadInput:  immutableIn,
+		mergeToolsInstalled: detectMergeTools(),
 	}
 }
 
+func detectMergeTools() map[string]bool {
+	installed := make(map[string]bool, len(jj.MergeTools))
+	for _, t := range jj.MergeTools {
+		installed[t.Name] = t.Installed()
+	}
+	return installed
+}
+
 // NewModelFromConfig creates a model initialized from config.
 func NewModelFromConfig(cfg *config.Config) Model {
 	m := NewModel()
 	if cfg != nil {
 		m.sanitizeBookmarks = cfg.ShouldSanitizeBookmarkNames()
+		m.lowBandwidth = cfg.LowBandwidthMode()
 		m.graphRevsetInput.SetValue(cfg.GraphRevset)
 		m.customEditorInput.SetValue(cfg.ExternalFileEditorCustom)
 		m.externalEditorPreset = presetIndexFromConfig(cfg.ExternalFileEditor)
+		m.SetMergeTool(cfg.MergeTool)
 	}
 	m.editorDropdown.SetSelectedIndex(m.externalEditorPreset)
 	return m
@@ -107,6 +142,9 @@ func (m Model) Init() tea.Cmd {
 
 // Update handles messages (key handling for inputs; zones handled by parent)
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.editingImmutable {
+		return m, m.UpdateImmutableHeadsInput(msg)
+	}
 	switch m.focusedField {
 	case 0:
 		var cmd tea.Cmd
@@ -136,6 +174,16 @@ func (m *Model) SetSanitizeBookmarks(sanitize bool) {
 	m.sanitizeBookmarks = sanitize
 }
 
+// GetLowBandwidth returns whether background network use is off
+func (m *Model) GetLowBandwidth() bool {
+	return m.lowBandwidth
+}
+
+// SetLowBandwidth sets whether background network use is off
+func (m *Model) SetLowBandwidth(on bool) {
+	m.lowBandwidth = on
+}
+
 // GetGraphRevset returns the graph revset string
 func (m *Model) GetGraphRevset() string {
 	return m.graphRevsetInput.Value()
@@ -196,6 +244,7 @@ func (m *Model) SetInputWidth(w int) {
 	}
 	m.graphRevsetInput.Width = w
 	m.customEditorInput.Width = w
+	m.immutableHeadInput.Width = w
 }
 
 // GetExternalEditorPreset returns the selected editor preset index (0..len(ExternalEditorPresetLabels)-1).
@@ -258,5 +307,125 @@ func (m *Model) SavedExternalEditor() (preset string, custom string) {
 	return externalEditorPresetConfig[i], strings.TrimSpace(m.customEditorInput.Value())
 }
 
+// MergeTool returns the selected merge tool's jj name ("" = jj's default merge editor).
+func (m *Model) MergeTool() string {
+	return m.mergeTool
+}
+
+// SetMergeTool selects a merge tool by name; unknown names select jj's default.
+func (m *Model) SetMergeTool(name string) {
+	t, _ := jj.LookupMergeTool(name)
+	m.mergeTool = t.Name
+}
+
+// MergeToolInstalled reports whether the named merge tool was found on PATH.
+func (m *Model) MergeToolInstalled(name string) bool {
+	return m.mergeToolsInstalled[name]
+}
+
+// CycleMergeTool selects the next merge tool, wrapping through jj's default.
+func (m *Model) CycleMergeTool() {
+	next := 0
+	for i, t := range jj.MergeTools {
+		if t.Name == m.mergeTool {
+			next = i + 1
+		}
+	}
+	if next < len(jj.MergeTools) {
+		m.mergeTool = jj.MergeTools[next].Name
+	} else {
+		m.mergeTool = ""
+	}
+}
+
+// OpenImmutableHeadsEditor opens the immutable_heads() editor; the caller loads the current
+// definition and passes it to SetImmutableHeads.
+func (m *Model) OpenImmutableHeadsEditor() tea.Cmd {
+	m.editingImmutable = true
+	m.immutableLoading = true
+	m.immutableSaving = false
+	m.immutableErr = ""
+	m.graphRevsetInput.Blur()
+	m.customEditorInput.Blur()
+	return m.immutableHeadInput.Focus()
+}
+
+// CloseImmutableHeadsEditor closes the immutable_heads() editor and refocuses the graph revset.
+func (m *Model) CloseImmutableHeadsEditor() tea.Cmd {
+	m.editingImmutable = false
+	m.immutableSaving = false
+	m.immutableErr = ""
+	m.immutableHeadInput.Blur()
+	return m.SetFocusedField(m.focusedField)
+}
+
+// IsEditingImmutableHeads reports whether the immutable_heads() editor is open.
+func (m *Model) IsEditingImmutableHeads() bool {
+	return m.editingImmutable
+}
+
+// SetImmutableHeads records jj's current immutable_heads() definition (or the error reading it)
+// and prefills the editor; jj's built-in default shows as an empty input.
+func (m *Model) SetImmutableHeads(revset string, err error) {
+	m.immutableLoading = false
+	if err != nil {
+		m.immutableErr = err.Error()
+		return
+	}
+	m.immutableHeads = revset
+	if m.editingImmutable {
+		if revset == jj.BuiltinImmutableHeads {
+			revset = ""
+		}
+		m.immutableHeadInput.SetValue(revset)
+		m.immutableHeadInput.CursorEnd()
+	}
+}
+
+// ImmutableHeads returns the last loaded immutable_heads() definition ("" = not loaded yet).
+func (m *Model) ImmutableHeads() string {
+	return m.immutableHeads
+}
+
+// ImmutableHeadsInput returns the edited immutable_heads() definition.
+func (m *Model) ImmutableHeadsInput() string {
+	return strings.TrimSpace(m.immutableHeadInput.Value())
+}
+
+// ApplyImmutableHeadsPreset fills the editor with preset i.
+func (m *Model) ApplyImmutableHeadsPreset(i int) {
+	if i < 0 || i >= len(jj.ImmutableHeadsPresets) {
+		return
+	}
+	m.immutableHeadInput.SetValue(jj.ImmutableHeadsPresets[i].Revset)
+	m.immutableHeadInput.CursorEnd()
+	m.immutableErr = ""
+}
+
+// SetImmutableHeadsSaving marks the edited definition as being written.
+func (m *Model) SetImmutableHeadsSaving() {
+	m.immutableSaving = true
+	m.immutableErr = ""
+}
+
+// SetImmutableHeadsSaveError keeps the editor open with the reason jj rejected the definition.
+func (m *Model) SetImmutableHeadsSaveError(err error) {
+	m.immutableSaving = false
+	m.immutableErr = err.Error()
+}
+
+// ImmutableHeadsEditorState returns the editor's input view, whether it is loading or saving, and
+// the last error.
+func (m *Model) ImmutableHeadsEditorState() (inputView string, loading, saving bool, errMsg string) {
+	return m.immutableHeadInput.View(), m.immutableLoading, m.immutableSaving, m.immutableErr
+}
+
+// UpdateImmutableHeadsInput forwards a message to the immutable_heads() input.
+func (m *Model) UpdateImmutableHeadsInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.immutableHeadInput, cmd = m.immutableHeadInput.Update(msg)
+	return cmd
+}
+
 // UpdateRepository updates the repository
 func (m *Model) UpdateRepository(repo *internal.Repository) {}
@@ -0,0 +1,36 @@
+package settings
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+)
+
+// The Advanced tab lists every graph revset preset next to the revset input.
+func TestRenderAdvanced_GraphRevsetPresets(t *testing.T) {
+	t.Parallel()
+
+	r := renderCtx{}
+	data := RenderData{Inputs: make([]struct{ View string }, 16), GraphRevset: "all()"}
+	out := strings.Join(r.renderAdvanced(data, 0), "\n")
+	for _, p := range jj.GraphRevsetPresets {
+		if !strings.Contains(out, "["+p.Name+"]") {
+			t.Errorf("renderAdvanced output missing preset %q\n%s", p.Name, out)
+		}
+	}
+}
+
+// Clicking a preset fills the revset input; the Default preset empties it.
+func TestHandleAdvancedZone_GraphRevsetPreset(t *testing.T) {
+	m := NewModel()
+	adv := m.GetAdvancedModel()
+	for i, p := range jj.GraphRevsetPresets {
+		adv.SetGraphRevset("x")
+		handleAdvancedZone(&m, mouse.ZoneSettingsGraphRevsetPreset(i))
+		if got := adv.GetGraphRevset(); got != p.Revset {
+			t.Errorf("preset %q: revset = %q, want %q", p.Name, got, p.Revset)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package settings
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+)
+
+// Ctrl+O on the Advanced tab opens the immutable_heads() editor and asks main to load the current
+// definition; a preset click fills the input and Enter asks main to save it.
+func TestImmutableHeadsEditor(t *testing.T) {
+	m := NewModel()
+	m.SetActiveSettingsTabIndex(7) // Advanced
+	m, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlO})
+	adv := m.GetAdvancedModel()
+	if !adv.IsEditingImmutableHeads() || !m.EscHandledInsideSettings() {
+		t.Fatal("Ctrl+O should open the immutable_heads() editor")
+	}
+	adv.SetImmutableHeads(jj.BuiltinImmutableHeads, nil)
+	if got := adv.ImmutableHeadsInput(); got != "" {
+		t.Fatalf("jj's default should show as an empty input, got %q", got)
+	}
+
+	want := jj.ImmutableHeadsPresets[1].Revset
+	m, _ = handleAdvancedZone(&m, mouse.ZoneSettingsImmutableHeadsPreset(1))
+	adv = m.GetAdvancedModel()
+	if got := adv.ImmutableHeadsInput(); got != want {
+		t.Fatalf("preset input = %q, want %q", got, want)
+	}
+	out := strings.Join(renderCtx{}.renderImmutableHeads(BuildRenderData(&m, ViewOpts{})), "\n")
+	for _, p := range jj.ImmutableHeadsPresets {
+		if !strings.Contains(out, "["+p.Name+"]") {
+			t.Errorf("editor missing preset %q\n%s", p.Name, out)
+		}
+	}
+
+	m, cmd := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter should request a save")
+	}
+	if req, ok := cmd().(RequestSaveImmutableHeadsMsg); !ok || req.Revset != want {
+		t.Fatalf("Enter sent %#v", req)
+	}
+
+	m, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.GetAdvancedModel().IsEditingImmutableHeads() {
+		t.Fatal("Esc should close the editor")
+	}
+}
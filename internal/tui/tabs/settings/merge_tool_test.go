@@ -0,0 +1,48 @@
+package settings
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+)
+
+// Ctrl+T cycles the merge tool through jj's default, a click picks one, and the choice is what
+// gets saved; testing a tool that isn't on PATH only sets the status.
+func TestMergeToolSetting(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	m := NewModelWithConfig(&config.Config{MergeTool: "code"})
+	m.SetActiveSettingsTabIndex(7) // Advanced
+	adv := m.GetAdvancedModel()
+	if got := adv.MergeTool(); got != "vscode" {
+		t.Fatalf("merge tool from config = %q, want vscode", got)
+	}
+	m, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if got := m.GetAdvancedModel().MergeTool(); got != "" {
+		t.Fatalf("Ctrl+T after the last tool = %q, want jj default", got)
+	}
+	m, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if got := m.GetAdvancedModel().MergeTool(); got != jj.MergeTools[0].Name {
+		t.Fatalf("Ctrl+T from jj default = %q, want %q", got, jj.MergeTools[0].Name)
+	}
+
+	m, _ = handleAdvancedZone(&m, mouse.ZoneSettingsMergeTool(2))
+	if got := m.GetAdvancedModel().MergeTool(); got != jj.MergeTools[1].Name {
+		t.Fatalf("clicked tool = %q, want %q", got, jj.MergeTools[1].Name)
+	}
+	if got := BuildSettingsParams(&m, "", "").MergeTool; got != jj.MergeTools[1].Name {
+		t.Fatalf("saved merge tool = %q", got)
+	}
+	out := strings.Join(renderCtx{}.renderMergeTool(BuildRenderData(&m, ViewOpts{})), "\n")
+	if !strings.Contains(out, "["+jj.MergeTools[1].Label+"] (not installed)") || !strings.Contains(out, "[jj default]") {
+		t.Fatalf("merge tool section:\n%s", out)
+	}
+
+	_, cmd := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if msg, ok := cmd().(RequestSetStatusMsg); !ok || !strings.Contains(msg.Status, "not installed") {
+		t.Fatalf("Ctrl+G with the tool missing sent %#v", msg)
+	}
+}
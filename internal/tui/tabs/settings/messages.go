@@ -3,10 +3,12 @@ package settings
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // CleanupCompletedMsg is sent when a settings cleanup operation completes (delete bookmarks, abandon old commits).
 type CleanupCompletedMsg struct {
+	state.RepoResult
 	Success bool
 	Message string
 	Err     error
@@ -67,6 +69,54 @@ func RequestSetStatusCmd(status string) tea.Cmd {
 	return func() tea.Msg { return RequestSetStatusMsg{Status: status} }
 }
 
+// RequestLoadImmutableHeadsMsg asks main to read jj's immutable_heads() definition for the editor.
+type RequestLoadImmutableHeadsMsg struct{}
+
+// RequestSaveImmutableHeadsMsg asks main to write immutable_heads() to the repo config ("" = unset).
+type RequestSaveImmutableHeadsMsg struct {
+	Revset string
+}
+
+// RequestLoadImmutableHeadsCmd returns a command that sends RequestLoadImmutableHeadsMsg.
+func RequestLoadImmutableHeadsCmd() tea.Cmd {
+	return func() tea.Msg { return RequestLoadImmutableHeadsMsg{} }
+}
+
+// RequestSaveImmutableHeadsCmd returns a command that sends RequestSaveImmutableHeadsMsg.
+func RequestSaveImmutableHeadsCmd(revset string) tea.Cmd {
+	return func() tea.Msg { return RequestSaveImmutableHeadsMsg{Revset: revset} }
+}
+
+// ImmutableHeadsLoadedMsg carries jj's current immutable_heads() definition.
+type ImmutableHeadsLoadedMsg struct {
+	state.RepoResult
+	Revset string
+	Err    error
+}
+
+// ImmutableHeadsSavedMsg is sent when writing immutable_heads() finishes.
+type ImmutableHeadsSavedMsg struct {
+	state.RepoResult
+	Revset string
+	Err    error
+}
+
+// RequestTestMergeToolMsg asks main to open the merge tool on a sample conflict (Settings → Advanced).
+type RequestTestMergeToolMsg struct {
+	Tool string
+}
+
+// RequestTestMergeToolCmd returns a command that sends RequestTestMergeToolMsg.
+func RequestTestMergeToolCmd(tool string) tea.Cmd {
+	return func() tea.Msg { return RequestTestMergeToolMsg{Tool: tool} }
+}
+
+// MergeToolTestedMsg is sent when a merge tool test launch exits.
+type MergeToolTestedMsg struct {
+	Tool string // label
+	Err  error
+}
+
 // GitHubCLILoginShowMsg tells main to open the GitHub CLI login modal (run `gh auth login`).
 type GitHubCLILoginShowMsg struct{}
 
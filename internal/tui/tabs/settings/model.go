@@ -9,6 +9,7 @@ import (
 	bubbledropdown "github.com/madicen/bubble-dropdown"
 	"github.com/madicen/jj-tui/internal"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/tabs/settings/advanced"
@@ -196,9 +197,9 @@ func (m *Model) SetViewOpts(opts ViewOpts) {
 }
 
 // EscHandledInsideSettings is true when Esc should be handled inside Settings (Theme tab / index 5
-// color picker, Advanced / index 7 cleanup confirm) instead of closing settings and returning to the graph.
+// color picker, Advanced / index 7 cleanup confirm or immutable_heads() editor) instead of closing settings and returning to the graph.
 func (m Model) EscHandledInsideSettings() bool {
-	if m.advancedModel.GetConfirmingCleanup() != "" {
+	if m.advancedModel.GetConfirmingCleanup() != "" || m.advancedModel.IsEditingImmutableHeads() {
 		return true
 	}
 	if m.anyDropdownOpen() {
@@ -218,6 +219,9 @@ func (m *Model) dropdownZoneForActiveTab() (string, bool) {
 	case 6: // AI
 		return mouse.ZoneSettingsAIProvider, true
 	case 7: // Advanced
+		if m.advancedModel.IsEditingImmutableHeads() {
+			return "", false
+		}
 		return mouse.ZoneSettingsExternalEditor, true
 	}
 	return "", false
@@ -294,6 +298,29 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// The immutable_heads() editor (Advanced, Ctrl+O) owns the keyboard while open.
+	if m.advancedModel.IsEditingImmutableHeads() {
+		switch msg.String() {
+		case "esc":
+			return m, m.advancedModel.CloseImmutableHeadsEditor()
+		case "enter", "ctrl+s":
+			m.advancedModel.SetImmutableHeadsSaving()
+			return m, RequestSaveImmutableHeadsCmd(m.advancedModel.ImmutableHeadsInput())
+		}
+		return m, m.advancedModel.UpdateImmutableHeadsInput(msg)
+	}
+	if m.settingsTab == 7 { // Advanced
+		switch msg.String() {
+		case "ctrl+o":
+			return m, tea.Batch(m.advancedModel.OpenImmutableHeadsEditor(), RequestLoadImmutableHeadsCmd())
+		case "ctrl+t":
+			m.advancedModel.CycleMergeTool()
+			return m, nil
+		case "ctrl+g":
+			return m, m.testMergeTool()
+		}
+	}
+
 	// Repository remote shortcuts (Settings → GitHub only). Handled here so they fire from any
 	// focusedField on the GitHub panel (including the token input row), and so they don't
 	// collide with j/k/space toggle handling further below.
@@ -414,6 +441,7 @@ func (m *Model) ZoneIDs() []string {
 		mouse.ZoneSettingsTabTickets, mouse.ZoneSettingsTabBranches, mouse.ZoneSettingsTabTheme, mouse.ZoneSettingsTabAI, mouse.ZoneSettingsTabAdvanced,
 		mouse.ZoneSettingsThemePrimary, mouse.ZoneSettingsThemeSecondary, mouse.ZoneSettingsThemeMuted,
 		mouse.ZoneSettingsThemePrimaryDefault, mouse.ZoneSettingsThemeSecondaryDefault, mouse.ZoneSettingsThemeMutedDefault,
+		mouse.ZoneSettingsThemePalette, mouse.ZoneSettingsThemeName,
 		mouse.ZoneSettingsTicketProvider,
 		mouse.ZoneSettingsAutoInProgress,
 		mouse.ZoneSettingsAdvancedConfirmYes, mouse.ZoneSettingsAdvancedConfirmNo,
@@ -437,10 +465,24 @@ func (m *Model) ZoneIDs() []string {
 	for i := 0; i < m.aiModel.ProfileCount(); i++ {
 		ids = append(ids, mouse.ZoneSettingsAIProfileRow(i))
 	}
+	for i := range jj.GraphRevsetPresets {
+		ids = append(ids, mouse.ZoneSettingsGraphRevsetPreset(i))
+	}
+	ids = append(ids,
+		mouse.ZoneSettingsImmutableHeadsEdit, mouse.ZoneSettingsImmutableHeadsSave, mouse.ZoneSettingsImmutableHeadsCancel,
+	)
+	for i := range jj.ImmutableHeadsPresets {
+		ids = append(ids, mouse.ZoneSettingsImmutableHeadsPreset(i))
+	}
+	for i := 0; i <= len(jj.MergeTools); i++ {
+		ids = append(ids, mouse.ZoneSettingsMergeTool(i))
+	}
 	ids = append(ids,
+		mouse.ZoneSettingsMergeToolTest,
 		mouse.ZoneSettingsExternalEditor,
 		mouse.ZoneSettingsExternalEditorCustom,
 		mouse.ZoneSettingsSanitizeBookmarks,
+		mouse.ZoneSettingsLowBandwidth,
 		mouse.ZoneSettingsGitHubLogin,
 		mouse.ZoneSettingsRemoteOriginInput, mouse.ZoneSettingsRemoteApply,
 		mouse.ZoneSettingsRemoteCreateGh, mouse.ZoneSettingsRemoteRemove,
@@ -450,7 +492,7 @@ func (m *Model) ZoneIDs() []string {
 		mouse.ZoneSettingsGitHubOnlyMine, mouse.ZoneSettingsGitHubShowMerged, mouse.ZoneSettingsGitHubShowClosed,
 		mouse.ZoneSettingsGitHubPRLimitDecrease, mouse.ZoneSettingsGitHubPRLimitIncrease,
 		mouse.ZoneSettingsGitHubRefreshDecrease, mouse.ZoneSettingsGitHubRefreshIncrease, mouse.ZoneSettingsGitHubRefreshToggle,
-		mouse.ZoneSettingsBranchLimitDecrease, mouse.ZoneSettingsBranchLimitIncrease, mouse.ZoneSettingsBranchShowAllRemotes,
+		mouse.ZoneSettingsBranchShowAllRemotes,
 		mouse.ZoneSettingsGitHubTokenClear, mouse.ZoneSettingsJiraURLClear, mouse.ZoneSettingsJiraUserClear,
 		mouse.ZoneSettingsJiraTokenClear, mouse.ZoneSettingsJiraProjectClear, mouse.ZoneSettingsJiraProjectFilterClear, mouse.ZoneSettingsJiraIssueTypeClear, mouse.ZoneSettingsJiraJQLClear,
 		mouse.ZoneSettingsJiraExcludedClear, mouse.ZoneSettingsCodecksSubdomainClear, mouse.ZoneSettingsCodecksTokenClear,
@@ -599,7 +641,13 @@ func (m Model) forwardKeyToActiveSubmodelReturn(msg tea.KeyMsg) (Model, tea.Cmd)
 		m.ticketsModel = updated
 		return m, cmd
 	case 5: // Theme
-		// No text inputs
+		// No text inputs; t cycles the color theme, p the status color palette
+		switch msg.String() {
+		case "t":
+			m.themeModel.CycleTheme()
+		case "p":
+			m.themeModel.CyclePalette()
+		}
 		return m, nil
 	case 6: // AI
 		updated, cmd := m.aiModel.Update(msg)
@@ -837,7 +885,6 @@ func (m *Model) GetSettingsOnlyMine() bool          { return m.githubModel.GetOn
 func (m *Model) GetSettingsPRLimit() int            { return m.githubModel.GetPRLimit() }
 func (m *Model) GetSettingsPRRefreshInterval() int  { return m.githubModel.GetRefreshInterval() }
 func (m *Model) GetSettingsAutoInProgress() bool    { return m.ticketsModel.GetAutoInProgress() }
-func (m *Model) GetSettingsBranchLimit() int        { return m.branchesModel.GetBranchLimit() }
 func (m *Model) GetSettingsShowAllRemotes() bool    { return m.branchesModel.GetShowAllRemotes() }
 func (m *Model) GetSettingsSanitizeBookmarks() bool { return m.advancedModel.GetSanitizeBookmarks() }
 func (m *Model) GetSettingsTicketProvider() string  { return m.ticketsModel.GetTicketProvider() }
@@ -850,7 +897,6 @@ func (m *Model) SetSettingsOnlyMine(v bool)          { m.githubModel.SetOnlyMine
 func (m *Model) SetSettingsPRLimit(v int)            { m.githubModel.SetPRLimit(v) }
 func (m *Model) SetSettingsPRRefreshInterval(v int)  { m.githubModel.SetRefreshInterval(v) }
 func (m *Model) SetSettingsAutoInProgress(v bool)    { m.ticketsModel.SetAutoInProgress(v) }
-func (m *Model) SetSettingsBranchLimit(v int)        { m.branchesModel.SetBranchLimit(v) }
 func (m *Model) SetSettingsShowAllRemotes(v bool)    { m.branchesModel.SetShowAllRemotes(v) }
 func (m *Model) SetSettingsSanitizeBookmarks(v bool) { m.advancedModel.SetSanitizeBookmarks(v) }
 func (m *Model) SetSettingsTicketProvider(s string)  { m.ticketsModel.SetTicketProvider(s) }
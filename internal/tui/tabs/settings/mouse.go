@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/state"
 )
@@ -223,22 +224,6 @@ func handleTicketsZone(m *Model, zoneID string) (Model, tea.Cmd) {
 func handleBranchesZone(m *Model, zoneID string) (Model, tea.Cmd) {
 	br := m.GetBranchesModel()
 	switch zoneID {
-	case mouse.ZoneSettingsBranchLimitDecrease:
-		n := br.GetBranchLimit()
-		if n > 10 {
-			br.SetBranchLimit(n - 10)
-		} else if n > 0 {
-			br.SetBranchLimit(0)
-		}
-		return *m, nil
-	case mouse.ZoneSettingsBranchLimitIncrease:
-		n := br.GetBranchLimit()
-		if n == 0 {
-			br.SetBranchLimit(10)
-		} else if n < 200 {
-			br.SetBranchLimit(n + 10)
-		}
-		return *m, nil
 	case mouse.ZoneSettingsBranchShowAllRemotes:
 		br.ToggleShowAllRemotes()
 		return *m, nil
@@ -246,8 +231,16 @@ func handleBranchesZone(m *Model, zoneID string) (Model, tea.Cmd) {
 	return *m, nil
 }
 
-// handleThemeZone handles zone clicks for the Theme settings panel (index 5): [Default] buttons or forward to the clicked swatch.
+// handleThemeZone handles zone clicks for the Theme settings panel (index 5): the theme and palette buttons, [Default] buttons, or forward to the clicked swatch.
 func handleThemeZone(m *Model, zoneID string, event tea.MouseMsg) (Model, tea.Cmd) {
+	if zoneID == mouse.ZoneSettingsThemeName {
+		m.themeModel.CycleTheme()
+		return *m, nil
+	}
+	if zoneID == mouse.ZoneSettingsThemePalette {
+		m.themeModel.CyclePalette()
+		return *m, nil
+	}
 	if idx := ThemeDefaultZoneIndex(zoneID); idx >= 0 {
 		m.themeModel.SetSwatchToDefault(idx)
 		return *m, nil
@@ -275,7 +268,45 @@ func handleAdvancedZone(m *Model, zoneID string) (Model, tea.Cmd) {
 		}
 		return *m, nil
 	}
+	if adv.IsEditingImmutableHeads() {
+		if strings.HasPrefix(zoneID, mouse.ZoneSettingsImmutableHeadsPresetPrefix) {
+			idx, err := strconv.Atoi(strings.TrimPrefix(zoneID, mouse.ZoneSettingsImmutableHeadsPresetPrefix))
+			if err == nil {
+				adv.ApplyImmutableHeadsPreset(idx)
+			}
+			return *m, nil
+		}
+		switch zoneID {
+		case mouse.ZoneSettingsImmutableHeadsSave:
+			adv.SetImmutableHeadsSaving()
+			return *m, RequestSaveImmutableHeadsCmd(adv.ImmutableHeadsInput())
+		case mouse.ZoneSettingsImmutableHeadsCancel:
+			return *m, adv.CloseImmutableHeadsEditor()
+		}
+		// The editor is modal: other Advanced controls stay inert until it closes.
+		return *m, nil
+	}
+	if strings.HasPrefix(zoneID, mouse.ZoneSettingsGraphRevsetPresetPrefix) {
+		idx, err := strconv.Atoi(strings.TrimPrefix(zoneID, mouse.ZoneSettingsGraphRevsetPresetPrefix))
+		if err == nil && idx >= 0 && idx < len(jj.GraphRevsetPresets) {
+			adv.SetGraphRevset(jj.GraphRevsetPresets[idx].Revset)
+		}
+		return *m, m.SetFocusedField(14)
+	}
+	if strings.HasPrefix(zoneID, mouse.ZoneSettingsMergeToolPrefix) {
+		idx, err := strconv.Atoi(strings.TrimPrefix(zoneID, mouse.ZoneSettingsMergeToolPrefix))
+		if err == nil && idx <= len(jj.MergeTools) {
+			name := "" // 0 = jj default
+			if idx > 0 {
+				name = jj.MergeTools[idx-1].Name
+			}
+			adv.SetMergeTool(name)
+		}
+		return *m, nil
+	}
 	switch zoneID {
+	case mouse.ZoneSettingsMergeToolTest:
+		return *m, m.testMergeTool()
 	case mouse.ZoneSettingsAdvancedDeleteBookmarks:
 		adv.SetConfirmingCleanup("delete_bookmarks")
 		return *m, RequestSetStatusCmd(StartDeleteBookmarksStatus)
@@ -285,6 +316,9 @@ func handleAdvancedZone(m *Model, zoneID string) (Model, tea.Cmd) {
 	case mouse.ZoneSettingsSanitizeBookmarks:
 		adv.SetSanitizeBookmarks(!adv.GetSanitizeBookmarks())
 		return *m, nil
+	case mouse.ZoneSettingsLowBandwidth:
+		adv.SetLowBandwidth(!adv.GetLowBandwidth())
+		return *m, nil
 	case mouse.ZoneSettingsGraphRevset:
 		return *m, m.SetFocusedField(14)
 	case mouse.ZoneSettingsGraphRevsetClear:
@@ -292,6 +326,8 @@ func handleAdvancedZone(m *Model, zoneID string) (Model, tea.Cmd) {
 		return *m, m.SetFocusedField(14)
 	case mouse.ZoneSettingsExternalEditorCustom:
 		return *m, m.SetFocusedField(15)
+	case mouse.ZoneSettingsImmutableHeadsEdit:
+		return *m, tea.Batch(adv.OpenImmutableHeadsEditor(), RequestLoadImmutableHeadsCmd())
 	}
 	return *m, nil
 }
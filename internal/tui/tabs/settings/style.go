@@ -6,9 +6,19 @@ import (
 )
 
 var (
-	clearButtonStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#F85149")).Bold(true)
-	settingsTabStyle  = lipgloss.NewStyle().Padding(0, 2).Foreground(styles.ColorMuted)
-	settingsTabActive = lipgloss.NewStyle().Padding(0, 2).Bold(true).Foreground(styles.ColorPrimary).Underline(true)
-	toggleOnStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Bold(true)
-	toggleOffStyle    = lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	clearButtonStyle  lipgloss.Style
+	settingsTabStyle  lipgloss.Style
+	settingsTabActive lipgloss.Style
+	toggleOnStyle     lipgloss.Style
+	toggleOffStyle    lipgloss.Style
 )
+
+func init() {
+	styles.OnThemeChange(func() {
+		clearButtonStyle = lipgloss.NewStyle().Foreground(styles.ColorNegative).Bold(true)
+		settingsTabStyle = lipgloss.NewStyle().Padding(0, 2).Foreground(styles.ColorMuted)
+		settingsTabActive = lipgloss.NewStyle().Padding(0, 2).Bold(true).Foreground(styles.ColorPrimary).Underline(true)
+		toggleOnStyle = lipgloss.NewStyle().Foreground(styles.ColorPositive).Bold(true)
+		toggleOffStyle = lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	})
+}
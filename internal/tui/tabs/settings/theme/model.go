@@ -1,16 +1,23 @@
 package theme
 
 import (
+	"cmp"
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/bubble-color-picker"
 	"github.com/madicen/jj-tui/internal/config"
 	"github.com/madicen/jj-tui/internal/tui/styles"
+	uitheme "github.com/madicen/jj-tui/internal/tui/theme"
 )
 
-// Model holds three SwatchPickers for Primary, Secondary, and Muted theme colors.
+// Model holds the color theme name, three SwatchPickers for the Primary, Secondary, and Muted
+// colors (the theme's own unless overridden), plus the status color palette name.
 type Model struct {
+	theme    string
 	swatches [3]*bubblepicker.SwatchPicker
+	palette  string
 }
 
 const (
@@ -19,31 +26,30 @@ const (
 	idxMuted
 )
 
-// Default theme colors (hex).
-const (
-	DefaultPrimary   = "#7E00AF"
-	DefaultSecondary = "#50FA7B"
-	DefaultMuted     = "#6272A4"
-)
-
-// NewModel creates a theme model with default colors.
+// NewModel creates a theme model with the default theme's colors.
 func NewModel() Model {
+	t := uitheme.Builtin[0]
 	return Model{
+		theme: t.Name,
 		swatches: [3]*bubblepicker.SwatchPicker{
-			bubblepicker.NewSwatchPicker("#7E00AF", "Primary"),
-			bubblepicker.NewSwatchPicker("#50FA7B", "Secondary"),
-			bubblepicker.NewSwatchPicker("#6272A4", "Muted"),
+			bubblepicker.NewSwatchPicker(t.Primary, "Primary"),
+			bubblepicker.NewSwatchPicker(t.Secondary, "Secondary"),
+			bubblepicker.NewSwatchPicker(t.Muted, "Muted"),
 		},
+		palette: styles.Palettes[0].Name,
 	}
 }
 
-// NewModelFromConfig creates a theme model from config (uses defaults for empty).
+// NewModelFromConfig creates a theme model from config (the theme's colors where not overridden).
 func NewModelFromConfig(cfg *config.Config) Model {
 	m := NewModel()
 	if cfg != nil {
-		m.swatches[idxPrimary].SetColor(cfg.GetThemePrimary())
-		m.swatches[idxSecondary].SetColor(cfg.GetThemeSecondary())
-		m.swatches[idxMuted].SetColor(cfg.GetThemeMuted())
+		t, _ := uitheme.Lookup(cfg.GetTheme())
+		m.theme = t.Name
+		m.swatches[idxPrimary].SetColor(cmp.Or(cfg.GetThemePrimary(), t.Primary))
+		m.swatches[idxSecondary].SetColor(cmp.Or(cfg.GetThemeSecondary(), t.Secondary))
+		m.swatches[idxMuted].SetColor(cmp.Or(cfg.GetThemeMuted(), t.Muted))
+		m.palette = cfg.GetThemePalette()
 	}
 	return m
 }
@@ -60,6 +66,42 @@ func (m *Model) Primary() string   { return m.swatches[idxPrimary].Color() }
 func (m *Model) Secondary() string { return m.swatches[idxSecondary].Color() }
 func (m *Model) Muted() string     { return m.swatches[idxMuted].Color() }
 
+// Theme returns the selected color theme name.
+func (m *Model) Theme() string { return m.theme }
+
+// Overrides returns the swatch colors that differ from the selected theme's, "" for the rest, so
+// saving keeps following the theme where the user didn't pick a color.
+func (m *Model) Overrides() (primary, secondary, muted string) {
+	t, _ := uitheme.Lookup(m.theme)
+	differ := func(color, own string) string {
+		if strings.EqualFold(color, own) {
+			return ""
+		}
+		return color
+	}
+	return differ(m.Primary(), t.Primary), differ(m.Secondary(), t.Secondary), differ(m.Muted(), t.Muted)
+}
+
+// CycleTheme selects the next color theme, resets the swatches to its colors and applies it to
+// live styles for preview.
+func (m *Model) CycleTheme() {
+	t, _ := uitheme.Lookup(uitheme.Next(m.theme))
+	m.theme = t.Name
+	m.swatches[idxPrimary].SetColor(t.Primary)
+	m.swatches[idxSecondary].SetColor(t.Secondary)
+	m.swatches[idxMuted].SetColor(t.Muted)
+	styles.ApplyTheme(t)
+}
+
+// Palette returns the selected status color palette name.
+func (m *Model) Palette() string { return m.palette }
+
+// CyclePalette selects the next status color palette and applies it to live styles for preview.
+func (m *Model) CyclePalette() {
+	m.palette = styles.NextPalette(m.palette)
+	styles.SetPalette(m.palette)
+}
+
 // Swatch returns the SwatchPicker at index (0=Primary, 1=Secondary, 2=Muted).
 func (m *Model) Swatch(i int) *bubblepicker.SwatchPicker {
 	if i < 0 || i > 2 {
@@ -68,15 +110,16 @@ func (m *Model) Swatch(i int) *bubblepicker.SwatchPicker {
 	return m.swatches[i]
 }
 
-// SetSwatchToDefault resets the swatch at index to its default color and updates live styles.
+// SetSwatchToDefault resets the swatch at index to the theme's color and updates live styles.
 func (m *Model) SetSwatchToDefault(index int) {
+	t, _ := uitheme.Lookup(m.theme)
 	switch index {
 	case idxPrimary:
-		m.swatches[idxPrimary].SetColor(DefaultPrimary)
+		m.swatches[idxPrimary].SetColor(t.Primary)
 	case idxSecondary:
-		m.swatches[idxSecondary].SetColor(DefaultSecondary)
+		m.swatches[idxSecondary].SetColor(t.Secondary)
 	case idxMuted:
-		m.swatches[idxMuted].SetColor(DefaultMuted)
+		m.swatches[idxMuted].SetColor(t.Muted)
 	default:
 		return
 	}
@@ -0,0 +1,25 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	uitheme "github.com/madicen/jj-tui/internal/tui/theme"
+)
+
+// Swatches follow the selected theme; only colors the user changed are saved as overrides.
+func TestCycleThemeKeepsOverridesEmpty(t *testing.T) {
+	t.Cleanup(func() { styles.ApplyTheme(uitheme.Dark) })
+	m := NewModelFromConfig(&config.Config{ThemeSecondary: "#FF79C6"})
+	if p, s, mu := m.Overrides(); p != "" || s != "#FF79C6" || mu != "" {
+		t.Fatalf("overrides = %q %q %q", p, s, mu)
+	}
+	m.CycleTheme()
+	if m.Theme() != "light" || m.Primary() != uitheme.Light.Primary || styles.ThemeName() != "light" {
+		t.Fatalf("theme = %q, primary = %q", m.Theme(), m.Primary())
+	}
+	if p, s, mu := m.Overrides(); p != "" || s != "" || mu != "" {
+		t.Fatalf("switching themes should drop overrides, got %q %q %q", p, s, mu)
+	}
+}
@@ -8,9 +8,12 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	bubbledropdown "github.com/madicen/bubble-dropdown"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/integrations/github"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/styles"
 	"github.com/madicen/jj-tui/internal/tui/tabs/settings/theme"
+	uitheme "github.com/madicen/jj-tui/internal/tui/theme"
 	"github.com/madicen/jj-tui/internal/version"
 )
 
@@ -55,9 +58,10 @@ type RenderData struct {
 	OriginInputView        string // rendered view of the origin URL textinput
 	GhAvailable            bool   // gh CLI present in PATH (controls the "Create new GitHub repo" button)
 	GhRepoPrivate          bool   // visibility flag for "Create new GitHub repo" (true => --private)
-	BranchLimit            int
 	BranchesShowAllRemotes bool
 	SanitizeBookmarks      bool
+	LowBandwidth           bool   // Advanced: no background network use
+	GraphRevset            string // Advanced: current graph revset input (highlights the matching preset)
 	ConfirmingCleanup      string
 	ExternalEditorPreset   int // Advanced: selected external editor preset index (radio rows)
 	AIEnabled              bool
@@ -89,6 +93,22 @@ type RenderData struct {
 	AIProviderDD        *bubbledropdown.Dropdown
 	EditorPresetDD      *bubbledropdown.Dropdown
 
+	// GitHubPermissions is the probed token permissions (nil = not probed); listed under "Connected".
+	GitHubPermissions *github.Permissions
+
+	// Advanced: jj's immutable_heads() ("" = not loaded) and the Ctrl+O editor.
+	ImmutableHeads          string
+	EditingImmutableHeads   bool
+	ImmutableHeadsInputView string
+	ImmutableHeadsInput     string
+	ImmutableHeadsLoading   bool
+	ImmutableHeadsSaving    bool
+	ImmutableHeadsErr       string
+
+	// Advanced: merge tool for the conflict viewer ("" = jj default) and which tools are on PATH.
+	MergeTool          string
+	MergeToolInstalled map[string]bool
+
 	// Scroll: when ContentHeight > 0, only lines [YOffset : YOffset+ContentHeight] are shown
 	YOffset       int
 	ContentHeight int
@@ -103,6 +123,8 @@ type ViewOpts struct {
 	// GhAvailable mirrors `gh` CLI presence in PATH (cached by main on Settings open). Used by
 	// renderGitHub to decide whether to show the "Create new GitHub repo" button or a hint.
 	GhAvailable bool
+	// GitHubPermissions is what the token may do in this repo (nil until probed).
+	GitHubPermissions *github.Permissions
 }
 
 // BuildRenderData builds RenderData from the settings model and opts. Used by RenderWithState.
@@ -120,11 +142,16 @@ func BuildRenderData(sm *Model, opts ViewOpts) RenderData {
 		TicketProvider:         sm.GetSettingsTicketProvider(),
 		TicketProviderName:     opts.TicketServiceName,
 		AutoInProgressOnBranch: sm.GetSettingsAutoInProgress(),
-		BranchLimit:            sm.GetSettingsBranchLimit(),
 		BranchesShowAllRemotes: sm.GetSettingsShowAllRemotes(),
 		SanitizeBookmarks:      sm.GetSettingsSanitizeBookmarks(),
+		LowBandwidth:           sm.GetAdvancedModel().GetLowBandwidth(),
+		GraphRevset:            sm.GetAdvancedModel().GetGraphRevset(),
+		ImmutableHeads:         sm.GetAdvancedModel().ImmutableHeads(),
+		EditingImmutableHeads:  sm.GetAdvancedModel().IsEditingImmutableHeads(),
+		ImmutableHeadsInput:    sm.GetAdvancedModel().ImmutableHeadsInput(),
 		ConfirmingCleanup:      sm.GetConfirmingCleanup(),
 		ExternalEditorPreset:   sm.GetAdvancedModel().GetExternalEditorPreset(),
+		MergeTool:              sm.GetAdvancedModel().MergeTool(),
 		AIEnabled:              sm.GetAIModel().GetAIEnabled(),
 		AIProviderID:           sm.GetAIModel().GetAIProvider(),
 		AIAPIKeySet:            opts.Config != nil && opts.Config.AISupportsGenerationCredentials(),
@@ -150,6 +177,7 @@ func BuildRenderData(sm *Model, opts ViewOpts) RenderData {
 		OriginInputView:        sm.GetGitHubModel().GetOriginInputView(),
 		GhAvailable:            opts.GhAvailable,
 		GhRepoPrivate:          sm.GetGitHubModel().GetGhPrivate(),
+		GitHubPermissions:      opts.GitHubPermissions,
 	}
 	data.Inputs = sm.GetSettingsInputs()
 	data.HasLocalConfig = config.HasLocalConfig()
@@ -164,6 +192,12 @@ func BuildRenderData(sm *Model, opts ViewOpts) RenderData {
 	data.CodecksConfigured = strings.TrimSpace(cc.GetSubdomain()) != "" &&
 		strings.TrimSpace(cc.GetToken()) != ""
 	data.GitHubIssuesConfigured = opts.GitHubAvailable
+	data.ImmutableHeadsInputView, data.ImmutableHeadsLoading, data.ImmutableHeadsSaving, data.ImmutableHeadsErr =
+		sm.GetAdvancedModel().ImmutableHeadsEditorState()
+	data.MergeToolInstalled = make(map[string]bool, len(jj.MergeTools))
+	for _, t := range jj.MergeTools {
+		data.MergeToolInstalled[t.Name] = sm.GetAdvancedModel().MergeToolInstalled(t.Name)
+	}
 	return data
 }
 
@@ -246,12 +280,12 @@ func Render(zm *zone.Manager, data RenderData) string {
 	lines = append(lines, "")
 	lines = append(lines, lipgloss.NewStyle().Bold(true).Render("Connection Status:"))
 	if data.GithubService {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Render("  ✓ GitHub connected"))
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorPositive).Render("  "+styles.GlyphSuccess+" GitHub connected"))
 	} else {
 		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("  ○ GitHub not connected"))
 	}
 	if data.JiraService {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Render("  ✓ Tickets connected"))
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorPositive).Render("  "+styles.GlyphSuccess+" Tickets connected"))
 	} else {
 		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("  ○ Tickets not connected"))
 	}
@@ -262,9 +296,9 @@ func Render(zm *zone.Manager, data RenderData) string {
 	versionLine := lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("  " + versionStr)
 	if updateInfo := version.GetUpdateInfo(); updateInfo != nil {
 		if updateInfo.UpdateAvailable {
-			versionLine += lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C")).Render(fmt.Sprintf(" → %s available", updateInfo.LatestVersion))
+			versionLine += lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(fmt.Sprintf(" → %s available", updateInfo.LatestVersion))
 		} else if updateInfo.LatestVersion != "" && updateInfo.LatestVersion != "dev" {
-			versionLine += lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Render(" (up to date)")
+			versionLine += lipgloss.NewStyle().Foreground(styles.ColorPositive).Render(" (up to date)")
 		}
 	}
 	lines = append(lines, versionLine)
@@ -365,10 +399,11 @@ func (r renderCtx) renderGitHub(data RenderData, base int) []string {
 		loginBtn = "Login with GitHub CLI"
 	}
 	if data.GithubService {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Render("  ✓ Connected to GitHub"))
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorPositive).Render("  "+styles.GlyphSuccess+" Connected to GitHub"))
 		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    "+r.mark(mouse.ZoneSettingsGitHubLogin, "[Reconnect]")))
+		lines = append(lines, renderGitHubPermissions(data.GitHubPermissions)...)
 	} else {
-		lines = append(lines, "  "+r.mark(mouse.ZoneSettingsGitHubLogin, styles.ButtonStyle.Background(lipgloss.Color("#238636")).Render(loginBtn)))
+		lines = append(lines, "  "+r.mark(mouse.ZoneSettingsGitHubLogin, styles.ButtonConfirmStyle.Render(loginBtn)))
 		if src == config.GitHubTokenSourceGhCLI {
 			lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    Opens `gh auth login` (token from `gh auth token`)"))
 		} else {
@@ -422,6 +457,31 @@ func (r renderCtx) renderGitHub(data RenderData, base int) []string {
 	return lines
 }
 
+// renderGitHubPermissions lists which GitHub features the token allows, with the reason for each
+// one it doesn't. Nothing is shown until the token has been probed.
+func renderGitHubPermissions(p *github.Permissions) []string {
+	if p == nil {
+		return nil
+	}
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	header := "  Token permissions (" + p.Kind
+	if len(p.Scopes) > 0 {
+		header += "; scopes: " + strings.Join(p.Scopes, ", ")
+	}
+	lines := []string{"", lipgloss.NewStyle().Bold(true).Render(header + "):")}
+	for _, c := range github.Capabilities {
+		if p.Can(c) {
+			lines = append(lines, "    "+lipgloss.NewStyle().Foreground(styles.ColorPositive).Render(styles.GlyphSuccess+" "+c.String()))
+			continue
+		}
+		lines = append(lines, "    "+lipgloss.NewStyle().Foreground(styles.ColorFailure).Render(styles.GlyphFailure+" "+c.String())+muted.Render(" — "+p.Reason(c)))
+	}
+	if p.Kind == github.TokenKindFineGrained {
+		lines = append(lines, muted.Render("    Write access can't be checked ahead of time for fine-grained tokens."))
+	}
+	return lines
+}
+
 // renderRepositoryRemote renders the "Repository remote" subsection of the GitHub settings tab.
 // Action-oriented (not part of Save): Apply / Create / Remove fire commands directly via
 // navigation messages handled by main, mirroring the welcome-screen flow.
@@ -453,8 +513,8 @@ func (r renderCtx) renderRepositoryRemote(data RenderData) []string {
 	if data.CurrentOrigin != "" {
 		applyLabel = "Update origin (^enter)"
 	}
-	applyButton := styles.ButtonStyle.Background(lipgloss.Color("#238636")).Render(applyLabel)
-	removeButton := styles.ButtonStyle.Background(lipgloss.Color("#8b1a1a")).Render("Remove origin (^x)")
+	applyButton := styles.ButtonConfirmStyle.Render(applyLabel)
+	removeButton := styles.ButtonDangerStyle.Render("Remove origin (^x)")
 	if data.CurrentOrigin == "" {
 		// Render a disabled-looking style when there's nothing to remove. Still mark the zone so
 		// the click is captured and we can show a clean status message ("no origin to remove").
@@ -476,8 +536,8 @@ func (r renderCtx) renderRepositoryRemote(data RenderData) []string {
 		pushCurrentBtn = lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("[" + pushCurrentLabel + " — set origin first]")
 		pushAllBtn = lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("[" + pushAllLabel + " — set origin first]")
 	} else {
-		pushCurrentBtn = styles.ButtonStyle.Background(lipgloss.Color("#1f6feb")).Render(pushCurrentLabel)
-		pushAllBtn = styles.ButtonStyle.Background(lipgloss.Color("#1f6feb")).Render(pushAllLabel)
+		pushCurrentBtn = styles.ButtonActionStyle.Render(pushCurrentLabel)
+		pushAllBtn = styles.ButtonActionStyle.Render(pushAllLabel)
 	}
 	lines = append(lines,
 		"  "+r.mark(mouse.ZoneSettingsRemotePushCurrent, pushCurrentBtn)+"  "+r.mark(mouse.ZoneSettingsRemotePushAll, pushAllBtn),
@@ -491,7 +551,7 @@ func (r renderCtx) renderRepositoryRemote(data RenderData) []string {
 		if data.GhRepoPrivate {
 			visLabel = "Private"
 		}
-		ghButton := styles.ButtonStyle.Background(lipgloss.Color("#1f6feb")).Render("Create new GitHub repo (g)")
+		ghButton := styles.ButtonActionStyle.Render("Create new GitHub repo (g)")
 		visButton := styles.ButtonStyle.Render(fmt.Sprintf("Visibility: %s  (^v)", visLabel))
 		lines = append(lines,
 			"  "+r.mark(mouse.ZoneSettingsRemoteCreateGh, ghButton)+"  "+r.mark(mouse.ZoneSettingsRemoteVisibilityToggle, visButton),
@@ -586,9 +646,9 @@ func (r renderCtx) renderTickets(data RenderData, base int) []string {
 	lines = append(lines, "")
 
 	if data.JiraService && data.TicketProviderName != "" {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Render("  ✓ Connected to "+data.TicketProviderName))
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorPositive).Render("  "+styles.GlyphSuccess+" Connected to "+data.TicketProviderName))
 	} else if data.TicketProvider != "" {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C")).Render("  ○ "+data.TicketProvider+" selected but not connected (check credentials)"))
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorWarning).Render("  "+styles.GlyphPending+" "+data.TicketProvider+" selected but not connected (check credentials)"))
 	} else {
 		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("  ○ No ticket provider selected"))
 	}
@@ -621,13 +681,6 @@ func (r renderCtx) renderBranches(data RenderData) []string {
 	var lines []string
 	lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Branch Settings"))
 	lines = append(lines, "", lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Configure how branches are loaded and displayed."), "")
-	lines = append(lines, lipgloss.NewStyle().Bold(true).Render("  Branch Limit:"))
-	lines = append(lines, "    "+r.mark(mouse.ZoneSettingsBranchLimitDecrease, lipgloss.NewStyle().Foreground(styles.ColorPrimary).Render("[-]"))+" "+
-		lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("%d", data.BranchLimit))+" "+
-		r.mark(mouse.ZoneSettingsBranchLimitIncrease, lipgloss.NewStyle().Foreground(styles.ColorPrimary).Render("[+]")))
-	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    Total branches to show (0 = all)"))
-	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    Local always included, remote filtered by recency"))
-	lines = append(lines, "")
 	lines = append(lines, "  "+r.renderToggle("Show all remote branches", data.BranchesShowAllRemotes, mouse.ZoneSettingsBranchShowAllRemotes))
 	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    Off: only tracked + your own branches. On: includes coworkers' untracked branches"))
 	return lines
@@ -643,7 +696,11 @@ func (r renderCtx) renderTheme(data RenderData, startRow int) []string {
 	}
 	tm := data.ThemeModel
 	lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Theme Colors"))
-	lines = append(lines, "", lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Click a swatch to change the color. Save (^s or ^l) to persist."), "")
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Click the theme (or press t) to cycle themes, a swatch to change a color. Save (^s or ^l) to persist."), "")
+	themeLabel := fmt.Sprintf("%-*s", themeLabelWidth, "Theme:")
+	t, _ := uitheme.Lookup(tm.Theme())
+	themeBtn := r.mark(mouse.ZoneSettingsThemeName, styles.ButtonStyle.Render(t.Name))
+	lines = append(lines, "  "+themeLabel+themeBtn+lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(t.Description))
 
 	sw, sh := tm.Swatch(0).Size()
 	const labelPrefix = "  "
@@ -652,13 +709,20 @@ func (r renderCtx) renderTheme(data RenderData, startRow int) []string {
 	secondaryLabel := fmt.Sprintf("%-*s", themeLabelWidth, "Secondary:")
 	mutedLabel := fmt.Sprintf("%-*s", themeLabelWidth, "Muted:")
 	swatchCol := len(labelPrefix) + themeLabelWidth
-	tm.SetBounds(0, startRow+2, swatchCol, sw, sh)
-	tm.SetBounds(1, startRow+3, swatchCol, sw, sh)
-	tm.SetBounds(2, startRow+4, swatchCol, sw, sh)
+	tm.SetBounds(0, startRow+3, swatchCol, sw, sh)
+	tm.SetBounds(1, startRow+4, swatchCol, sw, sh)
+	tm.SetBounds(2, startRow+5, swatchCol, sw, sh)
 
 	lines = append(lines, labelPrefix+r.mark(mouse.ZoneSettingsThemePrimary, primaryLabel+tm.Swatch(0).SwatchView())+" "+r.mark(mouse.ZoneSettingsThemePrimaryDefault, clearButtonStyle.Render("[Default]")))
 	lines = append(lines, labelPrefix+r.mark(mouse.ZoneSettingsThemeSecondary, secondaryLabel+tm.Swatch(1).SwatchView())+" "+r.mark(mouse.ZoneSettingsThemeSecondaryDefault, clearButtonStyle.Render("[Default]")))
 	lines = append(lines, labelPrefix+r.mark(mouse.ZoneSettingsThemeMuted, mutedLabel+tm.Swatch(2).SwatchView())+" "+r.mark(mouse.ZoneSettingsThemeMutedDefault, clearButtonStyle.Render("[Default]")))
+
+	lines = append(lines, "", lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Status Colors"))
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Click the palette (or press p) to cycle color-vision presets. Every status also has its own glyph."), "")
+	paletteLabel := fmt.Sprintf("%-*s", themeLabelWidth, "Palette:")
+	paletteBtn := r.mark(mouse.ZoneSettingsThemePalette, styles.ButtonStyle.Render(tm.Palette()))
+	lines = append(lines, labelPrefix+paletteLabel+paletteBtn+lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(styles.PaletteDescription(tm.Palette())))
+	lines = append(lines, labelPrefix+fmt.Sprintf("%-*s", themeLabelWidth, "Preview:")+styles.StatusPreview())
 	return lines
 }
 
@@ -729,7 +793,7 @@ func (r renderCtx) renderAI(data RenderData, base int) []string {
 	}
 	lines = append(lines, "")
 	if data.AIAPIKeySet {
-		lines = append(lines, "  "+lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Render("LLM credentials: ready (API key, Ollama preset, or local Ollama URL)"))
+		lines = append(lines, "  "+lipgloss.NewStyle().Foreground(styles.ColorPositive).Render("LLM credentials: ready (API key, Ollama preset, or local Ollama URL)"))
 	} else {
 		lines = append(lines, "  "+lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("LLM credentials: set an API key ("+config.EnvAIAPIKey+" or field below), choose Ollama, or use base URL http://127.0.0.1:11434/v1"))
 	}
@@ -822,14 +886,27 @@ func (r renderCtx) renderAdvanced(data RenderData, base int) []string {
 	}
 	lines = append(lines, "", "")
 
+	lines = append(lines, r.renderMergeTool(data)...)
+
 	lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Graph View"), "")
 	lines = append(lines, focusStyle(14).Render("  Default revset (jj):"))
 	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    Which commits to show in the commit graph. Empty = built-in default (fork parents + closest immutable per mutable stack; see README)."), "")
 	if len(data.Inputs) > 14 {
 		lines = append(lines, "  "+r.mark(mouse.ZoneSettingsGraphRevset, data.Inputs[14].View)+" "+r.mark(mouse.ZoneSettingsGraphRevsetClear, clearButtonStyle.Render("[Clear]")))
 	}
+	presets := make([]string, len(jj.GraphRevsetPresets))
+	for i, p := range jj.GraphRevsetPresets {
+		style := lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+		if p.Revset == strings.TrimSpace(data.GraphRevset) {
+			style = style.Bold(true).Underline(true)
+		}
+		presets[i] = r.mark(mouse.ZoneSettingsGraphRevsetPreset(i), style.Render("["+p.Name+"]"))
+	}
+	lines = append(lines, "  Presets: "+strings.Join(presets, " "))
 	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    e.g. trunk() | (ancestors(@) - ancestors(trunk())) for main + your branch only"), "", "")
 
+	lines = append(lines, r.renderImmutableHeads(data)...)
+
 	lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Bookmark Settings"), "")
 	toggleStr := "[ ]"
 	if data.SanitizeBookmarks {
@@ -838,13 +915,22 @@ func (r renderCtx) renderAdvanced(data RenderData, base int) []string {
 	lines = append(lines, "  "+r.mark(mouse.ZoneSettingsSanitizeBookmarks, lipgloss.NewStyle().Foreground(styles.ColorPrimary).Bold(true).Render(toggleStr+" Auto-fix bookmark names")))
 	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    Replace spaces and invalid characters with hyphens"), "", "")
 
+	lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Network"), "")
+	toggleStr = "[ ]"
+	if data.LowBandwidth {
+		toggleStr = "[✓]"
+	}
+	lines = append(lines, "  "+r.mark(mouse.ZoneSettingsLowBandwidth, lipgloss.NewStyle().Foreground(styles.ColorPrimary).Bold(true).Render(toggleStr+" Low-bandwidth mode")))
+	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    For tethered or metered connections: no PR/ticket auto-refresh, update checks or avatars."))
+	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    PRs and tickets load when you open their tab or press Ctrl+r."), "", "")
+
 	lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Advanced Maintenance"), "")
-	lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#F85149")).Bold(true).Render("WARNING: Destructive operations. Use caution!"), "")
+	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorNegative).Bold(true).Render("WARNING: Destructive operations. Use caution!"), "")
 
 	if data.ConfirmingCleanup != "" {
 		lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Are you sure? This cannot be undone."), "")
 		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Left,
-			r.mark(mouse.ZoneSettingsAdvancedConfirmYes, styles.ButtonStyle.Background(lipgloss.Color("#F85149")).Render("Yes, Confirm")),
+			r.mark(mouse.ZoneSettingsAdvancedConfirmYes, styles.ButtonDangerStyle.Render("Yes, Confirm")),
 			" ", r.mark(mouse.ZoneSettingsAdvancedConfirmNo, styles.ButtonStyle.Render("Cancel"))))
 		lines = append(lines, "", lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Press Y to confirm, N/Esc to cancel"))
 		return lines
@@ -857,3 +943,76 @@ func (r renderCtx) renderAdvanced(data RenderData, base int) []string {
 	lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("    Abandon commits before origin/main"))
 	return lines
 }
+
+// renderMergeTool renders the "Merge Tool" section of the Advanced panel: the tool the conflict
+// viewer's m key resolves with, marking tools that aren't on PATH.
+func (r renderCtx) renderMergeTool(data RenderData) []string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := []string{lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Merge Tool"), ""}
+	lines = append(lines, muted.Render("    Conflict viewer: m runs jj resolve --tool <tool> on the file. jj default = your ui.merge-editor."), "")
+	option := func(i int, label string, selected, installed bool) string {
+		style := lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+		if selected {
+			style = style.Bold(true).Underline(true)
+		}
+		s := r.mark(mouse.ZoneSettingsMergeTool(i), style.Render("["+label+"]"))
+		if !installed {
+			s += muted.Render(" (not installed)")
+		}
+		return s
+	}
+	opts := []string{option(0, "jj default", data.MergeTool == "", true)}
+	for i, t := range jj.MergeTools {
+		opts = append(opts, option(i+1, t.Label, data.MergeTool == t.Name, data.MergeToolInstalled[t.Name]))
+	}
+	lines = append(lines, "  "+strings.Join(opts, " "))
+	lines = append(lines, "  "+r.mark(mouse.ZoneSettingsMergeToolTest, clearButtonStyle.Render("[Test launch]")))
+	lines = append(lines, muted.Render("    Ctrl+T next tool · Ctrl+G test launch (opens a sample conflict)"), "", "")
+	return lines
+}
+
+// renderImmutableHeads renders the "Immutable Commits" section of the Advanced panel: jj's
+// immutable_heads() definition, or its editor with presets while open (Ctrl+O).
+func (r renderCtx) renderImmutableHeads(data RenderData) []string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := []string{lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("Immutable Commits"), ""}
+	lines = append(lines, muted.Render("    jj refuses to rewrite ancestors of immutable_heads() (\"commit is immutable\"). Saved to this repo's jj config."), "")
+	if !data.EditingImmutableHeads {
+		current := data.ImmutableHeads
+		if current == "" {
+			current = muted.Render("(not loaded)")
+		}
+		lines = append(lines, "  immutable_heads() = "+current+" "+r.mark(mouse.ZoneSettingsImmutableHeadsEdit, clearButtonStyle.Render("[Edit]")))
+		lines = append(lines, muted.Render("    Ctrl+O edit"), "", "")
+		return lines
+	}
+
+	lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render("  immutable_heads() ="))
+	switch {
+	case data.ImmutableHeadsLoading:
+		lines = append(lines, muted.Render("    Loading current definition…"))
+	default:
+		lines = append(lines, "  "+data.ImmutableHeadsInputView)
+	}
+	lines = append(lines, "")
+	for i, p := range jj.ImmutableHeadsPresets {
+		style := lipgloss.NewStyle().Foreground(styles.ColorSecondary)
+		if p.Revset == data.ImmutableHeadsInput {
+			style = style.Bold(true).Underline(true)
+		}
+		lines = append(lines, "  "+r.mark(mouse.ZoneSettingsImmutableHeadsPreset(i), style.Render("["+p.Name+"]"))+" "+muted.Render(p.Description))
+	}
+	lines = append(lines, "")
+	if data.ImmutableHeadsErr != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorNegative).Render("  "+data.ImmutableHeadsErr), "")
+	}
+	if data.ImmutableHeadsSaving {
+		lines = append(lines, muted.Render("  Saving…"))
+	} else {
+		lines = append(lines, "  "+lipgloss.JoinHorizontal(lipgloss.Left,
+			r.mark(mouse.ZoneSettingsImmutableHeadsSave, styles.ButtonStyle.Render("Save")),
+			" ", r.mark(mouse.ZoneSettingsImmutableHeadsCancel, styles.ButtonStyle.Render("Cancel"))))
+	}
+	lines = append(lines, muted.Render("    Enter save · Esc cancel · empty restores jj's default"), "", "")
+	return lines
+}
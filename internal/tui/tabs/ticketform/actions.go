@@ -22,11 +22,14 @@ func OpenCreateTicket(modal *Model, ticketService tickets.Service, width, height
 		return OpenCreateTicketResult{StatusMessage: "Create ticket not available for this provider", Ok: false}
 	}
 	providerName := ticketService.GetProviderName()
-	modal.Show(providerName)
+	var options tickets.CreateOptions
+	if svc, ok := ticketService.(tickets.CreateOptionsService); ok {
+		options = svc.CreateOptions()
+	}
+	modal.Show(providerName, options)
 	modal.GetTitleInput().Width = width
 	modal.GetBodyInput().SetWidth(width)
-	const fixedFormLines = 12
-	bodyHeight := height - fixedFormLines
+	bodyHeight := height - modal.FixedFormLines()
 	if bodyHeight < 3 {
 		bodyHeight = 3
 	}
@@ -41,6 +44,8 @@ func OpenCreateTicket(modal *Model, ticketService tickets.Service, width, height
 type SubmitTicketInput struct {
 	Summary       string
 	Description   string
+	Type          string
+	Priority      string
 	TicketService tickets.Service
 	DemoMode      bool
 }
@@ -62,6 +67,8 @@ func SubmitTicketCmd(input SubmitTicketInput) (tea.Cmd, string) {
 			ticket, err := input.TicketService.CreateTicket(context.Background(), &tickets.CreateTicketInput{
 				Summary:     summary,
 				Description: strings.TrimSpace(input.Description),
+				Type:        input.Type,
+				Priority:    input.Priority,
 			})
 			if err != nil {
 				return util.ErrorMsg{Err: err}
@@ -74,6 +81,8 @@ func SubmitTicketCmd(input SubmitTicketInput) (tea.Cmd, string) {
 		ticket, err := svc.CreateTicket(context.Background(), &tickets.CreateTicketInput{
 			Summary:     summary,
 			Description: strings.TrimSpace(input.Description),
+			Type:        input.Type,
+			Priority:    input.Priority,
 		})
 		if err != nil {
 			return util.ErrorMsg{Err: err}
@@ -94,6 +103,8 @@ func SubmitTicket(modal *Model, ticketService tickets.Service, demoMode bool) Su
 	cmd, errStr := SubmitTicketCmd(SubmitTicketInput{
 		Summary:       input.Summary,
 		Description:   input.Description,
+		Type:          input.Type,
+		Priority:      input.Priority,
 		TicketService: ticketService,
 		DemoMode:      demoMode,
 	})
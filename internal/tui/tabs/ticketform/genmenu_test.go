@@ -7,13 +7,14 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/tickets"
 	"github.com/madicen/jj-tui/internal/tui/genmenu"
 )
 
 // TestGenMenuLifecycle mirrors descedit's lifecycle test for the ticket form.
 func TestGenMenuLifecycle(t *testing.T) {
 	m := NewModel(zone.New())
-	m.Show("Jira")
+	m.Show("Jira", tickets.CreateOptions{})
 	m.SetAIProfiles([]config.AIProfile{
 		{Name: "fast", Provider: "openai_compatible"},
 		{Name: "smart", Provider: "openai_compatible", Model: "gpt-4o"},
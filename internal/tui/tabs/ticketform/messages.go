@@ -3,10 +3,12 @@ package ticketform
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // TicketCreatedMsg is sent when a ticket was successfully created.
 type TicketCreatedMsg struct {
+	state.RepoResult
 	Ticket *tickets.Ticket
 }
 
@@ -2,6 +2,7 @@ package ticketform
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -22,8 +23,11 @@ type Model struct {
 	shown        bool
 	titleInput   textinput.Model
 	bodyInput    textarea.Model
-	focusedField int // 0=title, 1=body
+	focusedField int // 0=title, 1=body, 2=type, 3=priority
 	providerName string
+	options      tickets.CreateOptions
+	typeIdx      int // 0 = provider default, else options.Types[typeIdx-1]
+	priorityIdx  int // 0 = provider default, else options.Priorities[priorityIdx-1]
 	// Long-press AI profile picker over the Generate chip. See descedit/model.go
 	// for the shared design notes.
 	genMenu       genmenu.State
@@ -97,13 +101,19 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m.handleKeyMsg(msg)
 	}
 
-	if m.focusedField == 0 {
-		var cmd tea.Cmd
+	return m.updateFocusedInput(msg)
+}
+
+// updateFocusedInput passes msg to the focused text field; the type and priority pickers take
+// no text.
+func (m Model) updateFocusedInput(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch m.focusedField {
+	case 0:
 		m.titleInput, cmd = m.titleInput.Update(msg)
-		return m, cmd
+	case 1:
+		m.bodyInput, cmd = m.bodyInput.Update(msg)
 	}
-	var cmd tea.Cmd
-	m.bodyInput, cmd = m.bodyInput.Update(msg)
 	return m, cmd
 }
 
@@ -162,8 +172,8 @@ func (m Model) View() string {
 func (m Model) renderForm() string {
 	subtitleStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted)
 	buttonStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#30363d")).
+		Foreground(styles.ColorText).
+		Background(styles.ColorPanel).
 		Padding(0, 1).
 		Bold(true)
 
@@ -200,11 +210,77 @@ func (m Model) renderForm() string {
 		"Description (optional):",
 		bodyInput,
 		"",
-		lipgloss.JoinHorizontal(lipgloss.Left, submitBtn, "  ", cancelBtn),
 	}
+	if pickers := m.renderPickers(mark); pickers != "" {
+		blocks = append(blocks, pickers, "")
+	}
+	blocks = append(blocks, lipgloss.JoinHorizontal(lipgloss.Left, submitBtn, "  ", cancelBtn))
 	return lipgloss.JoinVertical(lipgloss.Left, blocks...)
 }
 
+// renderPickers renders the type and priority pickers the provider offers on one row, or "".
+func (m Model) renderPickers(mark func(id, s string) string) string {
+	picker := func(field int, label string, choices []string, idx int) string {
+		value := "default"
+		if idx > 0 && idx <= len(choices) {
+			value = choices[idx-1]
+		}
+		style := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+		if m.focusedField == field {
+			style = lipgloss.NewStyle().Foreground(styles.ColorPrimary).Bold(true)
+		}
+		return label + " " + style.Render("‹ "+value+" ›")
+	}
+	var parts []string
+	if len(m.options.Types) > 0 {
+		parts = append(parts, mark(mouse.ZoneTicketFormType, picker(2, "Type:", m.options.Types, m.typeIdx)))
+	}
+	if len(m.options.Priorities) > 0 {
+		parts = append(parts, mark(mouse.ZoneTicketFormPriority, picker(3, "Priority:", m.options.Priorities, m.priorityIdx)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	hint := lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("←/→ to change")
+	return strings.Join(parts, "   ") + "   " + hint
+}
+
+// fields returns the focusable fields in Tab order: title, body, then the pickers the provider offers.
+func (m Model) fields() []int {
+	fields := []int{0, 1}
+	if len(m.options.Types) > 0 {
+		fields = append(fields, 2)
+	}
+	if len(m.options.Priorities) > 0 {
+		fields = append(fields, 3)
+	}
+	return fields
+}
+
+// cycleFocus moves focus delta fields along fields(), wrapping around.
+func (m *Model) cycleFocus(delta int) {
+	fields := m.fields()
+	pos := 0
+	for i, f := range fields {
+		if f == m.focusedField {
+			pos = i
+		}
+	}
+	m.SetFocusedField(fields[(pos+delta+len(fields))%len(fields)])
+}
+
+// cyclePicker steps the focused picker delta choices, through "default" and wrapping around.
+func (m *Model) cyclePicker(delta int) {
+	switch m.focusedField {
+	case 2:
+		n := len(m.options.Types) + 1
+		m.typeIdx = (m.typeIdx + delta + n) % n
+	case 3:
+		n := len(m.options.Priorities) + 1
+		m.priorityIdx = (m.priorityIdx + delta + n) % n
+	}
+}
+
 func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -214,29 +290,27 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 	case "ctrl+s", "ctrl+enter":
 		return m, SubmitRequestedCmd()
 	case "tab":
-		m.focusedField = (m.focusedField + 1) % 2
-		if m.focusedField == 0 {
-			m.titleInput.Focus()
-			m.bodyInput.Blur()
-		} else {
-			m.titleInput.Blur()
-			m.bodyInput.Focus()
-		}
+		m.cycleFocus(1)
+		return m, nil
+	case "shift+tab":
+		m.cycleFocus(-1)
 		return m, nil
 	}
-	if m.focusedField == 0 {
-		var cmd tea.Cmd
-		m.titleInput, cmd = m.titleInput.Update(msg)
-		return m, cmd
+	if m.focusedField >= 2 {
+		switch msg.String() {
+		case "right", "l", " ":
+			m.cyclePicker(1)
+		case "left", "h":
+			m.cyclePicker(-1)
+		}
+		return m, nil
 	}
-	var cmd tea.Cmd
-	m.bodyInput, cmd = m.bodyInput.Update(msg)
-	return m, cmd
+	return m.updateFocusedInput(msg)
 }
 
 // ZoneIDs returns the zone IDs this modal uses
 func (m Model) ZoneIDs() []string {
-	return []string{mouse.ZoneTicketFormTitle, mouse.ZoneTicketFormBody, mouse.ZoneTicketFormSubmit, mouse.ZoneTicketFormCancel, mouse.ZoneTicketFormGenerate}
+	return []string{mouse.ZoneTicketFormTitle, mouse.ZoneTicketFormBody, mouse.ZoneTicketFormSubmit, mouse.ZoneTicketFormCancel, mouse.ZoneTicketFormGenerate, mouse.ZoneTicketFormType, mouse.ZoneTicketFormPriority}
 }
 
 func (m Model) resolveClickedZone(msg zone.MsgZoneInBounds) string {
@@ -259,6 +333,16 @@ func (m Model) handleZoneClick(zoneID string) (Model, tea.Cmd) {
 	case mouse.ZoneTicketFormBody:
 		m.SetFocusedField(1)
 		return m, nil
+	case mouse.ZoneTicketFormType, mouse.ZoneTicketFormPriority:
+		field := 2
+		if zoneID == mouse.ZoneTicketFormPriority {
+			field = 3
+		}
+		if m.focusedField == field {
+			m.cyclePicker(1)
+		}
+		m.SetFocusedField(field)
+		return m, nil
 	case mouse.ZoneTicketFormSubmit:
 		return m, SubmitRequestedCmd()
 	case mouse.ZoneTicketFormCancel:
@@ -274,10 +358,12 @@ func (m *Model) IsShown() bool {
 	return m.shown
 }
 
-// Show displays the Create Ticket dialog
-func (m *Model) Show(providerName string) {
+// Show displays the Create Ticket dialog. options are the types and priorities the provider
+// offers; the pickers start on the provider default.
+func (m *Model) Show(providerName string, options tickets.CreateOptions) {
 	m.shown = true
 	m.providerName = providerName
+	m.options = options
 	m.focusedField = 0
 	m.titleInput.Focus()
 	m.bodyInput.Blur()
@@ -295,6 +381,16 @@ func (m *Model) Reset() {
 	m.titleInput.SetValue("")
 	m.bodyInput.SetValue("")
 	m.focusedField = 0
+	m.typeIdx = 0
+	m.priorityIdx = 0
+}
+
+// FixedFormLines is the number of form lines besides the description, for sizing the body.
+func (m *Model) FixedFormLines() int {
+	if len(m.options.Types) > 0 || len(m.options.Priorities) > 0 {
+		return 14
+	}
+	return 12
 }
 
 // GetSummary returns the title/summary
@@ -317,22 +413,24 @@ func (m *Model) SetDescription(description string) {
 	m.bodyInput.SetValue(description)
 }
 
-// GetFocusedField returns the focused field (0=title, 1=body)
+// GetFocusedField returns the focused field (0=title, 1=body, 2=type, 3=priority)
 func (m *Model) GetFocusedField() int {
 	return m.focusedField
 }
 
-// SetFocusedField sets the focused field
+// SetFocusedField sets the focused field; the type and priority pickers only when the provider
+// offers them.
 func (m *Model) SetFocusedField(i int) {
-	if i != 0 && i != 1 {
+	if i < 0 || i > 3 || (i == 2 && len(m.options.Types) == 0) || (i == 3 && len(m.options.Priorities) == 0) {
 		return
 	}
 	m.focusedField = i
-	if i == 0 {
+	m.titleInput.Blur()
+	m.bodyInput.Blur()
+	switch i {
+	case 0:
 		m.titleInput.Focus()
-		m.bodyInput.Blur()
-	} else {
-		m.titleInput.Blur()
+	case 1:
 		m.bodyInput.Focus()
 	}
 }
@@ -349,10 +447,17 @@ func (m *Model) GetBodyInput() *textarea.Model {
 
 // CreateTicketInput builds tickets.CreateTicketInput from the form
 func (m *Model) CreateTicketInput() *tickets.CreateTicketInput {
-	return &tickets.CreateTicketInput{
+	input := &tickets.CreateTicketInput{
 		Summary:     m.GetSummary(),
 		Description: m.GetDescription(),
 	}
+	if m.typeIdx > 0 && m.typeIdx <= len(m.options.Types) {
+		input.Type = m.options.Types[m.typeIdx-1]
+	}
+	if m.priorityIdx > 0 && m.priorityIdx <= len(m.options.Priorities) {
+		input.Priority = m.options.Priorities[m.priorityIdx-1]
+	}
+	return input
 }
 
 // SetAIProfiles updates the profile list shown by the long-press menu and the active profile mark.
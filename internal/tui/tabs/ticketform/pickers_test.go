@@ -0,0 +1,81 @@
+package ticketform
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/util"
+)
+
+func TestPickersSetTypeAndPriority(t *testing.T) {
+	svc := mock.NewTicketService("jira")
+	m := NewModel(nil)
+	if res := OpenCreateTicket(&m, svc, 60, 30); !res.Ok {
+		t.Fatalf("OpenCreateTicket: %+v", res)
+	}
+	m.SetSummary("Crash on start")
+	if !strings.Contains(m.View(), "Type: ‹ default ›") {
+		t.Fatalf("type picker missing:\n%s", m.View())
+	}
+
+	key := func(s string) {
+		var msg tea.KeyMsg
+		switch s {
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		}
+		m, _ = m.Update(msg)
+	}
+	key("tab")   // body
+	key("tab")   // type
+	key("right") // Task
+	key("right") // Bug
+	key("tab")   // priority
+	key("left")  // wraps to Lowest
+	if m.GetSummary() != "Crash on start" {
+		t.Errorf("picker keys leaked into the title: %q", m.GetSummary())
+	}
+
+	input := m.CreateTicketInput()
+	if input.Type != "Bug" || input.Priority != "Lowest" {
+		t.Errorf("input = %+v, want Bug/Lowest", input)
+	}
+
+	res := SubmitTicket(&m, svc, true)
+	msg := res.Cmd()
+	if e, ok := msg.(util.ErrorMsg); ok {
+		t.Fatal(e.Err)
+	}
+	created := msg.(TicketCreatedMsg).Ticket
+	if created.Type != "Bug" || created.Priority != "Lowest" {
+		t.Errorf("created %+v", created)
+	}
+
+	key("tab") // wraps back to the title
+	if m.GetFocusedField() != 0 {
+		t.Errorf("focus = %d, want title", m.GetFocusedField())
+	}
+}
+
+// Providers without CreateOptions get the plain title/description form.
+func TestNoPickersWithoutCreateOptions(t *testing.T) {
+	m := NewModel(nil)
+	OpenCreateTicket(&m, plainService{mock.NewTicketService("jira")}, 60, 30)
+	if strings.Contains(m.View(), "Priority:") {
+		t.Error("pickers shown for a provider without options")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.GetFocusedField() != 0 {
+		t.Errorf("Tab should cycle title/body only, focus = %d", m.GetFocusedField())
+	}
+}
+
+type plainService struct{ tickets.Service }
@@ -7,11 +7,28 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/i18n"
 	ticketdomain "github.com/madicen/jj-tui/internal/tickets"
 	"github.com/madicen/jj-tui/internal/tui/state"
 	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
+// PrefetchTicketsCmd fetches the assigned tickets without touching the status bar, for linking
+// bookmarks to tickets before the Tickets tab is first opened. Errors are dropped; the tab reports
+// them when it loads.
+func PrefetchTicketsCmd(svc ticketdomain.Service) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ticketList, err := svc.GetAssignedTickets(context.Background())
+		if err != nil {
+			return nil
+		}
+		return TicketsPrefetchedMsg{Tickets: ticketList}
+	}
+}
+
 // LoadTicketsCmd returns a command that fetches tickets and sends TicketsLoadedMsg or LoadErrorMsg.
 // Pass nil svc to send empty list; demoMode skips status filtering.
 func LoadTicketsCmd(svc ticketdomain.Service, demoMode bool) tea.Cmd {
@@ -25,42 +42,80 @@ func LoadTicketsCmd(svc ticketdomain.Service, demoMode bool) tea.Cmd {
 			return LoadErrorMsg{Err: fmt.Errorf("failed to load tickets: %w", err)}
 		}
 		if !demoMode {
-			cfg, _ := config.Load()
-			if cfg != nil {
-				excludedStatuses := make(map[string]bool)
-				var excludedStr string
-				switch service.GetProviderName() {
-				case "Jira":
-					excludedStr = cfg.JiraExcludedStatuses
-				case "Codecks":
-					excludedStr = cfg.CodecksExcludedStatuses
-				case "GitHub Issues":
-					excludedStr = cfg.GitHubIssuesExcludedStatuses
-				}
-				if excludedStr != "" {
-					for status := range strings.SplitSeq(excludedStr, ",") {
-						status = strings.TrimSpace(strings.ToLower(status))
-						if status != "" {
-							excludedStatuses[status] = true
-						}
-					}
-				}
-				if len(excludedStatuses) > 0 {
-					var filtered []ticketdomain.Ticket
-					for _, ticket := range ticketList {
-						statusLower := strings.ToLower(ticket.Status)
-						if !excludedStatuses[statusLower] {
-							filtered = append(filtered, ticket)
-						}
-					}
-					ticketList = filtered
-				}
-			}
+			ticketList = filterExcludedStatuses(service.GetProviderName(), ticketList)
 		}
 		return TicketsLoadedMsg{Tickets: ticketList}
 	}
 }
 
+// filterExcludedStatuses drops tickets whose status the provider's excluded-statuses setting lists.
+func filterExcludedStatuses(providerName string, ticketList []ticketdomain.Ticket) []ticketdomain.Ticket {
+	cfg, _ := config.Load()
+	if cfg == nil {
+		return ticketList
+	}
+	excludedStatuses := make(map[string]bool)
+	var excludedStr string
+	switch providerName {
+	case "Jira":
+		excludedStr = cfg.JiraExcludedStatuses
+	case "Codecks":
+		excludedStr = cfg.CodecksExcludedStatuses
+	case "GitHub Issues":
+		excludedStr = cfg.GitHubIssuesExcludedStatuses
+	}
+	if excludedStr != "" {
+		for status := range strings.SplitSeq(excludedStr, ",") {
+			status = strings.TrimSpace(strings.ToLower(status))
+			if status != "" {
+				excludedStatuses[status] = true
+			}
+		}
+	}
+	if len(excludedStatuses) == 0 {
+		return ticketList
+	}
+	var filtered []ticketdomain.Ticket
+	for _, ticket := range ticketList {
+		statusLower := strings.ToLower(ticket.Status)
+		if !excludedStatuses[statusLower] {
+			filtered = append(filtered, ticket)
+		}
+	}
+	return filtered
+}
+
+// PollTicketsCmd asks a provider that supports it whether the assigned tickets changed and sends
+// TicketsPolledMsg with the new list when they did. Returns nil for other providers; errors are
+// dropped like the startup prefetch's, since the next poll retries.
+func PollTicketsCmd(svc ticketdomain.Service) tea.Cmd {
+	poller, ok := svc.(ticketdomain.ChangePoller)
+	if !ok || util.IsNilInterface(svc) {
+		return nil
+	}
+	providerName := svc.GetProviderName()
+	return func() tea.Msg {
+		ticketList, changed, err := poller.PollAssignedTickets(context.Background())
+		if err != nil || !changed {
+			return TicketsPolledMsg{}
+		}
+		return TicketsPolledMsg{Tickets: filterExcludedStatuses(providerName, ticketList), Changed: true}
+	}
+}
+
+// CloseIssueForMergedPRCmd closes the ticket linked to a merged PR's branch with a comment linking
+// the PR (GitHub Issues), and sends IssueClosedForPRMsg. Returns nil when the provider can't.
+func CloseIssueForMergedPRCmd(svc ticketdomain.Service, ticketKey, ticketLabel string, prNumber int) tea.Cmd {
+	closer, ok := svc.(ticketdomain.MergeCloser)
+	if !ok || util.IsNilInterface(svc) || ticketKey == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		closed, err := closer.CloseForMergedPR(context.Background(), ticketKey, prNumber)
+		return IssueClosedForPRMsg{TicketLabel: ticketLabel, PRNumber: prNumber, Closed: closed, Err: err}
+	}
+}
+
 // LoadTransitionsCmd returns a command that loads transitions for the selected ticket and sends TransitionsLoadedMsg.
 func LoadTransitionsCmd(svc ticketdomain.Service, ticketList []ticketdomain.Ticket, selectedIdx int) tea.Cmd {
 	if svc == nil || selectedIdx < 0 || selectedIdx >= len(ticketList) {
@@ -153,7 +208,7 @@ func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.C
 		}
 		// When app is non-nil, caller (update) sets status and toggles mode; no cmd.
 		// When app is nil, return effect so main sets status and toggles.
-		status := "Ready"
+		status := i18n.T("status.ready")
 		if !ctx.IsStatusChangeMode {
 			status = "Change status (i/D/B/N)"
 		}
@@ -180,6 +235,17 @@ func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.C
 		}
 		return "", state.NavigateTarget{Kind: state.NavigateCreateTicket}.Cmd()
 	}
+	if r.LoadDetail {
+		ticket := ctx.SelectedTicketData()
+		if ticket == nil || ctx.TicketService == nil {
+			return "", nil
+		}
+		key := ticket.DisplayKey
+		if key == "" {
+			key = ticket.Key
+		}
+		return fmt.Sprintf("Loading details for %s...", key), LoadTicketDetailCmd(ctx.TicketService, *ticket)
+	}
 	if r.OpenInBrowser {
 		if ctx.TicketService == nil || !ctx.SelectedTicketValid() {
 			return "", nil
@@ -211,3 +277,32 @@ func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.C
 	}
 	return "", nil
 }
+
+// PagerTarget opens t's summary, status, and full description in the pager.
+func PagerTarget(t ticketdomain.Ticket) state.NavigateTarget {
+	var meta []string
+	for _, s := range []string{t.Type, t.Status, t.Priority} {
+		if s != "" {
+			meta = append(meta, s)
+		}
+	}
+	desc := t.MarkdownDescription()
+	if desc == "" {
+		desc = "(no description)"
+	}
+	var b strings.Builder
+	b.WriteString(t.Summary + "\n")
+	if len(meta) > 0 {
+		b.WriteString(strings.Join(meta, " · ") + "\n")
+	}
+	b.WriteString("\n" + desc)
+	key := t.DisplayKey
+	if key == "" {
+		key = t.Key
+	}
+	return state.NavigateTarget{
+		Kind:         state.NavigateOpenPager,
+		PagerTitle:   fmt.Sprintf("%s: %s", key, t.Summary),
+		PagerContent: b.String(),
+	}
+}
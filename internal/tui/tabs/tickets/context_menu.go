@@ -61,12 +61,12 @@ func (m *Model) renderContextMenu() string {
 		Padding(0, 1)
 
 	itemStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2"))
+		Foreground(styles.ColorText)
 	hoverStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#F8F8F2")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	hoverKeyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#CCCCCC")).
+		Foreground(styles.ColorOnAccent).
 		Background(styles.ColorPrimary)
 	disabledStyle := lipgloss.NewStyle().
 		Foreground(styles.ColorMuted)
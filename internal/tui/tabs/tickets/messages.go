@@ -1,22 +1,62 @@
 package tickets
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 	ticketdomain "github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/state"
 )
 
 // TicketsLoadedMsg is sent when tickets are loaded from the ticket service.
 type TicketsLoadedMsg struct {
+	state.RepoResult
+	Tickets []ticketdomain.Ticket
+}
+
+// TicketsPrefetchedMsg carries the tickets fetched in the background at startup (PrefetchTicketsCmd).
+type TicketsPrefetchedMsg struct {
+	state.RepoResult
 	Tickets []ticketdomain.Ticket
 }
 
+// TicketsPolledMsg is the result of PollTicketsCmd. Tickets is only set when Changed.
+type TicketsPolledMsg struct {
+	state.RepoResult
+	Tickets []ticketdomain.Ticket
+	Changed bool
+}
+
+// IssueClosedForPRMsg reports closing the issue linked to a merged PR (CloseIssueForMergedPRCmd).
+// Closed is false when the issue was already closed.
+type IssueClosedForPRMsg struct {
+	state.RepoResult
+	TicketLabel string
+	PRNumber    int
+	Closed      bool
+	Err         error
+}
+
+// Status returns the status bar message for the close.
+func (m IssueClosedForPRMsg) Status() string {
+	switch {
+	case m.Err != nil:
+		return fmt.Sprintf("PR #%d merged; couldn't close %s: %v", m.PRNumber, m.TicketLabel, m.Err)
+	case m.Closed:
+		return fmt.Sprintf("PR #%d merged; closed %s", m.PRNumber, m.TicketLabel)
+	}
+	return fmt.Sprintf("PR #%d merged; %s was already closed", m.PRNumber, m.TicketLabel)
+}
+
 // TransitionsLoadedMsg is sent when available transitions are loaded for a ticket.
 type TransitionsLoadedMsg struct {
+	state.RepoResult
 	Transitions []ticketdomain.Transition
 }
 
 // TransitionCompletedMsg is sent when a ticket status transition completes.
 type TransitionCompletedMsg struct {
+	state.RepoResult
 	TicketKey string
 	NewStatus string
 	Err       error
@@ -24,6 +64,7 @@ type TransitionCompletedMsg struct {
 
 // LoadErrorMsg is sent when loading tickets fails (main shows error modal).
 type LoadErrorMsg struct {
+	state.RepoResult
 	Err error
 }
 
@@ -35,6 +76,7 @@ type Request struct {
 	StartCreateTicket         bool // open Create Ticket modal when provider supports it
 	TransitionID               string
 	LoadTransitionsForSelection bool
+	LoadDetail                 bool // fetch the selected ticket's comments, assignee and labels
 }
 
 // Cmd returns a tea.Cmd that sends this request.
@@ -9,10 +9,12 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	overlay "github.com/madicen/bubble-overlay"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/i18n"
 	"github.com/madicen/jj-tui/internal/tickets"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/mousedouble"
 	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/util"
 )
 
 // Model represents the state of the Tickets tab
@@ -42,6 +44,16 @@ type Model struct {
 	statusSubmenu      *StatusSubmenuState
 
 	rowDoubleClick mousedouble.DoubleClick
+	clickBindings  mousedouble.Bindings // configured double/middle-click actions, refreshed on each zone click
+
+	// detail is the open ticket detail view (d; nil = closed); detailCache keeps fetched details by
+	// ticket key until the tickets reload.
+	detail      *ticketDetailState
+	detailCache map[string]*tickets.TicketDetail
+
+	// repository and ticketBookmarks (bookmark name to ticket key) find the PRs linked to a ticket.
+	repository      *internal.Repository
+	ticketBookmarks map[string]string
 }
 
 // NewModel creates a new Tickets tab model. zoneManager may be nil (e.g. in tests).
@@ -102,13 +114,13 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 			pName = msg.ProviderName + " tickets"
 		}
 		if app != nil {
-			app.StatusMessage = fmt.Sprintf("Loaded %d %s", len(msg.Tickets), pName)
+			app.StatusMessage = i18n.T("status.loaded_tickets", len(msg.Tickets), pName)
 			m.SetAvailableTransitions(nil)
 			m.SetLoadingTransitions(true)
 			return m, LoadTransitionsCmd(app.TicketService, m.GetTickets(), m.GetSelectedTicket())
 		}
 		return m, ApplyTicketsLoadedEffect{
-			StatusMessage: fmt.Sprintf("Loaded %d %s", len(msg.Tickets), pName),
+			StatusMessage: i18n.T("status.loaded_tickets", len(msg.Tickets), pName),
 		}.Cmd()
 	case TransitionsLoadedMsg:
 		m.SetLoadingTransitions(false)
@@ -144,12 +156,20 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 			StatusMessage: statusMsg,
 			ReloadTickets: reload,
 		}.Cmd()
+	case TicketDetailLoadedMsg:
+		m.setTicketDetail(msg)
+		if app != nil && msg.Err != nil {
+			app.StatusMessage = fmt.Sprintf("Failed to load details for %s: %v", msg.Key, msg.Err)
+		} else if app != nil && msg.Detail != nil {
+			app.StatusMessage = fmt.Sprintf("%s: %d comments", m.detailDisplayKey(msg.Key), len(msg.Detail.Comments))
+		}
+		return m, nil
 	case LoadErrorMsg:
 		if app != nil {
 			app.StatusMessage = fmt.Sprintf("Error: %v", msg.Err)
 			return m, nil
 		}
-		return m, ApplyTicketsLoadErrorEffect(msg).Cmd()
+		return m, ApplyTicketsLoadErrorEffect{Err: msg.Err}.Cmd()
 
 	case tea.WindowSizeMsg:
 		return m, nil
@@ -162,7 +182,7 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 				if newMode {
 					app.StatusMessage = "Change status (i/D/B/N)"
 				} else {
-					app.StatusMessage = "Ready"
+					app.StatusMessage = i18n.T("status.ready")
 				}
 				return updated, nil
 			}
@@ -184,6 +204,9 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 		}
 		return updated, cmd
 	case zone.MsgZoneInBounds:
+		if app != nil {
+			m.clickBindings = mousedouble.BindingsFrom(app.Config)
+		}
 		updated, req, cmd := m.handleZoneClick(msg.Zone, msg.Event)
 		if req != nil && app != nil {
 			if req.ToggleStatusChangeMode {
@@ -192,7 +215,7 @@ func (m Model) update(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
 				if newMode {
 					app.StatusMessage = "Change status (i/D/B/N)"
 				} else {
-					app.StatusMessage = "Ready"
+					app.StatusMessage = i18n.T("status.ready")
 				}
 				return updated, nil
 			}
@@ -300,6 +323,9 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
 		m.contextMenu = nil
 		return m, nil, nil
 	}
+	if m.detail != nil {
+		return m.handleTicketDetailKey(msg)
+	}
 	switch msg.String() {
 	case "j", "down":
 		if m.selectedTicket < len(m.ticketList)-1 {
@@ -331,6 +357,16 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
 		return m, nil, nil
 	case "o":
 		return m, &Request{OpenInBrowser: true}, nil
+	case "d":
+		if m.openTicketDetail() {
+			return m, &Request{LoadDetail: true}, nil
+		}
+		return m, nil, nil
+	case "v":
+		if m.selectedTicket >= 0 && m.selectedTicket < len(m.ticketList) {
+			return m, nil, PagerTarget(m.ticketList[m.selectedTicket]).Cmd()
+		}
+		return m, nil, nil
 	case "n":
 		if m.canCreateTicket {
 			return m, &Request{StartCreateTicket: true}, nil
@@ -445,11 +481,24 @@ func (m Model) handleZoneClick(z *zone.ZoneInfo, event tea.MouseMsg) (Model, *Re
 	}
 	for i := range m.ticketList {
 		if m.zoneManager.Get(mouse.ZoneJiraTicket(i)) == z {
+			click := m.rowDoubleClick.Classify(fmt.Sprintf("tickets:%d", i), event, time.Now(), m.clickBindings)
+			switch click {
+			case mousedouble.ClickIgnore:
+				return m, nil, nil
+			case mousedouble.ClickCopy:
+				key := m.ticketList[i].DisplayKey
+				if key == "" {
+					key = m.ticketList[i].Key
+				}
+				return m, nil, util.CopyToClipboard(key)
+			}
 			m.selectedTicket = i
 			m.scrollToSelectedTicket = true
-			key := fmt.Sprintf("tickets:%d", i)
-			if m.rowDoubleClick.ObserveLeftRelease(key, event, time.Now(), mousedouble.DefaultDoubleClickWindow) {
+			switch click {
+			case mousedouble.ClickPrimary:
 				return m, &Request{OpenInBrowser: true}, nil
+			case mousedouble.ClickView:
+				return m, nil, PagerTarget(m.ticketList[i]).Cmd()
 			}
 			return m, &Request{LoadTransitionsForSelection: true}, nil
 		}
@@ -466,6 +515,9 @@ func (m Model) handleZoneClick(z *zone.ZoneInfo, event tea.MouseMsg) (Model, *Re
 	if m.zoneManager.Get(mouse.ZoneTicketOpenBrowser) == z {
 		return m, &Request{OpenInBrowser: true}, nil
 	}
+	if m.zoneManager.Get(mouse.ZoneTicketRead) == z && m.selectedTicket >= 0 && m.selectedTicket < len(m.ticketList) {
+		return m, nil, PagerTarget(m.ticketList[m.selectedTicket]).Cmd()
+	}
 	if m.statusChangeMode && inBounds(mouse.ZoneStatusPopoverClose) {
 		return m, &Request{ToggleStatusChangeMode: true}, nil
 	}
@@ -507,8 +559,10 @@ func (m *Model) GetTickets() []tickets.Ticket {
 // UpdateTickets updates the ticket list
 func (m *Model) UpdateTickets(ticketList []tickets.Ticket) {
 	m.ticketList = ticketList
+	m.detailCache = nil
 	if len(ticketList) == 0 {
 		m.selectedTicket = -1
+		m.detail = nil
 		return
 	}
 	if m.selectedTicket < 0 {
@@ -520,12 +574,15 @@ func (m *Model) UpdateTickets(ticketList []tickets.Ticket) {
 		m.selectedTicket = len(ticketList) - 1
 		m.scrollToSelectedTicket = true
 	}
+	if t := m.selectedTicketData(); m.detail != nil && (t == nil || t.Key != m.detail.key) {
+		m.detail = nil
+	}
 }
 
 // UpdateRepository updates the repository
 func (m *Model) UpdateRepository(repo *internal.Repository) {
-	// Repos may be updated but tickets are loaded separately
-	// This is a no-op for tickets but required for interface consistency
+	// Tickets are loaded separately; the repository's PRs are only used to link PRs to tickets.
+	m.repository = repo
 }
 
 // GetAvailableTransitions returns available transitions
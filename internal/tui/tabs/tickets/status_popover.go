@@ -22,16 +22,16 @@ func transitionShortcutAndStyle(t tickets.Transition) (shortcut string, btnStyle
 	switch {
 	case isNotStarted:
 		shortcut = " (N)"
-		btnStyle = lipgloss.NewStyle().Background(lipgloss.Color("#6272A4")).Foreground(lipgloss.Color("#FFFFFF")).Padding(0, 1).Bold(true)
+		btnStyle = lipgloss.NewStyle().Background(styles.ColorMuted).Foreground(styles.ColorOnAccent).Padding(0, 1).Bold(true)
 	case isInProgress:
 		shortcut = " (i)"
-		btnStyle = lipgloss.NewStyle().Background(lipgloss.Color("#FFB86C")).Foreground(lipgloss.Color("#000000")).Padding(0, 1).Bold(true)
+		btnStyle = lipgloss.NewStyle().Background(styles.ColorWarning).Foreground(styles.ColorOnBright).Padding(0, 1).Bold(true)
 	case strings.Contains(lowerName, "done") || strings.Contains(lowerName, "complete") || strings.Contains(lowerName, "resolve"):
 		shortcut = " (D)"
-		btnStyle = lipgloss.NewStyle().Background(lipgloss.Color("#50FA7B")).Foreground(lipgloss.Color("#000000")).Padding(0, 1).Bold(true)
+		btnStyle = lipgloss.NewStyle().Background(styles.ColorPositive).Foreground(styles.ColorOnBright).Padding(0, 1).Bold(true)
 	case strings.Contains(lowerName, "block"):
 		shortcut = " (B)"
-		btnStyle = lipgloss.NewStyle().Background(lipgloss.Color("#FF5555")).Foreground(lipgloss.Color("#FFFFFF")).Padding(0, 1).Bold(true)
+		btnStyle = lipgloss.NewStyle().Background(styles.ColorNegative).Foreground(styles.ColorOnAccent).Padding(0, 1).Bold(true)
 	}
 	return shortcut, btnStyle
 }
@@ -40,10 +40,10 @@ func transitionShortcutAndStyle(t tickets.Transition) (shortcut string, btnStyle
 // hoverIdx enables hover highlighting (-1 means no hover). Used by both the actions-bar
 // popover and the cascading submenu from the long-press context menu.
 func (m *Model) renderStatusPopoverPanel(hoverIdx int) string {
-	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F8F8F2")).Background(styles.ColorPrimary)
+	hoverStyle := lipgloss.NewStyle().Foreground(styles.ColorOnAccent).Background(styles.ColorPrimary)
 
 	var lines []string
-	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#8BE9FD")).Render("Change status")
+	title := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorAccent).Render("Change status")
 	lines = append(lines, title)
 	lines = append(lines, "")
 
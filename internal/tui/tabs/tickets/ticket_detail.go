@@ -0,0 +1,289 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal"
+	ticketdomain "github.com/madicen/jj-tui/internal/tickets"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/xref"
+	"github.com/mattn/go-runewidth"
+)
+
+// TicketDetailLoadedMsg carries a ticket's comments, assignee and labels (d). Unsupported is set
+// when the provider can't fetch them; the view then shows the list fields only.
+type TicketDetailLoadedMsg struct {
+	state.RepoResult
+	Key         string
+	Detail      *ticketdomain.TicketDetail
+	Unsupported bool
+	Err         error
+}
+
+// LoadTicketDetailCmd fetches the detail of ticket t from the provider.
+func LoadTicketDetailCmd(svc ticketdomain.Service, t ticketdomain.Ticket) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	ds, ok := svc.(ticketdomain.DetailService)
+	if !ok {
+		return func() tea.Msg { return TicketDetailLoadedMsg{Key: t.Key, Unsupported: true} }
+	}
+	return func() tea.Msg {
+		d, err := ds.GetTicketDetail(context.Background(), t.Key)
+		return TicketDetailLoadedMsg{Key: t.Key, Detail: d, Err: err}
+	}
+}
+
+// ticketDetailState is the open ticket detail view (d), which replaces the ticket list.
+type ticketDetailState struct {
+	key         string
+	detail      *ticketdomain.TicketDetail // nil while loading or when unsupported
+	loading     bool
+	unsupported bool
+	err         string
+}
+
+// openTicketDetail opens the detail view for the selected ticket. It returns true when the
+// detail still has to be fetched; loaded details are cached until the tickets reload.
+func (m *Model) openTicketDetail() bool {
+	t := m.selectedTicketData()
+	if t == nil {
+		return false
+	}
+	m.listYOffset = 0
+	if d, ok := m.detailCache[t.Key]; ok {
+		m.detail = &ticketDetailState{key: t.Key, detail: d}
+		return false
+	}
+	m.detail = &ticketDetailState{key: t.Key, loading: true}
+	return true
+}
+
+// setTicketDetail applies a load result to the open view and caches it.
+func (m *Model) setTicketDetail(msg TicketDetailLoadedMsg) {
+	if msg.Err == nil && msg.Detail != nil {
+		if m.detailCache == nil {
+			m.detailCache = map[string]*ticketdomain.TicketDetail{}
+		}
+		m.detailCache[msg.Key] = msg.Detail
+	}
+	st := m.detail
+	if st == nil || st.key != msg.Key {
+		return
+	}
+	st.loading = false
+	st.unsupported = msg.Unsupported
+	st.detail = msg.Detail
+	if msg.Err != nil {
+		st.err = msg.Err.Error()
+	}
+}
+
+// SetTicketBookmarks records which bookmarks were created from which ticket (bookmark name to
+// ticket key), so PRs from those bookmarks are listed as linked in the detail view.
+func (m *Model) SetTicketBookmarks(bookmarks map[string]string) {
+	m.ticketBookmarks = bookmarks
+}
+
+// linkedPRs returns the PRs that belong to t: opened from a bookmark created from it, named after
+// its key, or mentioning it in the title or body.
+func (m *Model) linkedPRs(t ticketdomain.Ticket) []internal.GitHubPR {
+	if m.repository == nil {
+		return nil
+	}
+	key := t.DisplayKey
+	if key == "" {
+		key = t.Key
+	}
+	mentions := func(text string) bool {
+		for _, ref := range xref.Find(text, nil) {
+			switch ref.Kind {
+			case xref.KindTicket:
+				if strings.EqualFold(ref.Value, key) {
+					return true
+				}
+			case xref.KindPR:
+				if ref.Text == key {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	// Jira keys survive in bookmark names ("proj-142-dark-mode"); issue numbers are too short to match.
+	branchKey := ""
+	if !strings.HasPrefix(key, "#") {
+		branchKey = strings.ToLower(strings.TrimPrefix(key, "$"))
+	}
+	var out []internal.GitHubPR
+	for _, pr := range m.repository.PRs {
+		switch {
+		case m.ticketBookmarks[pr.HeadBranch] == t.Key,
+			branchKey != "" && strings.Contains(strings.ToLower(pr.HeadBranch), branchKey),
+			mentions(pr.Title), mentions(pr.Body):
+			out = append(out, pr)
+		}
+	}
+	return out
+}
+
+// handleTicketDetailKey handles keys while the detail view is open: j/k and PgUp/PgDn scroll, v
+// reads everything in the pager, o opens the ticket in the browser, and Esc or d close the view.
+func (m Model) handleTicketDetailKey(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.listYOffset++
+	case "k", "up":
+		m.listYOffset = max(m.listYOffset-1, 0)
+	case "pgdown", "ctrl+d", "ctrl+f":
+		m.listYOffset += 10
+	case "pgup", "ctrl+u", "ctrl+b":
+		m.listYOffset = max(m.listYOffset-10, 0)
+	case "home":
+		m.listYOffset = 0
+	case "end":
+		m.listYOffset = 99999
+	case "v":
+		if t := m.selectedTicketData(); t != nil {
+			return m, nil, ticketDetailPager(*t, m.detail.detail, m.linkedPRs(*t)).Cmd()
+		}
+	case "o":
+		return m, &Request{OpenInBrowser: true}, nil
+	case "esc", "d":
+		m.detail = nil
+		m.listYOffset = 0
+		m.scrollToSelectedTicket = true
+	}
+	return m, nil, nil
+}
+
+// renderTicketDetail renders the detail view shown instead of the ticket list.
+func (m *Model) renderTicketDetail() []string {
+	t := m.selectedTicketData()
+	if t == nil {
+		return nil
+	}
+	st := m.detail
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	bold := lipgloss.NewStyle().Bold(true)
+	width := max(m.width-4, 40)
+	fit := func(s string) string { return runewidth.Truncate(s, width, "…") }
+	key := t.DisplayKey
+	if key == "" {
+		key = t.Key
+	}
+	lines := []string{
+		bold.Render("Details of "+key) + muted.Render(" · j/k scroll · v read all · o open · Esc back"),
+	}
+
+	d := st.detail
+	switch {
+	case st.loading:
+		lines = append(lines, muted.Render(fmt.Sprintf("  Loading from %s…", m.providerName)))
+	case st.err != "":
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorNegative).Render("  "+st.err))
+	case st.unsupported:
+		lines = append(lines, muted.Render(fmt.Sprintf("  %s doesn't provide comments, assignee or labels here.", m.providerName)))
+	}
+	if d != nil {
+		assignee := d.Assignee
+		if assignee == "" {
+			assignee = muted.Render("unassigned")
+		}
+		lines = append(lines, fit("Assignee: "+assignee))
+		if len(d.Labels) > 0 {
+			lines = append(lines, fit("Labels: "+strings.Join(d.Labels, ", ")))
+		}
+	}
+
+	lines = append(lines, "", bold.Render("Description"))
+	desc := t.MarkdownDescription()
+	if d != nil && strings.TrimSpace(d.DescriptionMarkdown) != "" {
+		desc = d.DescriptionMarkdown
+	}
+	if strings.TrimSpace(desc) != "" {
+		lines = append(lines, styles.RenderMarkdown(desc, width)...)
+	} else {
+		lines = append(lines, muted.Italic(true).Render("  (No description)"))
+	}
+
+	prs := m.linkedPRs(*t)
+	lines = append(lines, "", bold.Render(fmt.Sprintf("Linked PRs (%d)", len(prs))))
+	if len(prs) == 0 {
+		lines = append(lines, muted.Render("  No PRs mention "+key+"."))
+	}
+	for _, pr := range prs {
+		lines = append(lines, fit(fmt.Sprintf("  #%d %s %s", pr.Number, pr.Title, muted.Render("("+pr.State+", "+pr.HeadBranch+")"))))
+	}
+
+	if d != nil {
+		lines = append(lines, "", bold.Render(fmt.Sprintf("Comments (%d)", len(d.Comments))))
+		if len(d.Comments) == 0 {
+			lines = append(lines, muted.Render("  No comments."))
+		}
+		for _, c := range d.Comments {
+			header := "  " + bold.Render(c.Author)
+			if !c.Created.IsZero() {
+				header += " " + muted.Render(c.Created.Local().Format("2006-01-02 15:04"))
+			}
+			lines = append(lines, fit(header))
+			lines = append(lines, styles.RenderMarkdown(c.Body, width-2)...)
+		}
+	}
+	return lines
+}
+
+// ticketDetailPager opens the ticket with its detail, linked PRs and comments in the pager.
+func ticketDetailPager(t ticketdomain.Ticket, d *ticketdomain.TicketDetail, prs []internal.GitHubPR) state.NavigateTarget {
+	target := PagerTarget(t)
+	var b strings.Builder
+	b.WriteString(target.PagerContent)
+	if d != nil {
+		fmt.Fprintf(&b, "\n\nAssignee: %s\n", d.Assignee)
+		if len(d.Labels) > 0 {
+			fmt.Fprintf(&b, "Labels: %s\n", strings.Join(d.Labels, ", "))
+		}
+	}
+	fmt.Fprintf(&b, "\nLinked PRs\n")
+	for _, pr := range prs {
+		fmt.Fprintf(&b, "  #%d %s (%s) %s\n", pr.Number, pr.Title, pr.State, pr.URL)
+	}
+	if d != nil {
+		fmt.Fprintf(&b, "\nComments\n")
+		for _, c := range d.Comments {
+			fmt.Fprintf(&b, "\n%s · %s\n%s\n", c.Author, c.Created.Local().Format("2006-01-02 15:04"), strings.TrimSpace(c.Body))
+		}
+	}
+	target.PagerContent = b.String()
+	return target
+}
+
+// detailDisplayKey returns the display key of the ticket with key, for status messages.
+func (m *Model) detailDisplayKey(key string) string {
+	for _, t := range m.ticketList {
+		if t.Key == key && t.DisplayKey != "" {
+			return t.DisplayKey
+		}
+	}
+	return key
+}
+
+// selectedTicketData returns the selected ticket or nil.
+func (m *Model) selectedTicketData() *ticketdomain.Ticket {
+	if m.selectedTicket < 0 || m.selectedTicket >= len(m.ticketList) {
+		return nil
+	}
+	return &m.ticketList[m.selectedTicket]
+}
+
+// IsTicketDetailOpen reports whether the detail view replaces the ticket list (Esc closes it
+// instead of leaving the tab).
+func (m *Model) IsTicketDetailOpen() bool {
+	return m.detail != nil
+}
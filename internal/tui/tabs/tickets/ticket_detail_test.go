@@ -0,0 +1,72 @@
+package tickets
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/mock"
+	"github.com/madicen/jj-tui/internal/tickets"
+)
+
+func TestTicketDetail_LoadsAndCloses(t *testing.T) {
+	svc := mock.NewTicketService("jira")
+	list, _ := svc.GetAssignedTickets(context.Background())
+	m := newTestModel()
+	m.UpdateTickets(list)
+	m.UpdateRepository(&internal.Repository{PRs: []internal.GitHubPR{
+		{Number: 7, Title: "Fix PROJ-142 crash", State: "open", HeadBranch: "fix-crash"},
+		{Number: 8, Title: "Unrelated", State: "open", HeadBranch: "other"},
+	}})
+	m.selectedTicket = 0
+
+	updated, req, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = updated
+	if req == nil || !req.LoadDetail {
+		t.Fatalf("d should request a detail load, got %+v", req)
+	}
+	if !m.IsTicketDetailOpen() {
+		t.Fatal("detail view should open while loading")
+	}
+
+	msg := LoadTicketDetailCmd(svc, list[0])()
+	updated, _ = m.Update(msg)
+	m = updated
+
+	view := m.View()
+	for _, want := range []string{"Demo User", "Alex Reviewer", "#7 Fix PROJ-142 crash", "Comments (2)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Unrelated") {
+		t.Error("unlinked PR listed in the detail view")
+	}
+
+	updated, _, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated
+	if m.IsTicketDetailOpen() {
+		t.Fatal("Esc should close the detail view")
+	}
+
+	// Reopening uses the cached detail.
+	updated, req, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = updated
+	if req != nil {
+		t.Errorf("cached detail should not be fetched again, got %+v", req)
+	}
+	if !strings.Contains(m.View(), "Alex Reviewer") {
+		t.Error("cached detail not shown")
+	}
+}
+
+type noDetailService struct{ tickets.Service }
+
+func TestLoadTicketDetailCmd_Unsupported(t *testing.T) {
+	msg := LoadTicketDetailCmd(noDetailService{}, tickets.Ticket{Key: "PROJ-1"})().(TicketDetailLoadedMsg)
+	if !msg.Unsupported {
+		t.Errorf("provider without DetailService should report Unsupported, got %+v", msg)
+	}
+}
@@ -6,6 +6,9 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal/i18n"
+	"github.com/madicen/jj-tui/internal/keymap"
+	"github.com/madicen/jj-tui/internal/tui/avatar"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/styles"
 )
@@ -66,10 +69,11 @@ func (m *Model) renderTickets() string {
 		}
 
 		var detailLines []string
-		detailLines = append(detailLines, fmt.Sprintf("%s %s",
-			lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render(displayKey),
-			ticket.Summary,
-		))
+		keyLabel := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render(displayKey)
+		if ticket.Type != "" {
+			keyLabel = avatar.TypeIcon(ticket.Type) + " " + keyLabel
+		}
+		detailLines = append(detailLines, fmt.Sprintf("%s %s", keyLabel, ticket.Summary))
 		detailLines = append(detailLines, fmt.Sprintf("Type: %s  |  Priority: %s  |  Status: %s",
 			ticket.Type, ticket.Priority, ticket.Status,
 		))
@@ -91,25 +95,26 @@ func (m *Model) renderTickets() string {
 		headerLines = append(headerLines, detailsBox)
 		detailsLineCount := strings.Count(detailsBox, "\n") + 1
 
-		separatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#444444"))
+		separatorStyle := lipgloss.NewStyle().Foreground(styles.ColorSeparator)
 		separatorWidth := m.width - 4
 		if separatorWidth < 20 {
 			separatorWidth = 80
 		}
 		separator := separatorStyle.Render(strings.Repeat("─", separatorWidth))
 		headerLines = append(headerLines, separator)
-		headerLines = append(headerLines, "Actions:")
+		headerLines = append(headerLines, i18n.T("label.actions"))
 		// Line index (0-based) of the actions button row within the final tickets view (before list).
 		actionsRowLineIndex := detailsLineCount + 2
 
 		var actionButtons []string
 		actionButtons = append(actionButtons,
-			mark(m.zoneManager, mouse.ZoneJiraCreateBranch, styles.ButtonStyle.Render("Create Branch (Enter)")),
-			mark(m.zoneManager, mouse.ZoneJiraOpenBrowser, styles.ButtonStyle.Render("Open in Browser (o)")),
+			mark(m.zoneManager, mouse.ZoneJiraCreateBranch, styles.ButtonStyle.Render(i18n.T("action.create_branch"))),
+			mark(m.zoneManager, mouse.ZoneJiraOpenBrowser, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.open_in_browser"), "ticket.open"))),
+			mark(m.zoneManager, mouse.ZoneTicketRead, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.read"), "ticket.read"))),
 		)
 		if m.canCreateTicket {
 			actionButtons = append(actionButtons,
-				mark(m.zoneManager, mouse.ZoneTicketNew, styles.ButtonStyle.Render("New Ticket (n)")),
+				mark(m.zoneManager, mouse.ZoneTicketNew, styles.ButtonStyle.Render(keymap.Button(i18n.T("action.new_ticket"), "ticket.new"))),
 			)
 		}
 
@@ -117,8 +122,8 @@ func (m *Model) renderTickets() string {
 		if len(m.availableTransitions) > 0 && !m.transitionInProgress {
 			if m.statusChangeMode {
 				highlightedBtnStyle := lipgloss.NewStyle().
-					Background(lipgloss.Color("#BD93F9")).
-					Foreground(lipgloss.Color("#000000")).
+					Background(styles.ColorAI).
+					Foreground(styles.ColorOnBright).
 					Padding(0, 1).
 					Bold(true)
 				changeStatusContent := highlightedBtnStyle.Render("Change Status (c)")
@@ -152,40 +157,12 @@ func (m *Model) renderTickets() string {
 		headerLines = append(headerLines, separator)
 	}
 
-	headerLines = append(headerLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Select a ticket to create a branch:"))
-
 	var listLines []string
-	for i, ticket := range m.ticketList {
-		prefix := "  "
-		style := styles.CommitStyle
-		if i == m.selectedTicket {
-			prefix = "► "
-			style = styles.CommitSelectedStyle
-		}
-		var statusStyle lipgloss.Style
-		switch strings.ToLower(ticket.Status) {
-		case "to do", "open", "backlog", "not started":
-			statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#6272A4"))
-		case "in progress", "in review", "started":
-			statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB86C"))
-		case "done", "closed", "resolved":
-			statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#50FA7B"))
-		case "blocked":
-			statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555"))
-		default:
-			statusStyle = lipgloss.NewStyle().Foreground(styles.ColorMuted)
-		}
-		displayKey := ticket.DisplayKey
-		if displayKey == "" {
-			displayKey = ticket.Key
-		}
-		ticketLine := fmt.Sprintf("%s%s %s %s",
-			prefix,
-			lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render(displayKey),
-			statusStyle.Render("["+ticket.Status+"]"),
-			ticket.Summary,
-		)
-		listLines = append(listLines, mark(m.zoneManager, mouse.ZoneJiraTicket(i), style.Render(ticketLine)))
+	if m.detail != nil {
+		listLines = m.renderTicketDetail()
+	} else {
+		headerLines = append(headerLines, lipgloss.NewStyle().Foreground(styles.ColorMuted).Render("Select a ticket to create a branch:"))
+		listLines = m.renderTicketRows()
 	}
 
 	fixedHeader := strings.Join(headerLines, "\n")
@@ -241,3 +218,41 @@ func (m *Model) renderTickets() string {
 	}
 	return outStr
 }
+
+// renderTicketRows renders one row per ticket, the selected one highlighted.
+func (m *Model) renderTicketRows() []string {
+	var listLines []string
+	for i, ticket := range m.ticketList {
+		prefix := "  "
+		style := styles.CommitStyle
+		if i == m.selectedTicket {
+			prefix = "► "
+			style = styles.CommitSelectedStyle
+		}
+		var statusStyle lipgloss.Style
+		switch strings.ToLower(ticket.Status) {
+		case "to do", "open", "backlog", "not started":
+			statusStyle = lipgloss.NewStyle().Foreground(styles.ColorMuted)
+		case "in progress", "in review", "started":
+			statusStyle = lipgloss.NewStyle().Foreground(styles.ColorWarning)
+		case "done", "closed", "resolved":
+			statusStyle = lipgloss.NewStyle().Foreground(styles.ColorPositive)
+		case "blocked":
+			statusStyle = lipgloss.NewStyle().Foreground(styles.ColorNegative)
+		default:
+			statusStyle = lipgloss.NewStyle().Foreground(styles.ColorMuted)
+		}
+		displayKey := ticket.DisplayKey
+		if displayKey == "" {
+			displayKey = ticket.Key
+		}
+		ticketLine := fmt.Sprintf("%s%s %s %s",
+			prefix,
+			lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render(displayKey),
+			statusStyle.Render("["+ticket.Status+"]"),
+			ticket.Summary,
+		)
+		listLines = append(listLines, mark(m.zoneManager, mouse.ZoneJiraTicket(i), style.Render(ticketLine)))
+	}
+	return listLines
+}
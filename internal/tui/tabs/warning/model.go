@@ -5,9 +5,12 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/i18n"
 	"github.com/madicen/jj-tui/internal/tui/mouse"
 	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
 	"github.com/madicen/jj-tui/internal/tui/util"
+	"github.com/madicen/jj-tui/internal/tui/xref"
 )
 
 // Model represents the warning modal (e.g., for empty commit descriptions)
@@ -16,6 +19,8 @@ type Model struct {
 	title       string
 	message     string
 	commits     []internal.Commit
+	details     []string // optional per-commit text shown after the summary
+	jump        bool     // Enter selects the commit in the graph instead of editing its description
 	selectedIdx int
 	zoneManager *zone.Manager // set by main (zones may be in main's view)
 }
@@ -68,21 +73,38 @@ func (m Model) View() string {
 
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("3")).
+		BorderForeground(styles.ColorWarning).
 		Padding(1, 2).
 		Width(70)
 
 	content := m.message
 	if len(m.commits) > 0 {
-		content += "\n\nCommits with issues:\n"
+		if !m.jump {
+			content += "\n\n" + i18n.T("modal.warning.commits_with_issues")
+		}
+		content += "\n"
 		for i, c := range m.commits {
 			marker := " "
 			if i == m.selectedIdx {
 				marker = ">"
 			}
-			content += marker + " " + c.Summary + "\n"
+			row := marker + " " + c.Summary
+			if m.jump {
+				row = marker + " " + c.ShortID + " " + c.Summary
+			}
+			if i < len(m.details) && m.details[i] != "" {
+				row += lipgloss.NewStyle().Foreground(styles.ColorSubtle).Render(" · " + m.details[i])
+			}
+			if m.zoneManager != nil {
+				row = m.zoneManager.Mark(mouse.ZoneWarningCommit(i), row)
+			}
+			content += "\n" + row
 		}
-		content += "\n(Use j/k to select, enter to edit, esc to cancel)"
+		hint := i18n.T("modal.warning.hint")
+		if m.jump {
+			hint = i18n.T("modal.warning.jump_hint")
+		}
+		content += "\n\n" + hint
 	}
 
 	return style.Render(content)
@@ -97,15 +119,12 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 		m.commits = nil
 		st := ""
 		if hadCommits {
-			st = "Cancelled"
+			st = i18n.T("status.cancelled")
 		}
 		return m, state.NavigateTarget{Kind: state.NavigateWarningCancel, StatusMessage: st}.Cmd()
 	case "enter":
 		if len(m.commits) > 0 && m.selectedIdx < len(m.commits) {
-			commit := m.commits[m.selectedIdx]
-			m.shown = false
-			m.commits = nil
-			return m, state.NavigateTarget{Kind: state.NavigateEditDescription, Commit: commit}.Cmd()
+			return m.open(m.commits[m.selectedIdx])
 		}
 		return m, nil
 	case "j", "down":
@@ -125,9 +144,26 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, nil
 }
 
-// ZoneIDs returns the zone IDs used when main renders this modal's buttons. Used to resolve clicks.
+// open closes the modal and edits commit's description, or selects it in the graph in jump mode.
+func (m Model) open(commit internal.Commit) (Model, tea.Cmd) {
+	m.shown = false
+	m.commits = nil
+	m.details = nil
+	if m.jump {
+		ref := xref.Ref{Kind: xref.KindChange, Text: commit.ShortID, Value: commit.ChangeID}
+		return m, state.NavigateTarget{Kind: state.NavigateFollowXRef, XRef: ref}.Cmd()
+	}
+	return m, state.NavigateTarget{Kind: state.NavigateEditDescription, Commit: commit}.Cmd()
+}
+
+// ZoneIDs returns the zone IDs used when main renders this modal's buttons and commit rows. Used
+// to resolve clicks.
 func (m Model) ZoneIDs() []string {
-	return []string{mouse.ZoneWarningGoToCommit, mouse.ZoneWarningDismiss}
+	ids := []string{mouse.ZoneWarningGoToCommit, mouse.ZoneWarningDismiss}
+	for i := range m.commits {
+		ids = append(ids, mouse.ZoneWarningCommit(i))
+	}
+	return ids
 }
 
 func (m Model) resolveClickedZone(msg zone.MsgZoneInBounds) string {
@@ -144,13 +180,15 @@ func (m Model) resolveClickedZone(msg zone.MsgZoneInBounds) string {
 }
 
 func (m Model) handleZoneClick(zoneID string) (Model, tea.Cmd) {
+	for i, c := range m.commits {
+		if zoneID == mouse.ZoneWarningCommit(i) {
+			return m.open(c)
+		}
+	}
 	switch zoneID {
 	case mouse.ZoneWarningGoToCommit:
 		if len(m.commits) > 0 && m.selectedIdx < len(m.commits) {
-			commit := m.commits[m.selectedIdx]
-			m.shown = false
-			m.commits = nil
-			return m, state.NavigateTarget{Kind: state.NavigateEditDescription, Commit: commit}.Cmd()
+			return m.open(m.commits[m.selectedIdx])
 		}
 		m.shown = false
 		m.commits = nil
@@ -161,7 +199,7 @@ func (m Model) handleZoneClick(zoneID string) (Model, tea.Cmd) {
 		m.commits = nil
 		st := ""
 		if hadCommits {
-			st = "Cancelled"
+			st = i18n.T("status.cancelled")
 		}
 		return m, state.NavigateTarget{Kind: state.NavigateWarningCancel, StatusMessage: st}.Cmd()
 	}
@@ -194,13 +232,24 @@ func (m *Model) Show(title, message string, commits []internal.Commit) {
 	m.title = title
 	m.message = message
 	m.commits = commits
+	m.details = nil
+	m.jump = false
 	m.selectedIdx = 0
 }
 
+// ShowJump displays the warning with commits that Enter (or a click) selects in the graph
+// instead of opening for a description edit. details[i], when set, follows commit i's summary.
+func (m *Model) ShowJump(title, message string, commits []internal.Commit, details []string) {
+	m.Show(title, message, commits)
+	m.details = details
+	m.jump = true
+}
+
 // Hide hides the modal
 func (m *Model) Hide() {
 	m.shown = false
 	m.commits = nil
+	m.details = nil
 }
 
 // GetSelectedCommit returns the selected commit
@@ -0,0 +1,33 @@
+package warning
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/xref"
+)
+
+// In jump mode the rows show each commit's details and Enter selects the commit in the graph.
+func TestModel_ShowJump(t *testing.T) {
+	m := NewModel()
+	commits := []internal.Commit{
+		{ChangeID: "aaaa", ShortID: "aa", Summary: "first"},
+		{ChangeID: "bbbb", ShortID: "bb", Summary: "second"},
+	}
+	m.ShowJump("New Conflicts", "msg", commits, []string{"2 conflicted files", ""})
+	if view := m.View(); !strings.Contains(view, "bb second") || !strings.Contains(view, "2 conflicted files") {
+		t.Fatalf("view:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsShown() || cmd == nil {
+		t.Fatal("enter should close the modal and navigate")
+	}
+	nav, ok := cmd().(state.NavigateMsg)
+	if !ok || nav.Target.Kind != state.NavigateFollowXRef || nav.Target.XRef != (xref.Ref{Kind: xref.KindChange, Text: "bb", Value: "bbbb"}) {
+		t.Fatalf("navigate = %#v", cmd())
+	}
+}
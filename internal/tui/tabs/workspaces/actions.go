@@ -0,0 +1,179 @@
+package workspaces
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/tabs/confirm"
+)
+
+// RequestContext is passed from the main model so the Workspaces tab can validate and execute
+// requests without depending on the model package.
+type RequestContext struct {
+	Selected  *jj.Workspace
+	JJService *jj.Service
+}
+
+// BuildRequestContextFromApp builds RequestContext from app state and the tab model.
+func BuildRequestContextFromApp(app *state.AppState, m *Model) *RequestContext {
+	if app == nil || m == nil {
+		return nil
+	}
+	return &RequestContext{Selected: m.selectedWorkspace(), JJService: app.JJService}
+}
+
+// EnterTab returns the status message and load command when navigating to the Workspaces tab.
+func EnterTab(svc *jj.Service) (status string, cmd tea.Cmd) {
+	return "Loading workspaces...", LoadWorkspacesCmd(svc)
+}
+
+// LoadWorkspacesCmd lists the repository's workspaces.
+func LoadWorkspacesCmd(svc *jj.Service) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		workspaces, err := svc.ListWorkspaces(context.Background())
+		return WorkspacesLoadedMsg{Workspaces: workspaces, Err: err}
+	}
+}
+
+// AddWorkspaceCmd creates a workspace at path, named after its directory.
+func AddWorkspaceCmd(svc *jj.Service, path string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		abs, err := svc.AddWorkspace(context.Background(), path, "", "")
+		return WorkspaceActionMsg{Action: "add", Path: abs, Err: err}
+	}
+}
+
+// ForgetWorkspaceCmd stops tracking workspace name.
+func ForgetWorkspaceCmd(svc *jj.Service, name string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		err := svc.ForgetWorkspace(context.Background(), name)
+		return WorkspaceActionMsg{Action: "forget", Name: name, Err: err}
+	}
+}
+
+// LoadSparseCmd lists the sparse patterns of the workspace jj-tui runs in.
+func LoadSparseCmd(svc *jj.Service) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		patterns, err := svc.SparsePatterns(context.Background())
+		return SparseLoadedMsg{Patterns: patterns, Err: err}
+	}
+}
+
+// SetSparseCmd adds or removes path from the sparse patterns, or resets them to a full checkout
+// (action "add", "remove" or "reset").
+func SetSparseCmd(svc *jj.Service, action, path string) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		var err error
+		switch action {
+		case "add":
+			err = svc.SetSparsePatterns(context.Background(), []string{path}, nil)
+		case "remove":
+			err = svc.SetSparsePatterns(context.Background(), nil, []string{path})
+		default:
+			err = svc.ResetSparsePatterns(context.Background())
+		}
+		return SparseActionMsg{Action: action, Path: path, Err: err}
+	}
+}
+
+// ExecuteRequest validates r and returns the status message and command that runs it.
+func ExecuteRequest(r Request, ctx *RequestContext) (statusMsg string, cmd tea.Cmd) {
+	if ctx == nil || ctx.JJService == nil {
+		return "", nil
+	}
+	switch {
+	case r.Refresh:
+		return EnterTab(ctx.JJService)
+	case r.Add:
+		return fmt.Sprintf("Adding workspace at %s...", r.AddPath), AddWorkspaceCmd(ctx.JJService, r.AddPath)
+	case r.Sparse:
+		return "Loading sparse patterns...", LoadSparseCmd(ctx.JJService)
+	case r.SparseAdd != "":
+		return fmt.Sprintf("Adding %s to the sparse patterns...", r.SparseAdd), SetSparseCmd(ctx.JJService, "add", r.SparseAdd)
+	case r.SparseRemove != "":
+		return fmt.Sprintf("Removing %s from the sparse patterns...", r.SparseRemove), SetSparseCmd(ctx.JJService, "remove", r.SparseRemove)
+	case r.SparseReset:
+		return "", confirm.Ask(confirm.Prompt{
+			Action:    "sparse_reset",
+			Title:     "Reset sparse patterns",
+			Message:   "Check out the whole repository again? Every sparse pattern is replaced by a full checkout.",
+			Command:   jj.CommandLine(jj.ResetSparseArgs()),
+			Status:    "Checking out the whole repository...",
+			OnConfirm: SetSparseCmd(ctx.JJService, "reset", ""),
+		})
+	}
+	w := ctx.Selected
+	if w == nil {
+		return "No workspace selected", nil
+	}
+	switch {
+	case r.Switch:
+		switch {
+		case w.Current:
+			return fmt.Sprintf("Already in workspace %s", w.Name), nil
+		case w.Path == "":
+			return fmt.Sprintf("jj did not report where workspace %s lives (needs `jj workspace root --name`)", w.Name), nil
+		case w.Missing:
+			return fmt.Sprintf("%s no longer exists; forget the workspace with x", w.Path), nil
+		}
+		sw := SwitchWorkspaceMsg{Name: w.Name, Path: w.Path}
+		return "", func() tea.Msg { return sw }
+	case r.Forget:
+		if w.Current {
+			return "Can't forget the workspace jj-tui is running in", nil
+		}
+		return "", confirm.Ask(confirm.Prompt{
+			Action:    "forget_workspace",
+			Title:     "Forget workspace",
+			Message:   fmt.Sprintf("Forget workspace %s? jj stops tracking it; its directory stays on disk.", w.Name),
+			Command:   jj.CommandLine(jj.ForgetWorkspaceArgs(w.Name)),
+			Status:    fmt.Sprintf("Forgetting workspace %s...", w.Name),
+			OnConfirm: ForgetWorkspaceCmd(ctx.JJService, w.Name),
+		})
+	}
+	return "", nil
+}
+
+// sparseStatus is the status line for a finished sparse patterns change.
+func sparseStatus(msg SparseActionMsg) string {
+	switch {
+	case msg.Err != nil:
+		return fmt.Sprintf("Failed to update sparse patterns: %v", msg.Err)
+	case msg.Action == "add":
+		return fmt.Sprintf("Checked out %s", msg.Path)
+	case msg.Action == "remove":
+		return fmt.Sprintf("Removed %s from the working copy", msg.Path)
+	default:
+		return "Checked out the whole repository"
+	}
+}
+
+// actionStatus is the status line for a finished workspace action.
+func actionStatus(msg WorkspaceActionMsg) string {
+	switch {
+	case msg.Err != nil:
+		return fmt.Sprintf("Failed to %s workspace: %v", msg.Action, msg.Err)
+	case msg.Action == "add":
+		return fmt.Sprintf("Added workspace at %s (Enter to switch to it)", msg.Path)
+	default:
+		return fmt.Sprintf("Forgot workspace %s", msg.Name)
+	}
+}
@@ -0,0 +1,65 @@
+package workspaces
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/state"
+)
+
+// WorkspacesLoadedMsg is sent when LoadWorkspacesCmd finishes.
+type WorkspacesLoadedMsg struct {
+	state.RepoResult
+	Workspaces []jj.Workspace
+	Err        error
+}
+
+// WorkspaceActionMsg is sent when adding or forgetting a workspace finishes.
+type WorkspaceActionMsg struct {
+	state.RepoResult
+	Action string // "add" or "forget"
+	Name   string
+	Path   string
+	Err    error
+}
+
+// SparseLoadedMsg is sent when LoadSparseCmd finishes.
+type SparseLoadedMsg struct {
+	state.RepoResult
+	Patterns []string
+	Err      error
+}
+
+// SparseActionMsg is sent when changing the sparse patterns finishes.
+type SparseActionMsg struct {
+	state.RepoResult
+	Action string // "add", "remove" or "reset"
+	Path   string
+	Err    error
+}
+
+// SwitchWorkspaceMsg asks the main model to run jj-tui in the workspace rooted at Path.
+type SwitchWorkspaceMsg struct {
+	Name string
+	Path string
+}
+
+// Request is sent to the main model to run workspace actions.
+type Request struct {
+	Switch  bool
+	Forget  bool
+	Refresh bool
+	// Add creates a workspace at AddPath (relative paths are resolved against the repository).
+	Add     bool
+	AddPath string
+	// Sparse opens the sparse patterns panel. SparseAdd and SparseRemove change one
+	// workspace-relative path; SparseReset checks out the whole repository again.
+	Sparse       bool
+	SparseAdd    string
+	SparseRemove string
+	SparseReset  bool
+}
+
+// Cmd returns a tea.Cmd that sends this request.
+func (r Request) Cmd() tea.Cmd {
+	return func() tea.Msg { return r }
+}
@@ -0,0 +1,350 @@
+package workspaces
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/state"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// Model represents the state of the Workspaces tab
+type Model struct {
+	zoneManager *zone.Manager
+	workspaces  []jj.Workspace
+	loaded      bool
+	selected    int
+	listYOffset int
+	width       int
+	height      int
+
+	// Inline "add workspace" input. While adding is true it captures all keystrokes; Enter
+	// submits an Add request, Esc cancels.
+	adding    bool
+	pathInput textinput.Model
+	// selectPath is the path of a just-added workspace to select once the list reloads.
+	selectPath string
+
+	// Sparse patterns panel (s): replaces the list with the current workspace's patterns.
+	sparseOpen     bool
+	sparse         []string
+	sparseLoaded   bool
+	sparseSelected int
+	sparseYOffset  int
+}
+
+// NewModel creates a new Workspaces tab model. zoneManager may be nil (e.g. in tests).
+func NewModel(zoneManager *zone.Manager) Model {
+	pathInput := textinput.New()
+	pathInput.Placeholder = "../my-repo-feature"
+	pathInput.CharLimit = 500
+	pathInput.Width = 50
+	return Model{
+		zoneManager: zoneManager,
+		selected:    -1,
+		width:       80,
+		height:      24,
+		pathInput:   pathInput,
+	}
+}
+
+// SetDimensions sets the content area size
+func (m *Model) SetDimensions(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// IsCapturingKeys reports whether the add input owns the keyboard
+func (m *Model) IsCapturingKeys() bool {
+	return m.adding
+}
+
+// IsSparseOpen reports whether the sparse patterns panel is shown (Esc closes it, not the tab).
+func (m *Model) IsSparseOpen() bool {
+	return m.sparseOpen
+}
+
+// GetWorkspaces returns the loaded workspaces
+func (m *Model) GetWorkspaces() []jj.Workspace {
+	return m.workspaces
+}
+
+// UpdateWithApp handles messages and runs requests in place (sets status, runs cmds).
+func (m Model) UpdateWithApp(msg tea.Msg, app *state.AppState) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case WorkspacesLoadedMsg:
+		if msg.Err != nil {
+			app.StatusMessage = fmt.Sprintf("Failed to load workspaces: %v", msg.Err)
+			return m, nil
+		}
+		m.setWorkspaces(msg.Workspaces)
+		app.StatusMessage = fmt.Sprintf("%d %s", len(m.workspaces), pluralWorkspaces(len(m.workspaces)))
+		return m, nil
+	case WorkspaceActionMsg:
+		app.StatusMessage = actionStatus(msg)
+		if msg.Err == nil && msg.Action == "add" {
+			m.selectPath = msg.Path
+		}
+		return m, LoadWorkspacesCmd(app.JJService)
+	case SparseLoadedMsg:
+		if msg.Err != nil {
+			app.StatusMessage = fmt.Sprintf("Failed to load sparse patterns: %v", msg.Err)
+			return m, nil
+		}
+		m.setSparse(msg.Patterns)
+		app.StatusMessage = sparseSummary(m.sparse)
+		return m, nil
+	case SparseActionMsg:
+		app.StatusMessage = sparseStatus(msg)
+		return m, LoadSparseCmd(app.JJService)
+	case tea.KeyMsg:
+		updated, req, cmd := m.handleKeyMsg(msg)
+		if req != nil {
+			return updated, updated.execute(*req, app)
+		}
+		return updated, cmd
+	case zone.MsgZoneInBounds:
+		updated, req := m.handleZoneClick(msg.Zone)
+		if req != nil {
+			return updated, updated.execute(*req, app)
+		}
+		return updated, nil
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.listYOffset = max(m.listYOffset-3, 0)
+			m.sparseYOffset = max(m.sparseYOffset-3, 0)
+		case tea.MouseButtonWheelDown:
+			m.listYOffset += 3
+			m.sparseYOffset += 3
+		}
+	}
+	return m, nil
+}
+
+// execute runs a request against app and sets the status line.
+func (m *Model) execute(r Request, app *state.AppState) tea.Cmd {
+	status, cmd := ExecuteRequest(r, BuildRequestContextFromApp(app, m))
+	if status != "" {
+		app.StatusMessage = status
+	}
+	return cmd
+}
+
+// setWorkspaces stores a loaded list, keeping the selection on the same workspace when it is
+// still there (or on a just-added one).
+func (m *Model) setWorkspaces(workspaces []jj.Workspace) {
+	prev := ""
+	if w := m.selectedWorkspace(); w != nil {
+		prev = w.Name
+	}
+	m.workspaces, m.loaded = workspaces, true
+	m.selected = -1
+	for i, w := range workspaces {
+		if (m.selectPath != "" && w.Path == m.selectPath) || (m.selectPath == "" && w.Name == prev) {
+			m.selected = i
+		}
+	}
+	m.selectPath = ""
+	if m.selected < 0 {
+		for i, w := range workspaces {
+			if w.Current {
+				m.selected = i
+			}
+		}
+	}
+	if m.selected < 0 && len(workspaces) > 0 {
+		m.selected = 0
+	}
+}
+
+// selectedWorkspace returns the selected workspace or nil.
+func (m *Model) selectedWorkspace() *jj.Workspace {
+	if m.selected < 0 || m.selected >= len(m.workspaces) {
+		return nil
+	}
+	w := m.workspaces[m.selected]
+	return &w
+}
+
+// handleKeyMsg handles keyboard input; returns (updated model, optional request, cmd).
+func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	if m.adding {
+		switch msg.String() {
+		case "esc":
+			m.closeAddInput()
+			return m, nil, nil
+		case "enter":
+			path := strings.TrimSpace(m.pathInput.Value())
+			m.closeAddInput()
+			switch {
+			case path == "":
+				return m, nil, nil
+			case m.sparseOpen:
+				return m, &Request{SparseAdd: path}, nil
+			}
+			return m, &Request{Add: true, AddPath: path}, nil
+		}
+		var cmd tea.Cmd
+		m.pathInput, cmd = m.pathInput.Update(msg)
+		return m, nil, cmd
+	}
+	if m.sparseOpen {
+		return m.handleSparseKey(msg)
+	}
+	switch msg.String() {
+	case "j", "down":
+		if m.selected < len(m.workspaces)-1 {
+			m.selected++
+		}
+	case "k", "up":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "enter":
+		return m, &Request{Switch: true}, nil
+	case "a":
+		return m.openAddInput()
+	case "x":
+		if m.selectedWorkspace() != nil {
+			return m, &Request{Forget: true}, nil
+		}
+	case "r":
+		return m, &Request{Refresh: true}, nil
+	case "s":
+		return m.openSparse()
+	}
+	return m, nil, nil
+}
+
+// openAddInput shows and focuses the inline input (a new workspace's path, or a sparse path
+// while the sparse panel is open).
+func (m Model) openAddInput() (Model, *Request, tea.Cmd) {
+	m.adding = true
+	m.pathInput.Placeholder = "../my-repo-feature"
+	if m.sparseOpen {
+		m.pathInput.Placeholder = "src/app"
+	}
+	m.pathInput.SetValue("")
+	return m, nil, tea.Batch(m.pathInput.Focus(), textinput.Blink)
+}
+
+// closeAddInput hides the inline input and clears its value.
+func (m *Model) closeAddInput() {
+	m.adding = false
+	m.pathInput.SetValue("")
+	m.pathInput.Blur()
+}
+
+// handleZoneClick handles zone clicks; returns (updated model, optional request).
+func (m Model) handleZoneClick(z *zone.ZoneInfo) (Model, *Request) {
+	if m.zoneManager == nil || z == nil {
+		return m, nil
+	}
+	if m.sparseOpen {
+		return m.handleSparseZoneClick(z)
+	}
+	for i := range m.workspaces {
+		if m.zoneManager.Get(mouse.ZoneWorkspace(i)) == z {
+			m.selected = i
+			return m, nil
+		}
+	}
+	switch z {
+	case m.zoneManager.Get(mouse.ZoneWorkspaceSwitch):
+		return m, &Request{Switch: true}
+	case m.zoneManager.Get(mouse.ZoneWorkspaceForget):
+		if m.selectedWorkspace() != nil {
+			return m, &Request{Forget: true}
+		}
+	case m.zoneManager.Get(mouse.ZoneWorkspaceAdd):
+		m, _, _ = m.openAddInput()
+	case m.zoneManager.Get(mouse.ZoneWorkspaceRefresh):
+		return m, &Request{Refresh: true}
+	case m.zoneManager.Get(mouse.ZoneWorkspaceSparse):
+		m, req, _ := m.openSparse()
+		return m, req
+	}
+	return m, nil
+}
+
+func pluralWorkspaces(n int) string {
+	if n == 1 {
+		return "workspace"
+	}
+	return "workspaces"
+}
+
+func mark(z *zone.Manager, id, content string) string {
+	if z == nil {
+		return content
+	}
+	return z.Mark(id, content)
+}
+
+// View renders the Workspaces tab (pointer receiver so render can persist listYOffset clamp)
+func (m *Model) View() string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	title, label, hint := "Workspaces", "Add workspace at", "Enter to create · Esc to cancel · relative paths start at the repository root"
+	if m.sparseOpen {
+		title, label, hint = "Sparse patterns", "Check out path", "Enter to check out · Esc to cancel · paths are relative to the workspace root"
+	}
+	lines := []string{styles.TitleStyle.Render(title)}
+	if m.adding {
+		box := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(styles.ColorPrimary).Padding(0, 1)
+		label := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPrimary).Render(label)
+		lines = append(lines, box.Render(strings.Join([]string{label, m.pathInput.View(), muted.Render(hint)}, "\n")))
+	}
+	if m.sparseOpen {
+		return m.sparseView(lines)
+	}
+	if !m.loaded {
+		return strings.Join(append(lines, "", "Loading workspaces..."), "\n")
+	}
+	buttons := []string{
+		mark(m.zoneManager, mouse.ZoneWorkspaceSwitch, styles.ButtonStyle.Render("Switch (Enter)")),
+		mark(m.zoneManager, mouse.ZoneWorkspaceAdd, styles.ButtonStyle.Render("Add (a)")),
+		mark(m.zoneManager, mouse.ZoneWorkspaceForget, styles.ButtonStyle.Render("Forget (x)")),
+		mark(m.zoneManager, mouse.ZoneWorkspaceSparse, styles.ButtonStyle.Render("Sparse (s)")),
+		mark(m.zoneManager, mouse.ZoneWorkspaceRefresh, styles.ButtonStyle.Render("Refresh (r)")),
+	}
+	lines = append(lines, strings.Join(buttons, " "))
+	lines = append(lines, "")
+
+	warn := lipgloss.NewStyle().Foreground(styles.ColorWarning)
+	var rows []string
+	for i, w := range m.workspaces {
+		prefix := "  "
+		style := styles.CommitStyle
+		if i == m.selected {
+			prefix = "► "
+			style = styles.CommitSelectedStyle
+		}
+		row := prefix + lipgloss.NewStyle().Bold(true).Render(w.Name)
+		if w.Current {
+			row += " " + lipgloss.NewStyle().Foreground(styles.ColorSuccess).Render("(current)")
+		}
+		row += " " + lipgloss.NewStyle().Foreground(styles.ColorSecondary).Render(w.ChangeID) + " " + style.Render(w.Summary)
+		switch {
+		case w.Missing:
+			row += " " + warn.Render("⚠ missing: "+w.Path)
+		case w.Path != "":
+			row += " " + muted.Render(w.Path)
+		}
+		rows = append(rows, mark(m.zoneManager, mouse.ZoneWorkspace(i), row))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, "No workspaces found.")
+	}
+
+	listHeight := max(m.height-strings.Count(strings.Join(lines, "\n"), "\n")-1, 0)
+	m.listYOffset = max(min(m.listYOffset, len(rows)-listHeight), 0)
+	end := min(m.listYOffset+listHeight, len(rows))
+	return strings.Join(append(lines, rows[m.listYOffset:end]...), "\n")
+}
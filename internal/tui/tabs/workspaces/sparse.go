@@ -0,0 +1,147 @@
+package workspaces
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/madicen/jj-tui/internal/tui/mouse"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// fullCheckout is jj's sparse pattern for the whole repository.
+const fullCheckout = "."
+
+// openSparse shows the sparse patterns panel and asks for the current patterns.
+func (m Model) openSparse() (Model, *Request, tea.Cmd) {
+	m.sparseOpen = true
+	return m, &Request{Sparse: true}, nil
+}
+
+// setSparse stores loaded patterns, keeping the selection index in range.
+func (m *Model) setSparse(patterns []string) {
+	m.sparse, m.sparseLoaded = patterns, true
+	m.sparseSelected = min(max(m.sparseSelected, 0), len(patterns)-1)
+}
+
+// selectedPattern returns the selected sparse pattern or "".
+func (m *Model) selectedPattern() string {
+	if m.sparseSelected < 0 || m.sparseSelected >= len(m.sparse) {
+		return ""
+	}
+	return m.sparse[m.sparseSelected]
+}
+
+func isFullCheckout(patterns []string) bool {
+	return len(patterns) == 1 && patterns[0] == fullCheckout
+}
+
+// sparseSummary is the status line after the patterns load.
+func sparseSummary(patterns []string) string {
+	switch {
+	case isFullCheckout(patterns):
+		return "Full checkout: every file in the repository is in the working copy"
+	case len(patterns) == 0:
+		return "No sparse patterns: the working copy is empty"
+	case len(patterns) == 1:
+		return "1 sparse pattern"
+	}
+	return fmt.Sprintf("%d sparse patterns", len(patterns))
+}
+
+// handleSparseKey handles keys while the sparse panel is open.
+func (m Model) handleSparseKey(msg tea.KeyMsg) (Model, *Request, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.sparseSelected < len(m.sparse)-1 {
+			m.sparseSelected++
+		}
+	case "k", "up":
+		if m.sparseSelected > 0 {
+			m.sparseSelected--
+		}
+	case "a":
+		return m.openAddInput()
+	case "x":
+		if p := m.selectedPattern(); p != "" {
+			return m, &Request{SparseRemove: p}, nil
+		}
+	case "R":
+		if !isFullCheckout(m.sparse) {
+			return m, &Request{SparseReset: true}, nil
+		}
+	case "r":
+		return m, &Request{Sparse: true}, nil
+	case "s", "esc":
+		m.sparseOpen = false
+	}
+	return m, nil, nil
+}
+
+// handleSparseZoneClick handles clicks while the sparse panel is open.
+func (m Model) handleSparseZoneClick(z *zone.ZoneInfo) (Model, *Request) {
+	for i := range m.sparse {
+		if m.zoneManager.Get(mouse.ZoneSparsePattern(i)) == z {
+			m.sparseSelected = i
+			return m, nil
+		}
+	}
+	switch z {
+	case m.zoneManager.Get(mouse.ZoneSparseAdd):
+		m, _, _ = m.openAddInput()
+	case m.zoneManager.Get(mouse.ZoneSparseRemove):
+		if p := m.selectedPattern(); p != "" {
+			return m, &Request{SparseRemove: p}
+		}
+	case m.zoneManager.Get(mouse.ZoneSparseReset):
+		if !isFullCheckout(m.sparse) {
+			return m, &Request{SparseReset: true}
+		}
+	case m.zoneManager.Get(mouse.ZoneSparseClose):
+		m.sparseOpen = false
+	}
+	return m, nil
+}
+
+// sparseView renders the sparse panel below header (title and add input).
+func (m *Model) sparseView(header []string) string {
+	muted := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	lines := append(header, muted.Render("Paths checked out in this workspace's working copy"))
+	if !m.sparseLoaded {
+		return strings.Join(append(lines, "", "Loading sparse patterns..."), "\n")
+	}
+	buttons := []string{
+		mark(m.zoneManager, mouse.ZoneSparseAdd, styles.ButtonStyle.Render("Add path (a)")),
+		mark(m.zoneManager, mouse.ZoneSparseRemove, styles.ButtonStyle.Render("Remove (x)")),
+		mark(m.zoneManager, mouse.ZoneSparseReset, styles.ButtonStyle.Render("Reset to full (R)")),
+		mark(m.zoneManager, mouse.ZoneSparseClose, styles.ButtonStyle.Render("Back (Esc)")),
+	}
+	lines = append(lines, strings.Join(buttons, " "))
+	if isFullCheckout(m.sparse) {
+		lines = append(lines, muted.Render("Full checkout. Add a path, then remove \".\", to narrow the working copy."))
+	}
+	lines = append(lines, "")
+
+	var rows []string
+	for i, p := range m.sparse {
+		prefix, style := "  ", styles.CommitStyle
+		if i == m.sparseSelected {
+			prefix, style = "► ", styles.CommitSelectedStyle
+		}
+		label := p
+		if p == fullCheckout {
+			label += " " + muted.Render("(whole repository)")
+		}
+		rows = append(rows, mark(m.zoneManager, mouse.ZoneSparsePattern(i), prefix+style.Render(label)))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, "No paths are checked out. Add one with a, or reset with R.")
+	}
+
+	listHeight := max(m.height-strings.Count(strings.Join(lines, "\n"), "\n")-1, 0)
+	m.sparseYOffset = max(min(m.sparseYOffset, len(rows)-listHeight), 0)
+	end := min(m.sparseYOffset+listHeight, len(rows))
+	return strings.Join(append(lines, rows[m.sparseYOffset:end]...), "\n")
+}
@@ -0,0 +1,295 @@
+// Package theme defines the TUI's color themes: named sets of color roles (text, selection,
+// bars, diff backgrounds, status colors, …) that styles.ApplyTheme turns into the live styles.
+//
+// Built-in themes are dark (the default), light and high-contrast. Users add their own in
+// config.json's "themes" object: each entry names a base theme and overrides any of its roles,
+// e.g. {"solarized": {"base": "light", "primary": "#268BD2"}}.
+package theme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Theme is a named set of color roles. Colors are hex ("#7E00AF") or ANSI numbers ("214").
+type Theme struct {
+	Name        string `json:"-"`
+	Description string `json:"-"`
+
+	// Accents
+	Primary   string `json:"primary"`   // titles, change IDs, active tab, focus borders
+	Secondary string `json:"secondary"` // section headers, marks, toggles that are on
+	Muted     string `json:"muted"`     // graph lines, hints, status bar text
+	Accent    string `json:"accent"`    // commit IDs in dialogs, links, cross references
+	Special   string `json:"special"`   // divergent commits, the selected branch
+	AI        string `json:"ai"`        // AI plan markers
+	Attention string `json:"attention"` // rebase and merge mode headers
+	Highlight string `json:"highlight"` // search matches
+
+	// Text
+	Text     string `json:"text"`      // body text
+	TextDim  string `json:"text_dim"`  // secondary text in menus
+	Subtle   string `json:"subtle"`    // placeholders and help hints
+	OnAccent string `json:"on_accent"` // text on Primary and other saturated backgrounds
+	OnBright string `json:"on_bright"` // text on light backgrounds (warning, highlight)
+
+	// Surfaces
+	Bar        string `json:"bar"`         // header and status bar background
+	BarText    string `json:"bar_text"`    // header text
+	Tab        string `json:"tab"`         // inactive tab labels
+	Panel      string `json:"panel"`       // form and dialog header background
+	Separator  string `json:"separator"`   // divider lines
+	Selection  string `json:"selection"`   // selected row background
+	Cursor     string `json:"cursor"`      // cursor row background in pickers
+	Button     string `json:"button"`      // button background
+	ButtonText string `json:"button_text"` // button text
+
+	// Buttons with a meaning (text is OnAccent)
+	Confirm string `json:"confirm"` // initialize, apply, log in
+	Action  string `json:"action"`  // push, create on GitHub
+	Danger  string `json:"danger"`  // remove, quit, confirm destructive operations
+
+	Disabled   string `json:"disabled"`    // text of actions that can't run
+	DisabledBg string `json:"disabled_bg"` // background of actions that can't run
+
+	// Rebase and merge mode rows (text is OnAccent)
+	RebaseSource string `json:"rebase_source"`
+	RebaseDest   string `json:"rebase_dest"`
+
+	// Diff backgrounds (text is Text)
+	DiffAdd        string `json:"diff_add"`
+	DiffDelete     string `json:"diff_delete"`
+	DiffContext    string `json:"diff_context"`
+	DiffMeta       string `json:"diff_meta"`
+	DiffHunk       string `json:"diff_hunk"`
+	ConflictOurs   string `json:"conflict_ours"`
+	ConflictTheirs string `json:"conflict_theirs"`
+
+	// Status colors of the "default" palette; the color-vision palettes replace them.
+	Success  string `json:"success"`  // checks passed, approved, PR open
+	Failure  string `json:"failure"`  // checks failed, changes requested, PR closed
+	Pending  string `json:"pending"`  // checks or review pending
+	Neutral  string `json:"neutral"`  // no checks, no reviews, draft
+	Merged   string `json:"merged"`   // PR merged
+	Positive string `json:"positive"` // commits ahead, added lines, errors fixed
+	Negative string `json:"negative"` // conflicts, removed lines, errors
+	Warning  string `json:"warning"`  // commits behind, modified files, unsnapshotted edits
+}
+
+// Dark is the default theme.
+var Dark = Theme{
+	Name: "dark", Description: "Dracula-style colors for dark terminals",
+
+	Primary: "#7E00AF", Secondary: "#50FA7B", Muted: "#6272A4", Accent: "#8BE9FD",
+	Special: "#FF79C6", AI: "#BD93F9", Attention: "#FFAA00", Highlight: "#F1FA8C",
+
+	Text: "#F8F8F2", TextDim: "#CCCCCC", Subtle: "#8B949E", OnAccent: "#FFFFFF", OnBright: "#000000",
+
+	Bar: "#1F2937", BarText: "#F9FAFB", Tab: "#9CA3AF", Panel: "#30363D", Separator: "#444444",
+	Selection: "#44475A", Cursor: "#3D4F5F", Button: "#44475A", ButtonText: "#F8F8F2",
+
+	Confirm: "#238636", Action: "#1F6FEB", Danger: "#C9302C",
+	Disabled: "#6C7086", DisabledBg: "#2A2C37",
+
+	RebaseSource: "#5555AA", RebaseDest: "#55AA55",
+
+	DiffAdd: "#1B4332", DiffDelete: "#4A232C", DiffContext: "#21222C", DiffMeta: "#2D303E",
+	DiffHunk: "#44475A", ConflictOurs: "#1E3A5F", ConflictTheirs: "#5C3B1E",
+
+	Success: "#2ea44f", Failure: "#cb2431", Pending: "#dbab09", Neutral: "#6a737d", Merged: "#6f42c1",
+	Positive: "#50FA7B", Negative: "#FF5555", Warning: "#FFB86C",
+}
+
+// Light is for terminals with a light background (GitHub light colors).
+var Light = Theme{
+	Name: "light", Description: "Dark text for light terminals",
+
+	Primary: "#8250DF", Secondary: "#1A7F37", Muted: "#57606A", Accent: "#0969DA",
+	Special: "#BF3989", AI: "#8250DF", Attention: "#BC4C00", Highlight: "#9A6700",
+
+	Text: "#24292F", TextDim: "#57606A", Subtle: "#6E7781", OnAccent: "#FFFFFF", OnBright: "#000000",
+
+	Bar: "#EAEEF2", BarText: "#24292F", Tab: "#57606A", Panel: "#D0D7DE", Separator: "#D0D7DE",
+	Selection: "#DDF4FF", Cursor: "#B6E3FF", Button: "#D0D7DE", ButtonText: "#24292F",
+
+	Confirm: "#1F883D", Action: "#0969DA", Danger: "#CF222E",
+	Disabled: "#8C959F", DisabledBg: "#F6F8FA",
+
+	RebaseSource: "#6639BA", RebaseDest: "#1A7F37",
+
+	DiffAdd: "#DAFBE1", DiffDelete: "#FFEBE9", DiffContext: "#F6F8FA", DiffMeta: "#EAEEF2",
+	DiffHunk: "#DDF4FF", ConflictOurs: "#DDF4FF", ConflictTheirs: "#FFF1E5",
+
+	Success: "#1A7F37", Failure: "#CF222E", Pending: "#9A6700", Neutral: "#6E7781", Merged: "#8250DF",
+	Positive: "#1A7F37", Negative: "#CF222E", Warning: "#BC4C00",
+}
+
+// HighContrast uses saturated colors on black with white or black text.
+var HighContrast = Theme{
+	Name: "high-contrast", Description: "Bright colors on black for low-vision use",
+
+	Primary: "#5FD7FF", Secondary: "#00FF00", Muted: "#C0C0C0", Accent: "#00FFFF",
+	Special: "#FF5FFF", AI: "#AF87FF", Attention: "#FFAF00", Highlight: "#FFFF00",
+
+	Text: "#FFFFFF", TextDim: "#E0E0E0", Subtle: "#C0C0C0", OnAccent: "#000000", OnBright: "#000000",
+
+	Bar: "#000000", BarText: "#FFFFFF", Tab: "#FFFFFF", Panel: "#303030", Separator: "#808080",
+	Selection: "#0037DA", Cursor: "#0037DA", Button: "#4E4E4E", ButtonText: "#FFFFFF",
+
+	Confirm: "#00D700", Action: "#5FAFFF", Danger: "#FF5F5F",
+	Disabled: "#808080", DisabledBg: "#1C1C1C",
+
+	RebaseSource: "#AF87FF", RebaseDest: "#00D700",
+
+	DiffAdd: "#005F00", DiffDelete: "#870000", DiffContext: "#000000", DiffMeta: "#303030",
+	DiffHunk: "#303030", ConflictOurs: "#00005F", ConflictTheirs: "#5F3700",
+
+	Success: "#00FF00", Failure: "#FF5F5F", Pending: "#FFFF00", Neutral: "#C0C0C0", Merged: "#D787FF",
+	Positive: "#00FF00", Negative: "#FF5F5F", Warning: "#FFAF00",
+}
+
+// Builtin lists the built-in themes; the first is the default.
+var Builtin = []Theme{Dark, Light, HighContrast}
+
+var (
+	mu     sync.Mutex
+	custom []Theme
+)
+
+// Load replaces the user-defined themes with defs (config.json's "themes": name -> roles, with
+// an optional "base" naming the theme to start from, dark by default). Themes with unknown
+// roles, bad colors or a reused built-in name are skipped and reported in the returned error;
+// the rest are still loaded.
+func Load(defs map[string]map[string]string) error {
+	var themes []Theme
+	var errs []error
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// A theme may build on another custom theme, so build in passes until none is left waiting
+	// on a base that is still to come.
+	for len(names) > 0 {
+		var waiting []string
+		for _, name := range names {
+			if base := defs[name]["base"]; base != name && slices.Contains(names, base) {
+				waiting = append(waiting, name)
+				continue
+			}
+			t, err := build(name, defs[name], themes)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("theme %q: %w", name, err))
+				continue
+			}
+			themes = append(themes, t)
+		}
+		if len(waiting) == len(names) {
+			for _, name := range waiting {
+				errs = append(errs, fmt.Errorf("theme %q: base %q is part of a cycle", name, defs[name]["base"]))
+			}
+			break
+		}
+		names = waiting
+	}
+	slices.SortFunc(themes, func(a, b Theme) int { return strings.Compare(a.Name, b.Name) })
+	mu.Lock()
+	custom = themes
+	mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// build makes a theme from roles over its base (a built-in or an earlier custom theme).
+func build(name string, roles map[string]string, earlier []Theme) (Theme, error) {
+	if strings.TrimSpace(name) == "" {
+		return Theme{}, errors.New("empty name")
+	}
+	if _, ok := find(Builtin, name); ok {
+		return Theme{}, errors.New("a built-in theme has this name")
+	}
+	baseName := roles["base"]
+	if baseName == "" {
+		baseName = Dark.Name
+	}
+	base, ok := find(Builtin, baseName)
+	if !ok {
+		if base, ok = find(earlier, baseName); !ok {
+			return Theme{}, fmt.Errorf("unknown base theme %q", baseName)
+		}
+	}
+	known := map[string]string{}
+	raw, _ := json.Marshal(base)
+	_ = json.Unmarshal(raw, &known)
+	var bad []string
+	for role, color := range roles {
+		if role == "base" {
+			continue
+		}
+		if _, ok := known[role]; !ok {
+			bad = append(bad, fmt.Sprintf("unknown role %q", role))
+			continue
+		}
+		if !ValidColor(color) {
+			bad = append(bad, fmt.Sprintf("%s: %q is not a hex or ANSI color", role, color))
+			continue
+		}
+		known[role] = color
+	}
+	if len(bad) > 0 {
+		sort.Strings(bad)
+		return Theme{}, errors.New(strings.Join(bad, "; "))
+	}
+	var t Theme
+	raw, _ = json.Marshal(known)
+	_ = json.Unmarshal(raw, &t)
+	t.Name = name
+	t.Description = "Custom, based on " + base.Name
+	return t, nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// ValidColor reports whether s is a hex color ("#RGB", "#RRGGBB") or an ANSI color number (0–255).
+func ValidColor(s string) bool {
+	if hexColor.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+func find(themes []Theme, name string) (Theme, bool) {
+	if i := slices.IndexFunc(themes, func(t Theme) bool { return t.Name == name }); i >= 0 {
+		return themes[i], true
+	}
+	return Theme{}, false
+}
+
+// All returns the built-in themes followed by the user-defined ones.
+func All() []Theme {
+	mu.Lock()
+	defer mu.Unlock()
+	return append(slices.Clone(Builtin), custom...)
+}
+
+// Lookup returns the named theme, or Dark and false when there is none.
+func Lookup(name string) (Theme, bool) {
+	if t, ok := find(All(), name); ok {
+		return t, true
+	}
+	return Dark, false
+}
+
+// Next returns the name of the theme after name in All, wrapping around.
+func Next(name string) string {
+	all := All()
+	i := slices.IndexFunc(all, func(t Theme) bool { return t.Name == name })
+	return all[(i+1)%len(all)].Name
+}
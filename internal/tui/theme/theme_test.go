@@ -0,0 +1,59 @@
+package theme
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Every built-in theme sets every role, so a custom theme never inherits an empty color.
+func TestBuiltinThemesAreComplete(t *testing.T) {
+	for _, th := range Builtin {
+		roles := map[string]string{}
+		raw, _ := json.Marshal(th)
+		_ = json.Unmarshal(raw, &roles)
+		for role, color := range roles {
+			if !ValidColor(color) {
+				t.Errorf("%s.%s = %q", th.Name, role, color)
+			}
+		}
+	}
+}
+
+func TestLoadCustomThemes(t *testing.T) {
+	t.Cleanup(func() { _ = Load(nil) })
+	err := Load(map[string]map[string]string{
+		"solarized": {"base": "light", "primary": "#268BD2"},
+		"mine":      {"base": "solarized", "text": "250"},
+		"broken":    {"primary": "purple", "sparkle": "#fff"},
+		"light":     {"primary": "#000"},
+	})
+	if err == nil {
+		t.Fatal("want errors")
+	}
+	for _, want := range []string{`theme "broken"`, `unknown role "sparkle"`, `"purple" is not a hex or ANSI color`, `theme "light": a built-in theme has this name`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q lacks %q", err, want)
+		}
+	}
+	sol, ok := Lookup("solarized")
+	if !ok || sol.Primary != "#268BD2" || sol.Text != Light.Text {
+		t.Fatalf("solarized = %+v", sol)
+	}
+	if mine, _ := Lookup("mine"); mine.Primary != "#268BD2" || mine.Text != "250" {
+		t.Fatalf("mine should build on solarized: %+v", mine)
+	}
+	if _, ok := Lookup("broken"); ok {
+		t.Fatal("broken theme should be skipped")
+	}
+	if th, ok := Lookup("nope"); ok || th.Name != Dark.Name {
+		t.Fatal("unknown themes fall back to dark")
+	}
+	name := Dark.Name
+	for range All() {
+		name = Next(name)
+	}
+	if name != Dark.Name || Next("high-contrast") != "mine" {
+		t.Fatalf("Next should cycle built-ins then custom themes, got %q", Next("high-contrast"))
+	}
+}
@@ -90,6 +90,21 @@ func LocalBookmarkName(b string) string {
 	return b
 }
 
+// ShortBookmarkLabel drops the namespace prefix from a graph bookmark label ("alice/fix*" or
+// "alice/fix@origin") when no other bookmark is named like the rest; taken holds the local names
+// of all bookmarks in view.
+func ShortBookmarkLabel(label, prefix string, taken map[string]bool) string {
+	if prefix == "" || !strings.HasPrefix(label, prefix) {
+		return label
+	}
+	short := label[len(prefix):]
+	name, _ := NormalizeBookmarkListToken(short)
+	if name == "" || taken[LocalBookmarkName(name)] {
+		return label
+	}
+	return short
+}
+
 // NormalizeBookmarkListToken normalizes a bookmark token as emitted by jj graph templates
 // or `jj bookmark list`: trims space, removes display-only suffixes " (conflicted)" / " (diverged)"
 // that some jj versions print before ':', then trailing * / ? (possibly interleaved).
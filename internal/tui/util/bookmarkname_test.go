@@ -67,3 +67,24 @@ func TestRevsetExactPattern(t *testing.T) {
 		t.Fatalf("RevsetExactPattern = %q; want %q", got, want)
 	}
 }
+
+func TestShortBookmarkLabel(t *testing.T) {
+	taken := map[string]bool{"alice/fix": true, "alice/docs": true, "docs": true, "main": true}
+	tests := []struct {
+		label, want string
+	}{
+		{"alice/fix*", "fix*"},
+		{"alice/fix@origin", "fix@origin"},
+		{"alice/docs", "alice/docs"}, // a "docs" bookmark exists too
+		{"main", "main"},
+		{"alice/", "alice/"},
+	}
+	for _, tt := range tests {
+		if got := ShortBookmarkLabel(tt.label, "alice/", taken); got != tt.want {
+			t.Errorf("ShortBookmarkLabel(%q) = %q; want %q", tt.label, got, tt.want)
+		}
+	}
+	if got := ShortBookmarkLabel("alice/fix", "", taken); got != "alice/fix" {
+		t.Errorf("no prefix: got %q", got)
+	}
+}
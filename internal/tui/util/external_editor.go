@@ -2,8 +2,10 @@ package util
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,8 +39,29 @@ func RepoAbsPath(repoRoot, relPath string) (string, error) {
 
 // OpenFileInExternalEditorCmd runs the configured editor against an absolute file path (non-blocking for GUI editors).
 func OpenFileInExternalEditorCmd(absPath string, cfg *config.Config) tea.Cmd {
+	return OpenFileAtLineCmd(absPath, 0, cfg)
+}
+
+// OpenFileAtLineCmd is OpenFileInExternalEditorCmd with the cursor on line (0 = editor default).
+// With no external editor configured it falls back to $VISUAL or $EDITOR, run in the terminal
+// (the TUI is suspended until it exits) as `$EDITOR +line path`.
+func OpenFileAtLineCmd(absPath string, line int, cfg *config.Config) tea.Cmd {
+	if config.NormalizeExternalFileEditor(cfg) == config.ExternalEditorNone {
+		if editor := terminalEditor(); editor != "" {
+			script := editor + " " + shellQuoteSingle(filepath.Clean(absPath))
+			if line > 0 {
+				script = fmt.Sprintf("%s +%d %s", editor, line, shellQuoteSingle(filepath.Clean(absPath)))
+			}
+			return tea.ExecProcess(exec.Command("sh", "-c", script), func(err error) tea.Msg {
+				if err != nil {
+					return ErrorMsg{Err: fmt.Errorf("%s: %w", editor, err), StatusOnly: true}
+				}
+				return ExternalEditorOpenedMsg{FileBase: filepath.Base(absPath)}
+			})
+		}
+	}
 	return func() tea.Msg {
-		if err := openFileInExternalEditor(absPath, cfg); err != nil {
+		if err := openFileInExternalEditor(absPath, line, cfg); err != nil {
 			return ErrorMsg{Err: err, StatusOnly: true}
 		}
 		time.Sleep(externalEditorLoadingMinVisible)
@@ -46,50 +69,92 @@ func OpenFileInExternalEditorCmd(absPath string, cfg *config.Config) tea.Cmd {
 	}
 }
 
-func openFileInExternalEditor(absPath string, cfg *config.Config) error {
+// HasFileEditor reports whether OpenFileAtLineCmd has an editor to run: a configured external
+// editor, or $VISUAL / $EDITOR.
+func HasFileEditor(cfg *config.Config) bool {
+	return config.NormalizeExternalFileEditor(cfg) != config.ExternalEditorNone || terminalEditor() != ""
+}
+
+// terminalEditor returns $VISUAL, else $EDITOR ("" when neither is set).
+func terminalEditor() string {
+	if v := strings.TrimSpace(os.Getenv("VISUAL")); v != "" {
+		return v
+	}
+	return strings.TrimSpace(os.Getenv("EDITOR"))
+}
+
+func openFileInExternalEditor(absPath string, line int, cfg *config.Config) error {
+	cmd, err := externalEditorCommand(absPath, line, cfg)
+	if err != nil {
+		return err
+	}
+	if err := startDetached(cmd); err != nil {
+		if config.NormalizeExternalFileEditor(cfg) == config.ExternalEditorNeovim {
+			// Requires Neovim remote (nvr) and a listening nvim instance.
+			return fmt.Errorf("nvr: %w (start nvim with --listen, or use Custom in settings)", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// externalEditorCommand builds the command that opens absPath in the configured editor, at line
+// when it is > 0. A custom command may use {line}; it is 1 when no line was asked for.
+func externalEditorCommand(absPath string, line int, cfg *config.Config) (*exec.Cmd, error) {
 	preset := config.NormalizeExternalFileEditor(cfg)
 	if preset == config.ExternalEditorNone {
-		return fmt.Errorf("no external editor configured (Settings → Advanced)")
+		return nil, fmt.Errorf("no external editor configured (Settings → Advanced)")
 	}
 
 	absPath = filepath.Clean(absPath)
 	if !filepath.IsAbs(absPath) {
-		return fmt.Errorf("internal error: path must be absolute")
+		return nil, fmt.Errorf("internal error: path must be absolute")
+	}
+	target := absPath // path:line for editors that take it
+	if line > 0 {
+		target = fmt.Sprintf("%s:%d", absPath, line)
 	}
 
 	switch preset {
 	case config.ExternalEditorCursor:
-		return startDetached(exec.Command("cursor", absPath))
+		if line > 0 {
+			return exec.Command("cursor", "-g", target), nil
+		}
+		return exec.Command("cursor", absPath), nil
 	case config.ExternalEditorVSCode:
-		return startDetached(exec.Command("code", "-g", absPath))
+		return exec.Command("code", "-g", target), nil
 	case config.ExternalEditorZed:
-		return startDetached(exec.Command("zed", absPath))
+		return exec.Command("zed", target), nil
 	case config.ExternalEditorNeovim:
-		// Requires Neovim remote (nvr) and a listening nvim instance.
-		if err := startDetached(exec.Command("nvr", "--remote", absPath)); err != nil {
-			return fmt.Errorf("nvr: %w (start nvim with --listen, or use Custom in settings)", err)
+		if line > 0 {
+			return exec.Command("nvr", "--remote", absPath, "-c", strconv.Itoa(line)), nil
 		}
-		return nil
+		return exec.Command("nvr", "--remote", absPath), nil
 	case config.ExternalEditorEmacs:
-		return startDetached(exec.Command("emacsclient", "-n", "-a", "emacs", absPath))
+		if line > 0 {
+			return exec.Command("emacsclient", "-n", "-a", "emacs", "+"+strconv.Itoa(line), absPath), nil
+		}
+		return exec.Command("emacsclient", "-n", "-a", "emacs", absPath), nil
 	case config.ExternalEditorSublime:
-		return startDetached(exec.Command("subl", absPath))
+		return exec.Command("subl", target), nil
 	case config.ExternalEditorIntelliJ:
-		return startDetached(exec.Command("idea", absPath))
+		if line > 0 {
+			return exec.Command("idea", "--line", strconv.Itoa(line), absPath), nil
+		}
+		return exec.Command("idea", absPath), nil
 	case config.ExternalEditorCustom:
 		tpl := ""
 		if cfg != nil {
 			tpl = strings.TrimSpace(cfg.ExternalFileEditorCustom)
 		}
 		if tpl == "" || !strings.Contains(tpl, "{path}") {
-			return fmt.Errorf(`custom editor needs a command with {path} (Settings → Advanced)`)
+			return nil, fmt.Errorf(`custom editor needs a command with {path} (Settings → Advanced)`)
 		}
-		q := shellQuoteSingle(absPath)
-		script := strings.ReplaceAll(tpl, "{path}", q)
-		cmd := exec.Command("sh", "-c", script)
-		return startDetached(cmd)
+		script := strings.ReplaceAll(tpl, "{path}", shellQuoteSingle(absPath))
+		script = strings.ReplaceAll(script, "{line}", strconv.Itoa(max(line, 1)))
+		return exec.Command("sh", "-c", script), nil
 	default:
-		return fmt.Errorf("unknown editor preset %q", preset)
+		return nil, fmt.Errorf("unknown editor preset %q", preset)
 	}
 }
 
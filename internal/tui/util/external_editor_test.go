@@ -3,7 +3,10 @@ package util
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+
+	"github.com/madicen/jj-tui/internal/config"
 )
 
 func TestRepoAbsPath(t *testing.T) {
@@ -24,3 +27,25 @@ func TestRepoAbsPath(t *testing.T) {
 		t.Fatal("expected error for path escape")
 	}
 }
+
+func TestExternalEditorCommand_Line(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "main.go")
+	for _, tc := range []struct {
+		cfg  *config.Config
+		line int
+		want []string
+	}{
+		{&config.Config{ExternalFileEditor: config.ExternalEditorVSCode}, 12, []string{"code", "-g", abs + ":12"}},
+		{&config.Config{ExternalFileEditor: config.ExternalEditorCursor}, 0, []string{"cursor", abs}},
+		{&config.Config{ExternalFileEditor: config.ExternalEditorIntelliJ}, 3, []string{"idea", "--line", "3", abs}},
+		{&config.Config{ExternalFileEditor: config.ExternalEditorCustom, ExternalFileEditorCustom: "ed {path} {line}"}, 0, []string{"sh", "-c", "ed '" + abs + "' 1"}},
+	} {
+		cmd, err := externalEditorCommand(abs, tc.line, tc.cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(cmd.Args, tc.want) {
+			t.Errorf("args = %q, want %q", cmd.Args, tc.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// selectionStyle marks selected lines. Reverse video reads on any theme and color profile.
+var selectionStyle = lipgloss.NewStyle().Reverse(true)
+
+// LineSelection is a keyboard (vim "visual line") selection inside a scrollable view. Mouse
+// selection is captured by the TUI while mouse reporting is on, so views that show text let the
+// user select lines with v, extend with the movement keys, and copy with y.
+type LineSelection struct {
+	active bool
+	anchor int
+	cursor int
+}
+
+// Start begins a selection of the single line at line.
+func (s *LineSelection) Start(line int) {
+	s.active = true
+	s.anchor, s.cursor = max(line, 0), max(line, 0)
+}
+
+// Cancel ends the selection.
+func (s *LineSelection) Cancel() { s.active = false }
+
+// Active reports whether a selection is in progress.
+func (s LineSelection) Active() bool { return s.active }
+
+// Cursor is the moving end of the selection (the anchor stays where v was pressed).
+func (s LineSelection) Cursor() int { return s.cursor }
+
+// Move moves the cursor by delta, clamped to [0, total).
+func (s *LineSelection) Move(delta, total int) {
+	s.cursor = max(min(s.cursor+delta, total-1), 0)
+}
+
+// Range returns the first and last selected line, inclusive.
+func (s LineSelection) Range() (from, to int) {
+	return min(s.anchor, s.cursor), max(s.anchor, s.cursor)
+}
+
+// Contains reports whether line i is selected.
+func (s LineSelection) Contains(i int) bool {
+	from, to := s.Range()
+	return s.active && i >= from && i <= to
+}
+
+// Text returns the selected lines of plain, joined with newlines.
+func (s LineSelection) Text(plain []string) string {
+	from, to := s.Range()
+	if !s.active || from >= len(plain) {
+		return ""
+	}
+	return strings.Join(plain[from:min(to+1, len(plain))], "\n")
+}
+
+// Status is the footer line while selecting.
+func (s LineSelection) Status() string {
+	from, to := s.Range()
+	n := to - from + 1
+	if n == 1 {
+		return "-- VISUAL -- 1 line · y copy · Esc cancel"
+	}
+	return fmt.Sprintf("-- VISUAL -- %d lines · y copy · Esc cancel", n)
+}
+
+// CopiedStatus is the status message after the selection is yanked.
+func (s LineSelection) CopiedStatus() string {
+	from, to := s.Range()
+	if to == from {
+		return "Copied 1 line"
+	}
+	return fmt.Sprintf("Copied %d lines", to-from+1)
+}
+
+// Highlight returns lines with the selected ones shown in the selection style. Their own styling
+// is dropped so the selection stays readable.
+func (s LineSelection) Highlight(lines []string) []string {
+	if !s.active {
+		return lines
+	}
+	out := make([]string, len(lines))
+	copy(out, lines)
+	from, to := s.Range()
+	for i := from; i <= to && i < len(out); i++ {
+		out[i] = selectionStyle.Render(ansi.Strip(out[i]))
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+// Package xref detects cross-references (PR numbers, ticket keys, jj change IDs) inside commit
+// descriptions and PR bodies and renders them as highlighted, clickable tokens.
+package xref
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/tui/styles"
+)
+
+// Kind identifies what a reference points at.
+type Kind int
+
+const (
+	KindPR     Kind = iota // #123 (GitHub PR or issue number)
+	KindTicket             // PROJ-123 (Jira) or $12u (Codecks)
+	KindChange             // jj change ID prefix of a commit in the loaded graph
+)
+
+// Ref is one reference found in a piece of text. Start/End are byte offsets into that text.
+type Ref struct {
+	Kind  Kind
+	Text  string // token as written, e.g. "#123"
+	Value string // normalized target: PR number digits, ticket key, or change ID prefix
+	Start int
+	End   int
+}
+
+// Number returns the PR/issue number for a KindPR ref, or 0.
+func (r Ref) Number() int {
+	if r.Kind != KindPR {
+		return 0
+	}
+	n, _ := strconv.Atoi(r.Value)
+	return n
+}
+
+var (
+	prPattern      = regexp.MustCompile(`#(\d+)\b`)
+	jiraPattern    = regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,9}-\d+\b`)
+	codecksPattern = regexp.MustCompile(`\$[1-9a-z]{3,}\b`)
+	// jj change IDs use the reverse-hex alphabet k-z; 8 characters is the shortest prefix we link
+	// so ordinary words are not mistaken for changes.
+	changePattern = regexp.MustCompile(`\b[k-z]{8,32}\b`)
+)
+
+// Find returns the references in text, ordered by position and never overlapping. isChange
+// reports whether a k-z token is a change ID (prefix) in the loaded graph; change-shaped tokens
+// that are not known locally are ignored since there is nothing to jump to. isChange may be nil.
+func Find(text string, isChange func(prefix string) bool) []Ref {
+	if text == "" {
+		return nil
+	}
+	var refs []Ref
+	for _, loc := range prPattern.FindAllStringSubmatchIndex(text, -1) {
+		// Skip "abc#12" (URL fragments, owner/repo#12 is still linked since '/' is not a word char).
+		if loc[0] > 0 && isWordByte(text[loc[0]-1]) {
+			continue
+		}
+		refs = append(refs, Ref{Kind: KindPR, Text: text[loc[0]:loc[1]], Value: text[loc[2]:loc[3]], Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range jiraPattern.FindAllStringIndex(text, -1) {
+		refs = append(refs, Ref{Kind: KindTicket, Text: text[loc[0]:loc[1]], Value: text[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range codecksPattern.FindAllStringIndex(text, -1) {
+		refs = append(refs, Ref{Kind: KindTicket, Text: text[loc[0]:loc[1]], Value: text[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+	}
+	if isChange != nil {
+		for _, loc := range changePattern.FindAllStringIndex(text, -1) {
+			tok := text[loc[0]:loc[1]]
+			if isChange(tok) {
+				refs = append(refs, Ref{Kind: KindChange, Text: tok, Value: tok, Start: loc[0], End: loc[1]})
+			}
+		}
+	}
+	sort.SliceStable(refs, func(i, j int) bool { return refs[i].Start < refs[j].Start })
+	out := refs[:0]
+	end := -1
+	for _, r := range refs {
+		if r.Start < end {
+			continue
+		}
+		out = append(out, r)
+		end = r.End
+	}
+	return out
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// Style is the highlight applied to reference tokens.
+var Style lipgloss.Style
+
+func init() {
+	styles.OnThemeChange(func() { Style = lipgloss.NewStyle().Foreground(styles.ColorAccent).Underline(true) })
+}
+
+// Render returns text with each ref highlighted. wrap, when non-nil, is applied to the rendered
+// token (index into refs) so callers can mark it as a click zone. Text between refs is rendered
+// with base so the surrounding style is kept on either side of a token.
+func Render(text string, refs []Ref, base lipgloss.Style, wrap func(i int, token string) string) string {
+	if len(refs) == 0 {
+		return base.Render(text)
+	}
+	var b strings.Builder
+	pos := 0
+	for i, r := range refs {
+		if r.Start < pos || r.End > len(text) {
+			continue
+		}
+		if r.Start > pos {
+			b.WriteString(base.Render(text[pos:r.Start]))
+		}
+		tok := Style.Inherit(base).Render(r.Text)
+		if wrap != nil {
+			tok = wrap(i, tok)
+		}
+		b.WriteString(tok)
+		pos = r.End
+	}
+	if pos < len(text) {
+		b.WriteString(base.Render(text[pos:]))
+	}
+	return b.String()
+}
+
+// ChangeMatcher returns an isChange func for Find that accepts a reference when it and one of
+// changeIDs are prefixes of each other (see MatchesChange): the graph loads short IDs, while a
+// description may quote a longer or full-length one. It sorts a copy of changeIDs once, so build
+// it when the repository loads rather than per call.
+func ChangeMatcher(changeIDs []string) func(string) bool {
+	sorted := slices.Clone(changeIDs)
+	slices.Sort(sorted)
+	return func(ref string) bool {
+		// The first ID not below ref is the only candidate that can start with it.
+		i, found := slices.BinarySearch(sorted, ref)
+		if found || i < len(sorted) && strings.HasPrefix(sorted[i], ref) {
+			return true
+		}
+		// An ID that is a prefix of ref is one of ref's own prefixes; look each up.
+		for n := len(ref) - 1; n > 0; n-- {
+			if _, ok := slices.BinarySearch(sorted, ref[:n]); ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchesChange reports whether ref names the change changeID: one is a prefix of the other.
+func MatchesChange(ref, changeID string) bool {
+	return changeID != "" && ref != "" && (strings.HasPrefix(changeID, ref) || strings.HasPrefix(ref, changeID))
+}
@@ -0,0 +1,77 @@
+package xref
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFind(t *testing.T) {
+	isChange := ChangeMatcher([]string{"qpvuntsmwlqt"})
+	text := "PROJ-12: fix #45 (see qpvuntsm, $12u and abc#9) unrelated zzzzzzzz"
+	got := Find(text, isChange)
+	want := []struct {
+		kind  Kind
+		value string
+	}{
+		{KindTicket, "PROJ-12"},
+		{KindPR, "45"},
+		{KindChange, "qpvuntsm"},
+		{KindTicket, "$12u"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Find = %+v, want %d refs", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].Kind != w.kind || got[i].Value != w.value {
+			t.Errorf("ref %d = %+v, want kind %d value %q", i, got[i], w.kind, w.value)
+		}
+		if text[got[i].Start:got[i].End] != got[i].Text {
+			t.Errorf("ref %d offsets [%d:%d] do not match text %q", i, got[i].Start, got[i].End, got[i].Text)
+		}
+	}
+	if got[1].Number() != 45 {
+		t.Errorf("Number() = %d, want 45", got[1].Number())
+	}
+}
+
+func TestChangeMatcher(t *testing.T) {
+	isChange := ChangeMatcher([]string{"zzzzzzzz", "kmkuslsw", "qpvuntsm", "kmkxyzab"})
+	for prefix, want := range map[string]bool{
+		"kmku": true, "kmkx": true, "kmk": true, "qpvuntsm": true, "zz": true,
+		"qpvuntsmxyzw": true, "zzzzzzzzz": true, "kmkuslswz": true,
+		"kmkv": false, "a": false, "qpvuntsl": false, "kmkuslsx": false,
+	} {
+		if got := isChange(prefix); got != want {
+			t.Errorf("isChange(%q) = %v, want %v", prefix, got, want)
+		}
+	}
+}
+
+func TestFind_FullLengthChangeID(t *testing.T) {
+	isChange := ChangeMatcher([]string{"kmkuslsw", "qpvuntsm"})
+	full := "qpvuntsmwlqtpsluzzsnyyzlmlwvmlnu"
+	refs := Find("follow-up to "+full+" and zzzzzzzzzzzz", isChange)
+	if len(refs) != 1 || refs[0].Kind != KindChange || refs[0].Value != full {
+		t.Fatalf("refs = %+v, want the full change ID linked", refs)
+	}
+	if !MatchesChange(refs[0].Value, "qpvuntsm") || MatchesChange(refs[0].Value, "kmkuslsw") {
+		t.Errorf("MatchesChange(%q) picked the wrong short ID", refs[0].Value)
+	}
+}
+
+func TestRender_WrapsEachToken(t *testing.T) {
+	text := "fix #1 and #2"
+	refs := Find(text, nil)
+	var wrapped []int
+	out := Render(text, refs, lipgloss.NewStyle(), func(i int, tok string) string {
+		wrapped = append(wrapped, i)
+		return "[" + tok + "]"
+	})
+	if len(wrapped) != 2 || wrapped[0] != 0 || wrapped[1] != 1 {
+		t.Errorf("wrap called for %v, want [0 1]", wrapped)
+	}
+	if out == text {
+		t.Errorf("Render did not mark tokens: %q", out)
+	}
+}
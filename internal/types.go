@@ -23,21 +23,44 @@ type Commit struct {
 	Conflicts          bool      `json:"conflicts"`
 	Immutable          bool      `json:"immutable"`
 	Divergent          bool      `json:"divergent"` // True if this change ID has multiple versions
+	Mine               bool      `json:"mine"`      // True if authored by the current jj user (jj's mine())
 	// HasDeltaVsBookmarkOrigin is true when this bookmark tip should offer "Forgot New Commit?":
 	// non-empty tree diff vs bookmark@origin and bookmark@origin is not an ancestor of this revision
 	// (once stacked on the remote tip, push suffices — diff may remain non-empty until then).
 	HasDeltaVsBookmarkOrigin bool `json:"has_delta_vs_bookmark_origin"`
 	// EvologSplitViable is true when experimental evolog split (z) can run: evolog has an older
 	// revision with a non-empty tree diff vs this change, no blocking descendants, etc.
-	EvologSplitViable bool     `json:"evolog_split_viable"`
-	GraphPrefix       string   `json:"graph_prefix"` // ASCII art graph prefix from jj (e.g., "│ ○  ")
-	GraphLines        []string `json:"graph_lines"`  // Connector lines after this commit (e.g., ["│", "├─╯"])
+	EvologSplitViable bool `json:"evolog_split_viable"`
+	// RemoteState says whether this exact commit is on a remote, only local, or a rewrite of a
+	// pushed change; empty when unknown (no remotes, or the graph was not enriched).
+	RemoteState RemoteState `json:"remote_state"`
+	// TrunkAhead counts this commit and its ancestors that are not on trunk(); TrunkBehind counts
+	// the trunk() commits it does not contain (trunk moved on past its base). Both are zero for
+	// immutable commits and when unknown.
+	TrunkAhead  int      `json:"trunk_ahead"`
+	TrunkBehind int      `json:"trunk_behind"`
+	GraphPrefix string   `json:"graph_prefix"` // ASCII art graph prefix from jj (e.g., "│ ○  ")
+	GraphLines  []string `json:"graph_lines"`  // Connector lines after this commit (e.g., ["│", "├─╯"])
 }
 
+// RemoteState is how a commit relates to the remote bookmarks, i.e. whether it is safe to rewrite.
+type RemoteState string
+
+const (
+	// RemoteStateLocal: no remote bookmark reaches the change; rewriting affects nobody else.
+	RemoteStateLocal RemoteState = "local"
+	// RemoteStatePushed: the commit is an ancestor of a remote bookmark.
+	RemoteStatePushed RemoteState = "pushed"
+	// RemoteStateDiverged: the change was pushed but has since been rewritten locally.
+	RemoteStateDiverged RemoteState = "diverged"
+)
+
 // CommitGraph represents the visual structure of commits
 type CommitGraph struct {
 	Commits     []Commit            `json:"commits"`
 	Connections map[string][]string `json:"connections"` // commit_id -> connected_commit_ids
+	// HasMore is set when the load stopped at the page limit with more commits left to show.
+	HasMore bool `json:"has_more,omitempty"`
 }
 
 // CheckStatus represents the CI check status of a PR
@@ -69,10 +92,79 @@ type GitHubPR struct {
 	State        string       `json:"state"`
 	BaseBranch   string       `json:"base_branch"`
 	HeadBranch   string       `json:"head_branch"`
+	HeadSHA      string       `json:"head_sha,omitempty"` // commit the head branch pointed at when fetched
 	CommitIDs    []string     `json:"commit_ids"`
 	CheckStatus  CheckStatus  `json:"check_status"`  // CI check status
 	ReviewStatus ReviewStatus `json:"review_status"` // Review status
 	IsDraft      bool         `json:"is_draft"`      // True if the PR is a draft
+	Author       string       `json:"author,omitempty"`
+	AuthorAvatar string       `json:"author_avatar,omitempty"` // avatar image URL
+}
+
+// Deployment is the latest status of one GitHub deployment environment for a ref.
+type Deployment struct {
+	Environment string    `json:"environment"`
+	State       string    `json:"state"` // success, failure, error, inactive, in_progress, queued, pending
+	URL         string    `json:"url"`   // environment URL (falls back to the status log URL)
+	Ref         string    `json:"ref"`
+	SHA         string    `json:"sha"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ReviewComment is a PR review comment anchored to a line of a file (a review thread's first
+// comment; replies are not listed).
+type ReviewComment struct {
+	ID       int64  `json:"id"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`     // line in the PR's latest diff; the original line when Outdated
+	Outdated bool   `json:"outdated"` // the commented code changed since the comment was made
+	Author   string `json:"author"`
+	Body     string `json:"body"`
+	URL      string `json:"url"`
+}
+
+// PRDetail is what the PR detail view shows beyond the list entry: every check of the head
+// commit, the review threads, the conversation, and the changed files.
+type PRDetail struct {
+	Number   int            `json:"number"`
+	Checks   []CheckRun     `json:"checks"`
+	Threads  []ReviewThread `json:"threads"`
+	Comments []PRComment    `json:"comments"`
+	Files    []PRFile       `json:"files"`
+}
+
+// CheckRun is one CI check (a check run or a commit status) on a PR's head commit.
+type CheckRun struct {
+	Name   string      `json:"name"`
+	Status CheckStatus `json:"status"`
+	State  string      `json:"state"` // GitHub's conclusion or status, lower case (e.g. "success", "in_progress")
+	URL    string      `json:"url"`
+}
+
+// ReviewThread is a review thread on a PR: its first comment and how many replies followed.
+type ReviewThread struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Resolved bool   `json:"resolved"`
+	Outdated bool   `json:"outdated"`
+	Author   string `json:"author"`
+	Body     string `json:"body"`
+	Replies  int    `json:"replies"`
+}
+
+// PRComment is a comment on a PR's conversation (not attached to a line).
+type PRComment struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PRFile is a file changed by a PR.
+type PRFile struct {
+	Path       string `json:"path"`
+	Additions  int    `json:"additions"`
+	Deletions  int    `json:"deletions"`
+	ChangeType string `json:"change_type"` // added, modified, deleted, renamed, copied, changed
 }
 
 // Repository represents the current jj repository state
@@ -91,6 +183,8 @@ type CreatePRRequest struct {
 	BaseBranch string   `json:"base_branch"`
 	CommitIDs  []string `json:"commit_ids"`
 	Draft      bool     `json:"draft"`
+	// ToUpstream opens the PR against the fork's upstream repository (head "owner:branch").
+	ToUpstream bool `json:"to_upstream,omitempty"`
 }
 
 // UpdatePRRequest represents a request to update a pull request
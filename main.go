@@ -7,22 +7,36 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
 	"runtime/pprof"
+	"strconv"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/madicen/jj-tui/internal/bench"
 	"github.com/madicen/jj-tui/internal/config"
+	"github.com/madicen/jj-tui/internal/crash"
+	"github.com/madicen/jj-tui/internal/events"
+	"github.com/madicen/jj-tui/internal/i18n"
 	"github.com/madicen/jj-tui/internal/integrations/jj"
+	"github.com/madicen/jj-tui/internal/ipc"
+	"github.com/madicen/jj-tui/internal/keymap"
+	"github.com/madicen/jj-tui/internal/playback"
 	"github.com/madicen/jj-tui/internal/tui"
+	"github.com/madicen/jj-tui/internal/tui/avatar"
 	"github.com/madicen/jj-tui/internal/tui/styles"
+	"github.com/madicen/jj-tui/internal/tui/theme"
 	"github.com/madicen/jj-tui/internal/tui/util"
 	"github.com/madicen/jj-tui/internal/version"
 	"github.com/muesli/termenv"
 )
 
 func main() {
-	// Non-TUI helper: jj invokes this as ui.diff-editor for AI hunk-level evolog split.
+	// Non-TUI helper: jj invokes this as ui.diff-editor for hunk-level splits (AI evolog split, H).
 	if len(os.Args) >= 5 && os.Args[1] == "diff-editor-evolog-hunk-split" {
 		if err := jj.RunEvologHunkSplitDiffEditor(os.Args[2], os.Args[3], os.Args[4]); err != nil {
 			fmt.Fprintf(os.Stderr, "jj-tui diff-editor-evolog-hunk-split: %v\n", err)
@@ -31,24 +45,73 @@ func main() {
 		return
 	}
 
+	// Non-TUI helper: send one command to a running instance's control socket.
+	if len(os.Args) >= 2 && os.Args[1] == "ctl" {
+		os.Exit(runCtl(os.Args[2:]))
+	}
+
+	// Hidden developer tool: time the graph pipeline (see internal/bench).
+	if len(os.Args) >= 2 && os.Args[1] == "bench" {
+		os.Exit(runBench(os.Args[2:]))
+	}
+
+	os.Exit(runTUI())
+}
+
+// runTUI parses the flags and runs the TUI, returning the process exit code. It returns instead of
+// calling os.Exit so its deferred cleanup (the event stream's session_end, profiles, the control
+// socket) runs on every path.
+func runTUI() int {
 	// Parse command-line flags
 	demoMode := flag.Bool("demo", false, "Run in demo mode with mock services (for screenshots/testing)")
 	cpuProfile := flag.String("cpuprofile", "", "Write CPU profile to file (on exit)")
 	memProfile := flag.String("memprofile", "", "Write memory profile to file (on exit)")
 	pprofAddr := flag.String("pprof", "", "Serve pprof HTTP at address (e.g. :6060); use with -demo to profile live")
+	eventsFD := flag.Int("events-fd", -1, "Write JSON Lines session events (jj ops, pushes, PRs created) to this open file descriptor")
+	eventsFile := flag.String("events-file", "", "Append JSON Lines session events (jj ops, pushes, PRs created) to this file or FIFO")
+	popup := flag.String("popup", "", "Popup/picker mode for tmux display-popup or zellij floating panes: start on graph, prs, tickets, or branches with a compact layout; Enter prints the selection to stdout and exits")
+	controlSocket := flag.String("control-socket", "", "Accept external commands (select <rev>, view <tab>, refresh) on this Unix socket; \"auto\" uses a per-repo path that `jj-tui ctl` finds (the startup repo's, even after switching repositories)")
+	safeMode := flag.Bool("safe-mode", false, "Start without network services (GitHub, tickets, update check) and without auto-refresh")
+	playbackFile := flag.String("playback", "", "Replay the timed key presses in this script file (see --record), e.g. for VHS recordings or bug reports")
+	recordFile := flag.String("record", "", "Record this session's key presses to a script file that --playback can replay")
+	repoPath := flag.String("repo", "", "Open the jj repository at this path instead of the current directory (Ctrl+O switches repositories in the app)")
 	flag.Parse()
 
+	// Open the event stream first so a consumer sees session_end on every exit below, including a
+	// bad --repo.
+	repo := *repoPath
+	if repo == "" {
+		repo, _ = os.Getwd()
+	} else if abs, err := filepath.Abs(repo); err == nil {
+		repo = abs
+	}
+	if closeEvents, err := openEventStream(*eventsFD, *eventsFile, repo); err != nil {
+		fmt.Fprintf(os.Stderr, "events: %v\n", err)
+		return 1
+	} else if closeEvents != nil {
+		defer closeEvents()
+	}
+
+	// Everything below (repo config, jj commands, the control socket path) works from the
+	// current directory, so move there first.
+	if *repoPath != "" {
+		if err := os.Chdir(*repoPath); err != nil {
+			fmt.Fprintf(os.Stderr, "repo: %v\n", err)
+			return 2
+		}
+	}
+
 	// Start CPU profiling if requested
 	if *cpuProfile != "" {
 		f, err := os.Create(*cpuProfile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "cpuprofile: %v\n", err)
-			os.Exit(1)
+			return 1
 		}
 		defer f.Close()
 		if err := pprof.StartCPUProfile(f); err != nil {
 			fmt.Fprintf(os.Stderr, "cpuprofile: %v\n", err)
-			os.Exit(1)
+			return 1
 		}
 		defer pprof.StopCPUProfile()
 	}
@@ -93,14 +156,47 @@ func main() {
 	// Apply saved config to environment (only sets env vars that are not already set)
 	cfg.ApplyToEnvironment()
 
-	// Apply theme colors from config so the TUI uses saved preferences
+	// Apply the color theme (built-in or from config "themes"), then any color overrides
+	if err := theme.Load(cfg.Themes); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	t, ok := theme.Lookup(cfg.GetTheme())
+	if !ok {
+		fmt.Printf("Warning: unknown theme %q, using %s\n", cfg.GetTheme(), t.Name)
+	}
+	styles.ApplyTheme(t)
 	styles.SetTheme(cfg.GetThemePrimary(), cfg.GetThemeSecondary(), cfg.GetThemeMuted())
+	styles.SetPalette(cfg.GetThemePalette())
+
+	// Inline avatars / ticket type icons (off unless inline_images is set)
+	avatar.Configure(cfg.GetInlineImages())
+
+	// UI language for the message catalog (English unless locale is set)
+	if err := i18n.SetLocale(cfg.Locale); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	// Rebound keys (keymap.json, then config keybindings); bad entries keep their built-in key
+	if err := keymap.Load(cfg.Keybindings); err != nil {
+		fmt.Printf("Warning: keybindings: %v\n", strings.ReplaceAll(err.Error(), "\n", "; "))
+	}
+
+	// Read the playback script before the TUI takes over the terminal so errors are readable.
+	var script []playback.Event
+	if *playbackFile != "" {
+		if script, err = playback.Load(*playbackFile); err != nil {
+			fmt.Fprintf(os.Stderr, "playback: %v\n", err)
+			return 2
+		}
+	}
 
 	// Initialize the TUI application
 	ctx := context.Background()
 
 	// Check for updates in background (non-blocking)
-	version.CheckForUpdates(ctx)
+	if !*safeMode && !cfg.LowBandwidthMode() {
+		version.CheckForUpdates(ctx)
+	}
 
 	// Create the model (demo mode uses mock services)
 	var model *tui.Model
@@ -110,15 +206,53 @@ func main() {
 		model = tui.New(ctx)
 	}
 	defer model.Close()
+	if *safeMode {
+		model.SetSafeMode()
+	}
+	if *popup != "" {
+		if err := model.SetPopup(*popup); err != nil {
+			fmt.Fprintf(os.Stderr, "popup: %v\n", err)
+			return 2
+		}
+	}
 
 	// WithMouseCellMotion: clicks, wheel, and drag (not bare pointer motion). All-motion (?1003)
 	// reports every move; on quit, moving the pointer off the window queues SGR sequences that
 	// can reach the shell after restore. Cell motion matches how most users interact with the TUI.
-	p := tea.NewProgram(
-		model,
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if *popup != "" {
+		// Draw on stderr so `rev=$(jj-tui --popup graph)` captures only the picked item.
+		opts = append(opts, tea.WithOutput(os.Stderr))
+	}
+	if *recordFile != "" {
+		f, err := os.Create(*recordFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "record: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		opts = append(opts, tea.WithFilter(playback.NewRecorder(f).Filter))
+	}
+	p := tea.NewProgram(model, opts...)
+
+	if len(script) > 0 {
+		playCtx, stopPlayback := context.WithCancel(ctx)
+		defer stopPlayback()
+		go playback.Play(playCtx, script, p.Send)
+	}
+
+	if *controlSocket != "" {
+		path := *controlSocket
+		if path == "auto" {
+			path = ipc.DefaultSocketPath(repoRoot())
+		}
+		srv, err := ipc.Listen(path, func(c ipc.Command) { p.Send(c) })
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "control socket: %v\n", err)
+			return 1
+		}
+		defer srv.Close()
+	}
 
 	// Full mouse teardown after Run (Tea also restores; this covers stderr /dev/tty etc.).
 	defer util.FlushMouse()
@@ -128,8 +262,129 @@ func main() {
 
 	time.Sleep(25 * time.Millisecond)
 	util.FlushMouse()
+	if path := crash.ReportPath(); path != "" {
+		fmt.Fprintf(os.Stderr, "jj-tui crashed. A report (stack, recent messages, jj commands) was written to:\n  %s\nPlease attach it when filing an issue at https://github.com/%s/issues\n", path, version.GitHubRepo)
+	}
 	if err != nil {
 		fmt.Printf("Error running TUI: %v\n", err)
-		os.Exit(1)
+		return 1
+	}
+	if pick := model.PopupResult(); pick != "" {
+		fmt.Println(pick)
+	}
+	return 0
+}
+
+// openEventStream wires the --events-fd / --events-file flags to the events package and returns a
+// func that writes session_end and closes the stream, or nil when neither flag is set. repo is
+// the directory the session events name.
+func openEventStream(fd int, path, repo string) (func(), error) {
+	var f *os.File
+	switch {
+	case fd >= 0 && path != "":
+		return nil, fmt.Errorf("use only one of --events-fd and --events-file")
+	case fd >= 0:
+		f = os.NewFile(uintptr(fd), fmt.Sprintf("events-fd-%d", fd))
+		if f == nil {
+			return nil, fmt.Errorf("invalid file descriptor %d", fd)
+		}
+	case path != "":
+		var err error
+		f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+	events.SetOutput(f)
+	events.Emit(events.Event{Type: events.TypeSessionStart, Repo: repo})
+	return func() {
+		events.Emit(events.Event{Type: events.TypeSessionEnd, Repo: repo})
+		events.SetOutput(nil)
+		_ = f.Close()
+	}, nil
+}
+
+// repoRoot returns the jj workspace root for the current directory, or the directory itself when
+// jj can't tell (so the control socket path still resolves outside a repo).
+func repoRoot() string {
+	cwd, _ := os.Getwd()
+	out, err := exec.Command("jj", "root").Output()
+	if err != nil {
+		return cwd
+	}
+	if root := strings.TrimSpace(string(out)); root != "" {
+		return root
+	}
+	return cwd
+}
+
+// runCtl implements `jj-tui ctl [-socket path] <command...>` and returns the process exit code.
+func runCtl(args []string) int {
+	fs := flag.NewFlagSet("ctl", flag.ContinueOnError)
+	socket := fs.String("socket", "", "Control socket path (default: the per-repo path used by --control-socket auto)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: jj-tui ctl [-socket path] select <rev> | view <tab> | refresh")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	line := strings.Join(fs.Args(), " ")
+	if _, err := ipc.Parse(line); err != nil {
+		fmt.Fprintf(os.Stderr, "jj-tui ctl: %v\n", err)
+		return 2
+	}
+	path := *socket
+	if path == "" {
+		path = ipc.DefaultSocketPath(repoRoot())
+	}
+	if err := ipc.Send(path, line); err != nil {
+		fmt.Fprintf(os.Stderr, "jj-tui ctl: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runBench implements the hidden `jj-tui bench graph` subcommand.
+func runBench(args []string) int {
+	if len(args) == 0 || args[0] != "graph" {
+		fmt.Fprintln(os.Stderr, "usage: jj-tui bench graph [flags]")
+		return 2
+	}
+	fs := flag.NewFlagSet("bench graph", flag.ContinueOnError)
+	sizes := fs.String("sizes", "100,1000,5000", "Comma-separated repository sizes (commits) to generate")
+	runs := fs.Int("runs", 3, "Timed runs per stage; the report shows the median")
+	width := fs.Int("width", 120, "Terminal width to render the graph at")
+	height := fs.Int("height", 40, "Terminal height to render the graph at")
+	keep := fs.Bool("keep", false, "Keep the generated repositories and print their paths")
+	synthetic := fs.Bool("synthetic", false, "Skip jj: time parse and render on a generated log only")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: jj-tui bench graph [-sizes 100,1000,5000] [-runs 3] [-width 120] [-height 40] [-keep] [-synthetic]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	opts := bench.GraphOptions{Runs: *runs, Width: *width, Height: *height, Keep: *keep, Synthetic: *synthetic}
+	for _, f := range strings.Split(*sizes, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || n <= 0 {
+			fmt.Fprintf(os.Stderr, "jj-tui bench: invalid size %q\n", f)
+			return 2
+		}
+		opts.Sizes = append(opts.Sizes, n)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := bench.RunGraph(ctx, opts, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "jj-tui bench: %v\n", err)
+		return 1
 	}
+	return 0
 }